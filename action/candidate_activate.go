@@ -66,7 +66,7 @@ func (cr *CandidateActivate) BucketID() uint64 { return cr.bucketID }
 
 // IntrinsicGas returns the intrinsic gas of a CandidateRegister
 func (cr *CandidateActivate) IntrinsicGas() (uint64, error) {
-	return CandidateActivateBaseIntrinsicGas, nil
+	return GasTableV1.payloadIntrinsicGas(cr)
 }
 
 func (cr *CandidateActivate) SanityCheck() error {
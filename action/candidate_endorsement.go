@@ -160,7 +160,7 @@ func (act *CandidateEndorsement) BucketIndex() uint64 {
 
 // IntrinsicGas returns the intrinsic gas of a CandidateEndorsement
 func (act *CandidateEndorsement) IntrinsicGas() (uint64, error) {
-	return CandidateEndorsementBaseIntrinsicGas, nil
+	return GasTableV1.payloadIntrinsicGas(act)
 }
 
 func (act *CandidateEndorsement) SanityCheck() error {
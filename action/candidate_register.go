@@ -270,8 +270,7 @@ func (cr *CandidateRegister) LoadProto(pbAct *iotextypes.CandidateRegister) erro
 
 // IntrinsicGas returns the intrinsic gas of a CandidateRegister
 func (cr *CandidateRegister) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(cr.Payload()))
-	return CalculateIntrinsicGas(CandidateRegisterBaseIntrinsicGas, CandidateRegisterPayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(cr)
 }
 
 // SanityCheck validates the variables in the action
@@ -110,8 +110,7 @@ func (act *CandidateTransferOwnership) NewOwner() address.Address { return act.n
 
 // IntrinsicGas returns the intrinsic gas of a CandidateTransferOwnership
 func (act *CandidateTransferOwnership) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(act.Payload()))
-	return CalculateIntrinsicGas(CandidateTransferOwnershipBaseIntrinsicGas, CandidateTransferOwnershipPayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(act)
 }
 
 // Serialize returns a raw byte stream of the CandidateTransferOwnership struct
@@ -162,7 +162,7 @@ func (cu *CandidateUpdate) LoadProto(pbAct *iotextypes.CandidateBasicInfo) error
 
 // IntrinsicGas returns the intrinsic gas of a CandidateUpdate
 func (cu *CandidateUpdate) IntrinsicGas() (uint64, error) {
-	return CandidateUpdateBaseIntrinsicGas, nil
+	return GasTableV1.payloadIntrinsicGas(cu)
 }
 
 // SanityCheck validates the variables in the action
@@ -163,8 +163,7 @@ func (c *ClaimFromRewardingFund) LoadProto(claim *iotextypes.ClaimFromRewardingF
 
 // IntrinsicGas returns the intrinsic gas of a claim action
 func (c *ClaimFromRewardingFund) IntrinsicGas() (uint64, error) {
-	dataLen := uint64(len(c.Data()))
-	return CalculateIntrinsicGas(ClaimFromRewardingFundBaseGas, ClaimFromRewardingFundGasPerByte, dataLen)
+	return GasTableV1.payloadIntrinsicGas(c)
 }
 
 // SanityCheck validates the variables in the action
@@ -113,8 +113,7 @@ func (d *DepositToRewardingFund) LoadProto(deposit *iotextypes.DepositToRewardin
 
 // IntrinsicGas returns the intrinsic gas of a deposit action
 func (d *DepositToRewardingFund) IntrinsicGas() (uint64, error) {
-	dataLen := uint64(len(d.Data()))
-	return CalculateIntrinsicGas(DepositToRewardingFundBaseGas, DepositToRewardingFundGasPerByte, dataLen)
+	return GasTableV1.payloadIntrinsicGas(d)
 }
 
 // SanityCheck validates the variables in the action
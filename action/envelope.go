@@ -206,15 +206,7 @@ func (elp *envelope) Cost() (*big.Int, error) {
 
 // IntrinsicGas returns intrinsic gas of action.
 func (elp *envelope) IntrinsicGas() (uint64, error) {
-	gas, err := elp.payload.IntrinsicGas()
-	if err != nil {
-		return 0, err
-	}
-	if acl := elp.AccessList(); len(acl) > 0 {
-		gas += uint64(len(acl)) * TxAccessListAddressGas
-		gas += uint64(acl.StorageKeys()) * TxAccessListStorageKeyGas
-	}
-	return gas, nil
+	return GasTableV1.IntrinsicGas(elp)
 }
 
 // Size returns the size of envelope
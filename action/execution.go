@@ -139,11 +139,7 @@ func (ex *Execution) LoadProto(pbAct *iotextypes.Execution) error {
 
 // IntrinsicGas returns the intrinsic gas of an execution
 func (ex *Execution) IntrinsicGas() (uint64, error) {
-	gas, err := CalculateIntrinsicGas(ExecutionBaseIntrinsicGas, ExecutionDataGas, uint64(len(ex.Data())))
-	if err != nil {
-		return gas, err
-	}
-	return gas, nil
+	return GasTableV1.payloadIntrinsicGas(ex)
 }
 
 // GasLimitForCost is an empty func to indicate that gas limit should be used
@@ -0,0 +1,193 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+type (
+	// IntrinsicGasParams is the intrinsic gas of an action type, which is the base gas plus the gas of each byte of
+	// the payload
+	IntrinsicGasParams struct {
+		Base    uint64 `json:"base"`
+		PerByte uint64 `json:"perByte"`
+	}
+
+	// GasTable is a version of the intrinsic gas of all action types. A new version is activated by a hard fork,
+	// see protocol.FeatureCtx for the version at a height
+	GasTable struct {
+		Version                    uint32             `json:"version"`
+		Transfer                   IntrinsicGasParams `json:"transfer"`
+		Execution                  IntrinsicGasParams `json:"execution"`
+		CreateStake                IntrinsicGasParams `json:"createStake"`
+		DepositToStake             IntrinsicGasParams `json:"depositToStake"`
+		MoveStake                  IntrinsicGasParams `json:"moveStake"`
+		ReclaimStake               IntrinsicGasParams `json:"reclaimStake"`
+		Restake                    IntrinsicGasParams `json:"restake"`
+		MigrateStake               IntrinsicGasParams `json:"migrateStake"`
+		CandidateRegister          IntrinsicGasParams `json:"candidateRegister"`
+		CandidateUpdate            IntrinsicGasParams `json:"candidateUpdate"`
+		CandidateActivate          IntrinsicGasParams `json:"candidateActivate"`
+		CandidateEndorsement       IntrinsicGasParams `json:"candidateEndorsement"`
+		CandidateTransferOwnership IntrinsicGasParams `json:"candidateTransferOwnership"`
+		DepositToRewardingFund     IntrinsicGasParams `json:"depositToRewardingFund"`
+		ClaimFromRewardingFund     IntrinsicGasParams `json:"claimFromRewardingFund"`
+		AccessListAddress          uint64             `json:"accessListAddress"`
+		AccessListStorageKey       uint64             `json:"accessListStorageKey"`
+	}
+)
+
+// GasTableV1 is the gas table since genesis, the IntrinsicGas of actions and envelopes is calculated by it
+var GasTableV1 = &GasTable{
+	Version:                    1,
+	Transfer:                   IntrinsicGasParams{TransferBaseIntrinsicGas, TransferPayloadGas},
+	Execution:                  IntrinsicGasParams{ExecutionBaseIntrinsicGas, ExecutionDataGas},
+	CreateStake:                IntrinsicGasParams{CreateStakeBaseIntrinsicGas, CreateStakePayloadGas},
+	DepositToStake:             IntrinsicGasParams{DepositToStakeBaseIntrinsicGas, DepositToStakePayloadGas},
+	MoveStake:                  IntrinsicGasParams{MoveStakeBaseIntrinsicGas, MoveStakePayloadGas},
+	ReclaimStake:               IntrinsicGasParams{ReclaimStakeBaseIntrinsicGas, ReclaimStakePayloadGas},
+	Restake:                    IntrinsicGasParams{RestakeBaseIntrinsicGas, RestakePayloadGas},
+	MigrateStake:               IntrinsicGasParams{MigrateStakeBaseIntrinsicGas, MigrateStakePayloadGas},
+	CandidateRegister:          IntrinsicGasParams{CandidateRegisterBaseIntrinsicGas, CandidateRegisterPayloadGas},
+	CandidateUpdate:            IntrinsicGasParams{Base: CandidateUpdateBaseIntrinsicGas},
+	CandidateActivate:          IntrinsicGasParams{Base: CandidateActivateBaseIntrinsicGas},
+	CandidateEndorsement:       IntrinsicGasParams{Base: CandidateEndorsementBaseIntrinsicGas},
+	CandidateTransferOwnership: IntrinsicGasParams{CandidateTransferOwnershipBaseIntrinsicGas, CandidateTransferOwnershipPayloadGas},
+	DepositToRewardingFund:     IntrinsicGasParams{DepositToRewardingFundBaseGas, DepositToRewardingFundGasPerByte},
+	ClaimFromRewardingFund:     IntrinsicGasParams{ClaimFromRewardingFundBaseGas, ClaimFromRewardingFundGasPerByte},
+	AccessListAddress:          TxAccessListAddressGas,
+	AccessListStorageKey:       TxAccessListStorageKeyGas,
+}
+
+// Upgrade returns the next version of the gas table, which overrides the intrinsic gas of the action types named as in
+// the json of the gas table
+func (t *GasTable) Upgrade(overrides map[string]IntrinsicGasParams) (*GasTable, error) {
+	next := *t
+	next.Version++
+	params := next.params()
+	for name, p := range overrides {
+		ptr, ok := params[name]
+		if !ok {
+			return nil, errors.Errorf("unknown action type %s of gas table", name)
+		}
+		*ptr = p
+	}
+	return &next, nil
+}
+
+func (t *GasTable) params() map[string]*IntrinsicGasParams {
+	return map[string]*IntrinsicGasParams{
+		"transfer":                   &t.Transfer,
+		"execution":                  &t.Execution,
+		"createStake":                &t.CreateStake,
+		"depositToStake":             &t.DepositToStake,
+		"moveStake":                  &t.MoveStake,
+		"reclaimStake":               &t.ReclaimStake,
+		"restake":                    &t.Restake,
+		"migrateStake":               &t.MigrateStake,
+		"candidateRegister":          &t.CandidateRegister,
+		"candidateUpdate":            &t.CandidateUpdate,
+		"candidateActivate":          &t.CandidateActivate,
+		"candidateEndorsement":       &t.CandidateEndorsement,
+		"candidateTransferOwnership": &t.CandidateTransferOwnership,
+		"depositToRewardingFund":     &t.DepositToRewardingFund,
+		"claimFromRewardingFund":     &t.ClaimFromRewardingFund,
+	}
+}
+
+// IntrinsicGas returns the intrinsic gas of a payload of the size
+func (p IntrinsicGasParams) IntrinsicGas(size uint64) (uint64, error) {
+	if p.PerByte == 0 {
+		return p.Base, nil
+	}
+	return CalculateIntrinsicGas(p.Base, p.PerByte, size)
+}
+
+// IntrinsicGas returns the intrinsic gas of the envelope by the gas table
+func (t *GasTable) IntrinsicGas(elp Envelope) (uint64, error) {
+	var (
+		gas uint64
+		err error
+	)
+	switch elp := elp.(type) {
+	case *envelope:
+		gas, err = t.payloadIntrinsicGas(elp.payload)
+	case *txContainer:
+		gas, err = t.Execution.IntrinsicGas(uint64(len(elp.tx.Data())))
+	default:
+		return elp.IntrinsicGas()
+	}
+	if err != nil {
+		return 0, err
+	}
+	if acl := elp.AccessList(); len(acl) > 0 {
+		gas += uint64(len(acl)) * t.AccessListAddress
+		gas += uint64(acl.StorageKeys()) * t.AccessListStorageKey
+	}
+	return gas, nil
+}
+
+// ActionIntrinsicGas returns the intrinsic gas of the action by the gas table
+func (t *GasTable) ActionIntrinsicGas(act Action) (uint64, error) {
+	payload, ok := act.(actionPayload)
+	if !ok {
+		return 0, errors.Wrapf(ErrInvalidAct, "action %T has no intrinsic gas", act)
+	}
+	return t.payloadIntrinsicGas(payload)
+}
+
+// ExecutionIntrinsicGas returns the intrinsic gas of an execution with the data size and the access list
+func (t *GasTable) ExecutionIntrinsicGas(size uint64, acl types.AccessList) (uint64, error) {
+	gas, err := t.Execution.IntrinsicGas(size)
+	if err != nil {
+		return 0, err
+	}
+	return gas + uint64(len(acl))*t.AccessListAddress + uint64(acl.StorageKeys())*t.AccessListStorageKey, nil
+}
+
+func (t *GasTable) payloadIntrinsicGas(payload actionPayload) (uint64, error) {
+	switch act := payload.(type) {
+	case *Transfer:
+		return t.Transfer.IntrinsicGas(uint64(len(act.Payload())))
+	case *Execution:
+		return t.Execution.IntrinsicGas(uint64(len(act.Data())))
+	case *CreateStake:
+		return t.CreateStake.IntrinsicGas(uint64(len(act.Payload())))
+	case *DepositToStake:
+		return t.DepositToStake.IntrinsicGas(uint64(len(act.Payload())))
+	case *ChangeCandidate:
+		return t.MoveStake.IntrinsicGas(uint64(len(act.Payload())))
+	case *TransferStake:
+		return t.MoveStake.IntrinsicGas(uint64(len(act.Payload())))
+	case *Unstake:
+		return t.ReclaimStake.IntrinsicGas(uint64(len(act.Payload())))
+	case *WithdrawStake:
+		return t.ReclaimStake.IntrinsicGas(uint64(len(act.Payload())))
+	case *Restake:
+		return t.Restake.IntrinsicGas(uint64(len(act.Payload())))
+	case *MigrateStake:
+		return t.MigrateStake.IntrinsicGas(0)
+	case *CandidateRegister:
+		return t.CandidateRegister.IntrinsicGas(uint64(len(act.Payload())))
+	case *CandidateUpdate:
+		return t.CandidateUpdate.IntrinsicGas(0)
+	case *CandidateActivate:
+		return t.CandidateActivate.IntrinsicGas(0)
+	case *CandidateEndorsement:
+		return t.CandidateEndorsement.IntrinsicGas(0)
+	case *CandidateTransferOwnership:
+		return t.CandidateTransferOwnership.IntrinsicGas(uint64(len(act.Payload())))
+	case *DepositToRewardingFund:
+		return t.DepositToRewardingFund.IntrinsicGas(uint64(len(act.Data())))
+	case *ClaimFromRewardingFund:
+		return t.ClaimFromRewardingFund.IntrinsicGas(uint64(len(act.Data())))
+	default:
+		// system actions do not consume intrinsic gas
+		return payload.IntrinsicGas()
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestGasTable(t *testing.T) {
+	require := require.New(t)
+	to := identityset.Address(1).String()
+	acl := types.AccessList{{
+		Address:     common.Address{},
+		StorageKeys: []common.Hash{{}, {1}},
+	}}
+	elps := []Envelope{
+		NewEnvelope(NewLegacyTx(1, 0, 0, big.NewInt(0)), NewTransfer(big.NewInt(1), to, []byte("payload"))),
+		NewEnvelope(NewAccessListTx(1, 0, 0, big.NewInt(0), acl), NewExecution(to, big.NewInt(0), []byte{1, 2, 3})),
+		NewEnvelope(NewLegacyTx(1, 0, 0, big.NewInt(0)), NewMigrateStake(1)),
+		NewEnvelope(NewLegacyTx(1, 0, 0, big.NewInt(0)), NewCandidateActivate(1)),
+		NewEnvelope(NewLegacyTx(1, 0, 0, big.NewInt(0)), &GrantReward{}),
+	}
+	expected := []uint64{
+		TransferBaseIntrinsicGas + 7*TransferPayloadGas,
+		ExecutionBaseIntrinsicGas + 3*ExecutionDataGas + TxAccessListAddressGas + 2*TxAccessListStorageKeyGas,
+		MigrateStakeBaseIntrinsicGas,
+		CandidateActivateBaseIntrinsicGas,
+		0,
+	}
+	for i, elp := range elps {
+		gas, err := elp.IntrinsicGas()
+		require.NoError(err)
+		require.Equal(expected[i], gas)
+		gas, err = GasTableV1.IntrinsicGas(elp)
+		require.NoError(err)
+		require.Equal(expected[i], gas)
+	}
+
+	// a new version of gas table
+	v2 := *GasTableV1
+	v2.Version = 2
+	v2.Transfer = IntrinsicGasParams{Base: 21000}
+	v2.Execution.PerByte = 16
+	v2.AccessListStorageKey = 0
+	expected = []uint64{
+		21000,
+		ExecutionBaseIntrinsicGas + 3*16 + TxAccessListAddressGas,
+		MigrateStakeBaseIntrinsicGas,
+		CandidateActivateBaseIntrinsicGas,
+		0,
+	}
+	for i, elp := range elps {
+		gas, err := v2.IntrinsicGas(elp)
+		require.NoError(err)
+		require.Equal(expected[i], gas)
+	}
+
+	gas, err := v2.ActionIntrinsicGas(NewTransfer(big.NewInt(1), to, []byte("payload")))
+	require.NoError(err)
+	require.Equal(uint64(21000), gas)
+	gas, err = v2.ExecutionIntrinsicGas(3, acl)
+	require.NoError(err)
+	require.Equal(expected[1], gas)
+
+	// upgrade the gas table
+	v3, err := v2.Upgrade(map[string]IntrinsicGasParams{"transfer": {Base: 30000, PerByte: 10}})
+	require.NoError(err)
+	require.Equal(uint32(3), v3.Version)
+	require.Equal(IntrinsicGasParams{Base: 21000}, v2.Transfer)
+	gas, err = v3.IntrinsicGas(elps[0])
+	require.NoError(err)
+	require.Equal(uint64(30070), gas)
+	_, err = v3.Upgrade(map[string]IntrinsicGasParams{"accessListAddress": {}})
+	require.Error(err)
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/state"
@@ -83,8 +84,28 @@ func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, sr protoco
 }
 
 // ReadState read the state on blockchain via protocol
-func (p *Protocol) ReadState(context.Context, protocol.StateReader, []byte, ...[]byte) ([]byte, uint64, error) {
-	return nil, uint64(0), protocol.ErrUnimplemented
+func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	switch string(method) {
+	case "Account":
+		if len(args) != 1 {
+			return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		addr, err := address.FromString(string(args[0]))
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		acct, height, err := accountutil.AccountStateWithHeight(ctx, sr, addr)
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		data, err := acct.Serialize()
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		return data, height, nil
+	default:
+		return nil, uint64(0), protocol.ErrUnimplemented
+	}
 }
 
 // Register registers the protocol with a unique ID
@@ -102,6 +123,21 @@ func (p *Protocol) Name() string {
 	return protocolID
 }
 
+// Namespaces returns the namespaces used by the protocol
+func (p *Protocol) Namespaces() []string {
+	return []string{protocol.AccountKVNamespace}
+}
+
+// ExportStates exports all the accounts
+func (p *Protocol) ExportStates(_ context.Context, sr protocol.StateReader, fn func(*protocol.StateEntry) error) error {
+	return protocol.ExportNamespaces(sr, fn, p.Namespaces()...)
+}
+
+// ImportStates imports the accounts
+func (p *Protocol) ImportStates(_ context.Context, sm protocol.StateManager, entries []*protocol.StateEntry) error {
+	return protocol.ImportNamespaces(sm, entries, p.Namespaces()...)
+}
+
 func createAccount(sm protocol.StateManager, encodedAddr string, init *big.Int, opts ...state.AccountCreationOption) error {
 	account := &state.Account{}
 	addr, err := address.FromString(encodedAddr)
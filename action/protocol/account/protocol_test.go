@@ -6,12 +6,15 @@
 package account
 
 import (
+	"bytes"
 	"context"
 	"math/big"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
@@ -22,6 +25,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
 )
 
 func TestLoadOrCreateAccountState(t *testing.T) {
@@ -181,6 +185,53 @@ func TestRegisterOrForceRegister(t *testing.T) {
 	require.Nil(FindProtocol(registry))
 }
 
+func TestExportImportStates(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	p := NewProtocol(rewarding.DepositGas)
+	sm := testdb.NewMockStateManager(ctrl)
+	for _, addr := range []address.Address{identityset.Address(3), identityset.Address(1), identityset.Address(2)} {
+		acc, err := state.NewAccount()
+		require.NoError(err)
+		require.NoError(acc.AddBalance(big.NewInt(10)))
+		_, err = sm.PutState(acc, protocol.NamespaceOption(protocol.AccountKVNamespace), protocol.LegacyKeyOption(hash.BytesToHash160(addr.Bytes())))
+		require.NoError(err)
+	}
+	export := func(sr protocol.StateReader) []*protocol.StateEntry {
+		var entries []*protocol.StateEntry
+		require.NoError(p.ExportStates(ctx, sr, func(e *protocol.StateEntry) error {
+			entries = append(entries, e)
+			return nil
+		}))
+		return entries
+	}
+	entries := export(sm)
+	require.Len(entries, 3)
+	for i := 1; i < len(entries); i++ {
+		require.Equal(-1, bytes.Compare(entries[i-1].Key, entries[i].Key))
+	}
+
+	sm2 := testdb.NewMockStateManager(ctrl)
+	require.NoError(p.ImportStates(ctx, sm2, entries))
+	require.Equal(entries, export(sm2))
+
+	// the export stops at the error of the callback
+	var (
+		count   int
+		errStop = errors.New("stop")
+	)
+	require.Equal(errStop, p.ExportStates(ctx, sm, func(*protocol.StateEntry) error {
+		count++
+		return errStop
+	}))
+	require.Equal(1, count)
+
+	// states out of protocol's namespaces are rejected
+	require.Error(p.ImportStates(ctx, sm2, []*protocol.StateEntry{{Namespace: "Staking"}}))
+}
+
 // TestAssertZeroBlockHeight tests the assertZeroBlockHeight funcs
 func TestAssertZeroBlockHeight(t *testing.T) {
 	require := require.New(t)
@@ -213,3 +264,35 @@ func TestAssertAmountsAndEqualLength(t *testing.T) {
 	addrs = addrs[:2]
 	require.Error(p.assertEqualLength(addrs, amounts))
 }
+
+func TestProtocol_ReadState(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+
+	p := NewProtocol(rewarding.DepositGas)
+	addr := identityset.Address(27)
+	require.NoError(createAccount(sm, addr.String(), big.NewInt(5)))
+	for _, test := range []struct {
+		addr    address.Address
+		balance string
+	}{
+		{addr, "5"},
+		{identityset.Address(28), "0"},
+	} {
+		data, height, err := p.ReadState(ctx, sm, []byte("Account"), []byte(test.addr.String()))
+		require.NoError(err)
+		require.Zero(height)
+		acct := &state.Account{}
+		require.NoError(acct.Deserialize(data))
+		require.Equal(test.balance, acct.Balance.String())
+	}
+
+	_, _, err := p.ReadState(ctx, sm, []byte("Account"))
+	require.Error(err)
+	_, _, err = p.ReadState(ctx, sm, []byte("Account"), []byte("invalid"))
+	require.Error(err)
+	_, _, err = p.ReadState(ctx, sm, []byte("Unknown"))
+	require.Equal(protocol.ErrUnimplemented, err)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package acl
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const _aclJSONABI = `[
+	{
+		"inputs": [
+			{"internalType": "address", "name": "account", "type": "address"},
+			{"internalType": "string[]", "name": "actionTypes", "type": "string[]"}
+		],
+		"name": "allow",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "address", "name": "account", "type": "address"}
+		],
+		"name": "revoke",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+var (
+	_allowMethod  abi.Method
+	_revokeMethod abi.Method
+
+	// ErrInvalidCall indicates the call to the acl protocol is malformed
+	ErrInvalidCall = errors.New("invalid acl call")
+)
+
+type (
+	// Call is a governance call updating the allowlist, the action types are ignored when revoking an account
+	Call struct {
+		Revoke      bool
+		Account     common.Address
+		ActionTypes []string
+	}
+
+	// allowance is the entry of an account in the allowlist, an empty list of action types allows all types
+	allowance struct {
+		actionTypes []string
+	}
+)
+
+func init() {
+	contractABI, err := abi.JSON(strings.NewReader(_aclJSONABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	if _allowMethod, ok = contractABI.Methods["allow"]; !ok {
+		panic("fail to load the method allow")
+	}
+	if _revokeMethod, ok = contractABI.Methods["revoke"]; !ok {
+		panic("fail to load the method revoke")
+	}
+}
+
+// EncodeCall encodes the call into the data of an execution sent to the acl protocol
+func EncodeCall(call *Call) ([]byte, error) {
+	if call.Revoke {
+		data, err := _revokeMethod.Inputs.Pack(call.Account)
+		if err != nil {
+			return nil, err
+		}
+		return append(_revokeMethod.ID, data...), nil
+	}
+	actionTypes := call.ActionTypes
+	if actionTypes == nil {
+		actionTypes = []string{}
+	}
+	data, err := _allowMethod.Inputs.Pack(call.Account, actionTypes)
+	if err != nil {
+		return nil, err
+	}
+	return append(_allowMethod.ID, data...), nil
+}
+
+// DecodeCall decodes the call from the data of an execution sent to the acl protocol
+func DecodeCall(data []byte) (*Call, error) {
+	if len(data) < 4 {
+		return nil, errors.Wrap(ErrInvalidCall, "data is too short")
+	}
+	var (
+		method abi.Method
+		call   = &Call{}
+	)
+	switch id := string(data[:4]); id {
+	case string(_allowMethod.ID):
+		method = _allowMethod
+	case string(_revokeMethod.ID):
+		method, call.Revoke = _revokeMethod, true
+	default:
+		return nil, errors.Wrapf(ErrInvalidCall, "unknown method %x", data[:4])
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCall, err.Error())
+	}
+	var ok bool
+	if call.Account, ok = args[0].(common.Address); !ok {
+		return nil, errors.Wrap(ErrInvalidCall, "invalid account")
+	}
+	if !call.Revoke {
+		if call.ActionTypes, ok = args[1].([]string); !ok {
+			return nil, errors.Wrap(ErrInvalidCall, "invalid action types")
+		}
+	}
+	return call, nil
+}
+
+// allows returns whether the action type is allowed
+func (a *allowance) allows(actionType string) bool {
+	if len(a.actionTypes) == 0 {
+		return true
+	}
+	for _, t := range a.actionTypes {
+		if t == actionType {
+			return true
+		}
+	}
+	return false
+}
+
+// Serialize serializes the allowance into bytes
+func (a *allowance) Serialize() ([]byte, error) {
+	data := binary.AppendUvarint(nil, uint64(len(a.actionTypes)))
+	for _, t := range a.actionTypes {
+		data = binary.AppendUvarint(data, uint64(len(t)))
+		data = append(data, t...)
+	}
+	return data, nil
+}
+
+// Deserialize deserializes bytes into the allowance
+func (a *allowance) Deserialize(data []byte) error {
+	count, n := binary.Uvarint(data)
+	if n <= 0 || count > uint64(len(data)) {
+		return errors.New("invalid allowance")
+	}
+	data = data[n:]
+	actionTypes := make([]string, 0, count)
+	for i := uint64(0); i < count; i++ {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return errors.New("invalid allowance")
+		}
+		actionTypes = append(actionTypes, string(data[n:n+int(size)]))
+		data = data[n+int(size):]
+	}
+	a.actionTypes = actionTypes
+	return nil
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package acl
+
+import (
+	"context"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const (
+	_protocolID = "acl"
+	// _allowlistNamespace stores the allowance of the accounts, keyed by the account address
+	_allowlistNamespace = "ACL"
+)
+
+var (
+	// ErrNotAllowed indicates the sender is not in the allowlist
+	ErrNotAllowed = errors.New("sender is not allowed")
+	// ErrActionTypeNotAllowed indicates the sender is not allowed to send the type of action
+	ErrActionTypeNotAllowed = errors.New("action type is not allowed")
+)
+
+type (
+	// Protocol defines the protocol of the action-level access control list for permissioned deployments. Only the
+	// senders in the allowlist can send actions, and the types of actions they can send are restricted by their
+	// entries in the allowlist. The allowlist is managed by the admins in genesis, by sending executions to the
+	// protocol address, which carry the allow or revoke calls.
+	Protocol struct {
+		addr       address.Address
+		admins     map[string]struct{}
+		depositGas protocol.DepositGas
+	}
+
+	actPoolValidator struct {
+		p  *Protocol
+		sr protocol.StateReader
+	}
+)
+
+// NewProtocol instantiates the protocol of acl with the admins of the allowlist
+func NewProtocol(admins []string, depositGas protocol.DepositGas) (*Protocol, error) {
+	h := hash.Hash160b([]byte(_protocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of acl protocol", zap.Error(err))
+	}
+	if len(admins) == 0 {
+		return nil, errors.New("no admin of acl")
+	}
+	p := &Protocol{
+		addr:       addr,
+		admins:     make(map[string]struct{}, len(admins)),
+		depositGas: depositGas,
+	}
+	for _, admin := range admins {
+		a, err := address.FromString(admin)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid acl admin %s", admin)
+		}
+		p.admins[a.String()] = struct{}{}
+	}
+	return p, nil
+}
+
+// FindProtocol finds the registered protocol from registry
+func FindProtocol(registry *protocol.Registry) *Protocol {
+	if registry == nil {
+		return nil
+	}
+	p, ok := registry.Find(_protocolID)
+	if !ok {
+		return nil
+	}
+	pp, ok := p.(*Protocol)
+	if !ok {
+		log.S().Panic("fail to cast acl protocol")
+	}
+	return pp
+}
+
+// Address returns the address to send the governance calls to
+func (p *Protocol) Address() address.Address {
+	return p.addr
+}
+
+// Handle handles a governance call updating the allowlist
+func (p *Protocol) Handle(ctx context.Context, elp action.Envelope, sm protocol.StateManager) (*action.Receipt, error) {
+	call, err := p.governanceCall(elp)
+	if call == nil || err != nil {
+		return nil, err
+	}
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	if !p.isAdmin(actionCtx.Caller) {
+		return nil, errors.Wrapf(ErrNotAllowed, "%s is not acl admin", actionCtx.Caller.String())
+	}
+	key := call.Account.Bytes()
+	if call.Revoke {
+		var a allowance
+		_, err := sm.State(&a, protocol.NamespaceOption(_allowlistNamespace), protocol.KeyOption(key))
+		switch errors.Cause(err) {
+		case nil:
+			if _, err := sm.DelState(protocol.NamespaceOption(_allowlistNamespace), protocol.KeyOption(key)); err != nil {
+				return nil, errors.Wrap(err, "failed to revoke account")
+			}
+		case state.ErrStateNotExist:
+		default:
+			return nil, err
+		}
+	} else {
+		if _, err := sm.PutState(
+			&allowance{actionTypes: call.ActionTypes},
+			protocol.NamespaceOption(_allowlistNamespace),
+			protocol.KeyOption(key),
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to allow account")
+		}
+	}
+	return p.settleAction(ctx, sm, elp)
+}
+
+// Validate validates the sender and the type of the action against the allowlist
+func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, sr protocol.StateReader) error {
+	switch elp.Action().(type) {
+	case *action.GrantReward, *action.PutPollResult:
+		// system actions are not sent by accounts
+		return nil
+	}
+	caller := protocol.MustGetActionCtx(ctx).Caller
+	call, err := p.governanceCall(elp)
+	if err != nil {
+		return err
+	}
+	if p.isAdmin(caller) {
+		if v := elp.Value(); call != nil && v != nil && v.Sign() != 0 {
+			return errors.Wrap(ErrInvalidCall, "cannot send amount to acl")
+		}
+		return nil
+	}
+	if call != nil {
+		return errors.Wrapf(ErrNotAllowed, "%s is not acl admin", caller.String())
+	}
+	var a allowance
+	_, err = sr.State(&a, protocol.NamespaceOption(_allowlistNamespace), protocol.KeyOption(caller.Bytes()))
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		return errors.Wrapf(ErrNotAllowed, "sender %s", caller.String())
+	default:
+		return err
+	}
+	if actionType := ActionType(elp.Action()); !a.allows(actionType) {
+		return errors.Wrapf(ErrActionTypeNotAllowed, "sender %s, action type %s", caller.String(), actionType)
+	}
+	return nil
+}
+
+// ActPoolValidator returns the validator of the allowlist in the actpool, so that the actions not allowed are rejected
+// before entering the actpool
+func (p *Protocol) ActPoolValidator(sr protocol.StateReader) action.SealedEnvelopeValidator {
+	return &actPoolValidator{
+		p:  p,
+		sr: sr,
+	}
+}
+
+// Validate validates the sender and the type of the action in the actpool
+func (v *actPoolValidator) Validate(ctx context.Context, selp *action.SealedEnvelope) error {
+	ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: selp.SenderAddress()})
+	return v.p.Validate(ctx, selp.Envelope, v.sr)
+}
+
+// ActionType returns the name of the type of the action in the allowlist, e.g., Transfer, Execution, CreateStake. It
+// returns empty string for the actions which cannot be allowed by type
+func ActionType(act action.Action) string {
+	switch act.(type) {
+	case *action.Transfer:
+		return "Transfer"
+	case *action.Execution:
+		return "Execution"
+	case *action.ClaimFromRewardingFund:
+		return "ClaimFromRewardingFund"
+	case *action.DepositToRewardingFund:
+		return "DepositToRewardingFund"
+	case *action.CreateStake:
+		return "CreateStake"
+	case *action.Unstake:
+		return "Unstake"
+	case *action.WithdrawStake:
+		return "WithdrawStake"
+	case *action.DepositToStake:
+		return "DepositToStake"
+	case *action.Restake:
+		return "Restake"
+	case *action.ChangeCandidate:
+		return "ChangeCandidate"
+	case *action.TransferStake:
+		return "TransferStake"
+	case *action.CandidateRegister:
+		return "CandidateRegister"
+	case *action.CandidateUpdate:
+		return "CandidateUpdate"
+	case *action.CandidateActivate:
+		return "CandidateActivate"
+	case *action.CandidateEndorsement:
+		return "CandidateEndorsement"
+	case *action.CandidateTransferOwnership:
+		return "CandidateTransferOwnership"
+	case *action.MigrateStake:
+		return "MigrateStake"
+	default:
+		return ""
+	}
+}
+
+// governanceCall returns the governance call if the action is sent to the acl protocol, nil otherwise
+func (p *Protocol) governanceCall(elp action.Envelope) (*Call, error) {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != p.addr.String() {
+		return nil, nil
+	}
+	return DecodeCall(exec.Data())
+}
+
+func (p *Protocol) isAdmin(addr address.Address) bool {
+	if addr == nil {
+		return false
+	}
+	_, ok := p.admins[addr.String()]
+	return ok
+}
+
+// settleAction deposits gas fee and updates caller's nonce
+func (p *Protocol) settleAction(ctx context.Context, sm protocol.StateManager, elp action.Envelope) (*action.Receipt, error) {
+	var (
+		actionCtx = protocol.MustGetActionCtx(ctx)
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+	)
+	gas, err := protocol.IntrinsicGas(ctx, elp)
+	if err != nil {
+		return nil, err
+	}
+	priorityFee, baseFee, err := protocol.SplitGas(ctx, elp, gas)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to split gas")
+	}
+	depositLog, err := p.depositGas(ctx, sm, baseFee, protocol.PriorityFeeOption(priorityFee))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deposit gas")
+	}
+	accountCreationOpts := []state.AccountCreationOption{}
+	if protocol.MustGetFeatureCtx(ctx).CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	acc, err := accountutil.LoadAccount(sm, actionCtx.Caller, accountCreationOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.SetPendingNonce(actionCtx.Nonce + 1); err != nil {
+		return nil, errors.Wrap(err, "failed to set nonce")
+	}
+	if err := accountutil.StoreAccount(sm, actionCtx.Caller, acc); err != nil {
+		return nil, errors.Wrap(err, "failed to update nonce")
+	}
+	r := action.Receipt{
+		Status:            uint64(iotextypes.ReceiptStatus_Success),
+		BlockHeight:       blkCtx.BlockHeight,
+		ActionHash:        actionCtx.ActionHash,
+		GasConsumed:       gas,
+		ContractAddress:   p.addr.String(),
+		EffectiveGasPrice: protocol.EffectiveGasPrice(ctx, elp),
+	}
+	r.AddTransactionLogs(depositLog...)
+	return &r, nil
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(context.Context, protocol.StateReader, []byte, ...[]byte) ([]byte, uint64, error) {
+	return nil, uint64(0), protocol.ErrUnimplemented
+}
+
+// Register registers the protocol with a unique ID
+func (p *Protocol) Register(r *protocol.Registry) error {
+	return r.Register(_protocolID, p)
+}
+
+// ForceRegister registers the protocol with a unique ID and force replacing the previous protocol if it exists
+func (p *Protocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(_protocolID, p)
+}
+
+// Name returns the name of protocol
+func (p *Protocol) Name() string {
+	return _protocolID
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package acl
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestCall(t *testing.T) {
+	require := require.New(t)
+	account := common.BytesToAddress(identityset.Address(1).Bytes())
+	for _, call := range []*Call{
+		{Account: account, ActionTypes: []string{"Transfer", "Execution"}},
+		{Account: account, ActionTypes: []string{}},
+		{Revoke: true, Account: account},
+	} {
+		data, err := EncodeCall(call)
+		require.NoError(err)
+		decoded, err := DecodeCall(data)
+		require.NoError(err)
+		require.Equal(call, decoded)
+	}
+	_, err := DecodeCall([]byte{1, 2, 3, 4})
+	require.Equal(ErrInvalidCall, errors.Cause(err))
+
+	a := &allowance{actionTypes: []string{"Transfer", "CreateStake"}}
+	data, err := a.Serialize()
+	require.NoError(err)
+	var b allowance
+	require.NoError(b.Deserialize(data))
+	require.Equal(a, &b)
+	require.True(b.allows("Transfer"))
+	require.False(b.allows("Execution"))
+	require.True((&allowance{}).allows("Execution"))
+	require.Error(b.Deserialize([]byte{2, 1}))
+}
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	_, err := NewProtocol(nil, nil)
+	require.Error(err)
+	_, err = NewProtocol([]string{"invalid"}, nil)
+	require.Error(err)
+	var deposited *big.Int
+	p, err := NewProtocol([]string{identityset.Address(0).String()}, func(_ context.Context, _ protocol.StateManager, amount *big.Int, _ ...protocol.DepositOption) ([]*action.TransactionLog, error) {
+		deposited = amount
+		return nil, nil
+	})
+	require.NoError(err)
+	require.Equal(_protocolID, p.Name())
+
+	var (
+		admin = identityset.Address(0)
+		user  = identityset.Address(1)
+	)
+	g := genesis.TestDefault()
+	ctx := genesis.WithGenesisContext(context.Background(), g)
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 10})
+	ctx = protocol.WithFeatureCtx(ctx)
+	withNonce := func(nonce uint64) context.Context {
+		return protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: admin, Nonce: nonce})
+	}
+	adminCtx := withNonce(0)
+	userCtx := protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: user, Nonce: 1})
+	newBuilder := func() *action.EnvelopeBuilder {
+		return (&action.EnvelopeBuilder{}).SetNonce(1).SetGasLimit(100000).SetGasPrice(big.NewInt(1))
+	}
+	newCall := func(call *Call) action.Envelope {
+		data, err := EncodeCall(call)
+		require.NoError(err)
+		return newBuilder().SetAction(action.NewExecution(p.Address().String(), big.NewInt(0), data)).Build()
+	}
+	transfer := newBuilder().SetAction(action.NewTransfer(big.NewInt(1), admin.String(), nil)).Build()
+	execution := newBuilder().SetAction(action.NewExecution(admin.String(), big.NewInt(0), nil)).Build()
+	require.Equal("Transfer", ActionType(transfer.Action()))
+	require.Equal("Execution", ActionType(execution.Action()))
+
+	// admin is always allowed, and the sender not in the allowlist is rejected
+	require.NoError(p.Validate(adminCtx, transfer, sm))
+	require.Equal(ErrNotAllowed, errors.Cause(p.Validate(userCtx, transfer, sm)))
+	// system actions are skipped
+	require.NoError(p.Validate(userCtx, newBuilder().SetAction(&action.GrantReward{}).Build(), sm))
+	// actions not sent to acl are not handled
+	r, err := p.Handle(adminCtx, transfer, sm)
+	require.NoError(err)
+	require.Nil(r)
+
+	// only admin can update the allowlist
+	allow := newCall(&Call{Account: common.BytesToAddress(user.Bytes()), ActionTypes: []string{"Transfer"}})
+	require.Equal(ErrNotAllowed, errors.Cause(p.Validate(userCtx, allow, sm)))
+	_, err = p.Handle(userCtx, allow, sm)
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+	require.NoError(p.Validate(adminCtx, allow, sm))
+	acc, err := accountutil.LoadOrCreateAccount(sm, admin)
+	require.NoError(err)
+	require.NoError(acc.AddBalance(big.NewInt(1000000)))
+	require.NoError(accountutil.StoreAccount(sm, admin, acc))
+	r, err = p.Handle(adminCtx, allow, sm)
+	require.NoError(err)
+	require.Equal(p.Address().String(), r.ContractAddress)
+	require.Equal(new(big.Int).SetUint64(r.GasConsumed), deposited)
+	acc, err = accountutil.LoadAccount(sm, admin)
+	require.NoError(err)
+	require.Equal(uint64(1), acc.PendingNonce())
+
+	// the user can only send the allowed types of actions
+	require.NoError(p.Validate(userCtx, transfer, sm))
+	require.Equal(ErrActionTypeNotAllowed, errors.Cause(p.Validate(userCtx, execution, sm)))
+	// the actpool validator checks the sender of the sealed envelope
+	v := p.ActPoolValidator(sm)
+	selp, err := action.Sign(transfer, identityset.PrivateKey(1))
+	require.NoError(err)
+	require.NoError(v.Validate(ctx, selp))
+	selp, err = action.Sign(execution, identityset.PrivateKey(1))
+	require.NoError(err)
+	require.Equal(ErrActionTypeNotAllowed, errors.Cause(v.Validate(ctx, selp)))
+	selp, err = action.Sign(transfer, identityset.PrivateKey(2))
+	require.NoError(err)
+	require.Equal(ErrNotAllowed, errors.Cause(v.Validate(ctx, selp)))
+
+	// revoked
+	_, err = p.Handle(withNonce(1), newCall(&Call{Revoke: true, Account: common.BytesToAddress(user.Bytes())}), sm)
+	require.NoError(err)
+	require.Equal(ErrNotAllowed, errors.Cause(p.Validate(userCtx, transfer, sm)))
+	// revoking an account not in the allowlist is a no-op
+	_, err = p.Handle(withNonce(2), newCall(&Call{Revoke: true, Account: common.BytesToAddress(user.Bytes())}), sm)
+	require.NoError(err)
+
+	// malformed call
+	bad := newBuilder().SetAction(action.NewExecution(p.Address().String(), big.NewInt(0), []byte{1, 2, 3, 4})).Build()
+	require.Equal(ErrInvalidCall, errors.Cause(p.Validate(adminCtx, bad, sm)))
+}
@@ -74,6 +74,8 @@ type (
 		ExcessBlobGas uint64
 		// SkipSidecarValidation dictates to validate sidecar (for blob tx) or not
 		SkipSidecarValidation bool
+		// SkipSignatureValidation dictates to verify the signatures of the actions or not
+		SkipSignatureValidation bool
 	}
 
 	// ActionCtx provides action auxiliary information.
@@ -149,6 +151,16 @@ type (
 		UnstakedButNotClearSelfStakeAmount      bool
 		CheckStakingDurationUpperLimit          bool
 		FixRevertSnapshot                       bool
+		EnableBaseFeeTreasury                   bool
+		EnableStatefulPrecompile                bool
+		EnablePaymaster                         bool
+		EnableAutoClaim                         bool
+		EnableClaimRewardCall                   bool
+		EnableRewardStatement                   bool
+		EnableFeeStats                          bool
+		EnableCandidateIdentity                 bool
+		// GasTable is the intrinsic gas table activated at the height
+		GasTable *action.GasTable
 	}
 
 	// FeatureWithHeightCtx provides feature check functions.
@@ -307,6 +319,15 @@ func WithFeatureCtx(ctx context.Context) context.Context {
 			UnstakedButNotClearSelfStakeAmount:      !g.IsVanuatu(height),
 			CheckStakingDurationUpperLimit:          g.IsVanuatu(height),
 			FixRevertSnapshot:                       g.IsVanuatu(height),
+			EnableBaseFeeTreasury:                   g.IsToBeEnabled(height),
+			EnableStatefulPrecompile:                g.IsToBeEnabled(height),
+			EnablePaymaster:                         g.IsToBeEnabled(height),
+			EnableAutoClaim:                         g.IsToBeEnabled(height),
+			EnableClaimRewardCall:                   g.IsToBeEnabled(height),
+			EnableRewardStatement:                   g.IsToBeEnabled(height),
+			EnableFeeStats:                          g.IsToBeEnabled(height),
+			EnableCandidateIdentity:                 g.IsToBeEnabled(height),
+			GasTable:                                gasTable(g.Blockchain, height),
 		},
 	)
 }
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Event is a typed event published by a protocol
+	Event interface {
+		Topic() string
+	}
+
+	// EventHandler handles an event
+	EventHandler func(context.Context, Event) error
+
+	// EventBus is an in-process event bus, events are delivered to subscribers synchronously in the order
+	// of subscription, so that the handling is deterministic when used in Handle/Commit
+	EventBus struct {
+		mu     sync.RWMutex
+		nextID uint64
+		subs   map[string][]subscription
+	}
+
+	subscription struct {
+		id      uint64
+		handler EventHandler
+	}
+)
+
+// NewEventBus creates a new event bus
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subs: make(map[string][]subscription),
+	}
+}
+
+// Subscribe subscribes to a topic, and returns a function to unsubscribe
+func (b *EventBus) Subscribe(topic string, handler EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.subs[topic] = append(b.subs[topic], subscription{id: id, handler: handler})
+	return func() {
+		b.unsubscribe(topic, id)
+	}
+}
+
+func (b *EventBus) unsubscribe(topic string, id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := b.subs[topic]
+	for i := range subs {
+		if subs[i].id == id {
+			b.subs[topic] = append(subs[:i:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(b.subs[topic]) == 0 {
+		delete(b.subs, topic)
+	}
+}
+
+// Publish delivers the event to all subscribers of its topic, and stops at the first error
+func (b *EventBus) Publish(ctx context.Context, e Event) error {
+	b.mu.RLock()
+	subs := b.subs[e.Topic()]
+	handlers := make([]EventHandler, len(subs))
+	for i := range subs {
+		handlers[i] = subs[i].handler
+	}
+	b.mu.RUnlock()
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil {
+			return errors.Wrapf(err, "failed to handle event %s", e.Topic())
+		}
+	}
+	return nil
+}
+
+// PublishEvent publishes the event to the event bus of the registry in context
+func PublishEvent(ctx context.Context, e Event) error {
+	reg, ok := GetRegistry(ctx)
+	if !ok || reg == nil || reg.EventBus() == nil {
+		return nil
+	}
+	return reg.EventBus().Publish(ctx, e)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent string
+
+func (e testEvent) Topic() string {
+	return string(e)
+}
+
+func TestEventBus(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	bus := NewEventBus()
+	var received []string
+	unsub1 := bus.Subscribe("a", func(_ context.Context, e Event) error {
+		received = append(received, "1"+e.Topic())
+		return nil
+	})
+	bus.Subscribe("a", func(_ context.Context, e Event) error {
+		received = append(received, "2"+e.Topic())
+		return nil
+	})
+	bus.Subscribe("b", func(_ context.Context, e Event) error {
+		received = append(received, "3"+e.Topic())
+		return nil
+	})
+	// delivered in the order of subscription
+	require.NoError(bus.Publish(ctx, testEvent("a")))
+	require.Equal([]string{"1a", "2a"}, received)
+	received = nil
+	require.NoError(bus.Publish(ctx, testEvent("c")))
+	require.Empty(received)
+	unsub1()
+	require.NoError(bus.Publish(ctx, testEvent("a")))
+	require.Equal([]string{"2a"}, received)
+
+	// error stops the delivery
+	received = nil
+	bus.Subscribe("b", func(context.Context, Event) error {
+		return errors.New("failed")
+	})
+	bus.Subscribe("b", func(_ context.Context, e Event) error {
+		received = append(received, "4"+e.Topic())
+		return nil
+	})
+	require.Error(bus.Publish(ctx, testEvent("b")))
+	require.Equal([]string{"3b"}, received)
+
+	// publish through registry in context
+	require.NoError(PublishEvent(ctx, testEvent("a")))
+	reg := NewRegistry()
+	received = nil
+	reg.EventBus().Subscribe("a", func(_ context.Context, e Event) error {
+		received = append(received, "5"+e.Topic())
+		return nil
+	})
+	require.NoError(PublishEvent(WithRegistry(ctx, reg), testEvent("a")))
+	require.Equal([]string{"5a"}, received)
+}
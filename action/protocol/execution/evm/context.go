@@ -3,6 +3,8 @@ package evm
 import (
 	"context"
 
+	"github.com/iotexproject/iotex-address/address"
+
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
@@ -15,6 +17,8 @@ type (
 		GetBlockHash   GetBlockHash
 		GetBlockTime   GetBlockTime
 		DepositGasFunc protocol.DepositGas
+		// GasPayer pays the gas fee instead of the caller if not nil
+		GasPayer address.Address
 	}
 )
 
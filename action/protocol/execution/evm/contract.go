@@ -189,10 +189,7 @@ func newContract(addr hash.Hash160, account *state.Account, sm protocol.StateMan
 	options := []mptrie.Option{
 		mptrie.KVStoreOption(protocol.NewKVStoreForTrieWithStateManager(ContractKVNameSpace, sm)),
 		mptrie.KeyLengthOption(len(hash.Hash256{})),
-		mptrie.HashFuncOption(func(data []byte) []byte {
-			h := hash.Hash256b(append(addr[:], data...))
-			return h[:]
-		}),
+		mptrie.HashFuncOption(StorageHashFunc(addr)),
 	}
 	if account.Root != hash.ZeroHash256 {
 		options = append(options, mptrie.RootHashOption(account.Root[:]))
@@ -211,3 +208,37 @@ func newContract(addr hash.Hash160, account *state.Account, sm protocol.StateMan
 	c.trie = tr
 	return c, nil
 }
+
+// StorageHashFunc returns the hash func of the storage trie of the contract
+func StorageHashFunc(addr hash.Hash160) mptrie.HashFunc {
+	return func(data []byte) []byte {
+		h := hash.Hash256b(append(addr[:], data...))
+		return h[:]
+	}
+}
+
+// StorageProof returns the root hash of the storage trie of the contract, and the value and merkle proof of each key
+// in the storage trie, the value of an absent key is nil
+func StorageProof(sm protocol.StateManager, addr hash.Hash160, account *state.Account, keys []hash.Hash256) ([]byte, [][]byte, [][][]byte, error) {
+	c, err := newContract(addr, account, sm, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tr := c.(*contract).trie
+	root, err := tr.RootHash()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	values := make([][]byte, len(keys))
+	proofs := make([][][]byte, len(keys))
+	for i, key := range keys {
+		values[i], err = tr.Get(key[:])
+		if err != nil && errors.Cause(err) != trie.ErrNotExist {
+			return nil, nil, nil, err
+		}
+		if proofs[i], err = tr.Proof(key[:]); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return root, values, proofs, nil
+}
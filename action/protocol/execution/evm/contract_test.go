@@ -19,6 +19,8 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
 	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
@@ -238,3 +240,30 @@ func TestSnapshot(t *testing.T) {
 		testfunc(true)
 	})
 }
+
+func TestStorageProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, err := initMockStateManager(ctrl)
+	require.NoError(err)
+	addr := hash.BytesToHash160(_c1[:])
+	c, err := newContract(addr, &state.Account{}, sm, false)
+	require.NoError(err)
+	require.NoError(c.SetState(_k1b, _v1b[:]))
+	require.NoError(c.SetState(_k2b, _v2b[:]))
+	require.NoError(c.Commit())
+
+	root, values, proofs, err := StorageProof(sm, addr, c.SelfState(), []hash.Hash256{_k1b, _k3b})
+	require.NoError(err)
+	require.Equal(c.SelfState().Root[:], root)
+	require.Equal(_v1b[:], values[0])
+	require.Nil(values[1])
+	v, err := mptrie.VerifyProof(root, _k1b[:], proofs[0], StorageHashFunc(addr))
+	require.NoError(err)
+	require.Equal(_v1b[:], v)
+	_, err = mptrie.VerifyProof(root, _k3b[:], proofs[1], StorageHashFunc(addr))
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+	// the storage trie is bound to the contract address
+	_, err = mptrie.VerifyProof(root, _k1b[:], proofs[0], StorageHashFunc(hash.BytesToHash160(_c2[:])))
+	require.Equal(mptrie.ErrInvalidProof, errors.Cause(err))
+}
@@ -8,7 +8,6 @@ package evm
 import (
 	"bytes"
 	"context"
-	"math"
 	"math/big"
 	"time"
 
@@ -94,6 +93,9 @@ type (
 		featureCtx  protocol.FeatureCtx
 		actionCtx   protocol.ActionCtx
 		helperCtx   HelperContext
+		precompile  StatefulPrecompile
+		// gasPayer pays the gas fee, which is the origin unless the gas is sponsored
+		gasPayer common.Address
 	}
 
 	stateDB interface {
@@ -200,6 +202,14 @@ func newParams(
 		vmTxCtx.BlobHashes = execution.BlobHashes()
 		vmTxCtx.BlobFeeCap = execution.BlobGasFeeCap()
 	}
+	var precompile StatefulPrecompile
+	if featureCtx.EnableStatefulPrecompile {
+		precompile = findStatefulPrecompile(ctx, execution.To())
+	}
+	gasPayer := executorAddr
+	if helperCtx.GasPayer != nil {
+		gasPayer = common.BytesToAddress(helperCtx.GasPayer.Bytes())
+	}
 	return &Params{
 		context,
 		vmTxCtx,
@@ -216,6 +226,8 @@ func newParams(
 		featureCtx,
 		actionCtx,
 		helperCtx,
+		precompile,
+		gasPayer,
 	}, nil
 }
 
@@ -230,10 +242,10 @@ func securityDeposit(ps *Params, stateDB vm.StateDB, gasLimit uint64) error {
 		return action.ErrGasLimit
 	}
 	gasConsumed := uint256.MustFromBig(new(big.Int).Mul(new(big.Int).SetUint64(ps.gas), ps.txCtx.GasPrice))
-	if stateDB.GetBalance(ps.txCtx.Origin).Cmp(gasConsumed) < 0 {
+	if stateDB.GetBalance(ps.gasPayer).Cmp(gasConsumed) < 0 {
 		return action.ErrInsufficientFunds
 	}
-	stateDB.SubBalance(ps.txCtx.Origin, gasConsumed)
+	stateDB.SubBalance(ps.gasPayer, gasConsumed)
 	return nil
 }
 
@@ -254,7 +266,7 @@ func ExecuteContract(
 	if err != nil {
 		return nil, nil, err
 	}
-	retval, depositGas, remainingGas, contractAddress, statusCode, err := executeInEVM(ctx, ps, stateDB)
+	retval, depositGas, remainingGas, contractAddress, statusCode, err := executeInEVM(ctx, ps, stateDB, sm)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -273,11 +285,11 @@ func ExecuteContract(
 	)
 	if ps.featureCtx.FixDoubleChargeGas {
 		// Refund all deposit and, actual gas fee will be subtracted when depositing gas fee to the rewarding protocol
-		stateDB.AddBalance(ps.txCtx.Origin, uint256.MustFromBig(big.NewInt(0).Mul(big.NewInt(0).SetUint64(depositGas), ps.txCtx.GasPrice)))
+		stateDB.AddBalance(ps.gasPayer, uint256.MustFromBig(big.NewInt(0).Mul(big.NewInt(0).SetUint64(depositGas), ps.txCtx.GasPrice)))
 	} else {
 		if remainingGas > 0 {
 			remainingValue := new(big.Int).Mul(new(big.Int).SetUint64(remainingGas), ps.txCtx.GasPrice)
-			stateDB.AddBalance(ps.txCtx.Origin, uint256.MustFromBig(remainingValue))
+			stateDB.AddBalance(ps.gasPayer, uint256.MustFromBig(remainingValue))
 		}
 		if consumedGas > 0 {
 			burnLog = &action.TransactionLog{
@@ -391,6 +403,11 @@ func prepareStateDB(ctx context.Context, sm protocol.StateManager) (*StateDBAdap
 		opts = append(opts, FixRevertSnapshotOption())
 		opts = append(opts, WithContext(ctx))
 	}
+	if featureCtx.EnableStatefulPrecompile {
+		if stubs := activeStatefulPrecompiles(ctx); len(stubs) > 0 {
+			opts = append(opts, PrecompileStubOption(stubs))
+		}
+	}
 	return NewStateDBAdapter(
 		sm,
 		blkCtx.BlockHeight,
@@ -437,7 +454,7 @@ func getChainConfig(g genesis.Blockchain, height uint64, id uint32, getBlockTime
 }
 
 // Error in executeInEVM is a consensus issue
-func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB) ([]byte, uint64, uint64, string, iotextypes.ReceiptStatus, error) {
+func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB, sr protocol.StateReader) ([]byte, uint64, uint64, string, iotextypes.ReceiptStatus, error) {
 	var (
 		gasLimit     = evmParams.blkCtx.GasLimit
 		blockHeight  = evmParams.blkCtx.BlockHeight
@@ -456,7 +473,7 @@ func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB) ([]by
 	if g.IsOkhotsk(blockHeight) {
 		accessList = evmParams.accessList
 	}
-	intriGas, err := intrinsicGas(uint64(len(evmParams.data)), accessList)
+	intriGas, err := intrinsicGas(evmParams.featureCtx.GasTable, uint64(len(evmParams.data)), accessList)
 	if err != nil {
 		return nil, evmParams.gas, remainingGas, action.EmptyAddress, iotextypes.ReceiptStatus_Failure, err
 	}
@@ -491,7 +508,11 @@ func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB) ([]by
 	} else {
 		stateDB.SetNonce(evmParams.txCtx.Origin, stateDB.GetNonce(evmParams.txCtx.Origin)+1)
 		// process contract
-		ret, remainingGas, evmErr = evm.Call(executor, *evmParams.contract, evmParams.data, remainingGas, amount)
+		if evmParams.precompile != nil {
+			ret, remainingGas, evmErr = runStatefulPrecompile(ctx, evmParams.precompile, sr, evmParams.data, remainingGas, amount)
+		} else {
+			ret, remainingGas, evmErr = evm.Call(executor, *evmParams.contract, evmParams.data, remainingGas, amount)
+		}
 	}
 	if evmErr != nil {
 		log.T(ctx).Debug("evm error", zap.Error(evmErr))
@@ -604,21 +625,15 @@ func evmErrToErrStatusCode(evmErr error, g genesis.Blockchain, height uint64) io
 	return iotextypes.ReceiptStatus_Failure
 }
 
-// intrinsicGas returns the intrinsic gas of an execution
-func intrinsicGas(size uint64, list types.AccessList) (uint64, error) {
-	if action.ExecutionDataGas == 0 {
-		panic("payload gas price cannot be zero")
-	}
-
-	var accessListGas uint64
-	if len(list) > 0 {
-		accessListGas = uint64(len(list)) * action.TxAccessListAddressGas
-		accessListGas += uint64(list.StorageKeys()) * action.TxAccessListStorageKeyGas
+// intrinsicGas returns the intrinsic gas of an execution by the gas table, or by the gas table since genesis if it is nil
+func intrinsicGas(table *action.GasTable, size uint64, list types.AccessList) (uint64, error) {
+	if table == nil {
+		table = action.GasTableV1
 	}
-	if (math.MaxInt64-action.ExecutionBaseIntrinsicGas-accessListGas)/action.ExecutionDataGas < size {
-		return 0, action.ErrInsufficientFunds
+	if table.Execution.PerByte == 0 {
+		panic("payload gas price cannot be zero")
 	}
-	return size*action.ExecutionDataGas + action.ExecutionBaseIntrinsicGas + accessListGas, nil
+	return table.ExecutionIntrinsicGas(size, list)
 }
 
 // SimulateExecution simulates the execution in evm
@@ -657,16 +672,17 @@ func SimulateExecution(
 			return nil, nil, err
 		}
 	}
-	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(
-		ctx,
-		protocol.BlockCtx{
-			BlockHeight:    bcCtx.Tip.Height + 1,
-			BlockTimeStamp: bcCtx.Tip.Timestamp.Add(g.BlockInterval),
-			GasLimit:       g.BlockGasLimitByHeight(bcCtx.Tip.Height + 1),
-			Producer:       zeroAddr,
-			BaseFee:        protocol.CalcBaseFee(g.Blockchain, &bcCtx.Tip),
-			ExcessBlobGas:  protocol.CalcExcessBlobGas(bcCtx.Tip.ExcessBlobGas, bcCtx.Tip.BlobGasUsed),
-		},
-	))
+	blkCtx := protocol.BlockCtx{
+		BlockHeight:    bcCtx.Tip.Height + 1,
+		BlockTimeStamp: bcCtx.Tip.Timestamp.Add(g.BlockInterval),
+		GasLimit:       g.BlockGasLimitByHeight(bcCtx.Tip.Height + 1),
+		Producer:       zeroAddr,
+		BaseFee:        protocol.CalcBaseFee(g.Blockchain, &bcCtx.Tip),
+		ExcessBlobGas:  protocol.CalcExcessBlobGas(bcCtx.Tip.ExcessBlobGas, bcCtx.Tip.BlobGasUsed),
+	}
+	if cfg.BlockCtx != nil {
+		blkCtx = *cfg.BlockCtx
+	}
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, blkCtx))
 	return ExecuteContract(ctx, sm, ex)
 }
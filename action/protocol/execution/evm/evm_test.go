@@ -21,7 +21,9 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
 	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
@@ -70,6 +72,89 @@ func TestExecuteContractFailure(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestExecuteContractGasPayer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	sm := mock_chainmanager.NewMockStateManager(ctrl)
+	cb := batch.NewCachedBatch()
+	sm.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+			cfg, err := protocol.CreateStateConfig(opts...)
+			if err != nil {
+				return 0, err
+			}
+			val, err := cb.Get("state", cfg.Key)
+			if err != nil {
+				return 0, state.ErrStateNotExist
+			}
+			return 0, state.Deserialize(account, val)
+		}).AnyTimes()
+	sm.EXPECT().PutState(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+			cfg, err := protocol.CreateStateConfig(opts...)
+			if err != nil {
+				return 0, err
+			}
+			ss, err := state.Serialize(account)
+			if err != nil {
+				return 0, err
+			}
+			cb.Put("state", cfg.Key, ss, "failed to put state")
+			return 0, nil
+		}).AnyTimes()
+	sm.EXPECT().Snapshot().Return(1).AnyTimes()
+
+	var (
+		caller = identityset.Address(27)
+		payer  = identityset.Address(28)
+	)
+	acc, err := accountutil.LoadOrCreateAccount(sm, payer)
+	require.NoError(err)
+	require.NoError(acc.AddBalance(big.NewInt(1000000)))
+	require.NoError(accountutil.StoreAccount(sm, payer, acc))
+
+	e := action.NewExecution(identityset.Address(29).String(), big.NewInt(0), nil)
+	elp := (&action.EnvelopeBuilder{}).SetNonce(1).SetGasPrice(big.NewInt(10)).
+		SetGasLimit(50000).SetAction(e).Build()
+	ctx := protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+		Caller: caller,
+	})
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		Producer: identityset.Address(27),
+		GasLimit: 1000000,
+	})
+	ctx = genesis.WithGenesisContext(ctx, genesis.TestDefault())
+	ctx = protocol.WithBlockchainCtx(protocol.WithFeatureCtx(ctx), protocol.BlockchainCtx{
+		ChainID:      1,
+		EvmNetworkID: 100,
+	})
+	helperCtx := HelperContext{
+		GetBlockHash: func(uint64) (hash.Hash256, error) {
+			return hash.ZeroHash256, nil
+		},
+		GetBlockTime: func(uint64) (time.Time, error) {
+			return time.Time{}, nil
+		},
+		DepositGasFunc: func(context.Context, protocol.StateManager, *big.Int, ...protocol.DepositOption) ([]*action.TransactionLog, error) {
+			return nil, nil
+		},
+	}
+	// the caller does not have balance to pay the gas fee
+	_, _, err = ExecuteContract(WithHelperCtx(ctx, helperCtx), sm, elp)
+	require.ErrorIs(err, action.ErrInsufficientFunds)
+
+	// the gas fee is paid by the payer
+	helperCtx.GasPayer = payer
+	_, receipt, err := ExecuteContract(WithHelperCtx(ctx, helperCtx), sm, elp)
+	require.NoError(err)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	require.NotZero(receipt.GasConsumed)
+	acc, err = accountutil.LoadAccount(sm, caller)
+	require.NoError(err)
+	require.Zero(acc.Balance.Sign())
+}
+
 func TestConstantinople(t *testing.T) {
 	require := require.New(t)
 
@@ -462,7 +547,7 @@ func TestGasEstimate(t *testing.T) {
 func gasExecuteInEVM(gas, consume, refund, size uint64) (uint64, uint64, error) {
 	remainingGas := gas
 
-	intriGas, err := intrinsicGas(size, nil)
+	intriGas, err := intrinsicGas(action.GasTableV1, size, nil)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -86,6 +86,7 @@ type (
 		panicUnrecoverableError    bool
 		enableCancun               bool
 		fixRevertSnapshot          bool
+		precompileStubs            map[common.Address]struct{}
 	}
 )
 
@@ -191,6 +192,17 @@ func EnableCancunEVMOption() StateDBAdapterOption {
 	}
 }
 
+// PrecompileStubOption sets the addresses of the precompiles served outside of the vm, which exist with the stub code
+func PrecompileStubOption(addrs []common.Address) StateDBAdapterOption {
+	return func(adapter *StateDBAdapter) error {
+		adapter.precompileStubs = make(map[common.Address]struct{}, len(addrs))
+		for _, addr := range addrs {
+			adapter.precompileStubs[addr] = struct{}{}
+		}
+		return nil
+	}
+}
+
 // FixRevertSnapshotOption set fixRevertSnapshot as true
 func FixRevertSnapshotOption() StateDBAdapterOption {
 	return func(adapter *StateDBAdapter) error {
@@ -552,6 +564,9 @@ func (stateDB *StateDBAdapter) Exist(evmAddr common.Address) bool {
 	if _, ok := stateDB.cachedContract[evmAddr]; ok {
 		return true
 	}
+	if _, ok := stateDB.precompileStubs[evmAddr]; ok {
+		return true
+	}
 	recorded, err := accountutil.Recorded(stateDB.sm, addr)
 	if stateDB.assertError(err, "Account does not exist.", zap.Error(err), zap.String("address", evmAddr.Hex())) {
 		return false
@@ -964,6 +979,11 @@ func (stateDB *StateDBAdapter) GetCodeHash(evmAddr common.Address) common.Hash {
 		copy(codeHash[:], contract.SelfState().CodeHash)
 		return codeHash
 	}
+	if _, ok := stateDB.precompileStubs[evmAddr]; ok {
+		h := hash.Hash256b(_precompileStub)
+		copy(codeHash[:], h[:])
+		return codeHash
+	}
 	account, err := accountutil.LoadAccountByHash160(stateDB.sm, hash.BytesToHash160(evmAddr[:]), stateDB.accountCreationOpts()...)
 	if stateDB.assertError(err, "Failed to load account.", zap.Error(err), zap.String("address", evmAddr.Hex())) {
 		return codeHash
@@ -982,6 +1002,9 @@ func (stateDB *StateDBAdapter) GetCode(evmAddr common.Address) []byte {
 		}
 		return code
 	}
+	if _, ok := stateDB.precompileStubs[evmAddr]; ok {
+		return _precompileStub
+	}
 	account, err := accountutil.LoadAccountByHash160(stateDB.sm, hash.BytesToHash160(evmAddr[:]), stateDB.accountCreationOpts()...)
 	if stateDB.assertError(err, "Failed to load account.", zap.Error(err), zap.String("address", evmAddr.Hex())) {
 		return nil
@@ -13,6 +13,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/golang/mock/gomock"
 	"github.com/holiman/uint256"
 	"github.com/iotexproject/go-pkgs/hash"
@@ -157,6 +158,27 @@ func TestEmptyAndCode(t *testing.T) {
 	require.False(stateDB.Empty(addr))
 }
 
+func TestPrecompileStub(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	sm, err := initMockStateManager(ctrl)
+	require.NoError(err)
+	addr := common.BytesToAddress([]byte{0x01, 0x00})
+	stateDB, err := NewStateDBAdapter(sm, 1, hash.ZeroHash256)
+	require.NoError(err)
+	require.False(stateDB.Exist(addr))
+	require.Empty(stateDB.GetCode(addr))
+
+	stateDB, err = NewStateDBAdapter(sm, 1, hash.ZeroHash256, PrecompileStubOption([]common.Address{addr}))
+	require.NoError(err)
+	require.True(stateDB.Exist(addr))
+	require.Equal([]byte{byte(vm.INVALID)}, stateDB.GetCode(addr))
+	require.Equal(1, stateDB.GetCodeSize(addr))
+	codeHash := hash.Hash256b([]byte{byte(vm.INVALID)})
+	require.Equal(common.BytesToHash(codeHash[:]), stateDB.GetCodeHash(addr))
+}
+
 var kvs = map[common.Hash]common.Hash{
 	common.HexToHash("0123456701234567012345670123456701234567012345670123456701234560"): common.HexToHash("0123456701234567012345670123456701234567012345670123456701234560"),
 	common.HexToHash("0123456701234567012345670123456701234567012345670123456701234561"): common.HexToHash("0123456701234567012345670123456701234567012345670123456701234561"),
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/account/accountpb"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+)
+
+type (
+	// AccountOverride is the state of an account overridden in simulation, the nil fields are not overridden. State
+	// replaces the whole storage of the account, while StateDiff only replaces the given slots
+	AccountOverride struct {
+		Balance   *big.Int
+		Nonce     *uint64
+		Code      []byte
+		State     map[common.Hash]common.Hash
+		StateDiff map[common.Hash]common.Hash
+	}
+
+	// StateOverrides is the overridden states of accounts
+	StateOverrides map[common.Address]*AccountOverride
+)
+
+// Apply writes the overridden states into the state manager, the context should carry the block and feature context
+func (so StateOverrides) Apply(ctx context.Context, sm protocol.StateManager) error {
+	addrs := make([]common.Address, 0, len(so))
+	for addr, ov := range so {
+		if ov.State != nil && ov.StateDiff != nil {
+			return errors.Errorf("both state and state diff of account %x are overridden", addr)
+		}
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+
+	stateDB, err := prepareStateDB(protocol.WithActionCtx(ctx, protocol.ActionCtx{ReadOnly: true}), sm)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		ov := so[addr]
+		if ov.Code != nil {
+			stateDB.SetCode(addr, ov.Code)
+		}
+		if ov.State != nil {
+			var slots []common.Hash
+			if err := stateDB.ForEachStorage(addr, func(k, _ common.Hash) bool {
+				slots = append(slots, k)
+				return true
+			}); err != nil {
+				return errors.Wrapf(err, "failed to clear the storage of account %x", addr)
+			}
+			for _, k := range slots {
+				stateDB.SetState(addr, k, common.Hash{})
+			}
+		}
+		for _, storage := range []map[common.Hash]common.Hash{ov.State, ov.StateDiff} {
+			for k, v := range storage {
+				stateDB.SetState(addr, k, v)
+			}
+		}
+		if ov.Balance != nil {
+			if ov.Balance.Sign() < 0 {
+				return errors.Errorf("negative balance %s of account %x", ov.Balance, addr)
+			}
+			diff := new(big.Int).Sub(ov.Balance, stateDB.GetBalance(addr).ToBig())
+			if diff.Sign() > 0 {
+				stateDB.AddBalance(addr, uint256.MustFromBig(diff))
+			} else {
+				stateDB.SubBalance(addr, uint256.MustFromBig(diff.Neg(diff)))
+			}
+		}
+		if err := stateDB.Error(); err != nil {
+			return errors.Wrapf(err, "failed to override the state of account %x", addr)
+		}
+	}
+	if err := stateDB.CommitContracts(); err != nil {
+		return err
+	}
+	// the nonce is overridden at last, since the account could be written by the contract commit
+	for _, addr := range addrs {
+		if nonce := so[addr].Nonce; nonce != nil {
+			if err := overrideNonce(sm, addr, *nonce); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SimulateOption returns the option to apply the overrides in simulation
+func (so StateOverrides) SimulateOption() protocol.SimulateOption {
+	return protocol.WithSimulateStateOverride(so.Apply)
+}
+
+// overrideNonce sets the pending nonce of the account, which is converted to the zero-nonce type
+func overrideNonce(sm protocol.StateManager, evmAddr common.Address, nonce uint64) error {
+	addr, err := address.FromBytes(evmAddr.Bytes())
+	if err != nil {
+		return err
+	}
+	acct, err := accountutil.LoadAccountByHash160(sm, hash.BytesToHash160(evmAddr[:]))
+	if err != nil {
+		return errors.Wrapf(err, "failed to load account %x", evmAddr)
+	}
+	pb := acct.ToProto()
+	pb.Type = accountpb.AccountType_ZERO_NONCE
+	pb.Nonce = nonce
+	acct.FromProto(pb)
+	return accountutil.StoreAccount(sm, addr, acct)
+}
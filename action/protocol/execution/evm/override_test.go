@@ -0,0 +1,89 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+)
+
+func TestStateOverrides(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, err := initMockStateManager(ctrl)
+	require.NoError(err)
+	g := genesis.TestDefault()
+	ctx := protocol.WithFeatureCtx(protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), g),
+		protocol.BlockCtx{BlockHeight: g.MidwayBlockHeight},
+	))
+
+	var (
+		eoa      = common.HexToAddress("02ae2a956d21e8d481c3a69e146633470cf625ec")
+		contract = common.HexToAddress("0x1234567890123456789012345678901234567890")
+		code     = []byte{0x60, 0x01, 0x60, 0x00, 0x55}
+		k1, k2   = common.HexToHash("0x01"), common.HexToHash("0x02")
+		v1, v2   = common.HexToHash("0xaa"), common.HexToHash("0xbb")
+		nonce    = uint64(5)
+	)
+	require.NoError(StateOverrides{
+		eoa: {
+			Balance: big.NewInt(100),
+			Nonce:   &nonce,
+		},
+		contract: {
+			Balance:   big.NewInt(7),
+			Code:      code,
+			StateDiff: map[common.Hash]common.Hash{k1: v1, k2: v2},
+		},
+	}.Apply(ctx, sm))
+
+	stateDB, err := prepareStateDB(protocol.WithActionCtx(ctx, protocol.ActionCtx{}), sm)
+	require.NoError(err)
+	require.Equal(big.NewInt(100), stateDB.GetBalance(eoa).ToBig())
+	acct, err := accountutil.LoadAccountByHash160(sm, hash.BytesToHash160(eoa[:]))
+	require.NoError(err)
+	require.Equal(nonce, acct.PendingNonce())
+	require.Equal(big.NewInt(7), stateDB.GetBalance(contract).ToBig())
+	require.Equal(code, stateDB.GetCode(contract))
+	require.Equal(v1, stateDB.GetState(contract, k1))
+	require.Equal(v2, stateDB.GetState(contract, k2))
+
+	// state replaces the whole storage, and balance could be decreased
+	require.NoError(StateOverrides{
+		contract: {
+			Balance: big.NewInt(3),
+			State:   map[common.Hash]common.Hash{k2: v1},
+		},
+	}.Apply(ctx, sm))
+	stateDB, err = prepareStateDB(protocol.WithActionCtx(ctx, protocol.ActionCtx{}), sm)
+	require.NoError(err)
+	require.Equal(big.NewInt(3), stateDB.GetBalance(contract).ToBig())
+	require.Equal(code, stateDB.GetCode(contract))
+	require.Equal(common.Hash{}, stateDB.GetState(contract, k1))
+	require.Equal(v1, stateDB.GetState(contract, k2))
+
+	// invalid overrides
+	require.ErrorContains(StateOverrides{
+		contract: {
+			State:     map[common.Hash]common.Hash{},
+			StateDiff: map[common.Hash]common.Hash{},
+		},
+	}.Apply(ctx, sm), "both state and state diff")
+	require.ErrorContains(StateOverrides{
+		eoa: {Balance: big.NewInt(-1)},
+	}.Apply(ctx, sm), "negative balance")
+}
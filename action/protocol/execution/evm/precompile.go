@@ -0,0 +1,133 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// StatefulPrecompile is a precompiled contract served by a protocol, which has read-only access to the states.
+// A protocol registered by RegisterStatefulPrecompile is called when an execution or a read-only call is sent to
+// its address. The vm only dispatches its own stateless precompiles, so the address holds the INVALID opcode for
+// calls from within contracts, which fail instead of succeeding silently with empty output.
+type StatefulPrecompile interface {
+	// PrecompileAddress returns the address of the precompiled contract
+	PrecompileAddress() common.Address
+	// RequiredGas returns the gas required to run the input
+	RequiredGas(input []byte) uint64
+	// RunPrecompile runs the input and returns the abi encoded output
+	RunPrecompile(ctx context.Context, sr protocol.StateReader, input []byte) ([]byte, error)
+}
+
+var (
+	// _statefulPrecompiles maps the address of a stateful precompile to the ID of the protocol serving it
+	_statefulPrecompiles = map[common.Address]string{}
+
+	// _precompileStub is the code of a stateful precompile seen from within contracts. The vm does not dispatch the
+	// precompiles of the protocols, so a call from a contract hits the INVALID opcode and fails, rather than
+	// succeeding with empty output as if calling an account without code
+	_precompileStub = []byte{byte(vm.INVALID)}
+)
+
+// RegisterStatefulPrecompile registers the protocol of the ID to serve the precompiled contract at the address.
+// It should be called in init(), and the address should not collide with the other precompiles.
+func RegisterStatefulPrecompile(addr common.Address, protocolID string) error {
+	if isVMPrecompile(addr) {
+		return errors.Errorf("address %x of precompile %s is taken by the vm", addr, protocolID)
+	}
+	if id, ok := _statefulPrecompiles[addr]; ok {
+		return errors.Errorf("address %x of precompile %s is taken by %s", addr, protocolID, id)
+	}
+	_statefulPrecompiles[addr] = protocolID
+	return nil
+}
+
+// isVMPrecompile returns true if the address is a precompile of the vm in any fork
+func isVMPrecompile(addr common.Address) bool {
+	for _, contracts := range []map[common.Address]vm.PrecompiledContract{
+		vm.PrecompiledContractsHomestead,
+		vm.PrecompiledContractsByzantium,
+		vm.PrecompiledContractsIstanbul,
+		vm.PrecompiledContractsBerlin,
+		vm.PrecompiledContractsCancun,
+		vm.PrecompiledContractsBLS,
+	} {
+		if _, ok := contracts[addr]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// findStatefulPrecompile returns the precompiled contract at the address served by the registered protocol
+func findStatefulPrecompile(ctx context.Context, addr *common.Address) StatefulPrecompile {
+	if addr == nil {
+		return nil
+	}
+	id, ok := _statefulPrecompiles[*addr]
+	if !ok {
+		return nil
+	}
+	reg, ok := protocol.GetRegistry(ctx)
+	if !ok {
+		return nil
+	}
+	p, ok := reg.Find(id)
+	if !ok {
+		return nil
+	}
+	sp, ok := p.(StatefulPrecompile)
+	if !ok || sp.PrecompileAddress() != *addr {
+		return nil
+	}
+	return sp
+}
+
+// activeStatefulPrecompiles returns the addresses of the stateful precompiles served by the registered protocols
+func activeStatefulPrecompiles(ctx context.Context) []common.Address {
+	var addrs []common.Address
+	for addr := range _statefulPrecompiles {
+		if findStatefulPrecompile(ctx, &addr) != nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// runStatefulPrecompile runs the precompiled contract, the output is returned as it is from a contract call
+func runStatefulPrecompile(
+	ctx context.Context,
+	p StatefulPrecompile,
+	sr protocol.StateReader,
+	input []byte,
+	gas uint64,
+	amount *uint256.Int,
+) ([]byte, uint64, error) {
+	// the precompiled contract is read-only, and does not accept fund
+	if !amount.IsZero() {
+		return nil, gas, vm.ErrExecutionReverted
+	}
+	requiredGas := p.RequiredGas(input)
+	if gas < requiredGas {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	gas -= requiredGas
+	ret, err := p.RunPrecompile(ctx, sr, input)
+	if err != nil {
+		log.T(ctx).Debug("failed to run precompiled contract", zap.Error(err))
+		return nil, gas, vm.ErrExecutionReverted
+	}
+	return ret, gas, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+)
+
+type testPrecompile struct {
+	addr common.Address
+}
+
+func (tp *testPrecompile) Handle(context.Context, action.Envelope, protocol.StateManager) (*action.Receipt, error) {
+	return nil, nil
+}
+
+func (tp *testPrecompile) ReadState(context.Context, protocol.StateReader, []byte, ...[]byte) ([]byte, uint64, error) {
+	return nil, 0, nil
+}
+
+func (tp *testPrecompile) Register(r *protocol.Registry) error {
+	return r.Register(tp.Name(), tp)
+}
+
+func (tp *testPrecompile) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(tp.Name(), tp)
+}
+
+func (tp *testPrecompile) Name() string {
+	return "testPrecompile"
+}
+
+func (tp *testPrecompile) PrecompileAddress() common.Address {
+	return tp.addr
+}
+
+func (tp *testPrecompile) RequiredGas(input []byte) uint64 {
+	return 100
+}
+
+func (tp *testPrecompile) RunPrecompile(_ context.Context, _ protocol.StateReader, input []byte) ([]byte, error) {
+	if len(input) == 0 {
+		return nil, errors.New("empty input")
+	}
+	return input, nil
+}
+
+func TestStatefulPrecompile(t *testing.T) {
+	require := require.New(t)
+	addr := common.HexToAddress("0x0000000000000000000000000000000000001001")
+	other := common.HexToAddress("0x0000000000000000000000000000000000001002")
+	tp := &testPrecompile{addr: addr}
+
+	require.NoError(RegisterStatefulPrecompile(addr, tp.Name()))
+	defer delete(_statefulPrecompiles, addr)
+	require.ErrorContains(RegisterStatefulPrecompile(addr, "other"), "taken by testPrecompile")
+	require.ErrorContains(RegisterStatefulPrecompile(common.BytesToAddress([]byte{0x0a}), "other"), "taken by the vm")
+
+	// no registry in context
+	require.Nil(findStatefulPrecompile(context.Background(), &addr))
+	reg := protocol.NewRegistry()
+	ctx := protocol.WithRegistry(context.Background(), reg)
+	// protocol not registered
+	require.Nil(findStatefulPrecompile(ctx, &addr))
+	require.Empty(activeStatefulPrecompiles(ctx))
+	require.NoError(tp.Register(reg))
+	require.Nil(findStatefulPrecompile(ctx, nil))
+	require.Nil(findStatefulPrecompile(ctx, &other))
+	require.Equal(tp, findStatefulPrecompile(ctx, &addr))
+	require.Equal([]common.Address{addr}, activeStatefulPrecompiles(ctx))
+
+	ret, gas, err := runStatefulPrecompile(ctx, tp, nil, []byte{1}, 1000, uint256.NewInt(0))
+	require.NoError(err)
+	require.Equal([]byte{1}, ret)
+	require.EqualValues(900, gas)
+
+	// transfer is not accepted
+	_, gas, err = runStatefulPrecompile(ctx, tp, nil, []byte{1}, 1000, uint256.NewInt(1))
+	require.Equal(vm.ErrExecutionReverted, err)
+	require.EqualValues(1000, gas)
+
+	// out of gas
+	_, gas, err = runStatefulPrecompile(ctx, tp, nil, []byte{1}, 10, uint256.NewInt(0))
+	require.Equal(vm.ErrOutOfGas, err)
+	require.Zero(gas)
+
+	// failed to run
+	_, gas, err = runStatefulPrecompile(ctx, tp, nil, nil, 1000, uint256.NewInt(0))
+	require.Equal(vm.ErrExecutionReverted, err)
+	require.EqualValues(900, gas)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+type (
+	// StateEntry is a raw state stored in a namespace
+	StateEntry struct {
+		Namespace string
+		Key       []byte
+		Value     []byte
+	}
+
+	// StateExporter dumps all the states of a protocol in a deterministic order, the entries are passed to the
+	// callback one at a time
+	StateExporter interface {
+		Namespaces() []string
+		ExportStates(context.Context, StateReader, func(*StateEntry) error) error
+	}
+
+	// StateImporter restores the states of a protocol dumped by StateExporter
+	StateImporter interface {
+		ImportStates(context.Context, StateManager, []*StateEntry) error
+	}
+)
+
+// ExportNamespaces reads all the states in the namespaces, and calls fn with each entry sorted by namespace and key.
+// The states of one namespace are held in memory at a time, as they are read by the states iterator
+func ExportNamespaces(sr StateReader, fn func(*StateEntry) error, namespaces ...string) error {
+	for _, ns := range namespaces {
+		_, iter, err := sr.States(NamespaceOption(ns))
+		switch errors.Cause(err) {
+		case nil:
+		case state.ErrStateNotExist:
+			continue
+		default:
+			return errors.Wrapf(err, "failed to read states in namespace %s", ns)
+		}
+		nsEntries := make([]*StateEntry, 0, iter.Size())
+		for i := 0; i < iter.Size(); i++ {
+			var value SerializableBytes
+			key, err := iter.Next(&value)
+			switch errors.Cause(err) {
+			case nil:
+			case state.ErrNilValue:
+				continue
+			default:
+				return errors.Wrapf(err, "failed to read state in namespace %s", ns)
+			}
+			nsEntries = append(nsEntries, &StateEntry{
+				Namespace: ns,
+				Key:       key,
+				Value:     value,
+			})
+		}
+		sort.Slice(nsEntries, func(i, j int) bool {
+			return bytes.Compare(nsEntries[i].Key, nsEntries[j].Key) < 0
+		})
+		for _, e := range nsEntries {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ImportNamespaces writes the entries into state manager, entries outside of the namespaces are rejected
+func ImportNamespaces(sm StateManager, entries []*StateEntry, namespaces ...string) error {
+	allowed := make(map[string]struct{}, len(namespaces))
+	for _, ns := range namespaces {
+		allowed[ns] = struct{}{}
+	}
+	for _, e := range entries {
+		if _, ok := allowed[e.Namespace]; !ok {
+			return errors.Errorf("namespace %s is not allowed", e.Namespace)
+		}
+		if _, err := sm.PutState(SerializableBytes(e.Value), NamespaceOption(e.Namespace), KeyOption(e.Key)); err != nil {
+			return errors.Wrapf(err, "failed to import state %x in namespace %s", e.Key, e.Namespace)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// gasTable returns the intrinsic gas table activated at the height, which is the gas table since genesis upgraded by
+// the versions in genesis activated at or below the height
+func gasTable(g genesis.Blockchain, height uint64) *action.GasTable {
+	table, err := gasTableAt(g, height)
+	if err != nil {
+		log.L().Panic("Invalid gas table upgrades in genesis.", zap.Error(err))
+	}
+	return table
+}
+
+// ValidateGasTableUpgrades checks that the gas table upgrades in genesis are in ascending order of heights, and only
+// override the intrinsic gas of known action types
+func ValidateGasTableUpgrades(g genesis.Blockchain) error {
+	_, err := gasTableAt(g, math.MaxUint64)
+	return err
+}
+
+func gasTableAt(g genesis.Blockchain, height uint64) (*action.GasTable, error) {
+	table := action.GasTableV1
+	for i, upgrade := range g.GasTableUpgrades {
+		if i > 0 && upgrade.Height <= g.GasTableUpgrades[i-1].Height {
+			return nil, errors.Errorf("gas table upgrade at height %d is not above the previous one", upgrade.Height)
+		}
+		if upgrade.Height > height {
+			break
+		}
+		overrides := make(map[string]action.IntrinsicGasParams, len(upgrade.IntrinsicGas))
+		for name, gas := range upgrade.IntrinsicGas {
+			overrides[name] = action.IntrinsicGasParams{Base: gas.Base, PerByte: gas.PerByte}
+		}
+		next, err := table.Upgrade(overrides)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid gas table upgrade at height %d", upgrade.Height)
+		}
+		table = next
+	}
+	return table, nil
+}
+
+// IntrinsicGas returns the intrinsic gas of the envelope by the gas table of the feature context, or by the gas table
+// since genesis if there is no feature context
+func IntrinsicGas(ctx context.Context, elp action.Envelope) (uint64, error) {
+	if fCtx, ok := GetFeatureCtx(ctx); ok && fCtx.GasTable != nil {
+		return fCtx.GasTable.IntrinsicGas(elp)
+	}
+	return elp.IntrinsicGas()
+}
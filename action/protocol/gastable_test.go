@@ -0,0 +1,56 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestIntrinsicGas(t *testing.T) {
+	require := require.New(t)
+	g := genesis.TestDefault()
+	g.GasTableUpgrades = []genesis.GasTableUpgrade{
+		{Height: 10, IntrinsicGas: map[string]genesis.IntrinsicGas{"transfer": {Base: 21000}}},
+		{Height: 20, IntrinsicGas: map[string]genesis.IntrinsicGas{"transfer": {Base: 30000, PerByte: 10}}},
+	}
+	require.NoError(ValidateGasTableUpgrades(g.Blockchain))
+	elp := action.NewEnvelope(action.NewLegacyTx(1, 0, 0, big.NewInt(0)),
+		action.NewTransfer(big.NewInt(1), identityset.Address(1).String(), []byte("payload")))
+	for _, c := range []struct {
+		height  uint64
+		version uint32
+		gas     uint64
+	}{
+		{9, 1, action.TransferBaseIntrinsicGas + 7*action.TransferPayloadGas},
+		{10, 2, 21000},
+		{19, 2, 21000},
+		{20, 3, 30070},
+	} {
+		ctx := WithFeatureCtx(WithBlockCtx(genesis.WithGenesisContext(context.Background(), g), BlockCtx{BlockHeight: c.height}))
+		require.Equal(c.version, MustGetFeatureCtx(ctx).GasTable.Version)
+		gas, err := IntrinsicGas(ctx, elp)
+		require.NoError(err)
+		require.Equal(c.gas, gas)
+	}
+	// the gas table since genesis is used without feature context
+	gas, err := IntrinsicGas(context.Background(), elp)
+	require.NoError(err)
+	require.Equal(action.TransferBaseIntrinsicGas+7*action.TransferPayloadGas, gas)
+
+	g.GasTableUpgrades[1].Height = 10
+	require.ErrorContains(ValidateGasTableUpgrades(g.Blockchain), "not above the previous one")
+	g.GasTableUpgrades[1].Height = 20
+	g.GasTableUpgrades[1].IntrinsicGas["transfers"] = genesis.IntrinsicGas{}
+	require.ErrorContains(ValidateGasTableUpgrades(g.Blockchain), "unknown action type transfers")
+}
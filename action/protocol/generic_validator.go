@@ -43,7 +43,7 @@ func NewGenericValidator(sr StateReader, accountState AccountState) *GenericVali
 
 // Validate validates a generic action
 func (v *GenericValidator) Validate(ctx context.Context, selp *action.SealedEnvelope) error {
-	intrinsicGas, err := selp.IntrinsicGas()
+	intrinsicGas, err := IntrinsicGas(ctx, selp.Envelope)
 	if err != nil {
 		return err
 	}
@@ -51,8 +51,10 @@ func (v *GenericValidator) Validate(ctx context.Context, selp *action.SealedEnve
 		return action.ErrIntrinsicGas
 	}
 	// Verify action using action sender's public key
-	if err := selp.VerifySignature(); err != nil {
-		return err
+	if bcCtx, ok := GetBlockCtx(ctx); !ok || !bcCtx.SkipSignatureValidation {
+		if err := selp.VerifySignature(); err != nil {
+			return err
+		}
 	}
 	caller := selp.SenderAddress()
 	if caller == nil {
@@ -161,4 +161,11 @@ func TestActionProtoAndGenericValidator(t *testing.T) {
 		err = valid.Validate(ctx, selp)
 		require.Contains(err.Error(), action.ErrInvalidSender.Error())
 	})
+	t.Run("skip signature", func(t *testing.T) {
+		elp := (&action.EnvelopeBuilder{}).SetGasPrice(big.NewInt(10)).SetNonce(3).
+			SetGasLimit(100000).SetAction(action.NewTransfer(big.NewInt(1), caller.String(), []byte{})).Build()
+		selp := action.FakeSeal(elp, identityset.PrivateKey(28).PublicKey())
+		require.ErrorContains(valid.Validate(WithBlockCtx(ctx, BlockCtx{}), selp), action.ErrInvalidSender.Error())
+		require.NoError(valid.Validate(WithBlockCtx(ctx, BlockCtx{SkipSignatureValidation: true}), selp))
+	})
 }
@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/iotexproject/go-pkgs/hash"
@@ -73,6 +74,11 @@ type (
 		ReadView(string) (interface{}, error)
 	}
 
+	// StateRootReader is implemented by the StateReader which commits to all the states with a root hash
+	StateRootReader interface {
+		StateRoot() (hash.Hash256, error)
+	}
+
 	// StateManager defines the stateDB interface atop IoTeX blockchain
 	StateManager interface {
 		StateReader
@@ -102,6 +108,9 @@ type (
 		PreOpt     func(StateManager) error
 		Nonce, Gas uint64
 		GasPrice   *big.Int
+		BlockCtx   *BlockCtx
+		// StateOverride overrides the states before the caller of the simulation is loaded
+		StateOverride func(context.Context, StateManager) error
 	}
 )
 
@@ -110,3 +119,17 @@ func WithSimulatePreOpt(fn func(StateManager) error) SimulateOption {
 		so.PreOpt = fn
 	}
 }
+
+// WithSimulateStateOverride overrides the states of the working set before the simulation
+func WithSimulateStateOverride(fn func(context.Context, StateManager) error) SimulateOption {
+	return func(so *SimulateOptionConfig) {
+		so.StateOverride = fn
+	}
+}
+
+// WithSimulateBlockCtx simulates in the given block context, instead of the block next to the tip
+func WithSimulateBlockCtx(blkCtx BlockCtx) SimulateOption {
+	return func(so *SimulateOptionConfig) {
+		so.BlockCtx = &blkCtx
+	}
+}
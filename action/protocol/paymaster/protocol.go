@@ -0,0 +1,303 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package paymaster
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const (
+	_protocolID = "paymaster"
+	// _sponsorshipNamespace stores the height at which a sponsorship is used, keyed by the sponsorship hash
+	_sponsorshipNamespace = "Sponsorship"
+)
+
+var (
+	// _gasSponsoredTopic is the topic of the log emitted when the gas fee of a call is paid by the sponsor
+	_gasSponsoredTopic = hash.BytesToHash256(crypto.Keccak256([]byte("GasSponsored(address,address,uint256)")))
+
+	// ErrSponsorshipExpired indicates the sponsorship has expired
+	ErrSponsorshipExpired = errors.New("sponsorship expired")
+	// ErrSponsorshipUsed indicates the sponsorship has been used
+	ErrSponsorshipUsed = errors.New("sponsorship has been used")
+	// ErrExceedMaxGasFee indicates the gas fee of the call may exceed the max gas fee of the sponsorship
+	ErrExceedMaxGasFee = errors.New("gas fee exceeds max gas fee of sponsorship")
+)
+
+type (
+	// Protocol defines the protocol of sponsored calls. A sponsored call is an execution sent to the paymaster
+	// address, which carries the contract call and a sponsorship signed by a third account. The call is run as if
+	// the execution is sent to the contract by the caller, while the gas fee is paid by the sponsor.
+	Protocol struct {
+		addr         address.Address
+		getBlockHash evm.GetBlockHash
+		getBlockTime evm.GetBlockTime
+		depositGas   protocol.DepositGas
+	}
+
+	// sponsoredTx is the execution with the call unwrapped from the sponsored call
+	sponsoredTx struct {
+		action.TxData
+		to   *common.Address
+		data []byte
+	}
+
+	// actPoolValidator validates the sponsored calls in the actpool
+	actPoolValidator struct {
+		p       *Protocol
+		sr      protocol.StateReader
+		chainID uint32
+	}
+)
+
+var _paymasterAddr address.Address
+
+func init() {
+	h := hash.Hash160b([]byte(_protocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of paymaster protocol", zap.Error(err))
+	}
+	_paymasterAddr = addr
+}
+
+// NewProtocol instantiates the protocol of paymaster
+func NewProtocol(getBlockHash evm.GetBlockHash, depositGas protocol.DepositGas, getBlockTime evm.GetBlockTime) *Protocol {
+	return &Protocol{
+		addr:         _paymasterAddr,
+		getBlockHash: getBlockHash,
+		getBlockTime: getBlockTime,
+		depositGas:   depositGas,
+	}
+}
+
+// FindProtocol finds the registered protocol from registry
+func FindProtocol(registry *protocol.Registry) *Protocol {
+	if registry == nil {
+		return nil
+	}
+	p, ok := registry.Find(_protocolID)
+	if !ok {
+		return nil
+	}
+	pp, ok := p.(*Protocol)
+	if !ok {
+		log.S().Panic("fail to cast paymaster protocol")
+	}
+	return pp
+}
+
+// IsSponsoredCall returns true if the action is a sponsored call sent to the paymaster, of which the gas fee is
+// paid by the sponsor instead of the sender
+func IsSponsoredCall(elp action.Envelope) bool {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != _paymasterAddr.String() {
+		return false
+	}
+	_, err := DecodeSponsoredCall(exec.Data())
+	return err == nil
+}
+
+// Address returns the address to send sponsored calls to
+func (p *Protocol) Address() address.Address {
+	return p.addr
+}
+
+// Handle handles a sponsored call
+func (p *Protocol) Handle(ctx context.Context, elp action.Envelope, sm protocol.StateManager) (*action.Receipt, error) {
+	call, err := p.sponsoredCall(ctx, elp)
+	if call == nil || err != nil {
+		return nil, err
+	}
+	sponsor, key, err := p.fetchSponsor(ctx, sm, elp, call)
+	if err != nil {
+		return nil, err
+	}
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	if _, err := sm.PutState(
+		protocol.SerializableBytes(byteutil.Uint64ToBytesBigEndian(blkCtx.BlockHeight)),
+		protocol.NamespaceOption(_sponsorshipNamespace),
+		protocol.KeyOption(key[:]),
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to mark sponsorship used")
+	}
+	ctx = evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash:   p.getBlockHash,
+		GetBlockTime:   p.getBlockTime,
+		DepositGasFunc: p.depositGasBy(sponsor),
+		GasPayer:       sponsor,
+	})
+	_, receipt, err := evm.ExecuteContract(ctx, sm, &sponsoredTx{TxData: elp, to: &call.To, data: call.Data})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute sponsored call")
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasConsumed), receipt.EffectiveGasPrice)
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	receipt.AddLogs(&action.Log{
+		Address: p.addr.String(),
+		Topics: []hash.Hash256{
+			_gasSponsoredTopic,
+			hash.BytesToHash256(sponsor.Bytes()),
+			hash.BytesToHash256(actionCtx.Caller.Bytes()),
+		},
+		Data:        fee.Bytes(),
+		BlockHeight: blkCtx.BlockHeight,
+		ActionHash:  actionCtx.ActionHash,
+	})
+	return receipt, nil
+}
+
+// Validate validates a sponsored call
+func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, sr protocol.StateReader) error {
+	call, err := p.sponsoredCall(ctx, elp)
+	if call == nil || err != nil {
+		return err
+	}
+	if call.To == (common.Address{}) {
+		return errors.Wrap(ErrInvalidSponsorship, "cannot sponsor contract creation")
+	}
+	if len(elp.BlobHashes()) > 0 {
+		return errors.Wrap(ErrInvalidSponsorship, "cannot sponsor blob tx")
+	}
+	_, _, err = p.fetchSponsor(ctx, sr, elp, call)
+	return err
+}
+
+// ActPoolValidator returns the validator of the sponsored calls in the actpool, which verifies the sponsorship and the
+// balance of the sponsor, as the actpool only charges the sender the value of a sponsored call
+func (p *Protocol) ActPoolValidator(sr protocol.StateReader, chainID uint32) action.SealedEnvelopeValidator {
+	return &actPoolValidator{
+		p:       p,
+		sr:      sr,
+		chainID: chainID,
+	}
+}
+
+// Validate validates a sponsored call in the actpool
+func (v *actPoolValidator) Validate(ctx context.Context, selp *action.SealedEnvelope) error {
+	if !IsSponsoredCall(selp.Envelope) {
+		return nil
+	}
+	if !protocol.MustGetFeatureCtx(ctx).EnablePaymaster {
+		return errors.Wrap(ErrInvalidSponsorship, "paymaster is not enabled")
+	}
+	ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{ChainID: v.chainID})
+	ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: selp.SenderAddress()})
+	return v.p.Validate(ctx, selp.Envelope, v.sr)
+}
+
+// sponsoredCall returns the sponsored call if the action is sent to the paymaster, nil otherwise
+func (p *Protocol) sponsoredCall(ctx context.Context, elp action.Envelope) (*SponsoredCall, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnablePaymaster {
+		return nil, nil
+	}
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != p.addr.String() {
+		return nil, nil
+	}
+	return DecodeSponsoredCall(exec.Data())
+}
+
+// fetchSponsor verifies the sponsorship, and returns the sponsor and the sponsorship hash
+func (p *Protocol) fetchSponsor(
+	ctx context.Context,
+	sr protocol.StateReader,
+	elp action.TxData,
+	call *SponsoredCall,
+) (address.Address, hash.Hash256, error) {
+	var (
+		s         = call.Sponsorship
+		actionCtx = protocol.MustGetActionCtx(ctx)
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		bcCtx     = protocol.MustGetBlockchainCtx(ctx)
+	)
+	if s.ExpireHeight < blkCtx.BlockHeight {
+		return nil, hash.ZeroHash256, errors.Wrapf(ErrSponsorshipExpired, "expire height %d, current height %d", s.ExpireHeight, blkCtx.BlockHeight)
+	}
+	key, err := s.Hash(bcCtx.ChainID, p.addr, actionCtx.Caller, call.To, call.Data, elp.Value())
+	if err != nil {
+		return nil, hash.ZeroHash256, err
+	}
+	sponsor, err := s.Sponsor(key)
+	if err != nil {
+		return nil, hash.ZeroHash256, err
+	}
+	var usedAt protocol.SerializableBytes
+	_, err = sr.State(&usedAt, protocol.NamespaceOption(_sponsorshipNamespace), protocol.KeyOption(key[:]))
+	switch errors.Cause(err) {
+	case nil:
+		return nil, hash.ZeroHash256, errors.Wrapf(ErrSponsorshipUsed, "sponsorship %x", key)
+	case state.ErrStateNotExist:
+	default:
+		return nil, hash.ZeroHash256, err
+	}
+	// the gas fee cannot exceed gas limit * fee cap
+	maxFee := new(big.Int).Mul(new(big.Int).SetUint64(elp.Gas()), elp.GasFeeCap())
+	if s.MaxGasFee.Cmp(maxFee) < 0 {
+		return nil, hash.ZeroHash256, errors.Wrapf(ErrExceedMaxGasFee, "max gas fee %s, required %s", s.MaxGasFee, maxFee)
+	}
+	acc, err := accountutil.AccountState(ctx, sr, sponsor)
+	if err != nil {
+		return nil, hash.ZeroHash256, errors.Wrapf(err, "failed to load the account of sponsor %s", sponsor.String())
+	}
+	if !acc.HasSufficientBalance(maxFee) {
+		return nil, hash.ZeroHash256, errors.Wrapf(state.ErrNotEnoughBalance, "sponsor %s balance not enough", sponsor.String())
+	}
+	return sponsor, key, nil
+}
+
+// depositGasBy returns the gas deposit function which charges the sponsor instead of the caller
+func (p *Protocol) depositGasBy(sponsor address.Address) protocol.DepositGas {
+	return func(ctx context.Context, sm protocol.StateManager, amount *big.Int, opts ...protocol.DepositOption) ([]*action.TransactionLog, error) {
+		actionCtx := protocol.MustGetActionCtx(ctx)
+		actionCtx.Caller = sponsor
+		return p.depositGas(protocol.WithActionCtx(ctx, actionCtx), sm, amount, opts...)
+	}
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(context.Context, protocol.StateReader, []byte, ...[]byte) ([]byte, uint64, error) {
+	return nil, uint64(0), protocol.ErrUnimplemented
+}
+
+// Register registers the protocol with a unique ID
+func (p *Protocol) Register(r *protocol.Registry) error {
+	return r.Register(_protocolID, p)
+}
+
+// ForceRegister registers the protocol with a unique ID and force replacing the previous protocol if it exists
+func (p *Protocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(_protocolID, p)
+}
+
+// Name returns the name of protocol
+func (p *Protocol) Name() string {
+	return _protocolID
+}
+
+func (tx *sponsoredTx) To() *common.Address {
+	return tx.to
+}
+
+func (tx *sponsoredTx) Data() []byte {
+	return tx.data
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package paymaster
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestProtocol_Validate(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	p := NewProtocol(nil, nil, nil)
+	require.Equal(_protocolID, p.Name())
+
+	g := genesis.TestDefault()
+	g.ToBeEnabledBlockHeight = 1
+	var (
+		caller  = identityset.Address(1)
+		sponsor = identityset.Address(3)
+		to      = common.BytesToAddress(identityset.Address(2).Bytes())
+		height  = uint64(10)
+	)
+	ctx := genesis.WithGenesisContext(context.Background(), g)
+	ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{ChainID: 1})
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height})
+	ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: caller})
+	ctx = protocol.WithFeatureCtx(ctx)
+
+	newElp := func(contract string, s *Sponsorship) action.Envelope {
+		data, err := EncodeSponsoredCall(&SponsoredCall{To: to, Data: []byte{1}, Sponsorship: s})
+		require.NoError(err)
+		return (&action.EnvelopeBuilder{}).SetNonce(1).SetGasLimit(10000).SetGasPrice(big.NewInt(1)).
+			SetAction(action.NewExecution(contract, big.NewInt(0), data)).Build()
+	}
+	newSponsorship := func(expireHeight uint64, maxGasFee int64) *Sponsorship {
+		s := &Sponsorship{ExpireHeight: expireHeight, MaxGasFee: big.NewInt(maxGasFee)}
+		require.NoError(s.Sign(identityset.PrivateKey(3), 1, p.Address(), caller, to, []byte{1}, big.NewInt(0)))
+		return s
+	}
+
+	// execution not sent to paymaster is skipped
+	require.NoError(p.Validate(ctx, newElp(identityset.Address(5).String(), &Sponsorship{MaxGasFee: big.NewInt(0)}), sm))
+	// paymaster is not enabled
+	s := newSponsorship(height, 10000)
+	elp := newElp(p.Address().String(), s)
+	disabled := g
+	disabled.ToBeEnabledBlockHeight = height + 1
+	require.NoError(p.Validate(protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, disabled)), elp, sm))
+
+	// sponsor does not have enough balance
+	require.Equal(state.ErrNotEnoughBalance, errors.Cause(p.Validate(ctx, elp, sm)))
+	acc, err := accountutil.LoadOrCreateAccount(sm, sponsor)
+	require.NoError(err)
+	require.NoError(acc.AddBalance(big.NewInt(10000)))
+	require.NoError(accountutil.StoreAccount(sm, sponsor, acc))
+	require.NoError(p.Validate(ctx, elp, sm))
+
+	// expired
+	require.Equal(ErrSponsorshipExpired, errors.Cause(p.Validate(ctx, newElp(p.Address().String(), newSponsorship(height-1, 10000)), sm)))
+	// max gas fee not enough
+	require.Equal(ErrExceedMaxGasFee, errors.Cause(p.Validate(ctx, newElp(p.Address().String(), newSponsorship(height, 9999)), sm)))
+	// the call data is changed, so the sponsor recovered does not have balance
+	changed, err := EncodeSponsoredCall(&SponsoredCall{To: to, Data: []byte{2}, Sponsorship: s})
+	require.NoError(err)
+	require.Equal(state.ErrNotEnoughBalance, errors.Cause(p.Validate(ctx, (&action.EnvelopeBuilder{}).SetNonce(1).
+		SetGasLimit(10000).SetGasPrice(big.NewInt(1)).SetAction(action.NewExecution(p.Address().String(), big.NewInt(0), changed)).Build(), sm)))
+	// signed by another beneficiary, so the sponsor recovered does not have balance
+	s2 := &Sponsorship{ExpireHeight: height, MaxGasFee: big.NewInt(10000)}
+	require.NoError(s2.Sign(identityset.PrivateKey(3), 1, p.Address(), identityset.Address(4), to, []byte{1}, big.NewInt(0)))
+	require.Equal(state.ErrNotEnoughBalance, errors.Cause(p.Validate(ctx, newElp(p.Address().String(), s2), sm)))
+	// used
+	key, err := s.Hash(1, p.Address(), caller, to, []byte{1}, big.NewInt(0))
+	require.NoError(err)
+	_, err = sm.PutState(
+		protocol.SerializableBytes(byteutil.Uint64ToBytesBigEndian(height)),
+		protocol.NamespaceOption(_sponsorshipNamespace),
+		protocol.KeyOption(key[:]),
+	)
+	require.NoError(err)
+	require.Equal(ErrSponsorshipUsed, errors.Cause(p.Validate(ctx, elp, sm)))
+	// malformed call
+	bad := (&action.EnvelopeBuilder{}).SetNonce(1).SetGasLimit(10000).SetGasPrice(big.NewInt(1)).
+		SetAction(action.NewExecution(p.Address().String(), big.NewInt(0), []byte{1, 2, 3, 4})).Build()
+	require.Equal(ErrInvalidSponsorship, errors.Cause(p.Validate(ctx, bad, sm)))
+}
+
+func TestActPoolValidator(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	p := NewProtocol(nil, nil, nil)
+	v := p.ActPoolValidator(sm, 1)
+
+	g := genesis.TestDefault()
+	g.ToBeEnabledBlockHeight = 1
+	var (
+		caller  = identityset.Address(1)
+		sponsor = identityset.Address(3)
+		to      = common.BytesToAddress(identityset.Address(2).Bytes())
+		height  = uint64(10)
+	)
+	ctx := genesis.WithGenesisContext(context.Background(), g)
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height}))
+
+	s := &Sponsorship{ExpireHeight: height, MaxGasFee: big.NewInt(10000)}
+	require.NoError(s.Sign(identityset.PrivateKey(3), 1, p.Address(), caller, to, []byte{1}, big.NewInt(0)))
+	data, err := EncodeSponsoredCall(&SponsoredCall{To: to, Data: []byte{1}, Sponsorship: s})
+	require.NoError(err)
+	// the caller does not have any balance
+	selp, err := action.SignedExecution(p.Address().String(), identityset.PrivateKey(1), 1, big.NewInt(0), 10000, big.NewInt(1), data)
+	require.NoError(err)
+	require.True(IsSponsoredCall(selp.Envelope))
+	transfer, err := action.SignedTransfer(p.Address().String(), identityset.PrivateKey(1), 1, big.NewInt(0), nil, 10000, big.NewInt(1))
+	require.NoError(err)
+	require.False(IsSponsoredCall(transfer.Envelope))
+
+	// sponsor does not have enough balance
+	require.Equal(state.ErrNotEnoughBalance, errors.Cause(v.Validate(ctx, selp)))
+	acc, err := accountutil.LoadOrCreateAccount(sm, sponsor)
+	require.NoError(err)
+	require.NoError(acc.AddBalance(big.NewInt(10000)))
+	require.NoError(accountutil.StoreAccount(sm, sponsor, acc))
+	require.NoError(v.Validate(ctx, selp))
+	// paymaster is not enabled
+	disabled := g
+	disabled.ToBeEnabledBlockHeight = height + 1
+	require.Equal(ErrInvalidSponsorship, errors.Cause(v.Validate(protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, disabled)), selp)))
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package paymaster
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+const _sponsoredCallJSONABI = `[
+	{
+		"inputs": [
+			{"internalType": "address", "name": "to", "type": "address"},
+			{"internalType": "bytes", "name": "data", "type": "bytes"},
+			{"internalType": "uint64", "name": "expireHeight", "type": "uint64"},
+			{"internalType": "uint256", "name": "maxGasFee", "type": "uint256"},
+			{"internalType": "uint64", "name": "salt", "type": "uint64"},
+			{"internalType": "bytes", "name": "signature", "type": "bytes"}
+		],
+		"name": "sponsoredCall",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	}
+]`
+
+var (
+	_sponsoredCallMethod abi.Method
+	_sponsorshipArgs     abi.Arguments
+
+	// ErrInvalidSponsorship indicates the sponsorship is malformed or not signed properly
+	ErrInvalidSponsorship = errors.New("invalid sponsorship")
+)
+
+type (
+	// Sponsorship is signed by the sponsor to cover the gas fee of a call made by the beneficiary
+	Sponsorship struct {
+		// ExpireHeight is the last height the sponsorship can be used
+		ExpireHeight uint64
+		// MaxGasFee is the max gas fee the sponsor pays
+		MaxGasFee *big.Int
+		// Salt differentiates the sponsorships of the same call
+		Salt      uint64
+		Signature []byte
+	}
+
+	// SponsoredCall is a contract call whose gas fee is paid by the sponsor
+	SponsoredCall struct {
+		To          common.Address
+		Data        []byte
+		Sponsorship *Sponsorship
+	}
+)
+
+func init() {
+	contractABI, err := abi.JSON(strings.NewReader(_sponsoredCallJSONABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	_sponsoredCallMethod, ok = contractABI.Methods["sponsoredCall"]
+	if !ok {
+		panic("fail to load the method")
+	}
+	uint32Type, _ := abi.NewType("uint32", "", nil)
+	addrType, _ := abi.NewType("address", "", nil)
+	uint64Type, _ := abi.NewType("uint64", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	_sponsorshipArgs = abi.Arguments{
+		{Name: "chainID", Type: uint32Type},
+		{Name: "paymaster", Type: addrType},
+		{Name: "beneficiary", Type: addrType},
+		{Name: "to", Type: addrType},
+		{Name: "dataHash", Type: bytes32Type},
+		{Name: "value", Type: uint256Type},
+		{Name: "expireHeight", Type: uint64Type},
+		{Name: "maxGasFee", Type: uint256Type},
+		{Name: "salt", Type: uint64Type},
+	}
+}
+
+// Hash returns the hash signed by the sponsor, which binds the sponsorship to the chain, the beneficiary and the
+// call, i.e., the contract to call, the keccak256 of the call data and the value sent
+func (s *Sponsorship) Hash(chainID uint32, paymaster, beneficiary address.Address, to common.Address, data []byte, value *big.Int) (hash.Hash256, error) {
+	if s.MaxGasFee == nil || s.MaxGasFee.Sign() < 0 {
+		return hash.ZeroHash256, errors.Wrap(ErrInvalidSponsorship, "invalid max gas fee")
+	}
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	if value.Sign() < 0 {
+		return hash.ZeroHash256, errors.Wrap(ErrInvalidSponsorship, "invalid value")
+	}
+	packed, err := _sponsorshipArgs.Pack(
+		chainID,
+		common.BytesToAddress(paymaster.Bytes()),
+		common.BytesToAddress(beneficiary.Bytes()),
+		to,
+		[32]byte(hash.Hash256b(data)),
+		value,
+		s.ExpireHeight,
+		s.MaxGasFee,
+		s.Salt,
+	)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.Hash256b(packed), nil
+}
+
+// Sign signs the sponsorship with the private key of the sponsor
+func (s *Sponsorship) Sign(sk crypto.PrivateKey, chainID uint32, paymaster, beneficiary address.Address, to common.Address, data []byte, value *big.Int) error {
+	h, err := s.Hash(chainID, paymaster, beneficiary, to, data, value)
+	if err != nil {
+		return err
+	}
+	sig, err := sk.Sign(h[:])
+	if err != nil {
+		return errors.Wrap(err, "failed to sign sponsorship")
+	}
+	s.Signature = sig
+	return nil
+}
+
+// Sponsor recovers the address of the sponsor from the signature over the hash
+func (s *Sponsorship) Sponsor(h hash.Hash256) (address.Address, error) {
+	pk, err := crypto.RecoverPubkey(h[:], s.Signature)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidSponsorship, err.Error())
+	}
+	return pk.Address(), nil
+}
+
+// EncodeSponsoredCall encodes the sponsored call into the data of an execution sent to the paymaster
+func EncodeSponsoredCall(call *SponsoredCall) ([]byte, error) {
+	s := call.Sponsorship
+	if s == nil || s.MaxGasFee == nil {
+		return nil, errors.Wrap(ErrInvalidSponsorship, "sponsorship is missing")
+	}
+	data, err := _sponsoredCallMethod.Inputs.Pack(call.To, call.Data, s.ExpireHeight, s.MaxGasFee, s.Salt, s.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return append(_sponsoredCallMethod.ID, data...), nil
+}
+
+// DecodeSponsoredCall decodes the sponsored call from the data of an execution sent to the paymaster
+func DecodeSponsoredCall(data []byte) (*SponsoredCall, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], _sponsoredCallMethod.ID) {
+		return nil, errors.Wrap(ErrInvalidSponsorship, "invalid method")
+	}
+	paramsMap := map[string]interface{}{}
+	if err := _sponsoredCallMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, errors.Wrap(ErrInvalidSponsorship, err.Error())
+	}
+	to, ok := paramsMap["to"].(common.Address)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidSponsorship, "invalid to")
+	}
+	callData, ok := paramsMap["data"].([]byte)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidSponsorship, "invalid data")
+	}
+	expireHeight, ok := paramsMap["expireHeight"].(uint64)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidSponsorship, "invalid expire height")
+	}
+	maxGasFee, ok := paramsMap["maxGasFee"].(*big.Int)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidSponsorship, "invalid max gas fee")
+	}
+	salt, ok := paramsMap["salt"].(uint64)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidSponsorship, "invalid salt")
+	}
+	sig, ok := paramsMap["signature"].([]byte)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidSponsorship, "invalid signature")
+	}
+	return &SponsoredCall{
+		To:   to,
+		Data: callData,
+		Sponsorship: &Sponsorship{
+			ExpireHeight: expireHeight,
+			MaxGasFee:    maxGasFee,
+			Salt:         salt,
+			Signature:    sig,
+		},
+	}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package paymaster
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestSponsorship(t *testing.T) {
+	require := require.New(t)
+	var (
+		paymaster   = identityset.Address(10)
+		beneficiary = identityset.Address(1)
+		to          = common.BytesToAddress(identityset.Address(2).Bytes())
+		callData    = []byte{1, 2, 3}
+		value       = big.NewInt(5)
+	)
+	s := &Sponsorship{
+		ExpireHeight: 100,
+		MaxGasFee:    big.NewInt(1000),
+		Salt:         7,
+	}
+	require.NoError(s.Sign(identityset.PrivateKey(3), 1, paymaster, beneficiary, to, callData, value))
+	h, err := s.Hash(1, paymaster, beneficiary, to, callData, value)
+	require.NoError(err)
+	sponsor, err := s.Sponsor(h)
+	require.NoError(err)
+	require.Equal(identityset.Address(3).String(), sponsor.String())
+
+	// the sponsorship is bound to the chain, beneficiary, contract, call data and value
+	for _, c := range []struct {
+		chainID     uint32
+		beneficiary address.Address
+		to          common.Address
+		data        []byte
+		value       *big.Int
+	}{
+		{2, beneficiary, to, callData, value},
+		{1, identityset.Address(4), to, callData, value},
+		{1, beneficiary, common.BytesToAddress(identityset.Address(5).Bytes()), callData, value},
+		{1, beneficiary, to, []byte{1, 2, 4}, value},
+		{1, beneficiary, to, nil, value},
+		{1, beneficiary, to, callData, big.NewInt(6)},
+		{1, beneficiary, to, callData, nil},
+	} {
+		h2, err := s.Hash(c.chainID, paymaster, c.beneficiary, c.to, c.data, c.value)
+		require.NoError(err)
+		require.NotEqual(h, h2)
+		sponsor, err = s.Sponsor(h2)
+		require.NoError(err)
+		require.NotEqual(identityset.Address(3).String(), sponsor.String())
+	}
+	_, err = s.Hash(1, paymaster, beneficiary, to, callData, big.NewInt(-1))
+	require.Equal(ErrInvalidSponsorship, errors.Cause(err))
+
+	// encode and decode
+	call := &SponsoredCall{
+		To:          to,
+		Data:        callData,
+		Sponsorship: s,
+	}
+	data, err := EncodeSponsoredCall(call)
+	require.NoError(err)
+	decoded, err := DecodeSponsoredCall(data)
+	require.NoError(err)
+	require.Equal(call.To, decoded.To)
+	require.Equal(call.Data, decoded.Data)
+	require.Equal(s.ExpireHeight, decoded.Sponsorship.ExpireHeight)
+	require.Zero(s.MaxGasFee.Cmp(decoded.Sponsorship.MaxGasFee))
+	require.Equal(s.Salt, decoded.Sponsorship.Salt)
+	require.Equal(s.Signature, decoded.Sponsorship.Signature)
+
+	_, err = DecodeSponsoredCall([]byte{1, 2, 3, 4})
+	require.Equal(ErrInvalidSponsorship, errors.Cause(err))
+	_, err = EncodeSponsoredCall(&SponsoredCall{To: to})
+	require.Equal(ErrInvalidSponsorship, errors.Cause(err))
+}
@@ -43,6 +43,7 @@ func (f contractReaderFunc) Read(ctx context.Context, contract string, data []by
 }
 
 type consortiumCommittee struct {
+	systemStates
 	contractReader contractReader
 	contract       string
 	abi            abi.ABI
@@ -112,7 +113,7 @@ func (cc *consortiumCommittee) CreateGenesisStates(ctx context.Context, sm proto
 	actionCtx.Nonce = _consortiumCommitteeContractNonce
 	actionCtx.ActionHash = _consortiumCommitteeContractHash
 	actionCtx.GasPrice = elp.GasPrice()
-	actionCtx.IntrinsicGas, err = elp.IntrinsicGas()
+	actionCtx.IntrinsicGas, err = protocol.IntrinsicGas(ctx, elp)
 	if err != nil {
 		return err
 	}
@@ -25,6 +25,7 @@ import (
 )
 
 type governanceChainCommitteeProtocol struct {
+	systemStates
 	getBlockTime              GetBlockTime
 	electionCommittee         committee.Committee
 	initGravityChainHeight    uint64
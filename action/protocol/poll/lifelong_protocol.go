@@ -23,6 +23,7 @@ import (
 )
 
 type lifeLongDelegatesProtocol struct {
+	systemStates
 	delegates state.CandidateList
 	addr      address.Address
 }
@@ -12,10 +12,12 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/v2/state"
 )
 
 type nativeStakingV2 struct {
+	systemStates
 	addr           address.Address
 	stakingV2      *staking.Protocol
 	candIndexer    *CandidateIndexer
@@ -95,6 +97,17 @@ func (ns *nativeStakingV2) CalculateUnproductiveDelegates(
 	return ns.slasher.calculateUnproductiveDelegates(ctx, sr)
 }
 
+// DryRunNextEpoch calculates the delegates and probation list of the next epoch
+func (ns *nativeStakingV2) DryRunNextEpoch(ctx context.Context, sm protocol.StateManager) (*EpochDryRun, error) {
+	return ns.slasher.DryRunNextEpoch(ctx, sm, ns)
+}
+
+// ProbationList returns the probation list of current epoch
+func (ns *nativeStakingV2) ProbationList(ctx context.Context, sr protocol.StateReader) (*vote.ProbationList, error) {
+	probationList, _, err := ns.slasher.GetProbationList(ctx, sr, false)
+	return probationList, err
+}
+
 // Delegates returns exact number of delegates of current epoch
 func (ns *nativeStakingV2) Delegates(ctx context.Context, sr protocol.StateReader) (state.CandidateList, error) {
 	delegates, _, err := ns.slasher.GetActiveBlockProducers(ctx, sr, false)
@@ -0,0 +1,188 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// ProbationExplanationMethod is the ReadState method to explain the probation list of the current epoch. The response
+// is the json encoded ProbationExplanation
+const ProbationExplanationMethod = "ProbationExplanationByEpoch"
+
+type (
+	// ProbationExplanation explains why the delegates are on the probation list of an epoch. A delegate is probated
+	// for each of the last ProbationPeriod epochs in which it produced less than Threshold percent of the blocks
+	// expected from an active delegate
+	ProbationExplanation struct {
+		Epoch           uint64              `json:"epoch"`
+		IntensityRate   uint32              `json:"intensityRate"`
+		ProbationPeriod uint64              `json:"probationPeriod"`
+		Threshold       uint64              `json:"threshold"`
+		Delegates       []*ProbatedDelegate `json:"delegates"`
+	}
+
+	// ProbatedDelegate is a delegate on the probation list and the epochs it was unproductive in
+	ProbatedDelegate struct {
+		Address            string               `json:"address"`
+		Count              uint32               `json:"count"`
+		UnproductiveEpochs []*UnproductiveEpoch `json:"unproductiveEpochs"`
+	}
+
+	// UnproductiveEpoch is the productivity of a delegate in an epoch, which is Produced * 100 / Expected percent
+	UnproductiveEpoch struct {
+		Epoch        uint64 `json:"epoch"`
+		Blocks       uint64 `json:"blocks"`
+		Produced     uint64 `json:"produced"`
+		Expected     uint64 `json:"expected"`
+		Productivity uint64 `json:"productivity"`
+	}
+
+	// epochProductivity is the number of blocks of an epoch and the number of blocks produced by each delegate
+	epochProductivity struct {
+		blocks       uint64
+		produce      map[string]uint64
+		numDelegates uint64
+	}
+)
+
+// readProbationExplanation explains the probation list of the epoch of the state reader height
+func (sh *Slasher) readProbationExplanation(ctx context.Context, sr protocol.StateReader, epochNum uint64) ([]byte, uint64, error) {
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	tipHeight, err := sr.Height()
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	// the unproductive delegates of the past epochs only exist in the state of the current epoch
+	if tipEpochNum := rp.GetEpochNum(tipHeight); epochNum != tipEpochNum {
+		return nil, uint64(0), errors.Errorf("probation can only be explained for current epoch %d, got %d", tipEpochNum, epochNum)
+	}
+	probationList, height, err := sh.GetProbationList(ctx, sr, false)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	upd, err := sh.getUnprodDelegate(sr)
+	if err != nil {
+		if errors.Cause(err) != state.ErrStateNotExist {
+			return nil, uint64(0), err
+		}
+		if upd, err = vote.NewUnproductiveDelegate(sh.probationEpochPeriod, sh.maxProbationPeriod); err != nil {
+			return nil, uint64(0), err
+		}
+	}
+	explanation, err := sh.explainProbation(epochNum, probationList, upd, func(epoch uint64) (*epochProductivity, error) {
+		blocks, produce, err := rp.ProductivityByEpoch(epoch, tipHeight, sh.productivity)
+		if err != nil {
+			return nil, err
+		}
+		numDelegates := sh.numDelegates
+		if sh.indexer != nil {
+			// the number of active block producers is exact if the epoch is indexed
+			if abps, err := sh.GetABPFromIndexer(ctx, rp.GetEpochHeight(epoch)); err == nil {
+				numDelegates = uint64(len(abps))
+			}
+		}
+		return &epochProductivity{
+			blocks:       blocks,
+			produce:      produce,
+			numDelegates: numDelegates,
+		}, nil
+	})
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	data, err := json.Marshal(explanation)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	return data, height, nil
+}
+
+// explainProbation matches the probated delegates with the unproductive delegate lists of the past epochs, the list
+// at index i is of epoch epochNum-1-i
+func (sh *Slasher) explainProbation(
+	epochNum uint64,
+	probationList *vote.ProbationList,
+	upd *vote.UnproductiveDelegate,
+	productivityByEpoch func(uint64) (*epochProductivity, error),
+) (*ProbationExplanation, error) {
+	explanation := &ProbationExplanation{
+		Epoch:           epochNum,
+		IntensityRate:   probationList.IntensityRate,
+		ProbationPeriod: sh.probationEpochPeriod,
+		Threshold:       sh.prodThreshold,
+		Delegates:       make([]*ProbatedDelegate, 0, len(probationList.ProbationInfo)),
+	}
+	addrs := make([]string, 0, len(probationList.ProbationInfo))
+	for addr := range probationList.ProbationInfo {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	delegates := make(map[string]*ProbatedDelegate, len(addrs))
+	for _, addr := range addrs {
+		delegate := &ProbatedDelegate{
+			Address:            addr,
+			Count:              probationList.ProbationInfo[addr],
+			UnproductiveEpochs: []*UnproductiveEpoch{},
+		}
+		delegates[addr] = delegate
+		explanation.Delegates = append(explanation.Delegates, delegate)
+	}
+	lists := upd.DelegateList()
+	for i := uint64(0); i < sh.probationEpochPeriod && i < uint64(len(lists)) && i+1 < epochNum; i++ {
+		var (
+			epoch = epochNum - 1 - i
+			stats *epochProductivity
+		)
+		for _, addr := range lists[i] {
+			delegate, ok := delegates[addr]
+			if !ok {
+				continue
+			}
+			if stats == nil {
+				var err error
+				if stats, err = productivityByEpoch(epoch); err != nil {
+					return nil, errors.Wrapf(err, "failed to get productivity of epoch %d", epoch)
+				}
+			}
+			delegate.UnproductiveEpochs = append(delegate.UnproductiveEpochs, stats.unproductiveEpoch(epoch, addr))
+		}
+	}
+	return explanation, nil
+}
+
+// unproductiveEpoch returns the productivity of the delegate in the epoch, the same as calculateUnproductiveDelegates
+func (stats *epochProductivity) unproductiveEpoch(epoch uint64, addr string) *UnproductiveEpoch {
+	// the delegates producing no block are not in the produce map
+	numDelegates := uint64(len(stats.produce))
+	if _, ok := stats.produce[addr]; !ok {
+		numDelegates++
+	}
+	if numDelegates < stats.numDelegates {
+		numDelegates = stats.numDelegates
+	}
+	res := &UnproductiveEpoch{
+		Epoch:    epoch,
+		Blocks:   stats.blocks,
+		Produced: stats.produce[addr],
+	}
+	if numDelegates > 0 {
+		res.Expected = stats.blocks / numDelegates
+	}
+	if res.Expected > 0 {
+		res.Productivity = res.Produced * 100 / res.Expected
+	}
+	return res
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestExplainProbation(t *testing.T) {
+	require := require.New(t)
+	var (
+		d1 = identityset.Address(1).String()
+		d2 = identityset.Address(2).String()
+		d3 = identityset.Address(3).String()
+		sh = &Slasher{
+			numDelegates:         4,
+			prodThreshold:        85,
+			probationEpochPeriod: 3,
+			maxProbationPeriod:   4,
+		}
+		// 40 blocks in an epoch, 10 blocks are expected from each of the 4 delegates
+		stats = map[uint64]*epochProductivity{
+			8:  {blocks: 40, produce: map[string]uint64{d1: 5, d2: 10, d3: 25}, numDelegates: 4},
+			9:  {blocks: 40, produce: map[string]uint64{d1: 8, d3: 16}, numDelegates: 4},
+			10: {blocks: 40, produce: map[string]uint64{d2: 20, d3: 20}, numDelegates: 4},
+		}
+		queried []uint64
+	)
+	upd, err := vote.NewUnproductiveDelegate(sh.probationEpochPeriod, sh.maxProbationPeriod)
+	require.NoError(err)
+	// the unproductive delegates of epoch 7, 8, 9 and 10
+	require.NoError(upd.AddRecentUPD([]string{d2}))
+	require.NoError(upd.AddRecentUPD([]string{d1}))
+	require.NoError(upd.AddRecentUPD([]string{d1, d2}))
+	require.NoError(upd.AddRecentUPD([]string{d1}))
+	probationList := &vote.ProbationList{
+		ProbationInfo: map[string]uint32{d1: 3, d2: 1},
+		IntensityRate: 50,
+	}
+	explanation, err := sh.explainProbation(11, probationList, upd, func(epoch uint64) (*epochProductivity, error) {
+		queried = append(queried, epoch)
+		return stats[epoch], nil
+	})
+	require.NoError(err)
+	// epoch 7 is beyond the probation period of epoch 11
+	require.Equal([]uint64{10, 9, 8}, queried)
+	require.EqualValues(11, explanation.Epoch)
+	require.EqualValues(50, explanation.IntensityRate)
+	require.EqualValues(3, explanation.ProbationPeriod)
+	require.EqualValues(85, explanation.Threshold)
+	require.Len(explanation.Delegates, 2)
+	delegates := map[string]*ProbatedDelegate{}
+	for _, d := range explanation.Delegates {
+		delegates[d.Address] = d
+	}
+	require.EqualValues(3, delegates[d1].Count)
+	require.Equal([]*UnproductiveEpoch{
+		{Epoch: 10, Blocks: 40, Produced: 0, Expected: 10, Productivity: 0},
+		{Epoch: 9, Blocks: 40, Produced: 8, Expected: 10, Productivity: 80},
+		{Epoch: 8, Blocks: 40, Produced: 5, Expected: 10, Productivity: 50},
+	}, delegates[d1].UnproductiveEpochs)
+	require.EqualValues(1, delegates[d2].Count)
+	require.Equal([]*UnproductiveEpoch{
+		{Epoch: 9, Blocks: 40, Produced: 0, Expected: 10, Productivity: 0},
+	}, delegates[d2].UnproductiveEpochs)
+}
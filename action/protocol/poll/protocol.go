@@ -85,6 +85,29 @@ type (
 		// CalculateUnproductiveDelegates calculates unproductive delegate on current epoch
 		CalculateUnproductiveDelegates(context.Context, protocol.StateReader) ([]string, error)
 	}
+
+	// EpochDryRun is the result of running the delegate selection of the next epoch against the current state
+	EpochDryRun struct {
+		EpochNum             uint64
+		Height               uint64
+		Candidates           state.CandidateList
+		BlockProducers       state.CandidateList
+		ActiveBlockProducers state.CandidateList
+		// ProbationList is nil if the probation is not enabled in the next epoch
+		ProbationList *vote.ProbationList
+	}
+
+	// NextEpochDryRunner runs the delegate selection of the next epoch ahead of time
+	NextEpochDryRunner interface {
+		// DryRunNextEpoch calculates the delegates of the next epoch as if the current block were the last block
+		// of the current epoch. The state manager is written by the calculation, and should be discarded afterwards
+		DryRunNextEpoch(context.Context, protocol.StateManager) (*EpochDryRun, error)
+	}
+
+	// ProbationListReader reads the probation list of the current epoch
+	ProbationListReader interface {
+		ProbationList(context.Context, protocol.StateReader) (*vote.ProbationList, error)
+	}
 )
 
 // FindProtocol finds the registered protocol from registry
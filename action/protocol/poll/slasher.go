@@ -239,6 +239,8 @@ func (sh *Slasher) ReadState(
 			return nil, uint64(0), err
 		}
 		return data, height, nil
+	case ProbationExplanationMethod:
+		return sh.readProbationExplanation(ctx, sr, rp.GetEpochNum(epochStartHeight))
 	default:
 		return nil, uint64(0), errors.New("corresponding method isn't found")
 	}
@@ -490,6 +492,39 @@ func (sh *Slasher) CalculateProbationList(
 	return nextProbationlist, setUnproductiveDelegates(sm, upd)
 }
 
+// DryRunNextEpoch calculates the candidates, block producers and probation list of the next epoch
+func (sh *Slasher) DryRunNextEpoch(ctx context.Context, sm protocol.StateManager, p Protocol) (*EpochDryRun, error) {
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	epochNum := rp.GetEpochNum(blkCtx.BlockHeight)
+	nextEpochStartHeight := rp.GetEpochHeight(epochNum + 1)
+	candidates, err := p.CalculateCandidatesByHeight(ctx, sm, rp.GetEpochHeight(epochNum))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to calculate candidates of epoch %d", epochNum+1)
+	}
+	res := &EpochDryRun{
+		EpochNum: epochNum + 1,
+		Height:   nextEpochStartHeight,
+	}
+	if featureWithHeightCtx.CalculateProbationList(nextEpochStartHeight) {
+		if res.ProbationList, err = sh.CalculateProbationList(ctx, sm, epochNum+1); err != nil {
+			return nil, errors.Wrapf(err, "failed to calculate probation list of epoch %d", epochNum+1)
+		}
+		if candidates, err = filterCandidates(candidates, res.ProbationList, nextEpochStartHeight); err != nil {
+			return nil, err
+		}
+	}
+	res.Candidates = candidates
+	if res.BlockProducers, err = sh.calculateBlockProducer(candidates); err != nil {
+		return nil, err
+	}
+	if res.ActiveBlockProducers, err = sh.calculateActiveBlockProducer(ctx, res.BlockProducers, nextEpochStartHeight); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (sh *Slasher) calculateUnproductiveDelegates(ctx context.Context, sr protocol.StateReader) ([]string, error) {
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 	bcCtx := protocol.MustGetBlockchainCtx(ctx)
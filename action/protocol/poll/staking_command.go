@@ -18,6 +18,7 @@ import (
 )
 
 type stakingCommand struct {
+	systemStates
 	addr      address.Address
 	stakingV1 Protocol
 	stakingV2 Protocol
@@ -113,6 +114,16 @@ func (sc *stakingCommand) CalculateUnproductiveDelegates(
 	return sc.stakingV1.CalculateUnproductiveDelegates(ctx, sr)
 }
 
+// DryRunNextEpoch calculates the delegates and probation list of the next epoch, which is only supported by native staking
+func (sc *stakingCommand) DryRunNextEpoch(ctx context.Context, sm protocol.StateManager) (*EpochDryRun, error) {
+	if sc.useV2(ctx, sm) {
+		if dr, ok := sc.stakingV2.(NextEpochDryRunner); ok {
+			return dr.DryRunNextEpoch(ctx, sm)
+		}
+	}
+	return nil, errors.New("dry run of next epoch is not supported before native staking")
+}
+
 // Delegates returns exact number of delegates of current epoch
 func (sc *stakingCommand) Delegates(ctx context.Context, sr protocol.StateReader) (state.CandidateList, error) {
 	if sc.useV2(ctx, sr) {
@@ -41,6 +41,7 @@ var (
 )
 
 type stakingCommittee struct {
+	systemStates
 	electionCommittee    committee.Committee
 	governanceStaking    Protocol
 	nativeStaking        *NativeStaking
@@ -121,7 +122,7 @@ func (sc *stakingCommittee) CreateGenesisStates(ctx context.Context, sm protocol
 	actionCtx.Nonce = _nativeStakingContractNonce
 	actionCtx.ActionHash = _nativeStakingContractHash
 	actionCtx.GasPrice = elp.GasPrice()
-	actionCtx.IntrinsicGas, err = elp.IntrinsicGas()
+	actionCtx.IntrinsicGas, err = protocol.IntrinsicGas(ctx, elp)
 	if err != nil {
 		return err
 	}
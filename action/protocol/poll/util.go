@@ -369,3 +369,22 @@ func blockMetaKey(blkHeight uint64, blocksInEpoch uint64) []byte {
 	}
 	return append(prefixKey[:], byteutil.Uint64ToBytesBigEndian(blkHeight%blocksInEpoch)...)
 }
+
+// systemStates implements protocol.StateExporter and protocol.StateImporter for poll protocols,
+// all of which store their states in the system namespace
+type systemStates struct{}
+
+// Namespaces returns the namespaces used by the protocol
+func (systemStates) Namespaces() []string {
+	return []string{protocol.SystemNamespace}
+}
+
+// ExportStates exports the states in system namespace
+func (s systemStates) ExportStates(_ context.Context, sr protocol.StateReader, fn func(*protocol.StateEntry) error) error {
+	return protocol.ExportNamespaces(sr, fn, s.Namespaces()...)
+}
+
+// ImportStates imports the states in system namespace
+func (s systemStates) ImportStates(_ context.Context, sm protocol.StateManager, entries []*protocol.StateEntry) error {
+	return protocol.ImportNamespaces(sm, entries, s.Namespaces()...)
+}
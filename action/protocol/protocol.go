@@ -26,14 +26,21 @@ var (
 const (
 	// SystemNamespace is the namespace to store system information such as candidates/probationList/unproductiveDelegates
 	SystemNamespace = "System"
+	// AccountKVNamespace is the namespace to store accounts, which is the default namespace of the states
+	AccountKVNamespace = "Account"
 )
 
 // Protocol defines the protocol interfaces atop IoTeX blockchain
 type Protocol interface {
 	ActionHandler
-	ReadState(context.Context, StateReader, []byte, ...[]byte) ([]byte, uint64, error)
+	Reader
 	Register(*Registry) error
 	ForceRegister(*Registry) error
+}
+
+// Reader defines a read-only protocol, which only serves ReadState and does not handle actions
+type Reader interface {
+	ReadState(context.Context, StateReader, []byte, ...[]byte) ([]byte, uint64, error)
 	Name() string
 }
 
@@ -42,6 +49,11 @@ type Starter interface {
 	Start(context.Context, StateReader) (interface{}, error)
 }
 
+// ViewPersister persists the protocol view at shutdown, so that it can be loaded at next start
+type ViewPersister interface {
+	PersistView(context.Context, StateReader, interface{}) error
+}
+
 // GenesisStateCreator creates some genesis states
 type GenesisStateCreator interface {
 	CreateGenesisStates(context.Context, StateManager) error
@@ -100,7 +112,8 @@ func BlobGasFeeOption(blobGasFee *big.Int) DepositOption {
 	}
 }
 
-// DepositGas deposits gas to rewarding pool and burns baseFee
+// DepositGas deposits gas to rewarding pool and burns baseFee, a share of baseFee is sent to
+// the treasury account instead if it is configured in genesis
 type DepositGas func(context.Context, StateManager, *big.Int, ...DepositOption) ([]*action.TransactionLog, error)
 
 // View stores the view for all protocols
@@ -13,11 +13,33 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ReaderCreator creates a read-only protocol to be registered on a running node
+type ReaderCreator func() (Reader, error)
+
+// _readerCreators are the read-only protocols built into the node, which are registered on demand by EnableReader
+var _readerCreators = map[string]ReaderCreator{}
+
+// RegisterReaderCreator makes the read-only protocol of the ID available to EnableReader. It should be called in
+// init(), e.g., of a plugin package which is imported for side effects
+func RegisterReaderCreator(id string, create ReaderCreator) error {
+	if create == nil {
+		return errors.New("reader creator is nil")
+	}
+	if _, loaded := _readerCreators[id]; loaded {
+		return errors.Errorf("Reader creator with ID %s is already registered", id)
+	}
+	_readerCreators[id] = create
+
+	return nil
+}
+
 // Registry is the hub of all protocols deployed on the chain
 type Registry struct {
 	mu        sync.RWMutex
 	ids       map[string]int
 	protocols []Protocol
+	readers   map[string]Reader
+	bus       *EventBus
 }
 
 // NewRegistry create a new Registry
@@ -25,10 +47,20 @@ func NewRegistry() *Registry {
 	return &Registry{
 		ids:       make(map[string]int, 0),
 		protocols: make([]Protocol, 0),
+		readers:   make(map[string]Reader),
+		bus:       NewEventBus(),
 	}
 }
 
+// EventBus returns the event bus shared by the protocols in the registry
+func (r *Registry) EventBus() *EventBus {
+	return r.bus
+}
+
 func (r *Registry) register(id string, p Protocol, force bool) error {
+	if _, loaded := r.readers[id]; loaded {
+		return errors.Errorf("Reader with ID %s is already registered", id)
+	}
 	idx, loaded := r.ids[id]
 	if loaded {
 		if !force {
@@ -72,6 +104,63 @@ func (r *Registry) Find(id string) (Protocol, bool) {
 	return r.protocols[idx], true
 }
 
+// RegisterReader registers a read-only protocol with a unique ID, it can be called on a running node
+func (r *Registry) RegisterReader(id string, reader Reader) error {
+	if reader == nil {
+		return errors.New("reader is nil")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, loaded := r.ids[id]; loaded {
+		return errors.Errorf("Protocol with ID %s is already registered", id)
+	}
+	if _, loaded := r.readers[id]; loaded {
+		return errors.Errorf("Reader with ID %s is already registered", id)
+	}
+	r.readers[id] = reader
+
+	return nil
+}
+
+// UnregisterReader removes a read-only protocol registered by RegisterReader
+func (r *Registry) UnregisterReader(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, loaded := r.readers[id]; !loaded {
+		return false
+	}
+	delete(r.readers, id)
+
+	return true
+}
+
+// EnableReader creates the read-only protocol of the ID by its creator and registers it, it can be called on a
+// running node
+func (r *Registry) EnableReader(id string) error {
+	create, ok := _readerCreators[id]
+	if !ok {
+		return errors.Errorf("Reader creator with ID %s is not registered", id)
+	}
+	reader, err := create()
+	if err != nil {
+		return errors.Wrapf(err, "failed to create reader %s", id)
+	}
+
+	return r.RegisterReader(id, reader)
+}
+
+// FindReader finds a protocol or a read-only protocol by ID
+func (r *Registry) FindReader(id string) (Reader, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if idx, loaded := r.ids[id]; loaded {
+		return r.protocols[idx], true
+	}
+	reader, loaded := r.readers[id]
+
+	return reader, loaded
+}
+
 // All returns all protocols
 func (r *Registry) All() []Protocol {
 	if r == nil {
@@ -113,3 +202,26 @@ func (r *Registry) StartAll(ctx context.Context, sr StateReader) (View, error) {
 	}
 	return allView, nil
 }
+
+// PersistAllViews persists the views of all protocols which are view persisters
+func (r *Registry) PersistAllViews(ctx context.Context, sr StateReader, view View) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.all() {
+		vp, ok := p.(ViewPersister)
+		if !ok {
+			continue
+		}
+		v, err := view.Read(p.Name())
+		if err != nil {
+			continue
+		}
+		if err := vp.PersistView(ctx, sr, v); err != nil {
+			return errors.Wrapf(err, "failed to persist view of protocol %s", p.Name())
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,3 +55,60 @@ func TestAll(t *testing.T) {
 	require.Equal(all[0], p)
 	require.Nil(all[1])
 }
+
+func TestRegisterReader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	reg := NewRegistry()
+	p := NewMockProtocol(ctrl)
+	require.NoError(reg.Register("1", p))
+	// Case I: reader ID conflicts with a protocol
+	require.Error(reg.RegisterReader("1", p))
+	// Case II: nil reader
+	require.Error(reg.RegisterReader("2", nil))
+	// Case III: Normal
+	require.NoError(reg.RegisterReader("2", p))
+	require.Error(reg.RegisterReader("2", p))
+	require.Error(reg.Register("2", p))
+	// readers are not part of the protocols
+	require.Equal(1, len(reg.All()))
+	_, ok := reg.Find("2")
+	require.False(ok)
+	r, ok := reg.FindReader("2")
+	require.True(ok)
+	require.Equal(p, r)
+	r, ok = reg.FindReader("1")
+	require.True(ok)
+	require.Equal(p, r)
+	// Case IV: unregister
+	require.True(reg.UnregisterReader("2"))
+	require.False(reg.UnregisterReader("2"))
+	_, ok = reg.FindReader("2")
+	require.False(ok)
+}
+
+func TestEnableReader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	reg := NewRegistry()
+	p := NewMockProtocol(ctrl)
+	require.Error(RegisterReaderCreator("reader", nil))
+	require.NoError(RegisterReaderCreator("reader", func() (Reader, error) { return p, nil }))
+	defer delete(_readerCreators, "reader")
+	require.Error(RegisterReaderCreator("reader", func() (Reader, error) { return p, nil }))
+	require.NoError(RegisterReaderCreator("failure", func() (Reader, error) { return nil, errors.New("failure") }))
+	defer delete(_readerCreators, "failure")
+
+	// Case I: no creator
+	require.ErrorContains(reg.EnableReader("unknown"), "not registered")
+	// Case II: creator fails
+	require.ErrorContains(reg.EnableReader("failure"), "failed to create reader")
+	// Case III: normal
+	require.NoError(reg.EnableReader("reader"))
+	r, ok := reg.FindReader("reader")
+	require.True(ok)
+	require.Equal(p, r)
+	require.Error(reg.EnableReader("reader"))
+	require.True(reg.UnregisterReader("reader"))
+	require.NoError(reg.EnableReader("reader"))
+}
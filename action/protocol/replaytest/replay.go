@@ -0,0 +1,307 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package replaytest replays recorded blocks against a single protocol in isolation, so that changes of the
+// protocol's handlers can be regression-tested against real history. A recording holds, for each block, the
+// states read by the protocol before being written, the states written by the protocol and the receipts it
+// generated. Replaying a block seeds an in-memory state manager with the recorded pre-states, runs the protocol
+// over the actions of the block, and reports any difference to the recorded post-states and receipts.
+package replaytest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+)
+
+const (
+	// MismatchState indicates the state written by the protocol differs from the recording
+	MismatchState = "state"
+	// MismatchReceipt indicates the receipt generated by the protocol differs from the recording
+	MismatchReceipt = "receipt"
+)
+
+type (
+	// Recording is the recorded execution of a range of blocks by a protocol
+	Recording struct {
+		Protocol string         `json:"protocol"`
+		Blocks   []*BlockRecord `json:"blocks"`
+	}
+
+	// BlockRecord is the recorded execution of a block by a protocol
+	BlockRecord struct {
+		// Block is the serialized block
+		Block []byte `json:"block"`
+		// PreState is the states read by the protocol before being written, nil value means not exist
+		PreState []*protocol.StateEntry `json:"preState"`
+		// PostState is the states written by the protocol, nil value means deleted
+		PostState []*protocol.StateEntry `json:"postState"`
+		// Receipts is the serialized receipts generated by the protocol, nil if the action is not handled
+		Receipts [][]byte `json:"receipts"`
+	}
+
+	// Mismatch is a difference between the replay and the recording
+	Mismatch struct {
+		Height uint64
+		Kind   string
+		Detail string
+	}
+
+	// Harness runs blocks against a protocol
+	Harness struct {
+		protocol     protocol.Protocol
+		deserializer *block.Deserializer
+	}
+)
+
+// Save writes the recording
+func (r *Recording) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r)
+}
+
+// LoadRecording reads a recording
+func LoadRecording(r io.Reader) (*Recording, error) {
+	rec := &Recording{}
+	if err := json.NewDecoder(r).Decode(rec); err != nil {
+		return nil, errors.Wrap(err, "failed to decode recording")
+	}
+	return rec, nil
+}
+
+func (m *Mismatch) String() string {
+	return fmt.Sprintf("block %d %s mismatch: %s", m.Height, m.Kind, m.Detail)
+}
+
+// NewHarness creates a harness for the protocol
+func NewHarness(p protocol.Protocol, evmNetworkID uint32) *Harness {
+	return &Harness{
+		protocol:     p,
+		deserializer: block.NewDeserializer(evmNetworkID),
+	}
+}
+
+// Record runs the block against the state manager, and records the states accessed and the receipts generated
+// by the protocol. The context should carry genesis, registry and blockchain context as in block processing.
+func (h *Harness) Record(ctx context.Context, sm protocol.StateManager, blk *block.Block) (*BlockRecord, error) {
+	data, err := blk.Serialize()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize block %d", blk.Height())
+	}
+	tsm := newTrackingStateManager(sm)
+	receipts, err := h.run(ctx, tsm, blk)
+	if err != nil {
+		return nil, err
+	}
+	rec := &BlockRecord{
+		Block:     data,
+		PreState:  tsm.preState(),
+		PostState: tsm.postState(),
+		Receipts:  make([][]byte, len(receipts)),
+	}
+	for i, r := range receipts {
+		if r == nil {
+			continue
+		}
+		if rec.Receipts[i], err = r.Serialize(); err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize receipt of block %d", blk.Height())
+		}
+	}
+	return rec, nil
+}
+
+// Replay replays the recorded blocks, each block is run on its recorded pre-states in isolation
+func (h *Harness) Replay(ctx context.Context, rec *Recording) ([]*Mismatch, error) {
+	if rec.Protocol != h.protocol.Name() {
+		return nil, errors.Errorf("recording of protocol %s cannot be replayed on protocol %s", rec.Protocol, h.protocol.Name())
+	}
+	var mismatches []*Mismatch
+	for _, br := range rec.Blocks {
+		m, err := h.ReplayBlock(ctx, br)
+		if err != nil {
+			return nil, err
+		}
+		mismatches = append(mismatches, m...)
+	}
+	return mismatches, nil
+}
+
+// ReplayBlock replays a recorded block
+func (h *Harness) ReplayBlock(ctx context.Context, br *BlockRecord) ([]*Mismatch, error) {
+	blk, err := h.deserializer.DeserializeBlock(br.Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize block")
+	}
+	tsm := newTrackingStateManager(newMemStateManager(blk.Height(), br.PreState))
+	receipts, err := h.run(ctx, tsm, blk)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to replay block %d", blk.Height())
+	}
+	mismatches := diffStates(blk.Height(), br.PostState, tsm.postState())
+	m, err := diffReceipts(blk.Height(), br.Receipts, receipts)
+	if err != nil {
+		return nil, err
+	}
+	return append(mismatches, m...), nil
+}
+
+func (h *Harness) run(ctx context.Context, sm protocol.StateManager, blk *block.Block) ([]*action.Receipt, error) {
+	ctx, err := withBlockContext(ctx, blk)
+	if err != nil {
+		return nil, err
+	}
+	p := h.protocol
+	if starter, ok := p.(protocol.Starter); ok {
+		view, err := starter.Start(ctx, sm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to start protocol %s", p.Name())
+		}
+		if view != nil {
+			if err := sm.WriteView(p.Name(), view); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if psc, ok := p.(protocol.PreStatesCreator); ok {
+		if err := psc.CreatePreStates(ctx, sm); err != nil {
+			return nil, errors.Wrapf(err, "failed to create pre-states of block %d", blk.Height())
+		}
+	}
+	receipts := make([]*action.Receipt, 0, len(blk.Actions))
+	for _, selp := range blk.Actions {
+		actCtx, err := withActionCtx(ctx, selp)
+		if err != nil {
+			return nil, err
+		}
+		receipt, err := p.Handle(actCtx, selp.Envelope, sm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to handle action in block %d", blk.Height())
+		}
+		receipts = append(receipts, receipt)
+	}
+	if pc, ok := p.(protocol.PreCommitter); ok {
+		if err := pc.PreCommit(ctx, sm); err != nil {
+			return nil, errors.Wrapf(err, "failed to pre-commit block %d", blk.Height())
+		}
+	}
+	if c, ok := p.(protocol.Committer); ok {
+		if err := c.Commit(ctx, sm); err != nil {
+			return nil, errors.Wrapf(err, "failed to commit block %d", blk.Height())
+		}
+	}
+	return receipts, nil
+}
+
+func withBlockContext(ctx context.Context, blk *block.Block) (context.Context, error) {
+	g := genesis.MustExtractGenesisContext(ctx)
+	producer, err := address.FromString(blk.Header.ProducerAddress())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid producer of block %d", blk.Height())
+	}
+	if bcCtx, ok := protocol.GetBlockchainCtx(ctx); ok {
+		bcCtx.Tip.Height = blk.Height() - 1
+		bcCtx.Tip.Hash = blk.PrevHash()
+		ctx = protocol.WithBlockchainCtx(ctx, bcCtx)
+	}
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight:    blk.Height(),
+		BlockTimeStamp: blk.Timestamp(),
+		GasLimit:       g.BlockGasLimitByHeight(blk.Height()),
+		Producer:       producer,
+		BaseFee:        blk.BaseFee(),
+		ExcessBlobGas:  blk.ExcessBlobGas(),
+	})
+	return protocol.WithFeatureCtx(ctx), nil
+}
+
+func withActionCtx(ctx context.Context, selp *action.SealedEnvelope) (context.Context, error) {
+	caller := selp.SenderAddress()
+	if caller == nil {
+		return nil, errors.New("failed to get address")
+	}
+	h, err := selp.Hash()
+	if err != nil {
+		return nil, err
+	}
+	intrinsicGas, err := protocol.IntrinsicGas(ctx, selp.Envelope)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.WithActionCtx(ctx, protocol.ActionCtx{
+		Caller:       caller,
+		ActionHash:   h,
+		GasPrice:     selp.GasPrice(),
+		IntrinsicGas: intrinsicGas,
+		Nonce:        selp.Nonce(),
+	}), nil
+}
+
+func diffStates(height uint64, expected, actual []*protocol.StateEntry) []*Mismatch {
+	var (
+		mismatches []*Mismatch
+		actualMap  = make(map[stateKey][]byte, len(actual))
+	)
+	for _, e := range actual {
+		actualMap[stateKey{ns: e.Namespace, key: string(e.Key)}] = e.Value
+	}
+	for _, e := range expected {
+		sk := stateKey{ns: e.Namespace, key: string(e.Key)}
+		v, ok := actualMap[sk]
+		delete(actualMap, sk)
+		switch {
+		case !ok:
+			mismatches = append(mismatches, &Mismatch{height, MismatchState, fmt.Sprintf("state %x in namespace %s is not written", e.Key, e.Namespace)})
+		case !bytes.Equal(v, e.Value):
+			mismatches = append(mismatches, &Mismatch{height, MismatchState, fmt.Sprintf("state %x in namespace %s is %x, expecting %x", e.Key, e.Namespace, v, e.Value)})
+		}
+	}
+	for _, e := range actual {
+		if _, ok := actualMap[stateKey{ns: e.Namespace, key: string(e.Key)}]; ok {
+			mismatches = append(mismatches, &Mismatch{height, MismatchState, fmt.Sprintf("state %x in namespace %s is unexpectedly written", e.Key, e.Namespace)})
+		}
+	}
+	return mismatches
+}
+
+func diffReceipts(height uint64, expected [][]byte, actual []*action.Receipt) ([]*Mismatch, error) {
+	if len(expected) != len(actual) {
+		return []*Mismatch{{height, MismatchReceipt, fmt.Sprintf("%d receipts, expecting %d", len(actual), len(expected))}}, nil
+	}
+	var mismatches []*Mismatch
+	for i := range expected {
+		switch {
+		case expected[i] == nil && actual[i] == nil:
+			continue
+		case expected[i] == nil:
+			mismatches = append(mismatches, &Mismatch{height, MismatchReceipt, fmt.Sprintf("action %d is unexpectedly handled", i)})
+			continue
+		case actual[i] == nil:
+			mismatches = append(mismatches, &Mismatch{height, MismatchReceipt, fmt.Sprintf("action %d is not handled", i)})
+			continue
+		}
+		r := &action.Receipt{}
+		if err := r.Deserialize(expected[i]); err != nil {
+			return nil, errors.Wrapf(err, "failed to deserialize receipt of block %d", height)
+		}
+		if r.Hash() == actual[i].Hash() {
+			continue
+		}
+		mismatches = append(mismatches, &Mismatch{height, MismatchReceipt, fmt.Sprintf(
+			"action %x has status %d, gas %d, %d logs, expecting status %d, gas %d, %d logs",
+			actual[i].ActionHash, actual[i].Status, actual[i].GasConsumed, len(actual[i].Logs()),
+			r.Status, r.GasConsumed, len(r.Logs()),
+		)})
+	}
+	return mismatches, nil
+}
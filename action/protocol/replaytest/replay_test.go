@@ -0,0 +1,126 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package replaytest
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+const _counterNamespace = "Counter"
+
+// counterProtocol counts the actions of each caller by step
+type counterProtocol struct {
+	step uint64
+}
+
+func (p *counterProtocol) Handle(ctx context.Context, _ action.Envelope, sm protocol.StateManager) (*action.Receipt, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	var count protocol.SerializableBytes
+	_, err := sm.State(&count, protocol.NamespaceOption(_counterNamespace), protocol.KeyOption(actCtx.Caller.Bytes()))
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		count = byteutil.Uint64ToBytes(0)
+	default:
+		return nil, err
+	}
+	n := byteutil.BytesToUint64(count) + p.step
+	if _, err := sm.PutState(protocol.SerializableBytes(byteutil.Uint64ToBytes(n)),
+		protocol.NamespaceOption(_counterNamespace), protocol.KeyOption(actCtx.Caller.Bytes())); err != nil {
+		return nil, err
+	}
+	return &action.Receipt{
+		Status:            uint64(iotextypes.ReceiptStatus_Success),
+		BlockHeight:       protocol.MustGetBlockCtx(ctx).BlockHeight,
+		ActionHash:        actCtx.ActionHash,
+		GasConsumed:       actCtx.IntrinsicGas,
+		EffectiveGasPrice: big.NewInt(0),
+	}, nil
+}
+
+func (p *counterProtocol) ReadState(context.Context, protocol.StateReader, []byte, ...[]byte) ([]byte, uint64, error) {
+	return nil, 0, nil
+}
+
+func (p *counterProtocol) Name() string { return "counter" }
+
+func (p *counterProtocol) Register(*protocol.Registry) error { return nil }
+
+func (p *counterProtocol) ForceRegister(*protocol.Registry) error { return nil }
+
+func TestRecordAndReplay(t *testing.T) {
+	require := require.New(t)
+	g := genesis.TestDefault()
+	ctx := genesis.WithGenesisContext(context.Background(), g)
+	ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{})
+
+	var acts []*action.SealedEnvelope
+	for i := 0; i < 3; i++ {
+		selp, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(i%2), uint64(i/2+1),
+			big.NewInt(1), nil, 10000, big.NewInt(0))
+		require.NoError(err)
+		acts = append(acts, selp)
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(10).
+		SetTimeStamp(time.Now()).
+		AddActions(acts...).
+		SignAndBuild(identityset.PrivateKey(2))
+	require.NoError(err)
+
+	// record on a state where caller 0 has counted 5
+	sm := newMemStateManager(9, []*protocol.StateEntry{
+		{Namespace: _counterNamespace, Key: identityset.Address(0).Bytes(), Value: byteutil.Uint64ToBytes(5)},
+		{Namespace: _counterNamespace, Key: identityset.Address(3).Bytes(), Value: byteutil.Uint64ToBytes(7)},
+	})
+	h := NewHarness(&counterProtocol{step: 1}, 0)
+	br, err := h.Record(ctx, sm, &blk)
+	require.NoError(err)
+	// only the touched states are recorded
+	require.Len(br.PreState, 2)
+	require.Equal(byteutil.Uint64ToBytes(5), br.PreState[0].Value)
+	require.Nil(br.PreState[1].Value)
+	require.Len(br.PostState, 2)
+	require.Equal(byteutil.Uint64ToBytes(7), br.PostState[0].Value)
+	require.Equal(byteutil.Uint64ToBytes(1), br.PostState[1].Value)
+	require.Len(br.Receipts, 3)
+
+	rec := &Recording{Protocol: "counter", Blocks: []*BlockRecord{br}}
+	buf := bytes.NewBuffer(nil)
+	require.NoError(rec.Save(buf))
+	rec, err = LoadRecording(buf)
+	require.NoError(err)
+
+	// replay on the same protocol matches the recording
+	mismatches, err := h.Replay(ctx, rec)
+	require.NoError(err)
+	require.Empty(mismatches)
+
+	// replay on a changed protocol reports the difference
+	mismatches, err = NewHarness(&counterProtocol{step: 2}, 0).Replay(ctx, rec)
+	require.NoError(err)
+	require.Len(mismatches, 2)
+	for _, m := range mismatches {
+		require.Equal(MismatchState, m.Kind)
+		require.EqualValues(10, m.Height)
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package replaytest
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+type (
+	// memStateManager is an in-memory state manager seeded with the recorded states
+	memStateManager struct {
+		protocol.Dock
+		height    uint64
+		kv        map[string]map[string][]byte
+		views     protocol.View
+		snapshots []map[string]map[string][]byte
+	}
+
+	stateKey struct {
+		ns  string
+		key string
+	}
+
+	// trackingStateManager wraps a state manager, and tracks the value of states before they are first written,
+	// and the value of states after being written
+	trackingStateManager struct {
+		protocol.StateManager
+		pre       map[stateKey][]byte
+		preOrder  []stateKey
+		post      map[stateKey][]byte
+		postOrder []stateKey
+		snapshots map[int]trackedWrites
+	}
+
+	trackedWrites struct {
+		post      map[stateKey][]byte
+		postOrder []stateKey
+	}
+)
+
+func newMemStateManager(height uint64, entries []*protocol.StateEntry) *memStateManager {
+	sm := &memStateManager{
+		Dock:   protocol.NewDock(),
+		height: height,
+		kv:     make(map[string]map[string][]byte),
+		views:  protocol.View{},
+	}
+	for _, e := range entries {
+		if e.Value != nil {
+			sm.put(e.Namespace, e.Key, e.Value)
+		}
+	}
+	return sm
+}
+
+func (sm *memStateManager) put(ns string, key, value []byte) {
+	if _, ok := sm.kv[ns]; !ok {
+		sm.kv[ns] = make(map[string][]byte)
+	}
+	sm.kv[ns][string(key)] = value
+}
+
+func (sm *memStateManager) Height() (uint64, error) {
+	return sm.height, nil
+}
+
+func (sm *memStateManager) State(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+	cfg, err := protocol.CreateStateConfig(opts...)
+	if err != nil {
+		return 0, err
+	}
+	value, ok := sm.kv[cfg.Namespace][string(cfg.Key)]
+	if !ok {
+		return sm.height, errors.Wrapf(state.ErrStateNotExist, "failed to get state %x in namespace %s", cfg.Key, cfg.Namespace)
+	}
+	return sm.height, state.Deserialize(s, value)
+}
+
+func (sm *memStateManager) States(opts ...protocol.StateOption) (uint64, state.Iterator, error) {
+	cfg, err := protocol.CreateStateConfig(opts...)
+	if err != nil {
+		return 0, nil, err
+	}
+	var keys, values [][]byte
+	if cfg.Keys == nil {
+		ns, ok := sm.kv[cfg.Namespace]
+		if !ok {
+			return sm.height, nil, errors.Wrapf(state.ErrStateNotExist, "namespace %s does not exist", cfg.Namespace)
+		}
+		for k := range ns {
+			keys = append(keys, []byte(k))
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return string(keys[i]) < string(keys[j])
+		})
+		for _, k := range keys {
+			values = append(values, ns[string(k)])
+		}
+	} else {
+		for _, k := range cfg.Keys {
+			keys = append(keys, k)
+			values = append(values, sm.kv[cfg.Namespace][string(k)])
+		}
+	}
+	iter, err := state.NewIterator(keys, values)
+	if err != nil {
+		return 0, nil, err
+	}
+	return sm.height, iter, nil
+}
+
+func (sm *memStateManager) ReadView(name string) (interface{}, error) {
+	return sm.views.Read(name)
+}
+
+func (sm *memStateManager) WriteView(name string, v interface{}) error {
+	return sm.views.Write(name, v)
+}
+
+func (sm *memStateManager) Snapshot() int {
+	snapshot := make(map[string]map[string][]byte, len(sm.kv))
+	for ns, kv := range sm.kv {
+		snapshot[ns] = make(map[string][]byte, len(kv))
+		for k, v := range kv {
+			snapshot[ns][k] = v
+		}
+	}
+	sm.snapshots = append(sm.snapshots, snapshot)
+	return len(sm.snapshots) - 1
+}
+
+func (sm *memStateManager) Revert(snapshot int) error {
+	if snapshot < 0 || snapshot >= len(sm.snapshots) {
+		return errors.Errorf("invalid snapshot %d", snapshot)
+	}
+	sm.kv = sm.snapshots[snapshot]
+	sm.snapshots = sm.snapshots[:snapshot]
+	return nil
+}
+
+func (sm *memStateManager) PutState(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+	cfg, err := protocol.CreateStateConfig(opts...)
+	if err != nil {
+		return 0, err
+	}
+	value, err := state.Serialize(s)
+	if err != nil {
+		return 0, err
+	}
+	sm.put(cfg.Namespace, cfg.Key, value)
+	return sm.height, nil
+}
+
+func (sm *memStateManager) DelState(opts ...protocol.StateOption) (uint64, error) {
+	cfg, err := protocol.CreateStateConfig(opts...)
+	if err != nil {
+		return 0, err
+	}
+	delete(sm.kv[cfg.Namespace], string(cfg.Key))
+	return sm.height, nil
+}
+
+func newTrackingStateManager(sm protocol.StateManager) *trackingStateManager {
+	return &trackingStateManager{
+		StateManager: sm,
+		pre:          make(map[stateKey][]byte),
+		post:         make(map[stateKey][]byte),
+		snapshots:    make(map[int]trackedWrites),
+	}
+}
+
+func (tsm *trackingStateManager) Snapshot() int {
+	post := make(map[stateKey][]byte, len(tsm.post))
+	for k, v := range tsm.post {
+		post[k] = v
+	}
+	snapshot := tsm.StateManager.Snapshot()
+	tsm.snapshots[snapshot] = trackedWrites{
+		post:      post,
+		postOrder: append([]stateKey(nil), tsm.postOrder...),
+	}
+	return snapshot
+}
+
+func (tsm *trackingStateManager) Revert(snapshot int) error {
+	if err := tsm.StateManager.Revert(snapshot); err != nil {
+		return err
+	}
+	if w, ok := tsm.snapshots[snapshot]; ok {
+		tsm.post = w.post
+		tsm.postOrder = w.postOrder
+	}
+	return nil
+}
+
+// trackRead records the value of a state if it has not been read or written before
+func (tsm *trackingStateManager) trackRead(ns string, key, value []byte) {
+	sk := stateKey{ns: ns, key: string(key)}
+	if _, ok := tsm.post[sk]; ok {
+		return
+	}
+	if _, ok := tsm.pre[sk]; ok {
+		return
+	}
+	tsm.pre[sk] = value
+	tsm.preOrder = append(tsm.preOrder, sk)
+}
+
+func (tsm *trackingStateManager) trackWrite(ns string, key, value []byte) {
+	sk := stateKey{ns: ns, key: string(key)}
+	if _, ok := tsm.post[sk]; !ok {
+		tsm.postOrder = append(tsm.postOrder, sk)
+	}
+	tsm.post[sk] = value
+}
+
+func (tsm *trackingStateManager) State(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+	cfg, err := protocol.CreateStateConfig(opts...)
+	if err != nil {
+		return 0, err
+	}
+	var value protocol.SerializableBytes
+	_, err = tsm.StateManager.State(&value, opts...)
+	switch errors.Cause(err) {
+	case nil:
+		tsm.trackRead(cfg.Namespace, cfg.Key, value)
+	case state.ErrStateNotExist:
+		tsm.trackRead(cfg.Namespace, cfg.Key, nil)
+	default:
+		return 0, err
+	}
+	return tsm.StateManager.State(s, opts...)
+}
+
+func (tsm *trackingStateManager) States(opts ...protocol.StateOption) (uint64, state.Iterator, error) {
+	cfg, err := protocol.CreateStateConfig(opts...)
+	if err != nil {
+		return 0, nil, err
+	}
+	height, iter, err := tsm.StateManager.States(opts...)
+	if err != nil {
+		return height, iter, err
+	}
+	keys := make([][]byte, 0, iter.Size())
+	values := make([][]byte, 0, iter.Size())
+	for i := 0; i < iter.Size(); i++ {
+		var value protocol.SerializableBytes
+		key, err := iter.Next(&value)
+		switch errors.Cause(err) {
+		case nil:
+		case state.ErrNilValue:
+			if i < len(cfg.Keys) {
+				key = cfg.Keys[i]
+			}
+			value = nil
+		default:
+			return 0, nil, err
+		}
+		if key != nil {
+			tsm.trackRead(cfg.Namespace, key, value)
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	iter, err = state.NewIterator(keys, values)
+	if err != nil {
+		return 0, nil, err
+	}
+	return height, iter, nil
+}
+
+func (tsm *trackingStateManager) PutState(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+	cfg, err := protocol.CreateStateConfig(opts...)
+	if err != nil {
+		return 0, err
+	}
+	value, err := state.Serialize(s)
+	if err != nil {
+		return 0, err
+	}
+	tsm.trackWrite(cfg.Namespace, cfg.Key, value)
+	return tsm.StateManager.PutState(s, opts...)
+}
+
+func (tsm *trackingStateManager) DelState(opts ...protocol.StateOption) (uint64, error) {
+	cfg, err := protocol.CreateStateConfig(opts...)
+	if err != nil {
+		return 0, err
+	}
+	tsm.trackWrite(cfg.Namespace, cfg.Key, nil)
+	return tsm.StateManager.DelState(opts...)
+}
+
+// preState returns the states read before being written, in the order of first access
+func (tsm *trackingStateManager) preState() []*protocol.StateEntry {
+	entries := make([]*protocol.StateEntry, 0, len(tsm.preOrder))
+	for _, sk := range tsm.preOrder {
+		entries = append(entries, &protocol.StateEntry{
+			Namespace: sk.ns,
+			Key:       []byte(sk.key),
+			Value:     tsm.pre[sk],
+		})
+	}
+	return entries
+}
+
+// postState returns the final value of written states in the order of first write, nil value means deleted
+func (tsm *trackingStateManager) postState() []*protocol.StateEntry {
+	entries := make([]*protocol.StateEntry, 0, len(tsm.postOrder))
+	for _, sk := range tsm.postOrder {
+		entries = append(entries, &protocol.StateEntry{
+			Namespace: sk.ns,
+			Key:       []byte(sk.key),
+			Value:     tsm.post[sk],
+		})
+	}
+	return entries
+}
@@ -260,6 +260,45 @@ func (p *Protocol) SetReward(
 	return p.putState(ctx, sm, _adminKey, &a)
 }
 
+// applyRewardSchedule sets the reward amounts at the height of the reward schedule in effect, and decreases them by
+// the decay percentage at every decay interval afterwards
+func (p *Protocol) applyRewardSchedule(ctx context.Context, sm protocol.StateManager) error {
+	height := protocol.MustGetBlockCtx(ctx).BlockHeight
+	schedule := p.cfg.RewardScheduleAt(height)
+	if schedule == nil {
+		return nil
+	}
+	blockReward, epochReward := schedule.BlockReward(), schedule.EpochReward()
+	if blockReward == nil && epochReward == nil {
+		return nil
+	}
+	elapsed := height - schedule.Height
+	if elapsed > 0 && (schedule.DecayInterval == 0 || elapsed%schedule.DecayInterval != 0) {
+		return nil
+	}
+	a := admin{}
+	if _, err := p.state(ctx, sm, _adminKey, &a); err != nil {
+		return err
+	}
+	if elapsed == 0 {
+		if blockReward != nil {
+			a.blockReward = blockReward
+		}
+		if epochReward != nil {
+			a.epochReward = epochReward
+		}
+	} else {
+		decay := new(big.Int).SetUint64(100 - schedule.DecayPercentage)
+		if blockReward != nil {
+			a.blockReward = new(big.Int).Div(new(big.Int).Mul(a.blockReward, decay), big.NewInt(100))
+		}
+		if epochReward != nil {
+			a.epochReward = new(big.Int).Div(new(big.Int).Mul(a.epochReward, decay), big.NewInt(100))
+		}
+	}
+	return p.putState(ctx, sm, _adminKey, &a)
+}
+
 func (p *Protocol) assertAmount(amount *big.Int) error {
 	if amount.Cmp(big.NewInt(0)) >= 0 {
 		return nil
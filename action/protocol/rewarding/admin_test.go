@@ -51,3 +51,54 @@ func TestProtocol_SetEpochReward(t *testing.T) {
 
 	}, false)
 }
+
+func TestProtocol_ApplyRewardSchedule(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		r := require.New(t)
+		p.cfg.RewardSchedules = []genesis.RewardSchedule{
+			{Height: 100, BlockRewardStr: "20"},
+			{Height: 200, EpochRewardStr: "1000", DecayInterval: 10, DecayPercentage: 10},
+		}
+		r.NoError(validateRewardSchedules(p.cfg))
+		for _, c := range []struct {
+			height      uint64
+			blockReward int64
+			epochReward int64
+		}{
+			{99, 10, 100},
+			{100, 20, 100},
+			{101, 20, 100},
+			{200, 20, 1000},
+			{205, 20, 1000},
+			{210, 20, 900},
+			{219, 20, 900},
+			{220, 20, 810},
+		} {
+			ctx := protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: c.height})
+			r.NoError(p.applyRewardSchedule(ctx, sm))
+			blockReward, err := p.BlockReward(ctx, sm)
+			r.NoError(err)
+			r.Equal(big.NewInt(c.blockReward), blockReward)
+			epochReward, err := p.EpochReward(ctx, sm)
+			r.NoError(err)
+			r.Equal(big.NewInt(c.epochReward), epochReward)
+		}
+	}, false)
+}
+
+func TestValidateRewardSchedules(t *testing.T) {
+	r := require.New(t)
+	g := genesis.TestDefault().Rewarding
+	r.NoError(validateRewardSchedules(g))
+
+	for _, schedules := range [][]genesis.RewardSchedule{
+		{{Height: 0, BlockRewardStr: "1"}},
+		{{Height: 10, BlockRewardStr: "1"}, {Height: 10, EpochRewardStr: "1"}},
+		{{Height: 10, BlockRewardStr: "-1"}},
+		{{Height: 10, EpochRewardStr: "x"}},
+		{{Height: 10, BlockRewardStr: "1", DecayInterval: 1, DecayPercentage: 101}},
+	} {
+		g.RewardSchedules = schedules
+		r.Error(validateRewardSchedules(g))
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// AutoClaimAccountsMethod is the ReadState method to get the accounts opted in to the automatic reward claiming. The
+// response is the json encoded list of the addresses
+const AutoClaimAccountsMethod = "AutoClaimAccounts"
+
+const (
+	_maxAutoClaimAccounts = 1024
+
+	// _autoClaimJSONABI is the call to opt in or out of the automatic reward claiming, which is sent as an execution
+	// to the rewarding protocol address
+	_autoClaimJSONABI = `[
+		{
+			"inputs": [
+				{"internalType": "bool", "name": "enabled", "type": "bool"}
+			],
+			"name": "setAutoClaim",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	_autoClaimKey = []byte("atc")
+
+	_setAutoClaimMethod abi.Method
+
+	// ErrInvalidAutoClaimCall indicates the auto claim call is malformed
+	ErrInvalidAutoClaimCall = errors.New("invalid auto claim call")
+)
+
+type (
+	// SetAutoClaimCall is the call to opt in or out of claiming the unclaimed balance of the caller into its account
+	// automatically at the end of each epoch
+	SetAutoClaimCall struct {
+		Enabled bool
+	}
+
+	// autoClaim is the list of accounts opted in to the automatic reward claiming, in the order of opting in
+	autoClaim struct {
+		addrs []address.Address
+	}
+)
+
+func init() {
+	contractABI, err := abi.JSON(strings.NewReader(_autoClaimJSONABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	if _setAutoClaimMethod, ok = contractABI.Methods["setAutoClaim"]; !ok {
+		panic("fail to load the method setAutoClaim")
+	}
+}
+
+// EncodeSetAutoClaimCall encodes the call into the data of an execution sent to the rewarding protocol address
+func EncodeSetAutoClaimCall(call *SetAutoClaimCall) ([]byte, error) {
+	data, err := _setAutoClaimMethod.Inputs.Pack(call.Enabled)
+	if err != nil {
+		return nil, err
+	}
+	return append(_setAutoClaimMethod.ID, data...), nil
+}
+
+// decodeSetAutoClaimCall decodes the call, and returns nil if the data is not an auto claim call
+func decodeSetAutoClaimCall(data []byte) (*SetAutoClaimCall, error) {
+	if len(data) < 4 || string(data[:4]) != string(_setAutoClaimMethod.ID) {
+		return nil, nil
+	}
+	args, err := _setAutoClaimMethod.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidAutoClaimCall, err.Error())
+	}
+	enabled, ok := args[0].(bool)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidAutoClaimCall, "invalid arguments")
+	}
+	return &SetAutoClaimCall{Enabled: enabled}, nil
+}
+
+// autoClaimCall returns the auto claim call if the action is an execution sent to the rewarding protocol address
+// carrying the call, nil otherwise
+func (p *Protocol) autoClaimCall(ctx context.Context, act *action.Execution) (*SetAutoClaimCall, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnableAutoClaim || act.Contract() != p.addr.String() {
+		return nil, nil
+	}
+	return decodeSetAutoClaimCall(act.Data())
+}
+
+func (p *Protocol) validateAutoClaimCall(ctx context.Context, act *action.Execution) error {
+	call, err := p.autoClaimCall(ctx, act)
+	if call == nil || err != nil {
+		return err
+	}
+	if act.Amount() != nil && act.Amount().Sign() != 0 {
+		return errors.Wrap(ErrInvalidAutoClaimCall, "cannot send amount with auto claim call")
+	}
+	return nil
+}
+
+// SetAutoClaim opts the account in or out of the automatic reward claiming. Only an account which has been granted
+// reward can opt in
+func (p *Protocol) SetAutoClaim(ctx context.Context, sm protocol.StateManager, addr address.Address, enabled bool) error {
+	ac := autoClaim{}
+	if _, err := p.state(ctx, sm, _autoClaimKey, &ac); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	idx := ac.index(addr)
+	if !enabled {
+		if idx < 0 {
+			return nil
+		}
+		ac.addrs = append(ac.addrs[:idx], ac.addrs[idx+1:]...)
+		return p.putState(ctx, sm, _autoClaimKey, &ac)
+	}
+	if idx >= 0 {
+		return nil
+	}
+	if len(ac.addrs) >= _maxAutoClaimAccounts {
+		return errors.Errorf("auto claim has reached %d accounts", _maxAutoClaimAccounts)
+	}
+	acc := rewardAccount{}
+	if _, err := p.state(ctx, sm, append(_adminKey, addr.Bytes()...), &acc); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return errors.Errorf("%s has no reward account", addr.String())
+		}
+		return err
+	}
+	ac.addrs = append(ac.addrs, addr)
+	return p.putState(ctx, sm, _autoClaimKey, &ac)
+}
+
+// claimForAutoClaimAccounts claims the unclaimed balance of each account opted in to the automatic reward claiming
+// into the account. An account failing to claim is skipped, so that it does not fail the epoch reward of the others
+func (p *Protocol) claimForAutoClaimAccounts(ctx context.Context, sm protocol.StateManager) ([]*action.TransactionLog, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnableAutoClaim {
+		return nil, nil
+	}
+	ac := autoClaim{}
+	if _, err := p.state(ctx, sm, _autoClaimKey, &ac); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+	tLogs := make([]*action.TransactionLog, 0, len(ac.addrs))
+	for _, addr := range ac.addrs {
+		balance, _, err := p.UnclaimedBalance(ctx, sm, addr)
+		if err != nil {
+			return nil, err
+		}
+		if balance.Sign() == 0 {
+			continue
+		}
+		si := sm.Snapshot()
+		tLog, err := p.Claim(ctx, sm, balance, addr)
+		if err != nil {
+			log.L().Warn("Failed to auto claim reward", zap.String("address", addr.String()), zap.Error(err))
+			if err := sm.Revert(si); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		tLogs = append(tLogs, tLog)
+	}
+	return tLogs, nil
+}
+
+func (p *Protocol) readAutoClaimAccounts(ctx context.Context, sr protocol.StateReader) ([]byte, uint64, error) {
+	ac := autoClaim{}
+	height, err := p.state(ctx, sr, _autoClaimKey, &ac)
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, height, err
+	}
+	addrs := make([]string, 0, len(ac.addrs))
+	for _, addr := range ac.addrs {
+		addrs = append(addrs, addr.String())
+	}
+	data, err := json.Marshal(addrs)
+	if err != nil {
+		return nil, height, err
+	}
+	return data, height, nil
+}
+
+func (ac *autoClaim) index(addr address.Address) int {
+	for i, a := range ac.addrs {
+		if address.Equal(a, addr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Serialize serializes the auto claim accounts into bytes
+func (ac *autoClaim) Serialize() ([]byte, error) {
+	data := binary.AppendUvarint(nil, uint64(len(ac.addrs)))
+	for _, addr := range ac.addrs {
+		data = append(data, addr.Bytes()...)
+	}
+	return data, nil
+}
+
+// Deserialize deserializes bytes into the auto claim accounts
+func (ac *autoClaim) Deserialize(data []byte) error {
+	errInvalid := errors.New("invalid auto claim accounts")
+	count, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) != count*uint64(common.AddressLength) {
+		return errInvalid
+	}
+	data = data[n:]
+	addrs := make([]address.Address, 0, count)
+	for i := uint64(0); i < count; i++ {
+		addr, err := address.FromBytes(data[:common.AddressLength])
+		if err != nil {
+			return errors.Wrap(errInvalid, err.Error())
+		}
+		addrs = append(addrs, addr)
+		data = data[common.AddressLength:]
+	}
+	ac.addrs = addrs
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
+)
+
+func TestAutoClaimCall(t *testing.T) {
+	require := require.New(t)
+	for _, enabled := range []bool{true, false} {
+		data, err := EncodeSetAutoClaimCall(&SetAutoClaimCall{Enabled: enabled})
+		require.NoError(err)
+		call, err := decodeSetAutoClaimCall(data)
+		require.NoError(err)
+		require.Equal(enabled, call.Enabled)
+
+		_, err = decodeSetAutoClaimCall(data[:len(data)-1])
+		require.ErrorIs(err, ErrInvalidAutoClaimCall)
+	}
+	// not an auto claim call
+	call, err := decodeSetAutoClaimCall([]byte{1, 2, 3, 4})
+	require.NoError(err)
+	require.Nil(call)
+	call, err = decodeSetAutoClaimCall(nil)
+	require.NoError(err)
+	require.Nil(call)
+
+	ac := autoClaim{addrs: []address.Address{identityset.Address(1), identityset.Address(2)}}
+	data, err := ac.Serialize()
+	require.NoError(err)
+	decoded := autoClaim{}
+	require.NoError(decoded.Deserialize(data))
+	require.Equal(ac, decoded)
+	require.Error(decoded.Deserialize(data[:len(data)-1]))
+}
+
+func TestProtocol_AutoClaim(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		require := require.New(t)
+		g := genesis.MustExtractGenesisContext(ctx)
+		g.ToBeEnabledBlockHeight = 0
+		ctx = protocol.WithFeatureWithHeightCtx(protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, g)))
+
+		_, err := p.Deposit(ctx, sm, big.NewInt(200), iotextypes.TransactionLogType_DEPOSIT_TO_REWARDING_FUND)
+		require.NoError(err)
+		_, err = p.GrantBlockReward(ctx, sm)
+		require.NoError(err)
+
+		var (
+			beneficiary = identityset.Address(0)
+			other       = identityset.Address(28)
+		)
+		setAutoClaim := func(caller address.Address, nonce uint64, amount *big.Int, enabled bool) (*action.Receipt, error) {
+			data, err := EncodeSetAutoClaimCall(&SetAutoClaimCall{Enabled: enabled})
+			require.NoError(err)
+			elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(0)).
+				SetAction(action.NewExecution(p.addr.String(), amount, data)).Build()
+			callCtx := protocol.WithActionCtx(ctx, protocol.ActionCtx{
+				Caller:   caller,
+				GasPrice: big.NewInt(0),
+				Nonce:    nonce,
+			})
+			if err := p.Validate(callCtx, elp, sm); err != nil {
+				return nil, err
+			}
+			return p.Handle(callCtx, elp, sm)
+		}
+		// cannot send amount with the call
+		_, err = setAutoClaim(beneficiary, 0, big.NewInt(1), true)
+		require.ErrorIs(err, ErrInvalidAutoClaimCall)
+		// an account without reward account cannot opt in
+		require.ErrorContains(p.SetAutoClaim(ctx, sm, identityset.Address(1), true), "no reward account")
+		receipt, err := setAutoClaim(beneficiary, 0, big.NewInt(0), true)
+		require.NoError(err)
+		require.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		// opting in twice is a no-op
+		require.NoError(p.SetAutoClaim(ctx, sm, beneficiary, true))
+		data, _, err := p.ReadState(ctx, sm, []byte(AutoClaimAccountsMethod))
+		require.NoError(err)
+		var addrs []string
+		require.NoError(json.Unmarshal(data, &addrs))
+		require.Equal([]string{beneficiary.String()}, addrs)
+
+		// the unclaimed balance is moved to the account at the end of the epoch
+		acc, err := accountutil.LoadAccount(sm, beneficiary)
+		require.NoError(err)
+		initBalance := acc.Balance
+		otherUnclaimed, _, err := p.UnclaimedBalance(ctx, sm, other)
+		require.NoError(err)
+		grantCtx := protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:   protocol.MustGetBlockCtx(ctx).Producer,
+			GasPrice: big.NewInt(0),
+		})
+		receipt, err = p.Handle(grantCtx, createGrantRewardAction(action.EpochReward, protocol.MustGetBlockCtx(ctx).BlockHeight), sm)
+		require.NoError(err)
+		require.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		var claimed *action.TransactionLog
+		for _, tLog := range receipt.TransactionLogs() {
+			if tLog.Type == iotextypes.TransactionLogType_CLAIM_FROM_REWARDING_FUND {
+				require.Nil(claimed)
+				claimed = tLog
+			}
+		}
+		require.NotNil(claimed)
+		require.Equal(beneficiary.String(), claimed.Recipient)
+		require.True(claimed.Amount.Sign() > 0)
+		unclaimed, _, err := p.UnclaimedBalance(ctx, sm, beneficiary)
+		require.NoError(err)
+		require.Zero(unclaimed.Sign())
+		acc, err = accountutil.LoadAccount(sm, beneficiary)
+		require.NoError(err)
+		require.Equal(new(big.Int).Add(initBalance, claimed.Amount), acc.Balance)
+		// the account not opted in keeps the reward unclaimed
+		unclaimed, _, err = p.UnclaimedBalance(ctx, sm, other)
+		require.NoError(err)
+		require.Equal(1, unclaimed.Cmp(otherUnclaimed))
+
+		// opt out
+		receipt, err = setAutoClaim(beneficiary, 1, big.NewInt(0), false)
+		require.NoError(err)
+		require.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		data, _, err = p.ReadState(ctx, sm, []byte(AutoClaimAccountsMethod))
+		require.NoError(err)
+		require.NoError(json.Unmarshal(data, &addrs))
+		require.Empty(addrs)
+
+		// the executions other than the auto claim calls are left to the execution protocol
+		receipt, err = p.Handle(ctx, (&action.EnvelopeBuilder{}).SetNonce(2).
+			SetAction(action.NewExecution(p.addr.String(), big.NewInt(0), []byte{1, 2, 3, 4})).Build(), sm)
+		require.NoError(err)
+		require.Nil(receipt)
+	}, false)
+}
+
+func TestProtocol_AutoClaimSkipFailure(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		require := require.New(t)
+		g := genesis.MustExtractGenesisContext(ctx)
+		g.ToBeEnabledBlockHeight = 0
+		ctx = protocol.WithFeatureWithHeightCtx(protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, g)))
+		sm.(*mock_chainmanager.MockStateManager).EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+
+		_, err := p.Deposit(ctx, sm, big.NewInt(200), iotextypes.TransactionLogType_DEPOSIT_TO_REWARDING_FUND)
+		require.NoError(err)
+		_, err = p.GrantBlockReward(ctx, sm)
+		require.NoError(err)
+		_, err = p.GrantEpochReward(ctx, sm)
+		require.NoError(err)
+
+		var (
+			first  = identityset.Address(0)
+			second = identityset.Address(28)
+		)
+		require.NoError(p.SetAutoClaim(ctx, sm, first, true))
+		require.NoError(p.SetAutoClaim(ctx, sm, second, true))
+		firstUnclaimed, _, err := p.UnclaimedBalance(ctx, sm, first)
+		require.NoError(err)
+		secondUnclaimed, _, err := p.UnclaimedBalance(ctx, sm, second)
+		require.NoError(err)
+		require.Equal(1, firstUnclaimed.Cmp(secondUnclaimed))
+
+		// the fund is only enough for the second account
+		f := fund{}
+		_, err = p.state(ctx, sm, _fundKey, &f)
+		require.NoError(err)
+		f.totalBalance = new(big.Int).Set(secondUnclaimed)
+		require.NoError(p.putState(ctx, sm, _fundKey, &f))
+
+		tLogs, err := p.claimForAutoClaimAccounts(ctx, sm)
+		require.NoError(err)
+		require.Len(tLogs, 1)
+		require.Equal(second.String(), tLogs[0].Recipient)
+		require.Equal(secondUnclaimed, tLogs[0].Amount)
+		unclaimed, _, err := p.UnclaimedBalance(ctx, sm, first)
+		require.NoError(err)
+		require.Equal(firstUnclaimed, unclaimed)
+		unclaimed, _, err = p.UnclaimedBalance(ctx, sm, second)
+		require.NoError(err)
+		require.Zero(unclaimed.Sign())
+	}, false)
+}
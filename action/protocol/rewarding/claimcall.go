@@ -0,0 +1,103 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+)
+
+// claimCallTx is the claim action taken as a call to the claimed contract with the data of the claim
+type claimCallTx struct {
+	action.TxData
+	to   *common.Address
+	data []byte
+}
+
+func (tx *claimCallTx) Value() *big.Int {
+	return big.NewInt(0)
+}
+
+func (tx *claimCallTx) To() *common.Address {
+	return tx.to
+}
+
+func (tx *claimCallTx) Data() []byte {
+	return tx.data
+}
+
+// isClaimCall returns true if the claim is to a contract with the calldata attached, in which case the data is
+// executed on the contract after the reward is claimed, so that a payout contract can distribute the reward atomically
+func (p *Protocol) isClaimCall(ctx context.Context, sr protocol.StateReader, act *action.ClaimFromRewardingFund) (bool, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnableClaimRewardCall || p.getBlockHash == nil ||
+		act.Address() == nil || len(act.Data()) == 0 {
+		return false, nil
+	}
+	acc, err := accountutil.AccountState(ctx, sr, act.Address())
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to load the account of %s", act.Address().String())
+	}
+	return acc.IsContract(), nil
+}
+
+// callClaimedContract calls the claimed contract with the data of the claim. The call is sent by the caller of the
+// claim, with the gas charged as an execution instead of the intrinsic gas of the claim. If the call fails, the claim
+// is reverted as well, while the gas consumed is charged.
+func (p *Protocol) callClaimedContract(
+	ctx context.Context,
+	sm protocol.StateManager,
+	elp action.Envelope,
+	act *action.ClaimFromRewardingFund,
+	si int,
+	claimLog *action.TransactionLog,
+) (*action.Receipt, error) {
+	to := common.BytesToAddress(act.Address().Bytes())
+	tx := &claimCallTx{
+		TxData: elp,
+		to:     &to,
+		data:   act.Data(),
+	}
+	if elp.Gas() < action.ExecutionBaseIntrinsicGas+action.ExecutionDataGas*uint64(len(tx.data))+accessListGas(elp) {
+		return p.settleUserAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+	}
+	ctx = evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash:   p.getBlockHash,
+		GetBlockTime:   p.getBlockTime,
+		DepositGasFunc: DepositGas,
+	})
+	_, receipt, err := evm.ExecuteContract(ctx, sm, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call the claimed contract")
+	}
+	if receipt.Status == uint64(iotextypes.ReceiptStatus_Success) {
+		return receipt.AddTransactionLogs(claimLog), nil
+	}
+	if err := sm.Revert(si); err != nil {
+		return nil, err
+	}
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	actionCtx.IntrinsicGas = receipt.GasConsumed
+	// the state has been reverted, settle the action on a new snapshot
+	r, err := p.settleUserAction(protocol.WithActionCtx(ctx, actionCtx), sm, elp, receipt.Status, sm.Snapshot(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.SetExecutionRevertMsg(receipt.ExecutionRevertMsg()), nil
+}
+
+func accessListGas(elp action.TxCommon) uint64 {
+	list := elp.AccessList()
+	return uint64(len(list))*action.TxAccessListAddressGas + uint64(list.StorageKeys())*action.TxAccessListStorageKeyGas
+}
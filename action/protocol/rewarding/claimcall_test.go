@@ -0,0 +1,77 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestIsClaimCall(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+
+	g := genesis.TestDefault()
+	g.ToBeEnabledBlockHeight = 1
+	ctx := genesis.WithGenesisContext(context.Background(), g)
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 1})
+	ctx = protocol.WithFeatureCtx(ctx)
+
+	var (
+		getBlockHash = func(uint64) (hash.Hash256, error) { return hash.ZeroHash256, nil }
+		getBlockTime = func(uint64) (time.Time, error) { return time.Time{}, nil }
+		p            = NewProtocol(g.Rewarding, EnableClaimCall(getBlockHash, getBlockTime))
+		contract     = identityset.Address(1)
+		user         = identityset.Address(2)
+	)
+	acc, err := accountutil.LoadOrCreateAccount(sm, contract)
+	require.NoError(err)
+	acc.CodeHash = common.Hash{1}.Bytes()
+	require.NoError(accountutil.StoreAccount(sm, contract, acc))
+
+	for _, c := range []struct {
+		p      *Protocol
+		claim  *action.ClaimFromRewardingFund
+		expect bool
+	}{
+		// claim to a contract with data
+		{p, action.NewClaimFromRewardingFund(big.NewInt(1), contract, []byte{1}), true},
+		// claim to a contract without data
+		{p, action.NewClaimFromRewardingFund(big.NewInt(1), contract, nil), false},
+		// claim to an account with data
+		{p, action.NewClaimFromRewardingFund(big.NewInt(1), user, []byte{1}), false},
+		// claim for the caller
+		{p, action.NewClaimFromRewardingFund(big.NewInt(1), nil, []byte{1}), false},
+		// claim call is not enabled in the protocol
+		{NewProtocol(g.Rewarding), action.NewClaimFromRewardingFund(big.NewInt(1), contract, []byte{1}), false},
+	} {
+		isCall, err := c.p.isClaimCall(ctx, sm, c.claim)
+		require.NoError(err)
+		require.Equal(c.expect, isCall)
+	}
+
+	// claim call is not activated yet
+	g.ToBeEnabledBlockHeight = 2
+	ctx = protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, g))
+	isCall, err := p.isClaimCall(ctx, sm, action.NewClaimFromRewardingFund(big.NewInt(1), contract, []byte{1}))
+	require.NoError(err)
+	require.False(isCall)
+}
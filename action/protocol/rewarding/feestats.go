@@ -0,0 +1,196 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/pkg/unit"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// FeeStatsMethod is the ReadState method to get the base fee and the priority fee charged, in the block and in
+// total. The response is the json encoded FeeStats
+const FeeStatsMethod = "FeeStats"
+
+var (
+	_feeStatsKey = []byte("fst")
+
+	_feeMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_fee_metrics",
+			Help: "Fee metrics in IOTX.",
+		},
+		[]string{"type"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_feeMtc)
+}
+
+type (
+	// FeeStats is the fee accounting at a height. The base fee includes the share sent to the treasury, the rest of
+	// it is deposited into the rewarding fund, so is the priority fee as the block producer reward
+	FeeStats struct {
+		Height           uint64 `json:"height"`
+		BlockBaseFee     string `json:"blockBaseFee"`
+		BlockPriorityFee string `json:"blockPriorityFee"`
+		TotalBaseFee     string `json:"totalBaseFee"`
+		TotalPriorityFee string `json:"totalPriorityFee"`
+	}
+
+	// feeStats stores the fees of the last block charging fees and the cumulative fees
+	feeStats struct {
+		height           uint64
+		blockBaseFee     *big.Int
+		blockPriorityFee *big.Int
+		totalBaseFee     *big.Int
+		totalPriorityFee *big.Int
+	}
+)
+
+func newFeeStats() *feeStats {
+	return &feeStats{
+		blockBaseFee:     big.NewInt(0),
+		blockPriorityFee: big.NewInt(0),
+		totalBaseFee:     big.NewInt(0),
+		totalPriorityFee: big.NewInt(0),
+	}
+}
+
+func (s *feeStats) amounts() []*big.Int {
+	return []*big.Int{s.blockBaseFee, s.blockPriorityFee, s.totalBaseFee, s.totalPriorityFee}
+}
+
+// Serialize serializes the fee stats into bytes
+func (s *feeStats) Serialize() ([]byte, error) {
+	data := byteutil.Uint64ToBytesBigEndian(s.height)
+	for _, v := range s.amounts() {
+		b := v.Bytes()
+		data = binary.AppendUvarint(data, uint64(len(b)))
+		data = append(data, b...)
+	}
+	return data, nil
+}
+
+// Deserialize deserializes bytes into the fee stats
+func (s *feeStats) Deserialize(data []byte) error {
+	if len(data) < 8 {
+		return errors.New("invalid fee stats")
+	}
+	stats := newFeeStats()
+	stats.height = byteutil.BytesToUint64BigEndian(data[:8])
+	data = data[8:]
+	for _, v := range stats.amounts() {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return errors.New("invalid fee stats")
+		}
+		v.SetBytes(data[n : n+int(size)])
+		data = data[n+int(size):]
+	}
+	*s = *stats
+	return nil
+}
+
+// add adds the fees charged at the height
+func (s *feeStats) add(height uint64, baseFee, priorityFee *big.Int) {
+	if s.height != height {
+		s.height = height
+		s.blockBaseFee = big.NewInt(0)
+		s.blockPriorityFee = big.NewInt(0)
+	}
+	if baseFee != nil {
+		s.blockBaseFee.Add(s.blockBaseFee, baseFee)
+		s.totalBaseFee.Add(s.totalBaseFee, baseFee)
+	}
+	if priorityFee != nil {
+		s.blockPriorityFee.Add(s.blockPriorityFee, priorityFee)
+		s.totalPriorityFee.Add(s.totalPriorityFee, priorityFee)
+	}
+}
+
+// at returns the fee stats at the height, the fees of the block are zero if no fee is charged at the height
+func (s *feeStats) at(height uint64) *FeeStats {
+	stats := &FeeStats{
+		Height:           height,
+		BlockBaseFee:     "0",
+		BlockPriorityFee: "0",
+		TotalBaseFee:     s.totalBaseFee.String(),
+		TotalPriorityFee: s.totalPriorityFee.String(),
+	}
+	if s.height == height {
+		stats.BlockBaseFee = s.blockBaseFee.String()
+		stats.BlockPriorityFee = s.blockPriorityFee.String()
+	}
+	return stats
+}
+
+// recordFees adds the fees charged by the current action to the fee stats
+func (p *Protocol) recordFees(ctx context.Context, sm protocol.StateManager, baseFee, priorityFee *big.Int) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableFeeStats || isZero(baseFee) && isZero(priorityFee) {
+		return nil
+	}
+	stats := newFeeStats()
+	if _, err := p.state(ctx, sm, _feeStatsKey, stats); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	stats.add(protocol.MustGetBlockCtx(ctx).BlockHeight, baseFee, priorityFee)
+	if err := p.putState(ctx, sm, _feeStatsKey, stats); err != nil {
+		return err
+	}
+	// stash the stats to update the metrics upon commit
+	return sm.Load(_protocolID, string(_feeStatsKey), stats)
+}
+
+// Commit updates the fee metrics with the fee stats of the committed block
+func (p *Protocol) Commit(ctx context.Context, sm protocol.StateManager) error {
+	stats := newFeeStats()
+	if err := sm.Unload(_protocolID, string(_feeStatsKey), stats); err != nil {
+		if err == protocol.ErrNoName {
+			return nil
+		}
+		return err
+	}
+	for _, m := range []struct {
+		name   string
+		amount *big.Int
+	}{
+		{"blockBaseFee", stats.blockBaseFee},
+		{"blockPriorityFee", stats.blockPriorityFee},
+		{"totalBaseFee", stats.totalBaseFee},
+		{"totalPriorityFee", stats.totalPriorityFee},
+	} {
+		iotx, _ := new(big.Float).Quo(new(big.Float).SetInt(m.amount), new(big.Float).SetInt64(unit.Iotx)).Float64()
+		_feeMtc.WithLabelValues(m.name).Set(iotx)
+	}
+	return nil
+}
+
+func (p *Protocol) readFeeStats(ctx context.Context, sr protocol.StateReader) ([]byte, uint64, error) {
+	height, err := sr.Height()
+	if err != nil {
+		return nil, 0, err
+	}
+	stats := newFeeStats()
+	if _, err := p.state(ctx, sr, _feeStatsKey, stats); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, height, err
+	}
+	data, err := json.Marshal(stats.at(height))
+	if err != nil {
+		return nil, height, err
+	}
+	return data, height, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeStats(t *testing.T) {
+	require := require.New(t)
+	stats := newFeeStats()
+	stats.add(10, big.NewInt(100), big.NewInt(1))
+	stats.add(10, big.NewInt(200), nil)
+	require.Equal(&FeeStats{
+		Height:           10,
+		BlockBaseFee:     "300",
+		BlockPriorityFee: "1",
+		TotalBaseFee:     "300",
+		TotalPriorityFee: "1",
+	}, stats.at(10))
+
+	// fees of a new block
+	stats.add(12, big.NewInt(50), big.NewInt(5))
+	data, err := stats.Serialize()
+	require.NoError(err)
+	decoded := newFeeStats()
+	require.NoError(decoded.Deserialize(data))
+	require.Equal(&FeeStats{
+		Height:           12,
+		BlockBaseFee:     "50",
+		BlockPriorityFee: "5",
+		TotalBaseFee:     "350",
+		TotalPriorityFee: "6",
+	}, decoded.at(12))
+	// no fee is charged in the block
+	require.Equal(&FeeStats{
+		Height:           13,
+		BlockBaseFee:     "0",
+		BlockPriorityFee: "0",
+		TotalBaseFee:     "350",
+		TotalPriorityFee: "6",
+	}, decoded.at(13))
+
+	require.Error(decoded.Deserialize(data[:len(data)-1]))
+	require.Error(decoded.Deserialize(data[:7]))
+}
@@ -153,12 +153,31 @@ func DepositGas(ctx context.Context, sm protocol.StateManager, amount *big.Int,
 		logs []*action.TransactionLog
 		err  error
 	)
+	var (
+		treasury    address.Address
+		treasuryFee *big.Int
+	)
 	if !isZero(amount) {
-		logs, err = rp.Deposit(ctx, sm, amount, iotextypes.TransactionLogType_GAS_FEE)
+		treasury, treasuryFee, err = rp.treasuryFee(ctx, amount)
+		if err != nil {
+			return nil, err
+		}
+		fundFee := amount
+		if !isZero(treasuryFee) {
+			fundFee = new(big.Int).Sub(amount, treasuryFee)
+		}
+		logs, err = rp.Deposit(ctx, sm, fundFee, iotextypes.TransactionLogType_GAS_FEE)
 		if err != nil {
 			return nil, err
 		}
 	}
+	if !isZero(treasuryFee) {
+		tlog, err := rp.depositTreasury(ctx, sm, treasury, treasuryFee)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, tlog)
+	}
 	cfg := protocol.DepositOptionCfg{}
 	for _, opt := range opts {
 		opt(&cfg)
@@ -177,9 +196,64 @@ func DepositGas(ctx context.Context, sm protocol.StateManager, amount *big.Int,
 		}
 		logs = append(logs, slogs...)
 	}
+	if err := rp.recordFees(ctx, sm, amount, cfg.PriorityFee); err != nil {
+		return nil, err
+	}
 	return logs, nil
 }
 
+// treasuryFee returns the treasury address and the share of base fee redirected to it
+func (p *Protocol) treasuryFee(ctx context.Context, baseFee *big.Int) (address.Address, *big.Int, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnableBaseFeeTreasury || p.cfg.TreasuryBaseFeePercentage == 0 {
+		return nil, nil, nil
+	}
+	treasury, err := p.cfg.TreasuryAddr()
+	if err != nil || treasury == nil {
+		return nil, nil, err
+	}
+	fee := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(p.cfg.TreasuryBaseFeePercentage))
+	return treasury, fee.Div(fee, big.NewInt(100)), nil
+}
+
+// depositTreasury transfers the treasury share of base fee from caller to the treasury account. iotex-proto has no
+// transaction log type for the treasury transfer yet, so it is logged as GAS_FEE, and is told apart from the deposit
+// into the rewarding fund by the recipient being the treasury instead of the rewarding protocol
+func (p *Protocol) depositTreasury(ctx context.Context, sm protocol.StateManager, treasury address.Address, amount *big.Int) (*action.TransactionLog, error) {
+	var (
+		actionCtx           = protocol.MustGetActionCtx(ctx)
+		accountCreationOpts = []state.AccountCreationOption{}
+	)
+	if protocol.MustGetFeatureCtx(ctx).CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	acc, err := accountutil.LoadAccount(sm, actionCtx.Caller, accountCreationOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.SubBalance(amount); err != nil {
+		return nil, err
+	}
+	if err := accountutil.StoreAccount(sm, actionCtx.Caller, acc); err != nil {
+		return nil, err
+	}
+	treasuryAcc, err := accountutil.LoadOrCreateAccount(sm, treasury, accountCreationOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := treasuryAcc.AddBalance(amount); err != nil {
+		return nil, err
+	}
+	if err := accountutil.StoreAccount(sm, treasury, treasuryAcc); err != nil {
+		return nil, err
+	}
+	return &action.TransactionLog{
+		Type:      iotextypes.TransactionLogType_GAS_FEE,
+		Sender:    actionCtx.Caller.String(),
+		Recipient: treasury.String(),
+		Amount:    amount,
+	}, nil
+}
+
 func isZero(a *big.Int) bool {
 	return a == nil || len(a.Bytes()) == 0
 }
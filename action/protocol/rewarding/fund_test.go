@@ -18,6 +18,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 )
 
 func TestProtocol_Fund(t *testing.T) {
@@ -56,3 +57,45 @@ func TestDepositNegativeGasFee(t *testing.T) {
 		require.Error(t, err)
 	}, false)
 }
+
+func TestDepositGasToTreasury(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		treasury := protocol.HashStringToAddress("treasury")
+		p.cfg.TreasuryAddrStr = treasury.String()
+		p.cfg.TreasuryBaseFeePercentage = 20
+
+		// treasury is not enabled yet
+		logs, err := DepositGas(ctx, sm, big.NewInt(10))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(logs))
+		require.Equal(t, iotextypes.TransactionLogType_GAS_FEE, logs[0].Type)
+
+		g := genesis.MustExtractGenesisContext(ctx)
+		g.ToBeEnabledBlockHeight = 0
+		ctx = protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, g))
+		logs, err = DepositGas(ctx, sm, big.NewInt(10))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(logs))
+		require.Equal(t, iotextypes.TransactionLogType_GAS_FEE, logs[0].Type)
+		require.Equal(t, big.NewInt(8), logs[0].Amount)
+		require.Equal(t, address.RewardingPoolAddr, logs[0].Recipient)
+		require.Equal(t, iotextypes.TransactionLogType_GAS_FEE, logs[1].Type)
+		require.Equal(t, big.NewInt(2), logs[1].Amount)
+		require.Equal(t, treasury.String(), logs[1].Recipient)
+
+		totalBalance, _, err := p.TotalBalance(ctx, sm)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(18), totalBalance)
+		acc, err := accountutil.LoadAccount(sm, treasury)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(2), acc.Balance)
+		acc, err = accountutil.LoadAccount(sm, protocol.MustGetActionCtx(ctx).Caller)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(980), acc.Balance)
+		// the fee stats count the base fee including the treasury share
+		stats := newFeeStats()
+		_, err = p.state(ctx, sm, _feeStatsKey, stats)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(10), stats.totalBaseFee)
+	}, false)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
@@ -45,13 +46,30 @@ var (
 // reward amount, users to donate tokens to the fund, block producers to grant them block and epoch reward and,
 // beneficiaries to claim the balance into their personal account.
 type Protocol struct {
-	keyPrefix []byte
-	addr      address.Address
-	cfg       genesis.Rewarding
+	keyPrefix    []byte
+	addr         address.Address
+	cfg          genesis.Rewarding
+	getBlockHash evm.GetBlockHash
+	getBlockTime evm.GetBlockTime
+}
+
+// Option is optional setting for rewarding protocol
+type Option func(*Protocol) error
+
+// EnableClaimCall enables claiming to a contract with the calldata of the claim executed in the same action
+func EnableClaimCall(getBlockHash evm.GetBlockHash, getBlockTime evm.GetBlockTime) Option {
+	return func(p *Protocol) error {
+		if getBlockHash == nil || getBlockTime == nil {
+			return errors.New("block hash and block time getters are required")
+		}
+		p.getBlockHash = getBlockHash
+		p.getBlockTime = getBlockTime
+		return nil
+	}
 }
 
 // NewProtocol instantiates a rewarding protocol instance.
-func NewProtocol(cfg genesis.Rewarding) *Protocol {
+func NewProtocol(cfg genesis.Rewarding, opts ...Option) *Protocol {
 	h := hash.Hash160b([]byte(_protocolID))
 	addr, err := address.FromBytes(h[:])
 	if err != nil {
@@ -60,11 +78,20 @@ func NewProtocol(cfg genesis.Rewarding) *Protocol {
 	if err = validateFoundationBonusExtension(cfg); err != nil {
 		log.L().Panic("failed to validate foundation bonus extension", zap.Error(err))
 	}
-	return &Protocol{
+	if err = validateRewardSchedules(cfg); err != nil {
+		log.L().Panic("failed to validate reward schedules", zap.Error(err))
+	}
+	p := &Protocol{
 		keyPrefix: h[:],
 		addr:      addr,
 		cfg:       cfg,
 	}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			log.L().Panic("failed to execute rewarding protocol creation option", zap.Error(err))
+		}
+	}
+	return p
 }
 
 // ProtocolAddr returns the address generated from protocol id
@@ -82,6 +109,29 @@ func validateFoundationBonusExtension(cfg genesis.Rewarding) error {
 	return nil
 }
 
+// verify that reward schedules are in increasing order of height with valid amounts
+func validateRewardSchedules(cfg genesis.Rewarding) error {
+	var last uint64
+	for i, s := range cfg.RewardSchedules {
+		if s.Height == 0 || (i > 0 && s.Height <= last) {
+			return errors.Errorf("invalid height %d of reward schedule %d", s.Height, i)
+		}
+		last = s.Height
+		for _, amount := range []string{s.BlockRewardStr, s.EpochRewardStr} {
+			if amount == "" {
+				continue
+			}
+			if val, ok := new(big.Int).SetString(amount, 10); !ok || val.Sign() < 0 {
+				return errors.Errorf("invalid amount %s of reward schedule %d", amount, i)
+			}
+		}
+		if s.DecayPercentage > 100 {
+			return errors.Errorf("invalid decay percentage %d of reward schedule %d", s.DecayPercentage, i)
+		}
+	}
+	return nil
+}
+
 // FindProtocol finds the registered protocol from registry
 func FindProtocol(registry *protocol.Registry) *Protocol {
 	if registry == nil {
@@ -102,17 +152,21 @@ func FindProtocol(registry *protocol.Registry) *Protocol {
 func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
 	g := genesis.MustExtractGenesisContext(ctx)
 	blkCtx := protocol.MustGetBlockCtx(ctx)
+	var err error
 	switch blkCtx.BlockHeight {
 	case g.AleutianBlockHeight:
-		return p.SetReward(ctx, sm, g.AleutianEpochReward(), false)
+		err = p.SetReward(ctx, sm, g.AleutianEpochReward(), false)
 	case g.DardanellesBlockHeight:
-		return p.SetReward(ctx, sm, g.DardanellesBlockReward(), true)
+		err = p.SetReward(ctx, sm, g.DardanellesBlockReward(), true)
 	case g.GreenlandBlockHeight:
-		return p.migrateValueGreenland(ctx, sm)
+		err = p.migrateValueGreenland(ctx, sm)
 	case g.KamchatkaBlockHeight:
-		return p.setFoundationBonusExtension(ctx, sm)
+		err = p.setFoundationBonusExtension(ctx, sm)
 	}
-	return nil
+	if err != nil {
+		return err
+	}
+	return p.applyRewardSchedule(ctx, sm)
 }
 
 func (p *Protocol) migrateValueGreenland(_ context.Context, sm protocol.StateManager) error {
@@ -196,6 +250,8 @@ func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, sr protoco
 		if !protocol.MustGetFeatureCtx(ctx).AddClaimRewardAddress && act.Address() != nil {
 			return errors.New("claim reward address not enabled yet")
 		}
+	case *action.Execution:
+		return p.validateAutoClaimCall(ctx, act)
 	}
 	return nil
 }
@@ -229,7 +285,25 @@ func (p *Protocol) Handle(
 			log.L().Debug("Error when handling rewarding action", zap.Error(err))
 			return p.settleUserAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
 		}
+		isCall, err := p.isClaimCall(ctx, sm, act)
+		if err != nil {
+			return nil, err
+		}
+		if isCall {
+			return p.callClaimedContract(ctx, sm, elp, act, si, rlog)
+		}
 		return p.settleUserAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, nil, rlog)
+	case *action.Execution:
+		// the executions other than the auto claim calls are handled by the execution protocol
+		call, err := p.autoClaimCall(ctx, act)
+		if call == nil || err != nil {
+			return nil, err
+		}
+		if err := p.SetAutoClaim(ctx, sm, protocol.MustGetActionCtx(ctx).Caller, call.Enabled); err != nil {
+			log.L().Debug("Error when handling rewarding action", zap.Error(err))
+			return p.settleUserAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+		}
+		return p.settleUserAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, nil)
 	case *action.GrantReward:
 		switch act.RewardType() {
 		case action.BlockReward:
@@ -248,7 +322,13 @@ func (p *Protocol) Handle(
 				log.L().Debug("Error when handling rewarding action", zap.Error(err))
 				return p.settleSystemAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
 			}
-			return p.settleSystemAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, rewardLogs)
+			// move the unclaimed balance of the opted in accounts after the epoch reward is granted
+			claimLogs, err := p.claimForAutoClaimAccounts(ctx, sm)
+			if err != nil {
+				log.L().Debug("Error when handling rewarding action", zap.Error(err))
+				return p.settleSystemAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+			}
+			return p.settleSystemAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, rewardLogs, claimLogs...)
 		}
 	}
 	return nil, nil
@@ -287,6 +367,12 @@ func (p *Protocol) ReadState(
 			return nil, uint64(0), err
 		}
 		return []byte(balance.String()), height, nil
+	case AutoClaimAccountsMethod:
+		return p.readAutoClaimAccounts(ctx, sr)
+	case EpochRewardStatementMethod:
+		return p.readEpochRewardStatement(ctx, sr, args...)
+	case FeeStatsMethod:
+		return p.readFeeStats(ctx, sr)
 	default:
 		return nil, uint64(0), errors.New("corresponding method isn't found")
 	}
@@ -307,6 +393,21 @@ func (p *Protocol) Name() string {
 	return _protocolID
 }
 
+// Namespaces returns the namespaces used by the protocol
+func (p *Protocol) Namespaces() []string {
+	return []string{_v2RewardingNamespace}
+}
+
+// ExportStates exports all the rewarding states stored in v2 storage
+func (p *Protocol) ExportStates(_ context.Context, sr protocol.StateReader, fn func(*protocol.StateEntry) error) error {
+	return protocol.ExportNamespaces(sr, fn, p.Namespaces()...)
+}
+
+// ImportStates imports the rewarding states
+func (p *Protocol) ImportStates(_ context.Context, sm protocol.StateManager, entries []*protocol.StateEntry) error {
+	return protocol.ImportNamespaces(sm, entries, p.Namespaces()...)
+}
+
 // useV2Storage return true after greenland when we start using v2 storage.
 func useV2Storage(ctx context.Context) bool {
 	return protocol.MustGetFeatureCtx(ctx).UseV2Storage
@@ -139,6 +139,9 @@ func (p *Protocol) GrantBlockReward(
 		}
 		msg = &rewardingpb.RewardLogs{Logs: rewardLogs}
 	}
+	if err := p.recordStatement(ctx, sm, _epochStatementKeyPrefix, rewardLogs...); err != nil {
+		return nil, err
+	}
 	data, err := proto.Marshal(msg)
 	if err != nil {
 		return nil, err
@@ -208,6 +211,7 @@ func (p *Protocol) GrantEpochReward(
 	}
 	actualTotalReward := big.NewInt(0)
 	rewardLogs := make([]*action.Log, 0)
+	statementLogs := make([]*rewardingpb.RewardLog, 0)
 	for i := range addrs {
 		// If reward address doesn't exist, do nothing
 		if addrs[i] == nil {
@@ -225,6 +229,7 @@ func (p *Protocol) GrantEpochReward(
 			Addr:   addrs[i].String(),
 			Amount: amounts[i].String(),
 		}
+		statementLogs = append(statementLogs, &rewardLog)
 		data, err := proto.Marshal(&rewardLog)
 		if err != nil {
 			return nil, err
@@ -267,6 +272,7 @@ func (p *Protocol) GrantEpochReward(
 				Addr:   candidates[i].RewardAddress,
 				Amount: a.foundationBonus.String(),
 			}
+			statementLogs = append(statementLogs, &rewardLog)
 			data, err := proto.Marshal(&rewardLog)
 			if err != nil {
 				return nil, err
@@ -289,6 +295,18 @@ func (p *Protocol) GrantEpochReward(
 	if err := p.updateRewardHistory(ctx, sm, _epochRewardHistoryKeyPrefix, epochNum); err != nil {
 		return nil, err
 	}
+	if protocol.MustGetFeatureCtx(ctx).EnableRewardStatement {
+		if err := p.recordStatement(ctx, sm, _epochStatementKeyPrefix, statementLogs...); err != nil {
+			return nil, err
+		}
+		deductions, err := p.probationDeductions(ctx, sm, epochStartHeight, candidates, &a, exemptAddrs, amounts)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.recordStatement(ctx, sm, _epochDeductionKeyPrefix, deductions...); err != nil {
+			return nil, err
+		}
+	}
 	return rewardLogs, nil
 }
 
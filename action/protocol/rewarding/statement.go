@@ -0,0 +1,260 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding/rewardingpb"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/pkg/enc"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// EpochRewardStatementMethod is the ReadState method to get the reward statement of an epoch. The argument is the
+// epoch number in decimal, and the response is the json encoded EpochRewardStatement
+const EpochRewardStatementMethod = "EpochRewardStatement"
+
+var (
+	_epochStatementKeyPrefix = []byte("est")
+	_epochDeductionKeyPrefix = []byte("esd")
+)
+
+type (
+	// EpochRewardStatement is the rewards granted in an epoch
+	EpochRewardStatement struct {
+		Epoch     uint64                     `json:"epoch"`
+		Delegates []*DelegateRewardStatement `json:"delegates"`
+	}
+
+	// DelegateRewardStatement is the rewards granted to the reward address of a delegate in an epoch
+	DelegateRewardStatement struct {
+		RewardAddress   string `json:"rewardAddress"`
+		BlockReward     string `json:"blockReward"`
+		PriorityBonus   string `json:"priorityBonus"`
+		EpochReward     string `json:"epochReward"`
+		FoundationBonus string `json:"foundationBonus"`
+		// ProbationDeduction is the epoch reward the delegate would have been granted if it were not on probation
+		ProbationDeduction string `json:"probationDeduction"`
+	}
+
+	// statementEntries stores the amounts of an epoch by reward type and address
+	statementEntries struct {
+		logs []*rewardingpb.RewardLog
+	}
+)
+
+// Serialize serializes the statement entries into bytes
+func (e statementEntries) Serialize() ([]byte, error) {
+	return proto.Marshal(&rewardingpb.RewardLogs{Logs: e.logs})
+}
+
+// Deserialize deserializes bytes into the statement entries
+func (e *statementEntries) Deserialize(data []byte) error {
+	logs := rewardingpb.RewardLogs{}
+	if err := proto.Unmarshal(data, &logs); err != nil {
+		return err
+	}
+	e.logs = logs.Logs
+	return nil
+}
+
+// add adds the amounts to the entries of the same type and address
+func (e *statementEntries) add(logs ...*rewardingpb.RewardLog) error {
+	for _, l := range logs {
+		amount, ok := new(big.Int).SetString(l.Amount, 10)
+		if !ok {
+			return errors.Errorf("invalid amount %s", l.Amount)
+		}
+		var entry *rewardingpb.RewardLog
+		for _, el := range e.logs {
+			if el.Type == l.Type && el.Addr == l.Addr {
+				entry = el
+				break
+			}
+		}
+		if entry == nil {
+			e.logs = append(e.logs, &rewardingpb.RewardLog{Type: l.Type, Addr: l.Addr, Amount: amount.String()})
+			continue
+		}
+		sum, ok := new(big.Int).SetString(entry.Amount, 10)
+		if !ok {
+			return errors.Errorf("invalid amount %s", entry.Amount)
+		}
+		entry.Amount = sum.Add(sum, amount).String()
+	}
+	return nil
+}
+
+// recordStatement adds the rewards granted in the current block to the statement of the epoch
+func (p *Protocol) recordStatement(ctx context.Context, sm protocol.StateManager, prefix []byte, logs ...*rewardingpb.RewardLog) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableRewardStatement || len(logs) == 0 {
+		return nil
+	}
+	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
+	if rp == nil {
+		return nil
+	}
+	key := statementKey(prefix, rp.GetEpochNum(protocol.MustGetBlockCtx(ctx).BlockHeight))
+	entries := statementEntries{}
+	if _, err := p.state(ctx, sm, key, &entries); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	if err := entries.add(logs...); err != nil {
+		return err
+	}
+	return p.putState(ctx, sm, key, &entries)
+}
+
+// probationDeductions returns the epoch rewards withheld from the delegates on probation, by splitting the epoch
+// reward again with the voting power of the delegates restored
+func (p *Protocol) probationDeductions(
+	ctx context.Context,
+	sm protocol.StateManager,
+	epochStartHeight uint64,
+	candidates []*state.Candidate,
+	a *admin,
+	exemptAddrs map[string]interface{},
+	amounts []*big.Int,
+) ([]*rewardingpb.RewardLog, error) {
+	pr, ok := poll.MustGetProtocol(protocol.MustGetRegistry(ctx)).(poll.ProbationListReader)
+	if !ok {
+		return nil, nil
+	}
+	pl, err := pr.ProbationList(ctx, sm)
+	if err != nil {
+		return nil, err
+	}
+	if pl == nil || len(pl.ProbationInfo) == 0 || pl.IntensityRate >= 100 {
+		// the voting power cannot be restored if it is cleared
+		return nil, nil
+	}
+	restored := make([]*state.Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if _, ok := pl.ProbationInfo[c.Address]; ok {
+			c = c.Clone()
+			c.Votes = new(big.Int).Div(new(big.Int).Mul(c.Votes, big.NewInt(100)), big.NewInt(int64(100-pl.IntensityRate)))
+		}
+		restored = append(restored, c)
+	}
+	addrs, fullAmounts, err := p.splitEpochReward(epochStartHeight, sm, restored, a.epochReward, a.numDelegatesForEpochReward, exemptAddrs, nil)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		deductions []*rewardingpb.RewardLog
+		i          int
+	)
+	// the delegates are in the same order as splitting the actual epoch reward
+	for _, c := range candidates {
+		if _, ok := exemptAddrs[c.Address]; ok {
+			continue
+		}
+		if i >= len(amounts) {
+			break
+		}
+		if _, ok := pl.ProbationInfo[c.Address]; ok && addrs[i] != nil {
+			if d := new(big.Int).Sub(fullAmounts[i], amounts[i]); d.Sign() > 0 {
+				deductions = append(deductions, &rewardingpb.RewardLog{
+					Type:   rewardingpb.RewardLog_EPOCH_REWARD,
+					Addr:   addrs[i].String(),
+					Amount: d.String(),
+				})
+			}
+		}
+		i++
+	}
+	return deductions, nil
+}
+
+func (p *Protocol) readEpochRewardStatement(ctx context.Context, sr protocol.StateReader, args ...[]byte) ([]byte, uint64, error) {
+	if len(args) != 1 {
+		return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	epoch, err := strconv.ParseUint(string(args[0]), 10, 64)
+	if err != nil {
+		return nil, uint64(0), errors.Wrapf(err, "invalid epoch number %s", args[0])
+	}
+	granted := statementEntries{}
+	height, err := p.state(ctx, sr, statementKey(_epochStatementKeyPrefix, epoch), &granted)
+	if err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil, height, errors.Wrapf(err, "no reward statement of epoch %d", epoch)
+		}
+		return nil, height, err
+	}
+	deductions := statementEntries{}
+	if _, err := p.state(ctx, sr, statementKey(_epochDeductionKeyPrefix, epoch), &deductions); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, height, err
+	}
+	data, err := json.Marshal(newEpochRewardStatement(epoch, granted.logs, deductions.logs))
+	if err != nil {
+		return nil, height, err
+	}
+	return data, height, nil
+}
+
+func newEpochRewardStatement(epoch uint64, granted, deductions []*rewardingpb.RewardLog) *EpochRewardStatement {
+	delegates := make(map[string]*DelegateRewardStatement)
+	get := func(addr string) *DelegateRewardStatement {
+		d, ok := delegates[addr]
+		if !ok {
+			d = &DelegateRewardStatement{
+				RewardAddress:      addr,
+				BlockReward:        "0",
+				PriorityBonus:      "0",
+				EpochReward:        "0",
+				FoundationBonus:    "0",
+				ProbationDeduction: "0",
+			}
+			delegates[addr] = d
+		}
+		return d
+	}
+	for _, l := range granted {
+		d := get(l.Addr)
+		switch l.Type {
+		case rewardingpb.RewardLog_BLOCK_REWARD:
+			d.BlockReward = l.Amount
+		case rewardingpb.RewardLog_PRIORITY_BONUS:
+			d.PriorityBonus = l.Amount
+		case rewardingpb.RewardLog_EPOCH_REWARD:
+			d.EpochReward = l.Amount
+		case rewardingpb.RewardLog_FOUNDATION_BONUS:
+			d.FoundationBonus = l.Amount
+		}
+	}
+	for _, l := range deductions {
+		get(l.Addr).ProbationDeduction = l.Amount
+	}
+	statement := &EpochRewardStatement{
+		Epoch:     epoch,
+		Delegates: make([]*DelegateRewardStatement, 0, len(delegates)),
+	}
+	for _, d := range delegates {
+		statement.Delegates = append(statement.Delegates, d)
+	}
+	sort.Slice(statement.Delegates, func(i, j int) bool {
+		return statement.Delegates[i].RewardAddress < statement.Delegates[j].RewardAddress
+	})
+	return statement
+}
+
+func statementKey(prefix []byte, epoch uint64) []byte {
+	var epochBytes [8]byte
+	enc.MachineEndian.PutUint64(epochBytes[:], epoch)
+	key := make([]byte, 0, len(prefix)+len(epochBytes))
+	return append(append(key, prefix...), epochBytes[:]...)
+}
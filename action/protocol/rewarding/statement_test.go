@@ -0,0 +1,75 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding/rewardingpb"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestStatementEntries(t *testing.T) {
+	require := require.New(t)
+	var (
+		addr1   = identityset.Address(1).String()
+		addr2   = identityset.Address(2).String()
+		entries = statementEntries{}
+	)
+	require.NoError(entries.add(
+		&rewardingpb.RewardLog{Type: rewardingpb.RewardLog_BLOCK_REWARD, Addr: addr1, Amount: "10"},
+		&rewardingpb.RewardLog{Type: rewardingpb.RewardLog_PRIORITY_BONUS, Addr: addr1, Amount: "1"},
+	))
+	require.NoError(entries.add(
+		&rewardingpb.RewardLog{Type: rewardingpb.RewardLog_BLOCK_REWARD, Addr: addr2, Amount: "10"},
+		&rewardingpb.RewardLog{Type: rewardingpb.RewardLog_BLOCK_REWARD, Addr: addr1, Amount: "10"},
+	))
+	require.Error(entries.add(&rewardingpb.RewardLog{Type: rewardingpb.RewardLog_BLOCK_REWARD, Addr: addr1, Amount: "x"}))
+	data, err := entries.Serialize()
+	require.NoError(err)
+	decoded := statementEntries{}
+	require.NoError(decoded.Deserialize(data))
+	require.Len(decoded.logs, 3)
+	require.Equal("20", decoded.logs[0].Amount)
+	require.Equal("1", decoded.logs[1].Amount)
+	require.Equal("10", decoded.logs[2].Amount)
+
+	require.NoError(decoded.add(
+		&rewardingpb.RewardLog{Type: rewardingpb.RewardLog_EPOCH_REWARD, Addr: addr2, Amount: "100"},
+		&rewardingpb.RewardLog{Type: rewardingpb.RewardLog_FOUNDATION_BONUS, Addr: addr2, Amount: "5"},
+	))
+	statement := newEpochRewardStatement(3, decoded.logs, []*rewardingpb.RewardLog{
+		{Type: rewardingpb.RewardLog_EPOCH_REWARD, Addr: addr1, Amount: "50"},
+	})
+	require.Equal(uint64(3), statement.Epoch)
+	require.Len(statement.Delegates, 2)
+	expected := map[string]DelegateRewardStatement{
+		addr1: {
+			RewardAddress:      addr1,
+			BlockReward:        "20",
+			PriorityBonus:      "1",
+			EpochReward:        "0",
+			FoundationBonus:    "0",
+			ProbationDeduction: "50",
+		},
+		addr2: {
+			RewardAddress:      addr2,
+			BlockReward:        "10",
+			PriorityBonus:      "0",
+			EpochReward:        "100",
+			FoundationBonus:    "5",
+			ProbationDeduction: "0",
+		},
+	}
+	for i, d := range statement.Delegates {
+		require.Equal(expected[d.RewardAddress], *d)
+		if i > 0 {
+			require.Less(statement.Delegates[i-1].RewardAddress, d.RewardAddress)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// BucketEvent is the bucket event decoded from the receipt log of a native staking action
+type BucketEvent struct {
+	// Type is the handler name of the staking action, e.g., createStake
+	Type        string
+	BucketIndex uint64
+	Candidate   address.Address
+	// Owner is the owner of the bucket, nil if not available in the log
+	Owner address.Address
+	// Amount is the amount moved in or out of the bucket, nil if no token is moved
+	Amount      *big.Int
+	BlockHeight uint64
+	ActionHash  hash.Hash256
+}
+
+// _bucketEventTopics maps the topic of the receipt log to the handler name and the position of the owner in topics,
+// which is 0 if the owner is not in topics
+var _bucketEventTopics = map[hash.Hash256]struct {
+	name     string
+	ownerIdx int
+}{
+	hash.BytesToHash256([]byte(HandleCreateStake)):    {HandleCreateStake, 0},
+	hash.BytesToHash256([]byte(HandleDepositToStake)): {HandleDepositToStake, 2},
+	hash.BytesToHash256([]byte(HandleUnstake)):        {HandleUnstake, 0},
+	hash.BytesToHash256([]byte(HandleWithdrawStake)):  {HandleWithdrawStake, 0},
+	hash.BytesToHash256([]byte(HandleTransferStake)):  {HandleTransferStake, 2},
+}
+
+// BucketEventsFromReceipt decodes the bucket events of create, deposit, unstake, withdraw and transfer from the
+// receipt of a successful native staking action. Only the receipt logs in the new staking receipt format are decoded.
+func BucketEventsFromReceipt(receipt *action.Receipt) []*BucketEvent {
+	if receipt == nil || receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		return nil
+	}
+	protocolAddr := ProtocolAddr().String()
+	var events []*BucketEvent
+	for _, l := range receipt.Logs() {
+		if l.Address != protocolAddr || len(l.Topics) < 3 {
+			continue
+		}
+		topic, ok := _bucketEventTopics[l.Topics[0]]
+		if !ok {
+			continue
+		}
+		evt := &BucketEvent{
+			Type:        topic.name,
+			BucketIndex: byteutil.BytesToUint64BigEndian(l.Topics[1][24:]),
+			Candidate:   topicToAddress(l.Topics[len(l.Topics)-1]),
+			BlockHeight: l.BlockHeight,
+			ActionHash:  l.ActionHash,
+		}
+		if topic.ownerIdx > 0 && topic.ownerIdx < len(l.Topics)-1 {
+			evt.Owner = topicToAddress(l.Topics[topic.ownerIdx])
+		}
+		for _, tl := range receipt.TransactionLogs() {
+			switch tl.Type {
+			case iotextypes.TransactionLogType_CREATE_BUCKET, iotextypes.TransactionLogType_DEPOSIT_TO_BUCKET:
+				if evt.Owner == nil && evt.Type == HandleCreateStake {
+					evt.Owner, _ = address.FromString(tl.Sender)
+				}
+				evt.Amount = tl.Amount
+			case iotextypes.TransactionLogType_WITHDRAW_BUCKET:
+				if evt.Owner == nil {
+					evt.Owner, _ = address.FromString(tl.Recipient)
+				}
+				evt.Amount = tl.Amount
+			}
+		}
+		events = append(events, evt)
+	}
+	return events
+}
+
+func topicToAddress(topic hash.Hash256) address.Address {
+	addr, err := address.FromBytes(topic[12:])
+	if err != nil {
+		return nil
+	}
+	return addr
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestBucketEventsFromReceipt(t *testing.T) {
+	r := require.New(t)
+
+	addr := ProtocolAddr().String()
+	cand := identityset.Address(5)
+	voter := identityset.Address(11)
+	index := byteutil.Uint64ToBytesBigEndian(3)
+	actHash := hash.Hash256b([]byte("test-action"))
+	ctx := protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+		ActionHash: actHash,
+	})
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: 6,
+	})
+	newReceipt := func(name string, topics [][]byte, txLogs ...*action.TransactionLog) *action.Receipt {
+		l := newReceiptLog(addr, name, true)
+		l.AddTopics(topics...)
+		return (&action.Receipt{
+			Status:      uint64(iotextypes.ReceiptStatus_Success),
+			BlockHeight: 6,
+			ActionHash:  actHash,
+		}).AddLogs(l.Build(ctx, nil)).AddTransactionLogs(txLogs...)
+	}
+
+	tests := []struct {
+		name    string
+		receipt *action.Receipt
+		owner   string
+		amount  *big.Int
+	}{
+		{
+			HandleCreateStake,
+			newReceipt(HandleCreateStake, [][]byte{index, cand.Bytes()}, &action.TransactionLog{
+				Type:      iotextypes.TransactionLogType_CREATE_BUCKET,
+				Amount:    big.NewInt(100),
+				Sender:    voter.String(),
+				Recipient: addr,
+			}),
+			voter.String(),
+			big.NewInt(100),
+		},
+		{
+			HandleDepositToStake,
+			newReceipt(HandleDepositToStake, [][]byte{index, voter.Bytes(), cand.Bytes()}, &action.TransactionLog{
+				Type:      iotextypes.TransactionLogType_DEPOSIT_TO_BUCKET,
+				Amount:    big.NewInt(10),
+				Sender:    identityset.Address(12).String(),
+				Recipient: addr,
+			}),
+			voter.String(),
+			big.NewInt(10),
+		},
+		{
+			HandleUnstake,
+			newReceipt(HandleUnstake, [][]byte{index, cand.Bytes()}),
+			"",
+			nil,
+		},
+		{
+			HandleWithdrawStake,
+			newReceipt(HandleWithdrawStake, [][]byte{index, cand.Bytes()}, &action.TransactionLog{
+				Type:      iotextypes.TransactionLogType_WITHDRAW_BUCKET,
+				Amount:    big.NewInt(100),
+				Sender:    addr,
+				Recipient: voter.String(),
+			}),
+			voter.String(),
+			big.NewInt(100),
+		},
+		{
+			HandleTransferStake,
+			newReceipt(HandleTransferStake, [][]byte{index, voter.Bytes(), cand.Bytes()}),
+			voter.String(),
+			nil,
+		},
+	}
+	for _, v := range tests {
+		events := BucketEventsFromReceipt(v.receipt)
+		r.Len(events, 1)
+		evt := events[0]
+		r.Equal(v.name, evt.Type)
+		r.EqualValues(3, evt.BucketIndex)
+		r.Equal(cand.String(), evt.Candidate.String())
+		if v.owner == "" {
+			r.Nil(evt.Owner)
+		} else {
+			r.Equal(v.owner, evt.Owner.String())
+		}
+		r.Equal(v.amount, evt.Amount)
+		r.EqualValues(6, evt.BlockHeight)
+		r.Equal(actHash, evt.ActionHash)
+	}
+
+	// failed action, other events and legacy receipt format are skipped
+	failed := newReceipt(HandleUnstake, [][]byte{index, cand.Bytes()})
+	failed.Status = uint64(iotextypes.ReceiptStatus_ErrUnstakeBeforeMaturity)
+	r.Empty(BucketEventsFromReceipt(failed))
+	r.Empty(BucketEventsFromReceipt(newReceipt(HandleRestake, [][]byte{index, cand.Bytes()})))
+	legacy := newReceiptLog(addr, HandleUnstake, false)
+	legacy.AddAddress(cand)
+	legacy.AddAddress(voter)
+	r.Empty(BucketEventsFromReceipt((&action.Receipt{
+		Status: uint64(iotextypes.ReceiptStatus_Success),
+	}).AddLogs(legacy.Build(ctx, nil))))
+	r.Empty(BucketEventsFromReceipt(nil))
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+)
+
+// ReadState methods of the bucket funding audit, which require the bucket funding indexer
+const (
+	// BucketFundingMethod reads the fundings of a bucket, the argument is the bucket index in decimal, and the
+	// response is the json encoded BucketFundingList
+	BucketFundingMethod = "BucketFunding"
+	// SourceFundingMethod reads the fundings of the buckets by the funding source, the arguments are the address of
+	// the source, the start and the count in decimal, and the response is the json encoded BucketFundingList
+	SourceFundingMethod = "SourceFunding"
+)
+
+const _maxSourceFundingCount = 1000
+
+type (
+	// BucketFunding is a bucket creation or deposit, along with the account which funded the staker most recently
+	// before it, so that the votes funded by the same source, e.g., the hot wallet of an exchange, can be detected
+	BucketFunding struct {
+		BucketIndex uint64 `json:"bucketIndex"`
+		Height      uint64 `json:"height"`
+		ActionHash  string `json:"actionHash"`
+		// Type is either createStake or depositToStake
+		Type   string `json:"type"`
+		Staker string `json:"staker"`
+		Amount string `json:"amount"`
+		// Source is the sender of the last transfer to the staker, empty if the staker has never been funded
+		Source           string `json:"source,omitempty"`
+		SourceHeight     uint64 `json:"sourceHeight,omitempty"`
+		SourceActionHash string `json:"sourceActionHash,omitempty"`
+	}
+
+	// BucketFundingList is the response of the bucket funding read methods
+	BucketFundingList struct {
+		Total    uint64           `json:"total"`
+		Fundings []*BucketFunding `json:"fundings"`
+	}
+
+	// BucketFundingIndexer is the indexer of the funding sources of the bucket creations and deposits
+	BucketFundingIndexer interface {
+		// BucketFundings returns the fundings of the bucket, from the creation to the latest deposit
+		BucketFundings(index uint64) ([]*BucketFunding, error)
+		// SourceFundingCount returns the number of fundings by the source
+		SourceFundingCount(source address.Address) (uint64, error)
+		// SourceFundings returns the fundings by the source [start, start+count)
+		SourceFundings(source address.Address, start, count uint64) ([]*BucketFunding, error)
+	}
+
+	// Option is the option to create the staking protocol
+	Option func(*Protocol)
+)
+
+// WithBucketFundingIndexer is the option to serve the bucket funding audit via ReadState
+func WithBucketFundingIndexer(indexer BucketFundingIndexer) Option {
+	return func(p *Protocol) {
+		p.fundingIndexer = indexer
+	}
+}
+
+func (p *Protocol) readBucketFunding(ctx context.Context, sr protocol.StateReader, method string, args ...[]byte) ([]byte, uint64, error) {
+	if p.fundingIndexer == nil {
+		return nil, 0, errors.New("bucket funding indexer is not enabled")
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, 0, err
+	}
+	res := &BucketFundingList{}
+	switch method {
+	case BucketFundingMethod:
+		if len(args) != 1 {
+			return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		index, err := strconv.ParseUint(string(args[0]), 10, 64)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "invalid bucket index %s", args[0])
+		}
+		if res.Fundings, err = p.fundingIndexer.BucketFundings(index); err != nil {
+			return nil, height, err
+		}
+		res.Total = uint64(len(res.Fundings))
+	case SourceFundingMethod:
+		if len(args) != 3 {
+			return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		source, err := address.FromString(string(args[0]))
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "invalid source %s", args[0])
+		}
+		var nums [2]uint64
+		for i := range nums {
+			if nums[i], err = strconv.ParseUint(string(args[i+1]), 10, 64); err != nil {
+				return nil, 0, errors.Wrapf(err, "invalid argument %s", args[i+1])
+			}
+		}
+		start, count := nums[0], nums[1]
+		if count == 0 || count > _maxSourceFundingCount {
+			return nil, 0, errors.Errorf("invalid count %d, at most %d", count, _maxSourceFundingCount)
+		}
+		if res.Total, err = p.fundingIndexer.SourceFundingCount(source); err != nil {
+			return nil, height, err
+		}
+		if start < res.Total {
+			if res.Fundings, err = p.fundingIndexer.SourceFundings(source, start, count); err != nil {
+				return nil, height, err
+			}
+		}
+	default:
+		return nil, 0, errors.Errorf("invalid method %s", method)
+	}
+	if res.Fundings == nil {
+		res.Fundings = []*BucketFunding{}
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, height, err
+	}
+	return data, height, nil
+}
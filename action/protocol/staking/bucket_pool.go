@@ -49,11 +49,14 @@ type (
 )
 
 func (t *totalAmount) Serialize() ([]byte, error) {
-	gen := stakingpb.TotalAmount{
+	return proto.Marshal(t.toProto())
+}
+
+func (t *totalAmount) toProto() *stakingpb.TotalAmount {
+	return &stakingpb.TotalAmount{
 		Amount: t.amount.String(),
 		Count:  t.count,
 	}
-	return proto.Marshal(&gen)
 }
 
 func (t *totalAmount) Deserialize(data []byte) error {
@@ -61,7 +64,10 @@ func (t *totalAmount) Deserialize(data []byte) error {
 	if err := proto.Unmarshal(data, &gen); err != nil {
 		return err
 	}
+	return t.fromProto(&gen)
+}
 
+func (t *totalAmount) fromProto(gen *stakingpb.TotalAmount) error {
 	var ok bool
 	if t.amount, ok = new(big.Int).SetString(gen.Amount, 10); !ok {
 		return state.ErrStateDeserialization
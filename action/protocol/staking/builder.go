@@ -10,6 +10,7 @@ type (
 		PersistStakingPatchBlock uint64
 		FixAliasForNonStopHeight uint64
 		StakingPatchDir          string
+		ViewCacheDir             string
 		Revise                   ReviseConfig
 	}
 )
@@ -334,7 +334,10 @@ func (l *CandidateList) Deserialize(buf []byte) error {
 	if err := proto.Unmarshal(buf, pb); err != nil {
 		return errors.Wrap(err, "failed to unmarshal candidate list")
 	}
+	return l.fromProto(pb)
+}
 
+func (l *CandidateList) fromProto(pb *stakingpb.Candidates) error {
 	*l = (*l)[:0]
 	for _, v := range pb.Candidates {
 		c := &Candidate{}
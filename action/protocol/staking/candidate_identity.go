@@ -0,0 +1,441 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// CandidateIdentityMethod is the ReadState method to read the DID document anchored by a candidate and the
+// attestations filed by third parties, the argument is the identifier of the candidate, and the response is the json
+// encoded CandidateIdentity
+const CandidateIdentityMethod = "CandidateIdentity"
+
+// handler names of the candidate identity calls
+const (
+	HandleCandidateUpdateIdentity = "candidateUpdateIdentity"
+	HandleAttestCandidateIdentity = "attestCandidateIdentity"
+)
+
+const (
+	_hashSize                = len(hash.Hash256{})
+	_maxIdentityURILength    = 256
+	_maxIdentityAttestations = 128
+
+	// _candidateIdentityJSONABI is the extension of the candidate update, which is sent as an execution to the
+	// staking protocol address
+	_candidateIdentityJSONABI = `[
+		{
+			"inputs": [
+				{"internalType": "bytes32", "name": "documentHash", "type": "bytes32"},
+				{"internalType": "string", "name": "uri", "type": "string"}
+			],
+			"name": "candidateUpdateIdentity",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		},
+		{
+			"inputs": [
+				{"internalType": "address", "name": "candidate", "type": "address"},
+				{"internalType": "bytes32", "name": "documentHash", "type": "bytes32"}
+			],
+			"name": "attestCandidateIdentity",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	_candidateUpdateIdentityMethod abi.Method
+	_attestCandidateIdentityMethod abi.Method
+
+	// ErrInvalidIdentityCall indicates the candidate identity call is malformed
+	ErrInvalidIdentityCall = errors.New("invalid candidate identity call")
+)
+
+type (
+	// CandidateIdentityCall is the call to anchor the hash of the DID document of a candidate, or to attest the
+	// DID document anchored by a candidate
+	CandidateIdentityCall struct {
+		// Attest is true for an attestation, false for anchoring the DID document of the caller's candidate
+		Attest bool
+		// Candidate is the identifier of the candidate to attest
+		Candidate    common.Address
+		DocumentHash hash.Hash256
+		// URI is the location of the DID document, e.g., did:io:0x..., ignored for an attestation
+		URI string
+	}
+
+	// CandidateIdentity is the DID document anchored by a candidate and the attestations on it
+	CandidateIdentity struct {
+		Candidate    string `json:"candidate"`
+		DocumentHash string `json:"documentHash"`
+		URI          string `json:"uri"`
+		// Height is the height the document is anchored
+		Height       uint64                 `json:"height"`
+		Attestations []*IdentityAttestation `json:"attestations"`
+	}
+
+	// IdentityAttestation is an attestation filed by a third party
+	IdentityAttestation struct {
+		Attester     string `json:"attester"`
+		DocumentHash string `json:"documentHash"`
+		Height       uint64 `json:"height"`
+		// Valid is true if the attested document is the one currently anchored by the candidate
+		Valid bool `json:"valid"`
+	}
+
+	candidateIdentity struct {
+		documentHash hash.Hash256
+		uri          string
+		height       uint64
+		attestations []*identityAttestation
+	}
+
+	identityAttestation struct {
+		attester     common.Address
+		documentHash hash.Hash256
+		height       uint64
+	}
+)
+
+func init() {
+	contractABI, err := abi.JSON(strings.NewReader(_candidateIdentityJSONABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	if _candidateUpdateIdentityMethod, ok = contractABI.Methods["candidateUpdateIdentity"]; !ok {
+		panic("fail to load the method candidateUpdateIdentity")
+	}
+	if _attestCandidateIdentityMethod, ok = contractABI.Methods["attestCandidateIdentity"]; !ok {
+		panic("fail to load the method attestCandidateIdentity")
+	}
+}
+
+// EncodeCandidateIdentityCall encodes the call into the data of an execution sent to the staking protocol address
+func EncodeCandidateIdentityCall(call *CandidateIdentityCall) ([]byte, error) {
+	var (
+		method = _candidateUpdateIdentityMethod
+		data   []byte
+		err    error
+	)
+	if call.Attest {
+		method = _attestCandidateIdentityMethod
+		data, err = method.Inputs.Pack(call.Candidate, [32]byte(call.DocumentHash))
+	} else {
+		data, err = method.Inputs.Pack([32]byte(call.DocumentHash), call.URI)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(method.ID, data...), nil
+}
+
+// decodeCandidateIdentityCall decodes the call, and returns nil if the data is not a candidate identity call
+func decodeCandidateIdentityCall(data []byte) (*CandidateIdentityCall, error) {
+	if len(data) < 4 {
+		return nil, nil
+	}
+	var (
+		method abi.Method
+		call   = &CandidateIdentityCall{}
+	)
+	switch string(data[:4]) {
+	case string(_candidateUpdateIdentityMethod.ID):
+		method = _candidateUpdateIdentityMethod
+	case string(_attestCandidateIdentityMethod.ID):
+		method, call.Attest = _attestCandidateIdentityMethod, true
+	default:
+		return nil, nil
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidIdentityCall, err.Error())
+	}
+	var (
+		docHash [32]byte
+		ok      bool
+	)
+	if call.Attest {
+		if call.Candidate, ok = args[0].(common.Address); !ok {
+			return nil, errors.Wrap(ErrInvalidIdentityCall, "invalid candidate")
+		}
+		docHash, ok = args[1].([32]byte)
+	} else {
+		if docHash, ok = args[0].([32]byte); ok {
+			call.URI, ok = args[1].(string)
+		}
+	}
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidIdentityCall, "invalid arguments")
+	}
+	call.DocumentHash = hash.Hash256(docHash)
+	return call, nil
+}
+
+// candidateIdentityCall returns the candidate identity call if the action is an execution sent to the staking
+// protocol address carrying the call, nil otherwise
+func (p *Protocol) candidateIdentityCall(ctx context.Context, act *action.Execution) (*CandidateIdentityCall, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnableCandidateIdentity || act.Contract() != p.addr.String() {
+		return nil, nil
+	}
+	return decodeCandidateIdentityCall(act.Data())
+}
+
+func (p *Protocol) validateCandidateIdentityCall(ctx context.Context, act *action.Execution) error {
+	call, err := p.candidateIdentityCall(ctx, act)
+	if call == nil || err != nil {
+		return err
+	}
+	if act.Amount() != nil && act.Amount().Sign() != 0 {
+		return errors.Wrap(ErrInvalidIdentityCall, "cannot send amount with candidate identity call")
+	}
+	if call.DocumentHash == hash.ZeroHash256 {
+		return errors.Wrap(ErrInvalidIdentityCall, "empty document hash")
+	}
+	if len(call.URI) > _maxIdentityURILength {
+		return errors.Wrapf(ErrInvalidIdentityCall, "uri exceeds %d bytes", _maxIdentityURILength)
+	}
+	return nil
+}
+
+func (p *Protocol) handleCandidateUpdateIdentity(ctx context.Context, call *CandidateIdentityCall, csm CandidateStateManager,
+) (*receiptLog, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), HandleCandidateUpdateIdentity, featureCtx.NewStakingReceiptFormat)
+
+	_, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
+	if fetchErr != nil {
+		return log, fetchErr
+	}
+	// only owner can anchor the DID document of candidate
+	c := csm.GetByOwner(actCtx.Caller)
+	if c == nil {
+		return log, errCandNotExist
+	}
+	id := c.GetIdentifier()
+	identity, err := getCandidateIdentity(csm.SM(), id)
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		identity = &candidateIdentity{}
+	default:
+		return log, err
+	}
+	identity.documentHash = call.DocumentHash
+	identity.uri = call.URI
+	identity.height = blkCtx.BlockHeight
+	if err := putCandidateIdentity(csm.SM(), id, identity); err != nil {
+		return log, err
+	}
+	log.AddTopics(id.Bytes(), call.DocumentHash[:])
+	log.AddAddress(actCtx.Caller)
+	return log, nil
+}
+
+func (p *Protocol) handleAttestCandidateIdentity(ctx context.Context, call *CandidateIdentityCall, csm CandidateStateManager,
+) (*receiptLog, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), HandleAttestCandidateIdentity, featureCtx.NewStakingReceiptFormat)
+
+	_, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
+	if fetchErr != nil {
+		return log, fetchErr
+	}
+	id, err := address.FromBytes(call.Candidate.Bytes())
+	if err != nil {
+		return log, err
+	}
+	c := csm.GetByIdentifier(id)
+	if c == nil {
+		return log, errCandNotExist
+	}
+	if address.Equal(c.Owner, actCtx.Caller) {
+		return log, &handleError{
+			err:           errors.New("candidate owner cannot attest its own identity"),
+			failureStatus: iotextypes.ReceiptStatus_Failure,
+		}
+	}
+	identity, err := getCandidateIdentity(csm.SM(), id)
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return log, err
+	}
+	// only the document currently anchored can be attested
+	if identity == nil || identity.documentHash != call.DocumentHash {
+		return log, &handleError{
+			err:           errors.Errorf("document %x is not anchored by candidate %s", call.DocumentHash, id.String()),
+			failureStatus: iotextypes.ReceiptStatus_Failure,
+		}
+	}
+	attester := common.BytesToAddress(actCtx.Caller.Bytes())
+	attestation := &identityAttestation{
+		attester:     attester,
+		documentHash: call.DocumentHash,
+		height:       blkCtx.BlockHeight,
+	}
+	replaced := false
+	for i, a := range identity.attestations {
+		if a.attester == attester {
+			identity.attestations[i], replaced = attestation, true
+			break
+		}
+	}
+	if !replaced {
+		if len(identity.attestations) >= _maxIdentityAttestations {
+			return log, &handleError{
+				err:           errors.Errorf("candidate %s has reached %d attestations", id.String(), _maxIdentityAttestations),
+				failureStatus: iotextypes.ReceiptStatus_Failure,
+			}
+		}
+		identity.attestations = append(identity.attestations, attestation)
+	}
+	if err := putCandidateIdentity(csm.SM(), id, identity); err != nil {
+		return log, err
+	}
+	log.AddTopics(id.Bytes(), call.DocumentHash[:], actCtx.Caller.Bytes())
+	log.AddAddress(actCtx.Caller)
+	return log, nil
+}
+
+func (p *Protocol) readCandidateIdentity(ctx context.Context, sr protocol.StateReader, args ...[]byte) ([]byte, uint64, error) {
+	if len(args) != 1 {
+		return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	id, err := address.FromString(string(args[0]))
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "invalid candidate %s", args[0])
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, 0, err
+	}
+	identity, err := getCandidateIdentity(sr, id)
+	if err != nil {
+		return nil, height, err
+	}
+	res := &CandidateIdentity{
+		Candidate:    id.String(),
+		DocumentHash: hex.EncodeToString(identity.documentHash[:]),
+		URI:          identity.uri,
+		Height:       identity.height,
+		Attestations: make([]*IdentityAttestation, 0, len(identity.attestations)),
+	}
+	for _, a := range identity.attestations {
+		attester, err := address.FromBytes(a.attester.Bytes())
+		if err != nil {
+			return nil, height, err
+		}
+		res.Attestations = append(res.Attestations, &IdentityAttestation{
+			Attester:     attester.String(),
+			DocumentHash: hex.EncodeToString(a.documentHash[:]),
+			Height:       a.height,
+			Valid:        a.documentHash == identity.documentHash,
+		})
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, height, err
+	}
+	return data, height, nil
+}
+
+func candidateIdentityKey(id address.Address) []byte {
+	return append([]byte{_candIdentity}, id.Bytes()...)
+}
+
+func getCandidateIdentity(sr protocol.StateReader, id address.Address) (*candidateIdentity, error) {
+	var identity candidateIdentity
+	if _, err := sr.State(
+		&identity,
+		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.KeyOption(candidateIdentityKey(id)),
+	); err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func putCandidateIdentity(sm protocol.StateManager, id address.Address, identity *candidateIdentity) error {
+	_, err := sm.PutState(
+		identity,
+		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.KeyOption(candidateIdentityKey(id)),
+	)
+	return err
+}
+
+// Serialize serializes the candidate identity into bytes
+func (ci *candidateIdentity) Serialize() ([]byte, error) {
+	data := append([]byte{}, ci.documentHash[:]...)
+	data = append(data, byteutil.Uint64ToBytesBigEndian(ci.height)...)
+	data = binary.AppendUvarint(data, uint64(len(ci.uri)))
+	data = append(data, ci.uri...)
+	data = binary.AppendUvarint(data, uint64(len(ci.attestations)))
+	for _, a := range ci.attestations {
+		data = append(data, a.attester.Bytes()...)
+		data = append(data, a.documentHash[:]...)
+		data = append(data, byteutil.Uint64ToBytesBigEndian(a.height)...)
+	}
+	return data, nil
+}
+
+// Deserialize deserializes bytes into the candidate identity
+func (ci *candidateIdentity) Deserialize(data []byte) error {
+	const attestationSize = common.AddressLength + _hashSize + 8
+	errInvalid := errors.New("invalid candidate identity")
+	if len(data) < _hashSize+8 {
+		return errInvalid
+	}
+	identity := candidateIdentity{}
+	copy(identity.documentHash[:], data[:_hashSize])
+	identity.height = byteutil.BytesToUint64BigEndian(data[_hashSize : _hashSize+8])
+	data = data[_hashSize+8:]
+	size, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < size {
+		return errInvalid
+	}
+	identity.uri = string(data[n : n+int(size)])
+	data = data[n+int(size):]
+	count, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) != count*uint64(attestationSize) {
+		return errInvalid
+	}
+	data = data[n:]
+	for i := uint64(0); i < count; i++ {
+		a := &identityAttestation{attester: common.BytesToAddress(data[:common.AddressLength])}
+		copy(a.documentHash[:], data[common.AddressLength:common.AddressLength+_hashSize])
+		a.height = byteutil.BytesToUint64BigEndian(data[common.AddressLength+_hashSize : attestationSize])
+		identity.attestations = append(identity.attestations, a)
+		data = data[attestationSize:]
+	}
+	*ci = identity
+	return nil
+}
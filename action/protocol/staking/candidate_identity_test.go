@@ -0,0 +1,156 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestCandidateIdentityCall(t *testing.T) {
+	require := require.New(t)
+	docHash := hash.Hash256b([]byte("did document"))
+	for _, call := range []*CandidateIdentityCall{
+		{DocumentHash: docHash, URI: "did:io:0x1234"},
+		{Attest: true, Candidate: common.BytesToAddress(identityset.Address(1).Bytes()), DocumentHash: docHash},
+	} {
+		data, err := EncodeCandidateIdentityCall(call)
+		require.NoError(err)
+		decoded, err := decodeCandidateIdentityCall(data)
+		require.NoError(err)
+		require.Equal(call, decoded)
+	}
+	// not a candidate identity call
+	call, err := decodeCandidateIdentityCall([]byte{1, 2, 3, 4})
+	require.NoError(err)
+	require.Nil(call)
+	_, err = decodeCandidateIdentityCall(_candidateUpdateIdentityMethod.ID)
+	require.Equal(ErrInvalidIdentityCall, errors.Cause(err))
+
+	identity := &candidateIdentity{
+		documentHash: docHash,
+		uri:          "did:io:0x1234",
+		height:       10,
+		attestations: []*identityAttestation{
+			{attester: common.BytesToAddress(identityset.Address(2).Bytes()), documentHash: docHash, height: 11},
+			{attester: common.BytesToAddress(identityset.Address(3).Bytes()), documentHash: docHash, height: 12},
+		},
+	}
+	data, err := identity.Serialize()
+	require.NoError(err)
+	var decoded candidateIdentity
+	require.NoError(decoded.Deserialize(data))
+	require.Equal(identity, &decoded)
+	require.Error(decoded.Deserialize(data[:len(data)-1]))
+}
+
+func TestCandidateIdentity(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	v, _, err := CreateBaseView(sm, false)
+	require.NoError(err)
+	require.NoError(sm.WriteView(_protocolID, v))
+	csm, err := NewCandidateStateManager(sm, false)
+	require.NoError(err)
+	addr, err := address.FromString(address.StakingProtocolAddr)
+	require.NoError(err)
+	p := &Protocol{addr: addr}
+
+	var (
+		owner    = identityset.Address(1)
+		attester = identityset.Address(2)
+		docHash  = hash.Hash256b([]byte("did document"))
+	)
+	require.NoError(csm.Upsert(&Candidate{
+		Owner:      owner,
+		Operator:   owner,
+		Reward:     owner,
+		Identifier: owner,
+		Name:       "test",
+		Votes:      big.NewInt(0),
+		SelfStake:  big.NewInt(0),
+	}))
+	g := genesis.TestDefault()
+	g.ToBeEnabledBlockHeight = 1
+	ctx := genesis.WithGenesisContext(context.Background(), g)
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 10}))
+	callerCtx := func(caller address.Address) context.Context {
+		return protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: caller, GasPrice: big.NewInt(0)})
+	}
+	handle := func(caller address.Address, call *CandidateIdentityCall) error {
+		var err error
+		if call.Attest {
+			_, err = p.handleAttestCandidateIdentity(callerCtx(caller), call, csm)
+		} else {
+			_, err = p.handleCandidateUpdateIdentity(callerCtx(caller), call, csm)
+		}
+		return err
+	}
+	attest := &CandidateIdentityCall{Attest: true, Candidate: common.BytesToAddress(owner.Bytes()), DocumentHash: docHash}
+
+	// only the owner can anchor the document, which must be anchored before attested
+	require.Equal(errCandNotExist, handle(attester, &CandidateIdentityCall{DocumentHash: docHash}))
+	require.Error(handle(attester, attest))
+	require.NoError(handle(owner, &CandidateIdentityCall{DocumentHash: docHash, URI: "did:io:0x1234"}))
+	// the owner cannot attest its own identity
+	require.Error(handle(owner, attest))
+	require.NoError(handle(attester, attest))
+	// attesting again replaces the previous attestation
+	require.NoError(handle(attester, attest))
+
+	read := func() *CandidateIdentity {
+		data, _, err := p.readCandidateIdentity(ctx, sm, []byte(owner.String()))
+		require.NoError(err)
+		var identity CandidateIdentity
+		require.NoError(json.Unmarshal(data, &identity))
+		return &identity
+	}
+	identity := read()
+	require.Equal("did:io:0x1234", identity.URI)
+	require.EqualValues(10, identity.Height)
+	require.Len(identity.Attestations, 1)
+	require.Equal(attester.String(), identity.Attestations[0].Attester)
+	require.True(identity.Attestations[0].Valid)
+
+	// the attestation is invalid once a new document is anchored
+	newHash := hash.Hash256b([]byte("new did document"))
+	require.NoError(handle(owner, &CandidateIdentityCall{DocumentHash: newHash, URI: "did:io:0x5678"}))
+	require.Error(handle(identityset.Address(3), attest))
+	identity = read()
+	require.Len(identity.Attestations, 1)
+	require.False(identity.Attestations[0].Valid)
+
+	// validate
+	newExec := func(amount int64, call *CandidateIdentityCall) action.Envelope {
+		data, err := EncodeCandidateIdentityCall(call)
+		require.NoError(err)
+		return (&action.EnvelopeBuilder{}).SetGasLimit(100000).SetGasPrice(big.NewInt(0)).
+			SetAction(action.NewExecution(addr.String(), big.NewInt(amount), data)).Build()
+	}
+	require.NoError(p.Validate(ctx, newExec(0, attest), sm))
+	require.Equal(ErrInvalidIdentityCall, errors.Cause(p.Validate(ctx, newExec(1, attest), sm)))
+	require.Equal(ErrInvalidIdentityCall, errors.Cause(p.Validate(ctx, newExec(0, &CandidateIdentityCall{}), sm)))
+	// not enabled
+	disabled := genesis.TestDefault()
+	disabled.ToBeEnabledBlockHeight = 11
+	require.NoError(p.Validate(protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, disabled)), newExec(1, attest), sm))
+}
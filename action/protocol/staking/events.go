@@ -0,0 +1,39 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+)
+
+// SelfStakeClearedTopic is the topic of SelfStakeClearedEvent
+const SelfStakeClearedTopic = "staking.selfStakeCleared"
+
+// SelfStakeClearedEvent is published when the self-stake of a candidate is cleared
+type SelfStakeClearedEvent struct {
+	Candidate   address.Address
+	BucketIndex uint64
+}
+
+// Topic returns the topic of the event
+func (e *SelfStakeClearedEvent) Topic() string {
+	return SelfStakeClearedTopic
+}
+
+func publishSelfStakeCleared(ctx context.Context, cand *Candidate, bucketIdx uint64) error {
+	if err := protocol.PublishEvent(ctx, &SelfStakeClearedEvent{
+		Candidate:   cand.GetIdentifier(),
+		BucketIndex: bucketIdx,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to publish self-stake cleared event of candidate %s", cand.GetIdentifier().String())
+	}
+	return nil
+}
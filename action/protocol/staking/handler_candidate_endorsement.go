@@ -77,6 +77,9 @@ func (p *Protocol) handleCandidateEndorsement(ctx context.Context, act *action.C
 			if err := csm.Upsert(cand); err != nil {
 				return log, nil, csmErrorToHandleError(actCtx.Caller.String(), err)
 			}
+			if err := publishSelfStakeCleared(ctx, cand, bucket.Index); err != nil {
+				return log, nil, err
+			}
 		}
 		if err := esm.Delete(bucket.Index); err != nil {
 			return log, nil, errors.Wrapf(err, "failed to delete endorsement with bucket index %d", bucket.Index)
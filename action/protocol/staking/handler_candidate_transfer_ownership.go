@@ -67,6 +67,10 @@ func (p *Protocol) handleCandidateTransferOwnership(ctx context.Context, act *ac
 		}
 		return false, nil, errors.Wrap(err, "failed to get self-stake bucket")
 	}
+	var (
+		selfStakeCleared bool
+		selfStakeIdx     = candidate.SelfStakeBucketIdx
+	)
 	if candidate.isSelfStakeBucketSettled() {
 		clear, subVotes, err := needClear()
 		if err != nil {
@@ -76,11 +80,17 @@ func (p *Protocol) handleCandidateTransferOwnership(ctx context.Context, act *ac
 			candidate.SelfStakeBucketIdx = candidateNoSelfStakeBucketIndex
 			candidate.SelfStake = big.NewInt(0)
 			candidate.Votes.Sub(candidate.Votes, subVotes)
+			selfStakeCleared = true
 		}
 	}
 	if err := csm.Upsert(candidate); err != nil {
 		return log, nil, csmErrorToHandleError(candidate.GetIdentifier().String(), err)
 	}
+	if selfStakeCleared {
+		if err := publishSelfStakeCleared(ctx, candidate, selfStakeIdx); err != nil {
+			return log, nil, err
+		}
+	}
 	log.AddTopics(actCtx.Caller.Bytes(), act.NewOwner().Bytes())
 	return log, nil, nil
 }
@@ -31,7 +31,7 @@ func (p *Protocol) handleStakeMigrate(ctx context.Context, elp action.Envelope,
 		actLogs      = make([]*action.Log, 0)
 		transferLogs = make([]*action.TransactionLog, 0)
 		act          = elp.Action().(*action.MigrateStake)
-		insGas, err  = act.IntrinsicGas()
+		insGas, err  = protocol.IntrinsicGas(ctx, elp)
 	)
 	if err != nil {
 		return nil, nil, 0, 0, err
@@ -137,13 +137,19 @@ func (p *Protocol) withdrawBucket(ctx context.Context, withdrawer *state.Account
 		}
 	}
 	// clear candidate's self stake if the
-	if cand.SelfStakeBucketIdx == bucket.Index {
+	selfStakeCleared := cand.SelfStakeBucketIdx == bucket.Index
+	if selfStakeCleared {
 		cand.SelfStake = big.NewInt(0)
 		cand.SelfStakeBucketIdx = candidateNoSelfStakeBucketIndex
 	}
 	if err := csm.Upsert(cand); err != nil {
 		return nil, nil, csmErrorToHandleError(cand.GetIdentifier().String(), err)
 	}
+	if selfStakeCleared {
+		if err := publishSelfStakeCleared(ctx, cand, bucket.Index); err != nil {
+			return nil, nil, err
+		}
+	}
 	// update withdrawer balance
 	if err := withdrawer.AddBalance(bucket.StakedAmount); err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to add balance %s", bucket.StakedAmount)
@@ -217,6 +217,11 @@ func (p *Protocol) handleUnstake(ctx context.Context, act *action.Unstake, csm C
 	if err := csm.Upsert(candidate); err != nil {
 		return log, csmErrorToHandleError(candidate.GetIdentifier().String(), err)
 	}
+	if selfStake {
+		if err := publishSelfStakeCleared(ctx, candidate, bucket.Index); err != nil {
+			return log, err
+		}
+	}
 
 	log.AddAddress(actionCtx.Caller)
 	return log, nil
@@ -371,13 +376,19 @@ func (p *Protocol) handleChangeCandidate(ctx context.Context, act *action.Change
 	}
 	// if the bucket equals to the previous candidate's self-stake bucket, it must be expired endorse bucket
 	// so we need to clear the self-stake of the previous candidate
-	if !featureCtx.DisableDelegateEndorsement && prevCandidate.SelfStakeBucketIdx == bucket.Index {
+	selfStakeCleared := !featureCtx.DisableDelegateEndorsement && prevCandidate.SelfStakeBucketIdx == bucket.Index
+	if selfStakeCleared {
 		prevCandidate.SelfStake.SetInt64(0)
 		prevCandidate.SelfStakeBucketIdx = candidateNoSelfStakeBucketIndex
 	}
 	if err := csm.Upsert(prevCandidate); err != nil {
 		return log, csmErrorToHandleError(prevCandidate.GetIdentifier().String(), err)
 	}
+	if selfStakeCleared {
+		if err := publishSelfStakeCleared(ctx, prevCandidate, bucket.Index); err != nil {
+			return log, err
+		}
+	}
 
 	// update current candidate
 	if err := candidate.AddVote(weightedVotes); err != nil {
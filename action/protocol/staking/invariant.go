@@ -0,0 +1,105 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// ErrInvariantViolated indicates the staking states are inconsistent
+var ErrInvariantViolated = errors.New("staking invariant violated")
+
+// BucketPoolTotal returns the total amount and the number of buckets stored in bucket pool, which is only stored
+// in state after Greenland height
+func BucketPoolTotal(sr protocol.StateReader) (*big.Int, uint64, error) {
+	total := totalAmount{amount: big.NewInt(0)}
+	if _, err := sr.State(&total, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey)); err != nil {
+		return nil, 0, err
+	}
+	return total.amount, total.count, nil
+}
+
+// CheckBucketPool checks the total amount and count of bucket pool match the native buckets in state
+func CheckBucketPool(sr protocol.StateReader) error {
+	amount, count, err := BucketPoolTotal(sr)
+	if err != nil {
+		return err
+	}
+	buckets, _, err := newCandidateStateReader(sr).getAllBuckets()
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	sum := big.NewInt(0)
+	for _, bucket := range buckets {
+		sum.Add(sum, bucket.StakedAmount)
+	}
+	if sum.Cmp(amount) != 0 || uint64(len(buckets)) != count {
+		return errors.Wrapf(ErrInvariantViolated, "bucket pool has %d buckets of %s, buckets in state are %d of %s",
+			count, amount, len(buckets), sum)
+	}
+	return nil
+}
+
+// CheckCandidateVotes checks the votes and self-stake of candidates match those recalculated from the native buckets
+// in state, in the same way as the vote reviser does
+func CheckCandidateVotes(sr protocol.StateReader, c genesis.VoteWeightCalConsts) error {
+	csr := newCandidateStateReader(sr)
+	cands, _, err := csr.getAllCandidates()
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	buckets, _, err := csr.getAllBuckets()
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	var (
+		votes      = make(map[string]*big.Int, len(cands))
+		selfStakes = make(map[string]*big.Int, len(cands))
+		selfIdx    = make(map[string]uint64, len(cands))
+	)
+	for _, cand := range cands {
+		id := cand.GetIdentifier().String()
+		votes[id] = big.NewInt(0)
+		selfStakes[id] = big.NewInt(0)
+		selfIdx[id] = cand.SelfStakeBucketIdx
+	}
+	for _, bucket := range buckets {
+		if bucket.isUnstaked() {
+			continue
+		}
+		id := bucket.Candidate.String()
+		if _, ok := votes[id]; !ok {
+			continue
+		}
+		selfStake := selfIdx[id] == bucket.Index
+		votes[id].Add(votes[id], CalculateVoteWeight(c, bucket, selfStake))
+		if selfStake {
+			selfStakes[id] = bucket.StakedAmount
+		}
+	}
+	var mismatches []string
+	for _, cand := range cands {
+		id := cand.GetIdentifier().String()
+		if cand.Votes.Cmp(votes[id]) != 0 || cand.SelfStake.Cmp(selfStakes[id]) != 0 {
+			mismatches = append(mismatches, fmt.Sprintf("%s has votes %s and self-stake %s, expecting %s and %s",
+				cand.Name, cand.Votes, cand.SelfStake, votes[id], selfStakes[id]))
+		}
+	}
+	if len(mismatches) > 0 {
+		sort.Strings(mismatches)
+		return errors.Wrap(ErrInvariantViolated, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
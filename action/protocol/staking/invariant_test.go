@@ -0,0 +1,79 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	csm := newCandidateStateManager(sm)
+	consts := genesis.TestDefault().Staking.VoteWeightCalConsts
+
+	// bucket pool is not stored before Greenland
+	_, _, err := BucketPoolTotal(sm)
+	r.Equal(state.ErrStateNotExist, errors.Cause(err))
+	r.Equal(state.ErrStateNotExist, errors.Cause(CheckBucketPool(sm)))
+
+	owner, cand := identityset.Address(1), identityset.Address(2)
+	now := time.Now()
+	buckets := []*VoteBucket{
+		NewVoteBucket(cand, cand, big.NewInt(1200), 91, now, true),
+		NewVoteBucket(cand, owner, big.NewInt(100), 21, now, false),
+		NewVoteBucket(cand, owner, big.NewInt(50), 0, now, false),
+	}
+	// unstaked bucket has no votes
+	buckets[2].UnstakeStartTime = now.Add(time.Hour)
+	pool := totalAmount{amount: big.NewInt(0)}
+	for _, b := range buckets {
+		_, err = csm.putBucket(b)
+		r.NoError(err)
+		pool.AddBalance(b.StakedAmount, true)
+	}
+	_, err = sm.PutState(&pool, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey))
+	r.NoError(err)
+	r.NoError(CheckBucketPool(sm))
+
+	c := &Candidate{
+		Owner:              cand,
+		Operator:           cand,
+		Reward:             cand,
+		Name:               "cand",
+		Votes:              new(big.Int).Add(CalculateVoteWeight(consts, buckets[0], true), CalculateVoteWeight(consts, buckets[1], false)),
+		SelfStakeBucketIdx: 0,
+		SelfStake:          big.NewInt(1200),
+	}
+	r.NoError(csm.putCandidate(c))
+	r.NoError(CheckCandidateVotes(sm, consts))
+
+	// votes missing the self-stake bonus
+	c.Votes = new(big.Int).Add(CalculateVoteWeight(consts, buckets[0], false), CalculateVoteWeight(consts, buckets[1], false))
+	r.NoError(csm.putCandidate(c))
+	err = CheckCandidateVotes(sm, consts)
+	r.Equal(ErrInvariantViolated, errors.Cause(err))
+	r.Contains(err.Error(), "cand has votes")
+
+	// bucket pool missing a deposit
+	pool.AddBalance(big.NewInt(1), false)
+	_, err = sm.PutState(&pool, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey))
+	r.NoError(err)
+	r.Equal(ErrInvariantViolated, errors.Cause(CheckBucketPool(sm)))
+}
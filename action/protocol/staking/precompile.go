@@ -0,0 +1,195 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const (
+	_precompileJSONABI = `[
+	{
+		"inputs": [{"internalType": "string", "name": "name", "type": "string"}],
+		"name": "candidateByName",
+		"outputs": [
+			{"internalType": "address", "name": "id", "type": "address"},
+			{"internalType": "address", "name": "owner", "type": "address"},
+			{"internalType": "address", "name": "operator", "type": "address"},
+			{"internalType": "address", "name": "reward", "type": "address"},
+			{"internalType": "uint256", "name": "totalWeightedVotes", "type": "uint256"},
+			{"internalType": "uint64", "name": "selfStakeBucketIdx", "type": "uint64"},
+			{"internalType": "uint256", "name": "selfStakingTokens", "type": "uint256"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [{"internalType": "uint64", "name": "index", "type": "uint64"}],
+		"name": "bucketByIndex",
+		"outputs": [
+			{"internalType": "address", "name": "owner", "type": "address"},
+			{"internalType": "address", "name": "candidate", "type": "address"},
+			{"internalType": "uint256", "name": "stakedAmount", "type": "uint256"},
+			{"internalType": "uint64", "name": "stakedDuration", "type": "uint64"},
+			{"internalType": "uint64", "name": "stakeStartTime", "type": "uint64"},
+			{"internalType": "uint64", "name": "unstakeStartTime", "type": "uint64"},
+			{"internalType": "bool", "name": "autoStake", "type": "bool"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "totalStaked",
+		"outputs": [{"internalType": "uint256", "name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+	// _precompileReadGas is the gas charged for each read of the staking precompiled contract
+	_precompileReadGas = uint64(5000)
+)
+
+var (
+	// PrecompileAddress is the address of the read-only staking precompiled contract
+	PrecompileAddress = common.HexToAddress("0x0000000000000000000000000000000000001001")
+
+	_precompileABI abi.ABI
+)
+
+func init() {
+	var err error
+	_precompileABI, err = abi.JSON(strings.NewReader(_precompileJSONABI))
+	if err != nil {
+		panic(err)
+	}
+	if err = evm.RegisterStatefulPrecompile(PrecompileAddress, _protocolID); err != nil {
+		panic(err)
+	}
+}
+
+// PrecompileAddress returns the address of the staking precompiled contract
+func (p *Protocol) PrecompileAddress() common.Address {
+	return PrecompileAddress
+}
+
+// RequiredGas returns the gas required to run the input on the staking precompiled contract
+func (p *Protocol) RequiredGas(input []byte) uint64 {
+	return _precompileReadGas
+}
+
+// RunPrecompile reads the native staking states for the staking precompiled contract, a candidate or bucket
+// which does not exist is returned with zero values
+func (p *Protocol) RunPrecompile(ctx context.Context, sr protocol.StateReader, input []byte) ([]byte, error) {
+	if len(input) < 4 {
+		return nil, errors.New("invalid input of staking precompile")
+	}
+	method, err := _precompileABI.MethodById(input[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unpack arguments of %s", method.Name)
+	}
+	switch method.Name {
+	case "candidateByName":
+		name, ok := args[0].(string)
+		if !ok {
+			return nil, errors.Wrap(ErrTypeAssertion, "expecting string")
+		}
+		cand, err := candidateByName(sr, name)
+		if err != nil {
+			return nil, err
+		}
+		if cand == nil {
+			return method.Outputs.Pack(common.Address{}, common.Address{}, common.Address{}, common.Address{}, big.NewInt(0), uint64(0), big.NewInt(0))
+		}
+		return method.Outputs.Pack(
+			toEthAddress(cand.GetIdentifier()),
+			toEthAddress(cand.Owner),
+			toEthAddress(cand.Operator),
+			toEthAddress(cand.Reward),
+			cand.Votes,
+			cand.SelfStakeBucketIdx,
+			cand.SelfStake,
+		)
+	case "bucketByIndex":
+		index, ok := args[0].(uint64)
+		if !ok {
+			return nil, errors.Wrap(ErrTypeAssertion, "expecting uint64")
+		}
+		bucket, err := newCandidateStateReader(sr).getBucket(index)
+		switch errors.Cause(err) {
+		case nil:
+		case state.ErrStateNotExist:
+			return method.Outputs.Pack(common.Address{}, common.Address{}, big.NewInt(0), uint64(0), uint64(0), uint64(0), false)
+		default:
+			return nil, err
+		}
+		unstakeTime := uint64(0)
+		if bucket.isUnstaked() {
+			unstakeTime = uint64(bucket.UnstakeStartTime.Unix())
+		}
+		return method.Outputs.Pack(
+			toEthAddress(bucket.Owner),
+			toEthAddress(bucket.Candidate),
+			bucket.StakedAmount,
+			uint64(bucket.StakedDuration.Seconds()),
+			uint64(bucket.StakeStartTime.Unix()),
+			unstakeTime,
+			bucket.AutoStake,
+		)
+	case "totalStaked":
+		var total totalAmount
+		_, err := sr.State(&total, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey))
+		switch errors.Cause(err) {
+		case nil:
+			return method.Outputs.Pack(total.amount)
+		case state.ErrStateNotExist:
+			return method.Outputs.Pack(big.NewInt(0))
+		default:
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("unknown method %s", method.Name)
+	}
+}
+
+// candidateByName reads the candidate by name, the changes in the current block are included if sr is a state manager
+func candidateByName(sr protocol.StateReader, name string) (*Candidate, error) {
+	if sm, ok := sr.(protocol.StateManager); ok {
+		csm, err := NewCandidateStateManager(sm, false)
+		if err != nil {
+			return nil, err
+		}
+		return csm.GetByName(name), nil
+	}
+	csr, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, err
+	}
+	return csr.GetCandidateByName(name), nil
+}
+
+func toEthAddress(addr address.Address) common.Address {
+	if addr == nil {
+		return common.Address{}
+	}
+	return common.BytesToAddress(addr.Bytes())
+}
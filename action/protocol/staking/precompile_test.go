@@ -0,0 +1,96 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestRunPrecompile(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	v, _, err := CreateBaseView(sm, true)
+	require.NoError(err)
+	require.NoError(sm.WriteView(_protocolID, v))
+	_, err = sm.PutState(v.bucketPool.total, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey))
+	require.NoError(err)
+	csm, err := NewCandidateStateManager(sm, true)
+	require.NoError(err)
+
+	p := &Protocol{}
+	ctx := context.Background()
+	owner := identityset.Address(1)
+	start := time.Unix(1700000000, 0).UTC()
+	bkt := NewVoteBucket(owner, owner, big.NewInt(100), 7, start, true)
+	idx, err := csm.putBucketAndIndex(bkt)
+	require.NoError(err)
+	require.NoError(csm.DebitBucketPool(big.NewInt(100), true))
+	cand := &Candidate{
+		Owner:              owner,
+		Operator:           identityset.Address(2),
+		Reward:             identityset.Address(3),
+		Name:               "test",
+		Votes:              big.NewInt(120),
+		SelfStakeBucketIdx: idx,
+		SelfStake:          big.NewInt(100),
+	}
+	require.NoError(csm.Upsert(cand))
+
+	run := func(method string, args ...interface{}) []interface{} {
+		input, err := _precompileABI.Pack(method, args...)
+		require.NoError(err)
+		output, err := p.RunPrecompile(ctx, sm, input)
+		require.NoError(err)
+		res, err := _precompileABI.Unpack(method, output)
+		require.NoError(err)
+		return res
+	}
+
+	// candidate changed in the current block is read
+	res := run("candidateByName", "test")
+	require.Equal(common.BytesToAddress(owner.Bytes()), res[0])
+	require.Equal(common.BytesToAddress(owner.Bytes()), res[1])
+	require.Equal(common.BytesToAddress(identityset.Address(2).Bytes()), res[2])
+	require.Equal(common.BytesToAddress(identityset.Address(3).Bytes()), res[3])
+	require.Zero(big.NewInt(120).Cmp(res[4].(*big.Int)))
+	require.Equal(idx, res[5])
+	require.Zero(big.NewInt(100).Cmp(res[6].(*big.Int)))
+	res = run("candidateByName", "notexist")
+	require.Equal(common.Address{}, res[0])
+
+	res = run("bucketByIndex", idx)
+	require.Equal(common.BytesToAddress(owner.Bytes()), res[0])
+	require.Zero(big.NewInt(100).Cmp(res[2].(*big.Int)))
+	require.EqualValues(7*24*3600, res[3])
+	require.EqualValues(start.Unix(), res[4])
+	require.Zero(res[5])
+	require.True(res[6].(bool))
+	res = run("bucketByIndex", idx+1)
+	require.Equal(common.Address{}, res[0])
+
+	res = run("totalStaked")
+	require.Zero(big.NewInt(100).Cmp(res[0].(*big.Int)))
+
+	// invalid input
+	_, err = p.RunPrecompile(ctx, sm, []byte{1, 2})
+	require.Error(err)
+	_, err = p.RunPrecompile(ctx, sm, []byte{1, 2, 3, 4})
+	require.Error(err)
+	require.Equal(_precompileReadGas, p.RequiredGas(nil))
+	require.Equal(PrecompileAddress, p.PrecompileAddress())
+}
@@ -52,6 +52,7 @@ const (
 	_voterIndex
 	_candIndex
 	_endorsement
+	_candIdentity
 )
 
 // Errors
@@ -83,7 +84,9 @@ type (
 		contractStakingIndexerV2 ContractStakingIndexer
 		voteReviser              *VoteReviser
 		patch                    *PatchStore
+		viewCache                *ViewCache
 		helperCtx                HelperCtx
+		fundingIndexer           BucketFundingIndexer
 	}
 
 	// Configuration is the staking protocol configuration.
@@ -128,6 +131,7 @@ func NewProtocol(
 	candBucketsIndexer *CandidatesBucketsIndexer,
 	contractStakingIndexer ContractStakingIndexerWithBucketType,
 	contractStakingIndexerV2 ContractStakingIndexer,
+	opts ...Option,
 ) (*Protocol, error) {
 	h := hash.Hash160b([]byte(_protocolID))
 	addr, err := address.FromBytes(h[:])
@@ -156,7 +160,11 @@ func NewProtocol(
 	if contractStakingIndexerV2 != nil {
 		migrateContractAddress = contractStakingIndexerV2.ContractAddress()
 	}
-	return &Protocol{
+	var viewCache *ViewCache
+	if cfg.ViewCacheDir != "" {
+		viewCache = NewViewCache(cfg.ViewCacheDir)
+	}
+	p := &Protocol{
 		addr: addr,
 		config: Configuration{
 			VoteWeightCalConsts: cfg.Staking.VoteWeightCalConsts,
@@ -175,10 +183,15 @@ func NewProtocol(
 		candBucketsIndexer:       candBucketsIndexer,
 		voteReviser:              voteReviser,
 		patch:                    NewPatchStore(cfg.StakingPatchDir),
+		viewCache:                viewCache,
 		contractStakingIndexer:   contractStakingIndexer,
 		helperCtx:                helperCtx,
 		contractStakingIndexerV2: contractStakingIndexerV2,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
 }
 
 // ProtocolAddr returns the address generated from protocol id
@@ -194,6 +207,16 @@ func (p *Protocol) Start(ctx context.Context, sr protocol.StateReader) (interfac
 		return nil, err
 	}
 
+	// load view persisted at last shutdown if state has not changed since then
+	if p.viewCache != nil && featureCtx.ReadStateFromDB(height) {
+		c, err := p.loadViewCache(ctx, sr, height)
+		if err == nil {
+			log.L().Info("Loaded staking view from cache.", zap.Uint64("height", height))
+			return c, nil
+		}
+		log.L().Info("Failed to load staking view from cache, reconstruct from state.", zap.Error(err))
+	}
+
 	// load view from SR
 	c, _, err := CreateBaseView(sr, featureCtx.ReadStateFromDB(height))
 	if err != nil {
@@ -215,6 +238,42 @@ func (p *Protocol) Start(ctx context.Context, sr protocol.StateReader) (interfac
 	return c, nil
 }
 
+func (p *Protocol) loadViewCache(ctx context.Context, sr protocol.StateReader, height uint64) (*ViewData, error) {
+	root, err := stateRoot(sr)
+	if err != nil {
+		return nil, err
+	}
+	return p.viewCache.Load(height, root, p.needToReadCandsMap(ctx, height))
+}
+
+// PersistView persists the staking view at shutdown, to be loaded at next startup
+func (p *Protocol) PersistView(ctx context.Context, sr protocol.StateReader, view interface{}) error {
+	if p.viewCache == nil {
+		return nil
+	}
+	vd, ok := view.(*ViewData)
+	if !ok {
+		return errors.Errorf("unexpected view type %T", view)
+	}
+	root, err := stateRoot(sr)
+	if errors.Cause(err) == ErrNoStateRoot {
+		// the view cannot be bound to the state
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return err
+	}
+	if !protocol.MustGetFeatureWithHeightCtx(ctx).ReadStateFromDB(height) {
+		// bucket pool is not stored in state yet, the view cannot be bound to state
+		return nil
+	}
+	return p.viewCache.Save(height, root, vd)
+}
+
 // CreateGenesisStates is used to setup BootstrapCandidates from genesis config.
 func (p *Protocol) CreateGenesisStates(
 	ctx context.Context,
@@ -412,6 +471,12 @@ func (p *Protocol) Commit(ctx context.Context, sm protocol.StateManager) error {
 
 // Handle handles a staking message
 func (p *Protocol) Handle(ctx context.Context, elp action.Envelope, sm protocol.StateManager) (*action.Receipt, error) {
+	// skip the executions other than the candidate identity calls before loading the candidate states
+	if exec, ok := elp.Action().(*action.Execution); ok {
+		if call, err := p.candidateIdentityCall(ctx, exec); call == nil || err != nil {
+			return nil, err
+		}
+	}
 	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
 	height, err := sm.Height()
 	if err != nil {
@@ -460,6 +525,16 @@ func (p *Protocol) handle(ctx context.Context, elp action.Envelope, csm Candidat
 		rLog, tLogs, err = p.handleCandidateEndorsement(ctx, act, csm)
 	case *action.CandidateTransferOwnership:
 		rLog, tLogs, err = p.handleCandidateTransferOwnership(ctx, act, csm)
+	case *action.Execution:
+		call, callErr := p.candidateIdentityCall(ctx, act)
+		if call == nil || callErr != nil {
+			return nil, callErr
+		}
+		if call.Attest {
+			rLog, err = p.handleAttestCandidateIdentity(ctx, call, csm)
+		} else {
+			rLog, err = p.handleCandidateUpdateIdentity(ctx, call, csm)
+		}
 	case *action.MigrateStake:
 		logs, tLogs, gasConsumed, gasToBeDeducted, err = p.handleStakeMigrate(ctx, elp, csm)
 		if err == nil {
@@ -518,6 +593,8 @@ func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, sr protoco
 		return p.validateCandidateTransferOwnershipAction(ctx, act)
 	case *action.MigrateStake:
 		return p.validateMigrateStake(ctx, act)
+	case *action.Execution:
+		return p.validateCandidateIdentityCall(ctx, act)
 	}
 	return nil
 }
@@ -580,6 +657,16 @@ func (p *Protocol) ActiveCandidates(ctx context.Context, sr protocol.StateReader
 
 // ReadState read the state on blockchain via protocol
 func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	switch string(method) {
+	case WithdrawTimelineMethod:
+		return p.readWithdrawTimeline(ctx, sr, args...)
+	case RewardDistributionMethod:
+		return p.readRewardDistribution(ctx, sr, args...)
+	case CandidateIdentityMethod:
+		return p.readCandidateIdentity(ctx, sr, args...)
+	case BucketFundingMethod, SourceFundingMethod:
+		return p.readBucketFunding(ctx, sr, string(method), args...)
+	}
 	m := iotexapi.ReadStakingDataMethod{}
 	if err := proto.Unmarshal(method, &m); err != nil {
 		return nil, uint64(0), errors.Wrap(err, "failed to unmarshal method name")
@@ -688,6 +775,21 @@ func (p *Protocol) Name() string {
 	return _protocolID
 }
 
+// Namespaces returns the namespaces used by the protocol
+func (p *Protocol) Namespaces() []string {
+	return []string{_stakingNameSpace, _candidateNameSpace, CandsMapNS}
+}
+
+// ExportStates exports all the staking states
+func (p *Protocol) ExportStates(_ context.Context, sr protocol.StateReader, fn func(*protocol.StateEntry) error) error {
+	return protocol.ExportNamespaces(sr, fn, p.Namespaces()...)
+}
+
+// ImportStates imports the staking states, the view needs to be rebuilt by Start afterwards
+func (p *Protocol) ImportStates(_ context.Context, sm protocol.StateManager, entries []*protocol.StateEntry) error {
+	return protocol.ImportNamespaces(sm, entries, p.Namespaces()...)
+}
+
 func (p *Protocol) calculateVoteWeight(v *VoteBucket, selfStake bool) *big.Int {
 	return CalculateVoteWeight(p.config.VoteWeightCalConsts, v, selfStake)
 }
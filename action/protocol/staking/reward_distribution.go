@@ -0,0 +1,219 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+)
+
+// RewardDistributionMethod is the ReadState method to compute the reward shares of the voters of a delegate over an
+// epoch range. The arguments are the candidate name, the start epoch, the end epoch, the commission rate in basis
+// points and the total reward in rau to distribute, all in decimal, and the response is the json encoded
+// RewardDistribution
+const RewardDistributionMethod = "RewardDistribution"
+
+const (
+	// _maxRewardDistributionEpochs is the max number of epochs in one query
+	_maxRewardDistributionEpochs = 720
+	_basisPoints                 = 10000
+)
+
+type (
+	// RewardDistribution is the distribution of the reward of a delegate among its voters. The total reward is split
+	// evenly among the epochs, and the reward of each epoch, less the commission, is shared by the voters in proportion
+	// to the weighted votes of their native buckets at the epoch. The reward of an epoch without votes and the rounding
+	// remainders go to the delegate as the commission
+	RewardDistribution struct {
+		Candidate      string         `json:"candidate"`
+		StartEpoch     uint64         `json:"startEpoch"`
+		EndEpoch       uint64         `json:"endEpoch"`
+		CommissionRate uint64         `json:"commissionRate"`
+		TotalReward    string         `json:"totalReward"`
+		Commission     string         `json:"commission"`
+		Voters         []*VoterReward `json:"voters"`
+	}
+
+	// VoterReward is the reward of a voter
+	VoterReward struct {
+		Voter string `json:"voter"`
+		// Votes is the sum of the weighted votes of the voter over the epochs
+		Votes  string `json:"votes"`
+		Reward string `json:"reward"`
+	}
+)
+
+func (p *Protocol) readRewardDistribution(ctx context.Context, sr protocol.StateReader, args ...[]byte) ([]byte, uint64, error) {
+	if len(args) != 5 {
+		return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	name := string(args[0])
+	var nums [3]uint64
+	for i := range nums {
+		n, err := strconv.ParseUint(string(args[i+1]), 10, 64)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "invalid argument %s", args[i+1])
+		}
+		nums[i] = n
+	}
+	start, end, rate := nums[0], nums[1], nums[2]
+	reward, ok := new(big.Int).SetString(string(args[4]), 10)
+	if !ok || reward.Sign() < 0 {
+		return nil, 0, errors.Errorf("invalid reward %s", args[4])
+	}
+	if rate > _basisPoints {
+		return nil, 0, errors.Errorf("invalid commission rate %d", rate)
+	}
+	if start == 0 || start > end || end-start+1 > _maxRewardDistributionEpochs {
+		return nil, 0, errors.Errorf("invalid epoch range [%d, %d], at most %d epochs", start, end, _maxRewardDistributionEpochs)
+	}
+	if p.candBucketsIndexer == nil {
+		return nil, 0, errors.New("staking indexer is not enabled")
+	}
+	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
+	if rp == nil {
+		return nil, 0, errors.New("rolldpos protocol is not registered")
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, 0, err
+	}
+	if current := rp.GetEpochNum(height); end > current {
+		return nil, height, errors.Errorf("end epoch %d is beyond the current epoch %d", end, current)
+	}
+
+	epochVotes := make([]map[string]*big.Int, 0, end-start+1)
+	for epoch := start; epoch <= end; epoch++ {
+		votes, err := p.epochVotes(rp.GetEpochHeight(epoch), name)
+		if err != nil {
+			return nil, height, err
+		}
+		epochVotes = append(epochVotes, votes)
+	}
+	distribution := distributeReward(epochVotes, reward, rate)
+	distribution.Candidate = name
+	distribution.StartEpoch = start
+	distribution.EndEpoch = end
+	data, err := json.Marshal(distribution)
+	if err != nil {
+		return nil, height, err
+	}
+	return data, height, nil
+}
+
+// epochVotes returns the weighted votes of the voters of the candidate at the epoch start height
+func (p *Protocol) epochVotes(epochHeight uint64, name string) (map[string]*big.Int, error) {
+	candidates, _, err := p.candBucketsIndexer.GetCandidates(epochHeight, 0, math.MaxUint32)
+	if err != nil {
+		return nil, err
+	}
+	buckets, _, err := p.candBucketsIndexer.GetBuckets(epochHeight, 0, math.MaxUint32)
+	if err != nil {
+		return nil, err
+	}
+	return votesByVoter(candidates, buckets, name, p.calculateVoteWeight), nil
+}
+
+// votesByVoter sums the weighted votes of the buckets voting for the candidate by their owners
+func votesByVoter(
+	candidates *iotextypes.CandidateListV2,
+	buckets *iotextypes.VoteBucketList,
+	name string,
+	calculateVoteWeight func(*VoteBucket, bool) *big.Int,
+) map[string]*big.Int {
+	votes := make(map[string]*big.Int)
+	var cand *iotextypes.CandidateV2
+	for _, c := range candidates.GetCandidates() {
+		if c.Name == name {
+			cand = c
+			break
+		}
+	}
+	if cand == nil {
+		return votes
+	}
+	for _, b := range buckets.GetBuckets() {
+		if b.CandidateAddress != cand.Id || b.UnstakeStartTime.AsTime().After(b.StakeStartTime.AsTime()) {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(b.StakedAmount, 10)
+		if !ok {
+			continue
+		}
+		weight := calculateVoteWeight(&VoteBucket{
+			StakedAmount:   amount,
+			StakedDuration: time.Duration(b.StakedDuration) * 24 * time.Hour,
+			AutoStake:      b.AutoStake,
+		}, b.Index == cand.SelfStakeBucketIdx)
+		if v, ok := votes[b.Owner]; ok {
+			v.Add(v, weight)
+		} else {
+			votes[b.Owner] = weight
+		}
+	}
+	return votes
+}
+
+func distributeReward(epochVotes []map[string]*big.Int, totalReward *big.Int, rate uint64) *RewardDistribution {
+	var (
+		voterVotes   = make(map[string]*big.Int)
+		voterRewards = make(map[string]*big.Int)
+		distributed  = new(big.Int)
+		numEpochs    = big.NewInt(int64(len(epochVotes)))
+	)
+	for _, votes := range epochVotes {
+		total := new(big.Int)
+		for _, v := range votes {
+			total.Add(total, v)
+		}
+		if total.Sign() == 0 {
+			continue
+		}
+		// the reward of the epoch shared by the voters
+		shared := new(big.Int).Div(totalReward, numEpochs)
+		shared.Mul(shared, big.NewInt(int64(_basisPoints-rate)))
+		shared.Div(shared, big.NewInt(_basisPoints))
+		for voter, v := range votes {
+			r := new(big.Int).Mul(shared, v)
+			r.Div(r, total)
+			if _, ok := voterRewards[voter]; !ok {
+				voterRewards[voter] = new(big.Int)
+				voterVotes[voter] = new(big.Int)
+			}
+			voterRewards[voter].Add(voterRewards[voter], r)
+			voterVotes[voter].Add(voterVotes[voter], v)
+			distributed.Add(distributed, r)
+		}
+	}
+	distribution := &RewardDistribution{
+		CommissionRate: rate,
+		TotalReward:    totalReward.String(),
+		Commission:     new(big.Int).Sub(totalReward, distributed).String(),
+		Voters:         make([]*VoterReward, 0, len(voterRewards)),
+	}
+	for voter, r := range voterRewards {
+		distribution.Voters = append(distribution.Voters, &VoterReward{
+			Voter:  voter,
+			Votes:  voterVotes[voter].String(),
+			Reward: r.String(),
+		})
+	}
+	sort.Slice(distribution.Voters, func(i, j int) bool {
+		return distribution.Voters[i].Voter < distribution.Voters[j].Voter
+	})
+	return distribution
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestVotesByVoter(t *testing.T) {
+	require := require.New(t)
+
+	start := timestamppb.New(time.Unix(1700000000, 0))
+	unstake := timestamppb.New(time.Unix(1700000000, 0).Add(time.Hour))
+	candidates := &iotextypes.CandidateListV2{Candidates: []*iotextypes.CandidateV2{
+		{Name: "alice", Id: "id1", SelfStakeBucketIdx: 0},
+		{Name: "bob", Id: "id2", SelfStakeBucketIdx: 4},
+	}}
+	buckets := &iotextypes.VoteBucketList{Buckets: []*iotextypes.VoteBucket{
+		{Index: 0, CandidateAddress: "id1", Owner: "a", StakedAmount: "100", StakeStartTime: start, UnstakeStartTime: start},
+		{Index: 1, CandidateAddress: "id1", Owner: "b", StakedAmount: "100", StakeStartTime: start, UnstakeStartTime: start},
+		{Index: 2, CandidateAddress: "id1", Owner: "b", StakedAmount: "200", StakeStartTime: start, UnstakeStartTime: start},
+		{Index: 3, CandidateAddress: "id1", Owner: "c", StakedAmount: "100", StakeStartTime: start, UnstakeStartTime: unstake},
+		{Index: 4, CandidateAddress: "id2", Owner: "c", StakedAmount: "100", StakeStartTime: start, UnstakeStartTime: start},
+	}}
+	// self-stake bucket is weighted double
+	weight := func(v *VoteBucket, selfStake bool) *big.Int {
+		if selfStake {
+			return new(big.Int).Mul(v.StakedAmount, big.NewInt(2))
+		}
+		return new(big.Int).Set(v.StakedAmount)
+	}
+
+	votes := votesByVoter(candidates, buckets, "alice", weight)
+	require.Len(votes, 2)
+	require.Equal(big.NewInt(200), votes["a"])
+	require.Equal(big.NewInt(300), votes["b"])
+	votes = votesByVoter(candidates, buckets, "bob", weight)
+	require.Equal(map[string]*big.Int{"c": big.NewInt(200)}, votes)
+	require.Empty(votesByVoter(candidates, buckets, "carol", weight))
+}
+
+func TestDistributeReward(t *testing.T) {
+	require := require.New(t)
+
+	epochVotes := []map[string]*big.Int{
+		{"a": big.NewInt(100), "b": big.NewInt(300)},
+		{},
+	}
+	// 500 per epoch, 450 after 10% commission, no voter in the 2nd epoch
+	d := distributeReward(epochVotes, big.NewInt(1000), 1000)
+	require.EqualValues(1000, d.CommissionRate)
+	require.Equal("1000", d.TotalReward)
+	require.Equal("551", d.Commission)
+	require.Equal([]*VoterReward{
+		{Voter: "a", Votes: "100", Reward: "112"},
+		{Voter: "b", Votes: "300", Reward: "337"},
+	}, d.Voters)
+
+	epochVotes[1] = map[string]*big.Int{"b": big.NewInt(100)}
+	d = distributeReward(epochVotes, big.NewInt(1000), 0)
+	require.Equal("0", d.Commission)
+	require.Equal([]*VoterReward{
+		{Voter: "a", Votes: "100", Reward: "125"},
+		{Voter: "b", Votes: "400", Reward: "875"},
+	}, d.Voters)
+
+	// all the reward is the commission at 100%
+	d = distributeReward(epochVotes, big.NewInt(1000), _basisPoints)
+	require.Equal("1000", d.Commission)
+	require.Equal("0", d.Voters[0].Reward)
+}
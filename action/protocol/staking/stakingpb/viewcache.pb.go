@@ -0,0 +1,214 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// To compile the proto, run:
+//      protoc --go_out=plugins=grpc:. *.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.4
+// 	protoc        v3.19.4
+// source: viewcache.proto
+
+package stakingpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ViewCache struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Height             uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Root               []byte                 `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+	Candidates         *Candidates            `protobuf:"bytes,3,opt,name=candidates,proto3" json:"candidates,omitempty"`
+	TotalAmount        *TotalAmount           `protobuf:"bytes,4,opt,name=totalAmount,proto3" json:"totalAmount,omitempty"`
+	NameCandidates     *Candidates            `protobuf:"bytes,5,opt,name=nameCandidates,proto3" json:"nameCandidates,omitempty"`
+	OperatorCandidates *Candidates            `protobuf:"bytes,6,opt,name=operatorCandidates,proto3" json:"operatorCandidates,omitempty"`
+	OwnerCandidates    *Candidates            `protobuf:"bytes,7,opt,name=ownerCandidates,proto3" json:"ownerCandidates,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ViewCache) Reset() {
+	*x = ViewCache{}
+	mi := &file_viewcache_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewCache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewCache) ProtoMessage() {}
+
+func (x *ViewCache) ProtoReflect() protoreflect.Message {
+	mi := &file_viewcache_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewCache.ProtoReflect.Descriptor instead.
+func (*ViewCache) Descriptor() ([]byte, []int) {
+	return file_viewcache_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ViewCache) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ViewCache) GetRoot() []byte {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *ViewCache) GetCandidates() *Candidates {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *ViewCache) GetTotalAmount() *TotalAmount {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return nil
+}
+
+func (x *ViewCache) GetNameCandidates() *Candidates {
+	if x != nil {
+		return x.NameCandidates
+	}
+	return nil
+}
+
+func (x *ViewCache) GetOperatorCandidates() *Candidates {
+	if x != nil {
+		return x.OperatorCandidates
+	}
+	return nil
+}
+
+func (x *ViewCache) GetOwnerCandidates() *Candidates {
+	if x != nil {
+		return x.OwnerCandidates
+	}
+	return nil
+}
+
+var File_viewcache_proto protoreflect.FileDescriptor
+
+var file_viewcache_proto_rawDesc = string([]byte{
+	0x0a, 0x0f, 0x76, 0x69, 0x65, 0x77, 0x63, 0x61, 0x63, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x1a, 0x0d, 0x73, 0x74,
+	0x61, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xef, 0x02, 0x0a, 0x09,
+	0x56, 0x69, 0x65, 0x77, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x74, 0x61, 0x6b,
+	0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x0b,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3d, 0x0a, 0x0e, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0e, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x45, 0x0a, 0x12, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x12, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x0f,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70,
+	0x62, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0f, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x42, 0x46, 0x5a,
+	0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f, 0x74, 0x65,
+	0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x2d, 0x63,
+	0x6f, 0x72, 0x65, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x74, 0x61, 0x6b,
+	0x69, 0x6e, 0x67, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_viewcache_proto_rawDescOnce sync.Once
+	file_viewcache_proto_rawDescData []byte
+)
+
+func file_viewcache_proto_rawDescGZIP() []byte {
+	file_viewcache_proto_rawDescOnce.Do(func() {
+		file_viewcache_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_viewcache_proto_rawDesc), len(file_viewcache_proto_rawDesc)))
+	})
+	return file_viewcache_proto_rawDescData
+}
+
+var file_viewcache_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_viewcache_proto_goTypes = []any{
+	(*ViewCache)(nil),   // 0: stakingpb.ViewCache
+	(*Candidates)(nil),  // 1: stakingpb.Candidates
+	(*TotalAmount)(nil), // 2: stakingpb.TotalAmount
+}
+var file_viewcache_proto_depIdxs = []int32{
+	1, // 0: stakingpb.ViewCache.candidates:type_name -> stakingpb.Candidates
+	2, // 1: stakingpb.ViewCache.totalAmount:type_name -> stakingpb.TotalAmount
+	1, // 2: stakingpb.ViewCache.nameCandidates:type_name -> stakingpb.Candidates
+	1, // 3: stakingpb.ViewCache.operatorCandidates:type_name -> stakingpb.Candidates
+	1, // 4: stakingpb.ViewCache.ownerCandidates:type_name -> stakingpb.Candidates
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_viewcache_proto_init() }
+func file_viewcache_proto_init() {
+	if File_viewcache_proto != nil {
+		return
+	}
+	file_staking_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_viewcache_proto_rawDesc), len(file_viewcache_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_viewcache_proto_goTypes,
+		DependencyIndexes: file_viewcache_proto_depIdxs,
+		MessageInfos:      file_viewcache_proto_msgTypes,
+	}.Build()
+	File_viewcache_proto = out.File
+	file_viewcache_proto_goTypes = nil
+	file_viewcache_proto_depIdxs = nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking/stakingpb"
+)
+
+const (
+	_viewCacheFile = "staking.view"
+)
+
+var (
+	// ErrViewCacheMismatch indicates the persisted view does not match the current state
+	ErrViewCacheMismatch = errors.New("staking view cache does not match state")
+	// ErrNoStateRoot indicates the state reader does not commit to the states with a root hash
+	ErrNoStateRoot = errors.New("state root is not available")
+)
+
+type (
+	// ViewCache persists the staking view at shutdown, so that the view can be loaded at next
+	// startup without reconstructing it from state. The view is bound to the state root, so it is
+	// only available for the state reader with a state root, i.e., the trie-based state factory
+	ViewCache struct {
+		dir string
+	}
+
+	persistedView struct {
+		height     uint64
+		root       hash.Hash256
+		candidates CandidateList
+		total      *totalAmount
+		name       CandidateList
+		operator   CandidateList
+		owners     CandidateList
+	}
+)
+
+// NewViewCache creates a new staking view cache
+func NewViewCache(dir string) *ViewCache {
+	return &ViewCache{dir: dir}
+}
+
+func (vc *ViewCache) path() string {
+	return filepath.Join(vc.dir, _viewCacheFile)
+}
+
+// Save writes the view of given height and state root to disk
+func (vc *ViewCache) Save(height uint64, root hash.Hash256, view *ViewData) error {
+	if view == nil || view.candCenter == nil || view.bucketPool == nil {
+		return ErrMissingField
+	}
+	pb := &stakingpb.ViewCache{
+		Height:      height,
+		Root:        root[:],
+		TotalAmount: view.bucketPool.total.toProto(),
+	}
+	base := view.candCenter.base
+	for _, c := range []struct {
+		list CandidateList
+		pb   **stakingpb.Candidates
+	}{
+		{view.candCenter.All(), &pb.Candidates},
+		{base.candsInNameMap(), &pb.NameCandidates},
+		{base.candsInOperatorMap(), &pb.OperatorCandidates},
+		{base.ownersList(), &pb.OwnerCandidates},
+	} {
+		cands, err := c.list.toProto()
+		if err != nil {
+			return errors.Wrap(err, "failed to serialize staking view")
+		}
+		*c.pb = cands
+	}
+	data, err := proto.Marshal(pb)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize staking view")
+	}
+	if err := os.MkdirAll(vc.dir, 0755); err != nil {
+		return err
+	}
+	// write to a temp file then rename, so that a partially written cache is never loaded
+	tmp := vc.path() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, vc.path())
+}
+
+// Load reads the persisted view, which is only returned if it matches the height and state root. The cache
+// file is kept, so that every protocol starting from the staking view, e.g., poll, loads it as well
+func (vc *ViewCache) Load(height uint64, root hash.Hash256, loadCandsMap bool) (*ViewData, error) {
+	pv, err := vc.read()
+	if err != nil {
+		return nil, err
+	}
+	if pv.height != height || pv.root != root {
+		return nil, errors.Wrapf(ErrViewCacheMismatch, "cache at height %d root %x, state at height %d root %x", pv.height, pv.root, height, root)
+	}
+	center, err := NewCandidateCenter(pv.candidates)
+	if err != nil {
+		return nil, err
+	}
+	if loadCandsMap {
+		if err := center.base.loadNameOperatorMapOwnerList(pv.name, pv.operator, pv.owners); err != nil {
+			return nil, err
+		}
+	}
+	return &ViewData{
+		candCenter: center,
+		bucketPool: &BucketPool{
+			enableSMStorage: true,
+			total:           pv.total,
+		},
+	}, nil
+}
+
+func (vc *ViewCache) read() (*persistedView, error) {
+	data, err := os.ReadFile(vc.path())
+	if err != nil {
+		return nil, err
+	}
+	pb := &stakingpb.ViewCache{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize staking view")
+	}
+	if len(pb.Root) != len(hash.ZeroHash256) || pb.TotalAmount == nil {
+		return nil, errors.Wrap(ErrMissingField, "failed to deserialize staking view")
+	}
+	pv := persistedView{
+		height: pb.Height,
+		root:   hash.BytesToHash256(pb.Root),
+		total:  &totalAmount{},
+	}
+	if err := pv.total.fromProto(pb.TotalAmount); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize staking view")
+	}
+	for _, c := range []struct {
+		list *CandidateList
+		pb   *stakingpb.Candidates
+	}{
+		{&pv.candidates, pb.Candidates},
+		{&pv.name, pb.NameCandidates},
+		{&pv.operator, pb.OperatorCandidates},
+		{&pv.owners, pb.OwnerCandidates},
+	} {
+		if c.pb == nil {
+			continue
+		}
+		if err := c.list.fromProto(c.pb); err != nil {
+			return nil, errors.Wrap(err, "failed to deserialize staking view")
+		}
+	}
+	return &pv, nil
+}
+
+// stateRoot returns the root of the states which the view is bound to
+func stateRoot(sr protocol.StateReader) (hash.Hash256, error) {
+	rr, ok := sr.(protocol.StateRootReader)
+	if !ok {
+		return hash.ZeroHash256, ErrNoStateRoot
+	}
+	return rr.StateRoot()
+}
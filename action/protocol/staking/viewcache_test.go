@@ -0,0 +1,70 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewCache(t *testing.T) {
+	require := require.New(t)
+
+	var all CandidateList
+	for _, v := range testCandidates {
+		all = append(all, v.d.Clone())
+	}
+	center, err := NewCandidateCenter(all)
+	require.NoError(err)
+	view := &ViewData{
+		candCenter: center,
+		bucketPool: &BucketPool{
+			enableSMStorage: true,
+			total: &totalAmount{
+				amount: big.NewInt(1000),
+				count:  7,
+			},
+		},
+	}
+
+	vc := NewViewCache(t.TempDir())
+	root := hash.Hash256b([]byte("root"))
+	// Case I: no cache
+	_, err = vc.Load(10, root, false)
+	require.ErrorIs(err, os.ErrNotExist)
+
+	// Case II: mismatched height or state root
+	require.NoError(vc.Save(10, root, view))
+	_, err = vc.Load(11, root, false)
+	require.ErrorIs(err, ErrViewCacheMismatch)
+	_, err = vc.Load(10, hash.ZeroHash256, false)
+	require.ErrorIs(err, ErrViewCacheMismatch)
+
+	// Case III: normal, the cache is kept to be loaded by every protocol starting from it
+	for i := 0; i < 2; i++ {
+		loaded, err := vc.Load(10, root, true)
+		require.NoError(err)
+		require.True(testEqual(loaded.candCenter, all))
+		require.Equal(view.bucketPool.Total(), loaded.bucketPool.Total())
+		require.Equal(view.bucketPool.Count(), loaded.bucketPool.Count())
+	}
+
+	// Case IV: corrupted cache
+	require.NoError(os.WriteFile(vc.path(), []byte{1, 2, 3}, 0644))
+	_, err = vc.Load(10, root, false)
+	require.ErrorContains(err, "failed to deserialize staking view")
+	require.NoError(os.WriteFile(vc.path(), nil, 0644))
+	_, err = vc.Load(10, root, false)
+	require.ErrorIs(err, ErrMissingField)
+
+	// Case V: the state reader without state root
+	_, err = stateRoot(nil)
+	require.ErrorIs(err, ErrNoStateRoot)
+}
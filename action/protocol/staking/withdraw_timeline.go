@@ -0,0 +1,134 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// WithdrawTimelineMethod is the ReadState method to read the withdraw timeline of a bucket, the argument is the
+// bucket index in decimal, and the response is the json encoded WithdrawTimeline
+const WithdrawTimelineMethod = "WithdrawTimeline"
+
+// blockers which prevent a bucket from being unstaked
+const (
+	BlockerAutoStake   = "autoStake"
+	BlockerEndorsed    = "endorsed"
+	BlockerUnendorsing = "unendorsing"
+)
+
+// WithdrawTimeline is the timeline for a bucket to be unstaked and withdrawn under the current config
+type WithdrawTimeline struct {
+	BucketIndex uint64 `json:"bucketIndex"`
+	Unstaked    bool   `json:"unstaked"`
+	// UnstakeTime is the time the bucket was unstaked, set if the bucket is unstaked
+	UnstakeTime *time.Time `json:"unstakeTime,omitempty"`
+	// EarliestUnstakeTime is the time the staked duration matures, not set if auto-stake is on or already unstaked
+	EarliestUnstakeTime *time.Time `json:"earliestUnstakeTime,omitempty"`
+	// EarliestWithdrawTime is the earliest time to withdraw the bucket, assuming it is unstaked at the earliest
+	// unstake time, not set if auto-stake is on
+	EarliestWithdrawTime *time.Time `json:"earliestWithdrawTime,omitempty"`
+	// WithdrawWaitingSeconds is the waiting period between unstake and withdraw
+	WithdrawWaitingSeconds uint64 `json:"withdrawWaitingSeconds"`
+	// EndorsementExpireHeight is the height when the endorsement of the bucket expires, set if the bucket is endorsed
+	EndorsementExpireHeight uint64 `json:"endorsementExpireHeight,omitempty"`
+	// Blockers are the conditions which must be cleared before the bucket can be unstaked
+	Blockers []string `json:"blockers"`
+}
+
+func (p *Protocol) readWithdrawTimeline(ctx context.Context, sr protocol.StateReader, args ...[]byte) ([]byte, uint64, error) {
+	if len(args) != 1 {
+		return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	index, err := strconv.ParseUint(string(args[0]), 10, 64)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "invalid bucket index %s", args[0])
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, 0, err
+	}
+	// the bucket can be unstaked or withdrawn from the next block on
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height + 1}))
+	timeline, err := p.withdrawTimeline(protocol.MustGetFeatureCtx(ctx), sr, index, height+1)
+	if err != nil {
+		return nil, height, err
+	}
+	data, err := json.Marshal(timeline)
+	if err != nil {
+		return nil, height, err
+	}
+	return data, height, nil
+}
+
+func (p *Protocol) withdrawTimeline(featureCtx protocol.FeatureCtx, sr protocol.StateReader, index, height uint64) (*WithdrawTimeline, error) {
+	bucket, err := newCandidateStateReader(sr).getBucket(index)
+	if err != nil {
+		return nil, err
+	}
+	withdrawWaitTime := p.config.WithdrawWaitingPeriod
+	if !featureCtx.NewStakingReceiptFormat {
+		withdrawWaitTime = _withdrawWaitingTime
+	}
+	timeline := &WithdrawTimeline{
+		BucketIndex:            index,
+		WithdrawWaitingSeconds: uint64(withdrawWaitTime / time.Second),
+		Blockers:               []string{},
+	}
+	timeline.Unstaked = bucket.UnstakeStartTime.Unix() != 0
+	if featureCtx.CannotUnstakeAgain {
+		timeline.Unstaked = bucket.isUnstaked()
+	}
+	if timeline.Unstaked {
+		unstakeTime := bucket.UnstakeStartTime.UTC()
+		withdrawTime := unstakeTime.Add(withdrawWaitTime)
+		timeline.UnstakeTime = &unstakeTime
+		timeline.EarliestWithdrawTime = &withdrawTime
+		return timeline, nil
+	}
+	if bucket.AutoStake {
+		timeline.Blockers = append(timeline.Blockers, BlockerAutoStake)
+	} else {
+		unstakeTime := bucket.StakeStartTime.Add(bucket.StakedDuration).UTC()
+		withdrawTime := unstakeTime.Add(withdrawWaitTime)
+		timeline.EarliestUnstakeTime = &unstakeTime
+		timeline.EarliestWithdrawTime = &withdrawTime
+	}
+	if featureCtx.DisableDelegateEndorsement {
+		return timeline, nil
+	}
+	esr := NewEndorsementStateReader(sr)
+	endorse, err := esr.Get(index)
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		return timeline, nil
+	default:
+		return nil, err
+	}
+	status, err := esr.Status(featureCtx, index, height)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case Endorsed:
+		timeline.Blockers = append(timeline.Blockers, BlockerEndorsed)
+	case UnEndorsing:
+		timeline.Blockers = append(timeline.Blockers, BlockerUnendorsing)
+	}
+	if status != EndorseExpired && endorse.ExpireHeight != endorsementNotExpireHeight {
+		timeline.EndorsementExpireHeight = endorse.ExpireHeight
+	}
+	return timeline, nil
+}
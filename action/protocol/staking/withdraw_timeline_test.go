@@ -0,0 +1,99 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestWithdrawTimeline(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	v, _, err := CreateBaseView(sm, false)
+	require.NoError(err)
+	require.NoError(sm.WriteView(_protocolID, v))
+	csm, err := NewCandidateStateManager(sm, false)
+	require.NoError(err)
+	esm := NewEndorsementStateManager(sm)
+
+	p := &Protocol{config: Configuration{WithdrawWaitingPeriod: 3 * 24 * time.Hour}}
+	fCtx := protocol.FeatureCtx{
+		NewStakingReceiptFormat: true,
+		CannotUnstakeAgain:      true,
+	}
+	owner := identityset.Address(1)
+	start := time.Unix(1700000000, 0).UTC()
+
+	// not existing bucket
+	_, err = p.withdrawTimeline(fCtx, sm, 0, 10)
+	require.Error(err)
+
+	// staked bucket
+	bkt := NewVoteBucket(owner, owner, big.NewInt(100), 7, start, false)
+	idx, err := csm.putBucketAndIndex(bkt)
+	require.NoError(err)
+	timeline, err := p.withdrawTimeline(fCtx, sm, idx, 10)
+	require.NoError(err)
+	require.False(timeline.Unstaked)
+	require.Equal(start.Add(7*24*time.Hour), *timeline.EarliestUnstakeTime)
+	require.Equal(start.Add(10*24*time.Hour), *timeline.EarliestWithdrawTime)
+	require.EqualValues(3*24*3600, timeline.WithdrawWaitingSeconds)
+	require.Empty(timeline.Blockers)
+
+	// withdraw waiting period is 14 days before the new staking receipt format
+	legacy := fCtx
+	legacy.NewStakingReceiptFormat = false
+	timeline, err = p.withdrawTimeline(legacy, sm, idx, 10)
+	require.NoError(err)
+	require.Equal(start.Add(21*24*time.Hour), *timeline.EarliestWithdrawTime)
+
+	// endorsed bucket
+	require.NoError(esm.Put(idx, &Endorsement{ExpireHeight: endorsementNotExpireHeight}))
+	timeline, err = p.withdrawTimeline(fCtx, sm, idx, 10)
+	require.NoError(err)
+	require.Equal([]string{BlockerEndorsed}, timeline.Blockers)
+	require.Zero(timeline.EndorsementExpireHeight)
+	require.NoError(esm.Put(idx, &Endorsement{ExpireHeight: 20}))
+	timeline, err = p.withdrawTimeline(fCtx, sm, idx, 10)
+	require.NoError(err)
+	require.Equal([]string{BlockerEndorsed}, timeline.Blockers)
+	require.EqualValues(20, timeline.EndorsementExpireHeight)
+	timeline, err = p.withdrawTimeline(fCtx, sm, idx, 20)
+	require.NoError(err)
+	require.Equal([]string{BlockerUnendorsing}, timeline.Blockers)
+
+	// auto-stake bucket
+	bkt = NewVoteBucket(owner, owner, big.NewInt(100), 7, start, true)
+	idx, err = csm.putBucketAndIndex(bkt)
+	require.NoError(err)
+	timeline, err = p.withdrawTimeline(fCtx, sm, idx, 10)
+	require.NoError(err)
+	require.Nil(timeline.EarliestUnstakeTime)
+	require.Nil(timeline.EarliestWithdrawTime)
+	require.Equal([]string{BlockerAutoStake}, timeline.Blockers)
+
+	// unstaked bucket
+	bkt = NewVoteBucket(owner, owner, big.NewInt(100), 7, start, false)
+	bkt.UnstakeStartTime = start.Add(8 * 24 * time.Hour)
+	idx, err = csm.putBucketAndIndex(bkt)
+	require.NoError(err)
+	timeline, err = p.withdrawTimeline(fCtx, sm, idx, 10)
+	require.NoError(err)
+	require.True(timeline.Unstaked)
+	require.Equal(bkt.UnstakeStartTime, *timeline.UnstakeTime)
+	require.Nil(timeline.EarliestUnstakeTime)
+	require.Equal(start.Add(11*24*time.Hour), *timeline.EarliestWithdrawTime)
+}
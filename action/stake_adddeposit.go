@@ -150,8 +150,7 @@ func (ds *DepositToStake) LoadProto(pbAct *iotextypes.StakeAddDeposit) error {
 
 // IntrinsicGas returns the intrinsic gas of a DepositToStake
 func (ds *DepositToStake) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(ds.Payload()))
-	return CalculateIntrinsicGas(DepositToStakeBaseIntrinsicGas, DepositToStakePayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(ds)
 }
 
 // SanityCheck validates the variables in the action
@@ -133,8 +133,7 @@ func (cc *ChangeCandidate) LoadProto(pbAct *iotextypes.StakeChangeCandidate) err
 
 // IntrinsicGas returns the intrinsic gas of a ChangeCandidate
 func (cc *ChangeCandidate) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(cc.Payload()))
-	return CalculateIntrinsicGas(MoveStakeBaseIntrinsicGas, MoveStakePayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(cc)
 }
 
 // SanityCheck validates the variables in the action
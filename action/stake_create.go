@@ -182,8 +182,7 @@ func (cs *CreateStake) LoadProto(pbAct *iotextypes.StakeCreate) error {
 
 // IntrinsicGas returns the intrinsic gas of a CreateStake
 func (cs *CreateStake) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(cs.Payload()))
-	return CalculateIntrinsicGas(CreateStakeBaseIntrinsicGas, CreateStakePayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(cs)
 }
 
 // SanityCheck validates the variables in the action
@@ -71,7 +71,7 @@ func (ms *MigrateStake) BucketIndex() uint64 { return ms.bucketIndex }
 
 // IntrinsicGas returns the intrinsic gas of a Restake
 func (ms *MigrateStake) IntrinsicGas() (uint64, error) {
-	return CalculateIntrinsicGas(MigrateStakeBaseIntrinsicGas, MigrateStakePayloadGas, 0)
+	return GasTableV1.payloadIntrinsicGas(ms)
 }
 
 // GasLimitForCost is an empty func to indicate that gas limit should be used
@@ -148,8 +148,7 @@ func NewUnstake(bucketIndex uint64, payload []byte) *Unstake {
 
 // IntrinsicGas returns the intrinsic gas of a Unstake
 func (su *Unstake) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(su.Payload()))
-	return CalculateIntrinsicGas(ReclaimStakeBaseIntrinsicGas, ReclaimStakePayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(su)
 }
 
 // EthData returns the ABI-encoded data for converting to eth tx
@@ -205,8 +204,7 @@ func NewWithdrawStake(bucketIndex uint64, payload []byte) *WithdrawStake {
 
 // IntrinsicGas returns the intrinsic gas of a WithdrawStake
 func (sw *WithdrawStake) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(sw.Payload()))
-	return CalculateIntrinsicGas(ReclaimStakeBaseIntrinsicGas, ReclaimStakePayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(sw)
 }
 
 // EthData returns the ABI-encoded data for converting to eth tx
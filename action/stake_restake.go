@@ -145,8 +145,7 @@ func (rs *Restake) LoadProto(pbAct *iotextypes.StakeRestake) error {
 
 // IntrinsicGas returns the intrinsic gas of a Restake
 func (rs *Restake) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(rs.Payload()))
-	return CalculateIntrinsicGas(RestakeBaseIntrinsicGas, RestakePayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(rs)
 }
 
 func (rs *Restake) SanityCheck() error {
@@ -136,8 +136,7 @@ func (ts *TransferStake) LoadProto(pbAct *iotextypes.StakeTransferOwnership) err
 
 // IntrinsicGas returns the intrinsic gas of a TransferStake
 func (ts *TransferStake) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(ts.Payload()))
-	return CalculateIntrinsicGas(MoveStakeBaseIntrinsicGas, MoveStakePayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(ts)
 }
 
 func (ts *TransferStake) SanityCheck() error {
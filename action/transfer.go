@@ -123,8 +123,7 @@ func (tsf *Transfer) LoadProto(pbAct *iotextypes.Transfer) error {
 
 // IntrinsicGas returns the intrinsic gas of a transfer
 func (tsf *Transfer) IntrinsicGas() (uint64, error) {
-	payloadSize := uint64(len(tsf.Payload()))
-	return CalculateIntrinsicGas(TransferBaseIntrinsicGas, TransferPayloadGas, payloadSize)
+	return GasTableV1.payloadIntrinsicGas(tsf)
 }
 
 // SanityCheck validates the variables in the action
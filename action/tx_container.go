@@ -233,15 +233,7 @@ func (etx *txContainer) Cost() (*big.Int, error) {
 }
 
 func (etx *txContainer) IntrinsicGas() (uint64, error) {
-	gas, err := CalculateIntrinsicGas(ExecutionBaseIntrinsicGas, ExecutionDataGas, uint64(len(etx.tx.Data())))
-	if err != nil {
-		return gas, err
-	}
-	if acl := etx.tx.AccessList(); len(acl) > 0 {
-		gas += uint64(len(acl)) * TxAccessListAddressGas
-		gas += uint64(acl.StorageKeys()) * TxAccessListStorageKeyGas
-	}
-	return gas, nil
+	return GasTableV1.IntrinsicGas(etx)
 }
 
 func (etx *txContainer) SetNonce(n uint64) {
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"sort"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/iotex-address/address"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+// _nativeStateKey is the shared key written by the native actions without a destination, e.g., staking and
+// rewarding actions, which all update the states of the protocols
+const _nativeStateKey = "native"
+
+type (
+	// AccessSet is the set of addresses an action may read and write. It is a hint computed before execution, which
+	// does not cover the addresses touched by the internal calls of a contract unless declared in the access list
+	AccessSet struct {
+		Reads  []string `json:"reads"`
+		Writes []string `json:"writes"`
+	}
+
+	// AccessSetReader returns the access set of the action in pool
+	AccessSetReader interface {
+		AccessSet(*action.SealedEnvelope) (*AccessSet, bool)
+	}
+
+	// accessSets stores the access sets of the actions in pool
+	accessSets struct {
+		sets cache.LRUCache
+	}
+)
+
+// NewAccessSet computes the access set of the action
+func NewAccessSet(selp *action.SealedEnvelope) *AccessSet {
+	var (
+		sender = selp.SenderAddress().String()
+		reads  = map[string]struct{}{sender: {}}
+		writes = map[string]struct{}{sender: {}}
+	)
+	switch selp.Action().(type) {
+	case *action.Transfer, *action.Execution:
+		// contract creation does not have a destination
+		if dst, ok := selp.Destination(); ok && dst != "" {
+			writes[dst] = struct{}{}
+		}
+	default:
+		writes[_nativeStateKey] = struct{}{}
+	}
+	// the addresses declared in access list could be written by the contract
+	for _, tuple := range selp.AccessList() {
+		addr, err := address.FromBytes(tuple.Address.Bytes())
+		if err != nil {
+			continue
+		}
+		writes[addr.String()] = struct{}{}
+	}
+	return &AccessSet{
+		Reads:  sortedKeys(reads),
+		Writes: sortedKeys(writes),
+	}
+}
+
+// Conflicts returns true if one action writes an address the other reads or writes
+func (s *AccessSet) Conflicts(o *AccessSet) bool {
+	return intersects(s.Writes, o.Writes) || intersects(s.Writes, o.Reads) || intersects(s.Reads, o.Writes)
+}
+
+func newAccessSets(size int) *accessSets {
+	return &accessSets{
+		sets: cache.NewThreadSafeLruCache(size),
+	}
+}
+
+func (as *accessSets) get(selp *action.SealedEnvelope) (*AccessSet, bool) {
+	h, err := selp.Hash()
+	if err != nil {
+		return nil, false
+	}
+	if set, ok := as.sets.Get(h); ok {
+		return set.(*AccessSet), true
+	}
+	set := NewAccessSet(selp)
+	as.sets.Add(h, set)
+	return set, true
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// intersects returns true if the two sorted slices have a common element
+func intersects(a, b []string) bool {
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			return true
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestAccessSet(t *testing.T) {
+	require := require.New(t)
+	sign := func(bd *action.EnvelopeBuilder, i int) *action.SealedEnvelope {
+		selp, err := action.Sign(bd.SetNonce(1).SetGasLimit(100000).SetGasPrice(big.NewInt(1)).Build(), identityset.PrivateKey(i))
+		require.NoError(err)
+		return selp
+	}
+	sorted := func(addrs ...string) []string {
+		sort.Strings(addrs)
+		return addrs
+	}
+	a, b, c := identityset.Address(1).String(), identityset.Address(2).String(), identityset.Address(3).String()
+
+	// transfer writes the recipient
+	tsf := NewAccessSet(sign((&action.EnvelopeBuilder{}).SetAction(action.NewTransfer(big.NewInt(1), b, nil)), 1))
+	require.Equal([]string{a}, tsf.Reads)
+	require.Equal(sorted(a, b), tsf.Writes)
+
+	// contract creation only touches the sender
+	deploy := NewAccessSet(sign((&action.EnvelopeBuilder{}).SetAction(action.NewExecution("", big.NewInt(0), []byte{1})), 3))
+	require.Equal([]string{c}, deploy.Writes)
+
+	// addresses in access list are written
+	acl := types.AccessList{{Address: common.BytesToAddress(identityset.Address(4).Bytes())}}
+	exec := NewAccessSet(sign((&action.EnvelopeBuilder{}).SetTxType(action.AccessListTxType).SetAccessList(acl).
+		SetAction(action.NewExecution(c, big.NewInt(0), []byte{1})), 2))
+	require.Equal([]string{b}, exec.Reads)
+	require.Equal(sorted(b, c, identityset.Address(4).String()), exec.Writes)
+
+	// native actions share the same state
+	ds, err := action.NewDepositToStake(1, "1", nil)
+	require.NoError(err)
+	stake := NewAccessSet(sign((&action.EnvelopeBuilder{}).SetAction(ds), 5))
+	claim := NewAccessSet(sign((&action.EnvelopeBuilder{}).SetAction(action.NewClaimFromRewardingFund(big.NewInt(1), nil, nil)), 6))
+	require.Contains(stake.Writes, _nativeStateKey)
+
+	require.True(tsf.Conflicts(exec))
+	require.True(exec.Conflicts(deploy))
+	require.False(tsf.Conflicts(deploy))
+	require.False(tsf.Conflicts(stake))
+	require.True(stake.Conflicts(claim))
+	require.False((&AccessSet{Reads: []string{a}}).Conflicts(&AccessSet{Reads: []string{a}}))
+
+	// access sets are stored when enabled
+	sets := newAccessSets(2)
+	selp := sign((&action.EnvelopeBuilder{}).SetAction(action.NewTransfer(big.NewInt(1), b, nil)), 1)
+	set, ok := sets.get(selp)
+	require.True(ok)
+	require.Equal(tsf, set)
+	set2, ok := sets.get(selp)
+	require.True(ok)
+	require.True(set == set2)
+}
@@ -8,6 +8,7 @@ package actpool
 import (
 	"container/heap"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/iotexproject/iotex-core/v2/action"
@@ -17,6 +18,10 @@ type (
 	accountItem struct {
 		index    int
 		actQueue ActQueue
+		// local is true if the account is a local address, whose actions are never evicted
+		local bool
+		// numFuture is the number of future actions in the queue as of the last update
+		numFuture int64
 	}
 
 	accountPriorityQueue []*accountItem
@@ -24,6 +29,8 @@ type (
 	accountPool struct {
 		accounts      map[string]*accountItem
 		priorityQueue accountPriorityQueue
+		// numFuture is the number of future actions of all the accounts
+		numFuture int64
 	}
 )
 
@@ -48,6 +55,7 @@ func (ap *accountPool) PopAccount(addr string) ActQueue {
 	if account, ok := ap.accounts[addr]; ok {
 		heap.Remove(&ap.priorityQueue, account.index)
 		delete(ap.accounts, addr)
+		atomic.AddInt64(&ap.numFuture, -atomic.SwapInt64(&account.numFuture, 0))
 		return account.actQueue
 	}
 
@@ -77,32 +85,74 @@ func (ap *accountPool) PutAction(
 		ap.accounts[addr] = &accountItem{
 			index:    len(ap.accounts),
 			actQueue: queue,
+			local:    actpool != nil && actpool.isLocal(addr),
 		}
 		heap.Push(&ap.priorityQueue, ap.accounts[addr])
+		ap.updateFuture(ap.accounts[addr])
 		return nil
 	}
 
 	if err := account.actQueue.Put(act); err != nil {
 		return err
 	}
+	ap.updateFuture(account)
 	heap.Fix(&ap.priorityQueue, account.index)
 
 	return nil
 }
 
+// PopPeek pops the action with the largest nonce of the account with the lowest gas price, the actions of the local
+// accounts are never popped
 func (ap *accountPool) PopPeek() *action.SealedEnvelope {
-	if len(ap.accounts) == 0 {
+	if len(ap.accounts) == 0 || ap.priorityQueue[0].local {
 		return nil
 	}
-	act := ap.priorityQueue[0].actQueue.PopActionWithLargestNonce()
+	account := ap.priorityQueue[0]
+	act := account.actQueue.PopActionWithLargestNonce()
+	ap.updateFuture(account)
 	heap.Fix(&ap.priorityQueue, 0)
 
 	return act
 }
 
+// PopOldestFuture pops the oldest future action of the non-local accounts
+func (ap *accountPool) PopOldestFuture() *action.SealedEnvelope {
+	var (
+		oldest   *accountItem
+		deadline time.Time
+	)
+	for _, account := range ap.accounts {
+		if account.local {
+			continue
+		}
+		if d, ok := account.actQueue.OldestFutureDeadline(); ok && (oldest == nil || d.Before(deadline)) {
+			oldest, deadline = account, d
+		}
+	}
+	if oldest == nil {
+		return nil
+	}
+	act := oldest.actQueue.PopOldestFutureAction()
+	ap.updateFuture(oldest)
+	heap.Fix(&ap.priorityQueue, oldest.index)
+
+	return act
+}
+
+// FutureLen returns the number of future actions of all the accounts
+func (ap *accountPool) FutureLen() uint64 {
+	return uint64(max(atomic.LoadInt64(&ap.numFuture), 0))
+}
+
+func (ap *accountPool) updateFuture(account *accountItem) {
+	n := int64(account.actQueue.FutureLen())
+	atomic.AddInt64(&ap.numFuture, n-atomic.SwapInt64(&account.numFuture, n))
+}
+
 func (ap *accountPool) Range(callback func(addr string, acct ActQueue)) {
 	for addr, account := range ap.accounts {
 		callback(addr, account.actQueue)
+		ap.updateFuture(account)
 	}
 	heap.Init(&ap.priorityQueue)
 }
@@ -115,11 +165,15 @@ func (ap *accountPool) DeleteIfEmpty(addr string) {
 	if account.actQueue.Empty() {
 		heap.Remove(&ap.priorityQueue, account.index)
 		delete(ap.accounts, addr)
+		atomic.AddInt64(&ap.numFuture, -atomic.SwapInt64(&account.numFuture, 0))
 	}
 }
 
 func (aq accountPriorityQueue) Len() int { return len(aq) }
 func (aq accountPriorityQueue) Less(i, j int) bool {
+	if aq[i].local != aq[j].local {
+		return aq[j].local
+	}
 	is, igp := aq[i].actQueue.NextAction()
 	js, jgp := aq[j].actQueue.NextAction()
 	if jgp == nil {
@@ -42,6 +42,20 @@ func TestAccountPool_PopPeek(t *testing.T) {
 		r.Nil(ap.PopPeek())
 		r.Equal(0, ap.Account(_addr1).Len())
 	})
+	t.Run("local account is never popped", func(t *testing.T) {
+		ap := newAccountPool()
+		pool := &actPool{locals: map[string]struct{}{_addr1: {}}}
+		tsf1, err := action.SignedTransfer(_addr1, _priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+		r.NoError(err)
+		tsf2, err := action.SignedTransfer(_addr2, _priKey2, 1, big.NewInt(100), nil, uint64(0), big.NewInt(2))
+		r.NoError(err)
+		r.NoError(ap.PutAction(_addr1, pool, 1, _balance, _expireTime, tsf1))
+		r.NoError(ap.PutAction(_addr2, pool, 1, _balance, _expireTime, tsf2))
+		// the remote account is popped even if with higher price
+		r.Equal(tsf2, ap.PopPeek())
+		r.Nil(ap.PopPeek())
+		r.Equal(1, ap.Account(_addr1).Len())
+	})
 	t.Run("peek with pending nonce", func(t *testing.T) {
 		ap := newAccountPool()
 		tsf1, err := action.SignedTransfer(_addr1, _priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(1))
@@ -192,3 +206,28 @@ func TestAccountPool_DeleteIfEmpty(t *testing.T) {
 	ap.DeleteIfEmpty(_addr1)
 	r.Nil(ap.Account(_addr1))
 }
+
+func TestAccountPool_PopOldestFuture(t *testing.T) {
+	r := require.New(t)
+	ap := newAccountPool()
+	r.Nil(ap.PopOldestFuture())
+	tsf1, err := action.SignedTransfer(_addr2, _priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+	r.NoError(err)
+	tsf3, err := action.SignedTransfer(_addr2, _priKey1, 3, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+	r.NoError(err)
+	tsf5, err := action.SignedTransfer(_addr1, _priKey2, 5, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+	r.NoError(err)
+	r.NoError(ap.PutAction(_addr1, nil, 1, _balance, _expireTime, tsf1))
+	r.NoError(ap.PutAction(_addr1, nil, 1, _balance, _expireTime, tsf3))
+	r.NoError(ap.PutAction(_addr2, nil, 1, _balance, _expireTime, tsf5))
+	r.Equal(uint64(2), ap.FutureLen())
+	popped := []*action.SealedEnvelope{ap.PopOldestFuture()}
+	r.Equal(uint64(1), ap.FutureLen())
+	popped = append(popped, ap.PopOldestFuture())
+	r.ElementsMatch([]*action.SealedEnvelope{tsf3, tsf5}, popped)
+	r.Zero(ap.FutureLen())
+	r.Nil(ap.PopOldestFuture())
+	r.Equal(1, ap.Account(_addr1).Len())
+	ap.DeleteIfEmpty(_addr2)
+	r.Nil(ap.Account(_addr2))
+}
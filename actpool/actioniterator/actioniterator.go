@@ -8,45 +8,68 @@ package actioniterator
 import (
 	"bytes"
 	"container/heap"
+	"math/big"
 
 	"github.com/iotexproject/iotex-core/v2/action"
 )
 
 // ActionByPrice implements both the sort and the heap interface, making it useful
 // for all at once sorting as well as individually adding and removing elements.
-// It's essentially a big root heap of actions
-type actionByPrice []*action.SealedEnvelope
+// It's essentially a big root heap of actions, ordered by the gas price, or by the
+// effective priority fee if the base fee is set
+type actionByPrice struct {
+	acts     []*action.SealedEnvelope
+	baseFee  *big.Int
+	priority func(*action.SealedEnvelope) bool
+}
 
-func (s actionByPrice) Len() int { return len(s) }
-func (s actionByPrice) Less(i, j int) bool {
-	switch s[i].GasPrice().Cmp(s[j].GasPrice()) {
+func (s *actionByPrice) Len() int { return len(s.acts) }
+func (s *actionByPrice) Less(i, j int) bool {
+	if s.priority != nil {
+		if pi, pj := s.priority(s.acts[i]), s.priority(s.acts[j]); pi != pj {
+			return pi
+		}
+	}
+	switch s.price(s.acts[i]).Cmp(s.price(s.acts[j])) {
 	case 1:
 		return true
 	case 0:
-		hi, _ := s[i].Hash()
-		hj, _ := s[j].Hash()
+		hi, _ := s.acts[i].Hash()
+		hj, _ := s.acts[j].Hash()
 		return bytes.Compare(hi[:], hj[:]) > 0
 	default:
 		return false
 	}
 }
 
-func (s actionByPrice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s *actionByPrice) Swap(i, j int) { s.acts[i], s.acts[j] = s.acts[j], s.acts[i] }
 
 // Push define the push function of heap
 func (s *actionByPrice) Push(x interface{}) {
-	*s = append(*s, x.(*action.SealedEnvelope))
+	s.acts = append(s.acts, x.(*action.SealedEnvelope))
 }
 
 // Pop define the pop function of heap
 func (s *actionByPrice) Pop() interface{} {
-	old := *s
+	old := s.acts
 	n := len(old)
 	x := old[n-1]
-	*s = old[0 : n-1]
+	s.acts = old[0 : n-1]
 	return x
 }
 
+// price returns the effective priority fee (EIP-1559 tip) of the action if the base fee is set, the tip of an action
+// whose fee cap is below the base fee is negative so that it is picked last
+func (s *actionByPrice) price(selp *action.SealedEnvelope) *big.Int {
+	if s.baseFee == nil {
+		return selp.GasPrice()
+	}
+	tip, _ := action.EffectiveGasTip(selp, s.baseFee)
+	return tip
+}
+
+func (s *actionByPrice) head() *action.SealedEnvelope { return s.acts[0] }
+
 // ActionIterator define the interface of action iterator
 type ActionIterator interface {
 	Next() (*action.SealedEnvelope, bool)
@@ -58,15 +81,39 @@ type actionIterator struct {
 	heads       actionByPrice
 }
 
+// Option is the option of the action iterator
+type Option func(*actionByPrice)
+
+// WithBaseFee orders the actions by the effective priority fee at the base fee, rather than by the gas price
+func WithBaseFee(baseFee *big.Int) Option {
+	return func(s *actionByPrice) {
+		if baseFee != nil {
+			s.baseFee = new(big.Int).Set(baseFee)
+		}
+	}
+}
+
+// WithPriority orders the actions in the priority lane before the others, regardless of the price
+func WithPriority(priority func(*action.SealedEnvelope) bool) Option {
+	return func(s *actionByPrice) {
+		s.priority = priority
+	}
+}
+
 // NewActionIterator return a new action iterator
-func NewActionIterator(accountActs map[string][]*action.SealedEnvelope) ActionIterator {
-	heads := make(actionByPrice, 0, len(accountActs))
+func NewActionIterator(accountActs map[string][]*action.SealedEnvelope, opts ...Option) ActionIterator {
+	heads := actionByPrice{
+		acts: make([]*action.SealedEnvelope, 0, len(accountActs)),
+	}
+	for _, opt := range opts {
+		opt(&heads)
+	}
 	for sender, accActs := range accountActs {
 		if len(accActs) == 0 {
 			continue
 		}
 
-		heads = append(heads, accActs[0])
+		heads.acts = append(heads.acts, accActs[0])
 		if len(accActs) > 1 {
 			accountActs[sender] = accActs[1:]
 		} else {
@@ -82,9 +129,9 @@ func NewActionIterator(accountActs map[string][]*action.SealedEnvelope) ActionIt
 
 // loadNextActionForTopAccount load next action of account of top action
 func (ai *actionIterator) loadNextActionForTopAccount() {
-	callerAddrStr := ai.heads[0].SenderAddress().String()
+	callerAddrStr := ai.heads.head().SenderAddress().String()
 	if actions, ok := ai.accountActs[callerAddrStr]; ok && len(actions) > 0 {
-		ai.heads[0], ai.accountActs[callerAddrStr] = actions[0], actions[1:]
+		ai.heads.acts[0], ai.accountActs[callerAddrStr] = actions[0], actions[1:]
 		heap.Fix(&ai.heads, 0)
 	} else {
 		heap.Pop(&ai.heads)
@@ -93,18 +140,93 @@ func (ai *actionIterator) loadNextActionForTopAccount() {
 
 // Next load next action of account of top action
 func (ai *actionIterator) Next() (*action.SealedEnvelope, bool) {
-	if len(ai.heads) == 0 {
+	if ai.heads.Len() == 0 {
 		return nil, false
 	}
 
-	headAction := ai.heads[0]
+	headAction := ai.heads.head()
 	ai.loadNextActionForTopAccount()
 	return headAction, true
 }
 
 // PopAccount will remove all actions related to this account
 func (ai *actionIterator) PopAccount() {
-	if len(ai.heads) != 0 {
+	if ai.heads.Len() != 0 {
 		heap.Pop(&ai.heads)
 	}
 }
+
+// AccessSetFunc returns the addresses an action reads and writes, false if unknown
+type AccessSetFunc func(*action.SealedEnvelope) (reads []string, writes []string, ok bool)
+
+// conflictAwareIterator picks the actions which do not conflict with the picked ones first, an account is deferred
+// when its next action conflicts, and the deferred accounts are picked in the order of price at last
+type conflictAwareIterator struct {
+	*actionIterator
+	accessSet AccessSetFunc
+	reads     map[string]struct{}
+	writes    map[string]struct{}
+	deferred  []*action.SealedEnvelope
+}
+
+// NewConflictAwareActionIterator returns a new action iterator which packs non-conflicting actions first
+func NewConflictAwareActionIterator(accountActs map[string][]*action.SealedEnvelope, accessSet AccessSetFunc, opts ...Option) ActionIterator {
+	return &conflictAwareIterator{
+		actionIterator: NewActionIterator(accountActs, opts...).(*actionIterator),
+		accessSet:      accessSet,
+		reads:          make(map[string]struct{}),
+		writes:         make(map[string]struct{}),
+	}
+}
+
+// Next returns the action with the highest price among the ones not conflicting with the picked ones
+func (ci *conflictAwareIterator) Next() (*action.SealedEnvelope, bool) {
+	if ci.accessSet == nil {
+		return ci.actionIterator.Next()
+	}
+	for ci.heads.Len() > 0 {
+		head := ci.heads.head()
+		reads, writes, ok := ci.accessSet(head)
+		if !ok || !ci.conflicts(reads, writes) {
+			ci.loadNextActionForTopAccount()
+			ci.record(reads, writes)
+			return head, true
+		}
+		heap.Pop(&ci.heads)
+		ci.deferred = append(ci.deferred, head)
+	}
+	// the deferred actions all conflict with the picked ones, and so do the actions after them, hence no need to
+	// check conflicts any more
+	for _, act := range ci.deferred {
+		heap.Push(&ci.heads, act)
+	}
+	ci.deferred = nil
+	ci.accessSet = nil
+	return ci.actionIterator.Next()
+}
+
+func (ci *conflictAwareIterator) conflicts(reads, writes []string) bool {
+	for _, addr := range writes {
+		if _, ok := ci.writes[addr]; ok {
+			return true
+		}
+		if _, ok := ci.reads[addr]; ok {
+			return true
+		}
+	}
+	for _, addr := range reads {
+		if _, ok := ci.writes[addr]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (ci *conflictAwareIterator) record(reads, writes []string) {
+	for _, addr := range reads {
+		ci.reads[addr] = struct{}{}
+	}
+	for _, addr := range writes {
+		ci.writes[addr] = struct{}{}
+	}
+}
@@ -82,6 +82,85 @@ func TestActionIterator(t *testing.T) {
 	require.Equal(appliedActionList, []*action.SealedEnvelope{selp3, selp1, selp2, selp4, selp5, selp6})
 }
 
+func TestActionIteratorWithBaseFee(t *testing.T) {
+	require := require.New(t)
+	dynamicFeeTx := func(idx int, nonce uint64, feeCap, tipCap int64) *action.SealedEnvelope {
+		elp := (&action.EnvelopeBuilder{}).SetTxType(action.DynamicFeeTxType).SetNonce(nonce).
+			SetDynamicGas(big.NewInt(feeCap), big.NewInt(tipCap)).
+			SetAction(action.NewTransfer(big.NewInt(100), "1", nil)).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(idx))
+		require.NoError(err)
+		return selp
+	}
+	legacyTx := func(idx int, nonce uint64, price int64) *action.SealedEnvelope {
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(price)).
+			SetAction(action.NewTransfer(big.NewInt(100), "1", nil)).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(idx))
+		require.NoError(err)
+		return selp
+	}
+	// tips at base fee 100: a1 = 50, a2 = 1, b1 = 10, c1 = 30, d1 = -10
+	a1, a2 := dynamicFeeTx(28, 1, 200, 50), dynamicFeeTx(28, 2, 101, 80)
+	b1 := dynamicFeeTx(29, 1, 1000, 10)
+	c1 := legacyTx(30, 1, 130)
+	d1 := dynamicFeeTx(31, 1, 90, 90)
+	newAccMap := func() map[string][]*action.SealedEnvelope {
+		return map[string][]*action.SealedEnvelope{
+			identityset.Address(28).String(): {a1, a2},
+			identityset.Address(29).String(): {b1},
+			identityset.Address(30).String(): {c1},
+			identityset.Address(31).String(): {d1},
+		}
+	}
+	pickAll := func(ai ActionIterator) []*action.SealedEnvelope {
+		var picked []*action.SealedEnvelope
+		for {
+			act, ok := ai.Next()
+			if !ok {
+				return picked
+			}
+			picked = append(picked, act)
+		}
+	}
+	require.Equal([]*action.SealedEnvelope{a1, c1, b1, a2, d1}, pickAll(NewActionIterator(newAccMap(), WithBaseFee(big.NewInt(100)))))
+	require.Equal([]*action.SealedEnvelope{a1, c1, b1, a2, d1}, pickAll(NewConflictAwareActionIterator(newAccMap(), nil, WithBaseFee(big.NewInt(100)))))
+	// ordered by the gas price without the base fee
+	require.Equal([]*action.SealedEnvelope{b1, a1, c1, a2, d1}, pickAll(NewActionIterator(newAccMap())))
+}
+
+func TestActionIteratorWithPriority(t *testing.T) {
+	require := require.New(t)
+	newTx := func(idx int, nonce uint64, price int64) *action.SealedEnvelope {
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(price)).
+			SetAction(action.NewTransfer(big.NewInt(100), "1", nil)).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(idx))
+		require.NoError(err)
+		return selp
+	}
+	a1, a2 := newTx(28, 1, 10), newTx(28, 2, 30)
+	b1 := newTx(29, 1, 20)
+	c1 := newTx(30, 1, 1)
+	accMap := map[string][]*action.SealedEnvelope{
+		identityset.Address(28).String(): {a1, a2},
+		identityset.Address(29).String(): {b1},
+		identityset.Address(30).String(): {c1},
+	}
+	local := identityset.Address(30).String()
+	ai := NewActionIterator(accMap, WithPriority(func(selp *action.SealedEnvelope) bool {
+		return selp.SenderAddress().String() == local
+	}))
+	var picked []*action.SealedEnvelope
+	for {
+		act, ok := ai.Next()
+		if !ok {
+			break
+		}
+		picked = append(picked, act)
+	}
+	// the priority lane is picked first, regardless of the price
+	require.Equal([]*action.SealedEnvelope{c1, b1, a1, a2}, picked)
+}
+
 func TestActionByPrice(t *testing.T) {
 	require := require.New(t)
 
@@ -129,3 +208,43 @@ func BenchmarkLooping(b *testing.B) {
 	}
 	b.StopTimer()
 }
+
+func TestConflictAwareActionIterator(t *testing.T) {
+	require := require.New(t)
+	var (
+		accMap = make(map[string][]*action.SealedEnvelope)
+		writes = make(map[*action.SealedEnvelope][]string)
+	)
+	newAction := func(i int, nonce uint64, price int64, recipient string) *action.SealedEnvelope {
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(price)).
+			SetAction(action.NewTransfer(big.NewInt(100), recipient, nil)).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(i))
+		require.NoError(err)
+		sender := identityset.Address(i).String()
+		accMap[sender] = append(accMap[sender], selp)
+		writes[selp] = []string{sender, recipient}
+		return selp
+	}
+	a, b, c, d := identityset.Address(1).String(), identityset.Address(2).String(), identityset.Address(3).String(), identityset.Address(4).String()
+	// b -> c conflicts with a -> b, so c -> d is picked before it despite its lower price
+	selp1 := newAction(1, 1, 30, b)
+	selp2 := newAction(2, 1, 20, c)
+	selp3 := newAction(2, 2, 25, a)
+	selp4 := newAction(3, 1, 10, d)
+	selp5 := newAction(4, 1, 5, "io1")
+	ai := NewConflictAwareActionIterator(accMap, func(selp *action.SealedEnvelope) ([]string, []string, bool) {
+		if selp == selp5 {
+			return nil, nil, false
+		}
+		return nil, writes[selp], true
+	})
+	var picked []*action.SealedEnvelope
+	for {
+		selp, ok := ai.Next()
+		if !ok {
+			break
+		}
+		picked = append(picked, selp)
+	}
+	require.Equal([]*action.SealedEnvelope{selp1, selp4, selp5, selp2, selp3}, picked)
+}
@@ -8,10 +8,13 @@ package actpool
 import (
 	"context"
 	"encoding/hex"
+	"math/big"
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/facebookgo/clock"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -42,12 +45,19 @@ var (
 		Name: "iotex_actpool_rejection_metrics",
 		Help: "actpool metrics.",
 	}, []string{"type"})
+	_actpoolOccupancyMtc = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "iotex_actpool_occupancy",
+		Help: "Number of actions in actpool.",
+	}, []string{"type"})
 	// ErrGasTooHigh error when the intrinsic gas of an action is too high
 	ErrGasTooHigh = errors.New("action gas is too high")
+	// ErrQuarantined indicates the action or its sender is in quarantine
+	ErrQuarantined = errors.New("action is in quarantine")
 )
 
 func init() {
 	prometheus.MustRegister(_actpoolMtc)
+	prometheus.MustRegister(_actpoolOccupancyMtc)
 }
 
 // ActPool is the interface of actpool
@@ -107,6 +117,8 @@ type actPool struct {
 	accountDesActs *destinationMap
 	allActions     *ttl.Cache
 	gasInPool      uint64
+	// excessBlobGas is the excess blob gas of the next block, which decides the blob fee
+	excessBlobGas uint64
 	// actionEnvelopeValidators are the validators that are used in both actpool.Add and actpool.Validate
 	// TODO: can combine with privateValidators after NOT use actpool to call generic_validator in block validate
 	actionEnvelopeValidators []action.SealedEnvelopeValidator
@@ -115,10 +127,20 @@ type actPool struct {
 	privateValidators []action.SealedEnvelopeValidator
 	timerFactory      *prometheustimer.TimerFactory
 	senderBlackList   map[string]bool
+	locals            map[string]struct{}
+	blobs             *blobValidator
 	jobQueue          []chan workerJob
 	worker            []*queueWorker
 	subs              []Subscriber
 	store             *actionStore // store is the persistent cache for actpool
+	persistAll        bool         // persistAll persists all the actions in store, otherwise only the blob txs
+	quarantine        *quarantine
+	accessSets        *accessSets
+	// maxNumActs and maxGas are the caps of the pool, which are adjustable at runtime
+	maxNumActs atomic.Uint64
+	maxGas     atomic.Uint64
+	// gasSponsored tells whether the gas fee of the action is paid by a sponsor instead of the sender
+	gasSponsored func(*action.SealedEnvelope) bool
 }
 
 // NewActPool constructs a new actpool
@@ -132,26 +154,40 @@ func NewActPool(g genesis.Genesis, sf protocol.StateReader, cfg Config, opts ...
 		senderBlackList[bannedSender] = true
 	}
 
+	locals := make(map[string]struct{})
+	for _, local := range cfg.Locals {
+		locals[local] = struct{}{}
+	}
+
 	actsMap, _ := ttl.NewCache()
 	ap := &actPool{
 		cfg:             cfg,
 		g:               g,
 		sf:              sf,
 		senderBlackList: senderBlackList,
+		locals:          locals,
 		accountDesActs:  &destinationMap{acts: make(map[string]map[hash.Hash256]*action.SealedEnvelope)},
 		allActions:      actsMap,
 		jobQueue:        make([]chan workerJob, _numWorker),
 		worker:          make([]*queueWorker, _numWorker),
 	}
+	ap.maxNumActs.Store(cfg.MaxNumActsPerPool)
+	ap.maxGas.Store(cfg.MaxGasLimitPerPool)
+	if cfg.ExecutionBudget.Budget > 0 {
+		ap.quarantine = newQuarantine(cfg.ExecutionBudget, clock.New())
+	}
+	if cfg.EnableAccessSet {
+		ap.accessSets = newAccessSets(int(cfg.MaxNumActsPerPool))
+	}
 	for _, opt := range opts {
 		if err := opt(ap); err != nil {
 			return nil, err
 		}
 	}
 	// init validators
-	blobValidator := newBlobValidator(cfg.MaxNumBlobsPerAcct)
-	ap.privateValidators = append(ap.privateValidators, blobValidator)
-	ap.AddSubscriber(blobValidator)
+	ap.blobs = newBlobValidator(cfg.MaxNumBlobsPerAcct, cfg.MaxNumBlobsPerPool)
+	ap.privateValidators = append(ap.privateValidators, ap.blobs)
+	ap.AddSubscriber(ap.blobs)
 
 	timerFactory, err := prometheustimer.New(
 		"iotex_action_pool_perf",
@@ -179,24 +215,30 @@ func (ap *actPool) Start(ctx context.Context) error {
 		return nil
 	}
 	// open action store and load all actions
-	blobs := make(SortedActions, 0)
+	acts := make(SortedActions, 0)
 	err := ap.store.Open(func(selp *action.SealedEnvelope) error {
-		if len(selp.BlobHashes()) > 0 {
-			blobs = append(blobs, selp)
-			return nil
-		}
-		return ap.add(ctx, selp)
+		acts = append(acts, selp)
+		return nil
 	})
 	if err != nil {
 		return err
 	}
-	// add blob txs to actpool in nonce order
-	sort.Sort(blobs)
-	for _, selp := range blobs {
+	// add actions to actpool in nonce order, the ones failing to pass the validation against the latest state, e.g.,
+	// those have been included in blocks, are dropped from the store
+	sort.Stable(acts)
+	loaded := 0
+	for _, selp := range acts {
 		if err := ap.add(ctx, selp); err != nil {
-			log.L().Info("Failed to load action from store", zap.Error(err))
+			h, _ := selp.Hash()
+			log.L().Info("Failed to load action from store", zap.Error(err), log.Hex("hash", h[:]))
+			if err := ap.store.Delete(h); err != nil {
+				log.L().Warn("Failed to delete action from store", zap.Error(err), log.Hex("hash", h[:]))
+			}
+			continue
 		}
+		loaded++
 	}
+	log.L().Info("Loaded actions from store", zap.Int("loaded", loaded), zap.Int("dropped", len(acts)-loaded))
 	return nil
 }
 
@@ -241,9 +283,35 @@ func (ap *actPool) reset() {
 		}(ap.worker[i])
 	}
 	wg.Wait()
+	ap.updateOccupancy()
+}
+
+// futureLen returns the number of future actions in the pool
+func (ap *actPool) futureLen() uint64 {
+	var n uint64
+	for _, worker := range ap.worker {
+		n += worker.accountActs.FutureLen()
+	}
+	return n
 }
 
-func (ap *actPool) ReceiveBlock(*block.Block) error {
+func (ap *actPool) updateOccupancy() {
+	var (
+		total  = uint64(ap.allActions.Count())
+		future = min(ap.futureLen(), total)
+	)
+	_actpoolOccupancyMtc.WithLabelValues("executable").Set(float64(total - future))
+	_actpoolOccupancyMtc.WithLabelValues("future").Set(float64(future))
+	_actpoolOccupancyMtc.WithLabelValues("gas").Set(float64(atomic.LoadUint64(&ap.gasInPool)))
+	if ap.blobs != nil {
+		_actpoolOccupancyMtc.WithLabelValues("blob").Set(float64(ap.blobs.count()))
+	}
+}
+
+func (ap *actPool) ReceiveBlock(blk *block.Block) error {
+	if blk != nil {
+		atomic.StoreUint64(&ap.excessBlobGas, protocol.CalcExcessBlobGas(blk.ExcessBlobGas(), blk.BlobGasUsed()))
+	}
 	ap.reset()
 	return nil
 }
@@ -289,7 +357,7 @@ func (ap *actPool) add(ctx context.Context, act *action.SealedEnvelope) error {
 		return action.ErrInvalidAct
 	}
 
-	if err := checkSelpData(act); err != nil {
+	if err := checkSelpData(ctx, act); err != nil {
 		return err
 	}
 
@@ -297,25 +365,44 @@ func (ap *actPool) add(ctx context.Context, act *action.SealedEnvelope) error {
 		return err
 	}
 
-	intrinsicGas, err := act.IntrinsicGas()
+	intrinsicGas, err := protocol.IntrinsicGas(ctx, act.Envelope)
 	if err != nil {
 		return err
 	}
-	if intrinsicGas > ap.cfg.MaxGasLimitPerPool {
+	maxGas := ap.maxGas.Load()
+	if intrinsicGas > maxGas {
 		_actpoolMtc.WithLabelValues("overMaxGasLimitPerPool").Inc()
 		return ErrGasTooHigh
 	}
 
-	return ap.enqueue(
+	if err := ap.enqueue(
 		ctx,
 		act,
-		atomic.LoadUint64(&ap.gasInPool) > ap.cfg.MaxGasLimitPerPool-intrinsicGas ||
-			uint64(ap.allActions.Count()) >= ap.cfg.MaxNumActsPerPool,
-	)
+		atomic.LoadUint64(&ap.gasInPool) > maxGas-intrinsicGas ||
+			uint64(ap.allActions.Count()) >= ap.capacity(act),
+	); err != nil {
+		return err
+	}
+	ap.updateOccupancy()
+	if ap.accessSets != nil {
+		ap.accessSets.get(act)
+	}
+	return nil
+}
+
+// cost returns the cost of the action to the sender, which is the value only if the gas fee is paid by a sponsor
+func (ap *actPool) cost(act *action.SealedEnvelope) (*big.Int, error) {
+	if ap != nil && ap.gasSponsored != nil && ap.gasSponsored(act) {
+		if value := act.Value(); value != nil {
+			return new(big.Int).Set(value), nil
+		}
+		return big.NewInt(0), nil
+	}
+	return act.Cost()
 }
 
-func checkSelpData(act *action.SealedEnvelope) error {
-	_, err := act.IntrinsicGas()
+func checkSelpData(ctx context.Context, act *action.SealedEnvelope) error {
+	_, err := protocol.IntrinsicGas(ctx, act.Envelope)
 	if err != nil {
 		return err
 	}
@@ -359,6 +446,10 @@ func (ap *actPool) checkSelpWithoutState(ctx context.Context, selp *action.Seale
 		_actpoolMtc.WithLabelValues("blacklisted").Inc()
 		return errors.Wrap(action.ErrAddress, "action source address is blacklisted")
 	}
+	if ap.quarantine != nil && ap.quarantine.quarantined(selp.SenderAddress().String(), hash) {
+		_actpoolMtc.WithLabelValues("quarantined").Inc()
+		return ErrQuarantined
+	}
 	validators := append(ap.privateValidators, ap.actionEnvelopeValidators...)
 	for _, ev := range validators {
 		span.AddEvent("ev.Validate")
@@ -369,6 +460,30 @@ func (ap *actPool) checkSelpWithoutState(ctx context.Context, selp *action.Seale
 	return nil
 }
 
+// RecordExecutionTime records the execution time of an action during block proposal, the sender is put into
+// quarantine if its actions exceed the budget too many times
+func (ap *actPool) RecordExecutionTime(selp *action.SealedEnvelope, d time.Duration) {
+	if ap.quarantine == nil {
+		return
+	}
+	h, err := selp.Hash()
+	if err != nil {
+		return
+	}
+	sender := selp.SenderAddress()
+	if ap.quarantine.record(sender.String(), h, d) {
+		ap.DeleteAction(sender)
+	}
+}
+
+// AccessSet returns the access set of an action, false if access set is not enabled
+func (ap *actPool) AccessSet(selp *action.SealedEnvelope) (*AccessSet, bool) {
+	if ap.accessSets == nil {
+		return nil, false
+	}
+	return ap.accessSets.get(selp)
+}
+
 // GetPendingNonce returns pending nonce in pool or confirmed nonce given an account address
 func (ap *actPool) GetPendingNonce(addrStr string) (uint64, error) {
 	addr, err := address.FromString(addrStr)
@@ -430,7 +545,7 @@ func (ap *actPool) GetSize() uint64 {
 
 // GetCapacity returns the act pool capacity
 func (ap *actPool) GetCapacity() uint64 {
-	return ap.cfg.MaxNumActsPerPool
+	return ap.maxNumActs.Load()
 }
 
 // GetGasSize returns the act pool gas size
@@ -440,7 +555,18 @@ func (ap *actPool) GetGasSize() uint64 {
 
 // GetGasCapacity returns the act pool gas capacity
 func (ap *actPool) GetGasCapacity() uint64 {
-	return ap.cfg.MaxGasLimitPerPool
+	return ap.maxGas.Load()
+}
+
+// SetCapacity sets the max number of actions and the max gas the pool can hold, the actions already in the pool are
+// kept even if the pool exceeds the new caps
+func (ap *actPool) SetCapacity(maxNumActs, maxGas uint64) error {
+	if maxNumActs == 0 || maxGas == 0 {
+		return errors.New("capacity of actpool should be positive")
+	}
+	ap.maxNumActs.Store(maxNumActs)
+	ap.maxGas.Store(maxGas)
+	return nil
 }
 
 func (ap *actPool) Validate(ctx context.Context, selp *action.SealedEnvelope) error {
@@ -489,6 +615,7 @@ func (ap *actPool) validate(ctx context.Context, selp *action.SealedEnvelope) er
 }
 
 func (ap *actPool) removeInvalidActs(acts []*action.SealedEnvelope) {
+	ctx := ap.context(context.Background())
 	for _, act := range acts {
 		hash, err := act.Hash()
 		if err != nil {
@@ -497,8 +624,8 @@ func (ap *actPool) removeInvalidActs(acts []*action.SealedEnvelope) {
 		}
 		log.L().Debug("Removed invalidated action.", log.Hex("hash", hash[:]))
 		ap.allActions.Delete(hash)
-		intrinsicGas, _ := act.IntrinsicGas()
-		atomic.AddUint64(&ap.gasInPool, ^uint64(intrinsicGas-1))
+		intrinsicGas, _ := protocol.IntrinsicGas(ctx, act.Envelope)
+		ap.subGasInPool(intrinsicGas)
 		ap.accountDesActs.delete(act)
 		if ap.store != nil {
 			if err = ap.store.Delete(hash); err != nil {
@@ -509,11 +636,27 @@ func (ap *actPool) removeInvalidActs(acts []*action.SealedEnvelope) {
 	}
 }
 
+// subGasInPool subtracts the gas of the removed action from the gas in pool, which is floored at zero in case the gas
+// table is upgraded after the action is added
+func (ap *actPool) subGasInPool(gas uint64) {
+	for {
+		old := atomic.LoadUint64(&ap.gasInPool)
+		left := uint64(0)
+		if old > gas {
+			left = old - gas
+		}
+		if atomic.CompareAndSwapUint64(&ap.gasInPool, old, left) {
+			return
+		}
+	}
+}
+
 func (ap *actPool) context(ctx context.Context) context.Context {
 	height, _ := ap.sf.Height()
 	return protocol.WithFeatureCtx(protocol.WithBlockCtx(
 		genesis.WithGenesisContext(ctx, ap.g), protocol.BlockCtx{
-			BlockHeight: height + 1,
+			BlockHeight:   height + 1,
+			ExcessBlobGas: atomic.LoadUint64(&ap.excessBlobGas),
 		}))
 }
 
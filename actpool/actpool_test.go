@@ -12,6 +12,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -20,11 +21,13 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/golang/mock/gomock"
 	"github.com/holiman/uint256"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/mohae/deepcopy"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
@@ -348,6 +351,18 @@ func TestActPool_AddActs(t *testing.T) {
 		require.NoError(ap.Add(ctx, testBlobTxWithNonce(2, 10, 2000000000000, 10)))
 		require.NoError(ap.Add(ctx, testBlobTxWithNonce(3, 10, 2000000000000, 10)))
 		require.ErrorIs(ap.Add(ctx, testBlobTxWithNonce(4, 10, 2000000000000, 10)), action.ErrNonceTooHigh)
+		// blob fee cap must cover the blob fee of the next block
+		atomic.StoreUint64(&ap.(*actPool).excessBlobGas, 20000000)
+		require.ErrorIs(ap.Add(ctx, testBlobTxWithNonce(4, 10, 2000000000000, 10)), action.ErrUnderpriced)
+		// max blob tx per pool
+		cfg := apConfig
+		cfg.MaxNumBlobsPerPool = 1
+		ap2, err := NewActPool(g, sf, cfg)
+		require.NoError(err)
+		require.NoError(ap2.Start(ctx))
+		defer ap2.Stop(ctx)
+		require.NoError(ap2.Add(ctx, testBlobTxWithNonce(1, 10, 2000000000000, 10)))
+		require.ErrorIs(ap2.Add(ctx, testBlobTxWithNonce(2, 10, 2000000000000, 10)), action.ErrTxPoolOverflow)
 	})
 }
 
@@ -932,6 +947,58 @@ func TestActPool_GetPendingNonce(t *testing.T) {
 	require.Equal(uint64(5), nonce)
 }
 
+func TestActPool_NonceGaps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	apConfig.PriceBump = 10
+	Ap, err := NewActPool(genesis.TestDefault(), sf, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+
+	tsf2, err := action.SignedTransfer(_addr1, _priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf5, err := action.SignedTransfer(_addr1, _priKey1, uint64(5), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(100))
+	require.NoError(err)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+	// no action in pool
+	gaps, err := ap.NonceGaps(identityset.Address(28))
+	require.NoError(err)
+	require.Equal(uint64(1), gaps.ConfirmedNonce)
+	require.Equal(uint64(1), gaps.PendingNonce)
+	require.Empty(gaps.Actions)
+	require.Empty(gaps.Gaps)
+
+	require.NoError(ap.Add(ctx, tsf2))
+	require.NoError(ap.Add(ctx, tsf5))
+	gaps, err = ap.NonceGaps(identityset.Address(28))
+	require.NoError(err)
+	require.Equal(uint64(1), gaps.ConfirmedNonce)
+	require.Equal(uint64(1), gaps.PendingNonce)
+	require.Equal([]NonceRange{{Start: 1, End: 1}, {Start: 3, End: 4}}, gaps.Gaps)
+	require.Len(gaps.Actions, 2)
+	require.Equal(tsf2, gaps.Actions[0].Action)
+	// zero gas price has to be bumped to at least 1
+	require.Equal(big.NewInt(1), gaps.Actions[0].MinGasFeeCap)
+	require.Equal(big.NewInt(1), gaps.Actions[0].MinGasTipCap)
+	require.Equal(tsf5, gaps.Actions[1].Action)
+	require.Equal(big.NewInt(110), gaps.Actions[1].MinGasFeeCap)
+	require.Equal(big.NewInt(110), gaps.Actions[1].MinGasTipCap)
+	require.Nil(gaps.Actions[1].MinBlobGasFeeCap)
+}
+
 func TestActPool_GetUnconfirmedActs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	require := require.New(t)
@@ -982,6 +1049,43 @@ func TestActPool_GetUnconfirmedActs(t *testing.T) {
 	}
 }
 
+func TestActPool_Content(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	Ap, err := NewActPool(genesis.TestDefault(), sf, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+
+	tsf1, err := action.SignedTransfer(_addr1, _priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := action.SignedTransfer(_addr1, _priKey1, uint64(3), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := action.SignedTransfer(_addr1, _priKey1, uint64(4), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf5, err := action.SignedTransfer(_addr1, _priKey2, uint64(1), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+	require.Empty(ap.Content())
+	for _, act := range []*action.SealedEnvelope{tsf4, tsf1, tsf3, tsf5} {
+		require.NoError(ap.Add(ctx, act))
+	}
+
+	content := ap.Content()
+	require.Len(content, 2)
+	require.Equal(&Content{Pending: []*action.SealedEnvelope{tsf1}, Queued: []*action.SealedEnvelope{tsf3, tsf4}}, content[_addr1])
+	require.Equal(&Content{Pending: []*action.SealedEnvelope{tsf5}}, content[_addr2])
+}
+
 func TestActPool_GetActionByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	require := require.New(t)
@@ -1017,6 +1121,80 @@ func TestActPool_GetActionByHash(t *testing.T) {
 	require.Equal(tsf2, act)
 }
 
+func TestActPool_PersistAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	confirmedNonce := uint64(0)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		for i := uint64(1); i <= confirmedNonce; i++ {
+			require.NoError(acct.SetPendingNonce(i + 1))
+		}
+		require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+
+	var (
+		ctx     = genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+		dataDir = t.TempDir()
+		encode  = func(selp *action.SealedEnvelope) ([]byte, error) {
+			return proto.Marshal(selp.Proto())
+		}
+		decode = func(blob []byte) (*action.SealedEnvelope, error) {
+			d := &action.Deserializer{}
+			a := &iotextypes.Action{}
+			if err := proto.Unmarshal(blob, a); err != nil {
+				return nil, err
+			}
+			return d.ActionToSealedEnvelope(a)
+		}
+		newActPool = func() *actPool {
+			Ap, err := NewActPool(genesis.TestDefault(), sf, getActPoolCfg(), WithStore(StoreConfig{
+				Datadir:    dataDir,
+				PersistAll: true,
+			}, encode, decode))
+			require.NoError(err)
+			ap, ok := Ap.(*actPool)
+			require.True(ok)
+			ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+			require.NoError(ap.Start(ctx))
+			return ap
+		}
+	)
+	ap := newActPool()
+	hashes := make([]hash.Hash256, 0, 3)
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		tsf, err := action.SignedTransfer(_addr1, _priKey1, nonce, big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		require.NoError(ap.Add(ctx, tsf))
+		h, err := tsf.Hash()
+		require.NoError(err)
+		hashes = append(hashes, h)
+	}
+	require.Equal(3, ap.allActions.Count())
+	require.NoError(ap.Stop(ctx))
+
+	// the first action is confirmed while the node is down, and dropped when the actions are reloaded
+	confirmedNonce = 1
+	ap = newActPool()
+	require.Equal(2, ap.allActions.Count())
+	_, err := ap.GetActionByHash(hashes[0])
+	require.Equal(action.ErrNotFound, errors.Cause(err))
+	for _, h := range hashes[1:] {
+		_, err := ap.GetActionByHash(h)
+		require.NoError(err)
+	}
+	require.NoError(ap.Stop(ctx))
+
+	ap = newActPool()
+	require.Equal(2, ap.allActions.Count())
+	require.NoError(ap.Stop(ctx))
+}
+
 func TestActPool_GetCapacity(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	require := require.New(t)
@@ -1029,6 +1207,11 @@ func TestActPool_GetCapacity(t *testing.T) {
 	require.True(ok)
 	require.Equal(uint64(_maxNumActsPerPool), ap.GetCapacity())
 	require.Equal(uint64(_maxGasLimitPerPool), ap.GetGasCapacity())
+
+	require.Error(ap.SetCapacity(0, _maxGasLimitPerPool))
+	require.NoError(ap.SetCapacity(_maxNumActsPerPool*2, _maxGasLimitPerPool/2))
+	require.Equal(uint64(_maxNumActsPerPool*2), ap.GetCapacity())
+	require.Equal(uint64(_maxGasLimitPerPool/2), ap.GetGasCapacity())
 }
 
 func TestActPool_GetSize(t *testing.T) {
@@ -24,6 +24,9 @@ import (
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
 
+// _blobPriceBump is the min percentage of the gas price bump for replacing a blob tx
+const _blobPriceBump = 100
+
 // ActQueue is the interface of actQueue
 type ActQueue interface {
 	Put(*action.SealedEnvelope) error
@@ -37,6 +40,9 @@ type ActQueue interface {
 	PendingActs(context.Context) []*action.SealedEnvelope
 	AllActs() []*action.SealedEnvelope
 	PopActionWithLargestNonce() *action.SealedEnvelope
+	FutureLen() int
+	OldestFutureDeadline() (time.Time, bool)
+	PopOldestFutureAction() *action.SealedEnvelope
 	Reset()
 }
 
@@ -98,36 +104,25 @@ func (q *actQueue) Put(act *action.SealedEnvelope) error {
 	defer q.mu.Unlock()
 	nonce := act.Nonce()
 
-	if cost, _ := act.Cost(); q.getPendingBalanceAtNonce(nonce).Cmp(cost) < 0 {
+	if cost, _ := q.ap.cost(act); q.getPendingBalanceAtNonce(nonce).Cmp(cost) < 0 {
 		return action.ErrInsufficientFunds
 	}
 
 	if actInPool, exist := q.items[nonce]; exist {
-		// act of higher gas price can cut in line
-		if nonce < q.pendingNonce && act.GasFeeCap().Cmp(actInPool.GasFeeCap()) != 1 {
-			return errors.Wrapf(action.ErrReplaceUnderpriced, "gas fee cap %s < %s", act.GasFeeCap(), actInPool.GasFeeCap())
-		}
-		// 2x bumps in gas price are allowed for blob tx
+		// act of higher gas price can replace the one in pool
+		priceBump := q.ap.cfg.PriceBump
 		isPrevBlobTx, isBlobTx := len(actInPool.BlobHashes()) > 0, len(act.BlobHashes()) > 0
 		if isPrevBlobTx {
 			if !isBlobTx {
 				return errors.Wrap(action.ErrReplaceUnderpriced, "blob tx can only replace blob tx")
 			}
-			var (
-				priceBump        = big.NewInt(2)
-				minGasFeeCap     = new(big.Int).Mul(actInPool.GasFeeCap(), priceBump)
-				minGasTipCap     = new(big.Int).Mul(actInPool.GasTipCap(), priceBump)
-				minBlobGasFeeCap = new(big.Int).Mul(actInPool.BlobGasFeeCap(), priceBump)
-			)
-			switch {
-			case act.GasFeeCap().Cmp(minGasFeeCap) < 0:
-				return errors.Wrapf(action.ErrReplaceUnderpriced, "gas fee cap %s < %s", act.GasFeeCap(), minGasFeeCap)
-			case act.GasTipCap().Cmp(minGasTipCap) < 0:
-				return errors.Wrapf(action.ErrReplaceUnderpriced, "gas tip cap %s < %s", act.GasTipCap(), minGasTipCap)
-			case act.BlobGasFeeCap().Cmp(minBlobGasFeeCap) < 0:
-				return errors.Wrapf(action.ErrReplaceUnderpriced, "blob gas fee cap %s < %s", act.BlobGasFeeCap(), minBlobGasFeeCap)
-			}
+			// at least 2x bumps in gas price are required for blob tx
+			priceBump = max(priceBump, _blobPriceBump)
 		}
+		if err := checkPriceBump(actInPool, act, priceBump); err != nil {
+			return err
+		}
+		_actpoolMtc.WithLabelValues("replaced").Inc()
 		// update action in q.items and q.index
 		q.items[nonce] = act
 		for i := range q.ascQueue {
@@ -140,6 +135,11 @@ func (q *actQueue) Put(act *action.SealedEnvelope) error {
 		q.ap.removeInvalidActs([]*action.SealedEnvelope{actInPool})
 		return nil
 	}
+	if nonce > q.pendingNonce && q.ap != nil && q.ap.cfg.MaxNumFutureActsPerAcct > 0 &&
+		uint64(q.futureLen()) >= q.ap.cfg.MaxNumFutureActsPerAcct {
+		_actpoolMtc.WithLabelValues("overMaxNumFutureActsPerAcct").Inc()
+		return errors.Wrapf(action.ErrTxPoolOverflow, "account has %d future actions", q.futureLen())
+	}
 	nttl := &nonceWithTTL{nonce: nonce, deadline: q.clock.Now().Add(q.ttl)}
 	heap.Push(&q.ascQueue, nttl)
 	heap.Push(&q.descQueue, nttl)
@@ -150,6 +150,49 @@ func (q *actQueue) Put(act *action.SealedEnvelope) error {
 	return nil
 }
 
+// checkPriceBump checks that the new action bumps both the gas fee cap and the gas tip cap of the action in pool by at
+// least the percentage. As the effective gas tip at base fee b is min(gasTipCap, gasFeeCap-b), it is bumped by at least
+// the percentage at any base fee as well
+func checkPriceBump(actInPool, act *action.SealedEnvelope, percentage uint64) error {
+	bumped := func(price *big.Int) *big.Int {
+		return bumpPrice(price, percentage)
+	}
+	if act.GasFeeCap().Cmp(actInPool.GasFeeCap()) <= 0 {
+		return errors.Wrapf(action.ErrReplaceUnderpriced, "gas fee cap %s <= %s", act.GasFeeCap(), actInPool.GasFeeCap())
+	}
+	if act.GasTipCap().Cmp(actInPool.GasTipCap()) <= 0 {
+		return errors.Wrapf(action.ErrReplaceUnderpriced, "gas tip cap %s <= %s", act.GasTipCap(), actInPool.GasTipCap())
+	}
+	if minGasFeeCap := bumped(actInPool.GasFeeCap()); act.GasFeeCap().Cmp(minGasFeeCap) < 0 {
+		return errors.Wrapf(action.ErrReplaceUnderpriced, "gas fee cap %s < %s", act.GasFeeCap(), minGasFeeCap)
+	}
+	if minGasTipCap := bumped(actInPool.GasTipCap()); act.GasTipCap().Cmp(minGasTipCap) < 0 {
+		return errors.Wrapf(action.ErrReplaceUnderpriced, "gas tip cap %s < %s", act.GasTipCap(), minGasTipCap)
+	}
+	if len(actInPool.BlobHashes()) > 0 {
+		if minBlobGasFeeCap := bumped(actInPool.BlobGasFeeCap()); act.BlobGasFeeCap().Cmp(minBlobGasFeeCap) < 0 {
+			return errors.Wrapf(action.ErrReplaceUnderpriced, "blob gas fee cap %s < %s", act.BlobGasFeeCap(), minBlobGasFeeCap)
+		}
+	}
+	return nil
+}
+
+// bumpPrice returns the price bumped by the percentage
+func bumpPrice(price *big.Int, percentage uint64) *big.Int {
+	res := new(big.Int).Mul(price, new(big.Int).SetUint64(100+percentage))
+	return res.Div(res, big.NewInt(100))
+}
+
+// minReplacementPrice returns the min gas fee cap or gas tip cap passing checkPriceBump, which is the price bumped by
+// the percentage and strictly greater than the price in pool
+func minReplacementPrice(price *big.Int, percentage uint64) *big.Int {
+	res := bumpPrice(price, percentage)
+	if res.Cmp(price) <= 0 {
+		res.Add(price, big.NewInt(1))
+	}
+	return res
+}
+
 func (q *actQueue) getPendingBalanceAtNonce(nonce uint64) *big.Int {
 	if nonce > q.pendingNonce {
 		return q.getPendingBalanceAtNonce(q.pendingNonce)
@@ -171,7 +214,7 @@ func (q *actQueue) updateFromNonce(start uint64) {
 			break
 		}
 
-		cost, _ := act.Cost()
+		cost, _ := q.ap.cost(act)
 		if balance.Cmp(cost) < 0 {
 			break
 		}
@@ -325,7 +368,7 @@ func (q *actQueue) PendingActs(ctx context.Context) []*action.SealedEnvelope {
 			break
 		}
 
-		cost, _ := act.Cost()
+		cost, _ := q.ap.cost(act)
 		if balance.Cmp(cost) < 0 {
 			break
 		}
@@ -363,3 +406,53 @@ func (q *actQueue) PopActionWithLargestNonce() *action.SealedEnvelope {
 	q.updateFromNonce(itemMeta.nonce)
 	return item
 }
+
+// FutureLen returns the number of the future actions, which are not executable due to the nonce gap or the balance
+func (q *actQueue) FutureLen() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.futureLen()
+}
+
+func (q *actQueue) futureLen() int {
+	// the actions of the nonces in [accountNonce, pendingNonce) are all in the queue
+	return max(len(q.items)-int(q.pendingNonce-q.accountNonce), 0)
+}
+
+// OldestFutureDeadline returns the deadline of the oldest future action
+func (q *actQueue) OldestFutureDeadline() (time.Time, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if oldest := q.oldestFuture(); oldest != nil {
+		return oldest.deadline, true
+	}
+	return time.Time{}, false
+}
+
+// PopOldestFutureAction pops the oldest future action, the pending nonce is not affected
+func (q *actQueue) PopOldestFutureAction() *action.SealedEnvelope {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	oldest := q.oldestFuture()
+	if oldest == nil {
+		return nil
+	}
+	heap.Remove(&q.ascQueue, oldest.ascIdx)
+	heap.Remove(&q.descQueue, oldest.descIdx)
+	item := q.items[oldest.nonce]
+	delete(q.items, oldest.nonce)
+	return item
+}
+
+func (q *actQueue) oldestFuture() *nonceWithTTL {
+	var oldest *nonceWithTTL
+	for _, nttl := range q.ascQueue {
+		if nttl.nonce < q.pendingNonce {
+			continue
+		}
+		if oldest == nil || nttl.deadline.Before(oldest.deadline) {
+			oldest = nttl
+		}
+	}
+	return oldest
+}
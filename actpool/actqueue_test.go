@@ -62,7 +62,9 @@ func TestNoncePriorityQueue(t *testing.T) {
 func TestActQueuePut(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
-	ap, err := NewActPool(genesis.TestDefault(), mock_chainmanager.NewMockStateReader(ctrl), DefaultConfig)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	ap, err := NewActPool(genesis.TestDefault(), sf, DefaultConfig)
 	require.NoError(err)
 	q := NewActQueue(ap.(*actPool), "", 1, big.NewInt(maxBalance)).(*actQueue)
 	tsf1, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(1))
@@ -85,6 +87,57 @@ func TestActQueuePut(t *testing.T) {
 	tsf4, err := action.SignedTransfer(_addr2, _priKey1, 1, big.NewInt(1000), nil, uint64(0), big.NewInt(2))
 	require.NoError(err)
 	require.NoError(q.Put(tsf4))
+	// replacing the pending action requires the price bump
+	sub := &testSubscriber{}
+	ap.AddSubscriber(sub)
+	tsf5, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(100))
+	require.NoError(err)
+	require.NoError(q.Put(tsf5))
+	require.Equal([]*action.SealedEnvelope{tsf1}, sub.removed)
+	tsf6, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(109))
+	require.NoError(err)
+	require.ErrorIs(q.Put(tsf6), action.ErrReplaceUnderpriced)
+	tsf7, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(110))
+	require.NoError(err)
+	require.NoError(q.Put(tsf7))
+	require.Equal([]*action.SealedEnvelope{tsf1, tsf5}, sub.removed)
+	require.Equal(tsf7, q.items[uint64(2)])
+}
+
+func TestActQueueGasSponsored(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sponsored := identityset.Address(30).String()
+	ap, err := NewActPool(genesis.TestDefault(), mock_chainmanager.NewMockStateReader(ctrl), DefaultConfig,
+		WithGasSponsor(func(selp *action.SealedEnvelope) bool {
+			dst, _ := selp.Destination()
+			return dst == sponsored
+		}))
+	require.NoError(err)
+	// the sender does not have balance
+	q := NewActQueue(ap.(*actPool), "", 1, big.NewInt(0)).(*actQueue)
+	exec1, err := action.SignedExecution(identityset.Address(31).String(), _priKey1, 1, big.NewInt(0), 10000, big.NewInt(1), nil)
+	require.NoError(err)
+	require.ErrorIs(q.Put(exec1), action.ErrInsufficientFunds)
+	// the gas fee of the sponsored call is not charged to the sender
+	exec2, err := action.SignedExecution(sponsored, _priKey1, 1, big.NewInt(0), 10000, big.NewInt(1), nil)
+	require.NoError(err)
+	require.NoError(q.Put(exec2))
+	require.Equal(uint64(2), q.pendingNonce)
+	// the value is still paid by the sender
+	exec3, err := action.SignedExecution(sponsored, _priKey1, 2, big.NewInt(1), 10000, big.NewInt(1), nil)
+	require.NoError(err)
+	require.ErrorIs(q.Put(exec3), action.ErrInsufficientFunds)
+}
+
+type testSubscriber struct {
+	removed []*action.SealedEnvelope
+}
+
+func (s *testSubscriber) OnAdded(*action.SealedEnvelope) {}
+
+func (s *testSubscriber) OnRemoved(act *action.SealedEnvelope) {
+	s.removed = append(s.removed, act)
 }
 
 func TestActQueueFilterNonce(t *testing.T) {
@@ -198,6 +251,45 @@ func TestActQueueTimeOutAction(t *testing.T) {
 	require.Equal(t, 1, q.Len())
 }
 
+func TestActQueueFutureActions(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	cfg := DefaultConfig
+	cfg.MaxNumFutureActsPerAcct = 2
+	ap, err := NewActPool(genesis.TestDefault(), mock_chainmanager.NewMockStateReader(ctrl), cfg)
+	require.NoError(err)
+	c := clock.NewMock()
+	q := NewActQueue(ap.(*actPool), "", 1, big.NewInt(maxBalance), WithClock(c), WithTimeOut(3*time.Minute))
+	tsfs := make([]*action.SealedEnvelope, 7)
+	for i := range tsfs {
+		tsfs[i], err = action.SignedTransfer(_addr2, _priKey1, uint64(i+1), big.NewInt(100), nil, uint64(0), big.NewInt(1))
+		require.NoError(err)
+	}
+	_, ok := q.OldestFutureDeadline()
+	require.False(ok)
+	require.NoError(q.Put(tsfs[0]))
+	require.NoError(q.Put(tsfs[4]))
+	c.Add(time.Minute)
+	require.NoError(q.Put(tsfs[3]))
+	require.Equal(2, q.FutureLen())
+	// the account reaches the limit of future actions
+	require.ErrorIs(q.Put(tsfs[5]), action.ErrTxPoolOverflow)
+	deadline, ok := q.OldestFutureDeadline()
+	require.True(ok)
+	require.Equal(c.Now().Add(2*time.Minute), deadline)
+
+	// filling the gap makes the future actions executable
+	require.NoError(q.Put(tsfs[1]))
+	require.NoError(q.Put(tsfs[2]))
+	require.Zero(q.FutureLen())
+	require.NoError(q.Put(tsfs[6]))
+	require.Equal(1, q.FutureLen())
+	require.Equal(tsfs[6], q.PopOldestFutureAction())
+	require.Nil(q.PopOldestFutureAction())
+	require.Equal(uint64(6), q.PendingNonce())
+	require.Equal(5, q.Len())
+}
+
 func TestActQueueCleanTimeout(t *testing.T) {
 	require := require.New(t)
 	q := NewActQueue(nil, "", 1, big.NewInt(1000)).(*actQueue)
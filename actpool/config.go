@@ -11,14 +11,24 @@ import (
 var (
 	// DefaultConfig is the default config for actpool
 	DefaultConfig = Config{
-		MaxNumActsPerPool:  32000,
-		MaxGasLimitPerPool: 320000000,
-		MaxNumActsPerAcct:  2000,
-		WorkerBufferSize:   2000,
-		ActionExpiry:       10 * time.Minute,
-		MinGasPriceStr:     big.NewInt(unit.Qev).String(),
-		BlackList:          []string{},
-		MaxNumBlobsPerAcct: 16,
+		MaxNumActsPerPool:       32000,
+		MaxGasLimitPerPool:      320000000,
+		MaxNumActsPerAcct:       2000,
+		MaxNumFutureActsPerPool: 8000,
+		MaxNumFutureActsPerAcct: 128,
+		WorkerBufferSize:        2000,
+		ActionExpiry:            10 * time.Minute,
+		MinGasPriceStr:          big.NewInt(unit.Qev).String(),
+		BlackList:               []string{},
+		Locals:                  []string{},
+		ReservedNumActs:         1000,
+		MaxNumBlobsPerAcct:      16,
+		MaxNumBlobsPerPool:      128,
+		PriceBump:               10,
+		ExecutionBudget: ExecutionBudgetConfig{
+			MaxOverBudget: 3,
+			Cooldown:      10 * time.Minute,
+		},
 		Store: &StoreConfig{
 			Datadir: "/var/data/actpool.cache",
 		},
@@ -33,6 +43,12 @@ type Config struct {
 	MaxGasLimitPerPool uint64 `yaml:"maxGasLimitPerPool"`
 	// MaxNumActsPerAcct indicates maximum number of actions an account queue can hold
 	MaxNumActsPerAcct uint64 `yaml:"maxNumActsPerAcct"`
+	// MaxNumFutureActsPerPool indicates maximum number of future actions the whole actpool can hold, 0 for no limit.
+	// A future action is one that is not executable yet due to the nonce gap. The oldest future actions are evicted
+	// once the limit is reached
+	MaxNumFutureActsPerPool uint64 `yaml:"maxNumFutureActsPerPool"`
+	// MaxNumFutureActsPerAcct indicates maximum number of future actions an account queue can hold, 0 for no limit
+	MaxNumFutureActsPerAcct uint64 `yaml:"maxNumFutureActsPerAcct"`
 	// WorkerBufferSize indicates the buffer size for each worker's job queue
 	WorkerBufferSize uint64 `yaml:"bufferPerAcct"`
 	// ActionExpiry defines how long an action will be kept in action pool.
@@ -41,10 +57,37 @@ type Config struct {
 	MinGasPriceStr string `yaml:"minGasPrice"`
 	// BlackList lists the account address that are banned from initiating actions
 	BlackList []string `yaml:"blackList"`
+	// Locals lists the addresses whose actions are never evicted from a full pool in favor of higher gas price, and
+	// are picked first when building blocks
+	Locals []string `yaml:"locals"`
+	// ReservedNumActs is the number of slots in the pool reserved for the actions of the locals and the system-critical
+	// actions, e.g., claiming rewards, which are still accepted when the rest of the pool is full
+	ReservedNumActs uint64 `yaml:"reservedNumActs"`
 	// Store defines the config for persistent cache
 	Store *StoreConfig `yaml:"store"`
 	// MaxNumBlobsPerAcct defines the maximum number of blob txs an account can have
 	MaxNumBlobsPerAcct uint64 `yaml:"maxNumBlobsPerAcct"`
+	// MaxNumBlobsPerPool defines the maximum number of blob txs the whole actpool can have, 0 for no limit. The blob txs
+	// are limited separately as each of them carries up to hundreds of KB of sidecar
+	MaxNumBlobsPerPool uint64 `yaml:"maxNumBlobsPerPool"`
+	// ExecutionBudget defines the execution time budget of an action during block proposal
+	ExecutionBudget ExecutionBudgetConfig `yaml:"executionBudget"`
+	// PriceBump is the min percentage by which an action replacing the pending one of the same sender and nonce should
+	// bump the gas price, i.e., both the gas fee cap and the gas tip cap
+	PriceBump uint64 `yaml:"priceBump"`
+	// EnableAccessSet enables computing the addresses each action reads and writes, so that the proposer packs
+	// the non-conflicting actions first
+	EnableAccessSet bool `yaml:"enableAccessSet"`
+}
+
+// ExecutionBudgetConfig is the config of action execution time budget
+type ExecutionBudgetConfig struct {
+	// Budget is the execution time budget of an action, 0 to disable
+	Budget time.Duration `yaml:"budget"`
+	// MaxOverBudget is the number of times a sender's actions can exceed the budget before quarantine
+	MaxOverBudget uint64 `yaml:"maxOverBudget"`
+	// Cooldown is how long a sender or an action stays in quarantine
+	Cooldown time.Duration `yaml:"cooldown"`
 }
 
 // MinGasPrice returns the minimal gas price threshold
@@ -59,4 +102,7 @@ func (ap Config) MinGasPrice() *big.Int {
 // StoreConfig is the configuration for the blob store
 type StoreConfig struct {
 	Datadir string `yaml:"datadir"` // Data directory containing the currently executable blobs
+	// PersistAll persists all the pending actions instead of only the blob txs, so that the pending actions survive the
+	// restarts of the node. The actions are revalidated when they are reloaded
+	PersistAll bool `yaml:"persistAll"`
 }
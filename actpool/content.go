@@ -0,0 +1,35 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+type (
+	// ContentReader reads the actions of all the senders in the pool
+	ContentReader interface {
+		// Content returns the actions in the pool by sender address
+		Content() map[string]*Content
+	}
+
+	// Content is the actions of a sender in the pool, sorted by nonce
+	Content struct {
+		// Pending are the actions ready to be executed
+		Pending []*action.SealedEnvelope
+		// Queued are the actions not executable yet due to the nonce gap or the insufficient balance
+		Queued []*action.SealedEnvelope
+	}
+)
+
+// Content returns the actions in the pool by sender address
+func (ap *actPool) Content() map[string]*Content {
+	content := make(map[string]*Content)
+	for _, worker := range ap.worker {
+		worker.Content(content)
+	}
+	return content
+}
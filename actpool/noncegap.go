@@ -0,0 +1,104 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+)
+
+type (
+	// NonceGapReader diagnoses the nonces of a sender in the pool
+	NonceGapReader interface {
+		// NonceGaps returns the nonce diagnostics of the sender
+		NonceGaps(address.Address) (*NonceGaps, error)
+	}
+
+	// NonceGaps is the nonce diagnostics of a sender, which tells why the actions of the sender are stuck in the pool
+	NonceGaps struct {
+		// ConfirmedNonce is the nonce of the next action to be confirmed on chain
+		ConfirmedNonce uint64
+		// PendingNonce is the nonce of the next action to be executable in the pool
+		PendingNonce uint64
+		// Actions are the actions of the sender in the pool, sorted by nonce
+		Actions []*ReplaceableAction
+		// Gaps are the missing nonces which block the actions of larger nonces
+		Gaps []NonceRange
+	}
+
+	// ReplaceableAction is an action in the pool and the min fees of the action replacing it
+	ReplaceableAction struct {
+		Action       *action.SealedEnvelope
+		MinGasFeeCap *big.Int
+		MinGasTipCap *big.Int
+		// MinBlobGasFeeCap is nil if the action is not a blob tx
+		MinBlobGasFeeCap *big.Int
+	}
+
+	// NonceRange is the nonces in [Start, End]
+	NonceRange struct {
+		Start uint64
+		End   uint64
+	}
+)
+
+// NonceGaps returns the nonce diagnostics of the sender
+func (ap *actPool) NonceGaps(sender address.Address) (*NonceGaps, error) {
+	ctx := ap.context(context.Background())
+	confirmedState, err := accountutil.AccountState(ctx, ap.sf, sender)
+	if err != nil {
+		return nil, err
+	}
+	res := &NonceGaps{}
+	if protocol.MustGetFeatureCtx(ctx).UseZeroNonceForFreshAccount {
+		res.ConfirmedNonce = confirmedState.PendingNonceConsideringFreshAccount()
+	} else {
+		res.ConfirmedNonce = confirmedState.PendingNonce()
+	}
+	worker := ap.worker[ap.allocatedWorker(sender)]
+	pendingNonce, ok := worker.PendingNonce(sender)
+	if !ok {
+		res.PendingNonce = res.ConfirmedNonce
+		return res, nil
+	}
+	res.PendingNonce = pendingNonce
+	acts, _ := worker.AllActions(sender)
+	next := res.ConfirmedNonce
+	for _, act := range acts {
+		nonce := act.Nonce()
+		if nonce > next {
+			res.Gaps = append(res.Gaps, NonceRange{Start: next, End: nonce - 1})
+		}
+		if nonce >= next {
+			next = nonce + 1
+		}
+		res.Actions = append(res.Actions, ap.replaceableAction(act))
+	}
+	return res, nil
+}
+
+func (ap *actPool) replaceableAction(act *action.SealedEnvelope) *ReplaceableAction {
+	priceBump := ap.cfg.PriceBump
+	isBlobTx := len(act.BlobHashes()) > 0
+	if isBlobTx {
+		priceBump = max(priceBump, _blobPriceBump)
+	}
+	res := &ReplaceableAction{
+		Action:       act,
+		MinGasFeeCap: minReplacementPrice(act.GasFeeCap(), priceBump),
+		MinGasTipCap: minReplacementPrice(act.GasTipCap(), priceBump),
+	}
+	if isBlobTx {
+		res.MinBlobGasFeeCap = bumpPrice(act.BlobGasFeeCap(), priceBump)
+	}
+	return res
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/facebookgo/clock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
 )
 
 // ActQueueOption is the option for actQueue.
@@ -48,6 +50,16 @@ func WithStore(cfg StoreConfig, encode encodeAction, decode decodeAction) func(*
 			return err
 		}
 		a.store = store
+		a.persistAll = cfg.PersistAll
+		return nil
+	}
+}
+
+// WithGasSponsor is the option to set the function telling whether the gas fee of an action is paid by a sponsor, the
+// sender of such action only needs the balance to cover the value
+func WithGasSponsor(sponsored func(*action.SealedEnvelope) bool) func(*actPool) error {
+	return func(a *actPool) error {
+		a.gasSponsored = sponsored
 		return nil
 	}
 }
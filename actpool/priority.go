@@ -0,0 +1,47 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+// PriorityReader tells whether an action is in the priority lane of the pool, which is picked first when building
+// blocks
+type PriorityReader interface {
+	IsPriority(*action.SealedEnvelope) bool
+}
+
+// IsPriority returns true if the action is sent by a local address, or is a system-critical action
+func (ap *actPool) IsPriority(selp *action.SealedEnvelope) bool {
+	return ap.isLocal(selp.SenderAddress().String()) || isCriticalAction(selp)
+}
+
+func (ap *actPool) isLocal(addr string) bool {
+	_, ok := ap.locals[addr]
+	return ok
+}
+
+// capacity returns the number of actions the pool can hold for the action, the reserved slots are only available to
+// the priority lane
+func (ap *actPool) capacity(selp *action.SealedEnvelope) uint64 {
+	maxNumActs := ap.maxNumActs.Load()
+	if ap.IsPriority(selp) {
+		return maxNumActs
+	}
+	return maxNumActs - min(ap.cfg.ReservedNumActs, maxNumActs)
+}
+
+// isCriticalAction returns true if the action is critical to the operation of the chain, e.g., the delegates claiming
+// the rewards to pay for the operation of the nodes
+func isCriticalAction(selp *action.SealedEnvelope) bool {
+	switch selp.Action().(type) {
+	case *action.ClaimFromRewardingFund:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+var (
+	_executionTimeMtc = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "iotex_actpool_action_execution_seconds",
+		Help:    "Execution wall time of actions during block proposal",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2},
+	})
+	_quarantineMtc = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "iotex_actpool_quarantine",
+		Help: "Number of senders and actions in quarantine",
+	}, []string{"type"})
+)
+
+func init() {
+	prometheus.MustRegister(_executionTimeMtc)
+	prometheus.MustRegister(_quarantineMtc)
+}
+
+// ExecutionTimeRecorder records the execution time of actions during block proposal
+type ExecutionTimeRecorder interface {
+	RecordExecutionTime(*action.SealedEnvelope, time.Duration)
+}
+
+// quarantine locally bans the senders whose actions repeatedly exceed the execution time budget
+type quarantine struct {
+	mu        sync.Mutex
+	clk       clock.Clock
+	budget    time.Duration
+	threshold uint64
+	cooldown  time.Duration
+	strikes   map[string]uint64
+	senders   map[string]time.Time
+	hashes    map[hash.Hash256]time.Time
+}
+
+func newQuarantine(cfg ExecutionBudgetConfig, clk clock.Clock) *quarantine {
+	return &quarantine{
+		clk:       clk,
+		budget:    cfg.Budget,
+		threshold: cfg.MaxOverBudget,
+		cooldown:  cfg.Cooldown,
+		strikes:   make(map[string]uint64),
+		senders:   make(map[string]time.Time),
+		hashes:    make(map[hash.Hash256]time.Time),
+	}
+}
+
+// record records the execution time of the action, returns true if the sender is put into quarantine
+func (q *quarantine) record(sender string, h hash.Hash256, d time.Duration) bool {
+	_executionTimeMtc.Observe(d.Seconds())
+	if d <= q.budget {
+		return false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := q.clk.Now()
+	q.purge(now)
+	until := now.Add(q.cooldown)
+	q.hashes[h] = until
+	q.strikes[sender]++
+	log.L().Warn("Action exceeds execution time budget.",
+		zap.String("sender", sender),
+		zap.String("hash", hex.EncodeToString(h[:])),
+		zap.Duration("duration", d),
+		zap.Uint64("strikes", q.strikes[sender]))
+	if q.strikes[sender] < q.threshold {
+		q.updateMetrics()
+		return false
+	}
+	delete(q.strikes, sender)
+	q.senders[sender] = until
+	q.updateMetrics()
+	return true
+}
+
+// quarantined returns true if the sender or the action is in quarantine
+func (q *quarantine) quarantined(sender string, h hash.Hash256) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := q.clk.Now()
+	if until, ok := q.senders[sender]; ok {
+		if now.Before(until) {
+			return true
+		}
+		delete(q.senders, sender)
+	}
+	if until, ok := q.hashes[h]; ok {
+		if now.Before(until) {
+			return true
+		}
+		delete(q.hashes, h)
+	}
+	q.updateMetrics()
+	return false
+}
+
+func (q *quarantine) purge(now time.Time) {
+	for sender, until := range q.senders {
+		if !now.Before(until) {
+			delete(q.senders, sender)
+		}
+	}
+	for h, until := range q.hashes {
+		if !now.Before(until) {
+			delete(q.hashes, h)
+		}
+	}
+}
+
+func (q *quarantine) updateMetrics() {
+	_quarantineMtc.WithLabelValues("sender").Set(float64(len(q.senders)))
+	_quarantineMtc.WithLabelValues("action").Set(float64(len(q.hashes)))
+}
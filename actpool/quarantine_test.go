@@ -0,0 +1,48 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestQuarantine(t *testing.T) {
+	require := require.New(t)
+	clk := clock.NewMock()
+	q := newQuarantine(ExecutionBudgetConfig{
+		Budget:        100 * time.Millisecond,
+		MaxOverBudget: 2,
+		Cooldown:      time.Minute,
+	}, clk)
+	sender := identityset.Address(1).String()
+	h1, h2, h3 := hash.Hash256b([]byte("1")), hash.Hash256b([]byte("2")), hash.Hash256b([]byte("3"))
+
+	// within budget
+	require.False(q.record(sender, h1, 50*time.Millisecond))
+	require.False(q.quarantined(sender, h1))
+
+	// the slow action is quarantined, but not the sender
+	require.False(q.record(sender, h1, 200*time.Millisecond))
+	require.True(q.quarantined(sender, h1))
+	require.False(q.quarantined(sender, h2))
+
+	// the sender is quarantined after exceeding the budget again
+	require.True(q.record(sender, h2, 200*time.Millisecond))
+	require.True(q.quarantined(sender, h3))
+	require.False(q.quarantined(identityset.Address(2).String(), h3))
+
+	// released after cooldown
+	clk.Add(time.Minute)
+	require.False(q.quarantined(sender, h1))
+	require.False(q.quarantined(sender, h3))
+}
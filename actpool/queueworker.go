@@ -87,7 +87,7 @@ func (worker *queueWorker) Handle(job workerJob) error {
 		act             = job.act
 		sender          = act.SenderAddress().String()
 		actHash, _      = act.Hash()
-		intrinsicGas, _ = act.IntrinsicGas()
+		intrinsicGas, _ = protocol.IntrinsicGas(ctx, act.Envelope)
 		replace         = job.rep
 	)
 	defer span.End()
@@ -106,8 +106,8 @@ func (worker *queueWorker) Handle(job workerJob) error {
 
 	worker.ap.allActions.Set(actHash, act)
 	worker.ap.onAdded(act)
-	isBlobTx := len(act.BlobHashes()) > 0 // only store blob tx
-	if worker.ap.store != nil && isBlobTx {
+	isBlobTx := len(act.BlobHashes()) > 0 // only store blob tx unless persisting all
+	if worker.ap.store != nil && (isBlobTx || worker.ap.persistAll) {
 		if err := worker.ap.store.Put(act); err != nil {
 			log.L().Warn("failed to store action", zap.Error(err), log.Hex("hash", actHash[:]))
 		}
@@ -127,7 +127,8 @@ func (worker *queueWorker) Handle(job workerJob) error {
 		// TODO: early return if sender is the account to pop and nonce is larger than largest in the queue
 		actToReplace := worker.accountActs.PopPeek()
 		if actToReplace == nil {
-			log.L().Warn("UNEXPECTED ERROR: action pool is full, but no action to drop")
+			// the actions of the locals are never dropped
+			log.L().Warn("action pool is full, but no action to drop other than the locals'")
 			return nil
 		}
 		worker.ap.removeInvalidActs([]*action.SealedEnvelope{actToReplace})
@@ -136,6 +137,16 @@ func (worker *queueWorker) Handle(job workerJob) error {
 			_actpoolMtc.WithLabelValues("overMaxNumActsPerPool").Inc()
 		}
 	}
+	if limit := worker.ap.cfg.MaxNumFutureActsPerPool; err == nil && limit > 0 && worker.ap.futureLen() > limit {
+		// evict the oldest future action to make room, which may be the new one
+		if actToEvict := worker.accountActs.PopOldestFuture(); actToEvict != nil {
+			worker.ap.removeInvalidActs([]*action.SealedEnvelope{actToEvict})
+			if actToEvict.SenderAddress().String() == sender && actToEvict.Nonce() == act.Nonce() {
+				err = action.ErrTxPoolOverflow
+			}
+			_actpoolMtc.WithLabelValues("overMaxNumFutureActsPerPool").Inc()
+		}
+	}
 
 	worker.removeEmptyAccounts()
 
@@ -193,7 +204,7 @@ func (worker *queueWorker) checkSelpWithState(act *action.SealedEnvelope, pendin
 		}
 	}
 
-	if cost, _ := act.Cost(); balance.Cmp(cost) < 0 {
+	if cost, _ := worker.ap.cost(act); balance.Cmp(cost) < 0 {
 		_actpoolMtc.WithLabelValues("insufficientBalance").Inc()
 		sender := act.SenderAddress().String()
 		actHash, _ := act.Hash()
@@ -313,6 +324,33 @@ func (worker *queueWorker) AllActions(sender address.Address) ([]*action.SealedE
 	return nil, false
 }
 
+// Content adds the pending and the queued actions of each account to the content
+func (worker *queueWorker) Content(content map[string]*Content) {
+	worker.mu.RLock()
+	defer worker.mu.RUnlock()
+	for sender, account := range worker.accountActs.accounts {
+		var (
+			acts         = account.actQueue.AllActs()
+			pendingNonce = account.actQueue.PendingNonce()
+			c            = &Content{}
+		)
+		if len(acts) == 0 {
+			continue
+		}
+		sort.Slice(acts, func(i, j int) bool {
+			return acts[i].Nonce() < acts[j].Nonce()
+		})
+		for _, act := range acts {
+			if act.Nonce() < pendingNonce {
+				c.Pending = append(c.Pending, act)
+			} else {
+				c.Queued = append(c.Queued, act)
+			}
+		}
+		content[sender] = c
+	}
+}
+
 // PendingNonce returns the pending nonce of sender
 func (worker *queueWorker) PendingNonce(sender address.Address) (uint64, bool) {
 	worker.mu.RLock()
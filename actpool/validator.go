@@ -8,19 +8,23 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
 
 type blobValidator struct {
-	blobCntLimitPerAcc uint64
-	blobCntPerAcc      map[string]uint64
-	mutex              sync.RWMutex
+	blobCntLimitPerAcc  uint64
+	blobCntLimitPerPool uint64
+	blobCntPerAcc       map[string]uint64
+	blobCnt             uint64
+	mutex               sync.RWMutex
 }
 
-func newBlobValidator(blobCntLimitPerAcc uint64) *blobValidator {
+func newBlobValidator(blobCntLimitPerAcc, blobCntLimitPerPool uint64) *blobValidator {
 	return &blobValidator{
-		blobCntLimitPerAcc: blobCntLimitPerAcc,
-		blobCntPerAcc:      make(map[string]uint64),
+		blobCntLimitPerAcc:  blobCntLimitPerAcc,
+		blobCntLimitPerPool: blobCntLimitPerPool,
+		blobCntPerAcc:       make(map[string]uint64),
 	}
 }
 
@@ -28,6 +32,13 @@ func (v *blobValidator) Validate(ctx context.Context, act *action.SealedEnvelope
 	if len(act.BlobHashes()) == 0 {
 		return nil
 	}
+	// blob fee cap must cover the blob fee of the next block
+	if blkCtx, ok := protocol.GetBlockCtx(ctx); ok {
+		if blobFee := protocol.CalcBlobFee(blkCtx.ExcessBlobGas); act.BlobGasFeeCap().Cmp(blobFee) < 0 {
+			_actpoolMtc.WithLabelValues("blobFeeLower").Inc()
+			return errors.Wrapf(action.ErrUnderpriced, "blob fee cap %s is lower than blob fee %s", act.BlobGasFeeCap(), blobFee)
+		}
+	}
 	v.mutex.RLock()
 	defer v.mutex.RUnlock()
 	// check max number of blob txs per account
@@ -35,9 +46,21 @@ func (v *blobValidator) Validate(ctx context.Context, act *action.SealedEnvelope
 	if v.blobCntPerAcc[sender] >= v.blobCntLimitPerAcc {
 		return errors.Wrap(action.ErrNonceTooHigh, "too many blob txs in the queue")
 	}
+	// check max number of blob txs in the pool
+	if v.blobCntLimitPerPool > 0 && v.blobCnt >= v.blobCntLimitPerPool {
+		_actpoolMtc.WithLabelValues("overMaxNumBlobsPerPool").Inc()
+		return errors.Wrap(action.ErrTxPoolOverflow, "too many blob txs in the pool")
+	}
 	return nil
 }
 
+// count returns the number of blob txs in the pool
+func (v *blobValidator) count() uint64 {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.blobCnt
+}
+
 func (v *blobValidator) OnAdded(act *action.SealedEnvelope) {
 	if len(act.BlobHashes()) == 0 {
 		return
@@ -46,6 +69,7 @@ func (v *blobValidator) OnAdded(act *action.SealedEnvelope) {
 	defer v.mutex.Unlock()
 	sender := act.SenderAddress().String()
 	v.blobCntPerAcc[sender]++
+	v.blobCnt++
 }
 
 func (v *blobValidator) OnRemoved(act *action.SealedEnvelope) {
@@ -60,4 +84,8 @@ func (v *blobValidator) OnRemoved(act *action.SealedEnvelope) {
 		return
 	}
 	v.blobCntPerAcc[sender]--
+	if v.blobCntPerAcc[sender] == 0 {
+		delete(v.blobCntPerAcc, sender)
+	}
+	v.blobCnt--
 }
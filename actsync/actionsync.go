@@ -42,6 +42,8 @@ type (
 
 	actionMsg struct {
 		lastTime time.Time
+		// announcer is the peer that announced the action, which is requested first as it has the action for sure
+		announcer string
 	}
 )
 
@@ -100,12 +102,18 @@ func (as *ActionSync) Stop(ctx context.Context) error {
 }
 
 // RequestAction requests an action by hash
-func (as *ActionSync) RequestAction(_ context.Context, hash hash.Hash256) {
+func (as *ActionSync) RequestAction(ctx context.Context, hash hash.Hash256) {
+	as.RequestActionFrom(ctx, hash, "")
+}
+
+// RequestActionFrom requests an action by hash, first from the peer that announced it, and then from the neighbors. It
+// matters for the blob txs, which are announced by hash only, and whose sidecars are pulled from the peers having them
+func (as *ActionSync) RequestActionFrom(_ context.Context, hash hash.Hash256, announcer string) {
 	if !as.IsReady() {
 		return
 	}
 	// check if the action is already requested
-	_, ok := as.actions.LoadOrStore(hash, &actionMsg{})
+	_, ok := as.actions.LoadOrStore(hash, &actionMsg{announcer: announcer})
 	if ok {
 		log.L().Debug("Action already requested", log.Hex("hash", hash[:]))
 		return
@@ -144,7 +152,7 @@ func (as *ActionSync) sync() {
 			}
 			msg.(*actionMsg).lastTime = time.Now()
 			// TODO: enhancement, request multiple actions in one message
-			if err := as.requestFromNeighbors(ctx, hash); err != nil {
+			if err := as.requestFromNeighbors(ctx, hash, msg.(*actionMsg).announcer); err != nil {
 				log.L().Warn("Failed to request action from neighbors", zap.Error(err))
 				counterMtc.WithLabelValues("failed").Inc()
 			}
@@ -186,7 +194,8 @@ func (as *ActionSync) trigger(hash hash.Hash256) {
 	}
 }
 
-func (as *ActionSync) selectPeers() ([]peer.AddrInfo, error) {
+// selectPeers selects the peers to request an action from, the announcer goes first if it is a neighbor
+func (as *ActionSync) selectPeers(announcer string) ([]peer.AddrInfo, error) {
 	neighbors, err := as.helper.P2PNeighbor()
 	if err != nil {
 		return nil, err
@@ -198,17 +207,25 @@ func (as *ActionSync) selectPeers() ([]peer.AddrInfo, error) {
 	if repeat == 0 {
 		return nil, errors.New("no peers")
 	}
-	peers := make([]peer.AddrInfo, repeat)
-	for i := 0; i < repeat; i++ {
+	peers := make([]peer.AddrInfo, 0, repeat)
+	if announcer != "" {
+		for _, p := range neighbors {
+			if p.ID.String() == announcer {
+				peers = append(peers, p)
+				break
+			}
+		}
+	}
+	for len(peers) < repeat {
 		peer := neighbors[fastrand.Uint32n(uint32(len(neighbors)))]
-		peers[i] = peer
+		peers = append(peers, peer)
 	}
 	return peers, nil
 }
 
-func (as *ActionSync) requestFromNeighbors(ctx context.Context, hash hash.Hash256) error {
+func (as *ActionSync) requestFromNeighbors(ctx context.Context, hash hash.Hash256, announcer string) error {
 	l := log.L().With(log.Hex("hash", hash[:]))
-	neighbors, err := as.selectPeers()
+	neighbors, err := as.selectPeers(announcer)
 	if err != nil {
 		l.Debug("Failed to get neighbors", zap.Error(err))
 		return err
@@ -163,3 +163,27 @@ func TestActionSync(t *testing.T) {
 		wg.Wait()
 	})
 }
+
+func TestActionSyncSelectPeers(t *testing.T) {
+	r := require.New(t)
+	neighbors := []peer.AddrInfo{
+		{ID: peer.ID("peer1")},
+		{ID: peer.ID("peer2")},
+		{ID: peer.ID("peer3")},
+	}
+	as := NewActionSync(DefaultConfig, &Helper{
+		P2PNeighbor: func() ([]peer.AddrInfo, error) {
+			return neighbors, nil
+		},
+	})
+	for i := 0; i < 10; i++ {
+		peers, err := as.selectPeers(neighbors[2].ID.String())
+		r.NoError(err)
+		r.Len(peers, batchPeerSize)
+		// the announcer goes first
+		r.Equal(neighbors[2], peers[0])
+	}
+	peers, err := as.selectPeers(peer.ID("unknown").String())
+	r.NoError(err)
+	r.Len(peers, batchPeerSize)
+}
@@ -3,12 +3,15 @@ package api
 import (
 	"context"
 	"encoding/hex"
+	"reflect"
 
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	batch "github.com/iotexproject/iotex-core/v2/pkg/messagebatcher"
 )
@@ -20,14 +23,29 @@ type ActionRadioOption func(*ActionRadio)
 func WithMessageBatch() ActionRadioOption {
 	return func(ar *ActionRadio) {
 		ar.messageBatcher = batch.NewManager(func(msg *batch.Message) error {
+			if msg.Target != nil {
+				return ar.unicastHandler(context.Background(), *msg.Target, msg.Data)
+			}
 			return ar.broadcastHandler(context.Background(), ar.chainID, msg.Data)
 		})
 	}
 }
 
+// WithGossipPolicy propagates the actions with the gossip policy instead of flooding
+func WithGossipPolicy(policy *p2p.GossipPolicy, connectedPeers ConnectedPeers, unicastHandler UnicastOutbound) ActionRadioOption {
+	return func(ar *ActionRadio) {
+		ar.gossipPolicy = policy
+		ar.connectedPeers = connectedPeers
+		ar.unicastHandler = unicastHandler
+	}
+}
+
 // ActionRadio broadcasts actions to the network
 type ActionRadio struct {
 	broadcastHandler BroadcastOutbound
+	unicastHandler   UnicastOutbound
+	connectedPeers   ConnectedPeers
+	gossipPolicy     *p2p.GossipPolicy
 	messageBatcher   *batch.Manager
 	chainID          uint32
 }
@@ -65,28 +83,70 @@ func (ar *ActionRadio) OnAdded(selp *action.SealedEnvelope) {
 	var (
 		hasSidecar = selp.BlobTxSidecar() != nil
 		hash, _    = selp.Hash()
-		out        proto.Message
+		mode       = ar.gossipMode(selp)
 		err        error
 	)
-	if hasSidecar {
-		out = &iotextypes.ActionHash{
+	switch {
+	case hasSidecar: // TODO: batch blobTx
+		err = ar.broadcastHandler(context.Background(), ar.chainID, &iotextypes.ActionHash{
 			Hash: hash[:],
+		})
+	case mode == p2p.GossipFlood:
+		err = ar.send(nil, selp.Proto())
+	default:
+		err = ar.gossip(selp, mode)
+	}
+	if err != nil {
+		log.L().Warn("Failed to broadcast SendAction request.", zap.Error(err), zap.String("actionHash", hex.EncodeToString(hash[:])))
+	}
+}
+
+func (ar *ActionRadio) gossipMode(selp *action.SealedEnvelope) string {
+	if ar.gossipPolicy == nil {
+		return p2p.GossipFlood
+	}
+	return ar.gossipPolicy.Mode(reflect.Indirect(reflect.ValueOf(selp.Action())).Type().Name())
+}
+
+// gossip sends the action to a sample of the connected peers, and announces the hash of the action in push-pull mode
+func (ar *ActionRadio) gossip(selp *action.SealedEnvelope, mode string) error {
+	peers, err := ar.connectedPeers()
+	if err != nil {
+		return err
+	}
+	act := selp.Proto()
+	for _, p := range ar.gossipPolicy.Sample(peers) {
+		p := p
+		if err := ar.send(&p, act); err != nil {
+			return err
 		}
-	} else {
-		out = selp.Proto()
 	}
-	if ar.messageBatcher != nil && !hasSidecar { // TODO: batch blobTx
-		err = ar.messageBatcher.Put(&batch.Message{
+	if mode != p2p.GossipPushPull {
+		return nil
+	}
+	hash, err := selp.Hash()
+	if err != nil {
+		return err
+	}
+	// the hash is not batched
+	return ar.broadcastHandler(context.Background(), ar.chainID, &iotextypes.ActionHash{
+		Hash: hash[:],
+	})
+}
+
+// send sends the message to the target, or broadcasts it if the target is nil
+func (ar *ActionRadio) send(target *peer.AddrInfo, msg proto.Message) error {
+	if ar.messageBatcher != nil {
+		return ar.messageBatcher.Put(&batch.Message{
 			ChainID: ar.chainID,
-			Target:  nil,
-			Data:    out,
+			Target:  target,
+			Data:    msg,
 		})
-	} else {
-		err = ar.broadcastHandler(context.Background(), ar.chainID, out)
 	}
-	if err != nil {
-		log.L().Warn("Failed to broadcast SendAction request.", zap.Error(err), zap.String("actionHash", hex.EncodeToString(hash[:])))
+	if target != nil {
+		return ar.unicastHandler(context.Background(), *target, msg)
 	}
+	return ar.broadcastHandler(context.Background(), ar.chainID, msg)
 }
 
 // OnRemoved does nothing
@@ -6,10 +6,13 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 )
 
@@ -35,3 +38,55 @@ func TestActionRadio(t *testing.T) {
 	radio.OnAdded(selp)
 	r.Equal(uint64(1), atomic.LoadUint64(&broadcastCount))
 }
+
+func TestActionRadioGossip(t *testing.T) {
+	r := require.New(t)
+	policy, err := p2p.NewGossipPolicy(p2p.GossipConfig{
+		Mode:  p2p.GossipSqrt,
+		Rules: []p2p.GossipRule{{ActionType: "Execution", Mode: p2p.GossipPushPull}},
+	})
+	r.NoError(err)
+	var (
+		broadcasts []proto.Message
+		unicasts   []peer.ID
+		peers      = make([]peer.AddrInfo, 9)
+	)
+	for i := range peers {
+		peers[i].ID = peer.ID(string(rune('a' + i)))
+	}
+	radio := NewActionRadio(
+		func(_ context.Context, _ uint32, msg proto.Message) error {
+			broadcasts = append(broadcasts, msg)
+			return nil
+		},
+		0,
+		WithGossipPolicy(
+			policy,
+			func() ([]peer.AddrInfo, error) { return peers, nil },
+			func(_ context.Context, p peer.AddrInfo, msg proto.Message) error {
+				_, ok := msg.(*iotextypes.Action)
+				r.True(ok)
+				unicasts = append(unicasts, p.ID)
+				return nil
+			},
+		),
+	)
+
+	gas := uint64(100000)
+	gasPrice := big.NewInt(10)
+	tsf, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(1), 1, big.NewInt(1), nil, gas, gasPrice)
+	r.NoError(err)
+	radio.OnAdded(tsf)
+	r.Empty(broadcasts)
+	r.Len(unicasts, 3)
+
+	// the hash of the execution is announced to the whole network
+	exec, err := action.SignedExecution(identityset.Address(2).String(), identityset.PrivateKey(1), 2, big.NewInt(0), gas, gasPrice, nil)
+	r.NoError(err)
+	radio.OnAdded(exec)
+	r.Len(unicasts, 6)
+	r.Len(broadcasts, 1)
+	h, err := exec.Hash()
+	r.NoError(err)
+	r.Equal(h[:], broadcasts[0].(*iotextypes.ActionHash).Hash)
+}
@@ -0,0 +1,359 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/audit"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/recovery"
+)
+
+// _maxAuditLogEntries is the max number of the audit log entries returned in a call
+const _maxAuditLogEntries = 1000
+
+type (
+	// AdminConfig is the config of the admin service. The service listens on localhost only, unless the mutual TLS is
+	// configured, in which case the clients are required to present a certificate signed by the client CA
+	AdminConfig struct {
+		// Port is the port of the admin service, the service is disabled if it is 0
+		Port int `yaml:"port"`
+		// CertFile and KeyFile are the certificate and the private key of the server
+		CertFile string `yaml:"certFile"`
+		KeyFile  string `yaml:"keyFile"`
+		// ClientCAFile is the CA certificate to verify the certificates of the clients
+		ClientCAFile string `yaml:"clientCAFile"`
+	}
+
+	// AdminOperator performs the routine operations of the node
+	AdminOperator interface {
+		// ActivateBlockProduction resumes or pauses the block production
+		ActivateBlockProduction(bool) error
+		// RequestSnapshot requests a snapshot to be taken at the next block
+		RequestSnapshot() error
+		// SetActPoolCapacity sets the max number of actions and the max gas the actpool can hold
+		SetActPoolCapacity(maxNumActs, maxGas uint64) error
+		// BlockPeer blocks the peer in the p2p layer
+		BlockPeer(string) error
+		// SetAPIKeys replaces the api keys of rate limiting with their tiers
+		SetAPIKeys(map[string]string) error
+		// EnableReader registers the read-only protocol built into the node
+		EnableReader(string) error
+		// DisableReader unregisters the read-only protocol
+		DisableReader(string) error
+	}
+
+	// AdminServiceServer is the server API of the admin service
+	AdminServiceServer interface {
+		// SetBlockProduction resumes or pauses the block production by "active"
+		SetBlockProduction(context.Context, *structpb.Struct) (*structpb.Struct, error)
+		// RequestSnapshot requests a snapshot to be taken at the next block
+		RequestSnapshot(context.Context, *structpb.Struct) (*structpb.Struct, error)
+		// SetActPoolCapacity sets the capacity of the actpool by "maxNumActs" and "maxGas"
+		SetActPoolCapacity(context.Context, *structpb.Struct) (*structpb.Struct, error)
+		// BlockPeer blocks the peer of "peer" id
+		BlockPeer(context.Context, *structpb.Struct) (*structpb.Struct, error)
+		// SetAPIKeys replaces the api keys by "apiKeys", which maps the keys to their tiers
+		SetAPIKeys(context.Context, *structpb.Struct) (*structpb.Struct, error)
+		// GetAuditLog returns the "count" entries of the audit log from the sequence number "start"
+		GetAuditLog(context.Context, *structpb.Struct) (*structpb.Struct, error)
+		// EnableReader registers the read-only protocol of "id" built into the node
+		EnableReader(context.Context, *structpb.Struct) (*structpb.Struct, error)
+		// DisableReader unregisters the read-only protocol of "id"
+		DisableReader(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	}
+
+	// AdminServer is the grpc server of the admin service
+	AdminServer struct {
+		addr string
+		svr  *grpc.Server
+	}
+
+	adminService struct {
+		op AdminOperator
+	}
+)
+
+// AdminServiceDesc is the grpc service descriptor of the admin service. The service is described with the well-known
+// types, so that the operators can call it without a dedicated proto, e.g.,
+// grpcurl -plaintext -d '{"active":false}' localhost:14015 iotexcore.AdminService/SetBlockProduction
+var AdminServiceDesc = grpc.ServiceDesc{
+	ServiceName: "iotexcore.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetBlockProduction",
+			Handler:    adminServiceHandler("SetBlockProduction", AdminServiceServer.SetBlockProduction),
+		},
+		{
+			MethodName: "RequestSnapshot",
+			Handler:    adminServiceHandler("RequestSnapshot", AdminServiceServer.RequestSnapshot),
+		},
+		{
+			MethodName: "SetActPoolCapacity",
+			Handler:    adminServiceHandler("SetActPoolCapacity", AdminServiceServer.SetActPoolCapacity),
+		},
+		{
+			MethodName: "BlockPeer",
+			Handler:    adminServiceHandler("BlockPeer", AdminServiceServer.BlockPeer),
+		},
+		{
+			MethodName: "SetAPIKeys",
+			Handler:    adminServiceHandler("SetAPIKeys", AdminServiceServer.SetAPIKeys),
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    adminServiceHandler("GetAuditLog", AdminServiceServer.GetAuditLog),
+		},
+		{
+			MethodName: "EnableReader",
+			Handler:    adminServiceHandler("EnableReader", AdminServiceServer.EnableReader),
+		},
+		{
+			MethodName: "DisableReader",
+			Handler:    adminServiceHandler("DisableReader", AdminServiceServer.DisableReader),
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "adminservice",
+}
+
+// NewAdminServer creates the admin server, it returns nil if the admin service is disabled
+func NewAdminServer(cfg AdminConfig, op AdminOperator) (*AdminServer, error) {
+	if cfg.Port == 0 || op == nil {
+		return nil, nil
+	}
+	host := "127.0.0.1"
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(adminLogInterceptor),
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" || cfg.ClientCAFile != "" {
+		tlsCfg, err := cfg.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		host = ""
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+	svr := grpc.NewServer(opts...)
+	svr.RegisterService(&AdminServiceDesc, &adminService{op: op})
+	return &AdminServer{
+		addr: net.JoinHostPort(host, strconv.Itoa(cfg.Port)),
+		svr:  svr,
+	}, nil
+}
+
+func (cfg *AdminConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" || cfg.ClientCAFile == "" {
+		return nil, errors.New("certificate, key and client CA are all required for the mutual TLS of admin service")
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the certificate of admin service")
+	}
+	ca, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the client CA of admin service")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("invalid client CA of admin service")
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// Start starts the admin server
+func (svr *AdminServer) Start(_ context.Context) error {
+	lis, err := net.Listen("tcp", svr.addr)
+	if err != nil {
+		return errors.Wrap(err, "admin server failed to listen")
+	}
+	log.L().Info("admin server is listening.", zap.String("addr", lis.Addr().String()))
+	go func() {
+		defer recovery.Recover()
+		if err := svr.svr.Serve(lis); err != nil {
+			log.L().Error("admin server failed to serve.", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Stop stops the admin server
+func (svr *AdminServer) Stop(_ context.Context) error {
+	svr.svr.Stop()
+	return nil
+}
+
+// SetBlockProduction resumes or pauses the block production by "active"
+func (service *adminService) SetBlockProduction(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	v, ok := in.GetFields()["active"]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "active is required")
+	}
+	active := v.GetBoolValue()
+	if err := service.op.ActivateBlockProduction(active); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return toStruct(map[string]any{"active": active})
+}
+
+// RequestSnapshot requests a snapshot to be taken at the next block
+func (service *adminService) RequestSnapshot(context.Context, *structpb.Struct) (*structpb.Struct, error) {
+	if err := service.op.RequestSnapshot(); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &structpb.Struct{}, nil
+}
+
+// SetActPoolCapacity sets the capacity of the actpool by "maxNumActs" and "maxGas"
+func (service *adminService) SetActPoolCapacity(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	fields := in.GetFields()
+	maxNumActs, maxGas := fields["maxNumActs"].GetNumberValue(), fields["maxGas"].GetNumberValue()
+	if maxNumActs < 1 || maxGas < 1 {
+		return nil, status.Error(codes.InvalidArgument, "maxNumActs and maxGas should be positive")
+	}
+	if err := service.op.SetActPoolCapacity(uint64(maxNumActs), uint64(maxGas)); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return toStruct(map[string]any{"maxNumActs": uint64(maxNumActs), "maxGas": uint64(maxGas)})
+}
+
+// BlockPeer blocks the peer of "peer" id
+func (service *adminService) BlockPeer(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	id := in.GetFields()["peer"].GetStringValue()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "peer is required")
+	}
+	if err := service.op.BlockPeer(id); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &structpb.Struct{}, nil
+}
+
+// SetAPIKeys replaces the api keys by "apiKeys", which maps the keys to their tiers
+func (service *adminService) SetAPIKeys(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	keys := make(map[string]string)
+	for key, tier := range in.GetFields()["apiKeys"].GetStructValue().GetFields() {
+		keys[key] = tier.GetStringValue()
+	}
+	if err := service.op.SetAPIKeys(keys); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return toStruct(map[string]any{"numAPIKeys": len(keys)})
+}
+
+// GetAuditLog returns the "count" entries of the audit log from the sequence number "start"
+func (service *adminService) GetAuditLog(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	l := audit.Global()
+	if l == nil {
+		return nil, status.Error(codes.FailedPrecondition, "audit log is not enabled")
+	}
+	fields := in.GetFields()
+	start, count := uint64(fields["start"].GetNumberValue()), uint64(fields["count"].GetNumberValue())
+	if count == 0 || count > _maxAuditLogEntries {
+		count = _maxAuditLogEntries
+	}
+	entries, err := l.Entries(start, count)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toStruct(map[string]any{"entries": entries})
+}
+
+// EnableReader registers the read-only protocol of "id" built into the node
+func (service *adminService) EnableReader(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	id := in.GetFields()["id"].GetStringValue()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if err := service.op.EnableReader(id); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &structpb.Struct{}, nil
+}
+
+// DisableReader unregisters the read-only protocol of "id"
+func (service *adminService) DisableReader(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	id := in.GetFields()["id"].GetStringValue()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if err := service.op.DisableReader(id); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &structpb.Struct{}, nil
+}
+
+// adminLogInterceptor logs and audits the admin operations along with the callers, reading the audit log is not
+// audited
+func adminLogInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	caller := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		caller = p.Addr.String()
+	}
+	res, err := handler(ctx, req)
+	log.L().Info("admin operation.", zap.String("method", info.FullMethod), zap.String("caller", caller), zap.Error(err))
+	if info.FullMethod != "/iotexcore.AdminService/GetAuditLog" {
+		details := map[string]string{
+			"method": info.FullMethod,
+			"caller": caller,
+		}
+		// the api keys are secrets, which are not recorded
+		if in, ok := req.(*structpb.Struct); ok && info.FullMethod != "/iotexcore.AdminService/SetAPIKeys" {
+			if data, err := in.MarshalJSON(); err == nil {
+				details["request"] = string(data)
+			}
+		}
+		if err != nil {
+			details["error"] = err.Error()
+		}
+		audit.Record(audit.OpAdmin, details)
+	}
+	return res, err
+}
+
+func adminServiceHandler(
+	method string,
+	call func(AdminServiceServer, context.Context, *structpb.Struct) (*structpb.Struct, error),
+) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		in := new(structpb.Struct)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(srv.(AdminServiceServer), ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: "/iotexcore.AdminService/" + method,
+		}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return call(srv.(AdminServiceServer), ctx, req.(*structpb.Struct))
+		}
+		return interceptor(ctx, in, info, handler)
+	}
+}
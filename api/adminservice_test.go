@@ -0,0 +1,167 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/audit"
+)
+
+type testAdminOperator struct {
+	active      bool
+	snapshot    bool
+	maxNumActs  uint64
+	maxGas      uint64
+	blockedPeer string
+	apiKeys     map[string]string
+	readers     map[string]bool
+}
+
+func (op *testAdminOperator) ActivateBlockProduction(active bool) error {
+	op.active = active
+	return nil
+}
+
+func (op *testAdminOperator) RequestSnapshot() error {
+	op.snapshot = true
+	return nil
+}
+
+func (op *testAdminOperator) SetActPoolCapacity(maxNumActs, maxGas uint64) error {
+	op.maxNumActs, op.maxGas = maxNumActs, maxGas
+	return nil
+}
+
+func (op *testAdminOperator) BlockPeer(id string) error {
+	op.blockedPeer = id
+	return nil
+}
+
+func (op *testAdminOperator) SetAPIKeys(keys map[string]string) error {
+	if len(keys) == 0 {
+		return errors.New("no api key")
+	}
+	op.apiKeys = keys
+	return nil
+}
+
+func (op *testAdminOperator) EnableReader(id string) error {
+	if op.readers[id] {
+		return errors.Errorf("reader %s is already registered", id)
+	}
+	op.readers[id] = true
+	return nil
+}
+
+func (op *testAdminOperator) DisableReader(id string) error {
+	if !op.readers[id] {
+		return errors.Errorf("reader %s is not registered", id)
+	}
+	delete(op.readers, id)
+	return nil
+}
+
+func TestAdminService(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	op := &testAdminOperator{active: true, readers: map[string]bool{}}
+	service := &adminService{op: op}
+	newStruct := func(v map[string]any) *structpb.Struct {
+		s, err := structpb.NewStruct(v)
+		require.NoError(err)
+		return s
+	}
+
+	_, err := service.SetBlockProduction(ctx, newStruct(nil))
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	res, err := service.SetBlockProduction(ctx, newStruct(map[string]any{"active": false}))
+	require.NoError(err)
+	require.False(res.GetFields()["active"].GetBoolValue())
+	require.False(op.active)
+
+	_, err = service.RequestSnapshot(ctx, newStruct(nil))
+	require.NoError(err)
+	require.True(op.snapshot)
+
+	_, err = service.SetActPoolCapacity(ctx, newStruct(map[string]any{"maxNumActs": 100}))
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = service.SetActPoolCapacity(ctx, newStruct(map[string]any{"maxNumActs": 100, "maxGas": 2000000}))
+	require.NoError(err)
+	require.Equal(uint64(100), op.maxNumActs)
+	require.Equal(uint64(2000000), op.maxGas)
+
+	_, err = service.BlockPeer(ctx, newStruct(nil))
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = service.BlockPeer(ctx, newStruct(map[string]any{"peer": "12D3KooW"}))
+	require.NoError(err)
+	require.Equal("12D3KooW", op.blockedPeer)
+
+	_, err = service.SetAPIKeys(ctx, newStruct(nil))
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = service.SetAPIKeys(ctx, newStruct(map[string]any{"apiKeys": map[string]any{"key2": "pro"}}))
+	require.NoError(err)
+	require.Equal(map[string]string{"key2": "pro"}, op.apiKeys)
+
+	_, err = service.EnableReader(ctx, newStruct(nil))
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = service.EnableReader(ctx, newStruct(map[string]any{"id": "analytics"}))
+	require.NoError(err)
+	require.True(op.readers["analytics"])
+	_, err = service.EnableReader(ctx, newStruct(map[string]any{"id": "analytics"}))
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = service.DisableReader(ctx, newStruct(nil))
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = service.DisableReader(ctx, newStruct(map[string]any{"id": "analytics"}))
+	require.NoError(err)
+	require.False(op.readers["analytics"])
+	_, err = service.DisableReader(ctx, newStruct(map[string]any{"id": "analytics"}))
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// audit log
+	_, err = service.GetAuditLog(ctx, newStruct(nil))
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	l, err := audit.NewLog(filepath.Join(t.TempDir(), "audit.log"))
+	require.NoError(err)
+	defer l.Close()
+	audit.SetGlobal(l)
+	defer audit.SetGlobal(nil)
+	in := newStruct(map[string]any{"peer": "12D3KooW"})
+	_, err = adminLogInterceptor(ctx, in, &grpc.UnaryServerInfo{FullMethod: "/iotexcore.AdminService/BlockPeer"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return service.BlockPeer(ctx, req.(*structpb.Struct))
+	})
+	require.NoError(err)
+	res, err = service.GetAuditLog(ctx, newStruct(map[string]any{"start": 1, "count": 10}))
+	require.NoError(err)
+	entries := res.GetFields()["entries"].GetListValue().GetValues()
+	require.Len(entries, 1)
+	details := entries[0].GetStructValue().GetFields()["details"].GetStructValue().GetFields()
+	require.Equal("/iotexcore.AdminService/BlockPeer", details["method"].GetStringValue())
+	require.Contains(details["request"].GetStringValue(), "12D3KooW")
+}
+
+func TestNewAdminServer(t *testing.T) {
+	require := require.New(t)
+	svr, err := NewAdminServer(AdminConfig{}, &testAdminOperator{})
+	require.NoError(err)
+	require.Nil(svr)
+
+	_, err = NewAdminServer(AdminConfig{Port: 14015, CertFile: "server.crt"}, &testAdminOperator{})
+	require.ErrorContains(err, "all required")
+
+	svr, err = NewAdminServer(AdminConfig{Port: 14015}, &testAdminOperator{})
+	require.NoError(err)
+	require.Equal("127.0.0.1:14015", svr.addr)
+}
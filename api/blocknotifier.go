@@ -0,0 +1,43 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"sync"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+// blockNotifier notifies the new blocks without blocking the chain listener, the notifications of blocks arriving
+// before the previous one is consumed are merged into one
+type blockNotifier struct {
+	notify chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newBlockNotifier() *blockNotifier {
+	return &blockNotifier{
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// Respond to new block
+func (bn *blockNotifier) Respond(_ string, _ *block.Block) error {
+	select {
+	case bn.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Exit closes the done channel
+func (bn *blockNotifier) Exit() {
+	bn.once.Do(func() {
+		close(bn.done)
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+type web3BucketEventListener struct {
+	streamHandle streamHandler
+}
+
+// NewWeb3BucketEventListener returns a new websocket staking bucket event listener
+func NewWeb3BucketEventListener(handler streamHandler) apitypes.Responder {
+	return &web3BucketEventListener{
+		streamHandle: handler,
+	}
+}
+
+// Respond to new block
+func (bl *web3BucketEventListener) Respond(id string, blk *block.Block) error {
+	blkHash := blk.HashBlock()
+	for _, receipt := range blk.Receipts {
+		for _, evt := range staking.BucketEventsFromReceipt(receipt) {
+			res := &streamResponse{
+				id: id,
+				result: &bucketEventResult{
+					blockHash: blkHash,
+					event:     evt,
+				},
+			}
+			if _, err := bl.streamHandle(res); err != nil {
+				log.L().Info(
+					"Error when streaming the bucket event",
+					zap.Uint64("height", blk.Height()),
+					zap.Error(err),
+				)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Exit send to error channel
+func (bl *web3BucketEventListener) Exit() {}
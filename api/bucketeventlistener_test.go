@@ -0,0 +1,67 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestWeb3BucketEventListener(t *testing.T) {
+	require := require.New(t)
+
+	unstake := &action.Log{
+		Address: staking.ProtocolAddr().String(),
+		Topics: action.Topics{
+			hash.BytesToHash256([]byte(staking.HandleUnstake)),
+			hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(7)),
+			hash.BytesToHash256(identityset.Address(1).Bytes()),
+		},
+		BlockHeight: 1,
+	}
+	receipts := []*action.Receipt{
+		(&action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success), BlockHeight: 1}).AddLogs(unstake),
+		// the receipt of failed action is skipped
+		(&action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Failure), BlockHeight: 1}).AddLogs(unstake),
+	}
+	testBlock, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(time.Now()).
+		SetReceipts(receipts).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+
+	var results []*bucketEventResult
+	listener := NewWeb3BucketEventListener(func(in interface{}) (int, error) {
+		res := in.(*streamResponse)
+		require.Equal("0x1", res.id)
+		results = append(results, res.result.(*bucketEventResult))
+		return 0, nil
+	})
+	require.NoError(listener.Respond("0x1", &testBlock))
+	require.Len(results, 1)
+	require.Equal(testBlock.HashBlock(), results[0].blockHash)
+	evt := results[0].event
+	require.Equal(staking.HandleUnstake, evt.Type)
+	require.EqualValues(7, evt.BucketIndex)
+	require.Equal(identityset.Address(1).String(), evt.Candidate.String())
+
+	// the error of handler is returned
+	listener = NewWeb3BucketEventListener(func(interface{}) (int, error) {
+		return 0, errorSend
+	})
+	require.Equal(errorSend, listener.Respond("0x1", &testBlock))
+}
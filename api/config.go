@@ -25,25 +25,65 @@ type Config struct {
 	Tracer          tracer.Config     `yaml:"tracer"`
 	// BatchRequestLimit is the maximum number of requests in a batch.
 	BatchRequestLimit int `yaml:"batchRequestLimit"`
+	// BatchRequestConcurrency is the maximum number of requests in a batch handled concurrently.
+	BatchRequestConcurrency int `yaml:"batchRequestConcurrency"`
 	// WebsocketRateLimit is the maximum number of messages per second per client.
 	WebsocketRateLimit int `yaml:"websocketRateLimit"`
 	// ListenerLimit is the maximum number of listeners.
 	ListenerLimit int `yaml:"listenerLimit"`
 	// ReadyDuration is the duration to wait for the server to be ready.
 	ReadyDuration time.Duration `yaml:"readyDuration"`
+	// RateLimit is the config of rate limiting the calls by ip and api key
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+	// ResponseCache is the config of the cache of hot read responses
+	ResponseCache ResponseCacheConfig `yaml:"responseCache"`
+	// JSTracer is the config of the javascript tracers in debug tracing
+	JSTracer JSTracerConfig `yaml:"jsTracer"`
+	// Admin is the config of the admin service for the routine operations of the node
+	Admin AdminConfig `yaml:"admin"`
+	// MetaTxRelay is the config of relaying the EIP-2771 meta transactions
+	MetaTxRelay MetaTxRelayConfig `yaml:"metaTxRelay"`
 }
 
 // DefaultConfig is the default config
 var DefaultConfig = Config{
-	UseRDS:             false,
-	GRPCPort:           14014,
-	HTTPPort:           15014,
-	WebSocketPort:      16014,
-	TpsWindow:          10,
-	GasStation:         gasstation.DefaultConfig,
-	RangeQueryLimit:    1000,
-	BatchRequestLimit:  _defaultBatchRequestLimit,
-	WebsocketRateLimit: 5,
-	ListenerLimit:      5000,
-	ReadyDuration:      time.Second * 30,
+	UseRDS:                  false,
+	GRPCPort:                14014,
+	HTTPPort:                15014,
+	WebSocketPort:           16014,
+	TpsWindow:               10,
+	GasStation:              gasstation.DefaultConfig,
+	RangeQueryLimit:         1000,
+	BatchRequestLimit:       _defaultBatchRequestLimit,
+	BatchRequestConcurrency: _defaultBatchRequestConcurrency,
+	WebsocketRateLimit:      5,
+	ListenerLimit:           5000,
+	ReadyDuration:           time.Second * 30,
+	RateLimit: RateLimitConfig{
+		Enabled:        false,
+		RateLimitQuota: RateLimitQuota{Rate: 50, Burst: 100},
+		APIKeyHeader:   "X-API-Key",
+		Tiers:          map[string]RateLimitQuota{},
+		APIKeys:        map[string]string{},
+		MethodWeights:  map[string]int{},
+		ClientTTL:      10 * time.Minute,
+	},
+	ResponseCache: ResponseCacheConfig{
+		Size: 10000,
+		TTL:  time.Hour,
+	},
+	JSTracer: JSTracerConfig{
+		Enabled:     true,
+		MaxCodeSize: 64 * 1024,
+		Timeout:     time.Minute,
+		MemoryLimit: 256 * 1024 * 1024,
+	},
+	MetaTxRelay: MetaTxRelayConfig{
+		ForwarderName:    "ERC2771Forwarder",
+		ForwarderVersion: "1",
+		MaxGas:           1000000,
+		AllowedTargets:   []string{},
+		SenderQuota:      RateLimitQuota{Rate: 0.2, Burst: 5},
+		SenderTTL:        10 * time.Minute,
+	},
 }
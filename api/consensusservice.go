@@ -0,0 +1,73 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ConsensusServiceServer is the server API of the consensus introspection service
+type ConsensusServiceServer interface {
+	// ReadConsensusState returns the state of the consensus round in progress
+	ReadConsensusState(context.Context, *emptypb.Empty) (*structpb.Struct, error)
+}
+
+// ConsensusServiceDesc is the grpc service descriptor of the consensus introspection service. The service is described
+// with the well-known types, so that the clients can call it without a dedicated proto, e.g.,
+// grpcurl -plaintext localhost:14014 iotexcore.ConsensusService/ReadConsensusState
+var ConsensusServiceDesc = grpc.ServiceDesc{
+	ServiceName: "iotexcore.ConsensusService",
+	HandlerType: (*ConsensusServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReadConsensusState",
+			Handler:    readConsensusStateHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consensusservice",
+}
+
+type consensusService struct {
+	core CoreService
+}
+
+func newConsensusService(core CoreService) *consensusService {
+	return &consensusService{
+		core: core,
+	}
+}
+
+// ReadConsensusState returns the state of the consensus round in progress
+func (service *consensusService) ReadConsensusState(context.Context, *emptypb.Empty) (*structpb.Struct, error) {
+	state, err := service.core.ReadConsensusState()
+	if err != nil {
+		return nil, err
+	}
+	return toStruct(state)
+}
+
+func readConsensusStateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsensusServiceServer).ReadConsensusState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexcore.ConsensusService/ReadConsensusState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsensusServiceServer).ReadConsensusState(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
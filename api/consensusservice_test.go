@@ -0,0 +1,64 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
+)
+
+func TestConsensusService(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	service := newConsensusService(core)
+	start := time.Unix(1700000000, 0).UTC()
+
+	core.EXPECT().ReadConsensusState().Return(&scheme.ConsensusState{
+		Height:             100,
+		Round:              2,
+		Epoch:              5,
+		FSMState:           "S_ACCEPT_LOCK_ENDORSEMENT",
+		Proposer:           "io1proposer",
+		RoundStartTime:     start,
+		NextRoundStartTime: start.Add(5 * time.Second),
+		NumDelegates:       24,
+		Endorsements: []scheme.BlockEndorsements{
+			{BlockHash: "abcd", Proposals: 17, Locks: 3},
+		},
+		PendingEvents: 1,
+		Timers: []scheme.ConsensusTimer{
+			{Event: "E_STOP_RECEIVING_LOCK_ENDORSEMENT", Height: 100, Round: 2, Deadline: start.Add(3 * time.Second)},
+		},
+	}, nil).Times(1)
+	res, err := service.ReadConsensusState(context.Background(), &emptypb.Empty{})
+	require.NoError(err)
+	fields := res.GetFields()
+	require.Equal(float64(100), fields["height"].GetNumberValue())
+	require.Equal(float64(2), fields["round"].GetNumberValue())
+	require.Equal("S_ACCEPT_LOCK_ENDORSEMENT", fields["fsmState"].GetStringValue())
+	require.Equal("2023-11-14T22:13:20Z", fields["roundStartTime"].GetStringValue())
+	require.NotContains(fields, "blockInLock")
+	endorsements := fields["endorsements"].GetListValue().GetValues()
+	require.Len(endorsements, 1)
+	require.Equal(float64(17), endorsements[0].GetStructValue().GetFields()["proposals"].GetNumberValue())
+	timers := fields["timers"].GetListValue().GetValues()
+	require.Len(timers, 1)
+	require.Equal("E_STOP_RECEIVING_LOCK_ENDORSEMENT", timers[0].GetStructValue().GetFields()["event"].GetStringValue())
+
+	core.EXPECT().ReadConsensusState().Return(nil, status.Error(codes.Unavailable, "consensus state is not available")).Times(1)
+	_, err = service.ReadConsensusState(context.Background(), &emptypb.Empty{})
+	require.Equal(codes.Unavailable, status.Code(err))
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"reflect"
 	"strconv"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
 
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -60,8 +62,10 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/blocksync"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/gasstation"
+	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/tracer"
 	"github.com/iotexproject/iotex-core/v2/pkg/unit"
@@ -91,14 +95,22 @@ type (
 		ServerMeta() (packageVersion string, packageCommitID string, gitStatus string, goVersion string, buildTime string)
 		// SendAction is the API to send an action to blockchain.
 		SendAction(ctx context.Context, in *iotextypes.Action) (string, error)
+		// RelayMetaTransaction sends the meta transaction through the trusted forwarder, the gas fee is paid by the relayer
+		RelayMetaTransaction(ctx context.Context, tx *MetaTransaction) (string, error)
 		// ReadContract reads the state in a contract address specified by the slot
-		ReadContract(ctx context.Context, callerAddr address.Address, sc action.Envelope) (string, *iotextypes.Receipt, error)
+		ReadContract(ctx context.Context, callerAddr address.Address, sc action.Envelope, opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error)
 		// ReadState reads state on blockchain
 		ReadState(protocolID string, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error)
 		// SuggestGasPrice suggests gas price
 		SuggestGasPrice() (uint64, error)
 		// SuggestGasTipCap suggests gas tip cap
 		SuggestGasTipCap() (*big.Int, error)
+		// SuggestFees suggests the fees at the slow, standard and fast levels
+		SuggestFees(ctx context.Context) (*gasstation.FeeSuggestion, error)
+		// GasTable returns the intrinsic gas table at the height, or at the next block if height is 0
+		GasTable(height uint64) *action.GasTable
+		// ReadConsensusState returns the state of the consensus round in progress
+		ReadConsensusState() (*scheme.ConsensusState, error)
 		// FeeHistory returns the fee history
 		FeeHistory(ctx context.Context, blocks, lastBlock uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, []*big.Int, []float64, error)
 		// EstimateGasForAction estimates gas for action
@@ -133,6 +145,12 @@ type (
 		PendingActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, error)
 		// ActionsInActPool returns the all Transaction Identifiers in the actpool
 		ActionsInActPool(actHashes []string) ([]*action.SealedEnvelope, error)
+		// AccessSetInActPool returns the access set of the action in actpool
+		AccessSetInActPool(h hash.Hash256) (*actpool.AccessSet, error)
+		// ActPoolContent returns the pending and the queued actions in actpool by sender address
+		ActPoolContent() (map[string]*actpool.Content, error)
+		// NonceGaps returns the nonce diagnostics of the sender in actpool
+		NonceGaps(addr address.Address) (*actpool.NonceGaps, error)
 		// BlockByHeightRange returns blocks within the height range
 		BlockByHeightRange(uint64, uint64) ([]*apitypes.BlockWithReceipts, error)
 		// BlockByHeight returns the block and its receipt from block height
@@ -159,12 +177,44 @@ type (
 		ChainID() uint32
 		// ReadContractStorage reads contract's storage
 		ReadContractStorage(ctx context.Context, addr address.Address, key []byte) ([]byte, error)
+		// StateProof returns the merkle proofs of an account and its contract storage slots
+		StateProof(addr address.Address, keys []hash.Hash256) (*AccountProof, error)
+		// Preimage returns the namespace or the key in the state trie whose hash is the given hash
+		Preimage(h hash.Hash160) ([]byte, error)
 		// ChainListener returns the instance of Listener
 		ChainListener() apitypes.Listener
+		// StreamActionsByAddress streams the actions of an address from the cursor, first the indexed ones and then
+		// those of the new blocks, until ctx is done or handler fails
+		StreamActionsByAddress(ctx context.Context, addr address.Address, cursor uint64, handler func(*iotexapi.ActionInfo, uint64) error) error
 		// SimulateExecution simulates execution
 		SimulateExecution(context.Context, address.Address, action.Envelope) ([]byte, *action.Receipt, error)
+		// SimulateBlocks simulates the calls in a sequence of blocks on top of the state at the height, or on top of
+		// the tip if height is 0
+		SimulateBlocks(ctx context.Context, height uint64, blocks []*apitypes.SimulateBlock) ([]*apitypes.SimulatedBlock, error)
+		// DryRunNextEpoch calculates the delegates and probation list of the next epoch against the current state
+		DryRunNextEpoch(ctx context.Context) (*poll.EpochDryRun, error)
+		// BalanceAt returns the balance of an account after the block at the height from the balance history index
+		BalanceAt(addr address.Address, height uint64) (*big.Int, error)
+		// TransferHistory returns the total number of the transfers of an account, and its transfers [start, start+count)
+		TransferHistory(addr address.Address, start uint64, count uint64) (uint64, []*blockindex.BalanceTransfer, error)
+		// TokenBalances returns the balances of the XRC20 and XRC721 tokens held by an account
+		TokenBalances(holder address.Address) ([]*blockindex.TokenBalance, error)
+		// TokenHolders returns the total number of the holders of a token contract, and its holders [start, start+count)
+		TokenHolders(contract address.Address, start uint64, count uint64) (uint64, []*blockindex.TokenBalance, error)
+		// TokenTransfers returns the total number of the token transfers of an account, or of a token contract if
+		// byContract is true, and the transfers [start, start+count)
+		TokenTransfers(addr address.Address, byContract bool, start uint64, count uint64) (uint64, []*blockindex.TokenTransfer, error)
+		// EpochPerformance returns the performance of the delegates in the epoch
+		EpochPerformance(epoch uint64) ([]*blockindex.DelegatePerformance, error)
+		// DelegatePerformance returns the performance of the delegate in the epochs [start, start+count)
+		DelegatePerformance(delegate address.Address, start uint64, count uint64) ([]*blockindex.DelegatePerformance, error)
+		// FinalityProof returns the endorsements committing the block at the height, and the proof of the delegates of
+		// its epoch
+		FinalityProof(height uint64) (*FinalityProof, error)
 		// SyncingProgress returns the syncing status of node
 		SyncingProgress() (uint64, uint64, uint64)
+		// SyncingStages returns the heights of the syncing stages of node
+		SyncingStages() []*apitypes.SyncStage
 		// TipHeight returns the tip of the chain
 		TipHeight() uint64
 		// PendingNonce returns the pending nonce of an account
@@ -192,6 +242,24 @@ type (
 		BlobSidecarsByHeight(height uint64) ([]*apitypes.BlobSidecarResult, error)
 	}
 
+	// StorageProof is the merkle proof of a slot in the storage trie of a contract
+	StorageProof struct {
+		Key   hash.Hash256
+		Value []byte
+		Proof [][]byte
+	}
+
+	// AccountProof is the merkle proof of an account in the state trie, and the proofs of its contract storage slots
+	AccountProof struct {
+		Address       address.Address
+		Account       *state.Account
+		Nonce         uint64
+		StateRoot     []byte
+		Proof         [][]byte
+		StorageRoot   []byte
+		StorageProofs []*StorageProof
+	}
+
 	// coreService implements the CoreService interface
 	coreService struct {
 		bc                blockchain.Blockchain
@@ -209,9 +277,29 @@ type (
 		chainListener     apitypes.Listener
 		electionCommittee committee.Committee
 		readCache         *ReadCache
+		responseCache     *responseCache
 		actionRadio       *ActionRadio
+		actionRadioOpts   []ActionRadioOption
 		apiStats          *nodestats.APILocalStats
 		getBlockTime      evm.GetBlockTime
+		consensusState    ConsensusStateReader
+		balanceIndexer    blockindex.BalanceIndexer
+		tokenIndexer      blockindex.TokenIndexer
+		perfIndexer       blockindex.PerformanceIndexer
+		metaTxRelayer     *metaTxRelayer
+	}
+
+	// chainMetaResponse is the cached response of ChainMeta
+	chainMetaResponse struct {
+		chainMeta  *iotextypes.ChainMeta
+		syncStatus string
+	}
+
+	// actionResponse is the cached response of ActionByActionHash
+	actionResponse struct {
+		selp  *action.SealedEnvelope
+		blk   *block.Block
+		index uint32
 	}
 
 	// jobDesc provides a struct to get and store logs in core.LogsInRange
@@ -234,6 +322,19 @@ func WithBroadcastOutbound(broadcastHandler BroadcastOutbound) Option {
 	}
 }
 
+// UnicastOutbound sends a unicast message to the peer
+type UnicastOutbound func(ctx context.Context, peer peer.AddrInfo, msg proto.Message) error
+
+// ConnectedPeers returns the connected peers
+type ConnectedPeers func() ([]peer.AddrInfo, error)
+
+// WithActionGossip is the option to propagate actions with the gossip policy
+func WithActionGossip(policy *p2p.GossipPolicy, connectedPeers ConnectedPeers, unicastHandler UnicastOutbound) Option {
+	return func(svr *coreService) {
+		svr.actionRadioOpts = append(svr.actionRadioOpts, WithGossipPolicy(policy, connectedPeers, unicastHandler))
+	}
+}
+
 // WithNativeElection is the option to return native election data through API.
 func WithNativeElection(committee committee.Committee) Option {
 	return func(svr *coreService) {
@@ -248,6 +349,37 @@ func WithAPIStats(stats *nodestats.APILocalStats) Option {
 	}
 }
 
+// ConsensusStateReader returns the state of the consensus round in progress
+type ConsensusStateReader func() (scheme.ConsensusState, error)
+
+// WithConsensusState is the option to return the consensus state through API
+func WithConsensusState(reader ConsensusStateReader) Option {
+	return func(svr *coreService) {
+		svr.consensusState = reader
+	}
+}
+
+// WithBalanceIndexer is the option to serve the balance history and the transfers of accounts
+func WithBalanceIndexer(indexer blockindex.BalanceIndexer) Option {
+	return func(svr *coreService) {
+		svr.balanceIndexer = indexer
+	}
+}
+
+// WithTokenIndexer is the option to serve the balances, the holders and the transfers of XRC20 and XRC721 tokens
+func WithTokenIndexer(indexer blockindex.TokenIndexer) Option {
+	return func(svr *coreService) {
+		svr.tokenIndexer = indexer
+	}
+}
+
+// WithPerformanceIndexer is the option to serve the performance of the delegates in each epoch
+func WithPerformanceIndexer(indexer blockindex.PerformanceIndexer) Option {
+	return func(svr *coreService) {
+		svr.perfIndexer = indexer
+	}
+}
+
 // WithArchiveSupport is the option to enable archive support
 func WithArchiveSupport() Option {
 	return func(svr *coreService) {
@@ -278,7 +410,7 @@ func newCoreService(
 	getBlockTime evm.GetBlockTime,
 	opts ...Option,
 ) (CoreService, error) {
-	if cfg == (Config{}) {
+	if reflect.DeepEqual(cfg, Config{}) {
 		log.L().Warn("API server is not configured.")
 		cfg = DefaultConfig
 	}
@@ -300,6 +432,7 @@ func newCoreService(
 		chainListener: NewChainListener(cfg.ListenerLimit),
 		gs:            gasstation.NewGasStation(chain, dao, cfg.GasStation),
 		readCache:     NewReadCache(),
+		responseCache: newResponseCache(cfg.ResponseCache),
 		getBlockTime:  getBlockTime,
 	}
 
@@ -307,8 +440,16 @@ func newCoreService(
 		opt(&core)
 	}
 
+	if cfg.MetaTxRelay.enabled() {
+		relayer, err := newMetaTxRelayer(cfg.MetaTxRelay)
+		if err != nil {
+			return nil, err
+		}
+		core.metaTxRelayer = relayer
+	}
+
 	if core.broadcastHandler != nil {
-		core.actionRadio = NewActionRadio(core.broadcastHandler, core.bc.ChainID(), WithMessageBatch())
+		core.actionRadio = NewActionRadio(core.broadcastHandler, core.bc.ChainID(), append(core.actionRadioOpts, WithMessageBatch())...)
 		actPool.AddSubscriber(core.actionRadio)
 	}
 
@@ -383,6 +524,20 @@ func (core *coreService) acccount(ctx context.Context, height uint64, state *sta
 // ChainMeta returns blockchain metadata
 func (core *coreService) ChainMeta() (*iotextypes.ChainMeta, string, error) {
 	tipHeight := core.bc.TipHeight()
+	res, err := cachedResponse(core.responseCache, true, "ChainMeta", tipHeight, cloneChainMeta, func() (*chainMetaResponse, error) {
+		chainMeta, syncStatus, err := core.chainMeta(tipHeight)
+		if err != nil {
+			return nil, err
+		}
+		return &chainMetaResponse{chainMeta, syncStatus}, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return res.chainMeta, res.syncStatus, nil
+}
+
+func (core *coreService) chainMeta(tipHeight uint64) (*iotextypes.ChainMeta, string, error) {
 	if tipHeight == 0 {
 		return &iotextypes.ChainMeta{
 			Epoch:   &iotextypes.EpochData{},
@@ -454,6 +609,46 @@ func (core *coreService) ChainMeta() (*iotextypes.ChainMeta, string, error) {
 	return chainMeta, syncStatus, nil
 }
 
+func cloneChainMeta(res *chainMetaResponse) (*chainMetaResponse, error) {
+	return &chainMetaResponse{proto.Clone(res.chainMeta).(*iotextypes.ChainMeta), res.syncStatus}, nil
+}
+
+func cloneReceipt(receipt *action.Receipt) (*action.Receipt, error) {
+	buf, err := receipt.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	clone := &action.Receipt{}
+	if err := clone.Deserialize(buf); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// cloneBlockWithReceipts copies the block and receipts by a round trip of the block store encoding
+func (core *coreService) cloneBlockWithReceipts(blk *apitypes.BlockWithReceipts) (*apitypes.BlockWithReceipts, error) {
+	buf, err := (&block.Store{Block: blk.Block, Receipts: blk.Receipts}).Serialize()
+	if err != nil {
+		return nil, err
+	}
+	store, err := block.NewDeserializer(core.EVMNetworkID()).DeserializeBlockStore(buf)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Block.Receipts != nil {
+		store.Block.Receipts = store.Receipts
+	}
+	return &apitypes.BlockWithReceipts{Block: store.Block, Receipts: store.Receipts}, nil
+}
+
+func (core *coreService) cloneActionResponse(res *actionResponse) (*actionResponse, error) {
+	blk, err := core.cloneBlockWithReceipts(&apitypes.BlockWithReceipts{Block: res.blk, Receipts: res.blk.Receipts})
+	if err != nil {
+		return nil, err
+	}
+	return &actionResponse{blk.Block.Actions[res.index], blk.Block, res.index}, nil
+}
+
 // ServerMeta gets the server metadata
 func (core *coreService) ServerMeta() (packageVersion string, packageCommitID string, gitStatus string, goVersion string, buildTime string) {
 	packageVersion = version.PackageVersion
@@ -533,7 +728,7 @@ func (core *coreService) validateChainID(chainID uint32) error {
 }
 
 // ReadContract reads the state in a contract address specified by the slot
-func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Address, elp action.Envelope) (string, *iotextypes.Receipt, error) {
+func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Address, elp action.Envelope, opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
 	log.Logger("api").Debug("receive read smart contract request")
 	exec, ok := elp.Action().(*action.Execution)
 	if !ok {
@@ -544,7 +739,7 @@ func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Ad
 		hdBytes   = append(byteutil.Uint64ToBytesBigEndian(tipHeight), []byte(exec.Contract())...)
 		key       = hash.Hash160b(append(hdBytes, exec.Data()...))
 	)
-	return core.readContract(ctx, key, tipHeight, false, callerAddr, elp)
+	return core.readContract(ctx, key, tipHeight, false, callerAddr, elp, opts...)
 }
 
 func (core *coreService) readContract(
@@ -553,9 +748,12 @@ func (core *coreService) readContract(
 	height uint64,
 	archive bool,
 	callerAddr address.Address,
-	elp action.Envelope) (string, *iotextypes.Receipt, error) {
+	elp action.Envelope,
+	opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
+	// the result simulated with options, such as the state overrides, is not cached
+	cacheable := len(opts) == 0
 	// TODO: either moving readcache into the upper layer or change the storage format
-	if d, ok := core.readCache.Get(key); ok {
+	if d, ok := core.readCache.Get(key); ok && cacheable {
 		res := iotexapi.ReadContractResponse{}
 		if err := proto.Unmarshal(d, &res); err == nil {
 			return res.Data, res.Receipt, nil
@@ -568,7 +766,7 @@ func (core *coreService) readContract(
 	if elp.Gas() == 0 || blockGasLimit < elp.Gas() {
 		elp.SetGas(blockGasLimit)
 	}
-	retval, receipt, err := core.simulateExecution(ctx, height, archive, callerAddr, elp)
+	retval, receipt, err := core.simulateExecution(ctx, height, archive, callerAddr, elp, opts...)
 	if err != nil {
 		return "", nil, status.Error(codes.Internal, err.Error())
 	}
@@ -576,6 +774,9 @@ func (core *coreService) readContract(
 		Data:    hex.EncodeToString(retval),
 		Receipt: receipt.ConvertToReceiptPb(),
 	}
+	if !cacheable {
+		return res.Data, res.Receipt, nil
+	}
 	if d, err := proto.Marshal(&res); err == nil {
 		core.readCache.Put(key, d)
 	}
@@ -584,7 +785,7 @@ func (core *coreService) readContract(
 
 // ReadState reads state on blockchain
 func (core *coreService) ReadState(protocolID string, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error) {
-	p, ok := core.registry.Find(protocolID)
+	p, ok := core.registry.FindReader(protocolID)
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "protocol %s isn't registered", protocolID)
 	}
@@ -613,6 +814,67 @@ func (core *coreService) SuggestGasPrice() (uint64, error) {
 	return core.gs.SuggestGasPrice()
 }
 
+// GasTable returns the intrinsic gas table at the height, or at the next block if height is 0
+func (core *coreService) GasTable(height uint64) *action.GasTable {
+	if height == 0 {
+		height = core.bc.TipHeight() + 1
+	}
+	ctx := protocol.WithFeatureCtx(protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), core.bc.Genesis()),
+		protocol.BlockCtx{BlockHeight: height},
+	))
+	return protocol.MustGetFeatureCtx(ctx).GasTable
+}
+
+// ReadConsensusState returns the state of the consensus round in progress
+func (core *coreService) ReadConsensusState() (*scheme.ConsensusState, error) {
+	if core.consensusState == nil {
+		return nil, status.Error(codes.Unavailable, "consensus state is not available")
+	}
+	state, err := core.consensusState()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &state, nil
+}
+
+// DryRunNextEpoch calculates the delegates and probation list of the next epoch, as if the pending block were the
+// last block of the current epoch
+func (core *coreService) DryRunNextEpoch(ctx context.Context) (*poll.EpochDryRun, error) {
+	dr, ok := poll.FindProtocol(core.registry).(poll.NextEpochDryRunner)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "dry run of next epoch is not supported")
+	}
+	ctx, err := core.bc.Context(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	tip := protocol.MustGetBlockchainCtx(ctx).Tip
+	header, err := core.bc.BlockHeaderByHeight(tip.Height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	producer, err := address.FromString(header.ProducerAddress())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ctx = protocol.WithFeatureCtx(protocol.WithRegistry(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight:    tip.Height + 1,
+		BlockTimeStamp: tip.Timestamp.Add(core.bc.Genesis().BlockInterval),
+		Producer:       producer,
+	}), core.registry))
+	// the working set is discarded after the dry run
+	ws, err := core.sf.WorkingSet(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res, err := dr.DryRunNextEpoch(ctx, ws)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return res, nil
+}
+
 func (core *coreService) SuggestGasTipCap() (*big.Int, error) {
 	sp, err := core.SuggestGasPrice()
 	if err != nil {
@@ -635,6 +897,16 @@ func (core *coreService) SuggestGasTipCap() (*big.Int, error) {
 	return fee, nil
 }
 
+// SuggestFees suggests the fees of the legacy and the dynamic fee actions at the slow, standard and fast levels, from
+// the priority fees paid in the recent blocks and the pending actions in actpool
+func (core *coreService) SuggestFees(ctx context.Context) (*gasstation.FeeSuggestion, error) {
+	var pending []*action.SealedEnvelope
+	for _, acts := range core.ap.PendingActionMap() {
+		pending = append(pending, acts...)
+	}
+	return core.gs.SuggestFees(ctx, pending)
+}
+
 // FeeHistory returns the fee history
 func (core *coreService) FeeHistory(ctx context.Context, blocks, lastBlock uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, []*big.Int, []float64, error) {
 	return core.gs.FeeHistory(ctx, blocks, lastBlock, rewardPercentiles)
@@ -647,7 +919,7 @@ func (core *coreService) EstimateGasForAction(ctx context.Context, in *iotextype
 		return 0, status.Error(codes.Internal, err.Error())
 	}
 	if _, ok := selp.Action().(*action.Execution); !ok {
-		gas, err := selp.IntrinsicGas()
+		gas, err := core.GasTable(0).IntrinsicGas(selp.Envelope)
 		if err != nil {
 			return 0, status.Error(codes.Internal, err.Error())
 		}
@@ -786,6 +1058,61 @@ func (core *coreService) ChainListener() apitypes.Listener {
 	return core.chainListener
 }
 
+// StreamActionsByAddress streams the actions of an address from the cursor, which is the position of an action in all
+// actions of the address. It pages the indexed actions first, and then the actions of new blocks once they are indexed,
+// until ctx is done or handler fails. The handler receives each action with its cursor, so a stream can be resumed
+// from the cursor next to the last one handled
+func (core *coreService) StreamActionsByAddress(ctx context.Context, addr address.Address, cursor uint64, handler func(*iotexapi.ActionInfo, uint64) error) error {
+	if err := core.checkActionIndex(); err != nil {
+		return err
+	}
+	// listen to new blocks before paging, so that the actions committed during paging are not missed
+	notifier := newBlockNotifier()
+	id, err := core.chainListener.AddResponder(notifier)
+	if err != nil {
+		return err
+	}
+	defer core.chainListener.RemoveResponder(id)
+
+	addrHash := hash.BytesToHash160(addr.Bytes())
+	for {
+		if cursor, err = core.streamIndexedActions(addrHash, cursor, handler); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notifier.done:
+			return nil
+		case <-notifier.notify:
+		}
+	}
+}
+
+// streamIndexedActions streams the indexed actions of an address from the cursor, and returns the next cursor
+func (core *coreService) streamIndexedActions(addr hash.Hash160, cursor uint64, handler func(*iotexapi.ActionInfo, uint64) error) (uint64, error) {
+	total, err := core.indexer.GetActionCountByAddress(addr)
+	if err != nil {
+		return cursor, status.Error(codes.Internal, err.Error())
+	}
+	for cursor < total {
+		hashes, err := core.indexer.GetActionsByAddress(addr, cursor, min(total-cursor, core.cfg.RangeQueryLimit))
+		if err != nil {
+			return cursor, status.Error(codes.Internal, err.Error())
+		}
+		for _, h := range hashes {
+			// same as ActionsByAddress, the action failing to load is skipped
+			if act, err := core.getAction(hash.BytesToHash256(h), false); err == nil {
+				if err := handler(act, cursor); err != nil {
+					return cursor, err
+				}
+			}
+			cursor++
+		}
+	}
+	return cursor, nil
+}
+
 // ElectionBuckets returns the native election buckets.
 func (core *coreService) ElectionBuckets(epochNum uint64) ([]*iotextypes.ElectionBucket, error) {
 	if core.electionCommittee == nil {
@@ -816,19 +1143,21 @@ func (core *coreService) ReceiptByActionHash(h hash.Hash256) (*action.Receipt, e
 		return nil, status.Error(codes.NotFound, blockindex.ErrActionIndexNA.Error())
 	}
 
-	actIndex, err := core.indexer.GetActionIndex(h[:])
-	if err != nil {
-		return nil, errors.Wrap(ErrNotFound, err.Error())
-	}
+	return cachedResponse(core.responseCache, false, "ReceiptByActionHash", h, cloneReceipt, func() (*action.Receipt, error) {
+		actIndex, err := core.indexer.GetActionIndex(h[:])
+		if err != nil {
+			return nil, errors.Wrap(ErrNotFound, err.Error())
+		}
 
-	receipts, err := core.dao.GetReceipts(actIndex.BlockHeight())
-	if err != nil {
-		return nil, err
-	}
-	if receipt := filterReceipts(receipts, h); receipt != nil {
-		return receipt, nil
-	}
-	return nil, errors.Wrapf(ErrNotFound, "failed to find receipt for action %x", h)
+		receipts, err := core.dao.GetReceipts(actIndex.BlockHeight())
+		if err != nil {
+			return nil, err
+		}
+		if receipt := filterReceipts(receipts, h); receipt != nil {
+			return receipt, nil
+		}
+		return nil, errors.Wrapf(ErrNotFound, "failed to find receipt for action %x", h)
+	})
 }
 
 // TransactionLogByActionHash returns transaction log by action hash
@@ -933,7 +1262,7 @@ func (core *coreService) Stop(_ context.Context) error {
 	return core.chainListener.Stop()
 }
 
-func (core *coreService) readState(ctx context.Context, p protocol.Protocol, height string, methodName []byte, arguments ...[]byte) ([]byte, uint64, error) {
+func (core *coreService) readState(ctx context.Context, p protocol.Reader, height string, methodName []byte, arguments ...[]byte) ([]byte, uint64, error) {
 	key := ReadKey{
 		Name:   p.Name(),
 		Height: height,
@@ -967,8 +1296,13 @@ func (core *coreService) readState(ctx context.Context, p protocol.Protocol, hei
 		if err != nil {
 			return nil, 0, err
 		}
-		rp := rolldpos.FindProtocol(core.registry)
-		if rp != nil {
+		if inputHeight > tipHeight {
+			return nil, 0, errors.Errorf("query height %d is higher than tip height %d", inputHeight, tipHeight)
+		}
+		// the states at any past height are available in archive mode, otherwise the past states are read at the
+		// epoch start height. The delegates of poll protocol are always read at the epoch start height
+		_, isPoll := p.(poll.Protocol)
+		if rp := rolldpos.FindProtocol(core.registry); rp != nil && (!core.archiveSupported || isPoll) {
 			tipEpochNum := rp.GetEpochNum(tipHeight)
 			inputEpochNum := rp.GetEpochNum(inputHeight)
 			if inputEpochNum < tipEpochNum {
@@ -977,6 +1311,9 @@ func (core *coreService) readState(ctx context.Context, p protocol.Protocol, hei
 		}
 		if inputHeight < tipHeight {
 			// old data, wrap to history state reader
+			ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+				BlockHeight: inputHeight,
+			}))
 			historySR, err := core.sf.WorkingSetAtHeight(ctx, inputHeight)
 			if err != nil {
 				return nil, 0, err
@@ -1122,6 +1459,136 @@ func (core *coreService) ActionsByAddress(addr address.Address, start uint64, co
 	return res, nil
 }
 
+// BalanceAt returns the balance of an account after the block at the height, which is accumulated from the
+// transaction logs without replaying the blocks on an archive node
+func (core *coreService) BalanceAt(addr address.Address, height uint64) (*big.Int, error) {
+	if core.balanceIndexer == nil {
+		return nil, status.Error(codes.Unimplemented, "balance index is not enabled")
+	}
+	balance, err := core.balanceIndexer.BalanceAt(addr, height)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return balance, nil
+}
+
+// TransferHistory returns the total number of the transfers of an account, and its transfers [start, start+count)
+// from the oldest to the latest
+func (core *coreService) TransferHistory(addr address.Address, start uint64, count uint64) (uint64, []*blockindex.BalanceTransfer, error) {
+	if core.balanceIndexer == nil {
+		return 0, nil, status.Error(codes.Unimplemented, "balance index is not enabled")
+	}
+	if err := core.checkRangeQuery(count); err != nil {
+		return 0, nil, err
+	}
+	total, err := core.balanceIndexer.TransferCount(addr)
+	if err != nil {
+		return 0, nil, status.Error(codes.Internal, err.Error())
+	}
+	if start >= total {
+		return total, nil, nil
+	}
+	transfers, err := core.balanceIndexer.Transfers(addr, start, count)
+	if err != nil {
+		return 0, nil, status.Error(codes.Internal, err.Error())
+	}
+	return total, transfers, nil
+}
+
+// TokenBalances returns the balances of the XRC20 and XRC721 tokens held by an account
+func (core *coreService) TokenBalances(holder address.Address) ([]*blockindex.TokenBalance, error) {
+	if core.tokenIndexer == nil {
+		return nil, status.Error(codes.Unimplemented, "token index is not enabled")
+	}
+	balances, err := core.tokenIndexer.TokenBalances(holder)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return balances, nil
+}
+
+// TokenHolders returns the total number of the holders of a token contract, and its holders [start, start+count) in
+// the order of address
+func (core *coreService) TokenHolders(contract address.Address, start uint64, count uint64) (uint64, []*blockindex.TokenBalance, error) {
+	if core.tokenIndexer == nil {
+		return 0, nil, status.Error(codes.Unimplemented, "token index is not enabled")
+	}
+	if err := core.checkRangeQuery(count); err != nil {
+		return 0, nil, err
+	}
+	total, holders, err := core.tokenIndexer.TokenHolders(contract, start, count)
+	if err != nil {
+		return 0, nil, status.Error(codes.Internal, err.Error())
+	}
+	return total, holders, nil
+}
+
+// TokenTransfers returns the total number of the token transfers of an account, or of a token contract if byContract
+// is true, and the transfers [start, start+count) from the oldest to the latest
+func (core *coreService) TokenTransfers(addr address.Address, byContract bool, start uint64, count uint64) (uint64, []*blockindex.TokenTransfer, error) {
+	if core.tokenIndexer == nil {
+		return 0, nil, status.Error(codes.Unimplemented, "token index is not enabled")
+	}
+	if err := core.checkRangeQuery(count); err != nil {
+		return 0, nil, err
+	}
+	var (
+		total     uint64
+		transfers []*blockindex.TokenTransfer
+		err       error
+	)
+	if byContract {
+		total, transfers, err = core.tokenIndexer.ContractTransfers(addr, start, count)
+	} else {
+		total, transfers, err = core.tokenIndexer.AccountTransfers(addr, start, count)
+	}
+	if err != nil {
+		return 0, nil, status.Error(codes.Internal, err.Error())
+	}
+	return total, transfers, nil
+}
+
+// EpochPerformance returns the produced blocks, the missed slots, the block fullness and the endorsements of the
+// delegates in the epoch
+func (core *coreService) EpochPerformance(epoch uint64) ([]*blockindex.DelegatePerformance, error) {
+	if core.perfIndexer == nil {
+		return nil, status.Error(codes.Unimplemented, "performance index is not enabled")
+	}
+	perfs, err := core.perfIndexer.EpochPerformance(epoch)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return perfs, nil
+}
+
+// DelegatePerformance returns the performance of the delegate in the epochs [start, start+count)
+func (core *coreService) DelegatePerformance(delegate address.Address, start uint64, count uint64) ([]*blockindex.DelegatePerformance, error) {
+	if core.perfIndexer == nil {
+		return nil, status.Error(codes.Unimplemented, "performance index is not enabled")
+	}
+	if err := core.checkRangeQuery(count); err != nil {
+		return nil, err
+	}
+	perfs, err := core.perfIndexer.DelegatePerformance(delegate, start, count)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return perfs, nil
+}
+
+func (core *coreService) checkRangeQuery(count uint64) error {
+	if count == 0 {
+		return status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	if count > core.cfg.RangeQueryLimit {
+		return status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	return nil
+}
+
 // BlockHashByBlockHeight returns block hash by block height
 func (core *coreService) BlockHashByBlockHeight(blkHeight uint64) (hash.Hash256, error) {
 	return core.dao.GetBlockHash(blkHeight)
@@ -1133,22 +1600,28 @@ func (core *coreService) ActionByActionHash(h hash.Hash256) (*action.SealedEnvel
 		return nil, nil, 0, status.Error(codes.NotFound, blockindex.ErrActionIndexNA.Error())
 	}
 
-	actIndex, err := core.indexer.GetActionIndex(h[:])
-	if err != nil {
-		return nil, nil, 0, errors.Wrap(ErrNotFound, err.Error())
-	}
-	blk, err := core.dao.GetBlockByHeight(actIndex.BlockHeight())
-	if err != nil {
-		return nil, nil, 0, errors.Wrap(ErrNotFound, err.Error())
-	}
-	if actIndex.TxNumber() > 0 {
-		return blk.Actions[actIndex.TxNumber()-1], blk, actIndex.TxNumber() - 1, nil
-	}
-	selp, index, err := blk.ActionByHash(h)
+	res, err := cachedResponse(core.responseCache, false, "ActionByActionHash", h, core.cloneActionResponse, func() (*actionResponse, error) {
+		actIndex, err := core.indexer.GetActionIndex(h[:])
+		if err != nil {
+			return nil, errors.Wrap(ErrNotFound, err.Error())
+		}
+		blk, err := core.dao.GetBlockByHeight(actIndex.BlockHeight())
+		if err != nil {
+			return nil, errors.Wrap(ErrNotFound, err.Error())
+		}
+		if actIndex.TxNumber() > 0 {
+			return &actionResponse{blk.Actions[actIndex.TxNumber()-1], blk, actIndex.TxNumber() - 1}, nil
+		}
+		selp, index, err := blk.ActionByHash(h)
+		if err != nil {
+			return nil, errors.Wrap(ErrNotFound, err.Error())
+		}
+		return &actionResponse{selp, blk, index}, nil
+	})
 	if err != nil {
-		return nil, nil, 0, errors.Wrap(ErrNotFound, err.Error())
+		return nil, nil, 0, err
 	}
-	return selp, blk, index, nil
+	return res.selp, res.blk, res.index, nil
 }
 
 // ActionByActionHash returns action by action hash
@@ -1160,6 +1633,46 @@ func (core *coreService) PendingActionByActionHash(h hash.Hash256) (*action.Seal
 	return selp, nil
 }
 
+// AccessSetInActPool returns the access set of the action in actpool
+func (core *coreService) AccessSetInActPool(h hash.Hash256) (*actpool.AccessSet, error) {
+	reader, ok := core.ap.(actpool.AccessSetReader)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "access set is not supported by actpool")
+	}
+	selp, err := core.ap.GetActionByHash(h)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, err.Error())
+	}
+	set, ok := reader.AccessSet(selp)
+	if !ok {
+		return nil, status.Error(codes.Unavailable, "access set is not enabled in actpool")
+	}
+	return set, nil
+}
+
+// ActPoolContent returns the pending and the queued actions in actpool by sender address
+func (core *coreService) ActPoolContent() (map[string]*actpool.Content, error) {
+	reader, ok := core.ap.(actpool.ContentReader)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "content is not supported by actpool")
+	}
+	return reader.Content(), nil
+}
+
+// NonceGaps returns the confirmed nonce and the pending nonce of the sender, the actions of the sender in actpool with
+// the min fees to replace them, and the missing nonces blocking the actions
+func (core *coreService) NonceGaps(addr address.Address) (*actpool.NonceGaps, error) {
+	reader, ok := core.ap.(actpool.NonceGapReader)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "nonce gaps is not supported by actpool")
+	}
+	gaps, err := reader.NonceGaps(addr)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return gaps, nil
+}
+
 // UnconfirmedActionsByAddress returns all unconfirmed actions in actpool associated with an address
 func (core *coreService) UnconfirmedActionsByAddress(address string, start uint64, count uint64) ([]*iotexapi.ActionInfo, error) {
 	if count == 0 {
@@ -1195,18 +1708,20 @@ func (core *coreService) BlockByHash(blkHash string) (*apitypes.BlockWithReceipt
 	if err != nil {
 		return nil, err
 	}
-	blk, err := core.dao.GetBlock(hash)
-	if err != nil {
-		return nil, errors.Wrap(ErrNotFound, err.Error())
-	}
-	receipts, err := core.dao.GetReceipts(blk.Height())
-	if err != nil {
-		return nil, errors.Wrap(ErrNotFound, err.Error())
-	}
-	return &apitypes.BlockWithReceipts{
-		Block:    blk,
-		Receipts: receipts,
-	}, nil
+	return cachedResponse(core.responseCache, false, "BlockByHash", hash, core.cloneBlockWithReceipts, func() (*apitypes.BlockWithReceipts, error) {
+		blk, err := core.dao.GetBlock(hash)
+		if err != nil {
+			return nil, errors.Wrap(ErrNotFound, err.Error())
+		}
+		receipts, err := core.dao.GetReceipts(blk.Height())
+		if err != nil {
+			return nil, errors.Wrap(ErrNotFound, err.Error())
+		}
+		return &apitypes.BlockWithReceipts{
+			Block:    blk,
+			Receipts: receipts,
+		}, nil
+	})
 }
 
 // BlockByHeightRange returns blocks within the height range
@@ -1601,11 +2116,10 @@ func (core *coreService) correctQueryRange(start, end uint64) (uint64, uint64, e
 
 // EstimateGasForNonExecution estimates action gas except execution
 func (core *coreService) EstimateGasForNonExecution(actType action.Action) (uint64, error) {
-	act, ok := actType.(intrinsicGasCalculator)
-	if !ok {
+	if _, ok := actType.(intrinsicGasCalculator); !ok {
 		return 0, errors.Errorf("invalid action type not supported")
 	}
-	return act.IntrinsicGas()
+	return core.GasTable(0).ActionIntrinsicGas(actType)
 }
 
 // EstimateMigrateStakeGasConsumption estimates gas consumption for migrate stake action
@@ -1650,7 +2164,7 @@ func (core *coreService) EstimateMigrateStakeGasConsumption(ctx context.Context,
 	if err != nil {
 		return 0, retval, err
 	}
-	intrinsicGas, err := ms.IntrinsicGas()
+	intrinsicGas, err := core.GasTable(header.Height() + 1).ActionIntrinsicGas(ms)
 	if err != nil {
 		return 0, retval, err
 	}
@@ -1850,8 +2364,85 @@ func (core *coreService) ReadContractStorage(ctx context.Context, addr address.A
 	return evm.ReadContractStorage(ctx, ws, addr, key)
 }
 
+// StateProof returns the merkle proofs of an account and its contract storage slots at the tip height
+func (core *coreService) StateProof(addr address.Address, keys []hash.Hash256) (*AccountProof, error) {
+	ctx, err := core.bc.Context(context.Background())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ws, err := core.sf.WorkingSet(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return stateProof(ctx, ws, addr, keys)
+}
+
+// Preimage returns the namespace or the key in the state trie whose hash is the given hash
+func (core *coreService) Preimage(h hash.Hash160) ([]byte, error) {
+	reader, ok := core.sf.(factory.PreimageReader)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "preimage store is not supported")
+	}
+	preimage, err := reader.Preimage(h)
+	if err != nil {
+		switch errors.Cause(err) {
+		case factory.ErrNotSupported:
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		case state.ErrStateNotExist:
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return preimage, nil
+}
+
+func stateProof(ctx context.Context, sm protocol.StateManager, addr address.Address, keys []hash.Hash256) (*AccountProof, error) {
+	prover, ok := sm.(factory.StateProver)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "state proof is not supported")
+	}
+	pkHash := hash.BytesToHash160(addr.Bytes())
+	root, proof, err := prover.StateProof(protocol.LegacyKeyOption(pkHash))
+	if err != nil {
+		if errors.Cause(err) == factory.ErrNotSupported {
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	account, err := accountutil.AccountState(ctx, sm, addr)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	nonce := account.PendingNonce()
+	if g := genesis.MustExtractGenesisContext(ctx); g.IsSumatra(protocol.MustGetBlockchainCtx(ctx).Tip.Height) {
+		nonce = account.PendingNonceConsideringFreshAccount()
+	}
+	storageRoot, values, proofs, err := evm.StorageProof(sm, pkHash, account, keys)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ret := &AccountProof{
+		Address:       addr,
+		Account:       account,
+		Nonce:         nonce,
+		StateRoot:     root,
+		Proof:         proof,
+		StorageRoot:   storageRoot,
+		StorageProofs: make([]*StorageProof, len(keys)),
+	}
+	for i := range keys {
+		ret.StorageProofs[i] = &StorageProof{
+			Key:   keys[i],
+			Value: values[i],
+			Proof: proofs[i],
+		}
+	}
+	return ret, nil
+}
+
 func (core *coreService) ReceiveBlock(blk *block.Block) error {
 	core.readCache.Clear()
+	core.responseCache.clearPinned()
 	return core.chainListener.ReceiveBlock(blk)
 }
 
@@ -1865,32 +2456,240 @@ func (core *coreService) SimulateExecution(ctx context.Context, addr address.Add
 	return core.simulateExecution(ctx, tipHeight, false, addr, elp)
 }
 
+// SimulateBlocks simulates the calls in a sequence of blocks, the state changes of the calls are carried over to the
+// following calls and blocks. The calls without gas limit use up the remaining gas of the block
+func (core *coreService) SimulateBlocks(ctx context.Context, height uint64, blocks []*apitypes.SimulateBlock) ([]*apitypes.SimulatedBlock, error) {
+	var (
+		err error
+		ws  protocol.StateManager
+	)
+	if height == 0 {
+		if ctx, err = core.bc.Context(ctx); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		ws, err = core.sf.WorkingSet(ctx)
+	} else {
+		if !core.archiveSupported {
+			return nil, ErrArchiveNotSupported
+		}
+		if ctx, err = core.bc.ContextAtHeight(ctx, height); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		ws, err = core.sf.WorkingSetAtHeight(ctx, height)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	zeroAddr, err := address.FromString(address.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		g   = core.bc.Genesis()
+		tip = protocol.MustGetBlockchainCtx(ctx).Tip
+		ret = make([]*apitypes.SimulatedBlock, 0, len(blocks))
+	)
+	ctx = evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash:   core.dao.GetBlockHash,
+		GetBlockTime:   core.getBlockTime,
+		DepositGasFunc: rewarding.DepositGas,
+	})
+	for _, blk := range blocks {
+		blkCtx := protocol.BlockCtx{
+			BlockHeight:    tip.Height + 1,
+			BlockTimeStamp: tip.Timestamp.Add(g.BlockInterval),
+			Producer:       zeroAddr,
+			BaseFee:        protocol.CalcBaseFee(g.Blockchain, &tip),
+			ExcessBlobGas:  protocol.CalcExcessBlobGas(tip.ExcessBlobGas, tip.BlobGasUsed),
+		}
+		if ov := blk.BlockOverrides; ov != nil {
+			if ov.Number != nil {
+				if *ov.Number <= tip.Height {
+					return nil, status.Errorf(codes.InvalidArgument, "block number %d is not greater than %d", *ov.Number, tip.Height)
+				}
+				blkCtx.BlockHeight = *ov.Number
+			}
+			if ov.Time != nil {
+				ts := time.Unix(int64(*ov.Time), 0)
+				if ts.Before(tip.Timestamp) {
+					return nil, status.Errorf(codes.InvalidArgument, "block time %d is earlier than %d", *ov.Time, tip.Timestamp.Unix())
+				}
+				blkCtx.BlockTimeStamp = ts
+			}
+			if ov.FeeRecipient != nil {
+				blkCtx.Producer = ov.FeeRecipient
+			}
+			if ov.BaseFee != nil {
+				blkCtx.BaseFee = ov.BaseFee
+			}
+		}
+		blkCtx.GasLimit = g.BlockGasLimitByHeight(blkCtx.BlockHeight)
+		if ov := blk.BlockOverrides; ov != nil && ov.GasLimit != nil {
+			blkCtx.GasLimit = *ov.GasLimit
+		}
+		bctx := protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, blkCtx))
+		if len(blk.StateOverrides) > 0 {
+			if err := blk.StateOverrides.Apply(bctx, ws); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+		res := &apitypes.SimulatedBlock{
+			Height:    blkCtx.BlockHeight,
+			Timestamp: blkCtx.BlockTimeStamp,
+			GasLimit:  blkCtx.GasLimit,
+			BaseFee:   blkCtx.BaseFee,
+			Producer:  blkCtx.Producer,
+			Calls:     make([]*apitypes.SimulatedCall, 0, len(blk.Calls)),
+		}
+		for i, call := range blk.Calls {
+			state, err := accountutil.AccountState(bctx, ws, call.From)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			if protocol.MustGetFeatureCtx(bctx).UseZeroNonceForFreshAccount {
+				call.Elp.SetNonce(state.PendingNonceConsideringFreshAccount())
+			} else {
+				call.Elp.SetNonce(state.PendingNonce())
+			}
+			remaining := blkCtx.GasLimit - res.GasUsed
+			if call.Elp.Gas() == 0 {
+				call.Elp.SetGas(remaining)
+			}
+			if call.Elp.Gas() > remaining {
+				return nil, status.Errorf(codes.InvalidArgument, "gas %d of call %d exceeds the remaining gas %d of block %d", call.Elp.Gas(), i, remaining, blkCtx.BlockHeight)
+			}
+			retval, receipt, err := evm.SimulateExecution(bctx, ws, call.From, call.Elp, protocol.WithSimulateBlockCtx(blkCtx))
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "failed to simulate call %d of block %d: %v", i, blkCtx.BlockHeight, err)
+			}
+			res.GasUsed += receipt.GasConsumed
+			res.Calls = append(res.Calls, &apitypes.SimulatedCall{
+				ReturnData: retval,
+				Receipt:    receipt,
+			})
+		}
+		ret = append(ret, res)
+		tip = protocol.TipInfo{
+			Height:        blkCtx.BlockHeight,
+			GasUsed:       res.GasUsed,
+			Timestamp:     blkCtx.BlockTimeStamp,
+			BaseFee:       blkCtx.BaseFee,
+			ExcessBlobGas: blkCtx.ExcessBlobGas,
+		}
+	}
+	return ret, nil
+}
+
 // SyncingProgress returns the syncing status of node
 func (core *coreService) SyncingProgress() (uint64, uint64, uint64) {
 	startingHeight, currentHeight, targetHeight, _ := core.bs.SyncStatus()
 	return startingHeight, currentHeight, targetHeight
 }
 
-// TraceTransaction returns the trace result of transaction
+// SyncingStages returns the heights of the syncing stages of node, a block is downloaded with its header and body,
+// then committed to chain db and state db, and indexed by the indexers
+func (core *coreService) SyncingStages() []*apitypes.SyncStage {
+	stages := []*apitypes.SyncStage{
+		{Name: "blocks", Height: core.bs.DownloadedHeight()},
+		{Name: "chain", Height: core.bc.TipHeight()},
+	}
+	if height, err := core.sf.Height(); err == nil {
+		stages = append(stages, &apitypes.SyncStage{Name: "state", Height: height})
+	} else {
+		log.L().Warn("failed to get the height of state db", zap.Error(err))
+	}
+	if core.indexer != nil {
+		stages = appendIndexerStage(stages, "indexer", core.indexer)
+	}
+	if core.bfIndexer != nil {
+		stages = appendIndexerStage(stages, "bloomfilter", core.bfIndexer)
+	}
+	if core.balanceIndexer != nil {
+		stages = appendIndexerStage(stages, "balance", core.balanceIndexer)
+	}
+	if core.tokenIndexer != nil {
+		stages = appendIndexerStage(stages, "token", core.tokenIndexer)
+	}
+	if core.perfIndexer != nil {
+		stages = appendIndexerStage(stages, "performance", core.perfIndexer)
+	}
+	return stages
+}
+
+func appendIndexerStage(stages []*apitypes.SyncStage, name string, indexer blockdao.BlockIndexer) []*apitypes.SyncStage {
+	height, err := indexer.Height()
+	if err != nil {
+		log.L().Warn("failed to get the height of indexer", zap.String("indexer", name), zap.Error(err))
+		return stages
+	}
+	return append(stages, &apitypes.SyncStage{Name: name, Height: height})
+}
+
+// TraceTransaction returns the trace result of transaction, which is replayed by its sender on the states before it in
+// its block, hence it requires the archive mode
 func (core *coreService) TraceTransaction(ctx context.Context, actHash string, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
-	actInfo, err := core.Action(util.Remove0xPrefix(actHash), false)
+	if !core.archiveSupported {
+		return nil, nil, nil, ErrArchiveNotSupported
+	}
+	h, err := hash.HexStringToHash256(util.Remove0xPrefix(actHash))
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	act, err := (&action.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).ActionToSealedEnvelope(actInfo.Action)
+	selp, blk, index, err := core.ActionByActionHash(h)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	if _, ok := act.Action().(*action.Execution); !ok {
+	if _, ok := selp.Action().(*action.Execution); !ok {
 		return nil, nil, nil, errors.New("the type of action is not supported")
 	}
-	addr, _ := address.FromString(address.ZeroAddress)
-	return core.traceTx(ctx, new(tracers.Context), config, func(ctx context.Context) ([]byte, *action.Receipt, error) {
-		return core.simulateExecution(ctx, core.bc.TipHeight(), false, addr, act.Envelope)
+	ctx, ws, err := core.replayContext(ctx, blk, index)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	blkHash := blk.HashBlock()
+	txctx := &tracers.Context{
+		BlockHash: common.BytesToHash(blkHash[:]),
+		TxIndex:   int(index),
+		TxHash:    common.BytesToHash(h[:]),
+	}
+	return core.traceTx(ctx, txctx, config, func(ctx context.Context) ([]byte, *action.Receipt, error) {
+		return evm.SimulateExecution(ctx, ws, selp.SenderAddress(), selp.Envelope)
+	})
+}
+
+// replayContext returns the context and the working set to replay the action at the index of the block, which has the
+// states after the actions before the index are processed
+func (core *coreService) replayContext(ctx context.Context, blk *block.Block, index uint32) (context.Context, protocol.StateManager, error) {
+	var (
+		height = blk.Height()
+		g      = core.bc.Genesis()
+	)
+	ctx, err := core.bc.ContextAtHeight(ctx, height-1)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(protocol.WithRegistry(ctx, core.registry), protocol.BlockCtx{
+		BlockHeight:    height,
+		BlockTimeStamp: blk.Timestamp(),
+		GasLimit:       g.BlockGasLimitByHeight(height),
+		Producer:       blk.PublicKey().Address(),
+		BaseFee:        blk.BaseFee(),
+		ExcessBlobGas:  blk.ExcessBlobGas(),
+	}))
+	ctx = evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash:   core.dao.GetBlockHash,
+		GetBlockTime:   core.getBlockTime,
+		DepositGasFunc: rewarding.DepositGas,
 	})
+	ws, err := core.sf.WorkingSetAtHeight(ctx, height-1, blk.Actions[:index]...)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	return ctx, ws, nil
 }
 
-// TraceCall returns the trace result of call
+// TraceCall returns the trace result of call, which is simulated on the states at the block of blkNumOrHash, i.e., the
+// block height or the block hash. The call at a block before the tip requires the archive mode
 func (core *coreService) TraceCall(ctx context.Context,
 	callerAddr address.Address,
 	blkNumOrHash any,
@@ -1902,8 +2701,27 @@ func (core *coreService) TraceCall(ctx context.Context,
 	config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
 	var (
 		g             = core.bc.Genesis()
-		blockGasLimit = g.BlockGasLimitByHeight(core.bc.TipHeight())
+		tipHeight     = core.bc.TipHeight()
+		height        = tipHeight
+		blockGasLimit = g.BlockGasLimitByHeight(tipHeight)
 	)
+	switch v := blkNumOrHash.(type) {
+	case uint64:
+		if v > 0 && v < tipHeight {
+			height = v
+		}
+	case string:
+		h, err := hash.HexStringToHash256(util.Remove0xPrefix(v))
+		if err != nil {
+			return nil, nil, nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if height, err = core.dao.GetBlockHeight(h); err != nil {
+			return nil, nil, nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
+	if height < tipHeight && !core.archiveSupported {
+		return nil, nil, nil, ErrArchiveNotSupported
+	}
 	if gasLimit == 0 {
 		gasLimit = blockGasLimit
 	}
@@ -1914,7 +2732,7 @@ func (core *coreService) TraceCall(ctx context.Context,
 	elp := (&action.EnvelopeBuilder{}).SetAction(action.NewExecution(contractAddress, amount, data)).
 		SetGasLimit(gasLimit).Build()
 	return core.traceTx(ctx, new(tracers.Context), config, func(ctx context.Context) ([]byte, *action.Receipt, error) {
-		return core.simulateExecution(ctx, core.bc.TipHeight(), false, callerAddr, elp)
+		return core.simulateExecution(ctx, height, height < tipHeight, callerAddr, elp)
 	})
 }
 
@@ -1948,10 +2766,19 @@ func (core *coreService) traceTx(ctx context.Context, txctx *tracers.Context, co
 				return nil, nil, nil, err
 			}
 		}
+		isJS := tracers.DefaultDirectory.IsJS(*config.Tracer)
+		if isJS {
+			if timeout, err = core.cfg.JSTracer.check(*config.Tracer, timeout); err != nil {
+				return nil, nil, nil, err
+			}
+		}
 		t, err := tracers.DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
 		if err != nil {
 			return nil, nil, nil, err
 		}
+		if isJS {
+			t = newJSTracer(t, core.cfg.JSTracer.MemoryLimit)
+		}
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 		go func() {
@@ -2000,6 +2827,18 @@ func (core *coreService) simulateExecution(
 	if err != nil {
 		return nil, nil, status.Error(codes.Internal, err.Error())
 	}
+	cfg := &protocol.SimulateOptionConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.StateOverride != nil {
+		overrideCtx := protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: height,
+		}))
+		if err := cfg.StateOverride(overrideCtx, ws); err != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
 	state, err := accountutil.AccountState(ctx, ws, addr)
 	if err != nil {
 		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
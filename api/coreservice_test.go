@@ -177,7 +177,7 @@ func getTopicsAddress(addr []string, topics [][]string) (*iotexapi.LogsFilter, e
 	return &filter, nil
 }
 
-func setupTestCoreService() (CoreService, blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, func()) {
+func setupTestCoreService(options ...Option) (CoreService, blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, func()) {
 	cfg := newConfig()
 
 	// TODO (zhi): revise
@@ -200,6 +200,7 @@ func setupTestCoreService() (CoreService, blockchain.Blockchain, blockdao.BlockD
 	opts := []Option{WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
 		return nil
 	})}
+	opts = append(opts, options...)
 	svr, err := newCoreService(cfg.api, bc, nil, sf, dao, indexer, bfIndexer, ap, registry, func(u uint64) (time.Time, error) { return time.Time{}, nil }, opts...)
 	if err != nil {
 		panic(err)
@@ -229,6 +230,32 @@ func TestEstimateGasForAction(t *testing.T) {
 	require.Contains(err.Error(), action.ErrNilProto.Error())
 }
 
+func TestGasTable(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	require.Equal(action.GasTableV1, svr.GasTable(0))
+	require.Equal(action.GasTableV1, svr.GasTable(bc.TipHeight()))
+}
+
+func TestReadStateAtHeight(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	addr := []byte(identityset.Address(27).String())
+	tip := bc.TipHeight()
+	res, err := svr.ReadState("account", "", []byte("Account"), [][]byte{addr})
+	require.NoError(err)
+	require.Equal(tip, res.BlockIdentifier.Height)
+	acct := &state.Account{}
+	require.NoError(acct.Deserialize(res.Data))
+
+	_, err = svr.ReadState("account", strconv.FormatUint(tip+1, 10), []byte("Account"), [][]byte{addr})
+	require.ErrorContains(err, "higher than tip height")
+}
+
 func TestElectionBuckets(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -610,7 +637,7 @@ func TestTraceTransaction(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	svr, bc, _, ap, cleanCallback := setupTestCoreService()
+	svr, bc, _, ap, cleanCallback := setupTestCoreService(WithArchiveSupport())
 	defer cleanCallback()
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -639,6 +666,13 @@ func TestTraceTransaction(t *testing.T) {
 	require.Equal(uint64(0x2710), receipt.GasConsumed)
 	require.Empty(receipt.ExecutionRevertMsg())
 	require.Equal(0, len(traces.(*logger.StructLogger).StructLogs()))
+
+	t.Run("archive mode is required", func(t *testing.T) {
+		svr, _, _, _, cleanCallback := setupTestCoreService()
+		defer cleanCallback()
+		_, _, _, err := svr.TraceTransaction(ctx, hex.EncodeToString(tsfhash[:]), cfg)
+		require.Equal(ErrArchiveNotSupported, err)
+	})
 }
 
 func TestTraceCall(t *testing.T) {
@@ -677,6 +711,14 @@ func TestTraceCall(t *testing.T) {
 	require.Equal(uint64(0x2710), receipt.GasConsumed)
 	require.Empty(receipt.ExecutionRevertMsg())
 	require.Equal(0, len(traces.(*logger.StructLogger).StructLogs()))
+
+	// the call at a block before the tip requires the archive mode
+	_, _, _, err = svr.TraceCall(ctx,
+		identityset.Address(29), blk.Height()-1,
+		identityset.Address(29).String(),
+		0, big.NewInt(0), testutil.TestGasLimit,
+		[]byte{}, cfg)
+	require.Equal(ErrArchiveNotSupported, err)
 }
 
 func TestProofAndCompareReverseActions(t *testing.T) {
@@ -993,6 +1035,7 @@ func TestReceiveBlock(t *testing.T) {
 	listener := mock_apitypes.NewMockListener(ctrl)
 	cs := &coreService{
 		readCache:     &ReadCache{},
+		responseCache: newResponseCache(DefaultConfig.ResponseCache),
 		chainListener: listener,
 	}
 
@@ -1084,6 +1127,61 @@ func TestSimulateExecution(t *testing.T) {
 	})
 }
 
+func TestStreamActionsByAddress(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+	core := svr.(*coreService)
+
+	addr := identityset.Address(0)
+	expected, err := core.ActionsByAddress(addr, 0, core.cfg.RangeQueryLimit)
+	require.NoError(err)
+	require.True(len(expected) > 2)
+	last := uint64(len(expected) - 1)
+
+	t.Run("StreamFromCursor", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var (
+			acts    []*iotexapi.ActionInfo
+			cursors []uint64
+		)
+		err := core.StreamActionsByAddress(ctx, addr, 1, func(act *iotexapi.ActionInfo, cursor uint64) error {
+			acts = append(acts, act)
+			cursors = append(cursors, cursor)
+			if cursor == last {
+				cancel()
+			}
+			return nil
+		})
+		require.Equal(context.Canceled, err)
+		require.Len(acts, len(expected)-1)
+		for i := range acts {
+			require.Equal(uint64(i+1), cursors[i])
+			require.Equal(expected[i+1].ActHash, acts[i].ActHash)
+		}
+	})
+	t.Run("HandlerFailed", func(t *testing.T) {
+		err := core.StreamActionsByAddress(context.Background(), addr, 0, func(*iotexapi.ActionInfo, uint64) error {
+			return errors.New(t.Name())
+		})
+		require.EqualError(err, t.Name())
+	})
+	t.Run("ListenerStopped", func(t *testing.T) {
+		done := make(chan error)
+		go func() {
+			done <- core.StreamActionsByAddress(context.Background(), addr, last+1, func(*iotexapi.ActionInfo, uint64) error {
+				return errors.New("no more actions expected")
+			})
+		}()
+		require.Eventually(func() bool {
+			return core.chainListener.(*chainListener).streamMap.Count() == 1
+		}, time.Second, 10*time.Millisecond)
+		require.NoError(core.chainListener.Stop())
+		require.NoError(<-done)
+	})
+}
+
 func TestSyncingProgress(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -1123,7 +1221,8 @@ func TestTraceTx(t *testing.T) {
 	var (
 		bc = mock_blockchain.NewMockBlockchain(ctrl)
 		cs = &coreService{
-			bc: bc,
+			bc:  bc,
+			cfg: DefaultConfig,
 		}
 		ctx = context.Background()
 	)
@@ -1173,6 +1272,16 @@ func TestTraceTx(t *testing.T) {
 			})
 			require.ErrorContains(err, t.Name())
 		})
+
+		t.Run("JSTracerDisabled", func(t *testing.T) {
+			cs := &coreService{bc: bc, cfg: DefaultConfig}
+			cs.cfg.JSTracer.Enabled = false
+			testStr := "{step: function() {}, fault: function() {}, result: function() { return 1; }}"
+			_, _, _, err := cs.traceTx(ctx, nil, &tracers.TraceConfig{Tracer: &testStr}, func(ctx context.Context) ([]byte, *action.Receipt, error) {
+				return nil, nil, nil
+			})
+			require.ErrorIs(err, ErrJSTracerDisabled)
+		})
 	})
 
 	t.Run("TracerIsNil", func(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
@@ -22,7 +23,8 @@ type (
 	// CoreServiceReaderWithHeight is an interface for state reader at certain height
 	CoreServiceReaderWithHeight interface {
 		Account(address.Address) (*iotextypes.AccountMeta, *iotextypes.BlockIdentifier, error)
-		ReadContract(context.Context, address.Address, action.Envelope) (string, *iotextypes.Receipt, error)
+		ReadContract(context.Context, address.Address, action.Envelope, ...protocol.SimulateOption) (string, *iotextypes.Receipt, error)
+		StateProof(address.Address, []hash.Hash256) (*AccountProof, error)
 	}
 
 	coreServiceReaderWithHeight struct {
@@ -77,7 +79,7 @@ func (core *coreServiceReaderWithHeight) stateAndNonce(addr address.Address) (*s
 	return state, pendingNonce, nil
 }
 
-func (core *coreServiceReaderWithHeight) ReadContract(ctx context.Context, callerAddr address.Address, elp action.Envelope) (string, *iotextypes.Receipt, error) {
+func (core *coreServiceReaderWithHeight) ReadContract(ctx context.Context, callerAddr address.Address, elp action.Envelope, opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
 	if !core.cs.archiveSupported {
 		return "", nil, ErrArchiveNotSupported
 	}
@@ -90,5 +92,20 @@ func (core *coreServiceReaderWithHeight) ReadContract(ctx context.Context, calle
 		hdBytes = append(byteutil.Uint64ToBytesBigEndian(core.height), []byte(exec.Contract())...)
 		key     = hash.Hash160b(append(hdBytes, exec.Data()...))
 	)
-	return core.cs.readContract(ctx, key, core.height, true, callerAddr, elp)
+	return core.cs.readContract(ctx, key, core.height, true, callerAddr, elp, opts...)
+}
+
+func (core *coreServiceReaderWithHeight) StateProof(addr address.Address, keys []hash.Hash256) (*AccountProof, error) {
+	if !core.cs.archiveSupported {
+		return nil, ErrArchiveNotSupported
+	}
+	ctx, err := core.cs.bc.ContextAtHeight(context.Background(), core.height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ws, err := core.cs.sf.WorkingSetAtHeight(ctx, core.height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return stateProof(ctx, ws, addr, keys)
 }
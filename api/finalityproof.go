@@ -0,0 +1,127 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+type (
+	// FinalityProof proves a block is final, so that a verifier off the chain, e.g., a bridge contract on another
+	// chain, can verify the events of the block. The block is final if the distinct endorsers of its COMMIT votes are
+	// more than 2/3 of the delegates of the epoch, and the delegates are proven by the CandidatesProof
+	FinalityProof struct {
+		Block     *block.Block
+		Epoch     uint64
+		Delegates state.CandidateList
+		// CandidatesProof is nil in the first epoch, whose candidates are in the genesis
+		CandidatesProof *CandidatesProof
+	}
+
+	// CandidatesProof proves the candidates of an epoch, which are put by the poll result action in a block of the
+	// previous epoch. The action is included in the tx root of the block, and the block is final by the endorsements
+	// of the delegates of the previous epoch, so the proofs chain back to the genesis
+	CandidatesProof struct {
+		Block  *block.Block
+		Action *action.SealedEnvelope
+		// Index is the index of the action in the block
+		Index int
+		// Siblings are the sibling hashes from the action up to the tx root
+		Siblings []hash.Hash256
+	}
+)
+
+// FinalityProof returns the finality proof of the block at the height
+func (core *coreService) FinalityProof(height uint64) (*FinalityProof, error) {
+	if height == 0 || height > core.bc.TipHeight() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block height %d", height)
+	}
+	rp := rolldpos.FindProtocol(core.registry)
+	if rp == nil {
+		return nil, status.Error(codes.Unimplemented, "rolldpos protocol is not registered")
+	}
+	pp := poll.FindProtocol(core.registry)
+	if pp == nil {
+		return nil, status.Error(codes.Unimplemented, "poll protocol is not registered")
+	}
+	blk, err := core.dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	epoch := rp.GetEpochNum(height)
+	data, _, err := core.readState(
+		context.Background(),
+		pp,
+		strconv.FormatUint(rp.GetEpochHeight(epoch), 10),
+		[]byte("ActiveBlockProducersByEpoch"),
+		[]byte(strconv.FormatUint(epoch, 10)),
+	)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	var delegates state.CandidateList
+	if err := delegates.Deserialize(data); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	proof := &FinalityProof{
+		Block:     blk,
+		Epoch:     epoch,
+		Delegates: delegates,
+	}
+	if epoch > 1 {
+		if proof.CandidatesProof, err = core.candidatesProof(rp, epoch); err != nil {
+			return nil, err
+		}
+	}
+	return proof, nil
+}
+
+// candidatesProof finds the poll result action of the epoch, which is put in the first block since the middle of the
+// previous epoch
+func (core *coreService) candidatesProof(rp *rolldpos.Protocol, epoch uint64) (*CandidatesProof, error) {
+	epochHeight := rp.GetEpochHeight(epoch)
+	prevEpochHeight := rp.GetEpochHeight(epoch - 1)
+	for height := prevEpochHeight + (epochHeight-prevEpochHeight)/2; height < epochHeight; height++ {
+		blk, err := core.dao.GetBlockByHeight(height)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		for i, selp := range blk.Actions {
+			if r, ok := selp.Action().(*action.PutPollResult); !ok || r.Height() != epochHeight {
+				continue
+			}
+			leaves := make([]hash.Hash256, len(blk.Actions))
+			for j, act := range blk.Actions {
+				if leaves[j], err = act.Hash(); err != nil {
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+			}
+			siblings, err := crypto.MerkleProof(leaves, i)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			return &CandidatesProof{
+				Block:    blk,
+				Action:   selp,
+				Index:    i,
+				Siblings: siblings,
+			}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "poll result of epoch %d is not found", epoch)
+}
@@ -82,22 +82,28 @@ func RecoveryInterceptor() grpc_recovery.Option {
 }
 
 // NewGRPCServer creates a new grpc server
-func NewGRPCServer(core CoreService, bds *blockDAOService, grpcPort int) *GRPCServer {
+func NewGRPCServer(core CoreService, bds *blockDAOService, grpcPort int, limiter *RateLimiter) *GRPCServer {
 	if grpcPort == 0 {
 		return nil
 	}
 
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_prometheus.StreamServerInterceptor,
+		otelgrpc.StreamServerInterceptor(),
+		grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_prometheus.UnaryServerInterceptor,
+		otelgrpc.UnaryServerInterceptor(),
+		grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
+	}
+	if limiter != nil {
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+	}
 	gSvr := grpc.NewServer(
-		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			grpc_prometheus.StreamServerInterceptor,
-			otelgrpc.StreamServerInterceptor(),
-			grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
-		)),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpc_prometheus.UnaryServerInterceptor,
-			otelgrpc.UnaryServerInterceptor(),
-			grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
-		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 		grpc.KeepaliveEnforcementPolicy(kaep),
 		grpc.KeepaliveParams(kasp),
 	)
@@ -105,6 +111,10 @@ func NewGRPCServer(core CoreService, bds *blockDAOService, grpcPort int) *GRPCSe
 	//serviceName: grpc.health.v1.Health
 	grpc_health_v1.RegisterHealthServer(gSvr, health.NewServer())
 	iotexapi.RegisterAPIServiceServer(gSvr, newGRPCHandler(core))
+	gSvr.RegisterService(&ConsensusServiceDesc, newConsensusService(core))
+	gSvr.RegisterService(&TokenServiceDesc, newTokenService(core))
+	gSvr.RegisterService(&PerformanceServiceDesc, newPerformanceService(core))
+	gSvr.RegisterService(&TxPoolServiceDesc, newTxPoolService(core))
 	if bds != nil {
 		blockdaopb.RegisterBlockDAOServiceServer(gSvr, bds)
 	}
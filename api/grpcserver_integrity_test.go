@@ -2622,7 +2622,7 @@ func TestGrpcServer_TraceTransactionStructLogsIntegrity(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig()
 	cfg.api.GRPCPort = testutil.RandomPort()
-	svr, bc, _, _, _, actPool, bfIndexFile, err := createServerV2(cfg, true)
+	svr, bc, _, _, _, actPool, bfIndexFile, err := createServerV2(cfg, true, WithArchiveSupport())
 	require.NoError(err)
 	grpcHandler := newGRPCHandler(svr.core)
 	defer func() {
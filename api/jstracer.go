@@ -0,0 +1,98 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"runtime/metrics"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/pkg/errors"
+)
+
+// _memoryCheckInterval is the number of steps between the checks of the memory used by a javascript tracer
+const _memoryCheckInterval = 1000
+
+const _heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+var (
+	// ErrJSTracerDisabled is the error when the javascript tracer is disabled
+	ErrJSTracerDisabled = errors.New("javascript tracer is disabled")
+	// ErrJSTracerMemoryLimit is the error when a javascript tracer exceeds the memory limit
+	ErrJSTracerMemoryLimit = errors.New("javascript tracer exceeds the memory limit")
+)
+
+type (
+	// JSTracerConfig is the config of the tracers in javascript, either bundled or supplied by users, which are run
+	// by the goja engine in debug_traceTransaction and debug_traceCall
+	JSTracerConfig struct {
+		Enabled bool `yaml:"enabled"`
+		// MaxCodeSize is the max size of the javascript code supplied by users in bytes
+		MaxCodeSize int `yaml:"maxCodeSize"`
+		// Timeout is the max duration of a trace, the timeout in the request is capped by it
+		Timeout time.Duration `yaml:"timeout"`
+		// MemoryLimit is the max growth of the heap in bytes during a trace, the tracer is stopped once exceeding it.
+		// The heap is shared with other requests so the limit is approximate, and it is not checked if it is 0
+		MemoryLimit uint64 `yaml:"memoryLimit"`
+	}
+
+	// jsTracer stops the wrapped javascript tracer once the heap grows beyond the memory limit
+	jsTracer struct {
+		tracers.Tracer
+		limit  uint64
+		base   uint64
+		steps  uint64
+		sample []metrics.Sample
+	}
+)
+
+// check checks if the tracer is allowed, and returns the timeout of the trace capped by the config
+func (cfg *JSTracerConfig) check(code string, timeout time.Duration) (time.Duration, error) {
+	if !cfg.Enabled {
+		return 0, ErrJSTracerDisabled
+	}
+	if cfg.MaxCodeSize > 0 && len(code) > cfg.MaxCodeSize {
+		return 0, errors.Errorf("size of javascript tracer %d exceeds the limit %d", len(code), cfg.MaxCodeSize)
+	}
+	if cfg.Timeout > 0 && timeout > cfg.Timeout {
+		timeout = cfg.Timeout
+	}
+	return timeout, nil
+}
+
+func newJSTracer(t tracers.Tracer, limit uint64) tracers.Tracer {
+	if limit == 0 {
+		return t
+	}
+	jt := &jsTracer{
+		Tracer: t,
+		limit:  limit,
+		sample: []metrics.Sample{{Name: _heapObjectsMetric}},
+	}
+	jt.base = jt.heap()
+	return jt
+}
+
+func (t *jsTracer) heap() uint64 {
+	metrics.Read(t.sample)
+	if t.sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return t.sample[0].Value.Uint64()
+}
+
+// CaptureState checks the memory used every _memoryCheckInterval steps
+func (t *jsTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	t.Tracer.CaptureState(pc, op, gas, cost, scope, rData, depth, err)
+	t.steps++
+	if t.steps%_memoryCheckInterval != 0 {
+		return
+	}
+	if heap := t.heap(); heap > t.base && heap-t.base > t.limit {
+		t.Tracer.Stop(ErrJSTracerMemoryLimit)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSTracerConfigCheck(t *testing.T) {
+	require := require.New(t)
+	cfg := JSTracerConfig{
+		Enabled:     true,
+		MaxCodeSize: 10,
+		Timeout:     time.Second,
+	}
+	timeout, err := cfg.check("callTracer", 5*time.Second)
+	require.NoError(err)
+	require.Equal(time.Second, timeout)
+	timeout, err = cfg.check("callTracer", time.Millisecond)
+	require.NoError(err)
+	require.Equal(time.Millisecond, timeout)
+	_, err = cfg.check("{result: function() {}}", time.Millisecond)
+	require.ErrorContains(err, "exceeds the limit")
+
+	cfg.Enabled = false
+	_, err = cfg.check("callTracer", time.Millisecond)
+	require.ErrorIs(err, ErrJSTracerDisabled)
+
+	// the tracer is not wrapped without the memory limit
+	require.Nil(newJSTracer(nil, 0))
+	jt, ok := newJSTracer(nil, 1).(*jsTracer)
+	require.True(ok)
+	require.NotZero(jt.base)
+}
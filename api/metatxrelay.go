@@ -0,0 +1,522 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/cache/ttl"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+// _forwarderJSONABI is the ABI of the EIP-2771 trusted forwarder, compatible with the ERC2771Forwarder of OpenZeppelin
+const _forwarderJSONABI = `[
+	{
+		"inputs": [
+			{
+				"components": [
+					{"internalType": "address", "name": "from", "type": "address"},
+					{"internalType": "address", "name": "to", "type": "address"},
+					{"internalType": "uint256", "name": "value", "type": "uint256"},
+					{"internalType": "uint256", "name": "gas", "type": "uint256"},
+					{"internalType": "uint48", "name": "deadline", "type": "uint48"},
+					{"internalType": "bytes", "name": "data", "type": "bytes"},
+					{"internalType": "bytes", "name": "signature", "type": "bytes"}
+				],
+				"internalType": "struct ERC2771Forwarder.ForwardRequestData",
+				"name": "request",
+				"type": "tuple"
+			}
+		],
+		"name": "execute",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"components": [
+					{"internalType": "address", "name": "from", "type": "address"},
+					{"internalType": "address", "name": "to", "type": "address"},
+					{"internalType": "uint256", "name": "value", "type": "uint256"},
+					{"internalType": "uint256", "name": "gas", "type": "uint256"},
+					{"internalType": "uint48", "name": "deadline", "type": "uint48"},
+					{"internalType": "bytes", "name": "data", "type": "bytes"},
+					{"internalType": "bytes", "name": "signature", "type": "bytes"}
+				],
+				"internalType": "struct ERC2771Forwarder.ForwardRequestData",
+				"name": "request",
+				"type": "tuple"
+			}
+		],
+		"name": "verify",
+		"outputs": [{"internalType": "bool", "name": "", "type": "bool"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"components": [
+					{"internalType": "address", "name": "from", "type": "address"},
+					{"internalType": "address", "name": "to", "type": "address"},
+					{"internalType": "uint256", "name": "value", "type": "uint256"},
+					{"internalType": "uint256", "name": "gas", "type": "uint256"},
+					{"internalType": "uint48", "name": "deadline", "type": "uint48"},
+					{"internalType": "bytes", "name": "data", "type": "bytes"},
+					{"internalType": "bytes", "name": "signature", "type": "bytes"}
+				],
+				"internalType": "struct ERC2771Forwarder.ForwardRequestData[]",
+				"name": "requests",
+				"type": "tuple[]"
+			},
+			{"internalType": "address payable", "name": "refundReceiver", "type": "address"}
+		],
+		"name": "executeBatch",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	}
+]`
+
+// _feeTokenJSONABI is the ABI of the ERC20 token the relayer fee is paid in
+const _feeTokenJSONABI = `[
+	{
+		"inputs": [
+			{"internalType": "address", "name": "to", "type": "address"},
+			{"internalType": "uint256", "name": "value", "type": "uint256"}
+		],
+		"name": "transfer",
+		"outputs": [{"internalType": "bool", "name": "", "type": "bool"}],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+var (
+	_forwarderABI       abi.ABI
+	_feeTokenABI        abi.ABI
+	_eip712DomainArgs   abi.Arguments
+	_forwardRequestArgs abi.Arguments
+
+	_eip712DomainTypeHash   = hash.Hash256b([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	_forwardRequestTypeHash = hash.Hash256b([]byte("ForwardRequest(address from,address to,uint256 value,uint256 gas,uint256 nonce,uint48 deadline,bytes data)"))
+
+	// ErrInvalidMetaTransaction indicates the meta transaction is malformed or not signed properly
+	ErrInvalidMetaTransaction = errors.New("invalid meta transaction")
+)
+
+type (
+	// MetaTxRelayConfig is the config of relaying the meta transactions to the EIP-2771 trusted forwarder, the relay is
+	// disabled if the forwarder or the private key is not set
+	MetaTxRelayConfig struct {
+		// Forwarder is the address of the trusted forwarder contract
+		Forwarder string `yaml:"forwarder"`
+		// ForwarderName and ForwarderVersion are the name and the version of the EIP-712 domain of the forwarder
+		ForwarderName    string `yaml:"forwarderName"`
+		ForwarderVersion string `yaml:"forwarderVersion"`
+		// PrivateKey is the private key of the relayer, which pays the gas fee of the relayed meta transactions
+		PrivateKey string `yaml:"privateKey"`
+		// MaxGas is the max gas a meta transaction can request
+		MaxGas uint64 `yaml:"maxGas"`
+		// FeeToken is the ERC20 token the relayer fee is paid in. The fee is transferred to the relayer by a meta
+		// transaction of the sender, which is relayed in the same batch before the call
+		FeeToken string `yaml:"feeToken"`
+		// FeePerGas is the relayer fee in the smallest unit of the fee token for each gas the meta transactions request
+		FeePerGas uint64 `yaml:"feePerGas"`
+		// AllowedTargets are the contracts the meta transactions are allowed to call
+		AllowedTargets []string `yaml:"allowedTargets"`
+		// SenderQuota is the quota of the meta transactions each sender can relay
+		SenderQuota RateLimitQuota `yaml:"senderQuota"`
+		// SenderTTL is the duration the quota of an idle sender is kept
+		SenderTTL time.Duration `yaml:"senderTTL"`
+	}
+
+	// MetaTransaction is a call signed by the sender with EIP-712 and sent through the trusted forwarder by a relayer
+	MetaTransaction struct {
+		From     common.Address
+		To       common.Address
+		Value    *big.Int
+		Gas      uint64
+		Nonce    *big.Int
+		Deadline uint64
+		Data     []byte
+		// Signature is the EIP-712 signature of the sender over the forward request
+		Signature []byte
+		// Fee is the meta transaction of the sender transferring the relayer fee in the fee token, which is signed
+		// with the nonce right before the call
+		Fee *MetaTransaction
+	}
+
+	// forwardRequestData is the request of the execute method of the forwarder
+	forwardRequestData struct {
+		From      common.Address
+		To        common.Address
+		Value     *big.Int
+		Gas       *big.Int
+		Deadline  *big.Int
+		Data      []byte
+		Signature []byte
+	}
+
+	// metaTxRelayer signs the executions of the meta transactions, the relays are serialized to assign the nonces
+	metaTxRelayer struct {
+		mu        sync.Mutex
+		cfg       MetaTxRelayConfig
+		sk        crypto.PrivateKey
+		forwarder address.Address
+		feeToken  address.Address
+		targets   map[common.Address]struct{}
+		// senders holds the rate limiters of the senders
+		senderMutex sync.Mutex
+		senders     *ttl.Cache
+	}
+)
+
+func init() {
+	var err error
+	if _forwarderABI, err = abi.JSON(strings.NewReader(_forwarderJSONABI)); err != nil {
+		panic(err)
+	}
+	if _feeTokenABI, err = abi.JSON(strings.NewReader(_feeTokenJSONABI)); err != nil {
+		panic(err)
+	}
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	addrType, _ := abi.NewType("address", "", nil)
+	_eip712DomainArgs = abi.Arguments{
+		{Name: "typeHash", Type: bytes32Type},
+		{Name: "name", Type: bytes32Type},
+		{Name: "version", Type: bytes32Type},
+		{Name: "chainId", Type: uint256Type},
+		{Name: "verifyingContract", Type: addrType},
+	}
+	_forwardRequestArgs = abi.Arguments{
+		{Name: "typeHash", Type: bytes32Type},
+		{Name: "from", Type: addrType},
+		{Name: "to", Type: addrType},
+		{Name: "value", Type: uint256Type},
+		{Name: "gas", Type: uint256Type},
+		{Name: "nonce", Type: uint256Type},
+		{Name: "deadline", Type: uint256Type},
+		{Name: "data", Type: bytes32Type},
+	}
+}
+
+func (cfg MetaTxRelayConfig) enabled() bool {
+	return cfg.Forwarder != "" && cfg.PrivateKey != ""
+}
+
+func newMetaTxRelayer(cfg MetaTxRelayConfig) (*metaTxRelayer, error) {
+	sk, err := crypto.HexStringToPrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the private key of meta transaction relayer")
+	}
+	forwarder, err := parseRelayAddress(cfg.Forwarder)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid forwarder address")
+	}
+	feeToken, err := parseRelayAddress(cfg.FeeToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid fee token address")
+	}
+	if cfg.FeePerGas == 0 {
+		return nil, errors.New("fee per gas of meta transaction relay should be positive")
+	}
+	if len(cfg.AllowedTargets) == 0 {
+		return nil, errors.New("no target is allowed for meta transaction relay")
+	}
+	targets := make(map[common.Address]struct{}, len(cfg.AllowedTargets))
+	for _, target := range cfg.AllowedTargets {
+		addr, err := parseRelayAddress(target)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid allowed target address")
+		}
+		targets[common.BytesToAddress(addr.Bytes())] = struct{}{}
+	}
+	if err := cfg.SenderQuota.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid sender quota of meta transaction relay")
+	}
+	opts := []ttl.Option{}
+	if cfg.SenderTTL > 0 {
+		opts = append(opts, ttl.AutoExpireOption(cfg.SenderTTL))
+	}
+	senders, err := ttl.NewCache(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &metaTxRelayer{
+		cfg:       cfg,
+		sk:        sk,
+		forwarder: forwarder,
+		feeToken:  feeToken,
+		targets:   targets,
+		senders:   senders,
+	}, nil
+}
+
+// parseRelayAddress parses the address in either io or 0x format
+func parseRelayAddress(s string) (address.Address, error) {
+	addr, err := address.FromString(s)
+	if err != nil {
+		if addr, err = address.FromHex(s); err != nil {
+			return nil, errors.Wrapf(err, "invalid address %s", s)
+		}
+	}
+	return addr, nil
+}
+
+// Hash returns the EIP-712 hash of the forward request signed by the sender
+func (tx *MetaTransaction) Hash(name, version string, evmNetworkID uint32, forwarder common.Address) (hash.Hash256, error) {
+	if tx.Value == nil || tx.Value.Sign() < 0 || tx.Nonce == nil || tx.Nonce.Sign() < 0 {
+		return hash.ZeroHash256, errors.Wrap(ErrInvalidMetaTransaction, "invalid value or nonce")
+	}
+	domain, err := _eip712DomainArgs.Pack(
+		[32]byte(_eip712DomainTypeHash),
+		[32]byte(hash.Hash256b([]byte(name))),
+		[32]byte(hash.Hash256b([]byte(version))),
+		new(big.Int).SetUint64(uint64(evmNetworkID)),
+		forwarder,
+	)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	request, err := _forwardRequestArgs.Pack(
+		[32]byte(_forwardRequestTypeHash),
+		tx.From,
+		tx.To,
+		tx.Value,
+		new(big.Int).SetUint64(tx.Gas),
+		tx.Nonce,
+		new(big.Int).SetUint64(tx.Deadline),
+		[32]byte(hash.Hash256b(tx.Data)),
+	)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	domainHash, requestHash := hash.Hash256b(domain), hash.Hash256b(request)
+	return hash.Hash256b(append(append([]byte{0x19, 0x01}, domainHash[:]...), requestHash[:]...)), nil
+}
+
+// Sender recovers the address of the signer of the EIP-712 hash
+func (tx *MetaTransaction) Sender(h hash.Hash256) (common.Address, error) {
+	if len(tx.Signature) != 65 {
+		return common.Address{}, errors.Wrapf(ErrInvalidMetaTransaction, "invalid signature length %d", len(tx.Signature))
+	}
+	pk, err := crypto.RecoverPubkey(h[:], tx.Signature)
+	if err != nil {
+		return common.Address{}, errors.Wrap(ErrInvalidMetaTransaction, err.Error())
+	}
+	return common.BytesToAddress(pk.Address().Bytes()), nil
+}
+
+func (tx *MetaTransaction) requestData() *forwardRequestData {
+	return &forwardRequestData{
+		From:      tx.From,
+		To:        tx.To,
+		Value:     tx.Value,
+		Gas:       new(big.Int).SetUint64(tx.Gas),
+		Deadline:  new(big.Int).SetUint64(tx.Deadline),
+		Data:      tx.Data,
+		Signature: tx.Signature,
+	}
+}
+
+// RelayMetaTransaction verifies the meta transaction and its fee payment, and sends both of them to the trusted forwarder
+// in a batch signed by the relayer, which pays the gas fee and receives the relayer fee
+func (core *coreService) RelayMetaTransaction(ctx context.Context, tx *MetaTransaction) (string, error) {
+	relayer := core.metaTxRelayer
+	if relayer == nil {
+		return "", status.Error(codes.Unimplemented, "meta transaction relay is not enabled")
+	}
+	if err := relayer.validate(tx, core.EVMNetworkID()); err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	// the quota is checked after the signature, so that a sender cannot use up the quota of others
+	if !relayer.allow(tx.From) {
+		return "", errRateLimited
+	}
+	var (
+		forwarder  = relayer.forwarder.String()
+		relayerAdr = relayer.sk.PublicKey().Address()
+		fee        = tx.Fee.requestData()
+	)
+	// the forwarder checks the signature against the current nonce of the sender, the deadline, and whether the
+	// fee token trusts the forwarder
+	data, err := _forwarderABI.Pack("verify", fee)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	res, _, err := core.ReadContract(ctx, relayerAdr,
+		(&action.EnvelopeBuilder{}).SetAction(action.NewExecution(forwarder, big.NewInt(0), data)).Build())
+	if err != nil {
+		return "", err
+	}
+	if !unpackBool("verify", _forwarderABI, res) {
+		return "", status.Error(codes.InvalidArgument, "fee payment is rejected by the forwarder")
+	}
+	// the fee payment must succeed, as the failure of a call does not revert the batch
+	sender, err := address.FromBytes(tx.From.Bytes())
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	res, _, err = core.ReadContract(ctx, sender,
+		(&action.EnvelopeBuilder{}).SetAction(action.NewExecution(relayer.feeToken.String(), big.NewInt(0), tx.Fee.Data)).Build())
+	if err != nil {
+		return "", err
+	}
+	if !unpackBool("transfer", _feeTokenABI, res) {
+		return "", status.Error(codes.InvalidArgument, "failed to pay the relayer fee")
+	}
+	// the batch is atomic without refund receiver, it reverts if any of the requests is invalid
+	if data, err = _forwarderABI.Pack("executeBatch", []forwardRequestData{*fee, *tx.requestData()}, common.Address{}); err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	gasLimit, _, err := core.EstimateExecutionGasConsumption(ctx,
+		(&action.EnvelopeBuilder{}).SetAction(action.NewExecution(forwarder, big.NewInt(0), data)).Build(), relayerAdr)
+	if err != nil {
+		return "", err
+	}
+	gasPrice, err := core.SuggestGasPrice()
+	if err != nil {
+		return "", err
+	}
+
+	relayer.mu.Lock()
+	defer relayer.mu.Unlock()
+	nonce, err := core.PendingNonce(relayerAdr)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).
+		SetGasLimit(gasLimit).
+		SetGasPrice(new(big.Int).SetUint64(gasPrice)).
+		SetChainID(core.ChainID()).
+		SetAction(action.NewExecution(forwarder, big.NewInt(0), data)).Build()
+	selp, err := action.Sign(elp, relayer.sk)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	return core.SendAction(ctx, selp.Proto())
+}
+
+// validate checks the meta transaction and its fee payment before simulating them on the forwarder
+func (relayer *metaTxRelayer) validate(tx *MetaTransaction, evmNetworkID uint32) error {
+	if _, ok := relayer.targets[tx.To]; !ok {
+		return errors.Wrapf(ErrInvalidMetaTransaction, "target %s is not allowed", tx.To.Hex())
+	}
+	if err := relayer.validateRequest(tx, evmNetworkID); err != nil {
+		return err
+	}
+	fee := tx.Fee
+	if fee == nil {
+		return errors.Wrap(ErrInvalidMetaTransaction, "relayer fee is missing")
+	}
+	if fee.To != common.BytesToAddress(relayer.feeToken.Bytes()) {
+		return errors.Wrapf(ErrInvalidMetaTransaction, "fee is not paid in the fee token %s", relayer.feeToken.Hex())
+	}
+	if fee.From != tx.From {
+		return errors.Wrapf(ErrInvalidMetaTransaction, "fee is paid by %s instead of the sender", fee.From.Hex())
+	}
+	if err := relayer.validateRequest(fee, evmNetworkID); err != nil {
+		return errors.Wrap(err, "invalid fee payment")
+	}
+	// the fee payment is executed right before the call
+	if new(big.Int).Add(fee.Nonce, big.NewInt(1)).Cmp(tx.Nonce) != 0 {
+		return errors.Wrapf(ErrInvalidMetaTransaction, "nonce %s of fee payment is not right before the nonce %s of call", fee.Nonce, tx.Nonce)
+	}
+	if len(fee.Data) < 4 || !bytes.Equal(fee.Data[:4], _feeTokenABI.Methods["transfer"].ID) {
+		return errors.Wrap(ErrInvalidMetaTransaction, "fee payment is not a token transfer")
+	}
+	args, err := _feeTokenABI.Methods["transfer"].Inputs.Unpack(fee.Data[4:])
+	if err != nil || len(args) != 2 {
+		return errors.Wrap(ErrInvalidMetaTransaction, "failed to decode the fee payment")
+	}
+	recipient, ok := args[0].(common.Address)
+	if !ok || recipient != common.BytesToAddress(relayer.sk.PublicKey().Address().Bytes()) {
+		return errors.Wrap(ErrInvalidMetaTransaction, "fee is not paid to the relayer")
+	}
+	amount, ok := args[1].(*big.Int)
+	if !ok {
+		return errors.Wrap(ErrInvalidMetaTransaction, "failed to decode the fee amount")
+	}
+	// the relayer pays the gas of both the call and the fee payment
+	minFee := new(big.Int).Mul(new(big.Int).SetUint64(relayer.cfg.FeePerGas), new(big.Int).SetUint64(tx.Gas+fee.Gas))
+	if amount.Cmp(minFee) < 0 {
+		return errors.Wrapf(ErrInvalidMetaTransaction, "fee %s is less than %s", amount, minFee)
+	}
+	return nil
+}
+
+// validateRequest checks the gas, the value, the deadline and the signature of a forward request
+func (relayer *metaTxRelayer) validateRequest(tx *MetaTransaction, evmNetworkID uint32) error {
+	if tx.Gas == 0 || tx.Gas > relayer.cfg.MaxGas {
+		return errors.Wrapf(ErrInvalidMetaTransaction, "gas %d is out of range (0, %d]", tx.Gas, relayer.cfg.MaxGas)
+	}
+	// the relayer pays the gas fee only, not the value of the call
+	if tx.Value == nil || tx.Value.Sign() != 0 {
+		return errors.Wrap(ErrInvalidMetaTransaction, "meta transaction with value is not supported")
+	}
+	if tx.Deadline < uint64(time.Now().Unix()) {
+		return errors.Wrapf(ErrInvalidMetaTransaction, "meta transaction expired at %d", tx.Deadline)
+	}
+	h, err := tx.Hash(relayer.cfg.ForwarderName, relayer.cfg.ForwarderVersion, evmNetworkID, common.BytesToAddress(relayer.forwarder.Bytes()))
+	if err != nil {
+		return err
+	}
+	sender, err := tx.Sender(h)
+	if err != nil {
+		return err
+	}
+	if sender != tx.From {
+		return errors.Wrapf(ErrInvalidMetaTransaction, "signer %s is not the sender %s", sender.Hex(), tx.From.Hex())
+	}
+	return nil
+}
+
+// allow returns whether the sender has quota to relay a meta transaction
+func (relayer *metaTxRelayer) allow(sender common.Address) bool {
+	relayer.senderMutex.Lock()
+	defer relayer.senderMutex.Unlock()
+	if v, ok := relayer.senders.Get(sender); ok {
+		return v.(*rate.Limiter).Allow()
+	}
+	quota := relayer.cfg.SenderQuota
+	limiter := rate.NewLimiter(rate.Limit(quota.Rate), quota.Burst)
+	relayer.senders.Set(sender, limiter)
+	return limiter.Allow()
+}
+
+// unpackBool returns whether the hex encoded result of the method is true
+func unpackBool(method string, contractABI abi.ABI, res string) bool {
+	data, err := hex.DecodeString(res)
+	if err != nil {
+		return false
+	}
+	out, err := contractABI.Unpack(method, data)
+	if err != nil || len(out) != 1 {
+		return false
+	}
+	ok, _ := out[0].(bool)
+	return ok
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestMetaTransaction(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultConfig.MetaTxRelay
+	cfg.Forwarder = identityset.Address(30).String()
+	cfg.PrivateKey = identityset.PrivateKey(29).HexString()
+	require.True(cfg.enabled())
+	_, err := newMetaTxRelayer(cfg)
+	require.Error(err)
+	cfg.FeeToken = identityset.Address(31).String()
+	cfg.FeePerGas = 10
+	cfg.AllowedTargets = []string{identityset.Address(28).String()}
+	relayer, err := newMetaTxRelayer(cfg)
+	require.NoError(err)
+
+	var (
+		sk           = identityset.PrivateKey(27)
+		evmNetworkID = uint32(4689)
+		forwarder    = common.BytesToAddress(identityset.Address(30).Bytes())
+		relayerAddr  = common.BytesToAddress(identityset.Address(29).Bytes())
+	)
+	sign := func(tx *MetaTransaction) {
+		h, err := tx.Hash(cfg.ForwarderName, cfg.ForwarderVersion, evmNetworkID, forwarder)
+		require.NoError(err)
+		sig, err := sk.Sign(h[:])
+		require.NoError(err)
+		// the wallets return v = 27 or 28
+		sig[64] += 27
+		tx.Signature = sig
+	}
+	newFee := func(recipient common.Address, amount int64) *MetaTransaction {
+		data, err := _feeTokenABI.Pack("transfer", recipient, big.NewInt(amount))
+		require.NoError(err)
+		fee := &MetaTransaction{
+			From:     common.BytesToAddress(sk.PublicKey().Address().Bytes()),
+			To:       common.BytesToAddress(identityset.Address(31).Bytes()),
+			Value:    big.NewInt(0),
+			Gas:      50000,
+			Nonce:    big.NewInt(2),
+			Deadline: uint64(time.Now().Add(time.Hour).Unix()),
+			Data:     data,
+		}
+		sign(fee)
+		return fee
+	}
+	newTx := func() *MetaTransaction {
+		tx := &MetaTransaction{
+			From:     common.BytesToAddress(sk.PublicKey().Address().Bytes()),
+			To:       common.BytesToAddress(identityset.Address(28).Bytes()),
+			Value:    big.NewInt(0),
+			Gas:      100000,
+			Nonce:    big.NewInt(3),
+			Deadline: uint64(time.Now().Add(time.Hour).Unix()),
+			Data:     []byte{1, 2, 3},
+		}
+		sign(tx)
+		tx.Fee = newFee(relayerAddr, 1500000)
+		return tx
+	}
+
+	t.Run("Signature", func(t *testing.T) {
+		tx := newTx()
+		h, err := tx.Hash(cfg.ForwarderName, cfg.ForwarderVersion, evmNetworkID, forwarder)
+		require.NoError(err)
+		sender, err := tx.Sender(h)
+		require.NoError(err)
+		require.Equal(tx.From, sender)
+		// the hash is bound to the chain and the forwarder
+		h2, err := tx.Hash(cfg.ForwarderName, cfg.ForwarderVersion, evmNetworkID+1, forwarder)
+		require.NoError(err)
+		require.NotEqual(h, h2)
+		h2, err = tx.Hash(cfg.ForwarderName, cfg.ForwarderVersion, evmNetworkID, common.Address{})
+		require.NoError(err)
+		require.NotEqual(h, h2)
+		tx.Signature = tx.Signature[:64]
+		_, err = tx.Sender(h)
+		require.ErrorIs(err, ErrInvalidMetaTransaction)
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		require.NoError(relayer.validate(newTx(), evmNetworkID))
+		for _, c := range []struct {
+			name   string
+			modify func(*MetaTransaction)
+		}{
+			{"ZeroGas", func(tx *MetaTransaction) { tx.Gas = 0 }},
+			{"GasTooLarge", func(tx *MetaTransaction) { tx.Gas = cfg.MaxGas + 1 }},
+			{"Value", func(tx *MetaTransaction) { tx.Value = big.NewInt(1) }},
+			{"Expired", func(tx *MetaTransaction) { tx.Deadline = uint64(time.Now().Add(-time.Minute).Unix()) }},
+			{"Tampered", func(tx *MetaTransaction) { tx.Data = []byte{4} }},
+			{"WrongSender", func(tx *MetaTransaction) { tx.From = common.Address{} }},
+			{"TargetNotAllowed", func(tx *MetaTransaction) { tx.To = relayerAddr }},
+			{"NoFee", func(tx *MetaTransaction) { tx.Fee = nil }},
+			{"FeeTooLow", func(tx *MetaTransaction) { tx.Fee = newFee(relayerAddr, 1499999) }},
+			{"FeeNotToRelayer", func(tx *MetaTransaction) { tx.Fee = newFee(tx.To, 1500000) }},
+			{"FeeNotInToken", func(tx *MetaTransaction) { tx.Fee.To = tx.To; sign(tx.Fee) }},
+			{"FeeNonce", func(tx *MetaTransaction) { tx.Fee.Nonce = big.NewInt(3); sign(tx.Fee) }},
+			{"FeeTampered", func(tx *MetaTransaction) { tx.Fee.Gas = 60000 }},
+		} {
+			tx := newTx()
+			c.modify(tx)
+			require.True(errors.Is(relayer.validate(tx, evmNetworkID), ErrInvalidMetaTransaction), c.name)
+		}
+	})
+
+	t.Run("SenderQuota", func(t *testing.T) {
+		from := common.BytesToAddress(sk.PublicKey().Address().Bytes())
+		for i := 0; i < cfg.SenderQuota.Burst; i++ {
+			require.True(relayer.allow(from))
+		}
+		require.False(relayer.allow(from))
+		// the quota is per sender
+		require.True(relayer.allow(relayerAddr))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		core := &coreService{}
+		_, err := core.RelayMetaTransaction(context.Background(), newTx())
+		require.Equal(codes.Unimplemented, status.Code(err))
+	})
+}
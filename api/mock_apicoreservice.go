@@ -16,10 +16,15 @@ import (
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/v2/action"
 	protocol "github.com/iotexproject/iotex-core/v2/action/protocol"
+	poll "github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	actpool "github.com/iotexproject/iotex-core/v2/actpool"
 	logfilter "github.com/iotexproject/iotex-core/v2/api/logfilter"
 	types "github.com/iotexproject/iotex-core/v2/api/types"
 	block "github.com/iotexproject/iotex-core/v2/blockchain/block"
 	genesis "github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	blockindex "github.com/iotexproject/iotex-core/v2/blockindex"
+	scheme "github.com/iotexproject/iotex-core/v2/consensus/scheme"
+	gasstation "github.com/iotexproject/iotex-core/v2/gasstation"
 	iotexapi "github.com/iotexproject/iotex-proto/golang/iotexapi"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -125,6 +130,36 @@ func (mr *MockCoreServiceMockRecorder) ActionsByAddress(addr, start, count inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).ActionsByAddress), addr, start, count)
 }
 
+// ActPoolContent mocks base method.
+func (m *MockCoreService) ActPoolContent() (map[string]*actpool.Content, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActPoolContent")
+	ret0, _ := ret[0].(map[string]*actpool.Content)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActPoolContent indicates an expected call of ActPoolContent.
+func (mr *MockCoreServiceMockRecorder) ActPoolContent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActPoolContent", reflect.TypeOf((*MockCoreService)(nil).ActPoolContent))
+}
+
+// AccessSetInActPool mocks base method.
+func (m *MockCoreService) AccessSetInActPool(h hash.Hash256) (*actpool.AccessSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccessSetInActPool", h)
+	ret0, _ := ret[0].(*actpool.AccessSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccessSetInActPool indicates an expected call of AccessSetInActPool.
+func (mr *MockCoreServiceMockRecorder) AccessSetInActPool(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessSetInActPool", reflect.TypeOf((*MockCoreService)(nil).AccessSetInActPool), h)
+}
+
 // ActionsInActPool mocks base method.
 func (m *MockCoreService) ActionsInActPool(actHashes []string) ([]*action.SealedEnvelope, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +175,21 @@ func (mr *MockCoreServiceMockRecorder) ActionsInActPool(actHashes interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionsInActPool", reflect.TypeOf((*MockCoreService)(nil).ActionsInActPool), actHashes)
 }
 
+// BalanceAt mocks base method.
+func (m *MockCoreService) BalanceAt(addr address.Address, height uint64) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceAt", addr, height)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceAt indicates an expected call of BalanceAt.
+func (mr *MockCoreServiceMockRecorder) BalanceAt(addr, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAt", reflect.TypeOf((*MockCoreService)(nil).BalanceAt), addr, height)
+}
+
 // BlobSidecarsByHeight mocks base method.
 func (m *MockCoreService) BlobSidecarsByHeight(height uint64) ([]*types.BlobSidecarResult, error) {
 	m.ctrl.T.Helper()
@@ -259,6 +309,36 @@ func (mr *MockCoreServiceMockRecorder) ChainMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainMeta", reflect.TypeOf((*MockCoreService)(nil).ChainMeta))
 }
 
+// DelegatePerformance mocks base method.
+func (m *MockCoreService) DelegatePerformance(delegate address.Address, start, count uint64) ([]*blockindex.DelegatePerformance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelegatePerformance", delegate, start, count)
+	ret0, _ := ret[0].([]*blockindex.DelegatePerformance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DelegatePerformance indicates an expected call of DelegatePerformance.
+func (mr *MockCoreServiceMockRecorder) DelegatePerformance(delegate, start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelegatePerformance", reflect.TypeOf((*MockCoreService)(nil).DelegatePerformance), delegate, start, count)
+}
+
+// DryRunNextEpoch mocks base method.
+func (m *MockCoreService) DryRunNextEpoch(ctx context.Context) (*poll.EpochDryRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunNextEpoch", ctx)
+	ret0, _ := ret[0].(*poll.EpochDryRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DryRunNextEpoch indicates an expected call of DryRunNextEpoch.
+func (mr *MockCoreServiceMockRecorder) DryRunNextEpoch(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunNextEpoch", reflect.TypeOf((*MockCoreService)(nil).DryRunNextEpoch), ctx)
+}
+
 // EVMNetworkID mocks base method.
 func (m *MockCoreService) EVMNetworkID() uint32 {
 	m.ctrl.T.Helper()
@@ -305,6 +385,21 @@ func (mr *MockCoreServiceMockRecorder) EpochMeta(epochNum interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EpochMeta", reflect.TypeOf((*MockCoreService)(nil).EpochMeta), epochNum)
 }
 
+// EpochPerformance mocks base method.
+func (m *MockCoreService) EpochPerformance(epoch uint64) ([]*blockindex.DelegatePerformance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EpochPerformance", epoch)
+	ret0, _ := ret[0].([]*blockindex.DelegatePerformance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EpochPerformance indicates an expected call of EpochPerformance.
+func (mr *MockCoreServiceMockRecorder) EpochPerformance(epoch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EpochPerformance", reflect.TypeOf((*MockCoreService)(nil).EpochPerformance), epoch)
+}
+
 // EstimateExecutionGasConsumption mocks base method.
 func (m *MockCoreService) EstimateExecutionGasConsumption(ctx context.Context, sc action.Envelope, callerAddr address.Address, opts ...protocol.SimulateOption) (uint64, []byte, error) {
 	m.ctrl.T.Helper()
@@ -392,6 +487,35 @@ func (mr *MockCoreServiceMockRecorder) FeeHistory(ctx, blocks, lastBlock, reward
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockCoreService)(nil).FeeHistory), ctx, blocks, lastBlock, rewardPercentiles)
 }
 
+// FinalityProof mocks base method.
+func (m *MockCoreService) FinalityProof(height uint64) (*FinalityProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinalityProof", height)
+	ret0, _ := ret[0].(*FinalityProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinalityProof indicates an expected call of FinalityProof.
+func (mr *MockCoreServiceMockRecorder) FinalityProof(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalityProof", reflect.TypeOf((*MockCoreService)(nil).FinalityProof), height)
+}
+
+// GasTable mocks base method.
+func (m *MockCoreService) GasTable(height uint64) *action.GasTable {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasTable", height)
+	ret0, _ := ret[0].(*action.GasTable)
+	return ret0
+}
+
+// GasTable indicates an expected call of GasTable.
+func (mr *MockCoreServiceMockRecorder) GasTable(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasTable", reflect.TypeOf((*MockCoreService)(nil).GasTable), height)
+}
+
 // Genesis mocks base method.
 func (m *MockCoreService) Genesis() genesis.Genesis {
 	m.ctrl.T.Helper()
@@ -437,6 +561,21 @@ func (mr *MockCoreServiceMockRecorder) LogsInRange(filter, start, end, paginatio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogsInRange", reflect.TypeOf((*MockCoreService)(nil).LogsInRange), filter, start, end, paginationSize)
 }
 
+// NonceGaps mocks base method.
+func (m *MockCoreService) NonceGaps(addr address.Address) (*actpool.NonceGaps, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NonceGaps", addr)
+	ret0, _ := ret[0].(*actpool.NonceGaps)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NonceGaps indicates an expected call of NonceGaps.
+func (mr *MockCoreServiceMockRecorder) NonceGaps(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NonceGaps", reflect.TypeOf((*MockCoreService)(nil).NonceGaps), addr)
+}
+
 // PendingActionByActionHash mocks base method.
 func (m *MockCoreService) PendingActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, error) {
 	m.ctrl.T.Helper()
@@ -482,10 +621,29 @@ func (mr *MockCoreServiceMockRecorder) RawBlocks(startHeight, count, withReceipt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawBlocks", reflect.TypeOf((*MockCoreService)(nil).RawBlocks), startHeight, count, withReceipts, withTransactionLogs)
 }
 
+// ReadConsensusState mocks base method.
+func (m *MockCoreService) ReadConsensusState() (*scheme.ConsensusState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadConsensusState")
+	ret0, _ := ret[0].(*scheme.ConsensusState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadConsensusState indicates an expected call of ReadConsensusState.
+func (mr *MockCoreServiceMockRecorder) ReadConsensusState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadConsensusState", reflect.TypeOf((*MockCoreService)(nil).ReadConsensusState))
+}
+
 // ReadContract mocks base method.
-func (m *MockCoreService) ReadContract(ctx context.Context, callerAddr address.Address, sc action.Envelope) (string, *iotextypes.Receipt, error) {
+func (m *MockCoreService) ReadContract(ctx context.Context, callerAddr address.Address, sc action.Envelope, opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadContract", ctx, callerAddr, sc)
+	varargs := []interface{}{ctx, callerAddr, sc}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReadContract", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(*iotextypes.Receipt)
 	ret2, _ := ret[2].(error)
@@ -493,9 +651,25 @@ func (m *MockCoreService) ReadContract(ctx context.Context, callerAddr address.A
 }
 
 // ReadContract indicates an expected call of ReadContract.
-func (mr *MockCoreServiceMockRecorder) ReadContract(ctx, callerAddr, sc interface{}) *gomock.Call {
+func (mr *MockCoreServiceMockRecorder) ReadContract(ctx, callerAddr, sc interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreService)(nil).ReadContract), ctx, callerAddr, sc)
+	varargs := append([]interface{}{ctx, callerAddr, sc}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreService)(nil).ReadContract), varargs...)
+}
+
+// Preimage mocks base method.
+func (m *MockCoreService) Preimage(h hash.Hash160) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preimage", h)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preimage indicates an expected call of Preimage.
+func (mr *MockCoreServiceMockRecorder) Preimage(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preimage", reflect.TypeOf((*MockCoreService)(nil).Preimage), h)
 }
 
 // ReadContractStorage mocks base method.
@@ -557,6 +731,21 @@ func (mr *MockCoreServiceMockRecorder) ReceiveBlock(blk interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveBlock", reflect.TypeOf((*MockCoreService)(nil).ReceiveBlock), blk)
 }
 
+// RelayMetaTransaction mocks base method.
+func (m *MockCoreService) RelayMetaTransaction(ctx context.Context, tx *MetaTransaction) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayMetaTransaction", ctx, tx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayMetaTransaction indicates an expected call of RelayMetaTransaction.
+func (mr *MockCoreServiceMockRecorder) RelayMetaTransaction(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayMetaTransaction", reflect.TypeOf((*MockCoreService)(nil).RelayMetaTransaction), ctx, tx)
+}
+
 // SendAction mocks base method.
 func (m *MockCoreService) SendAction(ctx context.Context, in *iotextypes.Action) (string, error) {
 	m.ctrl.T.Helper()
@@ -590,6 +779,21 @@ func (mr *MockCoreServiceMockRecorder) ServerMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerMeta", reflect.TypeOf((*MockCoreService)(nil).ServerMeta))
 }
 
+// SimulateBlocks mocks base method.
+func (m *MockCoreService) SimulateBlocks(ctx context.Context, height uint64, blocks []*types.SimulateBlock) ([]*types.SimulatedBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateBlocks", ctx, height, blocks)
+	ret0, _ := ret[0].([]*types.SimulatedBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateBlocks indicates an expected call of SimulateBlocks.
+func (mr *MockCoreServiceMockRecorder) SimulateBlocks(ctx, height, blocks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateBlocks", reflect.TypeOf((*MockCoreService)(nil).SimulateBlocks), ctx, height, blocks)
+}
+
 // SimulateExecution mocks base method.
 func (m *MockCoreService) SimulateExecution(arg0 context.Context, arg1 address.Address, arg2 action.Envelope) ([]byte, *action.Receipt, error) {
 	m.ctrl.T.Helper()
@@ -620,6 +824,21 @@ func (mr *MockCoreServiceMockRecorder) Start(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockCoreService)(nil).Start), ctx)
 }
 
+// StateProof mocks base method.
+func (m *MockCoreService) StateProof(addr address.Address, keys []hash.Hash256) (*AccountProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateProof", addr, keys)
+	ret0, _ := ret[0].(*AccountProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateProof indicates an expected call of StateProof.
+func (mr *MockCoreServiceMockRecorder) StateProof(addr, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateProof", reflect.TypeOf((*MockCoreService)(nil).StateProof), addr, keys)
+}
+
 // Stop mocks base method.
 func (m *MockCoreService) Stop(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -634,6 +853,35 @@ func (mr *MockCoreServiceMockRecorder) Stop(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockCoreService)(nil).Stop), ctx)
 }
 
+// StreamActionsByAddress mocks base method.
+func (m *MockCoreService) StreamActionsByAddress(ctx context.Context, addr address.Address, cursor uint64, handler func(*iotexapi.ActionInfo, uint64) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamActionsByAddress", ctx, addr, cursor, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamActionsByAddress indicates an expected call of StreamActionsByAddress.
+func (mr *MockCoreServiceMockRecorder) StreamActionsByAddress(ctx, addr, cursor, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).StreamActionsByAddress), ctx, addr, cursor, handler)
+}
+
+// SuggestFees mocks base method.
+func (m *MockCoreService) SuggestFees(ctx context.Context) (*gasstation.FeeSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestFees", ctx)
+	ret0, _ := ret[0].(*gasstation.FeeSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestFees indicates an expected call of SuggestFees.
+func (mr *MockCoreServiceMockRecorder) SuggestFees(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestFees", reflect.TypeOf((*MockCoreService)(nil).SuggestFees), ctx)
+}
+
 // SuggestGasPrice mocks base method.
 func (m *MockCoreService) SuggestGasPrice() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -664,6 +912,20 @@ func (mr *MockCoreServiceMockRecorder) SuggestGasTipCap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestGasTipCap", reflect.TypeOf((*MockCoreService)(nil).SuggestGasTipCap))
 }
 
+// SyncingStages mocks base method.
+func (m *MockCoreService) SyncingStages() []*types.SyncStage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncingStages")
+	ret0, _ := ret[0].([]*types.SyncStage)
+	return ret0
+}
+
+// SyncingStages indicates an expected call of SyncingStages.
+func (mr *MockCoreServiceMockRecorder) SyncingStages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncingStages", reflect.TypeOf((*MockCoreService)(nil).SyncingStages))
+}
+
 // SyncingProgress mocks base method.
 func (m *MockCoreService) SyncingProgress() (uint64, uint64, uint64) {
 	m.ctrl.T.Helper()
@@ -694,6 +956,53 @@ func (mr *MockCoreServiceMockRecorder) TipHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TipHeight", reflect.TypeOf((*MockCoreService)(nil).TipHeight))
 }
 
+// TokenBalances mocks base method.
+func (m *MockCoreService) TokenBalances(holder address.Address) ([]*blockindex.TokenBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenBalances", holder)
+	ret0, _ := ret[0].([]*blockindex.TokenBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokenBalances indicates an expected call of TokenBalances.
+func (mr *MockCoreServiceMockRecorder) TokenBalances(holder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenBalances", reflect.TypeOf((*MockCoreService)(nil).TokenBalances), holder)
+}
+
+// TokenHolders mocks base method.
+func (m *MockCoreService) TokenHolders(contract address.Address, start, count uint64) (uint64, []*blockindex.TokenBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenHolders", contract, start, count)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].([]*blockindex.TokenBalance)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TokenHolders indicates an expected call of TokenHolders.
+func (mr *MockCoreServiceMockRecorder) TokenHolders(contract, start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenHolders", reflect.TypeOf((*MockCoreService)(nil).TokenHolders), contract, start, count)
+}
+
+// TokenTransfers mocks base method.
+func (m *MockCoreService) TokenTransfers(addr address.Address, byContract bool, start, count uint64) (uint64, []*blockindex.TokenTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenTransfers", addr, byContract, start, count)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].([]*blockindex.TokenTransfer)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TokenTransfers indicates an expected call of TokenTransfers.
+func (mr *MockCoreServiceMockRecorder) TokenTransfers(addr, byContract, start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenTransfers", reflect.TypeOf((*MockCoreService)(nil).TokenTransfers), addr, byContract, start, count)
+}
+
 // TraceCall mocks base method.
 func (m *MockCoreService) TraceCall(ctx context.Context, callerAddr address.Address, blkNumOrHash any, contractAddress string, nonce uint64, amount *big.Int, gasLimit uint64, data []byte, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
 	m.ctrl.T.Helper()
@@ -771,6 +1080,22 @@ func (mr *MockCoreServiceMockRecorder) TransactionLogByBlockHeight(blockHeight i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionLogByBlockHeight", reflect.TypeOf((*MockCoreService)(nil).TransactionLogByBlockHeight), blockHeight)
 }
 
+// TransferHistory mocks base method.
+func (m *MockCoreService) TransferHistory(addr address.Address, start, count uint64) (uint64, []*blockindex.BalanceTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferHistory", addr, start, count)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].([]*blockindex.BalanceTransfer)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TransferHistory indicates an expected call of TransferHistory.
+func (mr *MockCoreServiceMockRecorder) TransferHistory(addr, start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferHistory", reflect.TypeOf((*MockCoreService)(nil).TransferHistory), addr, start, count)
+}
+
 // UnconfirmedActionsByAddress mocks base method.
 func (m *MockCoreService) UnconfirmedActionsByAddress(address string, start, count uint64) ([]*iotexapi.ActionInfo, error) {
 	m.ctrl.T.Helper()
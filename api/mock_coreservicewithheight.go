@@ -9,8 +9,10 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	hash "github.com/iotexproject/go-pkgs/hash"
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/v2/action"
+	protocol "github.com/iotexproject/iotex-core/v2/action/protocol"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
 
@@ -54,9 +56,13 @@ func (mr *MockCoreServiceReaderWithHeightMockRecorder) Account(arg0 interface{})
 }
 
 // ReadContract mocks base method.
-func (m *MockCoreServiceReaderWithHeight) ReadContract(arg0 context.Context, arg1 address.Address, arg2 action.Envelope) (string, *iotextypes.Receipt, error) {
+func (m *MockCoreServiceReaderWithHeight) ReadContract(arg0 context.Context, arg1 address.Address, arg2 action.Envelope, arg3 ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadContract", arg0, arg1, arg2)
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReadContract", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(*iotextypes.Receipt)
 	ret2, _ := ret[2].(error)
@@ -64,7 +70,23 @@ func (m *MockCoreServiceReaderWithHeight) ReadContract(arg0 context.Context, arg
 }
 
 // ReadContract indicates an expected call of ReadContract.
-func (mr *MockCoreServiceReaderWithHeightMockRecorder) ReadContract(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) ReadContract(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).ReadContract), arg0, arg1, arg2)
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).ReadContract), varargs...)
+}
+
+// StateProof mocks base method.
+func (m *MockCoreServiceReaderWithHeight) StateProof(arg0 address.Address, arg1 []hash.Hash256) (*AccountProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateProof", arg0, arg1)
+	ret0, _ := ret[0].(*AccountProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateProof indicates an expected call of StateProof.
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) StateProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateProof", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).StateProof), arg0, arg1)
 }
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+)
+
+// PerformanceServiceServer is the server API of the performance service, which serves the produced blocks, the missed
+// slots, the block fullness and the endorsement participation of the delegates in each epoch
+type PerformanceServiceServer interface {
+	// GetEpochPerformance returns the performance of the delegates in the "epoch"
+	GetEpochPerformance(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	// GetDelegatePerformance returns the performance of the delegate of the "address" in the epochs
+	// ["start", "start"+"count")
+	GetDelegatePerformance(context.Context, *structpb.Struct) (*structpb.Struct, error)
+}
+
+// PerformanceServiceDesc is the grpc service descriptor of the performance service, e.g.,
+// grpcurl -plaintext -d '{"epoch":100}' localhost:14014 iotexcore.PerformanceService/GetEpochPerformance
+var PerformanceServiceDesc = grpc.ServiceDesc{
+	ServiceName: "iotexcore.PerformanceService",
+	HandlerType: (*PerformanceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEpochPerformance",
+			Handler:    performanceServiceHandler("GetEpochPerformance", PerformanceServiceServer.GetEpochPerformance),
+		},
+		{
+			MethodName: "GetDelegatePerformance",
+			Handler:    performanceServiceHandler("GetDelegatePerformance", PerformanceServiceServer.GetDelegatePerformance),
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "performanceservice",
+}
+
+type (
+	performanceService struct {
+		core CoreService
+	}
+
+	delegatePerformancesResult struct {
+		perfs []*blockindex.DelegatePerformance
+	}
+
+	delegatePerformanceResult struct {
+		Epoch         uint64  `json:"epoch"`
+		Delegate      string  `json:"delegate"`
+		EpochBlocks   uint64  `json:"epochBlocks"`
+		Produced      uint64  `json:"produced"`
+		Missed        uint64  `json:"missed"`
+		Fullness      float64 `json:"fullness"`
+		Endorsed      uint64  `json:"endorsed"`
+		Participation float64 `json:"participation"`
+	}
+)
+
+func newPerformanceService(core CoreService) *performanceService {
+	return &performanceService{
+		core: core,
+	}
+}
+
+// GetEpochPerformance returns the performance of the delegates in the epoch
+func (service *performanceService) GetEpochPerformance(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	epoch := uint64(in.GetFields()["epoch"].GetNumberValue())
+	perfs, err := service.core.EpochPerformance(epoch)
+	if err != nil {
+		return nil, err
+	}
+	return toStruct(map[string]any{"performances": &delegatePerformancesResult{perfs: perfs}})
+}
+
+// GetDelegatePerformance returns the performance of the delegate in the epochs [start, start+count)
+func (service *performanceService) GetDelegatePerformance(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	addr, err := tokenRequestAddress(in)
+	if err != nil {
+		return nil, err
+	}
+	start, count := tokenRequestRange(in)
+	perfs, err := service.core.DelegatePerformance(addr, start, count)
+	if err != nil {
+		return nil, err
+	}
+	return toStruct(map[string]any{"performances": &delegatePerformancesResult{perfs: perfs}})
+}
+
+func (obj *delegatePerformancesResult) MarshalJSON() ([]byte, error) {
+	perfs := make([]delegatePerformanceResult, 0, len(obj.perfs))
+	for _, p := range obj.perfs {
+		perfs = append(perfs, delegatePerformanceResult{
+			Epoch:         p.Epoch,
+			Delegate:      p.Delegate.String(),
+			EpochBlocks:   p.EpochBlocks,
+			Produced:      p.Produced,
+			Missed:        p.Missed,
+			Fullness:      p.Fullness(),
+			Endorsed:      p.Endorsed,
+			Participation: p.Participation(),
+		})
+	}
+	return json.Marshal(perfs)
+}
+
+func performanceServiceHandler(
+	method string,
+	call func(PerformanceServiceServer, context.Context, *structpb.Struct) (*structpb.Struct, error),
+) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		in := new(structpb.Struct)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(srv.(PerformanceServiceServer), ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: "/iotexcore.PerformanceService/" + method,
+		}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return call(srv.(PerformanceServiceServer), ctx, req.(*structpb.Struct))
+		}
+		return interceptor(ctx, in, info, handler)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestPerformanceService(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	service := newPerformanceService(core)
+	ctx := context.Background()
+
+	delegate := identityset.Address(1)
+	newRequest := func(fields map[string]any) *structpb.Struct {
+		in, err := structpb.NewStruct(fields)
+		require.NoError(err)
+		return in
+	}
+	perf := &blockindex.DelegatePerformance{
+		Epoch:       7,
+		Delegate:    delegate,
+		EpochBlocks: 10,
+		Produced:    2,
+		Missed:      1,
+		GasUsed:     50,
+		GasLimit:    200,
+		Endorsed:    8,
+	}
+
+	t.Run("GetEpochPerformance", func(t *testing.T) {
+		core.EXPECT().EpochPerformance(uint64(7)).Return([]*blockindex.DelegatePerformance{perf}, nil).Times(1)
+		res, err := service.GetEpochPerformance(ctx, newRequest(map[string]any{"epoch": 7}))
+		require.NoError(err)
+		perfs := res.GetFields()["performances"].GetListValue().GetValues()
+		require.Len(perfs, 1)
+		fields := perfs[0].GetStructValue().GetFields()
+		require.Equal(delegate.String(), fields["delegate"].GetStringValue())
+		require.EqualValues(2, fields["produced"].GetNumberValue())
+		require.EqualValues(1, fields["missed"].GetNumberValue())
+		require.Equal(0.25, fields["fullness"].GetNumberValue())
+		require.Equal(0.8, fields["participation"].GetNumberValue())
+
+		core.EXPECT().EpochPerformance(uint64(8)).Return(nil, status.Error(codes.Unimplemented, "performance index is not enabled")).Times(1)
+		_, err = service.GetEpochPerformance(ctx, newRequest(map[string]any{"epoch": 8}))
+		require.Equal(codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("GetDelegatePerformance", func(t *testing.T) {
+		core.EXPECT().DelegatePerformance(gomock.Any(), uint64(5), uint64(3)).Return([]*blockindex.DelegatePerformance{perf}, nil).Times(1)
+		res, err := service.GetDelegatePerformance(ctx, newRequest(map[string]any{
+			"address": delegate.String(),
+			"start":   5,
+			"count":   3,
+		}))
+		require.NoError(err)
+		perfs := res.GetFields()["performances"].GetListValue().GetValues()
+		require.Len(perfs, 1)
+		require.EqualValues(7, perfs[0].GetStructValue().GetFields()["epoch"].GetNumberValue())
+
+		_, err = service.GetDelegatePerformance(ctx, newRequest(map[string]any{"address": "invalid"}))
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache/ttl"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	_anonymousTier = "anonymous"
+
+	_grpcServerName = "grpc"
+	_web3ServerName = "web3"
+)
+
+var (
+	_throttledCallMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iotex_api_throttled_calls",
+		Help: "api calls throttled by rate limiting.",
+	}, []string{"server", "method", "tier"})
+
+	errRateLimited = status.Error(codes.ResourceExhausted, "rate limit exceeded")
+)
+
+type (
+	// RateLimitQuota is the quota of a client, which is allowed to make Rate calls per second on average, and at
+	// most Burst calls at once
+	RateLimitQuota struct {
+		Rate  float64 `yaml:"rate"`
+		Burst int     `yaml:"burst"`
+	}
+
+	// RateLimitConfig is the config of rate limiting the calls to the api servers
+	RateLimitConfig struct {
+		Enabled bool `yaml:"enabled"`
+		// RateLimitQuota is the quota of the clients without a valid api key, which are limited by ip
+		RateLimitQuota `yaml:",inline"`
+		// APIKeyHeader is the http header, or the grpc metadata, carrying the api key
+		APIKeyHeader string `yaml:"apiKeyHeader"`
+		// RealIPHeader is the http header carrying the ip of the client set by a trusted proxy, the ip of the
+		// connection is used if it is empty
+		RealIPHeader string `yaml:"realIPHeader"`
+		// Tiers is the quota of the api key tiers
+		Tiers map[string]RateLimitQuota `yaml:"tiers"`
+		// APIKeys maps the api keys to their tiers
+		APIKeys map[string]string `yaml:"apiKeys"`
+		// MethodWeights is the number of calls a method counts for, which is 1 by default. Web3 methods are named as
+		// "eth_call", and grpc methods are named as "/iotexapi.APIService/ReadContract"
+		MethodWeights map[string]int `yaml:"methodWeights"`
+		// ClientTTL is the duration the limiter of an idle client is kept
+		ClientTTL time.Duration `yaml:"clientTTL"`
+	}
+
+	// RateLimiter limits the calls of the clients by their api keys, or by their ips
+	RateLimiter struct {
+		cfg      RateLimitConfig
+		mutex    sync.Mutex
+		limiters *ttl.Cache
+		// quotaMutex guards the quotas, the api keys and the method weights, which are adjustable at runtime
+		quotaMutex sync.RWMutex
+	}
+
+	rateLimitContextKey struct{}
+
+	// rateLimitClient is the client of an http request to be rate limited
+	rateLimitClient struct {
+		limiter *RateLimiter
+		ip      string
+		apiKey  string
+	}
+)
+
+func init() {
+	prometheus.MustRegister(_throttledCallMtc)
+}
+
+// NewRateLimiter creates a rate limiter, it returns nil if rate limiting is disabled
+func NewRateLimiter(cfg RateLimitConfig) (*RateLimiter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	opts := []ttl.Option{}
+	if cfg.ClientTTL > 0 {
+		opts = append(opts, ttl.AutoExpireOption(cfg.ClientTTL))
+	}
+	limiters, err := ttl.NewCache(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimiter{
+		cfg:      cfg,
+		limiters: limiters,
+	}, nil
+}
+
+func (cfg *RateLimitConfig) validate() error {
+	if err := cfg.RateLimitQuota.validate(); err != nil {
+		return errors.Wrap(err, "invalid default quota")
+	}
+	for tier, quota := range cfg.Tiers {
+		if err := quota.validate(); err != nil {
+			return errors.Wrapf(err, "invalid quota of tier %s", tier)
+		}
+	}
+	if err := cfg.validateAPIKeys(cfg.APIKeys); err != nil {
+		return err
+	}
+	for method, weight := range cfg.MethodWeights {
+		if weight < 0 {
+			return errors.Errorf("negative weight %d of method %s", weight, method)
+		}
+	}
+	return nil
+}
+
+func (cfg *RateLimitConfig) validateAPIKeys(keys map[string]string) error {
+	for key, tier := range keys {
+		if key == "" {
+			return errors.New("empty api key")
+		}
+		if _, ok := cfg.Tiers[tier]; !ok {
+			return errors.Errorf("tier %s of api key is not defined", tier)
+		}
+	}
+	return nil
+}
+
+func (q RateLimitQuota) validate() error {
+	if q.Rate <= 0 || q.Burst <= 0 {
+		return errors.Errorf("rate %f and burst %d should be positive", q.Rate, q.Burst)
+	}
+	return nil
+}
+
+// Allow returns errRateLimited if the client has run out of its quota to call the method
+func (rl *RateLimiter) Allow(server, ip, apiKey, method string) error {
+	rl.quotaMutex.RLock()
+	weight := 1
+	if w, ok := rl.cfg.MethodWeights[method]; ok {
+		weight = w
+	}
+	key, tier, quota := "ip:"+ip, _anonymousTier, rl.cfg.RateLimitQuota
+	if t, ok := rl.cfg.APIKeys[apiKey]; ok {
+		key, tier, quota = "key:"+apiKey, t, rl.cfg.Tiers[t]
+	}
+	rl.quotaMutex.RUnlock()
+	if weight == 0 {
+		return nil
+	}
+	if rl.limiter(key, quota).AllowN(time.Now(), weight) {
+		return nil
+	}
+	_throttledCallMtc.WithLabelValues(server, method, tier).Inc()
+	return errRateLimited
+}
+
+// SetQuotas replaces the quotas, the tiers, the api keys and the method weights by the config. The limiters of the
+// clients are reset, so that the new quotas take effect at once
+func (rl *RateLimiter) SetQuotas(cfg RateLimitConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	rl.quotaMutex.Lock()
+	rl.cfg.RateLimitQuota = cfg.RateLimitQuota
+	rl.cfg.Tiers = cfg.Tiers
+	rl.cfg.APIKeys = cfg.APIKeys
+	rl.cfg.MethodWeights = cfg.MethodWeights
+	rl.quotaMutex.Unlock()
+	rl.mutex.Lock()
+	rl.limiters.Reset()
+	rl.mutex.Unlock()
+	return nil
+}
+
+// SetAPIKeys replaces the api keys with their tiers. The clients of the revoked keys are limited by ip at once, and
+// the limiters of the keys expire as idle clients
+func (rl *RateLimiter) SetAPIKeys(keys map[string]string) error {
+	rl.quotaMutex.Lock()
+	defer rl.quotaMutex.Unlock()
+	if err := rl.cfg.validateAPIKeys(keys); err != nil {
+		return err
+	}
+	rl.cfg.APIKeys = keys
+	return nil
+}
+
+func (rl *RateLimiter) limiter(key string, quota RateLimitQuota) *rate.Limiter {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+	if v, ok := rl.limiters.Get(key); ok {
+		return v.(*rate.Limiter)
+	}
+	limiter := rate.NewLimiter(rate.Limit(quota.Rate), quota.Burst)
+	rl.limiters.Set(key, limiter)
+	return limiter
+}
+
+// rateLimitHandler puts the client of the request into the context, the web3 calls of the client are rate limited
+// by allowWeb3Call
+func rateLimitHandler(rl *RateLimiter, next http.Handler) http.Handler {
+	if rl == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		client := &rateLimitClient{
+			limiter: rl,
+			ip:      requestIP(req, rl.cfg.RealIPHeader),
+		}
+		if rl.cfg.APIKeyHeader != "" {
+			client.apiKey = req.Header.Get(rl.cfg.APIKeyHeader)
+		}
+		next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), rateLimitContextKey{}, client)))
+	})
+}
+
+func allowWeb3Call(ctx context.Context, method string) error {
+	client, ok := ctx.Value(rateLimitContextKey{}).(*rateLimitClient)
+	if !ok {
+		return nil
+	}
+	return client.limiter.Allow(_web3ServerName, client.ip, client.apiKey, method)
+}
+
+func requestIP(req *http.Request, realIPHeader string) string {
+	if realIPHeader != "" {
+		// the first one is the client if the header is a list of proxies like X-Forwarded-For
+		if ip, _, _ := strings.Cut(req.Header.Get(realIPHeader), ","); strings.TrimSpace(ip) != "" {
+			return strings.TrimSpace(ip)
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+func (rl *RateLimiter) allowGRPCCall(ctx context.Context, method string) error {
+	var ip, apiKey string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && rl.cfg.APIKeyHeader != "" {
+		if keys := md.Get(rl.cfg.APIKeyHeader); len(keys) > 0 {
+			apiKey = keys[0]
+		}
+	}
+	return rl.Allow(_grpcServerName, ip, apiKey, method)
+}
+
+// UnaryServerInterceptor returns the interceptor rate limiting the unary grpc calls
+func (rl *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := rl.allowGRPCCall(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns the interceptor rate limiting the streaming grpc calls
+func (rl *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rl.allowGRPCCall(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func testRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Enabled:        true,
+		RateLimitQuota: RateLimitQuota{Rate: 0.001, Burst: 2},
+		APIKeyHeader:   "X-API-Key",
+		Tiers: map[string]RateLimitQuota{
+			"pro": {Rate: 0.001, Burst: 4},
+		},
+		APIKeys: map[string]string{
+			"key1": "pro",
+		},
+		MethodWeights: map[string]int{
+			"eth_chainId": 0,
+			"eth_call":    2,
+		},
+		ClientTTL: time.Minute,
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	require := require.New(t)
+	rl, err := NewRateLimiter(DefaultConfig.RateLimit)
+	require.NoError(err)
+	require.Nil(rl)
+
+	for _, modify := range []func(*RateLimitConfig){
+		func(cfg *RateLimitConfig) { cfg.Burst = 0 },
+		func(cfg *RateLimitConfig) { cfg.Tiers["pro"] = RateLimitQuota{Rate: 0, Burst: 1} },
+		func(cfg *RateLimitConfig) { cfg.APIKeys["key2"] = "enterprise" },
+		func(cfg *RateLimitConfig) { cfg.APIKeys[""] = "pro" },
+		func(cfg *RateLimitConfig) { cfg.MethodWeights["eth_getLogs"] = -1 },
+	} {
+		cfg := testRateLimitConfig()
+		modify(&cfg)
+		_, err = NewRateLimiter(cfg)
+		require.Error(err)
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	require := require.New(t)
+	rl, err := NewRateLimiter(testRateLimitConfig())
+	require.NoError(err)
+
+	// anonymous clients are limited by ip
+	require.NoError(rl.Allow(_web3ServerName, "1.1.1.1", "", "eth_blockNumber"))
+	require.NoError(rl.Allow(_web3ServerName, "1.1.1.1", "unknown", "eth_blockNumber"))
+	require.Equal(errRateLimited, rl.Allow(_web3ServerName, "1.1.1.1", "", "eth_blockNumber"))
+	require.NoError(rl.Allow(_web3ServerName, "2.2.2.2", "", "eth_blockNumber"))
+	// method of weight 0 is not limited
+	require.NoError(rl.Allow(_web3ServerName, "1.1.1.1", "", "eth_chainId"))
+
+	// clients with api key are limited by the quota of the tier
+	for i := 0; i < 2; i++ {
+		require.NoError(rl.Allow(_web3ServerName, "1.1.1.1", "key1", "eth_call"))
+	}
+	require.Equal(errRateLimited, rl.Allow(_web3ServerName, "3.3.3.3", "key1", "eth_blockNumber"))
+
+	// the new quotas take effect at once
+	cfg := testRateLimitConfig()
+	cfg.Burst = 0
+	require.Error(rl.SetQuotas(cfg))
+	cfg = testRateLimitConfig()
+	cfg.Burst = 3
+	require.NoError(rl.SetQuotas(cfg))
+	for i := 0; i < 3; i++ {
+		require.NoError(rl.Allow(_web3ServerName, "1.1.1.1", "", "eth_blockNumber"))
+	}
+	require.Equal(errRateLimited, rl.Allow(_web3ServerName, "1.1.1.1", "", "eth_blockNumber"))
+
+	// rotate the api keys
+	require.Error(rl.SetAPIKeys(map[string]string{"key2": "enterprise"}))
+	require.NoError(rl.SetAPIKeys(map[string]string{"key2": "pro"}))
+	require.NoError(rl.Allow(_web3ServerName, "3.3.3.3", "key2", "eth_call"))
+	// the revoked key is limited by ip
+	require.NoError(rl.Allow(_web3ServerName, "4.4.4.4", "key1", "eth_blockNumber"))
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	require := require.New(t)
+	rl, err := NewRateLimiter(testRateLimitConfig())
+	require.NoError(err)
+
+	var results []error
+	handler := rateLimitHandler(rl, http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		results = append(results, allowWeb3Call(req.Context(), "eth_call"))
+	}))
+	for _, apiKey := range []string{"", "", "key1", "key1", "key1"} {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = "1.1.1.1:1234"
+		req.Header.Set("X-API-Key", apiKey)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	require.Equal([]error{nil, errRateLimited, nil, nil, errRateLimited}, results)
+
+	// real ip set by the proxy
+	cfg := testRateLimitConfig()
+	cfg.RealIPHeader = "X-Forwarded-For"
+	rl, err = NewRateLimiter(cfg)
+	require.NoError(err)
+	results = nil
+	handler = rateLimitHandler(rl, http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		results = append(results, allowWeb3Call(req.Context(), "eth_call"))
+	}))
+	for _, ip := range []string{"1.1.1.1, 10.0.0.1", "2.2.2.2", "1.1.1.1"} {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", ip)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	require.Equal([]error{nil, nil, errRateLimited}, results)
+
+	// calls are not limited without the handler
+	require.NoError(allowWeb3Call(context.Background(), "eth_call"))
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	require := require.New(t)
+	rl, err := NewRateLimiter(testRateLimitConfig())
+	require.NoError(err)
+
+	interceptor := rl.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/iotexapi.APIService/GetChainMeta"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 1234},
+	})
+	for i := 0; i < 2; i++ {
+		res, err := interceptor(ctx, nil, info, handler)
+		require.NoError(err)
+		require.Equal("ok", res)
+	}
+	_, err = interceptor(ctx, nil, info, handler)
+	require.Equal(codes.ResourceExhausted, status.Code(err))
+
+	// api key in the metadata
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", "key1"))
+	_, err = interceptor(ctx, nil, info, handler)
+	require.NoError(err)
+}
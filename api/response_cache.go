@@ -0,0 +1,126 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _responseCacheMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "iotex_api_response_cache",
+	Help: "hits and misses of the api response cache.",
+}, []string{"method", "result"})
+
+func init() {
+	prometheus.MustRegister(_responseCacheMtc)
+}
+
+type (
+	// ResponseCacheConfig is the config of the cache of hot read responses
+	ResponseCacheConfig struct {
+		// Size is the max number of responses of each kind in the cache, caching is disabled if it is 0
+		Size int `yaml:"size"`
+		// TTL is the duration a response is kept in the cache, responses never expire if it is 0
+		TTL time.Duration `yaml:"ttl"`
+	}
+
+	// responseCache is a LRU cache with TTL of the responses of read methods. The responses are either immutable,
+	// like the block of a hash, or pinned to the tip height, which are cleared on new blocks
+	responseCache struct {
+		enabled   bool
+		ttl       time.Duration
+		immutable cache.LRUCache
+		pinned    cache.LRUCache
+	}
+
+	responseCacheKey struct {
+		method string
+		key    any
+	}
+
+	responseCacheItem struct {
+		value    any
+		expireAt time.Time
+	}
+)
+
+func newResponseCache(cfg ResponseCacheConfig) *responseCache {
+	if cfg.Size <= 0 {
+		return &responseCache{
+			immutable: cache.NewDummyLruCache(),
+			pinned:    cache.NewDummyLruCache(),
+		}
+	}
+	return &responseCache{
+		enabled:   true,
+		ttl:       cfg.TTL,
+		immutable: cache.NewThreadSafeLruCache(cfg.Size),
+		pinned:    cache.NewThreadSafeLruCache(cfg.Size),
+	}
+}
+
+func (rc *responseCache) lru(pinned bool) cache.LRUCache {
+	if pinned {
+		return rc.pinned
+	}
+	return rc.immutable
+}
+
+func (rc *responseCache) get(pinned bool, method string, key any) (any, bool) {
+	var (
+		c = rc.lru(pinned)
+		k = responseCacheKey{method, key}
+	)
+	v, ok := c.Get(k)
+	if ok {
+		item := v.(*responseCacheItem)
+		if item.expireAt.IsZero() || time.Now().Before(item.expireAt) {
+			_responseCacheMtc.WithLabelValues(method, "hit").Inc()
+			return item.value, true
+		}
+		c.Remove(k)
+	}
+	_responseCacheMtc.WithLabelValues(method, "miss").Inc()
+	return nil, false
+}
+
+func (rc *responseCache) put(pinned bool, method string, key any, value any) {
+	item := &responseCacheItem{value: value}
+	if rc.ttl > 0 {
+		item.expireAt = time.Now().Add(rc.ttl)
+	}
+	rc.lru(pinned).Add(responseCacheKey{method, key}, item)
+}
+
+// clearPinned clears the responses pinned to the tip height, it is called on new blocks
+func (rc *responseCache) clearPinned() {
+	rc.pinned.Clear()
+}
+
+// cachedResponse returns the cached response of the method and key, or loads and caches it. Errors are not cached.
+// The cache keeps its own copy of the response made by clone, and hands out a new copy on each hit, so a caller
+// mutating the response never corrupts the cache
+func cachedResponse[T any](rc *responseCache, pinned bool, method string, key any, clone func(T) (T, error), load func() (T, error)) (T, error) {
+	if !rc.enabled {
+		return load()
+	}
+	if v, ok := rc.get(pinned, method, key); ok {
+		if c, err := clone(v.(T)); err == nil {
+			return c, nil
+		}
+	}
+	v, err := load()
+	if err != nil {
+		return v, err
+	}
+	if c, err := clone(v); err == nil {
+		rc.put(pinned, method, key, c)
+	}
+	return v, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func cloneString(s string) (string, error) {
+	return s, nil
+}
+
+func TestResponseCache(t *testing.T) {
+	require := require.New(t)
+	var loads int
+	load := func(v string, err error) func() (string, error) {
+		return func() (string, error) {
+			loads++
+			return v, err
+		}
+	}
+
+	t.Run("Immutable", func(t *testing.T) {
+		rc := newResponseCache(ResponseCacheConfig{Size: 2})
+		loads = 0
+		// errors are not cached
+		_, err := cachedResponse(rc, false, "BlockByHash", 1, cloneString, load("", errors.New(t.Name())))
+		require.ErrorContains(err, t.Name())
+		for i := 0; i < 2; i++ {
+			v, err := cachedResponse(rc, false, "BlockByHash", 1, cloneString, load("blk1", nil))
+			require.NoError(err)
+			require.Equal("blk1", v)
+		}
+		require.Equal(2, loads)
+		// same key of another method
+		v, err := cachedResponse(rc, false, "ReceiptByActionHash", 1, cloneString, load("receipt1", nil))
+		require.NoError(err)
+		require.Equal("receipt1", v)
+		// immutable responses are kept on new blocks, but evicted by lru
+		rc.clearPinned()
+		_, err = cachedResponse(rc, false, "ReceiptByActionHash", 1, cloneString, load("receipt1", nil))
+		require.NoError(err)
+		_, err = cachedResponse(rc, false, "ActionByActionHash", 1, cloneString, load("act1", nil))
+		require.NoError(err)
+		require.Equal(4, loads)
+		v, err = cachedResponse(rc, false, "BlockByHash", 1, cloneString, load("blk1", nil))
+		require.NoError(err)
+		require.Equal("blk1", v)
+		require.Equal(5, loads)
+	})
+
+	t.Run("Pinned", func(t *testing.T) {
+		rc := newResponseCache(ResponseCacheConfig{Size: 2})
+		loads = 0
+		for i := 0; i < 2; i++ {
+			v, err := cachedResponse(rc, true, "ChainMeta", uint64(10), cloneString, load("meta10", nil))
+			require.NoError(err)
+			require.Equal("meta10", v)
+		}
+		require.Equal(1, loads)
+		rc.clearPinned()
+		_, err := cachedResponse(rc, true, "ChainMeta", uint64(10), cloneString, load("meta10", nil))
+		require.NoError(err)
+		require.Equal(2, loads)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		rc := newResponseCache(ResponseCacheConfig{Size: 2, TTL: 50 * time.Millisecond})
+		loads = 0
+		_, err := cachedResponse(rc, false, "BlockByHash", 1, cloneString, load("blk1", nil))
+		require.NoError(err)
+		_, err = cachedResponse(rc, false, "BlockByHash", 1, cloneString, load("blk1", nil))
+		require.NoError(err)
+		require.Equal(1, loads)
+		time.Sleep(100 * time.Millisecond)
+		_, err = cachedResponse(rc, false, "BlockByHash", 1, cloneString, load("blk1", nil))
+		require.NoError(err)
+		require.Equal(2, loads)
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		rc := newResponseCache(ResponseCacheConfig{Size: 2})
+		clone := func(v []byte) ([]byte, error) {
+			return append([]byte{}, v...), nil
+		}
+		load := func() ([]byte, error) {
+			return []byte("blk1"), nil
+		}
+		// mutating the responses does not change the cached one
+		for i := 0; i < 2; i++ {
+			v, err := cachedResponse(rc, false, "BlockByHash", 1, clone, load)
+			require.NoError(err)
+			require.Equal("blk1", string(v))
+			v[0] = 'x'
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		rc := newResponseCache(ResponseCacheConfig{})
+		loads = 0
+		for i := 0; i < 2; i++ {
+			_, err := cachedResponse(rc, false, "BlockByHash", 1, cloneString, load("blk1", nil))
+			require.NoError(err)
+		}
+		require.Equal(2, loads)
+	})
+}
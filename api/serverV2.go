@@ -32,6 +32,7 @@ type ServerV2 struct {
 	httpSvr      *HTTPServer
 	websocketSvr *HTTPServer
 	tracer       *tracesdk.TracerProvider
+	rateLimiter  *RateLimiter
 }
 
 // NewServerV2 creates a new server with coreService and GRPC Server
@@ -52,7 +53,7 @@ func NewServerV2(
 	if err != nil {
 		return nil, err
 	}
-	web3Handler := NewWeb3Handler(coreAPI, cfg.RedisCacheURL, cfg.BatchRequestLimit)
+	web3Handler := NewWeb3Handler(coreAPI, cfg.RedisCacheURL, cfg.BatchRequestLimit, cfg.BatchRequestConcurrency)
 
 	tp, err := tracer.NewProvider(
 		tracer.WithServiceName(cfg.Tracer.ServiceName),
@@ -64,17 +65,22 @@ func NewServerV2(
 		return nil, errors.Wrapf(err, "cannot config tracer provider")
 	}
 
-	wrappedWeb3Handler := otelhttp.NewHandler(newHTTPHandler(web3Handler), "web3.jsonrpc")
+	rateLimiter, err := NewRateLimiter(cfg.RateLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create rate limiter")
+	}
+	wrappedWeb3Handler := otelhttp.NewHandler(rateLimitHandler(rateLimiter, newHTTPHandler(web3Handler)), "web3.jsonrpc")
 
 	limiter := rate.NewLimiter(rate.Limit(cfg.WebsocketRateLimit), 1)
-	wrappedWebsocketHandler := otelhttp.NewHandler(NewWebsocketHandler(coreAPI, web3Handler, limiter), "web3.websocket")
+	wrappedWebsocketHandler := otelhttp.NewHandler(rateLimitHandler(rateLimiter, NewWebsocketHandler(coreAPI, web3Handler, limiter)), "web3.websocket")
 
 	return &ServerV2{
 		core:         coreAPI,
-		grpcServer:   NewGRPCServer(coreAPI, newBlockDAOService(dao), cfg.GRPCPort),
+		grpcServer:   NewGRPCServer(coreAPI, newBlockDAOService(dao), cfg.GRPCPort, rateLimiter),
 		httpSvr:      NewHTTPServer("", cfg.HTTPPort, wrappedWeb3Handler),
 		websocketSvr: NewHTTPServer("", cfg.WebSocketPort, wrappedWebsocketHandler),
 		tracer:       tp,
+		rateLimiter:  rateLimiter,
 	}, nil
 }
 
@@ -134,6 +140,11 @@ func (svr *ServerV2) ReceiveBlock(blk *block.Block) error {
 	return svr.core.ReceiveBlock(blk)
 }
 
+// RateLimiter returns the rate limiter of the api, which is nil if rate limiting is disabled
+func (svr *ServerV2) RateLimiter() *RateLimiter {
+	return svr.rateLimiter
+}
+
 // CoreService returns the coreservice of the api
 func (svr *ServerV2) CoreService() CoreService {
 	return svr.core
@@ -22,10 +22,10 @@ func TestServerV2(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3Handler := NewWeb3Handler(core, "", _defaultBatchRequestLimit)
+	web3Handler := NewWeb3Handler(core, "", _defaultBatchRequestLimit, _defaultBatchRequestConcurrency)
 	svr := &ServerV2{
 		core:         core,
-		grpcServer:   NewGRPCServer(core, nil, testutil.RandomPort()),
+		grpcServer:   NewGRPCServer(core, nil, testutil.RandomPort(), nil),
 		httpSvr:      NewHTTPServer("", testutil.RandomPort(), newHTTPHandler(web3Handler)),
 		websocketSvr: NewHTTPServer("", testutil.RandomPort(), NewWebsocketHandler(core, web3Handler, nil)),
 	}
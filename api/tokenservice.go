@@ -0,0 +1,166 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/iotexproject/iotex-address/address"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TokenServiceServer is the server API of the token service, which serves the balances, the holders and the transfers
+// of XRC20 and XRC721 tokens. A request carries the "address" of the account or the token contract, and the "start"
+// and the "count" of a paginated query
+type TokenServiceServer interface {
+	// GetTokenBalances returns the balances of the tokens held by the account
+	GetTokenBalances(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	// GetTokenHolders returns the holders of the token contract
+	GetTokenHolders(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	// GetTokenTransfers returns the token transfers of the account, or of the token contract if "byContract" is true
+	GetTokenTransfers(context.Context, *structpb.Struct) (*structpb.Struct, error)
+}
+
+// TokenServiceDesc is the grpc service descriptor of the token service. The service is described with the well-known
+// types, so that the clients can call it without a dedicated proto, e.g.,
+// grpcurl -plaintext -d '{"address":"io1..."}' localhost:14014 iotexcore.TokenService/GetTokenBalances
+var TokenServiceDesc = grpc.ServiceDesc{
+	ServiceName: "iotexcore.TokenService",
+	HandlerType: (*TokenServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTokenBalances",
+			Handler:    tokenServiceHandler("GetTokenBalances", TokenServiceServer.GetTokenBalances),
+		},
+		{
+			MethodName: "GetTokenHolders",
+			Handler:    tokenServiceHandler("GetTokenHolders", TokenServiceServer.GetTokenHolders),
+		},
+		{
+			MethodName: "GetTokenTransfers",
+			Handler:    tokenServiceHandler("GetTokenTransfers", TokenServiceServer.GetTokenTransfers),
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tokenservice",
+}
+
+type tokenService struct {
+	core CoreService
+}
+
+func newTokenService(core CoreService) *tokenService {
+	return &tokenService{
+		core: core,
+	}
+}
+
+// GetTokenBalances returns the balances of the tokens held by the account
+func (service *tokenService) GetTokenBalances(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	addr, err := tokenRequestAddress(in)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := service.core.TokenBalances(addr)
+	if err != nil {
+		return nil, err
+	}
+	return toStruct(map[string]any{"balances": &tokenBalancesResult{balances: balances}})
+}
+
+// GetTokenHolders returns the holders of the token contract
+func (service *tokenService) GetTokenHolders(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	addr, err := tokenRequestAddress(in)
+	if err != nil {
+		return nil, err
+	}
+	start, count := tokenRequestRange(in)
+	total, holders, err := service.core.TokenHolders(addr, start, count)
+	if err != nil {
+		return nil, err
+	}
+	return toStruct(&tokenHoldersResult{total: total, holders: holders})
+}
+
+// GetTokenTransfers returns the token transfers of the account, or of the token contract if "byContract" is true
+func (service *tokenService) GetTokenTransfers(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	addr, err := tokenRequestAddress(in)
+	if err != nil {
+		return nil, err
+	}
+	start, count := tokenRequestRange(in)
+	byContract := in.GetFields()["byContract"].GetBoolValue()
+	total, transfers, err := service.core.TokenTransfers(addr, byContract, start, count)
+	if err != nil {
+		return nil, err
+	}
+	return toStruct(&tokenTransfersResult{total: total, transfers: transfers})
+}
+
+// tokenRequestAddress returns the address of the request, in either io or 0x format
+func tokenRequestAddress(in *structpb.Struct) (address.Address, error) {
+	str := in.GetFields()["address"].GetStringValue()
+	var (
+		addr address.Address
+		err  error
+	)
+	if strings.HasPrefix(str, "0x") {
+		addr, err = ethAddrToIoAddr(str)
+	} else {
+		addr, err = address.FromString(str)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %s", str)
+	}
+	return addr, nil
+}
+
+func tokenRequestRange(in *structpb.Struct) (uint64, uint64) {
+	fields := in.GetFields()
+	return uint64(fields["start"].GetNumberValue()), uint64(fields["count"].GetNumberValue())
+}
+
+// toStruct converts the JSON encoding of the value into a struct
+func toStruct(v any) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, res); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return res, nil
+}
+
+func tokenServiceHandler(
+	method string,
+	call func(TokenServiceServer, context.Context, *structpb.Struct) (*structpb.Struct, error),
+) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		in := new(structpb.Struct)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(srv.(TokenServiceServer), ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: "/iotexcore.TokenService/" + method,
+		}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return call(srv.(TokenServiceServer), ctx, req.(*structpb.Struct))
+		}
+		return interceptor(ctx, in, info, handler)
+	}
+}
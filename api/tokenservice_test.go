@@ -0,0 +1,102 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestTokenService(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	service := newTokenService(core)
+	ctx := context.Background()
+
+	var (
+		contract = identityset.Address(30)
+		alice    = identityset.Address(1)
+		bob      = identityset.Address(2)
+	)
+	newRequest := func(fields map[string]any) *structpb.Struct {
+		in, err := structpb.NewStruct(fields)
+		require.NoError(err)
+		return in
+	}
+
+	t.Run("GetTokenBalances", func(t *testing.T) {
+		core.EXPECT().TokenBalances(gomock.Any()).DoAndReturn(func(holder address.Address) ([]*blockindex.TokenBalance, error) {
+			require.Equal(alice.String(), holder.String())
+			return []*blockindex.TokenBalance{
+				{Standard: blockindex.XRC20, Contract: contract, Holder: alice, Balance: big.NewInt(100)},
+			}, nil
+		}).Times(1)
+		res, err := service.GetTokenBalances(ctx, newRequest(map[string]any{
+			"address": common.BytesToAddress(alice.Bytes()).Hex(),
+		}))
+		require.NoError(err)
+		balances := res.GetFields()["balances"].GetListValue().GetValues()
+		require.Len(balances, 1)
+		fields := balances[0].GetStructValue().GetFields()
+		require.Equal(common.BytesToAddress(contract.Bytes()).Hex(), fields["contract"].GetStringValue())
+		require.Equal("0x64", fields["balance"].GetStringValue())
+
+		_, err = service.GetTokenBalances(ctx, newRequest(map[string]any{"address": "invalid"}))
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("GetTokenHolders", func(t *testing.T) {
+		core.EXPECT().TokenHolders(gomock.Any(), uint64(1), uint64(2)).Return(uint64(3), []*blockindex.TokenBalance{
+			{Standard: blockindex.XRC721, Contract: contract, Holder: bob, Balance: big.NewInt(1)},
+		}, nil).Times(1)
+		res, err := service.GetTokenHolders(ctx, newRequest(map[string]any{
+			"address": contract.String(),
+			"start":   1,
+			"count":   2,
+		}))
+		require.NoError(err)
+		fields := res.GetFields()
+		require.Equal("0x3", fields["total"].GetStringValue())
+		holders := fields["holders"].GetListValue().GetValues()
+		require.Len(holders, 1)
+		require.Equal(common.BytesToAddress(bob.Bytes()).Hex(), holders[0].GetStructValue().GetFields()["holder"].GetStringValue())
+	})
+
+	t.Run("GetTokenTransfers", func(t *testing.T) {
+		core.EXPECT().TokenTransfers(gomock.Any(), true, uint64(0), uint64(10)).Return(uint64(1), []*blockindex.TokenTransfer{
+			{Height: 5, Standard: blockindex.XRC721, Contract: contract, From: alice, To: bob, Amount: big.NewInt(7)},
+		}, nil).Times(1)
+		res, err := service.GetTokenTransfers(ctx, newRequest(map[string]any{
+			"address":    contract.String(),
+			"count":      10,
+			"byContract": true,
+		}))
+		require.NoError(err)
+		transfers := res.GetFields()["transfers"].GetListValue().GetValues()
+		require.Len(transfers, 1)
+		fields := transfers[0].GetStructValue().GetFields()
+		require.Equal("0x7", fields["tokenId"].GetStringValue())
+		require.NotContains(fields, "value")
+		require.Equal("0x5", fields["blockNumber"].GetStringValue())
+
+		core.EXPECT().TokenTransfers(gomock.Any(), false, uint64(0), uint64(0)).Return(uint64(0), nil, status.Error(codes.Unimplemented, "token index is not enabled")).Times(1)
+		_, err = service.GetTokenTransfers(ctx, newRequest(map[string]any{"address": alice.String()}))
+		require.Equal(codes.Unimplemented, status.Code(err))
+	})
+}
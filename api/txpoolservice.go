@@ -0,0 +1,74 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TxPoolServiceServer is the server API of the actpool inspection service
+type TxPoolServiceServer interface {
+	// GetTxPoolContent returns the pending and the queued actions in actpool by sender and nonce
+	GetTxPoolContent(context.Context, *emptypb.Empty) (*structpb.Struct, error)
+}
+
+// TxPoolServiceDesc is the grpc service descriptor of the actpool inspection service. The service is described with
+// the well-known types, so that the clients can call it without a dedicated proto, e.g.,
+// grpcurl -plaintext localhost:14014 iotexcore.TxPoolService/GetTxPoolContent
+var TxPoolServiceDesc = grpc.ServiceDesc{
+	ServiceName: "iotexcore.TxPoolService",
+	HandlerType: (*TxPoolServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTxPoolContent",
+			Handler:    getTxPoolContentHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "txpoolservice",
+}
+
+type txPoolService struct {
+	core CoreService
+}
+
+func newTxPoolService(core CoreService) *txPoolService {
+	return &txPoolService{
+		core: core,
+	}
+}
+
+// GetTxPoolContent returns the pending and the queued actions in actpool by sender and nonce, in the same format as
+// the web3 API txpool_content
+func (service *txPoolService) GetTxPoolContent(context.Context, *emptypb.Empty) (*structpb.Struct, error) {
+	content, err := service.core.ActPoolContent()
+	if err != nil {
+		return nil, err
+	}
+	return toStruct(&txPoolContentResult{content: content, chainID: service.core.EVMNetworkID()})
+}
+
+func getTxPoolContentHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxPoolServiceServer).GetTxPoolContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexcore.TxPoolService/GetTxPoolContent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxPoolServiceServer).GetTxPoolContent(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
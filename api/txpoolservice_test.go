@@ -0,0 +1,50 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/actpool"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestTxPoolService(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	service := newTxPoolService(core)
+	ctx := context.Background()
+
+	selp, err := action.SignedTransfer(identityset.Address(30).String(), identityset.PrivateKey(28), 5, big.NewInt(10), nil, 100000, big.NewInt(1))
+	require.NoError(err)
+	core.EXPECT().ActPoolContent().Return(map[string]*actpool.Content{
+		identityset.Address(28).String(): {Queued: []*action.SealedEnvelope{selp}},
+	}, nil).Times(1)
+	core.EXPECT().EVMNetworkID().Return(uint32(4689)).Times(1)
+	res, err := service.GetTxPoolContent(ctx, &emptypb.Empty{})
+	require.NoError(err)
+	fields := res.GetFields()
+	require.Empty(fields["pending"].GetStructValue().GetFields())
+	sender := common.BytesToAddress(identityset.Address(28).Bytes()).Hex()
+	tx := fields["queued"].GetStructValue().GetFields()[sender].GetStructValue().GetFields()["5"].GetStructValue().GetFields()
+	require.Equal("0x5", tx["nonce"].GetStringValue())
+	require.Equal("0xa", tx["value"].GetStringValue())
+
+	core.EXPECT().ActPoolContent().Return(nil, status.Error(codes.Unimplemented, "content is not supported by actpool")).Times(1)
+	_, err = service.GetTxPoolContent(ctx, &emptypb.Empty{})
+	require.Equal(codes.Unimplemented, status.Code(err))
+}
@@ -3,11 +3,16 @@ package apitypes
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/iotex-address/address"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 )
 
@@ -40,6 +45,11 @@ type (
 		Block    *block.Block
 		Receipts []*action.Receipt
 	}
+	// SyncStage is the height a stage of syncing reaches
+	SyncStage struct {
+		Name   string
+		Height uint64
+	}
 	// BlobSidecarResult is the result of get blob sidecar
 	BlobSidecarResult struct {
 		BlobSidecar *types.BlobTxSidecar `json:"blobSidecar"`
@@ -48,6 +58,41 @@ type (
 		TxIndex     uint64               `json:"txIndex"`
 		TxHash      common.Hash          `json:"txHash"`
 	}
+
+	// SimulateBlock is a block of calls to simulate, with the overridden block context and states
+	SimulateBlock struct {
+		BlockOverrides *BlockOverrides
+		StateOverrides evm.StateOverrides
+		Calls          []*SimulateCall
+	}
+	// BlockOverrides is the overridden block context in simulation, the nil fields are not overridden
+	BlockOverrides struct {
+		Number       *uint64
+		Time         *uint64
+		GasLimit     *uint64
+		FeeRecipient address.Address
+		BaseFee      *big.Int
+	}
+	// SimulateCall is a call to simulate
+	SimulateCall struct {
+		From address.Address
+		Elp  action.Envelope
+	}
+	// SimulatedBlock is the result of a simulated block
+	SimulatedBlock struct {
+		Height    uint64
+		Timestamp time.Time
+		GasLimit  uint64
+		GasUsed   uint64
+		BaseFee   *big.Int
+		Producer  address.Address
+		Calls     []*SimulatedCall
+	}
+	// SimulatedCall is the result of a simulated call
+	SimulatedCall struct {
+		ReturnData []byte
+		Receipt    *action.Receipt
+	}
 )
 
 // responseWriter for server
@@ -66,9 +111,18 @@ func (w *responseWriter) Write(in interface{}) (int, error) {
 
 // BatchWriter for multiple web3 requests
 type BatchWriter struct {
+	mu        sync.Mutex
 	totalSize int
 	writer    Web3ResponseWriter
 	buf       []json.RawMessage
+	slots     [][]json.RawMessage
+}
+
+// batchSlotWriter writes the responses of a request in batch into its own slot, so that the requests can be handled
+// concurrently while the responses keep the order of requests
+type batchSlotWriter struct {
+	batch *BatchWriter
+	slot  int
 }
 
 // NewBatchWriter returns a new BatchWriter
@@ -81,20 +135,53 @@ func NewBatchWriter(singleWriter Web3ResponseWriter) *BatchWriter {
 
 // Write adds data into batch buffer
 func (w *BatchWriter) Write(in interface{}) (int, error) {
+	return w.write(-1, in)
+}
+
+// Slots returns n writers, the data written by which are flushed in the order of writers after the data written
+// into batch buffer directly
+func (w *BatchWriter) Slots(n int) []Web3ResponseWriter {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.slots = make([][]json.RawMessage, n)
+	writers := make([]Web3ResponseWriter, n)
+	for i := range writers {
+		writers[i] = &batchSlotWriter{batch: w, slot: i}
+	}
+	return writers
+}
+
+// Flush writes data in batch buffer
+func (w *BatchWriter) Flush() error {
+	w.mu.Lock()
+	for _, raws := range w.slots {
+		w.buf = append(w.buf, raws...)
+	}
+	w.slots = nil
+	w.mu.Unlock()
+	_, err := w.writer.Write(w.buf)
+	return err
+}
+
+func (w *BatchWriter) write(slot int, in interface{}) (int, error) {
 	raw, err := json.Marshal(in)
 	if err != nil {
 		return 0, err
 	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.totalSize += len(raw)
 	if w.totalSize > MaxResponseSize {
 		return w.totalSize, errors.New("response size exceeds limit")
 	}
-	w.buf = append(w.buf, raw)
+	if slot < 0 || slot >= len(w.slots) {
+		w.buf = append(w.buf, raw)
+	} else {
+		w.slots[slot] = append(w.slots[slot], raw)
+	}
 	return w.totalSize, nil
 }
 
-// Flush writes data in batch buffer
-func (w *BatchWriter) Flush() error {
-	_, err := w.writer.Write(w.buf)
-	return err
+func (sw *batchSlotWriter) Write(in interface{}) (int, error) {
+	return sw.batch.write(sw.slot, in)
 }
@@ -8,8 +8,10 @@ import (
 	"io"
 	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
@@ -17,6 +19,7 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/go-pkgs/util"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -27,7 +30,9 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding"
 	rewardingabi "github.com/iotexproject/iotex-core/v2/action/protocol/rewarding/ethabi"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	stakingabi "github.com/iotexproject/iotex-core/v2/action/protocol/staking/ethabi"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
@@ -38,6 +43,12 @@ const (
 	_metamaskBalanceContractAddr = "io1k8uw2hrlvnfq8s2qpwwc24ws2ru54heenx8chr"
 	// _defaultBatchRequestLimit is the default maximum number of items in a batch.
 	_defaultBatchRequestLimit = 100 // Maximum number of items in a batch.
+	// _defaultBatchRequestConcurrency is the default maximum number of items in a batch handled concurrently.
+	_defaultBatchRequestConcurrency = 8
+	// _simulateBlocksLimit is the maximum number of blocks in eth_simulateV1
+	_simulateBlocksLimit = 256
+	// _simulateCallsLimit is the maximum number of calls in all blocks of eth_simulateV1
+	_simulateCallsLimit = 1000
 )
 
 type (
@@ -47,9 +58,10 @@ type (
 	}
 
 	web3Handler struct {
-		coreService       CoreService
-		cache             apiCache
-		batchRequestLimit int
+		coreService             CoreService
+		cache                   apiCache
+		batchRequestLimit       int
+		batchRequestConcurrency int
 	}
 )
 
@@ -98,11 +110,12 @@ func init() {
 }
 
 // NewWeb3Handler creates a handle to process web3 requests
-func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit int) Web3Handler {
+func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit, batchRequestConcurrency int) Web3Handler {
 	return &web3Handler{
-		coreService:       core,
-		cache:             newAPICache(15*time.Minute, cacheURL),
-		batchRequestLimit: batchRequestLimit,
+		coreService:             core,
+		cache:                   newAPICache(15*time.Minute, cacheURL),
+		batchRequestLimit:       batchRequestLimit,
+		batchRequestConcurrency: batchRequestConcurrency,
 	}
 }
 
@@ -141,13 +154,7 @@ func (svr *web3Handler) HandlePOSTReq(ctx context.Context, reader io.Reader, wri
 		_, err = writer.Write(&web3Response{err: err})
 		return err
 	}
-	batchWriter := apitypes.NewBatchWriter(writer)
-	for i := range web3ReqArr {
-		if err := svr.handleWeb3Req(ctx, &web3ReqArr[i], batchWriter); err != nil {
-			return err
-		}
-	}
-	return batchWriter.Flush()
+	return svr.handleWeb3Batch(ctx, web3ReqArr, writer)
 }
 
 func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result, writer apitypes.Web3ResponseWriter) error {
@@ -162,6 +169,14 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 	log.T(ctx).Debug("handleWeb3Req", zap.String("method", method.(string)), zap.String("requestParams", fmt.Sprintf("%+v", web3Req)))
 	_web3ServerMtc.WithLabelValues(method.(string)).Inc()
 	_web3ServerMtc.WithLabelValues("requests_total").Inc()
+	if err = allowWeb3Call(ctx, method.(string)); err != nil {
+		id, _ := parseWeb3ReqID(web3Req)
+		size, err1 = writer.Write(&web3Response{
+			id:  id,
+			err: err,
+		})
+		return err1
+	}
 	switch method {
 	case "eth_accounts":
 		res, err = svr.ethAccounts()
@@ -229,6 +244,8 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.getTransactionReceipt(web3Req)
 	case "eth_getStorageAt":
 		res, err = svr.getStorageAt(web3Req)
+	case "eth_getProof":
+		res, err = svr.getProof(web3Req)
 	case "eth_getFilterLogs":
 		res, err = svr.getFilterLogs(web3Req)
 	case "eth_getFilterChanges":
@@ -253,11 +270,50 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.unsubscribe(web3Req)
 	case "eth_getBlobSidecars":
 		res, err = svr.getBlobSidecars(web3Req)
-	//TODO: enable debug api after archive mode is supported
-	// case "debug_traceTransaction":
-	// 	res, err = svr.traceTransaction(ctx, web3Req)
-	// case "debug_traceCall":
-	// 	res, err = svr.traceCall(ctx, web3Req)
+	case "txpool_accessSet":
+		res, err = svr.getAccessSet(web3Req)
+	case "txpool_content":
+		res, err = svr.txPoolContent()
+	case "txpool_status":
+		res, err = svr.txPoolStatus()
+	case "txpool_inspect":
+		res, err = svr.txPoolInspect()
+	case "iotex_getGasTable":
+		res, err = svr.getGasTable(web3Req)
+	case "iotex_dryRunNextEpoch":
+		res, err = svr.dryRunNextEpoch(ctx)
+	case "iotex_getBalanceAt":
+		res, err = svr.getBalanceAt(web3Req)
+	case "iotex_getTransferHistory":
+		res, err = svr.getTransferHistory(web3Req)
+	case "iotex_getTokenBalances":
+		res, err = svr.getTokenBalances(web3Req)
+	case "iotex_getTokenHolders":
+		res, err = svr.getTokenHolders(web3Req)
+	case "iotex_getTokenTransfers":
+		res, err = svr.getTokenTransfers(web3Req, false)
+	case "iotex_getTokenTransfersByContract":
+		res, err = svr.getTokenTransfers(web3Req, true)
+	case "iotex_getRewardDistribution":
+		res, err = svr.getRewardDistribution(web3Req)
+	case "iotex_suggestFees":
+		res, err = svr.suggestFees(ctx)
+	case "iotex_getNonceGaps":
+		res, err = svr.getNonceGaps(web3Req)
+	case "iotex_sendMetaTransaction":
+		res, err = svr.sendMetaTransaction(ctx, web3Req)
+	case "iotex_getFeeStats":
+		res, err = svr.getFeeStats()
+	case "iotex_getFinalityProof":
+		res, err = svr.getFinalityProof(web3Req)
+	case "debug_preimage":
+		res, err = svr.preimage(web3Req)
+	case "debug_traceTransaction":
+		res, err = svr.traceTransaction(ctx, web3Req)
+	case "debug_traceCall":
+		res, err = svr.traceCall(ctx, web3Req)
+	case "eth_simulateV1":
+		res, err = svr.simulateV1(ctx, web3Req)
 	case "eth_coinbase", "eth_getUncleCountByBlockHash", "eth_getUncleCountByBlockNumber",
 		"eth_sign", "eth_signTransaction", "eth_sendTransaction", "eth_getUncleByBlockHashAndIndex",
 		"eth_getUncleByBlockNumberAndIndex", "eth_pendingTransactions":
@@ -272,16 +328,9 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 	} else {
 		log.Logger("api").Debug("web3Debug", zap.String("response", fmt.Sprintf("%+v", res)))
 	}
-	var id any
-	reqID := web3Req.Get("id")
-	switch reqID.Type {
-	case gjson.String:
-		id = reqID.String()
-	case gjson.Number:
-		id = reqID.Int()
-	default:
-		id = 0
-		res, err = nil, errors.New("invalid id type")
+	id, idErr := parseWeb3ReqID(web3Req)
+	if idErr != nil {
+		res, err = nil, idErr
 	}
 	size, err1 = writer.Write(&web3Response{
 		id:     id,
@@ -291,6 +340,18 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 	return err1
 }
 
+func parseWeb3ReqID(web3Req *gjson.Result) (any, error) {
+	reqID := web3Req.Get("id")
+	switch reqID.Type {
+	case gjson.String:
+		return reqID.String(), nil
+	case gjson.Number:
+		return reqID.Int(), nil
+	default:
+		return 0, errors.New("invalid id type")
+	}
+}
+
 func parseWeb3Reqs(reader io.Reader) (gjson.Result, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
@@ -331,12 +392,32 @@ func (svr *web3Handler) maxPriorityFee() (interface{}, error) {
 	return uint64ToHex(ret.Uint64()), nil
 }
 
+// suggestFees returns the fees of the legacy and the dynamic fee transactions at the slow, standard and fast levels
+func (svr *web3Handler) suggestFees(ctx context.Context) (interface{}, error) {
+	ret, err := svr.coreService.SuggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &feeSuggestionResult{suggestion: ret}, nil
+}
+
 func (svr *web3Handler) feeHistory(ctx context.Context, in *gjson.Result) (interface{}, error) {
 	blkCnt, newestBlk, rewardPercentiles := in.Get("params.0"), in.Get("params.1"), in.Get("params.2")
 	if !blkCnt.Exists() || !newestBlk.Exists() {
 		return nil, errInvalidFormat
 	}
-	blocks, err := strconv.ParseUint(blkCnt.String(), 10, 64)
+	var (
+		blocks uint64
+		err    error
+	)
+	// block count could be either a decimal or a hex quantity
+	if blkCnt.Type == gjson.Number {
+		blocks = blkCnt.Uint()
+	} else if strings.HasPrefix(blkCnt.String(), "0x") {
+		blocks, err = hexStringToNumber(blkCnt.String())
+	} else {
+		blocks, err = strconv.ParseUint(blkCnt.String(), 10, 64)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -452,7 +533,7 @@ func (svr *web3Handler) getTransactionCount(in *gjson.Result) (interface{}, erro
 }
 
 func (svr *web3Handler) call(ctx context.Context, in *gjson.Result) (interface{}, error) {
-	callMsg, err := parseCallObject(in)
+	callMsg, err := parseCallObjectWithOverrides(in)
 	if err != nil {
 		return nil, err
 	}
@@ -501,9 +582,9 @@ func (svr *web3Handler) call(ctx context.Context, in *gjson.Result) (interface{}
 		height, archive = blockNumberToHeight(callMsg.BlockNumber)
 	)
 	if !archive {
-		ret, receipt, err = svr.coreService.ReadContract(context.Background(), callMsg.From, elp)
+		ret, receipt, err = svr.coreService.ReadContract(context.Background(), callMsg.From, elp, callMsg.simulateOptions()...)
 	} else {
-		ret, receipt, err = svr.coreService.WithHeight(height).ReadContract(context.Background(), callMsg.From, elp)
+		ret, receipt, err = svr.coreService.WithHeight(height).ReadContract(context.Background(), callMsg.From, elp, callMsg.simulateOptions()...)
 	}
 	if err != nil {
 		return nil, err
@@ -518,7 +599,7 @@ func (svr *web3Handler) call(ctx context.Context, in *gjson.Result) (interface{}
 }
 
 func (svr *web3Handler) estimateGas(ctx context.Context, in *gjson.Result) (interface{}, error) {
-	callMsg, err := parseCallObject(in)
+	callMsg, err := parseCallObjectWithOverrides(in)
 	if err != nil {
 		return nil, err
 	}
@@ -538,7 +619,7 @@ func (svr *web3Handler) estimateGas(ctx context.Context, in *gjson.Result) (inte
 	)
 	switch act := elp.Action().(type) {
 	case *action.Execution:
-		estimatedGas, retval, err = svr.coreService.EstimateExecutionGasConsumption(ctx, elp, from)
+		estimatedGas, retval, err = svr.coreService.EstimateExecutionGasConsumption(ctx, elp, from, callMsg.simulateOptions()...)
 	case *action.MigrateStake:
 		estimatedGas, retval, err = svr.coreService.EstimateMigrateStakeGasConsumption(ctx, act, from)
 	default:
@@ -663,13 +744,28 @@ func (svr *web3Handler) getProtocolVersion() (interface{}, error) {
 
 func (svr *web3Handler) isSyncing() (interface{}, error) {
 	start, curr, highest := svr.coreService.SyncingProgress()
-	if curr >= highest {
+	// the node is still syncing if any stage falls behind the chain tip, e.g., an indexer catching up
+	syncing := curr < highest
+	stages := svr.coreService.SyncingStages()
+	for _, stage := range stages {
+		if stage.Height < curr {
+			syncing = true
+			break
+		}
+	}
+	if !syncing {
 		return false, nil
 	}
 	return &getSyncingResult{
 		StartingBlock: uint64ToHex(start),
 		CurrentBlock:  uint64ToHex(curr),
-		HighestBlock:  uint64ToHex(highest),
+		HighestBlock:  uint64ToHex(max(curr, highest)),
+		Stages: mapper(stages, func(stage *apitypes.SyncStage) syncStageResult {
+			return syncStageResult{
+				Name:         stage.Name,
+				CurrentBlock: uint64ToHex(stage.Height),
+			}
+		}),
 	}, nil
 }
 
@@ -742,6 +838,291 @@ func (svr *web3Handler) getTransactionByHash(in *gjson.Result) (interface{}, err
 	return nil, err
 }
 
+func (svr *web3Handler) getAccessSet(in *gjson.Result) (interface{}, error) {
+	txHash := in.Get("params.0")
+	if !txHash.Exists() {
+		return nil, errInvalidFormat
+	}
+	actHash, err := hash.HexStringToHash256(util.Remove0xPrefix(txHash.String()))
+	if err != nil {
+		return nil, err
+	}
+	set, err := svr.coreService.AccessSetInActPool(actHash)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return set, nil
+}
+
+// txPoolContent returns the pending and the queued transactions in actpool by sender and nonce
+func (svr *web3Handler) txPoolContent() (interface{}, error) {
+	content, err := svr.coreService.ActPoolContent()
+	if err != nil {
+		return nil, err
+	}
+	return &txPoolContentResult{content: content, chainID: svr.coreService.EVMNetworkID()}, nil
+}
+
+// txPoolStatus returns the number of the pending and the queued transactions in actpool
+func (svr *web3Handler) txPoolStatus() (interface{}, error) {
+	content, err := svr.coreService.ActPoolContent()
+	if err != nil {
+		return nil, err
+	}
+	var pending, queued uint64
+	for _, c := range content {
+		pending += uint64(len(c.Pending))
+		queued += uint64(len(c.Queued))
+	}
+	return &txPoolStatusResult{
+		Pending: uint64ToHex(pending),
+		Queued:  uint64ToHex(queued),
+	}, nil
+}
+
+// txPoolInspect returns the summaries of the pending and the queued transactions in actpool by sender and nonce
+func (svr *web3Handler) txPoolInspect() (interface{}, error) {
+	content, err := svr.coreService.ActPoolContent()
+	if err != nil {
+		return nil, err
+	}
+	return &txPoolContentResult{content: content, chainID: svr.coreService.EVMNetworkID(), inspect: true}, nil
+}
+
+// getGasTable returns the intrinsic gas table at the block, the pending block by default
+func (svr *web3Handler) getGasTable(in *gjson.Result) (interface{}, error) {
+	var (
+		height uint64
+		err    error
+	)
+	if blkNum := in.Get("params.0"); blkNum.Exists() && blkNum.String() != _pendingBlockNumber {
+		if height, err = svr.parseBlockNumber(blkNum.String()); err != nil {
+			return nil, err
+		}
+	}
+	return svr.coreService.GasTable(height), nil
+}
+
+// dryRunNextEpoch returns the delegates and probation list of the next epoch calculated against the current state
+func (svr *web3Handler) dryRunNextEpoch(ctx context.Context) (interface{}, error) {
+	res, err := svr.coreService.DryRunNextEpoch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &epochDryRunResult{res}, nil
+}
+
+// getBalanceAt returns the balance of the account after the block from the balance history index
+func (svr *web3Handler) getBalanceAt(in *gjson.Result) (interface{}, error) {
+	addr, blkNum := in.Get("params.0"), in.Get("params.1")
+	if !addr.Exists() {
+		return nil, errInvalidFormat
+	}
+	ioAddr, err := ethAddrToIoAddr(addr.String())
+	if err != nil {
+		return nil, err
+	}
+	height, err := svr.parseBlockNumber(blkNum.String())
+	if err != nil {
+		return nil, err
+	}
+	balance, err := svr.coreService.BalanceAt(ioAddr, height)
+	if err != nil {
+		return nil, err
+	}
+	return bigIntToHex(balance), nil
+}
+
+// getTransferHistory returns the transfers of the account [start, start+count) from the oldest to the latest, and
+// the total number of its transfers
+func (svr *web3Handler) getTransferHistory(in *gjson.Result) (interface{}, error) {
+	ioAddr, start, count, err := parseAddressRange(in)
+	if err != nil {
+		return nil, err
+	}
+	total, transfers, err := svr.coreService.TransferHistory(ioAddr, start, count)
+	if err != nil {
+		return nil, err
+	}
+	return &transferHistoryResult{total: total, transfers: transfers}, nil
+}
+
+// getTokenBalances returns the balances of the XRC20 and XRC721 tokens held by the account
+func (svr *web3Handler) getTokenBalances(in *gjson.Result) (interface{}, error) {
+	addr := in.Get("params.0")
+	if !addr.Exists() {
+		return nil, errInvalidFormat
+	}
+	ioAddr, err := ethAddrToIoAddr(addr.String())
+	if err != nil {
+		return nil, err
+	}
+	balances, err := svr.coreService.TokenBalances(ioAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenBalancesResult{balances: balances}, nil
+}
+
+// getTokenHolders returns the holders of the token contract [start, start+count), and the total number of its holders
+func (svr *web3Handler) getTokenHolders(in *gjson.Result) (interface{}, error) {
+	ioAddr, start, count, err := parseAddressRange(in)
+	if err != nil {
+		return nil, err
+	}
+	total, holders, err := svr.coreService.TokenHolders(ioAddr, start, count)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenHoldersResult{total: total, holders: holders}, nil
+}
+
+// getTokenTransfers returns the token transfers of the account, or of the token contract if byContract is true,
+// [start, start+count) from the oldest to the latest, and the total number of the transfers
+func (svr *web3Handler) getTokenTransfers(in *gjson.Result, byContract bool) (interface{}, error) {
+	ioAddr, start, count, err := parseAddressRange(in)
+	if err != nil {
+		return nil, err
+	}
+	total, transfers, err := svr.coreService.TokenTransfers(ioAddr, byContract, start, count)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenTransfersResult{total: total, transfers: transfers}, nil
+}
+
+// getNonceGaps returns the nonce diagnostics of the sender, to tell why its transactions are stuck in actpool
+func (svr *web3Handler) getNonceGaps(in *gjson.Result) (interface{}, error) {
+	addr := in.Get("params.0")
+	if !addr.Exists() {
+		return nil, errInvalidFormat
+	}
+	ioAddr, err := ethAddrToIoAddr(addr.String())
+	if err != nil {
+		return nil, err
+	}
+	gaps, err := svr.coreService.NonceGaps(ioAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &nonceGapsResult{gaps: gaps}, nil
+}
+
+// sendMetaTransaction relays the EIP-2771 meta transaction signed by the sender along with the meta transaction paying
+// the relayer fee, and returns the hash of the execution sent to the trusted forwarder by the relayer
+func (svr *web3Handler) sendMetaTransaction(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	req := in.Get("params.0")
+	if !req.Exists() {
+		return nil, errInvalidFormat
+	}
+	tx, err := parseMetaTransaction(req)
+	if err != nil {
+		return nil, err
+	}
+	fee := req.Get("fee")
+	if !fee.Exists() {
+		return nil, errors.Wrap(errInvalidFormat, "relayer fee is missing")
+	}
+	if tx.Fee, err = parseMetaTransaction(fee); err != nil {
+		return nil, err
+	}
+	actionHash, err := svr.coreService.RelayMetaTransaction(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + actionHash, nil
+}
+
+func parseMetaTransaction(req gjson.Result) (*MetaTransaction, error) {
+	var (
+		fromStr, toStr = req.Get("from").String(), req.Get("to").String()
+		tx             = &MetaTransaction{
+			Value:     big.NewInt(0),
+			Data:      common.FromHex(req.Get("data").String()),
+			Signature: common.FromHex(req.Get("signature").String()),
+		}
+		err error
+	)
+	if !common.IsHexAddress(fromStr) || !common.IsHexAddress(toStr) {
+		return nil, errors.Wrapf(errUnkownType, "from: %s, to: %s", fromStr, toStr)
+	}
+	tx.From, tx.To = common.HexToAddress(fromStr), common.HexToAddress(toStr)
+	if tx.Gas, err = hexStringToNumber(req.Get("gas").String()); err != nil {
+		return nil, err
+	}
+	if tx.Deadline, err = hexStringToNumber(req.Get("deadline").String()); err != nil {
+		return nil, err
+	}
+	nonceStr := req.Get("nonce").String()
+	nonce, ok := new(big.Int).SetString(util.Remove0xPrefix(nonceStr), 16)
+	if !ok {
+		return nil, errors.Wrapf(errUnkownType, "nonce: %s", nonceStr)
+	}
+	tx.Nonce = nonce
+	if valStr := req.Get("value").String(); valStr != "" {
+		if tx.Value, ok = new(big.Int).SetString(util.Remove0xPrefix(valStr), 16); !ok {
+			return nil, errors.Wrapf(errUnkownType, "value: %s", valStr)
+		}
+	}
+	return tx, nil
+}
+
+// getRewardDistribution returns the reward shares of the voters of the delegate over the epoch range, given the
+// commission rate in basis points and the total reward to distribute
+func (svr *web3Handler) getRewardDistribution(in *gjson.Result) (interface{}, error) {
+	name, startStr, endStr, rateStr, rewardStr := in.Get("params.0"), in.Get("params.1"), in.Get("params.2"), in.Get("params.3"), in.Get("params.4")
+	if !name.Exists() || !startStr.Exists() || !endStr.Exists() || !rateStr.Exists() || !rewardStr.Exists() {
+		return nil, errInvalidFormat
+	}
+	args := [][]byte{[]byte(name.String())}
+	for _, str := range []gjson.Result{startStr, endStr, rateStr} {
+		n, err := hexStringToNumber(str.String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, []byte(strconv.FormatUint(n, 10)))
+	}
+	reward, ok := new(big.Int).SetString(util.Remove0xPrefix(rewardStr.String()), 16)
+	if !ok {
+		return nil, errors.Wrapf(errUnkownType, "reward: %s", rewardStr.String())
+	}
+	args = append(args, []byte(reward.String()))
+	res, err := svr.coreService.ReadState("staking", "", []byte(staking.RewardDistributionMethod), args)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res.Data), nil
+}
+
+// getFeeStats returns the base fee and the priority fee charged in the latest block and in total
+func (svr *web3Handler) getFeeStats() (interface{}, error) {
+	res, err := svr.coreService.ReadState("rewarding", "", []byte(rewarding.FeeStatsMethod), nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res.Data), nil
+}
+
+// getFinalityProof returns the endorsements committing the block and the proof of the delegates of its epoch
+func (svr *web3Handler) getFinalityProof(in *gjson.Result) (interface{}, error) {
+	blkNum := in.Get("params.0")
+	if !blkNum.Exists() {
+		return nil, errInvalidFormat
+	}
+	height, err := svr.parseBlockNumber(blkNum.String())
+	if err != nil {
+		return nil, err
+	}
+	proof, err := svr.coreService.FinalityProof(height)
+	if err != nil {
+		return nil, err
+	}
+	return &finalityProofResult{proof: proof}, nil
+}
+
 func (svr *web3Handler) getLogs(filter *filterObject) (interface{}, error) {
 	from, to, err := svr.parseBlockRange(filter.FromBlock, filter.ToBlock)
 	if err != nil {
@@ -888,6 +1269,66 @@ func (svr *web3Handler) getStorageAt(in *gjson.Result) (interface{}, error) {
 	return "0x" + hex.EncodeToString(val), nil
 }
 
+// preimage returns the namespace or the key in the state trie whose hash is the given 20-byte hash
+func (svr *web3Handler) preimage(in *gjson.Result) (interface{}, error) {
+	h := in.Get("params.0")
+	if !h.Exists() {
+		return nil, errInvalidFormat
+	}
+	data, err := hexToBytes(h.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != len(hash.ZeroHash160) {
+		return nil, errors.Wrapf(errInvalidFormat, "hash %s is not 20 bytes", h.String())
+	}
+	preimage, err := svr.coreService.Preimage(hash.BytesToHash160(data))
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + hex.EncodeToString(preimage), nil
+}
+
+func (svr *web3Handler) getProof(in *gjson.Result) (interface{}, error) {
+	ethAddr, storageKeys := in.Get("params.0"), in.Get("params.1")
+	if !ethAddr.Exists() || !storageKeys.IsArray() {
+		return nil, errInvalidFormat
+	}
+	addr, err := ethAddrToIoAddr(ethAddr.String())
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]hash.Hash256, 0, len(storageKeys.Array()))
+	for _, key := range storageKeys.Array() {
+		k, err := hexToBytes(key.String())
+		if err != nil {
+			return nil, err
+		}
+		if len(k) > len(hash.ZeroHash256) {
+			return nil, errors.Wrapf(errInvalidFormat, "storage key %s is longer than 32 bytes", key.String())
+		}
+		keys = append(keys, hash.BytesToHash256(k))
+	}
+	bnParam := in.Get("params.2")
+	bn, err := parseBlockNumber(&bnParam)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		proof           *AccountProof
+		height, archive = blockNumberToHeight(bn)
+	)
+	if !archive {
+		proof, err = svr.coreService.StateProof(addr, keys)
+	} else {
+		proof, err = svr.coreService.WithHeight(height).StateProof(addr, keys)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &getProofResult{proof}, nil
+}
+
 func (svr *web3Handler) newFilter(filter *filterObject) (interface{}, error) {
 	//check the validity of filter before caching
 	if filter == nil {
@@ -1041,6 +1482,14 @@ func (svr *web3Handler) subscribe(ctx *StreamContext, in *gjson.Result, writer a
 			return nil, err
 		}
 		return svr.streamLogs(ctx, filter, writer)
+	case "bucketEvents":
+		return svr.streamBucketEvents(ctx, writer)
+	case "actionsByAddress":
+		addr, cursor, err := parseActionsByAddressRequest(in.Get("params.1"))
+		if err != nil {
+			return nil, err
+		}
+		return svr.streamActionsByAddress(ctx, addr, cursor, writer)
 	default:
 		return nil, errInvalidFormat
 	}
@@ -1070,6 +1519,50 @@ func (svr *web3Handler) streamLogs(ctx *StreamContext, filterObj *filterObject,
 	return streamID, nil
 }
 
+func (svr *web3Handler) streamBucketEvents(ctx *StreamContext, writer apitypes.Web3ResponseWriter) (interface{}, error) {
+	chainListener := svr.coreService.ChainListener()
+	streamID, err := chainListener.AddResponder(NewWeb3BucketEventListener(writer.Write))
+	if err != nil {
+		return nil, err
+	}
+	ctx.AddListener(streamID)
+	return streamID, nil
+}
+
+// streamActionsByAddress streams the actions of the address from the cursor, the stream stops when the subscription
+// is removed on unsubscribe or when the connection is closed
+func (svr *web3Handler) streamActionsByAddress(ctx *StreamContext, addr address.Address, cursor uint64, writer apitypes.Web3ResponseWriter) (interface{}, error) {
+	sub := newBlockNotifier()
+	chainListener := svr.coreService.ChainListener()
+	streamID, err := chainListener.AddResponder(sub)
+	if err != nil {
+		return nil, err
+	}
+	ctx.AddListener(streamID)
+	streamCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-sub.done
+		cancel()
+	}()
+	go func() {
+		err := svr.coreService.StreamActionsByAddress(streamCtx, addr, cursor, func(act *iotexapi.ActionInfo, cursor uint64) error {
+			_, err := writer.Write(&streamResponse{
+				id: streamID,
+				result: &actionByAddressResult{
+					cursor: cursor,
+					action: act,
+				},
+			})
+			return err
+		})
+		if err != nil && errors.Cause(err) != context.Canceled {
+			log.Logger("api").Info("Error when streaming the actions by address", zap.String("address", addr.String()), zap.Error(err))
+		}
+		chainListener.RemoveResponder(streamID)
+	}()
+	return streamID, nil
+}
+
 func (svr *web3Handler) unsubscribe(in *gjson.Result) (interface{}, error) {
 	id := in.Get("params.0")
 	if !id.Exists() {
@@ -1127,6 +1620,10 @@ func (svr *web3Handler) traceTransaction(ctx context.Context, in *gjson.Result)
 		if tracerConfig := options.Get("tracerConfig"); tracerConfig.Exists() {
 			cfg.TracerConfig = json.RawMessage(tracerConfig.Raw)
 		}
+		if timeout := options.Get("timeout"); timeout.Exists() {
+			cfg.Timeout = new(string)
+			*cfg.Timeout = timeout.String()
+		}
 	}
 	retval, receipt, tracer, err := svr.coreService.TraceTransaction(ctx, actHash.String(), cfg)
 	if err != nil {
@@ -1148,28 +1645,87 @@ func (svr *web3Handler) traceTransaction(ctx context.Context, in *gjson.Result)
 	}
 }
 
+func (svr *web3Handler) simulateV1(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	opts := in.Get("params.0")
+	if !opts.Exists() {
+		return nil, errInvalidFormat
+	}
+	if opts.Get("validation").Bool() || opts.Get("traceTransfers").Bool() {
+		return nil, errors.Wrap(errNotImplemented, "validation and traceTransfers are not supported")
+	}
+	blockStateCalls := opts.Get("blockStateCalls").Array()
+	if len(blockStateCalls) == 0 {
+		return nil, errors.Wrap(errInvalidFormat, "empty blockStateCalls")
+	}
+	if len(blockStateCalls) > _simulateBlocksLimit {
+		return nil, errors.Wrapf(errInvalidFormat, "number of blocks exceeds the limit %d", _simulateBlocksLimit)
+	}
+	bnParam := in.Get("params.1")
+	bn, err := parseBlockNumber(&bnParam)
+	if err != nil {
+		return nil, err
+	}
+	height, archive := blockNumberToHeight(bn)
+	if !archive {
+		height = 0
+	}
+	var (
+		blocks   = make([]*apitypes.SimulateBlock, 0, len(blockStateCalls))
+		numCalls int
+	)
+	for _, bsc := range blockStateCalls {
+		blk, err := parseSimulateBlock(bsc)
+		if err != nil {
+			return nil, err
+		}
+		if numCalls += len(blk.Calls); numCalls > _simulateCallsLimit {
+			return nil, errors.Wrapf(errInvalidFormat, "number of calls exceeds the limit %d", _simulateCallsLimit)
+		}
+		blocks = append(blocks, blk)
+	}
+	results, err := svr.coreService.SimulateBlocks(ctx, height, blocks)
+	if err != nil {
+		return nil, err
+	}
+	return mapper(results, func(blk *apitypes.SimulatedBlock) *simulatedBlockResult {
+		return &simulatedBlockResult{blk}
+	}), nil
+}
+
 func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interface{}, error) {
 	var (
 		err     error
 		callMsg *callMsg
 	)
+	// the second param is a block number or hash object, which is parsed below
 	blkNumOrHashObj, options := in.Get("params.1"), in.Get("params.2")
-	callMsg, err = parseCallObject(in)
+	callMsg, err = parseCallMsg(in.Get("params.0"))
 	if err != nil {
 		return nil, err
 	}
 
 	var blkNumOrHash any
 	if blkNumOrHashObj.Exists() {
-		blkNumOrHash = blkNumOrHashObj.Get("blockHash").String()
-		if blkNumOrHash == "" {
-			blkNumOrHash = blkNumOrHashObj.Get("blockNumber").Uint()
+		if blkHash := blkNumOrHashObj.Get("blockHash").String(); blkHash != "" {
+			blkNumOrHash = blkHash
+		} else {
+			// the block is either a block number or an object of blockNumber
+			blkNum := blkNumOrHashObj.String()
+			if blkNumOrHashObj.IsObject() {
+				blkNum = blkNumOrHashObj.Get("blockNumber").String()
+			}
+			height, err := svr.parseBlockNumber(blkNum)
+			if err != nil {
+				return nil, err
+			}
+			blkNumOrHash = height
 		}
 	}
 
 	var (
 		enableMemory, disableStack, disableStorage, enableReturnData bool
 		tracerJs, tracerTimeout                                      *string
+		tracerConfig                                                 json.RawMessage
 	)
 	if options.Exists() {
 		enableMemory = options.Get("enableMemory").Bool()
@@ -1180,6 +1736,9 @@ func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interf
 		if trace.Exists() {
 			tracerJs = new(string)
 			*tracerJs = trace.String()
+			if cfg := options.Get("tracerConfig"); cfg.Exists() {
+				tracerConfig = json.RawMessage(cfg.Raw)
+			}
 		}
 		traceTimeout := options.Get("timeout")
 		if traceTimeout.Exists() {
@@ -1188,8 +1747,9 @@ func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interf
 		}
 	}
 	cfg := &tracers.TraceConfig{
-		Tracer:  tracerJs,
-		Timeout: tracerTimeout,
+		Tracer:       tracerJs,
+		Timeout:      tracerTimeout,
+		TracerConfig: tracerConfig,
 		Config: &logger.Config{
 			EnableMemory:     enableMemory,
 			DisableStack:     disableStack,
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+// tipPinnedCoreService pins the tip height for all the requests in a batch, so that the blocks, actions and receipts
+// committed while the batch is being handled are invisible to the whole batch. It is not a snapshot of the states,
+// the account and contract states are still read at the latest height, unless the height is specified in the request.
+type tipPinnedCoreService struct {
+	CoreService
+	once      sync.Once
+	tipHeight uint64
+}
+
+func newTipPinnedCoreService(core CoreService) *tipPinnedCoreService {
+	return &tipPinnedCoreService{
+		CoreService: core,
+	}
+}
+
+// TipHeight returns the tip height when the batch first reads the chain
+func (tp *tipPinnedCoreService) TipHeight() uint64 {
+	tp.once.Do(func() {
+		tp.tipHeight = tp.CoreService.TipHeight()
+	})
+	return tp.tipHeight
+}
+
+// BlockByHeight returns the block at the height if it is not later than the pinned tip
+func (tp *tipPinnedCoreService) BlockByHeight(height uint64) (*apitypes.BlockWithReceipts, error) {
+	if height > tp.TipHeight() {
+		return nil, errors.Wrapf(ErrNotFound, "block %d is later than the pinned tip %d", height, tp.TipHeight())
+	}
+	return tp.CoreService.BlockByHeight(height)
+}
+
+// BlockByHash returns the block of the hash if it is not later than the pinned tip
+func (tp *tipPinnedCoreService) BlockByHash(h string) (*apitypes.BlockWithReceipts, error) {
+	blk, err := tp.CoreService.BlockByHash(h)
+	if err != nil {
+		return nil, err
+	}
+	if height := blk.Block.Height(); height > tp.TipHeight() {
+		return nil, errors.Wrapf(ErrNotFound, "block %d is later than the pinned tip %d", height, tp.TipHeight())
+	}
+	return blk, nil
+}
+
+// BlockByHeightRange returns the blocks in the range which are not later than the pinned tip
+func (tp *tipPinnedCoreService) BlockByHeightRange(start uint64, count uint64) ([]*apitypes.BlockWithReceipts, error) {
+	if start > tp.TipHeight() {
+		return nil, errors.Wrap(errInvalidFormat, "start height should not exceed tip height")
+	}
+	blks, err := tp.CoreService.BlockByHeightRange(start, count)
+	if err != nil {
+		return nil, err
+	}
+	for i := range blks {
+		if blks[i].Block.Height() > tp.TipHeight() {
+			return blks[:i], nil
+		}
+	}
+	return blks, nil
+}
+
+// ActionByActionHash returns the action of the hash if it is not later than the pinned tip
+func (tp *tipPinnedCoreService) ActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, *block.Block, uint32, error) {
+	selp, blk, idx, err := tp.CoreService.ActionByActionHash(h)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if height := blk.Height(); height > tp.TipHeight() {
+		return nil, nil, 0, errors.Wrapf(ErrNotFound, "action %x is later than the pinned tip %d", h, tp.TipHeight())
+	}
+	return selp, blk, idx, nil
+}
+
+// ReceiptByActionHash returns the receipt of the action if it is not later than the pinned tip
+func (tp *tipPinnedCoreService) ReceiptByActionHash(h hash.Hash256) (*action.Receipt, error) {
+	receipt, err := tp.CoreService.ReceiptByActionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.BlockHeight > tp.TipHeight() {
+		return nil, errors.Wrapf(ErrNotFound, "receipt of action %x is later than the pinned tip %d", h, tp.TipHeight())
+	}
+	return receipt, nil
+}
+
+// handleWeb3Batch handles the requests in batch against the same tip height, at most batchRequestConcurrency requests
+// are handled at the same time, and the responses are written in the order of requests
+func (svr *web3Handler) handleWeb3Batch(ctx context.Context, web3Reqs []gjson.Result, writer apitypes.Web3ResponseWriter) error {
+	var (
+		batchWriter = apitypes.NewBatchWriter(writer)
+		handler     = *svr
+	)
+	handler.coreService = newTipPinnedCoreService(svr.coreService)
+	if svr.batchRequestConcurrency <= 1 {
+		for i := range web3Reqs {
+			if err := handler.handleWeb3Req(ctx, &web3Reqs[i], batchWriter); err != nil {
+				return err
+			}
+		}
+		return batchWriter.Flush()
+	}
+	var (
+		slots = batchWriter.Slots(len(web3Reqs))
+		eg    errgroup.Group
+	)
+	eg.SetLimit(svr.batchRequestConcurrency)
+	for i := range web3Reqs {
+		eg.Go(func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = errors.Wrapf(errPanic, "recovered from panic: %v, request params: %+v", r, web3Reqs[i])
+				}
+			}()
+			return handler.handleWeb3Req(ctx, &web3Reqs[i], slots[i])
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	return batchWriter.Flush()
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestHandleWeb3Batch(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blk, err := block.NewTestingBuilder().
+		SetHeight(11).
+		SetTimeStamp(time.Now()).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+
+	for _, concurrency := range []int{1, 4} {
+		core := NewMockCoreService(ctrl)
+		core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+		// the tip height is read only once for the whole batch
+		core.EXPECT().TipHeight().Return(uint64(10)).Times(1)
+		// the action committed after the pinned tip is invisible
+		core.EXPECT().ActionByActionHash(gomock.Any()).Return(nil, &blk, uint32(0), nil).Times(1)
+		svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, concurrency))
+
+		reqs := make([]string, 0, 20)
+		for i := 0; i < 20; i++ {
+			reqs = append(reqs, fmt.Sprintf(`{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":%d}`, i))
+		}
+		reqs[5] = `{"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["0xb", false],"id":5}`
+		reqs[7] = `{"jsonrpc":"2.0","method":"eth_getTransactionReceipt","params":["0x` + strings.Repeat("1", 64) + `"],"id":7}`
+
+		req, _ := http.NewRequest(http.MethodPost, "http://url.com", strings.NewReader("["+strings.Join(reqs, ",")+"]"))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		svr.ServeHTTP(resp, req)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(err)
+		require.True(gjson.ValidBytes(body))
+
+		results := gjson.ParseBytes(body).Array()
+		require.Len(results, 20)
+		for i, res := range results {
+			// responses are in the order of requests
+			require.EqualValues(i, res.Get("id").Int())
+			switch i {
+			case 5, 7:
+				require.Equal(gjson.Null, res.Get("result").Type)
+			default:
+				require.Equal("0xa", res.Get("result").String())
+			}
+		}
+	}
+}
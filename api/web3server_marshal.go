@@ -3,6 +3,9 @@ package api
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -10,13 +13,23 @@ import (
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/actpool"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/gasstation"
+	"github.com/iotexproject/iotex-core/v2/state"
 )
 
 const (
@@ -74,10 +87,36 @@ type (
 		log       *action.Log
 	}
 
+	bucketEventResult struct {
+		blockHash hash.Hash256
+		event     *staking.BucketEvent
+	}
+
+	actionByAddressResult struct {
+		cursor uint64
+		action *iotexapi.ActionInfo
+	}
+
+	getProofResult struct {
+		proof *AccountProof
+	}
+
+	storageProofResult struct {
+		Key   string   `json:"key"`
+		Value string   `json:"value"`
+		Proof []string `json:"proof"`
+	}
+
 	getSyncingResult struct {
-		StartingBlock string `json:"startingBlock"`
-		CurrentBlock  string `json:"currentBlock"`
-		HighestBlock  string `json:"highestBlock"`
+		StartingBlock string            `json:"startingBlock"`
+		CurrentBlock  string            `json:"currentBlock"`
+		HighestBlock  string            `json:"highestBlock"`
+		Stages        []syncStageResult `json:"stages,omitempty"`
+	}
+
+	syncStageResult struct {
+		Name         string `json:"name"`
+		CurrentBlock string `json:"currentBlock"`
 	}
 
 	debugTraceTransactionResult struct {
@@ -88,6 +127,121 @@ type (
 		StructLogs  []apitypes.StructLog `json:"structLogs"`
 	}
 
+	simulatedBlockResult struct {
+		blk *apitypes.SimulatedBlock
+	}
+
+	simulatedCallResult struct {
+		ReturnData string           `json:"returnData"`
+		Logs       []*getLogsResult `json:"logs"`
+		GasUsed    string           `json:"gasUsed"`
+		Status     string           `json:"status"`
+		Error      *errMessage      `json:"error,omitempty"`
+	}
+
+	epochDryRunResult struct {
+		res *poll.EpochDryRun
+	}
+
+	epochCandidateResult struct {
+		Address       string `json:"address"`
+		Name          string `json:"name"`
+		Votes         string `json:"votes"`
+		RewardAddress string `json:"rewardAddress"`
+	}
+
+	probationListResult struct {
+		IntensityRate uint32            `json:"intensityRate"`
+		Members       map[string]uint32 `json:"members"`
+	}
+
+	transferHistoryResult struct {
+		total     uint64
+		transfers []*blockindex.BalanceTransfer
+	}
+
+	tokenBalancesResult struct {
+		balances []*blockindex.TokenBalance
+	}
+
+	tokenHoldersResult struct {
+		total   uint64
+		holders []*blockindex.TokenBalance
+	}
+
+	tokenTransfersResult struct {
+		total     uint64
+		transfers []*blockindex.TokenTransfer
+	}
+
+	tokenBalanceResult struct {
+		Contract string `json:"contract,omitempty"`
+		Holder   string `json:"holder,omitempty"`
+		Standard string `json:"standard"`
+		Balance  string `json:"balance"`
+	}
+
+	txPoolContentResult struct {
+		content map[string]*actpool.Content
+		chainID uint32
+		// inspect summarizes each transaction in a line instead of the full object
+		inspect bool
+	}
+
+	feeSuggestionResult struct {
+		suggestion *gasstation.FeeSuggestion
+	}
+
+	feeLevelResult struct {
+		GasPrice             string `json:"gasPrice"`
+		MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+		MaxFeePerGas         string `json:"maxFeePerGas"`
+	}
+
+	nonceGapsResult struct {
+		gaps *actpool.NonceGaps
+	}
+
+	nonceGapResult struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}
+
+	finalityProofResult struct {
+		proof *FinalityProof
+	}
+
+	finalizedBlockResult struct {
+		Height       string              `json:"height"`
+		Hash         string              `json:"hash"`
+		Header       string              `json:"header"`
+		Endorsements []endorsementResult `json:"endorsements"`
+	}
+
+	endorsementResult struct {
+		Endorser  string `json:"endorser"`
+		PublicKey string `json:"publicKey"`
+		Timestamp string `json:"timestamp"`
+		// Digest is the hash signed by the endorser, computed from the block hash and the timestamp
+		Digest    string `json:"digest"`
+		Signature string `json:"signature"`
+	}
+
+	replaceableTxResult struct {
+		Hash             string  `json:"hash"`
+		Nonce            string  `json:"nonce"`
+		GasFeeCap        string  `json:"maxFeePerGas"`
+		GasTipCap        string  `json:"maxPriorityFeePerGas"`
+		MinGasFeeCap     string  `json:"minReplacementMaxFeePerGas"`
+		MinGasTipCap     string  `json:"minReplacementMaxPriorityFeePerGas"`
+		MinBlobGasFeeCap *string `json:"minReplacementMaxFeePerBlobGas,omitempty"`
+	}
+
+	txPoolStatusResult struct {
+		Pending string `json:"pending"`
+		Queued  string `json:"queued"`
+	}
+
 	feeHistoryResult struct {
 		OldestBlock       string     `json:"oldestBlock"`
 		BaseFeePerGas     []string   `json:"baseFeePerGas"`
@@ -441,6 +595,317 @@ func (obj *getLogsResult) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (obj *simulatedBlockResult) MarshalJSON() ([]byte, error) {
+	if obj.blk == nil {
+		return nil, errInvalidObject
+	}
+	feeRecipient, err := ioAddrToEthAddr(obj.blk.Producer.String())
+	if err != nil {
+		return nil, err
+	}
+	calls := make([]*simulatedCallResult, 0, len(obj.blk.Calls))
+	for _, call := range obj.blk.Calls {
+		res := &simulatedCallResult{
+			ReturnData: byteToHex(call.ReturnData),
+			Logs:       make([]*getLogsResult, 0, len(call.Receipt.Logs())),
+			GasUsed:    uint64ToHex(call.Receipt.GasConsumed),
+			Status:     "0x1",
+		}
+		// simulated blocks have no hash
+		for _, l := range call.Receipt.Logs() {
+			res.Logs = append(res.Logs, &getLogsResult{hash.ZeroHash256, l})
+		}
+		if call.Receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+			res.Status = "0x0"
+			res.Error = &errMessage{
+				Code:    -32015,
+				Message: iotextypes.ReceiptStatus_name[int32(call.Receipt.Status)],
+			}
+			if call.Receipt.Status == uint64(iotextypes.ReceiptStatus_ErrExecutionReverted) {
+				res.Error.Code, res.Error.Message = 3, "execution reverted"
+				if msg := call.Receipt.ExecutionRevertMsg(); msg != "" {
+					res.Error.Message += ": " + msg
+				}
+				res.Error.Data = res.ReturnData
+			}
+		}
+		calls = append(calls, res)
+	}
+	var baseFee *string
+	if obj.blk.BaseFee != nil {
+		fee := bigIntToHex(obj.blk.BaseFee)
+		baseFee = &fee
+	}
+	return json.Marshal(&struct {
+		Number        string                 `json:"number"`
+		Hash          string                 `json:"hash"`
+		Timestamp     string                 `json:"timestamp"`
+		GasLimit      string                 `json:"gasLimit"`
+		GasUsed       string                 `json:"gasUsed"`
+		FeeRecipient  string                 `json:"miner"`
+		BaseFeePerGas *string                `json:"baseFeePerGas,omitempty"`
+		Calls         []*simulatedCallResult `json:"calls"`
+	}{
+		Number:        uint64ToHex(obj.blk.Height),
+		Hash:          "0x" + hex.EncodeToString(hash.ZeroHash256[:]),
+		Timestamp:     uint64ToHex(uint64(obj.blk.Timestamp.Unix())),
+		GasLimit:      uint64ToHex(obj.blk.GasLimit),
+		GasUsed:       uint64ToHex(obj.blk.GasUsed),
+		FeeRecipient:  feeRecipient,
+		BaseFeePerGas: baseFee,
+		Calls:         calls,
+	})
+}
+
+func (obj *getProofResult) MarshalJSON() ([]byte, error) {
+	if obj.proof == nil || obj.proof.Account == nil {
+		return nil, errInvalidObject
+	}
+	storageProof := make([]storageProofResult, 0, len(obj.proof.StorageProofs))
+	for _, sp := range obj.proof.StorageProofs {
+		storageProof = append(storageProof, storageProofResult{
+			Key:   byteToHex(sp.Key[:]),
+			Value: bigIntToHex(new(big.Int).SetBytes(sp.Value)),
+			Proof: mapper(sp.Proof, byteToHex),
+		})
+	}
+	codeHash := hash.BytesToHash256(obj.proof.Account.CodeHash)
+	return json.Marshal(&struct {
+		Address      string               `json:"address"`
+		AccountProof []string             `json:"accountProof"`
+		Balance      string               `json:"balance"`
+		CodeHash     string               `json:"codeHash"`
+		Nonce        string               `json:"nonce"`
+		StorageHash  string               `json:"storageHash"`
+		StorageProof []storageProofResult `json:"storageProof"`
+		StateRoot    string               `json:"stateRoot"`
+	}{
+		Address:      common.BytesToAddress(obj.proof.Address.Bytes()).Hex(),
+		AccountProof: mapper(obj.proof.Proof, byteToHex),
+		Balance:      bigIntToHex(obj.proof.Account.Balance),
+		CodeHash:     byteToHex(codeHash[:]),
+		Nonce:        uint64ToHex(obj.proof.Nonce),
+		StorageHash:  byteToHex(obj.proof.StorageRoot),
+		StorageProof: storageProof,
+		StateRoot:    byteToHex(obj.proof.StateRoot),
+	})
+}
+
+func (obj *bucketEventResult) MarshalJSON() ([]byte, error) {
+	if obj.event == nil {
+		return nil, errInvalidObject
+	}
+	var candidate, owner *string
+	if obj.event.Candidate != nil {
+		addr := common.BytesToAddress(obj.event.Candidate.Bytes()).Hex()
+		candidate = &addr
+	}
+	if obj.event.Owner != nil {
+		addr := common.BytesToAddress(obj.event.Owner.Bytes()).Hex()
+		owner = &addr
+	}
+	var amount *string
+	if obj.event.Amount != nil {
+		amt := bigIntToHex(obj.event.Amount)
+		amount = &amt
+	}
+	return json.Marshal(&struct {
+		Type            string  `json:"type"`
+		BucketIndex     string  `json:"bucketIndex"`
+		Candidate       *string `json:"candidate"`
+		Owner           *string `json:"owner"`
+		Amount          *string `json:"amount"`
+		TransactionHash string  `json:"transactionHash"`
+		BlockHash       string  `json:"blockHash"`
+		BlockNumber     string  `json:"blockNumber"`
+	}{
+		Type:            obj.event.Type,
+		BucketIndex:     uint64ToHex(obj.event.BucketIndex),
+		Candidate:       candidate,
+		Owner:           owner,
+		Amount:          amount,
+		TransactionHash: "0x" + hex.EncodeToString(obj.event.ActionHash[:]),
+		BlockHash:       "0x" + hex.EncodeToString(obj.blockHash[:]),
+		BlockNumber:     uint64ToHex(obj.event.BlockHeight),
+	})
+}
+
+func (obj *actionByAddressResult) MarshalJSON() ([]byte, error) {
+	if obj.action == nil {
+		return nil, errInvalidObject
+	}
+	return json.Marshal(&struct {
+		Cursor          string `json:"cursor"`
+		TransactionHash string `json:"transactionHash"`
+		BlockHash       string `json:"blockHash"`
+		BlockNumber     string `json:"blockNumber"`
+		Timestamp       string `json:"timestamp"`
+	}{
+		Cursor:          uint64ToHex(obj.cursor),
+		TransactionHash: "0x" + obj.action.ActHash,
+		BlockHash:       "0x" + obj.action.BlkHash,
+		BlockNumber:     uint64ToHex(obj.action.BlkHeight),
+		Timestamp:       uint64ToHex(uint64(obj.action.Timestamp.GetSeconds())),
+	})
+}
+
+func (obj *epochDryRunResult) MarshalJSON() ([]byte, error) {
+	if obj.res == nil {
+		return nil, errInvalidObject
+	}
+	candidates := func(list state.CandidateList) []epochCandidateResult {
+		ret := make([]epochCandidateResult, 0, len(list))
+		for _, cand := range list {
+			votes := "0x0"
+			if cand.Votes != nil {
+				votes = bigIntToHex(cand.Votes)
+			}
+			ret = append(ret, epochCandidateResult{
+				Address:       cand.Address,
+				Name:          string(cand.CanName),
+				Votes:         votes,
+				RewardAddress: cand.RewardAddress,
+			})
+		}
+		return ret
+	}
+	var probationList *probationListResult
+	if obj.res.ProbationList != nil {
+		probationList = &probationListResult{
+			IntensityRate: obj.res.ProbationList.IntensityRate,
+			Members:       obj.res.ProbationList.ProbationInfo,
+		}
+		if probationList.Members == nil {
+			probationList.Members = map[string]uint32{}
+		}
+	}
+	return json.Marshal(&struct {
+		EpochNum             string                 `json:"epochNum"`
+		Height               string                 `json:"height"`
+		Candidates           []epochCandidateResult `json:"candidates"`
+		BlockProducers       []epochCandidateResult `json:"blockProducers"`
+		ActiveBlockProducers []epochCandidateResult `json:"activeBlockProducers"`
+		ProbationList        *probationListResult   `json:"probationList"`
+	}{
+		EpochNum:             uint64ToHex(obj.res.EpochNum),
+		Height:               uint64ToHex(obj.res.Height),
+		Candidates:           candidates(obj.res.Candidates),
+		BlockProducers:       candidates(obj.res.BlockProducers),
+		ActiveBlockProducers: candidates(obj.res.ActiveBlockProducers),
+		ProbationList:        probationList,
+	})
+}
+
+func (obj *transferHistoryResult) MarshalJSON() ([]byte, error) {
+	type transferResult struct {
+		Type            string  `json:"type"`
+		From            *string `json:"from"`
+		To              *string `json:"to"`
+		Value           string  `json:"value"`
+		TransactionHash string  `json:"transactionHash"`
+		BlockNumber     string  `json:"blockNumber"`
+	}
+	// the recipient is null if the amount is burned
+	toEthAddr := func(ioAddr string) *string {
+		if ioAddr == "" {
+			return nil
+		}
+		addr, err := ioAddrToEthAddr(ioAddr)
+		if err != nil {
+			return nil
+		}
+		return &addr
+	}
+	transfers := make([]transferResult, 0, len(obj.transfers))
+	for _, t := range obj.transfers {
+		transfers = append(transfers, transferResult{
+			Type:            t.Type.String(),
+			From:            toEthAddr(t.Sender),
+			To:              toEthAddr(t.Recipient),
+			Value:           bigIntToHex(t.Amount),
+			TransactionHash: "0x" + hex.EncodeToString(t.ActionHash[:]),
+			BlockNumber:     uint64ToHex(t.Height),
+		})
+	}
+	return json.Marshal(&struct {
+		Total     string           `json:"total"`
+		Transfers []transferResult `json:"transfers"`
+	}{
+		Total:     uint64ToHex(obj.total),
+		Transfers: transfers,
+	})
+}
+
+func (obj *tokenBalancesResult) MarshalJSON() ([]byte, error) {
+	balances := make([]tokenBalanceResult, 0, len(obj.balances))
+	for _, b := range obj.balances {
+		balances = append(balances, tokenBalanceResult{
+			Contract: common.BytesToAddress(b.Contract.Bytes()).Hex(),
+			Standard: b.Standard.String(),
+			Balance:  bigIntToHex(b.Balance),
+		})
+	}
+	return json.Marshal(balances)
+}
+
+func (obj *tokenHoldersResult) MarshalJSON() ([]byte, error) {
+	holders := make([]tokenBalanceResult, 0, len(obj.holders))
+	for _, b := range obj.holders {
+		holders = append(holders, tokenBalanceResult{
+			Holder:   common.BytesToAddress(b.Holder.Bytes()).Hex(),
+			Standard: b.Standard.String(),
+			Balance:  bigIntToHex(b.Balance),
+		})
+	}
+	return json.Marshal(&struct {
+		Total   string               `json:"total"`
+		Holders []tokenBalanceResult `json:"holders"`
+	}{
+		Total:   uint64ToHex(obj.total),
+		Holders: holders,
+	})
+}
+
+func (obj *tokenTransfersResult) MarshalJSON() ([]byte, error) {
+	type transferResult struct {
+		Contract        string  `json:"contract"`
+		Standard        string  `json:"standard"`
+		From            string  `json:"from"`
+		To              string  `json:"to"`
+		Value           *string `json:"value,omitempty"`
+		TokenID         *string `json:"tokenId,omitempty"`
+		TransactionHash string  `json:"transactionHash"`
+		LogIndex        string  `json:"logIndex"`
+		BlockNumber     string  `json:"blockNumber"`
+	}
+	transfers := make([]transferResult, 0, len(obj.transfers))
+	for _, t := range obj.transfers {
+		amount := bigIntToHex(t.Amount)
+		res := transferResult{
+			Contract:        common.BytesToAddress(t.Contract.Bytes()).Hex(),
+			Standard:        t.Standard.String(),
+			From:            common.BytesToAddress(t.From.Bytes()).Hex(),
+			To:              common.BytesToAddress(t.To.Bytes()).Hex(),
+			TransactionHash: "0x" + hex.EncodeToString(t.ActionHash[:]),
+			LogIndex:        uint64ToHex(uint64(t.LogIndex)),
+			BlockNumber:     uint64ToHex(t.Height),
+		}
+		if t.Standard == blockindex.XRC721 {
+			res.TokenID = &amount
+		} else {
+			res.Value = &amount
+		}
+		transfers = append(transfers, res)
+	}
+	return json.Marshal(&struct {
+		Total     string           `json:"total"`
+		Transfers []transferResult `json:"transfers"`
+	}{
+		Total:     uint64ToHex(obj.total),
+		Transfers: transfers,
+	})
+}
+
 func (obj *streamResponse) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		Jsonrpc string       `json:"jsonrpc"`
@@ -455,3 +920,230 @@ func (obj *streamResponse) MarshalJSON() ([]byte, error) {
 		},
 	})
 }
+
+func (obj *txPoolContentResult) MarshalJSON() ([]byte, error) {
+	var (
+		pending = make(map[string]map[string]any)
+		queued  = make(map[string]map[string]any)
+	)
+	for sender, c := range obj.content {
+		from, err := ioAddrToEthAddr(sender)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range []struct {
+			acts []*action.SealedEnvelope
+			res  map[string]map[string]any
+		}{
+			{c.Pending, pending},
+			{c.Queued, queued},
+		} {
+			if len(group.acts) == 0 {
+				continue
+			}
+			txs := make(map[string]any, len(group.acts))
+			for _, selp := range group.acts {
+				tx, err := obj.transaction(selp)
+				if err != nil {
+					return nil, err
+				}
+				txs[strconv.FormatUint(selp.Nonce(), 10)] = tx
+			}
+			group.res[from] = txs
+		}
+	}
+	return json.Marshal(map[string]any{
+		"pending": pending,
+		"queued":  queued,
+	})
+}
+
+func (obj *feeSuggestionResult) MarshalJSON() ([]byte, error) {
+	level := func(l *gasstation.FeeLevel) *feeLevelResult {
+		return &feeLevelResult{
+			GasPrice:             bigIntToHex(l.GasPrice),
+			MaxPriorityFeePerGas: bigIntToHex(l.GasTipCap),
+			MaxFeePerGas:         bigIntToHex(l.GasFeeCap),
+		}
+	}
+	var baseFee *string
+	if obj.suggestion.BaseFee != nil {
+		tmp := bigIntToHex(obj.suggestion.BaseFee)
+		baseFee = &tmp
+	}
+	return json.Marshal(&struct {
+		BaseFee  *string         `json:"baseFee,omitempty"`
+		Slow     *feeLevelResult `json:"slow"`
+		Standard *feeLevelResult `json:"standard"`
+		Fast     *feeLevelResult `json:"fast"`
+	}{
+		BaseFee:  baseFee,
+		Slow:     level(obj.suggestion.Slow),
+		Standard: level(obj.suggestion.Standard),
+		Fast:     level(obj.suggestion.Fast),
+	})
+}
+
+func (obj *nonceGapsResult) MarshalJSON() ([]byte, error) {
+	var (
+		gaps = make([]nonceGapResult, 0, len(obj.gaps.Gaps))
+		txs  = make([]replaceableTxResult, 0, len(obj.gaps.Actions))
+	)
+	for _, g := range obj.gaps.Gaps {
+		gaps = append(gaps, nonceGapResult{
+			Start: uint64ToHex(g.Start),
+			End:   uint64ToHex(g.End),
+		})
+	}
+	for _, act := range obj.gaps.Actions {
+		h, err := act.Action.Hash()
+		if err != nil {
+			return nil, err
+		}
+		tx := replaceableTxResult{
+			Hash:         "0x" + hex.EncodeToString(h[:]),
+			Nonce:        uint64ToHex(act.Action.Nonce()),
+			GasFeeCap:    bigIntToHex(act.Action.GasFeeCap()),
+			GasTipCap:    bigIntToHex(act.Action.GasTipCap()),
+			MinGasFeeCap: bigIntToHex(act.MinGasFeeCap),
+			MinGasTipCap: bigIntToHex(act.MinGasTipCap),
+		}
+		if act.MinBlobGasFeeCap != nil {
+			tmp := bigIntToHex(act.MinBlobGasFeeCap)
+			tx.MinBlobGasFeeCap = &tmp
+		}
+		txs = append(txs, tx)
+	}
+	return json.Marshal(&struct {
+		ConfirmedNonce string                `json:"confirmedNonce"`
+		PendingNonce   string                `json:"pendingNonce"`
+		Gaps           []nonceGapResult      `json:"gaps"`
+		Transactions   []replaceableTxResult `json:"transactions"`
+	}{
+		ConfirmedNonce: uint64ToHex(obj.gaps.ConfirmedNonce),
+		PendingNonce:   uint64ToHex(obj.gaps.PendingNonce),
+		Gaps:           gaps,
+		Transactions:   txs,
+	})
+}
+
+// transaction returns the transaction object, or its summary in the format of "to: value wei + gas × gasPrice wei"
+func (obj *txPoolContentResult) transaction(selp *action.SealedEnvelope) (any, error) {
+	if !obj.inspect {
+		return newGetTransactionResult(nil, selp, nil, obj.chainID)
+	}
+	ethTx, err := selp.ToEthTx()
+	if err != nil {
+		return nil, err
+	}
+	to := "contract creation"
+	if ethTx.To() != nil {
+		to = ethTx.To().Hex()
+	}
+	return fmt.Sprintf("%s: %s wei + %d gas × %s wei", to, ethTx.Value(), ethTx.Gas(), ethTx.GasFeeCap()), nil
+}
+
+func (obj *finalityProofResult) MarshalJSON() ([]byte, error) {
+	type candidatesProofResult struct {
+		finalizedBlockResult
+		Action     string   `json:"action"`
+		Index      string   `json:"index"`
+		Siblings   []string `json:"siblings"`
+		Candidates []string `json:"candidates"`
+	}
+	blk, err := newFinalizedBlockResult(obj.proof.Block)
+	if err != nil {
+		return nil, err
+	}
+	delegates, err := candidateEthAddrs(obj.proof.Delegates)
+	if err != nil {
+		return nil, err
+	}
+	var candidatesProof *candidatesProofResult
+	if cp := obj.proof.CandidatesProof; cp != nil {
+		r, ok := cp.Action.Action().(*action.PutPollResult)
+		if !ok {
+			return nil, errors.New("candidates proof is not a poll result")
+		}
+		prevBlk, err := newFinalizedBlockResult(cp.Block)
+		if err != nil {
+			return nil, err
+		}
+		act, err := proto.Marshal(cp.Action.Proto())
+		if err != nil {
+			return nil, err
+		}
+		candidates, err := candidateEthAddrs(r.Candidates())
+		if err != nil {
+			return nil, err
+		}
+		candidatesProof = &candidatesProofResult{
+			finalizedBlockResult: *prevBlk,
+			Action:               byteToHex(act),
+			Index:                uint64ToHex(uint64(cp.Index)),
+			Siblings:             make([]string, len(cp.Siblings)),
+			Candidates:           candidates,
+		}
+		for i, s := range cp.Siblings {
+			candidatesProof.Siblings[i] = byteToHex(s[:])
+		}
+	}
+	return json.Marshal(&struct {
+		*finalizedBlockResult
+		Epoch           string                 `json:"epoch"`
+		Delegates       []string               `json:"delegates"`
+		CandidatesProof *candidatesProofResult `json:"candidatesProof"`
+	}{
+		finalizedBlockResult: blk,
+		Epoch:                uint64ToHex(obj.proof.Epoch),
+		Delegates:            delegates,
+		CandidatesProof:      candidatesProof,
+	})
+}
+
+// newFinalizedBlockResult returns the header of the block and the endorsements of its COMMIT votes, the hash of the
+// header being the block hash
+func newFinalizedBlockResult(blk *block.Block) (*finalizedBlockResult, error) {
+	header, err := blk.Header.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	blkHash := blk.HashBlock()
+	vote := rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT)
+	ens := make([]endorsementResult, 0, len(blk.Endorsements()))
+	for _, en := range blk.Endorsements() {
+		endorser, err := ioAddrToEthAddr(en.Endorser().Address().String())
+		if err != nil {
+			return nil, err
+		}
+		digest, err := en.Digest(vote)
+		if err != nil {
+			return nil, err
+		}
+		ens = append(ens, endorsementResult{
+			Endorser:  endorser,
+			PublicKey: byteToHex(en.Endorser().Bytes()),
+			Timestamp: strconv.FormatInt(en.Timestamp().UnixNano(), 10),
+			Digest:    byteToHex(digest),
+			Signature: byteToHex(en.Signature()),
+		})
+	}
+	return &finalizedBlockResult{
+		Height:       uint64ToHex(blk.Height()),
+		Hash:         byteToHex(blkHash[:]),
+		Header:       byteToHex(header),
+		Endorsements: ens,
+	}, nil
+}
+
+func candidateEthAddrs(candidates state.CandidateList) ([]string, error) {
+	addrs := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		addr, err := ioAddrToEthAddr(c.Address)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
@@ -14,6 +15,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -23,13 +26,25 @@ import (
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/go-pkgs/util"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/v2/actpool"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/gasstation"
+	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	mock_apitypes "github.com/iotexproject/iotex-core/v2/test/mock/mock_apiresponder"
 	"github.com/iotexproject/iotex-core/v2/testutil"
@@ -102,7 +117,7 @@ func TestHandlePost(t *testing.T) {
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
 	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
-	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit))
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, _defaultBatchRequestConcurrency))
 	getServerResp := func(svr *hTTPHandler, req *http.Request) *httptest.ResponseRecorder {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
@@ -170,7 +185,7 @@ func TestGasPrice(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().SuggestGasPrice().Return(uint64(1), nil)
 	ret, err := web3svr.gasPrice()
 	require.NoError(err)
@@ -186,7 +201,7 @@ func TestGetChainID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().EVMNetworkID().Return(uint32(1))
 	ret, err := web3svr.getChainID()
 	require.NoError(err)
@@ -198,7 +213,7 @@ func TestGetBlockNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().TipHeight().Return(uint64(1))
 	ret, err := web3svr.getBlockNumber()
 	require.NoError(err)
@@ -210,7 +225,7 @@ func TestGetBlockByNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -261,7 +276,7 @@ func TestGetBalance(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	balance := "111111111111111111"
 	core.EXPECT().WithHeight(gomock.Any()).Return(core).Times(1)
 	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{Balance: balance}, nil, nil)
@@ -279,7 +294,7 @@ func TestGetTransactionCount(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().PendingNonce(gomock.Any()).Return(uint64(2), nil)
 
 	inNil := gjson.Parse(`{"params":[]}`)
@@ -297,7 +312,7 @@ func TestCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	t.Run("to is StakingProtocol addr", func(t *testing.T) {
 		meta := &iotextypes.AccountMeta{
@@ -388,7 +403,7 @@ func TestEstimateGas(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().ChainID().Return(uint32(1)).Times(2)
 	core.EXPECT().EVMNetworkID().Return(uint32(0)).Times(2)
 
@@ -432,7 +447,7 @@ func TestSendRawTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().Genesis().Return(genesis.TestDefault())
 	core.EXPECT().TipHeight().Return(uint64(0))
 	core.EXPECT().EVMNetworkID().Return(uint32(1))
@@ -459,7 +474,7 @@ func TestGetCode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	code := "608060405234801561001057600080fd5b50610150806100206contractbytecode"
 	data, _ := hex.DecodeString(code)
 	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{ContractByteCode: data}, nil, nil)
@@ -483,7 +498,7 @@ func TestGetNodeInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().ServerMeta().Return("111", "", "", "222", "")
 	ret, err := web3svr.getNodeInfo()
 	require.NoError(err)
@@ -495,7 +510,7 @@ func TestGetNetworkID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().EVMNetworkID().Return(uint32(123))
 	ret, err := web3svr.getNetworkID()
 	require.NoError(err)
@@ -507,8 +522,12 @@ func TestIsSyncing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().SyncingProgress().Return(uint64(1), uint64(2), uint64(3))
+	core.EXPECT().SyncingStages().Return([]*apitypes.SyncStage{
+		{Name: "blocks", Height: 3},
+		{Name: "chain", Height: 2},
+	})
 	ret, err := web3svr.isSyncing()
 	require.NoError(err)
 	rlt, ok := ret.(*getSyncingResult)
@@ -516,6 +535,30 @@ func TestIsSyncing(t *testing.T) {
 	require.Equal("0x1", rlt.StartingBlock)
 	require.Equal("0x2", rlt.CurrentBlock)
 	require.Equal("0x3", rlt.HighestBlock)
+	require.Equal([]syncStageResult{{"blocks", "0x3"}, {"chain", "0x2"}}, rlt.Stages)
+
+	// synced
+	stages := []*apitypes.SyncStage{
+		{Name: "blocks", Height: 3},
+		{Name: "chain", Height: 3},
+		{Name: "indexer", Height: 3},
+	}
+	core.EXPECT().SyncingProgress().Return(uint64(1), uint64(3), uint64(3))
+	core.EXPECT().SyncingStages().Return(stages)
+	ret, err = web3svr.isSyncing()
+	require.NoError(err)
+	require.Equal(false, ret)
+
+	// indexer is catching up
+	stages[2].Height = 2
+	core.EXPECT().SyncingProgress().Return(uint64(1), uint64(3), uint64(3))
+	core.EXPECT().SyncingStages().Return(stages)
+	ret, err = web3svr.isSyncing()
+	require.NoError(err)
+	rlt, ok = ret.(*getSyncingResult)
+	require.True(ok)
+	require.Equal("0x3", rlt.HighestBlock)
+	require.Equal(syncStageResult{"indexer", "0x2"}, rlt.Stages[2])
 }
 
 func TestGetBlockTransactionCountByHash(t *testing.T) {
@@ -523,7 +566,7 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -559,7 +602,7 @@ func TestGetBlockByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -611,7 +654,7 @@ func TestGetTransactionByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -681,7 +724,7 @@ func TestGetLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	logs := []*action.Log{
 		{
@@ -730,7 +773,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -778,7 +821,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -813,7 +856,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -860,7 +903,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -906,7 +949,7 @@ func TestGetStorageAt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	val := []byte("test")
 	core.EXPECT().ReadContractStorage(gomock.Any(), gomock.Any(), gomock.Any()).Return(val, nil)
 
@@ -916,12 +959,383 @@ func TestGetStorageAt(t *testing.T) {
 	require.Equal("0x"+hex.EncodeToString(val), ret.(string))
 }
 
+func TestGetGasTable(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	core.EXPECT().GasTable(uint64(0)).Return(action.GasTableV1).Times(2)
+	for _, params := range []string{`[]`, `["pending"]`} {
+		in := gjson.Parse(`{"params":` + params + `}`)
+		ret, err := web3svr.getGasTable(&in)
+		require.NoError(err)
+		require.Equal(action.GasTableV1, ret)
+	}
+	core.EXPECT().TipHeight().Return(uint64(10))
+	core.EXPECT().GasTable(uint64(10)).Return(action.GasTableV1)
+	in := gjson.Parse(`{"params":["latest"]}`)
+	ret, err := web3svr.getGasTable(&in)
+	require.NoError(err)
+	data, err := json.Marshal(ret)
+	require.NoError(err)
+	res := gjson.ParseBytes(data)
+	require.Equal(int64(1), res.Get("version").Int())
+	require.Equal(action.TransferBaseIntrinsicGas, res.Get("transfer.base").Uint())
+	require.Equal(action.ExecutionDataGas, res.Get("execution.perByte").Uint())
+
+	core.EXPECT().GasTable(uint64(0x20)).Return(action.GasTableV1)
+	in = gjson.Parse(`{"params":["0x20"]}`)
+	_, err = web3svr.getGasTable(&in)
+	require.NoError(err)
+}
+
+func TestDryRunNextEpoch(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	cands := state.CandidateList{
+		{Address: identityset.Address(1).String(), Votes: big.NewInt(300), RewardAddress: identityset.Address(11).String(), CanName: []byte("alice")},
+		{Address: identityset.Address(2).String(), Votes: big.NewInt(100), RewardAddress: identityset.Address(12).String(), CanName: []byte("bob")},
+	}
+	core.EXPECT().DryRunNextEpoch(gomock.Any()).Return(&poll.EpochDryRun{
+		EpochNum:             5,
+		Height:               1441,
+		Candidates:           cands,
+		BlockProducers:       cands,
+		ActiveBlockProducers: cands[:1],
+		ProbationList: &vote.ProbationList{
+			IntensityRate: 90,
+			ProbationInfo: map[string]uint32{identityset.Address(2).String(): 1},
+		},
+	}, nil)
+	ret, err := web3svr.dryRunNextEpoch(context.Background())
+	require.NoError(err)
+	data, err := json.Marshal(ret)
+	require.NoError(err)
+	res := gjson.ParseBytes(data)
+	require.Equal("0x5", res.Get("epochNum").String())
+	require.Equal("0x5a1", res.Get("height").String())
+	require.Len(res.Get("candidates").Array(), 2)
+	require.Equal("bob", res.Get("candidates.1.name").String())
+	require.Equal("0x64", res.Get("candidates.1.votes").String())
+	require.Equal(identityset.Address(12).String(), res.Get("candidates.1.rewardAddress").String())
+	require.Len(res.Get("activeBlockProducers").Array(), 1)
+	require.Equal(identityset.Address(1).String(), res.Get("activeBlockProducers.0.address").String())
+	require.Equal(int64(90), res.Get("probationList.intensityRate").Int())
+	require.Equal(int64(1), res.Get("probationList.members."+identityset.Address(2).String()).Int())
+
+	// no probation list before it is enabled
+	core.EXPECT().DryRunNextEpoch(gomock.Any()).Return(&poll.EpochDryRun{EpochNum: 1, Height: 1}, nil)
+	ret, err = web3svr.dryRunNextEpoch(context.Background())
+	require.NoError(err)
+	data, err = json.Marshal(ret)
+	require.NoError(err)
+	res = gjson.ParseBytes(data)
+	require.Equal(gjson.Null, res.Get("probationList").Type)
+	require.Empty(res.Get("candidates").Array())
+
+	core.EXPECT().DryRunNextEpoch(gomock.Any()).Return(nil, errors.New("not supported"))
+	_, err = web3svr.dryRunNextEpoch(context.Background())
+	require.Error(err)
+}
+
+func TestBalanceHistory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+	ethAddr := "0x" + hex.EncodeToString(identityset.Address(1).Bytes())
+
+	t.Run("getBalanceAt", func(t *testing.T) {
+		core.EXPECT().TipHeight().Return(uint64(10))
+		core.EXPECT().BalanceAt(identityset.Address(1), uint64(10)).Return(big.NewInt(100), nil)
+		in := gjson.Parse(fmt.Sprintf(`{"params":["%s", "latest"]}`, ethAddr))
+		ret, err := web3svr.getBalanceAt(&in)
+		require.NoError(err)
+		require.Equal("0x64", ret)
+
+		core.EXPECT().BalanceAt(identityset.Address(1), uint64(5)).Return(nil, errors.New("balance index is not enabled"))
+		in = gjson.Parse(fmt.Sprintf(`{"params":["%s", "0x5"]}`, ethAddr))
+		_, err = web3svr.getBalanceAt(&in)
+		require.Error(err)
+	})
+	t.Run("getTransferHistory", func(t *testing.T) {
+		in := gjson.Parse(fmt.Sprintf(`{"params":["%s", "0x1"]}`, ethAddr))
+		_, err := web3svr.getTransferHistory(&in)
+		require.Equal(errInvalidFormat, err)
+
+		core.EXPECT().TransferHistory(identityset.Address(1), uint64(1), uint64(2)).Return(uint64(3), []*blockindex.BalanceTransfer{
+			{
+				Height:     3,
+				ActionHash: hash.Hash256b([]byte("transfer")),
+				Type:       iotextypes.TransactionLogType_NATIVE_TRANSFER,
+				Sender:     identityset.Address(1).String(),
+				Recipient:  identityset.Address(2).String(),
+				Amount:     big.NewInt(10),
+			},
+			{
+				Height:     4,
+				ActionHash: hash.Hash256b([]byte("gas")),
+				Type:       iotextypes.TransactionLogType_GAS_FEE,
+				Sender:     identityset.Address(1).String(),
+				Amount:     big.NewInt(1),
+			},
+		}, nil)
+		in = gjson.Parse(fmt.Sprintf(`{"params":["%s", "0x1", "0x2"]}`, ethAddr))
+		ret, err := web3svr.getTransferHistory(&in)
+		require.NoError(err)
+		data, err := json.Marshal(ret)
+		require.NoError(err)
+		res := gjson.ParseBytes(data)
+		require.Equal("0x3", res.Get("total").String())
+		require.Len(res.Get("transfers").Array(), 2)
+		require.Equal("NATIVE_TRANSFER", res.Get("transfers.0.type").String())
+		require.Equal(ethAddr, strings.ToLower(res.Get("transfers.0.from").String()))
+		require.Equal("0xa", res.Get("transfers.0.value").String())
+		require.Equal("0x3", res.Get("transfers.0.blockNumber").String())
+		require.Equal("GAS_FEE", res.Get("transfers.1.type").String())
+		require.Equal(gjson.Null, res.Get("transfers.1.to").Type)
+	})
+}
+
+func TestTxPool(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	newTransfer := func(idx int, nonce uint64) *action.SealedEnvelope {
+		selp, err := action.SignedTransfer(identityset.Address(30).String(), identityset.PrivateKey(idx), nonce, big.NewInt(10), nil, 100000, big.NewInt(1))
+		require.NoError(err)
+		return selp
+	}
+	var (
+		sender1 = common.BytesToAddress(identityset.Address(28).Bytes()).Hex()
+		sender2 = common.BytesToAddress(identityset.Address(29).Bytes()).Hex()
+		to      = common.BytesToAddress(identityset.Address(30).Bytes()).Hex()
+	)
+	core.EXPECT().ActPoolContent().Return(map[string]*actpool.Content{
+		identityset.Address(28).String(): {
+			Pending: []*action.SealedEnvelope{newTransfer(28, 1), newTransfer(28, 2)},
+			Queued:  []*action.SealedEnvelope{newTransfer(28, 4)},
+		},
+		identityset.Address(29).String(): {
+			Queued: []*action.SealedEnvelope{newTransfer(29, 3)},
+		},
+	}, nil).Times(3)
+	core.EXPECT().EVMNetworkID().Return(uint32(4689)).AnyTimes()
+
+	t.Run("txpool_status", func(t *testing.T) {
+		ret, err := web3svr.txPoolStatus()
+		require.NoError(err)
+		require.Equal(&txPoolStatusResult{Pending: "0x2", Queued: "0x2"}, ret)
+	})
+	t.Run("txpool_content", func(t *testing.T) {
+		ret, err := web3svr.txPoolContent()
+		require.NoError(err)
+		data, err := json.Marshal(ret)
+		require.NoError(err)
+		res := gjson.ParseBytes(data)
+		require.Len(res.Get("pending").Map(), 1)
+		require.Len(res.Get("pending."+sender1).Map(), 2)
+		require.Equal("0x2", res.Get("pending."+sender1+".2.nonce").String())
+		require.Equal(strings.ToLower(sender1), strings.ToLower(res.Get("pending."+sender1+".2.from").String()))
+		require.Equal("0x4", res.Get("queued."+sender1+".4.nonce").String())
+		require.Equal("0x3", res.Get("queued."+sender2+".3.nonce").String())
+		require.Equal(gjson.Null, res.Get("pending."+sender1+".2.blockHash").Type)
+	})
+	t.Run("txpool_inspect", func(t *testing.T) {
+		ret, err := web3svr.txPoolInspect()
+		require.NoError(err)
+		data, err := json.Marshal(ret)
+		require.NoError(err)
+		res := gjson.ParseBytes(data)
+		require.Equal(to+": 10 wei + 100000 gas × 1 wei", res.Get("queued."+sender2+".3").String())
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		core.EXPECT().ActPoolContent().Return(nil, errors.New("unsupported"))
+		_, err := web3svr.txPoolStatus()
+		require.Error(err)
+	})
+}
+
+func TestSimulateV1(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	t.Run("InvalidParams", func(t *testing.T) {
+		for _, params := range []string{
+			`[]`,
+			`[{"blockStateCalls":[]}]`,
+			`[{"blockStateCalls":[{}],"validation":true}]`,
+			`[{"blockStateCalls":[{"stateOverrides":{"0x01":{}}}]}]`,
+			`[{"blockStateCalls":[{"blockOverrides":{"number":"xyz"}}]}]`,
+			`[{"blockStateCalls":[{}]},"pending"]`,
+		} {
+			in := gjson.Parse(`{"params":` + params + `}`)
+			_, err := web3svr.simulateV1(context.Background(), &in)
+			require.Error(err)
+		}
+	})
+
+	t.Run("Simulate", func(t *testing.T) {
+		var (
+			from     = "0x02ae2a956d21e8d481c3a69e146633470cf625ec"
+			contract = "0x1234567890123456789012345678901234567890"
+			logAddr  = identityset.Address(1)
+		)
+		in := gjson.Parse(`{"params":[{"blockStateCalls":[
+			{
+				"blockOverrides":{"number":"0x20","time":"0x64","baseFeePerGas":"0x3b9aca00"},
+				"stateOverrides":{"` + from + `":{"balance":"0xde0b6b3a7640000","nonce":"0x3"},
+					"` + contract + `":{"code":"0x6001","stateDiff":{"0x01":"0x02"}}},
+				"calls":[{"from":"` + from + `","to":"` + contract + `","data":"0x1234"}]
+			},
+			{
+				"calls":[{"from":"` + from + `","to":"` + contract + `","gas":"0x5208"}]
+			}
+		]},"0x10"]}`)
+		receipt := (&action.Receipt{
+			Status:      uint64(iotextypes.ReceiptStatus_Success),
+			GasConsumed: 30000,
+		}).AddLogs(&action.Log{
+			Address:     logAddr.String(),
+			Topics:      []hash.Hash256{hash.Hash256b([]byte("topic"))},
+			BlockHeight: 0x20,
+		})
+		reverted := &action.Receipt{
+			Status:      uint64(iotextypes.ReceiptStatus_ErrExecutionReverted),
+			GasConsumed: 21000,
+		}
+		reverted.SetExecutionRevertMsg("boom")
+		core.EXPECT().SimulateBlocks(gomock.Any(), uint64(0x10), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uint64, blocks []*apitypes.SimulateBlock) ([]*apitypes.SimulatedBlock, error) {
+				require.Len(blocks, 2)
+				ov := blocks[0].BlockOverrides
+				require.Equal(uint64(0x20), *ov.Number)
+				require.Equal(uint64(100), *ov.Time)
+				require.Nil(ov.GasLimit)
+				require.Equal(big.NewInt(1000000000), ov.BaseFee)
+				require.Len(blocks[0].StateOverrides, 2)
+				require.Equal(uint64(3), *blocks[0].StateOverrides[common.HexToAddress(from)].Nonce)
+				require.Equal([]byte{0x60, 0x01}, blocks[0].StateOverrides[common.HexToAddress(contract)].Code)
+				require.Len(blocks[0].Calls, 1)
+				require.Equal([]byte{0x12, 0x34}, blocks[0].Calls[0].Elp.Data())
+				require.Nil(blocks[1].BlockOverrides)
+				require.Equal(uint64(21000), blocks[1].Calls[0].Elp.Gas())
+				return []*apitypes.SimulatedBlock{
+					{
+						Height:    0x20,
+						Timestamp: time.Unix(100, 0),
+						GasLimit:  30000000,
+						GasUsed:   30000,
+						BaseFee:   big.NewInt(1000000000),
+						Producer:  identityset.Address(0),
+						Calls:     []*apitypes.SimulatedCall{{ReturnData: []byte{1}, Receipt: receipt}},
+					},
+					{
+						Height:    0x21,
+						Timestamp: time.Unix(105, 0),
+						GasLimit:  30000000,
+						GasUsed:   21000,
+						Producer:  identityset.Address(0),
+						Calls:     []*apitypes.SimulatedCall{{Receipt: reverted}},
+					},
+				}, nil
+			})
+		ret, err := web3svr.simulateV1(context.Background(), &in)
+		require.NoError(err)
+		data, err := json.Marshal(ret)
+		require.NoError(err)
+		res := gjson.ParseBytes(data)
+		require.Len(res.Array(), 2)
+		require.Equal("0x20", res.Get("0.number").String())
+		require.Equal("0x64", res.Get("0.timestamp").String())
+		require.Equal("0x3b9aca00", res.Get("0.baseFeePerGas").String())
+		require.Equal("0x7530", res.Get("0.calls.0.gasUsed").String())
+		require.Equal("0x1", res.Get("0.calls.0.status").String())
+		require.Equal("0x01", res.Get("0.calls.0.returnData").String())
+		require.Equal(1, len(res.Get("0.calls.0.logs").Array()))
+		require.False(res.Get("0.calls.0.error").Exists())
+		require.False(res.Get("1.baseFeePerGas").Exists())
+		require.Equal("0x0", res.Get("1.calls.0.status").String())
+		require.Equal(int64(3), res.Get("1.calls.0.error.code").Int())
+		require.Equal("execution reverted: boom", res.Get("1.calls.0.error.message").String())
+	})
+}
+
+func TestGetProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	account, err := state.NewAccount()
+	require.NoError(err)
+	require.NoError(account.AddBalance(big.NewInt(0x100)))
+	addr := identityset.Address(1)
+	key := hash.BytesToHash256([]byte{1})
+	proof := &AccountProof{
+		Address:     addr,
+		Account:     account,
+		Nonce:       2,
+		StateRoot:   []byte{0xaa},
+		Proof:       [][]byte{{1, 2}, {3}},
+		StorageRoot: []byte{0xbb},
+		StorageProofs: []*StorageProof{
+			{Key: key, Value: []byte{0x12}, Proof: [][]byte{{4}}},
+		},
+	}
+	core.EXPECT().StateProof(addr, []hash.Hash256{key}).Return(proof, nil)
+	in := gjson.Parse(`{"params":["` + addr.Hex() + `", ["0x01"], "latest"]}`)
+	ret, err := web3svr.getProof(&in)
+	require.NoError(err)
+	data, err := json.Marshal(ret)
+	require.NoError(err)
+	res := gjson.ParseBytes(data)
+	require.Equal("0x100", res.Get("balance").String())
+	require.Equal("0x2", res.Get("nonce").String())
+	require.Equal(`["0x0102","0x03"]`, res.Get("accountProof").Raw)
+	require.Equal("0xaa", res.Get("stateRoot").String())
+	require.Equal("0xbb", res.Get("storageHash").String())
+	require.Equal(byteToHex(key[:]), res.Get("storageProof.0.key").String())
+	require.Equal("0x12", res.Get("storageProof.0.value").String())
+	require.Equal(`["0x04"]`, res.Get("storageProof.0.proof").Raw)
+
+	// history height
+	coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+	core.EXPECT().WithHeight(uint64(16)).Return(coreWithHeight)
+	coreWithHeight.EXPECT().StateProof(addr, []hash.Hash256{}).Return(nil, ErrArchiveNotSupported)
+	in = gjson.Parse(`{"params":["` + addr.Hex() + `", [], "0x10"]}`)
+	_, err = web3svr.getProof(&in)
+	require.Equal(ErrArchiveNotSupported, err)
+
+	// invalid storage keys
+	in = gjson.Parse(`{"params":["` + addr.Hex() + `", "0x01"]}`)
+	_, err = web3svr.getProof(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+	in = gjson.Parse(`{"params":["` + addr.Hex() + `", ["0x` + strings.Repeat("01", 33) + `"]]}`)
+	_, err = web3svr.getProof(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+}
+
 func TestNewfilter(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	ret, err := web3svr.newFilter(&filterObject{
 		FromBlock: "1",
@@ -938,7 +1352,7 @@ func TestNewBlockFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().TipHeight().Return(uint64(123))
 
 	ret, err := web3svr.newBlockFilter()
@@ -951,7 +1365,7 @@ func TestUninstallFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	require.NoError(web3svr.cache.Set("123456789abc", []byte("test")))
 
@@ -974,7 +1388,7 @@ func TestGetFilterChanges(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 	core.EXPECT().TipHeight().Return(uint64(0)).Times(3)
 
 	t.Run("log filterType", func(t *testing.T) {
@@ -1059,7 +1473,7 @@ func TestGetFilterLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	logs := []*action.Log{
 		{
@@ -1115,7 +1529,7 @@ func TestSubscribe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
 	listener.EXPECT().AddResponder(gomock.Any()).Return("streamid_1", nil).Times(3)
@@ -1161,12 +1575,67 @@ func TestSubscribe(t *testing.T) {
 	})
 }
 
+func TestSubscribeActionsByAddress(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+	listener := NewChainListener(1)
+	core.EXPECT().ChainListener().Return(listener).AnyTimes()
+
+	addr := identityset.Address(1)
+	stopped := make(chan struct{})
+	core.EXPECT().StreamActionsByAddress(gomock.Any(), addr, uint64(3), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ address.Address, cursor uint64, handler func(*iotexapi.ActionInfo, uint64) error) error {
+			defer close(stopped)
+			if err := handler(&iotexapi.ActionInfo{ActHash: "01", BlkHash: "02", BlkHeight: 5}, cursor); err != nil {
+				return err
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	writer := mock_apitypes.NewMockWeb3ResponseWriter(ctrl)
+	written := make(chan interface{}, 1)
+	writer.EXPECT().Write(gomock.Any()).DoAndReturn(func(in interface{}) (int, error) {
+		written <- in
+		return 0, nil
+	})
+
+	sc, _ := StreamFromContext(WithStreamContext(context.Background()))
+	in := gjson.Parse(`{"params":["actionsByAddress",{"address":"` + common.BytesToAddress(addr.Bytes()).Hex() + `","cursor":"0x3"}]}`)
+	ret, err := web3svr.subscribe(sc, &in, writer)
+	require.NoError(err)
+	streamID := ret.(string)
+	require.Equal([]string{streamID}, sc.ListenerIDs())
+	res := (<-written).(*streamResponse)
+	require.Equal(streamID, res.id)
+	data, err := json.Marshal(res.result)
+	require.NoError(err)
+	require.JSONEq(`{"cursor":"0x3","transactionHash":"0x01","blockHash":"0x02","blockNumber":"0x5","timestamp":"0x0"}`, string(data))
+
+	// the stream stops on unsubscribe
+	_, err = listener.RemoveResponder(streamID)
+	require.NoError(err)
+	<-stopped
+
+	for _, params := range []string{
+		`{"params":["actionsByAddress"]}`,
+		`{"params":["actionsByAddress",{"address":"invalid"}]}`,
+		`{"params":["actionsByAddress",{"address":"0x0000000000000000000000000000000000000001","cursor":"invalid"}]}`,
+	} {
+		in := gjson.Parse(params)
+		_, err := web3svr.subscribe(sc, &in, writer)
+		require.Error(err)
+	}
+}
+
 func TestUnsubscribe(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
 	listener.EXPECT().RemoveResponder(gomock.Any()).Return(true, nil)
@@ -1206,7 +1675,7 @@ func TestDebugTraceTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1245,7 +1714,7 @@ func TestDebugTraceCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1269,6 +1738,23 @@ func TestDebugTraceCall(t *testing.T) {
 	require.Equal(uint64(100000), rlt.Gas)
 	require.Empty(rlt.Revert)
 	require.Equal(0, len(rlt.StructLogs))
+
+	t.Run("TraceAtBlock", func(t *testing.T) {
+		core := NewMockCoreService(ctrl)
+		web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+		core.EXPECT().TraceCall(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ address.Address, blkNumOrHash any, _ string, _ uint64, _ *big.Int, _ uint64, _ []byte, cfg *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
+				require.Equal(uint64(0x10), blkNumOrHash)
+				require.Equal("callTracer", *cfg.Tracer)
+				require.JSONEq(`{"onlyTopCall":true}`, string(cfg.TracerConfig))
+				return []byte{0x01}, receipt, structLogger, nil
+			}).Times(2)
+		for _, blk := range []string{`"0x10"`, `{"blockNumber":"0x10"}`} {
+			in := gjson.Parse(`{"params":[{"to":"0x6b175474e89094c44da98b954eedeac495271d0f"},` + blk + `,{"tracer":"callTracer","tracerConfig":{"onlyTopCall":true}}]}`)
+			_, err := web3svr.traceCall(ctx, &in)
+			require.NoError(err)
+		}
+	})
 }
 
 func TestResponseIDMatchTypeWithRequest(t *testing.T) {
@@ -1278,7 +1764,7 @@ func TestResponseIDMatchTypeWithRequest(t *testing.T) {
 	core := NewMockCoreService(ctrl)
 	core.EXPECT().TipHeight().Return(uint64(1)).AnyTimes()
 	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
-	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit))
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, _defaultBatchRequestConcurrency))
 	getServerResp := func(svr *hTTPHandler, req *http.Request) *httptest.ResponseRecorder {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
@@ -1304,3 +1790,290 @@ func TestResponseIDMatchTypeWithRequest(t *testing.T) {
 		require.Contains(string(bodyBytes), tt.sub)
 	}
 }
+
+func TestGetRewardDistribution(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	in := gjson.Parse(`{"params":["robotbp00001", "0x1", "0xa", "0x3e8"]}`)
+	_, err := web3svr.getRewardDistribution(&in)
+	require.Equal(errInvalidFormat, err)
+
+	data := []byte(`{"candidate":"robotbp00001","startEpoch":1,"endEpoch":10}`)
+	core.EXPECT().ReadState("staking", "", []byte(staking.RewardDistributionMethod), [][]byte{
+		[]byte("robotbp00001"), []byte("1"), []byte("10"), []byte("1000"), []byte("1000000000000000000"),
+	}).Return(&iotexapi.ReadStateResponse{Data: data}, nil)
+	in = gjson.Parse(`{"params":["robotbp00001", "0x1", "0xa", "0x3e8", "0xde0b6b3a7640000"]}`)
+	ret, err := web3svr.getRewardDistribution(&in)
+	require.NoError(err)
+	require.Equal(json.RawMessage(data), ret)
+}
+
+func TestGetFeeStats(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	data := []byte(`{"height":10,"blockBaseFee":"100","blockPriorityFee":"1","totalBaseFee":"1000","totalPriorityFee":"10"}`)
+	core.EXPECT().ReadState("rewarding", "", []byte(rewarding.FeeStatsMethod), nil).Return(&iotexapi.ReadStateResponse{Data: data}, nil)
+	ret, err := web3svr.getFeeStats()
+	require.NoError(err)
+	require.Equal(json.RawMessage(data), ret)
+}
+
+func TestGetFinalityProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	ts := time.Unix(1700000000, 0)
+	newBlock := func(height uint64, acts ...*action.SealedEnvelope) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(ts).
+			AddActions(acts...).
+			SignAndBuild(identityset.PrivateKey(1))
+		require.NoError(err)
+		blkHash := blk.HashBlock()
+		var ens []*endorsement.Endorsement
+		for i := 1; i <= 3; i++ {
+			en, err := endorsement.Endorse(identityset.PrivateKey(i), rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT), ts)
+			require.NoError(err)
+			ens = append(ens, en)
+		}
+		require.NoError(blk.Finalize(ens, ts))
+		return &blk
+	}
+	candidates := state.CandidateList{
+		{Address: identityset.Address(1).String(), Votes: big.NewInt(1)},
+		{Address: identityset.Address(2).String(), Votes: big.NewInt(1)},
+	}
+	elp := (&action.EnvelopeBuilder{}).SetNonce(0).SetGasLimit(0).SetAction(action.NewPutPollResult(721, candidates)).Build()
+	selp, err := action.Sign(elp, identityset.PrivateKey(1))
+	require.NoError(err)
+	tsf, err := action.SignedTransfer(identityset.Address(2).String(), identityset.PrivateKey(1), 1, big.NewInt(1), nil, 10000, big.NewInt(1))
+	require.NoError(err)
+	prevBlk := newBlock(361, tsf, selp)
+	tsfHash, err := tsf.Hash()
+	require.NoError(err)
+	selpHash, err := selp.Hash()
+	require.NoError(err)
+	siblings, err := crypto.MerkleProof([]hash.Hash256{tsfHash, selpHash}, 1)
+	require.NoError(err)
+	blk := newBlock(725)
+
+	core.EXPECT().TipHeight().Return(uint64(725))
+	core.EXPECT().FinalityProof(uint64(725)).Return(&FinalityProof{
+		Block:     blk,
+		Epoch:     3,
+		Delegates: candidates,
+		CandidatesProof: &CandidatesProof{
+			Block:    prevBlk,
+			Action:   selp,
+			Index:    1,
+			Siblings: siblings,
+		},
+	}, nil)
+	in := gjson.Parse(`{"params":["latest"]}`)
+	ret, err := web3svr.getFinalityProof(&in)
+	require.NoError(err)
+	raw, err := json.Marshal(ret)
+	require.NoError(err)
+	res := gjson.ParseBytes(raw)
+	blkHash := blk.HashBlock()
+	require.Equal("0x2d5", res.Get("height").String())
+	require.Equal("0x3", res.Get("epoch").String())
+	require.Equal(byteToHex(blkHash[:]), res.Get("hash").String())
+	header, err := hexToBytes(res.Get("header").String())
+	require.NoError(err)
+	require.Equal(blkHash, hash.Hash256b(header))
+	require.Len(res.Get("endorsements").Array(), 3)
+	require.Equal(common.BytesToAddress(identityset.Address(2).Bytes()).Hex(), res.Get("delegates.1").String())
+	require.Equal("0x169", res.Get("candidatesProof.height").String())
+	require.Equal("0x1", res.Get("candidatesProof.index").String())
+	require.Len(res.Get("candidatesProof.siblings").Array(), 1)
+	require.Len(res.Get("candidatesProof.candidates").Array(), 2)
+
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.getFinalityProof(&in)
+	require.Equal(errInvalidFormat, err)
+}
+
+func TestPreimage(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	key := []byte("key1")
+	h := hash.Hash160b(key)
+	core.EXPECT().Preimage(h).Return(key, nil)
+	in := gjson.Parse(`{"params":["` + byteToHex(h[:]) + `"]}`)
+	ret, err := web3svr.preimage(&in)
+	require.NoError(err)
+	require.Equal(byteToHex(key), ret)
+
+	in = gjson.Parse(`{"params":["0x0102"]}`)
+	_, err = web3svr.preimage(&in)
+	require.ErrorIs(err, errInvalidFormat)
+}
+
+func TestGetNonceGaps(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	in := gjson.Parse(`{"params":[]}`)
+	_, err := web3svr.getNonceGaps(&in)
+	require.ErrorIs(err, errInvalidFormat)
+
+	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(3), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(100))
+	require.NoError(err)
+	h, err := tsf.Hash()
+	require.NoError(err)
+	sender := identityset.Address(27)
+	core.EXPECT().NonceGaps(sender).Return(&actpool.NonceGaps{
+		ConfirmedNonce: 1,
+		PendingNonce:   1,
+		Actions: []*actpool.ReplaceableAction{
+			{Action: tsf, MinGasFeeCap: big.NewInt(110), MinGasTipCap: big.NewInt(110)},
+		},
+		Gaps: []actpool.NonceRange{{Start: 1, End: 2}},
+	}, nil)
+	in = gjson.Parse(`{"params":["` + common.BytesToAddress(sender.Bytes()).Hex() + `"]}`)
+	ret, err := web3svr.getNonceGaps(&in)
+	require.NoError(err)
+	raw, err := json.Marshal(ret)
+	require.NoError(err)
+	require.JSONEq(`{
+		"confirmedNonce": "0x1",
+		"pendingNonce": "0x1",
+		"gaps": [{"start": "0x1", "end": "0x2"}],
+		"transactions": [{
+			"hash": "0x`+hex.EncodeToString(h[:])+`",
+			"nonce": "0x3",
+			"maxFeePerGas": "0x64",
+			"maxPriorityFeePerGas": "0x64",
+			"minReplacementMaxFeePerGas": "0x6e",
+			"minReplacementMaxPriorityFeePerGas": "0x6e"
+		}]
+	}`, string(raw))
+}
+
+func TestSendMetaTransaction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	in := gjson.Parse(`{"params":[]}`)
+	_, err := web3svr.sendMetaTransaction(context.Background(), &in)
+	require.ErrorIs(err, errInvalidFormat)
+
+	var (
+		from     = common.BytesToAddress(identityset.Address(27).Bytes())
+		to       = common.BytesToAddress(identityset.Address(28).Bytes())
+		feeToken = common.BytesToAddress(identityset.Address(29).Bytes())
+		call     = `"from": "` + from.Hex() + `",
+		"to": "` + to.Hex() + `",
+		"gas": "0x186a0",
+		"nonce": "0x3",
+		"deadline": "0x6553f100",
+		"data": "0x1234",
+		"signature": "0x5678"`
+	)
+	// the relayer fee is required
+	in = gjson.Parse(`{"params":[{` + call + `}]}`)
+	_, err = web3svr.sendMetaTransaction(context.Background(), &in)
+	require.ErrorIs(err, errInvalidFormat)
+
+	core.EXPECT().RelayMetaTransaction(gomock.Any(), &MetaTransaction{
+		From:      from,
+		To:        to,
+		Value:     big.NewInt(0),
+		Gas:       100000,
+		Nonce:     big.NewInt(3),
+		Deadline:  1700000000,
+		Data:      []byte{0x12, 0x34},
+		Signature: []byte{0x56, 0x78},
+		Fee: &MetaTransaction{
+			From:      from,
+			To:        feeToken,
+			Value:     big.NewInt(0),
+			Gas:       50000,
+			Nonce:     big.NewInt(2),
+			Deadline:  1700000000,
+			Data:      []byte{0x9a},
+			Signature: []byte{0xbc},
+		},
+	}).Return("abcd", nil)
+	in = gjson.Parse(`{"params":[{` + call + `,
+		"fee": {
+			"from": "` + from.Hex() + `",
+			"to": "` + feeToken.Hex() + `",
+			"gas": "0xc350",
+			"nonce": "0x2",
+			"deadline": "0x6553f100",
+			"data": "0x9a",
+			"signature": "0xbc"
+		}
+	}]}`)
+	ret, err := web3svr.sendMetaTransaction(context.Background(), &in)
+	require.NoError(err)
+	require.Equal("0xabcd", ret)
+
+	in = gjson.Parse(`{"params":[{"from": "` + from.Hex() + `", "to": "invalid"}]}`)
+	_, err = web3svr.sendMetaTransaction(context.Background(), &in)
+	require.ErrorIs(err, errUnkownType)
+}
+
+func TestSuggestFees(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
+
+	level := func(price, tip, feeCap int64) *gasstation.FeeLevel {
+		return &gasstation.FeeLevel{GasPrice: big.NewInt(price), GasTipCap: big.NewInt(tip), GasFeeCap: big.NewInt(feeCap)}
+	}
+	core.EXPECT().SuggestFees(gomock.Any()).Return(&gasstation.FeeSuggestion{
+		BaseFee:  big.NewInt(16),
+		Slow:     level(17, 1, 33),
+		Standard: level(18, 2, 34),
+		Fast:     level(20, 4, 36),
+	}, nil)
+	ret, err := web3svr.suggestFees(context.Background())
+	require.NoError(err)
+	raw, err := json.Marshal(ret)
+	require.NoError(err)
+	require.JSONEq(`{
+		"baseFee": "0x10",
+		"slow": {"gasPrice": "0x11", "maxPriorityFeePerGas": "0x1", "maxFeePerGas": "0x21"},
+		"standard": {"gasPrice": "0x12", "maxPriorityFeePerGas": "0x2", "maxFeePerGas": "0x22"},
+		"fast": {"gasPrice": "0x14", "maxPriorityFeePerGas": "0x4", "maxFeePerGas": "0x24"}
+	}`, string(raw))
+
+	// no base fee before EIP-1559
+	core.EXPECT().SuggestFees(gomock.Any()).Return(&gasstation.FeeSuggestion{
+		Slow:     level(1, 1, 1),
+		Standard: level(1, 1, 1),
+		Fast:     level(1, 1, 1),
+	}, nil)
+	ret, err = web3svr.suggestFees(context.Background())
+	require.NoError(err)
+	raw, err = json.Marshal(ret)
+	require.NoError(err)
+	require.NotContains(string(raw), "baseFee")
+}
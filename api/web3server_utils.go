@@ -26,6 +26,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
 	logfilter "github.com/iotexproject/iotex-core/v2/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
@@ -157,6 +159,27 @@ func (svr *web3Handler) parseBlockNumber(str string) (uint64, error) {
 	}
 }
 
+// parseAddressRange parses the params of an address followed by the start and the count of a paginated query
+func parseAddressRange(in *gjson.Result) (address.Address, uint64, uint64, error) {
+	addr, startStr, countStr := in.Get("params.0"), in.Get("params.1"), in.Get("params.2")
+	if !addr.Exists() || !startStr.Exists() || !countStr.Exists() {
+		return nil, 0, 0, errInvalidFormat
+	}
+	ioAddr, err := ethAddrToIoAddr(addr.String())
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	start, err := hexStringToNumber(startStr.String())
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	count, err := hexStringToNumber(countStr.String())
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return ioAddr, start, count, nil
+}
+
 func (svr *web3Handler) parseBlockRange(fromStr string, toStr string) (from uint64, to uint64, err error) {
 	from, err = svr.parseBlockNumber(fromStr)
 	if err != nil {
@@ -256,6 +279,24 @@ func hexToBytes(str string) ([]byte, error) {
 	return hex.DecodeString(str)
 }
 
+// parseActionsByAddressRequest parses the address and the cursor to stream the actions from, the cursor is 0 if omitted
+func parseActionsByAddressRequest(in gjson.Result) (address.Address, uint64, error) {
+	if !in.Exists() {
+		return nil, 0, errInvalidFormat
+	}
+	addr, err := ethAddrToIoAddr(in.Get("address").String())
+	if err != nil {
+		return nil, 0, err
+	}
+	var cursor uint64
+	if c := in.Get("cursor"); c.Exists() {
+		if cursor, err = hexStringToNumber(c.String()); err != nil {
+			return nil, 0, errors.Wrapf(errInvalidFormat, "invalid cursor %s", c.String())
+		}
+	}
+	return addr, cursor, nil
+}
+
 func parseLogRequest(in gjson.Result) (*filterObject, error) {
 	if !in.Exists() {
 		return nil, errInvalidFormat
@@ -294,9 +335,51 @@ type callMsg struct {
 	Data        []byte           // input data, usually an ABI-encoded contract method invocation
 	AccessList  types.AccessList // EIP-2930 access list.
 	BlockNumber rpc.BlockNumber
+	// StateOverrides is the state override set of eth_call and eth_estimateGas
+	StateOverrides evm.StateOverrides
 }
 
 func parseCallObject(in *gjson.Result) (*callMsg, error) {
+	call, err := parseCallMsg(in.Get("params.0"))
+	if err != nil {
+		return nil, err
+	}
+	if bnParam := in.Get("params.1"); bnParam.Exists() {
+		if err = call.BlockNumber.UnmarshalJSON([]byte(bnParam.String())); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal height %s", bnParam.String())
+		}
+		if call.BlockNumber == rpc.PendingBlockNumber {
+			return nil, errors.Wrap(errNotImplemented, "pending block number is not supported")
+		}
+	}
+	return call, nil
+}
+
+// parseCallObjectWithOverrides parses the call object of eth_call and eth_estimateGas, whose third param is the
+// state override set
+func parseCallObjectWithOverrides(in *gjson.Result) (*callMsg, error) {
+	call, err := parseCallObject(in)
+	if err != nil {
+		return nil, err
+	}
+	if ov := in.Get("params.2"); ov.Exists() {
+		if call.StateOverrides, err = parseStateOverrides(ov); err != nil {
+			return nil, err
+		}
+	}
+	return call, nil
+}
+
+// simulateOptions returns the options to simulate the call with
+func (call *callMsg) simulateOptions() []protocol.SimulateOption {
+	if len(call.StateOverrides) == 0 {
+		return nil
+	}
+	return []protocol.SimulateOption{call.StateOverrides.SimulateOption()}
+}
+
+// parseCallMsg parses the call object of eth_call, eth_estimateGas and eth_simulateV1
+func parseCallMsg(in gjson.Result) (*callMsg, error) {
 	var (
 		from      address.Address
 		to        string
@@ -307,10 +390,9 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		value     *big.Int = big.NewInt(0)
 		data      []byte
 		acl       types.AccessList
-		bn        = rpc.LatestBlockNumber
 		err       error
 	)
-	fromStr := in.Get("params.0.from").String()
+	fromStr := in.Get("from").String()
 	if fromStr == "" {
 		fromStr = "0x0000000000000000000000000000000000000000"
 	}
@@ -318,7 +400,7 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		return nil, err
 	}
 
-	toStr := in.Get("params.0.to").String()
+	toStr := in.Get("to").String()
 	if toStr != "" {
 		ioAddr, err := ethAddrToIoAddr(toStr)
 		if err != nil {
@@ -327,14 +409,14 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		to = ioAddr.String()
 	}
 
-	gasStr := in.Get("params.0.gas").String()
+	gasStr := in.Get("gas").String()
 	if gasStr != "" {
 		if gasLimit, err = hexStringToNumber(gasStr); err != nil {
 			return nil, err
 		}
 	}
 
-	gasPriceStr := in.Get("params.0.gasPrice").String()
+	gasPriceStr := in.Get("gasPrice").String()
 	if gasPriceStr != "" {
 		var ok bool
 		if gasPrice, ok = new(big.Int).SetString(util.Remove0xPrefix(gasPriceStr), 16); !ok {
@@ -342,21 +424,21 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		}
 	}
 
-	if gasTipCapStr := in.Get("params.0.maxPriorityFeePerGas").String(); gasTipCapStr != "" {
+	if gasTipCapStr := in.Get("maxPriorityFeePerGas").String(); gasTipCapStr != "" {
 		var ok bool
 		if gasTipCap, ok = new(big.Int).SetString(util.Remove0xPrefix(gasTipCapStr), 16); !ok {
 			return nil, errors.Wrapf(errUnkownType, "gasTipCap: %s", gasTipCapStr)
 		}
 	}
 
-	if gasFeeCapStr := in.Get("params.0.maxFeePerGas").String(); gasFeeCapStr != "" {
+	if gasFeeCapStr := in.Get("maxFeePerGas").String(); gasFeeCapStr != "" {
 		var ok bool
 		if gasFeeCap, ok = new(big.Int).SetString(util.Remove0xPrefix(gasFeeCapStr), 16); !ok {
 			return nil, errors.Wrapf(errUnkownType, "gasFeeCap: %s", gasFeeCapStr)
 		}
 	}
 
-	valStr := in.Get("params.0.value").String()
+	valStr := in.Get("value").String()
 	if valStr != "" {
 		var ok bool
 		if value, ok = new(big.Int).SetString(util.Remove0xPrefix(valStr), 16); !ok {
@@ -364,27 +446,19 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		}
 	}
 
-	if input := in.Get("params.0.input"); input.Exists() {
+	if input := in.Get("input"); input.Exists() {
 		data = common.FromHex(input.String())
 	} else {
-		data = common.FromHex(in.Get("params.0.data").String())
+		data = common.FromHex(in.Get("data").String())
 	}
 
-	if accessList := in.Get("params.0.accessList"); accessList.Exists() {
+	if accessList := in.Get("accessList"); accessList.Exists() {
 		acl = types.AccessList{}
 		log.L().Info("raw acl", zap.String("accessList", accessList.Raw))
 		if err := json.Unmarshal([]byte(accessList.Raw), &acl); err != nil {
 			return nil, errors.Wrapf(err, "failed to unmarshal access list %s", accessList.Raw)
 		}
 	}
-	if bnParam := in.Get("params.1"); bnParam.Exists() {
-		if err = bn.UnmarshalJSON([]byte(bnParam.String())); err != nil {
-			return nil, errors.Wrapf(err, "failed to unmarshal height %s", bnParam.String())
-		}
-		if bn == rpc.PendingBlockNumber {
-			return nil, errors.Wrap(errNotImplemented, "pending block number is not supported")
-		}
-	}
 	return &callMsg{
 		From:        from,
 		To:          to,
@@ -395,10 +469,131 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		Value:       value,
 		Data:        data,
 		AccessList:  acl,
-		BlockNumber: bn,
+		BlockNumber: rpc.LatestBlockNumber,
 	}, nil
 }
 
+// parseSimulateBlock parses a block state call of eth_simulateV1
+func parseSimulateBlock(in gjson.Result) (*apitypes.SimulateBlock, error) {
+	var (
+		blk = &apitypes.SimulateBlock{}
+		err error
+	)
+	if ov := in.Get("blockOverrides"); ov.Exists() {
+		if blk.BlockOverrides, err = parseBlockOverrides(ov); err != nil {
+			return nil, err
+		}
+	}
+	if ov := in.Get("stateOverrides"); ov.Exists() {
+		if blk.StateOverrides, err = parseStateOverrides(ov); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range in.Get("calls").Array() {
+		callMsg, err := parseCallMsg(c)
+		if err != nil {
+			return nil, err
+		}
+		blk.Calls = append(blk.Calls, &apitypes.SimulateCall{
+			From: callMsg.From,
+			Elp: (&action.EnvelopeBuilder{}).SetAction(action.NewExecution(callMsg.To, callMsg.Value, callMsg.Data)).
+				SetGasLimit(callMsg.Gas).Build(),
+		})
+	}
+	return blk, nil
+}
+
+func parseBlockOverrides(in gjson.Result) (*apitypes.BlockOverrides, error) {
+	ov := &apitypes.BlockOverrides{}
+	for field, dst := range map[string]**uint64{
+		"number":   &ov.Number,
+		"time":     &ov.Time,
+		"gasLimit": &ov.GasLimit,
+	} {
+		if v := in.Get(field); v.Exists() {
+			n, err := hexStringToNumber(v.String())
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %s %s", field, v.String())
+			}
+			*dst = &n
+		}
+	}
+	if v := in.Get("feeRecipient"); v.Exists() {
+		addr, err := ethAddrToIoAddr(v.String())
+		if err != nil {
+			return nil, err
+		}
+		ov.FeeRecipient = addr
+	}
+	if v := in.Get("baseFeePerGas"); v.Exists() {
+		baseFee, ok := new(big.Int).SetString(util.Remove0xPrefix(v.String()), 16)
+		if !ok {
+			return nil, errors.Wrapf(errUnkownType, "baseFeePerGas: %s", v.String())
+		}
+		ov.BaseFee = baseFee
+	}
+	return ov, nil
+}
+
+func parseStateOverrides(in gjson.Result) (evm.StateOverrides, error) {
+	var (
+		overrides = make(evm.StateOverrides)
+		err       error
+	)
+	in.ForEach(func(key, value gjson.Result) bool {
+		if !common.IsHexAddress(key.String()) {
+			err = errors.Wrapf(errUnkownType, "ethAddr: %s", key.String())
+			return false
+		}
+		if value.Get("movePrecompileToAddress").Exists() {
+			err = errors.Wrap(errNotImplemented, "movePrecompileToAddress is not supported")
+			return false
+		}
+		ov := &evm.AccountOverride{}
+		if v := value.Get("balance"); v.Exists() {
+			balance, ok := new(big.Int).SetString(util.Remove0xPrefix(v.String()), 16)
+			if !ok {
+				err = errors.Wrapf(errUnkownType, "balance: %s", v.String())
+				return false
+			}
+			ov.Balance = balance
+		}
+		if v := value.Get("nonce"); v.Exists() {
+			var nonce uint64
+			if nonce, err = hexStringToNumber(v.String()); err != nil {
+				return false
+			}
+			ov.Nonce = &nonce
+		}
+		if v := value.Get("code"); v.Exists() {
+			if ov.Code, err = hexToBytes(v.String()); err != nil {
+				return false
+			}
+		}
+		if v := value.Get("state"); v.Exists() {
+			ov.State = parseStorageOverride(v)
+		}
+		if v := value.Get("stateDiff"); v.Exists() {
+			ov.StateDiff = parseStorageOverride(v)
+		}
+		overrides[common.HexToAddress(key.String())] = ov
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+func parseStorageOverride(in gjson.Result) map[common.Hash]common.Hash {
+	storage := make(map[common.Hash]common.Hash)
+	in.ForEach(func(key, value gjson.Result) bool {
+		storage[common.HexToHash(key.String())] = common.HexToHash(value.String())
+		return true
+	})
+	return storage
+}
+
 func parseBlockNumber(in *gjson.Result) (rpc.BlockNumber, error) {
 	if !in.Exists() {
 		return rpc.LatestBlockNumber, nil
@@ -145,6 +145,37 @@ func TestParseCallObject(t *testing.T) {
 		require.EqualError(err, "value: unknown: wrong type of params")
 	})
 
+	t.Run("parse state overrides", func(t *testing.T) {
+		input := `{"params":[{
+				"from":     "",
+				"to":       "0x7c13866F9253DEf79e20034eDD011e1d69E67fe5",
+				"input":     "0x6d4ce63c"
+			   }, "latest", {
+				"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5": {
+					"balance": "0x64",
+					"nonce": "0x2",
+					"code": "0x6001",
+					"stateDiff": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"}
+				}
+			   }]}`
+		in := gjson.Parse(input)
+		callMsg, err := parseCallObject(&in)
+		require.NoError(err)
+		require.Empty(callMsg.StateOverrides)
+		callMsg, err = parseCallObjectWithOverrides(&in)
+		require.NoError(err)
+		require.Len(callMsg.StateOverrides, 1)
+		ov := callMsg.StateOverrides[common.HexToAddress("0x7c13866F9253DEf79e20034eDD011e1d69E67fe5")]
+		require.Equal(big.NewInt(100), ov.Balance)
+		require.Equal(uint64(2), *ov.Nonce)
+		require.Equal([]byte{0x60, 0x01}, ov.Code)
+		require.Equal(common.BigToHash(big.NewInt(2)), ov.StateDiff[common.BigToHash(big.NewInt(1))])
+		require.Len(callMsg.simulateOptions(), 1)
+
+		in = gjson.Parse(`{"params":[{"to": "0x7c13866F9253DEf79e20034eDD011e1d69E67fe5"}, "latest", {"0x1": {}}]}`)
+		_, err = parseCallObjectWithOverrides(&in)
+		require.ErrorIs(err, errUnkownType)
+	})
 }
 
 func TestParseBlockNumber(t *testing.T) {
@@ -152,7 +183,7 @@ func TestParseBlockNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, _defaultBatchRequestConcurrency}
 
 	t.Run("earliest block number", func(t *testing.T) {
 		num, _ := web3svr.parseBlockNumber("earliest")
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+// A compact block is a block protobuf in which the actions are replaced by their hashes, the receivers reconstruct
+// the full block with the actions in their actpools. The hash of an action is put into the signature of an action
+// protobuf without core, which a valid action never has. System actions are kept, since they are created by the
+// block producer and never broadcast.
+
+// ConvertToCompactBlockPb converts the block to the compact protobuf
+func (b *Block) ConvertToCompactBlockPb() (*iotextypes.Block, error) {
+	actions := make([]*iotextypes.Action, 0, len(b.Actions))
+	for _, act := range b.Actions {
+		if action.IsSystemAction(act) {
+			actions = append(actions, act.Proto())
+			continue
+		}
+		h, err := act.Hash()
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, &iotextypes.Action{Signature: h[:]})
+	}
+	return &iotextypes.Block{
+		Header: b.Header.Proto(),
+		Body:   &iotextypes.BlockBody{Actions: actions},
+		Footer: b.Footer.Proto(),
+	}, nil
+}
+
+// IsCompactBlockPb returns true if any action of the block protobuf is replaced by its hash
+func IsCompactBlockPb(pb *iotextypes.Block) bool {
+	for _, act := range pb.GetBody().GetActions() {
+		if isActionHashPb(act) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompactBlockActionHashes returns the hashes of the actions to be filled into the compact block protobuf
+func CompactBlockActionHashes(pb *iotextypes.Block) []hash.Hash256 {
+	var hashes []hash.Hash256
+	for _, act := range pb.GetBody().GetActions() {
+		if isActionHashPb(act) {
+			hashes = append(hashes, hash.BytesToHash256(act.GetSignature()))
+		}
+	}
+	return hashes
+}
+
+// FillCompactBlockPb returns the full block protobuf of the compact one with the given actions
+func FillCompactBlockPb(pb *iotextypes.Block, acts map[hash.Hash256]*action.SealedEnvelope) (*iotextypes.Block, error) {
+	actions := make([]*iotextypes.Action, 0, len(pb.GetBody().GetActions()))
+	for _, actPb := range pb.GetBody().GetActions() {
+		if !isActionHashPb(actPb) {
+			actions = append(actions, actPb)
+			continue
+		}
+		h := hash.BytesToHash256(actPb.GetSignature())
+		act, ok := acts[h]
+		if !ok {
+			return nil, errors.Wrapf(action.ErrNotFound, "action %x of compact block is missing", h)
+		}
+		actions = append(actions, act.Proto())
+	}
+	return &iotextypes.Block{
+		Header: pb.GetHeader(),
+		Body:   &iotextypes.BlockBody{Actions: actions},
+		Footer: pb.GetFooter(),
+	}, nil
+}
+
+func isActionHashPb(pb *iotextypes.Action) bool {
+	return pb.GetCore() == nil && pb.GetSenderPubKey() == nil && len(pb.GetSignature()) == len(hash.ZeroHash256)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestCompactBlockPb(t *testing.T) {
+	require := require.New(t)
+	blk := makeBlock(t, 3)
+	grant, err := action.Sign(
+		(&action.EnvelopeBuilder{}).SetNonce(0).SetGasPrice(big.NewInt(0)).
+			SetAction(action.NewGrantReward(action.BlockReward, 1)).Build(),
+		identityset.PrivateKey(0),
+	)
+	require.NoError(err)
+	blk.Actions = append(blk.Actions, grant)
+	require.False(IsCompactBlockPb(blk.ConvertToBlockPb()))
+
+	pb, err := blk.ConvertToCompactBlockPb()
+	require.NoError(err)
+	require.True(IsCompactBlockPb(pb))
+	full, err := proto.Marshal(blk.ConvertToBlockPb())
+	require.NoError(err)
+	compact, err := proto.Marshal(pb)
+	require.NoError(err)
+	require.Less(len(compact), len(full))
+
+	// the system action is kept
+	hashes := CompactBlockActionHashes(pb)
+	require.Len(hashes, 3)
+	acts := make(map[hash.Hash256]*action.SealedEnvelope)
+	for i, h := range hashes {
+		act := blk.Actions[i]
+		actHash, err := act.Hash()
+		require.NoError(err)
+		require.Equal(actHash, h)
+		acts[h] = act
+	}
+	filled, err := FillCompactBlockPb(pb, acts)
+	require.NoError(err)
+	require.False(IsCompactBlockPb(filled))
+	require.True(proto.Equal(blk.ConvertToBlockPb(), filled))
+
+	delete(acts, hashes[1])
+	_, err = FillCompactBlockPb(pb, acts)
+	require.ErrorIs(err, action.ErrNotFound)
+}
@@ -7,6 +7,7 @@ package blockchain
 
 import (
 	"context"
+	"encoding/hex"
 	"math/big"
 	"strconv"
 	"sync"
@@ -26,6 +27,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
 	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/pkg/audit"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/prometheustimer"
@@ -165,7 +167,8 @@ func ClockOption(clk clock.Clock) Option {
 
 type (
 	BlockValidationCfg struct {
-		skipSidecarValidation bool
+		skipSidecarValidation   bool
+		skipSignatureValidation bool
 	}
 
 	BlockValidationOption func(*BlockValidationCfg)
@@ -177,6 +180,13 @@ func SkipSidecarValidationOption() BlockValidationOption {
 	}
 }
 
+// SkipSignatureValidationOption skips verifying the signatures of the actions in the block
+func SkipSignatureValidationOption() BlockValidationOption {
+	return func(opts *BlockValidationCfg) {
+		opts.skipSignatureValidation = true
+	}
+}
+
 // NewBlockchain creates a new blockchain and DB instance
 func NewBlockchain(cfg Config, g genesis.Genesis, dao blockdao.BlockDAO, bbf BlockBuilderFactory, opts ...Option) Blockchain {
 	// create the Blockchain
@@ -342,13 +352,14 @@ func (bc *blockchain) ValidateBlock(blk *block.Block, opts ...BlockValidationOpt
 	}
 	ctx = protocol.WithBlockCtx(ctx,
 		protocol.BlockCtx{
-			BlockHeight:           blk.Height(),
-			BlockTimeStamp:        blk.Timestamp(),
-			GasLimit:              bc.genesis.BlockGasLimitByHeight(blk.Height()),
-			Producer:              producerAddr,
-			BaseFee:               blk.BaseFee(),
-			ExcessBlobGas:         blk.ExcessBlobGas(),
-			SkipSidecarValidation: cfg.skipSidecarValidation,
+			BlockHeight:             blk.Height(),
+			BlockTimeStamp:          blk.Timestamp(),
+			GasLimit:                bc.genesis.BlockGasLimitByHeight(blk.Height()),
+			Producer:                producerAddr,
+			BaseFee:                 blk.BaseFee(),
+			ExcessBlobGas:           blk.ExcessBlobGas(),
+			SkipSidecarValidation:   cfg.skipSidecarValidation,
+			SkipSignatureValidation: cfg.skipSignatureValidation,
 		},
 	)
 	ctx = protocol.WithFeatureCtx(ctx)
@@ -439,6 +450,13 @@ func (bc *blockchain) MintNewBlock(timestamp time.Time) (*block.Block, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create block")
 	}
+	blkHash := blk.HashBlock()
+	audit.Record(audit.OpSignBlock, map[string]string{
+		"chainID":  strconv.FormatUint(uint64(bc.ChainID()), 10),
+		"height":   strconv.FormatUint(newblockHeight, 10),
+		"hash":     hex.EncodeToString(blkHash[:]),
+		"producer": bc.config.ProducerAddress().String(),
+	})
 	_blockMtc.WithLabelValues("MintGas").Set(float64(blk.GasUsed()))
 	_blockMtc.WithLabelValues("MintActions").Set(float64(len(blk.Actions)))
 	return &blk, nil
@@ -144,6 +144,11 @@ func (dao *blockDAO) Start(ctx context.Context) error {
 func (dao *blockDAO) checkIndexers(ctx context.Context) error {
 	checker := NewBlockIndexerChecker(dao)
 	for i, indexer := range dao.indexers {
+		if indexerWR, ok := indexer.(BlockIndexerWithRollback); ok {
+			if err := checker.RollbackIndexer(ctx, indexerWR); err != nil {
+				return errors.Wrapf(err, "failed to roll back indexer %d", i)
+			}
+		}
 		if err := checker.CheckIndexer(ctx, indexer, 0, func(height uint64) {
 			if height%5000 == 0 {
 				log.L().Info(
@@ -9,12 +9,14 @@ import (
 	"context"
 	"time"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
 
@@ -34,17 +36,68 @@ type (
 		StartHeight() uint64
 	}
 
+	// BlockIndexerWithRollback defines an interface of block indexer which could be rolled back to an earlier height,
+	// e.g., when the chain switches to a fork
+	BlockIndexerWithRollback interface {
+		BlockIndexer
+		// Checkpoint returns the hash of the indexed block at the height
+		Checkpoint(uint64) (hash.Hash256, error)
+		// Rollback reverts the index to the height
+		Rollback(context.Context, uint64) error
+	}
+
 	// BlockIndexerChecker defines a checker of block indexer
 	BlockIndexerChecker struct {
-		dao BlockDAO
+		dao BlockStore
 	}
 )
 
 // NewBlockIndexerChecker creates a new block indexer checker
-func NewBlockIndexerChecker(dao BlockDAO) *BlockIndexerChecker {
+func NewBlockIndexerChecker(dao BlockStore) *BlockIndexerChecker {
 	return &BlockIndexerChecker{dao: dao}
 }
 
+// RollbackIndexer rolls the indexer back to the common ancestor with block dao, if the indexer is on a fork of the
+// chain or higher than block dao. It returns error if the common ancestor is older than the checkpoints of the indexer,
+// which requires to rebuild the index
+func (bic *BlockIndexerChecker) RollbackIndexer(ctx context.Context, indexer BlockIndexerWithRollback) error {
+	tipHeight, err := indexer.Height()
+	if err != nil {
+		return err
+	}
+	daoTip, err := bic.dao.Height()
+	if err != nil {
+		return err
+	}
+	height := min(tipHeight, daoTip)
+	for ; height > 0; height-- {
+		checkpoint, err := indexer.Checkpoint(height)
+		if err != nil {
+			if errors.Cause(err) == db.ErrNotExist && height == tipHeight {
+				// the tip is indexed without checkpoint, the fork cannot be detected
+				return nil
+			}
+			return errors.Wrapf(err, "failed to find the common ancestor of indexer at height %d", height)
+		}
+		h, err := bic.dao.GetBlockHash(height)
+		if err != nil {
+			return err
+		}
+		if checkpoint == h {
+			break
+		}
+	}
+	if height == tipHeight {
+		return nil
+	}
+	log.L().Warn(
+		"rolling back indexer to the common ancestor.",
+		zap.Uint64("tipHeight", tipHeight),
+		zap.Uint64("ancestor", height),
+	)
+	return indexer.Rollback(ctx, height)
+}
+
 // CheckIndexer checks a block indexer against block dao
 func (bic *BlockIndexerChecker) CheckIndexer(ctx context.Context, indexer BlockIndexer, targetHeight uint64, progressReporter func(uint64)) error {
 	bcCtx, ok := protocol.GetBlockchainCtx(ctx)
@@ -14,6 +14,7 @@ import (
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/golang/mock/gomock"
 	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -22,6 +23,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockdao"
 )
@@ -289,3 +291,72 @@ func TestBlockIndexerChecker_CheckIndexer(t *testing.T) {
 		})
 	})
 }
+
+func TestBlockIndexerChecker_RollbackIndexer(t *testing.T) {
+	r := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	store := mock_blockdao.NewMockBlockDAO(ctrl)
+	bic := NewBlockIndexerChecker(store)
+	indexer := mock_blockdao.NewMockBlockIndexerWithRollback(ctrl)
+	blkHash := func(height uint64) hash.Hash256 {
+		return hash.Hash256b([]byte{byte(height)})
+	}
+	forkHash := func(height uint64) hash.Hash256 {
+		return hash.Hash256b([]byte{byte(height), 1})
+	}
+	store.EXPECT().GetBlockHash(gomock.Any()).DoAndReturn(func(height uint64) (hash.Hash256, error) {
+		return blkHash(height), nil
+	}).AnyTimes()
+
+	t.Run("SameChain", func(t *testing.T) {
+		indexer.EXPECT().Height().Return(uint64(5), nil).Times(1)
+		store.EXPECT().Height().Return(uint64(6), nil).Times(1)
+		indexer.EXPECT().Checkpoint(uint64(5)).Return(blkHash(5), nil).Times(1)
+
+		r.NoError(bic.RollbackIndexer(ctx, indexer))
+	})
+
+	t.Run("WithoutCheckpoint", func(t *testing.T) {
+		indexer.EXPECT().Height().Return(uint64(5), nil).Times(1)
+		store.EXPECT().Height().Return(uint64(6), nil).Times(1)
+		indexer.EXPECT().Checkpoint(uint64(5)).Return(hash.ZeroHash256, db.ErrNotExist).Times(1)
+
+		r.NoError(bic.RollbackIndexer(ctx, indexer))
+	})
+
+	t.Run("Fork", func(t *testing.T) {
+		indexer.EXPECT().Height().Return(uint64(5), nil).Times(1)
+		store.EXPECT().Height().Return(uint64(6), nil).Times(1)
+		indexer.EXPECT().Checkpoint(gomock.Any()).DoAndReturn(func(height uint64) (hash.Hash256, error) {
+			if height > 3 {
+				return forkHash(height), nil
+			}
+			return blkHash(height), nil
+		}).Times(3)
+		indexer.EXPECT().Rollback(ctx, uint64(3)).Return(nil).Times(1)
+
+		r.NoError(bic.RollbackIndexer(ctx, indexer))
+	})
+
+	t.Run("IndexerHigherThanDao", func(t *testing.T) {
+		indexer.EXPECT().Height().Return(uint64(7), nil).Times(1)
+		store.EXPECT().Height().Return(uint64(6), nil).Times(1)
+		indexer.EXPECT().Checkpoint(uint64(6)).Return(blkHash(6), nil).Times(1)
+		indexer.EXPECT().Rollback(ctx, uint64(6)).Return(nil).Times(1)
+
+		r.NoError(bic.RollbackIndexer(ctx, indexer))
+	})
+
+	t.Run("AncestorOutOfCheckpoints", func(t *testing.T) {
+		indexer.EXPECT().Height().Return(uint64(5), nil).Times(1)
+		store.EXPECT().Height().Return(uint64(6), nil).Times(1)
+		indexer.EXPECT().Checkpoint(uint64(5)).Return(forkHash(5), nil).Times(1)
+		indexer.EXPECT().Checkpoint(uint64(4)).Return(hash.ZeroHash256, db.ErrNotExist).Times(1)
+
+		r.ErrorContains(bic.RollbackIndexer(ctx, indexer), "failed to find the common ancestor")
+	})
+}
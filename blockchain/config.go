@@ -32,6 +32,10 @@ type (
 		BloomfilterIndexDBPath string `yaml:"bloomfilterIndexDBPath"`
 		CandidateIndexDBPath   string `yaml:"candidateIndexDBPath"`
 		StakingIndexDBPath     string `yaml:"stakingIndexDBPath"`
+		BalanceIndexDBPath     string `yaml:"balanceIndexDBPath"`
+		TokenIndexDBPath       string `yaml:"tokenIndexDBPath"`
+		FundingIndexDBPath     string `yaml:"fundingIndexDBPath"`
+		PerformanceIndexDBPath string `yaml:"performanceIndexDBPath"`
 		// deprecated
 		SGDIndexDBPath             string           `yaml:"sgdIndexDBPath"`
 		ContractStakingIndexDBPath string           `yaml:"contractStakingIndexDBPath"`
@@ -46,12 +50,37 @@ type (
 		EmptyGenesis               bool             `yaml:"emptyGenesis"`
 		GravityChainDB             db.Config        `yaml:"gravityChainDB"`
 		Committee                  committee.Config `yaml:"committee"`
+		// GravityChainCacheSize is the max number of gravity chain poll results cached in memory
+		GravityChainCacheSize int `yaml:"gravityChainCacheSize"`
+		// GravityChainMaxRetries is the max number of retries when the committee fails to return the poll data
+		GravityChainMaxRetries int `yaml:"gravityChainMaxRetries"`
+		// GravityChainRetryInterval is the interval before the first retry, which doubles after each retry
+		GravityChainRetryInterval time.Duration `yaml:"gravityChainRetryInterval"`
+		// GravityChainProbeInterval is the interval to probe the health of gravity chain endpoints, 0 means disabled
+		GravityChainProbeInterval time.Duration `yaml:"gravityChainProbeInterval"`
+
+		// ProducerKey selects the backend of the producer key, which signs the blocks and the consensus messages
+		ProducerKey KeyConfig `yaml:"producerKey"`
+		// OperatorKey selects the backend of the operator key, which signs the actions sent by the node, e.g., the
+		// double-sign evidences, the producer key is used if not set
+		OperatorKey KeyConfig `yaml:"operatorKey"`
 
 		EnableTrielessStateDB bool `yaml:"enableTrielessStateDB"`
+		// StakingViewCacheDir is the dir to persist the staking view at shutdown and load it at next startup. The
+		// view is bound to the state root, so the cache only works with the trie-based state factory, and is not
+		// used if EnableTrielessStateDB is true
+		StakingViewCacheDir string `yaml:"stakingViewCacheDir"`
 		// EnableStateDBCaching enables cachedStateDBOption
 		EnableStateDBCaching bool `yaml:"enableStateDBCaching"`
 		// EnableArchiveMode is only meaningful when EnableTrielessStateDB is false
 		EnableArchiveMode bool `yaml:"enableArchiveMode"`
+		// ArchiveSnapshotInterval enables the archive journal in archive mode, which keeps the diff of each block in
+		// memory and flattens the diffs into the snapshot on disk every interval blocks, instead of keeping a version
+		// of the state trie per height. The history before the journal is enabled is not available
+		ArchiveSnapshotInterval uint64 `yaml:"archiveSnapshotInterval"`
+		// EnablePreimageStore saves the preimages of the hashed namespaces and keys in the state trie, it is only
+		// meaningful when EnableTrielessStateDB is false
+		EnablePreimageStore bool `yaml:"enablePreimageStore"`
 		// EnableAsyncIndexWrite enables writing the block actions' and receipts' index asynchronously
 		EnableAsyncIndexWrite bool `yaml:"enableAsyncIndexWrite"`
 		// deprecated
@@ -60,6 +89,15 @@ type (
 		EnableStakingProtocol bool `yaml:"enableStakingProtocol"`
 		// EnableStakingIndexer enables staking indexer
 		EnableStakingIndexer bool `yaml:"enableStakingIndexer"`
+		// EnableBalanceIndexer enables the indexer of account balance history and transfers
+		EnableBalanceIndexer bool `yaml:"enableBalanceIndexer"`
+		// EnableTokenIndexer enables the indexer of XRC20 and XRC721 token balances and transfers
+		EnableTokenIndexer bool `yaml:"enableTokenIndexer"`
+		// EnableFundingIndexer enables the indexer of the funding sources of staking bucket creations and deposits
+		EnableFundingIndexer bool `yaml:"enableFundingIndexer"`
+		// EnablePerformanceIndexer enables the indexer of the produced blocks, missed slots, block fullness and
+		// endorsement participation of the delegates in each epoch
+		EnablePerformanceIndexer bool `yaml:"enablePerformanceIndexer"`
 		// AllowedBlockGasResidue is the amount of gas remained when block producer could stop processing more actions
 		AllowedBlockGasResidue uint64 `yaml:"allowedBlockGasResidue"`
 		// MaxCacheSize is the max number of blocks that will be put into an LRU cache. 0 means disabled
@@ -68,6 +106,12 @@ type (
 		PollInitialCandidatesInterval time.Duration `yaml:"pollInitialCandidatesInterval"`
 		// StateDBCacheSize is the max size of statedb LRU cache
 		StateDBCacheSize int `yaml:"stateDBCacheSize"`
+		// TrieNodeCacheSize is the max size in bytes of the trie node cache shared by the working sets and the reads
+		// of state factory, 0 disables the cache, it is only meaningful when EnableTrielessStateDB is false
+		TrieNodeCacheSize uint64 `yaml:"trieNodeCacheSize"`
+		// AccountFilterSize is the size in bits of the bloom filter of the existing accounts in state factory, which
+		// short-circuits the lookups of the nonexistent accounts, 0 disables the filter
+		AccountFilterSize uint64 `yaml:"accountFilterSize"`
 		// WorkingSetCacheSize is the max size of workingset cache in state factory
 		WorkingSetCacheSize uint64 `yaml:"workingSetCacheSize"`
 		// StreamingBlockBufferSize
@@ -80,6 +124,10 @@ type (
 		FactoryDBType string `yaml:"factoryDBType"`
 		// MintTimeout is the timeout for minting
 		MintTimeout time.Duration `yaml:"-"`
+
+		// producerKey and operatorKey are the keys held by the remote signer or the HSM
+		producerKey crypto.PrivateKey
+		operatorKey crypto.PrivateKey
 	}
 )
 
@@ -94,6 +142,10 @@ var (
 		BloomfilterIndexDBPath:     "/var/data/bloomfilter.index.db",
 		CandidateIndexDBPath:       "/var/data/candidate.index.db",
 		StakingIndexDBPath:         "/var/data/staking.index.db",
+		BalanceIndexDBPath:         "/var/data/balance.index.db",
+		TokenIndexDBPath:           "/var/data/token.index.db",
+		FundingIndexDBPath:         "/var/data/funding.index.db",
+		PerformanceIndexDBPath:     "/var/data/performance.index.db",
 		SGDIndexDBPath:             "/var/data/sgd.index.db",
 		ContractStakingIndexDBPath: "/var/data/contractstaking.index.db",
 		BlobStoreDBPath:            "/var/data/blob.db",
@@ -108,17 +160,29 @@ var (
 		Committee: committee.Config{
 			GravityChainAPIs: []string{},
 		},
+		GravityChainCacheSize:         64,
+		GravityChainMaxRetries:        2,
+		GravityChainRetryInterval:     100 * time.Millisecond,
+		GravityChainProbeInterval:     time.Minute,
 		EnableTrielessStateDB:         true,
 		EnableStateDBCaching:          false,
 		EnableArchiveMode:             false,
+		ArchiveSnapshotInterval:       0,
+		EnablePreimageStore:           false,
 		EnableAsyncIndexWrite:         true,
 		EnableSystemLogIndexer:        false,
 		EnableStakingProtocol:         true,
 		EnableStakingIndexer:          false,
+		EnableBalanceIndexer:          false,
+		EnableTokenIndexer:            false,
+		EnableFundingIndexer:          false,
+		EnablePerformanceIndexer:      false,
 		AllowedBlockGasResidue:        10000,
 		MaxCacheSize:                  0,
 		PollInitialCandidatesInterval: 10 * time.Second,
 		StateDBCacheSize:              1000,
+		TrieNodeCacheSize:             64 << 20,
+		AccountFilterSize:             0,
 		WorkingSetCacheSize:           20,
 		StreamingBlockBufferSize:      200,
 		PersistStakingPatchBlock:      19778037,
@@ -143,6 +207,9 @@ func (cfg *Config) ProducerAddress() address.Address {
 
 // ProducerPrivateKey returns the configured private key
 func (cfg *Config) ProducerPrivateKey() crypto.PrivateKey {
+	if cfg.producerKey != nil {
+		return cfg.producerKey
+	}
 	sk, err := crypto.HexStringToPrivateKey(cfg.ProducerPrivKey)
 	if err != nil {
 		log.L().Panic(
@@ -157,6 +224,32 @@ func (cfg *Config) ProducerPrivateKey() crypto.PrivateKey {
 	return sk
 }
 
+// OperatorPrivateKey returns the key signing the actions sent by the node, which is the producer key if not set
+func (cfg *Config) OperatorPrivateKey() crypto.PrivateKey {
+	if cfg.operatorKey != nil {
+		return cfg.operatorKey
+	}
+	return cfg.ProducerPrivateKey()
+}
+
+// SetKeyBackends loads the producer and operator keys held by the remote signer or the HSM
+func (cfg *Config) SetKeyBackends() error {
+	producerKey, err := newKey(cfg.ProducerKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to load producer key")
+	}
+	operatorKey, err := newKey(cfg.OperatorKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to load operator key")
+	}
+	// the keys of the backends are secp256k1 keys
+	if (producerKey != nil || operatorKey != nil) && !cfg.whitelistScheme(SigP256k1) {
+		return errors.Wrap(ErrConfig, "the signature scheme of key backend is not whitelisted")
+	}
+	cfg.producerKey, cfg.operatorKey = producerKey, operatorKey
+	return nil
+}
+
 // SetProducerPrivKey set producer privKey by PrivKeyConfigFile info
 func (cfg *Config) SetProducerPrivKey() error {
 	switch cfg.ProducerPrivKeySchema {
@@ -214,6 +307,10 @@ func (cfg *Config) whitelistSignatureScheme(sk crypto.PrivateKey) bool {
 	if sigScheme == "" {
 		return false
 	}
+	return cfg.whitelistScheme(sigScheme)
+}
+
+func (cfg *Config) whitelistScheme(sigScheme string) bool {
 	for _, e := range cfg.SignatureScheme {
 		if sigScheme == e {
 			// signature scheme is whitelisted
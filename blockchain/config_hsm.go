@@ -0,0 +1,122 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build pkcs11
+
+package blockchain
+
+import (
+	"encoding/asn1"
+	"math/big"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+	"github.com/pkg/errors"
+)
+
+// pkcs11Signer signs with the secp256k1 key in the token of an HSM, the PKCS#11 module is loaded with cgo, hence the
+// file is built with the pkcs11 tag only
+type pkcs11Signer struct {
+	mutex   sync.Mutex
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	key     pkcs11.ObjectHandle
+}
+
+func newPKCS11Signer(cfg HSMConfig) (ecdsaSigner, []byte, error) {
+	ctx := pkcs11.New(cfg.Module)
+	if ctx == nil {
+		return nil, nil, errors.Wrapf(ErrKeyBackend, "failed to load PKCS#11 module %s", cfg.Module)
+	}
+	if err := ctx.Initialize(); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to initialize PKCS#11 module")
+	}
+	slot, err := findSlot(ctx, cfg.TokenLabel)
+	if err != nil {
+		return nil, nil, err
+	}
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open PKCS#11 session")
+	}
+	if err := ctx.Login(session, pkcs11.CKU_USER, cfg.PIN); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to login PKCS#11 token")
+	}
+	key, err := findObject(ctx, session, pkcs11.CKO_PRIVATE_KEY, cfg.KeyLabel)
+	if err != nil {
+		return nil, nil, err
+	}
+	pub, err := findObject(ctx, session, pkcs11.CKO_PUBLIC_KEY, cfg.KeyLabel)
+	if err != nil {
+		return nil, nil, err
+	}
+	attrs, err := ctx.GetAttributeValue(session, pub, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil || len(attrs) == 0 {
+		return nil, nil, errors.Wrap(ErrKeyBackend, "failed to read public key from HSM")
+	}
+	// the EC point is DER-encoded as an octet string
+	var point []byte
+	if _, err := asn1.Unmarshal(attrs[0].Value, &point); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode public key from HSM")
+	}
+	return &pkcs11Signer{
+		ctx:     ctx,
+		session: session,
+		key:     key,
+	}, point, nil
+}
+
+// SignHash signs the hash with CKM_ECDSA, which returns the signature in the [R || S] format
+func (ps *pkcs11Signer) SignHash(hash []byte) (*big.Int, *big.Int, error) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	if err := ps.ctx.SignInit(ps.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, ps.key); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to init PKCS#11 signing")
+	}
+	sig, err := ps.ctx.Sign(ps.session, hash)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign with PKCS#11")
+	}
+	if len(sig) != 64 {
+		return nil, nil, errors.Wrapf(ErrKeyBackend, "invalid signature length %d", len(sig))
+	}
+	return new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:]), nil
+}
+
+func findSlot(ctx *pkcs11.Ctx, label string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list PKCS#11 slots")
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err == nil && info.Label == label {
+			return slot, nil
+		}
+	}
+	return 0, errors.Wrapf(ErrKeyBackend, "PKCS#11 token %s not found", label)
+}
+
+func findObject(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, class uint, label string) (pkcs11.ObjectHandle, error) {
+	if err := ctx.FindObjectsInit(session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}); err != nil {
+		return 0, errors.Wrap(err, "failed to find PKCS#11 object")
+	}
+	objs, _, err := ctx.FindObjects(session, 1)
+	if finalErr := ctx.FindObjectsFinal(session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find PKCS#11 object")
+	}
+	if len(objs) == 0 {
+		return 0, errors.Wrapf(ErrKeyBackend, "PKCS#11 object %s not found", label)
+	}
+	return objs[0], nil
+}
@@ -0,0 +1,18 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build !pkcs11
+
+package blockchain
+
+import (
+	"github.com/pkg/errors"
+)
+
+// newPKCS11Signer returns error as the PKCS#11 support is not built in, build the node with the pkcs11 tag to enable
+// the HSM key backend
+func newPKCS11Signer(HSMConfig) (ecdsaSigner, []byte, error) {
+	return nil, nil, errors.Wrap(ErrKeyBackend, "HSM key backend requires the node built with the pkcs11 tag")
+}
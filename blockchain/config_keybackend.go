@@ -0,0 +1,235 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/util"
+	"github.com/pkg/errors"
+)
+
+// key backends
+const (
+	// KeyBackendSoftware reads the key from the config, i.e., producerPrivKey
+	KeyBackendSoftware = "software"
+	// KeyBackendRemote signs with the key held by a remote signer
+	KeyBackendRemote = "remote"
+	// KeyBackendHSM signs with the key held by an HSM through PKCS#11
+	KeyBackendHSM = "hsm"
+)
+
+// ErrKeyBackend key backend error
+var ErrKeyBackend = errors.New("key backend error")
+
+type (
+	// KeyConfig is the config of the backend holding the key of a role
+	KeyConfig struct {
+		// Backend is the key backend, software if empty
+		Backend string `yaml:"backend"`
+		// PublicKey is the public key in hex of the key held by the remote signer, or by the HSM to double check
+		PublicKey string             `yaml:"publicKey"`
+		Remote    RemoteSignerConfig `yaml:"remote"`
+		HSM       HSMConfig          `yaml:"hsm"`
+	}
+
+	// RemoteSignerConfig is the config of the remote signer
+	RemoteSignerConfig struct {
+		// Endpoint is the url to which the hash to sign is posted
+		Endpoint string        `yaml:"endpoint"`
+		Token    string        `yaml:"token"`
+		Timeout  time.Duration `yaml:"timeout"`
+	}
+
+	// HSMConfig is the config of the PKCS#11 token holding the key
+	HSMConfig struct {
+		// Module is the path of the PKCS#11 library of the HSM
+		Module     string `yaml:"module"`
+		TokenLabel string `yaml:"tokenLabel"`
+		PIN        string `yaml:"pin"`
+		KeyLabel   string `yaml:"keyLabel"`
+	}
+
+	// ecdsaSigner signs the hash with a secp256k1 key held outside of the node
+	ecdsaSigner interface {
+		SignHash(hash []byte) (r, s *big.Int, err error)
+	}
+
+	// externalKey is a secp256k1 key held by a remote signer or an HSM, whose private part never enters the node
+	externalKey struct {
+		pubKey crypto.PublicKey
+		signer ecdsaSigner
+	}
+
+	remoteSigner struct {
+		cfg    RemoteSignerConfig
+		pubKey string
+		client *http.Client
+	}
+
+	remoteSignRequest struct {
+		PublicKey string `json:"publicKey"`
+		Hash      string `json:"hash"`
+	}
+
+	remoteSignResponse struct {
+		Signature string `json:"signature"`
+	}
+)
+
+var (
+	_secp256k1N     = ethcrypto.S256().Params().N
+	_secp256k1HalfN = new(big.Int).Rsh(_secp256k1N, 1)
+)
+
+// newKey returns the key of the backend, or nil if the key is read from the config
+func newKey(cfg KeyConfig) (crypto.PrivateKey, error) {
+	switch cfg.Backend {
+	case "", KeyBackendSoftware:
+		return nil, nil
+	case KeyBackendRemote:
+		if cfg.Remote.Endpoint == "" {
+			return nil, errors.Wrap(ErrKeyBackend, "endpoint of remote signer is empty")
+		}
+		pubKey, err := crypto.HexStringToPublicKey(cfg.PublicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid public key of remote signer")
+		}
+		return newExternalKey(pubKey, newRemoteSigner(cfg.Remote, pubKey.HexString()))
+	case KeyBackendHSM:
+		signer, raw, err := newPKCS11Signer(cfg.HSM)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := crypto.BytesToPublicKey(raw)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid public key of HSM")
+		}
+		if cfg.PublicKey != "" && cfg.PublicKey != pubKey.HexString() {
+			return nil, errors.Wrapf(ErrKeyBackend, "public key of HSM %s does not match %s", pubKey.HexString(), cfg.PublicKey)
+		}
+		return newExternalKey(pubKey, signer)
+	default:
+		return nil, errors.Wrapf(ErrKeyBackend, "invalid key backend %s", cfg.Backend)
+	}
+}
+
+func newExternalKey(pubKey crypto.PublicKey, signer ecdsaSigner) (*externalKey, error) {
+	if _, ok := pubKey.EcdsaPublicKey().(*ecdsa.PublicKey); !ok {
+		return nil, errors.Wrap(ErrKeyBackend, "only secp256k1 key is supported")
+	}
+	return &externalKey{
+		pubKey: pubKey,
+		signer: signer,
+	}, nil
+}
+
+// Bytes returns nil as the private key is not accessible
+func (k *externalKey) Bytes() []byte { return nil }
+
+// HexString returns empty as the private key is not accessible
+func (k *externalKey) HexString() string { return "" }
+
+// EcdsaPrivateKey returns nil as the private key is not accessible
+func (k *externalKey) EcdsaPrivateKey() interface{} { return nil }
+
+// PublicKey returns the public key
+func (k *externalKey) PublicKey() crypto.PublicKey { return k.pubKey }
+
+// Zero does nothing as the private key is not held by the node
+func (k *externalKey) Zero() {}
+
+// Sign signs the hash, and returns the signature in the [R || S || V] format
+func (k *externalKey) Sign(hash []byte) ([]byte, error) {
+	r, s, err := k.signer.SignHash(hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign hash")
+	}
+	return recoverableSignature(hash, r, s, k.pubKey)
+}
+
+// recoverableSignature normalizes the signature (r, s) to the lower s, and appends the recovery id with which the
+// public key is recovered from the signature
+func recoverableSignature(hash []byte, r, s *big.Int, pubKey crypto.PublicKey) ([]byte, error) {
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(_secp256k1N) >= 0 || s.Cmp(_secp256k1N) >= 0 {
+		return nil, errors.Wrap(ErrKeyBackend, "invalid signature")
+	}
+	if s.Cmp(_secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(_secp256k1N, s)
+	}
+	sig := make([]byte, 65)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		pk, err := crypto.RecoverPubkey(hash, sig)
+		if err == nil && bytes.Equal(pk.Bytes(), pubKey.Bytes()) {
+			return sig, nil
+		}
+	}
+	return nil, errors.Wrap(ErrKeyBackend, "signature does not match the public key")
+}
+
+func newRemoteSigner(cfg RemoteSignerConfig, pubKey string) *remoteSigner {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultHTTPTimeout
+	}
+	return &remoteSigner{
+		cfg:    cfg,
+		pubKey: pubKey,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// SignHash posts the hash to the remote signer, which returns the signature in the [R || S] or [R || S || V] format
+func (rs *remoteSigner) SignHash(hash []byte) (*big.Int, *big.Int, error) {
+	body, err := json.Marshal(&remoteSignRequest{
+		PublicKey: rs.pubKey,
+		Hash:      hex.EncodeToString(hash),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rs.cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rs.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if rs.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+rs.cfg.Token)
+	}
+	resp, err := rs.client.Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to request remote signer")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, errors.Wrapf(ErrKeyBackend, "remote signer returns status %d", resp.StatusCode)
+	}
+	var res remoteSignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode response of remote signer")
+	}
+	sig, err := hex.DecodeString(util.Remove0xPrefix(res.Signature))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode signature")
+	}
+	if len(sig) != 64 && len(sig) != 65 {
+		return nil, nil, errors.Wrapf(ErrKeyBackend, "invalid signature length %d", len(sig))
+	}
+	return new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64]), nil
+}
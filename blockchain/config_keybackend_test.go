@@ -0,0 +1,133 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestRemoteSignerKey(t *testing.T) {
+	require := require.New(t)
+	sk := identityset.PrivateKey(1)
+	highS := false
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req remoteSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PublicKey != sk.PublicKey().HexString() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h, _ := hex.DecodeString(req.Hash)
+		sig, _ := sk.Sign(h)
+		sig = sig[:64]
+		if highS {
+			// the signer may return the signature of the higher s
+			s := new(big.Int).Sub(_secp256k1N, new(big.Int).SetBytes(sig[32:]))
+			s.FillBytes(sig[32:])
+		}
+		json.NewEncoder(w).Encode(&remoteSignResponse{Signature: "0x" + hex.EncodeToString(sig)})
+	}))
+	defer svr.Close()
+
+	cfg := KeyConfig{
+		Backend:   KeyBackendRemote,
+		PublicKey: sk.PublicKey().HexString(),
+		Remote: RemoteSignerConfig{
+			Endpoint: svr.URL,
+			Token:    "secret",
+		},
+	}
+	key, err := newKey(cfg)
+	require.NoError(err)
+	require.Equal(sk.PublicKey().Address().String(), key.PublicKey().Address().String())
+	require.Empty(key.HexString())
+	msg := hash.Hash256b([]byte("block"))
+	expected, err := sk.Sign(msg[:])
+	require.NoError(err)
+	for _, high := range []bool{false, true} {
+		highS = high
+		sig, err := key.Sign(msg[:])
+		require.NoError(err)
+		require.Equal(expected, sig)
+		require.True(sk.PublicKey().Verify(msg[:], sig))
+	}
+
+	// the signature of another key is rejected
+	cfg.PublicKey = identityset.PrivateKey(2).PublicKey().HexString()
+	key, err = newKey(cfg)
+	require.NoError(err)
+	_, err = key.Sign(msg[:])
+	require.ErrorIs(err, ErrKeyBackend)
+
+	cfg.Remote.Token = ""
+	key, err = newKey(cfg)
+	require.NoError(err)
+	_, err = key.Sign(msg[:])
+	require.Contains(err.Error(), "status 401")
+
+	// a slow signer fails the signing after the timeout
+	done := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer slow.Close()
+	defer close(done)
+	cfg.Remote = RemoteSignerConfig{Endpoint: slow.URL, Timeout: 50 * time.Millisecond}
+	key, err = newKey(cfg)
+	require.NoError(err)
+	start := time.Now()
+	_, err = key.Sign(msg[:])
+	require.ErrorIs(err, context.DeadlineExceeded)
+	require.Less(time.Since(start), 5*time.Second)
+}
+
+func TestNewKey(t *testing.T) {
+	require := require.New(t)
+	key, err := newKey(KeyConfig{})
+	require.NoError(err)
+	require.Nil(key)
+	key, err = newKey(KeyConfig{Backend: KeyBackendSoftware})
+	require.NoError(err)
+	require.Nil(key)
+	for _, cfg := range []KeyConfig{
+		{Backend: "unknown"},
+		{Backend: KeyBackendRemote, PublicKey: identityset.PrivateKey(1).PublicKey().HexString()},
+	} {
+		_, err = newKey(cfg)
+		require.Equal(ErrKeyBackend, errors.Cause(err))
+	}
+	_, err = newKey(KeyConfig{Backend: KeyBackendRemote, PublicKey: "abcd", Remote: RemoteSignerConfig{Endpoint: "http://127.0.0.1"}})
+	require.Error(err)
+
+	cfg := DefaultConfig
+	cfg.ProducerKey = KeyConfig{
+		Backend:   KeyBackendRemote,
+		PublicKey: identityset.PrivateKey(1).PublicKey().HexString(),
+		Remote:    RemoteSignerConfig{Endpoint: "http://127.0.0.1"},
+	}
+	require.NoError(cfg.SetKeyBackends())
+	require.Equal(identityset.Address(1).String(), cfg.ProducerAddress().String())
+	// the operator key falls back to the producer key
+	require.Equal(identityset.Address(1).String(), cfg.OperatorPrivateKey().PublicKey().Address().String())
+	cfg.SignatureScheme = []string{SigP256sm2}
+	require.Equal(ErrConfig, errors.Cause(cfg.SetKeyBackends()))
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package filedao
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
+)
+
+type (
+	// TieredFileDAO is the FileDAO which moves the chain db files of the old blocks to the cold store
+	TieredFileDAO interface {
+		FileDAO
+		// MoveToColdStore moves the chain db files whose blocks are all below the height to the cold store, and
+		// returns the number of files moved
+		MoveToColdStore(height uint64) (int, error)
+	}
+
+	// ColdStoreMigrator moves the chain db files to the cold store periodically
+	ColdStoreMigrator struct {
+		fd         TieredFileDAO
+		coldHeight func(uint64) uint64
+		task       *routine.RecurringTask
+	}
+)
+
+// NewColdStoreMigrator creates a migrator, which moves the files whose blocks are below coldHeight(tip) to the cold
+// store every interval
+func NewColdStoreMigrator(fd TieredFileDAO, interval time.Duration, coldHeight func(uint64) uint64) *ColdStoreMigrator {
+	m := &ColdStoreMigrator{
+		fd:         fd,
+		coldHeight: coldHeight,
+	}
+	m.task = routine.NewRecurringTask(m.migrate, interval)
+	return m
+}
+
+// Start starts the migrator
+func (m *ColdStoreMigrator) Start(ctx context.Context) error {
+	return m.task.Start(ctx)
+}
+
+// Stop stops the migrator
+func (m *ColdStoreMigrator) Stop(ctx context.Context) error {
+	return m.task.Stop(ctx)
+}
+
+func (m *ColdStoreMigrator) migrate() {
+	tip, err := m.fd.Height()
+	if err != nil {
+		log.L().Error("Failed to get chain db height.", zap.Error(err))
+		return
+	}
+	height := m.coldHeight(tip)
+	if height == 0 {
+		return
+	}
+	moved, err := m.fd.MoveToColdStore(height)
+	if err != nil {
+		log.L().Error("Failed to move chain db files to cold store.", zap.Uint64("height", height), zap.Error(err))
+		return
+	}
+	if moved > 0 {
+		log.L().Info("Moved chain db files to cold store.", zap.Uint64("height", height), zap.Int("files", moved))
+	}
+}
+
+// MoveToColdStore moves the chain db files whose blocks are all below the height to the cold store. The master file
+// and the top file always stay in the hot store. A file is copied into the cold store with the cold compressor, and
+// replaces the hot one once the copy completes, so that the reads are served by either file all along
+func (fd *fileDAO) MoveToColdStore(height uint64) (int, error) {
+	if fd.cfg.ColdStore.Path == "" {
+		return 0, ErrNotSupported
+	}
+	fd.lock.Lock()
+	defer fd.lock.Unlock()
+
+	ctx := context.Background()
+	// the retired hot files may have been read by the last reads, remove them now
+	if err := fd.removeRetired(ctx); err != nil {
+		return 0, err
+	}
+	if fd.v2Fd == nil {
+		return 0, nil
+	}
+	if err := os.MkdirAll(fd.cfg.ColdStore.Path, 0o755); err != nil {
+		return 0, errors.Wrap(err, "failed to create cold store")
+	}
+	moved := 0
+	for i, index := range fd.v2Fd.Indices {
+		if i == len(fd.v2Fd.Indices)-1 || index.end >= height {
+			break
+		}
+		if index.fd.filename == fd.cfg.DbPath || fd.isCold(index.fd.filename) {
+			continue
+		}
+		cfg := fd.coldStoreConfig(index.fd.filename)
+		tmp, err := fd.copyFile(ctx, index.fd, cfg)
+		if err != nil {
+			return moved, err
+		}
+		if err := os.Rename(tmp, cfg.DbPath); err != nil {
+			return moved, errors.Wrapf(err, "failed to move %s to cold store", index.fd.filename)
+		}
+		cold, err := fd.openCopy(ctx, index.fd, cfg)
+		if err != nil {
+			return moved, err
+		}
+		fd.retire(index.fd, index.fd.filename)
+		index.fd = cold
+		moved++
+	}
+	return moved, nil
+}
+
+func (fd *fileDAO) isCold(filename string) bool {
+	return fd.cfg.ColdStore.Path != "" && filepath.Dir(filename) == filepath.Clean(fd.cfg.ColdStore.Path)
+}
+
+func (fd *fileDAO) coldStoreConfig(filename string) (cfg db.Config) {
+	cfg = fd.cfg
+	cfg.DbPath = filepath.Join(fd.cfg.ColdStore.Path, filepath.Base(filename))
+	cfg.Compressor = fd.cfg.ColdStore.Compressor
+	if fd.cfg.ColdStore.BlockStoreBatchSize > 0 {
+		cfg.BlockStoreBatchSize = fd.cfg.ColdStore.BlockStoreBatchSize
+	}
+	return
+}
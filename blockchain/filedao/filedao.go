@@ -78,6 +78,7 @@ type (
 		currFd            BaseFileDAO
 		legacyFd          FileDAO
 		v2Fd              *FileV2Manager // a collection of v2 db files
+		retired           []retiredFile  // files which have been replaced by their copies
 		blockDeserializer *block.Deserializer
 	}
 )
@@ -111,6 +112,23 @@ func NewFileDAO(cfg db.Config, deser *block.Deserializer) (FileDAO, error) {
 	}
 }
 
+// NewFileDAOWithBottom creates a new chain db whose first block is at the given height, which is used to bootstrap
+// the chain from a state snapshot instead of the genesis block
+func NewFileDAOWithBottom(bottom uint64, cfg db.Config, deser *block.Deserializer) (FileDAO, error) {
+	_, err := readFileHeader(cfg.DbPath, FileAll)
+	switch err {
+	case nil:
+		return nil, errors.Wrapf(ErrAlreadyExist, "chain db %s already exists", cfg.DbPath)
+	case ErrFileNotExist:
+	default:
+		return nil, err
+	}
+	if err := createNewV2File(bottom, cfg, deser); err != nil {
+		return nil, err
+	}
+	return CreateFileDAO(false, cfg, deser)
+}
+
 // NewFileDAOInMemForTest creates an in-memory FileDAO for testing
 func NewFileDAOInMemForTest() (FileDAO, error) {
 	return newTestInMemFd()
@@ -143,9 +161,13 @@ func (fd *fileDAO) Stop(ctx context.Context) error {
 		}
 	}
 	if fd.v2Fd != nil {
-		return fd.v2Fd.Stop(ctx)
+		if err := fd.v2Fd.Stop(ctx); err != nil {
+			return err
+		}
 	}
-	return nil
+	fd.lock.Lock()
+	defer fd.lock.Unlock()
+	return fd.removeRetired(ctx)
 }
 
 func (fd *fileDAO) Height() (uint64, error) {
@@ -370,7 +392,7 @@ func (fd *fileDAO) DeleteTipBlock() error {
 func CreateFileDAO(legacy bool, cfg db.Config, deser *block.Deserializer) (FileDAO, error) {
 	fd := fileDAO{splitHeight: 1, cfg: cfg, blockDeserializer: deser}
 	fds := []*fileDAOv2{}
-	v2Top, v2Files := checkAuxFiles(cfg.DbPath, FileV2)
+	v2Top, v2Files := checkV2Files(cfg)
 	if legacy {
 		legacyFd, err := newFileDAOLegacy(cfg, deser)
 		if err != nil {
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package filedao
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const (
+	// _tmpFileSuffix is the suffix of the file being copied, which is not recognized as a chain db file
+	_tmpFileSuffix = ".tmp"
+	// _oldFileSuffix is the suffix of the file being replaced by its copy
+	_oldFileSuffix = ".old"
+)
+
+// retiredFile is a file replaced by its copy, which is removed once no read is served by it
+type retiredFile struct {
+	fd   *fileDAOv2
+	path string
+}
+
+// copyFile copies the src file into a temp file created with the cfg, and returns the name of the temp file
+func (fd *fileDAO) copyFile(ctx context.Context, src *fileDAOv2, cfg db.Config) (string, error) {
+	cfg.DbPath += _tmpFileSuffix
+	if err := os.RemoveAll(cfg.DbPath); err != nil {
+		return "", err
+	}
+	dst, err := newFileDAOv2(src.header.Start, cfg, fd.blockDeserializer)
+	if err != nil {
+		return "", err
+	}
+	if err := dst.Start(ctx); err != nil {
+		return "", err
+	}
+	if err := dst.copyFrom(src); err != nil {
+		dst.Stop(ctx)
+		return "", errors.Wrapf(err, "failed to copy %s", src.filename)
+	}
+	if err := dst.Stop(ctx); err != nil {
+		return "", err
+	}
+	return cfg.DbPath, nil
+}
+
+// openCopy opens the copy of the src file, and checks it holds the same blocks
+func (fd *fileDAO) openCopy(ctx context.Context, src *fileDAOv2, cfg db.Config) (*fileDAOv2, error) {
+	v2 := openFileDAOv2(cfg, fd.blockDeserializer)
+	if err := v2.Start(ctx); err != nil {
+		return nil, err
+	}
+	if v2.header.Start != src.header.Start || v2.loadTip().Height != src.loadTip().Height {
+		v2.Stop(ctx)
+		return nil, errors.Wrapf(ErrDataCorruption, "copy %s does not match %s", cfg.DbPath, src.filename)
+	}
+	return v2, nil
+}
+
+// retire keeps the replaced file open for the reads in flight, the file at path is removed in the next round
+func (fd *fileDAO) retire(v2 *fileDAOv2, path string) {
+	fd.retired = append(fd.retired, retiredFile{fd: v2, path: path})
+}
+
+// removeRetired stops and removes the files which have been replaced
+func (fd *fileDAO) removeRetired(ctx context.Context) error {
+	for len(fd.retired) > 0 {
+		v2 := fd.retired[0]
+		if err := v2.fd.Stop(ctx); err != nil {
+			return err
+		}
+		if v2.path != "" {
+			if err := os.RemoveAll(v2.path); err != nil {
+				return err
+			}
+		}
+		fd.retired = fd.retired[1:]
+	}
+	return nil
+}
+
+// copyFrom copies the blocks, receipts, and transaction logs of the src file
+func (fd *fileDAOv2) copyFrom(src *fileDAOv2) error {
+	tip := src.loadTip()
+	for height := src.header.Start; height <= tip.Height; height++ {
+		blk, err := src.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		if blk.Receipts, err = src.GetReceipts(height); err != nil {
+			return err
+		}
+		// the transaction log is not part of the block read back, copy it as is
+		sysLog, err := src.sysStore.Get(height - src.header.Start)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get transaction log at height %d", height)
+		}
+		if sysLog, err = decompBytes(sysLog, src.header.Compressor); err != nil {
+			return err
+		}
+		if err := fd.putTipHashHeightMapping(blk); err != nil {
+			return err
+		}
+		if err := fd.putBlock(blk); err != nil {
+			return err
+		}
+		if err := fd.putSystemLog(sysLog); err != nil {
+			return err
+		}
+		if err := fd.kvStore.WriteBatch(fd.batch); err != nil {
+			return errors.Wrapf(err, "failed to put block at height %d", height)
+		}
+		fd.batch.Clear()
+		fd.storeTip(&FileTip{Height: height, Hash: blk.HashBlock()})
+	}
+	return nil
+}
+
+// recoverAuxFiles cleans up the copies of the auxiliary files interrupted by a restart. An unfinished copy is
+// removed, and a replaced file is restored if its copy has not been moved into place
+func recoverAuxFiles(filename string) {
+	dir, base := filepath.Dir(filename), filepath.Base(filename)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, v := range files {
+		var (
+			name   = filepath.Join(dir, v.Name())
+			suffix = filepath.Ext(v.Name())
+			orig   = strings.TrimSuffix(v.Name(), suffix)
+		)
+		if suffix != _tmpFileSuffix && suffix != _oldFileSuffix {
+			continue
+		}
+		if _, ok := isAuxFile(orig, base); !ok {
+			continue
+		}
+		if suffix == _oldFileSuffix && fileExists(filepath.Join(dir, orig)) != nil {
+			err = os.Rename(name, filepath.Join(dir, orig))
+		} else {
+			err = os.RemoveAll(name)
+		}
+		if err != nil {
+			log.L().Error("Failed to recover chain db file.", zap.String("file", name), zap.Error(err))
+		}
+	}
+}
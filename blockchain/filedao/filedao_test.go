@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/hex"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/iotexproject/go-pkgs/crypto"
@@ -186,6 +187,38 @@ func TestNewFileDAOSplitV2(t *testing.T) {
 	os.RemoveAll(file2)
 }
 
+func TestNewFileDAOWithBottom(t *testing.T) {
+	r := require.New(t)
+
+	cfg := db.DefaultConfig
+	cfg.DbPath = "./filedao_bottom.db"
+	defer os.RemoveAll(cfg.DbPath)
+
+	deser := block.NewDeserializer(_defaultEVMNetworkID)
+	fd, err := NewFileDAOWithBottom(100, cfg, deser)
+	r.NoError(err)
+	ctx := context.Background()
+	r.NoError(fd.Start(ctx))
+	height, err := fd.Height()
+	r.NoError(err)
+	r.EqualValues(99, height)
+	r.Equal(ErrInvalidTipHeight, testCommitBlocks(t, fd, 1, 1, hash.ZeroHash256))
+	r.NoError(testCommitBlocks(t, fd, 100, 110, hash.ZeroHash256))
+	testVerifyChainDB(t, fd, 100, 110)
+	_, err = fd.GetBlockByHeight(99)
+	r.Error(err)
+	r.NoError(fd.Stop(ctx))
+
+	// cannot overwrite an existing chain db
+	_, err = NewFileDAOWithBottom(100, cfg, deser)
+	r.ErrorIs(err, ErrAlreadyExist)
+	fd, err = NewFileDAO(cfg, deser)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	testVerifyChainDB(t, fd, 100, 110)
+	r.NoError(fd.Stop(ctx))
+}
+
 func TestNewFileDAOSplitLegacy(t *testing.T) {
 	r := require.New(t)
 
@@ -285,6 +318,162 @@ func TestNewFileDAOSplitLegacy(t *testing.T) {
 	os.RemoveAll(kthAuxFileName("./filedao_v2.db", fm.topIndex))
 }
 
+func TestNewFileDAOPebble(t *testing.T) {
+	r := require.New(t)
+
+	cfg := db.DefaultConfig
+	cfg.DBType = db.DBPebble
+	cfg.V2BlocksToSplitDB = 10
+	cfg.DbPath = filepath.Join(t.TempDir(), "chain.db")
+
+	// test empty db, this will create new v2 db with pebble
+	deser := block.NewDeserializer(_defaultEVMNetworkID)
+	fd, err := NewFileDAO(cfg, deser)
+	r.NoError(err)
+	info, err := os.Stat(cfg.DbPath)
+	r.NoError(err)
+	r.True(info.IsDir())
+	h, err := readFileHeader(cfg.DbPath, FileAll)
+	r.NoError(err)
+	r.Equal(FileV2, h.Version)
+	ctx := context.Background()
+	r.NoError(fd.Start(ctx))
+	r.NoError(testCommitBlocks(t, fd, 1, 25, hash.ZeroHash256))
+	testVerifyChainDB(t, fd, 1, 25)
+	r.NoError(fd.Stop(ctx))
+	top, files := checkAuxFiles(cfg.DbPath, FileV2)
+	r.EqualValues(2, top)
+	r.Len(files, 2)
+
+	// the existing pebble files are still opened after switching back to bolt, and the new file is created by bolt
+	cfg.DBType = db.DBBolt
+	fd, err = NewFileDAO(cfg, deser)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	testVerifyChainDB(t, fd, 1, 25)
+	r.NoError(testCommitBlocks(t, fd, 26, 35, hash.ZeroHash256))
+	testVerifyChainDB(t, fd, 1, 35)
+	r.NoError(fd.Stop(ctx))
+	info, err = os.Stat(kthAuxFileName(cfg.DbPath, 3))
+	r.NoError(err)
+	r.False(info.IsDir())
+}
+
+func TestFileDAOColdStore(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	cfg := db.DefaultConfig
+	cfg.V2BlocksToSplitDB = 10
+	cfg.DbPath = filepath.Join(dir, "chain.db")
+	cfg.ColdStore.Path = filepath.Join(dir, "cold")
+	cfg.ColdStore.BlockStoreBatchSize = 4
+
+	deser := block.NewDeserializer(_defaultEVMNetworkID)
+	fd, err := NewFileDAO(cfg, deser)
+	r.NoError(err)
+	ctx := context.Background()
+	r.NoError(fd.Start(ctx))
+	r.NoError(testCommitBlocks(t, fd, 1, 35, hash.ZeroHash256))
+	tfd, ok := fd.(TieredFileDAO)
+	r.True(ok)
+	// the master file and the top file stay in the hot store
+	moved, err := tfd.MoveToColdStore(31)
+	r.NoError(err)
+	r.Equal(2, moved)
+	testVerifyChainDB(t, fd, 1, 35)
+	moved, err = tfd.MoveToColdStore(36)
+	r.NoError(err)
+	r.Zero(moved)
+	r.NoError(fd.Stop(ctx))
+
+	for k := uint64(1); k <= 2; k++ {
+		_, err = os.Stat(kthAuxFileName(cfg.DbPath, k))
+		r.True(os.IsNotExist(err))
+		h, err := readFileHeader(kthAuxFileName(filepath.Join(cfg.ColdStore.Path, "chain.db"), k), FileV2)
+		r.NoError(err)
+		r.Equal(compress.Gzip, h.Compressor)
+		r.EqualValues(4, h.BlockStoreSize)
+	}
+	top, files := checkAuxFiles(cfg.DbPath, FileV2)
+	r.EqualValues(3, top)
+	r.Len(files, 1)
+
+	// reopen with the files in both stores
+	fd, err = NewFileDAO(cfg, deser)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	testVerifyChainDB(t, fd, 1, 35)
+	r.NoError(testCommitBlocks(t, fd, 36, 45, hash.ZeroHash256))
+	r.EqualValues(4, fd.(*fileDAO).topIndex)
+	testVerifyChainDB(t, fd, 1, 45)
+	r.NoError(fd.Stop(ctx))
+
+	// the cold store is disabled
+	cfg.ColdStore.Path = ""
+	fd, err = NewFileDAO(cfg, deser)
+	r.NoError(err)
+	_, err = fd.(TieredFileDAO).MoveToColdStore(36)
+	r.Equal(ErrNotSupported, err)
+}
+
+func TestFileDAORecompress(t *testing.T) {
+	r := require.New(t)
+
+	cfg := db.DefaultConfig
+	cfg.Compressor = compress.Snappy
+	cfg.V2BlocksToSplitDB = 10
+	cfg.DbPath = filepath.Join(t.TempDir(), "chain.db")
+
+	deser := block.NewDeserializer(_defaultEVMNetworkID)
+	fd, err := NewFileDAO(cfg, deser)
+	r.NoError(err)
+	ctx := context.Background()
+	r.NoError(fd.Start(ctx))
+	r.NoError(testCommitBlocks(t, fd, 1, 35, hash.ZeroHash256))
+	r.NoError(fd.Stop(ctx))
+
+	cfg.Compressor = compress.Zstd
+	fd, err = NewFileDAO(cfg, deser)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	rfd, ok := fd.(RecompressibleFileDAO)
+	r.True(ok)
+	// one file at a time, the master file and the top file are not recompressed
+	for _, expected := range []int{1, 1, 0} {
+		n, err := rfd.Recompress()
+		r.NoError(err)
+		r.Equal(expected, n)
+		testVerifyChainDB(t, fd, 1, 35)
+	}
+	r.NoError(fd.Stop(ctx))
+	for k, comp := range []string{compress.Snappy, compress.Zstd, compress.Zstd, compress.Snappy} {
+		name := cfg.DbPath
+		if k > 0 {
+			name = kthAuxFileName(cfg.DbPath, uint64(k))
+		}
+		h, err := readFileHeader(name, FileV2)
+		r.NoError(err)
+		r.Equal(comp, h.Compressor)
+		_, err = os.Stat(name + _oldFileSuffix)
+		r.True(os.IsNotExist(err))
+	}
+
+	// restart in the middle of replacing a file
+	file2 := kthAuxFileName(cfg.DbPath, 2)
+	r.NoError(os.Rename(file2, file2+_oldFileSuffix))
+	r.NoError(os.WriteFile(file2+_tmpFileSuffix, []byte("partial"), 0o600))
+	fd, err = NewFileDAO(cfg, deser)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	testVerifyChainDB(t, fd, 1, 35)
+	r.NoError(fd.Stop(ctx))
+	for _, name := range []string{file2 + _oldFileSuffix, file2 + _tmpFileSuffix} {
+		_, err = os.Stat(name)
+		r.True(os.IsNotExist(err))
+	}
+}
+
 func TestCheckFiles(t *testing.T) {
 	r := require.New(t)
 
@@ -10,13 +10,16 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
 
 	"github.com/iotexproject/go-pkgs/hash"
+	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
 
 func readFileHeader(filename, fileType string) (*FileHeader, error) {
@@ -24,7 +27,7 @@ func readFileHeader(filename, fileType string) (*FileHeader, error) {
 		return nil, err
 	}
 
-	file := db.NewBoltDB(db.Config{DbPath: filename, NumRetries: 3})
+	file := newFileKVStore(db.Config{DbPath: filename, NumRetries: 3})
 	ctx := context.Background()
 	if err := file.Start(ctx); err != nil {
 		// not a valid db file
@@ -34,15 +37,22 @@ func readFileHeader(filename, fileType string) (*FileHeader, error) {
 
 	switch fileType {
 	case FileLegacyMaster, FileLegacyAuxiliary:
-		return ReadHeaderLegacy(file)
+		// legacy file is always a bolt db file
+		bolt, ok := file.(*db.BoltDB)
+		if !ok {
+			return nil, ErrFileInvalid
+		}
+		return ReadHeaderLegacy(bolt)
 	case FileV2:
 		if headerV2, err := ReadHeaderV2(file); err == nil {
 			return headerV2, nil
 		}
 		return nil, ErrFileInvalid
 	case FileAll:
-		if header, err := ReadHeaderLegacy(file); err == nil {
-			return header, nil
+		if bolt, ok := file.(*db.BoltDB); ok {
+			if header, err := ReadHeaderLegacy(bolt); err == nil {
+				return header, nil
+			}
 		}
 		if headerV2, err := ReadHeaderV2(file); err == nil {
 			return headerV2, nil
@@ -53,9 +63,32 @@ func readFileHeader(filename, fileType string) (*FileHeader, error) {
 	}
 }
 
+// newFileKVStore returns the store of the chain db file. An existing file is opened with the backend it was
+// created by, a pebble db being a directory, so that switching the db type only applies to the new files
+func newFileKVStore(cfg db.Config) db.KVStore {
+	if info, err := os.Stat(cfg.DbPath); err == nil {
+		if info.IsDir() {
+			return db.NewPebbleDB(cfg)
+		}
+		return db.NewBoltDB(cfg)
+	}
+	if cfg.DBType == db.DBPebble {
+		return db.NewPebbleDB(cfg)
+	}
+	return db.NewBoltDB(cfg)
+}
+
 func fileExists(name string) error {
 	info, err := os.Stat(name)
-	if err != nil || info.IsDir() || info.Size() == 0 {
+	if err != nil {
+		return ErrFileNotExist
+	}
+	if info.IsDir() {
+		// a pebble db always has the CURRENT file
+		if _, err := os.Stat(filepath.Join(name, "CURRENT")); err != nil {
+			return ErrFileNotExist
+		}
+	} else if info.Size() == 0 {
 		return ErrFileNotExist
 	}
 	err = syscall.Access(name, syscall.O_RDWR)
@@ -82,9 +115,6 @@ func checkAuxFiles(filename, fileType string) (uint64, []string) {
 		possible []string
 	)
 	for _, v := range files {
-		if v.IsDir() {
-			continue
-		}
 		index, ok := isAuxFile(v.Name(), file)
 		if !ok {
 			continue
@@ -101,6 +131,37 @@ func checkAuxFiles(filename, fileType string) (uint64, []string) {
 	return top, possible
 }
 
+// checkV2Files returns the v2 auxiliary files in both the hot and the cold store. If a file exists in both, the
+// move to the cold store has completed, the cold one is kept and the hot one is removed
+func checkV2Files(cfg db.Config) (uint64, []string) {
+	recoverAuxFiles(cfg.DbPath)
+	top, files := checkAuxFiles(cfg.DbPath, FileV2)
+	if cfg.ColdStore.Path == "" {
+		return top, files
+	}
+	coldPath := filepath.Join(cfg.ColdStore.Path, filepath.Base(cfg.DbPath))
+	recoverAuxFiles(coldPath)
+	coldTop, coldFiles := checkAuxFiles(coldPath, FileV2)
+	if len(coldFiles) == 0 {
+		return top, files
+	}
+	cold := make(map[string]bool, len(coldFiles))
+	for _, name := range coldFiles {
+		cold[filepath.Base(name)] = true
+	}
+	for _, name := range files {
+		if !cold[filepath.Base(name)] {
+			coldFiles = append(coldFiles, name)
+		} else if err := os.RemoveAll(name); err != nil {
+			log.L().Error("Failed to remove chain db file moved to cold store.", zap.String("file", name), zap.Error(err))
+		}
+	}
+	if coldTop > top {
+		top = coldTop
+	}
+	return top, coldFiles
+}
+
 // isAuxFile returns true if file is an auxiliary chain db filename, and its index
 func isAuxFile(file, base string) (uint64, bool) {
 	extB := path.Ext(base)
@@ -69,7 +69,7 @@ func newFileDAOv2(bottom uint64, cfg db.Config, deser *block.Deserializer) (*fil
 			Height: bottom - 1,
 		},
 		blkStorePbCache: cache.NewThreadSafeLruCache(16),
-		kvStore:         db.NewBoltDB(cfg),
+		kvStore:         newFileKVStore(cfg),
 		batch:           batch.NewBatch(),
 		deser:           deser,
 	}
@@ -81,7 +81,7 @@ func openFileDAOv2(cfg db.Config, deser *block.Deserializer) *fileDAOv2 {
 	return &fileDAOv2{
 		filename:        cfg.DbPath,
 		blkStorePbCache: cache.NewThreadSafeLruCache(16),
-		kvStore:         db.NewBoltDB(cfg),
+		kvStore:         newFileKVStore(cfg),
 		batch:           batch.NewBatch(),
 		deser:           deser,
 	}
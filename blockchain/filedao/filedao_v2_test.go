@@ -90,7 +90,7 @@ func TestNewFileDAOv2(t *testing.T) {
 	}()
 
 	cfg := db.DefaultConfig
-	r.Equal(compress.Snappy, cfg.Compressor)
+	r.Equal(compress.Zstd, cfg.Compressor)
 	r.Equal(16, cfg.BlockStoreBatchSize)
 	cfg.DbPath = testPath
 	deser := block.NewDeserializer(_defaultEVMNetworkID)
@@ -112,7 +112,11 @@ func (fd *fileDAOv2) putTransactionLog(blk *block.Block) error {
 	if sysLog == nil {
 		sysLog = &block.BlkTransactionLog{}
 	}
-	logBytes, err := compBytes(sysLog.Serialize(), fd.header.Compressor)
+	return fd.putSystemLog(sysLog.Serialize())
+}
+
+func (fd *fileDAOv2) putSystemLog(ser []byte) error {
+	logBytes, err := compBytes(ser, fd.header.Compressor)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package filedao
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
+)
+
+type (
+	// RecompressibleFileDAO is the FileDAO which recompresses the chain db files written with another compressor
+	RecompressibleFileDAO interface {
+		FileDAO
+		// Recompress recompresses a chain db file with the configured compressor, and returns the number of files
+		// recompressed
+		Recompress() (int, error)
+	}
+
+	// Recompressor recompresses the chain db files in background
+	Recompressor struct {
+		fd   RecompressibleFileDAO
+		task *routine.RecurringTask
+	}
+)
+
+// NewRecompressor creates a recompressor, which recompresses a chain db file every interval
+func NewRecompressor(fd RecompressibleFileDAO, interval time.Duration) *Recompressor {
+	r := &Recompressor{
+		fd: fd,
+	}
+	r.task = routine.NewRecurringTask(r.recompress, interval)
+	return r
+}
+
+// Start starts the recompressor
+func (r *Recompressor) Start(ctx context.Context) error {
+	return r.task.Start(ctx)
+}
+
+// Stop stops the recompressor
+func (r *Recompressor) Stop(ctx context.Context) error {
+	return r.task.Stop(ctx)
+}
+
+func (r *Recompressor) recompress() {
+	n, err := r.fd.Recompress()
+	if err != nil {
+		log.L().Error("Failed to recompress chain db file.", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		log.L().Info("Recompressed chain db file.", zap.Int("files", n))
+	}
+}
+
+// Recompress recompresses the lowest file written with another compressor, one file at a time to spread the disk
+// load. The master file and the top file are not recompressed. A file in the cold store is recompressed with the
+// cold compressor
+func (fd *fileDAO) Recompress() (int, error) {
+	fd.lock.Lock()
+	defer fd.lock.Unlock()
+
+	ctx := context.Background()
+	if err := fd.removeRetired(ctx); err != nil {
+		return 0, err
+	}
+	if fd.v2Fd == nil {
+		return 0, nil
+	}
+	for i, index := range fd.v2Fd.Indices {
+		if i == len(fd.v2Fd.Indices)-1 {
+			break
+		}
+		if index.fd.filename == fd.cfg.DbPath {
+			continue
+		}
+		cfg := fd.cfg
+		cfg.DbPath = index.fd.filename
+		if fd.isCold(index.fd.filename) {
+			cfg = fd.coldStoreConfig(index.fd.filename)
+		}
+		if index.fd.header.Compressor == cfg.Compressor {
+			continue
+		}
+		tmp, err := fd.copyFile(ctx, index.fd, cfg)
+		if err != nil {
+			return 0, err
+		}
+		// the file is moved aside, as a pebble db being a directory cannot be replaced by rename
+		old := cfg.DbPath + _oldFileSuffix
+		if err := os.Rename(cfg.DbPath, old); err != nil {
+			return 0, errors.Wrapf(err, "failed to move %s aside", cfg.DbPath)
+		}
+		if err := os.Rename(tmp, cfg.DbPath); err != nil {
+			return 0, errors.Wrapf(err, "failed to replace %s", cfg.DbPath)
+		}
+		v2, err := fd.openCopy(ctx, index.fd, cfg)
+		if err != nil {
+			return 0, err
+		}
+		fd.retire(index.fd, old)
+		index.fd = v2
+		return 1, nil
+	}
+	return 0, nil
+}
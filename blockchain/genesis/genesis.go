@@ -76,6 +76,8 @@ func defaultConfig() Genesis {
 			UpernavikBlockHeight:      31174201,
 			VanuatuBlockHeight:        33730921,
 			ToBeEnabledBlockHeight:    math.MaxUint64,
+			ACLAdmins:                 []string{},
+			GasTableUpgrades:          []GasTableUpgrade{},
 		},
 		Account: Account{
 			InitBalanceMap: map[string]string{
@@ -182,6 +184,7 @@ func defaultConfig() Genesis {
 			FoundationBonusP2StartEpoch:    9698,
 			FoundationBonusP2EndEpoch:      18458,
 			ProductivityThreshold:          85,
+			RewardSchedules:                []RewardSchedule{},
 		},
 		Staking: Staking{
 			VoteWeightCalConsts: VoteWeightCalConsts{
@@ -249,6 +252,8 @@ type (
 		ActionGasLimit uint64 `yaml:"actionGasLimit"`
 		// BlockInterval is the interval between two blocks
 		BlockInterval time.Duration `yaml:"blockInterval"`
+		// AdaptiveBlockInterval adapts the block interval to the backlog of actions, which is disabled by default
+		AdaptiveBlockInterval AdaptiveBlockInterval `yaml:"adaptiveBlockInterval"`
 		// NumSubEpochs is the number of sub epochs in one epoch of block production
 		NumSubEpochs uint64 `yaml:"numSubEpochs"`
 		// DardanellesNumSubEpochs is the number of sub epochs starts from dardanelles height in one epoch of block production
@@ -354,6 +359,39 @@ type (
 		// ToBeEnabledBlockHeight is a fake height that acts as a gating factor for WIP features
 		// upon next release, change IsToBeEnabled() to IsNextHeight() for features to be released
 		ToBeEnabledBlockHeight uint64 `yaml:"toBeEnabledHeight"`
+		// ACLAdmins are the addresses managing the allowlist of senders and action types for permissioned
+		// deployments, the acl protocol is not registered if it is empty
+		ACLAdmins []string `yaml:"aclAdmins"`
+		// GasTableUpgrades are the versions of the intrinsic gas table in ascending order of heights, each of which
+		// upgrades the previous version from its height
+		GasTableUpgrades []GasTableUpgrade `yaml:"gasTableUpgrades"`
+	}
+	// GasTableUpgrade is a version of the intrinsic gas table activated at the height
+	GasTableUpgrade struct {
+		Height uint64 `yaml:"height"`
+		// IntrinsicGas overrides the intrinsic gas of the action types, named as in the json of the gas table
+		IntrinsicGas map[string]IntrinsicGas `yaml:"intrinsicGas"`
+	}
+	// IntrinsicGas is the intrinsic gas of an action type, which is the base gas plus the gas of each byte of payload
+	IntrinsicGas struct {
+		Base    uint64 `yaml:"base"`
+		PerByte uint64 `yaml:"perByte"`
+	}
+	// AdaptiveBlockInterval contains the configs of adapting the block interval to the backlog of actions. The
+	// backlog is measured by the gas used by the previous block, which the proposer packs from its actpool and all the
+	// delegates agree on. The interval after a block using gas no less than BacklogGasThreshold is shortened to
+	// MinInterval, and the interval after a block using no gas is lengthened to MaxInterval. Since the rounds are
+	// aligned to MinInterval from the fork height on, the block intervals should be multiples of it
+	AdaptiveBlockInterval struct {
+		// Height is the fork height starting from which the block interval is adaptive, it should be no earlier than
+		// the Dardanelles height, so that the block interval it adapts does not change afterwards
+		Height uint64 `yaml:"height"`
+		// MinInterval is the shortest block interval, the adaptive block interval is disabled if it is zero
+		MinInterval time.Duration `yaml:"minInterval"`
+		// MaxInterval is the longest block interval
+		MaxInterval time.Duration `yaml:"maxInterval"`
+		// BacklogGasThreshold is the gas used by a block, starting from which the next block interval is shortened
+		BacklogGasThreshold uint64 `yaml:"backlogGasThreshold"`
 	}
 	// Account contains the configs for account protocol
 	Account struct {
@@ -448,6 +486,25 @@ type (
 		FoundationBonusP2EndEpoch uint64 `yaml:"foundationBonusP2EndEpoch"`
 		// ProductivityThreshold is the percentage number that a delegate's productivity needs to reach not to get probation
 		ProductivityThreshold uint64 `yaml:"productivityThreshold"`
+		// TreasuryAddrStr is the address in encoded string format to receive the treasury share of base fee
+		TreasuryAddrStr string `yaml:"treasuryAddress"`
+		// TreasuryBaseFeePercentage is the percentage of base fee redirected to the treasury address
+		TreasuryBaseFeePercentage uint64 `yaml:"treasuryBaseFeePercentage"`
+		// RewardSchedules is the table of block and epoch reward amounts in increasing order of height
+		RewardSchedules []RewardSchedule `yaml:"rewardSchedules"`
+	}
+	// RewardSchedule sets the block and epoch reward amounts from a height
+	RewardSchedule struct {
+		// Height is the height from which the reward amounts take effect
+		Height uint64 `yaml:"height"`
+		// BlockRewardStr is the block reward amount in decimal string format, unchanged if empty
+		BlockRewardStr string `yaml:"blockReward"`
+		// EpochRewardStr is the epoch reward amount in decimal string format, unchanged if empty
+		EpochRewardStr string `yaml:"epochReward"`
+		// DecayInterval is the number of blocks between two decays of the reward amounts, no decay if 0
+		DecayInterval uint64 `yaml:"decayInterval"`
+		// DecayPercentage is the percentage the reward amounts set by the schedule decrease by at each decay
+		DecayPercentage uint64 `yaml:"decayPercentage"`
 	}
 	// Staking contains the configs for staking protocol
 	Staking struct {
@@ -501,6 +558,12 @@ func New(genesisPath string) (Genesis, error) {
 	if err := yaml.Get(config.Root).Populate(&genesis); err != nil {
 		return Genesis{}, errors.Wrap(err, "failed to unmarshal yaml genesis to struct")
 	}
+	if err := genesis.Rewarding.validateTreasury(); err != nil {
+		return Genesis{}, errors.Wrap(err, "invalid treasury of rewarding protocol")
+	}
+	if err := genesis.Blockchain.validateAdaptiveBlockInterval(); err != nil {
+		return Genesis{}, errors.Wrap(err, "invalid adaptive block interval")
+	}
 	return genesis, nil
 }
 
@@ -729,6 +792,44 @@ func (g *Blockchain) BlockGasLimitByHeight(height uint64) uint64 {
 	return g.BlockGasLimit
 }
 
+// Enabled returns true if the adaptive block interval is enabled
+func (a *AdaptiveBlockInterval) Enabled() bool {
+	return a.MinInterval > 0
+}
+
+// IsActive returns true if the block interval is adaptive at the height
+func (a *AdaptiveBlockInterval) IsActive(height uint64) bool {
+	return a.Enabled() && height >= a.Height
+}
+
+// Validate checks that the block interval at the fork height is a multiple of the min interval and within the bounds
+func (a *AdaptiveBlockInterval) Validate(blockInterval time.Duration) error {
+	if !a.Enabled() {
+		return nil
+	}
+	if a.Height == 0 {
+		return errors.New("fork height should be set")
+	}
+	if a.MaxInterval < blockInterval || blockInterval < a.MinInterval {
+		return errors.Errorf("block interval %s is out of the bounds [%s, %s]", blockInterval, a.MinInterval, a.MaxInterval)
+	}
+	if blockInterval%a.MinInterval != 0 || a.MaxInterval%a.MinInterval != 0 {
+		return errors.Errorf("block interval %s and max interval %s should be multiples of min interval %s", blockInterval, a.MaxInterval, a.MinInterval)
+	}
+	if a.BacklogGasThreshold == 0 {
+		return errors.New("backlog gas threshold should be positive")
+	}
+	return nil
+}
+
+func (g *Blockchain) validateAdaptiveBlockInterval() error {
+	a := g.AdaptiveBlockInterval
+	if a.Enabled() && a.Height < g.DardanellesBlockHeight {
+		return errors.Errorf("fork height %d is earlier than dardanelles height %d", a.Height, g.DardanellesBlockHeight)
+	}
+	return nil
+}
+
 // IsDeployerWhitelisted returns if the replay deployer is whitelisted
 func (a *Account) IsDeployerWhitelisted(deployer address.Address) bool {
 	for _, v := range a.ReplayDeployerWhitelist {
@@ -850,6 +951,42 @@ func (r *Rewarding) DardanellesBlockReward() *big.Int {
 	return val
 }
 
+// RewardScheduleAt returns the reward schedule in effect at the height, nil if there is none
+func (r *Rewarding) RewardScheduleAt(height uint64) *RewardSchedule {
+	var schedule *RewardSchedule
+	for i := range r.RewardSchedules {
+		if r.RewardSchedules[i].Height > height {
+			break
+		}
+		schedule = &r.RewardSchedules[i]
+	}
+	return schedule
+}
+
+// BlockReward returns the block reward amount set by the schedule, nil if it is unchanged
+func (s *RewardSchedule) BlockReward() *big.Int {
+	if s.BlockRewardStr == "" {
+		return nil
+	}
+	val, ok := new(big.Int).SetString(s.BlockRewardStr, 10)
+	if !ok {
+		log.S().Panicf("Error when casting scheduled block reward string %s into big int", s.BlockRewardStr)
+	}
+	return val
+}
+
+// EpochReward returns the epoch reward amount set by the schedule, nil if it is unchanged
+func (s *RewardSchedule) EpochReward() *big.Int {
+	if s.EpochRewardStr == "" {
+		return nil
+	}
+	val, ok := new(big.Int).SetString(s.EpochRewardStr, 10)
+	if !ok {
+		log.S().Panicf("Error when casting scheduled epoch reward string %s into big int", s.EpochRewardStr)
+	}
+	return val
+}
+
 // ExemptAddrsFromEpochReward returns the list of addresses that exempt from epoch reward
 func (r *Rewarding) ExemptAddrsFromEpochReward() []address.Address {
 	addrs := make([]address.Address, 0)
@@ -863,6 +1000,26 @@ func (r *Rewarding) ExemptAddrsFromEpochReward() []address.Address {
 	return addrs
 }
 
+// TreasuryAddr returns the treasury address, nil if the treasury is not set
+func (r *Rewarding) TreasuryAddr() (address.Address, error) {
+	if r.TreasuryAddrStr == "" {
+		return nil, nil
+	}
+	addr, err := address.FromString(r.TreasuryAddrStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the rewarding protocol treasury address")
+	}
+	return addr, nil
+}
+
+func (r *Rewarding) validateTreasury() error {
+	if r.TreasuryBaseFeePercentage > 100 {
+		return errors.Errorf("treasury base fee percentage %d exceeds 100", r.TreasuryBaseFeePercentage)
+	}
+	_, err := r.TreasuryAddr()
+	return err
+}
+
 // FoundationBonus returns the bootstrap bonus amount rewarded per epoch
 func (r *Rewarding) FoundationBonus() *big.Int {
 	val, ok := new(big.Int).SetString(r.FoundationBonusStr, 10)
@@ -7,7 +7,9 @@ package genesis
 
 import (
 	"encoding/hex"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -105,6 +107,44 @@ func TestTsunamiBlockGasLimit(t *testing.T) {
 	}
 }
 
+func TestAdaptiveBlockInterval(t *testing.T) {
+	r := require.New(t)
+
+	a := AdaptiveBlockInterval{}
+	r.False(a.Enabled())
+	r.NoError(a.Validate(5 * time.Second))
+	r.False(a.IsActive(1))
+	a = AdaptiveBlockInterval{
+		MinInterval:         time.Second,
+		MaxInterval:         10 * time.Second,
+		BacklogGasThreshold: 10000000,
+	}
+	r.True(a.Enabled())
+	r.ErrorContains(a.Validate(5*time.Second), "fork height should be set")
+	a.Height = 100
+	r.False(a.IsActive(99))
+	r.True(a.IsActive(100))
+	r.NoError(a.Validate(5 * time.Second))
+	r.Error(a.Validate(11 * time.Second))
+	r.Error(a.Validate(1500 * time.Millisecond))
+	a.MinInterval = 2 * time.Second
+	r.Error(a.Validate(time.Second))
+	r.Error(a.Validate(5 * time.Second))
+	a.BacklogGasThreshold = 0
+	r.Error(a.Validate(4 * time.Second))
+
+	g := TestDefault()
+	g.AdaptiveBlockInterval = AdaptiveBlockInterval{
+		Height:              g.DardanellesBlockHeight - 1,
+		MinInterval:         time.Second,
+		MaxInterval:         10 * time.Second,
+		BacklogGasThreshold: 10000000,
+	}
+	r.ErrorContains(g.validateAdaptiveBlockInterval(), "earlier than dardanelles height")
+	g.AdaptiveBlockInterval.Height = g.DardanellesBlockHeight
+	r.NoError(g.validateAdaptiveBlockInterval())
+}
+
 func TestDeployerWhitelist(t *testing.T) {
 	r := require.New(t)
 
@@ -142,3 +182,51 @@ func TestDeployerWhitelist(t *testing.T) {
 		runTest(&g)
 	})
 }
+
+func TestRewardScheduleAt(t *testing.T) {
+	r := require.New(t)
+	g := TestDefault()
+	r.Nil(g.RewardScheduleAt(100))
+
+	g.RewardSchedules = []RewardSchedule{
+		{Height: 100, BlockRewardStr: "20"},
+		{Height: 200, EpochRewardStr: "1000", DecayInterval: 10, DecayPercentage: 10},
+	}
+	r.Nil(g.RewardScheduleAt(99))
+	for _, height := range []uint64{100, 199} {
+		s := g.RewardScheduleAt(height)
+		r.Equal(uint64(100), s.Height)
+		r.Equal(big.NewInt(20), s.BlockReward())
+		r.Nil(s.EpochReward())
+	}
+	s := g.RewardScheduleAt(1000)
+	r.Equal(uint64(200), s.Height)
+	r.Nil(s.BlockReward())
+	r.Equal(big.NewInt(1000), s.EpochReward())
+}
+
+func TestTreasury(t *testing.T) {
+	r := require.New(t)
+
+	rp := Default.Rewarding
+	addr, err := rp.TreasuryAddr()
+	r.NoError(err)
+	r.Nil(addr)
+	r.NoError(rp.validateTreasury())
+
+	rp.TreasuryAddrStr = "io18743s33zmsvmvyynfxu5sy2f80e2g5mzk3y5ue"
+	rp.TreasuryBaseFeePercentage = 100
+	addr, err = rp.TreasuryAddr()
+	r.NoError(err)
+	r.Equal(rp.TreasuryAddrStr, addr.String())
+	r.NoError(rp.validateTreasury())
+
+	rp.TreasuryBaseFeePercentage = 101
+	r.ErrorContains(rp.validateTreasury(), "exceeds 100")
+
+	rp.TreasuryBaseFeePercentage = 20
+	rp.TreasuryAddrStr = "invalid"
+	_, err = rp.TreasuryAddr()
+	r.Error(err)
+	r.Error(rp.validateTreasury())
+}
@@ -0,0 +1,285 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package invariant checks the chain-wide invariants over a height range of a live or offline chain db, which are
+//   - supply: no token is minted by any transaction log, and the burned amount is reported
+//   - rewardingFund: the total balance of rewarding fund covers the unclaimed balance, and its change over the range
+//     equals the deposits minus the claims in transaction logs
+//   - bucketPool: the bucket pool equals the sum of native buckets, and its change over the range equals the
+//     stakes minus the withdrawals in transaction logs
+//   - candidateVotes: the votes and self-stake of candidates equal those recalculated from native buckets
+package invariant
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// Status is the status of a check
+type Status string
+
+// Invariants and status
+const (
+	Supply         = "supply"
+	RewardingFund  = "rewardingFund"
+	BucketPool     = "bucketPool"
+	CandidateVotes = "candidateVotes"
+
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+
+	// _maxDetails is the max number of violations listed in the detail of a result
+	_maxDetails = 10
+)
+
+type (
+	// TransactionLogReader reads the transaction logs of a block
+	TransactionLogReader interface {
+		TransactionLogs(uint64) (*iotextypes.TransactionLogs, error)
+	}
+
+	// StateReaderAt returns the state reader at the height, it should return error if the state at the height is not
+	// available, e.g., a history height of a state db not in archive mode
+	StateReaderAt func(context.Context, uint64) (protocol.StateReader, error)
+
+	// Result is the result of an invariant check
+	Result struct {
+		Name   string `json:"name"`
+		Status Status `json:"status"`
+		Detail string `json:"detail,omitempty"`
+	}
+
+	// Report is the machine-readable report of the checks over a height range
+	Report struct {
+		Start   uint64    `json:"start"`
+		End     uint64    `json:"end"`
+		Passed  int       `json:"passed"`
+		Failed  int       `json:"failed"`
+		Skipped int       `json:"skipped"`
+		Results []*Result `json:"results"`
+	}
+
+	// Checker checks the invariants
+	Checker struct {
+		g             genesis.Genesis
+		logs          TransactionLogReader
+		states        StateReaderAt
+		reward        *rewarding.Protocol
+		protocolAddrs []string
+	}
+
+	// flows are the net inflows of protocol accounts in transaction logs
+	flows map[string]*big.Int
+)
+
+// NewChecker creates a new invariant checker
+func NewChecker(g genesis.Genesis, logs TransactionLogReader, states StateReaderAt) *Checker {
+	return &Checker{
+		g:             g,
+		logs:          logs,
+		states:        states,
+		reward:        rewarding.NewProtocol(g.Rewarding),
+		protocolAddrs: []string{address.RewardingPoolAddr, address.StakingBucketPoolAddr},
+	}
+}
+
+// OK returns true if no check fails
+func (r *Report) OK() bool {
+	return r.Failed == 0
+}
+
+func (r *Report) add(name string, status Status, detail string) {
+	r.Results = append(r.Results, &Result{Name: name, Status: status, Detail: detail})
+	switch status {
+	case StatusPass:
+		r.Passed++
+	case StatusFail:
+		r.Failed++
+	default:
+		r.Skipped++
+	}
+}
+
+// Check checks the invariants over the blocks in [start, end], the states at start-1 and end are compared
+func (c *Checker) Check(ctx context.Context, start, end uint64) (*Report, error) {
+	if start == 0 {
+		start = 1
+	}
+	if start > end {
+		return nil, errors.Errorf("invalid height range [%d, %d]", start, end)
+	}
+	report := &Report{Start: start, End: end}
+	f := c.checkSupply(report, start, end)
+
+	prev, prevErr := c.states(ctx, start-1)
+	curr, err := c.states(ctx, end)
+	if err != nil {
+		detail := fmt.Sprintf("state at height %d is not available: %v", end, err)
+		for _, name := range []string{RewardingFund, BucketPool, CandidateVotes} {
+			report.add(name, StatusSkip, detail)
+		}
+		return report, nil
+	}
+	if prevErr != nil {
+		prev = nil
+	}
+	c.checkRewardingFund(ctx, report, prev, curr, start-1, end, f)
+	c.checkBucketPool(report, prev, curr, f)
+	if err := staking.CheckCandidateVotes(curr, c.g.Staking.VoteWeightCalConsts); err != nil {
+		report.add(CandidateVotes, statusOf(err), err.Error())
+	} else {
+		report.add(CandidateVotes, StatusPass, "")
+	}
+	return report, nil
+}
+
+// checkSupply checks no token is minted in transaction logs, and returns the net inflows of protocol accounts, which
+// is nil if transaction logs are not available
+func (c *Checker) checkSupply(report *Report, start, end uint64) flows {
+	var (
+		f          = make(flows, len(c.protocolAddrs))
+		burned     = big.NewInt(0)
+		violations []string
+		count      int
+	)
+	for _, addr := range c.protocolAddrs {
+		f[addr] = big.NewInt(0)
+	}
+	for h := start; h <= end; h++ {
+		logs, err := c.logs.TransactionLogs(h)
+		if err != nil {
+			report.add(Supply, StatusSkip, fmt.Sprintf("transaction logs at height %d are not available: %v", h, err))
+			return nil
+		}
+		for _, l := range logs.GetLogs() {
+			for _, tx := range l.GetTransactions() {
+				amount, ok := new(big.Int).SetString(tx.GetAmount(), 10)
+				switch {
+				case !ok || amount.Sign() < 0:
+					count++
+					violations = appendDetail(violations, fmt.Sprintf("height %d action %x has invalid amount %s", h, l.GetActionHash(), tx.GetAmount()))
+					continue
+				case tx.GetSender() == "":
+					count++
+					violations = appendDetail(violations, fmt.Sprintf("height %d action %x mints %s", h, l.GetActionHash(), amount))
+					continue
+				}
+				if tx.GetRecipient() == "" {
+					burned.Add(burned, amount)
+				}
+				if in, ok := f[tx.GetRecipient()]; ok {
+					in.Add(in, amount)
+				}
+				if out, ok := f[tx.GetSender()]; ok {
+					out.Sub(out, amount)
+				}
+			}
+		}
+	}
+	if count > 0 {
+		report.add(Supply, StatusFail, fmt.Sprintf("%d violations: %s", count, strings.Join(violations, "; ")))
+		return f
+	}
+	report.add(Supply, StatusPass, fmt.Sprintf("burned %s", burned))
+	return f
+}
+
+func (c *Checker) checkRewardingFund(ctx context.Context, report *Report, prev, curr protocol.StateReader, prevHeight, currHeight uint64, f flows) {
+	total, unclaimed, err := c.fund(ctx, curr, currHeight)
+	if err != nil {
+		report.add(RewardingFund, statusOf(err), err.Error())
+		return
+	}
+	if total.Cmp(unclaimed) < 0 {
+		report.add(RewardingFund, StatusFail, fmt.Sprintf("total balance %s is less than unclaimed balance %s", total, unclaimed))
+		return
+	}
+	if prev == nil || f == nil {
+		report.add(RewardingFund, StatusPass, "change over range is not checked")
+		return
+	}
+	prevTotal, _, err := c.fund(ctx, prev, prevHeight)
+	switch {
+	case errors.Cause(err) == state.ErrStateNotExist:
+		prevTotal = big.NewInt(0)
+	case err != nil:
+		report.add(RewardingFund, StatusSkip, err.Error())
+		return
+	}
+	if diff := new(big.Int).Sub(total, prevTotal); diff.Cmp(f[address.RewardingPoolAddr]) != 0 {
+		report.add(RewardingFund, StatusFail, fmt.Sprintf("total balance changes %s, transaction logs move %s", diff, f[address.RewardingPoolAddr]))
+		return
+	}
+	report.add(RewardingFund, StatusPass, "")
+}
+
+func (c *Checker) checkBucketPool(report *Report, prev, curr protocol.StateReader, f flows) {
+	if err := staking.CheckBucketPool(curr); err != nil {
+		report.add(BucketPool, statusOf(err), err.Error())
+		return
+	}
+	if prev == nil || f == nil {
+		report.add(BucketPool, StatusPass, "change over range is not checked")
+		return
+	}
+	prevTotal, _, err := staking.BucketPoolTotal(prev)
+	if err != nil {
+		// bucket pool is stored since Greenland, the change can only be checked after that
+		report.add(BucketPool, StatusPass, "change over range is not checked")
+		return
+	}
+	total, _, err := staking.BucketPoolTotal(curr)
+	if err != nil {
+		report.add(BucketPool, StatusSkip, err.Error())
+		return
+	}
+	if diff := new(big.Int).Sub(total, prevTotal); diff.Cmp(f[address.StakingBucketPoolAddr]) != 0 {
+		report.add(BucketPool, StatusFail, fmt.Sprintf("bucket pool changes %s, transaction logs move %s", diff, f[address.StakingBucketPoolAddr]))
+		return
+	}
+	report.add(BucketPool, StatusPass, "")
+}
+
+func (c *Checker) fund(ctx context.Context, sr protocol.StateReader, height uint64) (*big.Int, *big.Int, error) {
+	ctx = genesis.WithGenesisContext(ctx, c.g)
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height}))
+	total, _, err := c.reward.TotalBalance(ctx, sr)
+	if err != nil {
+		return nil, nil, err
+	}
+	unclaimed, _, err := c.reward.AvailableBalance(ctx, sr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return total, unclaimed, nil
+}
+
+// statusOf returns fail if the error is a violation, otherwise the check is skipped
+func statusOf(err error) Status {
+	if errors.Cause(err) == staking.ErrInvariantViolated {
+		return StatusFail
+	}
+	return StatusSkip
+}
+
+func appendDetail(details []string, detail string) []string {
+	if len(details) >= _maxDetails {
+		return details
+	}
+	return append(details, detail)
+}
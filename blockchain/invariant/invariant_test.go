@@ -0,0 +1,119 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package invariant
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+type txLogs map[uint64]*iotextypes.TransactionLogs
+
+func (l txLogs) TransactionLogs(height uint64) (*iotextypes.TransactionLogs, error) {
+	if logs, ok := l[height]; ok {
+		return logs, nil
+	}
+	return nil, errors.Wrapf(db.ErrNotExist, "height %d", height)
+}
+
+func TestChecker(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+
+	a, b := identityset.Address(1).String(), identityset.Address(2).String()
+	newLogs := func(txs ...*iotextypes.TransactionLog_Transaction) *iotextypes.TransactionLogs {
+		return &iotextypes.TransactionLogs{
+			Logs: []*iotextypes.TransactionLog{
+				{ActionHash: []byte{1}, NumTransactions: uint64(len(txs)), Transactions: txs},
+			},
+		}
+	}
+	logs := txLogs{
+		1: newLogs(
+			&iotextypes.TransactionLog_Transaction{Amount: "10", Sender: a, Recipient: b},
+			&iotextypes.TransactionLog_Transaction{Amount: "1", Sender: a, Recipient: address.RewardingPoolAddr},
+			&iotextypes.TransactionLog_Transaction{Amount: "2", Sender: a, Recipient: ""},
+		),
+		2: newLogs(
+			&iotextypes.TransactionLog_Transaction{Amount: "5", Sender: a, Recipient: address.StakingBucketPoolAddr},
+			&iotextypes.TransactionLog_Transaction{Amount: "3", Sender: address.StakingBucketPoolAddr, Recipient: b},
+		),
+		3: newLogs(
+			&iotextypes.TransactionLog_Transaction{Amount: "7", Sender: "", Recipient: b},
+			&iotextypes.TransactionLog_Transaction{Amount: "-1", Sender: a, Recipient: b},
+		),
+	}
+	var unavailable error
+	c := NewChecker(genesis.TestDefault(), logs, func(_ context.Context, height uint64) (protocol.StateReader, error) {
+		if unavailable != nil {
+			return nil, unavailable
+		}
+		return sm, nil
+	})
+
+	// flows of protocol accounts
+	report := &Report{}
+	f := c.checkSupply(report, 1, 2)
+	require.Equal(big.NewInt(1), f[address.RewardingPoolAddr])
+	require.Equal(big.NewInt(2), f[address.StakingBucketPoolAddr])
+	require.Equal(&Result{Name: Supply, Status: StatusPass, Detail: "burned 2"}, report.Results[0])
+
+	// empty states: the funds are not created yet, while no candidate is consistent
+	report, err := c.Check(context.Background(), 0, 2)
+	require.NoError(err)
+	require.EqualValues(1, report.Start)
+	require.True(report.OK())
+	require.Equal(2, report.Passed)
+	require.Equal(2, report.Skipped)
+	status := map[string]Status{}
+	for _, r := range report.Results {
+		status[r.Name] = r.Status
+	}
+	require.Equal(map[string]Status{
+		Supply:         StatusPass,
+		RewardingFund:  StatusSkip,
+		BucketPool:     StatusSkip,
+		CandidateVotes: StatusPass,
+	}, status)
+
+	// minting and invalid amount are violations
+	report, err = c.Check(context.Background(), 3, 3)
+	require.NoError(err)
+	require.False(report.OK())
+	require.Equal(StatusFail, report.Results[0].Status)
+	require.Contains(report.Results[0].Detail, "2 violations")
+	require.Contains(report.Results[0].Detail, "mints 7")
+
+	// missing transaction logs and states are skipped
+	unavailable = errors.New("archive mode is not enabled")
+	report, err = c.Check(context.Background(), 1, 4)
+	require.NoError(err)
+	require.True(report.OK())
+	require.Equal(4, report.Skipped)
+
+	// the report is machine-readable
+	data, err := json.Marshal(report)
+	require.NoError(err)
+	require.Contains(string(data), `"name":"candidateVotes","status":"skip"`)
+
+	_, err = c.Check(context.Background(), 3, 2)
+	require.Error(err)
+}
@@ -0,0 +1,426 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// the NS/bucket name here are used in balance.index.db, the balance history and the transfers of an account are kept
+// in the counting index named by the prefix followed by the address bytes
+const (
+	_balanceMetaNS         = "bm"
+	_balanceJournalNS      = "bj"
+	_balanceHistoryPrefix  = "bh"
+	_balanceTransferPrefix = "bt"
+)
+
+type (
+	// BalanceIndexer is the interface for the indexer of account balance history, which accumulates the balance
+	// deltas of the accounts from the transaction logs of each block
+	BalanceIndexer interface {
+		blockdao.BlockIndexerWithRollback
+		// BalanceAt returns the balance of the account after the block at the height
+		BalanceAt(address.Address, uint64) (*big.Int, error)
+		// TransferCount returns the number of transfers of the account
+		TransferCount(address.Address) (uint64, error)
+		// Transfers returns the transfers of the account [start, start+count), from the oldest to the latest
+		Transfers(address.Address, uint64, uint64) ([]*BalanceTransfer, error)
+	}
+
+	// BalanceTransfer is a transaction log which changes the balance of an account
+	BalanceTransfer struct {
+		Height     uint64
+		ActionHash hash.Hash256
+		Type       iotextypes.TransactionLogType
+		Sender     string
+		Recipient  string
+		Amount     *big.Int
+	}
+
+	// balanceIndexer implements the BalanceIndexer interface
+	balanceIndexer struct {
+		mutex        sync.RWMutex
+		kvStore      db.KVStore
+		journal      *db.Journal
+		height       uint64
+		initAddrs    []address.Address
+		initBalances []*big.Int
+	}
+)
+
+// NewBalanceIndexer creates a new balance indexer, the history of the accounts starts from their initial balances
+// of genesis
+func NewBalanceIndexer(kv db.KVStore, initAddrs []address.Address, initBalances []*big.Int) (BalanceIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	if _, ok := kv.(db.KVStoreWithRange); !ok {
+		return nil, errors.New("balance indexer can only be created from KVStoreWithRange")
+	}
+	if len(initAddrs) != len(initBalances) {
+		return nil, errors.Errorf("%d initial balances mismatch %d addresses", len(initBalances), len(initAddrs))
+	}
+	return &balanceIndexer{
+		kvStore:      kv,
+		journal:      db.NewJournal(kv, _balanceJournalNS, db.DefaultJournalDepth),
+		initAddrs:    initAddrs,
+		initBalances: initBalances,
+	}, nil
+}
+
+// Start starts the balance indexer
+func (x *balanceIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	value, err := x.kvStore.Get(_balanceMetaNS, []byte(CurrentHeightKey))
+	switch errors.Cause(err) {
+	case nil:
+		x.height = byteutil.BytesToUint64BigEndian(value)
+		return nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return x.initBalance()
+	default:
+		return err
+	}
+}
+
+// Stop stops the balance indexer
+func (x *balanceIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the balance indexer
+func (x *balanceIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// PutBlock indexes the balance deltas and the transfers of the block
+func (x *balanceIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	height := blk.Height()
+	if height <= x.height {
+		return nil
+	}
+	if height != x.height+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height+1)
+	}
+	var (
+		b         = batch.NewBatch()
+		deltas    = make(map[string]*big.Int)
+		transfers = make(map[string][]*BalanceTransfer)
+		addDelta  = func(addr string, amount *big.Int) {
+			if _, ok := deltas[addr]; !ok {
+				deltas[addr] = new(big.Int)
+			}
+			deltas[addr].Add(deltas[addr], amount)
+		}
+	)
+	for _, receipt := range blk.Receipts {
+		for _, l := range receipt.TransactionLogs() {
+			if l.Amount == nil || l.Amount.Sign() == 0 {
+				continue
+			}
+			t := &BalanceTransfer{
+				Height:     height,
+				ActionHash: receipt.ActionHash,
+				Type:       l.Type,
+				Sender:     l.Sender,
+				Recipient:  l.Recipient,
+				Amount:     l.Amount,
+			}
+			var senderKey string
+			if sender, err := address.FromString(l.Sender); err == nil {
+				senderKey = string(sender.Bytes())
+				addDelta(senderKey, new(big.Int).Neg(l.Amount))
+				transfers[senderKey] = append(transfers[senderKey], t)
+			}
+			// the recipient is empty if the amount is burned
+			if recipient, err := address.FromString(l.Recipient); err == nil {
+				recipientKey := string(recipient.Bytes())
+				addDelta(recipientKey, l.Amount)
+				if recipientKey != senderKey {
+					transfers[recipientKey] = append(transfers[recipientKey], t)
+				}
+			}
+		}
+	}
+	for _, addr := range sortedKeys(transfers) {
+		index, err := db.NewCountingIndexNX(x.kvStore, prefixBucket(_balanceTransferPrefix, []byte(addr)))
+		if err != nil {
+			return err
+		}
+		if err := index.UseBatch(b); err != nil {
+			return err
+		}
+		for _, t := range transfers[addr] {
+			if err := index.Add(t.Serialize(), true); err != nil {
+				return err
+			}
+		}
+		if err := index.Finalize(); err != nil {
+			return err
+		}
+	}
+	for _, addr := range sortedKeys(deltas) {
+		if deltas[addr].Sign() == 0 {
+			continue
+		}
+		if err := x.addBalance(b, []byte(addr), height, deltas[addr]); err != nil {
+			return err
+		}
+	}
+	b.Put(_balanceMetaNS, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(height), "failed to put current height")
+	if err := x.journal.Record(b, height, blk.HashBlock()); err != nil {
+		return err
+	}
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = height
+	return nil
+}
+
+// Checkpoint returns the hash of the indexed block at the height
+func (x *balanceIndexer) Checkpoint(height uint64) (hash.Hash256, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.journal.Checkpoint(height)
+}
+
+// Rollback reverts the balance index to the height
+func (x *balanceIndexer) Rollback(_ context.Context, height uint64) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	if height >= x.height {
+		return nil
+	}
+	if err := x.journal.Revert(x.height, height); err != nil {
+		return errors.Wrapf(err, "failed to roll back balance index from %d to %d", x.height, height)
+	}
+	x.height = height
+	return nil
+}
+
+// BalanceAt returns the balance of the account after the block at the height
+func (x *balanceIndexer) BalanceAt(addr address.Address, height uint64) (*big.Int, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	if height > x.height {
+		return nil, errors.Wrapf(db.ErrNotExist, "height %d is higher than balance index height %d", height, x.height)
+	}
+	index, err := db.GetCountingIndex(x.kvStore, prefixBucket(_balanceHistoryPrefix, addr.Bytes()))
+	if err != nil {
+		if errors.Cause(err) == db.ErrBucketNotExist || errors.Cause(err) == db.ErrNotExist {
+			return big.NewInt(0), nil
+		}
+		return nil, err
+	}
+	// find the last change of the balance at or before the height
+	var searchErr error
+	i := sort.Search(int(index.Size()), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		h, _, err := getBalanceEntry(index, uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return h > height
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if i == 0 {
+		return big.NewInt(0), nil
+	}
+	_, balance, err := getBalanceEntry(index, uint64(i-1))
+	return balance, err
+}
+
+// TransferCount returns the number of transfers of the account
+func (x *balanceIndexer) TransferCount(addr address.Address) (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	index, err := db.GetCountingIndex(x.kvStore, prefixBucket(_balanceTransferPrefix, addr.Bytes()))
+	if err != nil {
+		if errors.Cause(err) == db.ErrBucketNotExist || errors.Cause(err) == db.ErrNotExist {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return index.Size(), nil
+}
+
+// Transfers returns the transfers of the account [start, start+count)
+func (x *balanceIndexer) Transfers(addr address.Address, start, count uint64) ([]*BalanceTransfer, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	index, err := db.GetCountingIndex(x.kvStore, prefixBucket(_balanceTransferPrefix, addr.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	total := index.Size()
+	if start >= total {
+		return nil, errors.Wrapf(db.ErrInvalid, "start = %d >= total = %d", start, total)
+	}
+	if start+count > total {
+		count = total - start
+	}
+	values, err := index.Range(start, count)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*BalanceTransfer, 0, len(values))
+	for _, v := range values {
+		t, err := deserializeBalanceTransfer(v)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+// initBalance writes the initial balances of genesis at height 0
+func (x *balanceIndexer) initBalance() error {
+	b := batch.NewBatch()
+	for i, addr := range x.initAddrs {
+		if x.initBalances[i].Sign() == 0 {
+			continue
+		}
+		if err := x.addBalance(b, addr.Bytes(), 0, x.initBalances[i]); err != nil {
+			return err
+		}
+	}
+	b.Put(_balanceMetaNS, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(0), "failed to put current height")
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = 0
+	return nil
+}
+
+// addBalance adds the delta to the latest balance of the account, and puts the balance at the height into the batch
+func (x *balanceIndexer) addBalance(b batch.KVStoreBatch, addr []byte, height uint64, delta *big.Int) error {
+	index, err := db.NewCountingIndexNX(x.kvStore, prefixBucket(_balanceHistoryPrefix, addr))
+	if err != nil {
+		return err
+	}
+	balance := new(big.Int)
+	if size := index.Size(); size > 0 {
+		if _, balance, err = getBalanceEntry(index, size-1); err != nil {
+			return err
+		}
+	}
+	balance.Add(balance, delta)
+	if err := index.UseBatch(b); err != nil {
+		return err
+	}
+	if err := index.Add(serializeBalanceEntry(height, balance), true); err != nil {
+		return err
+	}
+	return index.Finalize()
+}
+
+func prefixBucket(prefix string, addr []byte) []byte {
+	return append([]byte(prefix), addr...)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// serializeBalanceEntry encodes the height, the sign and the absolute value of the balance
+func serializeBalanceEntry(height uint64, balance *big.Int) []byte {
+	data := byteutil.Uint64ToBytesBigEndian(height)
+	if balance.Sign() < 0 {
+		data = append(data, 1)
+	} else {
+		data = append(data, 0)
+	}
+	return append(data, balance.Bytes()...)
+}
+
+func getBalanceEntry(index db.CountingIndex, slot uint64) (uint64, *big.Int, error) {
+	data, err := index.Get(slot)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) < 9 {
+		return 0, nil, errors.Wrapf(db.ErrInvalid, "invalid balance entry %d", slot)
+	}
+	balance := new(big.Int).SetBytes(data[9:])
+	if data[8] == 1 {
+		balance.Neg(balance)
+	}
+	return byteutil.BytesToUint64BigEndian(data[:8]), balance, nil
+}
+
+// Serialize encodes the transfer into bytes
+func (t *BalanceTransfer) Serialize() []byte {
+	data := byteutil.Uint64ToBytesBigEndian(t.Height)
+	data = append(data, t.ActionHash[:]...)
+	data = binary.AppendUvarint(data, uint64(t.Type))
+	for _, v := range [][]byte{[]byte(t.Sender), []byte(t.Recipient)} {
+		data = binary.AppendUvarint(data, uint64(len(v)))
+		data = append(data, v...)
+	}
+	return append(data, t.Amount.Bytes()...)
+}
+
+func deserializeBalanceTransfer(data []byte) (*BalanceTransfer, error) {
+	if len(data) < 8+len(hash.ZeroHash256) {
+		return nil, errors.Wrap(db.ErrInvalid, "invalid transfer")
+	}
+	t := &BalanceTransfer{
+		Height:     byteutil.BytesToUint64BigEndian(data[:8]),
+		ActionHash: hash.BytesToHash256(data[8 : 8+len(hash.ZeroHash256)]),
+	}
+	data = data[8+len(hash.ZeroHash256):]
+	typ, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errors.Wrap(db.ErrInvalid, "invalid transfer type")
+	}
+	t.Type = iotextypes.TransactionLogType(typ)
+	data = data[n:]
+	var fields [2]string
+	for i := range fields {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return nil, errors.Wrap(db.ErrInvalid, "invalid transfer address")
+		}
+		fields[i], data = string(data[n:n+int(size)]), data[n+int(size):]
+	}
+	t.Sender, t.Recipient = fields[0], fields[1]
+	t.Amount = new(big.Int).SetBytes(data)
+	return t, nil
+}
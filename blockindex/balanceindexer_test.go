@@ -0,0 +1,150 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestBalanceIndexer(t *testing.T) {
+	require := require.New(t)
+
+	testPath, err := testutil.PathOfTempFile("test-balance-indexer")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	dbCfg := db.DefaultConfig
+	dbCfg.DbPath = testPath
+
+	var (
+		ctx      = context.Background()
+		alice    = identityset.Address(1)
+		bob      = identityset.Address(2)
+		carol    = identityset.Address(3)
+		newBlock = func(height uint64, logs ...*action.TransactionLog) *block.Block {
+			receipt := &action.Receipt{
+				ActionHash: hash.Hash256b([]byte{byte(height)}),
+			}
+			receipt.AddTransactionLogs(logs...)
+			blk, err := block.NewTestingBuilder().
+				SetHeight(height).
+				SetReceipts([]*action.Receipt{receipt}).
+				SignAndBuild(identityset.PrivateKey(27))
+			require.NoError(err)
+			return &blk
+		}
+		transfer = func(typ iotextypes.TransactionLogType, sender, recipient string, amount int64) *action.TransactionLog {
+			return &action.TransactionLog{
+				Type:      typ,
+				Sender:    sender,
+				Recipient: recipient,
+				Amount:    big.NewInt(amount),
+			}
+		}
+	)
+	blks := []*block.Block{
+		newBlock(1,
+			transfer(iotextypes.TransactionLogType_NATIVE_TRANSFER, alice.String(), bob.String(), 30),
+			transfer(iotextypes.TransactionLogType_GAS_FEE, alice.String(), "", 1),
+		),
+		newBlock(2),
+		newBlock(3,
+			transfer(iotextypes.TransactionLogType_NATIVE_TRANSFER, bob.String(), carol.String(), 10),
+			transfer(iotextypes.TransactionLogType_NATIVE_TRANSFER, bob.String(), bob.String(), 5),
+		),
+	}
+
+	indexer, err := NewBalanceIndexer(db.NewBoltDB(dbCfg), []address.Address{alice}, []*big.Int{big.NewInt(100)})
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	for _, blk := range blks {
+		require.NoError(indexer.PutBlock(ctx, blk))
+	}
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.EqualValues(3, height)
+
+	for _, c := range []struct {
+		addr    address.Address
+		height  uint64
+		balance int64
+	}{
+		{alice, 0, 100},
+		{alice, 1, 69},
+		{alice, 3, 69},
+		{bob, 0, 0},
+		{bob, 2, 30},
+		{bob, 3, 20},
+		{carol, 2, 0},
+		{carol, 3, 10},
+	} {
+		balance, err := indexer.BalanceAt(c.addr, c.height)
+		require.NoError(err)
+		require.Equal(big.NewInt(c.balance), balance)
+	}
+	_, err = indexer.BalanceAt(alice, 4)
+	require.ErrorIs(err, db.ErrNotExist)
+
+	count, err := indexer.TransferCount(bob)
+	require.NoError(err)
+	require.EqualValues(3, count)
+	transfers, err := indexer.Transfers(bob, 1, 5)
+	require.NoError(err)
+	require.Len(transfers, 2)
+	require.Equal(&BalanceTransfer{
+		Height:     3,
+		ActionHash: hash.Hash256b([]byte{3}),
+		Type:       iotextypes.TransactionLogType_NATIVE_TRANSFER,
+		Sender:     bob.String(),
+		Recipient:  carol.String(),
+		Amount:     big.NewInt(10),
+	}, transfers[0])
+	require.Equal(bob.String(), transfers[1].Recipient)
+	_, err = indexer.Transfers(bob, 3, 1)
+	require.ErrorIs(err, db.ErrInvalid)
+	count, err = indexer.TransferCount(identityset.Address(4))
+	require.NoError(err)
+	require.Zero(count)
+
+	// roll back and restart the indexer
+	h, err := indexer.Checkpoint(3)
+	require.NoError(err)
+	require.Equal(blks[2].HashBlock(), h)
+	require.NoError(indexer.Rollback(ctx, 1))
+	require.NoError(indexer.Stop(ctx))
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+	height, err = indexer.Height()
+	require.NoError(err)
+	require.EqualValues(1, height)
+	balance, err := indexer.BalanceAt(bob, 1)
+	require.NoError(err)
+	require.Equal(big.NewInt(30), balance)
+	count, err = indexer.TransferCount(carol)
+	require.NoError(err)
+	require.Zero(count)
+	_, err = indexer.Checkpoint(3)
+	require.ErrorIs(err, db.ErrNotExist)
+	require.NoError(indexer.PutBlock(ctx, blks[1]))
+	require.NoError(indexer.PutBlock(ctx, blks[2]))
+	balance, err = indexer.BalanceAt(carol, 3)
+	require.NoError(err)
+	require.Equal(big.NewInt(10), balance)
+}
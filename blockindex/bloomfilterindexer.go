@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/iotexproject/go-pkgs/bloom"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
@@ -28,8 +29,13 @@ const (
 	BlockBloomFilterNamespace = "BlockBloomFilters"
 	// RangeBloomFilterNamespace indicates the kvstore namespace to store range BloomFilters
 	RangeBloomFilterNamespace = "RangeBloomFilters"
+	// SegmentBloomFilterNamespace indicates the kvstore namespace to store segment BloomFilters, each of which indexes
+	// a fixed number of blocks
+	SegmentBloomFilterNamespace = "SegmentBloomFilters"
 	// CurrentHeightKey indicates the key of current bf indexer height in underlying DB
 	CurrentHeightKey = "CurrentHeight"
+	// JournalNamespace indicates the kvstore namespace to store the journal of blocks
+	JournalNamespace = "Journal"
 )
 
 const (
@@ -49,7 +55,7 @@ var (
 type (
 	// BloomFilterIndexer is the interface for bloomfilter indexer
 	BloomFilterIndexer interface {
-		blockdao.BlockIndexer
+		blockdao.BlockIndexerWithRollback
 		// RangeBloomFilterNumElements returns the number of elements that each rangeBloomfilter indexes
 		RangeBloomFilterNumElements() uint64
 		// BlockFilterByHeight returns the block-level bloomfilter which includes not only topic but also address of logs info by given block height
@@ -68,6 +74,11 @@ type (
 		currRangeBfKey      []byte
 		curRangeBloomfilter *bloomRange
 		totalRange          db.RangeIndex
+		journal             *db.Journal
+		segmentSize         uint64
+		segmentBfSize       uint64
+		segmentBfNumHash    uint64
+		curSegment          *bloomRange // nil if the current segment does not index all its blocks
 	}
 
 	jobDesc struct {
@@ -87,6 +98,11 @@ func NewBloomfilterIndexer(kv db.KVStore, cfg Config) (BloomFilterIndexer, error
 		rangeSize: cfg.RangeBloomFilterNumElements,
 		bfSize:    cfg.RangeBloomFilterSize,
 		bfNumHash: cfg.RangeBloomFilterNumHash,
+		journal:   db.NewJournal(kv, JournalNamespace, db.DefaultJournalDepth),
+
+		segmentSize:      cfg.SegmentBloomFilterNumBlocks,
+		segmentBfSize:    cfg.SegmentBloomFilterSize,
+		segmentBfNumHash: cfg.SegmentBloomFilterNumHash,
 	}, nil
 }
 
@@ -126,17 +142,44 @@ func (bfx *bloomfilterIndexer) initRangeBloomFilter(height uint64) error {
 		return err
 	}
 	if height > 0 {
-		bfx.currRangeBfKey, err = bfx.totalRange.Get(height)
+		// the next block goes into the range of height+1, which is not written yet if the range of height is full
+		bfx.currRangeBfKey, err = bfx.totalRange.Get(height + 1)
 		if err != nil {
 			return err
 		}
-		if err := bfx.loadBloomRangeFromDB(bfx.curRangeBloomfilter, bfx.currRangeBfKey); err != nil {
+		err = bfx.loadBloomRangeFromDB(bfx.curRangeBloomfilter, bfx.currRangeBfKey)
+		switch errors.Cause(err) {
+		case nil:
+		case db.ErrNotExist:
+			bfx.curRangeBloomfilter.SetStart(height + 1)
+		default:
 			return err
 		}
 	} else {
 		bfx.curRangeBloomfilter.SetStart(1)
 		bfx.currRangeBfKey = zero8Bytes
 	}
+	return bfx.initSegmentBloomFilter(height)
+}
+
+// initSegmentBloomFilter loads the current segment, which is only used if it indexes all its blocks up to the height.
+// Otherwise, e.g. the indexer is upgraded in the middle of a segment, the segment is left incomplete and never skipped
+func (bfx *bloomfilterIndexer) initSegmentBloomFilter(height uint64) error {
+	bfx.curSegment = nil
+	if bfx.segmentSize == 0 || height%bfx.segmentSize == 0 {
+		return nil
+	}
+	br, err := newBloomRange(bfx.segmentBfSize, bfx.segmentBfNumHash)
+	if err != nil {
+		return err
+	}
+	indexed, err := bfx.loadSegmentFromDB(br, bfx.segmentIndex(height))
+	if err != nil {
+		return err
+	}
+	if indexed && br.Start() == bfx.segmentStart(height) && br.End() == height {
+		bfx.curSegment = br
+	}
 	return nil
 }
 
@@ -160,8 +203,17 @@ func (bfx *bloomfilterIndexer) PutBlock(ctx context.Context, blk *block.Block) (
 	bfx.mutex.Lock()
 	defer bfx.mutex.Unlock()
 	bfx.addLogsToRangeBloomFilter(ctx, blk.Height(), blk.Receipts)
+	if bfx.segmentSize > 0 && bfx.segmentStart(blk.Height()) == blk.Height() {
+		if bfx.curSegment, err = newBloomRange(bfx.segmentBfSize, bfx.segmentBfNumHash); err != nil {
+			return err
+		}
+		bfx.curSegment.SetStart(blk.Height())
+	}
+	if bfx.curSegment != nil {
+		bfx.addLogsToBloomFilter(bfx.curSegment.BloomFilter, blk.Receipts)
+	}
 	// commit into DB and update tipHeight
-	if err := bfx.commit(blk.Height(), bfx.calculateBlockBloomFilter(ctx, blk.Receipts)); err != nil {
+	if err := bfx.commit(blk, bfx.calculateBlockBloomFilter(ctx, blk.Receipts)); err != nil {
 		return err
 	}
 	if bfx.curRangeBloomfilter.NumElements() >= bfx.rangeSize {
@@ -187,9 +239,75 @@ func (bfx *bloomfilterIndexer) DeleteTipBlock(_ context.Context, blk *block.Bloc
 		return err
 	}
 	bfx.curRangeBloomfilter = nil
+	if bfx.segmentSize > 0 {
+		// the logs of the block cannot be removed from the segment, so the segment is no longer indexed
+		if err := bfx.kvStore.Delete(SegmentBloomFilterNamespace, byteutil.Uint64ToBytesBigEndian(bfx.segmentIndex(height))); err != nil {
+			return err
+		}
+		bfx.curSegment = nil
+	}
 	return nil
 }
 
+// Checkpoint returns the hash of the indexed block at the height
+func (bfx *bloomfilterIndexer) Checkpoint(height uint64) (hash.Hash256, error) {
+	return bfx.journal.Checkpoint(height)
+}
+
+// Rollback reverts the index to the height
+func (bfx *bloomfilterIndexer) Rollback(_ context.Context, height uint64) error {
+	bfx.mutex.Lock()
+	defer bfx.mutex.Unlock()
+	tipHeight, err := bfx.Height()
+	if err != nil {
+		return err
+	}
+	if height >= tipHeight {
+		return nil
+	}
+	// make sure the blocks could be rolled back before any change
+	for h := height + 1; h <= tipHeight; h++ {
+		if _, err := bfx.journal.Checkpoint(h); err != nil {
+			return errors.Wrapf(err, "failed to get journal of block %d", h)
+		}
+	}
+	// the ranges started after the block following the height are removed from the total range, which is not written
+	// in the batch of block
+	tipIndex, err := bfx.getIndexByHeight(tipHeight)
+	if err != nil {
+		return err
+	}
+	nextIndex, err := bfx.getIndexByHeight(tipHeight + 1)
+	if err != nil {
+		return err
+	}
+	if nextIndex != tipIndex {
+		if err := bfx.totalRange.Delete(tipHeight + 1); err != nil {
+			return err
+		}
+	}
+	br, err := newBloomRange(bfx.bfSize, bfx.bfNumHash)
+	if err != nil {
+		return err
+	}
+	for idx := tipIndex; idx > 0; idx-- {
+		if err := bfx.loadBloomRangeFromDB(br, byteutil.Uint64ToBytesBigEndian(idx)); err != nil {
+			return err
+		}
+		if br.Start() <= height+1 {
+			break
+		}
+		if err := bfx.totalRange.Delete(br.Start()); err != nil {
+			return err
+		}
+	}
+	if err := bfx.journal.Revert(tipHeight, height); err != nil {
+		return errors.Wrapf(err, "failed to roll back bloomfilter index from %d to %d", tipHeight, height)
+	}
+	bfx.totalRange.Close()
+	return bfx.initRangeBloomFilter(height)
+}
+
 // RangeBloomFilterNumElements returns the number of elements that each rangeBloomfilter indexes
 func (bfx *bloomfilterIndexer) RangeBloomFilterNumElements() uint64 {
 	bfx.mutex.RLock()
@@ -222,21 +340,32 @@ func (bfx *bloomfilterIndexer) FilterBlocksInRange(l *filter.LogFilter, start, e
 	if end-start > _maxBlockRange {
 		return nil, errRangeTooLarge
 	}
-	var (
-		startIndex, endIndex uint64
-		err                  error
-	)
-	if startIndex, err = bfx.getIndexByHeight(start); err != nil {
+	candidates, err := bfx.segmentCandidates(l, start, end)
+	if err != nil {
 		return nil, err
 	}
-	if endIndex, err = bfx.getIndexByHeight(end); err != nil {
-		return nil, err
+	// only the range bloomfilters overlapping with the candidates are searched
+	var indices []uint64
+	for _, c := range candidates {
+		startIndex, err := bfx.getIndexByHeight(c[0])
+		if err != nil {
+			return nil, err
+		}
+		endIndex, err := bfx.getIndexByHeight(c[1])
+		if err != nil {
+			return nil, err
+		}
+		for idx := startIndex; idx <= endIndex; idx++ {
+			if n := len(indices); n == 0 || indices[n-1] < idx {
+				indices = append(indices, idx)
+			}
+		}
 	}
 
 	var (
 		ctx, cancel = context.WithTimeout(context.Background(), _queryTimeout)
-		blkNums     = make([][]uint64, endIndex-startIndex+1)
-		jobs        = make(chan jobDesc, endIndex-startIndex+1)
+		blkNums     = make([][]uint64, len(indices))
+		jobs        = make(chan jobDesc, len(indices))
 		eg          *errgroup.Group
 		bufPool     sync.Pool
 	)
@@ -278,7 +407,7 @@ func (bfx *bloomfilterIndexer) FilterBlocksInRange(l *filter.LogFilter, start, e
 						if end < searchEnd {
 							searchEnd = end
 						}
-						blkNums[job.idx] = l.SelectBlocksFromRangeBloomFilter(br.BloomFilter, searchStart, searchEnd)
+						blkNums[job.idx] = selectBlocksInCandidates(l, br.BloomFilter, searchStart, searchEnd, candidates)
 					}
 					bufPool.Put(br)
 				}
@@ -287,8 +416,8 @@ func (bfx *bloomfilterIndexer) FilterBlocksInRange(l *filter.LogFilter, start, e
 	}
 
 	// send job to job chan
-	for idx := startIndex; idx <= endIndex; idx++ {
-		jobs <- jobDesc{idx - startIndex, byteutil.Uint64ToBytesBigEndian(idx)}
+	for i, idx := range indices {
+		jobs <- jobDesc{uint64(i), byteutil.Uint64ToBytesBigEndian(idx)}
 	}
 	close(jobs)
 
@@ -309,7 +438,8 @@ func (bfx *bloomfilterIndexer) FilterBlocksInRange(l *filter.LogFilter, start, e
 	return ret, nil
 }
 
-func (bfx *bloomfilterIndexer) commit(blockNumber uint64, blkBloomfilter bloom.BloomFilter) error {
+func (bfx *bloomfilterIndexer) commit(blk *block.Block, blkBloomfilter bloom.BloomFilter) error {
+	blockNumber := blk.Height()
 	bfx.curRangeBloomfilter.SetEnd(blockNumber)
 	bfBytes, err := bfx.curRangeBloomfilter.Bytes()
 	if err != nil {
@@ -318,24 +448,41 @@ func (bfx *bloomfilterIndexer) commit(blockNumber uint64, blkBloomfilter bloom.B
 	b := batch.NewBatch()
 	b.Put(RangeBloomFilterNamespace, bfx.currRangeBfKey, bfBytes, "failed to put range bloom filter")
 	b.Put(BlockBloomFilterNamespace, byteutil.Uint64ToBytesBigEndian(blockNumber), blkBloomfilter.Bytes(), "failed to put block bloom filter")
+	if bfx.curSegment != nil {
+		bfx.curSegment.SetEnd(blockNumber)
+		segBytes, err := bfx.curSegment.Bytes()
+		if err != nil {
+			return err
+		}
+		b.Put(SegmentBloomFilterNamespace, byteutil.Uint64ToBytesBigEndian(bfx.segmentIndex(blockNumber)), segBytes, "failed to put segment bloom filter")
+		b.AddFillPercent(SegmentBloomFilterNamespace, 1.0)
+	}
 	b.Put(RangeBloomFilterNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(blockNumber), "failed to put current height")
 	b.AddFillPercent(RangeBloomFilterNamespace, 1.0)
 	b.AddFillPercent(BlockBloomFilterNamespace, 1.0)
+	if err := bfx.journal.Record(b, blockNumber, blk.HashBlock()); err != nil {
+		return err
+	}
 	return bfx.kvStore.WriteBatch(b)
 }
 
 // TODO: improve performance
 func (bfx *bloomfilterIndexer) calculateBlockBloomFilter(ctx context.Context, receipts []*action.Receipt) bloom.BloomFilter {
 	bloom, _ := bloom.NewBloomFilter(2048, 3)
+	bfx.addLogsToBloomFilter(bloom, receipts)
+	return bloom
+}
+
+// addLogsToBloomFilter adds the addresses and the topics of logs into the bloomfilter, in the same way as block bloomfilter
+func (bfx *bloomfilterIndexer) addLogsToBloomFilter(bf bloom.BloomFilter, receipts []*action.Receipt) {
 	for _, receipt := range receipts {
 		for _, l := range receipt.Logs() {
-			bloom.Add([]byte(l.Address))
+			bf.Add([]byte(l.Address))
 			for i, topic := range l.Topics {
-				bloom.Add(append(byteutil.Uint64ToBytes(uint64(i)), topic[:]...)) //position-sensitive
+				bf.Add(append(byteutil.Uint64ToBytes(uint64(i)), topic[:]...)) //position-sensitive
 			}
 		}
 	}
-	return bloom
 }
 
 // TODO: improve performance
@@ -372,3 +519,72 @@ func (bfx *bloomfilterIndexer) getIndexByHeight(height uint64) (uint64, error) {
 	}
 	return byteutil.BytesToUint64BigEndian(val), nil
 }
+
+func (bfx *bloomfilterIndexer) segmentIndex(height uint64) uint64 {
+	return (height - 1) / bfx.segmentSize
+}
+
+func (bfx *bloomfilterIndexer) segmentStart(height uint64) uint64 {
+	return bfx.segmentIndex(height)*bfx.segmentSize + 1
+}
+
+// loadSegmentFromDB loads the segment of index, and returns false if the segment is not indexed
+func (bfx *bloomfilterIndexer) loadSegmentFromDB(br *bloomRange, index uint64) (bool, error) {
+	bfBytes, err := bfx.kvStore.Get(SegmentBloomFilterNamespace, byteutil.Uint64ToBytesBigEndian(index))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist:
+		return false, nil
+	default:
+		return false, err
+	}
+	// the segment written with another bloomfilter size is treated as not indexed
+	return br.FromBytes(bfBytes) == nil, nil
+}
+
+// segmentCandidates returns the sorted intervals in [start, end] which may have logs matching the filter. The blocks
+// of a segment whose bloomfilter does not match the filter are skipped
+func (bfx *bloomfilterIndexer) segmentCandidates(l *filter.LogFilter, start, end uint64) ([][2]uint64, error) {
+	if bfx.segmentSize == 0 {
+		return [][2]uint64{{start, end}}, nil
+	}
+	br, err := newBloomRange(bfx.segmentBfSize, bfx.segmentBfNumHash)
+	if err != nil {
+		return nil, err
+	}
+	var candidates [][2]uint64
+	for idx := bfx.segmentIndex(start); idx <= bfx.segmentIndex(end); idx++ {
+		segStart := idx*bfx.segmentSize + 1
+		from, to := max(start, segStart), min(end, segStart+bfx.segmentSize-1)
+		indexed, err := bfx.loadSegmentFromDB(br, idx)
+		if err != nil {
+			return nil, err
+		}
+		if indexed && br.Start() == segStart && !l.ExistInBloomFilterv2(br.BloomFilter) {
+			if br.End() >= to {
+				continue
+			}
+			// the blocks after the end of segment are not indexed yet
+			from = max(from, br.End()+1)
+		}
+		if n := len(candidates); n > 0 && candidates[n-1][1]+1 == from {
+			candidates[n-1][1] = to
+		} else {
+			candidates = append(candidates, [2]uint64{from, to})
+		}
+	}
+	return candidates, nil
+}
+
+// selectBlocksInCandidates selects the blocks in [start, end] from the range bloomfilter, within the candidates only
+func selectBlocksInCandidates(l *filter.LogFilter, bf bloom.BloomFilter, start, end uint64, candidates [][2]uint64) []uint64 {
+	var blkNums []uint64
+	for _, c := range candidates {
+		from, to := max(start, c[0]), min(end, c[1])
+		if from > to {
+			continue
+		}
+		blkNums = append(blkNums, l.SelectBlocksFromRangeBloomFilter(bf, from, to)...)
+	}
+	return blkNums
+}
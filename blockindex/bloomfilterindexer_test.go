@@ -271,6 +271,118 @@ func TestBloomfilterIndexer(t *testing.T) {
 	})
 }
 
+func TestBloomfilterIndexerSegment(t *testing.T) {
+	require := require.New(t)
+
+	blks := getTestLogBlocks(t)
+	testPath, err := testutil.PathOfTempFile("test-indexer")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	dbCfg := db.DefaultConfig
+	dbCfg.DbPath = testPath
+	cfg := DefaultConfig
+	cfg.RangeBloomFilterNumElements = 16
+	cfg.RangeBloomFilterSize = 4096
+	cfg.RangeBloomFilterNumHash = 4
+	cfg.SegmentBloomFilterNumBlocks = 2
+	cfg.SegmentBloomFilterSize = 4096
+	cfg.SegmentBloomFilterNumHash = 4
+
+	ctx := context.Background()
+	indexer, err := NewBloomfilterIndexer(db.NewBoltDB(dbCfg), cfg)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	for i := 0; i < 3; i++ {
+		require.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	// restart in the middle of a segment
+	require.NoError(indexer.Stop(ctx))
+	indexer, err = NewBloomfilterIndexer(db.NewBoltDB(dbCfg), cfg)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+	bfx := indexer.(*bloomfilterIndexer)
+	require.NotNil(bfx.curSegment)
+	for i := 3; i < len(blks); i++ {
+		require.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+
+	lf := logfilter.NewLogFilter(&iotexapi.LogsFilter{
+		Address: []string{identityset.Address(18).String()},
+	})
+	candidates, err := bfx.segmentCandidates(lf, 1, 5)
+	require.NoError(err)
+	require.Equal([][2]uint64{{3, 4}}, candidates)
+	candidates, err = bfx.segmentCandidates(lf, 2, 3)
+	require.NoError(err)
+	require.Equal([][2]uint64{{3, 3}}, candidates)
+	res, err := indexer.FilterBlocksInRange(lf, 1, 5, 0)
+	require.NoError(err)
+	require.Equal([]uint64{3, 4}, res)
+
+	// the segment of the deleted block is no longer skipped
+	require.NoError(bfx.DeleteTipBlock(ctx, blks[4]))
+	require.Nil(bfx.curSegment)
+	candidates, err = bfx.segmentCandidates(lf, 1, 5)
+	require.NoError(err)
+	require.Equal([][2]uint64{{3, 5}}, candidates)
+}
+
+func TestBloomfilterIndexerRollback(t *testing.T) {
+	require := require.New(t)
+
+	blks := getTestLogBlocks(t)
+	testPath, err := testutil.PathOfTempFile("test-indexer")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	dbCfg := db.DefaultConfig
+	dbCfg.DbPath = testPath
+	cfg := DefaultConfig
+	cfg.RangeBloomFilterNumElements = 4
+	cfg.RangeBloomFilterSize = 4096
+	cfg.RangeBloomFilterNumHash = 4
+	cfg.SegmentBloomFilterNumBlocks = 2
+	cfg.SegmentBloomFilterSize = 4096
+	cfg.SegmentBloomFilterNumHash = 4
+
+	ctx := context.Background()
+	indexer, err := NewBloomfilterIndexer(db.NewBoltDB(dbCfg), cfg)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+	for i := 0; i < len(blks); i++ {
+		require.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	h, err := indexer.Checkpoint(5)
+	require.NoError(err)
+	require.Equal(blks[4].HashBlock(), h)
+
+	lf := logfilter.NewLogFilter(&iotexapi.LogsFilter{
+		Address: []string{identityset.Address(18).String()},
+	})
+	require.NoError(indexer.Rollback(ctx, 2))
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.EqualValues(2, height)
+	_, err = indexer.BlockFilterByHeight(3)
+	require.Error(err)
+	res, err := indexer.FilterBlocksInRange(lf, 1, 2, 0)
+	require.NoError(err)
+	require.Empty(res)
+
+	// index the blocks again after rollback
+	for i := 2; i < len(blks); i++ {
+		require.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	res, err = indexer.FilterBlocksInRange(lf, 1, 5, 0)
+	require.NoError(err)
+	require.Equal([]uint64{3, 4}, res)
+}
+
 func BenchmarkBloomfilterIndexer(b *testing.B) {
 	require := require.New(b)
 
@@ -13,6 +13,12 @@ type Config struct {
 	RangeBloomFilterSize uint64 `yaml:"rangeBloomFilterSize"`
 	// RangeBloomFilterNumHash is the number of hash functions of rangeBloomfilter
 	RangeBloomFilterNumHash uint64 `yaml:"rangeBloomFilterNumHash"`
+	// SegmentBloomFilterNumBlocks is the number of blocks each segmentBloomfilter indexes, 0 disables segmentBloomfilter
+	SegmentBloomFilterNumBlocks uint64 `yaml:"segmentBloomFilterNumBlocks"`
+	// SegmentBloomFilterSize is the size (in bits) of segmentBloomfilter
+	SegmentBloomFilterSize uint64 `yaml:"segmentBloomFilterSize"`
+	// SegmentBloomFilterNumHash is the number of hash functions of segmentBloomfilter
+	SegmentBloomFilterNumHash uint64 `yaml:"segmentBloomFilterNumHash"`
 }
 
 // DefaultConfig is the default config of indexer
@@ -20,4 +26,7 @@ var DefaultConfig = Config{
 	RangeBloomFilterNumElements: 100000,
 	RangeBloomFilterSize:        1200000,
 	RangeBloomFilterNumHash:     8,
+	SegmentBloomFilterNumBlocks: 4096,
+	SegmentBloomFilterSize:      1 << 20,
+	SegmentBloomFilterNumHash:   4,
 }
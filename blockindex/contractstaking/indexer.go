@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
@@ -22,6 +23,8 @@ import (
 
 const (
 	maxBlockNumber uint64 = math.MaxUint64
+
+	_StakingJournalNS = "sjn"
 )
 
 type (
@@ -33,6 +36,7 @@ type (
 		kvstore db.KVStore            // persistent storage, used to initialize index cache at startup
 		cache   *contractStakingCache // in-memory index for clean data, used to query index data
 		config  Config                // indexer config
+		journal *db.Journal           // journal of the latest blocks, used to roll back the index
 	}
 
 	// Config is the config for contract staking indexer
@@ -62,6 +66,7 @@ func NewContractStakingIndexer(kvStore db.KVStore, config Config) (*Indexer, err
 		kvstore: kvStore,
 		cache:   newContractStakingCache(config),
 		config:  config,
+		journal: db.NewJournal(kvStore, _StakingJournalNS, db.DefaultJournalDepth),
 	}, nil
 }
 
@@ -192,10 +197,31 @@ func (s *Indexer) PutBlock(ctx context.Context, blk *block.Block) error {
 	}
 
 	// commit the result
-	return s.commit(handler, blk.Height())
+	return s.commit(handler, blk.Height(), blk.HashBlock())
+}
+
+// Checkpoint returns the hash of the indexed block at the height
+func (s *Indexer) Checkpoint(height uint64) (hash.Hash256, error) {
+	return s.journal.Checkpoint(height)
+}
+
+// Rollback reverts the index to the height
+func (s *Indexer) Rollback(_ context.Context, height uint64) error {
+	// the blocks before the contract deployment are not indexed
+	if deployHeight := s.config.ContractDeployHeight; deployHeight > 0 && height < deployHeight-1 {
+		height = deployHeight - 1
+	}
+	tipHeight := s.cache.Height()
+	if height >= tipHeight {
+		return nil
+	}
+	if err := s.journal.Revert(tipHeight, height); err != nil {
+		return errors.Wrapf(err, "failed to roll back contract staking index from %d to %d", tipHeight, height)
+	}
+	return s.reloadCache()
 }
 
-func (s *Indexer) commit(handler *contractStakingEventHandler, height uint64) error {
+func (s *Indexer) commit(handler *contractStakingEventHandler, height uint64, blkHash hash.Hash256) error {
 	batch, delta := handler.Result()
 	// update cache
 	if err := s.cache.Merge(delta, height); err != nil {
@@ -204,6 +230,10 @@ func (s *Indexer) commit(handler *contractStakingEventHandler, height uint64) er
 	}
 	// update db
 	batch.Put(_StakingNS, _stakingHeightKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
+	if err := s.journal.Record(batch, height, blkHash); err != nil {
+		s.reloadCache()
+		return err
+	}
 	if err := s.kvstore.WriteBatch(batch); err != nil {
 		s.reloadCache()
 		return err
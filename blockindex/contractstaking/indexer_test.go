@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/stretchr/testify/require"
 
@@ -105,7 +106,7 @@ func TestContractStakingIndexerLoadCache(t *testing.T) {
 	owner := identityset.Address(0)
 	delegate := identityset.Address(1)
 	stake(r, handler, owner, delegate, 1, 10, 100, height)
-	err = indexer.commit(handler, height)
+	err = indexer.commit(handler, height, hash.ZeroHash256)
 	r.NoError(err)
 	buckets, err := indexer.Buckets(height)
 	r.NoError(err)
@@ -170,7 +171,7 @@ func TestContractStakingIndexerDirty(t *testing.T) {
 	r.NoError(err)
 	r.EqualValues(0, gotHeight)
 	// after commit dirty, the cache should be updated
-	err = indexer.commit(handler, height)
+	err = indexer.commit(handler, height, hash.ZeroHash256)
 	r.NoError(err)
 	gotHeight, err = indexer.Height()
 	r.NoError(err)
@@ -225,12 +226,12 @@ func TestContractStakingIndexerThreadSafe(t *testing.T) {
 		// activate bucket type
 		handler := newContractStakingEventHandler(indexer.cache)
 		activateBucketType(r, handler, 10, 100, 1)
-		r.NoError(indexer.commit(handler, 1))
+		r.NoError(indexer.commit(handler, 1, hash.ZeroHash256))
 		for i := 2; i < 1000; i++ {
 			height := uint64(i)
 			handler := newContractStakingEventHandler(indexer.cache)
 			stake(r, handler, owner, delegate, int64(i), 10, 100, height)
-			err := indexer.commit(handler, height)
+			err := indexer.commit(handler, height, hash.ZeroHash256)
 			r.NoError(err)
 		}
 	}()
@@ -278,7 +279,7 @@ func TestContractStakingIndexerBucketType(t *testing.T) {
 	for _, data := range bucketTypeData {
 		activateBucketType(r, handler, data[0], data[1], height)
 	}
-	err = indexer.commit(handler, height)
+	err = indexer.commit(handler, height, hash.ZeroHash256)
 	r.NoError(err)
 	bucketTypes, err := indexer.BucketTypes(height)
 	r.NoError(err)
@@ -294,7 +295,7 @@ func TestContractStakingIndexerBucketType(t *testing.T) {
 		data := bucketTypeData[i]
 		deactivateBucketType(r, handler, data[0], data[1], height)
 	}
-	err = indexer.commit(handler, height)
+	err = indexer.commit(handler, height, hash.ZeroHash256)
 	r.NoError(err)
 	bucketTypes, err = indexer.BucketTypes(height)
 	r.NoError(err)
@@ -310,7 +311,7 @@ func TestContractStakingIndexerBucketType(t *testing.T) {
 		data := bucketTypeData[i]
 		activateBucketType(r, handler, data[0], data[1], height)
 	}
-	err = indexer.commit(handler, height)
+	err = indexer.commit(handler, height, hash.ZeroHash256)
 	r.NoError(err)
 	bucketTypes, err = indexer.BucketTypes(height)
 	r.NoError(err)
@@ -356,7 +357,7 @@ func TestContractStakingIndexerBucketInfo(t *testing.T) {
 	for _, data := range bucketTypeData {
 		activateBucketType(r, handler, data[0], data[1], height)
 	}
-	err = indexer.commit(handler, height)
+	err = indexer.commit(handler, height, hash.ZeroHash256)
 	r.NoError(err)
 	ctx := protocol.WithFeatureCtx(protocol.WithBlockCtx(genesis.WithGenesisContext(context.Background(), genesis.TestDefault()), protocol.BlockCtx{BlockHeight: 1}))
 
@@ -368,7 +369,7 @@ func TestContractStakingIndexerBucketInfo(t *testing.T) {
 	handler = newContractStakingEventHandler(indexer.cache)
 	stake(r, handler, owner, delegate, 1, 10, 100, height)
 	r.NoError(err)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	bucket, ok, err := indexer.Bucket(1, height)
 	r.NoError(err)
 	r.True(ok)
@@ -394,7 +395,7 @@ func TestContractStakingIndexerBucketInfo(t *testing.T) {
 	height++
 	handler = newContractStakingEventHandler(indexer.cache)
 	transfer(r, handler, newOwner, int64(bucket.Index))
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	bucket, ok, err = indexer.Bucket(bucket.Index, height)
 	r.NoError(err)
 	r.True(ok)
@@ -404,7 +405,7 @@ func TestContractStakingIndexerBucketInfo(t *testing.T) {
 	height++
 	handler = newContractStakingEventHandler(indexer.cache)
 	unlock(r, handler, int64(bucket.Index), height)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	bucket, ok, err = indexer.Bucket(bucket.Index, height)
 	r.NoError(err)
 	r.True(ok)
@@ -429,7 +430,7 @@ func TestContractStakingIndexerBucketInfo(t *testing.T) {
 	height++
 	handler = newContractStakingEventHandler(indexer.cache)
 	lock(r, handler, int64(bucket.Index), int64(10))
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	bucket, ok, err = indexer.Bucket(bucket.Index, height)
 	r.NoError(err)
 	r.True(ok)
@@ -455,7 +456,7 @@ func TestContractStakingIndexerBucketInfo(t *testing.T) {
 	handler = newContractStakingEventHandler(indexer.cache)
 	unlock(r, handler, int64(bucket.Index), height)
 	unstake(r, handler, int64(bucket.Index), height)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	bucket, ok, err = indexer.Bucket(bucket.Index, height)
 	r.NoError(err)
 	r.True(ok)
@@ -480,7 +481,7 @@ func TestContractStakingIndexerBucketInfo(t *testing.T) {
 	height++
 	handler = newContractStakingEventHandler(indexer.cache)
 	withdraw(r, handler, int64(bucket.Index))
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	bucket, ok, err = indexer.Bucket(bucket.Index, height)
 	r.NoError(err)
 	r.False(ok)
@@ -521,7 +522,7 @@ func TestContractStakingIndexerChangeBucketType(t *testing.T) {
 	for _, data := range bucketTypeData {
 		activateBucketType(r, handler, data[0], data[1], height)
 	}
-	err = indexer.commit(handler, height)
+	err = indexer.commit(handler, height, hash.ZeroHash256)
 	r.NoError(err)
 
 	t.Run("expand bucket type", func(t *testing.T) {
@@ -531,13 +532,13 @@ func TestContractStakingIndexerChangeBucketType(t *testing.T) {
 		handler = newContractStakingEventHandler(indexer.cache)
 		stake(r, handler, owner, delegate, 1, 10, 100, height)
 		r.NoError(err)
-		r.NoError(indexer.commit(handler, height))
+		r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 		bucket, ok, err := indexer.Bucket(1, height)
 		r.NoError(err)
 		r.True(ok)
 
 		expandBucketType(r, handler, int64(bucket.Index), 20, 100)
-		r.NoError(indexer.commit(handler, height))
+		r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 		bucket, ok, err = indexer.Bucket(bucket.Index, height)
 		r.NoError(err)
 		r.True(ok)
@@ -575,7 +576,7 @@ func TestContractStakingIndexerReadBuckets(t *testing.T) {
 	for _, data := range bucketTypeData {
 		activateBucketType(r, handler, data[0], data[1], height)
 	}
-	err = indexer.commit(handler, height)
+	err = indexer.commit(handler, height, hash.ZeroHash256)
 	r.NoError(err)
 
 	// stake
@@ -596,7 +597,7 @@ func TestContractStakingIndexerReadBuckets(t *testing.T) {
 		stake(r, handler, identityset.Address(data.owner), identityset.Address(data.delegate), int64(i), int64(data.amount), int64(data.duration), height)
 	}
 	r.NoError(err)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 
 	t.Run("Buckets", func(t *testing.T) {
 		buckets, err := indexer.Buckets(height)
@@ -687,7 +688,7 @@ func TestContractStakingIndexerCacheClean(t *testing.T) {
 	bts, err := indexer.cache.Buckets(height - 1)
 	r.NoError(err)
 	r.Len(bts, 0)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	abt, err = indexer.cache.ActiveBucketTypes(height)
 	r.NoError(err)
 	r.Len(abt, 2)
@@ -707,7 +708,7 @@ func TestContractStakingIndexerCacheClean(t *testing.T) {
 	r.NoError(err)
 	r.True(ok)
 	r.Equal(owner.String(), bt.Owner.String())
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	bt, ok, err = indexer.Bucket(3, height)
 	r.NoError(err)
 	r.True(ok)
@@ -751,7 +752,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	stake(r, handler, owner, delegate1, 2, 20, 20, height)
 	stake(r, handler, owner, delegate2, 3, 20, 20, height)
 	stake(r, handler, owner, delegate2, 4, 20, 20, height)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err := indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(30, votes.Uint64())
@@ -765,7 +766,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	height++
 	handler = newContractStakingEventHandler(indexer.cache)
 	changeDelegate(r, handler, delegate1, 3)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err = indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(50, votes.Uint64())
@@ -778,7 +779,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	handler = newContractStakingEventHandler(indexer.cache)
 	unlock(r, handler, 1, height)
 	unlock(r, handler, 4, height)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err = indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(50, votes.Uint64())
@@ -791,7 +792,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	handler = newContractStakingEventHandler(indexer.cache)
 	unstake(r, handler, 1, height)
 	lock(r, handler, 4, 20)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err = indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(40, votes.Uint64())
@@ -803,7 +804,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	height++
 	handler = newContractStakingEventHandler(indexer.cache)
 	expandBucketType(r, handler, 2, 30, 20)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err = indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(50, votes.Uint64())
@@ -815,7 +816,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	height++
 	handler = newContractStakingEventHandler(indexer.cache)
 	transfer(r, handler, delegate2, 4)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err = indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(50, votes.Uint64())
@@ -829,7 +830,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	stake(r, handler, owner, delegate2, 5, 20, 20, height)
 	stake(r, handler, owner, delegate2, 6, 20, 20, height)
 	stake(r, handler, owner, delegate2, 7, 20, 20, height)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err = indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(50, votes.Uint64())
@@ -841,7 +842,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	height++
 	handler = newContractStakingEventHandler(indexer.cache)
 	mergeBuckets(r, handler, []int64{5, 6, 7}, 60, 20)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err = indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(50, votes.Uint64())
@@ -854,7 +855,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	handler = newContractStakingEventHandler(indexer.cache)
 	unlock(r, handler, 5, height)
 	unstake(r, handler, 5, height)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err = indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(50, votes.Uint64())
@@ -869,7 +870,7 @@ func TestContractStakingIndexerVotes(t *testing.T) {
 	stake(r, handler, owner, delegate2, 9, 20, 20, height)
 	stake(r, handler, owner, delegate2, 10, 20, 20, height)
 	mergeBuckets(r, handler, []int64{8, 9, 10}, 60, 20)
-	r.NoError(indexer.commit(handler, height))
+	r.NoError(indexer.commit(handler, height, hash.ZeroHash256))
 	votes, err = indexer.CandidateVotes(ctx, delegate1, height)
 	r.NoError(err)
 	r.EqualValues(110, votes.Uint64())
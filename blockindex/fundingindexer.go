@@ -0,0 +1,388 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// the NS/bucket name here are used in funding.index.db, the fundings of a bucket and the fundings by a source are kept
+// in the counting index named by the prefix followed by the bucket index or the address bytes
+const (
+	_fundingMetaNS        = "fm"
+	_fundingJournalNS     = "fj"
+	_lastFunderNS         = "fl"
+	_bucketFundingPrefix  = "fb"
+	_sourceFundingPrefix  = "fs"
+	_lastFunderEntryBytes = 20 + 8 + 32
+)
+
+type (
+	// FundingIndexer is the indexer of the funding sources of the staking bucket creations and deposits, which
+	// tracks the last transfer to each account from the transaction logs, and records it as the funding source when
+	// the account creates or deposits to a bucket
+	FundingIndexer interface {
+		blockdao.BlockIndexerWithRollback
+		staking.BucketFundingIndexer
+	}
+
+	// fundingIndexer implements the FundingIndexer interface
+	fundingIndexer struct {
+		mutex   sync.RWMutex
+		kvStore db.KVStore
+		journal *db.Journal
+		height  uint64
+	}
+
+	// lastFunder is the last transfer to an account
+	lastFunder struct {
+		sender     []byte
+		height     uint64
+		actionHash hash.Hash256
+	}
+)
+
+// NewFundingIndexer creates a new bucket funding indexer
+func NewFundingIndexer(kv db.KVStore) (FundingIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	if _, ok := kv.(db.KVStoreWithRange); !ok {
+		return nil, errors.New("funding indexer can only be created from KVStoreWithRange")
+	}
+	return &fundingIndexer{
+		kvStore: kv,
+		journal: db.NewJournal(kv, _fundingJournalNS, db.DefaultJournalDepth),
+	}, nil
+}
+
+// Start starts the funding indexer
+func (x *fundingIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	value, err := x.kvStore.Get(_fundingMetaNS, []byte(CurrentHeightKey))
+	switch errors.Cause(err) {
+	case nil:
+		x.height = byteutil.BytesToUint64BigEndian(value)
+		return nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		x.height = 0
+		return nil
+	default:
+		return err
+	}
+}
+
+// Stop stops the funding indexer
+func (x *fundingIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the funding indexer
+func (x *fundingIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// PutBlock indexes the fundings of the bucket creations and deposits in the block
+func (x *fundingIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	height := blk.Height()
+	if height <= x.height {
+		return nil
+	}
+	if height != x.height+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height+1)
+	}
+	actions := make(map[hash.Hash256]action.Action, len(blk.Actions))
+	for _, selp := range blk.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			return err
+		}
+		actions[h] = selp.Action()
+	}
+	var (
+		b              = batch.NewBatch()
+		funders        = make(map[string]*lastFunder)
+		bucketFundings = make(map[string][]*staking.BucketFunding)
+		sourceFundings = make(map[string][]*staking.BucketFunding)
+	)
+	for _, receipt := range blk.Receipts {
+		for _, l := range receipt.TransactionLogs() {
+			if l.Amount == nil || l.Amount.Sign() == 0 {
+				continue
+			}
+			switch l.Type {
+			case iotextypes.TransactionLogType_CREATE_BUCKET, iotextypes.TransactionLogType_DEPOSIT_TO_BUCKET:
+				if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+					continue
+				}
+				funding, err := x.bucketFunding(receipt, actions[receipt.ActionHash], l, funders)
+				if err != nil {
+					return err
+				}
+				if funding == nil {
+					continue
+				}
+				funding.Height = height
+				bucketKey := string(byteutil.Uint64ToBytesBigEndian(funding.BucketIndex))
+				bucketFundings[bucketKey] = append(bucketFundings[bucketKey], funding)
+				if source, err := address.FromString(funding.Source); err == nil {
+					sourceKey := string(source.Bytes())
+					sourceFundings[sourceKey] = append(sourceFundings[sourceKey], funding)
+				}
+			case iotextypes.TransactionLogType_NATIVE_TRANSFER,
+				iotextypes.TransactionLogType_IN_CONTRACT_TRANSFER,
+				iotextypes.TransactionLogType_WITHDRAW_BUCKET,
+				iotextypes.TransactionLogType_CLAIM_FROM_REWARDING_FUND:
+				sender, err := address.FromString(l.Sender)
+				if err != nil {
+					continue
+				}
+				recipient, err := address.FromString(l.Recipient)
+				if err != nil || address.Equal(sender, recipient) {
+					continue
+				}
+				funders[string(recipient.Bytes())] = &lastFunder{
+					sender:     sender.Bytes(),
+					height:     height,
+					actionHash: receipt.ActionHash,
+				}
+			}
+		}
+	}
+	for _, key := range sortedKeys(funders) {
+		b.Put(_lastFunderNS, []byte(key), funders[key].serialize(), "failed to put last funder")
+	}
+	for _, key := range sortedKeys(bucketFundings) {
+		if err := x.addFundings(b, prefixBucket(_bucketFundingPrefix, []byte(key)), bucketFundings[key]); err != nil {
+			return err
+		}
+	}
+	for _, key := range sortedKeys(sourceFundings) {
+		if err := x.addFundings(b, prefixBucket(_sourceFundingPrefix, []byte(key)), sourceFundings[key]); err != nil {
+			return err
+		}
+	}
+	b.Put(_fundingMetaNS, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(height), "failed to put current height")
+	if err := x.journal.Record(b, height, blk.HashBlock()); err != nil {
+		return err
+	}
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = height
+	return nil
+}
+
+// Checkpoint returns the hash of the indexed block at the height
+func (x *fundingIndexer) Checkpoint(height uint64) (hash.Hash256, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.journal.Checkpoint(height)
+}
+
+// Rollback reverts the funding index to the height
+func (x *fundingIndexer) Rollback(_ context.Context, height uint64) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	if height >= x.height {
+		return nil
+	}
+	if err := x.journal.Revert(x.height, height); err != nil {
+		return errors.Wrapf(err, "failed to roll back funding index from %d to %d", x.height, height)
+	}
+	x.height = height
+	return nil
+}
+
+// BucketFundings returns the fundings of the bucket, from the creation to the latest deposit
+func (x *fundingIndexer) BucketFundings(index uint64) ([]*staking.BucketFunding, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	name := prefixBucket(_bucketFundingPrefix, byteutil.Uint64ToBytesBigEndian(index))
+	total, err := x.fundingCount(name)
+	if err != nil || total == 0 {
+		return nil, err
+	}
+	return x.fundings(name, 0, total)
+}
+
+// SourceFundingCount returns the number of fundings by the source
+func (x *fundingIndexer) SourceFundingCount(source address.Address) (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.fundingCount(prefixBucket(_sourceFundingPrefix, source.Bytes()))
+}
+
+// SourceFundings returns the fundings by the source [start, start+count)
+func (x *fundingIndexer) SourceFundings(source address.Address, start, count uint64) ([]*staking.BucketFunding, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.fundings(prefixBucket(_sourceFundingPrefix, source.Bytes()), start, count)
+}
+
+// bucketFunding returns the funding of the bucket creation or deposit, along with the last funder of the staker
+func (x *fundingIndexer) bucketFunding(
+	receipt *action.Receipt,
+	act action.Action,
+	l *action.TransactionLog,
+	funders map[string]*lastFunder,
+) (*staking.BucketFunding, error) {
+	funding := &staking.BucketFunding{
+		ActionHash: hex.EncodeToString(receipt.ActionHash[:]),
+		Staker:     l.Sender,
+		Amount:     l.Amount.String(),
+	}
+	switch act := act.(type) {
+	case *action.CreateStake:
+		// the index of the created bucket is the data of the staking receipt log
+		found := false
+		for _, log := range receipt.Logs() {
+			if log.Address == address.StakingProtocolAddr && len(log.Data) == 8 {
+				funding.BucketIndex, found = byteutil.BytesToUint64BigEndian(log.Data), true
+				break
+			}
+		}
+		if !found {
+			return nil, nil
+		}
+		funding.Type = staking.HandleCreateStake
+	case *action.DepositToStake:
+		funding.BucketIndex = act.BucketIndex()
+		funding.Type = staking.HandleDepositToStake
+	default:
+		return nil, nil
+	}
+	staker, err := address.FromString(l.Sender)
+	if err != nil {
+		return nil, nil
+	}
+	funder, ok := funders[string(staker.Bytes())]
+	if !ok {
+		if funder, err = x.lastFunder(staker.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	if funder != nil {
+		source, err := address.FromBytes(funder.sender)
+		if err != nil {
+			return nil, err
+		}
+		funding.Source = source.String()
+		funding.SourceHeight = funder.height
+		funding.SourceActionHash = hex.EncodeToString(funder.actionHash[:])
+	}
+	return funding, nil
+}
+
+// lastFunder returns the last funder of the account, nil if the account has never been funded
+func (x *fundingIndexer) lastFunder(addr []byte) (*lastFunder, error) {
+	value, err := x.kvStore.Get(_lastFunderNS, addr)
+	switch errors.Cause(err) {
+	case nil:
+		return deserializeLastFunder(value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+func (x *fundingIndexer) addFundings(b batch.KVStoreBatch, name []byte, fundings []*staking.BucketFunding) error {
+	index, err := db.NewCountingIndexNX(x.kvStore, name)
+	if err != nil {
+		return err
+	}
+	if err := index.UseBatch(b); err != nil {
+		return err
+	}
+	for _, f := range fundings {
+		data, err := json.Marshal(f)
+		if err != nil {
+			return err
+		}
+		if err := index.Add(data, true); err != nil {
+			return err
+		}
+	}
+	return index.Finalize()
+}
+
+func (x *fundingIndexer) fundingCount(name []byte) (uint64, error) {
+	index, err := db.GetCountingIndex(x.kvStore, name)
+	if err != nil {
+		if errors.Cause(err) == db.ErrBucketNotExist || errors.Cause(err) == db.ErrNotExist {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return index.Size(), nil
+}
+
+func (x *fundingIndexer) fundings(name []byte, start, count uint64) ([]*staking.BucketFunding, error) {
+	index, err := db.GetCountingIndex(x.kvStore, name)
+	if err != nil {
+		return nil, err
+	}
+	total := index.Size()
+	if start >= total {
+		return nil, errors.Wrapf(db.ErrInvalid, "start = %d >= total = %d", start, total)
+	}
+	if start+count > total {
+		count = total - start
+	}
+	values, err := index.Range(start, count)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*staking.BucketFunding, 0, len(values))
+	for _, v := range values {
+		f := &staking.BucketFunding{}
+		if err := json.Unmarshal(v, f); err != nil {
+			return nil, errors.Wrap(db.ErrInvalid, err.Error())
+		}
+		ret = append(ret, f)
+	}
+	return ret, nil
+}
+
+func (f *lastFunder) serialize() []byte {
+	data := append([]byte{}, f.sender...)
+	data = append(data, byteutil.Uint64ToBytesBigEndian(f.height)...)
+	return append(data, f.actionHash[:]...)
+}
+
+func deserializeLastFunder(data []byte) (*lastFunder, error) {
+	if len(data) != _lastFunderEntryBytes {
+		return nil, errors.Wrap(db.ErrInvalid, "invalid last funder")
+	}
+	return &lastFunder{
+		sender:     data[:20],
+		height:     byteutil.BytesToUint64BigEndian(data[20:28]),
+		actionHash: hash.BytesToHash256(data[28:]),
+	}, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestFundingIndexer(t *testing.T) {
+	require := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-funding-indexer")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	dbCfg := db.DefaultConfig
+	dbCfg.DbPath = testPath
+	var (
+		ctx      = context.Background()
+		exchange = identityset.Address(1)
+		staker   = identityset.Address(2)
+		other    = identityset.Address(3)
+	)
+	transfer := func(sender, recipient address.Address, amount int64) *action.Receipt {
+		receipt := &action.Receipt{
+			Status:     uint64(iotextypes.ReceiptStatus_Success),
+			ActionHash: hash.Hash256b([]byte(sender.String() + recipient.String())),
+		}
+		return receipt.AddTransactionLogs(&action.TransactionLog{
+			Type:      iotextypes.TransactionLogType_NATIVE_TRANSFER,
+			Sender:    sender.String(),
+			Recipient: recipient.String(),
+			Amount:    big.NewInt(amount),
+		})
+	}
+	stake := func(selp *action.SealedEnvelope, typ iotextypes.TransactionLogType, bucketIndex uint64, amount int64) *action.Receipt {
+		h, err := selp.Hash()
+		require.NoError(err)
+		receipt := &action.Receipt{
+			Status:     uint64(iotextypes.ReceiptStatus_Success),
+			ActionHash: h,
+		}
+		receipt.AddLogs(&action.Log{
+			Address: address.StakingProtocolAddr,
+			Data:    byteutil.Uint64ToBytesBigEndian(bucketIndex),
+		})
+		return receipt.AddTransactionLogs(&action.TransactionLog{
+			Type:      typ,
+			Sender:    selp.SenderAddress().String(),
+			Recipient: address.StakingBucketPoolAddr,
+			Amount:    big.NewInt(amount),
+		})
+	}
+	newBlock := func(height uint64, acts []*action.SealedEnvelope, receipts ...*action.Receipt) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			AddActions(acts...).
+			SetReceipts(receipts).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		return &blk
+	}
+	create, err := action.SignedCreateStake(1, "cand", "100", 7, false, nil, 100000, big.NewInt(0), identityset.PrivateKey(2))
+	require.NoError(err)
+	deposit, err := action.SignedDepositToStake(2, 5, "50", nil, 100000, big.NewInt(0), identityset.PrivateKey(2))
+	require.NoError(err)
+	unfunded, err := action.SignedCreateStake(1, "cand", "100", 7, false, nil, 100000, big.NewInt(0), identityset.PrivateKey(3))
+	require.NoError(err)
+
+	indexer, err := NewFundingIndexer(db.NewBoltDB(dbCfg))
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	for _, blk := range []*block.Block{
+		newBlock(1, nil, transfer(exchange, staker, 200)),
+		// the staker is funded by the exchange before the bucket creation in the same block
+		newBlock(2, []*action.SealedEnvelope{create},
+			transfer(other, other, 10),
+			stake(create, iotextypes.TransactionLogType_CREATE_BUCKET, 5, 100),
+		),
+		newBlock(3, []*action.SealedEnvelope{deposit, unfunded},
+			stake(deposit, iotextypes.TransactionLogType_DEPOSIT_TO_BUCKET, 0, 50),
+			stake(unfunded, iotextypes.TransactionLogType_CREATE_BUCKET, 6, 100),
+		),
+	} {
+		require.NoError(indexer.PutBlock(ctx, blk))
+	}
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.EqualValues(3, height)
+
+	fundings, err := indexer.BucketFundings(5)
+	require.NoError(err)
+	require.Len(fundings, 2)
+	createHash, err := create.Hash()
+	require.NoError(err)
+	require.Equal(&staking.BucketFunding{
+		BucketIndex:      5,
+		Height:           2,
+		ActionHash:       hex.EncodeToString(createHash[:]),
+		Type:             staking.HandleCreateStake,
+		Staker:           staker.String(),
+		Amount:           "100",
+		Source:           exchange.String(),
+		SourceHeight:     1,
+		SourceActionHash: hex.EncodeToString(transfer(exchange, staker, 200).ActionHash[:]),
+	}, fundings[0])
+	require.Equal(staking.HandleDepositToStake, fundings[1].Type)
+	require.Equal(exchange.String(), fundings[1].Source)
+	fundings, err = indexer.BucketFundings(6)
+	require.NoError(err)
+	require.Len(fundings, 1)
+	require.Empty(fundings[0].Source)
+	fundings, err = indexer.BucketFundings(7)
+	require.NoError(err)
+	require.Empty(fundings)
+
+	count, err := indexer.SourceFundingCount(exchange)
+	require.NoError(err)
+	require.EqualValues(2, count)
+	fundings, err = indexer.SourceFundings(exchange, 1, 10)
+	require.NoError(err)
+	require.Len(fundings, 1)
+	require.EqualValues(3, fundings[0].Height)
+	count, err = indexer.SourceFundingCount(other)
+	require.NoError(err)
+	require.Zero(count)
+
+	// rollback
+	require.NoError(indexer.Rollback(ctx, 2))
+	count, err = indexer.SourceFundingCount(exchange)
+	require.NoError(err)
+	require.EqualValues(1, count)
+	require.NoError(indexer.Stop(ctx))
+}
@@ -112,6 +112,10 @@ func (ib *IndexBuilder) ReceiveBlock(blk *block.Block) error {
 }
 
 func (ib *IndexBuilder) init(ctx context.Context) error {
+	// roll the indexer back to the common ancestor, if it is on a fork of the chain
+	if err := blockdao.NewBlockIndexerChecker(ib.dao).RollbackIndexer(ctx, ib.indexer); err != nil {
+		return err
+	}
 	startHeight, err := ib.indexer.Height()
 	if err != nil {
 		return err
@@ -31,6 +31,7 @@ const (
 	_hashOffset          = 12
 	_blockHashToHeightNS = "hh"
 	_actionToBlockHashNS = "ab"
+	_journalNS           = "jn"
 )
 
 var (
@@ -58,6 +59,8 @@ type (
 		GetActionHashFromIndex(uint64, uint64) ([][]byte, error)
 		GetActionCountByAddress(hash.Hash160) (uint64, error)
 		GetActionsByAddress(hash.Hash160, uint64, uint64) ([][]byte, error)
+		Checkpoint(uint64) (hash.Hash256, error)
+		Rollback(context.Context, uint64) error
 	}
 
 	// blockIndexer implements the Indexer interface
@@ -66,6 +69,7 @@ type (
 		genesisHash hash.Hash256
 		kvStore     db.KVStoreWithRange
 		batch       batch.KVStoreBatch
+		journal     *db.Journal
 		dirtyAddr   addrIndex
 		tbk         db.CountingIndex
 		tac         db.CountingIndex
@@ -84,6 +88,7 @@ func NewIndexer(kv db.KVStore, genesisHash hash.Hash256) (Indexer, error) {
 	x := blockIndexer{
 		kvStore:     kvRange,
 		batch:       batch.NewBatch(),
+		journal:     db.NewJournal(kvRange, _journalNS, db.DefaultJournalDepth),
 		dirtyAddr:   make(addrIndex),
 		genesisHash: genesisHash,
 	}
@@ -122,13 +127,17 @@ func (x *blockIndexer) Stop(ctx context.Context) error {
 func (x *blockIndexer) PutBlocks(ctx context.Context, blks []*block.Block) error {
 	x.mutex.Lock()
 	defer x.mutex.Unlock()
+	if len(blks) == 0 {
+		return nil
+	}
 	for _, blk := range blks {
 		if err := x.putBlock(ctx, blk); err != nil {
 			// TODO: Revert changes
 			return err
 		}
 	}
-	return x.commit()
+	// the blocks are journaled as a whole at the last block
+	return x.commit(blks[len(blks)-1])
 }
 
 // PutBlock index the block
@@ -139,7 +148,34 @@ func (x *blockIndexer) PutBlock(ctx context.Context, blk *block.Block) error {
 	if err := x.putBlock(ctx, blk); err != nil {
 		return err
 	}
-	return x.commit()
+	return x.commit(blk)
+}
+
+// Checkpoint returns the hash of the indexed block at the height
+func (x *blockIndexer) Checkpoint(height uint64) (hash.Hash256, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.journal.Checkpoint(height)
+}
+
+// Rollback reverts the index to the height
+func (x *blockIndexer) Rollback(_ context.Context, height uint64) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	tipHeight := x.tbk.Size() - 1
+	if height >= tipHeight {
+		return nil
+	}
+	if err := x.journal.Revert(tipHeight, height); err != nil {
+		return errors.Wrapf(err, "failed to roll back index from %d to %d", tipHeight, height)
+	}
+	// reload the total block and action index
+	var err error
+	if x.tbk, err = db.NewCountingIndexNX(x.kvStore, _totalBlocksBucket); err != nil {
+		return err
+	}
+	x.tac, err = db.NewCountingIndexNX(x.kvStore, _totalActionsBucket)
+	return err
 }
 
 // Height return the blockchain height
@@ -303,8 +339,8 @@ func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 	return nil
 }
 
-// commit writes the changes
-func (x *blockIndexer) commit() error {
+// commit writes the changes, which are journaled at the block
+func (x *blockIndexer) commit(blk *block.Block) error {
 	var commitErr error
 	for k, v := range x.dirtyAddr {
 		if commitErr == nil {
@@ -324,6 +360,9 @@ func (x *blockIndexer) commit() error {
 	if err := x.tac.Finalize(); err != nil {
 		return err
 	}
+	if err := x.journal.Record(x.batch, blk.Height(), blk.HashBlock()); err != nil {
+		return err
+	}
 	if err := x.kvStore.WriteBatch(x.batch); err != nil {
 		return err
 	}
@@ -260,6 +260,44 @@ func TestIndexer(t *testing.T) {
 		}
 	}
 
+	testRollback := func(kvStore db.KVStore, t *testing.T) {
+		ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+		indexer, err := NewIndexer(kvStore, hash.ZeroHash256)
+		require.NoError(err)
+		require.NoError(indexer.Start(ctx))
+		defer func() {
+			require.NoError(indexer.Stop(ctx))
+		}()
+
+		for i := 0; i < 3; i++ {
+			require.NoError(indexer.PutBlock(ctx, blks[i]))
+			h, err := indexer.Checkpoint(blks[i].Height())
+			require.NoError(err)
+			require.Equal(blks[i].HashBlock(), h)
+		}
+		require.NoError(indexer.Rollback(ctx, 1))
+		height, err := indexer.Height()
+		require.NoError(err)
+		require.EqualValues(1, height)
+		total, err := indexer.GetTotalActions()
+		require.NoError(err)
+		require.EqualValues(len(blks[0].Actions), total)
+		_, err = indexer.GetActionIndex(t2Hash[:])
+		require.Equal(db.ErrNotExist, errors.Cause(err))
+		_, err = indexer.Checkpoint(2)
+		require.Equal(db.ErrNotExist, errors.Cause(err))
+
+		// index the blocks again after rollback
+		for i := 1; i < 3; i++ {
+			require.NoError(indexer.PutBlock(ctx, blks[i]))
+		}
+		for i := range indexTests[0].actions {
+			actionCount, err := indexer.GetActionCountByAddress(indexTests[0].actions[i].addr)
+			require.NoError(err)
+			require.EqualValues(len(indexTests[0].actions[i].hashes), actionCount)
+		}
+	}
+
 	t.Run("In-memory KV indexer", func(t *testing.T) {
 		testIndexer(db.NewMemKVStore(), t)
 	})
@@ -284,4 +322,13 @@ func TestIndexer(t *testing.T) {
 		defer testutil.CleanupPath(testPath)
 		testDelete(db.NewBoltDB(cfg), t)
 	})
+
+	t.Run("In-memory KV rollback", func(t *testing.T) {
+		testRollback(db.NewMemKVStore(), t)
+	})
+	t.Run("Bolt DB rollback", func(t *testing.T) {
+		testutil.CleanupPath(testPath)
+		defer testutil.CleanupPath(testPath)
+		testRollback(db.NewBoltDB(cfg), t)
+	})
 }
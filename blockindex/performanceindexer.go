@@ -0,0 +1,416 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// the NS/bucket name here are used in performance.index.db, the performance of a delegate in an epoch is kept under
+// both the key of epoch + delegate and the key of delegate + epoch, and the number of blocks of an epoch is kept under
+// the key of epoch
+const (
+	_perfMetaNS          = "pm"
+	_perfJournalNS       = "pj"
+	_perfEpochNS         = "pe"
+	_perfDelegateNS      = "pd"
+	_perfEpochBlocksNS   = "pb"
+	_perfAddressLength   = 20
+	_perfPerformanceSize = 5 * 8
+)
+
+type (
+	// DelegatesByEpochFunc returns the delegates of the epoch in the order of the proposer rotation
+	DelegatesByEpochFunc func(uint64) ([]string, error)
+
+	// PerformanceIndexer is the indexer of the performance of the delegates in each epoch, which tracks the blocks
+	// produced, the slots missed, the gas used and the endorsements of each delegate
+	PerformanceIndexer interface {
+		blockdao.BlockIndexerWithRollback
+		// EpochPerformance returns the performance of the delegates in the epoch, in the order of address
+		EpochPerformance(uint64) ([]*DelegatePerformance, error)
+		// DelegatePerformance returns the performance of the delegate in the epochs [start, start+count)
+		DelegatePerformance(address.Address, uint64, uint64) ([]*DelegatePerformance, error)
+	}
+
+	// DelegatePerformance is the performance of a delegate in an epoch
+	DelegatePerformance struct {
+		Epoch    uint64
+		Delegate address.Address
+		// EpochBlocks is the number of the blocks indexed in the epoch
+		EpochBlocks uint64
+		// Produced is the number of the blocks produced by the delegate
+		Produced uint64
+		// Missed is the number of the slots of the delegate skipped by the blocks produced in the later rounds
+		Missed uint64
+		// GasUsed and GasLimit are the sums of the gas used and the gas limit of the blocks produced by the delegate
+		GasUsed  uint64
+		GasLimit uint64
+		// Endorsed is the number of the blocks endorsed by the delegate
+		Endorsed uint64
+	}
+
+	// performanceIndexer implements the PerformanceIndexer interface
+	performanceIndexer struct {
+		mutex            sync.RWMutex
+		kvStore          db.KVStore
+		journal          *db.Journal
+		height           uint64
+		epochNum         func(uint64) uint64
+		gasLimit         func(uint64) uint64
+		delegatesByEpoch DelegatesByEpochFunc
+	}
+)
+
+// NewPerformanceIndexer creates a new delegate performance indexer. The missed slots are counted only if the
+// delegates of the epoch are available from delegatesByEpoch
+func NewPerformanceIndexer(
+	kv db.KVStore,
+	epochNum func(uint64) uint64,
+	gasLimit func(uint64) uint64,
+	delegatesByEpoch DelegatesByEpochFunc,
+) (PerformanceIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	if _, ok := kv.(db.KVStoreWithRange); !ok {
+		return nil, errors.New("performance indexer can only be created from KVStoreWithRange")
+	}
+	if epochNum == nil || gasLimit == nil {
+		return nil, errors.New("empty epoch or gas limit function")
+	}
+	return &performanceIndexer{
+		kvStore:          kv,
+		journal:          db.NewJournal(kv, _perfJournalNS, db.DefaultJournalDepth),
+		epochNum:         epochNum,
+		gasLimit:         gasLimit,
+		delegatesByEpoch: delegatesByEpoch,
+	}, nil
+}
+
+// Start starts the performance indexer
+func (x *performanceIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	value, err := x.kvStore.Get(_perfMetaNS, []byte(CurrentHeightKey))
+	switch errors.Cause(err) {
+	case nil:
+		x.height = byteutil.BytesToUint64BigEndian(value)
+		return nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		x.height = 0
+		return nil
+	default:
+		return err
+	}
+}
+
+// Stop stops the performance indexer
+func (x *performanceIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the performance indexer
+func (x *performanceIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// PutBlock indexes the producer, the skipped proposers and the endorsers of the block
+func (x *performanceIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	height := blk.Height()
+	if height <= x.height {
+		return nil
+	}
+	if height != x.height+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height+1)
+	}
+	producer, err := address.FromString(blk.ProducerAddress())
+	if err != nil {
+		return err
+	}
+	var (
+		b     = batch.NewBatch()
+		epoch = x.epochNum(height)
+		perfs = make(map[string]*DelegatePerformance)
+		perf  = func(addr address.Address) (*DelegatePerformance, error) {
+			key := string(addr.Bytes())
+			if p, ok := perfs[key]; ok {
+				return p, nil
+			}
+			p, err := x.performance(epoch, addr)
+			if err != nil {
+				return nil, err
+			}
+			perfs[key] = p
+			return p, nil
+		}
+	)
+	p, err := perf(producer)
+	if err != nil {
+		return err
+	}
+	p.Produced++
+	p.GasUsed += blk.GasUsed()
+	p.GasLimit += x.gasLimit(height)
+	for _, addr := range x.missedProposers(epoch, height, producer.String()) {
+		if p, err = perf(addr); err != nil {
+			return err
+		}
+		p.Missed++
+	}
+	endorsers := make(map[string]struct{})
+	for _, en := range blk.Endorsements() {
+		addr := en.Endorser().Address()
+		if _, ok := endorsers[addr.String()]; ok {
+			continue
+		}
+		endorsers[addr.String()] = struct{}{}
+		if p, err = perf(addr); err != nil {
+			return err
+		}
+		p.Endorsed++
+	}
+	epochBlocks, err := x.epochBlocks(epoch)
+	if err != nil {
+		return err
+	}
+	epochKey := byteutil.Uint64ToBytesBigEndian(epoch)
+	b.Put(_perfEpochBlocksNS, epochKey, byteutil.Uint64ToBytesBigEndian(epochBlocks+1), fmt.Sprintf("failed to put blocks of epoch %d", epoch))
+	for _, key := range sortedKeys(perfs) {
+		value := perfs[key].serialize()
+		b.Put(_perfEpochNS, append(append([]byte{}, epochKey...), key...), value, "failed to put delegate performance")
+		b.Put(_perfDelegateNS, append([]byte(key), epochKey...), value, "failed to put delegate performance")
+	}
+	b.Put(_perfMetaNS, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(height), "failed to put current height")
+	if err := x.journal.Record(b, height, blk.HashBlock()); err != nil {
+		return err
+	}
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = height
+	return nil
+}
+
+// Checkpoint returns the hash of the indexed block at the height
+func (x *performanceIndexer) Checkpoint(height uint64) (hash.Hash256, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.journal.Checkpoint(height)
+}
+
+// Rollback reverts the performance index to the height
+func (x *performanceIndexer) Rollback(_ context.Context, height uint64) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	if height >= x.height {
+		return nil
+	}
+	if err := x.journal.Revert(x.height, height); err != nil {
+		return errors.Wrapf(err, "failed to roll back performance index from %d to %d", x.height, height)
+	}
+	x.height = height
+	return nil
+}
+
+// EpochPerformance returns the performance of the delegates in the epoch, in the order of address
+func (x *performanceIndexer) EpochPerformance(epoch uint64) ([]*DelegatePerformance, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	epochBlocks, err := x.epochBlocks(epoch)
+	if err != nil {
+		return nil, err
+	}
+	prefix := byteutil.Uint64ToBytesBigEndian(epoch)
+	keys, values, err := x.kvStore.Filter(_perfEpochNS, func(k, _ []byte) bool {
+		return bytes.HasPrefix(k, prefix)
+	}, prefix, append(append([]byte{}, prefix...), bytes.Repeat([]byte{0xff}, _perfAddressLength)...))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return []*DelegatePerformance{}, nil
+	default:
+		return nil, err
+	}
+	ret := make([]*DelegatePerformance, 0, len(keys))
+	for i := range keys {
+		p, err := deserializeDelegatePerformance(epoch, keys[i][len(prefix):], values[i])
+		if err != nil {
+			return nil, err
+		}
+		p.EpochBlocks = epochBlocks
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+// DelegatePerformance returns the performance of the delegate in the epochs [start, start+count), the epochs in
+// which the delegate has no record are skipped
+func (x *performanceIndexer) DelegatePerformance(delegate address.Address, start, count uint64) ([]*DelegatePerformance, error) {
+	if count == 0 {
+		return []*DelegatePerformance{}, nil
+	}
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	prefix := delegate.Bytes()
+	keys, values, err := x.kvStore.Filter(_perfDelegateNS, func(k, _ []byte) bool {
+		return bytes.HasPrefix(k, prefix)
+	}, append(append([]byte{}, prefix...), byteutil.Uint64ToBytesBigEndian(start)...),
+		append(append([]byte{}, prefix...), byteutil.Uint64ToBytesBigEndian(start+count-1)...))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return []*DelegatePerformance{}, nil
+	default:
+		return nil, err
+	}
+	ret := make([]*DelegatePerformance, 0, len(keys))
+	for i := range keys {
+		epoch := byteutil.BytesToUint64BigEndian(keys[i][len(prefix):])
+		p, err := deserializeDelegatePerformance(epoch, prefix, values[i])
+		if err != nil {
+			return nil, err
+		}
+		if p.EpochBlocks, err = x.epochBlocks(epoch); err != nil {
+			return nil, err
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+// performance returns the indexed performance of the delegate in the epoch
+func (x *performanceIndexer) performance(epoch uint64, delegate address.Address) (*DelegatePerformance, error) {
+	value, err := x.kvStore.Get(_perfEpochNS, append(byteutil.Uint64ToBytesBigEndian(epoch), delegate.Bytes()...))
+	switch errors.Cause(err) {
+	case nil:
+		return deserializeDelegatePerformance(epoch, delegate.Bytes(), value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return &DelegatePerformance{Epoch: epoch, Delegate: delegate}, nil
+	default:
+		return nil, err
+	}
+}
+
+func (x *performanceIndexer) epochBlocks(epoch uint64) (uint64, error) {
+	value, err := x.kvStore.Get(_perfEpochBlocksNS, byteutil.Uint64ToBytesBigEndian(epoch))
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64BigEndian(value), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// missedProposers returns the addresses of the proposers missing the rounds before the block is produced
+func (x *performanceIndexer) missedProposers(epoch, height uint64, producer string) []address.Address {
+	if x.delegatesByEpoch == nil {
+		return nil
+	}
+	proposers, err := x.delegatesByEpoch(epoch)
+	if err != nil {
+		log.L().Debug("Failed to get the delegates of epoch.", zap.Uint64("epoch", epoch), zap.Error(err))
+		return nil
+	}
+	missed := MissedProposers(proposers, height, producer)
+	addrs := make([]address.Address, 0, len(missed))
+	for _, proposer := range missed {
+		addr, err := address.FromString(proposer)
+		if err != nil {
+			log.L().Debug("Invalid delegate address.", zap.String("delegate", proposer), zap.Error(err))
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// MissedProposers returns the proposers of the rounds before the one in which the producer proposed the block. The
+// proposer of a round is the delegate at (height + round) % number of delegates, the same as the consensus
+func MissedProposers(proposers []string, height uint64, producer string) []string {
+	n := uint64(len(proposers))
+	for i, proposer := range proposers {
+		if proposer != producer {
+			continue
+		}
+		var (
+			base   = height % n
+			rounds = (uint64(i) + n - base) % n
+			missed = make([]string, 0, rounds)
+		)
+		for k := uint64(0); k < rounds; k++ {
+			missed = append(missed, proposers[(base+k)%n])
+		}
+		return missed
+	}
+	// the block is not produced by the delegates of the epoch
+	return nil
+}
+
+// Fullness returns the average ratio of the gas used to the gas limit of the blocks produced by the delegate
+func (p *DelegatePerformance) Fullness() float64 {
+	if p.GasLimit == 0 {
+		return 0
+	}
+	return float64(p.GasUsed) / float64(p.GasLimit)
+}
+
+// Participation returns the ratio of the blocks endorsed by the delegate to the blocks of the epoch
+func (p *DelegatePerformance) Participation() float64 {
+	if p.EpochBlocks == 0 {
+		return 0
+	}
+	return float64(p.Endorsed) / float64(p.EpochBlocks)
+}
+
+func (p *DelegatePerformance) serialize() []byte {
+	data := make([]byte, 0, _perfPerformanceSize)
+	for _, v := range []uint64{p.Produced, p.Missed, p.GasUsed, p.GasLimit, p.Endorsed} {
+		data = binary.BigEndian.AppendUint64(data, v)
+	}
+	return data
+}
+
+func deserializeDelegatePerformance(epoch uint64, delegate, data []byte) (*DelegatePerformance, error) {
+	if len(data) != _perfPerformanceSize {
+		return nil, errors.Wrapf(db.ErrInvalid, "invalid delegate performance length %d", len(data))
+	}
+	addr, err := address.FromBytes(delegate)
+	if err != nil {
+		return nil, err
+	}
+	p := &DelegatePerformance{
+		Epoch:    epoch,
+		Delegate: addr,
+	}
+	for i, v := range []*uint64{&p.Produced, &p.Missed, &p.GasUsed, &p.GasLimit, &p.Endorsed} {
+		*v = binary.BigEndian.Uint64(data[i*8:])
+	}
+	return p, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestPerformanceIndexer(t *testing.T) {
+	require := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-performance-indexer")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	dbCfg := db.DefaultConfig
+	dbCfg.DbPath = testPath
+
+	var (
+		ctx       = context.Background()
+		delegates = []string{
+			identityset.Address(1).String(),
+			identityset.Address(2).String(),
+			identityset.Address(3).String(),
+			identityset.Address(4).String(),
+		}
+		// 4 blocks in an epoch
+		epochNum = func(height uint64) uint64 { return (height-1)/4 + 1 }
+		gasLimit = func(uint64) uint64 { return 100 }
+		// the delegates of epoch 2 are not available
+		delegatesByEpoch = func(epoch uint64) ([]string, error) {
+			if epoch == 1 {
+				return delegates, nil
+			}
+			return nil, errors.New("unknown epoch")
+		}
+		newBlock = func(height uint64, producer int, endorsers ...int) *block.Block {
+			blk, err := block.NewTestingBuilder().
+				SetHeight(height).
+				SignAndBuild(identityset.PrivateKey(producer))
+			require.NoError(err)
+			footer := &iotextypes.BlockFooter{Timestamp: timestamppb.Now()}
+			for _, i := range endorsers {
+				en := endorsement.NewEndorsement(time.Now(), identityset.PrivateKey(i).PublicKey(), []byte{})
+				footer.Endorsements = append(footer.Endorsements, en.Proto())
+			}
+			require.NoError(blk.Footer.ConvertFromBlockFooterPb(footer))
+			return &blk
+		}
+	)
+	indexer, err := NewPerformanceIndexer(db.NewBoltDB(dbCfg), epochNum, gasLimit, delegatesByEpoch)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+
+	blks := []*block.Block{
+		// the proposer of round 0 is delegates[height % 4]
+		newBlock(1, 2, 1, 2, 3),
+		// delegate 3 missed the slot
+		newBlock(2, 4, 1, 1, 4),
+		// delegate 4 missed the slot
+		newBlock(3, 1),
+		newBlock(4, 1),
+		// the missed slots are not counted without the delegates
+		newBlock(5, 4),
+	}
+	for _, blk := range blks {
+		require.NoError(indexer.PutBlock(ctx, blk))
+	}
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.EqualValues(5, height)
+
+	perfs, err := indexer.EpochPerformance(1)
+	require.NoError(err)
+	require.Len(perfs, 4)
+	expected := map[string][3]uint64{
+		delegates[0]: {2, 0, 2},
+		delegates[1]: {1, 0, 1},
+		delegates[2]: {0, 1, 1},
+		delegates[3]: {1, 1, 1},
+	}
+	for _, p := range perfs {
+		e, ok := expected[p.Delegate.String()]
+		require.True(ok)
+		require.EqualValues(1, p.Epoch)
+		require.EqualValues(4, p.EpochBlocks)
+		require.Equal(e, [3]uint64{p.Produced, p.Missed, p.Endorsed})
+		require.Equal(p.Produced*100, p.GasLimit)
+	}
+
+	d4, err := address.FromString(delegates[3])
+	require.NoError(err)
+	perfs, err = indexer.DelegatePerformance(d4, 1, 2)
+	require.NoError(err)
+	require.Len(perfs, 2)
+	require.EqualValues(2, perfs[1].Epoch)
+	require.EqualValues(1, perfs[1].EpochBlocks)
+	require.EqualValues(1, perfs[1].Produced)
+	require.Zero(perfs[1].Missed)
+	require.Equal(0.25, perfs[0].Participation())
+	perfs, err = indexer.DelegatePerformance(d4, 2, 5)
+	require.NoError(err)
+	require.Len(perfs, 1)
+
+	// roll back the last block
+	require.NoError(indexer.Rollback(ctx, 4))
+	perfs, err = indexer.EpochPerformance(2)
+	require.NoError(err)
+	require.Empty(perfs)
+	perfs, err = indexer.DelegatePerformance(d4, 1, 2)
+	require.NoError(err)
+	require.Len(perfs, 1)
+	require.NoError(indexer.PutBlock(ctx, blks[4]))
+	perfs, err = indexer.EpochPerformance(2)
+	require.NoError(err)
+	require.Len(perfs, 1)
+}
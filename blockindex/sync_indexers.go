@@ -8,8 +8,12 @@ package blockindex
 import (
 	"context"
 
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/db"
 )
 
 // SyncIndexers is a special index that includes multiple indexes,
@@ -69,6 +73,51 @@ func (ig *SyncIndexers) PutBlock(ctx context.Context, blk *block.Block) error {
 	return nil
 }
 
+// Checkpoint returns the hash of the block at the height indexed by the first indexer in the group which could be
+// rolled back and has indexed the height
+func (ig *SyncIndexers) Checkpoint(height uint64) (hash.Hash256, error) {
+	for _, indexer := range ig.indexers {
+		indexerWR, ok := indexer.(blockdao.BlockIndexerWithRollback)
+		if !ok {
+			continue
+		}
+		if indexerWS, ok := indexer.(blockdao.BlockIndexerWithStart); ok && height < indexerWS.StartHeight() {
+			continue
+		}
+		tipHeight, err := indexer.Height()
+		if err != nil {
+			return hash.ZeroHash256, err
+		}
+		if height > tipHeight {
+			continue
+		}
+		return indexerWR.Checkpoint(height)
+	}
+	return hash.ZeroHash256, errors.Wrapf(db.ErrNotExist, "no checkpoint at height %d", height)
+}
+
+// Rollback reverts the indexers in the group to the height. It fails if an indexer higher than the height could not
+// be rolled back
+func (ig *SyncIndexers) Rollback(ctx context.Context, height uint64) error {
+	for i, indexer := range ig.indexers {
+		tipHeight, err := indexer.Height()
+		if err != nil {
+			return err
+		}
+		if tipHeight <= height {
+			continue
+		}
+		indexerWR, ok := indexer.(blockdao.BlockIndexerWithRollback)
+		if !ok {
+			return errors.Errorf("indexer %d at height %d cannot be rolled back to %d", i, tipHeight, height)
+		}
+		if err := indexerWR.Rollback(ctx, height); err != nil {
+			return err
+		}
+	}
+	return ig.initStartHeight()
+}
+
 // StartHeight returns the minimum start height of the indexers in the group
 func (ig *SyncIndexers) StartHeight() uint64 {
 	return ig.minStartHeight
@@ -0,0 +1,490 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// the NS/bucket name here are used in token.index.db, the balance of a holder is kept under both the key of
+// contract + holder and the key of holder + contract, and the transfers of an account or a contract are kept in the
+// counting index named by the prefix followed by the address bytes
+const (
+	_tokenMetaNS            = "km"
+	_tokenJournalNS         = "kj"
+	_tokenContractHolderNS  = "kc"
+	_tokenHolderContractNS  = "kh"
+	_tokenAccountTransfer   = "ka"
+	_tokenContractTransfer  = "kt"
+	_tokenAddressLength     = 20
+	_tokenTransferMinLength = 8 + 32 + 4 + 1 + 3*_tokenAddressLength
+)
+
+// token standards
+const (
+	// XRC20 is the standard of fungible token
+	XRC20 TokenStandard = iota + 1
+	// XRC721 is the standard of non-fungible token
+	XRC721
+)
+
+var (
+	// _tokenTransferTopic is the topic of Transfer(address,address,uint256), which is shared by XRC20 and XRC721
+	_tokenTransferTopic = hash.BytesToHash256(crypto.Keccak256([]byte("Transfer(address,address,uint256)")))
+)
+
+type (
+	// TokenStandard is the standard of a token contract
+	TokenStandard uint8
+
+	// TokenIndexer is the interface for the indexer of XRC20 and XRC721 tokens, which decodes the Transfer events
+	// from the receipts of each block into the balances of the holders and the transfer history
+	TokenIndexer interface {
+		blockdao.BlockIndexerWithRollback
+		// TokenBalances returns the balances of the tokens held by the account
+		TokenBalances(address.Address) ([]*TokenBalance, error)
+		// TokenHolders returns the number of the holders of the token contract, and the holders [start, start+count)
+		// in the order of address
+		TokenHolders(address.Address, uint64, uint64) (uint64, []*TokenBalance, error)
+		// AccountTransfers returns the number of the token transfers of the account, and its transfers
+		// [start, start+count) from the oldest to the latest
+		AccountTransfers(address.Address, uint64, uint64) (uint64, []*TokenTransfer, error)
+		// ContractTransfers returns the number of the transfers of the token contract, and its transfers
+		// [start, start+count) from the oldest to the latest
+		ContractTransfers(address.Address, uint64, uint64) (uint64, []*TokenTransfer, error)
+	}
+
+	// TokenTransfer is a Transfer event of a token contract
+	TokenTransfer struct {
+		Height     uint64
+		ActionHash hash.Hash256
+		LogIndex   uint32
+		Standard   TokenStandard
+		Contract   address.Address
+		From       address.Address
+		To         address.Address
+		// Amount is the value of XRC20 token, or the id of XRC721 token
+		Amount *big.Int
+	}
+
+	// TokenBalance is the balance of a holder of a token contract
+	TokenBalance struct {
+		Standard TokenStandard
+		Contract address.Address
+		Holder   address.Address
+		// Balance is the amount of XRC20 token, or the number of XRC721 tokens
+		Balance *big.Int
+	}
+
+	// tokenIndexer implements the TokenIndexer interface
+	tokenIndexer struct {
+		mutex   sync.RWMutex
+		kvStore db.KVStore
+		journal *db.Journal
+		height  uint64
+	}
+)
+
+// NewTokenIndexer creates a new token indexer
+func NewTokenIndexer(kv db.KVStore) (TokenIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	if _, ok := kv.(db.KVStoreWithRange); !ok {
+		return nil, errors.New("token indexer can only be created from KVStoreWithRange")
+	}
+	return &tokenIndexer{
+		kvStore: kv,
+		journal: db.NewJournal(kv, _tokenJournalNS, db.DefaultJournalDepth),
+	}, nil
+}
+
+// String returns the name of the standard
+func (s TokenStandard) String() string {
+	switch s {
+	case XRC20:
+		return "XRC20"
+	case XRC721:
+		return "XRC721"
+	default:
+		return "unknown"
+	}
+}
+
+// Start starts the token indexer
+func (x *tokenIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	value, err := x.kvStore.Get(_tokenMetaNS, []byte(CurrentHeightKey))
+	switch errors.Cause(err) {
+	case nil:
+		x.height = byteutil.BytesToUint64BigEndian(value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		x.height = 0
+	default:
+		return err
+	}
+	return nil
+}
+
+// Stop stops the token indexer
+func (x *tokenIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the token indexer
+func (x *tokenIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// PutBlock indexes the token transfers of the block
+func (x *tokenIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	height := blk.Height()
+	if height <= x.height {
+		return nil
+	}
+	if height != x.height+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height+1)
+	}
+	var (
+		b         = batch.NewBatch()
+		standards = make(map[string]TokenStandard)
+		deltas    = make(map[string]*big.Int)
+		transfers = make(map[string][]*TokenTransfer)
+		addDelta  = func(contract, holder address.Address, amount *big.Int) {
+			if isZeroAddress(holder) {
+				// minted or burned
+				return
+			}
+			key := string(contract.Bytes()) + string(holder.Bytes())
+			if _, ok := deltas[key]; !ok {
+				deltas[key] = new(big.Int)
+			}
+			deltas[key].Add(deltas[key], amount)
+		}
+		addTransfer = func(prefix string, addr address.Address, t *TokenTransfer) {
+			key := string(prefixBucket(prefix, addr.Bytes()))
+			transfers[key] = append(transfers[key], t)
+		}
+	)
+	for _, receipt := range blk.Receipts {
+		for _, l := range receipt.Logs() {
+			t, err := decodeTokenTransfer(l)
+			if err != nil || t == nil {
+				continue
+			}
+			t.Height = height
+			standards[string(t.Contract.Bytes())] = t.Standard
+			amount := t.Amount
+			if t.Standard == XRC721 {
+				amount = big.NewInt(1)
+			}
+			addDelta(t.Contract, t.From, new(big.Int).Neg(amount))
+			addDelta(t.Contract, t.To, amount)
+			addTransfer(_tokenContractTransfer, t.Contract, t)
+			if !isZeroAddress(t.From) {
+				addTransfer(_tokenAccountTransfer, t.From, t)
+			}
+			if !isZeroAddress(t.To) && !bytes.Equal(t.To.Bytes(), t.From.Bytes()) {
+				addTransfer(_tokenAccountTransfer, t.To, t)
+			}
+		}
+	}
+	for _, key := range sortedKeys(transfers) {
+		index, err := db.NewCountingIndexNX(x.kvStore, []byte(key))
+		if err != nil {
+			return err
+		}
+		if err := index.UseBatch(b); err != nil {
+			return err
+		}
+		for _, t := range transfers[key] {
+			if err := index.Add(t.Serialize(), true); err != nil {
+				return err
+			}
+		}
+		if err := index.Finalize(); err != nil {
+			return err
+		}
+	}
+	for _, key := range sortedKeys(deltas) {
+		if deltas[key].Sign() == 0 {
+			continue
+		}
+		contract, holder := []byte(key[:_tokenAddressLength]), []byte(key[_tokenAddressLength:])
+		if err := x.addTokenBalance(b, standards[string(contract)], contract, holder, deltas[key]); err != nil {
+			return err
+		}
+	}
+	b.Put(_tokenMetaNS, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(height), "failed to put current height")
+	if err := x.journal.Record(b, height, blk.HashBlock()); err != nil {
+		return err
+	}
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = height
+	return nil
+}
+
+// Checkpoint returns the hash of the indexed block at the height
+func (x *tokenIndexer) Checkpoint(height uint64) (hash.Hash256, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.journal.Checkpoint(height)
+}
+
+// Rollback reverts the token index to the height
+func (x *tokenIndexer) Rollback(_ context.Context, height uint64) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	if height >= x.height {
+		return nil
+	}
+	if err := x.journal.Revert(x.height, height); err != nil {
+		return errors.Wrapf(err, "failed to roll back token index from %d to %d", x.height, height)
+	}
+	x.height = height
+	return nil
+}
+
+// TokenBalances returns the balances of the tokens held by the account
+func (x *tokenIndexer) TokenBalances(holder address.Address) ([]*TokenBalance, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	keys, values, err := x.filterByPrefix(_tokenHolderContractNS, holder.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*TokenBalance, 0, len(keys))
+	for i := range keys {
+		balance, err := deserializeTokenBalance(keys[i][_tokenAddressLength:], keys[i][:_tokenAddressLength], values[i])
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, balance)
+	}
+	return ret, nil
+}
+
+// TokenHolders returns the number of the holders of the token contract, and the holders [start, start+count)
+func (x *tokenIndexer) TokenHolders(contract address.Address, start, count uint64) (uint64, []*TokenBalance, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	keys, values, err := x.filterByPrefix(_tokenContractHolderNS, contract.Bytes())
+	if err != nil {
+		return 0, nil, err
+	}
+	total := uint64(len(keys))
+	if start >= total {
+		return total, nil, nil
+	}
+	end := min(start+count, total)
+	ret := make([]*TokenBalance, 0, end-start)
+	for i := start; i < end; i++ {
+		balance, err := deserializeTokenBalance(keys[i][:_tokenAddressLength], keys[i][_tokenAddressLength:], values[i])
+		if err != nil {
+			return 0, nil, err
+		}
+		ret = append(ret, balance)
+	}
+	return total, ret, nil
+}
+
+// AccountTransfers returns the number of the token transfers of the account, and its transfers [start, start+count)
+func (x *tokenIndexer) AccountTransfers(addr address.Address, start, count uint64) (uint64, []*TokenTransfer, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.transfers(prefixBucket(_tokenAccountTransfer, addr.Bytes()), start, count)
+}
+
+// ContractTransfers returns the number of the transfers of the token contract, and its transfers [start, start+count)
+func (x *tokenIndexer) ContractTransfers(contract address.Address, start, count uint64) (uint64, []*TokenTransfer, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.transfers(prefixBucket(_tokenContractTransfer, contract.Bytes()), start, count)
+}
+
+func (x *tokenIndexer) transfers(name []byte, start, count uint64) (uint64, []*TokenTransfer, error) {
+	index, err := db.GetCountingIndex(x.kvStore, name)
+	if err != nil {
+		if errors.Cause(err) == db.ErrBucketNotExist || errors.Cause(err) == db.ErrNotExist {
+			return 0, nil, nil
+		}
+		return 0, nil, err
+	}
+	total := index.Size()
+	if start >= total || count == 0 {
+		return total, nil, nil
+	}
+	values, err := index.Range(start, min(count, total-start))
+	if err != nil {
+		return 0, nil, err
+	}
+	ret := make([]*TokenTransfer, 0, len(values))
+	for _, v := range values {
+		t, err := deserializeTokenTransfer(v)
+		if err != nil {
+			return 0, nil, err
+		}
+		ret = append(ret, t)
+	}
+	return total, ret, nil
+}
+
+// addTokenBalance adds the delta to the balance of the holder, the balance is removed once it drops to 0. The
+// balance does not go below 0, in case the token changes the balances without Transfer events
+func (x *tokenIndexer) addTokenBalance(b batch.KVStoreBatch, standard TokenStandard, contract, holder []byte, delta *big.Int) error {
+	var (
+		chKey   = append(append([]byte{}, contract...), holder...)
+		hcKey   = append(append([]byte{}, holder...), contract...)
+		balance = new(big.Int)
+	)
+	value, err := x.kvStore.Get(_tokenContractHolderNS, chKey)
+	switch errors.Cause(err) {
+	case nil:
+		if len(value) == 0 {
+			return errors.Wrap(db.ErrInvalid, "invalid token balance")
+		}
+		balance.SetBytes(value[1:])
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return err
+	}
+	if balance.Add(balance, delta).Sign() <= 0 {
+		b.Delete(_tokenContractHolderNS, chKey, "failed to delete token balance")
+		b.Delete(_tokenHolderContractNS, hcKey, "failed to delete token balance")
+		return nil
+	}
+	value = append([]byte{byte(standard)}, balance.Bytes()...)
+	b.Put(_tokenContractHolderNS, chKey, value, "failed to put token balance")
+	b.Put(_tokenHolderContractNS, hcKey, value, "failed to put token balance")
+	return nil
+}
+
+// filterByPrefix returns the keys and the values in the namespace which start with the address
+func (x *tokenIndexer) filterByPrefix(ns string, addr []byte) ([][]byte, [][]byte, error) {
+	keys, values, err := x.kvStore.Filter(ns, func(k, _ []byte) bool {
+		return bytes.HasPrefix(k, addr)
+	}, addr, append(append([]byte{}, addr...), bytes.Repeat([]byte{0xff}, _tokenAddressLength)...))
+	if cause := errors.Cause(err); cause == db.ErrBucketNotExist || cause == db.ErrNotExist {
+		return nil, nil, nil
+	}
+	return keys, values, err
+}
+
+// decodeTokenTransfer decodes the Transfer event of XRC20 or XRC721, it returns nil if the log is not such an event
+func decodeTokenTransfer(l *action.Log) (*TokenTransfer, error) {
+	if len(l.Topics) < 3 || l.Topics[0] != _tokenTransferTopic {
+		return nil, nil
+	}
+	t := &TokenTransfer{
+		ActionHash: l.ActionHash,
+		LogIndex:   l.Index,
+	}
+	switch {
+	case len(l.Topics) == 3 && len(l.Data) == 32:
+		t.Standard = XRC20
+		t.Amount = new(big.Int).SetBytes(l.Data)
+	case len(l.Topics) == 4 && len(l.Data) == 0:
+		t.Standard = XRC721
+		t.Amount = new(big.Int).SetBytes(l.Topics[3][:])
+	default:
+		return nil, nil
+	}
+	var err error
+	if t.Contract, err = address.FromString(l.Address); err != nil {
+		return nil, err
+	}
+	if t.From, err = address.FromBytes(l.Topics[1][32-_tokenAddressLength:]); err != nil {
+		return nil, err
+	}
+	if t.To, err = address.FromBytes(l.Topics[2][32-_tokenAddressLength:]); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func isZeroAddress(addr address.Address) bool {
+	return bytes.Equal(addr.Bytes(), make([]byte, _tokenAddressLength))
+}
+
+// Serialize encodes the token transfer into bytes
+func (t *TokenTransfer) Serialize() []byte {
+	data := byteutil.Uint64ToBytesBigEndian(t.Height)
+	data = append(data, t.ActionHash[:]...)
+	data = binary.BigEndian.AppendUint32(data, t.LogIndex)
+	data = append(data, byte(t.Standard))
+	for _, addr := range []address.Address{t.Contract, t.From, t.To} {
+		data = append(data, addr.Bytes()...)
+	}
+	return append(data, t.Amount.Bytes()...)
+}
+
+func deserializeTokenTransfer(data []byte) (*TokenTransfer, error) {
+	if len(data) < _tokenTransferMinLength {
+		return nil, errors.Wrap(db.ErrInvalid, "invalid token transfer")
+	}
+	t := &TokenTransfer{
+		Height:     byteutil.BytesToUint64BigEndian(data[:8]),
+		ActionHash: hash.BytesToHash256(data[8:40]),
+		LogIndex:   binary.BigEndian.Uint32(data[40:44]),
+		Standard:   TokenStandard(data[44]),
+	}
+	data = data[45:]
+	addrs := make([]address.Address, 3)
+	for i := range addrs {
+		addr, err := address.FromBytes(data[:_tokenAddressLength])
+		if err != nil {
+			return nil, err
+		}
+		addrs[i], data = addr, data[_tokenAddressLength:]
+	}
+	t.Contract, t.From, t.To = addrs[0], addrs[1], addrs[2]
+	t.Amount = new(big.Int).SetBytes(data)
+	return t, nil
+}
+
+func deserializeTokenBalance(contract, holder, value []byte) (*TokenBalance, error) {
+	if len(value) == 0 {
+		return nil, errors.Wrap(db.ErrInvalid, "invalid token balance")
+	}
+	contractAddr, err := address.FromBytes(contract)
+	if err != nil {
+		return nil, err
+	}
+	holderAddr, err := address.FromBytes(holder)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenBalance{
+		Standard: TokenStandard(value[0]),
+		Contract: contractAddr,
+		Holder:   holderAddr,
+		Balance:  new(big.Int).SetBytes(value[1:]),
+	}, nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestTokenIndexer(t *testing.T) {
+	require := require.New(t)
+
+	testPath, err := testutil.PathOfTempFile("test-token-indexer")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	dbCfg := db.DefaultConfig
+	dbCfg.DbPath = testPath
+
+	var (
+		ctx     = context.Background()
+		erc20   = identityset.Address(30)
+		erc721  = identityset.Address(31)
+		alice   = identityset.Address(1)
+		bob     = identityset.Address(2)
+		zero, _ = address.FromBytes(make([]byte, 20))
+		topic   = func(addr address.Address) hash.Hash256 {
+			return hash.BytesToHash256(common.LeftPadBytes(addr.Bytes(), 32))
+		}
+		transfer = func(contract, from, to address.Address, amount int64, nft bool) *action.Log {
+			l := &action.Log{
+				Address: contract.String(),
+				Topics:  []hash.Hash256{_tokenTransferTopic, topic(from), topic(to)},
+			}
+			if nft {
+				l.Topics = append(l.Topics, hash.BytesToHash256(big.NewInt(amount).Bytes()))
+			} else {
+				l.Data = common.LeftPadBytes(big.NewInt(amount).Bytes(), 32)
+			}
+			return l
+		}
+		newBlock = func(height uint64, logs ...*action.Log) *block.Block {
+			receipt := &action.Receipt{}
+			receipt.AddLogs(logs...)
+			blk, err := block.NewTestingBuilder().
+				SetHeight(height).
+				SetReceipts([]*action.Receipt{receipt}).
+				SignAndBuild(identityset.PrivateKey(27))
+			require.NoError(err)
+			return &blk
+		}
+	)
+	blks := []*block.Block{
+		newBlock(1,
+			transfer(erc20, zero, alice, 100, false),
+			transfer(erc721, zero, alice, 7, true),
+			transfer(erc721, zero, alice, 8, true),
+		),
+		newBlock(2,
+			transfer(erc20, alice, bob, 40, false),
+			transfer(erc721, alice, bob, 7, true),
+			// not a transfer event
+			&action.Log{Address: erc20.String(), Topics: []hash.Hash256{hash.Hash256b([]byte("Approval"))}},
+		),
+		newBlock(3,
+			transfer(erc20, bob, zero, 40, false),
+		),
+	}
+
+	indexer, err := NewTokenIndexer(db.NewBoltDB(dbCfg))
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+	for _, blk := range blks {
+		require.NoError(indexer.PutBlock(ctx, blk))
+	}
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.EqualValues(3, height)
+
+	balances, err := indexer.TokenBalances(alice)
+	require.NoError(err)
+	require.Len(balances, 2)
+	for _, b := range balances {
+		switch b.Contract.String() {
+		case erc20.String():
+			require.Equal(XRC20, b.Standard)
+			require.Equal(big.NewInt(60), b.Balance)
+		case erc721.String():
+			require.Equal(XRC721, b.Standard)
+			require.Equal(big.NewInt(1), b.Balance)
+		default:
+			require.Fail("unexpected contract")
+		}
+	}
+	// bob burned all the XRC20 tokens
+	balances, err = indexer.TokenBalances(bob)
+	require.NoError(err)
+	require.Len(balances, 1)
+	require.Equal(erc721.String(), balances[0].Contract.String())
+
+	total, holders, err := indexer.TokenHolders(erc721, 0, 10)
+	require.NoError(err)
+	require.EqualValues(2, total)
+	require.Len(holders, 2)
+	total, holders, err = indexer.TokenHolders(erc20, 0, 10)
+	require.NoError(err)
+	require.EqualValues(1, total)
+	require.Equal(alice.String(), holders[0].Holder.String())
+
+	total, transfers, err := indexer.ContractTransfers(erc20, 1, 10)
+	require.NoError(err)
+	require.EqualValues(3, total)
+	require.Len(transfers, 2)
+	require.Equal(alice.String(), transfers[0].From.String())
+	require.Equal(bob.String(), transfers[0].To.String())
+	require.Equal(big.NewInt(40), transfers[0].Amount)
+	require.EqualValues(2, transfers[0].Height)
+	total, transfers, err = indexer.AccountTransfers(alice, 0, 10)
+	require.NoError(err)
+	require.EqualValues(5, total)
+	require.Len(transfers, 5)
+	require.Equal(XRC721, transfers[1].Standard)
+	require.Equal(big.NewInt(7), transfers[1].Amount)
+	total, transfers, err = indexer.AccountTransfers(zero, 0, 10)
+	require.NoError(err)
+	require.Zero(total)
+	require.Empty(transfers)
+
+	// roll back to height 1
+	require.NoError(indexer.Rollback(ctx, 1))
+	balances, err = indexer.TokenBalances(bob)
+	require.NoError(err)
+	require.Empty(balances)
+	total, _, err = indexer.AccountTransfers(alice, 0, 10)
+	require.NoError(err)
+	require.EqualValues(3, total)
+	total, holders, err = indexer.TokenHolders(erc20, 0, 10)
+	require.NoError(err)
+	require.EqualValues(1, total)
+	require.Equal(big.NewInt(100), holders[0].Balance)
+	require.NoError(indexer.PutBlock(ctx, blks[1]))
+	h, err := indexer.Checkpoint(2)
+	require.NoError(err)
+	require.Equal(blks[1].HashBlock(), h)
+}
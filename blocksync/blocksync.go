@@ -20,7 +20,6 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
-	"github.com/iotexproject/iotex-core/v2/pkg/fastrand"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/routine"
@@ -53,6 +52,8 @@ type (
 		ProcessBlock(context.Context, string, *block.Block) error
 		// SyncStatus report block sync status
 		SyncStatus() (startingHeight uint64, currentHeight uint64, targetHeight uint64, syncSpeedDesc string)
+		// DownloadedHeight returns the height up to which the blocks are downloaded, including the ones pending commit
+		DownloadedHeight() uint64
 	}
 
 	dummyBlockSync struct{}
@@ -68,9 +69,13 @@ type (
 		p2pNeighbor          Neighbors
 		unicastOutbound      UniCastOutbound
 		blockP2pPeer         BlockPeer
+		checkpoints          *Checkpoints
 
+		requester     *requester
+		requesting    atomic.Bool
 		syncTask      *routine.RecurringTask
 		syncStageTask *routine.RecurringTask
+		retryTask     *routine.RecurringTask
 
 		syncStageHeight   uint64
 		syncBlockIncrease uint64
@@ -124,6 +129,10 @@ func (*dummyBlockSync) SyncStatus() (uint64, uint64, uint64, string) {
 	return 0, 0, 0, ""
 }
 
+func (*dummyBlockSync) DownloadedHeight() uint64 {
+	return 0
+}
+
 func (*dummyBlockSync) BuildReport() string {
 	return ""
 }
@@ -138,6 +147,10 @@ func NewBlockSyncer(
 	uniCastHandler UniCastOutbound,
 	blockP2pPeer BlockPeer,
 ) (BlockSync, error) {
+	checkpoints, err := NewCheckpoints(cfg.Checkpoints)
+	if err != nil {
+		return nil, err
+	}
 	bs := &blockSyncer{
 		cfg:                  cfg,
 		lastTipUpdateTime:    time.Now(),
@@ -148,11 +161,16 @@ func NewBlockSyncer(
 		p2pNeighbor:          p2pNeighbor,
 		unicastOutbound:      uniCastHandler,
 		blockP2pPeer:         blockP2pPeer,
+		checkpoints:          checkpoints,
 		targetHeight:         0,
+		requester:            newRequester(cfg.MaxInflightPerPeer, cfg.RequestTimeout, cfg.Interval),
 	}
 	if bs.cfg.Interval != 0 {
 		bs.syncTask = routine.NewRecurringTask(bs.sync, bs.cfg.Interval)
 		bs.syncStageTask = routine.NewRecurringTask(bs.syncStageChecker, bs.cfg.Interval)
+		if bs.cfg.RequestTimeout != 0 {
+			bs.retryTask = routine.NewRecurringTask(bs.retry, bs.cfg.RequestTimeout)
+		}
 	}
 	atomic.StoreUint64(&bs.syncBlockIncrease, 0)
 	return bs, nil
@@ -199,12 +217,27 @@ func (bs *blockSyncer) sync() {
 	log.L().Info("block sync intervals.",
 		zap.Any("intervals", intervals),
 		zap.Uint64("targetHeight", targetHeight))
-	for i, interval := range intervals {
-		bs.requestBlock(context.Background(), interval.Start, interval.End, bs.cfg.MaxRepeat-i/bs.cfg.RepeatDecayStep)
+	bs.requestBlocks(context.Background())
+}
+
+// retry requests the blocks again from other peers if any peer does not respond in time
+func (bs *blockSyncer) retry() {
+	if bs.requester.expire(time.Now()) > 0 {
+		bs.requestBlocks(context.Background())
 	}
 }
 
-func (bs *blockSyncer) requestBlock(ctx context.Context, start uint64, end uint64, repeat int) {
+// requestBlocks stripes the intervals to sync across the peers, and sends the requests to the peers in parallel
+func (bs *blockSyncer) requestBlocks(ctx context.Context) {
+	if !bs.requesting.CompareAndSwap(false, true) {
+		return
+	}
+	defer bs.requesting.Store(false)
+	tip := bs.tipHeightHandler()
+	intervals := bs.buf.GetBlocksIntervalsToSync(tip, bs.TargetHeight())
+	if len(intervals) == 0 {
+		return
+	}
 	peers, err := bs.p2pNeighbor()
 	if err != nil {
 		log.L().Error("failed to get neighbours", zap.Error(err))
@@ -214,22 +247,31 @@ func (bs *blockSyncer) requestBlock(ctx context.Context, start uint64, end uint6
 		log.L().Error("no peers")
 		return
 	}
-	if repeat < 2 {
-		repeat = 2
-	}
-	if repeat > len(peers) {
-		repeat = len(peers)
+	assignments := bs.requester.assign(tip, intervals, peers, func(i int) int {
+		return bs.cfg.MaxRepeat - i/bs.cfg.RepeatDecayStep
+	}, time.Now())
+	byPeer := make(map[peer.ID][]blockAssignment)
+	for _, a := range assignments {
+		byPeer[a.peer.ID] = append(byPeer[a.peer.ID], a)
 	}
-	for i := 0; i < repeat; i++ {
-		peer := peers[fastrand.Uint32n(uint32(len(peers)))]
-		if err := bs.unicastOutbound(
-			ctx,
-			peer,
-			&iotexrpc.BlockSync{Start: start, End: end},
-		); err != nil {
-			log.L().Error("failed to request blocks", zap.Error(err), zap.String("peer", peer.ID.String()), zap.Uint64("start", start), zap.Uint64("end", end))
-		}
+	var wg sync.WaitGroup
+	for _, reqs := range byPeer {
+		wg.Add(1)
+		go func(reqs []blockAssignment) {
+			defer wg.Done()
+			for _, a := range reqs {
+				if err := bs.unicastOutbound(
+					ctx,
+					a.peer,
+					&iotexrpc.BlockSync{Start: a.start, End: a.end},
+				); err != nil {
+					bs.requester.onFailure(a.peer.ID.String(), a.start)
+					log.L().Error("failed to request blocks", zap.Error(err), zap.String("peer", a.peer.ID.String()), zap.Uint64("start", a.start), zap.Uint64("end", a.end))
+				}
+			}
+		}(reqs)
 	}
+	wg.Wait()
 }
 
 func (bs *blockSyncer) TargetHeight() uint64 {
@@ -247,7 +289,12 @@ func (bs *blockSyncer) Start(ctx context.Context) error {
 		}
 	}
 	if bs.syncStageTask != nil {
-		return bs.syncStageTask.Start(ctx)
+		if err := bs.syncStageTask.Start(ctx); err != nil {
+			return err
+		}
+	}
+	if bs.retryTask != nil {
+		return bs.retryTask.Start(ctx)
 	}
 	return nil
 }
@@ -255,6 +302,11 @@ func (bs *blockSyncer) Start(ctx context.Context) error {
 // Stop stops a block syncer
 func (bs *blockSyncer) Stop(ctx context.Context) error {
 	log.L().Debug("Stopping block syncer.")
+	if bs.retryTask != nil {
+		if err := bs.retryTask.Stop(ctx); err != nil {
+			return err
+		}
+	}
 	if bs.syncStageTask != nil {
 		if err := bs.syncStageTask.Stop(ctx); err != nil {
 			return err
@@ -272,7 +324,17 @@ func (bs *blockSyncer) ProcessBlock(ctx context.Context, peer string, blk *block
 	if blk == nil {
 		return errors.New("block is nil")
 	}
+	if err := bs.checkpoints.Verify(blk); err != nil {
+		bs.blockP2pPeer(peer)
+		return err
+	}
 
+	// request the next blocks once a peer completes its request, instead of waiting for the next sync
+	if bs.requester.onBlock(peer, blk.Height()) {
+		defer func() {
+			go bs.requestBlocks(context.Background())
+		}()
+	}
 	tip := bs.tipHeightHandler()
 	added, targetHeight := bs.buf.AddBlock(tip, newPeerBlock(peer, blk))
 	bs.mu.Lock()
@@ -345,6 +407,11 @@ func (bs *blockSyncer) SyncStatus() (uint64, uint64, uint64, string) {
 	return bs.startingHeight, bs.tipHeightHandler(), bs.targetHeight, syncSpeedDesc
 }
 
+// DownloadedHeight returns the height up to which the blocks are downloaded, including the ones in buffer
+func (bs *blockSyncer) DownloadedHeight() uint64 {
+	return bs.buf.ContiguousHeight(bs.tipHeightHandler())
+}
+
 // BuildReport builds a report of block syncer
 func (bs *blockSyncer) BuildReport() string {
 	startingHeight, tipHeight, targetHeight, syncSpeedDesc := bs.SyncStatus()
@@ -61,6 +61,19 @@ func (b *blockBuffer) AddBlock(tipHeight uint64, blk *peerBlock) (bool, uint64)
 	return true, blkHeight
 }
 
+// ContiguousHeight returns the height up to which the blocks after the tip height are all in buffer
+func (b *blockBuffer) ContiguousHeight(tipHeight uint64) uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	h := tipHeight
+	for {
+		if _, ok := b.blockQueues[h+1]; !ok {
+			return h
+		}
+		h++
+	}
+}
+
 // GetBlocksIntervalsToSync returns groups of syncBlocksInterval are missing upto targetHeight.
 func (b *blockBuffer) GetBlocksIntervalsToSync(confirmedHeight uint64, targetHeight uint64) []syncBlocksInterval {
 	b.mu.RLock()
@@ -267,3 +267,17 @@ func TestBlockBufferGetBlocksIntervalsToSync(t *testing.T) {
 	// There should always have at least 1 interval range to sync
 	assert.Len(b.GetBlocksIntervalsToSync(chain.TipHeight(), 0), 1)
 }
+
+func TestBlockBufferContiguousHeight(t *testing.T) {
+	require := require.New(t)
+	b := newBlockBuffer(16, 8)
+	require.Equal(uint64(5), b.ContiguousHeight(5))
+	for _, h := range []uint64{6, 7, 9} {
+		blk, err := block.NewTestingBuilder().SetHeight(h).SignAndBuild(identityset.PrivateKey(0))
+		require.NoError(err)
+		added, _ := b.AddBlock(5, newPeerBlock("peer", &blk))
+		require.True(added)
+	}
+	require.Equal(uint64(7), b.ContiguousHeight(5))
+	require.Equal(uint64(9), b.ContiguousHeight(8))
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"strings"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+// ErrCheckpointMismatch indicates the block conflicts with a checkpoint
+var ErrCheckpointMismatch = errors.New("block mismatches checkpoint")
+
+type (
+	// Checkpoint is a trusted block hash at a height
+	Checkpoint struct {
+		Height uint64 `yaml:"height"`
+		Hash   string `yaml:"hash"`
+	}
+
+	// Checkpoints anchors the chain to the trusted blocks. A block conflicting with a checkpoint is refused, so that
+	// a fork of the chain below the highest checkpoint can never be committed
+	Checkpoints struct {
+		hashes  map[uint64]hash.Hash256
+		highest uint64
+	}
+)
+
+// NewCheckpoints creates the checkpoints from the config
+func NewCheckpoints(cps []Checkpoint) (*Checkpoints, error) {
+	c := &Checkpoints{
+		hashes: make(map[uint64]hash.Hash256, len(cps)),
+	}
+	for _, cp := range cps {
+		if cp.Height == 0 {
+			return nil, errors.New("checkpoint height must be positive")
+		}
+		if len(strings.TrimPrefix(cp.Hash, "0x")) != 2*len(hash.ZeroHash256) {
+			return nil, errors.Errorf("invalid checkpoint hash length at height %d", cp.Height)
+		}
+		h, err := hash.HexStringToHash256(cp.Hash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid checkpoint hash at height %d", cp.Height)
+		}
+		if existing, ok := c.hashes[cp.Height]; ok && existing != h {
+			return nil, errors.Errorf("conflicting checkpoints at height %d", cp.Height)
+		}
+		c.hashes[cp.Height] = h
+		c.highest = max(c.highest, cp.Height)
+	}
+	return c, nil
+}
+
+// Verify returns ErrCheckpointMismatch if the block is at the height of a checkpoint with a different hash
+func (c *Checkpoints) Verify(blk *block.Block) error {
+	if c == nil {
+		return nil
+	}
+	expected, ok := c.hashes[blk.Height()]
+	if !ok {
+		return nil
+	}
+	if h := blk.HashBlock(); h != expected {
+		return errors.Wrapf(ErrCheckpointMismatch, "height %d, expected hash %x, got %x", blk.Height(), expected, h)
+	}
+	return nil
+}
+
+// Trusted returns true if the height is not above the highest checkpoint, whose block can only be committed on top of
+// the checkpointed chain. No height is trusted if there is no checkpoint
+func (c *Checkpoints) Trusted(height uint64) bool {
+	return c != nil && c.highest > 0 && height <= c.highest
+}
+
+// Highest returns the highest checkpoint height, or 0 if there is no checkpoint
+func (c *Checkpoints) Highest() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.highest
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestCheckpoints(t *testing.T) {
+	require := require.New(t)
+	blk, err := block.NewTestingBuilder().SetHeight(10).SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	fork, err := block.NewTestingBuilder().SetHeight(10).SignAndBuild(identityset.PrivateKey(1))
+	require.NoError(err)
+	other, err := block.NewTestingBuilder().SetHeight(5).SignAndBuild(identityset.PrivateKey(1))
+	require.NoError(err)
+	h := blk.HashBlock()
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewCheckpoints([]Checkpoint{{Height: 0, Hash: hex.EncodeToString(h[:])}})
+		require.Error(err)
+		_, err = NewCheckpoints([]Checkpoint{{Height: 10, Hash: "0x1234"}})
+		require.Error(err)
+		_, err = NewCheckpoints([]Checkpoint{
+			{Height: 10, Hash: hex.EncodeToString(h[:])},
+			{Height: 10, Hash: "0x" + hex.EncodeToString(make([]byte, 32))},
+		})
+		require.Error(err)
+	})
+	t.Run("verify", func(t *testing.T) {
+		cps, err := NewCheckpoints([]Checkpoint{{Height: 10, Hash: "0x" + hex.EncodeToString(h[:])}})
+		require.NoError(err)
+		require.NoError(cps.Verify(&blk))
+		require.NoError(cps.Verify(&other))
+		require.Equal(ErrCheckpointMismatch, errors.Cause(cps.Verify(&fork)))
+		require.True(cps.Trusted(5))
+		require.True(cps.Trusted(10))
+		require.False(cps.Trusted(11))
+		require.Equal(uint64(10), cps.Highest())
+	})
+	t.Run("none", func(t *testing.T) {
+		cps, err := NewCheckpoints(nil)
+		require.NoError(err)
+		require.NoError(cps.Verify(&fork))
+		require.False(cps.Trusted(0))
+		require.False(cps.Trusted(1))
+		require.Zero(cps.Highest())
+		var nilCps *Checkpoints
+		require.NoError(nilCps.Verify(&fork))
+		require.False(nilCps.Trusted(1))
+	})
+	t.Run("refuse", func(t *testing.T) {
+		cfg := DefaultConfig
+		cfg.Interval = 0
+		cfg.Checkpoints = []Checkpoint{{Height: 10, Hash: hex.EncodeToString(h[:])}}
+		var blocked []string
+		bs, err := NewBlockSyncer(cfg,
+			func() uint64 { return 9 },
+			func(uint64) (*block.Block, error) { return nil, nil },
+			func(*block.Block) error { return nil },
+			nil, nil,
+			func(pid string) { blocked = append(blocked, pid) },
+		)
+		require.NoError(err)
+		require.Equal(ErrCheckpointMismatch, errors.Cause(bs.ProcessBlock(context.Background(), "peer1", &fork)))
+		require.Equal([]string{"peer1"}, blocked)
+	})
+}
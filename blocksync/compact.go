@@ -0,0 +1,273 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+var _compactBlockMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_compact_block_relay",
+		Help: "Compact block relay statistics",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(_compactBlockMtc)
+}
+
+type (
+	// ActionByHash returns the action of a given hash
+	ActionByHash func(hash.Hash256) (*action.SealedEnvelope, error)
+	// RequestAction requests the action of a given hash from the neighbors
+	RequestAction func(context.Context, hash.Hash256)
+
+	// CompactRelayConfig is the config of compact block relay, in which the blocks are broadcast with the hashes of
+	// the actions, and reconstructed with the actions in the actpool. It should only be enabled after all the nodes
+	// of the network support compact blocks
+	CompactRelayConfig struct {
+		Enabled bool `yaml:"enabled"`
+		// MaxPendingBlocks is the max number of blocks waiting for the missing actions
+		MaxPendingBlocks int `yaml:"maxPendingBlocks"`
+		// PendingTTL is the duration a block waits for the missing actions, after which it is dropped and left to
+		// block sync
+		PendingTTL time.Duration `yaml:"pendingTTL"`
+		// RecentActions is the number of actions of recent blocks kept to serve the action requests of the peers,
+		// since they are removed from the actpool once the block is committed
+		RecentActions int `yaml:"recentActions"`
+	}
+
+	// CompactBlockRelay converts blocks to compact blocks, and reconstructs the full blocks of the compact ones
+	CompactBlockRelay struct {
+		cfg           CompactRelayConfig
+		deserializer  *block.Deserializer
+		actionByHash  ActionByHash
+		requestAction RequestAction
+		recent        cache.LRUCache
+
+		mutex   sync.Mutex
+		pending map[hash.Hash256]*pendingCompactBlock
+		// waiting maps the missing actions to the hashes of the blocks waiting for them
+		waiting map[hash.Hash256][]hash.Hash256
+	}
+
+	pendingCompactBlock struct {
+		peer     string
+		pb       *iotextypes.Block
+		actions  map[hash.Hash256]*action.SealedEnvelope
+		missing  map[hash.Hash256]struct{}
+		deadline time.Time
+	}
+
+	// ReconstructedBlock is a full block reconstructed from a compact block received from the peer
+	ReconstructedBlock struct {
+		Peer  string
+		Block *iotextypes.Block
+	}
+)
+
+// NewCompactBlockRelay creates a compact block relay
+func NewCompactBlockRelay(
+	cfg CompactRelayConfig,
+	deserializer *block.Deserializer,
+	actionByHash ActionByHash,
+	requestAction RequestAction,
+) *CompactBlockRelay {
+	recent := cache.NewDummyLruCache()
+	if cfg.RecentActions > 0 {
+		recent = cache.NewThreadSafeLruCache(cfg.RecentActions)
+	}
+	return &CompactBlockRelay{
+		cfg:           cfg,
+		deserializer:  deserializer,
+		actionByHash:  actionByHash,
+		requestAction: requestAction,
+		recent:        recent,
+		pending:       make(map[hash.Hash256]*pendingCompactBlock),
+		waiting:       make(map[hash.Hash256][]hash.Hash256),
+	}
+}
+
+// CompactBlockPb converts the block protobuf to broadcast to the compact one
+func (r *CompactBlockRelay) CompactBlockPb(pb *iotextypes.Block) (*iotextypes.Block, error) {
+	blk, err := r.deserializer.FromBlockProto(pb)
+	if err != nil {
+		return nil, err
+	}
+	r.addRecent(blk.Actions...)
+	return blk.ConvertToCompactBlockPb()
+}
+
+// Action returns the action of a recent block
+func (r *CompactBlockRelay) Action(h hash.Hash256) (*action.SealedEnvelope, bool) {
+	v, ok := r.recent.Get(h)
+	if !ok {
+		return nil, false
+	}
+	return v.(*action.SealedEnvelope), true
+}
+
+// ReceiveBlock reconstructs the full block of the compact block protobuf. It returns nil if some actions are
+// missing, which are requested from the neighbors, and the block is returned by ReceiveAction once they are received
+func (r *CompactBlockRelay) ReceiveBlock(ctx context.Context, peer string, pb *iotextypes.Block) (*iotextypes.Block, error) {
+	if !block.IsCompactBlockPb(pb) {
+		return pb, nil
+	}
+	var header block.Header
+	if err := header.LoadFromBlockHeaderProto(pb.GetHeader()); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize block header")
+	}
+	blkHash := header.HashBlock()
+	pending := &pendingCompactBlock{
+		peer:    peer,
+		pb:      pb,
+		actions: make(map[hash.Hash256]*action.SealedEnvelope),
+		missing: make(map[hash.Hash256]struct{}),
+	}
+	for _, h := range block.CompactBlockActionHashes(pb) {
+		if act, ok := r.Action(h); ok {
+			pending.actions[h] = act
+			continue
+		}
+		act, err := r.actionByHash(h)
+		switch errors.Cause(err) {
+		case nil:
+			pending.actions[h] = act
+		case action.ErrNotFound:
+			pending.missing[h] = struct{}{}
+		default:
+			return nil, err
+		}
+	}
+	if len(pending.missing) == 0 {
+		_compactBlockMtc.WithLabelValues("reconstructed").Inc()
+		return r.fill(pending)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.pending[blkHash]; ok {
+		return nil, nil
+	}
+	r.removeExpired(time.Now())
+	if len(r.pending) >= r.cfg.MaxPendingBlocks {
+		_compactBlockMtc.WithLabelValues("dropped").Inc()
+		return nil, errors.Errorf("too many pending compact blocks, dropping block %x", blkHash)
+	}
+	pending.deadline = time.Now().Add(r.cfg.PendingTTL)
+	r.pending[blkHash] = pending
+	for h := range pending.missing {
+		r.waiting[h] = append(r.waiting[h], blkHash)
+		r.requestAction(ctx, h)
+	}
+	_compactBlockMtc.WithLabelValues("pending").Inc()
+	log.L().Debug("waiting for missing actions of compact block",
+		log.Hex("block", blkHash[:]),
+		zap.Uint64("height", header.Height()),
+		zap.Int("missing", len(pending.missing)),
+	)
+	return nil, nil
+}
+
+// ReceiveAction fills the action into the pending compact blocks, and returns the blocks completed by it
+func (r *CompactBlockRelay) ReceiveAction(act *action.SealedEnvelope) ([]*ReconstructedBlock, error) {
+	h, err := act.Hash()
+	if err != nil {
+		return nil, err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.removeExpired(time.Now())
+	blkHashes, ok := r.waiting[h]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.waiting, h)
+	var blks []*ReconstructedBlock
+	for _, blkHash := range blkHashes {
+		pending, ok := r.pending[blkHash]
+		if !ok {
+			continue
+		}
+		delete(pending.missing, h)
+		pending.actions[h] = act
+		if len(pending.missing) > 0 {
+			continue
+		}
+		delete(r.pending, blkHash)
+		pb, err := r.fill(pending)
+		if err != nil {
+			return nil, err
+		}
+		_compactBlockMtc.WithLabelValues("completed").Inc()
+		blks = append(blks, &ReconstructedBlock{Peer: pending.peer, Block: pb})
+	}
+	return blks, nil
+}
+
+func (r *CompactBlockRelay) fill(pending *pendingCompactBlock) (*iotextypes.Block, error) {
+	pb, err := block.FillCompactBlockPb(pending.pb, pending.actions)
+	if err != nil {
+		return nil, err
+	}
+	for _, act := range pending.actions {
+		r.addRecent(act)
+	}
+	return pb, nil
+}
+
+func (r *CompactBlockRelay) addRecent(acts ...*action.SealedEnvelope) {
+	for _, act := range acts {
+		if action.IsSystemAction(act) {
+			continue
+		}
+		h, err := act.Hash()
+		if err != nil {
+			continue
+		}
+		r.recent.Add(h, act)
+	}
+}
+
+// removeExpired removes the expired pending blocks, it should be called with the lock held
+func (r *CompactBlockRelay) removeExpired(now time.Time) {
+	for blkHash, pending := range r.pending {
+		if now.Before(pending.deadline) {
+			continue
+		}
+		delete(r.pending, blkHash)
+		for h := range pending.missing {
+			blkHashes := r.waiting[h]
+			for i := range blkHashes {
+				if blkHashes[i] == blkHash {
+					blkHashes = append(blkHashes[:i], blkHashes[i+1:]...)
+					break
+				}
+			}
+			if len(blkHashes) == 0 {
+				delete(r.waiting, h)
+			} else {
+				r.waiting[h] = blkHashes
+			}
+		}
+		_compactBlockMtc.WithLabelValues("expired").Inc()
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestCompactBlockRelay(t *testing.T) {
+	require := require.New(t)
+	var acts []*action.SealedEnvelope
+	for i := 0; i < 3; i++ {
+		act, err := action.SignedTransfer(identityset.Address(i+1).String(), identityset.PrivateKey(0), uint64(i+1), big.NewInt(1), nil, 100000, big.NewInt(0))
+		require.NoError(err)
+		acts = append(acts, act)
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(time.Now()).
+		AddActions(acts...).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	full := blk.ConvertToBlockPb()
+
+	// the actpool has the first action only
+	pool := make(map[hash.Hash256]*action.SealedEnvelope)
+	h0, err := acts[0].Hash()
+	require.NoError(err)
+	pool[h0] = acts[0]
+	var requested []hash.Hash256
+	cfg := DefaultConfig.CompactRelay
+	cfg.Enabled = true
+	newRelay := func() *CompactBlockRelay {
+		requested = nil
+		return NewCompactBlockRelay(
+			cfg,
+			block.NewDeserializer(0),
+			func(h hash.Hash256) (*action.SealedEnvelope, error) {
+				if act, ok := pool[h]; ok {
+					return act, nil
+				}
+				return nil, action.ErrNotFound
+			},
+			func(_ context.Context, h hash.Hash256) {
+				requested = append(requested, h)
+			},
+		)
+	}
+
+	// the sender keeps the actions to serve the requests
+	sender := newRelay()
+	compact, err := sender.CompactBlockPb(full)
+	require.NoError(err)
+	require.True(block.IsCompactBlockPb(compact))
+	for _, act := range acts {
+		h, err := act.Hash()
+		require.NoError(err)
+		cached, ok := sender.Action(h)
+		require.True(ok)
+		require.Equal(act, cached)
+	}
+
+	// full block is returned as is
+	r := newRelay()
+	pb, err := r.ReceiveBlock(context.Background(), "peer", full)
+	require.NoError(err)
+	require.Equal(full, pb)
+
+	pb, err = r.ReceiveBlock(context.Background(), "peer", compact)
+	require.NoError(err)
+	require.Nil(pb)
+	require.Len(requested, 2)
+	// duplicate compact block is not requested again
+	pb, err = r.ReceiveBlock(context.Background(), "peer", compact)
+	require.NoError(err)
+	require.Nil(pb)
+	require.Len(requested, 2)
+
+	blks, err := r.ReceiveAction(acts[1])
+	require.NoError(err)
+	require.Empty(blks)
+	blks, err = r.ReceiveAction(acts[0])
+	require.NoError(err)
+	require.Empty(blks)
+	blks, err = r.ReceiveAction(acts[2])
+	require.NoError(err)
+	require.Len(blks, 1)
+	require.Equal("peer", blks[0].Peer)
+	require.True(proto.Equal(full, blks[0].Block))
+
+	// the block is reconstructed at once if all actions are known
+	pb, err = r.ReceiveBlock(context.Background(), "peer", compact)
+	require.NoError(err)
+	require.True(proto.Equal(full, pb))
+
+	// pending blocks expire
+	cfg.PendingTTL = 0
+	r = newRelay()
+	pb, err = r.ReceiveBlock(context.Background(), "peer", compact)
+	require.NoError(err)
+	require.Nil(pb)
+	blks, err = r.ReceiveAction(acts[1])
+	require.NoError(err)
+	require.Empty(blks)
+	require.Empty(r.pending)
+	require.Empty(r.waiting)
+
+	// too many pending blocks
+	cfg.MaxPendingBlocks = 0
+	r = newRelay()
+	_, err = r.ReceiveBlock(context.Background(), "peer", compact)
+	require.ErrorContains(err, "too many pending compact blocks")
+}
@@ -17,6 +17,31 @@ type Config struct {
 	MaxRepeat int `yaml:"maxRepeat"`
 	// RepeatDecayStep is the step for repeat number decreasing by 1
 	RepeatDecayStep int `yaml:"repeatDecayStep"`
+	// MaxInflightPerPeer is the max number of block requests in flight to a peer, the block ranges are striped
+	// across the peers within the limit
+	MaxInflightPerPeer int `yaml:"maxInflightPerPeer"`
+	// RequestTimeout is the time for a peer to respond a block request, before the request is sent to other peers
+	RequestTimeout time.Duration `yaml:"requestTimeout"`
+	// Checkpoints are the trusted block hashes, the blocks conflicting with them are refused
+	Checkpoints []Checkpoint `yaml:"checkpoints"`
+	// SkipSignatureBelowCheckpoint skips verifying the action signatures of the blocks not above the highest
+	// checkpoint, for faster initial sync. The block signature and the endorsements are still verified. It is off by
+	// default, and has no effect unless a checkpoint is configured
+	SkipSignatureBelowCheckpoint bool `yaml:"skipSignatureBelowCheckpoint"`
+	// Replica is the config of replica mode
+	Replica ReplicaConfig `yaml:"replica"`
+	// CompactRelay is the config of compact block relay
+	CompactRelay CompactRelayConfig `yaml:"compactRelay"`
+}
+
+// ReplicaConfig is the config of replica mode, in which p2p and consensus are disabled and blocks are
+// streamed from a trusted upstream node
+type ReplicaConfig struct {
+	// Upstream is the gRPC endpoint of the upstream node, replica mode is enabled if it is not empty
+	Upstream      string        `yaml:"upstream"`
+	Insecure      bool          `yaml:"insecure"`
+	BatchSize     uint64        `yaml:"batchSize"`
+	RetryInterval time.Duration `yaml:"retryInterval"`
 }
 
 // DefaultConfig is the default config
@@ -27,4 +52,21 @@ var DefaultConfig = Config{
 	IntervalSize:          20,
 	MaxRepeat:             3,
 	RepeatDecayStep:       1,
+	MaxInflightPerPeer:    2,
+	RequestTimeout:        10 * time.Second,
+	Checkpoints:           []Checkpoint{},
+	Replica: ReplicaConfig{
+		BatchSize:     100,
+		RetryInterval: 5 * time.Second,
+	},
+	CompactRelay: CompactRelayConfig{
+		MaxPendingBlocks: 16,
+		PendingTTL:       5 * time.Second,
+		RecentActions:    10000,
+	},
+}
+
+// Enabled returns true if replica mode is enabled
+func (cfg ReplicaConfig) Enabled() bool {
+	return cfg.Upstream != ""
 }
@@ -0,0 +1,250 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// ErrReplicaBlockMismatch indicates the block received from upstream does not match the local chain
+var ErrReplicaBlockMismatch = errors.New("replica block mismatch")
+
+type (
+	// TipHash returns the tip hash of blockchain
+	TipHash func() hash.Hash256
+
+	// replicaSyncer implements BlockSync interface, it ingests blocks from a trusted upstream node
+	replicaSyncer struct {
+		cfg                ReplicaConfig
+		tipHeightHandler   TipHeight
+		tipHashHandler     TipHash
+		commitBlockHandler CommitBlock
+		deserializer       *block.Deserializer
+
+		conn           *grpc.ClientConn
+		client         iotexapi.APIServiceClient
+		startingHeight uint64
+		targetHeight   atomic.Uint64
+		cancel         context.CancelFunc
+		wg             sync.WaitGroup
+	}
+)
+
+// NewReplicaSyncer returns a block syncer which streams blocks from the upstream node
+func NewReplicaSyncer(
+	cfg ReplicaConfig,
+	tipHeightHandler TipHeight,
+	tipHashHandler TipHash,
+	commitBlockHandler CommitBlock,
+	deserializer *block.Deserializer,
+) (BlockSync, error) {
+	if !cfg.Enabled() {
+		return nil, errors.New("upstream of replica is not set")
+	}
+	if cfg.BatchSize == 0 {
+		return nil, errors.New("batch size of replica should be greater than 0")
+	}
+	return &replicaSyncer{
+		cfg:                cfg,
+		tipHeightHandler:   tipHeightHandler,
+		tipHashHandler:     tipHashHandler,
+		commitBlockHandler: commitBlockHandler,
+		deserializer:       deserializer,
+	}, nil
+}
+
+func (rs *replicaSyncer) Start(ctx context.Context) error {
+	opts := []grpc.DialOption{}
+	if rs.cfg.Insecure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})))
+	}
+	conn, err := grpc.NewClient(rs.cfg.Upstream, opts...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to upstream %s", rs.cfg.Upstream)
+	}
+	rs.conn = conn
+	rs.client = iotexapi.NewAPIServiceClient(conn)
+	rs.startingHeight = rs.tipHeightHandler()
+	rs.targetHeight.Store(rs.startingHeight)
+	cctx, cancel := context.WithCancel(context.Background())
+	rs.cancel = cancel
+	rs.wg.Add(1)
+	go rs.run(cctx)
+	return nil
+}
+
+func (rs *replicaSyncer) Stop(ctx context.Context) error {
+	if rs.cancel != nil {
+		rs.cancel()
+	}
+	rs.wg.Wait()
+	if rs.conn != nil {
+		return rs.conn.Close()
+	}
+	return nil
+}
+
+func (rs *replicaSyncer) run(ctx context.Context) {
+	defer rs.wg.Done()
+	for {
+		if err := rs.sync(ctx); err != nil && ctx.Err() == nil {
+			log.L().Warn("Failed to sync from upstream.", zap.String("upstream", rs.cfg.Upstream), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rs.cfg.RetryInterval):
+		}
+	}
+}
+
+// sync subscribes to the new blocks of upstream, catches up with upstream and then commits the streamed blocks
+func (rs *replicaSyncer) sync(ctx context.Context) error {
+	// subscribe before catching up, so that no block is missed in between
+	stream, err := rs.client.StreamBlocks(ctx, &iotexapi.StreamBlocksRequest{})
+	if err != nil {
+		return errors.Wrap(err, "failed to stream blocks")
+	}
+	if err := rs.catchUp(ctx); err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return errors.Wrap(err, "failed to receive block")
+		}
+		height := resp.GetBlockIdentifier().GetHeight()
+		if height > rs.targetHeight.Load() {
+			rs.targetHeight.Store(height)
+		}
+		tip := rs.tipHeightHandler()
+		switch {
+		case height <= tip:
+			continue
+		case height > tip+1:
+			if err := rs.catchUp(ctx); err != nil {
+				return err
+			}
+		default:
+			if err := rs.processBlock(resp.GetBlock().GetBlock(), resp.GetBlockIdentifier().GetHash()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (rs *replicaSyncer) catchUp(ctx context.Context) error {
+	meta, err := rs.client.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get chain meta")
+	}
+	target := meta.GetChainMeta().GetHeight()
+	if target > rs.targetHeight.Load() {
+		rs.targetHeight.Store(target)
+	}
+	for tip := rs.tipHeightHandler(); tip < target; tip = rs.tipHeightHandler() {
+		count := rs.cfg.BatchSize
+		if target-tip < count {
+			count = target - tip
+		}
+		resp, err := rs.client.GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+			StartHeight: tip + 1,
+			Count:       count,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get blocks from height %d", tip+1)
+		}
+		if len(resp.GetBlocks()) == 0 {
+			return errors.Errorf("no block returned from height %d", tip+1)
+		}
+		for _, blkInfo := range resp.GetBlocks() {
+			if err := rs.processBlock(blkInfo.GetBlock(), ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// processBlock verifies the block against the local tip and commits it, expectedHash is checked if not empty
+func (rs *replicaSyncer) processBlock(pb *iotextypes.Block, expectedHash string) error {
+	blk, err := rs.deserializer.FromBlockProto(pb)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize block")
+	}
+	h := blk.HashBlock()
+	if expectedHash != "" && hex.EncodeToString(h[:]) != expectedHash {
+		return errors.Wrapf(ErrReplicaBlockMismatch, "hash of block %d is %x, upstream reports %s", blk.Height(), h, expectedHash)
+	}
+	if tip := rs.tipHeightHandler(); blk.Height() != tip+1 {
+		return errors.Wrapf(ErrReplicaBlockMismatch, "block height %d does not follow tip height %d", blk.Height(), tip)
+	}
+	if prevHash, tipHash := blk.PrevHash(), rs.tipHashHandler(); prevHash != tipHash {
+		return errors.Wrapf(ErrReplicaBlockMismatch, "prev hash %x of block %d does not match tip hash %x", prevHash, blk.Height(), tipHash)
+	}
+	if !blk.Header.VerifySignature() {
+		return errors.Wrapf(ErrReplicaBlockMismatch, "invalid signature of block %d", blk.Height())
+	}
+	if err := blk.VerifyTxRoot(); err != nil {
+		return errors.Wrapf(err, "invalid tx root of block %d", blk.Height())
+	}
+	return rs.commitBlockHandler(blk)
+}
+
+func (rs *replicaSyncer) TargetHeight() uint64 {
+	return rs.targetHeight.Load()
+}
+
+// ProcessSyncRequest is a no-op, replica does not serve p2p sync requests
+func (*replicaSyncer) ProcessSyncRequest(context.Context, peer.AddrInfo, uint64, uint64) error {
+	return nil
+}
+
+// ProcessBlock is a no-op, replica only accepts blocks from upstream
+func (*replicaSyncer) ProcessBlock(context.Context, string, *block.Block) error {
+	return nil
+}
+
+func (rs *replicaSyncer) SyncStatus() (uint64, uint64, uint64, string) {
+	return rs.startingHeight, rs.tipHeightHandler(), rs.targetHeight.Load(), "replica of " + rs.cfg.Upstream
+}
+
+// DownloadedHeight returns the tip height, replica commits the blocks as they are received
+func (rs *replicaSyncer) DownloadedHeight() uint64 {
+	return rs.tipHeightHandler()
+}
+
+func (rs *replicaSyncer) BuildReport() string {
+	startingHeight, tipHeight, targetHeight, syncSpeedDesc := rs.SyncStatus()
+	return fmt.Sprintf(
+		"BlockSync startingHeight: %d, tipHeight: %d, targetHeight: %d, %s",
+		startingHeight,
+		tipHeight,
+		targetHeight,
+		syncSpeedDesc,
+	)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestReplicaSyncerProcessBlock(t *testing.T) {
+	require := require.New(t)
+	_, err := NewReplicaSyncer(ReplicaConfig{BatchSize: 1}, nil, nil, nil, nil)
+	require.Error(err)
+
+	var (
+		tipHeight uint64 = 1
+		tipHash          = hash.Hash256b([]byte("tip"))
+	)
+	bs, err := NewReplicaSyncer(
+		ReplicaConfig{Upstream: "localhost:14014", BatchSize: 10},
+		func() uint64 { return tipHeight },
+		func() hash.Hash256 { return tipHash },
+		func(blk *block.Block) error {
+			tipHeight = blk.Height()
+			tipHash = blk.HashBlock()
+			return nil
+		},
+		block.NewDeserializer(0),
+	)
+	require.NoError(err)
+	rs := bs.(*replicaSyncer)
+
+	newBlock := func(height uint64, prevHash hash.Hash256) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(time.Now()).
+			SignAndBuild(identityset.PrivateKey(0))
+		require.NoError(err)
+		return &blk
+	}
+	// wrong height
+	blk := newBlock(3, tipHash)
+	require.Equal(ErrReplicaBlockMismatch, errors.Cause(rs.processBlock(blk.ConvertToBlockPb(), "")))
+	// wrong prev hash
+	blk = newBlock(2, hash.ZeroHash256)
+	require.Equal(ErrReplicaBlockMismatch, errors.Cause(rs.processBlock(blk.ConvertToBlockPb(), "")))
+	// wrong hash reported by upstream
+	blk = newBlock(2, tipHash)
+	require.Equal(ErrReplicaBlockMismatch, errors.Cause(rs.processBlock(blk.ConvertToBlockPb(), "abcd")))
+	require.EqualValues(1, tipHeight)
+	// success
+	h := blk.HashBlock()
+	require.NoError(rs.processBlock(blk.ConvertToBlockPb(), hex.EncodeToString(h[:])))
+	require.EqualValues(2, tipHeight)
+	require.Equal(h, tipHash)
+	blk = newBlock(3, tipHash)
+	require.NoError(rs.processBlock(blk.ConvertToBlockPb(), ""))
+	require.EqualValues(3, tipHeight)
+}
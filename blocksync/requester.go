@@ -0,0 +1,235 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/fastrand"
+)
+
+type (
+	// blockRequest is a block range requested from one or more peers
+	blockRequest struct {
+		start uint64
+		end   uint64
+		// peers are the peers the range is requested from and not responded yet, and the time of the request
+		peers map[string]time.Time
+	}
+
+	// blockAssignment is a block range to request from a peer
+	blockAssignment struct {
+		peer  peer.AddrInfo
+		start uint64
+		end   uint64
+	}
+
+	// requester stripes the block ranges across the peers, with a limit of the requests in flight of each peer.
+	// A peer not responding a request in time is deprioritized for a while, and the range is requested again
+	requester struct {
+		maxInflight int
+		timeout     time.Duration
+		slowPenalty time.Duration
+
+		mu       sync.Mutex
+		requests []*blockRequest
+		inflight map[string]int
+		slow     map[string]time.Time
+	}
+)
+
+func newRequester(maxInflight int, timeout, slowPenalty time.Duration) *requester {
+	if maxInflight < 1 {
+		maxInflight = 1
+	}
+	return &requester{
+		maxInflight: maxInflight,
+		timeout:     timeout,
+		slowPenalty: slowPenalty,
+		inflight:    make(map[string]int),
+		slow:        make(map[string]time.Time),
+	}
+}
+
+// assign assigns the intervals not in flight to the peers, the i-th interval is requested from repeat(i) peers. The
+// peers with fewer requests in flight are preferred, and the slow peers are the last resort
+func (r *requester) assign(tip uint64, intervals []syncBlocksInterval, peers []peer.AddrInfo, repeat func(int) int, now time.Time) []blockAssignment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune(tip, now)
+	var assignments []blockAssignment
+	for i, interval := range intervals {
+		if r.requested(interval.Start) {
+			continue
+		}
+		candidates := r.candidates(peers, now)
+		if len(candidates) == 0 {
+			break
+		}
+		n := repeat(i)
+		if n < 1 {
+			n = 1
+		}
+		if n > len(candidates) {
+			n = len(candidates)
+		}
+		req := &blockRequest{
+			start: interval.Start,
+			end:   interval.End,
+			peers: make(map[string]time.Time, n),
+		}
+		for _, p := range candidates[:n] {
+			pid := p.ID.String()
+			req.peers[pid] = now
+			r.inflight[pid]++
+			assignments = append(assignments, blockAssignment{
+				peer:  p,
+				start: interval.Start,
+				end:   interval.End,
+			})
+		}
+		r.requests = append(r.requests, req)
+	}
+	return assignments
+}
+
+// onBlock records the block received from the peer, and returns true if the peer completes a request
+func (r *requester) onBlock(pid string, height uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	completed := false
+	for _, req := range r.requests {
+		if _, ok := req.peers[pid]; ok && req.end == height {
+			r.release(req, pid)
+			completed = true
+		}
+	}
+	if completed {
+		r.compact()
+	}
+	return completed
+}
+
+// onFailure releases the request which failed to be sent to the peer
+func (r *requester) onFailure(pid string, start uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, req := range r.requests {
+		if _, ok := req.peers[pid]; ok && req.start == start {
+			r.release(req, pid)
+		}
+	}
+	r.compact()
+}
+
+// expire releases the requests not responded in time and marks the peers slow, and returns the number of them
+func (r *requester) expire(now time.Time) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	expired := 0
+	for _, req := range r.requests {
+		for pid, t := range req.peers {
+			if now.Sub(t) < r.timeout {
+				continue
+			}
+			r.release(req, pid)
+			r.slow[pid] = now
+			expired++
+		}
+	}
+	r.compact()
+	return expired
+}
+
+// prune releases the requests of the committed blocks
+func (r *requester) prune(tip uint64, now time.Time) {
+	for _, req := range r.requests {
+		if req.end > tip {
+			continue
+		}
+		for pid := range req.peers {
+			r.release(req, pid)
+		}
+	}
+	r.compact()
+	for pid, t := range r.slow {
+		if now.Sub(t) >= r.slowPenalty {
+			delete(r.slow, pid)
+		}
+	}
+}
+
+func (r *requester) requested(height uint64) bool {
+	for _, req := range r.requests {
+		if req.start <= height && height <= req.end {
+			return true
+		}
+	}
+	return false
+}
+
+// candidates returns the peers able to take one more request in order of preference
+func (r *requester) candidates(peers []peer.AddrInfo, now time.Time) []peer.AddrInfo {
+	type candidate struct {
+		peer     peer.AddrInfo
+		slow     bool
+		inflight int
+		rand     uint32
+	}
+	cands := make([]candidate, 0, len(peers))
+	for _, p := range peers {
+		pid := p.ID.String()
+		if r.inflight[pid] >= r.maxInflight {
+			continue
+		}
+		t, slow := r.slow[pid]
+		cands = append(cands, candidate{
+			peer:     p,
+			slow:     slow && now.Sub(t) < r.slowPenalty,
+			inflight: r.inflight[pid],
+			rand:     fastrand.Uint32(),
+		})
+	}
+	sort.Slice(cands, func(i, j int) bool {
+		if cands[i].slow != cands[j].slow {
+			return !cands[i].slow
+		}
+		if cands[i].inflight != cands[j].inflight {
+			return cands[i].inflight < cands[j].inflight
+		}
+		return cands[i].rand < cands[j].rand
+	})
+	res := make([]peer.AddrInfo, len(cands))
+	for i := range cands {
+		res[i] = cands[i].peer
+	}
+	return res
+}
+
+func (r *requester) release(req *blockRequest, pid string) {
+	delete(req.peers, pid)
+	if r.inflight[pid]--; r.inflight[pid] <= 0 {
+		delete(r.inflight, pid)
+	}
+}
+
+// compact removes the requests no peer is working on
+func (r *requester) compact() {
+	reqs := r.requests[:0]
+	for _, req := range r.requests {
+		if len(req.peers) > 0 {
+			reqs = append(reqs, req)
+		}
+	}
+	for i := len(reqs); i < len(r.requests); i++ {
+		r.requests[i] = nil
+	}
+	r.requests = reqs
+}
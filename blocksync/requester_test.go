@@ -0,0 +1,81 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequester(t *testing.T) {
+	require := require.New(t)
+	var (
+		peers     = []peer.AddrInfo{{ID: "peer1"}, {ID: "peer2"}, {ID: "peer3"}}
+		intervals = []syncBlocksInterval{{Start: 1, End: 10}, {Start: 11, End: 20}, {Start: 21, End: 30}, {Start: 31, End: 40}}
+		repeat    = func(i int) int { return 2 - i }
+		now       = time.Now()
+		r         = newRequester(2, 10*time.Second, time.Minute)
+	)
+	countByPeer := func(assignments []blockAssignment) map[peer.ID]int {
+		res := map[peer.ID]int{}
+		for _, a := range assignments {
+			res[a.peer.ID]++
+		}
+		return res
+	}
+
+	// the first interval is requested from 2 peers, and the others are striped across the peers
+	assignments := r.assign(0, intervals, peers, repeat, now)
+	require.Len(assignments, 5)
+	require.EqualValues(1, assignments[0].start)
+	require.EqualValues(1, assignments[1].start)
+	require.NotEqual(assignments[0].peer.ID, assignments[1].peer.ID)
+	for _, n := range countByPeer(assignments) {
+		require.LessOrEqual(n, 2)
+	}
+	// the intervals in flight are not requested again, and the peers are at the in-flight limit
+	require.Empty(r.assign(0, intervals, peers, repeat, now))
+	require.Len(r.requests, 4)
+
+	// a peer completing a request takes the next one, but not the peer at the in-flight limit
+	busy, last := assignments[3], assignments[4]
+	require.False(r.onBlock(last.peer.ID.String(), last.end-1))
+	require.True(r.onBlock(last.peer.ID.String(), last.end))
+	assignments = r.assign(0, append(intervals[:3:3], syncBlocksInterval{Start: 41, End: 50}), peers, repeat, now)
+	require.Len(assignments, 1)
+	require.NotEqual(busy.peer.ID, assignments[0].peer.ID)
+	require.EqualValues(41, assignments[0].start)
+
+	// the committed blocks release the requests
+	r.assign(10, nil, peers, repeat, now)
+	for _, req := range r.requests {
+		require.Greater(req.end, uint64(10))
+	}
+
+	// the slow peers are released and deprioritized
+	require.Zero(r.expire(now.Add(time.Second)))
+	require.Equal(3, r.expire(now.Add(10*time.Second)))
+	require.Empty(r.requests)
+	require.Empty(r.inflight)
+	fast := peer.AddrInfo{ID: "peer4"}
+	assignments = r.assign(10, intervals[1:], append(peers, fast), repeat, now.Add(10*time.Second))
+	require.Contains([]peer.ID{assignments[0].peer.ID, assignments[1].peer.ID}, fast.ID)
+	require.NotContains(r.slow, assignments[0].peer.ID.String())
+	// the slow peers are preferred again after the penalty
+	r.prune(10, now.Add(2*time.Minute))
+	require.Empty(r.slow)
+
+	// the request failed to send is released
+	r = newRequester(1, 10*time.Second, time.Minute)
+	assignments = r.assign(0, intervals[:1], peers[:1], repeat, now)
+	require.Len(assignments, 1)
+	r.onFailure(peers[0].ID.String(), 1)
+	require.Empty(r.requests)
+	require.Len(r.assign(0, intervals[:1], peers[:1], repeat, now), 1)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/api"
+)
+
+type (
+	// adminOperator performs the admin operations on the chain service
+	adminOperator struct {
+		cs        *ChainService
+		apiServer *api.ServerV2
+	}
+)
+
+// NewAdminServer creates the admin server of the chain service, it returns nil if the admin service is disabled.
+// The api keys are rotated on the api server, which could be nil
+func (cs *ChainService) NewAdminServer(cfg api.AdminConfig, apiServer *api.ServerV2) (*api.AdminServer, error) {
+	return api.NewAdminServer(cfg, &adminOperator{
+		cs:        cs,
+		apiServer: apiServer,
+	})
+}
+
+// ActivateBlockProduction resumes or pauses the block production
+func (op *adminOperator) ActivateBlockProduction(active bool) error {
+	if op.cs.consensus == nil {
+		return errors.New("consensus is not enabled")
+	}
+	op.cs.consensus.Activate(active)
+	return nil
+}
+
+// RequestSnapshot requests a snapshot to be taken at the next block
+func (op *adminOperator) RequestSnapshot() error {
+	if op.cs.snapshotExporter == nil {
+		return errors.New("snapshot is not enabled")
+	}
+	op.cs.snapshotExporter.Request()
+	return nil
+}
+
+// SetActPoolCapacity sets the max number of actions and the max gas the actpool can hold
+func (op *adminOperator) SetActPoolCapacity(maxNumActs, maxGas uint64) error {
+	return op.cs.SetActPoolCapacity(maxNumActs, maxGas)
+}
+
+// BlockPeer blocks the peer in the p2p layer
+func (op *adminOperator) BlockPeer(id string) error {
+	if _, err := peer.Decode(id); err != nil {
+		return errors.Wrapf(err, "invalid peer id %s", id)
+	}
+	op.cs.p2pAgent.BlockPeer(id)
+	return nil
+}
+
+// EnableReader registers the read-only protocol built into the node
+func (op *adminOperator) EnableReader(id string) error {
+	return op.cs.registry.EnableReader(id)
+}
+
+// DisableReader unregisters the read-only protocol
+func (op *adminOperator) DisableReader(id string) error {
+	if !op.cs.registry.UnregisterReader(id) {
+		return errors.Errorf("reader %s is not registered", id)
+	}
+	return nil
+}
+
+// SetAPIKeys replaces the api keys of rate limiting with their tiers
+func (op *adminOperator) SetAPIKeys(keys map[string]string) error {
+	if op.apiServer == nil || op.apiServer.RateLimiter() == nil {
+		return errors.New("rate limiting is not enabled")
+	}
+	return op.apiServer.RateLimiter().SetAPIKeys(keys)
+}
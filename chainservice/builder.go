@@ -9,6 +9,7 @@ import (
 	"context"
 	"math/big"
 	"net/url"
+	"os"
 	"time"
 
 	"github.com/iotexproject/go-pkgs/cache"
@@ -23,8 +24,10 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/acl"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/execution"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/paymaster"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
@@ -50,14 +53,30 @@ import (
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/blockutil"
 	"github.com/iotexproject/iotex-core/v2/server/itx/nodestats"
+	"github.com/iotexproject/iotex-core/v2/sink"
+	"github.com/iotexproject/iotex-core/v2/snapshot"
 	"github.com/iotexproject/iotex-core/v2/state/factory"
 	"github.com/iotexproject/iotex-core/v2/systemcontractindex/stakingindex"
+	"github.com/iotexproject/iotex-core/v2/webhook"
+)
+
+const (
+	_snapshotFactoryStore         = "factory"
+	_snapshotContractStakingStore = "contractstaking"
+	// _snapshotRecentBlocks is the number of the recent blocks whose hashes are accessible to the contracts
+	_snapshotRecentBlocks = 256
 )
 
 // Builder is a builder to build chainservice
 type Builder struct {
 	cfg config.Config
 	cs  *ChainService
+	// snapshotStores are the stores included in the state snapshots
+	snapshotStores []snapshot.Store
+	// coldStoreMigrator moves the chain db files of the old blocks to the cold store
+	coldStoreMigrator *filedao.ColdStoreMigrator
+	// recompressor recompresses the chain db files written with another compressor
+	recompressor *filedao.Recompressor
 }
 
 // NewBuilder creates a new chainservice builder
@@ -189,6 +208,7 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 		if err != nil {
 			return nil, err
 		}
+		builder.snapshotStores = append(builder.snapshotStores, snapshot.Store{Name: _snapshotFactoryStore, KVStore: dao})
 		return factory.NewStateDB(factoryCfg, dao, opts...)
 	}
 	if forTest {
@@ -198,6 +218,7 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 	if err != nil {
 		return nil, err
 	}
+	builder.snapshotStores = append(builder.snapshotStores, snapshot.Store{Name: _snapshotFactoryStore, KVStore: dao})
 	return factory.NewFactory(
 		factoryCfg,
 		dao,
@@ -240,6 +261,7 @@ func (builder *Builder) createElectionCommittee() (committee.Committee, error) {
 	committeeConfig.ScoreThreshold = "0"
 	committeeConfig.StakingContractAddress = cfg.Genesis.StakingContractAddress
 	committeeConfig.SelfStakingThreshold = cfg.Genesis.SelfStakingThreshold
+	committeeConfig.GravityChainAPIs = orderEndpointsByHealth(context.Background(), committeeConfig.GravityChainAPIs, probeEthEndpoint)
 
 	arch, err := committee.NewArchive(
 		cfg.Chain.GravityChainDB.DbPath,
@@ -250,12 +272,28 @@ func (builder *Builder) createElectionCommittee() (committee.Committee, error) {
 	if err != nil {
 		return nil, err
 	}
-	return committee.NewCommittee(arch, committeeConfig)
+	ec, err := committee.NewCommittee(arch, committeeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newGravityChainCommittee(
+		ec,
+		committeeConfig.GravityChainAPIs,
+		cfg.Chain.GravityChainCacheSize,
+		cfg.Chain.GravityChainMaxRetries,
+		cfg.Chain.GravityChainRetryInterval,
+		cfg.Chain.GravityChainProbeInterval,
+		probeEthEndpoint,
+	), nil
 }
 
 func (builder *Builder) buildActionPool() error {
 	if builder.cs.actpool == nil {
-		options := []actpool.Option{}
+		options := []actpool.Option{
+			actpool.WithGasSponsor(func(selp *action.SealedEnvelope) bool {
+				return paymaster.IsSponsoredCall(selp.Envelope)
+			}),
+		}
 		if builder.cfg.ActPool.Store != nil {
 			d := &action.Deserializer{}
 			d.SetEvmNetworkID(builder.cfg.Chain.EVMNetworkID)
@@ -316,6 +354,23 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	if builder.cs.bfIndexer != nil {
 		indexers = append(indexers, builder.cs.bfIndexer)
 	}
+	if builder.cs.balanceIndexer != nil {
+		indexers = append(indexers, builder.cs.balanceIndexer)
+	}
+	if builder.cs.tokenIndexer != nil {
+		indexers = append(indexers, builder.cs.tokenIndexer)
+	}
+	if builder.cs.fundingIndexer != nil {
+		indexers = append(indexers, builder.cs.fundingIndexer)
+	}
+	if builder.cs.perfIndexer != nil {
+		indexers = append(indexers, builder.cs.perfIndexer)
+	}
+	if !forTest && builder.cfg.Snapshot.Interval > 0 && len(builder.snapshotStores) > 0 {
+		// the exporter should be the last one, after all the stores have committed the block
+		builder.cs.snapshotExporter = builder.createSnapshotExporter()
+		indexers = append(indexers, builder.cs.snapshotExporter)
+	}
 	var (
 		cfg       = builder.cfg
 		err       error
@@ -338,6 +393,12 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 			dbConfig := cfg.DB
 			dbConfig.DbPath = uri.Path
 			store, err = filedao.NewFileDAO(dbConfig, block.NewDeserializer(builder.cfg.Chain.EVMNetworkID))
+			if tiered, ok := store.(filedao.TieredFileDAO); ok && err == nil && dbConfig.ColdStore.Path != "" {
+				builder.coldStoreMigrator = builder.createColdStoreMigrator(tiered)
+			}
+			if fd, ok := store.(filedao.RecompressibleFileDAO); ok && err == nil && dbConfig.RecompressInterval > 0 {
+				builder.recompressor = filedao.NewRecompressor(fd, dbConfig.RecompressInterval)
+			}
 		default:
 			return errors.Errorf("unsupported blockdao scheme %s", uri.Scheme)
 		}
@@ -361,6 +422,67 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	return nil
 }
 
+func (builder *Builder) createColdStoreMigrator(fd filedao.TieredFileDAO) *filedao.ColdStoreMigrator {
+	cs := builder.cs
+	hotEpochs := builder.cfg.DB.ColdStore.HotEpochs
+	return filedao.NewColdStoreMigrator(fd, builder.cfg.DB.ColdStore.Interval, func(tip uint64) uint64 {
+		// the blocks of the recent epochs stay in the hot store
+		rp := rolldpos.FindProtocol(cs.registry)
+		if rp == nil {
+			return 0
+		}
+		epochNum := rp.GetEpochNum(tip)
+		if epochNum <= hotEpochs {
+			return 0
+		}
+		return rp.GetEpochHeight(epochNum - hotEpochs)
+	})
+}
+
+func (builder *Builder) createSnapshotExporter() *snapshot.Exporter {
+	cs := builder.cs
+	return snapshot.NewExporter(
+		builder.cfg.Snapshot,
+		builder.snapshotStores,
+		cs.factory.Height,
+		func(height uint64) uint64 {
+			return snapshotBottom(rolldpos.FindProtocol(cs.registry), height)
+		},
+		func(height uint64) (*block.Store, error) {
+			return readBlockStore(cs.blockdao, height)
+		},
+	)
+}
+
+// bootstrapFromSnapshot imports the snapshot of the peers if the chain db does not exist
+func (builder *Builder) bootstrapFromSnapshot() error {
+	cfg := builder.cfg
+	if !cfg.Snapshot.Bootstrap() {
+		return nil
+	}
+	chainDBPath, err := chainDBFilePath(cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(chainDBPath); err == nil {
+		log.L().Info("chain db exists, skip bootstrapping from snapshot")
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	ctx := context.Background()
+	stores, stop, err := startSnapshotStores(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer stop()
+	dbConfig := cfg.DB
+	dbConfig.DbPath = chainDBPath
+	_, err = snapshot.Bootstrap(ctx, cfg.Snapshot, stores, dbConfig, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	return err
+}
+
 func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	if !builder.cfg.Chain.EnableStakingProtocol {
 		return nil
@@ -400,6 +522,9 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 		)
 		builder.cs.contractStakingIndexerV2 = indexer
 	}
+	if builder.cs.contractStakingIndexer != nil || builder.cs.contractStakingIndexerV2 != nil {
+		builder.snapshotStores = append(builder.snapshotStores, snapshot.Store{Name: _snapshotContractStakingStore, KVStore: kvstore})
+	}
 
 	return nil
 }
@@ -419,10 +544,131 @@ func (builder *Builder) buildGatewayComponents(forTest bool) error {
 	}
 	builder.cs.bfIndexer = bfIndexer
 	builder.cs.indexer = indexer
+	builder.cs.balanceIndexer, err = builder.createBalanceIndexer(forTest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create balance indexer")
+	}
+	builder.cs.tokenIndexer, err = builder.createTokenIndexer(forTest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create token indexer")
+	}
+	builder.cs.fundingIndexer, err = builder.createFundingIndexer(forTest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create funding indexer")
+	}
+	builder.cs.perfIndexer, err = builder.createPerformanceIndexer(forTest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create performance indexer")
+	}
 
 	return nil
 }
 
+func (builder *Builder) createTokenIndexer(forTest bool) (blockindex.TokenIndexer, error) {
+	_, gateway := builder.cfg.Plugins[config.GatewayPlugin]
+	if !gateway || !builder.cfg.Chain.EnableTokenIndexer {
+		return nil, nil
+	}
+	if forTest {
+		return blockindex.NewTokenIndexer(db.NewMemKVStore())
+	}
+	dbConfig := builder.cfg.DB
+	dbConfig.DbPath = builder.cfg.Chain.TokenIndexDBPath
+	return blockindex.NewTokenIndexer(db.NewBoltDB(dbConfig))
+}
+
+func (builder *Builder) createFundingIndexer(forTest bool) (blockindex.FundingIndexer, error) {
+	_, gateway := builder.cfg.Plugins[config.GatewayPlugin]
+	if !gateway || !builder.cfg.Chain.EnableFundingIndexer {
+		return nil, nil
+	}
+	if forTest {
+		return blockindex.NewFundingIndexer(db.NewMemKVStore())
+	}
+	dbConfig := builder.cfg.DB
+	dbConfig.DbPath = builder.cfg.Chain.FundingIndexDBPath
+	return blockindex.NewFundingIndexer(db.NewBoltDB(dbConfig))
+}
+
+func (builder *Builder) createPerformanceIndexer(forTest bool) (blockindex.PerformanceIndexer, error) {
+	_, gateway := builder.cfg.Plugins[config.GatewayPlugin]
+	if !gateway || !builder.cfg.Chain.EnablePerformanceIndexer {
+		return nil, nil
+	}
+	var (
+		g                = builder.cfg.Genesis
+		epochNum         = builder.epochNumFunc()
+		delegatesByEpoch = builder.delegatesByEpochFunc()
+	)
+	if forTest {
+		return blockindex.NewPerformanceIndexer(db.NewMemKVStore(), epochNum, g.BlockGasLimitByHeight, delegatesByEpoch)
+	}
+	dbConfig := builder.cfg.DB
+	dbConfig.DbPath = builder.cfg.Chain.PerformanceIndexDBPath
+	return blockindex.NewPerformanceIndexer(db.NewBoltDB(dbConfig), epochNum, g.BlockGasLimitByHeight, delegatesByEpoch)
+}
+
+// epochNumFunc returns the epoch number of a height, which is 0 before the rolldpos protocol is registered
+func (builder *Builder) epochNumFunc() func(uint64) uint64 {
+	cs := builder.cs
+	return func(height uint64) uint64 {
+		// the protocols are registered after the indexers are created
+		rp := rolldpos.FindProtocol(cs.registry)
+		if rp == nil {
+			return 0
+		}
+		return rp.GetEpochNum(height)
+	}
+}
+
+// delegatesByEpochFunc returns the delegates of an epoch, only the epoch of the state factory height is supported
+func (builder *Builder) delegatesByEpochFunc() func(uint64) ([]string, error) {
+	var (
+		cs = builder.cs
+		g  = builder.cfg.Genesis
+	)
+	return func(epoch uint64) ([]string, error) {
+		rp := rolldpos.FindProtocol(cs.registry)
+		pp := poll.FindProtocol(cs.registry)
+		if rp == nil || pp == nil {
+			return nil, errors.New("rolldpos or poll protocol is not registered")
+		}
+		// the state factory has committed the block being indexed, so only the delegates of its epoch are known
+		height, err := cs.factory.Height()
+		if err != nil {
+			return nil, err
+		}
+		if tipEpoch := rp.GetEpochNum(height); epoch != tipEpoch {
+			return nil, errors.Errorf("invalid epoch number %d compared to tip epoch number %d", epoch, tipEpoch)
+		}
+		ctx := genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), cs.registry), g)
+		ctx = protocol.WithFeatureWithHeightCtx(ctx)
+		candidates, err := pp.Delegates(ctx, cs.factory)
+		if err != nil {
+			return nil, err
+		}
+		addrs := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			addrs = append(addrs, c.Address)
+		}
+		return addrs, nil
+	}
+}
+
+func (builder *Builder) createBalanceIndexer(forTest bool) (blockindex.BalanceIndexer, error) {
+	_, gateway := builder.cfg.Plugins[config.GatewayPlugin]
+	if !gateway || !builder.cfg.Chain.EnableBalanceIndexer {
+		return nil, nil
+	}
+	initAddrs, initBalances := builder.cfg.Genesis.InitBalances()
+	if forTest {
+		return blockindex.NewBalanceIndexer(db.NewMemKVStore(), initAddrs, initBalances)
+	}
+	dbConfig := builder.cfg.DB
+	dbConfig.DbPath = builder.cfg.Chain.BalanceIndexDBPath
+	return blockindex.NewBalanceIndexer(db.NewBoltDB(dbConfig), initAddrs, initBalances)
+}
+
 func (builder *Builder) createGateWayComponents(forTest bool) (
 	indexer blockindex.Indexer,
 	bfIndexer blockindex.BloomFilterIndexer,
@@ -486,9 +732,18 @@ func (builder *Builder) buildBlockchain(forSubChain, forTest bool) error {
 	builder.cs.chain = builder.createBlockchain(forSubChain, forTest)
 	builder.cs.lifecycle.Add(builder.cs.chain)
 	builder.cs.lifecycle.Add(builder.cs.actpool)
+	if builder.coldStoreMigrator != nil {
+		builder.cs.lifecycle.Add(builder.coldStoreMigrator)
+	}
+	if builder.recompressor != nil {
+		builder.cs.lifecycle.Add(builder.recompressor)
+	}
 	if err := builder.cs.chain.AddSubscriber(builder.cs.actpool); err != nil {
 		return errors.Wrap(err, "failed to add actpool as subscriber")
 	}
+	if err := builder.buildSink(forTest); err != nil {
+		return err
+	}
 	if builder.cs.indexer != nil && builder.cfg.Chain.EnableAsyncIndexWrite {
 		// config asks for a standalone indexer
 		indexBuilder, err := blockindex.NewIndexBuilder(builder.cs.chain.ChainID(), builder.cfg.Genesis, builder.cs.blockdao, builder.cs.indexer)
@@ -503,6 +758,56 @@ func (builder *Builder) buildBlockchain(forSubChain, forTest bool) error {
 	return nil
 }
 
+func (builder *Builder) buildSink(forTest bool) error {
+	if forTest || !builder.cfg.Sink.Enabled() {
+		return nil
+	}
+	publisher, err := sink.NewPublisher(builder.cfg.Sink)
+	if err != nil {
+		return errors.Wrap(err, "failed to create sink publisher")
+	}
+	s := sink.NewSink(builder.cfg.Sink, builder.cs.blockdao, publisher)
+	builder.cs.lifecycle.Add(s)
+	if err := builder.cs.chain.AddSubscriber(s); err != nil {
+		return errors.Wrap(err, "failed to add sink as subscriber")
+	}
+	return nil
+}
+
+func (builder *Builder) buildWebhookNotifier(forTest bool) error {
+	if forTest || !builder.cfg.Webhook.Enabled() {
+		return nil
+	}
+	var (
+		cs               = builder.cs
+		epochNum         = builder.epochNumFunc()
+		delegatesByEpoch = builder.delegatesByEpochFunc()
+	)
+	notifier, err := webhook.NewNotifier(
+		builder.cfg.Webhook,
+		func() (uint64, uint64) {
+			_, currentHeight, targetHeight, _ := cs.blocksync.SyncStatus()
+			return currentHeight, targetHeight
+		},
+		func(blk *block.Block) []string {
+			delegates, err := delegatesByEpoch(epochNum(blk.Height()))
+			if err != nil {
+				log.L().Debug("Failed to get delegates for web-hook.", zap.Uint64("height", blk.Height()), zap.Error(err))
+				return nil
+			}
+			return blockindex.MissedProposers(delegates, blk.Height(), blk.ProducerAddress())
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create web-hook notifier")
+	}
+	cs.lifecycle.Add(notifier)
+	if err := cs.chain.AddSubscriber(notifier); err != nil {
+		return errors.Wrap(err, "failed to add web-hook notifier as subscriber")
+	}
+	return nil
+}
+
 func (builder *Builder) createBlockchain(forSubChain, forTest bool) blockchain.Blockchain {
 	if builder.cs.chain != nil {
 		return builder.cs.chain
@@ -565,6 +870,82 @@ func (builder *Builder) buildBlockSyncer() error {
 	consens := builder.cs.consensus
 	dao := builder.cs.blockdao
 	cfg := builder.cfg
+	checkpoints, err := blocksync.NewCheckpoints(cfg.BlockSync.Checkpoints)
+	if err != nil {
+		return errors.Wrap(err, "failed to load checkpoints")
+	}
+	if cfg.BlockSync.SkipSignatureBelowCheckpoint && checkpoints.Highest() == 0 {
+		log.L().Warn("No checkpoint is configured, the action signatures are verified for all blocks.")
+	}
+
+	commitBlock := func(blk *block.Block) error {
+		if err := checkpoints.Verify(blk); err != nil {
+			log.L().Warn("Refuse the block conflicting with checkpoint.", zap.Error(err))
+			return err
+		}
+		if err := consens.ValidateBlockFooter(blk); err != nil {
+			log.L().Debug("Failed to validate block footer.", zap.Error(err), zap.Uint64("height", blk.Height()))
+			return err
+		}
+		retries := 1
+		if !builder.cfg.Genesis.IsHawaii(blk.Height()) {
+			retries = 4
+		}
+		var err error
+		opts := []blockchain.BlockValidationOption{}
+		if now := time.Now(); now.After(blk.Timestamp()) &&
+			blk.Height()+cfg.Genesis.MinBlocksForBlobRetention <= estimateTipHeight(&cfg, blk, now.Sub(blk.Timestamp())) {
+			opts = append(opts, blockchain.SkipSidecarValidationOption())
+		}
+		if cfg.BlockSync.SkipSignatureBelowCheckpoint && checkpoints.Trusted(blk.Height()) {
+			opts = append(opts, blockchain.SkipSignatureValidationOption())
+		}
+		for i := 0; i < retries; i++ {
+			if err = chain.ValidateBlock(blk, opts...); err == nil {
+				if err = chain.CommitBlock(blk); err == nil {
+					break
+				}
+			}
+			switch errors.Cause(err) {
+			case blockchain.ErrInvalidTipHeight:
+				log.L().Debug("Skip block.", zap.Error(err), zap.Uint64("height", blk.Height()))
+				return nil
+			case block.ErrDeltaStateMismatch:
+				log.L().Debug("Delta state mismatched.", zap.Uint64("height", blk.Height()))
+			case blockdao.ErrRemoteHeightTooLow:
+				if retries == 1 {
+					retries = 4
+				}
+				log.L().Debug("Remote height too low.", zap.Uint64("height", blk.Height()))
+				time.Sleep(100 * time.Millisecond)
+			default:
+				log.L().Debug("Failed to commit the block.", zap.Error(err), zap.Uint64("height", blk.Height()))
+				return err
+			}
+		}
+		if err != nil {
+			log.L().Debug("Failed to commit block.", zap.Error(err), zap.Uint64("height", blk.Height()))
+			return err
+		}
+		log.L().Info("Successfully committed block.", zap.Uint64("height", blk.Height()))
+		consens.Calibrate(blk.Height())
+		return nil
+	}
+	if builder.cfg.BlockSync.Replica.Enabled() {
+		replica, err := blocksync.NewReplicaSyncer(
+			builder.cfg.BlockSync.Replica,
+			chain.TipHeight,
+			chain.TipHash,
+			commitBlock,
+			block.NewDeserializer(chain.EvmNetworkID()),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to create replica syncer")
+		}
+		builder.cs.blocksync = replica
+		builder.cs.lifecycle.Add(replica)
+		return nil
+	}
 
 	blocksync, err := blocksync.NewBlockSyncer(
 		builder.cfg.BlockSync,
@@ -589,55 +970,12 @@ func (builder *Builder) buildBlockSyncer() error {
 			deser := (&action.Deserializer{}).SetEvmNetworkID(builder.cfg.Chain.EVMNetworkID)
 			return blk.WithBlobSidecars(sidecars, hashes, deser)
 		},
-		func(blk *block.Block) error {
-			if err := consens.ValidateBlockFooter(blk); err != nil {
-				log.L().Debug("Failed to validate block footer.", zap.Error(err), zap.Uint64("height", blk.Height()))
-				return err
-			}
-			retries := 1
-			if !builder.cfg.Genesis.IsHawaii(blk.Height()) {
-				retries = 4
-			}
-			var err error
-			opts := []blockchain.BlockValidationOption{}
-			if now := time.Now(); now.After(blk.Timestamp()) &&
-				blk.Height()+cfg.Genesis.MinBlocksForBlobRetention <= estimateTipHeight(&cfg, blk, now.Sub(blk.Timestamp())) {
-				opts = append(opts, blockchain.SkipSidecarValidationOption())
-			}
-			for i := 0; i < retries; i++ {
-				if err = chain.ValidateBlock(blk, opts...); err == nil {
-					if err = chain.CommitBlock(blk); err == nil {
-						break
-					}
-				}
-				switch errors.Cause(err) {
-				case blockchain.ErrInvalidTipHeight:
-					log.L().Debug("Skip block.", zap.Error(err), zap.Uint64("height", blk.Height()))
-					return nil
-				case block.ErrDeltaStateMismatch:
-					log.L().Debug("Delta state mismatched.", zap.Uint64("height", blk.Height()))
-				case blockdao.ErrRemoteHeightTooLow:
-					if retries == 1 {
-						retries = 4
-					}
-					log.L().Debug("Remote height too low.", zap.Uint64("height", blk.Height()))
-					time.Sleep(100 * time.Millisecond)
-				default:
-					log.L().Debug("Failed to commit the block.", zap.Error(err), zap.Uint64("height", blk.Height()))
-					return err
-				}
-			}
-			if err != nil {
-				log.L().Debug("Failed to commit block.", zap.Error(err), zap.Uint64("height", blk.Height()))
-				return err
-			}
-			log.L().Info("Successfully committed block.", zap.Uint64("height", blk.Height()))
-			consens.Calibrate(blk.Height())
-			return nil
-		},
+		commitBlock,
 		p2pAgent.ConnectedPeers,
 		p2pAgent.UnicastOutbound,
-		p2pAgent.BlockPeer,
+		func(pid string) {
+			p2pAgent.ReportPeer(pid, p2p.PeerEventInvalidBlock)
+		},
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to create block syncer")
@@ -662,11 +1000,44 @@ func (builder *Builder) buildActionSyncer() error {
 	return nil
 }
 
+func (builder *Builder) buildCompactBlockRelay() error {
+	if !builder.cfg.BlockSync.CompactRelay.Enabled || builder.cs.compactRelay != nil {
+		return nil
+	}
+	actionsync := builder.cs.actionsync
+	builder.cs.compactRelay = blocksync.NewCompactBlockRelay(
+		builder.cfg.BlockSync.CompactRelay,
+		block.NewDeserializer(builder.cs.chain.EvmNetworkID()),
+		builder.cs.actpool.GetActionByHash,
+		actionsync.RequestAction,
+	)
+	return nil
+}
+
+func (builder *Builder) buildActionGossip() error {
+	if builder.cs.actionGossip != nil {
+		return nil
+	}
+	policy, err := p2p.NewGossipPolicy(builder.cfg.Network.ActionGossip)
+	if err != nil {
+		return errors.Wrap(err, "failed to create action gossip policy")
+	}
+	builder.cs.actionGossip = policy
+	return nil
+}
+
 func (builder *Builder) registerStakingProtocol() error {
 	if !builder.cfg.Chain.EnableStakingProtocol {
 		return nil
 	}
 	consensusCfg := consensusfsm.NewConsensusConfig(builder.cfg.Consensus.RollDPoS.FSM, builder.cfg.DardanellesUpgrade, builder.cfg.Genesis, builder.cfg.Consensus.RollDPoS.Delay)
+	opts := []staking.Option{}
+	if builder.cs.fundingIndexer != nil {
+		opts = append(opts, staking.WithBucketFundingIndexer(builder.cs.fundingIndexer))
+	}
+	if builder.cfg.Chain.StakingViewCacheDir != "" && builder.cfg.Chain.EnableTrielessStateDB {
+		log.L().Warn("Staking view cache is not used, since the trieless state db has no state root to bind the view to.")
+	}
 	stakingProtocol, err := staking.NewProtocol(
 		staking.HelperCtx{
 			DepositGas:    rewarding.DepositGas,
@@ -677,6 +1048,7 @@ func (builder *Builder) registerStakingProtocol() error {
 			PersistStakingPatchBlock: builder.cfg.Chain.PersistStakingPatchBlock,
 			FixAliasForNonStopHeight: builder.cfg.Chain.FixAliasForNonStopHeight,
 			StakingPatchDir:          builder.cfg.Chain.StakingPatchDir,
+			ViewCacheDir:             builder.cfg.Chain.StakingViewCacheDir,
 			Revise: staking.ReviseConfig{
 				VoteWeight:                  builder.cfg.Genesis.VoteWeightCalConsts,
 				ReviseHeights:               []uint64{builder.cfg.Genesis.GreenlandBlockHeight, builder.cfg.Genesis.HawaiiBlockHeight},
@@ -688,6 +1060,7 @@ func (builder *Builder) registerStakingProtocol() error {
 		builder.cs.candBucketsIndexer,
 		builder.cs.contractStakingIndexer,
 		builder.cs.contractStakingIndexerV2,
+		opts...,
 	)
 	if err != nil {
 		return err
@@ -698,7 +1071,10 @@ func (builder *Builder) registerStakingProtocol() error {
 
 func (builder *Builder) registerRewardingProtocol() error {
 	// TODO: rewarding protocol for standalone mode is weird, rDPoSProtocol could be passed via context
-	return rewarding.NewProtocol(builder.cfg.Genesis.Rewarding).Register(builder.cs.registry)
+	return rewarding.NewProtocol(
+		builder.cfg.Genesis.Rewarding,
+		rewarding.EnableClaimCall(builder.cs.blockdao.GetBlockHash, builder.cs.blockTimeCalculator.CalculateBlockTime),
+	).Register(builder.cs.registry)
 }
 
 func (builder *Builder) registerAccountProtocol() error {
@@ -709,16 +1085,46 @@ func (builder *Builder) registerExecutionProtocol() error {
 	return execution.NewProtocol(builder.cs.blockdao.GetBlockHash, rewarding.DepositGas, builder.cs.blockTimeCalculator.CalculateBlockTime).Register(builder.cs.registry)
 }
 
+func (builder *Builder) registerACLProtocol() error {
+	admins := builder.cfg.Genesis.ACLAdmins
+	if len(admins) == 0 {
+		return nil
+	}
+	p, err := acl.NewProtocol(admins, rewarding.DepositGas)
+	if err != nil {
+		return err
+	}
+	if err := p.Register(builder.cs.registry); err != nil {
+		return err
+	}
+	builder.cs.actpool.AddActionEnvelopeValidators(p.ActPoolValidator(builder.cs.factory))
+	return nil
+}
+
+func (builder *Builder) registerPaymasterProtocol() error {
+	p := paymaster.NewProtocol(builder.cs.blockdao.GetBlockHash, rewarding.DepositGas, builder.cs.blockTimeCalculator.CalculateBlockTime)
+	if err := p.Register(builder.cs.registry); err != nil {
+		return err
+	}
+	// the actpool only charges the sender the value of a sponsored call, so the sponsor is checked here
+	builder.cs.actpool.AddActionEnvelopeValidators(p.ActPoolValidator(builder.cs.factory, builder.cfg.Chain.ID))
+	return nil
+}
+
+func newRollDPoSProtocol(g genesis.Genesis) *rolldpos.Protocol {
+	return rolldpos.NewProtocol(
+		g.NumCandidateDelegates,
+		g.NumDelegates,
+		g.NumSubEpochs,
+		rolldpos.EnableDardanellesSubEpoch(g.DardanellesBlockHeight, g.DardanellesNumSubEpochs),
+	)
+}
+
 func (builder *Builder) registerRollDPoSProtocol() error {
 	if builder.cfg.Consensus.Scheme != config.RollDPoSScheme {
 		return nil
 	}
-	if err := rolldpos.NewProtocol(
-		builder.cfg.Genesis.NumCandidateDelegates,
-		builder.cfg.Genesis.NumDelegates,
-		builder.cfg.Genesis.NumSubEpochs,
-		rolldpos.EnableDardanellesSubEpoch(builder.cfg.Genesis.DardanellesBlockHeight, builder.cfg.Genesis.DardanellesNumSubEpochs),
-	).Register(builder.cs.registry); err != nil {
+	if err := newRollDPoSProtocol(builder.cfg.Genesis).Register(builder.cs.registry); err != nil {
 		return err
 	}
 	factory := builder.cs.factory
@@ -797,8 +1203,16 @@ func (builder *Builder) buildBlockTimeCalculator() (err error) {
 
 func (builder *Builder) buildConsensusComponent() error {
 	p2pAgent := builder.cs.p2pAgent
+	cs := builder.cs
 	copts := []consensus.Option{
 		consensus.WithBroadcast(func(msg proto.Message) error {
+			if blkPb, ok := msg.(*iotextypes.Block); ok && cs.compactRelay != nil {
+				compact, err := cs.compactRelay.CompactBlockPb(blkPb)
+				if err != nil {
+					return errors.Wrap(err, "failed to convert block to compact block")
+				}
+				msg = compact
+			}
 			return p2pAgent.BroadcastOutbound(context.Background(), msg)
 		}),
 	}
@@ -808,6 +1222,9 @@ func (builder *Builder) buildConsensusComponent() error {
 	if pollProtocol := poll.FindProtocol(builder.cs.registry); pollProtocol != nil {
 		copts = append(copts, consensus.WithPollProtocol(pollProtocol))
 	}
+	if builder.cfg.Consensus.RollDPoS.ReportEvidence {
+		copts = append(copts, consensus.WithEvidenceHandler(builder.evidenceHandler()))
+	}
 
 	// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	builderCfg := rp.BuilderConfig{
@@ -825,11 +1242,74 @@ func (builder *Builder) buildConsensusComponent() error {
 	}
 	builder.cs.consensus = component
 	builder.cs.lifecycle.Add(component)
+	if builder.cfg.Consensus.Scheme == config.RollDPoSScheme && builder.cfg.Consensus.RollDPoS.ShadowProposal {
+		sp := rp.NewShadowProposer(builder.cs.chain, builder.cfg.Chain.ProducerAddress().String(), builder.cfg.DardanellesUpgrade.BlockInterval)
+		builder.cs.lifecycle.Add(sp)
+		if err := builder.cs.chain.AddSubscriber(sp); err != nil {
+			return errors.Wrap(err, "failed to add shadow proposer as subscriber")
+		}
+	}
 
 	return nil
 }
 
+// evidenceHandler returns the handler sending the double-sign evidence to the slashing protocol, as an execution
+// signed by the producer and added into the actpool, from which it is broadcast to the network
+func (builder *Builder) evidenceHandler() rp.EvidenceHandler {
+	cs := builder.cs
+	chainCfg := builder.cfg.Chain
+	minGasPrice := builder.cfg.ActPool.MinGasPrice()
+	return func(evidence *rp.DoubleSignEvidence) error {
+		data, err := evidence.Serialize()
+		if err != nil {
+			return err
+		}
+		ctx, err := cs.chain.Context(context.Background())
+		if err != nil {
+			return err
+		}
+		tip := protocol.MustGetBlockchainCtx(ctx).Tip
+		ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: tip.Height + 1,
+		}))
+		gasLimit, err := protocol.IntrinsicGas(ctx, (&action.EnvelopeBuilder{}).SetChainID(chainCfg.ID).
+			SetAction(action.NewExecution(rp.EvidenceAddress().String(), big.NewInt(0), data)).Build())
+		if err != nil {
+			return err
+		}
+		// pay the base fee of the next block, which is not below the min gas price of the actpool
+		gasPrice := minGasPrice
+		if baseFee := protocol.CalcBaseFee(genesis.MustExtractGenesisContext(ctx).Blockchain, &tip); baseFee != nil && baseFee.Cmp(gasPrice) > 0 {
+			gasPrice = baseFee
+		}
+		operator := chainCfg.OperatorPrivateKey()
+		nonce, err := cs.actpool.GetPendingNonce(operator.PublicKey().Address().String())
+		if err != nil {
+			return errors.Wrap(err, "failed to get pending nonce of operator")
+		}
+		selp, err := action.SignedExecution(
+			rp.EvidenceAddress().String(),
+			operator,
+			nonce,
+			big.NewInt(0),
+			gasLimit,
+			gasPrice,
+			data,
+			action.WithChainID(chainCfg.ID),
+		)
+		if err != nil {
+			return err
+		}
+		return cs.actpool.Add(protocol.WithRegistry(context.Background(), cs.registry), selp)
+	}
+}
+
 func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error) {
+	if !forTest {
+		if err := builder.bootstrapFromSnapshot(); err != nil {
+			return nil, errors.Wrap(err, "failed to bootstrap from snapshot")
+		}
+	}
 	builder.cs.registry = protocol.NewRegistry()
 	if builder.cs.p2pAgent == nil {
 		builder.cs.p2pAgent = p2p.NewDummyAgent()
@@ -868,12 +1348,21 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.registerRollDPoSProtocol(); err != nil {
 		return nil, errors.Wrap(err, "failed to register roll dpos related protocols")
 	}
-	if err := builder.registerExecutionProtocol(); err != nil {
-		return nil, errors.Wrap(err, "failed to register execution protocol")
+	// acl protocol need to be put in registry before execution protocol, to handle the governance calls
+	if err := builder.registerACLProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register acl protocol")
+	}
+	// paymaster protocol need to be put in registry before execution protocol, to handle the sponsored calls
+	if err := builder.registerPaymasterProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register paymaster protocol")
 	}
+	// rewarding protocol need to be put in registry before execution protocol, to handle the auto claim calls
 	if err := builder.registerRewardingProtocol(); err != nil {
 		return nil, errors.Wrap(err, "failed to register rewarding protocol")
 	}
+	if err := builder.registerExecutionProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register execution protocol")
+	}
 	if err := builder.buildConsensusComponent(); err != nil {
 		return nil, err
 	}
@@ -886,6 +1375,15 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.buildActionSyncer(); err != nil {
 		return nil, err
 	}
+	if err := builder.buildCompactBlockRelay(); err != nil {
+		return nil, err
+	}
+	if err := builder.buildActionGossip(); err != nil {
+		return nil, err
+	}
+	if err := builder.buildWebhookNotifier(forTest); err != nil {
+		return nil, err
+	}
 	cs := builder.cs
 	builder.cs = nil
 
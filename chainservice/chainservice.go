@@ -41,6 +41,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/blockutil"
 	"github.com/iotexproject/iotex-core/v2/server/itx/nodestats"
+	"github.com/iotexproject/iotex-core/v2/snapshot"
 	"github.com/iotexproject/iotex-core/v2/state/factory"
 	"github.com/iotexproject/iotex-core/v2/systemcontractindex/stakingindex"
 )
@@ -73,6 +74,10 @@ type ChainService struct {
 	// TODO: explorer dependency deleted at #1085, need to api related params
 	indexer                  blockindex.Indexer
 	bfIndexer                blockindex.BloomFilterIndexer
+	balanceIndexer           blockindex.BalanceIndexer
+	tokenIndexer             blockindex.TokenIndexer
+	fundingIndexer           blockindex.FundingIndexer
+	perfIndexer              blockindex.PerformanceIndexer
 	candidateIndexer         *poll.CandidateIndexer
 	candBucketsIndexer       *staking.CandidatesBucketsIndexer
 	contractStakingIndexer   *contractstaking.Indexer
@@ -82,6 +87,9 @@ type ChainService struct {
 	apiStats                 *nodestats.APILocalStats
 	blockTimeCalculator      *blockutil.BlockTimeCalculator
 	actionsync               *actsync.ActionSync
+	compactRelay             *blocksync.CompactBlockRelay
+	snapshotExporter         *snapshot.Exporter
+	actionGossip             *p2p.GossipPolicy
 	rateLimiters             cache.LRUCache
 	accRateLimitCfg          int
 }
@@ -129,6 +137,17 @@ func (cs *ChainService) HandleAction(ctx context.Context, actPb *iotextypes.Acti
 		return err
 	}
 	cs.actionsync.ReceiveAction(ctx, hash)
+	if cs.compactRelay != nil {
+		blks, err := cs.compactRelay.ReceiveAction(act)
+		if err != nil {
+			return err
+		}
+		for _, blk := range blks {
+			if err := cs.processBlock(ctx, blk.Peer, blk.Block); err != nil {
+				log.L().Debug("failed to process compact block", zap.Error(err), zap.String("peer", blk.Peer))
+			}
+		}
+	}
 	return nil
 }
 
@@ -141,25 +160,48 @@ func (cs *ChainService) HandleActionHash(ctx context.Context, actHash hash.Hash2
 	if !errors.Is(err, action.ErrNotFound) {
 		return err
 	}
-	cs.actionsync.RequestAction(ctx, actHash)
+	cs.actionsync.RequestActionFrom(ctx, actHash, from)
 	return nil
 }
 
 func (cs *ChainService) HandleActionRequest(ctx context.Context, peer peer.AddrInfo, actHash hash.Hash256) error {
 	act, err := cs.actpool.GetActionByHash(actHash)
 	if err != nil {
-		if errors.Is(err, action.ErrNotFound) {
+		if !errors.Is(err, action.ErrNotFound) {
+			return err
+		}
+		// the action could have been removed from the actpool by a block the peer is reconstructing
+		if cs.compactRelay == nil {
+			return nil
+		}
+		var ok bool
+		if act, ok = cs.compactRelay.Action(actHash); !ok {
 			return nil
 		}
-		return err
 	}
 	return cs.p2pAgent.UnicastOutbound(ctx, peer, act.Proto())
 }
 
 // HandleBlock handles incoming block request.
 func (cs *ChainService) HandleBlock(ctx context.Context, peer string, pbBlock *iotextypes.Block) error {
+	if block.IsCompactBlockPb(pbBlock) {
+		if cs.compactRelay == nil {
+			return errors.New("compact block relay is disabled")
+		}
+		full, err := cs.compactRelay.ReceiveBlock(ctx, peer, pbBlock)
+		if err != nil || full == nil {
+			// the block is processed once the missing actions are received
+			return err
+		}
+		pbBlock = full
+	}
+	return cs.processBlock(ctx, peer, pbBlock)
+}
+
+func (cs *ChainService) processBlock(ctx context.Context, peer string, pbBlock *iotextypes.Block) error {
 	blk, err := block.NewDeserializer(cs.chain.EvmNetworkID()).FromBlockProto(pbBlock)
 	if err != nil {
+		cs.p2pAgent.ReportPeer(peer, p2p.PeerEventInvalidBlock)
 		return err
 	}
 	ctx, err = cs.chain.Context(ctx)
@@ -175,7 +217,11 @@ func (cs *ChainService) HandleSyncRequest(ctx context.Context, peer peer.AddrInf
 }
 
 // HandleConsensusMsg handles incoming consensus message.
-func (cs *ChainService) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
+func (cs *ChainService) HandleConsensusMsg(ctx context.Context, peer string, msg *iotextypes.ConsensusMessage) error {
+	if msg.GetHeight() < cs.chain.TipHeight() {
+		// votes of the tip height could arrive late, but older ones are useless
+		cs.p2pAgent.ReportPeer(peer, p2p.PeerEventStaleVote)
+	}
 	return cs.consensus.HandleConsensusMsg(msg)
 }
 
@@ -228,6 +274,11 @@ func (cs *ChainService) NodeInfoManager() *nodeinfo.InfoManager {
 	return cs.nodeInfoManager
 }
 
+// SnapshotExporter returns the state snapshot exporter, which is nil if taking snapshots is disabled
+func (cs *ChainService) SnapshotExporter() *snapshot.Exporter {
+	return cs.snapshotExporter
+}
+
 // Registry returns a pointer to the registry
 func (cs *ChainService) Registry() *protocol.Registry { return cs.registry }
 
@@ -244,6 +295,21 @@ func (cs *ChainService) NewAPIServer(cfg api.Config, archive bool) (*api.ServerV
 		api.WithNativeElection(cs.electionCommittee),
 		api.WithAPIStats(cs.apiStats),
 	}
+	if cs.actionGossip != nil {
+		apiServerOptions = append(apiServerOptions, api.WithActionGossip(cs.actionGossip, p2pAgent.ConnectedPeers, p2pAgent.UnicastOutbound))
+	}
+	if cs.consensus != nil {
+		apiServerOptions = append(apiServerOptions, api.WithConsensusState(cs.consensus.State))
+	}
+	if cs.balanceIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithBalanceIndexer(cs.balanceIndexer))
+	}
+	if cs.tokenIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithTokenIndexer(cs.tokenIndexer))
+	}
+	if cs.perfIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithPerformanceIndexer(cs.perfIndexer))
+	}
 	if archive {
 		apiServerOptions = append(apiServerOptions, api.WithArchiveSupport())
 	}
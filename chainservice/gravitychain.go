@@ -0,0 +1,215 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/iotex-election/committee"
+	"github.com/iotexproject/iotex-election/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
+)
+
+const _endpointProbeTimeout = 5 * time.Second
+
+var (
+	_gravityChainEndpointMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_gravity_chain_endpoint_health",
+			Help: "Health of gravity chain endpoints, 1 for healthy and 0 for unhealthy",
+		},
+		[]string{"endpoint"},
+	)
+	_gravityChainCommitteeMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_gravity_chain_committee",
+			Help: "Gravity chain committee query statistics",
+		},
+		[]string{"method", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_gravityChainEndpointMtc)
+	prometheus.MustRegister(_gravityChainCommitteeMtc)
+}
+
+type (
+	// gravityChainCommittee wraps the election committee with caching and retries of the poll data, and probes the
+	// health of the gravity chain endpoints periodically
+	gravityChainCommittee struct {
+		committee.Committee
+		results       cache.LRUCache
+		heights       cache.LRUCache
+		maxRetries    int
+		retryInterval time.Duration
+		probeTask     *routine.RecurringTask
+	}
+
+	// endpointProbe returns the error if the endpoint is not healthy
+	endpointProbe func(ctx context.Context, endpoint string) error
+)
+
+func newGravityChainCommittee(
+	c committee.Committee,
+	endpoints []string,
+	cacheSize int,
+	maxRetries int,
+	retryInterval time.Duration,
+	probeInterval time.Duration,
+	probe endpointProbe,
+) *gravityChainCommittee {
+	gc := &gravityChainCommittee{
+		Committee:     c,
+		results:       cache.NewThreadSafeLruCache(cacheSize),
+		heights:       cache.NewThreadSafeLruCache(cacheSize),
+		maxRetries:    maxRetries,
+		retryInterval: retryInterval,
+	}
+	if probeInterval > 0 && len(endpoints) > 0 {
+		gc.probeTask = routine.NewRecurringTask(func() {
+			probeEndpoints(context.Background(), endpoints, probe)
+		}, probeInterval)
+	}
+	return gc
+}
+
+func (gc *gravityChainCommittee) Start(ctx context.Context) error {
+	if err := gc.Committee.Start(ctx); err != nil {
+		return err
+	}
+	if gc.probeTask != nil {
+		return gc.probeTask.Start(ctx)
+	}
+	return nil
+}
+
+func (gc *gravityChainCommittee) Stop(ctx context.Context) error {
+	if gc.probeTask != nil {
+		if err := gc.probeTask.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	return gc.Committee.Stop(ctx)
+}
+
+// ResultByHeight returns the election result at the gravity chain height, which never changes once fetched
+func (gc *gravityChainCommittee) ResultByHeight(height uint64) (*types.ElectionResult, error) {
+	if v, ok := gc.results.Get(height); ok {
+		_gravityChainCommitteeMtc.WithLabelValues("ResultByHeight", "hit").Inc()
+		return v.(*types.ElectionResult), nil
+	}
+	var r *types.ElectionResult
+	if err := gc.retry("ResultByHeight", func() (err error) {
+		r, err = gc.Committee.ResultByHeight(height)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	gc.results.Add(height, r)
+	return r, nil
+}
+
+// HeightByTime returns the gravity chain height of the timestamp
+func (gc *gravityChainCommittee) HeightByTime(ts time.Time) (uint64, error) {
+	key := ts.UnixNano()
+	if v, ok := gc.heights.Get(key); ok {
+		_gravityChainCommitteeMtc.WithLabelValues("HeightByTime", "hit").Inc()
+		return v.(uint64), nil
+	}
+	var height uint64
+	if err := gc.retry("HeightByTime", func() (err error) {
+		height, err = gc.Committee.HeightByTime(ts)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	gc.heights.Add(key, height)
+	return height, nil
+}
+
+// retry calls f until it succeeds or the retries run out, the interval doubles after each retry. The last error is
+// returned as it is, so that the callers could check its cause
+func (gc *gravityChainCommittee) retry(method string, f func() error) error {
+	var (
+		err      error
+		interval = gc.retryInterval
+	)
+	for i := 0; ; i++ {
+		if err = f(); err == nil {
+			_gravityChainCommitteeMtc.WithLabelValues(method, "success").Inc()
+			return nil
+		}
+		if i >= gc.maxRetries {
+			break
+		}
+		_gravityChainCommitteeMtc.WithLabelValues(method, "retry").Inc()
+		log.L().Debug("retry gravity chain committee", zap.String("method", method), zap.Int("attempt", i+1), zap.Error(err))
+		time.Sleep(interval)
+		interval *= 2
+	}
+	_gravityChainCommitteeMtc.WithLabelValues(method, "failure").Inc()
+	return err
+}
+
+// probeEndpoints probes the endpoints and updates the health metrics, it returns the health of each endpoint
+func probeEndpoints(ctx context.Context, endpoints []string, probe endpointProbe) map[string]bool {
+	health := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		err := probe(ctx, endpoint)
+		health[endpoint] = err == nil
+		if err != nil {
+			log.L().Warn("gravity chain endpoint is unhealthy", zap.String("endpoint", endpointLabel(endpoint)), zap.Error(err))
+			_gravityChainEndpointMtc.WithLabelValues(endpointLabel(endpoint)).Set(0)
+			continue
+		}
+		_gravityChainEndpointMtc.WithLabelValues(endpointLabel(endpoint)).Set(1)
+	}
+	return health
+}
+
+// orderEndpointsByHealth moves the healthy endpoints to the front, so the committee starts with a healthy one and
+// fails over to the others in the original order
+func orderEndpointsByHealth(ctx context.Context, endpoints []string, probe endpointProbe) []string {
+	health := probeEndpoints(ctx, endpoints, probe)
+	ordered := make([]string, len(endpoints))
+	copy(ordered, endpoints)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return health[ordered[i]] && !health[ordered[j]]
+	})
+	return ordered
+}
+
+// probeEthEndpoint checks whether the endpoint returns the latest block number
+func probeEthEndpoint(ctx context.Context, endpoint string) error {
+	ctx, cancel := context.WithTimeout(ctx, _endpointProbeTimeout)
+	defer cancel()
+	client, err := ethclient.DialContext(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	_, err = client.BlockNumber(ctx)
+	return err
+}
+
+// endpointLabel strips the path and query of the endpoint, which may carry the api key
+func endpointLabel(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "unknown"
+	}
+	return u.Host
+}
@@ -0,0 +1,67 @@
+package chainservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-election/test/mock/mock_committee"
+	"github.com/iotexproject/iotex-election/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGravityChainCommittee(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	mc := mock_committee.NewMockCommittee(ctrl)
+	gc := newGravityChainCommittee(mc, nil, 8, 2, time.Millisecond, 0, nil)
+	errUnavailable := errors.New("unavailable")
+
+	t.Run("result is cached", func(t *testing.T) {
+		result := &types.ElectionResult{}
+		gomock.InOrder(
+			mc.EXPECT().ResultByHeight(uint64(100)).Return(nil, errUnavailable).Times(2),
+			mc.EXPECT().ResultByHeight(uint64(100)).Return(result, nil).Times(1),
+		)
+		res, err := gc.ResultByHeight(100)
+		r.NoError(err)
+		r.Equal(result, res)
+		res, err = gc.ResultByHeight(100)
+		r.NoError(err)
+		r.Equal(result, res)
+	})
+	t.Run("error after retries", func(t *testing.T) {
+		ts := time.Unix(1700000000, 0)
+		mc.EXPECT().HeightByTime(ts).Return(uint64(0), errUnavailable).Times(3)
+		_, err := gc.HeightByTime(ts)
+		r.Equal(errUnavailable, err)
+		mc.EXPECT().HeightByTime(ts).Return(uint64(200), nil).Times(1)
+		for i := 0; i < 2; i++ {
+			h, err := gc.HeightByTime(ts)
+			r.NoError(err)
+			r.Equal(uint64(200), h)
+		}
+	})
+}
+
+func TestOrderEndpointsByHealth(t *testing.T) {
+	r := require.New(t)
+	endpoints := []string{
+		"https://a.example.com/v3/key",
+		"https://b.example.com/v3/key",
+		"https://c.example.com/v3/key",
+		"https://d.example.com/v3/key",
+	}
+	probe := func(_ context.Context, endpoint string) error {
+		if endpoint == endpoints[0] || endpoint == endpoints[2] {
+			return errors.New("down")
+		}
+		return nil
+	}
+	r.Equal([]string{endpoints[1], endpoints[3], endpoints[0], endpoints[2]}, orderEndpointsByHealth(context.Background(), endpoints, probe))
+	r.Equal(endpoints[0], "https://a.example.com/v3/key")
+	r.Equal("a.example.com", endpointLabel(endpoints[0]))
+	r.Equal("unknown", endpointLabel("not a url"))
+}
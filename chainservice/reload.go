@@ -0,0 +1,34 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/p2p"
+)
+
+// capacitySetter is the actpool of which the capacity is adjustable at runtime
+type capacitySetter interface {
+	SetCapacity(maxNumActs, maxGas uint64) error
+}
+
+// SetActPoolCapacity sets the max number of actions and the max gas the actpool can hold
+func (cs *ChainService) SetActPoolCapacity(maxNumActs, maxGas uint64) error {
+	ap, ok := cs.actpool.(capacitySetter)
+	if !ok {
+		return errors.New("capacity of actpool is not adjustable")
+	}
+	return ap.SetCapacity(maxNumActs, maxGas)
+}
+
+// UpdateActionGossip replaces the gossip policy of actions by the config
+func (cs *ChainService) UpdateActionGossip(cfg p2p.GossipConfig) error {
+	if cs.actionGossip == nil {
+		return errors.New("action gossip is not enabled")
+	}
+	return cs.actionGossip.Update(cfg)
+}
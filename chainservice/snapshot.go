@@ -0,0 +1,190 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/snapshot"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+type blockReader interface {
+	GetBlockByHeight(uint64) (*block.Block, error)
+	GetReceipts(uint64) ([]*action.Receipt, error)
+}
+
+// ExportSnapshotArchive writes the snapshot of the stopped node into the archive. The snapshot is taken at the height
+// of the state db, which should be the height if it is not 0
+func ExportSnapshotArchive(cfg config.Config, w io.Writer, height uint64) (*snapshot.Manifest, error) {
+	chainDBPath, err := chainDBFilePath(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(chainDBPath); err != nil {
+		return nil, errors.Wrapf(err, "failed to find chain db %s", chainDBPath)
+	}
+	ctx := context.Background()
+	stores, stop, err := startSnapshotStores(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+	value, err := stores[0].KVStore.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the height of state db")
+	}
+	tip := byteutil.BytesToUint64(value)
+	if tip == 0 {
+		return nil, errors.New("state db has no block to export")
+	}
+	if height == 0 {
+		height = tip
+	} else if height != tip {
+		return nil, errors.Errorf("state db is at height %d, cannot export the snapshot at height %d", tip, height)
+	}
+
+	dbConfig := cfg.DB
+	dbConfig.DbPath = chainDBPath
+	fd, err := filedao.NewFileDAO(dbConfig, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	if err != nil {
+		return nil, err
+	}
+	if err := fd.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer fd.Stop(ctx)
+	blkHash, err := fd.GetBlockHash(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block %d from chain db", height)
+	}
+	var rp *rolldpos.Protocol
+	if cfg.Consensus.Scheme == config.RollDPoSScheme {
+		rp = newRollDPoSProtocol(cfg.Genesis)
+	}
+	return snapshot.WriteArchive(
+		w,
+		height,
+		blkHash,
+		cfg.Snapshot.ChunkSize,
+		snapshotBottom(rp, height),
+		func(height uint64) (*block.Store, error) {
+			return readBlockStore(fd, height)
+		},
+		stores,
+	)
+}
+
+// ImportSnapshotArchive restores the state db and the chain db of a new node from the archive. If the trusted root
+// is not empty, the root of the archive should match it
+func ImportSnapshotArchive(ctx context.Context, cfg config.Config, r io.Reader, trustedRoot string) (*snapshot.Manifest, error) {
+	chainDBPath, err := chainDBFilePath(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(chainDBPath); err == nil {
+		return nil, errors.Errorf("chain db %s already exists", chainDBPath)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	stores, stop, err := startSnapshotStores(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+	if _, err := stores[0].KVStore.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey)); err == nil {
+		return nil, errors.Errorf("state db %s is not empty", cfg.Chain.TrieDBPath)
+	}
+	dbConfig := cfg.DB
+	dbConfig.DbPath = chainDBPath
+	return snapshot.ImportArchive(ctx, r, trustedRoot, stores, dbConfig, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+}
+
+// chainDBFilePath returns the path of the local chain db
+func chainDBFilePath(cfg config.Config) (string, error) {
+	uri, err := url.Parse(cfg.Chain.ChainDBPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse chain db path %s", cfg.Chain.ChainDBPath)
+	}
+	if uri.Scheme != "" && uri.Scheme != "file" {
+		return "", errors.Errorf("snapshot is not supported with blockdao scheme %s", uri.Scheme)
+	}
+	return uri.Path, nil
+}
+
+// startSnapshotStores starts the stores included in the snapshots, which should be the same as snapshotStores of
+// the builder, and returns the func to stop them
+func startSnapshotStores(ctx context.Context, cfg config.Config) ([]snapshot.Store, func(), error) {
+	factoryDBCfg := cfg.DB
+	factoryDBCfg.DBType = cfg.Chain.FactoryDBType
+	factoryDB, err := db.CreateKVStore(factoryDBCfg, cfg.Chain.TrieDBPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	stores := []snapshot.Store{{Name: _snapshotFactoryStore, KVStore: factoryDB}}
+	if cfg.Chain.EnableStakingProtocol && (len(cfg.Genesis.SystemStakingContractAddress) > 0 || len(cfg.Genesis.SystemStakingContractV2Address) > 0) {
+		dbConfig := cfg.DB
+		dbConfig.DbPath = cfg.Chain.ContractStakingIndexDBPath
+		stores = append(stores, snapshot.Store{Name: _snapshotContractStakingStore, KVStore: db.NewBoltDB(dbConfig)})
+	}
+	var started []snapshot.Store
+	stop := func() {
+		for _, store := range started {
+			if err := store.KVStore.Stop(ctx); err != nil {
+				log.L().Error("failed to stop store", zap.String("store", store.Name), zap.Error(err))
+			}
+		}
+	}
+	for _, store := range stores {
+		if err := store.KVStore.Start(ctx); err != nil {
+			stop()
+			return nil, nil, errors.Wrapf(err, "failed to start store %s", store.Name)
+		}
+		started = append(started, store)
+	}
+	return stores, stop, nil
+}
+
+// snapshotBottom returns the height of the first block included in the snapshot of the height
+func snapshotBottom(rp *rolldpos.Protocol, height uint64) uint64 {
+	// keep the recent blocks for BLOCKHASH opcode, and the blocks of the current epoch for rewarding
+	bottom := uint64(1)
+	if height > _snapshotRecentBlocks {
+		bottom = height - _snapshotRecentBlocks + 1
+	}
+	if rp != nil {
+		if start := rp.GetEpochHeight(rp.GetEpochNum(height)); start < bottom {
+			bottom = start
+		}
+	}
+	return bottom
+}
+
+func readBlockStore(dao blockReader, height uint64) (*block.Store, error) {
+	blk, err := dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := dao.GetReceipts(height)
+	if err != nil {
+		return nil, err
+	}
+	return &block.Store{Block: blk, Receipts: receipts}, nil
+}
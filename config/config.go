@@ -6,6 +6,8 @@
 package config
 
 import (
+	"math/big"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	uconfig "go.uber.org/config"
 
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/actpool"
 	"github.com/iotexproject/iotex-core/v2/actsync"
 	"github.com/iotexproject/iotex-core/v2/api"
@@ -24,9 +27,13 @@ import (
 	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/dispatcher"
+	"github.com/iotexproject/iotex-core/v2/light"
 	"github.com/iotexproject/iotex-core/v2/nodeinfo"
 	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/sink"
+	"github.com/iotexproject/iotex-core/v2/snapshot"
+	"github.com/iotexproject/iotex-core/v2/webhook"
 )
 
 // IMPORTANT: to define a config, add a field or a new config type to the existing config types. In addition, provide
@@ -84,6 +91,10 @@ var (
 		Genesis:    genesis.Default,
 		NodeInfo:   nodeinfo.DefaultConfig,
 		ActionSync: actsync.DefaultConfig,
+		Snapshot:   snapshot.DefaultConfig,
+		Sink:       sink.DefaultConfig,
+		Webhook:    webhook.DefaultConfig,
+		Light:      light.DefaultConfig,
 	}
 
 	// ErrInvalidCfg indicates the invalid config value
@@ -97,6 +108,14 @@ var (
 		ValidateAPI,
 		ValidateActPool,
 		ValidateForkHeights,
+		ValidateGasTableUpgrades,
+		ValidateReplica,
+		ValidateCheckpoints,
+		ValidateSnapshot,
+		ValidateActionGossip,
+		ValidateDBType,
+		ValidateSink,
+		ValidateWebhook,
 	}
 )
 
@@ -115,6 +134,8 @@ type (
 		StartSubChainInterval time.Duration `yaml:"startSubChainInterval"`
 		SystemLogDBPath       string        `yaml:"systemLogDBPath"`
 		MptrieLogPath         string        `yaml:"mptrieLogPath"`
+		// AuditLogPath is the path of the audit log of the privileged operations, it is disabled if empty
+		AuditLogPath string `yaml:"auditLogPath"`
 	}
 
 	// Config is the root config struct, each package's config should be put as its sub struct
@@ -136,6 +157,10 @@ type (
 		Genesis            genesis.Genesis                 `yaml:"genesis"`
 		NodeInfo           nodeinfo.Config                 `yaml:"nodeinfo"`
 		ActionSync         actsync.Config                  `yaml:"actionSync"`
+		Snapshot           snapshot.Config                 `yaml:"snapshot"`
+		Sink               sink.Config                     `yaml:"sink"`
+		Webhook            webhook.Config                  `yaml:"webhook"`
+		Light              light.Config                    `yaml:"light"`
 	}
 
 	// Validate is the interface of validating the config
@@ -167,6 +192,9 @@ func New(configPaths []string, _plugins []string, validates ...Validate) (Config
 	if err := cfg.Chain.SetProducerPrivKey(); err != nil {
 		return Config{}, errors.Wrap(err, "failed to set producer private key")
 	}
+	if err := cfg.Chain.SetKeyBackends(); err != nil {
+		return Config{}, errors.Wrap(err, "failed to set key backends")
+	}
 	// set default value for mint timeout
 	cfg.Chain.MintTimeout = Default.Chain.MintTimeout
 
@@ -234,6 +262,9 @@ func ValidateDispatcher(cfg Config) error {
 	if cfg.Dispatcher.ActionChanSize <= 0 || cfg.Dispatcher.BlockChanSize <= 0 || cfg.Dispatcher.BlockSyncChanSize <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "dispatcher chan size should be greater than 0")
 	}
+	if cfg.Dispatcher.Workers == 0 {
+		return errors.Wrap(ErrInvalidCfg, "dispatcher workers should be greater than 0")
+	}
 
 	if cfg.Dispatcher.ProcessSyncRequestInterval < 0 {
 		return errors.Wrap(ErrInvalidCfg, "dispatcher processSyncRequestInterval should not be less than 0")
@@ -254,6 +285,99 @@ func ValidateRollDPoS(cfg Config) error {
 	return nil
 }
 
+// ValidateReplica validates the replica mode setting
+func ValidateReplica(cfg Config) error {
+	if !cfg.BlockSync.Replica.Enabled() || cfg.Consensus.Scheme == NOOPScheme {
+		return nil
+	}
+
+	return errors.Wrap(ErrInvalidCfg, "replica mode requires NOOP consensus scheme")
+}
+
+// ValidateCheckpoints validates the block sync checkpoints
+func ValidateCheckpoints(cfg Config) error {
+	if _, err := blocksync.NewCheckpoints(cfg.BlockSync.Checkpoints); err != nil {
+		return errors.Wrap(ErrInvalidCfg, err.Error())
+	}
+	return nil
+}
+
+// ValidateSnapshot validates the snapshot configs
+func ValidateSnapshot(cfg Config) error {
+	if cfg.Snapshot.Interval > 0 && cfg.Chain.FactoryDBType != db.DBBolt {
+		return errors.Wrap(ErrInvalidCfg, "taking snapshots requires bolt factory db")
+	}
+	if !cfg.Snapshot.Bootstrap() {
+		return nil
+	}
+	if _, ok := cfg.Plugins[GatewayPlugin]; ok {
+		return errors.Wrap(ErrInvalidCfg, "bootstrapping from snapshot is incompatible with gateway plugin, whose indexers require all the blocks")
+	}
+	if cfg.Snapshot.TrustedRoot == "" && cfg.Snapshot.MinAgreement <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "bootstrapping from snapshot requires either trusted root or positive min agreement")
+	}
+	return nil
+}
+
+// ValidateActionGossip validates the gossip policy of actions
+func ValidateActionGossip(cfg Config) error {
+	if _, err := p2p.NewGossipPolicy(cfg.Network.ActionGossip); err != nil {
+		return errors.Wrap(ErrInvalidCfg, err.Error())
+	}
+	return nil
+}
+
+// ValidateDBType validates the db types of chain db and state db
+func ValidateDBType(cfg Config) error {
+	for _, dbType := range []string{cfg.DB.DBType, cfg.Chain.FactoryDBType} {
+		if dbType != db.DBBolt && dbType != db.DBPebble {
+			return errors.Wrapf(ErrInvalidCfg, "unsupported db type %s", dbType)
+		}
+	}
+	return nil
+}
+
+// ValidateSink validates the external sink config
+func ValidateSink(cfg Config) error {
+	switch cfg.Sink.Type {
+	case "":
+		return nil
+	case sink.TypeKafka:
+		if cfg.Sink.Kafka.ProxyURL == "" || cfg.Sink.Kafka.Topic == "" {
+			return errors.Wrap(ErrInvalidCfg, "kafka sink requires proxy url and topic")
+		}
+	case sink.TypePostgres:
+		if cfg.Sink.Postgres.DSN == "" {
+			return errors.Wrap(ErrInvalidCfg, "postgres sink requires dsn")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unsupported sink type %s", cfg.Sink.Type)
+	}
+	if cfg.Sink.BatchSize == 0 {
+		return errors.Wrap(ErrInvalidCfg, "sink batch size should be positive")
+	}
+	return nil
+}
+
+// ValidateWebhook validates the web-hook notifier config
+func ValidateWebhook(cfg Config) error {
+	if !cfg.Webhook.Enabled() {
+		return nil
+	}
+	for _, ep := range cfg.Webhook.Endpoints {
+		if _, err := url.ParseRequestURI(ep.URL); err != nil {
+			return errors.Wrapf(ErrInvalidCfg, "invalid web-hook url %s", ep.URL)
+		}
+	}
+	if amount, ok := new(big.Int).SetString(cfg.Webhook.LargeStakeAmount, 10); !ok || amount.Sign() < 0 {
+		return errors.Wrapf(ErrInvalidCfg, "invalid web-hook large stake amount %s", cfg.Webhook.LargeStakeAmount)
+	}
+	if cfg.Webhook.QueueSize <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "web-hook queue size should be positive")
+	}
+	return nil
+}
+
 // ValidateArchiveMode validates the state factory setting
 func ValidateArchiveMode(cfg Config) error {
 	if !cfg.Chain.EnableArchiveMode || !cfg.Chain.EnableTrielessStateDB {
@@ -287,6 +411,20 @@ func ValidateActPool(cfg Config) error {
 			"maximum number of actions per pool cannot be less than maximum number of actions per account",
 		)
 	}
+	if cfg.ActPool.ReservedNumActs >= maxNumActPerPool {
+		return errors.Wrap(
+			ErrInvalidCfg,
+			"number of reserved actions should be less than maximum number of actions per pool",
+		)
+	}
+	return nil
+}
+
+// ValidateGasTableUpgrades validates the gas table upgrades in genesis
+func ValidateGasTableUpgrades(cfg Config) error {
+	if err := protocol.ValidateGasTableUpgrades(cfg.Genesis.Blockchain); err != nil {
+		return errors.Wrap(ErrInvalidCfg, err.Error())
+	}
 	return nil
 }
 
@@ -18,6 +18,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blocksync"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/p2p"
+	"github.com/iotexproject/iotex-core/v2/sink"
+	"github.com/iotexproject/iotex-core/v2/webhook"
 )
 
 const (
@@ -237,6 +242,11 @@ func TestValidateDispatcher(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "dispatcher chan size should be greater than 0"),
 	)
+	cfg.Dispatcher.BlockSyncChanSize = 100
+	cfg.Dispatcher.Workers = 0
+	err = ValidateDispatcher(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
 }
 
 func TestValidateRollDPoS(t *testing.T) {
@@ -270,6 +280,123 @@ func TestValidateArchiveMode(t *testing.T) {
 	require.NoError(t, errors.Cause(ValidateArchiveMode(cfg)))
 }
 
+func TestValidateDBType(t *testing.T) {
+	require := require.New(t)
+	cfg := Default
+	require.NoError(ValidateDBType(cfg))
+	cfg.DB.DBType = db.DBPebble
+	cfg.Chain.FactoryDBType = db.DBPebble
+	require.NoError(ValidateDBType(cfg))
+	cfg.DB.DBType = "badgerdb"
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateDBType(cfg)))
+	cfg.DB.DBType = db.DBBolt
+	cfg.Chain.FactoryDBType = ""
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateDBType(cfg)))
+}
+
+func TestValidateReplica(t *testing.T) {
+	cfg := Default
+	cfg.BlockSync.Replica.Upstream = "api.iotex.one:443"
+	cfg.Consensus.Scheme = RollDPoSScheme
+	require.Equal(t, ErrInvalidCfg, errors.Cause(ValidateReplica(cfg)))
+	cfg.Consensus.Scheme = NOOPScheme
+	require.NoError(t, ValidateReplica(cfg))
+	cfg.BlockSync.Replica.Upstream = ""
+	cfg.Consensus.Scheme = RollDPoSScheme
+	require.NoError(t, ValidateReplica(cfg))
+}
+
+func TestValidateCheckpoints(t *testing.T) {
+	require := require.New(t)
+	cfg := Default
+	require.NoError(ValidateCheckpoints(cfg))
+	h := "0x" + strings.Repeat("ab", 32)
+	cfg.BlockSync.Checkpoints = []blocksync.Checkpoint{{Height: 100, Hash: h}}
+	require.NoError(ValidateCheckpoints(cfg))
+	cfg.BlockSync.Checkpoints = []blocksync.Checkpoint{{Height: 100, Hash: "0x1234"}}
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateCheckpoints(cfg)))
+	cfg.BlockSync.Checkpoints = []blocksync.Checkpoint{{Height: 0, Hash: h}}
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateCheckpoints(cfg)))
+}
+
+func TestValidateGasTableUpgrades(t *testing.T) {
+	require := require.New(t)
+	cfg := Default
+	require.NoError(ValidateGasTableUpgrades(cfg))
+	cfg.Genesis.GasTableUpgrades = []genesis.GasTableUpgrade{
+		{Height: 100, IntrinsicGas: map[string]genesis.IntrinsicGas{"transfer": {Base: 21000}}},
+		{Height: 200, IntrinsicGas: map[string]genesis.IntrinsicGas{"execution": {Base: 21000, PerByte: 16}}},
+	}
+	require.NoError(ValidateGasTableUpgrades(cfg))
+	cfg.Genesis.GasTableUpgrades[1].Height = 100
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateGasTableUpgrades(cfg)))
+	cfg.Genesis.GasTableUpgrades[1].Height = 200
+	cfg.Genesis.GasTableUpgrades[1].IntrinsicGas["unknown"] = genesis.IntrinsicGas{}
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateGasTableUpgrades(cfg)))
+}
+
+func TestValidateSnapshot(t *testing.T) {
+	require := require.New(t)
+	cfg := Default
+	require.NoError(ValidateSnapshot(cfg))
+	cfg.Snapshot.Interval = 360
+	cfg.Chain.FactoryDBType = db.DBPebble
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateSnapshot(cfg)))
+	cfg.Chain.FactoryDBType = db.DBBolt
+	require.NoError(ValidateSnapshot(cfg))
+
+	cfg.Snapshot.Peers = []string{"http://127.0.0.1:8090"}
+	require.NoError(ValidateSnapshot(cfg))
+	cfg.Snapshot.MinAgreement = 0
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateSnapshot(cfg)))
+	cfg.Snapshot.TrustedRoot = "0102"
+	require.NoError(ValidateSnapshot(cfg))
+	cfg.Plugins = map[int]interface{}{GatewayPlugin: nil}
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateSnapshot(cfg)))
+}
+
+func TestValidateActionGossip(t *testing.T) {
+	require := require.New(t)
+	cfg := Default
+	require.NoError(ValidateActionGossip(cfg))
+	cfg.Network.ActionGossip = p2p.GossipConfig{
+		Mode:  p2p.GossipSqrt,
+		Rules: []p2p.GossipRule{{ActionType: "Transfer", Mode: p2p.GossipPushPull}},
+	}
+	require.NoError(ValidateActionGossip(cfg))
+	cfg.Network.ActionGossip.Mode = "random"
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateActionGossip(cfg)))
+}
+
+func TestValidateSink(t *testing.T) {
+	require := require.New(t)
+	cfg := Default
+	require.NoError(ValidateSink(cfg))
+	cfg.Sink.Type = sink.TypeKafka
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateSink(cfg)))
+	cfg.Sink.Kafka.ProxyURL = "http://localhost:8082"
+	require.NoError(ValidateSink(cfg))
+	cfg.Sink.BatchSize = 0
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateSink(cfg)))
+	cfg.Sink.Type = "redis"
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateSink(cfg)))
+}
+
+func TestValidateWebhook(t *testing.T) {
+	require := require.New(t)
+	cfg := Default
+	require.NoError(ValidateWebhook(cfg))
+	cfg.Webhook.Endpoints = []webhook.EndpointConfig{{URL: "localhost"}}
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateWebhook(cfg)))
+	cfg.Webhook.Endpoints = []webhook.EndpointConfig{{URL: "https://example.com/hook"}}
+	require.NoError(ValidateWebhook(cfg))
+	cfg.Webhook.LargeStakeAmount = "1e24"
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateWebhook(cfg)))
+	cfg.Webhook.LargeStakeAmount = webhook.DefaultConfig.LargeStakeAmount
+	cfg.Webhook.QueueSize = 0
+	require.Equal(ErrInvalidCfg, errors.Cause(ValidateWebhook(cfg)))
+}
+
 func TestValidateActPool(t *testing.T) {
 	cfg := Default
 	cfg.ActPool.MaxNumActsPerAcct = 0
@@ -308,6 +435,12 @@ func TestValidateActPool(t *testing.T) {
 			"maximum number of actions per pool cannot be less than maximum number of actions per account",
 		),
 	)
+
+	cfg.ActPool.MaxNumActsPerPool = 1000
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	cfg.ActPool.ReservedNumActs = 10
+	require.NoError(t, ValidateActPool(cfg))
 }
 
 func TestBlobConfig(t *testing.T) {
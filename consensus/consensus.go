@@ -35,6 +35,7 @@ type Consensus interface {
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
 	Metrics() (scheme.ConsensusMetrics, error)
+	State() (scheme.ConsensusState, error)
 	Activate(bool)
 	Active() bool
 }
@@ -49,6 +50,7 @@ type optionParams struct {
 	broadcastHandler scheme.Broadcast
 	pp               poll.Protocol
 	rp               *rp.Protocol
+	evidenceHandler  rolldpos.EvidenceHandler
 }
 
 // Option sets Consensus construction parameter.
@@ -78,6 +80,14 @@ func WithPollProtocol(pp poll.Protocol) Option {
 	}
 }
 
+// WithEvidenceHandler is an option to handle the double-sign evidences detected by RollDPoS
+func WithEvidenceHandler(handler rolldpos.EvidenceHandler) Option {
+	return func(ops *optionParams) error {
+		ops.evidenceHandler = handler
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg rolldpos.BuilderConfig,
@@ -140,6 +150,7 @@ func NewConsensus(
 			SetBlockDeserializer(block.NewDeserializer(bc.EvmNetworkID())).
 			SetClock(clock).
 			SetBroadcast(ops.broadcastHandler).
+			SetEvidenceHandler(ops.evidenceHandler).
 			SetDelegatesByEpochFunc(delegatesByEpochFunc).
 			SetProposersByEpochFunc(proposersByEpochFunc).
 			RegisterProtocol(ops.rp)
@@ -216,6 +227,11 @@ func (c *IotxConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 	return c.scheme.Metrics()
 }
 
+// State returns the state of the consensus round in progress
+func (c *IotxConsensus) State() (scheme.ConsensusState, error) {
+	return c.scheme.State()
+}
+
 // HandleConsensusMsg handles consensus messages
 func (c *IotxConsensus) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	return c.scheme.HandleConsensusMsg(msg)
@@ -0,0 +1,212 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package consensusfsm
+
+import (
+	"container/heap"
+	"sync"
+
+	fsm "github.com/iotexproject/go-fsm"
+)
+
+type (
+	// eventQueue is a priority queue of the consensus events keyed by (height, round, event type), so that the
+	// endorsements of the current round are handled before the events of the future rounds and the timeouts of the
+	// current round, without waiting behind a flood of messages queued earlier. The queue bounds the number of the
+	// messages received from the network only, the events produced by the fsm itself are never dropped
+	eventQueue struct {
+		mutex    sync.Mutex
+		events   eventHeap
+		messages int
+		capacity int
+		seq      uint64
+		// height and round of the latest consumed event, below which the messages are obsolete
+		height uint64
+		round  uint32
+		notify chan struct{}
+	}
+
+	queuedEvent struct {
+		*ConsensusEvent
+		seq uint64
+	}
+
+	eventHeap []*queuedEvent
+)
+
+func newEventQueue(capacity int) *eventQueue {
+	return &eventQueue{
+		capacity: capacity,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// push adds the event into the queue, and returns false if the event is dropped
+func (q *eventQueue) push(evt *ConsensusEvent) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.obsolete(evt) {
+		return false
+	}
+	qe := &queuedEvent{ConsensusEvent: evt, seq: q.seq}
+	q.seq++
+	if isMessage(evt.Type()) {
+		if q.messages >= q.capacity {
+			// evict the message of the lowest priority to make room for the new one
+			idx := -1
+			for i, e := range q.events {
+				if isMessage(e.Type()) && (idx < 0 || q.events[idx].less(e)) {
+					idx = i
+				}
+			}
+			if idx < 0 || !qe.less(q.events[idx]) {
+				return false
+			}
+			evicted := heap.Remove(&q.events, idx).(*queuedEvent)
+			q.messages--
+			_consensusEvtsMtc.WithLabelValues(string(evicted.Type()), "dropped").Inc()
+		}
+		q.messages++
+	}
+	heap.Push(&q.events, qe)
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// pop removes and returns the event of the highest priority, and blocks until an event is available or done is closed.
+// Once done is closed, it returns false even if there are events queued, since the fsm may keep producing events
+func (q *eventQueue) pop(done <-chan interface{}) (*ConsensusEvent, bool) {
+	for {
+		select {
+		case <-done:
+			return nil, false
+		default:
+		}
+		q.mutex.Lock()
+		if q.events.Len() > 0 {
+			qe := heap.Pop(&q.events).(*queuedEvent)
+			if isMessage(qe.Type()) {
+				q.messages--
+			}
+			q.mutex.Unlock()
+			return qe.ConsensusEvent, true
+		}
+		q.mutex.Unlock()
+		select {
+		case <-done:
+			return nil, false
+		case <-q.notify:
+		}
+	}
+}
+
+// prune drops the queued messages obsoleted by the consumed event of the height and round
+func (q *eventQueue) prune(height uint64, round uint32) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if height < q.height || height == q.height && round <= q.round {
+		return
+	}
+	q.height, q.round = height, round
+	events := q.events[:0]
+	for _, e := range q.events {
+		if q.obsolete(e.ConsensusEvent) {
+			q.messages--
+			_consensusEvtsMtc.WithLabelValues(string(e.Type()), "stale").Inc()
+			continue
+		}
+		events = append(events, e)
+	}
+	for i := len(events); i < len(q.events); i++ {
+		q.events[i] = nil
+	}
+	q.events = events
+	heap.Init(&q.events)
+}
+
+func (q *eventQueue) len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.events.Len()
+}
+
+// obsolete returns true if the event is a message of a previous height, or a message other than the pre-commit
+// endorsement of a previous round, consistent with the stale events of the context
+func (q *eventQueue) obsolete(evt *ConsensusEvent) bool {
+	if !isMessage(evt.Type()) {
+		return false
+	}
+	switch {
+	case evt.Height() < q.height:
+		return true
+	case evt.Height() > q.height || evt.Round() >= q.round:
+		return false
+	default:
+		return evt.Type() != eReceivePreCommitEndorsement
+	}
+}
+
+// isMessage returns true if the event carries a consensus message, which may be received from the network
+func isMessage(et fsm.EventType) bool {
+	switch et {
+	case eReceiveBlock, eReceiveProposalEndorsement, eReceiveLockEndorsement, eReceivePreCommitEndorsement:
+		return true
+	default:
+		return false
+	}
+}
+
+// priority returns the priority of the event type, the lower the earlier. The messages of a round are handled in the
+// order of the consensus steps, ahead of the timeouts of the round
+func priority(et fsm.EventType) int {
+	switch et {
+	case eCalibrate, BackdoorEvent:
+		return 0
+	case eReceiveBlock:
+		return 1
+	case eReceiveProposalEndorsement:
+		return 2
+	case eReceiveLockEndorsement:
+		return 3
+	case eReceivePreCommitEndorsement:
+		return 4
+	default:
+		return 5
+	}
+}
+
+func (e *queuedEvent) less(other *queuedEvent) bool {
+	switch {
+	case e.Height() != other.Height():
+		return e.Height() < other.Height()
+	case e.Round() != other.Round():
+		return e.Round() < other.Round()
+	case priority(e.Type()) != priority(other.Type()):
+		return priority(e.Type()) < priority(other.Type())
+	default:
+		return e.seq < other.seq
+	}
+}
+
+func (h eventHeap) Len() int           { return len(h) }
+func (h eventHeap) Less(i, j int) bool { return h[i].less(h[j]) }
+func (h eventHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *eventHeap) Push(x interface{}) {
+	*h = append(*h, x.(*queuedEvent))
+}
+
+func (h *eventHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
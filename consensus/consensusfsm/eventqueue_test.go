@@ -0,0 +1,129 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package consensusfsm
+
+import (
+	"testing"
+	"time"
+
+	fsm "github.com/iotexproject/go-fsm"
+	"github.com/stretchr/testify/require"
+)
+
+func popEvent(q *eventQueue) *ConsensusEvent {
+	evt, _ := q.pop(nil)
+	return evt
+}
+
+func TestEventQueue(t *testing.T) {
+	require := require.New(t)
+	newEvt := func(et fsm.EventType, height uint64, round uint32) *ConsensusEvent {
+		return NewConsensusEvent(et, nil, height, round, time.Now())
+	}
+	type key struct {
+		et     fsm.EventType
+		height uint64
+		round  uint32
+	}
+	popKeys := func(q *eventQueue) []key {
+		var keys []key
+		for q.len() > 0 {
+			evt := popEvent(q)
+			keys = append(keys, key{evt.Type(), evt.Height(), evt.Round()})
+		}
+		return keys
+	}
+
+	t.Run("order", func(t *testing.T) {
+		q := newEventQueue(10)
+		for _, evt := range []*ConsensusEvent{
+			newEvt(eStopReceivingProposalEndorsement, 10, 1),
+			newEvt(eReceiveBlock, 10, 2),
+			newEvt(eReceivePreCommitEndorsement, 10, 1),
+			newEvt(eReceiveProposalEndorsement, 10, 1),
+			newEvt(eReceiveBlock, 10, 1),
+			newEvt(eReceiveProposalEndorsement, 11, 0),
+			newEvt(eCalibrate, 10, 1),
+			newEvt(eReceiveProposalEndorsement, 10, 1),
+		} {
+			require.True(q.push(evt))
+		}
+		require.Equal(8, q.len())
+		require.Equal([]key{
+			{eCalibrate, 10, 1},
+			{eReceiveBlock, 10, 1},
+			{eReceiveProposalEndorsement, 10, 1},
+			{eReceiveProposalEndorsement, 10, 1},
+			{eReceivePreCommitEndorsement, 10, 1},
+			{eStopReceivingProposalEndorsement, 10, 1},
+			{eReceiveBlock, 10, 2},
+			{eReceiveProposalEndorsement, 11, 0},
+		}, popKeys(q))
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		q := newEventQueue(10)
+		for _, evt := range []*ConsensusEvent{
+			newEvt(eReceiveBlock, 10, 1),
+			newEvt(eReceiveLockEndorsement, 10, 1),
+			newEvt(eReceivePreCommitEndorsement, 10, 1),
+			newEvt(eStopReceivingLockEndorsement, 10, 1),
+			newEvt(eReceivePreCommitEndorsement, 9, 3),
+			newEvt(eReceiveBlock, 10, 2),
+		} {
+			require.True(q.push(evt))
+		}
+		q.prune(10, 2)
+		// the obsolete messages are dropped on push as well
+		require.False(q.push(newEvt(eReceiveProposalEndorsement, 10, 0)))
+		require.True(q.push(newEvt(eReceivePreCommitEndorsement, 10, 0)))
+		// the watermark does not go back
+		q.prune(10, 0)
+		require.Equal([]key{
+			{eReceivePreCommitEndorsement, 10, 0},
+			{eReceivePreCommitEndorsement, 10, 1},
+			{eStopReceivingLockEndorsement, 10, 1},
+			{eReceiveBlock, 10, 2},
+		}, popKeys(q))
+	})
+
+	t.Run("capacity", func(t *testing.T) {
+		q := newEventQueue(2)
+		require.True(q.push(newEvt(eReceiveBlock, 10, 2)))
+		require.True(q.push(newEvt(eReceiveBlock, 10, 1)))
+		// the events produced by the fsm are not bounded
+		require.True(q.push(newEvt(ePrepare, 10, 3)))
+		// the message of lower priority than the queued ones is dropped
+		require.False(q.push(newEvt(eReceiveBlock, 11, 0)))
+		// the message of the lowest priority is evicted
+		require.True(q.push(newEvt(eReceiveLockEndorsement, 10, 1)))
+		require.Equal([]key{
+			{eReceiveBlock, 10, 1},
+			{eReceiveLockEndorsement, 10, 1},
+			{ePrepare, 10, 3},
+		}, popKeys(q))
+	})
+
+	t.Run("pop", func(t *testing.T) {
+		q := newEventQueue(10)
+		done := make(chan interface{})
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			q.push(newEvt(eReceiveBlock, 10, 1))
+		}()
+		evt, ok := q.pop(done)
+		require.True(ok)
+		require.Equal(eReceiveBlock, evt.Type())
+		close(done)
+		_, ok = q.pop(done)
+		require.False(ok)
+		// the queued events are not handled once done is closed
+		require.True(q.push(newEvt(ePrepare, 10, 2)))
+		_, ok = q.pop(done)
+		require.False(ok)
+		require.Equal(1, q.len())
+	})
+}
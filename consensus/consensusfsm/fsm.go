@@ -7,6 +7,7 @@ package consensusfsm
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -29,10 +30,17 @@ var (
 		},
 		[]string{"type", "status"},
 	)
+	_consensusTimersMtc = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iotex_consensus_pending_timers",
+			Help: "IoTeX consensus timeout events pending",
+		},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(_consensusEvtsMtc)
+	prometheus.MustRegister(_consensusTimersMtc)
 }
 
 const (
@@ -88,20 +96,32 @@ var (
 // ConsensusFSM wraps over the general purpose FSM and implements the consensus logic
 type ConsensusFSM struct {
 	fsm   fsm.FSM
-	evtq  chan *ConsensusEvent
+	evtq  *eventQueue
 	close chan interface{}
 	clock clock.Clock
 	ctx   Context
 	wg    sync.WaitGroup
+	// timers are the deadlines of the delayed events not produced yet
+	timers     map[*ConsensusEvent]time.Time
+	timerMutex sync.Mutex
+}
+
+// Timer is a delayed event scheduled by the fsm, e.g., the timeout of a consensus step
+type Timer struct {
+	Event    fsm.EventType
+	Height   uint64
+	Round    uint32
+	Deadline time.Time
 }
 
 // NewConsensusFSM returns a new fsm
 func NewConsensusFSM(ctx Context, clock clock.Clock) (*ConsensusFSM, error) {
 	cm := &ConsensusFSM{
-		evtq:  make(chan *ConsensusEvent, ctx.EventChanSize()),
-		close: make(chan interface{}),
-		ctx:   ctx,
-		clock: clock,
+		evtq:   newEventQueue(int(ctx.EventChanSize())),
+		close:  make(chan interface{}),
+		ctx:    ctx,
+		clock:  clock,
+		timers: make(map[*ConsensusEvent]time.Time),
 	}
 	b := fsm.NewBuilder().
 		AddInitialState(sPrepare).
@@ -226,18 +246,16 @@ func NewConsensusFSM(ctx Context, clock clock.Clock) (*ConsensusFSM, error) {
 func (m *ConsensusFSM) Start(c context.Context) error {
 	m.wg.Add(1)
 	go func() {
-		running := true
-		for running {
-			select {
-			case <-m.close:
-				running = false
-			case evt := <-m.evtq:
-				if err := m.handle(evt); err != nil {
-					m.ctx.Logger().Error(
-						"consensus state transition fails",
-						zap.Error(err),
-					)
-				}
+		for {
+			evt, ok := m.evtq.pop(m.close)
+			if !ok {
+				break
+			}
+			if err := m.handle(evt); err != nil {
+				m.ctx.Logger().Error(
+					"consensus state transition fails",
+					zap.Error(err),
+				)
 			}
 		}
 		m.wg.Done()
@@ -259,7 +277,7 @@ func (m *ConsensusFSM) CurrentState() fsm.State {
 
 // NumPendingEvents returns the number of pending events
 func (m *ConsensusFSM) NumPendingEvents() int {
-	return len(m.evtq)
+	return m.evtq.len()
 }
 
 // Calibrate calibrates the state if necessary
@@ -307,17 +325,59 @@ func (m *ConsensusFSM) produce(evt *ConsensusEvent, delay time.Duration) {
 	}
 	_consensusEvtsMtc.WithLabelValues(string(evt.Type()), "produced").Inc()
 	if delay > 0 {
+		m.setTimer(evt, m.clock.Now().Add(delay))
 		m.wg.Add(1)
 		go func() {
 			select {
 			case <-m.close:
 			case <-m.clock.After(delay):
-				m.evtq <- evt
+				m.push(evt)
 			}
+			m.clearTimer(evt)
 			m.wg.Done()
 		}()
 	} else {
-		m.evtq <- evt
+		m.push(evt)
+	}
+}
+
+// Timers returns the delayed events not produced yet, in the order of deadlines
+func (m *ConsensusFSM) Timers() []Timer {
+	m.timerMutex.Lock()
+	timers := make([]Timer, 0, len(m.timers))
+	for evt, deadline := range m.timers {
+		timers = append(timers, Timer{
+			Event:    evt.Type(),
+			Height:   evt.Height(),
+			Round:    evt.Round(),
+			Deadline: deadline,
+		})
+	}
+	m.timerMutex.Unlock()
+	sort.Slice(timers, func(i, j int) bool {
+		return timers[i].Deadline.Before(timers[j].Deadline)
+	})
+	return timers
+}
+
+func (m *ConsensusFSM) setTimer(evt *ConsensusEvent, deadline time.Time) {
+	m.timerMutex.Lock()
+	defer m.timerMutex.Unlock()
+	m.timers[evt] = deadline
+	_consensusTimersMtc.Set(float64(len(m.timers)))
+}
+
+func (m *ConsensusFSM) clearTimer(evt *ConsensusEvent) {
+	m.timerMutex.Lock()
+	defer m.timerMutex.Unlock()
+	delete(m.timers, evt)
+	_consensusTimersMtc.Set(float64(len(m.timers)))
+}
+
+func (m *ConsensusFSM) push(evt *ConsensusEvent) {
+	if !m.evtq.push(evt) {
+		m.ctx.Logger().Debug("drop event", zap.Any("event", evt.Type()))
+		_consensusEvtsMtc.WithLabelValues(string(evt.Type()), "dropped").Inc()
 	}
 }
 
@@ -345,6 +405,7 @@ func (m *ConsensusFSM) handle(evt *ConsensusEvent) error {
 			zap.String("evt", string(evt.Type())),
 		)
 		_consensusEvtsMtc.WithLabelValues(string(evt.Type()), "consumed").Inc()
+		m.evtq.prune(evt.Height(), evt.Round())
 	case fsm.ErrTransitionNotFound:
 		if m.ctx.IsStaleUnmatchedEvent(evt) {
 			_consensusEvtsMtc.WithLabelValues(string(evt.Type()), "stale").Inc()
@@ -94,7 +94,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			require.Equal(sPrepare, state)
 			time.Sleep(100 * time.Millisecond)
 			mockClock.Add(10 * time.Second)
-			evt := <-cfsm.evtq
+			evt := popEvent(cfsm.evtq)
 			require.Equal(ePrepare, evt.Type())
 		})
 		t.Run("stand-by-or-is-not-delegate", func(t *testing.T) {
@@ -108,7 +108,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			require.Equal(sPrepare, state)
 			time.Sleep(100 * time.Millisecond)
 			mockClock.Add(10 * time.Second)
-			evt := <-cfsm.evtq
+			evt := popEvent(cfsm.evtq)
 			require.Equal(ePrepare, evt.Type())
 			// deactivate node
 			mockCtx.EXPECT().Active().Return(false).Times(1)
@@ -117,14 +117,14 @@ func TestStateTransitionFunctions(t *testing.T) {
 			require.Equal(sPrepare, state)
 			time.Sleep(100 * time.Millisecond)
 			mockClock.Add(10 * time.Second)
-			require.Equal(0, len(cfsm.evtq))
+			require.Equal(0, cfsm.evtq.len())
 			// reactivate node
 			mockCtx.EXPECT().Active().Return(true).Times(1)
 			_, err = cfsm.BackToPrepare(0)
 			require.NoError(err)
 			time.Sleep(100 * time.Millisecond)
 			mockClock.Add(10 * time.Second)
-			evt = <-cfsm.evtq
+			evt = popEvent(cfsm.evtq)
 			require.Equal(ePrepare, evt.Type())
 		})
 		t.Run("is-delegate", func(t *testing.T) {
@@ -141,16 +141,16 @@ func TestStateTransitionFunctions(t *testing.T) {
 					time.Sleep(100 * time.Millisecond)
 					// garbage collection
 					mockClock.Add(cfsm.ctx.AcceptBlockTTL(0))
-					evt := <-cfsm.evtq
+					evt := popEvent(cfsm.evtq)
 					require.Equal(eFailedToReceiveBlock, evt.Type())
 					mockClock.Add(cfsm.ctx.AcceptProposalEndorsementTTL(0))
-					evt = <-cfsm.evtq
+					evt = popEvent(cfsm.evtq)
 					require.Equal(eStopReceivingProposalEndorsement, evt.Type())
 					mockClock.Add(cfsm.ctx.AcceptLockEndorsementTTL(0))
-					evt = <-cfsm.evtq
+					evt = popEvent(cfsm.evtq)
 					require.Equal(eStopReceivingLockEndorsement, evt.Type())
 					mockClock.Add(cfsm.ctx.CommitTTL(0))
-					evt = <-cfsm.evtq
+					evt = popEvent(cfsm.evtq)
 					require.Equal(eStopReceivingPreCommitEndorsement, evt.Type())
 				})
 				t.Run("ready-to-commit", func(t *testing.T) {
@@ -165,16 +165,16 @@ func TestStateTransitionFunctions(t *testing.T) {
 					time.Sleep(100 * time.Millisecond)
 					// garbage collection
 					mockClock.Add(cfsm.ctx.AcceptBlockTTL(0))
-					evt := <-cfsm.evtq
+					evt := popEvent(cfsm.evtq)
 					require.Equal(eBroadcastPreCommitEndorsement, evt.Type())
 					mockClock.Add(cfsm.ctx.AcceptProposalEndorsementTTL(0))
-					evt = <-cfsm.evtq
+					evt = popEvent(cfsm.evtq)
 					require.Equal(eBroadcastPreCommitEndorsement, evt.Type())
 					mockClock.Add(cfsm.ctx.AcceptLockEndorsementTTL(0))
-					evt = <-cfsm.evtq
+					evt = popEvent(cfsm.evtq)
 					require.Equal(eBroadcastPreCommitEndorsement, evt.Type())
 					mockClock.Add(cfsm.ctx.CommitTTL(0))
-					evt = <-cfsm.evtq
+					evt = popEvent(cfsm.evtq)
 					require.Equal(eStopReceivingPreCommitEndorsement, evt.Type())
 				})
 			})
@@ -186,7 +186,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 					state, err := cfsm.prepare(evt)
 					require.NoError(err)
 					require.Equal(sPrepare, state)
-					evt := <-cfsm.evtq
+					evt := popEvent(cfsm.evtq)
 					require.Equal(ePrepare, evt.Type())
 				})
 				t.Run("success-to-mint", func(t *testing.T) {
@@ -199,18 +199,18 @@ func TestStateTransitionFunctions(t *testing.T) {
 					state, err := cfsm.prepare(evt)
 					require.NoError(err)
 					require.Equal(sAcceptBlockProposal, state)
-					evt := <-cfsm.evtq
+					evt := popEvent(cfsm.evtq)
 					require.Equal(eReceiveBlock, evt.Type())
 					// garbage collection
 					time.Sleep(100 * time.Millisecond)
 					mockClock.Add(4 * time.Second)
-					evt = <-cfsm.evtq
+					evt = popEvent(cfsm.evtq)
 					require.Equal(eFailedToReceiveBlock, evt.Type())
 					mockClock.Add(2 * time.Second)
-					evt = <-cfsm.evtq
+					evt = popEvent(cfsm.evtq)
 					require.Equal(eStopReceivingProposalEndorsement, evt.Type())
 					mockClock.Add(2 * time.Second)
-					evt = <-cfsm.evtq
+					evt = popEvent(cfsm.evtq)
 					require.Equal(eStopReceivingLockEndorsement, evt.Type())
 				})
 			})
@@ -239,7 +239,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			state, err := cfsm.onReceiveBlock(&ConsensusEvent{data: NewMockEndorsement(ctrl)})
 			require.NoError(err)
 			require.Equal(sAcceptProposalEndorsement, state)
-			evt := <-cfsm.evtq
+			evt := popEvent(cfsm.evtq)
 			require.Equal(eReceiveProposalEndorsement, evt.Type())
 		})
 	})
@@ -249,7 +249,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 		state, err := cfsm.onFailedToReceiveBlock(nil)
 		require.NoError(err)
 		require.Equal(sAcceptProposalEndorsement, state)
-		evt := <-cfsm.evtq
+		evt := popEvent(cfsm.evtq)
 		require.Equal(eReceiveProposalEndorsement, evt.Type())
 	})
 	t.Run("onReceiveProposalEndorsementInAcceptProposalEndorsementState", func(t *testing.T) {
@@ -292,7 +292,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			})
 			require.NoError(err)
 			require.Equal(sAcceptLockEndorsement, state)
-			evt := <-cfsm.evtq
+			evt := popEvent(cfsm.evtq)
 			require.Equal(eReceiveLockEndorsement, evt.Type())
 			state, err = cfsm.onReceiveProposalEndorsementInAcceptProposalEndorsementState(&ConsensusEvent{
 				eventType: eReceivePreCommitEndorsement,
@@ -300,7 +300,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			})
 			require.NoError(err)
 			require.Equal(sAcceptLockEndorsement, state)
-			evt = <-cfsm.evtq
+			evt = popEvent(cfsm.evtq)
 			require.Equal(eReceiveLockEndorsement, evt.Type())
 		})
 	})
@@ -349,7 +349,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			})
 			require.NoError(err)
 			require.Equal(sAcceptLockEndorsement, state)
-			evt := <-cfsm.evtq
+			evt := popEvent(cfsm.evtq)
 			require.Equal(eReceiveLockEndorsement, evt.Type())
 			state, err = cfsm.onReceiveProposalEndorsementInAcceptLockEndorsementState(&ConsensusEvent{
 				eventType: eReceivePreCommitEndorsement,
@@ -357,7 +357,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			})
 			require.NoError(err)
 			require.Equal(sAcceptLockEndorsement, state)
-			evt = <-cfsm.evtq
+			evt = popEvent(cfsm.evtq)
 			require.Equal(eReceiveLockEndorsement, evt.Type())
 		})
 	})
@@ -401,7 +401,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			})
 			require.NoError(err)
 			require.Equal(sAcceptPreCommitEndorsement, state)
-			evt := <-cfsm.evtq
+			evt := popEvent(cfsm.evtq)
 			require.Equal(eReceivePreCommitEndorsement, evt.Type())
 		})
 	})
@@ -410,7 +410,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 		state, err := cfsm.onStopReceivingLockEndorsement(nil)
 		require.NoError(err)
 		require.Equal(sPrepare, state)
-		evt := <-cfsm.evtq
+		evt := popEvent(cfsm.evtq)
 		require.Equal(ePrepare, evt.Type())
 	})
 	t.Run("onBroadcastPreCommitEndorsement", func(t *testing.T) {
@@ -466,7 +466,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			})
 			require.NoError(err)
 			require.Equal(sPrepare, state)
-			evt := <-cfsm.evtq
+			evt := popEvent(cfsm.evtq)
 			require.Equal(ePrepare, evt.Type())
 		})
 	})
@@ -491,7 +491,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 		})
 		require.NoError(err)
 		require.Equal(sPrepare, state)
-		evt := <-cfsm.evtq
+		evt := popEvent(cfsm.evtq)
 		require.Equal(ePrepare, evt.Type())
 	})
 	t.Run("handle", func(t *testing.T) {
@@ -514,7 +514,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 			require.NoError(cfsm.handle(cEvt))
 			time.Sleep(10 * time.Millisecond)
 			mockClock.Add(cfsm.ctx.UnmatchedEventInterval(0))
-			evt := <-cfsm.evtq
+			evt := popEvent(cfsm.evtq)
 			require.Equal(cEvt, evt)
 		})
 		mockCtx.EXPECT().IsStaleEvent(gomock.Any()).Return(false).AnyTimes()
@@ -537,7 +537,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 				require.NoError(cfsm.handle(cEvt))
 				time.Sleep(10 * time.Millisecond)
 				mockClock.Add(cfsm.ctx.UnmatchedEventInterval(0))
-				evtc := <-cfsm.evtq
+				evtc := popEvent(cfsm.evtq)
 				require.Equal(evtc, cEvt)
 			})
 		})
@@ -555,3 +555,30 @@ func TestStateTransitionFunctions(t *testing.T) {
 		})
 	})
 }
+
+func TestTimers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	mockClock := clock.NewMock()
+	mockCtx := NewMockContext(ctrl)
+	mockCtx.EXPECT().EventChanSize().Return(uint(10)).AnyTimes()
+	cfsm, err := NewConsensusFSM(mockCtx, mockClock)
+	require.NoError(err)
+	now := mockClock.Now()
+	cfsm.produce(NewConsensusEvent(eFailedToReceiveBlock, nil, 10, 1, now), 4*time.Second)
+	cfsm.produce(NewConsensusEvent(eStopReceivingProposalEndorsement, nil, 10, 1, now), 2*time.Second)
+	cfsm.produce(NewConsensusEvent(eReceiveBlock, nil, 10, 1, now), 0)
+	require.Equal([]Timer{
+		{eStopReceivingProposalEndorsement, 10, 1, now.Add(2 * time.Second)},
+		{eFailedToReceiveBlock, 10, 1, now.Add(4 * time.Second)},
+	}, cfsm.Timers())
+	time.Sleep(100 * time.Millisecond)
+	mockClock.Add(3 * time.Second)
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 100*time.Millisecond, func() (bool, error) {
+		return len(cfsm.Timers()) == 1, nil
+	}))
+	require.Equal(eFailedToReceiveBlock, cfsm.Timers()[0].Event)
+	close(cfsm.close)
+	cfsm.wg.Wait()
+	require.Empty(cfsm.Timers())
+}
@@ -53,6 +53,14 @@ func (n *Noop) Metrics() (ConsensusMetrics, error) {
 	)
 }
 
+// State is not implemented for noop scheme
+func (n *Noop) State() (ConsensusState, error) {
+	return ConsensusState{}, errors.Wrapf(
+		ErrNotImplemented,
+		"noop scheme does not supported state yet",
+	)
+}
+
 // Activate is not implemented for noop scheme
 func (n *Noop) Activate(_ bool) {
 	log.S().Warn("Noop scheme could not support activate")
@@ -7,6 +7,7 @@ package rolldpos
 
 import (
 	"encoding/hex"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,6 +15,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
 	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos/endorsementpb"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/endorsement"
@@ -406,6 +408,23 @@ func (m *endorsementManager) Cleanup(timestamp time.Time) error {
 	return nil
 }
 
+// Stats returns the number of endorsements of each topic received for the blocks
+func (m *endorsementManager) Stats() []scheme.BlockEndorsements {
+	stats := make([]scheme.BlockEndorsements, 0, len(m.collections))
+	for encoded, c := range m.collections {
+		stats = append(stats, scheme.BlockEndorsements{
+			BlockHash: encoded,
+			Proposals: len(c.Endorsements([]ConsensusVoteTopic{PROPOSAL})),
+			Locks:     len(c.Endorsements([]ConsensusVoteTopic{LOCK})),
+			Commits:   len(c.Endorsements([]ConsensusVoteTopic{COMMIT})),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].BlockHash < stats[j].BlockHash
+	})
+	return stats
+}
+
 func (m *endorsementManager) Log(
 	logger *zap.Logger,
 	delegates []string,
@@ -0,0 +1,360 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const (
+	// SlashingProtocolID is the id of the protocol consuming the double-sign evidence
+	SlashingProtocolID = "slashing"
+
+	_evidenceNS = "DoubleSignEvidence"
+	// _evidenceHeightWindow is the number of heights below the latest one, of which the messages are kept to detect
+	// double signing
+	_evidenceHeightWindow = 2
+	// _proposalKind is the kind of the block proposal, distinguished from the topics of the votes
+	_proposalKind = -1
+)
+
+var (
+	// ErrInvalidEvidence indicates the double-sign evidence is invalid
+	ErrInvalidEvidence = errors.New("invalid double-sign evidence")
+)
+
+type (
+	// DoubleSignEvidence is the evidence of a delegate signing two conflicting consensus messages of the same kind at
+	// the same height and round, i.e., two proposals of different blocks, or two votes of the same topic for different
+	// blocks
+	DoubleSignEvidence struct {
+		round uint32
+		msgs  [2]*EndorsedConsensusMessage
+	}
+
+	// EvidenceHandler handles the double-sign evidence detected, e.g., sends it to the slashing protocol
+	EvidenceHandler func(*DoubleSignEvidence) error
+
+	// RoundOf returns the round number of the consensus message signed at the time for the height
+	RoundOf func(uint64, time.Time) (uint32, error)
+
+	evidenceKey struct {
+		height   uint64
+		round    uint32
+		endorser string
+		kind     int
+	}
+
+	// evidenceDetector keeps the consensus messages of the recent heights, and reports the conflicting ones signed by
+	// the same delegate
+	evidenceDetector struct {
+		kv       db.KVStore
+		roundOf  RoundOf
+		handler  EvidenceHandler
+		mutex    sync.Mutex
+		height   uint64
+		seen     map[evidenceKey]*EndorsedConsensusMessage
+		reported map[evidenceKey]struct{}
+	}
+)
+
+// NewDoubleSignEvidence creates a double-sign evidence of the two messages signed in the round
+func NewDoubleSignEvidence(round uint32, msg1, msg2 *EndorsedConsensusMessage) *DoubleSignEvidence {
+	return &DoubleSignEvidence{
+		round: round,
+		msgs:  [2]*EndorsedConsensusMessage{msg1, msg2},
+	}
+}
+
+// EvidenceAddress returns the address of the slashing protocol, to which the double-sign evidence is sent
+func EvidenceAddress() address.Address {
+	h := hash.Hash160b([]byte(SlashingProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("failed to create evidence address", zap.Error(err))
+	}
+	return addr
+}
+
+// Height returns the height of the conflicting messages
+func (e *DoubleSignEvidence) Height() uint64 {
+	return e.msgs[0].Height()
+}
+
+// Round returns the round of the conflicting messages
+func (e *DoubleSignEvidence) Round() uint32 {
+	return e.round
+}
+
+// Endorser returns the address of the delegate signing the conflicting messages
+func (e *DoubleSignEvidence) Endorser() address.Address {
+	return e.msgs[0].Endorsement().Endorser().Address()
+}
+
+// Messages returns the conflicting messages
+func (e *DoubleSignEvidence) Messages() [2]*EndorsedConsensusMessage {
+	return e.msgs
+}
+
+// Serialize encodes the evidence as the round followed by the length-prefixed consensus messages
+func (e *DoubleSignEvidence) Serialize() ([]byte, error) {
+	buf := binary.AppendUvarint(nil, uint64(e.round))
+	for _, msg := range e.msgs {
+		pb, err := msg.Proto()
+		if err != nil {
+			return nil, err
+		}
+		data, err := proto.Marshal(pb)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal consensus message")
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(data)))
+		buf = append(buf, data...)
+	}
+	return buf, nil
+}
+
+// Deserialize decodes the evidence
+func (e *DoubleSignEvidence) Deserialize(data []byte, deserializer *block.Deserializer) error {
+	round, n := binary.Uvarint(data)
+	if n <= 0 || round > uint64(^uint32(0)) {
+		return errors.Wrap(ErrInvalidEvidence, "failed to decode round")
+	}
+	data = data[n:]
+	var msgs [2]*EndorsedConsensusMessage
+	for i := range msgs {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || size > uint64(len(data)-n) {
+			return errors.Wrap(ErrInvalidEvidence, "failed to decode consensus message length")
+		}
+		pb := &iotextypes.ConsensusMessage{}
+		if err := proto.Unmarshal(data[n:n+int(size)], pb); err != nil {
+			return errors.Wrap(err, "failed to unmarshal consensus message")
+		}
+		msgs[i] = &EndorsedConsensusMessage{}
+		if err := msgs[i].LoadProto(pb, deserializer); err != nil {
+			return err
+		}
+		data = data[n+int(size):]
+	}
+	if len(data) != 0 {
+		return errors.Wrap(ErrInvalidEvidence, "trailing bytes")
+	}
+	e.round = uint32(round)
+	e.msgs = msgs
+	return nil
+}
+
+// Hash returns the hash of the evidence
+func (e *DoubleSignEvidence) Hash() (hash.Hash256, error) {
+	data, err := e.Serialize()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.Hash256b(data), nil
+}
+
+// Verify checks that the messages are signed by the same delegate in the round, and conflict with each other
+func (e *DoubleSignEvidence) Verify(roundOf RoundOf) error {
+	for _, msg := range e.msgs {
+		if msg == nil || msg.Endorsement() == nil {
+			return errors.Wrap(ErrInvalidEvidence, "missing consensus message")
+		}
+		if !endorsement.VerifyEndorsedDocument(msg) {
+			return errors.Wrap(ErrInvalidEvidence, "failed to verify signature in endorsement")
+		}
+		round, err := roundOf(msg.Height(), msg.Endorsement().Timestamp())
+		if err != nil {
+			return err
+		}
+		if round != e.round {
+			return errors.Wrapf(ErrInvalidEvidence, "message of round %d, expecting %d", round, e.round)
+		}
+	}
+	if e.msgs[0].Height() != e.msgs[1].Height() {
+		return errors.Wrap(ErrInvalidEvidence, "messages of different heights")
+	}
+	if !bytes.Equal(e.msgs[0].Endorsement().Endorser().Bytes(), e.msgs[1].Endorsement().Endorser().Bytes()) {
+		return errors.Wrap(ErrInvalidEvidence, "messages of different endorsers")
+	}
+	kind0, hash0, ok0 := signedContent(e.msgs[0])
+	kind1, hash1, ok1 := signedContent(e.msgs[1])
+	if !ok0 || !ok1 || kind0 != kind1 || bytes.Equal(hash0, hash1) {
+		return errors.Wrap(ErrInvalidEvidence, "messages do not conflict")
+	}
+	return nil
+}
+
+// signedContent returns the kind and the block hash of the consensus message. The votes for no block are not
+// counted, since a delegate may vote for nothing after failing to receive the block, and then for the block
+// received late
+func signedContent(msg *EndorsedConsensusMessage) (int, []byte, bool) {
+	switch doc := msg.Document().(type) {
+	case *blockProposal:
+		if doc.block == nil {
+			return 0, nil, false
+		}
+		h := doc.block.HashBlock()
+		return _proposalKind, h[:], true
+	case *ConsensusVote:
+		blkHash := doc.BlockHash()
+		return int(doc.Topic()), blkHash, len(blkHash) > 0
+	default:
+		return 0, nil, false
+	}
+}
+
+func newEvidenceDetector(kv db.KVStore, roundOf RoundOf, handler EvidenceHandler) *evidenceDetector {
+	return &evidenceDetector{
+		kv:       kv,
+		roundOf:  roundOf,
+		handler:  handler,
+		seen:     make(map[evidenceKey]*EndorsedConsensusMessage),
+		reported: make(map[evidenceKey]struct{}),
+	}
+}
+
+// Start starts the evidence db if any
+func (d *evidenceDetector) Start(ctx context.Context) error {
+	if d.kv == nil {
+		return nil
+	}
+	return errors.Wrap(d.kv.Start(ctx), "failed to start evidence db")
+}
+
+// Stop stops the evidence db if any
+func (d *evidenceDetector) Stop(ctx context.Context) error {
+	if d.kv == nil {
+		return nil
+	}
+	return d.kv.Stop(ctx)
+}
+
+// observe records the verified consensus message, and returns the evidence if the endorser has signed a conflicting
+// message of the same kind in the round. Each double signing is reported once
+func (d *evidenceDetector) observe(msg *EndorsedConsensusMessage) (*DoubleSignEvidence, error) {
+	kind, blkHash, ok := signedContent(msg)
+	if !ok {
+		return nil, nil
+	}
+	round, err := d.roundOf(msg.Height(), msg.Endorsement().Timestamp())
+	if err != nil {
+		return nil, err
+	}
+	key := evidenceKey{
+		height:   msg.Height(),
+		round:    round,
+		endorser: msg.Endorsement().Endorser().HexString(),
+		kind:     kind,
+	}
+	d.mutex.Lock()
+	d.prune(msg.Height())
+	if msg.Height()+_evidenceHeightWindow < d.height {
+		d.mutex.Unlock()
+		return nil, nil
+	}
+	prev, ok := d.seen[key]
+	if !ok {
+		d.seen[key] = msg
+		d.mutex.Unlock()
+		return nil, nil
+	}
+	_, prevHash, _ := signedContent(prev)
+	if _, reported := d.reported[key]; reported || bytes.Equal(prevHash, blkHash) {
+		d.mutex.Unlock()
+		return nil, nil
+	}
+	d.reported[key] = struct{}{}
+	d.mutex.Unlock()
+
+	evidence := NewDoubleSignEvidence(round, prev, msg)
+	log.Logger("consensus").Warn(
+		"detected double signing",
+		zap.String("endorser", evidence.Endorser().String()),
+		zap.Uint64("height", evidence.Height()),
+		zap.Uint32("round", round),
+		zap.Int("kind", kind),
+	)
+	if err := d.persist(evidence); err != nil {
+		return nil, err
+	}
+	if d.handler != nil {
+		if err := d.handler(evidence); err != nil {
+			return nil, errors.Wrap(err, "failed to handle double-sign evidence")
+		}
+	}
+	return evidence, nil
+}
+
+// prune drops the messages below the window of the latest height, it should be called with the lock held
+func (d *evidenceDetector) prune(height uint64) {
+	if height <= d.height {
+		return
+	}
+	d.height = height
+	for key := range d.seen {
+		if key.height+_evidenceHeightWindow < height {
+			delete(d.seen, key)
+		}
+	}
+	for key := range d.reported {
+		if key.height+_evidenceHeightWindow < height {
+			delete(d.reported, key)
+		}
+	}
+}
+
+func (d *evidenceDetector) persist(evidence *DoubleSignEvidence) error {
+	if d.kv == nil {
+		return nil
+	}
+	data, err := evidence.Serialize()
+	if err != nil {
+		return err
+	}
+	h := hash.Hash256b(data)
+	return errors.Wrap(d.kv.Put(_evidenceNS, h[:], data), "failed to persist double-sign evidence")
+}
+
+// Evidences returns the double-sign evidences persisted
+func (d *evidenceDetector) Evidences(deserializer *block.Deserializer) ([]*DoubleSignEvidence, error) {
+	if d.kv == nil {
+		return nil, nil
+	}
+	_, values, err := d.kv.Filter(_evidenceNS, func(_, _ []byte) bool { return true }, nil, nil)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, nil
+	default:
+		return nil, errors.Wrap(err, "failed to load double-sign evidences")
+	}
+	evidences := make([]*DoubleSignEvidence, 0, len(values))
+	for _, v := range values {
+		evidence := &DoubleSignEvidence{}
+		if err := evidence.Deserialize(v, deserializer); err != nil {
+			return nil, err
+		}
+		evidences = append(evidences, evidence)
+	}
+	return evidences, nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestDoubleSignEvidence(t *testing.T) {
+	require := require.New(t)
+	start := time.Unix(1700000000, 0)
+	roundOf := func(_ uint64, ts time.Time) (uint32, error) {
+		return uint32(ts.Sub(start) / (10 * time.Second)), nil
+	}
+	sign := func(idx int, doc endorsement.Document, height uint64, ts time.Time) *EndorsedConsensusMessage {
+		en, err := endorsement.Endorse(identityset.PrivateKey(idx), doc, ts)
+		require.NoError(err)
+		return NewEndorsedConsensusMessage(height, doc, en)
+	}
+	vote := func(idx int, blkHash string, topic ConsensusVoteTopic, height uint64, ts time.Time) *EndorsedConsensusMessage {
+		var h []byte
+		if blkHash != "" {
+			bh := hash.Hash256b([]byte(blkHash))
+			h = bh[:]
+		}
+		return sign(idx, NewConsensusVote(h, topic), height, ts)
+	}
+	proposal := func(idx int, height uint64, ts time.Time) *EndorsedConsensusMessage {
+		rap := block.RunnableActionsBuilder{}
+		blk, err := block.NewBuilder(rap.Build()).
+			SetHeight(height).
+			SetTimestamp(ts).
+			SetVersion(1).
+			SetPrevBlockHash(hash.Hash256b([]byte("prev"))).
+			SignAndBuild(identityset.PrivateKey(idx))
+		require.NoError(err)
+		return sign(idx, newBlockProposal(&blk, nil), height, ts)
+	}
+
+	t.Run("detect", func(t *testing.T) {
+		var handled []*DoubleSignEvidence
+		d := newEvidenceDetector(nil, roundOf, func(e *DoubleSignEvidence) error {
+			handled = append(handled, e)
+			return nil
+		})
+		ts := start.Add(time.Second)
+		for _, msg := range []*EndorsedConsensusMessage{
+			vote(1, "a", PROPOSAL, 10, ts),
+			// same block, another topic, another endorser, or another round
+			vote(1, "a", PROPOSAL, 10, ts.Add(time.Second)),
+			vote(1, "b", LOCK, 10, ts),
+			vote(2, "b", PROPOSAL, 10, ts),
+			vote(1, "b", PROPOSAL, 10, ts.Add(10*time.Second)),
+			// the votes for no block are not counted
+			vote(1, "", PROPOSAL, 10, ts),
+		} {
+			evidence, err := d.observe(msg)
+			require.NoError(err)
+			require.Nil(evidence)
+		}
+		evidence, err := d.observe(vote(1, "b", PROPOSAL, 10, ts.Add(time.Second)))
+		require.NoError(err)
+		require.NotNil(evidence)
+		require.Equal(uint64(10), evidence.Height())
+		require.Zero(evidence.Round())
+		require.Equal(identityset.Address(1).String(), evidence.Endorser().String())
+		require.NoError(evidence.Verify(roundOf))
+		// reported once
+		evidence, err = d.observe(vote(1, "c", PROPOSAL, 10, ts))
+		require.NoError(err)
+		require.Nil(evidence)
+
+		p1, p2 := proposal(3, 10, ts), proposal(3, 10, ts.Add(time.Second))
+		_, err = d.observe(p1)
+		require.NoError(err)
+		evidence, err = d.observe(p2)
+		require.NoError(err)
+		require.NotNil(evidence)
+		require.NoError(evidence.Verify(roundOf))
+		require.Len(handled, 2)
+
+		// the messages below the window are dropped
+		_, err = d.observe(vote(4, "a", COMMIT, 20, ts))
+		require.NoError(err)
+		require.Empty(d.seen[evidenceKey{10, 0, identityset.PrivateKey(1).PublicKey().HexString(), int(PROPOSAL)}])
+		evidence, err = d.observe(vote(1, "d", PROPOSAL, 10, ts))
+		require.NoError(err)
+		require.Nil(evidence)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		ts := start.Add(time.Second)
+		for _, c := range []struct {
+			msgs [2]*EndorsedConsensusMessage
+			err  error
+		}{
+			{[2]*EndorsedConsensusMessage{vote(1, "a", LOCK, 10, ts), vote(1, "b", LOCK, 10, ts)}, nil},
+			{[2]*EndorsedConsensusMessage{vote(1, "a", LOCK, 10, ts), vote(1, "a", LOCK, 10, ts)}, ErrInvalidEvidence},
+			{[2]*EndorsedConsensusMessage{vote(1, "a", LOCK, 10, ts), vote(1, "b", COMMIT, 10, ts)}, ErrInvalidEvidence},
+			{[2]*EndorsedConsensusMessage{vote(1, "a", LOCK, 10, ts), vote(2, "b", LOCK, 10, ts)}, ErrInvalidEvidence},
+			{[2]*EndorsedConsensusMessage{vote(1, "a", LOCK, 10, ts), vote(1, "b", LOCK, 11, ts)}, ErrInvalidEvidence},
+			{[2]*EndorsedConsensusMessage{vote(1, "a", LOCK, 10, ts), vote(1, "", LOCK, 10, ts)}, ErrInvalidEvidence},
+			{[2]*EndorsedConsensusMessage{vote(1, "a", LOCK, 10, ts), vote(1, "b", LOCK, 10, ts.Add(10*time.Second))}, ErrInvalidEvidence},
+		} {
+			err := NewDoubleSignEvidence(0, c.msgs[0], c.msgs[1]).Verify(roundOf)
+			require.Equal(c.err, errors.Cause(err))
+		}
+		// tampered signature
+		msg := vote(1, "a", LOCK, 10, ts)
+		forged := NewEndorsedConsensusMessage(10, NewConsensusVote(hash.ZeroHash256[:], LOCK), msg.Endorsement())
+		require.ErrorIs(NewDoubleSignEvidence(0, msg, forged).Verify(roundOf), ErrInvalidEvidence)
+	})
+
+	t.Run("serialize", func(t *testing.T) {
+		ts := start.Add(time.Second)
+		evidence := NewDoubleSignEvidence(0, proposal(3, 10, ts), proposal(3, 10, ts.Add(time.Second)))
+		data, err := evidence.Serialize()
+		require.NoError(err)
+		decoded := &DoubleSignEvidence{}
+		require.NoError(decoded.Deserialize(data, block.NewDeserializer(0)))
+		require.NoError(decoded.Verify(roundOf))
+		h1, err := evidence.Hash()
+		require.NoError(err)
+		h2, err := decoded.Hash()
+		require.NoError(err)
+		require.Equal(h1, h2)
+		require.ErrorIs(decoded.Deserialize(data[:len(data)-1], block.NewDeserializer(0)), ErrInvalidEvidence)
+		require.ErrorIs(decoded.Deserialize(append(data, 0), block.NewDeserializer(0)), ErrInvalidEvidence)
+	})
+
+	t.Run("persist", func(t *testing.T) {
+		ctx := context.Background()
+		cfg := db.DefaultConfig
+		cfg.DbPath = filepath.Join(t.TempDir(), "evidence.db")
+		d := newEvidenceDetector(db.NewBoltDB(cfg), roundOf, nil)
+		require.NoError(d.Start(ctx))
+		evidences, err := d.Evidences(block.NewDeserializer(0))
+		require.NoError(err)
+		require.Empty(evidences)
+		ts := start.Add(time.Second)
+		_, err = d.observe(vote(1, "a", COMMIT, 10, ts))
+		require.NoError(err)
+		expected, err := d.observe(vote(1, "b", COMMIT, 10, ts))
+		require.NoError(err)
+		require.NoError(d.Stop(ctx))
+
+		d = newEvidenceDetector(db.NewBoltDB(cfg), roundOf, nil)
+		require.NoError(d.Start(ctx))
+		defer d.Stop(ctx)
+		evidences, err = d.Evidences(block.NewDeserializer(0))
+		require.NoError(err)
+		require.Len(evidences, 1)
+		h1, err := expected.Hash()
+		require.NoError(err)
+		h2, err := evidences[0].Hash()
+		require.NoError(err)
+		require.Equal(h1, h2)
+	})
+}
+
+func TestEvidenceAddress(t *testing.T) {
+	require := require.New(t)
+	h := hash.Hash160b([]byte(SlashingProtocolID))
+	require.Equal(h[:], EvidenceAddress().Bytes())
+}
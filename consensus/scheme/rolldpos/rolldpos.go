@@ -43,6 +43,13 @@ type (
 		ToleratedOvertime time.Duration                `yaml:"toleratedOvertime"`
 		Delay             time.Duration                `yaml:"delay"`
 		ConsensusDBPath   string                       `yaml:"consensusDBPath"`
+		// EvidenceDBPath is the path of the db persisting the double-sign evidences, not persisted if it is empty
+		EvidenceDBPath string `yaml:"evidenceDBPath"`
+		// ReportEvidence enables sending the double-sign evidences to the slashing protocol
+		ReportEvidence bool `yaml:"reportEvidence"`
+		// ShadowProposal enables building a candidate block each round without broadcasting it, which is compared
+		// with the committed block
+		ShadowProposal bool `yaml:"shadowProposal"`
 	}
 
 	// ChainManager defines the blockchain interface
@@ -51,6 +58,8 @@ type (
 		BlockProposeTime(uint64) (time.Time, error)
 		// BlockCommitTime return commit time by height
 		BlockCommitTime(uint64) (time.Time, error)
+		// BlockGasUsed returns the gas used by the block at the height
+		BlockGasUsed(uint64) (uint64, error)
 		// MintNewBlock creates a new block with given actions
 		// Note: the coinbase transfer will be added to the given transfers when minting a new block
 		MintNewBlock(timestamp time.Time) (*block.Block, error)
@@ -83,6 +92,9 @@ var DefaultConfig = Config{
 	ToleratedOvertime: 2 * time.Second,
 	Delay:             5 * time.Second,
 	ConsensusDBPath:   "/var/data/consensus.db",
+	EvidenceDBPath:    "",
+	ReportEvidence:    false,
+	ShadowProposal:    false,
 }
 
 // NewChainManager creates a chain manager
@@ -119,6 +131,18 @@ func (cm *chainManager) BlockCommitTime(height uint64) (time.Time, error) {
 	return footer.CommitTime(), nil
 }
 
+// BlockGasUsed returns the gas used by the block at the height
+func (cm *chainManager) BlockGasUsed(height uint64) (uint64, error) {
+	header, err := cm.bc.BlockHeaderByHeight(height)
+	if err != nil {
+		return 0, errors.Wrapf(
+			err, "error when getting the block at height: %d",
+			height,
+		)
+	}
+	return header.GasUsed(), nil
+}
+
 // MintNewBlock creates a new block with given actions
 func (cm *chainManager) MintNewBlock(timestamp time.Time) (*block.Block, error) {
 	return cm.bc.MintNewBlock(timestamp)
@@ -150,6 +174,7 @@ type RollDPoS struct {
 	ctx        RDPoSCtx
 	startDelay time.Duration
 	ready      chan interface{}
+	evidence   *evidenceDetector
 }
 
 // Start starts RollDPoS consensus
@@ -157,6 +182,9 @@ func (r *RollDPoS) Start(ctx context.Context) error {
 	if err := r.ctx.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting the roll dpos context")
 	}
+	if err := r.evidence.Start(ctx); err != nil {
+		return err
+	}
 	if err := r.cfsm.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting the consensus FSM")
 	}
@@ -172,6 +200,9 @@ func (r *RollDPoS) Stop(ctx context.Context) error {
 	if err := r.cfsm.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping the consensus FSM")
 	}
+	if err := r.evidence.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping the evidence detector")
+	}
 	return errors.Wrap(r.ctx.Stop(ctx), "error when stopping the roll dpos context")
 }
 
@@ -215,12 +246,14 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 		if err := r.ctx.CheckBlockProposer(endorsedMessage.Height(), consensusMessage, en); err != nil {
 			return errors.Wrap(err, "failed to verify block proposal")
 		}
+		r.detectDoubleSign(endorsedMessage)
 		r.cfsm.ProduceReceiveBlockEvent(endorsedMessage)
 		return nil
 	case *ConsensusVote:
 		if err := r.ctx.CheckVoteEndorser(endorsedMessage.Height(), consensusMessage, en); err != nil {
 			return errors.Wrapf(err, "failed to verify vote")
 		}
+		r.detectDoubleSign(endorsedMessage)
 		switch consensusMessage.Topic() {
 		case PROPOSAL:
 			r.cfsm.ProduceReceiveProposalEndorsementEvent(endorsedMessage)
@@ -236,6 +269,17 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	}
 }
 
+func (r *RollDPoS) detectDoubleSign(msg *EndorsedConsensusMessage) {
+	if _, err := r.evidence.observe(msg); err != nil {
+		log.Logger("consensus").Error("failed to handle double signing", zap.Error(err))
+	}
+}
+
+// Evidences returns the double-sign evidences persisted
+func (r *RollDPoS) Evidences() ([]*DoubleSignEvidence, error) {
+	return r.evidence.Evidences(r.ctx.BlockDeserializer())
+}
+
 // Calibrate called on receive a new block not via consensus
 func (r *RollDPoS) Calibrate(height uint64) {
 	r.cfsm.Calibrate(height)
@@ -290,6 +334,25 @@ func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 	}, nil
 }
 
+// State returns the state of the consensus round in progress, including the endorsements received and the timeout
+// timers scheduled
+func (r *RollDPoS) State() (scheme.ConsensusState, error) {
+	state := r.ctx.RoundState()
+	state.FSMState = string(r.cfsm.CurrentState())
+	state.PendingEvents = r.cfsm.NumPendingEvents()
+	timers := r.cfsm.Timers()
+	state.Timers = make([]scheme.ConsensusTimer, 0, len(timers))
+	for _, t := range timers {
+		state.Timers = append(state.Timers, scheme.ConsensusTimer{
+			Event:    string(t.Event),
+			Height:   t.Height,
+			Round:    t.Round,
+			Deadline: t.Deadline,
+		})
+	}
+	return state, nil
+}
+
 // NumPendingEvts returns the number of pending events
 func (r *RollDPoS) NumPendingEvts() int {
 	return r.cfsm.NumPendingEvents()
@@ -341,6 +404,7 @@ type (
 		rp                   *rolldpos.Protocol
 		delegatesByEpochFunc NodesSelectionByEpochFunc
 		proposersByEpochFunc NodesSelectionByEpochFunc
+		evidenceHandler      EvidenceHandler
 	}
 )
 
@@ -407,6 +471,12 @@ func (b *Builder) SetProposersByEpochFunc(
 	return b
 }
 
+// SetEvidenceHandler sets the handler of the double-sign evidences
+func (b *Builder) SetEvidenceHandler(handler EvidenceHandler) *Builder {
+	b.evidenceHandler = handler
+	return b
+}
+
 // RegisterProtocol sets the rolldpos protocol
 func (b *Builder) RegisterProtocol(rp *rolldpos.Protocol) *Builder {
 	b.rp = rp
@@ -424,6 +494,8 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if b.clock == nil {
 		b.clock = clock.New()
 	}
+	evidenceDBCfg := b.cfg.DB
+	evidenceDBCfg.DbPath = b.cfg.Consensus.EvidenceDBPath
 	b.cfg.DB.DbPath = b.cfg.Consensus.ConsensusDBPath
 	ctx, err := NewRollDPoSCtx(
 		consensusfsm.NewConsensusConfig(b.cfg.Consensus.FSM, b.cfg.DardanellesUpgrade, b.cfg.Genesis, b.cfg.Consensus.Delay),
@@ -441,6 +513,7 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		b.priKey,
 		b.clock,
 		b.cfg.Genesis.BeringBlockHeight,
+		b.cfg.Genesis.AdaptiveBlockInterval,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing consensus context")
@@ -449,10 +522,23 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing the consensus FSM")
 	}
+	var evidenceKV db.KVStore
+	if evidenceDBCfg.DbPath != "" {
+		evidenceKV = db.NewBoltDB(evidenceDBCfg)
+	}
+	var evidenceHandler EvidenceHandler
+	if b.cfg.Consensus.ReportEvidence {
+		evidenceHandler = b.evidenceHandler
+	}
+	roundOf := func(height uint64, ts time.Time) (uint32, error) {
+		round, _, err := ctx.RoundCalculator().RoundInfo(height, ctx.BlockInterval(height), ts)
+		return round, err
+	}
 	return &RollDPoS{
 		cfsm:       cfsm,
 		ctx:        ctx,
 		startDelay: b.cfg.Consensus.Delay,
 		ready:      make(chan interface{}),
+		evidence:   newEvidenceDetector(evidenceKV, roundOf, evidenceHandler),
 	}, nil
 }
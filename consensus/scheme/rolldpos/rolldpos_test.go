@@ -346,6 +346,17 @@ func TestRollDPoS_Metrics(t *testing.T) {
 	assert.Equal(t, uint64(3), m.LatestEpoch)
 	assert.Equal(t, candidates[:4], m.LatestDelegates)
 	assert.Equal(t, candidates[1], m.LatestBlockProducer)
+
+	state, err := r.State()
+	require.NoError(t, err)
+	assert.Equal(t, blockHeight+1, state.Height)
+	assert.Equal(t, uint64(3), state.Epoch)
+	assert.Equal(t, string(consensusfsm.InitState), state.FSMState)
+	assert.Equal(t, ctx.round.Proposer(), state.Proposer)
+	assert.Equal(t, 4, state.NumDelegates)
+	assert.False(t, state.Locked)
+	assert.Empty(t, state.Endorsements)
+	assert.Empty(t, state.Timers)
 }
 
 // E2E RollDPoS tests bellow
@@ -20,6 +20,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
 	"github.com/iotexproject/iotex-core/v2/db"
@@ -59,6 +60,14 @@ var (
 		},
 		[]string{},
 	)
+
+	_consensusEndorsementMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_endorsements",
+			Help: "Consensus endorsements received",
+		},
+		[]string{"topic", "status"},
+	)
 )
 
 func init() {
@@ -66,6 +75,7 @@ func init() {
 	prometheus.MustRegister(_blockIntervalMtc)
 	prometheus.MustRegister(_consensusDurationMtc)
 	prometheus.MustRegister(_consensusHeightMtc)
+	prometheus.MustRegister(_consensusEndorsementMtc)
 }
 
 type (
@@ -81,6 +91,7 @@ type (
 		Clock() clock.Clock
 		CheckBlockProposer(uint64, *blockProposal, *endorsement.Endorsement) error
 		CheckVoteEndorser(uint64, *ConsensusVote, *endorsement.Endorsement) error
+		RoundState() scheme.ConsensusState
 	}
 
 	rollDPoSCtx struct {
@@ -120,6 +131,7 @@ func NewRollDPoSCtx(
 	priKey crypto.PrivateKey,
 	clock clock.Clock,
 	beringHeight uint64,
+	adaptiveInterval genesis.AdaptiveBlockInterval,
 ) (RDPoSCtx, error) {
 	if chain == nil {
 		return nil, errors.New("chain cannot be nil")
@@ -146,6 +158,9 @@ func NewRollDPoSCtx(
 			cfg.BlockInterval(0),
 		)
 	}
+	if err := adaptiveInterval.Validate(cfg.BlockInterval(adaptiveInterval.Height)); err != nil {
+		return nil, errors.Wrap(err, "invalid adaptive block interval")
+	}
 	var eManagerDB db.KVStore
 	if len(consensusDBConfig.DbPath) > 0 {
 		eManagerDB = db.NewBoltDB(consensusDBConfig)
@@ -157,6 +172,7 @@ func NewRollDPoSCtx(
 		rp:                   rp,
 		timeBasedRotation:    timeBasedRotation,
 		beringHeight:         beringHeight,
+		adaptiveInterval:     adaptiveInterval,
 	}
 	return &rollDPoSCtx{
 		ConsensusConfig:   cfg,
@@ -604,6 +620,28 @@ func (ctx *rollDPoSCtx) Height() uint64 {
 	return ctx.round.Height()
 }
 
+// RoundState returns the state of the round in progress
+func (ctx *rollDPoSCtx) RoundState() scheme.ConsensusState {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+
+	state := scheme.ConsensusState{
+		Height:             ctx.round.Height(),
+		Round:              ctx.round.Number(),
+		Epoch:              ctx.round.EpochNum(),
+		Proposer:           ctx.round.Proposer(),
+		RoundStartTime:     ctx.round.StartTime(),
+		NextRoundStartTime: ctx.round.NextRoundStartTime(),
+		Locked:             ctx.round.IsLocked(),
+		NumDelegates:       len(ctx.round.Delegates()),
+		Endorsements:       ctx.round.eManager.Stats(),
+	}
+	if ctx.round.IsLocked() {
+		state.BlockInLock = encodeToString(ctx.round.HashOfBlockInLock())
+	}
+	return state
+}
+
 func (ctx *rollDPoSCtx) Activate(active bool) {
 	ctx.mutex.Lock()
 	defer ctx.mutex.Unlock()
@@ -718,9 +756,12 @@ func (ctx *rollDPoSCtx) verifyVote(
 	}
 	blkHash := vote.BlockHash()
 	endorsement := consensusMsg.Endorsement()
+	topic := vote.Topic().String()
 	if err := ctx.round.AddVoteEndorsement(vote, endorsement); err != nil {
+		_consensusEndorsementMtc.WithLabelValues(topic, "rejected").Inc()
 		return blkHash, err
 	}
+	_consensusEndorsementMtc.WithLabelValues(topic, "accepted").Inc()
 	ctx.loggerWithStats().Debug(
 		"verified consensus vote",
 		log.Hex("block", blkHash),
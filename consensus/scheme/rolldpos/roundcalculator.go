@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/endorsement"
 )
 
@@ -23,10 +24,15 @@ type roundCalculator struct {
 	delegatesByEpochFunc NodesSelectionByEpochFunc
 	proposersByEpochFunc NodesSelectionByEpochFunc
 	beringHeight         uint64
+	adaptiveInterval     genesis.AdaptiveBlockInterval
 }
 
 // UpdateRound updates previous roundCtx
 func (c *roundCalculator) UpdateRound(round *roundCtx, height uint64, blockInterval time.Duration, now time.Time, toleratedOvertime time.Duration) (*roundCtx, error) {
+	blockInterval, err := c.blockInterval(height, blockInterval)
+	if err != nil {
+		return nil, err
+	}
 	epochNum := round.EpochNum()
 	epochStartHeight := round.EpochStartHeight()
 	delegates := round.Delegates()
@@ -43,7 +49,6 @@ func (c *roundCalculator) UpdateRound(round *roundCtx, height uint64, blockInter
 			// update the epoch
 			epochNum = c.rp.GetEpochNum(height)
 			epochStartHeight = c.rp.GetEpochHeight(epochNum)
-			var err error
 			if delegates, err = c.Delegates(height); err != nil {
 				return nil, err
 			}
@@ -126,9 +131,32 @@ func (c *roundCalculator) RoundInfo(
 	blockInterval time.Duration,
 	now time.Time,
 ) (roundNum uint32, roundStartTime time.Time, err error) {
+	if blockInterval, err = c.blockInterval(height, blockInterval); err != nil {
+		return
+	}
 	return c.roundInfo(height, blockInterval, now, 0)
 }
 
+// blockInterval returns the interval of the block at the height, which adapts to the gas used by the previous block
+// from the fork height of the adaptive block interval on
+func (c *roundCalculator) blockInterval(height uint64, blockInterval time.Duration) (time.Duration, error) {
+	if !c.adaptiveInterval.IsActive(height) || height <= 1 || height < c.beringHeight {
+		return blockInterval, nil
+	}
+	gasUsed, err := c.chain.BlockGasUsed(height - 1)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case gasUsed == 0:
+		return c.adaptiveInterval.MaxInterval, nil
+	case gasUsed >= c.adaptiveInterval.BacklogGasThreshold:
+		return c.adaptiveInterval.MinInterval, nil
+	default:
+		return blockInterval, nil
+	}
+}
+
 func (c *roundCalculator) roundInfo(
 	height uint64,
 	blockInterval time.Duration,
@@ -145,7 +173,12 @@ func (c *roundCalculator) roundInfo(
 			if lastBlkProposeTime, err = c.chain.BlockProposeTime(height - 1); err != nil {
 				return
 			}
-			lastBlockTime = lastBlockTime.Add(lastBlkProposeTime.Sub(lastBlockTime) / blockInterval * blockInterval)
+			// the rounds are aligned to the min interval if the block interval is adaptive
+			alignment := blockInterval
+			if c.adaptiveInterval.IsActive(height) {
+				alignment = c.adaptiveInterval.MinInterval
+			}
+			lastBlockTime = lastBlockTime.Add(lastBlkProposeTime.Sub(lastBlockTime) / alignment * alignment)
 		} else {
 			var lastBlkCommitTime time.Time
 			if lastBlkCommitTime, err = c.chain.BlockCommitTime(height - 1); err != nil {
@@ -232,6 +265,9 @@ func (c *roundCalculator) newRound(
 		if proposers, err = c.Proposers(height); err != nil {
 			return
 		}
+		if blockInterval, err = c.blockInterval(height, blockInterval); err != nil {
+			return
+		}
 		if roundNum, roundStartTime, err = c.roundInfo(height, blockInterval, now, toleratedOvertime); err != nil {
 			return
 		}
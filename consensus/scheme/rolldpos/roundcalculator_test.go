@@ -59,6 +59,42 @@ func TestUpdateRound(t *testing.T) {
 	require.Equal(identityset.Address(10).String(), ra.proposer)
 }
 
+func TestAdaptiveBlockInterval(t *testing.T) {
+	require := require.New(t)
+	rc := makeRoundCalculator(t)
+	lastBlockTime, err := rc.chain.BlockProposeTime(50)
+	require.NoError(err)
+	rc.adaptiveInterval = genesis.AdaptiveBlockInterval{
+		Height:              52,
+		MinInterval:         time.Second,
+		MaxInterval:         4 * time.Second,
+		BacklogGasThreshold: 10000,
+	}
+	// the interval is not adaptive before the fork height, and the rounds are aligned to the block interval
+	interval, err := rc.blockInterval(51, 2*time.Second)
+	require.NoError(err)
+	require.Equal(2*time.Second, interval)
+	round, start, err := rc.RoundInfo(51, 2*time.Second, lastBlockTime.Add(time.Second))
+	require.NoError(err)
+	require.Zero(round)
+	require.Equal(lastBlockTime.Add(time.Second), start)
+	rc.adaptiveInterval.Height = 51
+	// the interval after an empty block is lengthened
+	interval, err = rc.blockInterval(51, 2*time.Second)
+	require.NoError(err)
+	require.Equal(4*time.Second, interval)
+	interval, err = rc.blockInterval(1, 2*time.Second)
+	require.NoError(err)
+	require.Equal(2*time.Second, interval)
+	round, start, err = rc.RoundInfo(51, 2*time.Second, lastBlockTime.Add(3*time.Second))
+	require.NoError(err)
+	require.Zero(round)
+	require.Equal(lastBlockTime.Add(4*time.Second), start)
+	ra, err := rc.NewRound(51, 2*time.Second, lastBlockTime.Add(3*time.Second), nil)
+	require.NoError(err)
+	require.Equal(lastBlockTime.Add(8*time.Second), ra.NextRoundStartTime())
+}
+
 func TestNewRound(t *testing.T) {
 	require := require.New(t)
 	rc := makeRoundCalculator(t)
@@ -271,5 +307,6 @@ func makeRoundCalculator(t *testing.T) *roundCalculator {
 		delegatesByEpoch,
 		delegatesByEpoch,
 		0,
+		genesis.AdaptiveBlockInterval{},
 	}
 }
@@ -0,0 +1,186 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+var (
+	_shadowProposalMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_shadow_proposal",
+			Help: "Comparison of the shadow proposal with the committed block.",
+		},
+		[]string{"type"},
+	)
+	_shadowProposalResultMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_shadow_proposal_result",
+			Help: "Number of shadow proposals by the result of the comparison.",
+		},
+		[]string{"result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_shadowProposalMtc)
+	prometheus.MustRegister(_shadowProposalResultMtc)
+}
+
+type (
+	// BlockMinter mints a new block on top of the tip
+	BlockMinter interface {
+		MintNewBlock(timestamp time.Time) (*block.Block, error)
+	}
+
+	// ShadowProposer builds a candidate block locally at the start of each round without broadcasting it, and compares
+	// it with the block committed at the height, so that a node can validate its config before becoming a delegate
+	ShadowProposer struct {
+		minter   BlockMinter
+		producer string
+		interval time.Duration
+
+		mutex     sync.Mutex
+		candidate *block.Block
+		timer     *time.Timer
+		stopped   bool
+	}
+
+	// ShadowProposal is the comparison of the shadow proposal with the committed block
+	ShadowProposal struct {
+		Height           uint64
+		CandidateActions int
+		ActualActions    int
+		CommonActions    int
+		CandidateGasUsed uint64
+		ActualGasUsed    uint64
+	}
+)
+
+// NewShadowProposer creates a shadow proposer, the producer is the address of the node, and the interval is the block
+// interval, at which the candidate of the next height is built after a block is committed
+func NewShadowProposer(minter BlockMinter, producer string, interval time.Duration) *ShadowProposer {
+	return &ShadowProposer{
+		minter:   minter,
+		producer: producer,
+		interval: interval,
+	}
+}
+
+// Start starts the shadow proposer
+func (sp *ShadowProposer) Start(context.Context) error {
+	return nil
+}
+
+// Stop stops building the candidates
+func (sp *ShadowProposer) Stop(context.Context) error {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+	sp.stopped = true
+	if sp.timer != nil {
+		sp.timer.Stop()
+	}
+	return nil
+}
+
+// ReceiveBlock compares the candidate with the committed block, and schedules the candidate of the next height at the
+// time the next round starts
+func (sp *ShadowProposer) ReceiveBlock(blk *block.Block) error {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+	if sp.stopped {
+		return nil
+	}
+	// the comparison is skipped if the node itself is the proposer of the block
+	if sp.candidate != nil && sp.candidate.Height() == blk.Height() && blk.ProducerAddress() != sp.producer {
+		sp.report(compareProposal(sp.candidate, blk))
+	}
+	sp.candidate = nil
+	if sp.timer != nil {
+		sp.timer.Stop()
+	}
+	height, timestamp := blk.Height()+1, blk.Timestamp().Add(sp.interval)
+	sp.timer = time.AfterFunc(time.Until(timestamp), func() {
+		sp.propose(height, timestamp)
+	})
+	return nil
+}
+
+func (sp *ShadowProposer) propose(height uint64, timestamp time.Time) {
+	blk, err := sp.minter.MintNewBlock(timestamp)
+	if err != nil {
+		log.L().Warn("failed to build shadow proposal", zap.Uint64("height", height), zap.Error(err))
+		return
+	}
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+	// the block of the height has been committed before the candidate is built
+	if blk.Height() != height {
+		return
+	}
+	sp.candidate = blk
+}
+
+func (sp *ShadowProposer) report(p *ShadowProposal) {
+	result := "match"
+	if p.CommonActions != p.CandidateActions || p.CommonActions != p.ActualActions {
+		result = "mismatch"
+	}
+	_shadowProposalResultMtc.WithLabelValues(result).Inc()
+	_shadowProposalMtc.WithLabelValues("height").Set(float64(p.Height))
+	_shadowProposalMtc.WithLabelValues("candidateActions").Set(float64(p.CandidateActions))
+	_shadowProposalMtc.WithLabelValues("actualActions").Set(float64(p.ActualActions))
+	_shadowProposalMtc.WithLabelValues("commonActions").Set(float64(p.CommonActions))
+	_shadowProposalMtc.WithLabelValues("candidateGasUsed").Set(float64(p.CandidateGasUsed))
+	_shadowProposalMtc.WithLabelValues("actualGasUsed").Set(float64(p.ActualGasUsed))
+	log.L().Info("shadow proposal",
+		zap.String("result", result),
+		zap.Uint64("height", p.Height),
+		zap.Int("candidateActions", p.CandidateActions),
+		zap.Int("actualActions", p.ActualActions),
+		zap.Int("commonActions", p.CommonActions),
+		zap.Uint64("candidateGasUsed", p.CandidateGasUsed),
+		zap.Uint64("actualGasUsed", p.ActualGasUsed),
+	)
+}
+
+func compareProposal(candidate, actual *block.Block) *ShadowProposal {
+	p := &ShadowProposal{
+		Height:           actual.Height(),
+		CandidateActions: len(candidate.Actions),
+		ActualActions:    len(actual.Actions),
+		CandidateGasUsed: candidate.GasUsed(),
+		ActualGasUsed:    actual.GasUsed(),
+	}
+	actions := make(map[hash.Hash256]struct{}, len(actual.Actions))
+	for _, selp := range actual.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			continue
+		}
+		actions[h] = struct{}{}
+	}
+	for _, selp := range candidate.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			continue
+		}
+		if _, ok := actions[h]; ok {
+			p.CommonActions++
+		}
+	}
+	return p
+}
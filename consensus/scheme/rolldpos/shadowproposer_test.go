@@ -0,0 +1,86 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+type testMinter func(time.Time) (*block.Block, error)
+
+func (f testMinter) MintNewBlock(ts time.Time) (*block.Block, error) { return f(ts) }
+
+func TestShadowProposer(t *testing.T) {
+	require := require.New(t)
+	var acts []*action.SealedEnvelope
+	for i := uint64(1); i <= 3; i++ {
+		selp, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(2), i, big.NewInt(1), nil, 10000, big.NewInt(0))
+		require.NoError(err)
+		acts = append(acts, selp)
+	}
+	newBlock := func(height uint64, producer int, acts ...*action.SealedEnvelope) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(time.Now()).
+			AddActions(acts...).
+			SignAndBuild(identityset.PrivateKey(producer))
+		require.NoError(err)
+		return &blk
+	}
+	candidate := newBlock(2, 0, acts[0], acts[1])
+	minted := make(chan time.Time, 1)
+	sp := NewShadowProposer(testMinter(func(ts time.Time) (*block.Block, error) {
+		minted <- ts
+		return candidate, nil
+	}), identityset.Address(0).String(), 10*time.Millisecond)
+
+	// the candidate of the next height is built after the block interval
+	tip := newBlock(1, 1)
+	require.NoError(sp.ReceiveBlock(tip))
+	select {
+	case ts := <-minted:
+		require.Equal(tip.Timestamp().Add(10*time.Millisecond), ts)
+	case <-time.After(time.Second):
+		require.FailNow("candidate is not built")
+	}
+	require.Eventually(func() bool {
+		sp.mutex.Lock()
+		defer sp.mutex.Unlock()
+		return sp.candidate == candidate
+	}, time.Second, 10*time.Millisecond)
+
+	p := compareProposal(candidate, newBlock(2, 1, acts[1], acts[2]))
+	require.Equal(&ShadowProposal{
+		Height:           2,
+		CandidateActions: 2,
+		ActualActions:    2,
+		CommonActions:    1,
+	}, p)
+
+	// the candidate is dropped once the block of the height is committed
+	require.NoError(sp.ReceiveBlock(newBlock(2, 1, acts[1], acts[2])))
+	sp.mutex.Lock()
+	require.Nil(sp.candidate)
+	sp.mutex.Unlock()
+	require.NoError(sp.Stop(context.Background()))
+	require.NoError(sp.ReceiveBlock(newBlock(3, 1)))
+	select {
+	case <-minted:
+	case <-time.After(100 * time.Millisecond):
+	}
+	sp.mutex.Lock()
+	require.Nil(sp.candidate)
+	sp.mutex.Unlock()
+}
@@ -6,6 +6,8 @@
 package scheme
 
 import (
+	"time"
+
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
@@ -36,6 +38,7 @@ type Scheme interface {
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
 	Metrics() (ConsensusMetrics, error)
+	State() (ConsensusState, error)
 	Activate(bool)
 	Active() bool
 }
@@ -47,3 +50,36 @@ type ConsensusMetrics struct {
 	LatestDelegates     []string
 	LatestBlockProducer string
 }
+
+// ConsensusState contains the state of the consensus round in progress
+type ConsensusState struct {
+	Height             uint64              `json:"height"`
+	Round              uint32              `json:"round"`
+	Epoch              uint64              `json:"epoch"`
+	FSMState           string              `json:"fsmState"`
+	Proposer           string              `json:"proposer"`
+	RoundStartTime     time.Time           `json:"roundStartTime"`
+	NextRoundStartTime time.Time           `json:"nextRoundStartTime"`
+	Locked             bool                `json:"locked"`
+	BlockInLock        string              `json:"blockInLock,omitempty"`
+	NumDelegates       int                 `json:"numDelegates"`
+	Endorsements       []BlockEndorsements `json:"endorsements"`
+	PendingEvents      int                 `json:"pendingEvents"`
+	Timers             []ConsensusTimer    `json:"timers"`
+}
+
+// BlockEndorsements contains the number of endorsements received for a block in the round
+type BlockEndorsements struct {
+	BlockHash string `json:"blockHash"`
+	Proposals int    `json:"proposals"`
+	Locks     int    `json:"locks"`
+	Commits   int    `json:"commits"`
+}
+
+// ConsensusTimer is a timeout event scheduled by the consensus fsm
+type ConsensusTimer struct {
+	Event    string    `json:"event"`
+	Height   uint64    `json:"height"`
+	Round    uint32    `json:"round"`
+	Deadline time.Time `json:"deadline"`
+}
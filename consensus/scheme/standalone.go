@@ -94,6 +94,14 @@ func (s *Standalone) Metrics() (ConsensusMetrics, error) {
 	)
 }
 
+// State is not implemented for standalone scheme
+func (s *Standalone) State() (ConsensusState, error) {
+	return ConsensusState{}, errors.Wrapf(
+		ErrNotImplemented,
+		"standalone scheme does not supported state yet",
+	)
+}
+
 // Activate is not implemented for standalone scheme
 func (s *Standalone) Activate(_ bool) {
 	log.S().Warn("Standalone scheme could not support activate")
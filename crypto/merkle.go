@@ -7,6 +7,7 @@ package crypto
 
 import (
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
 )
 
 // Merkle tree struct
@@ -78,3 +79,44 @@ func (mk *Merkle) HashTree() hash.Hash256 {
 	mk.root = merkle[0]
 	return mk.root
 }
+
+// MerkleProof returns the sibling hashes from the leaf at the index up to the root, in which the last node of a level
+// is paired with itself if the level has an odd number of nodes
+func MerkleProof(leaves []hash.Hash256, index int) ([]hash.Hash256, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, errors.Errorf("leaf index %d out of range %d", index, len(leaves))
+	}
+	var siblings []hash.Hash256
+	level := append([]hash.Hash256{}, leaves...)
+	for len(level) > 1 {
+		if len(level)&1 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+		siblings = append(siblings, level[index^1])
+		next := make([]hash.Hash256, len(level)>>1)
+		for i := range next {
+			next[i] = hashPair(level[i<<1], level[i<<1+1])
+		}
+		level = next
+		index >>= 1
+	}
+	return siblings, nil
+}
+
+// MerkleRoot computes the root hash from the leaf at the index and its sibling hashes
+func MerkleRoot(leaf hash.Hash256, index int, siblings []hash.Hash256) hash.Hash256 {
+	h := leaf
+	for _, s := range siblings {
+		if index&1 == 0 {
+			h = hashPair(h, s)
+		} else {
+			h = hashPair(s, h)
+		}
+		index >>= 1
+	}
+	return h
+}
+
+func hashPair(left, right hash.Hash256) hash.Hash256 {
+	return hash.Hash256b(append(left[:], right[:]...))
+}
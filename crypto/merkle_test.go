@@ -40,3 +40,23 @@ func TestMerkleTree(t *testing.T) {
 	rootHashHex := hex.EncodeToString(rootHash[:])
 	assert.Equal(t, "4de26a6d1d6618f7bfeb3d168e37ef645db94c2d558bf8c3546d1311877ddffa", rootHashHex)
 }
+
+func TestMerkleProof(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		leaves := make([]hash.Hash256, n)
+		for i := range leaves {
+			leaves[i] = hash.Hash256b([]byte{byte(n), byte(i)})
+		}
+		root := NewMerkleTree(leaves).HashTree()
+		for i := range leaves {
+			siblings, err := MerkleProof(leaves, i)
+			assert.NoError(t, err)
+			assert.Equal(t, root, MerkleRoot(leaves[i], i, siblings), "%d of %d leaves", i, n)
+			if n > 1 {
+				assert.NotEqual(t, root, MerkleRoot(leaves[(i+1)%n], i, siblings))
+			}
+		}
+		_, err := MerkleProof(leaves, n)
+		assert.Error(t, err)
+	}
+}
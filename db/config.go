@@ -5,6 +5,8 @@
 
 package db
 
+import "time"
+
 // Config is the config for database
 type Config struct {
 	DbPath string `yaml:"dbPath"`
@@ -30,6 +32,25 @@ type Config struct {
 	ReadOnly bool `yaml:"readOnly"`
 	// DBType is the type of database
 	DBType string `yaml:"dbType"`
+	// RecompressInterval is the interval to recompress a chain db file written with another compressor, 0 to disable
+	RecompressInterval time.Duration `yaml:"recompressInterval"`
+	// ColdStore is the config of the cold tier of chain db
+	ColdStore ColdStoreConfig `yaml:"coldStore"`
+}
+
+// ColdStoreConfig is the config of the cold tier of chain db, to which the chain db files of the old blocks are moved
+type ColdStoreConfig struct {
+	// Path is the directory of the cold store, e.g., a slower disk or a mounted S3-compatible bucket. The cold tier
+	// is disabled if empty
+	Path string `yaml:"path"`
+	// Compressor is the compression used on block data in the cold store
+	Compressor string `yaml:"compressor"`
+	// BlockStoreBatchSize is the number of blocks to be stored together in the cold store
+	BlockStoreBatchSize int `yaml:"blockStoreBatchSize"`
+	// HotEpochs is the number of recent epochs whose blocks stay in the hot store
+	HotEpochs uint64 `yaml:"hotEpochs"`
+	// Interval is the interval to check the blocks to move to the cold store
+	Interval time.Duration `yaml:"interval"`
 }
 
 // Database types
@@ -49,10 +70,17 @@ var DefaultConfig = Config{
 	MaxCacheSize:          64,
 	BlockStoreBatchSize:   16,
 	V2BlocksToSplitDB:     1000000,
-	Compressor:            "Snappy",
+	Compressor:            "Zstd",
 	CompressLegacy:        false,
 	SplitDBSizeMB:         0,
 	SplitDBHeight:         900000,
 	HistoryStateRetention: 2000,
 	DBType:                DBBolt,
+	RecompressInterval:    time.Hour,
+	ColdStore: ColdStoreConfig{
+		Compressor:          "Gzip",
+		BlockStoreBatchSize: 128,
+		HotEpochs:           720,
+		Interval:            10 * time.Minute,
+	},
 }
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+)
+
+// CopyKVStore copies all the records of src into dst in batches of batchSize records, and returns the number of
+// records copied. It is used to migrate a db to another backend offline, e.g., from bolt to pebble
+func CopyKVStore(src KVStoreWithExport, dst KVStore, batchSize int) (uint64, error) {
+	if batchSize <= 0 {
+		return 0, errors.Wrapf(ErrInvalid, "invalid batch size %d", batchSize)
+	}
+	view, err := src.Export()
+	if err != nil {
+		return 0, err
+	}
+	defer view.Close()
+
+	var (
+		b     = batch.NewBatch()
+		total uint64
+	)
+	if err := view.ForEach(func(ns string, k, v []byte) error {
+		// the key and value are only valid in the iteration
+		b.Put(ns, append([]byte{}, k...), append([]byte{}, v...), "failed to copy record")
+		if b.Size() < batchSize {
+			return nil
+		}
+		if err := dst.WriteBatch(b); err != nil {
+			return err
+		}
+		total += uint64(b.Size())
+		b.Clear()
+		return nil
+	}); err != nil {
+		return 0, errors.Wrapf(err, "failed to copy records after %d", total)
+	}
+	if b.Size() > 0 {
+		if err := dst.WriteBatch(b); err != nil {
+			return 0, errors.Wrapf(err, "failed to copy records after %d", total)
+		}
+		total += uint64(b.Size())
+	}
+	return total, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyKVStore(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	cfg := DefaultConfig
+	cfg.DbPath = filepath.Join(t.TempDir(), "bolt.db")
+	src := NewBoltDB(cfg)
+	r.NoError(src.Start(ctx))
+	defer src.Stop(ctx)
+	records := []kvTest{
+		{"ns1", _k1, _v1},
+		{"ns1", _k2, _v2},
+		{"ns2", _k1, _v3},
+		{"ns2", _k3, _v4},
+		{"ns3", _k4, _v1},
+	}
+	for _, rec := range records {
+		r.NoError(src.Put(rec.ns, rec.k, rec.v))
+	}
+
+	for _, size := range []int{1, 2, 100} {
+		cfg.DbPath = t.TempDir()
+		dst := NewPebbleDB(cfg)
+		r.NoError(dst.Start(ctx))
+		total, err := CopyKVStore(src, dst, size)
+		r.NoError(err)
+		r.EqualValues(len(records), total)
+		for _, rec := range records {
+			v, err := dst.Get(rec.ns, rec.k)
+			r.NoError(err)
+			r.Equal(rec.v, v)
+		}
+		r.NoError(dst.Stop(ctx))
+	}
+	_, err := CopyKVStore(src, NewMemKVStore(), 0)
+	r.ErrorIs(err, ErrInvalid)
+}
@@ -17,6 +17,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/faultinject"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
@@ -99,13 +100,19 @@ func (b *BoltDB) Put(namespace string, key, value []byte) (err error) {
 		return ErrDBNotStarted
 	}
 
+	if err = faultinject.Check(faultinject.DBWrite, namespace); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
 	for c := uint8(0); c < b.config.NumRetries; c++ {
 		if err = b.db.Update(func(tx *bolt.Tx) error {
 			bucket, err := tx.CreateBucketIfNotExists([]byte(namespace))
 			if err != nil {
 				return err
 			}
-			return bucket.Put(key, value)
+			if err := bucket.Put(key, value); err != nil {
+				return err
+			}
+			return faultinject.Check(faultinject.DBSync, namespace)
 		}); err == nil {
 			break
 		}
@@ -289,6 +296,9 @@ func (b *BoltDB) Delete(namespace string, key []byte) (err error) {
 		return ErrDBNotStarted
 	}
 
+	if err = faultinject.Check(faultinject.DBWrite, namespace); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
 	numRetries := b.config.NumRetries
 	for c := uint8(0); c < numRetries; c++ {
 		if key == nil {
@@ -336,6 +346,7 @@ func (b *BoltDB) WriteBatch(kvsb batch.KVStoreBatch) (err error) {
 	// remove duplicate keys, only keep the last write for each key
 	entryKeySet := make(map[doubleKey]struct{})
 	uniqEntries := make([]*batch.WriteInfo, 0)
+	namespaces := make([]string, 0)
 	for i := kvsb.Size() - 1; i >= 0; i-- {
 		write, e := kvsb.Entry(i)
 		if e != nil {
@@ -349,8 +360,12 @@ func (b *BoltDB) WriteBatch(kvsb batch.KVStoreBatch) (err error) {
 		if _, ok := entryKeySet[k]; !ok {
 			entryKeySet[k] = struct{}{}
 			uniqEntries = append(uniqEntries, write)
+			namespaces = append(namespaces, write.Namespace())
 		}
 	}
+	if err = faultinject.Check(faultinject.DBWrite, namespaces...); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
 	boltdbMtc.WithLabelValues(b.path, "entrySize").Set(float64(kvsb.Size()))
 	boltdbMtc.WithLabelValues(b.path, "uniqueEntrySize").Set(float64(len(entryKeySet)))
 	for c := uint8(0); c < b.config.NumRetries; c++ {
@@ -381,7 +396,7 @@ func (b *BoltDB) WriteBatch(kvsb batch.KVStoreBatch) (err error) {
 					}
 				}
 			}
-			return nil
+			return faultinject.Check(faultinject.DBSync, namespaces...)
 		}); err == nil {
 			break
 		}
@@ -396,6 +411,39 @@ func (b *BoltDB) WriteBatch(kvsb batch.KVStoreBatch) (err error) {
 	return err
 }
 
+// Export returns a consistent read-only view of all the records. The view holds a read-only transaction, so the
+// pages freed by the writes cannot be reused, and a write growing the db file waits, until it is closed
+func (b *BoltDB) Export() (ExportView, error) {
+	if !b.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return &boltExportView{tx: tx}, nil
+}
+
+type boltExportView struct {
+	tx *bolt.Tx
+}
+
+func (v *boltExportView) ForEach(fn func(namespace string, key, value []byte) error) error {
+	return v.tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+		return bucket.ForEach(func(k, v []byte) error {
+			if v == nil {
+				// nested bucket
+				return nil
+			}
+			return fn(string(name), k, v)
+		})
+	})
+}
+
+func (v *boltExportView) Close() error {
+	return v.tx.Rollback()
+}
+
 // BucketExists returns true if bucket exists
 func (b *BoltDB) BucketExists(namespace string) bool {
 	if !b.IsReady() {
@@ -80,6 +80,44 @@ func TestBucketExists(t *testing.T) {
 	r.Equal([]byte{}, v)
 }
 
+func TestBoltDBExport(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-export")
+	r.NoError(err)
+	defer func() {
+		testutil.CleanupPath(testPath)
+	}()
+
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	kv := NewBoltDB(cfg)
+	ctx := context.Background()
+	r.NoError(kv.Start(ctx))
+	defer kv.Stop(ctx)
+	r.NoError(kv.Put("ns1", []byte("k2"), []byte("v2")))
+	r.NoError(kv.Put("ns1", []byte("k1"), []byte("v1")))
+	r.NoError(kv.Put("ns2", []byte("k3"), []byte("v3")))
+
+	view, err := kv.Export()
+	r.NoError(err)
+	// writes after the view is opened are not visible, a write growing the db file waits for the view to close
+	written := make(chan error, 1)
+	go func() {
+		written <- kv.Put("ns2", []byte("k4"), []byte("v4"))
+	}()
+	var records []string
+	r.NoError(view.ForEach(func(ns string, k, v []byte) error {
+		records = append(records, fmt.Sprintf("%s/%s/%s", ns, k, v))
+		return nil
+	}))
+	r.NoError(view.Close())
+	r.NoError(<-written)
+	r.Equal([]string{"ns1/k1/v1", "ns1/k2/v2", "ns2/k3/v3"}, records)
+	v, err := kv.Get("ns2", []byte("k4"))
+	r.NoError(err)
+	r.Equal([]byte("v4"), v)
+}
+
 func TestDiskfullErr(t *testing.T) {
 	err := fmt.Errorf("write /run/data/chain.db: %w", syscall.ENOSPC)
 	require.True(t, errors.Is(err, syscall.ENOSPC))
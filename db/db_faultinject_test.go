@@ -0,0 +1,54 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build faultinject
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/faultinject"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestBoltDBInjectedFailure(t *testing.T) {
+	r := require.New(t)
+	path, err := testutil.PathOfTempFile("boltdb")
+	r.NoError(err)
+	defer testutil.CleanupPath(path)
+	cfg := DefaultConfig
+	cfg.DbPath = path
+	kv := NewBoltDB(cfg)
+	r.NoError(kv.Start(context.Background()))
+	defer kv.Stop(context.Background())
+
+	injector := faultinject.NewInjector(
+		faultinject.Rule{Point: faultinject.DBWrite, Target: "ns1", Skip: 1, Times: 1},
+		faultinject.Rule{Point: faultinject.DBSync, Target: "ns2"},
+	)
+	defer faultinject.Enable(injector)()
+
+	// the first write passes, the second fails, and the later ones pass
+	r.NoError(kv.Put("ns1", []byte("k1"), []byte("v1")))
+	r.ErrorIs(kv.Put("ns1", []byte("k2"), []byte("v2")), ErrIO)
+	_, err = kv.Get("ns1", []byte("k2"))
+	r.Error(err)
+	r.NoError(kv.Put("ns1", []byte("k2"), []byte("v2")))
+
+	// failing the sync rolls back the whole batch
+	b := batch.NewBatch()
+	b.Put("ns1", []byte("k3"), []byte("v3"), "")
+	b.Put("ns2", []byte("k1"), []byte("v1"), "")
+	r.ErrorIs(kv.WriteBatch(b), ErrIO)
+	_, err = kv.Get("ns1", []byte("k3"))
+	r.Error(err)
+	r.Equal(1, injector.Injected(faultinject.DBWrite))
+	r.Equal(int(cfg.NumRetries), injector.Injected(faultinject.DBSync))
+}
@@ -17,6 +17,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/faultinject"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
@@ -104,6 +105,9 @@ func (b *PebbleDB) Put(ns string, key, value []byte) (err error) {
 	if !b.IsReady() {
 		return ErrDBNotStarted
 	}
+	if err = faultinject.Check(faultinject.DBWrite, ns); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
 	err = b.db.Set(nsKey(ns, key), value, nil)
 	if err != nil {
 		if errors.Is(err, syscall.ENOSPC) {
@@ -122,6 +126,9 @@ func (b *PebbleDB) Delete(ns string, key []byte) (err error) {
 	if key == nil {
 		panic("delete whole ns not supported by PebbleDB")
 	}
+	if err = faultinject.Check(faultinject.DBWrite, ns); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
 	err = b.db.Delete(nsKey(ns, key), nil)
 	if err != nil {
 		if errors.Is(err, syscall.ENOSPC) {
@@ -138,10 +145,13 @@ func (b *PebbleDB) WriteBatch(kvsb batch.KVStoreBatch) error {
 		return ErrDBNotStarted
 	}
 
-	batch, err := b.dedup(kvsb)
+	batch, namespaces, err := b.dedup(kvsb)
 	if err != nil {
 		return nil
 	}
+	if err = faultinject.Check(faultinject.DBWrite, namespaces...); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
 	err = batch.Commit(nil)
 	if err != nil {
 		if errors.Is(err, syscall.ENOSPC) {
@@ -152,7 +162,7 @@ func (b *PebbleDB) WriteBatch(kvsb batch.KVStoreBatch) error {
 	return err
 }
 
-func (b *PebbleDB) dedup(kvsb batch.KVStoreBatch) (*pebble.Batch, error) {
+func (b *PebbleDB) dedup(kvsb batch.KVStoreBatch) (*pebble.Batch, []string, error) {
 	kvsb.Lock()
 	defer kvsb.Unlock()
 
@@ -163,12 +173,13 @@ func (b *PebbleDB) dedup(kvsb batch.KVStoreBatch) (*pebble.Batch, error) {
 	// remove duplicate keys, only keep the last write for each key
 	var (
 		entryKeySet = make(map[doubleKey]struct{})
+		namespaces  = make([]string, 0)
 		ch          = b.db.NewBatch()
 	)
 	for i := kvsb.Size() - 1; i >= 0; i-- {
 		write, e := kvsb.Entry(i)
 		if e != nil {
-			return nil, e
+			return nil, nil, e
 		}
 		// only handle Put and Delete
 		if write.WriteType() != batch.Put && write.WriteType() != batch.Delete {
@@ -178,6 +189,7 @@ func (b *PebbleDB) dedup(kvsb batch.KVStoreBatch) (*pebble.Batch, error) {
 		k := doubleKey{ns: write.Namespace(), key: string(key)}
 		if _, ok := entryKeySet[k]; !ok {
 			entryKeySet[k] = struct{}{}
+			namespaces = append(namespaces, write.Namespace())
 			// add into batch
 			if write.WriteType() == batch.Put {
 				ch.Set(nsKey(write.Namespace(), key), write.Value(), nil)
@@ -186,7 +198,7 @@ func (b *PebbleDB) dedup(kvsb batch.KVStoreBatch) (*pebble.Batch, error) {
 			}
 		}
 	}
-	return ch, nil
+	return ch, namespaces, nil
 }
 
 // Filter returns <k, v> pair in a bucket that meet the condition
@@ -229,6 +241,34 @@ func (b *PebbleDB) Filter(ns string, cond Condition, minKey []byte, maxKey []byt
 	return
 }
 
+// Range retrieves values for a range of keys
+func (b *PebbleDB) Range(ns string, key []byte, count uint64) ([][]byte, error) {
+	if !b.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+	iter, err := b.db.NewIter(&pebble.IterOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create iterator")
+	}
+	defer func() {
+		if e := iter.Close(); e != nil {
+			log.L().Error("Failed to close iterator", zap.Error(e))
+		}
+	}()
+	value := make([][]byte, count)
+	iter.SeekPrefixGE(nsKey(ns, key))
+	for i := uint64(0); i < count; i++ {
+		if !iter.Valid() {
+			return nil, errors.Wrapf(ErrNotExist, "entry for key 0x%x doesn't exist", key)
+		}
+		v := iter.Value()
+		value[i] = make([]byte, len(v))
+		copy(value[i], v)
+		iter.Next()
+	}
+	return value, nil
+}
+
 // ForEach iterates over all <k, v> pairs in a bucket
 func (b *PebbleDB) ForEach(ns string, fn func(k, v []byte) error) error {
 	if !b.IsReady() {
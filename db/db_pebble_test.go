@@ -228,3 +228,40 @@ func TestPebbleDB_Foreach(t *testing.T) {
 	})
 	r.NoError(err)
 }
+
+func TestPebbleDB_Range(t *testing.T) {
+	r := require.New(t)
+	cfg := DefaultConfig
+	cfg.DbPath = t.TempDir()
+	db := NewPebbleDB(cfg)
+	ctx := context.Background()
+	r.NoError(db.Start(ctx))
+	defer func() {
+		r.NoError(db.Stop(ctx))
+	}()
+
+	r.NoError(db.Put("ns1", _k1, _v1))
+	r.NoError(db.Put("ns1", _k2, _v2))
+	r.NoError(db.Put("ns1", _k3, _v3))
+	r.NoError(db.Put("ns2", _k4, _v4))
+	vs, err := db.Range("ns1", _k1, 3)
+	r.NoError(err)
+	r.Equal([][]byte{_v1, _v2, _v3}, vs)
+	vs, err = db.Range("ns1", _k2, 2)
+	r.NoError(err)
+	r.Equal([][]byte{_v2, _v3}, vs)
+	// the range does not cross the namespace
+	_, err = db.Range("ns1", _k2, 3)
+	r.ErrorIs(err, ErrNotExist)
+	_, err = db.Range("ns3", _k1, 1)
+	r.ErrorIs(err, ErrNotExist)
+
+	// counting index works on pebble
+	index, err := NewCountingIndexNX(db, []byte("index"))
+	r.NoError(err)
+	r.NoError(index.Add(_v1, false))
+	r.NoError(index.Add(_v2, false))
+	vs, err = index.Range(0, 2)
+	r.NoError(err)
+	r.Equal([][]byte{_v1, _v2}, vs)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// DefaultJournalDepth is the default number of the latest blocks which could be rolled back
+const DefaultJournalDepth = 128
+
+// undo types of the journal
+const (
+	_undoDelete byte = iota
+	_undoPut
+)
+
+// Journal records the undo of the writes of each block into a namespace of the KVStore, so that the blocks could be
+// rolled back. The hash of each block is recorded as the checkpoint, to find the common ancestor with the chain
+type Journal struct {
+	kvStore KVStore
+	ns      string
+	depth   uint64
+}
+
+// NewJournal creates a journal in the namespace, which keeps the undo of the latest depth blocks
+func NewJournal(kv KVStore, ns string, depth uint64) *Journal {
+	return &Journal{
+		kvStore: kv,
+		ns:      ns,
+		depth:   depth,
+	}
+}
+
+// Record adds the undo of the writes in the batch, and the checkpoint of the block into the batch, which should be
+// the writes of the block at the height. The undo of the block out of the depth is deleted in the batch as well
+func (j *Journal) Record(b batch.KVStoreBatch, height uint64, blkHash hash.Hash256) error {
+	var (
+		record = append([]byte{}, blkHash[:]...)
+		seen   = make(map[string]struct{})
+	)
+	for i := 0; i < b.Size(); i++ {
+		write, err := b.Entry(i)
+		if err != nil {
+			return err
+		}
+		id := write.Namespace() + "/" + string(write.Key())
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		value, err := j.kvStore.Get(write.Namespace(), write.Key())
+		switch errors.Cause(err) {
+		case nil:
+			record = appendUndo(record, _undoPut, write.Namespace(), write.Key(), value)
+		case ErrNotExist:
+			record = appendUndo(record, _undoDelete, write.Namespace(), write.Key(), nil)
+		default:
+			return err
+		}
+	}
+	b.Put(j.ns, byteutil.Uint64ToBytesBigEndian(height), record, fmt.Sprintf("failed to put journal of block %d", height))
+	if height > j.depth {
+		b.Delete(j.ns, byteutil.Uint64ToBytesBigEndian(height-j.depth), fmt.Sprintf("failed to delete journal of block %d", height-j.depth))
+	}
+	return nil
+}
+
+// Checkpoint returns the hash of the block at the height recorded in the journal
+func (j *Journal) Checkpoint(height uint64) (hash.Hash256, error) {
+	record, err := j.kvStore.Get(j.ns, byteutil.Uint64ToBytesBigEndian(height))
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	if len(record) < len(hash.ZeroHash256) {
+		return hash.ZeroHash256, errors.Wrapf(ErrInvalid, "invalid journal of block %d", height)
+	}
+	return hash.BytesToHash256(record[:len(hash.ZeroHash256)]), nil
+}
+
+// Revert reverts the writes of the blocks from the tip down to the block after the height in a batch. It fails without
+// any write if the journal of any of the blocks is missing
+func (j *Journal) Revert(tip, height uint64) error {
+	b := batch.NewBatch()
+	for h := tip; h > height; h-- {
+		key := byteutil.Uint64ToBytesBigEndian(h)
+		record, err := j.kvStore.Get(j.ns, key)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get journal of block %d", h)
+		}
+		if len(record) < len(hash.ZeroHash256) {
+			return errors.Wrapf(ErrInvalid, "invalid journal of block %d", h)
+		}
+		if err := decodeUndo(b, record[len(hash.ZeroHash256):]); err != nil {
+			return errors.Wrapf(err, "invalid journal of block %d", h)
+		}
+		b.Delete(j.ns, key, fmt.Sprintf("failed to delete journal of block %d", h))
+	}
+	return j.kvStore.WriteBatch(b)
+}
+
+func appendUndo(record []byte, typ byte, ns string, key, value []byte) []byte {
+	record = append(record, typ)
+	for _, v := range [][]byte{[]byte(ns), key, value} {
+		record = binary.AppendUvarint(record, uint64(len(v)))
+		record = append(record, v...)
+	}
+	return record
+}
+
+func decodeUndo(b batch.KVStoreBatch, data []byte) error {
+	for len(data) > 0 {
+		typ := data[0]
+		data = data[1:]
+		var fields [3][]byte
+		for i := range fields {
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return ErrInvalid
+			}
+			fields[i], data = data[n:n+int(size)], data[n+int(size):]
+		}
+		switch typ {
+		case _undoPut:
+			b.Put(string(fields[0]), fields[1], fields[2], "failed to revert the write")
+		case _undoDelete:
+			b.Delete(string(fields[0]), fields[1], "failed to revert the write")
+		default:
+			return ErrInvalid
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+)
+
+func TestJournal(t *testing.T) {
+	require := require.New(t)
+
+	kv := NewMemKVStore()
+	ctx := context.Background()
+	require.NoError(kv.Start(ctx))
+	defer func() {
+		require.NoError(kv.Stop(ctx))
+	}()
+	j := NewJournal(kv, "journal", 2)
+
+	writeBlock := func(height uint64, write func(b batch.KVStoreBatch)) {
+		b := batch.NewBatch()
+		write(b)
+		require.NoError(j.Record(b, height, hash.Hash256b([]byte{byte(height)})))
+		require.NoError(kv.WriteBatch(b))
+	}
+	writeBlock(1, func(b batch.KVStoreBatch) {
+		b.Put("ns", []byte("a"), []byte("a1"), "")
+	})
+	writeBlock(2, func(b batch.KVStoreBatch) {
+		b.Put("ns", []byte("a"), []byte("a2"), "")
+		b.Put("ns", []byte("b"), []byte("b2"), "")
+	})
+	writeBlock(3, func(b batch.KVStoreBatch) {
+		b.Delete("ns", []byte("a"), "")
+		b.Put("ns", []byte("b"), []byte("b3"), "")
+		b.Put("ns", []byte("b"), []byte("b3'"), "")
+	})
+
+	for height := uint64(2); height <= 3; height++ {
+		h, err := j.Checkpoint(height)
+		require.NoError(err)
+		require.Equal(hash.Hash256b([]byte{byte(height)}), h)
+	}
+	// the journal of block 1 is out of the depth
+	_, err := j.Checkpoint(1)
+	require.Equal(ErrNotExist, errors.Cause(err))
+	require.Equal(ErrNotExist, errors.Cause(j.Revert(3, 0)))
+
+	require.NoError(j.Revert(3, 2))
+	v, err := kv.Get("ns", []byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("a2"), v)
+	v, err = kv.Get("ns", []byte("b"))
+	require.NoError(err)
+	require.Equal([]byte("b2"), v)
+	_, err = j.Checkpoint(3)
+	require.Equal(ErrNotExist, errors.Cause(err))
+
+	writeBlock(3, func(b batch.KVStoreBatch) {
+		b.Put("ns", []byte("c"), []byte("c3"), "")
+	})
+	require.NoError(j.Revert(3, 1))
+	v, err = kv.Get("ns", []byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("a1"), v)
+	for _, key := range []string{"b", "c"} {
+		_, err = kv.Get("ns", []byte(key))
+		require.Equal(ErrNotExist, errors.Cause(err))
+	}
+}
@@ -43,6 +43,21 @@ type (
 		Range(string, []byte, uint64) ([][]byte, error)
 	}
 
+	// KVStoreWithExport is KVStore whose records could be exported
+	KVStoreWithExport interface {
+		KVStore
+		// Export returns a consistent read-only view of all the records, which must be closed after use
+		Export() (ExportView, error)
+	}
+
+	// ExportView is a consistent read-only view of all the records of a KVStore
+	ExportView interface {
+		// ForEach iterates the records by namespace and key
+		ForEach(func(namespace string, key, value []byte) error) error
+		// Close releases the view
+		Close() error
+	}
+
 	// KVStoreForRangeIndex is KVStore for range index
 	KVStoreForRangeIndex interface {
 		KVStore
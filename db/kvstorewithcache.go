@@ -70,6 +70,15 @@ func (kvc *kvStoreWithCache) Filter(namespace string, cond Condition, minKey, ma
 	return kvc.store.Filter(namespace, cond, minKey, maxKey)
 }
 
+// Export returns a consistent read-only view of all the records in kvstore
+func (kvc *kvStoreWithCache) Export() (ExportView, error) {
+	store, ok := kvc.store.(KVStoreWithExport)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return store.Export()
+}
+
 // Delete deletes a record from statecaches if exists, and from kvstore
 func (kvc *kvStoreWithCache) Delete(namespace string, key []byte) (err error) {
 	if err := kvc.store.Delete(namespace, key); err != nil {
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/triepb"
+)
+
+// ErrInvalidProof indicates the proof does not match the root hash or the key
+var ErrInvalidProof = errors.New("invalid merkle proof")
+
+// Proof returns the serialized nodes on the path from root to the key. The path ends at the leaf of the key if it
+// exists, otherwise at the node where the key diverges, which proves the absence of the key
+func (mpt *merklePatriciaTrie) Proof(key []byte) ([][]byte, error) {
+	mpt.mutex.RLock()
+	defer mpt.mutex.RUnlock()
+
+	kt, err := mpt.checkKeyType(key)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		proof  [][]byte
+		n      node = mpt.root
+		offset uint8
+	)
+	for n != nil {
+		if hn, ok := n.(*hashNode); ok {
+			if n, err = hn.LoadNode(mpt); err != nil {
+				return nil, err
+			}
+		}
+		sn, ok := n.(serializable)
+		if !ok {
+			return nil, errors.Wrapf(trie.ErrInvalidTrie, "unexpected node type %T", n)
+		}
+		pb, err := sn.proto(mpt, false)
+		if err != nil {
+			return nil, err
+		}
+		ser, err := proto.Marshal(pb)
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, ser)
+		switch nd := n.(type) {
+		case *branchNode:
+			n = nd.children[kt[offset]]
+			offset++
+		case *extensionNode:
+			if nd.commonPrefixLength(kt[offset:]) != uint8(len(nd.path)) {
+				return proof, nil
+			}
+			offset += uint8(len(nd.path))
+			n = nd.child
+		default:
+			n = nil
+		}
+	}
+	return proof, nil
+}
+
+// VerifyProof verifies the proof of the key against the root hash, and returns the value of the key. It returns
+// trie.ErrNotExist if the proof is valid and proves the absence of the key
+func VerifyProof(rootHash []byte, key []byte, proof [][]byte, hashFunc HashFunc) ([]byte, error) {
+	value, n, err := verifyProof(rootHash, key, proof, hashFunc)
+	if err != nil && errors.Cause(err) != trie.ErrNotExist {
+		return nil, err
+	}
+	if n != len(proof) {
+		return nil, errors.Wrapf(ErrInvalidProof, "%d redundant nodes", len(proof)-n)
+	}
+	return value, err
+}
+
+// VerifyTwoLayerProof verifies the proof of a two layer trie generated by TwoLayerTrie.Proof, and returns the value of
+// the key in layer two
+func VerifyTwoLayerProof(rootHash []byte, layerOneKey []byte, layerTwoKey []byte, proof [][]byte) ([]byte, error) {
+	layerTwoRoot, n, err := verifyProof(rootHash, layerOneKey, proof, DefaultHashFunc)
+	if err != nil {
+		if errors.Cause(err) == trie.ErrNotExist && n != len(proof) {
+			return nil, errors.Wrapf(ErrInvalidProof, "%d redundant nodes", len(proof)-n)
+		}
+		return nil, err
+	}
+	return VerifyProof(layerTwoRoot, layerTwoKey, proof[n:], DefaultHashFunc)
+}
+
+// verifyProof walks the proof from root to the key, and returns the value and the number of nodes on the path
+func verifyProof(rootHash []byte, key []byte, proof [][]byte, hashFunc HashFunc) ([]byte, int, error) {
+	var (
+		expected = rootHash
+		offset   int
+	)
+	for i, ser := range proof {
+		if !bytes.Equal(hashFunc(ser), expected) {
+			return nil, i, errors.Wrapf(ErrInvalidProof, "hash of node %d mismatches", i)
+		}
+		pb := triepb.NodePb{}
+		if err := proto.Unmarshal(ser, &pb); err != nil {
+			return nil, i, errors.Wrapf(ErrInvalidProof, "failed to unmarshal node %d: %v", i, err)
+		}
+		switch {
+		case pb.GetBranch() != nil:
+			if offset >= len(key) {
+				return nil, i, errors.Wrapf(ErrInvalidProof, "branch node %d is beyond the key", i)
+			}
+			expected = nil
+			for _, b := range pb.GetBranch().GetBranches() {
+				if b.GetIndex() == uint32(key[offset]) {
+					expected = b.GetPath()
+					break
+				}
+			}
+			offset++
+			if expected == nil {
+				return nil, i + 1, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+			}
+		case pb.GetExtend() != nil:
+			path := pb.GetExtend().GetPath()
+			if !bytes.HasPrefix(key[offset:], path) {
+				return nil, i + 1, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+			}
+			offset += len(path)
+			expected = pb.GetExtend().GetValue()
+		case pb.GetLeaf() != nil:
+			if !bytes.Equal(pb.GetLeaf().GetPath(), key) {
+				return nil, i + 1, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+			}
+			return pb.GetLeaf().GetValue(), i + 1, nil
+		default:
+			return nil, i, errors.Wrapf(ErrInvalidProof, "invalid node %d", i)
+		}
+	}
+	return nil, len(proof), errors.Wrap(ErrInvalidProof, "incomplete proof")
+}
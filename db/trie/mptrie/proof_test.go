@@ -0,0 +1,119 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+)
+
+func TestProof(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		require := require.New(t)
+		opts := []Option{KeyLengthOption(8)}
+		if async {
+			opts = append(opts, AsyncOption())
+		}
+		tr, err := New(opts...)
+		require.NoError(err)
+		require.NoError(tr.Start(context.Background()))
+
+		// proof of empty trie
+		root, err := tr.RootHash()
+		require.NoError(err)
+		proof, err := tr.Proof(cat)
+		require.NoError(err)
+		require.Len(proof, 1)
+		_, err = VerifyProof(root, cat, proof, DefaultHashFunc)
+		require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+		keys := [][]byte{ham, car, cat, rat, egg, dog, cow}
+		for i, k := range keys {
+			require.NoError(tr.Upsert(k, testV[i%len(testV)]))
+		}
+		root, err = tr.RootHash()
+		require.NoError(err)
+		for i, k := range keys {
+			proof, err := tr.Proof(k)
+			require.NoError(err)
+			v, err := VerifyProof(root, k, proof, DefaultHashFunc)
+			require.NoError(err)
+			require.Equal(testV[i%len(testV)], v)
+		}
+		// absent keys diverge at a branch or an extension
+		for _, k := range [][]byte{fox, ant, br1, cl2} {
+			proof, err := tr.Proof(k)
+			require.NoError(err)
+			_, err = VerifyProof(root, k, proof, DefaultHashFunc)
+			require.Equal(trie.ErrNotExist, errors.Cause(err))
+		}
+
+		proof, err = tr.Proof(cat)
+		require.NoError(err)
+		require.True(len(proof) > 2)
+		// proof of another key
+		_, err = VerifyProof(root, rat, proof, DefaultHashFunc)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+		// wrong root
+		_, err = VerifyProof(emptyTrieRootHash, cat, proof, DefaultHashFunc)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+		// incomplete and redundant proof
+		_, err = VerifyProof(root, cat, proof[:len(proof)-1], DefaultHashFunc)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+		_, err = VerifyProof(root, cat, append(proof, proof[0]), DefaultHashFunc)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+		// tampered node
+		tampered := make([][]byte, len(proof))
+		copy(tampered, proof)
+		tampered[1] = append([]byte{}, proof[1]...)
+		tampered[1][len(tampered[1])-1]++
+		_, err = VerifyProof(root, cat, tampered, DefaultHashFunc)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+
+		// invalid key length
+		_, err = tr.Proof([]byte{1})
+		require.Error(err)
+		require.NoError(tr.Stop(context.Background()))
+	}
+}
+
+func TestTwoLayerTrieProof(t *testing.T) {
+	require := require.New(t)
+	tlt := NewTwoLayerTrie(trie.NewMemKVStore(), "rootKey")
+	require.NoError(tlt.Start(context.Background()))
+
+	ns1, ns2 := []byte("layerOneKey111111111"), []byte("layerOneKey222222222")
+	require.NoError(tlt.Upsert(ns1, []byte("layerTwoKey1"), []byte("value1")))
+	require.NoError(tlt.Upsert(ns1, []byte("layerTwoKey2"), []byte("value2")))
+	// pending changes are included in the proof
+	proof, err := tlt.Proof(ns1, []byte("layerTwoKey1"))
+	require.NoError(err)
+	root, err := tlt.RootHash()
+	require.NoError(err)
+	v, err := VerifyTwoLayerProof(root, ns1, []byte("layerTwoKey1"), proof)
+	require.NoError(err)
+	require.Equal([]byte("value1"), v)
+
+	// absent in layer two
+	proof, err = tlt.Proof(ns1, []byte("layerTwoKey3"))
+	require.NoError(err)
+	_, err = VerifyTwoLayerProof(root, ns1, []byte("layerTwoKey3"), proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+	// absent in layer one
+	proof, err = tlt.Proof(ns2, []byte("layerTwoKey1"))
+	require.NoError(err)
+	_, err = VerifyTwoLayerProof(root, ns2, []byte("layerTwoKey1"), proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+	_, err = VerifyTwoLayerProof(root, ns2, []byte("layerTwoKey1"), append(proof, proof[0]))
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	require.NoError(tlt.Stop(context.Background()))
+}
@@ -201,3 +201,30 @@ func (tlt *twoLayerTrie) Delete(layerOneKey []byte, layerTwoKey []byte) error {
 
 	return nil
 }
+
+func (tlt *twoLayerTrie) Proof(layerOneKey []byte, layerTwoKey []byte) ([][]byte, error) {
+	// pending changes of layer two are flushed into layer one, to be consistent with the root hash
+	if err := tlt.flush(context.Background()); err != nil {
+		return nil, err
+	}
+	proof, err := tlt.layerOne.Proof(layerOneKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tlt.layerOne.Get(layerOneKey); err != nil {
+		if errors.Cause(err) == trie.ErrNotExist {
+			return proof, nil
+		}
+		return nil, err
+	}
+	lt, err := tlt.layerTwoTrie(layerOneKey, len(layerTwoKey))
+	if err != nil {
+		return nil, err
+	}
+	layerTwoProof, err := lt.tr.Proof(layerTwoKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(proof, layerTwoProof...), nil
+}
@@ -48,6 +48,9 @@ type (
 		IsEmpty() bool
 		// Clone clones a trie with a new kvstore
 		Clone(KVStore) (Trie, error)
+		// Proof returns the serialized nodes on the path from root to the key, which proves the existence or
+		// absence of the key
+		Proof([]byte) ([][]byte, error)
 	}
 	// TwoLayerTrie is a trie data structure with two layers
 	TwoLayerTrie interface {
@@ -65,5 +68,8 @@ type (
 		Upsert([]byte, []byte, []byte) error
 		// Delete deletes an item in layer two
 		Delete([]byte, []byte) error
+		// Proof returns the proof of the item in layer two, which is the proof of layer two root in layer one
+		// followed by the proof of the item in layer two
+		Proof([]byte, []byte) ([][]byte, error)
 	}
 )
@@ -31,8 +31,17 @@ type (
 		BlockChanSize              uint          `yaml:"blockChanSize"`
 		BlockSyncChanSize          uint          `yaml:"blockSyncChanSize"`
 		ConsensusChanSize          uint          `yaml:"consensusChanSize"`
+		NodeInfoChanSize           uint          `yaml:"nodeInfoChanSize"`
 		MiscChanSize               uint          `yaml:"miscChanSize"`
 		ProcessSyncRequestInterval time.Duration `yaml:"processSyncRequestInterval"`
+		// Workers is the number of workers handling the messages of all queues
+		Workers uint `yaml:"workers"`
+		// ActionWorkers is the max number of workers handling the actions concurrently, the other queues are
+		// handled by one worker at a time to keep the message order
+		ActionWorkers uint `yaml:"actionWorkers"`
+		// QueueWeights is the share of the workers of each queue (action, block, blockSync, consensus, nodeInfo and
+		// misc) when multiple queues have pending messages
+		QueueWeights map[string]uint `yaml:"queueWeights"`
 		// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	}
 )
@@ -44,9 +53,21 @@ var (
 		BlockChanSize:     1000,
 		BlockSyncChanSize: 400,
 		ConsensusChanSize: 1000,
+		NodeInfoChanSize:  200,
 		MiscChanSize:      1000,
 
 		ProcessSyncRequestInterval: 0 * time.Second,
+
+		Workers:       8,
+		ActionWorkers: 4,
+		QueueWeights: map[string]uint{
+			consensusQ: 8,
+			blockQ:     4,
+			blockSyncQ: 2,
+			actionQ:    2,
+			nodeInfoQ:  1,
+			miscQ:      1,
+		},
 	}
 )
 
@@ -114,11 +135,15 @@ func NewDispatcher(cfg Config) (Dispatcher, error) {
 		eventAudit:   make(map[iotexrpc.MessageType]int),
 	}
 	queueMgr := newMsgQueueMgr(msgQueueConfig{
+		workers:        cfg.Workers,
+		actionWorkers:  cfg.ActionWorkers,
 		actionChanSize: cfg.ActionChanSize,
 		blockChanSize:  cfg.BlockChanSize,
 		blockSyncSize:  cfg.BlockSyncChanSize,
 		consensusSize:  cfg.ConsensusChanSize,
+		nodeInfoSize:   cfg.NodeInfoChanSize,
 		miscSize:       cfg.MiscChanSize,
+		weights:        cfg.QueueWeights,
 	}, func(msg *message) {
 		if !d.filter(msg) {
 			return
@@ -161,11 +186,7 @@ func (d *IotxDispatcher) Stop(ctx context.Context) error {
 
 // EventQueueSize returns the event queue size
 func (d *IotxDispatcher) EventQueueSize() map[string]int {
-	res := make(map[string]int)
-	for k, v := range d.queueMgr.queues {
-		res[k] = len(v)
-	}
-	return res
+	return d.queueMgr.Sizes()
 }
 
 // EventAudit returns the event audit map
@@ -207,14 +228,12 @@ func (d *IotxDispatcher) HandleBroadcast(ctx context.Context, chainID uint32, pe
 		peer:    peer,
 		msgType: msgType,
 	}
-	queue := d.queueForMsg(msg)
-	select {
-	case queue <- msg:
-	default:
+	fullness, queued := d.queueMgr.Enqueue(msg)
+	if !queued {
 		log.L().Warn("Broadcast queue is full.", zap.Any("msgType", msgType))
 	}
 
-	d.updateMetrics(msg, queue)
+	d.updateMetrics(msg, fullness)
 }
 
 // HandleTell handles incoming unicast message
@@ -235,14 +254,12 @@ func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer pe
 		peer:     cp.ID.String(),
 		msgType:  msgType,
 	}
-	queue := d.queueForMsg(msg)
-	select {
-	case queue <- msg:
-	default:
+	fullness, queued := d.queueMgr.Enqueue(msg)
+	if !queued {
 		log.L().Warn("Unicast queue is full.", zap.Any("msgType", msgType))
 	}
 
-	d.updateMetrics(msg, queue)
+	d.updateMetrics(msg, fullness)
 }
 
 func (d *IotxDispatcher) updateEventAudit(t iotexrpc.MessageType) {
@@ -251,18 +268,14 @@ func (d *IotxDispatcher) updateEventAudit(t iotexrpc.MessageType) {
 	d.eventAudit[t]++
 }
 
-func (d *IotxDispatcher) updateMetrics(msg *message, queue chan *message) {
+func (d *IotxDispatcher) updateMetrics(msg *message, fullness float32) {
 	d.updateEventAudit(msg.msgType)
 	subscriber := d.subscriber(msg.chainID)
 	if subscriber != nil {
-		subscriber.ReportFullness(msg.ctx, msg.msgType, float32(len(queue))/float32(cap(queue)))
+		subscriber.ReportFullness(msg.ctx, msg.msgType, fullness)
 	}
 }
 
-func (d *IotxDispatcher) queueForMsg(msg *message) msgQueue {
-	return d.queueMgr.Queue(msg)
-}
-
 func (d *IotxDispatcher) filter(msg *message) bool {
 	if msg.msgType != iotexrpc.MessageType_BLOCK_REQUEST {
 		return true
@@ -288,7 +301,7 @@ func (d *IotxDispatcher) dispatchMsg(message *message) {
 	}
 	switch msg := message.msg.(type) {
 	case *iotextypes.ConsensusMessage:
-		if err := subscriber.HandleConsensusMsg(msg); err != nil {
+		if err := subscriber.HandleConsensusMsg(message.ctx, message.peer, msg); err != nil {
 			log.L().Warn("Failed to handle consensus message.", zap.Error(err))
 		}
 	case *iotextypes.Action:
@@ -170,8 +170,8 @@ func TestDispatcherV2(t *testing.T) {
 }
 
 func dispatcherIsClean(dsp *IotxDispatcher) bool {
-	for k := range dsp.queueMgr.queues {
-		if len(dsp.queueMgr.queues[k]) != 0 {
+	for _, size := range dsp.EventQueueSize() {
+		if size != 0 {
 			return false
 		}
 	}
@@ -190,7 +190,9 @@ func (ds *dummySubscriber) HandleSyncRequest(context.Context, peer.AddrInfo, *io
 
 func (ds *dummySubscriber) HandleAction(context.Context, *iotextypes.Action) error { return nil }
 
-func (ds *dummySubscriber) HandleConsensusMsg(*iotextypes.ConsensusMessage) error { return nil }
+func (ds *dummySubscriber) HandleConsensusMsg(context.Context, string, *iotextypes.ConsensusMessage) error {
+	return nil
+}
 
 func (ds *dummySubscriber) HandleNodeInfoRequest(context.Context, peer.AddrInfo, *iotextypes.NodeInfoRequest) error {
 	return nil
@@ -236,7 +238,7 @@ func (cs *counterSubscriber) HandleAction(context.Context, *iotextypes.Action) e
 	return nil
 }
 
-func (cs *counterSubscriber) HandleConsensusMsg(*iotextypes.ConsensusMessage) error {
+func (cs *counterSubscriber) HandleConsensusMsg(context.Context, string, *iotextypes.ConsensusMessage) error {
 	cs.consensus.Inc()
 	return nil
 }
@@ -4,7 +4,10 @@ import (
 	"context"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
@@ -14,80 +17,205 @@ const (
 	blockQ     = "block"
 	blockSyncQ = "blockSync"
 	consensusQ = "consensus"
+	nodeInfoQ  = "nodeInfo"
 	miscQ      = "misc"
 )
 
+const (
+	// dropNewest drops the incoming message when the queue is full
+	dropNewest dropPolicy = iota
+	// dropOldest drops the head of the queue to make room for the incoming message, for the messages whose newer
+	// ones supersede the older ones
+	dropOldest
+)
+
+var _droppedMsgMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_dispatcher_dropped_message",
+		Help: "Number of messages dropped by the dispatcher queues.",
+	},
+	[]string{"queue"},
+)
+
+func init() {
+	prometheus.MustRegister(_droppedMsgMtc)
+}
+
 type (
+	dropPolicy int
+
+	// msgQueue is a bounded FIFO queue of a message category
+	msgQueue struct {
+		name     string
+		capacity int
+		// weight is the share of the workers the queue gets when the other queues have pending messages too
+		weight int
+		// workers is the max number of messages of the queue handled concurrently
+		workers int
+		policy  dropPolicy
+		msgs    []*message
+		running int
+		// current is the current weight of smooth weighted round-robin
+		current int
+	}
+
+	// msgQueueMgr schedules the messages of the queues to a pool of workers by smooth weighted round-robin, so that
+	// a flood of one category, e.g. actions, cannot starve the others, e.g. consensus
 	msgQueueMgr struct {
-		queues    map[string]msgQueue
-		wg        sync.WaitGroup
+		mu        sync.Mutex
+		cond      *sync.Cond
+		queues    map[string]*msgQueue
+		order     []*msgQueue
+		workers   int
 		handleMsg func(msg *message)
-		quit      chan struct{}
+		quit      bool
+		wg        sync.WaitGroup
 	}
-	msgQueue       chan *message
+
 	msgQueueConfig struct {
+		workers        uint
+		actionWorkers  uint
 		actionChanSize uint
 		blockChanSize  uint
 		blockSyncSize  uint
 		consensusSize  uint
+		nodeInfoSize   uint
 		miscSize       uint
+		weights        map[string]uint
 	}
 )
 
 func newMsgQueueMgr(cfg msgQueueConfig, handler func(msg *message)) *msgQueueMgr {
-	queues := make(map[string]msgQueue)
-	queues[actionQ] = make(chan *message, cfg.actionChanSize)
-	queues[blockQ] = make(chan *message, cfg.blockChanSize)
-	queues[blockSyncQ] = make(chan *message, cfg.blockSyncSize)
-	queues[consensusQ] = make(chan *message, cfg.consensusSize)
-	queues[miscQ] = make(chan *message, cfg.miscSize)
-	return &msgQueueMgr{
-		queues:    queues,
+	m := &msgQueueMgr{
+		queues:    make(map[string]*msgQueue),
+		workers:   max(int(cfg.workers), 1),
 		handleMsg: handler,
-		quit:      make(chan struct{}),
 	}
+	m.cond = sync.NewCond(&m.mu)
+	for _, q := range []*msgQueue{
+		// consensus messages of the latest rounds matter, and the ones of the past rounds are stale
+		{name: consensusQ, capacity: int(cfg.consensusSize), workers: 1, policy: dropOldest},
+		{name: blockQ, capacity: int(cfg.blockChanSize), workers: 1, policy: dropOldest},
+		{name: blockSyncQ, capacity: int(cfg.blockSyncSize), workers: 1, policy: dropNewest},
+		{name: actionQ, capacity: int(cfg.actionChanSize), workers: max(int(cfg.actionWorkers), 1), policy: dropNewest},
+		{name: nodeInfoQ, capacity: int(cfg.nodeInfoSize), workers: 1, policy: dropOldest},
+		{name: miscQ, capacity: int(cfg.miscSize), workers: 1, policy: dropNewest},
+	} {
+		q.weight = max(int(cfg.weights[q.name]), 1)
+		m.queues[q.name] = q
+		m.order = append(m.order, q)
+	}
+	return m
 }
 
 func (m *msgQueueMgr) Start(ctx context.Context) error {
-	for i := 0; i <= 4; i++ {
+	for i := 0; i < m.workers; i++ {
 		m.wg.Add(1)
-		go m.consume(actionQ)
+		go m.consume()
 	}
-
-	m.wg.Add(1)
-	go m.consume(blockQ)
-
-	m.wg.Add(1)
-	go m.consume(blockSyncQ)
-
-	m.wg.Add(1)
-	go m.consume(consensusQ)
-
-	m.wg.Add(1)
-	go m.consume(miscQ)
 	return nil
 }
 
 func (m *msgQueueMgr) Stop() error {
-	close(m.quit)
+	m.mu.Lock()
+	m.quit = true
+	m.mu.Unlock()
+	m.cond.Broadcast()
 	m.wg.Wait()
 	return nil
 }
 
-func (m *msgQueueMgr) consume(q string) {
+func (m *msgQueueMgr) consume() {
 	defer m.wg.Done()
 	for {
-		select {
-		case msg := <-m.queues[q]:
-			m.handleMsg(msg)
-		case <-m.quit:
+		q, msg := m.next()
+		if msg == nil {
 			log.L().Debug("message handler is terminated.")
 			return
 		}
+		m.handleMsg(msg)
+		m.mu.Lock()
+		q.running--
+		m.mu.Unlock()
+		// the queue may have pending messages waiting for a free slot
+		m.cond.Signal()
 	}
 }
 
-func (m *msgQueueMgr) Queue(msg *message) msgQueue {
+// next blocks until there is a message to handle, and returns nil once the manager stops
+func (m *msgQueueMgr) next() (*msgQueue, *message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for !m.quit {
+		var (
+			picked *msgQueue
+			total  int
+		)
+		for _, q := range m.order {
+			if len(q.msgs) == 0 || q.running >= q.workers {
+				continue
+			}
+			q.current += q.weight
+			total += q.weight
+			if picked == nil || q.current > picked.current {
+				picked = q
+			}
+		}
+		if picked == nil {
+			m.cond.Wait()
+			continue
+		}
+		picked.current -= total
+		picked.running++
+		return picked, picked.pop()
+	}
+	return nil, nil
+}
+
+// Enqueue adds the message to its queue, and returns the fullness of the queue and whether the message is queued.
+// When the queue is full, either the message or the oldest message of the queue is dropped by the queue policy
+func (m *msgQueueMgr) Enqueue(msg *message) (float32, bool) {
+	m.mu.Lock()
+	q := m.queue(msg)
+	queued := true
+	if len(q.msgs) >= q.capacity {
+		_droppedMsgMtc.WithLabelValues(q.name).Inc()
+		if q.policy == dropNewest || q.capacity == 0 {
+			queued = false
+		} else {
+			q.pop()
+		}
+	}
+	if queued {
+		q.msgs = append(q.msgs, msg)
+	}
+	fullness := float32(1)
+	if q.capacity > 0 {
+		fullness = float32(len(q.msgs)) / float32(q.capacity)
+	}
+	m.mu.Unlock()
+	if queued {
+		m.cond.Signal()
+	}
+	return fullness, queued
+}
+
+// Sizes returns the number of pending messages of each queue
+func (m *msgQueueMgr) Sizes() map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make(map[string]int, len(m.queues))
+	for k, q := range m.queues {
+		res[k] = len(q.msgs)
+	}
+	return res
+}
+
+func (m *msgQueueMgr) queue(msg *message) *msgQueue {
+	switch msg.msg.(type) {
+	case *iotextypes.NodeInfo, *iotextypes.NodeInfoRequest:
+		return m.queues[nodeInfoQ]
+	}
 	switch msg.msgType {
 	case iotexrpc.MessageType_ACTION, iotexrpc.MessageType_ACTIONS, iotexrpc.MessageType_ACTION_HASH, iotexrpc.MessageType_ACTION_REQUEST:
 		return m.queues[actionQ]
@@ -101,3 +229,10 @@ func (m *msgQueueMgr) Queue(msg *message) msgQueue {
 		return m.queues[miscQ]
 	}
 }
+
+func (q *msgQueue) pop() *message {
+	msg := q.msgs[0]
+	q.msgs[0] = nil
+	q.msgs = q.msgs[1:]
+	return msg
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+)
+
+func TestMsgQueueMgr(t *testing.T) {
+	require := require.New(t)
+	newMgr := func() *msgQueueMgr {
+		return newMsgQueueMgr(msgQueueConfig{
+			workers:        1,
+			actionWorkers:  2,
+			actionChanSize: 3,
+			blockChanSize:  3,
+			blockSyncSize:  3,
+			consensusSize:  2,
+			nodeInfoSize:   3,
+			miscSize:       3,
+			weights:        DefaultConfig.QueueWeights,
+		}, func(*message) {})
+	}
+	actionMsg := func() *message {
+		return &message{msg: &iotextypes.Action{}, msgType: iotexrpc.MessageType_ACTION}
+	}
+	consensusMsg := func(height uint64) *message {
+		return &message{msg: &iotextypes.ConsensusMessage{Height: height}, msgType: iotexrpc.MessageType_CONSENSUS}
+	}
+
+	t.Run("dropPolicy", func(t *testing.T) {
+		m := newMgr()
+		// the incoming actions are dropped when the queue is full
+		for i := 0; i < 3; i++ {
+			fullness, queued := m.Enqueue(actionMsg())
+			require.True(queued)
+			require.Equal(float32(i+1)/3, fullness)
+		}
+		fullness, queued := m.Enqueue(actionMsg())
+		require.False(queued)
+		require.Equal(float32(1), fullness)
+		// the oldest consensus messages are dropped when the queue is full
+		for h := uint64(1); h <= 3; h++ {
+			_, queued = m.Enqueue(consensusMsg(h))
+			require.True(queued)
+		}
+		require.Equal(3, m.Sizes()[actionQ])
+		require.Equal(2, m.Sizes()[consensusQ])
+		q, msg := m.next()
+		require.Equal(consensusQ, q.name)
+		require.EqualValues(2, msg.msg.(*iotextypes.ConsensusMessage).Height)
+		// node info goes to its own queue
+		_, queued = m.Enqueue(&message{msg: &iotextypes.NodeInfo{}})
+		require.True(queued)
+		require.Equal(1, m.Sizes()[nodeInfoQ])
+	})
+	t.Run("weightedScheduling", func(t *testing.T) {
+		m := newMgr()
+		for i := 0; i < 3; i++ {
+			m.Enqueue(actionMsg())
+		}
+		m.Enqueue(consensusMsg(1))
+		m.Enqueue(consensusMsg(2))
+		// consensus is picked first despite the earlier actions, and one consensus message is handled at a time
+		q, _ := m.next()
+		require.Equal(consensusQ, q.name)
+		q, _ = m.next()
+		require.Equal(actionQ, q.name)
+		q, _ = m.next()
+		require.Equal(actionQ, q.name)
+		// both action workers are busy
+		q.running--
+		m.queues[consensusQ].running--
+		q, _ = m.next()
+		require.Equal(consensusQ, q.name)
+		q, _ = m.next()
+		require.Equal(actionQ, q.name)
+		require.Equal(map[string]int{
+			actionQ:    0,
+			blockQ:     0,
+			blockSyncQ: 0,
+			consensusQ: 0,
+			nodeInfoQ:  0,
+			miscQ:      0,
+		}, m.Sizes())
+	})
+}
@@ -15,7 +15,7 @@ type Subscriber interface {
 	HandleAction(context.Context, *iotextypes.Action) error
 	HandleBlock(context.Context, string, *iotextypes.Block) error
 	HandleSyncRequest(context.Context, peer.AddrInfo, *iotexrpc.BlockSync) error
-	HandleConsensusMsg(*iotextypes.ConsensusMessage) error
+	HandleConsensusMsg(context.Context, string, *iotextypes.ConsensusMessage) error
 	HandleNodeInfoRequest(context.Context, peer.AddrInfo, *iotextypes.NodeInfoRequest) error
 	HandleNodeInfo(context.Context, string, *iotextypes.NodeInfo) error
 	HandleActionRequest(ctx context.Context, peer peer.AddrInfo, actHash hash.Hash256) error
@@ -124,7 +124,7 @@ func TestBlobTx(t *testing.T) {
 				time.Now(),
 			},
 			expect: []actionExpect{&functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
-				r.ErrorIs(err, errReceiptNotFound)
+				r.ErrorIs(err, action.ErrUnderpriced)
 			}}},
 		},
 		{
@@ -94,6 +94,11 @@ func VerifyEndorsement(doc Document, en *Endorsement) bool {
 	return en.Endorser().Verify(hash, en.Signature())
 }
 
+// Digest returns the digest of the document signed by the endorsement
+func (en *Endorsement) Digest(doc Document) ([]byte, error) {
+	return hashDocWithTime(doc, en.ts)
+}
+
 // Timestamp returns the signature time
 func (en *Endorsement) Timestamp() time.Time {
 	return en.ts
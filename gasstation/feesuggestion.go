@@ -0,0 +1,161 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package gasstation
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+// _feeLevels are the slow, standard and fast levels of the fee suggestion. The tip of a level is the percentile of
+// the priority fees paid in the recent blocks, i.e., the chance that the same tip got the action included, and is
+// raised to outbid the pending actions in actpool which fill up the blocks of the level
+var _feeLevels = []struct {
+	percentile float64
+	blocks     uint64
+}{
+	{percentile: 25, blocks: 4},
+	{percentile: 50, blocks: 2},
+	{percentile: 90, blocks: 1},
+}
+
+type (
+	// FeeLevel is the suggested fees of an action at a confidence level of inclusion
+	FeeLevel struct {
+		// GasPrice is the gas price of the legacy actions
+		GasPrice *big.Int
+		// GasTipCap and GasFeeCap are the gas tip cap and the gas fee cap of the dynamic fee actions
+		GasTipCap *big.Int
+		GasFeeCap *big.Int
+	}
+
+	// FeeSuggestion is the suggested fees at the slow, standard and fast levels
+	FeeSuggestion struct {
+		// BaseFee is the base fee of the next block, nil if EIP-1559 is not enabled yet
+		BaseFee  *big.Int
+		Slow     *FeeLevel
+		Standard *FeeLevel
+		Fast     *FeeLevel
+	}
+)
+
+// SuggestFees suggests the fees at the slow, standard and fast levels, from the priority fees paid in the recent
+// blocks and the pending actions competing for the next blocks
+func (gs *GasStation) SuggestFees(ctx context.Context, pending []*action.SealedEnvelope) (*FeeSuggestion, error) {
+	percentiles := make([]float64, len(_feeLevels))
+	for i, level := range _feeLevels {
+		percentiles[i] = level.percentile
+	}
+	tip := gs.bc.TipHeight()
+	_, rewards, baseFees, _, _, _, err := gs.FeeHistory(ctx, uint64(gs.cfg.SuggestBlockWindow), tip, percentiles)
+	if err != nil {
+		return nil, err
+	}
+	var baseFee *big.Int
+	if len(baseFees) > 0 {
+		baseFee = baseFees[len(baseFees)-1]
+	}
+	if baseFee == nil {
+		// EIP-1559 is not enabled yet, there is no priority fee to learn from
+		gasPrice, err := gs.SuggestGasPrice()
+		if err != nil {
+			return nil, err
+		}
+		price := new(big.Int).SetUint64(gasPrice)
+		level := &FeeLevel{GasPrice: price, GasTipCap: price, GasFeeCap: price}
+		return &FeeSuggestion{Slow: level, Standard: level, Fast: level}, nil
+	}
+	var (
+		g        = gs.bc.Genesis()
+		gasLimit = g.BlockGasLimitByHeight(tip + 1)
+		tips     = pendingTips(pending, baseFee)
+		levels   = make([]*FeeLevel, len(_feeLevels))
+	)
+	for i, level := range _feeLevels {
+		tipCap := medianReward(rewards, i)
+		if outbid := tipToOutbid(tips, gasLimit*level.blocks); outbid.Cmp(tipCap) > 0 {
+			tipCap = outbid
+		}
+		levels[i] = gs.feeLevel(baseFee, tipCap)
+	}
+	return &FeeSuggestion{
+		BaseFee:  baseFee,
+		Slow:     levels[0],
+		Standard: levels[1],
+		Fast:     levels[2],
+	}, nil
+}
+
+// feeLevel returns the fees paying the tip on top of the base fee, the gas fee cap allows the base fee to double
+func (gs *GasStation) feeLevel(baseFee, tip *big.Int) *FeeLevel {
+	minGasPrice := new(big.Int).SetUint64(gs.cfg.DefaultGas)
+	gasPrice := new(big.Int).Add(baseFee, tip)
+	if gasPrice.Cmp(minGasPrice) < 0 {
+		gasPrice = minGasPrice
+	}
+	gasFeeCap := new(big.Int).Add(new(big.Int).Lsh(baseFee, 1), tip)
+	if gasFeeCap.Cmp(minGasPrice) < 0 {
+		gasFeeCap = new(big.Int).Set(minGasPrice)
+	}
+	return &FeeLevel{
+		GasPrice:  gasPrice,
+		GasTipCap: new(big.Int).Set(tip),
+		GasFeeCap: gasFeeCap,
+	}
+}
+
+// medianReward returns the median of the rewards at the i-th percentile across the blocks
+func medianReward(rewards [][]*big.Int, i int) *big.Int {
+	if len(rewards) == 0 {
+		return big.NewInt(0)
+	}
+	sorted := make([]*big.Int, len(rewards))
+	for j := range rewards {
+		sorted[j] = rewards[j][i]
+	}
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].Cmp(sorted[b]) < 0
+	})
+	return new(big.Int).Set(sorted[len(sorted)/2])
+}
+
+type pendingTip struct {
+	gas uint64
+	tip *big.Int
+}
+
+// pendingTips returns the effective tips of the pending actions in descending order, the actions which can't afford
+// the base fee are skipped
+func pendingTips(pending []*action.SealedEnvelope, baseFee *big.Int) []*pendingTip {
+	tips := make([]*pendingTip, 0, len(pending))
+	for _, act := range pending {
+		tip := new(big.Int).Sub(act.EffectiveGasPrice(baseFee), baseFee)
+		if tip.Sign() < 0 {
+			continue
+		}
+		tips = append(tips, &pendingTip{gas: act.Gas(), tip: tip})
+	}
+	sort.SliceStable(tips, func(i, j int) bool {
+		return tips[i].tip.Cmp(tips[j].tip) > 0
+	})
+	return tips
+}
+
+// tipToOutbid returns the tip needed to get into the blocks of the gas, which is one more than the tip of the pending
+// action overflowing the gas, or zero if the pending actions don't fill up the gas
+func tipToOutbid(tips []*pendingTip, gas uint64) *big.Int {
+	var sum uint64
+	for _, t := range tips {
+		sum += t.gas
+		if sum > gas {
+			return new(big.Int).Add(t.tip, big.NewInt(1))
+		}
+	}
+	return big.NewInt(0)
+}
@@ -124,11 +124,22 @@ type blockFee struct {
 }
 
 type blockPercents struct {
-	ascEffectivePriorityFees []*big.Int
+	// ascRewards are the effective priority fees of the actions in ascending order
+	ascRewards []*actionReward
+	gasUsed    uint64
+}
+
+type actionReward struct {
+	gasUsed uint64
+	reward  *big.Int
 }
 
 // FeeHistory returns fee history over a series of blocks
 func (gs *GasStation) FeeHistory(ctx context.Context, blocks, lastBlock uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, []*big.Int, []float64, error) {
+	if blocks > lastBlock {
+		// the history starts from block 1
+		blocks = lastBlock
+	}
 	if blocks < 1 {
 		return 0, nil, nil, nil, nil, nil, nil
 	}
@@ -190,24 +201,16 @@ func (gs *GasStation) FeeHistory(ctx context.Context, blocks, lastBlock uint64,
 		if len(rewardPercentiles) > 0 {
 			if blkPercents, ok := gs.percentileCache.Get(height); ok {
 				log.T(ctx).Debug("percentile cache hit", zap.Uint64("height", height))
-				rewards = append(rewards, feesPercentiles(blkPercents.(*blockPercents).ascEffectivePriorityFees, rewardPercentiles))
+				rewards = append(rewards, feesPercentiles(blkPercents.(*blockPercents), rewardPercentiles))
 			} else {
 				log.T(ctx).Debug("percentile cache miss", zap.Uint64("height", height))
 				receipts, err := gs.dao.GetReceipts(height)
 				if err != nil {
 					return 0, nil, nil, nil, nil, nil, status.Error(codes.NotFound, err.Error())
 				}
-				fees := make([]*big.Int, 0, len(receipts))
-				for _, r := range receipts {
-					fees = append(fees, r.PriorityFee())
-				}
-				sort.Slice(fees, func(i, j int) bool {
-					return fees[i].Cmp(fees[j]) < 0
-				})
-				rewards = append(rewards, feesPercentiles(fees, rewardPercentiles))
-				gs.percentileCache.Add(height, &blockPercents{
-					ascEffectivePriorityFees: fees,
-				})
+				bp := newBlockPercents(receipts)
+				rewards = append(rewards, feesPercentiles(bp, rewardPercentiles))
+				gs.percentileCache.Add(height, bp)
 			}
 		}
 	}
@@ -228,14 +231,49 @@ func (gs *GasStation) FeeHistory(ctx context.Context, blocks, lastBlock uint64,
 	return lastBlock - blocks + 1, rewards, baseFees, gasUsedRatios, blobBaseFees, blobGasUsedRatios, nil
 }
 
-func feesPercentiles(ascFees []*big.Int, percentiles []float64) []*big.Int {
+// newBlockPercents sorts the effective priority fee per gas of the actions in the block
+func newBlockPercents(receipts []*action.Receipt) *blockPercents {
+	bp := &blockPercents{
+		ascRewards: make([]*actionReward, 0, len(receipts)),
+	}
+	for _, r := range receipts {
+		reward := big.NewInt(0)
+		if fee := r.PriorityFee(); fee != nil && r.GasConsumed > 0 {
+			reward.Div(fee, new(big.Int).SetUint64(r.GasConsumed))
+		}
+		bp.ascRewards = append(bp.ascRewards, &actionReward{
+			gasUsed: r.GasConsumed,
+			reward:  reward,
+		})
+		bp.gasUsed += r.GasConsumed
+	}
+	sort.SliceStable(bp.ascRewards, func(i, j int) bool {
+		return bp.ascRewards[i].reward.Cmp(bp.ascRewards[j].reward) < 0
+	})
+	return bp
+}
+
+// feesPercentiles returns the rewards at the percentiles weighted by the gas used of each action, the same as
+// eth_feeHistory of ethereum
+func feesPercentiles(bp *blockPercents, percentiles []float64) []*big.Int {
 	res := make([]*big.Int, len(percentiles))
+	if len(bp.ascRewards) == 0 {
+		for i := range res {
+			res[i] = big.NewInt(0)
+		}
+		return res
+	}
+	var (
+		idx        = 0
+		sumGasUsed = bp.ascRewards[0].gasUsed
+	)
 	for i, p := range percentiles {
-		idx := int(float64(len(ascFees)) * p)
-		if idx >= len(ascFees) {
-			idx = len(ascFees) - 1
+		threshold := uint64(float64(bp.gasUsed) * p / 100)
+		for sumGasUsed < threshold && idx < len(bp.ascRewards)-1 {
+			idx++
+			sumGasUsed += bp.ascRewards[idx].gasUsed
 		}
-		res[i] = ascFees[idx]
+		res[i] = bp.ascRewards[idx].reward
 	}
 	return res
 }
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/v2/action"
@@ -283,3 +284,80 @@ func prepareBlocks(r *require.Assertions, cases []testActionGas) map[uint64]*blo
 	}
 	return blocks
 }
+
+func TestFeesPercentiles(t *testing.T) {
+	require := require.New(t)
+	newReceipt := func(gas uint64, tip int64) *action.Receipt {
+		r := &action.Receipt{GasConsumed: gas}
+		if tip > 0 {
+			r.AddTransactionLogs(&action.TransactionLog{
+				Type:   iotextypes.TransactionLogType_PRIORITY_FEE,
+				Amount: new(big.Int).Mul(big.NewInt(tip), new(big.Int).SetUint64(gas)),
+			})
+		}
+		return r
+	}
+	toInt64 := func(fees []*big.Int) []int64 {
+		res := make([]int64, len(fees))
+		for i, f := range fees {
+			res[i] = f.Int64()
+		}
+		return res
+	}
+	percentiles := []float64{0, 10, 50, 90, 100}
+
+	// empty block
+	require.Equal([]int64{0, 0, 0, 0, 0}, toInt64(feesPercentiles(newBlockPercents(nil), percentiles)))
+	// system action without priority fee
+	require.Equal([]int64{0, 0, 0, 0, 0}, toInt64(feesPercentiles(newBlockPercents([]*action.Receipt{newReceipt(0, 0)}), percentiles)))
+	// weighted by gas used
+	bp := newBlockPercents([]*action.Receipt{
+		newReceipt(10000, 30),
+		newReceipt(0, 0),
+		newReceipt(70000, 10),
+		newReceipt(20000, 20),
+	})
+	require.Equal(uint64(100000), bp.gasUsed)
+	require.Equal([]int64{0, 10, 10, 20, 30}, toInt64(feesPercentiles(bp, percentiles)))
+}
+
+func TestSuggestFeesLevels(t *testing.T) {
+	require := require.New(t)
+	gs := NewGasStation(nil, nil, DefaultConfig)
+	qev := big.NewInt(unit.Qev)
+	baseFee := new(big.Int).Set(qev)
+
+	// the actions paying less than the base fee don't compete
+	var pending []*action.SealedEnvelope
+	for _, price := range []int64{1, 3, 2, 0} {
+		selp, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(1), 1, big.NewInt(0), []byte{}, 100, new(big.Int).Mul(qev, big.NewInt(price)))
+		require.NoError(err)
+		pending = append(pending, selp)
+	}
+	tips := pendingTips(pending, baseFee)
+	require.Len(tips, 3)
+	for i, expected := range []int64{2, 1, 0} {
+		require.Equal(new(big.Int).Mul(qev, big.NewInt(expected)).String(), tips[i].tip.String())
+	}
+	require.Zero(tipToOutbid(tips, 300).Sign())
+	require.Equal(new(big.Int).Add(qev, big.NewInt(1)), tipToOutbid(tips, 150))
+	require.Equal(new(big.Int).Add(new(big.Int).Mul(qev, big.NewInt(2)), big.NewInt(1)), tipToOutbid(tips, 50))
+
+	rewards := [][]*big.Int{
+		{big.NewInt(1), big.NewInt(5)},
+		{big.NewInt(3), big.NewInt(4)},
+		{big.NewInt(2), big.NewInt(6)},
+	}
+	require.Equal(big.NewInt(2), medianReward(rewards, 0))
+	require.Equal(big.NewInt(5), medianReward(rewards, 1))
+	require.Zero(medianReward(nil, 0).Sign())
+
+	level := gs.feeLevel(baseFee, qev)
+	require.Equal(new(big.Int).Mul(qev, big.NewInt(2)), level.GasPrice)
+	require.Equal(qev, level.GasTipCap)
+	require.Equal(new(big.Int).Mul(qev, big.NewInt(3)), level.GasFeeCap)
+	// the fees are at least the min gas price
+	level = gs.feeLevel(big.NewInt(0), big.NewInt(0))
+	require.Equal(qev, level.GasPrice)
+	require.Equal(qev, level.GasFeeCap)
+}
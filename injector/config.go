@@ -0,0 +1,121 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package injector
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Config is the config of the injector, which sends actions to an endpoint at the targeted tps
+	Config struct {
+		Endpoint string
+		Insecure bool
+		// KeyFile is the yaml file of the key pairs of the funded accounts sending the actions
+		KeyFile string
+		TPS     int
+		// Duration is how long the injection lasts
+		Duration time.Duration
+		// Workers is the number of the goroutines sending the actions
+		Workers int
+		Mix     Mix
+		// GasPrice is the gas price in rau of all the actions
+		GasPrice string
+
+		TransferAmount   string
+		TransferGasLimit uint64
+
+		// Contract is the contract the executions call with the ExecutionData
+		Contract          string
+		ExecutionData     []byte
+		ExecutionAmount   string
+		ExecutionGasLimit uint64
+
+		// StakeCandidate is the name of the candidate the stakes vote for
+		StakeCandidate string
+		StakeAmount    string
+		StakeDuration  uint32
+		StakeGasLimit  uint64
+
+		// Confirm measures the latency until the receipt of each action is available, besides the latency of sending
+		Confirm        bool
+		ConfirmTimeout time.Duration
+	}
+
+	// Mix is the weights of the types of the actions to inject
+	Mix struct {
+		Transfer  uint
+		Execution uint
+		Stake     uint
+	}
+)
+
+// DefaultConfig is the default config of the injector
+var DefaultConfig = Config{
+	Endpoint:          "127.0.0.1:14014",
+	Insecure:          true,
+	TPS:               10,
+	Duration:          time.Minute,
+	Workers:           10,
+	Mix:               Mix{Transfer: 1},
+	GasPrice:          "1000000000000",
+	TransferAmount:    "1",
+	TransferGasLimit:  10000,
+	ExecutionAmount:   "0",
+	ExecutionGasLimit: 100000,
+	StakeAmount:       "100000000000000000000",
+	StakeGasLimit:     10000,
+	ConfirmTimeout:    time.Minute,
+}
+
+// ParseMix parses the weights of the types of the actions, e.g., "transfer=8,execution=1,stake=1"
+func ParseMix(s string) (Mix, error) {
+	var m Mix
+	for _, item := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(kv) != 2 {
+			return Mix{}, errors.Errorf("invalid action mix %s", item)
+		}
+		w, err := strconv.ParseUint(kv[1], 10, 32)
+		if err != nil {
+			return Mix{}, errors.Wrapf(err, "invalid weight of %s", kv[0])
+		}
+		switch kv[0] {
+		case "transfer":
+			m.Transfer = uint(w)
+		case "execution":
+			m.Execution = uint(w)
+		case "stake":
+			m.Stake = uint(w)
+		default:
+			return Mix{}, errors.Errorf("unknown action type %s", kv[0])
+		}
+	}
+	if m.total() == 0 {
+		return Mix{}, errors.New("weights of the action mix are all 0")
+	}
+	return m, nil
+}
+
+func (m Mix) total() uint {
+	return m.Transfer + m.Execution + m.Stake
+}
+
+// pick returns the type of the action by the random number in [0, total)
+func (m Mix) pick(r uint) actionType {
+	switch {
+	case r < m.Transfer:
+		return _transfer
+	case r < m.Transfer+m.Execution:
+		return _execution
+	default:
+		return _stake
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package injector
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// _bounds are the upper bounds of the buckets of the histogram, doubling from 1ms to about 33s
+var _bounds = func() []time.Duration {
+	bounds := make([]time.Duration, 16)
+	for i := range bounds {
+		bounds[i] = time.Millisecond << i
+	}
+	return bounds
+}()
+
+// Histogram counts the latencies in the buckets of exponential bounds
+type Histogram struct {
+	mu     sync.Mutex
+	counts []uint64
+	count  uint64
+	sum    time.Duration
+	max    time.Duration
+}
+
+// NewHistogram returns an empty histogram
+func NewHistogram() *Histogram {
+	// the last bucket counts the latencies beyond all the bounds
+	return &Histogram{counts: make([]uint64, len(_bounds)+1)}
+}
+
+// Observe adds the latency into the histogram
+func (h *Histogram) Observe(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i := 0
+	for i < len(_bounds) && d > _bounds[i] {
+		i++
+	}
+	h.counts[i]++
+	h.count++
+	h.sum += d
+	if d > h.max {
+		h.max = d
+	}
+}
+
+// Count returns the number of the latencies observed
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// Percentile returns the upper bound of the bucket the q-th percentile falls in, or the max latency if it is beyond
+// all the bounds
+func (h *Histogram) Percentile(q float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.percentile(q)
+}
+
+func (h *Histogram) percentile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := uint64(q / 100 * float64(h.count))
+	if rank == 0 {
+		rank = 1
+	}
+	var acc uint64
+	for i, c := range h.counts {
+		acc += c
+		if acc >= rank {
+			if i == len(_bounds) || _bounds[i] > h.max {
+				return h.max
+			}
+			return _bounds[i]
+		}
+	}
+	return h.max
+}
+
+// String prints the non-empty buckets and the percentiles
+func (h *Histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.count == 0 {
+		return "no latency observed\n"
+	}
+	var sb strings.Builder
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		bucket := "> " + _bounds[len(_bounds)-1].String()
+		if i < len(_bounds) {
+			bucket = "<= " + _bounds[i].String()
+		}
+		fmt.Fprintf(&sb, "%12s %8d %6.2f%% %s\n", bucket, c, float64(c)*100/float64(h.count),
+			strings.Repeat("*", int(c*50/h.count)))
+	}
+	fmt.Fprintf(&sb, "avg %v, p50 %v, p90 %v, p99 %v, max %v\n",
+		h.sum/time.Duration(h.count), h.percentile(50), h.percentile(90), h.percentile(99), h.max)
+	return sb.String()
+}
@@ -0,0 +1,393 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package injector
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const _confirmInterval = 500 * time.Millisecond
+
+type actionType int
+
+const (
+	_transfer actionType = iota
+	_execution
+	_stake
+)
+
+func (t actionType) String() string {
+	switch t {
+	case _transfer:
+		return "transfer"
+	case _execution:
+		return "execution"
+	default:
+		return "stake"
+	}
+}
+
+type (
+	// keyPairs is the format of the key file, same as the one of the genesis transfer addresses of the devnet
+	keyPairs struct {
+		Pairs []struct {
+			SK string `yaml:"priKey"`
+		} `yaml:"pkPairs"`
+	}
+
+	// sender is an account sending the actions, whose nonce is synced from the endpoint at the start and after a
+	// failed send, which may leave a gap in the nonces
+	sender struct {
+		key  crypto.PrivateKey
+		addr string
+
+		mu    sync.Mutex
+		nonce uint64
+		stale bool
+	}
+
+	// Injector sends the mix of the transfers, executions and stakes to the endpoint at the targeted tps, and measures
+	// the latencies. The actions of an account are sent one by one in the order of the nonces, so the achievable tps
+	// grows with the number of the accounts
+	Injector struct {
+		cfg     Config
+		conn    *grpc.ClientConn
+		api     iotexapi.APIServiceClient
+		senders []*sender
+		chainID uint32
+
+		gasPrice        *big.Int
+		transferAmount  *big.Int
+		executionAmount *big.Int
+
+		next           atomic.Uint64
+		sent           atomic.Uint64
+		failed         atomic.Uint64
+		dropped        atomic.Uint64
+		reverted       atomic.Uint64
+		unconfirmed    atomic.Uint64
+		sendLatency    *Histogram
+		confirmLatency *Histogram
+		confirms       sync.WaitGroup
+	}
+
+	// Report is the result of an injection
+	Report struct {
+		Elapsed time.Duration
+		Sent    uint64
+		Failed  uint64
+		// Dropped is the number of the actions not sent because the workers could not keep up with the tps
+		Dropped uint64
+		// Reverted and Unconfirmed are only counted if the receipts are awaited
+		Reverted       uint64
+		Unconfirmed    uint64
+		SendLatency    *Histogram
+		ConfirmLatency *Histogram
+	}
+)
+
+// New creates an injector connected to the endpoint, sending the actions by the accounts in the key file
+func New(cfg Config) (*Injector, error) {
+	keys, err := loadKeys(cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	opts := []grpc.DialOption{}
+	if cfg.Insecure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})))
+	}
+	conn, err := grpc.NewClient(cfg.Endpoint, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", cfg.Endpoint)
+	}
+	inj, err := newInjector(cfg, iotexapi.NewAPIServiceClient(conn), keys)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	inj.conn = conn
+	return inj, nil
+}
+
+func newInjector(cfg Config, api iotexapi.APIServiceClient, keys []crypto.PrivateKey) (*Injector, error) {
+	if cfg.TPS <= 0 || cfg.Workers <= 0 {
+		return nil, errors.New("tps and workers of injector should be greater than 0")
+	}
+	if cfg.Mix.total() == 0 {
+		return nil, errors.New("weights of the action mix are all 0")
+	}
+	if cfg.Mix.Execution > 0 {
+		if _, err := address.FromString(cfg.Contract); err != nil {
+			return nil, errors.Wrap(err, "invalid contract of executions")
+		}
+	}
+	if cfg.Mix.Stake > 0 {
+		if cfg.StakeCandidate == "" {
+			return nil, errors.New("candidate of stakes is not set")
+		}
+		if _, ok := new(big.Int).SetString(cfg.StakeAmount, 10); !ok {
+			return nil, errors.Errorf("invalid stake amount %s", cfg.StakeAmount)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no account to send actions")
+	}
+	inj := &Injector{
+		cfg:            cfg,
+		api:            api,
+		sendLatency:    NewHistogram(),
+		confirmLatency: NewHistogram(),
+	}
+	for _, v := range []struct {
+		name  string
+		value string
+		ptr   **big.Int
+	}{
+		{"gas price", cfg.GasPrice, &inj.gasPrice},
+		{"transfer amount", cfg.TransferAmount, &inj.transferAmount},
+		{"execution amount", cfg.ExecutionAmount, &inj.executionAmount},
+	} {
+		amount, ok := new(big.Int).SetString(v.value, 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, errors.Errorf("invalid %s %s", v.name, v.value)
+		}
+		*v.ptr = amount
+	}
+	for _, key := range keys {
+		addr := key.PublicKey().Address()
+		if addr == nil {
+			return nil, errors.New("failed to get address of the key")
+		}
+		inj.senders = append(inj.senders, &sender{key: key, addr: addr.String(), stale: true})
+	}
+	return inj, nil
+}
+
+func loadKeys(path string) ([]crypto.PrivateKey, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read key file")
+	}
+	var pairs keyPairs
+	if err := yaml.Unmarshal(data, &pairs); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal key file")
+	}
+	keys := make([]crypto.PrivateKey, 0, len(pairs.Pairs))
+	for _, pair := range pairs.Pairs {
+		sk, err := crypto.HexStringToPrivateKey(pair.SK)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode private key")
+		}
+		keys = append(keys, sk)
+	}
+	return keys, nil
+}
+
+// Close closes the connection to the endpoint
+func (inj *Injector) Close() error {
+	if inj.conn == nil {
+		return nil
+	}
+	return inj.conn.Close()
+}
+
+// Run injects the actions until the duration elapses or the context is done, and waits for the receipts if they are
+// awaited
+func (inj *Injector) Run(ctx context.Context) (*Report, error) {
+	meta, err := inj.api.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get chain meta")
+	}
+	inj.chainID = meta.GetChainMeta().GetChainID()
+	for _, s := range inj.senders {
+		if err := inj.syncNonce(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, inj.cfg.Duration)
+	defer cancel()
+	var (
+		workers sync.WaitGroup
+		jobs    = make(chan struct{}, inj.cfg.Workers)
+	)
+	for i := 0; i < inj.cfg.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for range jobs {
+				inj.injectOne(ctx)
+			}
+		}()
+	}
+	began := time.Now()
+	interval := time.Second / time.Duration(inj.cfg.TPS)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+pace:
+	for count := 0; ; count++ {
+		select {
+		case <-ctx.Done():
+			break pace
+		case <-timer.C:
+		}
+		select {
+		case jobs <- struct{}{}:
+		default:
+			inj.dropped.Add(1)
+		}
+		timer.Reset(time.Until(began.Add(time.Duration(count+1) * interval)))
+	}
+	close(jobs)
+	workers.Wait()
+	elapsed := time.Since(began)
+	inj.confirms.Wait()
+	return &Report{
+		Elapsed:        elapsed,
+		Sent:           inj.sent.Load(),
+		Failed:         inj.failed.Load(),
+		Dropped:        inj.dropped.Load(),
+		Reverted:       inj.reverted.Load(),
+		Unconfirmed:    inj.unconfirmed.Load(),
+		SendLatency:    inj.sendLatency,
+		ConfirmLatency: inj.confirmLatency,
+	}, nil
+}
+
+func (inj *Injector) syncNonce(ctx context.Context, s *sender) error {
+	resp, err := inj.api.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: s.addr})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get nonce of %s", s.addr)
+	}
+	s.nonce, s.stale = resp.GetAccountMeta().GetPendingNonce(), false
+	return nil
+}
+
+// injectOne sends an action of the type picked by the weights, by the next account in turn
+func (inj *Injector) injectOne(ctx context.Context) {
+	s := inj.senders[inj.next.Add(1)%uint64(len(inj.senders))]
+	t := inj.cfg.Mix.pick(uint(rand.Int63n(int64(inj.cfg.Mix.total()))))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stale {
+		if err := inj.syncNonce(ctx, s); err != nil {
+			if ctx.Err() == nil {
+				inj.failed.Add(1)
+				log.L().Warn("Failed to sync nonce.", zap.Error(err))
+			}
+			return
+		}
+	}
+	selp, err := inj.sign(t, s)
+	if err != nil {
+		inj.failed.Add(1)
+		log.L().Error("Failed to sign action.", zap.Stringer("type", t), zap.Error(err))
+		return
+	}
+	start := time.Now()
+	if _, err := inj.api.SendAction(ctx, &iotexapi.SendActionRequest{Action: selp.Proto()}); err != nil {
+		if ctx.Err() == nil {
+			inj.failed.Add(1)
+			s.stale = true
+			log.L().Debug("Failed to send action.", zap.String("sender", s.addr), zap.Uint64("nonce", s.nonce), zap.Error(err))
+		}
+		return
+	}
+	inj.sendLatency.Observe(time.Since(start))
+	inj.sent.Add(1)
+	s.nonce++
+	if inj.cfg.Confirm {
+		h, err := selp.Hash()
+		if err != nil {
+			log.L().Error("Failed to hash action.", zap.Error(err))
+			return
+		}
+		inj.confirms.Add(1)
+		go inj.confirm(h, start)
+	}
+}
+
+func (inj *Injector) sign(t actionType, s *sender) (*action.SealedEnvelope, error) {
+	bd := (&action.EnvelopeBuilder{}).SetNonce(s.nonce).SetGasPrice(inj.gasPrice).SetChainID(inj.chainID)
+	switch t {
+	case _transfer:
+		recipient := inj.senders[rand.Intn(len(inj.senders))].addr
+		bd.SetGasLimit(inj.cfg.TransferGasLimit).SetAction(action.NewTransfer(inj.transferAmount, recipient, nil))
+	case _execution:
+		bd.SetGasLimit(inj.cfg.ExecutionGasLimit).
+			SetAction(action.NewExecution(inj.cfg.Contract, inj.executionAmount, inj.cfg.ExecutionData))
+	default:
+		stake, err := action.NewCreateStake(inj.cfg.StakeCandidate, inj.cfg.StakeAmount, inj.cfg.StakeDuration, false, nil)
+		if err != nil {
+			return nil, err
+		}
+		bd.SetGasLimit(inj.cfg.StakeGasLimit).SetAction(stake)
+	}
+	return action.Sign(bd.Build(), s.key)
+}
+
+// confirm waits for the receipt of the action, and measures the latency since it is sent
+func (inj *Injector) confirm(h hash.Hash256, start time.Time) {
+	defer inj.confirms.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), inj.cfg.ConfirmTimeout)
+	defer cancel()
+	ticker := time.NewTicker(_confirmInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := inj.api.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{ActionHash: hex.EncodeToString(h[:])})
+		if err == nil {
+			inj.confirmLatency.Observe(time.Since(start))
+			if resp.GetReceiptInfo().GetReceipt().GetStatus() != 1 {
+				inj.reverted.Add(1)
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+			inj.unconfirmed.Add(1)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// String prints the counts, the achieved tps and the latency histograms
+func (r *Report) String() string {
+	s := fmt.Sprintf("elapsed %v, sent %d, failed %d, dropped %d, tps %.2f\n",
+		r.Elapsed.Round(time.Millisecond), r.Sent, r.Failed, r.Dropped, float64(r.Sent)/r.Elapsed.Seconds())
+	s += "send latency:\n" + r.SendLatency.String()
+	if r.ConfirmLatency.Count() > 0 || r.Unconfirmed > 0 {
+		s += fmt.Sprintf("confirm latency (reverted %d, unconfirmed %d):\n", r.Reverted, r.Unconfirmed)
+		s += r.ConfirmLatency.String()
+	}
+	return s
+}
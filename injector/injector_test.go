@@ -0,0 +1,142 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package injector
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestParseMix(t *testing.T) {
+	require := require.New(t)
+
+	m, err := ParseMix("transfer=8, execution=1,stake=1")
+	require.NoError(err)
+	require.Equal(Mix{Transfer: 8, Execution: 1, Stake: 1}, m)
+	require.Equal(_transfer, m.pick(7))
+	require.Equal(_execution, m.pick(8))
+	require.Equal(_stake, m.pick(9))
+
+	for _, s := range []string{"transfer", "transfer=a", "vote=1", "transfer=0"} {
+		_, err = ParseMix(s)
+		require.Error(err, s)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	require := require.New(t)
+
+	h := NewHistogram()
+	require.Zero(h.Percentile(50))
+	for i := 0; i < 90; i++ {
+		h.Observe(3 * time.Millisecond)
+	}
+	for i := 0; i < 10; i++ {
+		h.Observe(100 * time.Millisecond)
+	}
+	require.Equal(uint64(100), h.Count())
+	require.Equal(4*time.Millisecond, h.Percentile(50))
+	require.Equal(4*time.Millisecond, h.Percentile(90))
+	// the max is more accurate than the bound of its bucket
+	require.Equal(100*time.Millisecond, h.Percentile(99))
+	h.Observe(time.Minute)
+	require.Equal(time.Minute, h.Percentile(100))
+	require.Contains(h.String(), "p99")
+}
+
+func TestInjector(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	var (
+		mu       sync.Mutex
+		pending  = map[string]uint64{}
+		failOnce = true
+	)
+	api.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+		ChainMeta: &iotextypes.ChainMeta{ChainID: 1},
+	}, nil)
+	api.EXPECT().GetAccount(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *iotexapi.GetAccountRequest, _ ...grpc.CallOption) (*iotexapi.GetAccountResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if _, ok := pending[req.Address]; !ok {
+				pending[req.Address] = 5
+			}
+			return &iotexapi.GetAccountResponse{
+				AccountMeta: &iotextypes.AccountMeta{Address: req.Address, PendingNonce: pending[req.Address]},
+			}, nil
+		}).MinTimes(3)
+	api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *iotexapi.SendActionRequest, _ ...grpc.CallOption) (*iotexapi.SendActionResponse, error) {
+			pk, err := crypto.BytesToPublicKey(req.Action.SenderPubKey)
+			if err != nil {
+				return nil, err
+			}
+			addr := pk.Address().String()
+			mu.Lock()
+			defer mu.Unlock()
+			if failOnce {
+				failOnce = false
+				return nil, errors.New("network error")
+			}
+			if req.Action.Core.Nonce != pending[addr] {
+				return nil, errors.Errorf("invalid nonce %d, expecting %d", req.Action.Core.Nonce, pending[addr])
+			}
+			if req.Action.Core.GetTransfer() == nil {
+				return nil, errors.New("not a transfer")
+			}
+			pending[addr]++
+			return &iotexapi.SendActionResponse{}, nil
+		}).AnyTimes()
+	api.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(&iotexapi.GetReceiptByActionResponse{
+		ReceiptInfo: &iotexapi.ReceiptInfo{Receipt: &iotextypes.Receipt{Status: 1}},
+	}, nil).AnyTimes()
+
+	cfg := DefaultConfig
+	cfg.TPS = 200
+	cfg.Duration = 200 * time.Millisecond
+	cfg.Workers = 4
+	cfg.Confirm = true
+	inj, err := newInjector(cfg, api, []crypto.PrivateKey{identityset.PrivateKey(1), identityset.PrivateKey(2)})
+	require.NoError(err)
+	report, err := inj.Run(context.Background())
+	require.NoError(err)
+	require.Equal(uint64(1), report.Failed)
+	require.NotZero(report.Sent)
+	require.Equal(report.Sent, report.SendLatency.Count())
+	require.Equal(report.Sent, report.ConfirmLatency.Count())
+	// the nonces are contiguous after the failed send
+	mu.Lock()
+	var sent uint64
+	for _, nonce := range pending {
+		sent += nonce - 5
+	}
+	mu.Unlock()
+	require.Equal(report.Sent, sent)
+
+	cfg.Mix = Mix{Stake: 1}
+	_, err = newInjector(cfg, api, []crypto.PrivateKey{identityset.PrivateKey(1)})
+	require.Error(err)
+	cfg.Mix = Mix{Execution: 1}
+	cfg.Contract = "invalid"
+	_, err = newInjector(cfg, api, []crypto.PrivateKey{identityset.PrivateKey(1)})
+	require.Error(err)
+}
@@ -271,6 +271,16 @@ func (c *client) AddressWithDefaultIfNotExist(in string) (string, error) {
 }
 
 func (c *client) Address(in string) (string, error) {
+	switch {
+	case strings.HasPrefix(in, util.DIDPrefix):
+		return util.ResolveDID(in)
+	case util.IsINSName(in):
+		cli, err := c.APIServiceClient()
+		if err != nil {
+			return "", err
+		}
+		return util.ResolveINS(context.Background(), cli, c.cfg.InsResolverContract, in)
+	}
 	if len(in) >= validator.IoAddrLen {
 		if err := validator.ValidateAddress(in); err != nil {
 			return "", err
@@ -76,6 +76,7 @@ func init() {
 	AccountCmd.AddCommand(_accountEthaddrCmd)
 	AccountCmd.AddCommand(_accountExportCmd)
 	AccountCmd.AddCommand(_accountExportPublicCmd)
+	AccountCmd.AddCommand(_accountGenerateCmd)
 	AccountCmd.AddCommand(_accountImportCmd)
 	AccountCmd.AddCommand(_accountInfoCmd)
 	AccountCmd.AddCommand(_accountListCmd)
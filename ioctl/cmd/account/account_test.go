@@ -8,7 +8,9 @@ package account
 import (
 	"crypto/ecdsa"
 	"math/rand"
+	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
@@ -89,3 +91,29 @@ func TestAccount(t *testing.T) {
 	r.NoError(err)
 	r.Equal(sk.PublicKey().Hash(), account.Address.Bytes())
 }
+
+func TestGenerateKeystoreAccounts(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	passwd := "3dj,<>@@SF{}rj0ZF#"
+	accounts, err := generateKeystoreAccounts(dir, 3, passwd, keystore.LightScryptN, keystore.LightScryptP)
+	r.NoError(err)
+	r.Len(accounts, 3)
+	for _, a := range accounts {
+		keyJSON, err := os.ReadFile(a.Keystore)
+		r.NoError(err)
+		key, err := keystore.DecryptKey(keyJSON, passwd)
+		r.NoError(err)
+		addr, err := address.FromBytes(key.Address.Bytes())
+		r.NoError(err)
+		r.Equal(a.Address, addr.String())
+		r.Equal(a.EthAddress, addr.Hex())
+	}
+
+	message := generateMessage{Accounts: accounts}
+	lines := strings.Split(message.String(), "\n")
+	r.Len(lines, 4)
+	r.Equal("address,ethAddress,keystore", lines[0])
+	r.Equal(strings.Join([]string{accounts[0].Address, accounts[0].EthAddress, accounts[0].Keystore}, ","), lines[1])
+}
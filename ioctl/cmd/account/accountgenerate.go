@@ -0,0 +1,164 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"crypto/ecdsa"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/flag"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_generateCmdUses = map[config.Language]string{
+		config.English: "generate --keystore DIR [--count N] [-P PASSWORD]",
+		config.Chinese: "generate --keystore 目录 [--count 数量] [-P 密码]",
+	}
+	_generateCmdShorts = map[config.Language]string{
+		config.English: "Generate N new accounts into encrypted keystore files and print their addresses in csv",
+		config.Chinese: "生成 N 个新账户并保存为加密的keystore文件，以csv格式打印其地址",
+	}
+	_flagGenerateCountUsages = map[config.Language]string{
+		config.English: "number of accounts to generate",
+		config.Chinese: "生成账户的数量",
+	}
+	_flagGenerateKeystoreUsages = map[config.Language]string{
+		config.English: "directory to write the keystore files into",
+		config.Chinese: "写入keystore文件的目录",
+	}
+)
+
+var (
+	_generateCountFlag    = flag.NewUint64VarP("count", "", 1, config.TranslateInLang(_flagGenerateCountUsages, config.UILanguage))
+	_generateKeystoreFlag = flag.NewStringVarP("keystore", "", "", config.TranslateInLang(_flagGenerateKeystoreUsages, config.UILanguage))
+)
+
+// _accountGenerateCmd represents the account generate command
+var _accountGenerateCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_generateCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_generateCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := accountGenerate()
+		return output.PrintError(err)
+	},
+}
+
+type generateMessage struct {
+	Accounts []keystoreAccount `json:"accounts"`
+}
+
+type keystoreAccount struct {
+	Address    string `json:"address"`
+	EthAddress string `json:"ethAddress"`
+	Keystore   string `json:"keystore"`
+}
+
+func init() {
+	_generateCountFlag.RegisterCommand(_accountGenerateCmd)
+	_generateKeystoreFlag.RegisterCommand(_accountGenerateCmd)
+	RegisterPasswordFlag(_accountGenerateCmd)
+}
+
+func accountGenerate() error {
+	if CryptoSm2 {
+		return output.NewError(output.FlagError, "sm2 key cannot be written into keystore file", nil)
+	}
+	dir := _generateKeystoreFlag.Value().(string)
+	if dir == "" {
+		return output.NewError(output.FlagError, "keystore directory is required, set it by --keystore", nil)
+	}
+	count := _generateCountFlag.Value().(uint64)
+	if count == 0 {
+		return output.NewError(output.FlagError, "count must be positive", nil)
+	}
+	password := PasswordByFlag()
+	if password == "" {
+		output.PrintQuery("Set password of the keystore files\n")
+		var err error
+		if password, err = util.ReadSecretFromStdin(); err != nil {
+			return output.NewError(output.InputError, "failed to get password", err)
+		}
+		output.PrintQuery("Enter password again\n")
+		passwordAgain, err := util.ReadSecretFromStdin()
+		if err != nil {
+			return output.NewError(output.InputError, "failed to get password", err)
+		}
+		if password != passwordAgain {
+			return output.NewError(output.ValidationError, ErrPasswdNotMatch.Error(), nil)
+		}
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return output.NewError(output.WriteFileError, "failed to create keystore directory", err)
+	}
+	accounts, err := generateKeystoreAccounts(dir, count, password, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return err
+	}
+	message := generateMessage{Accounts: accounts}
+	fmt.Println(message.String())
+	return nil
+}
+
+// generateKeystoreAccounts generates the accounts, each encrypted by the password into a keystore file in the dir
+func generateKeystoreAccounts(dir string, count uint64, password string, scryptN, scryptP int) ([]keystoreAccount, error) {
+	ks := keystore.NewKeyStore(dir, scryptN, scryptP)
+	accounts := make([]keystoreAccount, 0, count)
+	for i := uint64(0); i < count; i++ {
+		private, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, output.NewError(output.CryptoError, "failed to generate new private key", err)
+		}
+		addr := private.PublicKey().Address()
+		if addr == nil {
+			private.Zero()
+			return nil, output.NewError(output.ConvertError, "failed to convert public key into address", nil)
+		}
+		sk, ok := private.EcdsaPrivateKey().(*ecdsa.PrivateKey)
+		if !ok {
+			private.Zero()
+			return nil, output.NewError(output.CryptoError, "invalid private key", nil)
+		}
+		account, err := ks.ImportECDSA(sk, password)
+		private.Zero()
+		if err != nil {
+			return nil, output.NewError(output.KeystoreError, "failed to import private key into keystore", err)
+		}
+		accounts = append(accounts, keystoreAccount{
+			Address:    addr.String(),
+			EthAddress: addr.Hex(),
+			Keystore:   account.URL.Path,
+		})
+	}
+	return accounts, nil
+}
+
+func (m *generateMessage) String() string {
+	if output.Format == "" {
+		var sb strings.Builder
+		w := csv.NewWriter(&sb)
+		_ = w.Write([]string{"address", "ethAddress", "keystore"})
+		for _, a := range m.Accounts {
+			_ = w.Write([]string{a.Address, a.EthAddress, a.Keystore})
+		}
+		w.Flush()
+		return strings.TrimSuffix(sb.String(), "\n")
+	}
+	return output.FormatString(output.Result, m)
+}
@@ -47,12 +47,15 @@ var (
 	}
 )
 
-const _defaultGasLimit = uint64(20000000)
+const (
+	_defaultGasLimit = uint64(20000000)
+	_defaultGasPrice = "1"
+)
 
 // Flags
 var (
 	_gasLimitFlag = flag.NewUint64VarP("gas-limit", "l", _defaultGasLimit, "set gas limit")
-	_gasPriceFlag = flag.NewStringVarP("gas-price", "p", "1", "set gas price (unit: 10^(-6)IOTX), use suggested gas price if input is \"0\"")
+	_gasPriceFlag = flag.NewStringVarP("gas-price", "p", _defaultGasPrice, "set gas price (unit: 10^(-6)IOTX), use suggested gas price if input is \"0\"")
 	_nonceFlag    = flag.NewUint64VarP("nonce", "n", 0, "set nonce (default using pending nonce)")
 	_signerFlag   = flag.NewStringVarP("signer", "s", "", "choose a signing account")
 	_bytecodeFlag = flag.NewStringVarP("bytecode", "b", "", "set the byte code")
@@ -87,6 +90,7 @@ func init() {
 	ActionCmd.AddCommand(_actionClaimCmd)
 	ActionCmd.AddCommand(_actionDepositCmd)
 	ActionCmd.AddCommand(_actionSendRawCmd)
+	ActionCmd.AddCommand(_actionDecodeCmd)
 	ActionCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
 		config.ReadConfig.Endpoint, config.TranslateInLang(_flagActionEndPointUsages,
 			config.UILanguage))
@@ -140,12 +144,24 @@ func RegisterWriteCommand(cmd *cobra.Command) {
 	account.RegisterPasswordFlag(cmd)
 }
 
+// gasLimitValue returns the gas limit set by -l, or the one in the config if -l is not set
+func gasLimitValue() uint64 {
+	gasLimit := _gasLimitFlag.Value().(uint64)
+	if gasLimit == _defaultGasLimit && config.ReadConfig.GasLimit != 0 {
+		return config.ReadConfig.GasLimit
+	}
+	return gasLimit
+}
+
 // gasPriceInRau returns the suggest gas price
 func gasPriceInRau() (*big.Int, error) {
 	if account.CryptoSm2 {
 		return big.NewInt(0), nil
 	}
 	gasPrice := _gasPriceFlag.Value().(string)
+	if gasPrice == _defaultGasPrice && config.ReadConfig.GasPrice != "" {
+		gasPrice = config.ReadConfig.GasPrice
+	}
 	if len(gasPrice) != 0 {
 		return util.StringToRau(gasPrice, util.GasPriceDecimalNum)
 	}
@@ -245,6 +261,18 @@ func SendRawAndRespond(selp *iotextypes.Action) (*iotexapi.SendActionResponse, e
 	return response, nil
 }
 
+// chainID returns the chain ID in the config, or the one of the endpoint if not set
+func chainID() (uint32, error) {
+	if config.ReadConfig.ChainID != 0 {
+		return config.ReadConfig.ChainID, nil
+	}
+	chainMeta, err := bc.GetChainMeta()
+	if err != nil {
+		return 0, output.NewError(0, "failed to get chain meta", err)
+	}
+	return chainMeta.GetChainID(), nil
+}
+
 // SendAction sends signed action to blockchain
 func SendAction(elp action.Envelope, signer string) error {
 	resp, err := SendActionAndResponse(elp, signer)
@@ -262,11 +290,11 @@ func SendActionAndResponse(elp action.Envelope, signer string) (*iotexapi.SendAc
 		return nil, err
 	}
 
-	chainMeta, err := bc.GetChainMeta()
+	chainID, err := chainID()
 	if err != nil {
-		return nil, output.NewError(0, "failed to get chain meta", err)
+		return nil, err
 	}
-	elp.SetChainID(chainMeta.GetChainID())
+	elp.SetChainID(chainID)
 
 	if util.AliasIsHdwalletKey(signer) {
 		addr := prvKey.PublicKey().Address()
@@ -338,7 +366,7 @@ func ExecuteAndResponse(contract string, amount *big.Int, bytecode []byte) (*iot
 	if err != nil {
 		return nil, output.NewError(0, "failed to get nonce", err)
 	}
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	tx := action.NewExecution(contract, amount, bytecode)
 	if gasLimit == 0 {
 		gasLimit, err = fixGasLimit(signer, tx)
@@ -383,7 +411,7 @@ func Read(contract address.Address, amount string, bytecode []byte) (string, err
 				Data:     bytecode,
 			},
 			CallerAddress: callerAddr,
-			GasLimit:      _gasLimitFlag.Value().(uint64),
+			GasLimit:      gasLimitValue(),
 		},
 	)
 	if err == nil {
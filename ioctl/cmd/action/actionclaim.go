@@ -6,6 +6,7 @@
 package action
 
 import (
+	"encoding/hex"
 	"math/big"
 
 	"github.com/pkg/errors"
@@ -16,6 +17,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/ioctl/config"
 	"github.com/iotexproject/iotex-core/v2/ioctl/flag"
 	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
 )
 
 // Multi-language support
@@ -25,16 +27,17 @@ var (
 		config.Chinese: "从奖励基金中获取奖励",
 	}
 	_claimCmdUses = map[config.Language]string{
-		config.English: "claim --amount AMOUNT_IOTX [-address ACCOUNT_REWARD_TO] [--payload DATA] [-s SIGNER] [-n NONCE] [-l GAS_LIMIT] [-p GAS_PRICE] [-P PASSWORD] [-y]",
-		config.Chinese: "claim --amount IOTX数量 [--address 获取奖励的账户地址] [--payload 数据] [-s 签署人] [-n NONCE] [-l GAS限制] [-p GAS价格] [-P 密码] [-y]",
+		config.English: "claim --amount AMOUNT_IOTX [-address ACCOUNT_REWARD_TO] [--payload DATA|--calldata CALLDATA] [-s SIGNER] [-n NONCE] [-l GAS_LIMIT] [-p GAS_PRICE] [-P PASSWORD] [-y]",
+		config.Chinese: "claim --amount IOTX数量 [--address 获取奖励的账户地址] [--payload 数据|--calldata 调用数据] [-s 签署人] [-n NONCE] [-l GAS限制] [-p GAS价格] [-P 密码] [-y]",
 	}
 )
 
 // flags
 var (
-	claimAmount  = flag.NewStringVarP("amount", "", "", config.TranslateInLang(_flagClaimAmount, config.UILanguage))
-	claimPayload = flag.NewStringVarP("payload", "", "", config.TranslateInLang(_flagClaimPayload, config.UILanguage))
-	claimAddress = flag.NewStringVarP("address", "", "", config.TranslateInLang(_flagClaimAddress, config.UILanguage))
+	claimAmount   = flag.NewStringVarP("amount", "", "", config.TranslateInLang(_flagClaimAmount, config.UILanguage))
+	claimPayload  = flag.NewStringVarP("payload", "", "", config.TranslateInLang(_flagClaimPayload, config.UILanguage))
+	claimAddress  = flag.NewStringVarP("address", "", "", config.TranslateInLang(_flagClaimAddress, config.UILanguage))
+	claimCalldata = flag.NewStringVarP("calldata", "", "", config.TranslateInLang(_flagClaimCalldata, config.UILanguage))
 )
 
 // flag multi-language
@@ -51,6 +54,11 @@ var (
 		config.English: "address of claim reward to, default is the action sender address",
 		config.Chinese: "获取奖励的账户地址, 默认使用action发送者地址",
 	}
+	_flagClaimCalldata = map[config.Language]string{
+		config.English: "hex calldata executed on the contract set by --address after the reward is claimed, " +
+			"e.g., to distribute the reward by a payout contract",
+		config.Chinese: "获取奖励后在 --address 指定的合约上执行的十六进制调用数据，例如由分配合约分发奖励",
+	}
 )
 
 // _actionClaimCmd represents the action claim command
@@ -73,7 +81,17 @@ var _actionClaimCmd = &cobra.Command{
 				return output.PrintError(errors.Errorf("invalid claim address: %s", err.Error()))
 			}
 		}
-		err = claim(amount, claimPayload.Value().(string), addr)
+		payload := []byte(claimPayload.Value().(string))
+		if s := claimCalldata.Value().(string); len(s) > 0 {
+			if addr == nil || len(payload) > 0 {
+				return output.PrintError(errors.New("calldata requires the contract address and cannot be used with payload"))
+			}
+			payload, err = hex.DecodeString(util.TrimHexPrefix(s))
+			if err != nil {
+				return output.PrintError(errors.Errorf("invalid calldata: %s", err.Error()))
+			}
+		}
+		err = claim(amount, payload, addr)
 		return output.PrintError(err)
 	},
 }
@@ -82,13 +100,14 @@ func init() {
 	claimAmount.RegisterCommand(_actionClaimCmd)
 	claimPayload.RegisterCommand(_actionClaimCmd)
 	claimAddress.RegisterCommand(_actionClaimCmd)
+	claimCalldata.RegisterCommand(_actionClaimCmd)
 
 	_ = _actionClaimCmd.MarkFlagRequired("amount")
 
 	RegisterWriteCommand(_actionClaimCmd)
 }
 
-func claim(amount *big.Int, payload string, address address.Address) error {
+func claim(amount *big.Int, payload []byte, address address.Address) error {
 	if amount.Cmp(new(big.Int).SetInt64(0)) < 1 {
 		return output.PrintError(errors.Errorf("expect amount greater than 0, but got: %v", amount.Int64()))
 	}
@@ -96,7 +115,7 @@ func claim(amount *big.Int, payload string, address address.Address) error {
 	if err != nil {
 		return output.NewError(output.AddressError, "failed to get signer address", err)
 	}
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		gasLimit = action.ClaimFromRewardingFundBaseGas +
 			action.ClaimFromRewardingFundGasPerByte*uint64(len(payload))
@@ -109,7 +128,7 @@ func claim(amount *big.Int, payload string, address address.Address) error {
 	if err != nil {
 		return output.NewError(0, "failed to get nonce", err)
 	}
-	act := action.NewClaimFromRewardingFund(amount, address, []byte(payload))
+	act := action.NewClaimFromRewardingFund(amount, address, payload)
 	return SendAction((&action.EnvelopeBuilder{}).SetNonce(nonce).
 		SetGasPrice(gasPriceRau).
 		SetGasLimit(gasLimit).
@@ -0,0 +1,275 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/flag"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_decodeCmdShorts = map[config.Language]string{
+		config.English: "Decode an action into a human-readable breakdown",
+		config.Chinese: "将交易解码为可读的明细",
+	}
+	_decodeCmdUses = map[config.Language]string{
+		config.English: "decode (ACTION_HASH|SERIALIZED_ACTION|RAW_ETH_TX) [--abi ABI_FILE]",
+		config.Chinese: "decode (交易哈希|序列化的交易|以太坊原始交易) [--abi ABI文件]",
+	}
+	_flagDecodeAbiUsages = map[config.Language]string{
+		config.English: "abi file to decode the calldata, default is the xrc20 abi",
+		config.Chinese: "用于解码调用数据的abi文件，默认为xrc20 abi",
+	}
+)
+
+var _decodeAbiFlag = flag.NewStringVarP("abi", "", "", config.TranslateInLang(_flagDecodeAbiUsages, config.UILanguage))
+
+// _actionDecodeCmd represents the action decode command
+var _actionDecodeCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_decodeCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_decodeCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := decode(args[0])
+		return output.PrintError(err)
+	},
+}
+
+type calldataMessage struct {
+	Method    string            `json:"method"`
+	Arguments map[string]string `json:"arguments"`
+	// args keeps the order of the arguments
+	args []string
+}
+
+type decodeMessage struct {
+	Hash     string             `json:"hash"`
+	Proto    *iotextypes.Action `json:"proto"`
+	Calldata *calldataMessage   `json:"calldata,omitempty"`
+}
+
+func (m *decodeMessage) String() string {
+	if output.Format == "" {
+		message, err := printActionProto(m.Proto)
+		if err != nil {
+			return err.Error()
+		}
+		message += fmt.Sprintf("actHash: %s\n", m.Hash)
+		if m.Calldata != nil {
+			message += fmt.Sprintf("calldata: <\n  method: %s\n", m.Calldata.Method)
+			for _, arg := range m.Calldata.args {
+				message += fmt.Sprintf("  %s: %s\n", arg, m.Calldata.Arguments[arg])
+			}
+			message += ">\n"
+		}
+		return message
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func init() {
+	_decodeAbiFlag.RegisterCommand(_actionDecodeCmd)
+}
+
+// decode decodes the action by the hash on chain, or the serialized action, or the raw ethereum transaction
+func decode(arg string) error {
+	data, err := hex.DecodeString(util.TrimHexPrefix(arg))
+	if err != nil {
+		return output.NewError(output.ConvertError, "failed to decode hex", err)
+	}
+	var (
+		act *iotextypes.Action
+		h   string
+	)
+	if len(data) == len(hash.ZeroHash256) {
+		h = hex.EncodeToString(data)
+		if act, err = actionOnChain(h); err != nil {
+			return err
+		}
+	} else if act, h, err = decodeRawAction(data); err != nil {
+		return err
+	}
+	message := decodeMessage{Hash: h, Proto: act}
+	if execution := act.GetCore().GetExecution(); execution != nil && len(execution.Data) >= 4 {
+		if message.Calldata, err = decodeCalldata(execution.Data); err != nil {
+			return err
+		}
+	}
+	fmt.Println(message.String())
+	return nil
+}
+
+func actionOnChain(h string) (*iotextypes.Action, error) {
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return nil, output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+	response, err := iotexapi.NewAPIServiceClient(conn).GetActions(ctx, &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_ByHash{
+			ByHash: &iotexapi.GetActionByHashRequest{
+				ActionHash:   h,
+				CheckPending: true,
+			},
+		},
+	})
+	if err != nil {
+		if sta, ok := status.FromError(err); ok {
+			return nil, output.NewError(output.APIError, sta.Message(), nil)
+		}
+		return nil, output.NewError(output.NetworkError, "failed to invoke GetActions api", err)
+	}
+	if len(response.ActionInfo) == 0 {
+		return nil, output.NewError(output.APIError, "no action info returned", nil)
+	}
+	return response.ActionInfo[0].Action, nil
+}
+
+// decodeRawAction decodes the raw ethereum transaction, or the serialized action if it is not, and returns the
+// action and its hash
+func decodeRawAction(data []byte) (*iotextypes.Action, string, error) {
+	tx := types.Transaction{}
+	if err := tx.UnmarshalBinary(data); err == nil {
+		act, err := ethTxToActionProto(&tx)
+		if err != nil {
+			return nil, "", err
+		}
+		return act, hex.EncodeToString(tx.Hash().Bytes()), nil
+	}
+	act := &iotextypes.Action{}
+	if err := proto.Unmarshal(data, act); err != nil || act.Core == nil {
+		return nil, "", output.NewError(output.SerializationError, "data is neither a serialized action nor a raw ethereum transaction", err)
+	}
+	// the evm network id only matters to the hash of the action signed in ethereum encoding
+	selp, err := (&action.Deserializer{}).SetEvmNetworkID(uint32(_evmNetworkIDs[act.Core.ChainID])).ActionToSealedEnvelope(act)
+	if err != nil {
+		return nil, "", output.NewError(output.SerializationError, "failed to deserialize action", err)
+	}
+	h, err := selp.Hash()
+	if err != nil {
+		return nil, "", output.NewError(output.SerializationError, "failed to hash action", err)
+	}
+	return act, hex.EncodeToString(h[:]), nil
+}
+
+// ethTxToActionProto converts the ethereum transaction into the action it is executed as
+func ethTxToActionProto(tx *types.Transaction) (*iotextypes.Action, error) {
+	encoding, sig, pubkey, err := action.ExtractTypeSigPubkey(tx)
+	if err != nil {
+		return nil, output.NewError(output.CryptoError, "failed to recover the sender", err)
+	}
+	elp, err := action.StakingRewardingTxToEnvelope(0, tx)
+	if err != nil {
+		return nil, output.NewError(output.ConvertError, "failed to decode staking or rewarding action", err)
+	}
+	var core *iotextypes.ActionCore
+	if elp != nil {
+		core = elp.Proto()
+	} else {
+		core = &iotextypes.ActionCore{
+			Version:  1,
+			TxType:   uint32(tx.Type()),
+			Nonce:    tx.Nonce(),
+			GasLimit: tx.Gas(),
+			GasPrice: tx.GasPrice().String(),
+		}
+		if tx.Type() >= types.DynamicFeeTxType {
+			core.GasFeeCap, core.GasTipCap = tx.GasFeeCap().String(), tx.GasTipCap().String()
+		}
+		var to string
+		if tx.To() != nil {
+			addr, err := address.FromBytes(tx.To().Bytes())
+			if err != nil {
+				return nil, output.NewError(output.AddressError, "invalid recipient", err)
+			}
+			to = addr.String()
+		}
+		if to == "" || len(tx.Data()) > 0 {
+			core.Action = &iotextypes.ActionCore_Execution{Execution: &iotextypes.Execution{
+				Amount:   tx.Value().String(),
+				Contract: to,
+				Data:     tx.Data(),
+			}}
+		} else {
+			core.Action = &iotextypes.ActionCore_Transfer{Transfer: &iotextypes.Transfer{
+				Amount:    tx.Value().String(),
+				Recipient: to,
+			}}
+		}
+	}
+	return &iotextypes.Action{
+		Core:         core,
+		SenderPubKey: pubkey.Bytes(),
+		Signature:    sig,
+		Encoding:     encoding,
+	}, nil
+}
+
+// decodeCalldata decodes the calldata by the method in the abi of the --abi flag, or in the xrc20 abi
+func decodeCalldata(data []byte) (*calldataMessage, error) {
+	contractABI := &_xrc20ABI
+	if abiFile := _decodeAbiFlag.Value().(string); abiFile != "" {
+		abiBytes, err := os.ReadFile(filepath.Clean(abiFile))
+		if err != nil {
+			return nil, output.NewError(output.ReadFileError, "failed to read abi file", err)
+		}
+		parsed, err := abi.JSON(strings.NewReader(string(abiBytes)))
+		if err != nil {
+			return nil, output.NewError(output.SerializationError, "failed to parse abi", err)
+		}
+		contractABI = &parsed
+	}
+	method, err := contractABI.MethodById(data[:4])
+	if err != nil {
+		// calldata of a method not in the abi is left as it is
+		return nil, nil
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, output.NewError(output.ConvertError, "failed to unpack calldata of "+method.Sig, err)
+	}
+	message := &calldataMessage{
+		Method:    method.Sig,
+		Arguments: make(map[string]string, len(values)),
+	}
+	for i, v := range values {
+		name := method.Inputs[i].Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		message.args = append(message.args, name)
+		message.Arguments[name] = fmt.Sprint(v)
+	}
+	return message, nil
+}
@@ -56,7 +56,7 @@ func deposit(args []string) error {
 	if err != nil {
 		return output.NewError(output.AddressError, "failed to get signer address", err)
 	}
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		gasLimit = action.DepositToRewardingFundBaseGas +
 			action.DepositToRewardingFundGasPerByte*uint64(len(payload))
@@ -12,6 +12,7 @@ import (
 	"log"
 	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	"github.com/iotexproject/go-pkgs/crypto"
@@ -20,7 +21,7 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	protoV1 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/encoding/prototext"
 
 	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/alias"
@@ -259,8 +260,7 @@ func printActionProto(act *iotextypes.Action) (string, error) {
 		result += "  >\n" +
 			">\n"
 	default:
-		bs, _ := protoV1.Marshal(core)
-		result += string(bs)
+		result += printActionOneof(core)
 	}
 	result += fmt.Sprintf("senderPubKey: %x\n", act.SenderPubKey) +
 		fmt.Sprintf("signature: %x\n", act.Signature)
@@ -268,6 +268,25 @@ func printActionProto(act *iotextypes.Action) (string, error) {
 	return result, nil
 }
 
+// printActionOneof prints the fields of the action set in the core, e.g., a staking action
+func printActionOneof(core *iotextypes.ActionCore) string {
+	m := core.ProtoReflect()
+	oneofs := m.Descriptor().Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		fd := m.WhichOneof(oneofs.Get(i))
+		if fd == nil || fd.Message() == nil {
+			continue
+		}
+		text := prototext.MarshalOptions{Multiline: true}.Format(m.Get(fd).Message().Interface())
+		result := fmt.Sprintf("%s: <\n", fd.JSONName())
+		for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+			result += "  " + line + "\n"
+		}
+		return result + ">\n"
+	}
+	return ""
+}
+
 func printReceiptProto(receipt *iotextypes.Receipt) string {
 	result := fmt.Sprintf("status: %d %s\n", receipt.Status,
 		Match(strconv.Itoa(int(receipt.Status)), "status")) +
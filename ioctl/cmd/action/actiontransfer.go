@@ -75,7 +75,7 @@ func transfer(args []string) error {
 	if err != nil {
 		return output.NewError(output.AddressError, "failed to get signed address", err)
 	}
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		gasLimit = action.TransferBaseIntrinsicGas +
 			action.TransferPayloadGas*uint64(len(payload))
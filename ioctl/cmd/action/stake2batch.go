@@ -0,0 +1,309 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/account"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_stake2BatchCmdUses = map[config.Language]string{
+		config.English: "batch FILE" +
+			" [-s SIGNER] [-n NONCE] [-l GAS_LIMIT] [-p GAS_PRICE] [-P PASSWORD] [-y]",
+		config.Chinese: "batch 文件" +
+			" [-s 签署人] [-n NONCE] [-l GAS限制] [-p GAS价格] [-P 密码] [-y]",
+	}
+	_stake2BatchCmdShorts = map[config.Language]string{
+		config.English: "Submit a batch of bucket operations (unstake, withdraw, change) from a CSV or JSON file",
+		config.Chinese: "从CSV或JSON文件批量提交投票操作（撤回、提取、改变候选人）",
+	}
+	_stake2BatchCmdLongs = map[config.Language]string{
+		config.English: "Submit a batch of bucket operations from a CSV or JSON file, signed by the same signer with " +
+			"consecutive nonces.\n\n" +
+			"A CSV file has one operation per line, as OP,BUCKET_INDEX[,CANDIDATE_NAME][,DATA], where OP is one of " +
+			"unstake, withdraw and change, e.g.\n" +
+			"  unstake,12\n" +
+			"  withdraw,13\n" +
+			"  change,34,robotbp00001\n\n" +
+			"A JSON file (*.json) is an array of operations, e.g.\n" +
+			"  [{\"op\":\"unstake\",\"bucket\":12},{\"op\":\"change\",\"bucket\":34,\"candidate\":\"robotbp00001\"}]\n\n" +
+			"The gas limit of each action is estimated from its type unless set by -l, and the nonce of the first " +
+			"action is the pending nonce of the signer unless set by -n. A summary of all the operations is printed " +
+			"at the end.",
+		config.Chinese: "从CSV或JSON文件批量提交投票操作，由同一签署人以连续的nonce签署。\n\n" +
+			"CSV文件每行一个操作，格式为 操作,票索引[,候选人名字][,数据]，操作为 unstake、withdraw 或 change，例如\n" +
+			"  unstake,12\n" +
+			"  withdraw,13\n" +
+			"  change,34,robotbp00001\n\n" +
+			"JSON文件（*.json）为操作数组，例如\n" +
+			"  [{\"op\":\"unstake\",\"bucket\":12},{\"op\":\"change\",\"bucket\":34,\"candidate\":\"robotbp00001\"}]\n\n" +
+			"除非使用 -l 设置，每个操作的GAS限制根据其类型估算；除非使用 -n 设置，第一个操作的nonce为签署人的pending nonce。" +
+			"所有操作的汇总在最后输出。",
+	}
+)
+
+const (
+	_batchOpUnstake  = "unstake"
+	_batchOpWithdraw = "withdraw"
+	_batchOpChange   = "change"
+)
+
+// _stake2BatchCmd represents the stake2 batch command
+var _stake2BatchCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_stake2BatchCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_stake2BatchCmdShorts, config.UILanguage),
+	Long:  config.TranslateInLang(_stake2BatchCmdLongs, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := stake2Batch(args[0])
+		return output.PrintError(err)
+	},
+}
+
+type (
+	// bucketOp is an operation on a bucket in the batch file
+	bucketOp struct {
+		Op        string `json:"op"`
+		Bucket    uint64 `json:"bucket"`
+		Candidate string `json:"candidate,omitempty"`
+		Data      string `json:"data,omitempty"`
+	}
+
+	bucketOpResult struct {
+		Op     string `json:"op"`
+		Bucket uint64 `json:"bucket"`
+		Nonce  uint64 `json:"nonce,omitempty"`
+		Hash   string `json:"hash,omitempty"`
+		Error  string `json:"error,omitempty"`
+	}
+
+	stake2BatchMessage struct {
+		Total     int               `json:"total"`
+		Succeeded int               `json:"succeeded"`
+		Failed    int               `json:"failed"`
+		Results   []*bucketOpResult `json:"results"`
+	}
+)
+
+func init() {
+	RegisterWriteCommand(_stake2BatchCmd)
+}
+
+func (m *stake2BatchMessage) String() string {
+	if output.Format == "" {
+		lines := make([]string, 0, len(m.Results)+1)
+		for i, r := range m.Results {
+			if r.Error != "" {
+				lines = append(lines, fmt.Sprintf("#%d %s bucket %d: failed, %s", i, r.Op, r.Bucket, r.Error))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("#%d %s bucket %d: nonce %d, hash %s", i, r.Op, r.Bucket, r.Nonce, r.Hash))
+		}
+		lines = append(lines, fmt.Sprintf("%d operations in total, %d succeeded, %d failed", m.Total, m.Succeeded, m.Failed))
+		return strings.Join(lines, "\n")
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func stake2Batch(file string) error {
+	ops, err := readBucketOps(file)
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return output.NewError(output.InputError, "no bucket operation in "+file, nil)
+	}
+	gasPriceRau, err := gasPriceInRau()
+	if err != nil {
+		return output.NewError(0, "failed to get gas price", err)
+	}
+	elps := make([]action.Envelope, len(ops))
+	for i, op := range ops {
+		if elps[i], err = bucketOpEnvelope(op, gasPriceRau); err != nil {
+			return output.NewError(output.InputError, fmt.Sprintf("invalid bucket operation #%d", i), err)
+		}
+	}
+
+	signer, err := Signer()
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get signer address", err)
+	}
+	prvKey, err := account.PrivateKeyFromSigner(signer, account.PasswordByFlag())
+	if err != nil {
+		return err
+	}
+	defer prvKey.Zero()
+	if util.AliasIsHdwalletKey(signer) {
+		signer = prvKey.PublicKey().Address().String()
+	}
+	nonce, err := nonce(signer)
+	if err != nil {
+		return output.NewError(0, "failed to get nonce", err)
+	}
+	chainID, err := chainID()
+	if err != nil {
+		return err
+	}
+
+	if !_yesFlag.Value().(bool) {
+		info := fmt.Sprintf("%d bucket operations are to be sent by %s, starting from nonce %d.\n\nPlease confirm your action.\n",
+			len(ops), signer, nonce)
+		confirmed, err := confirmBatch(info)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			output.PrintResult("quit")
+			return nil
+		}
+	}
+
+	message := stake2BatchMessage{Total: len(ops), Results: make([]*bucketOpResult, len(ops))}
+	for i, elp := range elps {
+		result := &bucketOpResult{Op: ops[i].Op, Bucket: ops[i].Bucket}
+		message.Results[i] = result
+		elp.SetNonce(nonce)
+		elp.SetChainID(chainID)
+		sealed, err := action.Sign(elp, prvKey)
+		if err != nil {
+			result.Error = "failed to sign action: " + err.Error()
+			message.Failed++
+			continue
+		}
+		resp, err := SendRawAndRespond(sealed.Proto())
+		if err != nil {
+			// the nonce is not consumed, reuse it for the next operation to avoid the nonce gap
+			result.Error = err.Error()
+			message.Failed++
+			continue
+		}
+		result.Nonce = nonce
+		result.Hash = resp.ActionHash
+		message.Succeeded++
+		nonce++
+	}
+	fmt.Println(message.String())
+	if message.Failed > 0 {
+		return output.NewError(output.APIError, fmt.Sprintf("%d of %d bucket operations failed", message.Failed, message.Total), nil)
+	}
+	return nil
+}
+
+func confirmBatch(info string) (bool, error) {
+	var answer string
+	message := output.ConfirmationMessage{Info: info, Options: []string{"yes"}}
+	fmt.Println(message.String())
+	if _, err := fmt.Scanf("%s", &answer); err != nil {
+		return false, output.NewError(output.InputError, "failed to input yes", err)
+	}
+	return strings.EqualFold(answer, "yes"), nil
+}
+
+// readBucketOps reads the bucket operations from a JSON file if the file has the .json extension, or a CSV file
+// otherwise
+func readBucketOps(file string) ([]*bucketOp, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, output.NewError(output.ReadFileError, "failed to open "+file, err)
+	}
+	defer f.Close()
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		var ops []*bucketOp
+		if err := json.NewDecoder(f).Decode(&ops); err != nil {
+			return nil, output.NewError(output.SerializationError, "failed to decode "+file, err)
+		}
+		return ops, nil
+	}
+	return parseBucketOpsCSV(f)
+}
+
+func parseBucketOpsCSV(r io.Reader) ([]*bucketOp, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, output.NewError(output.SerializationError, "failed to read csv", err)
+	}
+	ops := make([]*bucketOp, 0, len(records))
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "op") {
+			// skip the header
+			continue
+		}
+		if len(record) < 2 {
+			return nil, output.NewError(output.InputError, fmt.Sprintf("too few fields in line %d", i+1), nil)
+		}
+		bucket, err := strconv.ParseUint(record[1], 10, 64)
+		if err != nil {
+			return nil, output.NewError(output.ConvertError, fmt.Sprintf("failed to convert bucket index in line %d", i+1), err)
+		}
+		op := &bucketOp{Op: strings.ToLower(record[0]), Bucket: bucket}
+		rest := record[2:]
+		if op.Op == _batchOpChange && len(rest) > 0 {
+			op.Candidate, rest = rest[0], rest[1:]
+		}
+		if len(rest) > 0 {
+			op.Data = rest[0]
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// bucketOpEnvelope builds the envelope of the operation, the nonce and chain ID are set upon sending
+func bucketOpEnvelope(op *bucketOp, gasPrice *big.Int) (action.Envelope, error) {
+	data, err := hex.DecodeString(util.TrimHexPrefix(op.Data))
+	if err != nil {
+		return nil, output.NewError(output.ConvertError, "failed to decode data", err)
+	}
+	var (
+		bd       = (&action.EnvelopeBuilder{}).SetGasPrice(gasPrice)
+		gasLimit uint64
+	)
+	switch strings.ToLower(op.Op) {
+	case _batchOpUnstake:
+		bd.SetAction(action.NewUnstake(op.Bucket, data))
+		gasLimit = action.ReclaimStakeBaseIntrinsicGas + action.ReclaimStakePayloadGas*uint64(len(data))
+	case _batchOpWithdraw:
+		bd.SetAction(action.NewWithdrawStake(op.Bucket, data))
+		gasLimit = action.ReclaimStakeBaseIntrinsicGas + action.ReclaimStakePayloadGas*uint64(len(data))
+	case _batchOpChange:
+		if !action.IsValidCandidateName(op.Candidate) {
+			return nil, output.NewError(output.ValidationError, "", action.ErrInvalidCanName)
+		}
+		bd.SetAction(action.NewChangeCandidate(op.Candidate, op.Bucket, data))
+		gasLimit = action.MoveStakeBaseIntrinsicGas + action.MoveStakePayloadGas*uint64(len(data))
+	default:
+		return nil, output.NewError(output.InputError, "unknown bucket operation "+op.Op, nil)
+	}
+	// unlike the single operation, the default gas limit is not applied to every action in the batch, as the
+	// balance has to cover the gas of all of them at the same time
+	if limit := gasLimitValue(); limit != 0 && limit != _defaultGasLimit {
+		gasLimit = limit
+	}
+	return bd.SetGasLimit(gasLimit).Build(), nil
+}
@@ -118,7 +118,7 @@ func doEndorsement(bucketIndex uint64, op action.CandidateEndorsementOp) error {
 		return output.NewError(output.AddressError, "failed to get signed address", err)
 	}
 
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		gasLimit = action.CandidateEndorsementBaseIntrinsicGas
 	}
@@ -66,7 +66,7 @@ func stake2Migrate(args []string) error {
 	if err != nil {
 		return output.NewError(0, "failed to get gas price", err)
 	}
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		s2t := action.NewMigrateStake(bucketIndex)
 		gas, err := migrateGasLimit(sender, s2t)
@@ -86,7 +86,7 @@ func stake2Reclaim(args []string) error {
 		return output.NewError(output.InputError, "failed to create reclaim JSON", err)
 	}
 
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		gasLimit = action.MoveStakeBaseIntrinsicGas +
 			action.MoveStakePayloadGas*uint64(len(payload))
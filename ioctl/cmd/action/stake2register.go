@@ -89,7 +89,7 @@ func register(args []string) error {
 		return output.NewError(output.AddressError, "failed to get signed address", err)
 	}
 
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		gasLimit = action.CandidateRegisterBaseIntrinsicGas +
 			action.CandidateRegisterPayloadGas*uint64(len(payload))
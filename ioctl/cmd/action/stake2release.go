@@ -71,7 +71,7 @@ func stake2Release(args []string) error {
 	if err != nil {
 		return output.NewError(0, "failed to get nonce", err)
 	}
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		gasLimit = action.ReclaimStakeBaseIntrinsicGas + action.ReclaimStakePayloadGas*uint64(len(data))
 	}
@@ -73,7 +73,7 @@ func stake2Renew(args []string) error {
 		return output.NewError(output.AddressError, "failed to get signed address", err)
 	}
 
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		gasLimit = action.RestakeBaseIntrinsicGas +
 			action.RestakePayloadGas*uint64(len(payload))
@@ -64,7 +64,7 @@ func stake2TransferOwnership(args []string) error {
 		return output.NewError(output.AddressError, "failed to get signed address", err)
 	}
 
-	gasLimit := _gasLimitFlag.Value().(uint64)
+	gasLimit := gasLimitValue()
 	if gasLimit == 0 {
 		gasLimit = action.CandidateTransferOwnershipBaseIntrinsicGas
 	}
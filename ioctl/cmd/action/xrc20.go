@@ -75,6 +75,9 @@ func init() {
 	Xrc20Cmd.AddCommand(_xrc20TransferFromCmd)
 	Xrc20Cmd.AddCommand(_xrc20ApproveCmd)
 	Xrc20Cmd.AddCommand(_xrc20AllowanceCmd)
+	Xrc20Cmd.AddCommand(_xrc20IncreaseAllowanceCmd)
+	Xrc20Cmd.AddCommand(_xrc20DecreaseAllowanceCmd)
+	Xrc20Cmd.AddCommand(_xrc20PermitCmd)
 	Xrc20Cmd.PersistentFlags().StringVarP(&_xrc20ContractAddress, "contract-address", "c", "",
 		config.TranslateInLang(_flagContractAddressUsages, config.UILanguage))
 	Xrc20Cmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
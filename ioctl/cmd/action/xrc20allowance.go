@@ -14,21 +14,28 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/alias"
 	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/flag"
 	"github.com/iotexproject/iotex-core/v2/ioctl/output"
 )
 
 // Multi-language support
 var (
 	_xrc20AllowanceCmdUses = map[config.Language]string{
-		config.English: "allowance [-s SIGNER] (ALIAS|SPENDER_ADDRESS) -c ALIAS|CONTRACT_ADDRESS ",
-		config.Chinese: "allowance [-s 签署人] (ALIAS|支出者地址) -c 别名|合约地址 ",
+		config.English: "allowance [-s SIGNER] [--owner ALIAS|OWNER_ADDRESS] (ALIAS|SPENDER_ADDRESS) -c ALIAS|CONTRACT_ADDRESS ",
+		config.Chinese: "allowance [-s 签署人] [--owner 别名|所有者地址] (ALIAS|支出者地址) -c 别名|合约地址 ",
 	}
 	_xrc20AllowanceCmdShorts = map[config.Language]string{
 		config.English: "the amount which spender is still allowed to withdraw from owner",
 		config.Chinese: "仍然允许支出者从所有者中提取的金额",
 	}
+	_flagXrc20AllowanceOwnerUsages = map[config.Language]string{
+		config.English: "owner of the allowance, default is the signer",
+		config.Chinese: "额度的所有者，默认为签署人",
+	}
 )
 
+var _xrc20AllowanceOwner = flag.NewStringVarP("owner", "", "", config.TranslateInLang(_flagXrc20AllowanceOwnerUsages, config.UILanguage))
+
 // _xrc20AllowanceCmd represents your signer limited amount on target address
 var _xrc20AllowanceCmd = &cobra.Command{
 	Use:   config.TranslateInLang(_xrc20AllowanceCmdUses, config.UILanguage),
@@ -43,12 +50,17 @@ var _xrc20AllowanceCmd = &cobra.Command{
 
 func init() {
 	RegisterWriteCommand(_xrc20AllowanceCmd)
+	_xrc20AllowanceOwner.RegisterCommand(_xrc20AllowanceCmd)
 }
 
 func allowance(arg string) error {
-	caller, err := Signer()
-	if err != nil {
-		return output.NewError(output.AddressError, "failed to get signer address", err)
+	caller := _xrc20AllowanceOwner.Value().(string)
+	if caller == "" {
+		signer, err := Signer()
+		if err != nil {
+			return output.NewError(output.AddressError, "failed to get signer address", err)
+		}
+		caller = signer
 	}
 	owner, err := alias.EtherAddress(caller)
 	if err != nil {
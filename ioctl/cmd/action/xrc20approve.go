@@ -47,6 +47,11 @@ func init() {
 }
 
 func approve(args []string) error {
+	return changeAllowance("approve", args)
+}
+
+// changeAllowance sets, increases or decreases the allowance of the spender by the method of the contract
+func changeAllowance(method string, args []string) error {
 	spender, err := alias.EtherAddress(args[0])
 	if err != nil {
 		return output.NewError(output.AddressError, "failed to get spender address", err)
@@ -59,7 +64,7 @@ func approve(args []string) error {
 	if err != nil {
 		return output.NewError(0, "failed to parse amount", err)
 	}
-	bytecode, err := _xrc20ABI.Pack("approve", spender, amount)
+	bytecode, err := _xrc20ABI.Pack(method, spender, amount)
 	if err != nil {
 		return output.NewError(output.ConvertError, "cannot generate bytecode from given command", err)
 	}
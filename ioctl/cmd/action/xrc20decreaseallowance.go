@@ -0,0 +1,43 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+)
+
+// Multi-language support
+var (
+	_xrc20DecreaseAllowanceCmdUses = map[config.Language]string{
+		config.English: "decreaseAllowance (ALIAS|SPENDER_ADDRESS) (XRC20_AMOUNT) -c ALIAS|CONTRACT_ADDRESS" +
+			" [-s SIGNER] [-n NONCE] [-l GAS_LIMIT] [-p GAS_PRICE] [-P PASSWORD] [-y]",
+		config.Chinese: "decreaseAllowance (别名|支出者地址) (XRC20数量) -c 别名|合约地址" +
+			" [-s 签署人] [-n NONCE] [-l GAS限制] [-p GAS价格] [-P 密码] [-y]",
+	}
+	_xrc20DecreaseAllowanceCmdShorts = map[config.Language]string{
+		config.English: "Decrease the amount which spender is allowed to withdraw from your account",
+		config.Chinese: "减少允许支出者从您的帐户中提取的金额",
+	}
+)
+
+// _xrc20DecreaseAllowanceCmd decreases the allowance of the spender
+var _xrc20DecreaseAllowanceCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_xrc20DecreaseAllowanceCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_xrc20DecreaseAllowanceCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := changeAllowance("decreaseAllowance", args)
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	RegisterWriteCommand(_xrc20DecreaseAllowanceCmd)
+}
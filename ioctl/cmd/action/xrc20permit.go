@@ -0,0 +1,227 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/account"
+	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/alias"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/flag"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+)
+
+// Multi-language support
+var (
+	_xrc20PermitCmdUses = map[config.Language]string{
+		config.English: "permit (ALIAS|SPENDER_ADDRESS) (XRC20_AMOUNT) -c ALIAS|CONTRACT_ADDRESS" +
+			" [-s SIGNER] [--deadline UNIX_TIME] [--version VERSION] [--evm-network-id ID] [-P PASSWORD]",
+		config.Chinese: "permit (别名|支出者地址) (XRC20数量) -c 别名|合约地址" +
+			" [-s 签署人] [--deadline UNIX时间] [--version 版本] [--evm-network-id ID] [-P 密码]",
+	}
+	_xrc20PermitCmdShorts = map[config.Language]string{
+		config.English: "Sign an EIP-2612 permit, by which anyone can approve the spender on behalf of your account",
+		config.Chinese: "签署EIP-2612许可，任何人可凭此代表您的帐户批准支出者",
+	}
+	_flagXrc20PermitDeadlineUsages = map[config.Language]string{
+		config.English: "unix time after which the permit expires, default is an hour later",
+		config.Chinese: "许可过期的unix时间，默认为一小时后",
+	}
+	_flagXrc20PermitVersionUsages = map[config.Language]string{
+		config.English: "version in the EIP-712 domain of the contract",
+		config.Chinese: "合约EIP-712域中的版本",
+	}
+	_flagXrc20PermitEVMNetworkIDUsages = map[config.Language]string{
+		config.English: "evm network id in the EIP-712 domain, default is the one of the chain of the endpoint",
+		config.Chinese: "EIP-712域中的evm网络ID，默认为端点所在链的ID",
+	}
+)
+
+var (
+	_xrc20PermitDeadline     = flag.NewUint64VarP("deadline", "", 0, config.TranslateInLang(_flagXrc20PermitDeadlineUsages, config.UILanguage))
+	_xrc20PermitVersion      = flag.NewStringVarP("version", "", "1", config.TranslateInLang(_flagXrc20PermitVersionUsages, config.UILanguage))
+	_xrc20PermitEVMNetworkID = flag.NewUint64VarP("evm-network-id", "", 0, config.TranslateInLang(_flagXrc20PermitEVMNetworkIDUsages, config.UILanguage))
+
+	_eip712DomainTypeHash = crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	_permitTypeHash       = crypto.Keccak256([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+	// _evmNetworkIDs are the evm network ids of the mainnet and the testnet
+	_evmNetworkIDs = map[uint32]uint64{1: 4689, 2: 4690}
+)
+
+// _xrc20PermitCmd signs the permit of the spender
+var _xrc20PermitCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_xrc20PermitCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_xrc20PermitCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := permit(args)
+		return output.PrintError(err)
+	},
+}
+
+type permitMessage struct {
+	Owner    string `json:"owner"`
+	Spender  string `json:"spender"`
+	Value    string `json:"value"`
+	Nonce    string `json:"nonce"`
+	Deadline uint64 `json:"deadline"`
+	V        uint8  `json:"v"`
+	R        string `json:"r"`
+	S        string `json:"s"`
+}
+
+func (m *permitMessage) String() string {
+	if output.Format == "" {
+		return fmt.Sprintf("owner: %s\nspender: %s\nvalue: %s\nnonce: %s\ndeadline: %d\nv: %d\nr: %s\ns: %s",
+			m.Owner, m.Spender, m.Value, m.Nonce, m.Deadline, m.V, m.R, m.S)
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func init() {
+	_signerFlag.RegisterCommand(_xrc20PermitCmd)
+	account.RegisterPasswordFlag(_xrc20PermitCmd)
+	_xrc20PermitDeadline.RegisterCommand(_xrc20PermitCmd)
+	_xrc20PermitVersion.RegisterCommand(_xrc20PermitCmd)
+	_xrc20PermitEVMNetworkID.RegisterCommand(_xrc20PermitCmd)
+}
+
+func permit(args []string) error {
+	spender, err := alias.EtherAddress(args[0])
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get spender address", err)
+	}
+	contract, err := xrc20Contract()
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get contract address", err)
+	}
+	amount, err := parseAmount(contract, args[1])
+	if err != nil {
+		return output.NewError(0, "failed to parse amount", err)
+	}
+	signer, err := Signer()
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get signer address", err)
+	}
+	prvKey, err := account.PrivateKeyFromSigner(signer, account.PasswordByFlag())
+	if err != nil {
+		return err
+	}
+	defer prvKey.Zero()
+	owner := common.BytesToAddress(prvKey.PublicKey().Address().Bytes())
+
+	name, err := xrc20Name(contract)
+	if err != nil {
+		return err
+	}
+	nonce, err := xrc20Nonce(contract, owner)
+	if err != nil {
+		return err
+	}
+	evmNetworkID := _xrc20PermitEVMNetworkID.Value().(uint64)
+	if evmNetworkID == 0 {
+		id, err := chainID()
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if evmNetworkID, ok = _evmNetworkIDs[id]; !ok {
+			return output.NewError(output.FlagError, fmt.Sprintf("unknown evm network id of chain %d, set it by --evm-network-id", id), nil)
+		}
+	}
+	deadline := _xrc20PermitDeadline.Value().(uint64)
+	if deadline == 0 {
+		deadline = uint64(time.Now().Add(time.Hour).Unix())
+	}
+
+	domainSeparator := crypto.Keccak256(
+		_eip712DomainTypeHash,
+		crypto.Keccak256([]byte(name)),
+		crypto.Keccak256([]byte(_xrc20PermitVersion.Value().(string))),
+		math.U256Bytes(new(big.Int).SetUint64(evmNetworkID)),
+		common.LeftPadBytes(contract.Bytes(), 32),
+	)
+	structHash := crypto.Keccak256(
+		_permitTypeHash,
+		common.LeftPadBytes(owner.Bytes(), 32),
+		common.LeftPadBytes(spender.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(amount)),
+		math.U256Bytes(new(big.Int).Set(nonce)),
+		math.U256Bytes(new(big.Int).SetUint64(deadline)),
+	)
+	digest := crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash)
+	sig, err := prvKey.Sign(digest)
+	if err != nil {
+		return output.NewError(output.CryptoError, "failed to sign permit", err)
+	}
+	if len(sig) != 65 {
+		return output.NewError(output.CryptoError, "permit is only supported by secp256k1 key", nil)
+	}
+	message := permitMessage{
+		Owner:    owner.Hex(),
+		Spender:  spender.Hex(),
+		Value:    amount.String(),
+		Nonce:    nonce.String(),
+		Deadline: deadline,
+		// the recovery id is offset by 27 for ecrecover
+		V: sig[64] + 27,
+		R: "0x" + hex.EncodeToString(sig[:32]),
+		S: "0x" + hex.EncodeToString(sig[32:64]),
+	}
+	fmt.Println(message.String())
+	return nil
+}
+
+func xrc20Name(contract address.Address) (string, error) {
+	bytecode, err := _xrc20ABI.Pack("name")
+	if err != nil {
+		return "", output.NewError(output.ConvertError, "cannot generate bytecode from given command", err)
+	}
+	result, err := Read(contract, "0", bytecode)
+	if err != nil {
+		return "", output.NewError(0, "failed to read contract", err)
+	}
+	data, err := hex.DecodeString(result)
+	if err != nil {
+		return "", output.NewError(output.ConvertError, "failed to decode name", err)
+	}
+	res, err := _xrc20ABI.Unpack("name", data)
+	if err != nil || len(res) != 1 {
+		return "", output.NewError(output.ConvertError, "failed to unpack name", err)
+	}
+	name, ok := res[0].(string)
+	if !ok {
+		return "", output.NewError(output.ConvertError, "failed to unpack name", nil)
+	}
+	return name, nil
+}
+
+func xrc20Nonce(contract address.Address, owner common.Address) (*big.Int, error) {
+	bytecode, err := _xrc20ABI.Pack("nonces", owner)
+	if err != nil {
+		return nil, output.NewError(output.ConvertError, "cannot generate bytecode from given command", err)
+	}
+	result, err := Read(contract, "0", bytecode)
+	if err != nil {
+		return nil, output.NewError(0, "failed to read contract", err)
+	}
+	nonce, ok := new(big.Int).SetString(result, 16)
+	if !ok {
+		return nil, output.NewError(output.ConvertError, "failed to convert nonce", nil)
+	}
+	return nonce, nil
+}
@@ -61,6 +61,7 @@ func init() {
 	ContractCmd.AddCommand(_contractDeployCmd)
 	ContractCmd.AddCommand(_contractInvokeCmd)
 	ContractCmd.AddCommand(_contractTestCmd)
+	ContractCmd.AddCommand(_contractCallCmd)
 	ContractCmd.AddCommand(_contractShareCmd)
 	ContractCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
 		config.ReadConfig.Endpoint, config.TranslateInLang(_flagEndpointUsages, config.UILanguage))
@@ -197,3 +197,68 @@ func TestParseOutputArgument(t *testing.T) {
 		r.Equal(test.expect, result)
 	}
 }
+
+func TestParseArgumentString(t *testing.T) {
+	r := require.New(t)
+
+	tests := []struct {
+		t      string
+		input  string
+		expect interface{}
+	}{
+		{"bool", "true", true},
+		{"string", "IoTeX", "IoTeX"},
+		{"uint8", "12", uint8(12)},
+		{"uint256", "2346783498523230921101011", bigIntFromString("2346783498523230921101011")},
+		{"address", "io1h8zxmdacge966wp6t90a02ncghaa6eptnftfqr", common.HexToAddress("0xb9c46db7b8464bad383a595fd7aa7845fbdd642b")},
+		{"bytes", "0x0102", []byte{1, 2}},
+		{"uint8[]", "[1,2,3]", []uint8{1, 2, 3}},
+		{"string[2]", `["a","b"]`, [2]string{"a", "b"}},
+	}
+	for _, test := range tests {
+		typ, err := abi.NewType(test.t, "", nil)
+		r.NoError(err)
+		arg, err := parseArgumentString(&typ, test.input)
+		r.NoError(err)
+		r.Equal(test.expect, arg)
+	}
+
+	typ, err := abi.NewType("bool", "", nil)
+	r.NoError(err)
+	_, err = parseArgumentString(&typ, "yes")
+	r.ErrorIs(err, ErrInvalidArg)
+	typ, err = abi.NewType("uint8[]", "", nil)
+	r.NoError(err)
+	_, err = parseArgumentString(&typ, "1,2,3")
+	r.ErrorIs(err, ErrInvalidArg)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	r := require.New(t)
+
+	testAbi, err := parseAbi([]byte(`[{"anonymous":false,"inputs":[` +
+		`{"indexed":true,"name":"from","type":"address"},` +
+		`{"indexed":true,"name":"to","type":"address"},` +
+		`{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`))
+	r.NoError(err)
+	event := testAbi.Events["Transfer"]
+	from := common.HexToAddress("0xb9c46db7b8464bad383a595fd7aa7845fbdd642b")
+	to := common.HexToAddress("0xaa77fbf8596e0de5ce362dbd5ab29599a6c38ac4")
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(100))
+	r.NoError(err)
+	topics := [][]byte{event.ID.Bytes(), common.LeftPadBytes(from.Bytes(), 32), common.LeftPadBytes(to.Bytes(), 32)}
+
+	result, err := decodeEvent(testAbi, topics, data)
+	r.NoError(err)
+	r.Equal("Transfer{from:io1h8zxmdacge966wp6t90a02ncghaa6eptnftfqr to:io14fmlh7zedcx7tn3k9k744v54nxnv8zky86tjhj value:100}", result)
+
+	_, err = decodeEvent(testAbi, [][]byte{common.Hash{}.Bytes()}, nil)
+	r.Error(err)
+	_, err = decodeEvent(testAbi, nil, data)
+	r.ErrorIs(err, ErrInvalidArg)
+}
+
+func bigIntFromString(s string) *big.Int {
+	b, _ := new(big.Int).SetString(s, 10)
+	return b
+}
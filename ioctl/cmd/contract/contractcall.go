@@ -0,0 +1,274 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package contract
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/action"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/flag"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_callCmdUses = map[config.Language]string{
+		config.English: "call (CONTRACT_ADDRESS|ALIAS) --abi ABI_PATH --method METHOD_NAME [AMOUNT_IOTX] " +
+			"[--with-arguments INVOKE_INPUT]",
+		config.Chinese: "call (合约地址|别名) --abi ABI文件路径 --method 函数名 [IOTX数量] [--with-arguments 调用输入]",
+	}
+	_callCmdShorts = map[config.Language]string{
+		config.English: "Call a read-only method of smart contract on IoTeX blockchain, and decode the return values",
+		config.Chinese: "调用IoTeX区块链上智能合约的只读函数，并解码返回值",
+	}
+	_invokeABICmdUses = map[config.Language]string{
+		config.English: "invoke (CONTRACT_ADDRESS|ALIAS) --abi ABI_PATH --method METHOD_NAME [AMOUNT_IOTX] " +
+			"[--with-arguments INVOKE_INPUT] [--wait]",
+		config.Chinese: "invoke (合约地址|别名) --abi ABI文件路径 --method 函数名 [IOTX数量] [--with-arguments 调用输入] [--wait]",
+	}
+	_flagABIUsages = map[config.Language]string{
+		config.English: "set the abi file of the contract",
+		config.Chinese: "设置合约的abi文件",
+	}
+	_flagMethodUsages = map[config.Language]string{
+		config.English: "set the method to call, the arguments are prompted by type unless set by --with-arguments",
+		config.Chinese: "设置调用的函数，除非使用 --with-arguments 设置，参数将按类型逐个提示输入",
+	}
+	_flagWaitUsages = map[config.Language]string{
+		config.English: "wait for the receipt and decode the events",
+		config.Chinese: "等待回执并解码事件",
+	}
+)
+
+// Flags
+var (
+	_abiFlag    = flag.NewStringVar("abi", "", config.TranslateInLang(_flagABIUsages, config.UILanguage))
+	_methodFlag = flag.NewStringVar("method", "", config.TranslateInLang(_flagMethodUsages, config.UILanguage))
+	_waitFlag   = flag.BoolVarP("wait", "", false, config.TranslateInLang(_flagWaitUsages, config.UILanguage))
+)
+
+// _contractCallCmd represents the contract call command
+var _contractCallCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_callCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_callCmdShorts, config.UILanguage),
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := contractCall(args)
+		return output.PrintError(err)
+	},
+}
+
+type invokeResultMessage struct {
+	ActionHash string   `json:"actionHash"`
+	Status     uint64   `json:"status"`
+	Height     uint64   `json:"height"`
+	GasUsed    uint64   `json:"gasUsed"`
+	Events     []string `json:"events"`
+}
+
+func (m *invokeResultMessage) String() string {
+	if output.Format == "" {
+		lines := []string{
+			fmt.Sprintf("action %s is executed in block %d with status %d, gas used %d", m.ActionHash, m.Height, m.Status, m.GasUsed),
+		}
+		for _, e := range m.Events {
+			lines = append(lines, "event: "+e)
+		}
+		return strings.Join(lines, "\n")
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func init() {
+	for _, f := range []flag.Flag{_abiFlag, _methodFlag} {
+		f.RegisterCommand(_contractCallCmd)
+		f.RegisterCommand(_contractInvokeCmd)
+		f.MarkFlagRequired(_contractCallCmd)
+	}
+	_waitFlag.RegisterCommand(_contractInvokeCmd)
+	flag.WithArgumentsFlag.RegisterCommand(_contractCallCmd)
+	flag.WithArgumentsFlag.RegisterCommand(_contractInvokeCmd)
+}
+
+func contractCall(args []string) error {
+	addr, err := util.Address(args[0])
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get contract address", err)
+	}
+	contract, err := address.FromString(addr)
+	if err != nil {
+		return output.NewError(output.ConvertError, "failed to convert string into address", err)
+	}
+	amount, err := parseAmount(args)
+	if err != nil {
+		return err
+	}
+	abi, bytecode, err := packMethodCall()
+	if err != nil {
+		return err
+	}
+
+	rowResult, err := action.Read(contract, amount.String(), bytecode)
+	if err != nil {
+		return err
+	}
+	result, err := ParseOutput(abi, _methodFlag.Value().(string), rowResult)
+	if err != nil {
+		result = rowResult
+	}
+	output.PrintResult("return: " + result)
+	return nil
+}
+
+func contractInvokeABI(args []string) error {
+	contract, err := util.Address(args[0])
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get contract address", err)
+	}
+	amount, err := parseAmount(args)
+	if err != nil {
+		return err
+	}
+	abi, bytecode, err := packMethodCall()
+	if err != nil {
+		return err
+	}
+
+	resp, err := action.ExecuteAndResponse(contract, amount, bytecode)
+	if err != nil || resp == nil {
+		return err
+	}
+	if !_waitFlag.Value().(bool) {
+		output.PrintResult("action hash: " + resp.ActionHash)
+		return nil
+	}
+	receipt, err := waitReceipt(resp.ActionHash)
+	if err != nil {
+		return err
+	}
+	message := invokeResultMessage{
+		ActionHash: resp.ActionHash,
+		Status:     receipt.Status,
+		Height:     receipt.BlkHeight,
+		GasUsed:    receipt.GasConsumed,
+	}
+	for _, log := range receipt.Logs {
+		event, err := decodeEvent(abi, log.Topics, log.Data)
+		if err != nil {
+			// the log is emitted by other contracts, or the event is not in the abi
+			continue
+		}
+		message.Events = append(message.Events, event)
+	}
+	fmt.Println(message.String())
+	return nil
+}
+
+func parseAmount(args []string) (*big.Int, error) {
+	if len(args) < 2 {
+		return big.NewInt(0), nil
+	}
+	amount, err := util.StringToRau(args[1], util.IotxDecimalNum)
+	if err != nil {
+		return nil, output.NewError(output.ConvertError, "invalid amount", err)
+	}
+	return amount, nil
+}
+
+// packMethodCall packs the calldata of the method set by --method, the arguments are read from --with-arguments, or
+// prompted one by one if not set
+func packMethodCall() (*abi.ABI, []byte, error) {
+	abiFile := _abiFlag.Value().(string)
+	targetAbi, err := readAbiFile(abiFile)
+	if err != nil {
+		return nil, nil, output.NewError(output.ReadFileError, "failed to read abi file "+abiFile, err)
+	}
+	methodName := _methodFlag.Value().(string)
+	method, ok := targetAbi.Methods[methodName]
+	if !ok {
+		return nil, nil, output.NewError(output.InputError, "invalid method name", nil)
+	}
+
+	if rowInput := flag.WithArgumentsFlag.Value().(string); rowInput != "" || len(method.Inputs) == 0 {
+		bytecode, err := packArguments(targetAbi, methodName, rowInput)
+		if err != nil {
+			return nil, nil, output.NewError(output.ConvertError, "failed to pack given arguments", err)
+		}
+		return targetAbi, bytecode, nil
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	arguments := make([]interface{}, 0, len(method.Inputs))
+	for i, param := range method.Inputs {
+		name := param.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		output.PrintQuery(fmt.Sprintf("%s (%s):", name, param.Type.String()))
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, nil, output.NewError(output.InputError, fmt.Sprintf("failed to input argument \"%s\"", name), err)
+		}
+		arg, err := parseArgumentString(&param.Type, strings.TrimSpace(line))
+		if err != nil {
+			return nil, nil, output.NewError(output.InputError, fmt.Sprintf("failed to parse argument \"%s\"", name), err)
+		}
+		arguments = append(arguments, arg)
+	}
+	bytecode, err := targetAbi.Pack(methodName, arguments...)
+	if err != nil {
+		return nil, nil, output.NewError(output.ConvertError, "failed to pack given arguments", err)
+	}
+	return targetAbi, bytecode, nil
+}
+
+func waitReceipt(h string) (*iotextypes.Receipt, error) {
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return nil, output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	cli := iotexapi.NewAPIServiceClient(conn)
+	ctx := context.Background()
+
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+
+	var rsp *iotexapi.GetReceiptByActionResponse
+	err = backoff.Retry(func() error {
+		rsp, err = cli.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{
+			ActionHash: h,
+		})
+		return err
+	}, backoff.WithMaxRetries(backoff.NewConstantBackOff(5*time.Second), 12))
+	if err != nil {
+		if sta, ok := status.FromError(err); ok {
+			return nil, output.NewError(output.APIError, sta.Message(), nil)
+		}
+		return nil, output.NewError(output.NetworkError, "failed to invoke GetReceiptByAction api", err)
+	}
+	return rsp.GetReceiptInfo().GetReceipt(), nil
+}
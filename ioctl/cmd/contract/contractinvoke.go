@@ -10,6 +10,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/action"
 	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
 )
 
 // Multi-language support
@@ -22,8 +23,20 @@ var (
 
 // _contractInvokeCmd represents the contract invoke command
 var _contractInvokeCmd = &cobra.Command{
-	Use:   "invoke",
+	Use:   config.TranslateInLang(_invokeABICmdUses, config.UILanguage),
 	Short: config.TranslateInLang(_invokeCmdShorts, config.UILanguage),
+	Args:  cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return cmd.Help()
+		}
+		cmd.SilenceUsage = true
+		if _abiFlag.Value().(string) == "" || _methodFlag.Value().(string) == "" {
+			return output.PrintError(output.NewError(output.FlagError, "both --abi and --method are required", nil))
+		}
+		err := contractInvokeABI(args)
+		return output.PrintError(err)
+	},
 }
 
 func init() {
@@ -31,5 +44,5 @@ func init() {
 	_contractInvokeCmd.AddCommand(_contractInvokeBytecodeCmd)
 	action.RegisterWriteCommand(_contractInvokeFunctionCmd)
 	action.RegisterWriteCommand(_contractInvokeBytecodeCmd)
-
+	action.RegisterWriteCommand(_contractInvokeCmd)
 }
@@ -385,3 +385,64 @@ func parseOutputArgument(v interface{}, t *abi.Type) (string, bool) {
 
 	return str, ok
 }
+
+// parseArgumentString parses the argument typed in as a string, the arrays and slices are typed in JSON format
+func parseArgumentString(t *abi.Type, s string) (interface{}, error) {
+	var arg interface{}
+	switch t.T {
+	case abi.BoolTy:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, ErrInvalidArg
+		}
+		arg = b
+	case abi.SliceTy, abi.ArrayTy:
+		if err := json.Unmarshal([]byte(s), &arg); err != nil {
+			return nil, errors.Wrap(ErrInvalidArg, err.Error())
+		}
+	default:
+		arg = s
+	}
+	return parseInputArgument(t, arg)
+}
+
+// decodeEvent decodes the log emitted by the event in the abi as a human-readable string
+func decodeEvent(targetAbi *abi.ABI, topics [][]byte, data []byte) (string, error) {
+	if len(topics) == 0 {
+		return "", errors.Wrap(ErrInvalidArg, "anonymous event is not supported")
+	}
+	event, err := targetAbi.EventByID(common.BytesToHash(topics[0]))
+	if err != nil {
+		return "", err
+	}
+	values := make(map[string]interface{})
+	if len(data) > 0 {
+		if err := targetAbi.UnpackIntoMap(values, event.Name, data); err != nil {
+			return "", err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	hashes := make([]common.Hash, 0, len(topics)-1)
+	for _, topic := range topics[1:] {
+		hashes = append(hashes, common.BytesToHash(topic))
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, hashes); err != nil {
+		return "", err
+	}
+
+	fields := make([]string, 0, len(event.Inputs))
+	for _, arg := range event.Inputs {
+		v, ok := values[arg.Name]
+		if !ok {
+			continue
+		}
+		str, _ := parseOutputArgument(v, &arg.Type)
+		fields = append(fields, arg.Name+":"+str)
+	}
+	return event.Name + "{" + strings.Join(fields, " ") + "}", nil
+}
@@ -39,6 +39,8 @@ func init() {
 	NodeCmd.AddCommand(_nodeDelegateCmd)
 	NodeCmd.AddCommand(_nodeRewardCmd)
 	NodeCmd.AddCommand(_nodeProbationlistCmd)
+	NodeCmd.AddCommand(_nodeWatchCmd)
+	NodeCmd.AddCommand(_nodePerformanceCmd)
 	NodeCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
 		config.ReadConfig.Endpoint, config.TranslateInLang(_flagEndpointUsages, config.UILanguage))
 	NodeCmd.PersistentFlags().BoolVar(&config.Insecure, "insecure", config.Insecure,
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/bc"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_performanceCmdUses = map[config.Language]string{
+		config.English: "performance [ALIAS|DELEGATE_ADDRESS] [-e epoch-num] [-n count]",
+		config.Chinese: "performance [别名|代表地址] [-e epoch数] [-n 数量]",
+	}
+	_performanceCmdShorts = map[config.Language]string{
+		config.English: "Print the block production and endorsement performance of the delegates",
+		config.Chinese: "打印代表的出块和背书表现",
+	}
+	_performanceCmdLongs = map[config.Language]string{
+		config.English: "ioctl node performance prints the performance of all delegates in the epoch, or of the given " +
+			"delegate in the count of epochs starting from the epoch, including:\n" +
+			"  Produced/Missed: the number of blocks produced by the delegate, and the number of its proposer slots " +
+			"filled by the other delegates;\n" +
+			"  Fullness: the gas used over the gas limit of the blocks produced by the delegate;\n" +
+			"  Endorsed: the number of blocks of the epoch endorsed by the delegate.\n" +
+			"The API endpoint needs to enable the performance indexer.",
+		config.Chinese: "ioctl node performance 打印epoch内所有代表的表现，或指定代表从该epoch开始若干个epoch内的表现，包括：\n" +
+			"  Produced/Missed：代表的出块数，以及代表的出块时段由其他代表出块的次数；\n" +
+			"  Fullness：代表所出区块的已用gas与gas上限之比；\n" +
+			"  Endorsed：代表背书的epoch内区块数。\n" +
+			"API节点需要启用performance indexer。",
+	}
+	_flagPerformanceCountUsages = map[config.Language]string{
+		config.English: "number of epochs of the delegate",
+		config.Chinese: "代表的epoch数",
+	}
+)
+
+var _performanceCount uint64
+
+// _nodePerformanceCmd represents the node performance command
+var _nodePerformanceCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_performanceCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_performanceCmdShorts, config.UILanguage),
+	Long:  config.TranslateInLang(_performanceCmdLongs, config.UILanguage),
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		delegate := ""
+		if len(args) == 1 {
+			delegate = args[0]
+		}
+		err := performance(delegate)
+		return output.PrintError(err)
+	},
+}
+
+type delegatePerformance struct {
+	Epoch         uint64  `json:"epoch"`
+	Delegate      string  `json:"delegate"`
+	EpochBlocks   uint64  `json:"epochBlocks"`
+	Produced      uint64  `json:"produced"`
+	Missed        uint64  `json:"missed"`
+	Fullness      float64 `json:"fullness"`
+	Endorsed      uint64  `json:"endorsed"`
+	Participation float64 `json:"participation"`
+}
+
+type performanceMessage struct {
+	Performances []*delegatePerformance `json:"performances"`
+}
+
+func (m *performanceMessage) String() string {
+	if output.Format == "" {
+		formatTitleString := "%-6s   %-41s   %-8s   %-6s   %-8s   %-18s"
+		formatDataString := "%-6d   %-41s   %-8d   %-6d   %-7.2f%%   %d / %d (%.2f%%)"
+		lines := []string{fmt.Sprintf(formatTitleString, "Epoch", "Delegate", "Produced", "Missed", "Fullness", "Endorsed")}
+		lines = append(lines, strings.Repeat("-", len(lines[0])))
+		for _, p := range m.Performances {
+			lines = append(lines, fmt.Sprintf(formatDataString, p.Epoch, p.Delegate, p.Produced, p.Missed,
+				p.Fullness*100, p.Endorsed, p.EpochBlocks, p.Participation*100))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func init() {
+	_nodePerformanceCmd.Flags().Uint64VarP(&_epochNum, "epoch-num", "e", 0,
+		config.TranslateInLang(_flagEpochNumUsages, config.UILanguage))
+	_nodePerformanceCmd.Flags().Uint64VarP(&_performanceCount, "count", "n", 1,
+		config.TranslateInLang(_flagPerformanceCountUsages, config.UILanguage))
+}
+
+func performance(delegate string) error {
+	if _epochNum == 0 {
+		chainMeta, err := bc.GetChainMeta()
+		if err != nil {
+			return output.NewError(0, "failed to get chain meta", err)
+		}
+		epochData := chainMeta.GetEpoch()
+		if epochData == nil {
+			return output.NewError(0, "ROLLDPOS is not registered", nil)
+		}
+		_epochNum = epochData.Num
+	}
+	var (
+		method = "/iotexcore.PerformanceService/GetEpochPerformance"
+		fields = map[string]any{"epoch": _epochNum}
+	)
+	if delegate != "" {
+		addr, err := util.Address(delegate)
+		if err != nil {
+			return output.NewError(output.AddressError, "", err)
+		}
+		method = "/iotexcore.PerformanceService/GetDelegatePerformance"
+		fields = map[string]any{"address": addr, "start": _epochNum, "count": _performanceCount}
+	}
+	in, err := structpb.NewStruct(fields)
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to build request", err)
+	}
+
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+	res := new(structpb.Struct)
+	if err := conn.Invoke(ctx, method, in, res); err != nil {
+		sta, ok := status.FromError(err)
+		if ok {
+			return output.NewError(output.APIError, sta.Message(), nil)
+		}
+		return output.NewError(output.NetworkError, "failed to invoke "+method, err)
+	}
+	data, err := res.MarshalJSON()
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to marshal response", err)
+	}
+	message := performanceMessage{}
+	if err := json.Unmarshal(data, &message); err != nil {
+		return output.NewError(output.SerializationError, "failed to unmarshal response", err)
+	}
+	fmt.Println(message.String())
+	return nil
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+const (
+	// _watchMaxBlocksPerPoll is the max number of blocks fetched in one poll to count the endorsements
+	_watchMaxBlocksPerPoll = 100
+	_clearScreen           = "\033[H\033[2J"
+)
+
+// Multi-language support
+var (
+	_watchCmdUses = map[config.Language]string{
+		config.English: "watch ALIAS|OPERATOR_ADDRESS|NAME [-i INTERVAL]",
+		config.Chinese: "watch 别名|操作者地址|名称 [-i 间隔]",
+	}
+	_watchCmdShorts = map[config.Language]string{
+		config.English: "Continuously display the block production, endorsements and rewards of a delegate",
+		config.Chinese: "持续显示代表的出块、背书和奖励情况",
+	}
+	_watchCmdLongs = map[config.Language]string{
+		config.English: "ioctl node watch polls the API and refreshes a dashboard of the delegate in the current epoch until " +
+			"interrupted, including:\n" +
+			"  Produced/Expected/Missed: the number of blocks produced by the delegate, the number of blocks it is " +
+			"expected to produce so far as an active delegate, and the difference of the two;\n" +
+			"  Endorsed: the number of blocks endorsed by the delegate among the blocks seen since the watch started;\n" +
+			"  Unclaimed/Accumulated: the unclaimed reward of the delegate, and how much it has grown since the watch started.",
+		config.Chinese: "ioctl node watch 轮询API并持续刷新代表在当前epoch内的情况，直到被中断，包括：\n" +
+			"  Produced/Expected/Missed：代表的出块数，作为活跃代表到目前为止应出块数，以及两者之差；\n" +
+			"  Endorsed：自开始观察以来的区块中代表背书的区块数；\n" +
+			"  Unclaimed/Accumulated：代表未支取的奖励，以及自开始观察以来的增长。",
+	}
+	_flagWatchIntervalUsages = map[config.Language]string{
+		config.English: "polling interval",
+		config.Chinese: "轮询间隔",
+	}
+)
+
+var _watchInterval time.Duration
+
+// _nodeWatchCmd represents the node watch command
+var _nodeWatchCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_watchCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_watchCmdShorts, config.UILanguage),
+	Long:  config.TranslateInLang(_watchCmdLongs, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := watch(args[0])
+		return output.PrintError(err)
+	},
+}
+
+type watchMessage struct {
+	Time        time.Time `json:"time"`
+	Name        string    `json:"name"`
+	Operator    string    `json:"operator"`
+	Height      uint64    `json:"height"`
+	Epoch       uint64    `json:"epoch"`
+	EpochStart  uint64    `json:"epochStart"`
+	Active      bool      `json:"active"`
+	Produced    uint64    `json:"produced"`
+	Expected    uint64    `json:"expected"`
+	Missed      uint64    `json:"missed"`
+	Endorsed    uint64    `json:"endorsed"`
+	Observed    uint64    `json:"observed"`
+	Unclaimed   string    `json:"unclaimed"`
+	Accumulated string    `json:"accumulated"`
+}
+
+func (m *watchMessage) String() string {
+	if output.Format == "" {
+		participation := "-"
+		if m.Observed > 0 {
+			participation = fmt.Sprintf("%.2f%%", float64(m.Endorsed)*100/float64(m.Observed))
+		}
+		lines := []string{
+			fmt.Sprintf("%s    %s (%s)", m.Time.Format(time.RFC3339), m.Name, m.Operator),
+			"",
+			fmt.Sprintf("Height:      %d", m.Height),
+			fmt.Sprintf("Epoch:       %d (start height %d)", m.Epoch, m.EpochStart),
+			fmt.Sprintf("Status:      %s", _nodeStatus[m.Active]),
+			fmt.Sprintf("Produced:    %d", m.Produced),
+			fmt.Sprintf("Expected:    %d", m.Expected),
+			fmt.Sprintf("Missed:      %d", m.Missed),
+			fmt.Sprintf("Endorsed:    %d / %d (%s)", m.Endorsed, m.Observed, participation),
+			fmt.Sprintf("Unclaimed:   %s IOTX", m.Unclaimed),
+			fmt.Sprintf("Accumulated: %s IOTX", m.Accumulated),
+		}
+		return strings.Join(lines, "\n")
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func init() {
+	_nodeWatchCmd.Flags().DurationVarP(&_watchInterval, "interval", "i", 10*time.Second,
+		config.TranslateInLang(_flagWatchIntervalUsages, config.UILanguage))
+}
+
+// watcher polls the chain for the states of a delegate
+type watcher struct {
+	cli       iotexapi.APIServiceClient
+	candidate *iotextypes.CandidateV2
+	// reward is the unclaimed reward when the watch started
+	reward *big.Int
+	// lastHeight is the height of the last block whose endorsements are counted
+	lastHeight uint64
+	endorsed   uint64
+	observed   uint64
+}
+
+func watch(arg string) error {
+	if _watchInterval <= 0 {
+		return output.NewError(output.FlagError, "interval must be positive", nil)
+	}
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+
+	w := &watcher{cli: iotexapi.NewAPIServiceClient(conn)}
+	if w.candidate, err = getCandidateByAddressOrName(w.cli, arg); err != nil {
+		return output.NewError(output.AddressError, "failed to get delegate", err)
+	}
+	ticker := time.NewTicker(_watchInterval)
+	defer ticker.Stop()
+	for {
+		message, err := w.poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if output.Format == "" {
+			fmt.Print(_clearScreen)
+		}
+		fmt.Println(message.String())
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *watcher) poll(ctx context.Context) (*watchMessage, error) {
+	chainMeta, err := w.cli.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return nil, apiError(err, "failed to invoke GetChainMeta api")
+	}
+	epoch := chainMeta.GetChainMeta().GetEpoch()
+	if epoch == nil {
+		return nil, output.NewError(0, "ROLLDPOS is not registered", nil)
+	}
+	height := chainMeta.GetChainMeta().GetHeight()
+	epochMeta, err := w.cli.GetEpochMeta(ctx, &iotexapi.GetEpochMetaRequest{EpochNumber: epoch.Num})
+	if err != nil {
+		return nil, apiError(err, "failed to invoke GetEpochMeta api")
+	}
+	message := &watchMessage{
+		Time:       time.Now(),
+		Name:       w.candidate.Name,
+		Operator:   w.candidate.OperatorAddress,
+		Height:     height,
+		Epoch:      epoch.Num,
+		EpochStart: epoch.Height,
+	}
+	var numActive uint64
+	for _, bp := range epochMeta.BlockProducersInfo {
+		if !bp.Active {
+			continue
+		}
+		numActive++
+		if bp.Address == w.candidate.OperatorAddress {
+			message.Active = true
+			message.Produced = bp.Production
+		}
+	}
+	if message.Active && height >= epoch.Height {
+		// the active delegates take turns to produce the blocks
+		message.Expected = (height - epoch.Height + 1) / numActive
+		if message.Expected > message.Produced {
+			message.Missed = message.Expected - message.Produced
+		}
+	}
+	if err := w.countEndorsements(ctx, height); err != nil {
+		return nil, err
+	}
+	message.Endorsed, message.Observed = w.endorsed, w.observed
+
+	reward, err := w.unclaimedReward(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if w.reward == nil {
+		w.reward = reward
+	}
+	message.Unclaimed = util.RauToString(reward, util.IotxDecimalNum)
+	message.Accumulated = util.RauToString(new(big.Int).Sub(reward, w.reward), util.IotxDecimalNum)
+	return message, nil
+}
+
+// countEndorsements counts the endorsements of the delegate in the blocks produced since the last poll
+func (w *watcher) countEndorsements(ctx context.Context, height uint64) error {
+	if w.lastHeight == 0 || height-w.lastHeight > _watchMaxBlocksPerPoll {
+		// only the latest blocks are checked when the watch starts or falls behind
+		w.lastHeight = height - min(height, _watchMaxBlocksPerPoll)
+	}
+	if height <= w.lastHeight {
+		return nil
+	}
+	response, err := w.cli.GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+		StartHeight: w.lastHeight + 1,
+		Count:       height - w.lastHeight,
+	})
+	if err != nil {
+		return apiError(err, "failed to invoke GetRawBlocks api")
+	}
+	for _, blkInfo := range response.Blocks {
+		for _, en := range blkInfo.GetBlock().GetFooter().GetEndorsements() {
+			pk, err := crypto.BytesToPublicKey(en.Endorser)
+			if err != nil {
+				return output.NewError(output.CryptoError, "failed to get endorser's public key", err)
+			}
+			if pk.Address().String() == w.candidate.OperatorAddress {
+				w.endorsed++
+				break
+			}
+		}
+		w.observed++
+	}
+	w.lastHeight = height
+	return nil
+}
+
+func (w *watcher) unclaimedReward(ctx context.Context) (*big.Int, error) {
+	response, err := w.cli.ReadState(ctx, &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("rewarding"),
+		MethodName: []byte("UnclaimedBalance"),
+		Arguments:  [][]byte{[]byte(w.candidate.RewardAddress)},
+	})
+	if err != nil {
+		return nil, apiError(err, "failed to invoke ReadState api")
+	}
+	reward, ok := new(big.Int).SetString(string(response.Data), 10)
+	if !ok {
+		return nil, output.NewError(output.ConvertError, "failed to convert string into big int", nil)
+	}
+	return reward, nil
+}
+
+func getCandidateByAddressOrName(cli iotexapi.APIServiceClient, arg string) (*iotextypes.CandidateV2, error) {
+	addr, err := util.Address(arg)
+	if err != nil {
+		// not an address or alias, take it as the name
+		addr = ""
+	}
+	cl, err := getAllStakingCandidates(cli)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range cl.Candidates {
+		if candidate.OperatorAddress == addr || candidate.Name == arg {
+			return candidate, nil
+		}
+	}
+	return nil, errors.Errorf("delegate %s is not found", arg)
+}
+
+func apiError(err error, msg string) error {
+	if sta, ok := status.FromError(err); ok {
+		return output.NewError(output.APIError, sta.Message(), nil)
+	}
+	return output.NewError(output.NetworkError, msg, err)
+}
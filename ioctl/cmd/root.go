@@ -48,14 +48,23 @@ var (
 		config.English: "output format",
 		config.Chinese: "指定输出格式",
 	}
+	_flagProfileUsages = map[config.Language]string{
+		config.English: "use the profile for once",
+		config.Chinese: "一次使用指定的配置",
+	}
 )
 
+var _profile string
+
 // NewIoctl returns ioctl root cmd
 func NewIoctl() *cobra.Command {
 	var rootCmd = &cobra.Command{
 		Use:   "ioctl",
 		Short: config.TranslateInLang(_ioctlRootCmdShorts, config.UILanguage),
 		Long:  config.TranslateInLang(_ioctlRootCmdLongs, config.UILanguage),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.PrintError(useProfile(cmd))
+		},
 	}
 
 	rootCmd.AddCommand(config.ConfigCmd)
@@ -77,10 +86,27 @@ func NewIoctl() *cobra.Command {
 	rootCmd.AddCommand(ioid.IoIDCmd)
 	rootCmd.PersistentFlags().StringVarP(&output.Format, "output-format", "o", "",
 		config.TranslateInLang(_flagOutputFormatUsages, config.UILanguage))
+	rootCmd.PersistentFlags().StringVar(&_profile, "profile", "",
+		config.TranslateInLang(_flagProfileUsages, config.UILanguage))
 
 	return rootCmd
 }
 
+// useProfile applies the profile set by --profile to the command, the endpoint set by --endpoint takes precedence
+func useProfile(cmd *cobra.Command) error {
+	if _profile == "" {
+		return nil
+	}
+	endpoint := config.ReadConfig.Endpoint
+	if err := config.UseProfile(_profile); err != nil {
+		return err
+	}
+	if f := cmd.Flags().Lookup("endpoint"); f != nil && f.Changed {
+		config.ReadConfig.Endpoint = endpoint
+	}
+	return nil
+}
+
 // NewXctl returns xctl root cmd
 func NewXctl() *cobra.Command {
 	var rootCmd = &cobra.Command{
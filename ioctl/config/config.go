@@ -91,6 +91,19 @@ type Config struct {
 	IoidProjectRegisterContract string `json:"ioidProjectRegisterContract" yaml:"ioidProjectRegisterContract"`
 	// IoidProjectStoreContract is the ioID project store contract address
 	IoidProjectStoreContract string `json:"ioidProjectStoreContract" yaml:"ioidProjectStoreContract"`
+	// InsResolverContract is the INS resolver contract address, by which the INS names in the address arguments are
+	// resolved
+	InsResolverContract string `json:"insResolverContract" yaml:"insResolverContract"`
+	// ChainID is the chain ID to sign the actions with, 0 to use the chain ID of the endpoint
+	ChainID uint32 `json:"chainID" yaml:"chainID"`
+	// GasPrice is the default gas price in 10^(-6)IOTX
+	GasPrice string `json:"gasPrice" yaml:"gasPrice"`
+	// GasLimit is the default gas limit
+	GasLimit uint64 `json:"gasLimit" yaml:"gasLimit"`
+	// Profile is the name of the profile in use
+	Profile string `json:"profile" yaml:"profile"`
+	// Profiles are the named settings of the networks
+	Profiles map[string]*Profile `json:"profiles,omitempty" yaml:"profiles,omitempty"`
 }
 
 var (
@@ -208,6 +221,7 @@ func init() {
 	ConfigCmd.AddCommand(_configGetCmd)
 	ConfigCmd.AddCommand(_configSetCmd)
 	ConfigCmd.AddCommand(_configResetCmd)
+	ConfigCmd.AddCommand(_configProfileCmd)
 }
 
 // LoadConfig loads config file in yaml format
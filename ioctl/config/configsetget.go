@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 
@@ -56,7 +57,7 @@ const (
 
 var (
 	_supportedLanguage = []string{"English", "中文"}
-	_validArgs         = []string{"endpoint", "wallet", "explorer", "defaultacc", "language", "nsv2height", "wsEndpoint", "ipfsEndpoint", "ipfsGateway", "wsProjectRegisterContract", "wsProjectStoreContract", "wsFleetManagementContract", "wsProverStoreContract", "wsProjectDevicesContract", "wsRouterContract", "wsVmTypeContract"}
+	_validArgs         = []string{"endpoint", "wallet", "explorer", "defaultacc", "language", "nsv2height", "wsEndpoint", "ipfsEndpoint", "ipfsGateway", "wsProjectRegisterContract", "wsProjectStoreContract", "wsFleetManagementContract", "wsProverStoreContract", "wsProjectDevicesContract", "wsRouterContract", "wsVmTypeContract", "insResolverContract"}
 	_validGetArgs      = []string{"endpoint", "wallet", "explorer", "defaultacc", "language", "nsv2height", "analyserEndpoint", "wsEndpoint", "ipfsEndpoint", "ipfsGateway", "wsProjectRegisterContract", "wsProjectStoreContract", "wsFleetManagementContract", "wsProverStoreContract", "wsProjectDevicesContract", "wsRouterContract", "wsVmTypeContract", "all"}
 	_validExpl         = []string{"iotexscan", "iotxplorer"}
 	_endpointCompile   = regexp.MustCompile("^" + _endpointPattern + "$")
@@ -193,6 +194,8 @@ func Get(arg string) error {
 		fmt.Println(ReadConfig.WsRouterContract)
 	case "wsVmTypeContract":
 		fmt.Println(ReadConfig.WsVmTypeContract)
+	case "insResolverContract":
+		fmt.Println(ReadConfig.InsResolverContract)
 	case "all":
 		fmt.Println(ReadConfig.String())
 	}
@@ -341,6 +344,11 @@ func set(args []string) error {
 		ReadConfig.WsRouterContract = args[1]
 	case "wsVmTypeContract":
 		ReadConfig.WsVmTypeContract = args[1]
+	case "insResolverContract":
+		if err := validator.ValidateAddress(args[1]); err != nil && !common.IsHexAddress(args[1]) {
+			return output.NewError(output.ValidationError, "invalid INS resolver contract address", err)
+		}
+		ReadConfig.InsResolverContract = args[1]
 	}
 	err := writeConfig()
 	if err != nil {
@@ -369,6 +377,10 @@ func reset() error {
 	ReadConfig.WsProjectDevicesContract = _defaultWsProjectDevicesContract
 	ReadConfig.WsRouterContract = _defaultWsRouterContract
 	ReadConfig.WsVmTypeContract = _defaultWsVmTypeContract
+	ReadConfig.ChainID = 0
+	ReadConfig.GasPrice = ""
+	ReadConfig.GasLimit = 0
+	ReadConfig.Profile = ""
 
 	err := writeConfig()
 	if err != nil {
@@ -0,0 +1,228 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/validator"
+)
+
+// Profile bundles the settings to work with a network, e.g., the mainnet, the testnet or a private chain
+type Profile struct {
+	Endpoint       string  `json:"endpoint" yaml:"endpoint"`
+	SecureConnect  bool    `json:"secureConnect" yaml:"secureConnect"`
+	ChainID        uint32  `json:"chainID" yaml:"chainID"`
+	DefaultAccount Context `json:"defaultAccount" yaml:"defaultAccount"`
+	// GasPrice is the gas price in 10^(-6)IOTX, used unless set by the command
+	GasPrice string `json:"gasPrice" yaml:"gasPrice"`
+	// GasLimit is the gas limit, used unless set by the command
+	GasLimit uint64 `json:"gasLimit" yaml:"gasLimit"`
+}
+
+// Flags of config profile add
+var (
+	_profileEndpoint   string
+	_profileChainID    uint32
+	_profileDefaultAcc string
+	_profileGasPrice   string
+	_profileGasLimit   uint64
+	_profileInsecure   bool
+)
+
+// _configProfileCmd represents the config profile command
+var _configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage the network profiles of ioctl",
+	Long: "Manage the network profiles of ioctl\n" +
+		"A profile bundles the endpoint, chain ID, default account and gas settings of a network. " +
+		"Use \"ioctl config profile use NAME\" to switch to a profile, or \"--profile NAME\" to use it for one command.",
+}
+
+// _configProfileAddCmd represents the config profile add command
+var _configProfileAddCmd = &cobra.Command{
+	Use:   "add NAME [--endpoint ENDPOINT] [--chain-id CHAIN_ID] [--defaultacc ADDRESS|ALIAS] [--gas-price GAS_PRICE] [--gas-limit GAS_LIMIT] [--insecure]",
+	Short: "Add or update a profile, the current endpoint and default account are used unless set",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := addProfile(args[0])
+		return output.PrintError(err)
+	},
+}
+
+// _configProfileUseCmd represents the config profile use command
+var _configProfileUseCmd = &cobra.Command{
+	Use:   "use NAME",
+	Short: "Switch to a profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := switchProfile(args[0])
+		return output.PrintError(err)
+	},
+}
+
+// _configProfileListCmd represents the config profile list command
+var _configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the profiles",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		message := profileListMessage{Current: ReadConfig.Profile, Profiles: ReadConfig.Profiles}
+		fmt.Println(message.String())
+		return nil
+	},
+}
+
+// _configProfileRemoveCmd represents the config profile remove command
+var _configProfileRemoveCmd = &cobra.Command{
+	Use:   "remove NAME",
+	Short: "Remove a profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := removeProfile(args[0])
+		return output.PrintError(err)
+	},
+}
+
+type profileListMessage struct {
+	Current  string              `json:"current"`
+	Profiles map[string]*Profile `json:"profiles"`
+}
+
+func (m *profileListMessage) String() string {
+	if output.Format == "" {
+		names := make([]string, 0, len(m.Profiles))
+		for name := range m.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		lines := make([]string, 0, len(names))
+		for _, name := range names {
+			p := m.Profiles[name]
+			mark := " "
+			if name == m.Current {
+				mark = "*"
+			}
+			lines = append(lines, fmt.Sprintf("%s %s    endpoint: %s, secure connect(TLS): %t, chain ID: %d, default account: %s, gas price: %s, gas limit: %d",
+				mark, name, p.Endpoint, p.SecureConnect, p.ChainID, p.DefaultAccount.AddressOrAlias, p.GasPrice, p.GasLimit))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func init() {
+	_configProfileAddCmd.Flags().StringVar(&_profileEndpoint, "endpoint", "", "set the endpoint")
+	_configProfileAddCmd.Flags().Uint32Var(&_profileChainID, "chain-id", 0, "set the chain ID (default using the chain ID of the endpoint)")
+	_configProfileAddCmd.Flags().StringVar(&_profileDefaultAcc, "defaultacc", "", "set the default account")
+	_configProfileAddCmd.Flags().StringVar(&_profileGasPrice, "gas-price", "", "set the gas price (unit: 10^(-6)IOTX)")
+	_configProfileAddCmd.Flags().Uint64Var(&_profileGasLimit, "gas-limit", 0, "set the gas limit")
+	_configProfileAddCmd.Flags().BoolVar(&_profileInsecure, "insecure", false, "set insecure connection to the endpoint")
+	_configProfileCmd.AddCommand(_configProfileAddCmd)
+	_configProfileCmd.AddCommand(_configProfileUseCmd)
+	_configProfileCmd.AddCommand(_configProfileListCmd)
+	_configProfileCmd.AddCommand(_configProfileRemoveCmd)
+}
+
+func addProfile(name string) error {
+	p := &Profile{
+		Endpoint:       ReadConfig.Endpoint,
+		SecureConnect:  ReadConfig.SecureConnect,
+		ChainID:        _profileChainID,
+		DefaultAccount: ReadConfig.DefaultAccount,
+		GasPrice:       _profileGasPrice,
+		GasLimit:       _profileGasLimit,
+	}
+	if _profileEndpoint != "" {
+		if !isValidEndpoint(_profileEndpoint) {
+			return output.NewError(output.ConfigError, fmt.Sprintf("endpoint %s is not valid", _profileEndpoint), nil)
+		}
+		p.Endpoint = _profileEndpoint
+		p.SecureConnect = !_profileInsecure
+	}
+	if _profileDefaultAcc != "" {
+		err1 := validator.ValidateAlias(_profileDefaultAcc)
+		err2 := validator.ValidateAddress(_profileDefaultAcc)
+		if err1 != nil && err2 != nil {
+			return output.NewError(output.ValidationError, "failed to validate alias or address", nil)
+		}
+		p.DefaultAccount.AddressOrAlias = _profileDefaultAcc
+	}
+	if p.GasPrice != "" {
+		if gasPrice, ok := new(big.Float).SetString(p.GasPrice); !ok || gasPrice.Sign() < 0 {
+			return output.NewError(output.ValidationError, fmt.Sprintf("gas price %s is not valid", p.GasPrice), nil)
+		}
+	}
+	if ReadConfig.Profiles == nil {
+		ReadConfig.Profiles = make(map[string]*Profile)
+	}
+	ReadConfig.Profiles[name] = p
+	if name == ReadConfig.Profile {
+		ReadConfig.apply(p)
+	}
+	if err := writeConfig(); err != nil {
+		return err
+	}
+	output.PrintResult(fmt.Sprintf("Profile %s is added", name))
+	return nil
+}
+
+func switchProfile(name string) error {
+	if err := UseProfile(name); err != nil {
+		return err
+	}
+	if err := writeConfig(); err != nil {
+		return err
+	}
+	output.PrintResult(fmt.Sprintf("Profile is switched to %s", name))
+	return nil
+}
+
+func removeProfile(name string) error {
+	if _, ok := ReadConfig.Profiles[name]; !ok {
+		return output.NewError(output.ConfigError, fmt.Sprintf("profile %s does not exist", name), nil)
+	}
+	delete(ReadConfig.Profiles, name)
+	if name == ReadConfig.Profile {
+		// the settings of the profile are kept until changed
+		ReadConfig.Profile = ""
+	}
+	if err := writeConfig(); err != nil {
+		return err
+	}
+	output.PrintResult(fmt.Sprintf("Profile %s is removed", name))
+	return nil
+}
+
+// UseProfile applies the settings of the profile to the config in memory, call writeConfig to persist it
+func UseProfile(name string) error {
+	p, ok := ReadConfig.Profiles[name]
+	if !ok {
+		return output.NewError(output.ConfigError, fmt.Sprintf("profile %s does not exist", name), nil)
+	}
+	ReadConfig.apply(p)
+	ReadConfig.Profile = name
+	return nil
+}
+
+func (c *Config) apply(p *Profile) {
+	c.Endpoint = p.Endpoint
+	c.SecureConnect = p.SecureConnect
+	c.ChainID = p.ChainID
+	c.DefaultAccount = p.DefaultAccount
+	c.GasPrice = p.GasPrice
+	c.GasLimit = p.GasLimit
+}
@@ -139,7 +139,7 @@ func TestConfigGet(t *testing.T) {
 		},
 		{
 			"all",
-			"  \"endpoint\": \"\",\n  \"secureConnect\": true,\n  \"aliases\": {},\n  \"defaultAccount\": {\n    \"addressOrAlias\": \"test\"\n  },\n  \"explorer\": \"iotexscan\",\n  \"language\": \"English\",\n  \"nsv2height\": 0,\n  \"analyserEndpoint\": \"testAnalyser\",\n  \"wsEndpoint\": \"testWsEndpoint\",\n  \"ipfsEndpoint\": \"testIPFSEndpoint\",\n  \"ipfsGateway\": \"testIPFSGateway\",\n  \"wsProjectRegisterContract\": \"testWsProjectRegisterContract\",\n  \"wsProjectStoreContract\": \"testWsProjectStoreContract\",\n  \"wsFleetManagementContract\": \"testWsFleetManagementContract\",\n  \"wsProverStoreContract\": \"testWsProverStoreContract\",\n  \"wsProjectDevicesContract\": \"testWsProjectDevicesContract\",\n  \"wsRouterContract\": \"testWsRouterContract\",\n  \"wsVmTypeContract\": \"testWsVmTypeContract\",\n  \"ioidProjectRegisterContract\": \"\",\n  \"ioidProjectStoreContract\": \"\"\n}",
+			"  \"endpoint\": \"\",\n  \"secureConnect\": true,\n  \"aliases\": {},\n  \"defaultAccount\": {\n    \"addressOrAlias\": \"test\"\n  },\n  \"explorer\": \"iotexscan\",\n  \"language\": \"English\",\n  \"nsv2height\": 0,\n  \"analyserEndpoint\": \"testAnalyser\",\n  \"wsEndpoint\": \"testWsEndpoint\",\n  \"ipfsEndpoint\": \"testIPFSEndpoint\",\n  \"ipfsGateway\": \"testIPFSGateway\",\n  \"wsProjectRegisterContract\": \"testWsProjectRegisterContract\",\n  \"wsProjectStoreContract\": \"testWsProjectStoreContract\",\n  \"wsFleetManagementContract\": \"testWsFleetManagementContract\",\n  \"wsProverStoreContract\": \"testWsProverStoreContract\",\n  \"wsProjectDevicesContract\": \"testWsProjectDevicesContract\",\n  \"wsRouterContract\": \"testWsRouterContract\",\n  \"wsVmTypeContract\": \"testWsVmTypeContract\",\n  \"ioidProjectRegisterContract\": \"\",\n  \"ioidProjectStoreContract\": \"\",\n  \"insResolverContract\": \"\",\n  \"chainID\": 0,\n  \"gasPrice\": \"\",\n  \"gasLimit\": 0,\n  \"profile\": \"\"\n}",
 		},
 	}
 
@@ -0,0 +1,211 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+)
+
+const (
+	// DIDPrefix is the prefix of the DID of an IoTeX account
+	DIDPrefix = "did:io:"
+	// INSSuffix is the suffix of an INS name
+	INSSuffix = ".io"
+
+	_insCacheFile = "ins.cache"
+	_insCacheTTL  = time.Hour
+	_insGasLimit  = 100000
+)
+
+// _addrSelector is the selector of addr(bytes32) of the INS resolver
+var _addrSelector = []byte{0x3b, 0x3b, 0x57, 0xde}
+
+type (
+	insCacheEntry struct {
+		Address string    `yaml:"address"`
+		Expiry  time.Time `yaml:"expiry"`
+	}
+
+	// insCache caches the resolved INS names in the config directory, so that the commands of ioctl, each running in
+	// a process of its own, do not query the resolver for the same name again
+	insCache struct {
+		mu      sync.Mutex
+		path    string
+		entries map[string]insCacheEntry
+	}
+)
+
+var _insNames = &insCache{}
+
+// IsINSName returns true if the input is an INS name, e.g., "alice.io"
+func IsINSName(in string) bool {
+	name := strings.ToLower(in)
+	return strings.HasSuffix(name, INSSuffix) && len(name) > len(INSSuffix) && !strings.ContainsAny(name, ":/")
+}
+
+// NameHash returns the node of the name, computed recursively from the labels as in ENS
+func NameHash(name string) [32]byte {
+	var node [32]byte
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := sha3.NewLegacyKeccak256()
+		label.Write([]byte(labels[i]))
+		sha := sha3.NewLegacyKeccak256()
+		sha.Write(node[:])
+		sha.Write(label.Sum(nil))
+		sha.Sum(node[:0])
+	}
+	return node
+}
+
+// resolveName resolves the DID or the INS name to an IoTeX address, ok being false if the input is neither
+func resolveName(in string) (addr string, ok bool, err error) {
+	switch {
+	case strings.HasPrefix(in, DIDPrefix):
+		addr, err = ResolveDID(in)
+		return addr, true, err
+	case IsINSName(in):
+		conn, err := ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+		if err != nil {
+			return "", true, output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+		}
+		defer conn.Close()
+		ctx := context.Background()
+		if jwtMD, err := JwtAuth(); err == nil {
+			ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+		}
+		addr, err = ResolveINS(ctx, iotexapi.NewAPIServiceClient(conn), config.ReadConfig.InsResolverContract, in)
+		return addr, true, err
+	default:
+		return "", false, nil
+	}
+}
+
+// ResolveDID returns the IoTeX address of the DID, e.g., "did:io:0x..."
+func ResolveDID(did string) (string, error) {
+	id := strings.TrimPrefix(did, DIDPrefix)
+	if common.IsHexAddress(id) {
+		a, err := address.FromHex(id)
+		if err != nil {
+			return "", output.NewError(output.AddressError, "", err)
+		}
+		return a.String(), nil
+	}
+	if _, err := address.FromString(id); err != nil {
+		return "", output.NewError(output.AddressError, "invalid DID "+did, err)
+	}
+	return id, nil
+}
+
+// ResolveINS resolves the name by addr(bytes32) of the INS resolver contract. The result is cached for an hour
+func ResolveINS(ctx context.Context, cli iotexapi.APIServiceClient, resolver, name string) (string, error) {
+	if resolver == "" {
+		return "", output.NewError(output.ConfigError,
+			`use "ioctl config set insResolverContract ADDRESS" to config the INS resolver first`, nil)
+	}
+	if common.IsHexAddress(resolver) {
+		a, err := address.FromHex(resolver)
+		if err != nil {
+			return "", output.NewError(output.AddressError, "invalid INS resolver contract", err)
+		}
+		resolver = a.String()
+	}
+	name = strings.ToLower(name)
+	// the same name may be resolved differently by the resolvers of the networks
+	key := resolver + "/" + name
+	if addr, ok := _insNames.get(key); ok {
+		return addr, nil
+	}
+	node := NameHash(name)
+	res, err := cli.ReadContract(ctx, &iotexapi.ReadContractRequest{
+		Execution: &iotextypes.Execution{
+			Amount:   "0",
+			Contract: resolver,
+			Data:     append(append([]byte{}, _addrSelector...), node[:]...),
+		},
+		CallerAddress: address.ZeroAddress,
+		GasLimit:      _insGasLimit,
+	})
+	if err != nil {
+		if sta, ok := status.FromError(err); ok {
+			return "", output.NewError(output.APIError, sta.Message(), nil)
+		}
+		return "", output.NewError(output.NetworkError, "failed to invoke ReadContract api", err)
+	}
+	data, err := hex.DecodeString(res.Data)
+	if err != nil || len(data) != 32 {
+		return "", output.NewError(output.ConvertError, "invalid result of INS resolver", err)
+	}
+	a, err := address.FromBytes(data[12:])
+	if err != nil {
+		return "", output.NewError(output.ConvertError, "invalid result of INS resolver", err)
+	}
+	if a.String() == address.ZeroAddress {
+		return "", output.NewError(output.AddressError, "cannot resolve INS name "+name, nil)
+	}
+	_insNames.put(key, a.String())
+	return a.String(), nil
+}
+
+func (c *insCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.Expiry) {
+		return "", false
+	}
+	return e.Address, true
+}
+
+func (c *insCache) put(key, addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	now := time.Now()
+	for k, e := range c.entries {
+		if now.After(e.Expiry) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = insCacheEntry{Address: addr, Expiry: now.Add(_insCacheTTL)}
+	// failing to write the cache only costs another query next time
+	if data, err := yaml.Marshal(c.entries); err == nil {
+		_ = os.WriteFile(c.path, data, 0600)
+	}
+}
+
+func (c *insCache) load() {
+	if c.entries != nil {
+		return
+	}
+	c.path = filepath.Join(config.ConfigDir, _insCacheFile)
+	c.entries = make(map[string]insCacheEntry)
+	if data, err := os.ReadFile(filepath.Clean(c.path)); err == nil {
+		_ = yaml.Unmarshal(data, &c.entries)
+	}
+}
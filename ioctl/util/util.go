@@ -153,8 +153,12 @@ func GetAddress(in string) (string, error) {
 	return Address(addr)
 }
 
-// Address returns the address corresponding to alias. if 'in' is an IoTeX address, returns 'in'
+// Address returns the address corresponding to alias. if 'in' is an IoTeX address, returns 'in'. A DID or an INS
+// name is resolved to the address it refers to
 func Address(in string) (string, error) {
+	if addr, ok, err := resolveName(in); ok {
+		return addr, err
+	}
 	// if in is an eth address, convert it to IoTeX address
 	if common.IsHexAddress(in) {
 		add, err := address.FromHex(in)
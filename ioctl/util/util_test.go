@@ -6,10 +6,16 @@
 package util
 
 import (
+	"context"
+	"encoding/hex"
 	"math/big"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	"github.com/iotexproject/iotex-core/v2/ioctl/config"
 )
@@ -119,4 +125,70 @@ func TestAddress(t *testing.T) {
 	_, err = Address("invalidalias")
 	require.Error(err)
 	require.ErrorContains(err, "cannot find address for alias")
+
+	// Test DID
+	addr, err = Address("did:io:0x3041a575c7a70021e3082929798c8c3fdaa9d824")
+	require.NoError(err)
+	require.Equal("io1xpq62aw85uqzrccg9y5hnryv8ld2nkpycc3gza", addr)
+	addr, err = Address("did:io:io1xpq62aw85uqzrccg9y5hnryv8ld2nkpycc3gza")
+	require.NoError(err)
+	require.Equal("io1xpq62aw85uqzrccg9y5hnryv8ld2nkpycc3gza", addr)
+	_, err = Address("did:io:invalid")
+	require.Error(err)
+}
+
+func TestNameHash(t *testing.T) {
+	require := require.New(t)
+	require.Equal([32]byte{}, NameHash(""))
+	node := NameHash("eth")
+	require.Equal("93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae", hex.EncodeToString(node[:]))
+	require.Equal(NameHash("alice.io"), NameHash("Alice.IO"))
+
+	require.True(IsINSName("alice.io"))
+	require.True(IsINSName("pay.alice.io"))
+	require.False(IsINSName(".io"))
+	require.False(IsINSName("did:io:alice.io"))
+	require.False(IsINSName("io1xpq62aw85uqzrccg9y5hnryv8ld2nkpycc3gza"))
+}
+
+func TestResolveINS(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	dir := config.ConfigDir
+	config.ConfigDir = t.TempDir()
+	_insNames = &insCache{}
+	defer func() {
+		config.ConfigDir = dir
+		_insNames = &insCache{}
+	}()
+
+	resolver := "io1xpq62aw85uqzrccg9y5hnryv8ld2nkpycc3gza"
+	_, err := ResolveINS(context.Background(), nil, "", "alice.io")
+	require.ErrorContains(err, "insResolverContract")
+
+	cli := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	node := NameHash("alice.io")
+	result := "000000000000000000000000" + "3041a575c7a70021e3082929798c8c3fdaa9d824"
+	cli.EXPECT().ReadContract(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.ReadContractRequest, _ ...grpc.CallOption) (*iotexapi.ReadContractResponse, error) {
+			require.Equal(resolver, in.Execution.Contract)
+			require.Equal(append([]byte{0x3b, 0x3b, 0x57, 0xde}, node[:]...), in.Execution.Data)
+			return &iotexapi.ReadContractResponse{Data: result}, nil
+		}).Times(1)
+	for i := 0; i < 2; i++ {
+		addr, err := ResolveINS(context.Background(), cli, resolver, "Alice.io")
+		require.NoError(err)
+		require.Equal("io1xpq62aw85uqzrccg9y5hnryv8ld2nkpycc3gza", addr)
+	}
+	// the cache persists across the processes of ioctl
+	_insNames = &insCache{}
+	addr, err := ResolveINS(context.Background(), cli, resolver, "alice.io")
+	require.NoError(err)
+	require.Equal("io1xpq62aw85uqzrccg9y5hnryv8ld2nkpycc3gza", addr)
+
+	cli.EXPECT().ReadContract(gomock.Any(), gomock.Any()).Return(&iotexapi.ReadContractResponse{
+		Data: hex.EncodeToString(make([]byte, 32)),
+	}, nil)
+	_, err = ResolveINS(context.Background(), cli, resolver, "bob.io")
+	require.ErrorContains(err, "cannot resolve INS name bob.io")
 }
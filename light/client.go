@@ -0,0 +1,393 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package light
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blocksync"
+	"github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const _delegatesCacheSize = 4
+
+// ErrNotSynced indicates the requested block is not synced yet
+var ErrNotSynced = errors.New("block is not synced yet")
+
+// Client is a light client, which syncs the block headers with the endorsements from an upstream node, and verifies
+// each header is committed by the delegates of its epoch. The delegates of each epoch are read from the upstream, and
+// cross-checked with the witnesses if any
+type Client struct {
+	cfg            Config
+	rp             *rolldpos.Protocol
+	genesisHash    hash.Hash256
+	checkpointHash hash.Hash256
+	kv             db.KVStore
+	store          *headerStore
+	conns          []*grpc.ClientConn
+	upstream       iotexapi.APIServiceClient
+	witnesses      []iotexapi.APIServiceClient
+
+	mu        sync.Mutex
+	delegates map[uint64]map[string]bool
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewClient creates a light client of the chain
+func NewClient(cfg Config, g genesis.Genesis) (*Client, error) {
+	if !cfg.Enabled() {
+		return nil, errors.New("upstream of light client is not set")
+	}
+	dbCfg := db.DefaultConfig
+	dbCfg.DbPath = cfg.DBPath
+	c, err := newClient(cfg, g, db.NewBoltDB(dbCfg))
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range append([]string{cfg.Upstream}, cfg.Witnesses...) {
+		conn, err := dial(endpoint, cfg.Insecure)
+		if err != nil {
+			c.closeConns()
+			return nil, err
+		}
+		c.conns = append(c.conns, conn)
+	}
+	c.upstream = iotexapi.NewAPIServiceClient(c.conns[0])
+	for _, conn := range c.conns[1:] {
+		c.witnesses = append(c.witnesses, iotexapi.NewAPIServiceClient(conn))
+	}
+	return c, nil
+}
+
+func newClient(cfg Config, g genesis.Genesis, kv db.KVStore) (*Client, error) {
+	if cfg.BatchSize == 0 {
+		return nil, errors.New("batch size of light client should be greater than 0")
+	}
+	var checkpointHash hash.Hash256
+	if cfg.Checkpoint.Height > 0 {
+		if _, err := blocksync.NewCheckpoints([]blocksync.Checkpoint{cfg.Checkpoint}); err != nil {
+			return nil, errors.Wrap(err, "invalid checkpoint of light client")
+		}
+		checkpointHash, _ = hash.HexStringToHash256(cfg.Checkpoint.Hash)
+	}
+	return &Client{
+		cfg: cfg,
+		rp: rolldpos.NewProtocol(
+			g.NumCandidateDelegates,
+			g.NumDelegates,
+			g.NumSubEpochs,
+			rolldpos.EnableDardanellesSubEpoch(g.DardanellesBlockHeight, g.DardanellesNumSubEpochs),
+		),
+		genesisHash:    g.Hash(),
+		checkpointHash: checkpointHash,
+		kv:             kv,
+		store:          newHeaderStore(kv),
+		delegates:      make(map[uint64]map[string]bool),
+	}, nil
+}
+
+func dial(endpoint string, insecureConn bool) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{}
+	if insecureConn {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})))
+	}
+	conn, err := grpc.NewClient(endpoint, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", endpoint)
+	}
+	return conn, nil
+}
+
+// Start starts syncing the headers
+func (c *Client) Start(ctx context.Context) error {
+	if err := c.kv.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start header store")
+	}
+	cctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.wg.Add(1)
+	go c.run(cctx)
+	return nil
+}
+
+// Stop stops syncing the headers
+func (c *Client) Stop(ctx context.Context) error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	c.closeConns()
+	return c.kv.Stop(ctx)
+}
+
+func (c *Client) closeConns() {
+	for _, conn := range c.conns {
+		if err := conn.Close(); err != nil {
+			log.L().Warn("Failed to close connection.", zap.String("target", conn.Target()), zap.Error(err))
+		}
+	}
+	c.conns = nil
+}
+
+func (c *Client) run(ctx context.Context) {
+	defer c.wg.Done()
+	for {
+		if err := c.sync(ctx); err != nil && ctx.Err() == nil {
+			log.L().Warn("Failed to sync headers.", zap.String("upstream", c.cfg.Upstream), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.cfg.RetryInterval):
+		}
+	}
+}
+
+// sync syncs the headers up to the tip of the upstream
+func (c *Client) sync(ctx context.Context) error {
+	meta, err := c.upstream.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get chain meta")
+	}
+	target := meta.GetChainMeta().GetHeight()
+	for {
+		tip, err := c.store.tip()
+		if err != nil {
+			return err
+		}
+		start := tip + 1
+		if tip == 0 && c.cfg.Checkpoint.Height > 0 {
+			start = c.cfg.Checkpoint.Height
+		}
+		if start > target {
+			return nil
+		}
+		count := min(c.cfg.BatchSize, target-start+1)
+		resp, err := c.upstream.GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+			StartHeight: start,
+			Count:       count,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get blocks from height %d", start)
+		}
+		if len(resp.GetBlocks()) == 0 {
+			return errors.Errorf("no block returned from height %d", start)
+		}
+		headers := make([]*header, 0, len(resp.GetBlocks()))
+		for _, blkInfo := range resp.GetBlocks() {
+			h, err := headerFromProto(blkInfo.GetBlock())
+			if err != nil {
+				return err
+			}
+			headers = append(headers, h)
+		}
+		if err := c.process(ctx, tip, headers); err != nil {
+			return err
+		}
+	}
+}
+
+// process verifies the headers following the tip and stores them
+func (c *Client) process(ctx context.Context, tip uint64, headers []*header) error {
+	var prevHash hash.Hash256
+	switch {
+	case tip > 0:
+		h, err := c.store.headerByHeight(tip)
+		if err != nil {
+			return err
+		}
+		prevHash = h.HashBlock()
+	case c.cfg.Checkpoint.Height > 0:
+		// the checkpoint is trusted, so is its parent
+		if headers[0].HashBlock() != c.checkpointHash {
+			return errors.Wrapf(ErrInvalidHeader, "block %d mismatches the checkpoint", headers[0].Height())
+		}
+		tip = c.cfg.Checkpoint.Height - 1
+		prevHash = headers[0].PrevHash()
+	default:
+		prevHash = c.genesisHash
+	}
+	finalized, err := c.store.finalized()
+	if err != nil {
+		return err
+	}
+	for _, h := range headers {
+		epoch := c.rp.GetEpochNum(h.Height())
+		delegates, err := c.delegatesOf(ctx, epoch)
+		if err != nil {
+			return err
+		}
+		if err := verifyHeader(h, tip+1, prevHash, delegates); err != nil {
+			return err
+		}
+		if h.Height() == c.rp.GetEpochLastBlockHeight(epoch) {
+			finalized = epoch
+		}
+		tip, prevHash = h.Height(), h.HashBlock()
+	}
+	return c.store.put(headers, finalized)
+}
+
+// delegatesOf returns the delegates of the epoch, which the upstream and the witnesses agree on
+func (c *Client) delegatesOf(ctx context.Context, epoch uint64) (map[string]bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if delegates, ok := c.delegates[epoch]; ok {
+		return delegates, nil
+	}
+	req := &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("poll"),
+		MethodName: []byte("ActiveBlockProducersByEpoch"),
+		Arguments:  [][]byte{[]byte(strconv.FormatUint(epoch, 10))},
+		Height:     strconv.FormatUint(c.rp.GetEpochHeight(epoch), 10),
+	}
+	resp, err := c.upstream.ReadState(ctx, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read delegates of epoch %d", epoch)
+	}
+	for i, witness := range c.witnesses {
+		wresp, err := witness.ReadState(ctx, req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read delegates of epoch %d from witness %d", epoch, i)
+		}
+		if !bytes.Equal(resp.GetData(), wresp.GetData()) {
+			return nil, errors.Errorf("witness %d disagrees on the delegates of epoch %d", i, epoch)
+		}
+	}
+	var candidates state.CandidateList
+	if err := candidates.Deserialize(resp.GetData()); err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize delegates of epoch %d", epoch)
+	}
+	if len(candidates) == 0 {
+		return nil, errors.Errorf("no delegate of epoch %d", epoch)
+	}
+	delegates := make(map[string]bool, len(candidates))
+	for _, cand := range candidates {
+		delegates[cand.Address] = true
+	}
+	if len(c.delegates) >= _delegatesCacheSize {
+		for e := range c.delegates {
+			if e < epoch {
+				delete(c.delegates, e)
+			}
+		}
+	}
+	c.delegates[epoch] = delegates
+	return delegates, nil
+}
+
+// TipHeight returns the height of the last verified header
+func (c *Client) TipHeight() (uint64, error) {
+	return c.store.tip()
+}
+
+// FinalizedEpoch returns the last epoch whose headers are all verified
+func (c *Client) FinalizedEpoch() (uint64, error) {
+	return c.store.finalized()
+}
+
+// HeaderByHeight returns the verified header and footer of the height
+func (c *Client) HeaderByHeight(height uint64) (*block.Header, *block.Footer, error) {
+	h, err := c.store.headerByHeight(height)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, nil, errors.Wrapf(ErrNotSynced, "height %d", height)
+		}
+		return nil, nil, err
+	}
+	return h.Header, h.footer, nil
+}
+
+// HeaderByHash returns the verified header and footer of the block hash
+func (c *Client) HeaderByHash(blkHash hash.Hash256) (*block.Header, *block.Footer, error) {
+	h, err := c.store.headerByHash(blkHash)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, nil, errors.Wrapf(ErrNotSynced, "hash %x", blkHash)
+		}
+		return nil, nil, err
+	}
+	return h.Header, h.footer, nil
+}
+
+// ReceiptProof returns the proof of the receipt of the action against the receipt root of a verified header. The
+// receipts of the block are read from the upstream, and the proof is verified before returned
+func (c *Client) ReceiptProof(ctx context.Context, actHash hash.Hash256) (*ReceiptProof, error) {
+	resp, err := c.upstream.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{
+		ActionHash: hex.EncodeToString(actHash[:]),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get receipt of action %x", actHash)
+	}
+	height := resp.GetReceiptInfo().GetReceipt().GetBlkHeight()
+	h, _, err := c.HeaderByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	blks, err := c.upstream.GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+		StartHeight:  height,
+		Count:        1,
+		WithReceipts: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get receipts of height %d", height)
+	}
+	if len(blks.GetBlocks()) != 1 {
+		return nil, errors.Errorf("no block returned at height %d", height)
+	}
+	receipts := blks.GetBlocks()[0].GetReceipts()
+	leaves := make([]hash.Hash256, len(receipts))
+	index := -1
+	for i, r := range receipts {
+		if leaves[i], err = receiptHash(r); err != nil {
+			return nil, err
+		}
+		if bytes.Equal(r.GetActHash(), actHash[:]) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, errors.Errorf("receipt of action %x is not in block %d", actHash, height)
+	}
+	siblings, err := crypto.MerkleProof(leaves, index)
+	if err != nil {
+		return nil, err
+	}
+	proof := &ReceiptProof{
+		Height:    height,
+		BlockHash: h.HashBlock(),
+		Receipt:   receipts[index],
+		Index:     index,
+		Siblings:  siblings,
+	}
+	if err := proof.Verify(h.ReceiptRoot()); err != nil {
+		return nil, errors.Wrapf(err, "upstream returns invalid receipts of height %d", height)
+	}
+	return proof, nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package light
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestClient(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	g := genesis.TestDefault()
+
+	var delegates state.CandidateList
+	for i := 1; i <= 4; i++ {
+		delegates = append(delegates, &state.Candidate{Address: identityset.Address(i).String(), Votes: big.NewInt(1)})
+	}
+	delegatesData, err := delegates.Serialize()
+	require.NoError(err)
+	actHashes := []hash.Hash256{hash.Hash256b([]byte{1}), hash.Hash256b([]byte{2})}
+	receipts := []*iotextypes.Receipt{
+		{Status: 1, BlkHeight: 2, ActHash: actHashes[0][:], GasConsumed: 10},
+		{Status: 1, BlkHeight: 2, ActHash: actHashes[1][:], GasConsumed: 20},
+	}
+	leaves := make([]hash.Hash256, len(receipts))
+	for i, r := range receipts {
+		leaves[i], err = receiptHash(r)
+		require.NoError(err)
+	}
+	newBlock := func(height uint64, prev hash.Hash256, producer int, endorsers ...int) *iotexapi.BlockInfo {
+		ts := time.Unix(1700000000+int64(height), 0)
+		blk, err := block.NewBuilder(block.NewRunnableActionsBuilder().Build()).
+			SetHeight(height).
+			SetPrevBlockHash(prev).
+			SetTimestamp(ts).
+			SetReceiptRoot(crypto.NewMerkleTree(leaves).HashTree()).
+			SignAndBuild(identityset.PrivateKey(producer))
+		require.NoError(err)
+		blkHash := blk.HashBlock()
+		var ens []*endorsement.Endorsement
+		for _, i := range endorsers {
+			en, err := endorsement.Endorse(identityset.PrivateKey(i), rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT), ts)
+			require.NoError(err)
+			ens = append(ens, en)
+		}
+		require.NoError(blk.Finalize(ens, ts))
+		return &iotexapi.BlockInfo{Block: blk.ConvertToBlockPb(), Receipts: receipts}
+	}
+	blk1 := newBlock(1, g.Hash(), 1, 1, 2, 3)
+	h1, err := headerFromProto(blk1.Block)
+	require.NoError(err)
+	blk2 := newBlock(2, h1.HashBlock(), 2, 1, 2, 3, 4)
+	h2, err := headerFromProto(blk2.Block)
+	require.NoError(err)
+
+	newTestClient := func() (*Client, *mock_iotexapi.MockAPIServiceClient) {
+		cfg := DefaultConfig
+		cfg.Upstream = "localhost:14014"
+		c, err := newClient(cfg, g, db.NewMemKVStore())
+		require.NoError(err)
+		upstream := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+		upstream.EXPECT().ReadState(gomock.Any(), gomock.Any()).Return(&iotexapi.ReadStateResponse{Data: delegatesData}, nil).AnyTimes()
+		c.upstream = upstream
+		require.NoError(c.kv.Start(ctx))
+		return c, upstream
+	}
+
+	t.Run("sync", func(t *testing.T) {
+		c, upstream := newTestClient()
+		upstream.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+			ChainMeta: &iotextypes.ChainMeta{Height: 2},
+		}, nil)
+		upstream.EXPECT().GetRawBlocks(gomock.Any(), &iotexapi.GetRawBlocksRequest{StartHeight: 1, Count: 2}).Return(&iotexapi.GetRawBlocksResponse{
+			Blocks: []*iotexapi.BlockInfo{blk1, blk2},
+		}, nil)
+		require.NoError(c.sync(ctx))
+		tip, err := c.TipHeight()
+		require.NoError(err)
+		require.Equal(uint64(2), tip)
+		h, f, err := c.HeaderByHash(h2.HashBlock())
+		require.NoError(err)
+		require.Equal(uint64(2), h.Height())
+		require.Len(f.Endorsements(), 4)
+		_, _, err = c.HeaderByHeight(3)
+		require.Equal(ErrNotSynced, errors.Cause(err))
+
+		upstream.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(&iotexapi.GetReceiptByActionResponse{
+			ReceiptInfo: &iotexapi.ReceiptInfo{Receipt: receipts[1]},
+		}, nil)
+		upstream.EXPECT().GetRawBlocks(gomock.Any(), &iotexapi.GetRawBlocksRequest{StartHeight: 2, Count: 1, WithReceipts: true}).Return(&iotexapi.GetRawBlocksResponse{
+			Blocks: []*iotexapi.BlockInfo{blk2},
+		}, nil)
+		proof, err := c.ReceiptProof(ctx, actHashes[1])
+		require.NoError(err)
+		require.Equal(1, proof.Index)
+		require.Equal(h2.HashBlock(), proof.BlockHash)
+		require.NoError(proof.Verify(h.ReceiptRoot()))
+	})
+	t.Run("insufficientEndorsements", func(t *testing.T) {
+		c, _ := newTestClient()
+		h, err := headerFromProto(newBlock(1, g.Hash(), 1, 1, 2).Block)
+		require.NoError(err)
+		require.Equal(ErrInsufficientEndorsements, errors.Cause(c.process(ctx, 0, []*header{h})))
+	})
+	t.Run("invalidEndorser", func(t *testing.T) {
+		c, _ := newTestClient()
+		h, err := headerFromProto(newBlock(1, g.Hash(), 1, 1, 2, 5).Block)
+		require.NoError(err)
+		require.Equal(ErrInvalidHeader, errors.Cause(c.process(ctx, 0, []*header{h})))
+	})
+	t.Run("fork", func(t *testing.T) {
+		c, _ := newTestClient()
+		h, err := headerFromProto(newBlock(2, hash.Hash256b([]byte("fork")), 2, 1, 2, 3).Block)
+		require.NoError(err)
+		require.NoError(c.process(ctx, 0, []*header{h1}))
+		require.Equal(ErrInvalidHeader, errors.Cause(c.process(ctx, 1, []*header{h})))
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package light
+
+import (
+	"time"
+
+	"github.com/iotexproject/iotex-core/v2/blocksync"
+)
+
+// Config is the config of the light client, which syncs the block headers and the endorsements only
+type Config struct {
+	// Upstream is the gRPC endpoint of the full node the headers are synced from, light mode is enabled if it is not
+	// empty
+	Upstream string `yaml:"upstream"`
+	Insecure bool   `yaml:"insecure"`
+	// Witnesses are the gRPC endpoints of other full nodes, which must agree with the upstream on the delegates of
+	// each epoch
+	Witnesses []string `yaml:"witnesses"`
+	// Checkpoint is the trusted block to start from, the client starts from the genesis if it is not set
+	Checkpoint    blocksync.Checkpoint `yaml:"checkpoint"`
+	BatchSize     uint64               `yaml:"batchSize"`
+	RetryInterval time.Duration        `yaml:"retryInterval"`
+	// DBPath is the path of the header store
+	DBPath string `yaml:"dbPath"`
+	// HTTPPort is the port serving the header and receipt proof queries, 0 to disable
+	HTTPPort int `yaml:"httpPort"`
+}
+
+// DefaultConfig is the default config of the light client
+var DefaultConfig = Config{
+	BatchSize:     100,
+	RetryInterval: 5 * time.Second,
+	Witnesses:     []string{},
+	DBPath:        "/var/data/light.db",
+	HTTPPort:      15016,
+}
+
+// Enabled returns true if light mode is enabled
+func (cfg Config) Enabled() bool {
+	return cfg.Upstream != ""
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package light
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+type (
+	statusResponse struct {
+		TipHeight      uint64 `json:"tipHeight"`
+		FinalizedEpoch uint64 `json:"finalizedEpoch"`
+	}
+
+	receiptProofResponse struct {
+		Height    uint64          `json:"height"`
+		BlockHash string          `json:"blockHash"`
+		Receipt   json.RawMessage `json:"receipt"`
+		Index     int             `json:"index"`
+		Siblings  []string        `json:"siblings"`
+	}
+)
+
+// Handler returns the http handler serving the queries, which serves the sync status at /status, the header and the
+// endorsements at /header/{height} or /header/0x{hash}, and the receipt proof of an action at /receipt/{hash}
+func (c *Client) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		tip, err := c.TipHeight()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		finalized, err := c.FinalizedEpoch()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, &statusResponse{TipHeight: tip, FinalizedEpoch: finalized})
+	})
+	mux.HandleFunc("GET /header/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var (
+			h   *block.Header
+			f   *block.Footer
+			err error
+		)
+		id := r.PathValue("id")
+		if height, perr := strconv.ParseUint(id, 10, 64); perr == nil {
+			h, f, err = c.HeaderByHeight(height)
+		} else {
+			blkHash, herr := hash.HexStringToHash256(id)
+			if herr != nil {
+				http.Error(w, "invalid height or hash", http.StatusBadRequest)
+				return
+			}
+			h, f, err = c.HeaderByHash(blkHash)
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		data, err := protojson.Marshal(&iotextypes.Block{Header: h.Proto(), Footer: f.Proto()})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+	mux.HandleFunc("GET /receipt/{hash}", func(w http.ResponseWriter, r *http.Request) {
+		actHash, err := hash.HexStringToHash256(r.PathValue("hash"))
+		if err != nil {
+			http.Error(w, "invalid action hash", http.StatusBadRequest)
+			return
+		}
+		proof, err := c.ReceiptProof(r.Context(), actHash)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		receipt, err := protojson.Marshal(proof.Receipt)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		resp := &receiptProofResponse{
+			Height:    proof.Height,
+			BlockHash: hex.EncodeToString(proof.BlockHash[:]),
+			Receipt:   receipt,
+			Index:     proof.Index,
+			Siblings:  make([]string, len(proof.Siblings)),
+		}
+		for i, s := range proof.Siblings {
+			resp.Siblings[i] = hex.EncodeToString(s[:])
+		}
+		writeJSON(w, resp)
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.L().Warn("Failed to write light client response.", zap.Error(err))
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Cause(err) == ErrNotSynced {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package light
+
+import (
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/crypto"
+)
+
+// ReceiptProof proves a receipt is included in the receipt root of a block
+type ReceiptProof struct {
+	Height    uint64
+	BlockHash hash.Hash256
+	Receipt   *iotextypes.Receipt
+	// Index is the index of the receipt in the block
+	Index int
+	// Siblings are the sibling hashes from the leaf up to the root
+	Siblings []hash.Hash256
+}
+
+// Verify verifies the proof against the receipt root of the block
+func (p *ReceiptProof) Verify(receiptRoot hash.Hash256) error {
+	leaf, err := receiptHash(p.Receipt)
+	if err != nil {
+		return err
+	}
+	if crypto.MerkleRoot(leaf, p.Index, p.Siblings) != receiptRoot {
+		return errors.New("receipt proof mismatches the receipt root")
+	}
+	return nil
+}
+
+// receiptHash is the hash of the receipt as a leaf of the receipt root
+func receiptHash(receipt *iotextypes.Receipt) (hash.Hash256, error) {
+	data, err := proto.Marshal(receipt)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.Hash256b(data), nil
+}
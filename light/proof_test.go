@@ -0,0 +1,38 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package light
+
+import (
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/crypto"
+)
+
+func TestReceiptProofVerify(t *testing.T) {
+	require := require.New(t)
+	receipts := []*iotextypes.Receipt{
+		{Status: 1, BlkHeight: 5, ActHash: []byte{1}, GasConsumed: 10},
+		{Status: 1, BlkHeight: 5, ActHash: []byte{2}, GasConsumed: 20},
+		{Status: 0, BlkHeight: 5, ActHash: []byte{3}, GasConsumed: 30},
+	}
+	leaves := make([]hash.Hash256, len(receipts))
+	for i, r := range receipts {
+		h, err := receiptHash(r)
+		require.NoError(err)
+		leaves[i] = h
+	}
+	root := crypto.NewMerkleTree(leaves).HashTree()
+	siblings, err := crypto.MerkleProof(leaves, 2)
+	require.NoError(err)
+	proof := &ReceiptProof{Height: 5, Receipt: receipts[2], Index: 2, Siblings: siblings}
+	require.NoError(proof.Verify(root))
+	proof.Receipt = &iotextypes.Receipt{Status: 1, BlkHeight: 5, ActHash: []byte{3}, GasConsumed: 30}
+	require.Error(proof.Verify(root))
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package light
+
+import (
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	_headerNS = "lightHeader"
+	_hashNS   = "lightHash"
+	_metaNS   = "lightMeta"
+)
+
+var (
+	_tipKey       = []byte("tip")
+	_finalizedKey = []byte("finalized")
+)
+
+type (
+	// headerStore stores the verified headers and footers by height
+	headerStore struct {
+		kv db.KVStore
+	}
+
+	// header is a block header with the endorsements of the block
+	header struct {
+		*block.Header
+		footer *block.Footer
+	}
+)
+
+func newHeaderStore(kv db.KVStore) *headerStore {
+	return &headerStore{kv: kv}
+}
+
+// tip returns the height of the last verified header, 0 if there is none
+func (s *headerStore) tip() (uint64, error) {
+	return s.meta(_tipKey)
+}
+
+// finalized returns the last epoch whose headers are all verified
+func (s *headerStore) finalized() (uint64, error) {
+	return s.meta(_finalizedKey)
+}
+
+func (s *headerStore) meta(key []byte) (uint64, error) {
+	v, err := s.kv.Get(_metaNS, key)
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64BigEndian(v), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// put stores the verified headers, which advance the tip
+func (s *headerStore) put(headers []*header, finalizedEpoch uint64) error {
+	if len(headers) == 0 {
+		return nil
+	}
+	b := batch.NewBatch()
+	for _, h := range headers {
+		data, err := proto.Marshal(&iotextypes.Block{
+			Header: h.Proto(),
+			Footer: h.footer.Proto(),
+		})
+		if err != nil {
+			return err
+		}
+		height := byteutil.Uint64ToBytesBigEndian(h.Height())
+		blkHash := h.HashBlock()
+		b.Put(_headerNS, height, data, "failed to put header")
+		b.Put(_hashNS, blkHash[:], height, "failed to put header hash")
+	}
+	b.Put(_metaNS, _tipKey, byteutil.Uint64ToBytesBigEndian(headers[len(headers)-1].Height()), "failed to put tip")
+	b.Put(_metaNS, _finalizedKey, byteutil.Uint64ToBytesBigEndian(finalizedEpoch), "failed to put finalized epoch")
+	return s.kv.WriteBatch(b)
+}
+
+func (s *headerStore) headerByHeight(height uint64) (*header, error) {
+	data, err := s.kv.Get(_headerNS, byteutil.Uint64ToBytesBigEndian(height))
+	if err != nil {
+		return nil, err
+	}
+	pb := &iotextypes.Block{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return nil, err
+	}
+	return headerFromProto(pb)
+}
+
+func (s *headerStore) headerByHash(h hash.Hash256) (*header, error) {
+	height, err := s.kv.Get(_hashNS, h[:])
+	if err != nil {
+		return nil, err
+	}
+	return s.headerByHeight(byteutil.BytesToUint64BigEndian(height))
+}
+
+// headerFromProto loads the header and the footer of the block, the body is ignored
+func headerFromProto(pb *iotextypes.Block) (*header, error) {
+	h := &header{
+		Header: &block.Header{},
+		footer: &block.Footer{},
+	}
+	if err := h.LoadFromBlockHeaderProto(pb.GetHeader()); err != nil {
+		return nil, errors.Wrap(err, "failed to load block header")
+	}
+	if err := h.footer.ConvertFromBlockFooterPb(pb.GetFooter()); err != nil {
+		return nil, errors.Wrap(err, "failed to load block footer")
+	}
+	return h, nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package light
+
+import (
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+)
+
+var (
+	// ErrInvalidHeader indicates the header fails the verification
+	ErrInvalidHeader = errors.New("invalid header")
+	// ErrInsufficientEndorsements indicates the header is not endorsed by the majority of the delegates
+	ErrInsufficientEndorsements = errors.New("insufficient endorsements")
+)
+
+// verifyHeader verifies the header follows the parent, is produced by a delegate, and is committed by more than 2/3
+// of the delegates of the epoch
+func verifyHeader(h *header, height uint64, prevHash hash.Hash256, delegates map[string]bool) error {
+	if h.Height() != height {
+		return errors.Wrapf(ErrInvalidHeader, "expect height %d, got %d", height, h.Height())
+	}
+	if h.PrevHash() != prevHash {
+		return errors.Wrapf(ErrInvalidHeader, "prev hash %x of height %d mismatches %x", h.PrevHash(), height, prevHash)
+	}
+	if !h.VerifySignature() {
+		return errors.Wrapf(ErrInvalidHeader, "invalid signature of height %d", height)
+	}
+	if !delegates[h.ProducerAddress()] {
+		return errors.Wrapf(ErrInvalidHeader, "producer %s of height %d is not a delegate", h.ProducerAddress(), height)
+	}
+	return verifyEndorsements(h, delegates)
+}
+
+// verifyEndorsements verifies the block is committed by more than 2/3 of the delegates
+func verifyEndorsements(h *header, delegates map[string]bool) error {
+	blkHash := h.HashBlock()
+	vote := rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT)
+	endorsers := make(map[string]bool, len(delegates))
+	for _, en := range h.footer.Endorsements() {
+		addr := en.Endorser().Address()
+		if addr == nil || !delegates[addr.String()] {
+			return errors.Wrapf(ErrInvalidHeader, "invalid endorser of height %d", h.Height())
+		}
+		if !endorsement.VerifyEndorsement(vote, en) {
+			return errors.Wrapf(ErrInvalidHeader, "invalid endorsement of %s at height %d", addr.String(), h.Height())
+		}
+		endorsers[addr.String()] = true
+	}
+	if 3*len(endorsers) <= 2*len(delegates) {
+		return errors.Wrapf(ErrInsufficientEndorsements, "%d of %d delegates endorsed height %d", len(endorsers), len(delegates), h.Height())
+	}
+	return nil
+}
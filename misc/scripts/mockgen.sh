@@ -173,6 +173,10 @@ mockgen -destination=./test/mock/mock_blockdao/mock_blockindexer_withstart.go  \
         -package=mock_blockdao \
         github.com/iotexproject/iotex-core/v2/blockchain/blockdao \
         BlockIndexerWithStart
+mockgen -destination=./test/mock/mock_blockdao/mock_blockindexer_withrollback.go  \
+        -package=mock_blockdao \
+        github.com/iotexproject/iotex-core/v2/blockchain/blockdao \
+        BlockIndexerWithRollback
 
 mkdir -p ./test/mock/mock_envelope
 mockgen -destination=./test/mock/mock_envelope/mock_envelope.go \
@@ -7,6 +7,8 @@ package nodeinfo
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"slices"
 	"sync/atomic"
 	"time"
@@ -22,6 +24,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/routine"
@@ -34,6 +37,7 @@ type (
 		BroadcastOutbound(context.Context, proto.Message) error
 		UnicastOutbound(context.Context, peer.AddrInfo, proto.Message) error
 		Info() (peer.AddrInfo, error)
+		PeerScores() []p2p.PeerScore
 	}
 
 	chain interface {
@@ -49,6 +53,12 @@ type (
 		PeerID    string
 	}
 
+	// PeerScore is the score of a peer, with the address of the node if its node info is received
+	PeerScore struct {
+		p2p.PeerScore
+		Address string `json:"address,omitempty"`
+	}
+
 	// InfoManager manage delegate node info
 	InfoManager struct {
 		lifecycle.Lifecycle
@@ -161,6 +171,32 @@ func (dm *InfoManager) GetNodeInfo(addr string) (Info, bool) {
 	return info.(Info), true
 }
 
+// PeerScores returns the scores of the peers
+func (dm *InfoManager) PeerScores() []PeerScore {
+	addrs := make(map[string]string)
+	dm.nodeMap.Range(func(_ lru.Key, value interface{}) bool {
+		info := value.(Info)
+		addrs[info.PeerID] = info.Address
+		return true
+	})
+	peerScores := dm.transmitter.PeerScores()
+	scores := make([]PeerScore, len(peerScores))
+	for i := range peerScores {
+		scores[i] = PeerScore{
+			PeerScore: peerScores[i],
+			Address:   addrs[peerScores[i].PeerID],
+		}
+	}
+	return scores
+}
+
+// HandlePeerScores handles the admin request of the peer scores
+func (dm *InfoManager) HandlePeerScores(w http.ResponseWriter, _ *http.Request) {
+	if err := json.NewEncoder(w).Encode(dm.PeerScores()); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
 // BroadcastNodeInfo broadcast request node info message
 func (dm *InfoManager) BroadcastNodeInfo(ctx context.Context) error {
 	log.L().Debug("nodeinfo manager broadcast node info")
@@ -19,6 +19,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_nodeinfo"
 )
 
@@ -262,3 +263,23 @@ func TestDelegateManager_GetNodeByAddr(t *testing.T) {
 	})
 
 }
+
+func TestPeerScores(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	privK, err := crypto.GenerateKey()
+	require.NoError(err)
+	hMock := mock_nodeinfo.NewMockchain(ctrl)
+	tMock := mock_nodeinfo.NewMocktransmitter(ctrl)
+	cfg := Config{false, 100 * time.Millisecond, 100 * time.Millisecond, 1000}
+	dm := NewInfoManager(&cfg, tMock, hMock, privK, getEmptyWhiteList)
+	dm.updateNode(&Info{Address: "io1delegate", PeerID: "peer1"})
+	tMock.EXPECT().PeerScores().Return([]p2p.PeerScore{
+		{PeerID: "peer1", Score: 10},
+		{PeerID: "peer2", Score: -30, Deprioritized: true},
+	}).Times(1)
+	require.Equal([]PeerScore{
+		{PeerScore: p2p.PeerScore{PeerID: "peer1", Score: 10}, Address: "io1delegate"},
+		{PeerScore: p2p.PeerScore{PeerID: "peer2", Score: -30, Deprioritized: true}},
+	}, dm.PeerScores())
+}
@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -25,7 +27,10 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 	goproto "github.com/iotexproject/iotex-proto/golang"
 	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/faultinject"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/routine"
@@ -69,6 +74,7 @@ const (
 	_unicastTopic      = "unicast"
 	_numDialRetries    = 8
 	_dialRetryInterval = 2 * time.Second
+	_dialTimeout       = 10 * time.Second
 )
 
 type (
@@ -97,6 +103,14 @@ type (
 		MaxMessageSize    int                 `yaml:"maxMessageSize"`
 		// AccountRateLimit is the maximum number of requests per second per account.
 		AccountRateLimit int `yaml:"accountRateLimit"`
+		// PeerScore is the config of peer scoring
+		PeerScore PeerScoreConfig `yaml:"peerScore"`
+		// PeerStore is the config of the persisted peer address book
+		PeerStore PeerStoreConfig `yaml:"peerStore"`
+		// ActionGossip is the config of the gossip policy of actions
+		ActionGossip GossipConfig `yaml:"actionGossip"`
+		// Compression is the config of the compression of large unicast messages
+		Compression CompressionConfig `yaml:"compression"`
 	}
 
 	// Agent is the agent to help the blockchain node connect into the P2P networks and send/receive messages
@@ -115,6 +129,10 @@ type (
 		ConnectedPeers() ([]peer.AddrInfo, error)
 		// BlockPeer blocks the peer in p2p layer
 		BlockPeer(string)
+		// ReportPeer reports an event of the peer, which changes the score of the peer
+		ReportPeer(string, PeerEvent)
+		// PeerScores returns the scores of the peers
+		PeerScores() []PeerScore
 	}
 
 	dummyAgent struct{}
@@ -130,6 +148,9 @@ type (
 		reconnectTimeout           time.Duration
 		reconnectTask              *routine.RecurringTask
 		qosMetrics                 *Qos
+		scorer                     *peerScorer
+		peerStore                  *peerStore
+		compressor                 *msgCompressor
 	}
 )
 
@@ -148,6 +169,10 @@ var DefaultConfig = Config{
 	MaxPeers:          30,
 	MaxMessageSize:    p2p.DefaultConfig.MaxMessageSize,
 	AccountRateLimit:  100,
+	PeerScore:         DefaultPeerScoreConfig,
+	PeerStore:         DefaultPeerStoreConfig,
+	ActionGossip:      DefaultGossipConfig,
+	Compression:       DefaultCompressionConfig,
 }
 
 // NewDummyAgent creates a dummy p2p agent
@@ -187,6 +212,12 @@ func (*dummyAgent) BlockPeer(string) {
 	return
 }
 
+func (*dummyAgent) ReportPeer(string, PeerEvent) {}
+
+func (*dummyAgent) PeerScores() []PeerScore {
+	return nil
+}
+
 func (*dummyAgent) BuildReport() string {
 	return ""
 }
@@ -194,7 +225,7 @@ func (*dummyAgent) BuildReport() string {
 // NewAgent instantiates a local P2P agent instance
 func NewAgent(cfg Config, chainID uint32, genesisHash hash.Hash256, broadcastHandler HandleBroadcastInbound, unicastHandler HandleUnicastInboundAsync) Agent {
 	log.L().Info("p2p agent", log.Hex("topicSuffix", genesisHash[22:]))
-	return &agent{
+	p := &agent{
 		cfg:     cfg,
 		chainID: chainID,
 		// Make sure the honest node only care the messages related the chain from the same genesis
@@ -204,6 +235,23 @@ func NewAgent(cfg Config, chainID uint32, genesisHash hash.Hash256, broadcastHan
 		reconnectTimeout:           cfg.ReconnectInterval,
 		qosMetrics:                 NewQoS(time.Now(), 2*cfg.ReconnectInterval),
 	}
+	if cfg.PeerScore.Enabled {
+		p.scorer = newPeerScorer(cfg.PeerScore, p.BlockPeer)
+	}
+	if cfg.PeerStore.DBPath != "" {
+		dbCfg := db.DefaultConfig
+		dbCfg.DbPath = cfg.PeerStore.DBPath
+		p.peerStore = newPeerStore(cfg.PeerStore, db.NewBoltDB(dbCfg))
+	}
+	if cfg.Compression.Enabled {
+		compressor, err := newMsgCompressor(cfg.Compression, cfg.MaxMessageSize)
+		if err != nil {
+			log.L().Error("Failed to create message compressor, compression is disabled.", zap.Error(err))
+		} else {
+			p.compressor = compressor
+		}
+	}
+	return p
 }
 
 func (p *agent) Start(ctx context.Context) error {
@@ -235,6 +283,11 @@ func (p *agent) Start(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "error when instantiating Agent host")
 	}
+	if p.peerStore != nil {
+		if err := p.peerStore.Start(ctx); err != nil {
+			return err
+		}
+	}
 
 	if err := host.AddBroadcastPubSub(ctx, _broadcastTopic+p.topicSuffix, func(ctx context.Context, data []byte) (err error) {
 		// Blocking handling the broadcast message until the agent is started
@@ -292,7 +345,7 @@ func (p *agent) Start(ctx context.Context) error {
 		return errors.Wrap(err, "error when adding broadcast pubsub")
 	}
 
-	if err := host.AddUnicastPubSub(_unicastTopic+p.topicSuffix, func(ctx context.Context, peerInfo peer.AddrInfo, data []byte) (err error) {
+	unicastHandler := func(ctx context.Context, peerInfo peer.AddrInfo, data []byte) (err error) {
 		// Blocking handling the unicast message until the agent is started
 		<-ready
 		var (
@@ -325,12 +378,30 @@ func (p *agent) Start(ctx context.Context) error {
 		t := unicast.GetTimestamp().AsTime()
 		latency = time.Since(t).Nanoseconds() / time.Millisecond.Nanoseconds()
 
+		if blk, ok := msg.(*iotextypes.Block); ok && p.scorer != nil {
+			p.scorer.onBlockResponse(peerID, blk.GetHeader().GetCore().GetHeight(), time.Now())
+		}
 		p.unicastInboundAsyncHandler(ctx, unicast.ChainId, peerInfo, msg)
 		p.qosMetrics.updateRecvUnicast(peerID, time.Now())
 		return
-	}); err != nil {
+	}
+	if err := host.AddUnicastPubSub(_unicastTopic+p.topicSuffix, unicastHandler); err != nil {
 		return errors.Wrap(err, "error when adding unicast pubsub")
 	}
+	if p.compressor != nil {
+		// serving the unicast protocol of a codec tells the peers the codec is accepted
+		for _, codec := range p.cfg.Compression.Codecs {
+			if err := host.AddUnicastPubSub(compressedUnicastTopic(codec)+p.topicSuffix, func(ctx context.Context, peerInfo peer.AddrInfo, data []byte) error {
+				raw, err := p.compressor.decompress(codec, data)
+				if err != nil {
+					return err
+				}
+				return unicastHandler(ctx, peerInfo, raw)
+			}); err != nil {
+				return errors.Wrapf(err, "error when adding %s unicast pubsub", codec)
+			}
+		}
+	}
 
 	// create boot nodes list except itself
 	hostName := host.HostIdentity()
@@ -346,8 +417,15 @@ func (p *agent) Start(ctx context.Context) error {
 	host.JoinOverlay()
 	p.host = host
 
-	// connect to bootstrap nodes
-	if err := p.connectBootNode(ctx); err != nil {
+	// connect to the known peers and bootstrap nodes, the bootstrap nodes are not waited for if any known peer is
+	// connected
+	if p.dialKnownPeers(ctx) > 0 {
+		go func() {
+			if err := p.connectBootNode(ctx); err != nil {
+				log.L().Warn("fail to connect bootnode", zap.Error(err))
+			}
+		}()
+	} else if err := p.connectBootNode(ctx); err != nil {
 		log.L().Error("fail to connect bootnode", zap.Error(err))
 		return err
 	}
@@ -373,9 +451,20 @@ func (p *agent) Stop(ctx context.Context) error {
 	if err := p.reconnectTask.Stop(ctx); err != nil {
 		return err
 	}
+	if p.peerStore != nil {
+		if err := p.peerStore.onConnected(p.host.ConnectedPeers(), time.Now()); err != nil {
+			log.L().Warn("failed to record connected peers", zap.Error(err))
+		}
+	}
 	if err := p.host.Close(); err != nil {
 		return errors.Wrap(err, "error when closing Agent host")
 	}
+	if p.compressor != nil {
+		p.compressor.close()
+	}
+	if p.peerStore != nil {
+		return p.peerStore.Stop(ctx)
+	}
 	return nil
 }
 
@@ -406,6 +495,9 @@ func (p *agent) BroadcastOutbound(ctx context.Context, msg proto.Message) (err e
 	if err != nil {
 		return
 	}
+	if err = faultinject.Check(faultinject.P2PBroadcast, msgType.String()); err != nil {
+		return
+	}
 	broadcast := iotexrpc.BroadcastMsg{
 		ChainId:   p.chainID,
 		PeerId:    host.HostIdentity(),
@@ -450,6 +542,9 @@ func (p *agent) UnicastOutbound(ctx context.Context, peer peer.AddrInfo, msg pro
 	if err != nil {
 		return
 	}
+	if err = faultinject.Check(faultinject.P2PUnicast, msgType.String(), peerName); err != nil {
+		return
+	}
 	unicast := iotexrpc.UnicastMsg{
 		ChainId:   p.chainID,
 		PeerId:    host.HostIdentity(),
@@ -464,15 +559,44 @@ func (p *agent) UnicastOutbound(ctx context.Context, peer peer.AddrInfo, msg pro
 	}
 
 	t := time.Now()
-	if err = host.Unicast(ctx, peer, _unicastTopic+p.topicSuffix, data); err != nil {
+	if err = p.unicast(ctx, host, peer, msgType, data); err != nil {
 		err = errors.Wrap(err, "error when sending unicast message")
 		p.qosMetrics.updateSendUnicast(peerName, t, false)
+		p.ReportPeer(peerName, PeerEventTimeout)
 		return
 	}
 	p.qosMetrics.updateSendUnicast(peerName, t, true)
+	if req, ok := msg.(*iotexrpc.BlockSync); ok && p.scorer != nil {
+		p.scorer.onSyncRequest(peerName, req.GetStart(), req.GetEnd(), t)
+	}
 	return
 }
 
+// unicast sends the message compressed by the codec negotiated with the peer, or uncompressed if the peer accepts
+// no codec
+func (p *agent) unicast(ctx context.Context, host *p2p.Host, peer peer.AddrInfo, msgType iotexrpc.MessageType, data []byte) error {
+	if p.compressor != nil && p.compressor.compressible(msgType, len(data)) {
+		peerName := peer.ID.String()
+		for _, codec := range p.compressor.codecs(peerName, time.Now()) {
+			compressed, err := p.compressor.compress(codec, data)
+			if err != nil {
+				log.L().Warn("Failed to compress unicast message.", zap.String("codec", codec), zap.Error(err))
+				break
+			}
+			if err = host.Unicast(ctx, peer, compressedUnicastTopic(codec)+p.topicSuffix, compressed); err == nil {
+				p.compressor.onAccepted(peerName, codec, len(data), len(compressed))
+				return nil
+			}
+			if ctx.Err() != nil {
+				return err
+			}
+			log.L().Debug("Peer rejected compressed unicast message.", zap.String("peer", peerName), zap.String("codec", codec), zap.Error(err))
+			p.compressor.onRejected(peerName, codec, time.Now())
+		}
+	}
+	return host.Unicast(ctx, peer, _unicastTopic+p.topicSuffix, data)
+}
+
 func (p *agent) Info() (peer.AddrInfo, error) {
 	if p.host == nil {
 		return peer.AddrInfo{}, ErrAgentNotStarted
@@ -491,7 +615,11 @@ func (p *agent) ConnectedPeers() ([]peer.AddrInfo, error) {
 	if p.host == nil {
 		return nil, ErrAgentNotStarted
 	}
-	return p.host.ConnectedPeers(), nil
+	peers := p.host.ConnectedPeers()
+	if p.scorer != nil {
+		peers = p.scorer.filter(peers, time.Now())
+	}
+	return peers, nil
 }
 
 func (p *agent) BlockPeer(pidStr string) {
@@ -502,6 +630,25 @@ func (p *agent) BlockPeer(pidStr string) {
 	p.host.BlockPeer(pid)
 }
 
+// ReportPeer reports an event of the peer. If peer scoring is disabled, the peer is blocked on invalid blocks
+func (p *agent) ReportPeer(pidStr string, event PeerEvent) {
+	if p.scorer == nil {
+		if event == PeerEventInvalidBlock {
+			p.BlockPeer(pidStr)
+		}
+		return
+	}
+	p.scorer.report(pidStr, event, time.Now())
+}
+
+// PeerScores returns the scores of the peers, or nil if peer scoring is disabled
+func (p *agent) PeerScores() []PeerScore {
+	if p.scorer == nil {
+		return nil
+	}
+	return p.scorer.scores(time.Now())
+}
+
 // BuildReport builds a report of p2p agent
 func (p *agent) BuildReport() string {
 	neighbors, err := p.ConnectedPeers()
@@ -558,10 +705,68 @@ func (p *agent) connectBootNode(ctx context.Context) error {
 	return nil
 }
 
+// dialKnownPeers dials the known peers in the peer store, and returns the number of the peers connected
+func (p *agent) dialKnownPeers(ctx context.Context) int {
+	if p.peerStore == nil {
+		return 0
+	}
+	var (
+		candidates = p.peerStore.candidates(p.host.ConnectedPeers(), p.cfg.PeerStore.DialPeers, time.Now())
+		wg         sync.WaitGroup
+		connected  atomic.Int32
+	)
+	for _, rec := range candidates {
+		wg.Add(1)
+		go func(rec *peerRecord) {
+			defer wg.Done()
+			start := time.Now()
+			err := p.dialPeer(ctx, rec)
+			if err == nil {
+				connected.Add(1)
+			} else {
+				log.L().Debug("failed to dial known peer", zap.String("peer", rec.ID), zap.Error(err))
+			}
+			if err := p.peerStore.onDialed(rec.ID, time.Since(start), err, time.Now()); err != nil {
+				log.L().Warn("failed to record dial result", zap.String("peer", rec.ID), zap.Error(err))
+			}
+		}(rec)
+	}
+	wg.Wait()
+	if len(candidates) > 0 {
+		log.L().Info("dialed known peers", zap.Int("candidates", len(candidates)), zap.Int32("connected", connected.Load()))
+	}
+	return int(connected.Load())
+}
+
+func (p *agent) dialPeer(ctx context.Context, rec *peerRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, _dialTimeout)
+	defer cancel()
+	err := errors.Errorf("no address of peer %s", rec.ID)
+	for _, addr := range rec.Addrs {
+		var ma multiaddr.Multiaddr
+		if ma, err = multiaddr.NewMultiaddr(addr); err != nil {
+			continue
+		}
+		if err = p.host.ConnectWithMultiaddr(ctx, ma); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 func (p *agent) reconnect() {
 	if p.host == nil {
 		return
 	}
+	if p.peerStore != nil {
+		connected := p.host.ConnectedPeers()
+		if err := p.peerStore.onConnected(connected, time.Now()); err != nil {
+			log.L().Warn("failed to record connected peers", zap.Error(err))
+		}
+		if len(connected) < p.cfg.PeerStore.DialPeers {
+			p.dialKnownPeers(context.Background())
+		}
+	}
 	if len(p.host.ConnectedPeers()) == 0 || p.qosMetrics.lostConnection() {
 		log.L().Info("network lost, try re-connecting.")
 		p.host.ClearBlocklist()
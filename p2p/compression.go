@@ -0,0 +1,224 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/compress"
+)
+
+// compression codecs of unicast messages
+const (
+	CodecZstd   = "zstd"
+	CodecSnappy = "snappy"
+)
+
+const _compressionPeerCacheSize = 1024
+
+var (
+	_p2pCompressionBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_p2p_compression_bytes",
+			Help: "Raw and compressed bytes of the compressed p2p messages",
+		},
+		[]string{"codec", "direction", "type"},
+	)
+	_p2pCompressionNegotiation = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_p2p_compression_negotiation",
+			Help: "Results of the compression negotiation with the peers",
+		},
+		[]string{"codec", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_p2pCompressionBytes)
+	prometheus.MustRegister(_p2pCompressionNegotiation)
+}
+
+type (
+	// CompressionConfig is the config of the compression of the large unicast messages, i.e., blocks and block
+	// requests. A node accepts a codec by serving the unicast protocol of the codec, so the codec of a connection is
+	// negotiated by the sender trying the codecs in order of preference, and falling back to the uncompressed
+	// protocol if the peer supports none. Broadcast messages are relayed by the whole network and not compressed
+	CompressionConfig struct {
+		Enabled bool `yaml:"enabled"`
+		// Codecs are the supported codecs in order of preference, zstd and snappy are supported
+		Codecs []string `yaml:"codecs"`
+		// MinSize is the min size of a message to be compressed
+		MinSize int `yaml:"minSize"`
+		// RenegotiateInterval is the interval before retrying a codec the peer does not support
+		RenegotiateInterval time.Duration `yaml:"renegotiateInterval"`
+	}
+
+	// msgCompressor compresses the unicast messages with the codecs negotiated with the peers
+	msgCompressor struct {
+		cfg     CompressionConfig
+		zstdDec *zstd.Decoder
+		maxSize int
+		mu      sync.Mutex
+		peers   cache.LRUCache
+	}
+
+	// peerCompression is the negotiation state of a peer
+	peerCompression struct {
+		// codec is the codec the peer accepted, empty if not negotiated yet
+		codec string
+		// unsupported is the time each codec was rejected by the peer
+		unsupported map[string]time.Time
+	}
+)
+
+// DefaultCompressionConfig is the default config of the compression
+var DefaultCompressionConfig = CompressionConfig{
+	Enabled:             true,
+	Codecs:              []string{CodecZstd, CodecSnappy},
+	MinSize:             4096,
+	RenegotiateInterval: time.Hour,
+}
+
+func newMsgCompressor(cfg CompressionConfig, maxSize int) (*msgCompressor, error) {
+	for _, codec := range cfg.Codecs {
+		if codec != CodecZstd && codec != CodecSnappy {
+			return nil, errors.Errorf("unsupported compression codec %s", codec)
+		}
+	}
+	opts := []zstd.DOption{zstd.WithDecoderConcurrency(0)}
+	if maxSize > 0 {
+		// limit the memory of decompressing a malicious message
+		opts = append(opts, zstd.WithDecoderMaxMemory(uint64(maxSize)))
+	}
+	dec, err := zstd.NewReader(nil, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create zstd decoder")
+	}
+	return &msgCompressor{
+		cfg:     cfg,
+		zstdDec: dec,
+		maxSize: maxSize,
+		peers:   cache.NewThreadSafeLruCache(_compressionPeerCacheSize),
+	}, nil
+}
+
+// compressible returns true if the message of the type and size should be compressed
+func (c *msgCompressor) compressible(msgType iotexrpc.MessageType, size int) bool {
+	if size < c.cfg.MinSize {
+		return false
+	}
+	return msgType == iotexrpc.MessageType_BLOCK || msgType == iotexrpc.MessageType_BLOCK_REQUEST
+}
+
+// codecs returns the codecs to try with the peer in order, which is the negotiated codec if any, otherwise the codecs
+// not rejected by the peer recently
+func (c *msgCompressor) codecs(peerID string, now time.Time) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pc := c.peer(peerID)
+	if pc.codec != "" {
+		return []string{pc.codec}
+	}
+	codecs := make([]string, 0, len(c.cfg.Codecs))
+	for _, codec := range c.cfg.Codecs {
+		if t, ok := pc.unsupported[codec]; ok && now.Sub(t) < c.cfg.RenegotiateInterval {
+			continue
+		}
+		codecs = append(codecs, codec)
+	}
+	return codecs
+}
+
+// onAccepted records the peer accepted the message compressed by the codec
+func (c *msgCompressor) onAccepted(peerID, codec string, rawSize, compressedSize int) {
+	c.mu.Lock()
+	pc := c.peer(peerID)
+	if pc.codec != codec {
+		pc.codec = codec
+		delete(pc.unsupported, codec)
+		_p2pCompressionNegotiation.WithLabelValues(codec, "accepted").Inc()
+	}
+	c.mu.Unlock()
+	_p2pCompressionBytes.WithLabelValues(codec, "out", "raw").Add(float64(rawSize))
+	_p2pCompressionBytes.WithLabelValues(codec, "out", "compressed").Add(float64(compressedSize))
+}
+
+// onRejected records the peer failed to accept the message compressed by the codec
+func (c *msgCompressor) onRejected(peerID, codec string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pc := c.peer(peerID)
+	if pc.codec == codec {
+		pc.codec = ""
+	}
+	pc.unsupported[codec] = now
+	_p2pCompressionNegotiation.WithLabelValues(codec, "rejected").Inc()
+}
+
+func (c *msgCompressor) peer(peerID string) *peerCompression {
+	if v, ok := c.peers.Get(peerID); ok {
+		return v.(*peerCompression)
+	}
+	pc := &peerCompression{unsupported: make(map[string]time.Time)}
+	c.peers.Add(peerID, pc)
+	return pc
+}
+
+func (c *msgCompressor) compress(codec string, data []byte) ([]byte, error) {
+	switch codec {
+	case CodecZstd:
+		return compress.CompZstd(data)
+	case CodecSnappy:
+		return compress.CompSnappy(data)
+	default:
+		return nil, errors.Errorf("unsupported compression codec %s", codec)
+	}
+}
+
+func (c *msgCompressor) decompress(codec string, data []byte) ([]byte, error) {
+	var (
+		raw []byte
+		err error
+	)
+	switch codec {
+	case CodecZstd:
+		raw, err = c.zstdDec.DecodeAll(data, nil)
+	case CodecSnappy:
+		var size int
+		if size, err = snappy.DecodedLen(data); err == nil {
+			if c.maxSize > 0 && size > c.maxSize {
+				return nil, errors.Errorf("decompressed size %d exceeds the max message size %d", size, c.maxSize)
+			}
+			raw, err = snappy.Decode(nil, data)
+		}
+	default:
+		return nil, errors.Errorf("unsupported compression codec %s", codec)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decompress %s message", codec)
+	}
+	_p2pCompressionBytes.WithLabelValues(codec, "in", "raw").Add(float64(len(raw)))
+	_p2pCompressionBytes.WithLabelValues(codec, "in", "compressed").Add(float64(len(data)))
+	return raw, nil
+}
+
+func (c *msgCompressor) close() {
+	c.zstdDec.Close()
+}
+
+// compressedUnicastTopic is the unicast topic of the messages compressed by the codec
+func compressedUnicastTopic(codec string) string {
+	return _unicastTopic + "-" + codec
+}
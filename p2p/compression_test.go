@@ -0,0 +1,70 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+)
+
+func TestMsgCompressor(t *testing.T) {
+	require := require.New(t)
+	_, err := newMsgCompressor(CompressionConfig{Codecs: []string{"gzip"}}, 0)
+	require.Error(err)
+
+	cfg := DefaultCompressionConfig
+	c, err := newMsgCompressor(cfg, 1<<16)
+	require.NoError(err)
+	defer c.close()
+
+	t.Run("compressible", func(t *testing.T) {
+		require.True(c.compressible(iotexrpc.MessageType_BLOCK, cfg.MinSize))
+		require.True(c.compressible(iotexrpc.MessageType_BLOCK_REQUEST, cfg.MinSize))
+		require.False(c.compressible(iotexrpc.MessageType_BLOCK, cfg.MinSize-1))
+		require.False(c.compressible(iotexrpc.MessageType_CONSENSUS, cfg.MinSize))
+	})
+	t.Run("codec", func(t *testing.T) {
+		data := bytes.Repeat([]byte("iotex"), 2000)
+		for _, codec := range cfg.Codecs {
+			compressed, err := c.compress(codec, data)
+			require.NoError(err)
+			require.Less(len(compressed), len(data))
+			raw, err := c.decompress(codec, compressed)
+			require.NoError(err)
+			require.Equal(data, raw)
+			_, err = c.decompress(codec, []byte("invalid"))
+			require.Error(err)
+		}
+		// the message decompressed beyond the max message size is rejected
+		compressed, err := c.compress(CodecSnappy, make([]byte, 1<<17))
+		require.NoError(err)
+		_, err = c.decompress(CodecSnappy, compressed)
+		require.Error(err)
+		compressed, err = c.compress(CodecZstd, make([]byte, 1<<17))
+		require.NoError(err)
+		_, err = c.decompress(CodecZstd, compressed)
+		require.Error(err)
+	})
+	t.Run("negotiation", func(t *testing.T) {
+		now := time.Now()
+		require.Equal([]string{CodecZstd, CodecSnappy}, c.codecs("peer1", now))
+		// the peer rejects zstd and accepts snappy
+		c.onRejected("peer1", CodecZstd, now)
+		require.Equal([]string{CodecSnappy}, c.codecs("peer1", now))
+		c.onAccepted("peer1", CodecSnappy, 100, 10)
+		require.Equal([]string{CodecSnappy}, c.codecs("peer1", now))
+		// the peer rejecting all codecs gets the uncompressed messages until renegotiation
+		c.onRejected("peer1", CodecSnappy, now)
+		require.Empty(c.codecs("peer1", now))
+		require.Equal([]string{CodecZstd, CodecSnappy}, c.codecs("peer1", now.Add(cfg.RenegotiateInterval)))
+		require.Equal([]string{CodecZstd, CodecSnappy}, c.codecs("peer2", now))
+	})
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+)
+
+// gossip modes of actions
+const (
+	// GossipFlood broadcasts the action to the whole network
+	GossipFlood = "flood"
+	// GossipSqrt sends the action to a random sample of sqrt(n) of the n connected peers, each of which relays it
+	// the same way when the action is added into its actpool
+	GossipSqrt = "sqrt"
+	// GossipPushPull sends the action to a random sample of sqrt(n) peers, and announces the hash of the action to
+	// the whole network, from which the other peers pull the action if missing
+	GossipPushPull = "pushpull"
+)
+
+type (
+	// GossipConfig is the config of the gossip policy of actions
+	GossipConfig struct {
+		// Mode is the default gossip mode
+		Mode string `yaml:"mode"`
+		// Rules overrides the gossip mode of the action types
+		Rules []GossipRule `yaml:"rules"`
+	}
+
+	// GossipRule is the gossip mode of an action type
+	GossipRule struct {
+		// ActionType is the type name of the action, e.g., Transfer, Execution, CreateStake
+		ActionType string `yaml:"actionType"`
+		Mode       string `yaml:"mode"`
+	}
+
+	// GossipPolicy decides how an action is propagated to the network
+	GossipPolicy struct {
+		mu    sync.RWMutex
+		mode  string
+		rules map[string]string
+	}
+)
+
+// DefaultGossipConfig is the default config of action gossip
+var DefaultGossipConfig = GossipConfig{
+	Mode:  GossipFlood,
+	Rules: []GossipRule{},
+}
+
+// NewGossipPolicy creates the gossip policy of the config
+func NewGossipPolicy(cfg GossipConfig) (*GossipPolicy, error) {
+	p := &GossipPolicy{}
+	if err := p.Update(cfg); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Update replaces the gossip modes by the config, the policy is unchanged if the config is invalid
+func (p *GossipPolicy) Update(cfg GossipConfig) error {
+	if err := validateGossipMode(cfg.Mode); err != nil {
+		return err
+	}
+	rules := make(map[string]string, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		if rule.ActionType == "" {
+			return errors.New("action type of gossip rule is empty")
+		}
+		if _, ok := rules[rule.ActionType]; ok {
+			return errors.Errorf("duplicate gossip rule of action type %s", rule.ActionType)
+		}
+		if err := validateGossipMode(rule.Mode); err != nil {
+			return err
+		}
+		rules[rule.ActionType] = rule.Mode
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mode, p.rules = cfg.Mode, rules
+	return nil
+}
+
+// Mode returns the gossip mode of the action type
+func (p *GossipPolicy) Mode(actionType string) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if mode, ok := p.rules[actionType]; ok {
+		return mode
+	}
+	return p.mode
+}
+
+// Sample returns a random sample of ceil(sqrt(n)) of the n peers
+func (p *GossipPolicy) Sample(peers []peer.AddrInfo) []peer.AddrInfo {
+	n := int(math.Ceil(math.Sqrt(float64(len(peers)))))
+	sample := make([]peer.AddrInfo, len(peers))
+	copy(sample, peers)
+	rand.Shuffle(len(sample), func(i, j int) {
+		sample[i], sample[j] = sample[j], sample[i]
+	})
+	return sample[:n]
+}
+
+func validateGossipMode(mode string) error {
+	switch mode {
+	case GossipFlood, GossipSqrt, GossipPushPull:
+		return nil
+	default:
+		return errors.Errorf("invalid gossip mode %s", mode)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGossipPolicy(t *testing.T) {
+	require := require.New(t)
+	for _, cfg := range []GossipConfig{
+		{Mode: ""},
+		{Mode: GossipFlood, Rules: []GossipRule{{ActionType: "Transfer", Mode: "random"}}},
+		{Mode: GossipFlood, Rules: []GossipRule{{Mode: GossipSqrt}}},
+		{Mode: GossipFlood, Rules: []GossipRule{{ActionType: "Transfer", Mode: GossipSqrt}, {ActionType: "Transfer", Mode: GossipFlood}}},
+	} {
+		_, err := NewGossipPolicy(cfg)
+		require.Error(err)
+	}
+
+	p, err := NewGossipPolicy(GossipConfig{
+		Mode:  GossipSqrt,
+		Rules: []GossipRule{{ActionType: "CreateStake", Mode: GossipFlood}, {ActionType: "Execution", Mode: GossipPushPull}},
+	})
+	require.NoError(err)
+	require.Equal(GossipSqrt, p.Mode("Transfer"))
+	require.Equal(GossipFlood, p.Mode("CreateStake"))
+	require.Equal(GossipPushPull, p.Mode("Execution"))
+
+	require.Error(p.Update(GossipConfig{Mode: "random"}))
+	require.Equal(GossipSqrt, p.Mode("Transfer"))
+	require.NoError(p.Update(GossipConfig{Mode: GossipFlood, Rules: []GossipRule{{ActionType: "Transfer", Mode: GossipSqrt}}}))
+	require.Equal(GossipSqrt, p.Mode("Transfer"))
+	require.Equal(GossipFlood, p.Mode("Execution"))
+
+	for n, expected := range map[int]int{0: 0, 1: 1, 2: 2, 16: 4, 17: 5, 100: 10} {
+		peers := make([]peer.AddrInfo, n)
+		for i := range peers {
+			peers[i].ID = peer.ID(fmt.Sprintf("peer%d", i))
+		}
+		sample := p.Sample(peers)
+		require.Len(sample, expected)
+		seen := make(map[peer.ID]struct{})
+		for _, s := range sample {
+			require.Contains(peers, s)
+			seen[s.ID] = struct{}{}
+		}
+		require.Len(seen, expected)
+	}
+}
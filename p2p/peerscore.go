@@ -0,0 +1,296 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// PeerEvent is an event of a peer which changes its score
+type PeerEvent int
+
+const (
+	// PeerEventInvalidBlock is reported when the peer sends a block failing to be validated or committed
+	PeerEventInvalidBlock PeerEvent = iota
+	// PeerEventStaleVote is reported when the peer sends a consensus message of a height already committed
+	PeerEventStaleVote
+	// PeerEventTimeout is reported when a message fails to be sent to the peer, or a block sync request to the
+	// peer is not responded in time
+	PeerEventTimeout
+	// PeerEventUselessResponse is reported when the peer sends a block which is not requested
+	PeerEventUselessResponse
+	// PeerEventUsefulResponse is reported when the peer responds a block sync request
+	PeerEventUsefulResponse
+)
+
+var (
+	_peerEventNames = map[PeerEvent]string{
+		PeerEventInvalidBlock:    "invalidBlock",
+		PeerEventStaleVote:       "staleVote",
+		PeerEventTimeout:         "timeout",
+		PeerEventUselessResponse: "uselessResponse",
+		PeerEventUsefulResponse:  "usefulResponse",
+	}
+	_peerEventScores = map[PeerEvent]float64{
+		PeerEventInvalidBlock:    -50,
+		PeerEventStaleVote:       -1,
+		PeerEventTimeout:         -5,
+		PeerEventUselessResponse: -2,
+		PeerEventUsefulResponse:  1,
+	}
+
+	_peerEventCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_p2p_peer_event_counter",
+			Help: "P2P peer scoring events",
+		},
+		[]string{"event"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_peerEventCounter)
+}
+
+// String returns the name of the event
+func (e PeerEvent) String() string {
+	if name, ok := _peerEventNames[e]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+type (
+	// PeerScoreConfig is the config of peer scoring. A peer starts from score 0, which is changed by the events of
+	// the peer and decays towards 0 over time
+	PeerScoreConfig struct {
+		Enabled bool `yaml:"enabled"`
+		// DeprioritizeThreshold is the score below which the peer is not selected for requests, unless all the
+		// connected peers are below it
+		DeprioritizeThreshold float64 `yaml:"deprioritizeThreshold"`
+		// BanThreshold is the score below which the peer is blocked
+		BanThreshold float64 `yaml:"banThreshold"`
+		// MaxScore is the max score a peer could accumulate
+		MaxScore float64 `yaml:"maxScore"`
+		// HalfLife is the duration for a score to decay to its half
+		HalfLife time.Duration `yaml:"halfLife"`
+		// RequestTimeout is the duration to wait for the response of a block sync request
+		RequestTimeout time.Duration `yaml:"requestTimeout"`
+	}
+
+	// PeerScore is the score of a peer
+	PeerScore struct {
+		PeerID        string  `json:"peerID"`
+		Score         float64 `json:"score"`
+		Deprioritized bool    `json:"deprioritized"`
+		// Events is the number of events reported of the peer since it was last banned
+		Events map[string]int `json:"events"`
+	}
+
+	peerScorer struct {
+		cfg      PeerScoreConfig
+		ban      func(string)
+		mutex    sync.Mutex
+		peers    map[string]*peerScoreEntry
+		requests map[string][]*syncRequest
+	}
+
+	peerScoreEntry struct {
+		score   float64
+		updated time.Time
+		events  map[PeerEvent]int
+	}
+
+	syncRequest struct {
+		start, end uint64
+		deadline   time.Time
+		responded  bool
+	}
+)
+
+// DefaultPeerScoreConfig is the default config of peer scoring
+var DefaultPeerScoreConfig = PeerScoreConfig{
+	Enabled:               false,
+	DeprioritizeThreshold: -20,
+	BanThreshold:          -100,
+	MaxScore:              50,
+	HalfLife:              10 * time.Minute,
+	RequestTimeout:        10 * time.Second,
+}
+
+func newPeerScorer(cfg PeerScoreConfig, ban func(string)) *peerScorer {
+	return &peerScorer{
+		cfg:      cfg,
+		ban:      ban,
+		peers:    make(map[string]*peerScoreEntry),
+		requests: make(map[string][]*syncRequest),
+	}
+}
+
+// report changes the score of the peer by the event, and bans the peer if its score drops below the threshold
+func (s *peerScorer) report(pid string, event PeerEvent, now time.Time) {
+	s.mutex.Lock()
+	banned := s.reportLocked(pid, event, now)
+	s.mutex.Unlock()
+	if banned {
+		s.ban(pid)
+	}
+}
+
+func (s *peerScorer) reportLocked(pid string, event PeerEvent, now time.Time) bool {
+	_peerEventCounter.WithLabelValues(event.String()).Inc()
+	entry, ok := s.peers[pid]
+	if !ok {
+		entry = &peerScoreEntry{events: make(map[PeerEvent]int)}
+		s.peers[pid] = entry
+	}
+	entry.decay(s.cfg.HalfLife, now)
+	entry.score = math.Min(entry.score+_peerEventScores[event], s.cfg.MaxScore)
+	entry.events[event]++
+	if entry.score > s.cfg.BanThreshold {
+		return false
+	}
+	log.L().Info("ban peer of low score",
+		zap.String("peer", pid),
+		zap.Float64("score", entry.score),
+		zap.String("event", event.String()),
+	)
+	// the peer starts over once the ban is lifted
+	delete(s.peers, pid)
+	delete(s.requests, pid)
+	return true
+}
+
+// score returns the current score of the peer
+func (s *peerScorer) score(pid string, now time.Time) float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entry, ok := s.peers[pid]
+	if !ok {
+		return 0
+	}
+	entry.decay(s.cfg.HalfLife, now)
+	return entry.score
+}
+
+// scores returns the scores of the peers in descending order
+func (s *peerScorer) scores(now time.Time) []PeerScore {
+	s.expireRequests(now)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	scores := make([]PeerScore, 0, len(s.peers))
+	for pid, entry := range s.peers {
+		entry.decay(s.cfg.HalfLife, now)
+		events := make(map[string]int, len(entry.events))
+		for event, n := range entry.events {
+			events[event.String()] = n
+		}
+		scores = append(scores, PeerScore{
+			PeerID:        pid,
+			Score:         entry.score,
+			Deprioritized: entry.score < s.cfg.DeprioritizeThreshold,
+			Events:        events,
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].PeerID < scores[j].PeerID
+	})
+	return scores
+}
+
+// filter removes the deprioritized peers, unless all the peers are deprioritized
+func (s *peerScorer) filter(peers []peer.AddrInfo, now time.Time) []peer.AddrInfo {
+	s.expireRequests(now)
+	filtered := make([]peer.AddrInfo, 0, len(peers))
+	for _, p := range peers {
+		if s.score(p.ID.String(), now) >= s.cfg.DeprioritizeThreshold {
+			filtered = append(filtered, p)
+		}
+	}
+	if len(filtered) == 0 {
+		return peers
+	}
+	return filtered
+}
+
+// onSyncRequest records the block sync request sent to the peer
+func (s *peerScorer) onSyncRequest(pid string, start, end uint64, now time.Time) {
+	s.expireRequests(now)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests[pid] = append(s.requests[pid], &syncRequest{
+		start:    start,
+		end:      end,
+		deadline: now.Add(s.cfg.RequestTimeout),
+	})
+}
+
+// onBlockResponse checks whether the block unicast by the peer is requested
+func (s *peerScorer) onBlockResponse(pid string, height uint64, now time.Time) {
+	s.expireRequests(now)
+	s.mutex.Lock()
+	event := PeerEventUselessResponse
+	for _, req := range s.requests[pid] {
+		if req.start <= height && height <= req.end {
+			req.responded = true
+			event = PeerEventUsefulResponse
+			break
+		}
+	}
+	banned := s.reportLocked(pid, event, now)
+	s.mutex.Unlock()
+	if banned {
+		s.ban(pid)
+	}
+}
+
+// expireRequests removes the expired block sync requests, and penalizes the peers not responding them
+func (s *peerScorer) expireRequests(now time.Time) {
+	var banned []string
+	s.mutex.Lock()
+	for pid, reqs := range s.requests {
+		remaining := reqs[:0]
+		timeout := false
+		for _, req := range reqs {
+			if now.Before(req.deadline) {
+				remaining = append(remaining, req)
+				continue
+			}
+			timeout = timeout || !req.responded
+		}
+		if len(remaining) == 0 {
+			delete(s.requests, pid)
+		} else {
+			s.requests[pid] = remaining
+		}
+		if timeout && s.reportLocked(pid, PeerEventTimeout, now) {
+			banned = append(banned, pid)
+		}
+	}
+	s.mutex.Unlock()
+	for _, pid := range banned {
+		s.ban(pid)
+	}
+}
+
+func (e *peerScoreEntry) decay(halfLife time.Duration, now time.Time) {
+	if !e.updated.IsZero() && halfLife > 0 && now.After(e.updated) {
+		e.score *= math.Pow(0.5, float64(now.Sub(e.updated))/float64(halfLife))
+	}
+	e.updated = now
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerScorer(t *testing.T) {
+	require := require.New(t)
+	var banned []string
+	s := newPeerScorer(DefaultPeerScoreConfig, func(pid string) {
+		banned = append(banned, pid)
+	})
+	var (
+		now     = time.Now()
+		peers   = []peer.AddrInfo{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+		a, b, c = peers[0].ID.String(), peers[1].ID.String(), peers[2].ID.String()
+	)
+
+	s.report(a, PeerEventTimeout, now)
+	s.report(a, PeerEventStaleVote, now)
+	require.Equal(-6.0, s.score(a, now))
+	// the score decays towards 0
+	require.InDelta(-3.0, s.score(a, now.Add(DefaultPeerScoreConfig.HalfLife)), 1e-9)
+	require.Zero(s.score(b, now))
+
+	// the score is capped
+	for i := 0; i < 100; i++ {
+		s.report(b, PeerEventUsefulResponse, now)
+	}
+	require.Equal(DefaultPeerScoreConfig.MaxScore, s.score(b, now))
+
+	// deprioritized peers are filtered, unless all peers are deprioritized
+	s.report(c, PeerEventInvalidBlock, now)
+	require.Equal(peers[:2], s.filter(peers, now))
+	require.Equal(peers[2:], s.filter(peers[2:], now))
+
+	scores := s.scores(now)
+	require.Len(scores, 3)
+	require.Equal(b, scores[0].PeerID)
+	require.Equal(c, scores[2].PeerID)
+	require.True(scores[2].Deprioritized)
+	require.Equal(map[string]int{"invalidBlock": 1}, scores[2].Events)
+
+	// peer is banned and starts over
+	s.report(c, PeerEventInvalidBlock, now)
+	require.Equal([]string{c}, banned)
+	require.Zero(s.score(c, now))
+}
+
+func TestPeerScorerSyncRequest(t *testing.T) {
+	require := require.New(t)
+	s := newPeerScorer(DefaultPeerScoreConfig, func(string) {})
+	now := time.Now()
+
+	s.onSyncRequest("a", 10, 20, now)
+	s.onSyncRequest("b", 10, 20, now)
+	s.onBlockResponse("a", 15, now)
+	require.Equal(1.0, s.score("a", now))
+	// block not requested
+	s.onBlockResponse("a", 30, now)
+	s.onBlockResponse("c", 15, now)
+	require.Equal(-1.0, s.score("a", now))
+	require.Equal(-2.0, s.score("c", now))
+
+	// b does not respond in time
+	later := now.Add(DefaultPeerScoreConfig.RequestTimeout)
+	s.scores(later)
+	require.InDelta(-5.0, s.score("b", later), 0.05)
+	require.InDelta(-1.0, s.score("a", later), 0.05)
+	require.Empty(s.requests)
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const _peerStoreNS = "peers"
+
+type (
+	// PeerStoreConfig is the config of the persisted peer address book, from which the known peers are dialed on
+	// restart, besides the bootstrap nodes
+	PeerStoreConfig struct {
+		// DBPath is the path of the peer store db, the peer store is disabled if it is empty
+		DBPath string `yaml:"dbPath"`
+		// MaxPeers is the max number of peers kept, the peers not seen for the longest time are evicted
+		MaxPeers int `yaml:"maxPeers"`
+		// DialPeers is the number of known peers dialed on start or on reconnect
+		DialPeers int `yaml:"dialPeers"`
+		// MinBackoff is the backoff after the first dial failure, which doubles on each consecutive failure
+		MinBackoff time.Duration `yaml:"minBackoff"`
+		// MaxBackoff is the max backoff of dialing a failed peer
+		MaxBackoff time.Duration `yaml:"maxBackoff"`
+	}
+
+	// peerRecord is the record of a known peer
+	peerRecord struct {
+		ID       string        `json:"id"`
+		Addrs    []string      `json:"addrs"`
+		LastSeen time.Time     `json:"lastSeen"`
+		Latency  time.Duration `json:"latency"`
+		Failures int           `json:"failures"`
+		NextDial time.Time     `json:"nextDial"`
+	}
+
+	peerStore struct {
+		cfg   PeerStoreConfig
+		kv    db.KVStore
+		mutex sync.Mutex
+		peers map[string]*peerRecord
+	}
+)
+
+// DefaultPeerStoreConfig is the default config of peer store
+var DefaultPeerStoreConfig = PeerStoreConfig{
+	DBPath:     "",
+	MaxPeers:   1000,
+	DialPeers:  10,
+	MinBackoff: 30 * time.Second,
+	MaxBackoff: time.Hour,
+}
+
+func newPeerStore(cfg PeerStoreConfig, kv db.KVStore) *peerStore {
+	return &peerStore{
+		cfg:   cfg,
+		kv:    kv,
+		peers: make(map[string]*peerRecord),
+	}
+}
+
+// Start loads the known peers
+func (s *peerStore) Start(ctx context.Context) error {
+	if err := s.kv.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start peer store db")
+	}
+	_, values, err := s.kv.Filter(_peerStoreNS, func(_, _ []byte) bool { return true }, nil, nil)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil
+	default:
+		return errors.Wrap(err, "failed to load peers")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, v := range values {
+		rec := &peerRecord{}
+		if err := json.Unmarshal(v, rec); err != nil {
+			log.L().Warn("failed to decode peer record", zap.Error(err))
+			continue
+		}
+		s.peers[rec.ID] = rec
+	}
+	log.L().Info("loaded known peers", zap.Int("peers", len(s.peers)))
+	return nil
+}
+
+// Stop closes the peer store db
+func (s *peerStore) Stop(ctx context.Context) error {
+	return s.kv.Stop(ctx)
+}
+
+// onConnected records the peers connected, whose backoff are reset
+func (s *peerStore) onConnected(peers []peer.AddrInfo, now time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b := batch.NewBatch()
+	for i := range peers {
+		addrs, err := peer.AddrInfoToP2pAddrs(&peers[i])
+		if err != nil || len(addrs) == 0 {
+			continue
+		}
+		id := peers[i].ID.String()
+		rec, ok := s.peers[id]
+		if !ok {
+			rec = &peerRecord{ID: id}
+			s.peers[id] = rec
+		}
+		rec.Addrs = rec.Addrs[:0]
+		for _, addr := range addrs {
+			rec.Addrs = append(rec.Addrs, addr.String())
+		}
+		rec.LastSeen = now
+		rec.Failures = 0
+		rec.NextDial = time.Time{}
+		if err := s.put(b, rec); err != nil {
+			return err
+		}
+	}
+	s.evict(b)
+	return s.kv.WriteBatch(b)
+}
+
+// onDialed records the result of dialing the peer
+func (s *peerStore) onDialed(id string, latency time.Duration, dialErr error, now time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	rec, ok := s.peers[id]
+	if !ok {
+		return nil
+	}
+	if dialErr == nil {
+		rec.LastSeen = now
+		rec.Latency = latency
+		rec.Failures = 0
+		rec.NextDial = time.Time{}
+	} else {
+		rec.Failures++
+		backoff := s.cfg.MinBackoff << (rec.Failures - 1)
+		if backoff <= 0 || backoff > s.cfg.MaxBackoff {
+			// overflow or capped
+			backoff = s.cfg.MaxBackoff
+		}
+		rec.NextDial = now.Add(backoff)
+	}
+	b := batch.NewBatch()
+	if err := s.put(b, rec); err != nil {
+		return err
+	}
+	return s.kv.WriteBatch(b)
+}
+
+// candidates returns the peers to dial, which are not connected and not backing off, in the order of failures,
+// last seen time, and latency
+func (s *peerStore) candidates(connected []peer.AddrInfo, n int, now time.Time) []*peerRecord {
+	skip := make(map[string]struct{}, len(connected))
+	for _, p := range connected {
+		skip[p.ID.String()] = struct{}{}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	recs := make([]*peerRecord, 0, len(s.peers))
+	for id, rec := range s.peers {
+		if _, ok := skip[id]; ok || rec.NextDial.After(now) {
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		switch {
+		case recs[i].Failures != recs[j].Failures:
+			return recs[i].Failures < recs[j].Failures
+		case !recs[i].LastSeen.Equal(recs[j].LastSeen):
+			return recs[i].LastSeen.After(recs[j].LastSeen)
+		default:
+			return recs[i].Latency < recs[j].Latency
+		}
+	})
+	if len(recs) > n {
+		recs = recs[:n]
+	}
+	// return copies, since the records are updated by the dial results
+	copies := make([]*peerRecord, len(recs))
+	for i, rec := range recs {
+		r := *rec
+		r.Addrs = append([]string{}, rec.Addrs...)
+		copies[i] = &r
+	}
+	return copies
+}
+
+// evict removes the peers not seen for the longest time beyond the capacity, it should be called with the lock held
+func (s *peerStore) evict(b batch.KVStoreBatch) {
+	if s.cfg.MaxPeers <= 0 || len(s.peers) <= s.cfg.MaxPeers {
+		return
+	}
+	recs := make([]*peerRecord, 0, len(s.peers))
+	for _, rec := range s.peers {
+		recs = append(recs, rec)
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		return recs[i].LastSeen.Before(recs[j].LastSeen)
+	})
+	for _, rec := range recs[:len(recs)-s.cfg.MaxPeers] {
+		delete(s.peers, rec.ID)
+		b.Delete(_peerStoreNS, []byte(rec.ID), "failed to delete peer")
+	}
+}
+
+func (s *peerStore) put(b batch.KVStoreBatch, rec *peerRecord) error {
+	v, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode peer record")
+	}
+	b.Put(_peerStoreNS, []byte(rec.ID), v, "failed to put peer")
+	return nil
+}
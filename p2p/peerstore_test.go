@@ -0,0 +1,93 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+)
+
+func TestPeerStore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dbCfg := db.DefaultConfig
+	dbCfg.DbPath = filepath.Join(t.TempDir(), "peers.db")
+	cfg := DefaultPeerStoreConfig
+	cfg.MaxPeers = 3
+	cfg.DialPeers = 2
+
+	peers := make([]peer.AddrInfo, 4)
+	for i := range peers {
+		sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(err)
+		id, err := peer.IDFromPrivateKey(sk)
+		require.NoError(err)
+		peers[i] = peer.AddrInfo{ID: id, Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1/tcp/4689")}}
+	}
+	a, b, c, d := peers[0].ID.String(), peers[1].ID.String(), peers[2].ID.String(), peers[3].ID.String()
+	ids := func(recs []*peerRecord) []string {
+		var ret []string
+		for _, rec := range recs {
+			ret = append(ret, rec.ID)
+		}
+		return ret
+	}
+
+	s := newPeerStore(cfg, db.NewBoltDB(dbCfg))
+	require.NoError(s.Start(ctx))
+	now := time.Now()
+	require.NoError(s.onConnected(peers[:1], now))
+	require.NoError(s.onConnected(peers[1:3], now.Add(time.Second)))
+	require.Len(s.peers, 3)
+	require.Equal("/ip4/127.0.0.1/tcp/4689/p2p/"+a, s.peers[a].Addrs[0])
+	// the connected peers are skipped, and the recently seen peers come first
+	require.Equal([]string{b, a}, ids(s.candidates(peers[2:3], 5, now)))
+	require.Len(s.candidates(nil, 2, now), 2)
+
+	// the backoff doubles on each failure and is capped
+	dialErr := errors.New("dial error")
+	require.NoError(s.onDialed(b, 0, dialErr, now))
+	require.Equal(now.Add(cfg.MinBackoff), s.peers[b].NextDial)
+	require.NoError(s.onDialed(b, 0, dialErr, now))
+	require.Equal(now.Add(2*cfg.MinBackoff), s.peers[b].NextDial)
+	for i := 0; i < 100; i++ {
+		require.NoError(s.onDialed(b, 0, dialErr, now))
+	}
+	require.Equal(now.Add(cfg.MaxBackoff), s.peers[b].NextDial)
+	require.Equal([]string{c, a}, ids(s.candidates(nil, 5, now)))
+	// the peer with failures comes last after the backoff
+	require.Equal([]string{c, a, b}, ids(s.candidates(nil, 5, now.Add(cfg.MaxBackoff))))
+
+	// the latency breaks the tie
+	require.NoError(s.onDialed(a, 2*time.Millisecond, nil, now))
+	require.NoError(s.onDialed(c, time.Millisecond, nil, now))
+	require.Equal([]string{c, a}, ids(s.candidates(nil, 5, now)))
+
+	// the peer not seen for the longest time is evicted
+	require.NoError(s.onConnected(peers[2:], now.Add(2*time.Second)))
+	require.Len(s.peers, 3)
+	require.NotContains(s.peers, a)
+	require.NoError(s.Stop(ctx))
+
+	// the peers are loaded on restart
+	s = newPeerStore(cfg, db.NewBoltDB(dbCfg))
+	require.NoError(s.Start(ctx))
+	defer s.Stop(ctx)
+	require.Len(s.peers, 3)
+	require.Equal(102, s.peers[b].Failures)
+	require.Equal([]string{d, c}, ids(s.candidates(nil, 5, now)))
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// the operations recorded in the audit log
+const (
+	// OpSignBlock is the operation of signing a block with the producer key
+	OpSignBlock = "signBlock"
+	// OpLoadConfig is the operation of loading the config of the node
+	OpLoadConfig = "loadConfig"
+	// OpAdmin is the operation of calling the admin service
+	OpAdmin = "admin"
+)
+
+// _maxEntrySize is the max size of an entry in the audit log file
+const _maxEntrySize = 1 << 20
+
+// ErrBrokenChain is the error when the hash chain of the audit log is broken, i.e., the log has been tampered with
+var ErrBrokenChain = errors.New("hash chain of audit log is broken")
+
+var _global atomic.Pointer[Log]
+
+type (
+	// Entry is a record of a privileged operation. The entries are chained by the hashes, the hash of an entry covers
+	// all its fields and the hash of the previous entry
+	Entry struct {
+		Seq       uint64            `json:"seq"`
+		Timestamp time.Time         `json:"timestamp"`
+		Operation string            `json:"operation"`
+		Details   map[string]string `json:"details,omitempty"`
+		PrevHash  string            `json:"prevHash"`
+		Hash      string            `json:"hash"`
+	}
+
+	// Log is an append-only audit log stored in a file, with an entry in json per line
+	Log struct {
+		mutex    sync.Mutex
+		path     string
+		file     *os.File
+		seq      uint64
+		lastHash string
+	}
+)
+
+// NewLog opens the audit log at the path, the existing entries are verified
+func NewLog(path string) (*Log, error) {
+	l := &Log{path: path}
+	if err := l.scan(func(*Entry) bool { return true }); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open audit log %s", path)
+	}
+	l.file = file
+	return l, nil
+}
+
+// Record appends the operation to the audit log
+func (l *Log) Record(op string, details map[string]string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	entry := &Entry{
+		Seq:       l.seq + 1,
+		Timestamp: time.Now().UTC(),
+		Operation: op,
+		Details:   details,
+		PrevHash:  l.lastHash,
+	}
+	h, err := entry.hash()
+	if err != nil {
+		return err
+	}
+	entry.Hash = h
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize audit log entry")
+	}
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return errors.Wrap(err, "failed to write audit log")
+	}
+	if err := l.file.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync audit log")
+	}
+	l.seq, l.lastHash = entry.Seq, entry.Hash
+	return nil
+}
+
+// Entries returns at most count entries starting from the sequence number start
+func (l *Log) Entries(start, count uint64) ([]*Entry, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	var entries []*Entry
+	if count == 0 {
+		return entries, nil
+	}
+	if err := l.scan(func(e *Entry) bool {
+		if e.Seq >= start {
+			entries = append(entries, e)
+		}
+		return uint64(len(entries)) < count
+	}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Close closes the audit log
+func (l *Log) Close() error {
+	return l.file.Close()
+}
+
+// scan verifies the entries in the file in order, and calls fn on each entry until it returns false
+func (l *Log) scan(fn func(*Entry) bool) error {
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to open audit log %s", l.path)
+	}
+	defer file.Close()
+	var (
+		seq      uint64
+		lastHash string
+		scanner  = bufio.NewScanner(file)
+	)
+	scanner.Buffer(make([]byte, 0, 4096), _maxEntrySize)
+	for scanner.Scan() {
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return errors.Wrapf(ErrBrokenChain, "invalid entry after %d: %v", seq, err)
+		}
+		if entry.Seq != seq+1 || entry.PrevHash != lastHash {
+			return errors.Wrapf(ErrBrokenChain, "entry %d does not follow entry %d", entry.Seq, seq)
+		}
+		if h, err := entry.hash(); err != nil || h != entry.Hash {
+			return errors.Wrapf(ErrBrokenChain, "hash mismatch of entry %d", entry.Seq)
+		}
+		seq, lastHash = entry.Seq, entry.Hash
+		if !fn(entry) {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrapf(err, "failed to read audit log %s", l.path)
+	}
+	l.seq, l.lastHash = seq, lastHash
+	return nil
+}
+
+func (e *Entry) hash() (string, error) {
+	unhashed := *e
+	unhashed.Hash = ""
+	data, err := json.Marshal(&unhashed)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to serialize audit log entry")
+	}
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// SetGlobal sets the global audit log of the node
+func SetGlobal(l *Log) {
+	_global.Store(l)
+}
+
+// Global returns the global audit log, which is nil if the audit log is disabled
+func Global() *Log {
+	return _global.Load()
+}
+
+// Record appends the operation to the global audit log, it is a no-op if the audit log is disabled
+func Record(op string, details map[string]string) {
+	l := Global()
+	if l == nil {
+		return
+	}
+	if err := l.Record(op, details); err != nil {
+		log.L().Error("failed to record audit log.", zap.String("operation", op), zap.Error(err))
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	l, err := NewLog(path)
+	require.NoError(err)
+	require.NoError(l.Record(OpLoadConfig, map[string]string{"digest": "abcd"}))
+	require.NoError(l.Record(OpSignBlock, map[string]string{"height": "1"}))
+	require.NoError(l.Close())
+
+	// the entries are chained across restarts
+	l, err = NewLog(path)
+	require.NoError(err)
+	require.NoError(l.Record(OpSignBlock, map[string]string{"height": "2"}))
+	entries, err := l.Entries(1, 10)
+	require.NoError(err)
+	require.Len(entries, 3)
+	for i, e := range entries {
+		require.Equal(uint64(i+1), e.Seq)
+		if i > 0 {
+			require.Equal(entries[i-1].Hash, e.PrevHash)
+		}
+	}
+	require.Equal("2", entries[2].Details["height"])
+	entries, err = l.Entries(2, 1)
+	require.NoError(err)
+	require.Len(entries, 1)
+	require.Equal(OpSignBlock, entries[0].Operation)
+	entries, err = l.Entries(4, 10)
+	require.NoError(err)
+	require.Empty(entries)
+	require.NoError(l.Close())
+
+	// tampered log
+	data, err := os.ReadFile(path)
+	require.NoError(err)
+	require.NoError(os.WriteFile(path, bytes.Replace(data, []byte(`"height":"1"`), []byte(`"height":"9"`), 1), 0600))
+	_, err = NewLog(path)
+	require.ErrorIs(err, ErrBrokenChain)
+}
+
+func TestGlobal(t *testing.T) {
+	require := require.New(t)
+	require.Nil(Global())
+	// no-op if the audit log is disabled
+	Record(OpAdmin, nil)
+
+	l, err := NewLog(filepath.Join(t.TempDir(), "audit.log"))
+	require.NoError(err)
+	defer l.Close()
+	SetGlobal(l)
+	defer SetGlobal(nil)
+	Record(OpAdmin, map[string]string{"method": "BlockPeer"})
+	entries, err := l.Entries(0, 10)
+	require.NoError(err)
+	require.Len(entries, 1)
+	require.Equal(OpAdmin, entries[0].Operation)
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pkg/errors"
 )
 
@@ -18,6 +19,7 @@ import (
 const (
 	Gzip   = "Gzip"
 	Snappy = "Snappy"
+	Zstd   = "Zstd"
 )
 
 // error definition
@@ -25,6 +27,13 @@ var (
 	ErrInputEmpty = errors.New("input cannot be empty")
 )
 
+var (
+	// the encoder and decoder are safe for concurrent use with EncodeAll and DecodeAll, the encoder writes a frame
+	// for empty input so that it is decompressed like the other compressors
+	_zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression), zstd.WithZeroFrames(true))
+	_zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+)
+
 // Compress compresses input according to compressor
 func Compress(value []byte, compressor string) ([]byte, error) {
 	if value == nil {
@@ -36,6 +45,8 @@ func Compress(value []byte, compressor string) ([]byte, error) {
 		return CompGzip(value)
 	case Snappy:
 		return CompSnappy(value)
+	case Zstd:
+		return CompZstd(value)
 	default:
 		panic("unsupported compressor")
 	}
@@ -48,6 +59,8 @@ func Decompress(value []byte, compressor string) ([]byte, error) {
 		return DecompGzip(value)
 	case Snappy:
 		return DecompSnappy(value)
+	case Zstd:
+		return DecompZstd(value)
 	default:
 		panic("unsupported compressor")
 	}
@@ -96,3 +109,23 @@ func DecompSnappy(data []byte) ([]byte, error) {
 	}
 	return v, err
 }
+
+// CompZstd uses zstd to compress the input bytes
+func CompZstd(data []byte) ([]byte, error) {
+	return _zstdEncoder.EncodeAll(data, nil), nil
+}
+
+// DecompZstd uses zstd to decompress the input bytes
+func DecompZstd(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, ErrInputEmpty
+	}
+	v, err := _zstdDecoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		v = []byte{}
+	}
+	return v, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package faultinject provides the hooks to inject failures into db writes, db syncs and p2p sends. The hooks are
+// no-op unless an injector is enabled, which is only done by tests to exercise the recovery paths deterministically.
+// The hooks are compiled in only with the faultinject build tag, so release builds have no injection points.
+package faultinject
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Point is the place where a failure can be injected
+type Point string
+
+const (
+	// DBWrite fails a db write before anything is written
+	DBWrite Point = "db.write"
+	// DBSync fails a db write after the data is written but before it is committed to disk, which is rolled back
+	// as if the node crashes during commit, it is only supported by bolt db
+	DBSync Point = "db.sync"
+	// P2PBroadcast fails a broadcast message before it is sent
+	P2PBroadcast Point = "p2p.broadcast"
+	// P2PUnicast fails a unicast message before it is sent
+	P2PUnicast Point = "p2p.unicast"
+)
+
+// ErrInjected is the default error returned by an injected failure
+var ErrInjected = errors.New("injected failure")
+
+type (
+	// Rule defines when a failure is injected
+	Rule struct {
+		// Point is where the failure is injected
+		Point Point
+		// Target is the db namespace, the p2p message type or the unicast peer id to fail, empty matches all
+		Target string
+		// Skip is the number of matched calls to pass before failing
+		Skip int
+		// Times is the number of failures to inject, 0 means failing all matched calls after skipped ones
+		Times int
+		// Err is the error returned by the failure, ErrInjected if nil
+		Err error
+	}
+
+	// Injector injects failures according to the rules, the first matched rule decides the result of a call
+	Injector struct {
+		mu       sync.Mutex
+		rules    []Rule
+		matched  []int
+		injected map[Point]int
+	}
+)
+
+// NewInjector creates an injector with the rules
+func NewInjector(rules ...Rule) *Injector {
+	return &Injector{
+		rules:    rules,
+		matched:  make([]int, len(rules)),
+		injected: make(map[Point]int),
+	}
+}
+
+// Check returns the injected failure of the call at the point on the targets
+func (i *Injector) Check(point Point, targets ...string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for idx := range i.rules {
+		r := &i.rules[idx]
+		if r.Point != point || !r.match(targets) {
+			continue
+		}
+		i.matched[idx]++
+		n := i.matched[idx] - r.Skip
+		if n <= 0 || (r.Times > 0 && n > r.Times) {
+			return nil
+		}
+		i.injected[point]++
+		if r.Err != nil {
+			return errors.Wrapf(r.Err, "failure injected at %s", point)
+		}
+		return errors.Wrapf(ErrInjected, "failure injected at %s", point)
+	}
+	return nil
+}
+
+// Injected returns the number of failures injected at the point
+func (i *Injector) Injected(point Point) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.injected[point]
+}
+
+func (r *Rule) match(targets []string) bool {
+	if r.Target == "" {
+		return true
+	}
+	for _, t := range targets {
+		if t == r.Target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build faultinject
+
+package faultinject
+
+import (
+	"sync/atomic"
+)
+
+var _injector atomic.Pointer[Injector]
+
+// Enable enables the injector globally, and returns the function to disable it
+func Enable(i *Injector) func() {
+	_injector.Store(i)
+	return func() {
+		_injector.CompareAndSwap(i, nil)
+	}
+}
+
+// Check returns the injected failure of the call at the point on the targets, nil if no injector is enabled
+func Check(point Point, targets ...string) error {
+	i := _injector.Load()
+	if i == nil {
+		return nil
+	}
+	return i.Check(point, targets...)
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build faultinject
+
+package faultinject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnable(t *testing.T) {
+	require := require.New(t)
+	// disabled by default
+	require.NoError(Check(DBWrite, "ns"))
+	i := NewInjector(Rule{Point: DBWrite, Target: "ns"})
+	disable := Enable(i)
+	require.Error(Check(DBWrite, "ns"))
+	require.NoError(Check(DBWrite, "ns2"))
+	require.Equal(1, i.Injected(DBWrite))
+
+	disable()
+	require.NoError(Check(DBWrite, "ns"))
+	// disabling a replaced injector has no effect
+	Enable(NewInjector(Rule{Point: DBWrite}))
+	disable()
+	require.Error(Check(DBWrite))
+	Enable(nil)
+	require.NoError(Check(DBWrite))
+}
@@ -0,0 +1,18 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build !faultinject
+
+package faultinject
+
+// Enable does nothing as the hooks are not built in, build with the faultinject tag to inject failures
+func Enable(*Injector) func() {
+	return func() {}
+}
+
+// Check returns nil as the hooks are not built in
+func Check(Point, ...string) error {
+	return nil
+}
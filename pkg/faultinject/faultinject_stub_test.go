@@ -0,0 +1,22 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build !faultinject
+
+package faultinject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableNotBuiltIn(t *testing.T) {
+	require := require.New(t)
+	i := NewInjector(Rule{Point: DBWrite})
+	defer Enable(i)()
+	require.NoError(Check(DBWrite, "ns"))
+	require.Zero(i.Injected(DBWrite))
+}
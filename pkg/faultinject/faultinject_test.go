@@ -0,0 +1,45 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package faultinject
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjector(t *testing.T) {
+	require := require.New(t)
+	errPartition := errors.New("partition")
+	i := NewInjector(
+		Rule{Point: P2PUnicast, Target: "peer1", Err: errPartition},
+		Rule{Point: P2PBroadcast, Target: "CONSENSUS", Skip: 2, Times: 2},
+		Rule{Point: DBSync, Times: 1},
+	)
+	// partition peer1 only
+	for j := 0; j < 3; j++ {
+		require.Equal(errPartition, errors.Cause(i.Check(P2PUnicast, "CONSENSUS", "peer1")))
+		require.NoError(i.Check(P2PUnicast, "CONSENSUS", "peer2"))
+	}
+	require.Equal(3, i.Injected(P2PUnicast))
+
+	// fail the 3rd and 4th consensus broadcast
+	var failed []int
+	for j := 0; j < 6; j++ {
+		require.NoError(i.Check(P2PBroadcast, "BLOCK"))
+		if err := i.Check(P2PBroadcast, "CONSENSUS"); err != nil {
+			require.Equal(ErrInjected, errors.Cause(err))
+			failed = append(failed, j)
+		}
+	}
+	require.Equal([]int{2, 3}, failed)
+
+	// empty target matches all
+	require.Error(i.Check(DBSync, "ns"))
+	require.NoError(i.Check(DBSync, "ns"))
+	require.NoError(i.Check(DBWrite, "ns"))
+}
@@ -32,6 +32,7 @@ var (
 	_logMu            sync.RWMutex
 	_logServeMux      = http.NewServeMux()
 	_subLoggers       map[string]*zap.Logger
+	_levels           map[string]zap.AtomicLevel
 	_globalLoggerName = "global"
 )
 
@@ -47,6 +48,7 @@ func init() {
 	_logMu.Lock()
 	_globalCfg.Zap = &zapCfg
 	_subLoggers = make(map[string]*zap.Logger)
+	_levels = map[string]zap.AtomicLevel{_globalLoggerName: zapCfg.Level}
 	_logMu.Unlock()
 	zap.ReplaceGlobals(l)
 }
@@ -132,6 +134,7 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 		} else {
 			_subLoggers[name] = logger
 		}
+		_levels[name] = cfg.Zap.Level
 		_logServeMux.HandleFunc("/"+name, cfg.Zap.Level.ServeHTTP)
 		_logMu.Unlock()
 	}
@@ -139,6 +142,21 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 	return nil
 }
 
+// SetLevel sets the level of the sub logger of the name, or the global logger if the name is empty
+func SetLevel(name string, level zapcore.Level) error {
+	if name == "" {
+		name = _globalLoggerName
+	}
+	_logMu.RLock()
+	defer _logMu.RUnlock()
+	l, ok := _levels[name]
+	if !ok {
+		return errors.Errorf("logger %s is not found", name)
+	}
+	l.SetLevel(level)
+	return nil
+}
+
 // RegisterLevelConfigMux registers log's level config http mux.
 func RegisterLevelConfigMux(root *http.ServeMux) {
 	_logMu.Lock()
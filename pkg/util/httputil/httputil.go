@@ -39,6 +39,13 @@ func ReadHeaderTimeout(h time.Duration) ServerOption {
 	}
 }
 
+// WriteTimeout sets write timeout
+func WriteTimeout(h time.Duration) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.WriteTimeout = h
+	}
+}
+
 // NewServer creates a HTTP server with time out settings.
 func NewServer(addr string, handler http.Handler, opts ...ServerOption) http.Server {
 	cfg := DefaultServerConfig
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/injector"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// isInjectCommand returns true if the server runs the inject subcommand
+func isInjectCommand() bool {
+	return len(os.Args) > 1 && os.Args[1] == "inject"
+}
+
+// runInjectCommand injects the actions into the endpoint of a devnet for load test, and prints the report
+func runInjectCommand(args []string) {
+	var (
+		fs            = flag.NewFlagSet("inject", flag.ExitOnError)
+		cfg           = injector.DefaultConfig
+		mix           string
+		data          string
+		stakeDuration uint
+	)
+	fs.StringVar(&cfg.Endpoint, "endpoint", cfg.Endpoint, "gRPC endpoint to inject the actions into")
+	fs.BoolVar(&cfg.Insecure, "insecure", cfg.Insecure, "Connect to the endpoint without TLS")
+	fs.StringVar(&cfg.KeyFile, "key-file", "", "Yaml file of the key pairs of the funded accounts sending the actions")
+	fs.IntVar(&cfg.TPS, "tps", cfg.TPS, "Targeted number of actions injected per second")
+	fs.DurationVar(&cfg.Duration, "duration", cfg.Duration, "Duration of the injection")
+	fs.IntVar(&cfg.Workers, "workers", cfg.Workers, "Number of workers sending the actions")
+	fs.StringVar(&mix, "mix", "transfer=1", "Weights of the actions, e.g., transfer=8,execution=1,stake=1")
+	fs.StringVar(&cfg.GasPrice, "gas-price", cfg.GasPrice, "Gas price in rau")
+	fs.StringVar(&cfg.TransferAmount, "transfer-amount", cfg.TransferAmount, "Amount in rau of each transfer")
+	fs.Uint64Var(&cfg.TransferGasLimit, "transfer-gas-limit", cfg.TransferGasLimit, "Gas limit of each transfer")
+	fs.StringVar(&cfg.Contract, "contract", "", "Contract called by the executions")
+	fs.StringVar(&data, "execution-data", "", "Hex encoded calldata of the executions")
+	fs.StringVar(&cfg.ExecutionAmount, "execution-amount", cfg.ExecutionAmount, "Amount in rau of each execution")
+	fs.Uint64Var(&cfg.ExecutionGasLimit, "execution-gas-limit", cfg.ExecutionGasLimit, "Gas limit of each execution")
+	fs.StringVar(&cfg.StakeCandidate, "stake-candidate", "", "Name of the candidate the stakes vote for")
+	fs.StringVar(&cfg.StakeAmount, "stake-amount", cfg.StakeAmount, "Amount in rau of each stake")
+	fs.UintVar(&stakeDuration, "stake-duration", 0, "Duration in days of each stake")
+	fs.Uint64Var(&cfg.StakeGasLimit, "stake-gas-limit", cfg.StakeGasLimit, "Gas limit of each stake")
+	fs.BoolVar(&cfg.Confirm, "confirm", cfg.Confirm, "Wait for the receipts and measure the confirmation latency")
+	fs.DurationVar(&cfg.ConfirmTimeout, "confirm-timeout", cfg.ConfirmTimeout, "Timeout of waiting for a receipt")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "usage: server inject -key-file=[string] [flags]\n")
+		fs.PrintDefaults()
+		os.Exit(2)
+	}
+	if err := fs.Parse(args); err != nil || cfg.KeyFile == "" {
+		fs.Usage()
+	}
+	cfg.StakeDuration = uint32(stakeDuration)
+	var err error
+	if cfg.Mix, err = injector.ParseMix(mix); err != nil {
+		log.L().Fatal("Invalid action mix.", zap.Error(err))
+	}
+	if cfg.ExecutionData, err = hex.DecodeString(strings.TrimPrefix(data, "0x")); err != nil {
+		log.L().Fatal("Invalid execution data.", zap.Error(err))
+	}
+
+	inj, err := injector.New(cfg)
+	if err != nil {
+		log.L().Fatal("Failed to create injector.", zap.Error(err))
+	}
+	defer func() {
+		if err := inj.Close(); err != nil {
+			log.L().Error("Failed to close injector.", zap.Error(err))
+		}
+	}()
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	report, err := inj.Run(ctx)
+	if err != nil {
+		log.L().Error("Failed to inject actions.", zap.Error(err))
+		return
+	}
+	fmt.Print(report)
+}
@@ -8,9 +8,12 @@ package itx
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/iotexproject/go-fsm"
 	"github.com/pkg/errors"
@@ -51,11 +54,42 @@ func init() {
 	prometheus.MustRegister(_versionMtc)
 }
 
-// HeartbeatHandler is the handler to periodically log the system key metrics
-type HeartbeatHandler struct {
-	s *Server
-	l *zap.Logger
-}
+type (
+	// HeartbeatHandler is the handler to periodically log the system key metrics, which are also published as
+	// prometheus gauges, and served as a json document by ServeHTTP
+	HeartbeatHandler struct {
+		s *Server
+		l *zap.Logger
+
+		mu     sync.RWMutex
+		status *HeartbeatStatus
+	}
+
+	// HeartbeatStatus is the key metrics of the node collected in the last heartbeat
+	HeartbeatStatus struct {
+		Timestamp               time.Time      `json:"timestamp"`
+		OperatorAddress         string         `json:"operatorAddress"`
+		PackageVersion          string         `json:"packageVersion"`
+		NumConnectedPeers       int            `json:"numConnectedPeers"`
+		PendingDispatcherEvents map[string]int `json:"pendingDispatcherEvents"`
+		Chains                  []*ChainStatus `json:"chains"`
+	}
+
+	// ChainStatus is the key metrics of a chain service
+	ChainStatus struct {
+		ChainID               uint32 `json:"chainID"`
+		BlockchainHeight      uint64 `json:"blockchainHeight"`
+		TargetHeight          uint64 `json:"targetHeight"`
+		ActpoolSize           uint64 `json:"actpoolSize"`
+		ActpoolCapacity       uint64 `json:"actpoolCapacity"`
+		ActpoolGasInPool      uint64 `json:"actpoolGasInPool"`
+		PendingRolldposEvents int    `json:"pendingRolldposEvents"`
+		FSMState              string `json:"fsmState"`
+		ConsensusEpoch        uint64 `json:"consensusEpoch"`
+		ConsensusHeight       uint64 `json:"consensusHeight"`
+		ConsensusRound        uint32 `json:"consensusRound"`
+	}
+)
 
 // NewHeartbeatHandler instantiates a HeartbeatHandler instance
 func NewHeartbeatHandler(s *Server, cfg p2p.Config) *HeartbeatHandler {
@@ -83,6 +117,7 @@ func (h *HeartbeatHandler) Log() {
 	for event, num := range numDPEvts {
 		totalDPEventNumber += num
 		events = append(events, event+":"+strconv.Itoa(num))
+		_heartbeatMtc.WithLabelValues("dispatcherQueueSize", event).Set(float64(num))
 	}
 	dpEvtsAudit, err := json.Marshal(dp.EventAudit())
 	if err != nil {
@@ -105,8 +140,16 @@ func (h *HeartbeatHandler) Log() {
 
 	_heartbeatMtc.WithLabelValues("numConnectedPeers", "node").Set(float64(numPeers))
 	_heartbeatMtc.WithLabelValues("pendingDispatcherEvents", "node").Set(float64(totalDPEventNumber))
+	status := &HeartbeatStatus{
+		Timestamp:               time.Now(),
+		OperatorAddress:         cfg.ProducerAddress().String(),
+		PackageVersion:          version.PackageVersion,
+		NumConnectedPeers:       numPeers,
+		PendingDispatcherEvents: numDPEvts,
+	}
 	// chain service
-	for _, c := range h.s.chainservices {
+	for _, id := range h.s.ChainIDs() {
+		c := h.s.ChainService(id)
 		// Consensus metrics
 		cs, ok := c.Consensus().(*consensus.IotxConsensus)
 		if !ok {
@@ -119,11 +162,16 @@ func (h *HeartbeatHandler) Log() {
 		consensusHeight := uint64(0)
 		height := c.Blockchain().TipHeight()
 
+		consensusRound := uint32(0)
+
 		var consensusMetrics scheme.ConsensusMetrics
 		var state fsm.State
 		if ok {
 			numPendingEvts = rolldpos.NumPendingEvts()
 			state = rolldpos.CurrentState()
+			if roundState, err := rolldpos.State(); err == nil {
+				consensusRound = roundState.Round
+			}
 
 			// RollDpos Consensus Metrics
 			consensusMetrics, err = rolldpos.Metrics()
@@ -157,8 +205,9 @@ func (h *HeartbeatHandler) Log() {
 		)
 
 		chainIDStr := strconv.FormatUint(uint64(c.ChainID()), 10)
-		_heartbeatMtc.WithLabelValues("consensusEpoch", chainIDStr).Set(float64(consensusHeight))
-		_heartbeatMtc.WithLabelValues("consensusRound", chainIDStr).Set(float64(consensusEpoch))
+		_heartbeatMtc.WithLabelValues("consensusEpoch", chainIDStr).Set(float64(consensusEpoch))
+		_heartbeatMtc.WithLabelValues("consensusHeight", chainIDStr).Set(float64(consensusHeight))
+		_heartbeatMtc.WithLabelValues("consensusRound", chainIDStr).Set(float64(consensusRound))
 		_heartbeatMtc.WithLabelValues("pendingRolldposEvents", chainIDStr).Set(float64(numPendingEvts))
 		_heartbeatMtc.WithLabelValues("blockchainHeight", chainIDStr).Set(float64(height))
 		_heartbeatMtc.WithLabelValues("actpoolSize", chainIDStr).Set(float64(actPoolSize))
@@ -168,12 +217,54 @@ func (h *HeartbeatHandler) Log() {
 		_heartbeatMtc.WithLabelValues("packageVersion", version.PackageVersion).Set(1)
 		_heartbeatMtc.WithLabelValues("packageCommitID", version.PackageCommitID).Set(1)
 		_heartbeatMtc.WithLabelValues("goVersion", version.GoVersion).Set(1)
+		status.Chains = append(status.Chains, &ChainStatus{
+			ChainID:               c.ChainID(),
+			BlockchainHeight:      height,
+			TargetHeight:          targetHeight,
+			ActpoolSize:           actPoolSize,
+			ActpoolCapacity:       actPoolCapacity,
+			ActpoolGasInPool:      c.ActionPool().GetGasSize(),
+			PendingRolldposEvents: numPendingEvts,
+			FSMState:              string(state),
+			ConsensusEpoch:        consensusEpoch,
+			ConsensusHeight:       consensusHeight,
+			ConsensusRound:        consensusRound,
+		})
 	}
+	h.mu.Lock()
+	h.status = status
+	h.mu.Unlock()
 
 	// Mem metrics
 	memMetrics()
 }
 
+// Status returns the status collected in the last heartbeat, which is nil before the first heartbeat
+func (h *HeartbeatHandler) Status() *HeartbeatStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.status
+}
+
+// ServeHTTP serves the status collected in the last heartbeat as a json document
+func (h *HeartbeatHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	status := h.Status()
+	if status == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		h.l.Error("failed to serialize the heartbeat status", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		h.l.Warn("failed to send the heartbeat status", zap.Error(err))
+	}
+}
+
 func memMetrics() {
 	bToMb := func(b uint64) uint64 {
 		return b / 1024 / 1024
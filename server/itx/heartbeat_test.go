@@ -7,6 +7,9 @@ package itx
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -28,6 +31,9 @@ func TestNewHeartbeatHandler(t *testing.T) {
 	handler := NewHeartbeatHandler(s, cfg.Network)
 	require.NotNil(handler)
 	require.Panics(func() { handler.Log() }, "P2pAgent is nil")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/heartbeat", nil))
+	require.Equal(http.StatusServiceUnavailable, rec.Code)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	livenessCtx, livenessCancel := context.WithCancel(context.Background())
@@ -36,6 +42,14 @@ func TestNewHeartbeatHandler(t *testing.T) {
 	require.NoError(s.Start(ctx))
 	time.Sleep(time.Second * 2)
 	handler.Log()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/heartbeat", nil))
+	require.Equal(http.StatusOK, rec.Code)
+	status := &HeartbeatStatus{}
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), status))
+	require.Equal(cfg.Chain.ProducerAddress().String(), status.OperatorAddress)
+	require.Len(status.Chains, 1)
+	require.Equal(cfg.Chain.ID, status.Chains[0].ChainID)
 	cancel()
 	require.NoError(probeSvr.Stop(livenessCtx))
 	livenessCancel()
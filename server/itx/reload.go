@@ -0,0 +1,115 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/iotexproject/iotex-core/v2/api"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/p2p"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// Reload applies the reloadable subset of the config without restart, which are the log levels, the quotas of api
+// rate limiting, the capacity of the actpool and the gossip policy of actions. The changes are validated before
+// any of them is applied, and the changes of the other fields take effect after restart
+func (s *Server) Reload(cfg config.Config) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var (
+		changes []string
+		applies []func() error
+	)
+
+	// log levels
+	levels := map[string][2]*zapcore.Level{"": {logLevel(s.cfg.Log), logLevel(cfg.Log)}}
+	for name, sub := range cfg.SubLogs {
+		old, ok := s.cfg.SubLogs[name]
+		if !ok {
+			return errors.Errorf("sub logger %s cannot be added without restart", name)
+		}
+		levels[name] = [2]*zapcore.Level{logLevel(old), logLevel(sub)}
+	}
+	for name, l := range levels {
+		if l[0] == nil || l[1] == nil || *l[0] == *l[1] {
+			continue
+		}
+		name, level := name, *l[1]
+		if name == "" {
+			changes = append(changes, fmt.Sprintf("log.zap.level: %s -> %s", *l[0], level))
+		} else {
+			changes = append(changes, fmt.Sprintf("subLogs.%s.zap.level: %s -> %s", name, *l[0], level))
+		}
+		applies = append(applies, func() error { return log.SetLevel(name, level) })
+	}
+
+	// quotas of api rate limiting
+	if oldRL, newRL := s.cfg.API.RateLimit, cfg.API.RateLimit; !reflect.DeepEqual(oldRL, newRL) {
+		if oldRL.Enabled != newRL.Enabled {
+			return errors.New("rate limiting cannot be enabled or disabled without restart")
+		}
+		apiServer, ok := s.apiServers[s.rootChainService.ChainID()]
+		if ok && apiServer.RateLimiter() != nil {
+			if _, err := api.NewRateLimiter(newRL); err != nil {
+				return errors.Wrap(err, "invalid rate limit")
+			}
+			changes = append(changes, "api.rateLimit")
+			applies = append(applies, func() error { return apiServer.RateLimiter().SetQuotas(newRL) })
+		}
+	}
+
+	// capacity of the actpool
+	if oldAP, newAP := s.cfg.ActPool, cfg.ActPool; oldAP.MaxNumActsPerPool != newAP.MaxNumActsPerPool ||
+		oldAP.MaxGasLimitPerPool != newAP.MaxGasLimitPerPool {
+		if newAP.MaxNumActsPerPool == 0 || newAP.MaxGasLimitPerPool == 0 {
+			return errors.New("capacity of actpool should be positive")
+		}
+		changes = append(changes, fmt.Sprintf("actPool.maxNumActsPerPool: %d -> %d, actPool.maxGasLimitPerPool: %d -> %d",
+			oldAP.MaxNumActsPerPool, newAP.MaxNumActsPerPool, oldAP.MaxGasLimitPerPool, newAP.MaxGasLimitPerPool))
+		applies = append(applies, func() error {
+			return s.rootChainService.SetActPoolCapacity(newAP.MaxNumActsPerPool, newAP.MaxGasLimitPerPool)
+		})
+	}
+
+	// gossip policy of actions
+	if oldGossip, newGossip := s.cfg.Network.ActionGossip, cfg.Network.ActionGossip; !reflect.DeepEqual(oldGossip, newGossip) {
+		if _, err := p2p.NewGossipPolicy(newGossip); err != nil {
+			return errors.Wrap(err, "invalid action gossip")
+		}
+		changes = append(changes, "network.actionGossip")
+		applies = append(applies, func() error { return s.rootChainService.UpdateActionGossip(newGossip) })
+	}
+
+	if len(changes) == 0 {
+		log.L().Info("Config reloaded without change.")
+		return nil
+	}
+	for _, apply := range applies {
+		if err := apply(); err != nil {
+			return errors.Wrap(err, "failed to apply reloaded config")
+		}
+	}
+	s.cfg.Log, s.cfg.SubLogs = cfg.Log, cfg.SubLogs
+	s.cfg.API.RateLimit = cfg.API.RateLimit
+	s.cfg.ActPool.MaxNumActsPerPool, s.cfg.ActPool.MaxGasLimitPerPool = cfg.ActPool.MaxNumActsPerPool, cfg.ActPool.MaxGasLimitPerPool
+	s.cfg.Network.ActionGossip = cfg.Network.ActionGossip
+	log.L().Info("Config reloaded.", zap.Strings("changes", changes))
+	return nil
+}
+
+func logLevel(cfg log.GlobalConfig) *zapcore.Level {
+	if cfg.Zap == nil {
+		return nil
+	}
+	l := cfg.Zap.Level.Level()
+	return &l
+}
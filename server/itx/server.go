@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/api"
+	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/chainservice"
 	"github.com/iotexproject/iotex-core/v2/config"
 	"github.com/iotexproject/iotex-core/v2/dispatcher"
@@ -32,16 +34,17 @@ import (
 
 // Server is the iotex server instance containing all components.
 type Server struct {
-	cfg                  config.Config
-	rootChainService     *chainservice.ChainService
-	chainservices        map[uint32]*chainservice.ChainService
-	apiServers           map[uint32]*api.ServerV2
-	p2pAgent             p2p.Agent
-	dispatcher           dispatcher.Dispatcher
-	nodeStats            *nodestats.NodeStats
-	initializedSubChains map[uint32]bool
-	mutex                sync.RWMutex
-	subModuleCancel      context.CancelFunc
+	cfg              config.Config
+	rootChainService *chainservice.ChainService
+	chainservices    map[uint32]*chainservice.ChainService
+	apiServers       map[uint32]*api.ServerV2
+	adminServer      *api.AdminServer
+	p2pAgent         p2p.Agent
+	dispatcher       dispatcher.Dispatcher
+	nodeStats        *nodestats.NodeStats
+	runningChains    map[uint32]bool
+	mutex            sync.RWMutex
+	subModuleCancel  context.CancelFunc
 }
 
 // NewServer creates a new server
@@ -62,8 +65,8 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 		return nil, errors.Wrap(err, "fail to create dispatcher")
 	}
 	var p2pAgent p2p.Agent
-	switch cfg.Consensus.Scheme {
-	case config.StandaloneScheme:
+	switch {
+	case cfg.Consensus.Scheme == config.StandaloneScheme, cfg.BlockSync.Replica.Enabled():
 		p2pAgent = p2p.NewDummyAgent()
 	default:
 		p2pAgent = p2p.NewAgent(cfg.Network, cfg.Chain.ID, cfg.Genesis.Hash(), dispatcher.HandleBroadcast, dispatcher.HandleTell)
@@ -95,21 +98,24 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 			return nil, errors.Wrap(err, "failed to add api server as subscriber")
 		}
 	}
+	adminServer, err := cs.NewAdminServer(cfg.API.Admin, apiServer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create admin server")
+	}
 	// TODO: explorer dependency deleted here at #1085, need to revive by migrating to api
 	chains[cs.ChainID()] = cs
 	dispatcher.AddSubscriber(cs.ChainID(), cs)
 	svr := Server{
-		cfg:                  cfg,
-		p2pAgent:             p2pAgent,
-		dispatcher:           dispatcher,
-		rootChainService:     cs,
-		chainservices:        chains,
-		apiServers:           apiServers,
-		nodeStats:            nodeStats,
-		initializedSubChains: map[uint32]bool{},
-	}
-	// Setup sub-chain starter
-	// TODO: sub-chain infra should use main-chain API instead of protocol directly
+		cfg:              cfg,
+		p2pAgent:         p2pAgent,
+		dispatcher:       dispatcher,
+		rootChainService: cs,
+		chainservices:    chains,
+		apiServers:       apiServers,
+		adminServer:      adminServer,
+		nodeStats:        nodeStats,
+		runningChains:    map[uint32]bool{},
+	}
 	return &svr, nil
 }
 
@@ -117,16 +123,14 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 func (s *Server) Start(ctx context.Context) error {
 	cctx, cancel := context.WithCancel(ctx)
 	s.subModuleCancel = cancel
-	for id, cs := range s.chainservices {
-		if err := cs.Start(cctx); err != nil {
-			return errors.Wrap(err, "error when starting blockchain")
-		}
-		if as, ok := s.apiServers[id]; ok {
-			if err := as.Start(cctx); err != nil {
-				return errors.Wrapf(err, "failed to start api server for chain %d", id)
-			}
+	s.mutex.Lock()
+	for id := range s.chainservices {
+		if err := s.startChainService(cctx, id); err != nil {
+			s.mutex.Unlock()
+			return err
 		}
 	}
+	s.mutex.Unlock()
 	if err := s.p2pAgent.Start(cctx); err != nil {
 		return errors.Wrap(err, "error when starting P2P agent")
 	}
@@ -136,12 +140,22 @@ func (s *Server) Start(ctx context.Context) error {
 	if err := s.nodeStats.Start(cctx); err != nil {
 		return errors.Wrap(err, "error when starting node stats")
 	}
+	if s.adminServer != nil {
+		if err := s.adminServer.Start(cctx); err != nil {
+			return errors.Wrap(err, "error when starting admin server")
+		}
+	}
 	return nil
 }
 
 // Stop stops the server
 func (s *Server) Stop(ctx context.Context) error {
 	defer s.subModuleCancel()
+	if s.adminServer != nil {
+		if err := s.adminServer.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping admin server")
+		}
+	}
 	if err := s.nodeStats.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping node stats")
 	}
@@ -153,42 +167,121 @@ func (s *Server) Stop(ctx context.Context) error {
 		// notest
 		return errors.Wrap(err, "error when stopping dispatcher")
 	}
-	for id, cs := range s.chainservices {
-		if as, ok := s.apiServers[id]; ok {
-			if err := as.Stop(ctx); err != nil {
-				return errors.Wrapf(err, "error when stopping api server")
-			}
-		}
-		if err := cs.Stop(ctx); err != nil {
-			return errors.Wrap(err, "error when stopping blockchain")
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for id := range s.chainservices {
+		if err := s.stopChainService(ctx, id); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-// NewSubChainService creates a new chain service in this server.
+// NewSubChainService creates a new chain service in this server. The sub-chain has its own config, registry and api
+// server, and shares the p2p agent and the dispatcher with the root chain. It is started along with the server, or by
+// StartChainService if the server is running.
 func (s *Server) NewSubChainService(cfg config.Config) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	// TODO: explorer dependency deleted here at #1085, need to revive by migrating to api
+	if _, ok := s.chainservices[cfg.Chain.ID]; ok {
+		return errors.Errorf("chain %d already exists", cfg.Chain.ID)
+	}
 	builder := chainservice.NewBuilder(cfg)
 	cs, err := builder.SetP2PAgent(s.p2pAgent).BuildForSubChain()
 	if err != nil {
-		return err
+		return errors.Wrapf(err, "failed to create chain service for chain %d", cfg.Chain.ID)
+	}
+	apiServer, err := cs.NewAPIServer(cfg.API, cfg.Chain.EnableArchiveMode)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create api server for chain %d", cfg.Chain.ID)
+	}
+	if apiServer != nil {
+		if err := cs.Blockchain().AddSubscriber(apiServer); err != nil {
+			return errors.Wrap(err, "failed to add api server as subscriber")
+		}
+		s.apiServers[cs.ChainID()] = apiServer
 	}
 	s.chainservices[cs.ChainID()] = cs
+	s.dispatcher.AddSubscriber(cs.ChainID(), cs)
 	return nil
 }
 
-// StopChainService stops the chain service run in the server.
+// StartChainService starts the chain service and its api server in the server.
+func (s *Server) StartChainService(ctx context.Context, id uint32) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.startChainService(ctx, id)
+}
+
+// StopChainService stops the chain service and its api server run in the server.
 func (s *Server) StopChainService(ctx context.Context, id uint32) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.stopChainService(ctx, id)
+}
+
+func (s *Server) startChainService(ctx context.Context, id uint32) error {
+	cs, ok := s.chainservices[id]
+	if !ok {
+		return errors.Errorf("chain %d does not match any existing chains", id)
+	}
+	if s.runningChains[id] {
+		return nil
+	}
+	if err := cs.Start(ctx); err != nil {
+		return errors.Wrapf(err, "error when starting blockchain %d", id)
+	}
+	if as, ok := s.apiServers[id]; ok {
+		if err := as.Start(ctx); err != nil {
+			return errors.Wrapf(err, "failed to start api server for chain %d", id)
+		}
+	}
+	s.runningChains[id] = true
+	return nil
+}
+
+func (s *Server) stopChainService(ctx context.Context, id uint32) error {
+	cs, ok := s.chainservices[id]
+	if !ok {
+		return errors.Errorf("chain %d does not match any existing chains", id)
+	}
+	if !s.runningChains[id] {
+		return nil
+	}
+	if as, ok := s.apiServers[id]; ok {
+		if err := as.Stop(ctx); err != nil {
+			return errors.Wrapf(err, "error when stopping api server for chain %d", id)
+		}
+	}
+	if err := cs.Stop(ctx); err != nil {
+		return errors.Wrapf(err, "error when stopping blockchain %d", id)
+	}
+	delete(s.runningChains, id)
+	return nil
+}
+
+// SubscribeChain subscribes to the blocks of the chain, which is the hook for the chains in the server to
+// communicate with each other, e.g., a sub-chain relays the messages in the blocks of the root chain
+func (s *Server) SubscribeChain(id uint32, subscriber blockchain.BlockCreationSubscriber) error {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	c, ok := s.chainservices[id]
+	cs, ok := s.chainservices[id]
 	if !ok {
-		return errors.New("Chain ID does not match any existing chains")
+		return errors.Errorf("chain %d does not match any existing chains", id)
 	}
-	return c.Stop(ctx)
+	return cs.Blockchain().AddSubscriber(subscriber)
+}
+
+// ChainIDs returns the ids of the chains in the server
+func (s *Server) ChainIDs() []uint32 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	ids := make([]uint32, 0, len(s.chainservices))
+	for id := range s.chainservices {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
 }
 
 // Config returns the server's config
@@ -245,7 +338,9 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 	log.L().Info("Server is ready.")
 
 	if cfg.System.HeartbeatInterval > 0 {
-		task := routine.NewRecurringTask(NewHeartbeatHandler(svr, cfg.Network).Log, cfg.System.HeartbeatInterval)
+		heartbeat := NewHeartbeatHandler(svr, cfg.Network)
+		probeSvr.Handle("/heartbeat", heartbeat)
+		task := routine.NewRecurringTask(heartbeat.Log, cfg.System.HeartbeatInterval)
 		if err := task.Start(ctx); err != nil {
 			log.L().Panic("Failed to start heartbeat routine.", zap.Error(err))
 		}
@@ -256,12 +351,37 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 		}()
 	}
 
+	if exporter := svr.rootChainService.SnapshotExporter(); exporter != nil && cfg.Snapshot.ServePort > 0 {
+		snapshotserv := httputil.NewServer(
+			fmt.Sprintf(":%d", cfg.Snapshot.ServePort),
+			exporter.Handler(),
+			// chunks are large, the write timeout is aligned with the download timeout of the peers
+			httputil.WriteTimeout(cfg.Snapshot.RequestTimeout),
+		)
+		defer func() {
+			if err := snapshotserv.Shutdown(ctx); err != nil {
+				log.L().Error("Error when shutting down snapshot server.", zap.Error(err))
+			}
+		}()
+		go func() {
+			ln, err := httputil.LimitListener(snapshotserv.Addr)
+			if err != nil {
+				log.L().Error("Error when listen to snapshot port.", zap.Error(err))
+				return
+			}
+			if err := snapshotserv.Serve(ln); err != nil && err != http.ErrServerClosed {
+				log.L().Error("Error when serving snapshots.", zap.Error(err))
+			}
+		}()
+	}
+
 	var adminserv http.Server
 	if cfg.System.HTTPAdminPort > 0 {
 		mux := http.NewServeMux()
 		log.RegisterLevelConfigMux(mux)
 		haCtl := ha.New(svr.rootChainService.Consensus())
 		mux.Handle("/ha", http.HandlerFunc(haCtl.Handle))
+		mux.Handle("/peerscores", http.HandlerFunc(svr.rootChainService.NodeInfoManager().HandlePeerScores))
 		mux.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
 		mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
 		mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
@@ -10,11 +10,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/p2p"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/probe"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockcreationsubscriber"
 	"github.com/iotexproject/iotex-core/v2/testutil"
 )
 
@@ -40,14 +44,21 @@ func TestNewSubChainService(t *testing.T) {
 	defer cleanupPath()
 	svr, err := NewServer(cfg)
 	require.NoError(err)
-	err = svr.NewSubChainService(cfg)
-	require.NoError(err)
-	cs := svr.ChainService(1)
-	require.NotNil(cs)
+	require.ErrorContains(svr.NewSubChainService(cfg), "chain 1 already exists")
+	subCfg, cleanupSubPath := newConfig(t)
+	defer cleanupSubPath()
+	subCfg.Chain.ID = 2
+	require.NoError(svr.NewSubChainService(subCfg))
+	require.Equal([]uint32{1, 2}, svr.ChainIDs())
+	require.NotNil(svr.ChainService(2))
+	require.NotNil(svr.APIServer(2))
 	ctx := context.Background()
-	require.NoError(cs.Start(ctx))
+	require.ErrorContains(svr.StartChainService(ctx, 3), "does not match any existing chains")
+	require.NoError(svr.StartChainService(ctx, 2))
+	require.NoError(svr.SubscribeChain(1, mock_blockcreationsubscriber.NewMockBlockCreationSubscriber(gomock.NewController(t))))
+	require.Error(svr.SubscribeChain(3, nil))
 	err = testutil.WaitUntil(100*time.Millisecond, 3*time.Second, func() (bool, error) {
-		err = svr.StopChainService(ctx, 1)
+		err = svr.StopChainService(ctx, 2)
 		return err == nil, err
 	})
 	require.NoError(err)
@@ -71,6 +82,29 @@ func TestStartServer(t *testing.T) {
 	StartServer(ctx, svr, probeSvr, cfg)
 }
 
+func TestReload(t *testing.T) {
+	require := require.New(t)
+	cfg, cleanupPath := newConfig(t)
+	defer cleanupPath()
+	svr, err := NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Reload(cfg))
+
+	newCfg := cfg
+	newCfg.ActPool.MaxNumActsPerPool = cfg.ActPool.MaxNumActsPerPool * 2
+	newCfg.Network.ActionGossip = p2p.GossipConfig{Mode: "random"}
+	require.Error(svr.Reload(newCfg))
+	// nothing is applied if any change is invalid
+	require.Equal(cfg.ActPool.MaxNumActsPerPool, svr.ChainService(cfg.Chain.ID).ActionPool().GetCapacity())
+
+	newCfg.Network.ActionGossip = p2p.GossipConfig{Mode: p2p.GossipSqrt}
+	require.NoError(svr.Reload(newCfg))
+	require.Equal(newCfg.ActPool.MaxNumActsPerPool, svr.ChainService(cfg.Chain.ID).ActionPool().GetCapacity())
+
+	newCfg.SubLogs = map[string]log.GlobalConfig{"new": {}}
+	require.Error(svr.Reload(newCfg))
+}
+
 func newConfig(t *testing.T) (config.Config, func()) {
 	require := require.New(t)
 	dbPath, err := testutil.PathOfTempFile("chain.db")
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/light"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/httputil"
+)
+
+// runLightClient runs the node in light mode until the context is done, which syncs the block headers only and serves
+// the header and receipt proof queries
+func runLightClient(ctx context.Context, cfg config.Config) {
+	client, err := light.NewClient(cfg.Light, cfg.Genesis)
+	if err != nil {
+		log.L().Fatal("Failed to create light client.", zap.Error(err))
+	}
+	if err := client.Start(ctx); err != nil {
+		log.L().Fatal("Failed to start light client.", zap.Error(err))
+	}
+	var svr *http.Server
+	if cfg.Light.HTTPPort > 0 {
+		s := httputil.NewServer(":"+strconv.Itoa(cfg.Light.HTTPPort), client.Handler())
+		svr = &s
+		go func() {
+			if err := svr.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.L().Fatal("Failed to serve light client queries.", zap.Error(err))
+			}
+		}()
+	}
+	log.L().Info("Light client started.", zap.String("upstream", cfg.Light.Upstream))
+	<-ctx.Done()
+	if svr != nil {
+		if err := svr.Shutdown(context.Background()); err != nil {
+			log.L().Error("Failed to stop light client server.", zap.Error(err))
+		}
+	}
+	if err := client.Stop(context.Background()); err != nil {
+		log.L().Error("Failed to stop light client.", zap.Error(err))
+	}
+}
@@ -6,12 +6,17 @@
 // Usage:
 //   make build
 //   ./bin/server -config-file=./config.yaml
+//   ./bin/server snapshot export -config-path=./config.yaml -output=./snapshot.bin
+//   ./bin/server snapshot import -config-path=./config.yaml -input=./snapshot.bin
+//   ./bin/server inject -key-file=./keys.yaml -tps=100 -mix=transfer=8,execution=1,stake=1
 //
 
 package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	glog "log"
@@ -28,6 +33,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/config"
 	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/v2/pkg/audit"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/probe"
 	"github.com/iotexproject/iotex-core/v2/pkg/recovery"
@@ -63,18 +69,30 @@ func init() {
 	flag.StringVar(&_genesisPath, "genesis-path", "", "Genesis path")
 	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
 	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
-	flag.StringVar(&_subChainPath, "sub-config-path", "", "Sub chain Config path")
+	flag.StringVar(&_subChainPath, "sub-config-path", "", "Sub chain config paths, separated by comma")
 	flag.Var(&_plugins, "plugin", "Plugin of the node")
 	flag.Usage = func() {
 		_, _ = fmt.Fprintf(os.Stderr,
-			"usage: server -config-path=[string]\n")
+			"usage: server -config-path=[string]\n       server snapshot export|import [flags]\n       server inject [flags]\n")
 		flag.PrintDefaults()
 		os.Exit(2)
 	}
+	if isSnapshotCommand() || isInjectCommand() {
+		// the flags of the subcommand are parsed by itself
+		return
+	}
 	flag.Parse()
 }
 
 func main() {
+	if isSnapshotCommand() {
+		runSnapshotCommand(os.Args[2:])
+		return
+	}
+	if isInjectCommand() {
+		runInjectCommand(os.Args[2:])
+		return
+	}
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 	signal.Notify(stop, syscall.SIGTERM)
@@ -82,6 +100,88 @@ func main() {
 	stopped := make(chan struct{})
 	livenessCtx, livenessCancel := context.WithCancel(context.Background())
 
+	cfg := loadConfig()
+	defer recovery.Recover()
+
+	// liveness start
+	probeSvr := probe.New(cfg.System.HTTPStatsPort)
+	if err := probeSvr.Start(ctx); err != nil {
+		log.L().Fatal("Failed to start probe server.", zap.Error(err))
+	}
+	go func() {
+		<-stop
+		// start stopping
+		cancel()
+		<-stopped
+
+		// liveness end
+		if err := probeSvr.Stop(livenessCtx); err != nil {
+			log.L().Error("Error when stopping probe server.", zap.Error(err))
+		}
+		livenessCancel()
+	}()
+
+	if cfg.System.AuditLogPath != "" {
+		auditLog, err := audit.NewLog(cfg.System.AuditLogPath)
+		if err != nil {
+			log.L().Fatal("Failed to open audit log.", zap.Error(err))
+		}
+		audit.SetGlobal(auditLog)
+		defer func() {
+			audit.SetGlobal(nil)
+			if err := auditLog.Close(); err != nil {
+				log.L().Error("Failed to close audit log.", zap.Error(err))
+			}
+		}()
+		audit.Record(audit.OpLoadConfig, configDigests())
+	}
+
+	if cfg.System.MptrieLogPath != "" {
+		if err := mptrie.OpenLogDB(cfg.System.MptrieLogPath); err != nil {
+			log.L().Fatal("Failed to open mptrie log DB.", zap.Error(err))
+		}
+		defer func() {
+			if err := mptrie.CloseLogDB(); err != nil {
+				log.L().Error("Failed to close mptrie log DB.", zap.Error(err))
+			}
+		}()
+	}
+	if cfg.Light.Enabled() {
+		runLightClient(ctx, cfg)
+		close(stopped)
+		<-livenessCtx.Done()
+		return
+	}
+	// create and start the node
+	svr, err := itx.NewServer(cfg)
+	if err != nil {
+		log.L().Fatal("Failed to create server.", zap.Error(err))
+	}
+
+	for _, path := range strings.Split(_subChainPath, ",") {
+		if path == "" {
+			continue
+		}
+		cfgsub, err := config.NewSub([]string{_secretPath, path})
+		if err != nil {
+			log.L().Fatal("Failed to new sub chain config.", zap.String("path", path), zap.Error(err))
+		}
+		if cfgsub.Chain.ID == 0 {
+			continue
+		}
+		if err := svr.NewSubChainService(cfgsub); err != nil {
+			log.L().Fatal("Failed to new sub chain.", zap.Uint32("chainID", cfgsub.Chain.ID), zap.Error(err))
+		}
+	}
+
+	go reloadOnSignal(ctx, svr)
+	itx.StartServer(ctx, svr, probeSvr, cfg)
+	close(stopped)
+	<-livenessCtx.Done()
+}
+
+// loadConfig loads the genesis and the config of the node
+func loadConfig() config.Config {
 	genesisCfg, err := genesis.New(_genesisPath)
 	if err != nil {
 		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
@@ -108,7 +208,6 @@ func main() {
 	if err = recovery.SetCrashlogDir(cfg.System.SystemLogDBPath); err != nil {
 		glog.Fatalln("Failed to set directory of crashlog: ", zap.Error(err))
 	}
-	defer recovery.Recover()
 
 	// check EVM network ID and chain ID
 	if cfg.Chain.EVMNetworkID == 0 || cfg.Chain.ID == 0 {
@@ -123,60 +222,31 @@ func main() {
 	log.S().Infof("EVM Network ID: %d, Chain ID: %d", cfg.Chain.EVMNetworkID, cfg.Chain.ID)
 	log.S().Infof("Genesis timestamp: %d", genesisCfg.Timestamp)
 	log.S().Infof("Genesis hash: %x", block.GenesisHash())
+	return cfg
+}
 
-	// liveness start
-	probeSvr := probe.New(cfg.System.HTTPStatsPort)
-	if err := probeSvr.Start(ctx); err != nil {
-		log.L().Fatal("Failed to start probe server.", zap.Error(err))
+// configDigests returns the digests of the config files in use, the secret is not included
+func configDigests() map[string]string {
+	genesisHash := block.GenesisHash()
+	digests := map[string]string{
+		"genesisHash": hex.EncodeToString(genesisHash[:]),
 	}
-	go func() {
-		<-stop
-		// start stopping
-		cancel()
-		<-stopped
-
-		// liveness end
-		if err := probeSvr.Stop(livenessCtx); err != nil {
-			log.L().Error("Error when stopping probe server.", zap.Error(err))
+	for name, path := range map[string]string{
+		"config":  _overwritePath,
+		"genesis": _genesisPath,
+	} {
+		if path == "" {
+			continue
 		}
-		livenessCancel()
-	}()
-
-	if cfg.System.MptrieLogPath != "" {
-		if err = mptrie.OpenLogDB(cfg.System.MptrieLogPath); err != nil {
-			log.L().Fatal("Failed to open mptrie log DB.", zap.Error(err))
-		}
-		defer func() {
-			if err = mptrie.CloseLogDB(); err != nil {
-				log.L().Error("Failed to close mptrie log DB.", zap.Error(err))
-			}
-		}()
-	}
-	// create and start the node
-	svr, err := itx.NewServer(cfg)
-	if err != nil {
-		log.L().Fatal("Failed to create server.", zap.Error(err))
-	}
-
-	var cfgsub config.Config
-	if _subChainPath != "" {
-		cfgsub, err = config.NewSub([]string{_secretPath, _subChainPath})
+		data, err := os.ReadFile(path)
 		if err != nil {
-			log.L().Fatal("Failed to new sub chain config.", zap.Error(err))
-		}
-	} else {
-		cfgsub = config.Config{}
-	}
-
-	if cfgsub.Chain.ID != 0 {
-		if err := svr.NewSubChainService(cfgsub); err != nil {
-			log.L().Fatal("Failed to new sub chain.", zap.Error(err))
+			digests[name] = "unreadable " + path
+			continue
 		}
+		h := sha256.Sum256(data)
+		digests[name] = path + " sha256:" + hex.EncodeToString(h[:])
 	}
-
-	itx.StartServer(ctx, svr, probeSvr, cfg)
-	close(stopped)
-	<-livenessCtx.Done()
+	return digests
 }
 
 func initLogger(cfg config.Config) error {
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/server/itx"
+)
+
+// reloadOnSignal reloads the config files on SIGHUP until the context is done, e.g., by "kill -HUP <pid>"
+func reloadOnSignal(ctx context.Context, svr *itx.Server) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			log.L().Info("Reloading config.", zap.String("path", _overwritePath))
+			cfg, err := config.New([]string{_overwritePath, _secretPath}, _plugins)
+			if err != nil {
+				log.L().Error("Failed to reload config.", zap.Error(err))
+				continue
+			}
+			if err := svr.Reload(cfg); err != nil {
+				log.L().Error("Failed to reload config.", zap.Error(err))
+			}
+		}
+	}
+}
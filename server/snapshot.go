@@ -0,0 +1,114 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/chainservice"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// isSnapshotCommand returns true if the server runs the snapshot subcommand
+func isSnapshotCommand() bool {
+	return len(os.Args) > 1 && os.Args[1] == "snapshot"
+}
+
+// runSnapshotCommand exports the state of the stopped node into an archive, or imports an archive into a new node
+func runSnapshotCommand(args []string) {
+	usage := func() {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"usage: server snapshot export -config-path=[string] -output=[string] [-height=[uint]]\n"+
+				"       server snapshot import -config-path=[string] -input=[string] [-root=[string]]\n")
+		os.Exit(2)
+	}
+	if len(args) == 0 {
+		usage()
+	}
+	var (
+		fs     = flag.NewFlagSet("snapshot "+args[0], flag.ExitOnError)
+		height uint64
+		file   string
+		root   string
+	)
+	fs.StringVar(&_genesisPath, "genesis-path", "", "Genesis path")
+	fs.StringVar(&_overwritePath, "config-path", "", "Config path")
+	fs.StringVar(&_secretPath, "secret-path", "", "Secret path")
+	fs.Var(&_plugins, "plugin", "Plugin of the node")
+	switch args[0] {
+	case "export":
+		fs.Uint64Var(&height, "height", 0, "Height of the snapshot, which should be the height of the state db, 0 for the height of the state db")
+		fs.StringVar(&file, "output", "", "Path of the archive to write")
+	case "import":
+		fs.StringVar(&file, "input", "", "Path of the archive to read")
+		fs.StringVar(&root, "root", "", "Hex encoded root of the trusted snapshot, which is printed by the export")
+	default:
+		usage()
+	}
+	if err := fs.Parse(args[1:]); err != nil || file == "" {
+		usage()
+	}
+
+	cfg := loadConfig()
+	if args[0] == "export" {
+		exportSnapshot(cfg, file, height)
+		return
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	importSnapshot(ctx, cfg, file, root)
+}
+
+func exportSnapshot(cfg config.Config, file string, height uint64) {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		log.L().Fatal("Failed to create snapshot archive.", zap.Error(err))
+	}
+	w := bufio.NewWriterSize(f, 1<<20)
+	m, err := chainservice.ExportSnapshotArchive(cfg, w, height)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if rmErr := os.Remove(file); rmErr != nil {
+			log.L().Error("Failed to remove snapshot archive.", zap.Error(rmErr))
+		}
+		log.L().Fatal("Failed to export snapshot.", zap.Error(err))
+	}
+	root, err := m.Root()
+	if err != nil {
+		log.L().Fatal("Failed to get the root of snapshot.", zap.Error(err))
+	}
+	fmt.Printf("exported snapshot at height %d into %s, root %x\n", m.Height, file, root)
+}
+
+func importSnapshot(ctx context.Context, cfg config.Config, file, root string) {
+	f, err := os.Open(file)
+	if err != nil {
+		log.L().Fatal("Failed to open snapshot archive.", zap.Error(err))
+	}
+	defer f.Close()
+	m, err := chainservice.ImportSnapshotArchive(ctx, cfg, f, root)
+	if err != nil {
+		log.L().Fatal("Failed to import snapshot, remove the state dbs before retrying.", zap.Error(err))
+	}
+	fmt.Printf("imported snapshot at height %d from %s\n", m.Height, file)
+}
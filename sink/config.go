@@ -0,0 +1,78 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package sink
+
+import "time"
+
+const (
+	// TypeKafka publishes the blocks to a kafka topic via the kafka REST proxy over HTTP, the native kafka protocol
+	// is not used, so a REST proxy (e.g., confluent REST proxy) is required in front of the brokers
+	TypeKafka = "kafka"
+	// TypePostgres writes the blocks into the tables of a postgres database
+	TypePostgres = "postgres"
+)
+
+type (
+	// Config is the config of the external sink
+	Config struct {
+		// Type is the type of the sink, empty disables the sink
+		Type string `yaml:"type"`
+		// StartHeight is the height of the first block published to the sink
+		StartHeight uint64 `yaml:"startHeight"`
+		// BatchSize is the max number of blocks published in a batch
+		BatchSize uint64 `yaml:"batchSize"`
+		// PollInterval is the interval of checking new blocks, in addition to the notification of the new blocks
+		PollInterval time.Duration `yaml:"pollInterval"`
+		// RetryInterval is the interval of retrying to publish after a failure
+		RetryInterval time.Duration `yaml:"retryInterval"`
+		// Kafka is the config of the kafka sink
+		Kafka KafkaConfig `yaml:"kafka"`
+		// Postgres is the config of the postgres sink
+		Postgres PostgresConfig `yaml:"postgres"`
+	}
+
+	// KafkaConfig is the config of the kafka sink
+	KafkaConfig struct {
+		// ProxyURL is the URL of the kafka REST proxy, which the blocks are produced to over HTTP
+		ProxyURL string `yaml:"proxyURL"`
+		// Topic is the topic the blocks are published to, keyed by the block height
+		Topic string `yaml:"topic"`
+		// OffsetDBPath is the path of the db storing the height of the last published block
+		OffsetDBPath string `yaml:"offsetDBPath"`
+		// RequestTimeout is the timeout of a produce request
+		RequestTimeout time.Duration `yaml:"requestTimeout"`
+	}
+
+	// PostgresConfig is the config of the postgres sink
+	PostgresConfig struct {
+		// DSN is the data source name of the database
+		DSN string `yaml:"dsn"`
+		// TablePrefix is the prefix of the table names
+		TablePrefix string `yaml:"tablePrefix"`
+	}
+)
+
+// DefaultConfig is the default config of the external sink
+var DefaultConfig = Config{
+	Type:          "",
+	StartHeight:   1,
+	BatchSize:     100,
+	PollInterval:  10 * time.Second,
+	RetryInterval: 5 * time.Second,
+	Kafka: KafkaConfig{
+		Topic:          "iotex.blocks",
+		OffsetDBPath:   "/var/data/sink.offset.db",
+		RequestTimeout: 30 * time.Second,
+	},
+	Postgres: PostgresConfig{
+		TablePrefix: "iotex_",
+	},
+}
+
+// Enabled returns true if the external sink is enabled
+func (cfg Config) Enabled() bool {
+	return cfg.Type != ""
+}
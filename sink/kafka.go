@@ -0,0 +1,136 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	_kafkaOffsetNS     = "sink"
+	_kafkaContentType  = "application/vnd.kafka.json.v2+json"
+	_kafkaAcceptHeader = "application/vnd.kafka.v2+json"
+)
+
+var _kafkaOffsetKey = []byte("offset")
+
+type (
+	// kafkaPublisher produces the block messages to a kafka topic via the kafka REST proxy, and keeps the offset in a
+	// local db
+	kafkaPublisher struct {
+		cfg    KafkaConfig
+		kv     db.KVStore
+		client *http.Client
+	}
+
+	kafkaRecord struct {
+		Key   string        `json:"key"`
+		Value *BlockMessage `json:"value"`
+	}
+
+	kafkaProduceResponse struct {
+		Offsets []struct {
+			Partition int    `json:"partition"`
+			Offset    int64  `json:"offset"`
+			ErrorCode *int   `json:"error_code"`
+			Error     string `json:"error"`
+		} `json:"offsets"`
+	}
+)
+
+// NewKafkaPublisher creates a publisher producing to a kafka topic through the kafka REST proxy over HTTP
+func NewKafkaPublisher(cfg KafkaConfig, kv db.KVStore) (Publisher, error) {
+	if cfg.ProxyURL == "" || cfg.Topic == "" {
+		return nil, errors.New("kafka sink requires proxy url and topic")
+	}
+	if _, err := url.Parse(cfg.ProxyURL); err != nil {
+		return nil, errors.Wrapf(err, "invalid kafka proxy url %s", cfg.ProxyURL)
+	}
+	return &kafkaPublisher{
+		cfg:    cfg,
+		kv:     kv,
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+	}, nil
+}
+
+func (kp *kafkaPublisher) Start(ctx context.Context) error {
+	return kp.kv.Start(ctx)
+}
+
+func (kp *kafkaPublisher) Stop(ctx context.Context) error {
+	return kp.kv.Stop(ctx)
+}
+
+func (kp *kafkaPublisher) Offset() (uint64, error) {
+	value, err := kp.kv.Get(_kafkaOffsetNS, _kafkaOffsetKey)
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64BigEndian(value), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+func (kp *kafkaPublisher) Publish(ctx context.Context, msgs []*BlockMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	records := make([]kafkaRecord, 0, len(msgs))
+	for _, msg := range msgs {
+		records = append(records, kafkaRecord{
+			Key:   strconv.FormatUint(msg.Height, 10),
+			Value: msg,
+		})
+	}
+	body, err := json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode records")
+	}
+	endpoint := fmt.Sprintf("%s/topics/%s", strings.TrimSuffix(kp.cfg.ProxyURL, "/"), url.PathEscape(kp.cfg.Topic))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", _kafkaContentType)
+	req.Header.Set("Accept", _kafkaAcceptHeader)
+	resp, err := kp.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to produce records")
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read produce response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to produce records, status %d: %s", resp.StatusCode, data)
+	}
+	var res kafkaProduceResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return errors.Wrap(err, "failed to decode produce response")
+	}
+	for _, o := range res.Offsets {
+		if o.ErrorCode != nil {
+			return errors.Errorf("failed to produce records, error code %d: %s", *o.ErrorCode, o.Error)
+		}
+	}
+	return kp.kv.Put(_kafkaOffsetNS, _kafkaOffsetKey, byteutil.Uint64ToBytesBigEndian(msgs[len(msgs)-1].Height))
+}
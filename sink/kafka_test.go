@@ -0,0 +1,75 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+)
+
+func TestKafkaPublisher(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		records []kafkaRecord
+		fail    bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("/topics/iotex.blocks", r.URL.Path)
+		require.Equal(_kafkaContentType, r.Header.Get("Content-Type"))
+		if fail {
+			w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":50003,"error":"retriable"}]}`))
+			return
+		}
+		var req struct {
+			Records []kafkaRecord `json:"records"`
+		}
+		require.NoError(json.NewDecoder(r.Body).Decode(&req))
+		records = append(records, req.Records...)
+		w.Write([]byte(`{"offsets":[{"partition":0,"offset":1}]}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewKafkaPublisher(KafkaConfig{Topic: "iotex.blocks"}, db.NewMemKVStore())
+	require.Error(err)
+	cfg := DefaultConfig.Kafka
+	cfg.ProxyURL = srv.URL + "/"
+	publisher, err := NewKafkaPublisher(cfg, db.NewMemKVStore())
+	require.NoError(err)
+	ctx := context.Background()
+	require.NoError(publisher.Start(ctx))
+	defer func() {
+		require.NoError(publisher.Stop(ctx))
+	}()
+	offset, err := publisher.Offset()
+	require.NoError(err)
+	require.Zero(offset)
+
+	require.NoError(publisher.Publish(ctx, []*BlockMessage{
+		{Version: SchemaVersion, Height: 1},
+		{Version: SchemaVersion, Height: 2},
+	}))
+	require.Len(records, 2)
+	require.Equal("2", records[1].Key)
+	require.EqualValues(2, records[1].Value.Height)
+	offset, err = publisher.Offset()
+	require.NoError(err)
+	require.EqualValues(2, offset)
+
+	// the offset does not advance if any record fails
+	fail = true
+	require.Error(publisher.Publish(ctx, []*BlockMessage{{Version: SchemaVersion, Height: 3}}))
+	offset, err = publisher.Offset()
+	require.NoError(err)
+	require.EqualValues(2, offset)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "github.com/lib/pq" // register the postgres driver
+	"github.com/pkg/errors"
+)
+
+// postgresPublisher writes the block messages into the tables of a postgres database. The rows of a batch and the
+// offset are written in a transaction, and a row written again is ignored, so the tables never contain duplicates
+type postgresPublisher struct {
+	db     *sql.DB
+	prefix string
+}
+
+// NewPostgresPublisher creates a publisher writing to a postgres database
+func NewPostgresPublisher(db *sql.DB, tablePrefix string) Publisher {
+	return &postgresPublisher{
+		db:     db,
+		prefix: tablePrefix,
+	}
+}
+
+func (pp *postgresPublisher) Start(ctx context.Context) error {
+	if err := pp.db.PingContext(ctx); err != nil {
+		return errors.Wrap(err, "failed to connect to postgres")
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS %[1]soffset (
+			id INT PRIMARY KEY,
+			height BIGINT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS %[1]sblocks (
+			height BIGINT PRIMARY KEY,
+			hash TEXT NOT NULL,
+			timestamp TIMESTAMPTZ NOT NULL,
+			producer TEXT NOT NULL,
+			num_actions INT NOT NULL,
+			gas_used BIGINT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS %[1]sreceipts (
+			action_hash TEXT PRIMARY KEY,
+			block_height BIGINT NOT NULL,
+			status BIGINT NOT NULL,
+			gas_consumed BIGINT NOT NULL,
+			contract_address TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS %[1]slogs (
+			block_height BIGINT NOT NULL,
+			log_index INT NOT NULL,
+			action_hash TEXT NOT NULL,
+			address TEXT NOT NULL,
+			topics JSONB NOT NULL,
+			data TEXT NOT NULL,
+			PRIMARY KEY (block_height, log_index)
+		)`,
+		`CREATE TABLE IF NOT EXISTS %[1]stransaction_logs (
+			block_height BIGINT NOT NULL,
+			action_hash TEXT NOT NULL,
+			log_index INT NOT NULL,
+			type TEXT NOT NULL,
+			sender TEXT NOT NULL,
+			recipient TEXT NOT NULL,
+			amount NUMERIC NOT NULL,
+			PRIMARY KEY (block_height, action_hash, log_index)
+		)`,
+	} {
+		if _, err := pp.db.ExecContext(ctx, fmt.Sprintf(stmt, pp.prefix)); err != nil {
+			return errors.Wrap(err, "failed to create table")
+		}
+	}
+	return nil
+}
+
+func (pp *postgresPublisher) Stop(context.Context) error {
+	return pp.db.Close()
+}
+
+func (pp *postgresPublisher) Offset() (uint64, error) {
+	var height uint64
+	err := pp.db.QueryRow(fmt.Sprintf("SELECT height FROM %soffset WHERE id = 1", pp.prefix)).Scan(&height)
+	switch err {
+	case nil:
+		return height, nil
+	case sql.ErrNoRows:
+		return 0, nil
+	default:
+		return 0, errors.Wrap(err, "failed to read offset")
+	}
+}
+
+func (pp *postgresPublisher) Publish(ctx context.Context, msgs []*BlockMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	tx, err := pp.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	if err := pp.write(ctx, tx, msgs); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "failed to commit transaction")
+}
+
+func (pp *postgresPublisher) write(ctx context.Context, tx *sql.Tx, msgs []*BlockMessage) error {
+	for _, msg := range msgs {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+			`INSERT INTO %sblocks (height, hash, timestamp, producer, num_actions, gas_used)
+			VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING`, pp.prefix),
+			msg.Height, msg.Hash, msg.Timestamp, msg.Producer, msg.NumActions, msg.GasUsed,
+		); err != nil {
+			return errors.Wrapf(err, "failed to write block %d", msg.Height)
+		}
+		for _, r := range msg.Receipts {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+				`INSERT INTO %sreceipts (action_hash, block_height, status, gas_consumed, contract_address)
+				VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`, pp.prefix),
+				r.ActionHash, msg.Height, r.Status, r.GasConsumed, r.ContractAddress,
+			); err != nil {
+				return errors.Wrapf(err, "failed to write receipt %s", r.ActionHash)
+			}
+			for _, l := range r.Logs {
+				topics, err := json.Marshal(l.Topics)
+				if err != nil {
+					return err
+				}
+				if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+					`INSERT INTO %slogs (block_height, log_index, action_hash, address, topics, data)
+					VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING`, pp.prefix),
+					msg.Height, l.Index, r.ActionHash, l.Address, string(topics), l.Data,
+				); err != nil {
+					return errors.Wrapf(err, "failed to write log %d of block %d", l.Index, msg.Height)
+				}
+			}
+			for i, l := range r.TransactionLogs {
+				if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+					`INSERT INTO %stransaction_logs (block_height, action_hash, log_index, type, sender, recipient, amount)
+					VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING`, pp.prefix),
+					msg.Height, r.ActionHash, i, l.Type, l.Sender, l.Recipient, l.Amount,
+				); err != nil {
+					return errors.Wrapf(err, "failed to write transaction log %d of action %s", i, r.ActionHash)
+				}
+			}
+		}
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(
+		`INSERT INTO %soffset (id, height) VALUES (1, $1) ON CONFLICT (id) DO UPDATE SET height = EXCLUDED.height`, pp.prefix),
+		msgs[len(msgs)-1].Height,
+	)
+	return errors.Wrap(err, "failed to write offset")
+}
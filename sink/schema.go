@@ -0,0 +1,118 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package sink
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+// SchemaVersion is the version of the schema of the messages published to the sink. It is bumped on any change
+// incompatible with the consumers
+const SchemaVersion = 1
+
+type (
+	// BlockMessage is the message of a committed block published to the sink. A block could be published more than
+	// once, e.g., after the node restarts, so the consumers should deduplicate the messages by the height
+	BlockMessage struct {
+		Version    int               `json:"version"`
+		Height     uint64            `json:"height"`
+		Hash       string            `json:"hash"`
+		Timestamp  time.Time         `json:"timestamp"`
+		Producer   string            `json:"producer"`
+		NumActions int               `json:"numActions"`
+		GasUsed    uint64            `json:"gasUsed"`
+		Receipts   []*ReceiptMessage `json:"receipts"`
+	}
+
+	// ReceiptMessage is the receipt of an action in the block
+	ReceiptMessage struct {
+		ActionHash      string                   `json:"actionHash"`
+		Status          uint64                   `json:"status"`
+		GasConsumed     uint64                   `json:"gasConsumed"`
+		ContractAddress string                   `json:"contractAddress,omitempty"`
+		Logs            []*LogMessage            `json:"logs"`
+		TransactionLogs []*TransactionLogMessage `json:"transactionLogs"`
+	}
+
+	// LogMessage is an evm event emitted by the action
+	LogMessage struct {
+		Index   uint32   `json:"index"`
+		Address string   `json:"address"`
+		Topics  []string `json:"topics"`
+		Data    string   `json:"data"`
+	}
+
+	// TransactionLogMessage is a native token transfer made by the action. The recipient is empty if the tokens are
+	// burnt, and the amount is a decimal string
+	TransactionLogMessage struct {
+		Type      string `json:"type"`
+		Sender    string `json:"sender"`
+		Recipient string `json:"recipient,omitempty"`
+		Amount    string `json:"amount"`
+	}
+)
+
+// NewBlockMessage creates the message of the block, with its receipts and transaction logs. The transaction logs could
+// be nil if the block store does not keep them
+func NewBlockMessage(blk *block.Block, receipts []*action.Receipt, txLogs *iotextypes.TransactionLogs) *BlockMessage {
+	h := blk.HashBlock()
+	msg := &BlockMessage{
+		Version:    SchemaVersion,
+		Height:     blk.Height(),
+		Hash:       hex.EncodeToString(h[:]),
+		Timestamp:  blk.Timestamp().UTC(),
+		Producer:   blk.ProducerAddress(),
+		NumActions: len(blk.Actions),
+		GasUsed:    blk.GasUsed(),
+		Receipts:   make([]*ReceiptMessage, 0, len(receipts)),
+	}
+	byAction := make(map[string]*ReceiptMessage, len(receipts))
+	for _, r := range receipts {
+		rm := &ReceiptMessage{
+			ActionHash:      hex.EncodeToString(r.ActionHash[:]),
+			Status:          r.Status,
+			GasConsumed:     r.GasConsumed,
+			ContractAddress: r.ContractAddress,
+			Logs:            make([]*LogMessage, 0, len(r.Logs())),
+			TransactionLogs: []*TransactionLogMessage{},
+		}
+		for _, l := range r.Logs() {
+			topics := make([]string, 0, len(l.Topics))
+			for _, t := range l.Topics {
+				topics = append(topics, hex.EncodeToString(t[:]))
+			}
+			rm.Logs = append(rm.Logs, &LogMessage{
+				Index:   l.Index,
+				Address: l.Address,
+				Topics:  topics,
+				Data:    hex.EncodeToString(l.Data),
+			})
+		}
+		msg.Receipts = append(msg.Receipts, rm)
+		byAction[rm.ActionHash] = rm
+	}
+	for _, l := range txLogs.GetLogs() {
+		rm, ok := byAction[hex.EncodeToString(l.GetActionHash())]
+		if !ok {
+			continue
+		}
+		for _, tx := range l.GetTransactions() {
+			rm.TransactionLogs = append(rm.TransactionLogs, &TransactionLogMessage{
+				Type:      tx.GetType().String(),
+				Sender:    tx.GetSender(),
+				Recipient: tx.GetRecipient(),
+				Amount:    tx.GetAmount(),
+			})
+		}
+	}
+	return msg
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+var _sinkHeightMtc = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "iotex_sink_height",
+		Help: "Height of the last block published to the external sink",
+	},
+	[]string{"type"},
+)
+
+func init() {
+	prometheus.MustRegister(_sinkHeightMtc)
+}
+
+type (
+	// Publisher publishes the block messages to an external system
+	Publisher interface {
+		Start(context.Context) error
+		Stop(context.Context) error
+		// Offset returns the height of the last published block
+		Offset() (uint64, error)
+		// Publish publishes the messages of consecutive blocks, and then advances the offset to the height of the last
+		// one. The messages are published again if the offset fails to advance, so the delivery is at-least-once
+		Publish(context.Context, []*BlockMessage) error
+	}
+
+	// BlockReader reads the committed blocks
+	BlockReader interface {
+		Height() (uint64, error)
+		GetBlockByHeight(uint64) (*block.Block, error)
+		GetReceipts(uint64) ([]*action.Receipt, error)
+		ContainsTransactionLog() bool
+		TransactionLogs(uint64) (*iotextypes.TransactionLogs, error)
+	}
+
+	// Sink streams the committed blocks to an external system. It runs in background, resuming from the offset of the
+	// publisher, so that a failure of the external system never blocks the chain
+	Sink struct {
+		cfg       Config
+		reader    BlockReader
+		publisher Publisher
+		notify    chan struct{}
+		cancel    context.CancelFunc
+		wg        sync.WaitGroup
+	}
+)
+
+// NewPublisher creates the publisher of the type in config
+func NewPublisher(cfg Config) (Publisher, error) {
+	switch cfg.Type {
+	case TypeKafka:
+		dbCfg := db.DefaultConfig
+		dbCfg.DbPath = cfg.Kafka.OffsetDBPath
+		return NewKafkaPublisher(cfg.Kafka, db.NewBoltDB(dbCfg))
+	case TypePostgres:
+		sqlDB, err := sql.Open("postgres", cfg.Postgres.DSN)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open postgres db")
+		}
+		return NewPostgresPublisher(sqlDB, cfg.Postgres.TablePrefix), nil
+	default:
+		return nil, errors.Errorf("unsupported sink type %s", cfg.Type)
+	}
+}
+
+// NewSink creates a new sink
+func NewSink(cfg Config, reader BlockReader, publisher Publisher) *Sink {
+	return &Sink{
+		cfg:       cfg,
+		reader:    reader,
+		publisher: publisher,
+		notify:    make(chan struct{}, 1),
+	}
+}
+
+// Start starts the sink
+func (s *Sink) Start(ctx context.Context) error {
+	if err := s.publisher.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start publisher")
+	}
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.wg.Add(1)
+	go s.run(ctx)
+	return nil
+}
+
+// Stop stops the sink
+func (s *Sink) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	return s.publisher.Stop(ctx)
+}
+
+// ReceiveBlock notifies the sink of a new block, which is published in background
+func (s *Sink) ReceiveBlock(*block.Block) error {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *Sink) run(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		wait := s.cfg.PollInterval
+		if err := s.sync(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.L().Error("failed to publish blocks to sink", zap.String("type", s.cfg.Type), zap.Error(err))
+			wait = s.cfg.RetryInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.notify:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// sync publishes the blocks from the offset of the publisher to the tip
+func (s *Sink) sync(ctx context.Context) error {
+	offset, err := s.publisher.Offset()
+	if err != nil {
+		return err
+	}
+	if s.cfg.StartHeight > 0 {
+		offset = max(offset, s.cfg.StartHeight-1)
+	}
+	tip, err := s.reader.Height()
+	if err != nil {
+		return err
+	}
+	for offset < tip {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := min(offset+max(s.cfg.BatchSize, 1), tip)
+		msgs := make([]*BlockMessage, 0, end-offset)
+		for h := offset + 1; h <= end; h++ {
+			msg, err := s.blockMessage(h)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		if err := s.publisher.Publish(ctx, msgs); err != nil {
+			return errors.Wrapf(err, "failed to publish blocks %d to %d", offset+1, end)
+		}
+		offset = end
+		_sinkHeightMtc.WithLabelValues(s.cfg.Type).Set(float64(offset))
+	}
+	return nil
+}
+
+func (s *Sink) blockMessage(height uint64) (*BlockMessage, error) {
+	blk, err := s.reader.GetBlockByHeight(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block %d", height)
+	}
+	receipts, err := s.reader.GetReceipts(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get receipts of block %d", height)
+	}
+	var txLogs *iotextypes.TransactionLogs
+	if s.reader.ContainsTransactionLog() {
+		txLogs, err = s.reader.TransactionLogs(height)
+		if err != nil && errors.Cause(err) != db.ErrNotExist {
+			return nil, errors.Wrapf(err, "failed to get transaction logs of block %d", height)
+		}
+	}
+	return NewBlockMessage(blk, receipts, txLogs), nil
+}
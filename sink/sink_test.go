@@ -0,0 +1,157 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package sink
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockdao"
+)
+
+type testPublisher struct {
+	mu     sync.Mutex
+	offset uint64
+	fails  int
+	msgs   []*BlockMessage
+}
+
+func (tp *testPublisher) Start(context.Context) error { return nil }
+
+func (tp *testPublisher) Stop(context.Context) error { return nil }
+
+func (tp *testPublisher) Offset() (uint64, error) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	return tp.offset, nil
+}
+
+func (tp *testPublisher) Publish(_ context.Context, msgs []*BlockMessage) error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	if tp.fails > 0 {
+		tp.fails--
+		return errors.New("unavailable")
+	}
+	tp.msgs = append(tp.msgs, msgs...)
+	tp.offset = msgs[len(msgs)-1].Height
+	return nil
+}
+
+func (tp *testPublisher) published() []*BlockMessage {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	return append([]*BlockMessage{}, tp.msgs...)
+}
+
+func TestSink(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	dao := mock_blockdao.NewMockBlockDAO(ctrl)
+
+	var (
+		tip       uint64 = 5
+		tipMu     sync.Mutex
+		actHash   = hash.Hash256b([]byte("action"))
+		publisher = &testPublisher{offset: 1, fails: 1}
+		cfg       = DefaultConfig
+	)
+	cfg.Type = TypeKafka
+	cfg.BatchSize = 2
+	cfg.PollInterval = time.Hour
+	cfg.RetryInterval = 10 * time.Millisecond
+	dao.EXPECT().Height().DoAndReturn(func() (uint64, error) {
+		tipMu.Lock()
+		defer tipMu.Unlock()
+		return tip, nil
+	}).AnyTimes()
+	dao.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(func(height uint64) (*block.Block, error) {
+		blk, err := block.NewTestingBuilder().SetHeight(height).SignAndBuild(identityset.PrivateKey(27))
+		return &blk, err
+	}).AnyTimes()
+	dao.EXPECT().GetReceipts(gomock.Any()).DoAndReturn(func(height uint64) ([]*action.Receipt, error) {
+		receipt := &action.Receipt{Status: 1, ActionHash: actHash, BlockHeight: height}
+		receipt.AddLogs(&action.Log{Address: identityset.Address(1).String(), Topics: action.Topics{actHash}, Index: 3})
+		return []*action.Receipt{receipt}, nil
+	}).AnyTimes()
+	dao.EXPECT().ContainsTransactionLog().Return(true).AnyTimes()
+	dao.EXPECT().TransactionLogs(gomock.Any()).Return(&iotextypes.TransactionLogs{
+		Logs: []*iotextypes.TransactionLog{{
+			ActionHash: actHash[:],
+			Transactions: []*iotextypes.TransactionLog_Transaction{{
+				Type:      iotextypes.TransactionLogType_NATIVE_TRANSFER,
+				Sender:    identityset.Address(1).String(),
+				Recipient: identityset.Address(2).String(),
+				Amount:    "10",
+			}},
+		}},
+	}, nil).AnyTimes()
+
+	s := NewSink(cfg, dao, publisher)
+	ctx := context.Background()
+	require.NoError(s.Start(ctx))
+	defer func() {
+		require.NoError(s.Stop(ctx))
+	}()
+	// the blocks after the offset are published after a retry
+	require.Eventually(func() bool {
+		return len(publisher.published()) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	msgs := publisher.published()
+	for i, msg := range msgs {
+		require.Equal(SchemaVersion, msg.Version)
+		require.EqualValues(i+2, msg.Height)
+	}
+	require.Len(msgs[0].Receipts, 1)
+	receipt := msgs[0].Receipts[0]
+	require.Equal(hex.EncodeToString(actHash[:]), receipt.ActionHash)
+	require.Len(receipt.Logs, 1)
+	require.EqualValues(3, receipt.Logs[0].Index)
+	require.Equal([]string{hex.EncodeToString(actHash[:])}, receipt.Logs[0].Topics)
+	require.Equal([]*TransactionLogMessage{{
+		Type:      "NATIVE_TRANSFER",
+		Sender:    identityset.Address(1).String(),
+		Recipient: identityset.Address(2).String(),
+		Amount:    "10",
+	}}, receipt.TransactionLogs)
+
+	// a new block is published on notification
+	tipMu.Lock()
+	tip = 6
+	tipMu.Unlock()
+	require.NoError(s.ReceiveBlock(nil))
+	require.Eventually(func() bool {
+		return len(publisher.published()) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestNewPublisher(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig
+	cfg.Type = TypePostgres
+	cfg.Postgres.DSN = "postgres://localhost:5432/iotex?sslmode=disable"
+	p, err := NewPublisher(cfg)
+	require.NoError(err)
+	require.IsType(&postgresPublisher{}, p)
+	require.NoError(p.Stop(context.Background()))
+
+	cfg.Type = "unknown"
+	_, err = NewPublisher(cfg)
+	require.ErrorContains(err, "unsupported sink type")
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// frames of the archive
+const (
+	// _frameHeader is the manifest without the chunks, which comes first
+	_frameHeader byte = iota + 1
+	// _frameChunk is the hash of a chunk followed by the chunk
+	_frameChunk
+	// _frameManifest is the complete manifest, which comes last
+	_frameManifest
+)
+
+// _maxFrameSize is the max size of a frame accepted when reading an archive
+const _maxFrameSize = 1 << 30
+
+var (
+	// _archiveMagic is the magic and the version of the archive format
+	_archiveMagic = []byte("IOTXSNAP\x01")
+
+	// ErrInvalidArchive indicates the archive is malformed or does not match its manifest
+	ErrInvalidArchive = errors.New("invalid snapshot archive")
+)
+
+// WriteArchive writes the snapshot of the stores at the height into a single archive, which is portable across the
+// nodes. The archive streams the header of the manifest, then the chunks each with its hash, and the complete manifest
+// at last, so that it is written and verified without holding the snapshot in memory or on disk. The bottom is the
+// height of the first block included in the snapshot
+func WriteArchive(
+	w io.Writer,
+	height uint64,
+	blkHash hash.Hash256,
+	chunkSize int,
+	bottom uint64,
+	blockStore BlockStoreByHeight,
+	stores []Store,
+) (*Manifest, error) {
+	views, err := openViews(stores)
+	if err != nil {
+		return nil, err
+	}
+	defer closeViews(views)
+
+	m := &Manifest{
+		Height:    height,
+		BlockHash: hex.EncodeToString(blkHash[:]),
+		Stores:    storeNames(stores),
+	}
+	if _, err := w.Write(_archiveMagic); err != nil {
+		return nil, err
+	}
+	if err := writeManifestFrame(w, _frameHeader, m); err != nil {
+		return nil, err
+	}
+	if err := writeRecords(m, chunkSize, bottom, blockStore, views, func() error {
+		return nil
+	}, func(_ int, data []byte) error {
+		h := hash.Hash256b(data)
+		return writeFrame(w, _frameChunk, h[:], data)
+	}); err != nil {
+		return nil, err
+	}
+	if err := writeManifestFrame(w, _frameManifest, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ImportArchive imports the records of the archive into the stores, and creates the chain db with the blocks of the
+// archive. The chunks are verified against their hashes while they are read, and the chain db is created only after
+// the whole archive is verified against its manifest. If the trusted root is not empty, the root of the manifest
+// should match it. The stores should be started and empty, and the chain db should not exist
+func ImportArchive(
+	ctx context.Context,
+	r io.Reader,
+	trustedRoot string,
+	stores []Store,
+	chainDBCfg db.Config,
+	deser *block.Deserializer,
+) (*Manifest, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(_archiveMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, _archiveMagic) {
+		return nil, errors.Wrap(ErrInvalidArchive, "unknown archive format")
+	}
+	header, err := readManifestFrame(br, _frameHeader)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStores(header, stores); err != nil {
+		return nil, err
+	}
+	log.L().Info("importing snapshot archive", zap.Uint64("height", header.Height))
+
+	var (
+		m      = &Manifest{}
+		blocks = make(map[uint64][]byte)
+		chunks []string
+	)
+	for {
+		typ, payload, err := readFrame(br)
+		if err != nil {
+			return nil, err
+		}
+		if typ == _frameManifest {
+			if err := json.Unmarshal(payload, m); err != nil {
+				return nil, errors.Wrap(ErrInvalidArchive, "failed to decode manifest")
+			}
+			break
+		}
+		if typ != _frameChunk || len(payload) < len(hash.ZeroHash256) {
+			return nil, errors.Wrapf(ErrInvalidArchive, "unexpected frame %d", typ)
+		}
+		h, data := payload[:len(hash.ZeroHash256)], payload[len(hash.ZeroHash256):]
+		if hash.Hash256b(data) != hash.BytesToHash256(h) {
+			return nil, errors.Wrapf(ErrInvalidChunk, "hash of chunk %d mismatch", len(chunks))
+		}
+		if err := importChunk(header, len(chunks), data, stores, func(height uint64, value []byte) {
+			blocks[height] = value
+		}); err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, hex.EncodeToString(h))
+		log.L().Debug("chunk is imported", zap.Int("chunk", len(chunks)-1))
+	}
+	if m.Height != header.Height || m.BlockHash != header.BlockHash ||
+		strings.Join(m.Stores, ",") != strings.Join(header.Stores, ",") ||
+		strings.Join(m.Chunks, ",") != strings.Join(chunks, ",") {
+		return nil, errors.Wrap(ErrInvalidArchive, "archive mismatches its manifest")
+	}
+	root, err := m.Root()
+	if err != nil {
+		return nil, err
+	}
+	if trustedRoot != "" && hex.EncodeToString(root[:]) != strings.TrimPrefix(trustedRoot, "0x") {
+		return nil, errors.Wrapf(ErrUntrustedSnapshot, "root of the archive %x mismatches %s", root, trustedRoot)
+	}
+	if err := createChainDB(ctx, m, blocks, chainDBCfg, deser); err != nil {
+		return nil, err
+	}
+	log.L().Info("imported snapshot archive", zap.Uint64("height", m.Height), log.Hex("root", root[:]))
+	return m, nil
+}
+
+func writeManifestFrame(w io.Writer, typ byte, m *Manifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return writeFrame(w, typ, data)
+}
+
+func readManifestFrame(r *bufio.Reader, typ byte) (*Manifest, error) {
+	t, payload, err := readFrame(r)
+	if err != nil {
+		return nil, err
+	}
+	if t != typ {
+		return nil, errors.Wrapf(ErrInvalidArchive, "unexpected frame %d", t)
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(payload, m); err != nil {
+		return nil, errors.Wrap(ErrInvalidArchive, "failed to decode manifest")
+	}
+	return m, nil
+}
+
+// writeFrame writes the type and the size of the frame, followed by the parts of the payload
+func writeFrame(w io.Writer, typ byte, parts ...[]byte) error {
+	size := 0
+	for _, part := range parts {
+		size += len(part)
+	}
+	if _, err := w.Write(binary.AppendUvarint([]byte{typ}, uint64(size))); err != nil {
+		return err
+	}
+	for _, part := range parts {
+		if _, err := w.Write(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readFrame(r *bufio.Reader) (byte, []byte, error) {
+	typ, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, errors.Wrap(ErrInvalidArchive, "archive is truncated")
+	}
+	size, err := binary.ReadUvarint(r)
+	if err != nil || size > _maxFrameSize {
+		return 0, nil, errors.Wrap(ErrInvalidArchive, "invalid frame size")
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, errors.Wrap(ErrInvalidArchive, "archive is truncated")
+	}
+	return typ, payload, nil
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// Bootstrap downloads the trusted snapshot of the peers, imports the records into the stores, and creates the chain
+// db with the blocks of the snapshot, after which the node syncs the blocks following the snapshot. The stores should
+// be started and empty, and the chain db should not exist. The chain db is created at last, so the bootstrap starts
+// over if the node is interrupted in the middle
+func Bootstrap(ctx context.Context, cfg Config, stores []Store, chainDBCfg db.Config, deser *block.Deserializer) (*Manifest, error) {
+	m, peers, err := selectSnapshot(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStores(m, stores); err != nil {
+		return nil, err
+	}
+	log.L().Info("bootstrapping from snapshot",
+		zap.Uint64("height", m.Height),
+		zap.Int("chunks", len(m.Chunks)),
+		zap.Strings("peers", peers),
+	)
+
+	var (
+		mutex  sync.Mutex
+		blocks = make(map[uint64][]byte)
+	)
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(max(cfg.Concurrency, 1))
+	for i := range m.Chunks {
+		i := i
+		eg.Go(func() error {
+			data, err := downloadChunk(egCtx, cfg, peers, m, i)
+			if err != nil {
+				return err
+			}
+			if err := importChunk(m, i, data, stores, func(height uint64, value []byte) {
+				mutex.Lock()
+				blocks[height] = value
+				mutex.Unlock()
+			}); err != nil {
+				return err
+			}
+			log.L().Debug("chunk is imported", zap.Int("chunk", i))
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	if err := createChainDB(ctx, m, blocks, chainDBCfg, deser); err != nil {
+		return nil, err
+	}
+	log.L().Info("bootstrapped from snapshot", zap.Uint64("height", m.Height))
+	return m, nil
+}
+
+func checkStores(m *Manifest, stores []Store) error {
+	if names := storeNames(stores); strings.Join(m.Stores, ",") != strings.Join(names, ",") {
+		return errors.Errorf("stores of snapshot %v mismatch the stores of the node %v", m.Stores, names)
+	}
+	return nil
+}
+
+// importChunk imports the records of the chunk into the stores, and passes the serialized blocks to putBlock
+func importChunk(m *Manifest, index int, data []byte, stores []Store, putBlock func(uint64, []byte)) error {
+	batches := make([]batch.KVStoreBatch, len(stores))
+	for j := range batches {
+		batches[j] = batch.NewBatch()
+	}
+	if err := decodeRecords(data, func(r *record) error {
+		switch {
+		case r.store == 0:
+			if len(r.key) != 8 {
+				return errors.Wrapf(ErrInvalidChunk, "invalid block height in chunk %d", index)
+			}
+			putBlock(binary.BigEndian.Uint64(r.key), r.value)
+		case r.store < uint64(len(m.Stores)):
+			batches[r.store-1].Put(r.ns, r.key, r.value, "failed to import snapshot record")
+		default:
+			return errors.Wrapf(ErrInvalidChunk, "invalid store in chunk %d", index)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for j, b := range batches {
+		if b.Size() == 0 {
+			continue
+		}
+		if err := stores[j].KVStore.WriteBatch(b); err != nil {
+			return errors.Wrapf(err, "failed to import chunk %d into store %s", index, stores[j].Name)
+		}
+	}
+	return nil
+}
+
+// selectSnapshot returns the manifest of the trusted snapshot, and the peers serving it
+func selectSnapshot(ctx context.Context, cfg Config) (*Manifest, []string, error) {
+	type candidate struct {
+		manifest *Manifest
+		peers    []string
+	}
+	candidates := make(map[hash.Hash256]*candidate)
+	for _, peer := range cfg.Peers {
+		m, err := fetchManifest(ctx, cfg, peer)
+		if err != nil {
+			log.L().Warn("failed to fetch snapshot manifest", zap.String("peer", peer), zap.Error(err))
+			continue
+		}
+		root, err := m.Root()
+		if err != nil {
+			log.L().Warn("invalid snapshot manifest", zap.String("peer", peer), zap.Error(err))
+			continue
+		}
+		c, ok := candidates[root]
+		if !ok {
+			c = &candidate{manifest: m}
+			candidates[root] = c
+		}
+		c.peers = append(c.peers, peer)
+	}
+	if cfg.TrustedRoot != "" {
+		b, err := hex.DecodeString(strings.TrimPrefix(cfg.TrustedRoot, "0x"))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid trusted root %s", cfg.TrustedRoot)
+		}
+		c, ok := candidates[hash.BytesToHash256(b)]
+		if !ok {
+			return nil, nil, errors.Wrapf(ErrUntrustedSnapshot, "no peer serves the snapshot of root %s", cfg.TrustedRoot)
+		}
+		return c.manifest, c.peers, nil
+	}
+	var best *candidate
+	for _, c := range candidates {
+		if len(c.peers) < cfg.MinAgreement {
+			continue
+		}
+		if best == nil || c.manifest.Height > best.manifest.Height {
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, nil, errors.Wrapf(ErrUntrustedSnapshot, "no snapshot is served by at least %d peers", cfg.MinAgreement)
+	}
+	return best.manifest, best.peers, nil
+}
+
+func fetchManifest(ctx context.Context, cfg Config, peer string) (*Manifest, error) {
+	data, err := get(ctx, cfg, strings.TrimSuffix(peer, "/")+"/snapshot/manifest")
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, errors.Wrap(err, "failed to decode manifest")
+	}
+	return m, nil
+}
+
+// downloadChunk downloads the chunk from the peers in turn until a valid one is received
+func downloadChunk(ctx context.Context, cfg Config, peers []string, m *Manifest, index int) ([]byte, error) {
+	var lastErr error
+	for i := range peers {
+		peer := peers[(index+i)%len(peers)]
+		data, err := get(ctx, cfg, fmt.Sprintf("%s/snapshot/%d/%d", strings.TrimSuffix(peer, "/"), m.Height, index))
+		if err == nil {
+			err = m.VerifyChunk(index, data)
+		}
+		if err == nil {
+			return data, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		log.L().Warn("failed to download chunk", zap.String("peer", peer), zap.Int("chunk", index), zap.Error(err))
+		lastErr = err
+	}
+	return nil, errors.Wrapf(lastErr, "failed to download chunk %d", index)
+}
+
+func get(ctx context.Context, cfg Config, url string) ([]byte, error) {
+	if cfg.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.RequestTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s of %s", resp.Status, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// createChainDB creates the chain db starting from the first block of the snapshot
+func createChainDB(ctx context.Context, m *Manifest, blocks map[uint64][]byte, cfg db.Config, deser *block.Deserializer) error {
+	heights := make([]uint64, 0, len(blocks))
+	for h := range blocks {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	if len(heights) == 0 || heights[len(heights)-1] != m.Height || heights[len(heights)-1]-heights[0]+1 != uint64(len(heights)) {
+		return errors.Wrap(ErrInvalidChunk, "blocks of the snapshot are not contiguous up to the snapshot height")
+	}
+	fd, err := filedao.NewFileDAOWithBottom(heights[0], cfg, deser)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chain db")
+	}
+	if err := fd.Start(ctx); err != nil {
+		return err
+	}
+	if err := putBlocks(ctx, fd, m, heights, blocks, deser); err != nil {
+		// remove the chain db to start over
+		if stopErr := fd.Stop(ctx); stopErr != nil {
+			log.L().Error("failed to stop chain db", zap.Error(stopErr))
+		}
+		if rmErr := os.RemoveAll(cfg.DbPath); rmErr != nil {
+			log.L().Error("failed to remove chain db", zap.String("path", cfg.DbPath), zap.Error(rmErr))
+		}
+		return err
+	}
+	return fd.Stop(ctx)
+}
+
+func putBlocks(ctx context.Context, fd filedao.FileDAO, m *Manifest, heights []uint64, blocks map[uint64][]byte, deser *block.Deserializer) error {
+	var prevHash hash.Hash256
+	for _, h := range heights {
+		store, err := deser.DeserializeBlockStore(blocks[h])
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize block %d", h)
+		}
+		blk := store.Block
+		if blk.Height() != h || (h != heights[0] && blk.PrevHash() != prevHash) {
+			return errors.Wrapf(ErrInvalidChunk, "block %d does not link to the previous block", h)
+		}
+		blk.Receipts = store.Receipts
+		if err := fd.PutBlock(ctx, blk); err != nil {
+			return errors.Wrapf(err, "failed to put block %d", h)
+		}
+		prevHash = blk.HashBlock()
+	}
+	if hex.EncodeToString(prevHash[:]) != m.BlockHash {
+		return errors.Wrap(ErrInvalidChunk, "hash of the top block mismatches the manifest")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package snapshot
+
+import "time"
+
+// Config is the config of state snapshots
+type Config struct {
+	// Interval is the number of blocks between the snapshots taken by the node, 0 disables taking snapshots. It should
+	// be a multiple of the epoch length, so that the snapshots are taken at the end of the epochs
+	Interval uint64 `yaml:"interval"`
+	// Dir is the directory to store the snapshots
+	Dir string `yaml:"dir"`
+	// Keep is the number of the latest snapshots kept in the directory
+	Keep int `yaml:"keep"`
+	// ChunkSize is the size of a chunk in bytes
+	ChunkSize int `yaml:"chunkSize"`
+	// ServePort is the port to serve the snapshots to the peers, 0 disables serving
+	ServePort int `yaml:"servePort"`
+	// Peers are the URLs of the nodes serving the snapshots. If it is not empty, a node without chain db bootstraps
+	// from the latest snapshot of the peers, instead of replaying the blocks from the genesis
+	Peers []string `yaml:"peers"`
+	// TrustedRoot is the hex encoded root of the snapshot to bootstrap from, which is published out-of-band. If it is
+	// empty, the snapshot of the highest height served with the same root by at least MinAgreement peers is trusted
+	TrustedRoot string `yaml:"trustedRoot"`
+	// MinAgreement is the min number of peers serving the same snapshot if TrustedRoot is empty
+	MinAgreement int `yaml:"minAgreement"`
+	// Concurrency is the number of chunks downloaded concurrently
+	Concurrency int `yaml:"concurrency"`
+	// RequestTimeout is the timeout of downloading a manifest or a chunk
+	RequestTimeout time.Duration `yaml:"requestTimeout"`
+}
+
+// DefaultConfig is the default config of state snapshots
+var DefaultConfig = Config{
+	Interval:       0,
+	Dir:            "/var/data/snapshot",
+	Peers:          []string{},
+	Keep:           2,
+	ChunkSize:      16 << 20,
+	ServePort:      0,
+	MinAgreement:   2,
+	Concurrency:    4,
+	RequestTimeout: 5 * time.Minute,
+}
+
+// Bootstrap returns true if the node bootstraps from a snapshot of the peers
+func (cfg Config) Bootstrap() bool {
+	return len(cfg.Peers) > 0
+}
@@ -0,0 +1,398 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const _manifestFile = "manifest.json"
+
+type (
+	// Store is a KV store included in the snapshots
+	Store struct {
+		Name    string
+		KVStore db.KVStore
+	}
+
+	// BlockStoreByHeight returns the block and its receipts of the height
+	BlockStoreByHeight func(uint64) (*block.Store, error)
+
+	// Exporter takes the snapshots of the stores every interval blocks, and serves them to the peers. It is added as
+	// the last indexer of the block dao, so that the views of the stores are opened after all the stores have
+	// committed the block, and before the next block is committed
+	Exporter struct {
+		cfg        Config
+		stores     []Store
+		height     func() (uint64, error)
+		bottom     func(uint64) uint64
+		blockStore BlockStoreByHeight
+
+		mutex     sync.RWMutex
+		manifests []*Manifest
+		exporting atomic.Bool
+		requested atomic.Bool
+		quit      chan struct{}
+		wg        sync.WaitGroup
+	}
+)
+
+// NewExporter creates a snapshot exporter. The height returns the height of the stores, and the bottom returns the
+// height of the first block included in the snapshot of a height, since the blocks of the current epoch and the
+// recent block hashes are needed to resume the chain
+func NewExporter(
+	cfg Config,
+	stores []Store,
+	height func() (uint64, error),
+	bottom func(uint64) uint64,
+	blockStore BlockStoreByHeight,
+) *Exporter {
+	return &Exporter{
+		cfg:        cfg,
+		stores:     stores,
+		height:     height,
+		bottom:     bottom,
+		blockStore: blockStore,
+	}
+}
+
+// Start loads the snapshots in the directory
+func (e *Exporter) Start(_ context.Context) error {
+	e.quit = make(chan struct{})
+	if err := os.MkdirAll(e.cfg.Dir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create snapshot dir %s", e.cfg.Dir)
+	}
+	entries, err := os.ReadDir(e.cfg.Dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read snapshot dir %s", e.cfg.Dir)
+	}
+	var manifests []*Manifest
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := strconv.ParseUint(entry.Name(), 10, 64); err != nil {
+			continue
+		}
+		m, err := readManifest(filepath.Join(e.cfg.Dir, entry.Name(), _manifestFile))
+		if err != nil {
+			log.L().Warn("failed to load snapshot", zap.String("snapshot", entry.Name()), zap.Error(err))
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].Height < manifests[j].Height
+	})
+	e.mutex.Lock()
+	e.manifests = manifests
+	e.mutex.Unlock()
+	return nil
+}
+
+// Stop stops the snapshot in progress
+func (e *Exporter) Stop(_ context.Context) error {
+	close(e.quit)
+	e.wg.Wait()
+	return nil
+}
+
+// Height returns the height of the stores
+func (e *Exporter) Height() (uint64, error) {
+	return e.height()
+}
+
+// Request requests a snapshot to be taken at the next block regardless of the interval
+func (e *Exporter) Request() {
+	e.requested.Store(true)
+}
+
+// PutBlock takes a snapshot if the height of the block is a multiple of the interval, or a snapshot is requested
+func (e *Exporter) PutBlock(_ context.Context, blk *block.Block) error {
+	height := blk.Height()
+	if !e.requested.Swap(false) && (e.cfg.Interval == 0 || height%e.cfg.Interval != 0) {
+		return nil
+	}
+	if !e.exporting.CompareAndSwap(false, true) {
+		log.L().Warn("skip snapshot since the previous one is in progress", zap.Uint64("height", height))
+		return nil
+	}
+	views, err := openViews(e.stores)
+	if err != nil {
+		e.exporting.Store(false)
+		return err
+	}
+	blkHash := blk.HashBlock()
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		defer e.exporting.Store(false)
+		defer closeViews(views)
+		if err := e.export(height, blkHash, views); err != nil {
+			log.L().Error("failed to take snapshot", zap.Uint64("height", height), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Manifest returns the manifest of the latest snapshot
+func (e *Exporter) Manifest() (*Manifest, bool) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	if len(e.manifests) == 0 {
+		return nil, false
+	}
+	return e.manifests[len(e.manifests)-1], true
+}
+
+// Handler returns the http handler serving the snapshots, which serves the manifest of the latest snapshot at
+// /snapshot/manifest, and the chunks at /snapshot/{height}/{index}
+func (e *Exporter) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /snapshot/manifest", func(w http.ResponseWriter, r *http.Request) {
+		m, ok := e.Manifest()
+		if !ok {
+			http.Error(w, "no snapshot", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(m); err != nil {
+			log.L().Warn("failed to write snapshot manifest", zap.Error(err))
+		}
+	})
+	mux.HandleFunc("GET /snapshot/{height}/{index}", func(w http.ResponseWriter, r *http.Request) {
+		height, err := strconv.ParseUint(r.PathValue("height"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid height", http.StatusBadRequest)
+			return
+		}
+		index, err := strconv.Atoi(r.PathValue("index"))
+		if err != nil {
+			http.Error(w, "invalid chunk index", http.StatusBadRequest)
+			return
+		}
+		if !e.hasChunk(height, index) {
+			http.Error(w, "chunk not found", http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, chunkPath(filepath.Join(e.cfg.Dir, strconv.FormatUint(height, 10)), index))
+	})
+	return mux
+}
+
+func (e *Exporter) hasChunk(height uint64, index int) bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	for _, m := range e.manifests {
+		if m.Height == height {
+			return index >= 0 && index < len(m.Chunks)
+		}
+	}
+	return false
+}
+
+func (e *Exporter) export(height uint64, blkHash hash.Hash256, views []db.ExportView) error {
+	dir := filepath.Join(e.cfg.Dir, strconv.FormatUint(height, 10))
+	tmpDir := dir + ".tmp"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpDir, 0700); err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	m := &Manifest{
+		Height:    height,
+		BlockHash: hex.EncodeToString(blkHash[:]),
+		Stores:    storeNames(e.stores),
+	}
+	if err := writeRecords(m, e.cfg.ChunkSize, e.bottom(height), e.blockStore, views, func() error {
+		select {
+		case <-e.quit:
+			return errors.New("snapshot is stopped")
+		default:
+			return nil
+		}
+	}, func(index int, data []byte) error {
+		return os.WriteFile(chunkPath(tmpDir, index), data, 0600)
+	}); err != nil {
+		return err
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, _manifestFile), data, 0600); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return err
+	}
+	root, err := m.Root()
+	if err != nil {
+		return err
+	}
+	log.L().Info("snapshot is taken",
+		zap.Uint64("height", height),
+		zap.Int("chunks", len(m.Chunks)),
+		log.Hex("root", root[:]),
+	)
+	e.addManifest(m)
+	return nil
+}
+
+func (e *Exporter) addManifest(m *Manifest) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.manifests = append(e.manifests, m)
+	if e.cfg.Keep <= 0 || len(e.manifests) <= e.cfg.Keep {
+		return
+	}
+	expired := e.manifests[:len(e.manifests)-e.cfg.Keep]
+	e.manifests = append([]*Manifest{}, e.manifests[len(expired):]...)
+	for _, m := range expired {
+		if err := os.RemoveAll(filepath.Join(e.cfg.Dir, strconv.FormatUint(m.Height, 10))); err != nil {
+			log.L().Warn("failed to remove snapshot", zap.Uint64("height", m.Height), zap.Error(err))
+		}
+	}
+}
+
+// writeRecords writes the blocks from the bottom to the height of the manifest, followed by the records of the views
+// in the order of the stores of the manifest, into the chunks. The check is called before each record to abort the
+// writing, and the flush is called with each chunk before its hash is added to the manifest
+func writeRecords(
+	m *Manifest,
+	chunkSize int,
+	bottom uint64,
+	blockStore BlockStoreByHeight,
+	views []db.ExportView,
+	check func() error,
+	flush func(int, []byte) error,
+) error {
+	var buf []byte
+	flushChunk := func() error {
+		if err := flush(len(m.Chunks), buf); err != nil {
+			return err
+		}
+		h := hash.Hash256b(buf)
+		m.Chunks = append(m.Chunks, hex.EncodeToString(h[:]))
+		buf = buf[:0]
+		return nil
+	}
+	write := func(r *record) error {
+		if err := check(); err != nil {
+			return err
+		}
+		buf = appendRecord(buf, r)
+		if len(buf) < chunkSize {
+			return nil
+		}
+		return flushChunk()
+	}
+	for h := bottom; h <= m.Height; h++ {
+		store, err := blockStore(h)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block %d", h)
+		}
+		value, err := store.Serialize()
+		if err != nil {
+			return err
+		}
+		if err := write(&record{
+			key:   binary.BigEndian.AppendUint64(nil, h),
+			value: value,
+		}); err != nil {
+			return err
+		}
+	}
+	for i, view := range views {
+		store := uint64(i + 1)
+		if err := view.ForEach(func(ns string, key, value []byte) error {
+			return write(&record{store: store, ns: ns, key: key, value: value})
+		}); err != nil {
+			return errors.Wrapf(err, "failed to export store %s", m.Stores[store])
+		}
+	}
+	if len(buf) > 0 {
+		return flushChunk()
+	}
+	return nil
+}
+
+// openViews opens the views of the stores
+func openViews(stores []Store) ([]db.ExportView, error) {
+	views := make([]db.ExportView, 0, len(stores))
+	for _, store := range stores {
+		kv, ok := store.KVStore.(db.KVStoreWithExport)
+		if !ok {
+			closeViews(views)
+			return nil, errors.Wrapf(db.ErrNotSupported, "store %s cannot be exported", store.Name)
+		}
+		view, err := kv.Export()
+		if err != nil {
+			closeViews(views)
+			return nil, errors.Wrapf(err, "failed to open the view of store %s", store.Name)
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+func closeViews(views []db.ExportView) {
+	for _, view := range views {
+		if err := view.Close(); err != nil {
+			log.L().Error("failed to close snapshot view", zap.Error(err))
+		}
+	}
+}
+
+// storeNames returns the names of the stores in a snapshot, the first of which is the block store
+func storeNames(stores []Store) []string {
+	names := []string{BlockStoreName}
+	for _, store := range stores {
+		names = append(names, store.Name)
+	}
+	return names
+}
+
+func chunkPath(dir string, index int) string {
+	return filepath.Join(dir, fmt.Sprintf("chunk-%d", index))
+}
+
+func readManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/crypto"
+)
+
+// BlockStoreName is the name of the store of the recent blocks in a snapshot, the key of which is the height in big
+// endian, and the value is the serialized block with receipts
+const BlockStoreName = "blocks"
+
+var (
+	// ErrInvalidChunk indicates the chunk does not match the manifest
+	ErrInvalidChunk = errors.New("invalid chunk")
+	// ErrUntrustedSnapshot indicates no snapshot of the peers is trusted
+	ErrUntrustedSnapshot = errors.New("untrusted snapshot")
+)
+
+type (
+	// Manifest describes a snapshot, which consists of the records of the stores at a height, split into chunks
+	Manifest struct {
+		Height uint64 `json:"height"`
+		// BlockHash is the hex encoded hash of the block at the height
+		BlockHash string `json:"blockHash"`
+		// Stores are the names of the stores in the snapshot
+		Stores []string `json:"stores"`
+		// Chunks are the hex encoded hashes of the chunks
+		Chunks []string `json:"chunks"`
+	}
+
+	record struct {
+		store uint64
+		ns    string
+		key   []byte
+		value []byte
+	}
+)
+
+// Root returns the merkle root of the manifest, the leaves of which are the hash of the header fields followed by
+// the hashes of the chunks
+func (m *Manifest) Root() (hash.Hash256, error) {
+	var header bytes.Buffer
+	header.Write(binary.BigEndian.AppendUint64(nil, m.Height))
+	header.WriteString(m.BlockHash)
+	header.WriteString(strings.Join(m.Stores, ","))
+	leaves := []hash.Hash256{hash.Hash256b(header.Bytes())}
+	for i := range m.Chunks {
+		h, err := m.chunkHash(i)
+		if err != nil {
+			return hash.ZeroHash256, err
+		}
+		leaves = append(leaves, h)
+	}
+	return crypto.NewMerkleTree(leaves).HashTree(), nil
+}
+
+// VerifyChunk verifies the chunk of the index against the manifest
+func (m *Manifest) VerifyChunk(index int, data []byte) error {
+	if index < 0 || index >= len(m.Chunks) {
+		return errors.Wrapf(ErrInvalidChunk, "chunk %d out of range", index)
+	}
+	h, err := m.chunkHash(index)
+	if err != nil {
+		return err
+	}
+	if hash.Hash256b(data) != h {
+		return errors.Wrapf(ErrInvalidChunk, "hash of chunk %d mismatch", index)
+	}
+	return nil
+}
+
+func (m *Manifest) chunkHash(index int) (hash.Hash256, error) {
+	b, err := hex.DecodeString(m.Chunks[index])
+	if err != nil || len(b) != len(hash.ZeroHash256) {
+		return hash.ZeroHash256, errors.Wrapf(ErrInvalidChunk, "invalid hash of chunk %d", index)
+	}
+	return hash.BytesToHash256(b), nil
+}
+
+func appendRecord(buf []byte, r *record) []byte {
+	buf = binary.AppendUvarint(buf, r.store)
+	buf = binary.AppendUvarint(buf, uint64(len(r.ns)))
+	buf = append(buf, r.ns...)
+	buf = binary.AppendUvarint(buf, uint64(len(r.key)))
+	buf = append(buf, r.key...)
+	buf = binary.AppendUvarint(buf, uint64(len(r.value)))
+	return append(buf, r.value...)
+}
+
+// decodeRecords decodes the records of a chunk, the slices of the records refer to the chunk
+func decodeRecords(data []byte, fn func(*record) error) error {
+	readBytes := func() ([]byte, error) {
+		n, size := binary.Uvarint(data)
+		if size <= 0 || n > uint64(len(data)-size) {
+			return nil, errors.Wrap(ErrInvalidChunk, "malformed record")
+		}
+		b := data[size : size+int(n)]
+		data = data[size+int(n):]
+		return b, nil
+	}
+	for len(data) > 0 {
+		store, size := binary.Uvarint(data)
+		if size <= 0 {
+			return errors.Wrap(ErrInvalidChunk, "malformed record")
+		}
+		data = data[size:]
+		ns, err := readBytes()
+		if err != nil {
+			return err
+		}
+		key, err := readBytes()
+		if err != nil {
+			return err
+		}
+		value, err := readBytes()
+		if err != nil {
+			return err
+		}
+		if err := fn(&record{store: store, ns: string(ns), key: key, value: value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
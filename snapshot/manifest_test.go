@@ -0,0 +1,77 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecords(t *testing.T) {
+	require := require.New(t)
+	records := []*record{
+		{store: 0, key: []byte{0, 0, 0, 0, 0, 0, 0, 1}, value: []byte("block")},
+		{store: 1, ns: "Account", key: []byte("currentHeight"), value: []byte{}},
+		{store: 300, ns: "ns", key: []byte("key"), value: make([]byte, 1000)},
+	}
+	var buf []byte
+	for _, r := range records {
+		buf = appendRecord(buf, r)
+	}
+	var decoded []*record
+	require.NoError(decodeRecords(buf, func(r *record) error {
+		decoded = append(decoded, r)
+		return nil
+	}))
+	require.Equal(records, decoded)
+
+	// truncated chunk
+	require.ErrorIs(decodeRecords(buf[:len(buf)-1], func(*record) error { return nil }), ErrInvalidChunk)
+}
+
+func TestManifest(t *testing.T) {
+	require := require.New(t)
+	chunks := [][]byte{[]byte("chunk0"), []byte("chunk1")}
+	m := &Manifest{
+		Height:    100,
+		BlockHash: hex.EncodeToString(hash.ZeroHash256[:]),
+		Stores:    []string{BlockStoreName, "factory"},
+	}
+	for _, c := range chunks {
+		h := hash.Hash256b(c)
+		m.Chunks = append(m.Chunks, hex.EncodeToString(h[:]))
+	}
+	for i, c := range chunks {
+		require.NoError(m.VerifyChunk(i, c))
+	}
+	require.ErrorIs(m.VerifyChunk(0, chunks[1]), ErrInvalidChunk)
+	require.ErrorIs(m.VerifyChunk(2, chunks[1]), ErrInvalidChunk)
+
+	root, err := m.Root()
+	require.NoError(err)
+	// the root commits to the header fields and the chunks
+	for _, modify := range []func(*Manifest){
+		func(m *Manifest) { m.Height++ },
+		func(m *Manifest) { m.Stores = m.Stores[:1] },
+		func(m *Manifest) { m.Chunks = m.Chunks[:1] },
+		func(m *Manifest) { m.Chunks[0], m.Chunks[1] = m.Chunks[1], m.Chunks[0] },
+	} {
+		modified := *m
+		modified.Stores = append([]string{}, m.Stores...)
+		modified.Chunks = append([]string{}, m.Chunks...)
+		modify(&modified)
+		r, err := modified.Root()
+		require.NoError(err)
+		require.NotEqual(root, r)
+	}
+
+	m.Chunks[0] = "invalid"
+	_, err = m.Root()
+	require.ErrorIs(err, ErrInvalidChunk)
+}
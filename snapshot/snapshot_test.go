@@ -0,0 +1,250 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestSnapshot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	newStores := func(name string) []Store {
+		var stores []Store
+		for _, s := range []string{"factory", "contractstaking"} {
+			cfg := db.DefaultConfig
+			cfg.DbPath = filepath.Join(dir, fmt.Sprintf("%s.%s.db", name, s))
+			kv := db.NewBoltDB(cfg)
+			require.NoError(kv.Start(ctx))
+			stores = append(stores, Store{Name: s, KVStore: kv})
+		}
+		return stores
+	}
+	stopStores := func(stores []Store) {
+		for _, s := range stores {
+			require.NoError(s.KVStore.Stop(ctx))
+		}
+	}
+
+	// the source node with 4 blocks
+	var (
+		blocks   []*block.Store
+		prevHash hash.Hash256
+	)
+	for i := uint64(1); i <= 4; i++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(time.Unix(int64(i), 0)).
+			SetReceipts([]*action.Receipt{{Status: 1, BlockHeight: i, ActionHash: prevHash}}).
+			SignAndBuild(identityset.PrivateKey(0))
+		require.NoError(err)
+		blocks = append(blocks, &block.Store{Block: &blk, Receipts: blk.Receipts})
+		prevHash = blk.HashBlock()
+	}
+	source := newStores("source")
+	defer stopStores(source)
+	for i := 0; i < 100; i++ {
+		require.NoError(source[0].KVStore.Put("Account", []byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+	}
+	require.NoError(source[1].KVStore.Put("stk", []byte("shk"), []byte{4}))
+
+	cfg := DefaultConfig
+	cfg.Interval = 2
+	cfg.Dir = filepath.Join(dir, "snapshot")
+	cfg.ChunkSize = 256
+	e := NewExporter(
+		cfg,
+		source,
+		func() (uint64, error) { return 4, nil },
+		func(height uint64) uint64 { return height - 2 },
+		func(height uint64) (*block.Store, error) { return blocks[height-1], nil },
+	)
+	require.NoError(e.Start(ctx))
+	require.NoError(e.PutBlock(ctx, blocks[2].Block))
+	_, ok := e.Manifest()
+	require.False(ok)
+	require.NoError(e.PutBlock(ctx, blocks[3].Block))
+	// the records written after the block are not in the snapshot
+	require.NoError(source[0].KVStore.Put("Account", []byte("key100"), []byte("value100")))
+	e.wg.Wait()
+	m, ok := e.Manifest()
+	require.True(ok)
+	require.EqualValues(4, m.Height)
+	require.Equal([]string{BlockStoreName, "factory", "contractstaking"}, m.Stores)
+	require.Greater(len(m.Chunks), 1)
+	require.NoError(e.Stop(ctx))
+
+	// the snapshots are loaded on start
+	e = NewExporter(cfg, source, nil, nil, nil)
+	require.NoError(e.Start(ctx))
+	loaded, ok := e.Manifest()
+	require.True(ok)
+	require.Equal(m, loaded)
+	defer e.Stop(ctx)
+
+	peer1 := httptest.NewServer(e.Handler())
+	defer peer1.Close()
+	peer2 := httptest.NewServer(e.Handler())
+	defer peer2.Close()
+	cfg.Peers = []string{peer1.URL, peer2.URL}
+
+	// the snapshot is not trusted
+	untrusted := newStores("untrusted")
+	defer stopStores(untrusted)
+	cfg.MinAgreement = 3
+	_, err := Bootstrap(ctx, cfg, untrusted, db.DefaultConfig, block.NewDeserializer(0))
+	require.ErrorIs(err, ErrUntrustedSnapshot)
+	cfg.MinAgreement = 2
+	cfg.TrustedRoot = hex.EncodeToString(hash.ZeroHash256[:])
+	_, err = Bootstrap(ctx, cfg, untrusted, db.DefaultConfig, block.NewDeserializer(0))
+	require.ErrorIs(err, ErrUntrustedSnapshot)
+
+	root, err := m.Root()
+	require.NoError(err)
+	cfg.TrustedRoot = hex.EncodeToString(root[:])
+	target := newStores("target")
+	defer stopStores(target)
+	chainDBCfg := db.DefaultConfig
+	chainDBCfg.DbPath = filepath.Join(dir, "chain.db")
+	bootstrapped, err := Bootstrap(ctx, cfg, target, chainDBCfg, block.NewDeserializer(0))
+	require.NoError(err)
+	require.Equal(m, bootstrapped)
+	for i := 0; i < 100; i++ {
+		v, err := target[0].KVStore.Get("Account", []byte(fmt.Sprintf("key%d", i)))
+		require.NoError(err)
+		require.Equal([]byte(fmt.Sprintf("value%d", i)), v)
+	}
+	_, err = target[0].KVStore.Get("Account", []byte("key100"))
+	require.ErrorIs(err, db.ErrNotExist)
+	v, err := target[1].KVStore.Get("stk", []byte("shk"))
+	require.NoError(err)
+	require.Equal([]byte{4}, v)
+
+	fd, err := filedao.NewFileDAO(chainDBCfg, block.NewDeserializer(0))
+	require.NoError(err)
+	require.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+	height, err := fd.Height()
+	require.NoError(err)
+	require.EqualValues(4, height)
+	for i := uint64(2); i <= 4; i++ {
+		h, err := fd.GetBlockHash(i)
+		require.NoError(err)
+		require.Equal(blocks[i-1].Block.HashBlock(), h)
+	}
+	_, err = fd.GetBlockByHeight(1)
+	require.Error(err)
+}
+
+func TestArchive(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	newStores := func(name string) []Store {
+		cfg := db.DefaultConfig
+		cfg.DbPath = filepath.Join(dir, name+".factory.db")
+		kv := db.NewBoltDB(cfg)
+		require.NoError(kv.Start(ctx))
+		return []Store{{Name: "factory", KVStore: kv}}
+	}
+
+	var (
+		blocks   []*block.Store
+		prevHash hash.Hash256
+	)
+	for i := uint64(1); i <= 4; i++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(time.Unix(int64(i), 0)).
+			SignAndBuild(identityset.PrivateKey(0))
+		require.NoError(err)
+		blocks = append(blocks, &block.Store{Block: &blk, Receipts: []*action.Receipt{}})
+		prevHash = blk.HashBlock()
+	}
+	source := newStores("source")
+	defer source[0].KVStore.Stop(ctx)
+	for i := 0; i < 100; i++ {
+		require.NoError(source[0].KVStore.Put("Account", []byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+	}
+	var buf bytes.Buffer
+	m, err := WriteArchive(&buf, 4, prevHash, 256, 3, func(height uint64) (*block.Store, error) {
+		return blocks[height-1], nil
+	}, source)
+	require.NoError(err)
+	require.Equal([]string{BlockStoreName, "factory"}, m.Stores)
+	require.Greater(len(m.Chunks), 1)
+	root, err := m.Root()
+	require.NoError(err)
+	archive := buf.Bytes()
+
+	deser := block.NewDeserializer(0)
+	for _, c := range []struct {
+		data []byte
+		root string
+		err  error
+	}{
+		{archive[:len(archive)-1], "", ErrInvalidArchive},
+		{append([]byte("IOTXSNAP\x02"), archive[9:]...), "", ErrInvalidArchive},
+		{archive, hex.EncodeToString(hash.ZeroHash256[:]), ErrUntrustedSnapshot},
+	} {
+		target := newStores("invalid")
+		chainDBCfg := db.DefaultConfig
+		chainDBCfg.DbPath = filepath.Join(dir, "invalid.chain.db")
+		_, err = ImportArchive(ctx, bytes.NewReader(c.data), c.root, target, chainDBCfg, deser)
+		require.ErrorIs(err, c.err)
+		require.NoFileExists(chainDBCfg.DbPath)
+		require.NoError(target[0].KVStore.Stop(ctx))
+	}
+	// a tampered chunk
+	tampered := append([]byte{}, archive...)
+	tampered[len(tampered)/2] ^= 1
+	target := newStores("tampered")
+	_, err = ImportArchive(ctx, bytes.NewReader(tampered), "", target, db.DefaultConfig, deser)
+	require.Error(err)
+	require.NoError(target[0].KVStore.Stop(ctx))
+
+	target = newStores("target")
+	defer target[0].KVStore.Stop(ctx)
+	chainDBCfg := db.DefaultConfig
+	chainDBCfg.DbPath = filepath.Join(dir, "chain.db")
+	imported, err := ImportArchive(ctx, bytes.NewReader(archive), "0x"+hex.EncodeToString(root[:]), target, chainDBCfg, deser)
+	require.NoError(err)
+	require.Equal(m, imported)
+	for i := 0; i < 100; i++ {
+		v, err := target[0].KVStore.Get("Account", []byte(fmt.Sprintf("key%d", i)))
+		require.NoError(err)
+		require.Equal([]byte(fmt.Sprintf("value%d", i)), v)
+	}
+	fd, err := filedao.NewFileDAO(chainDBCfg, deser)
+	require.NoError(err)
+	require.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+	height, err := fd.Height()
+	require.NoError(err)
+	require.EqualValues(4, height)
+	h, err := fd.GetBlockHash(3)
+	require.NoError(err)
+	require.Equal(blocks[2].Block.HashBlock(), h)
+}
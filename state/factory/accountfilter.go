@@ -0,0 +1,143 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/bloom"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const _accountFilterNumHash = 4
+
+var _accountFilterMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_account_filter",
+		Help: "IoTeX account existence filter lookups",
+	},
+	[]string{"type"},
+)
+
+func init() {
+	prometheus.MustRegister(_accountFilterMtc)
+}
+
+// accountFilter is a KVStore with a bloom filter of the keys in AccountKVNamespace, so that the lookups of the
+// accounts which definitely don't exist, e.g., the random recipients of spam transfers, don't hit the DB. The filter
+// is built from the DB on start, and the keys are added before they are written, deleted keys stay in the filter
+type accountFilter struct {
+	mutex  sync.RWMutex
+	store  db.KVStore
+	size   uint64
+	filter bloom.BloomFilter
+}
+
+func newAccountFilter(store db.KVStore, size uint64) *accountFilter {
+	return &accountFilter{
+		store: store,
+		size:  size,
+	}
+}
+
+func (f *accountFilter) Start(ctx context.Context) error {
+	if err := f.store.Start(ctx); err != nil {
+		return err
+	}
+	filter, err := bloom.NewBloomFilter(f.size, _accountFilterNumHash)
+	if err != nil {
+		return err
+	}
+	// the condition never matches, so the filter returns ErrNotExist after iterating all the accounts
+	_, _, err = f.store.Filter(AccountKVNamespace, func(k, v []byte) bool {
+		filter.Add(k)
+		return false
+	}, nil, nil)
+	if cause := errors.Cause(err); cause != nil && cause != db.ErrNotExist && cause != db.ErrBucketNotExist {
+		// the lookups go to the DB directly
+		log.L().Warn("Failed to build account filter.", zap.Error(err))
+		return nil
+	}
+	f.mutex.Lock()
+	f.filter = filter
+	f.mutex.Unlock()
+	log.L().Info("Account filter is built.", zap.Uint64("accounts", filter.NumElements()))
+	return nil
+}
+
+func (f *accountFilter) Stop(ctx context.Context) error {
+	f.mutex.Lock()
+	f.filter = nil
+	f.mutex.Unlock()
+	return f.store.Stop(ctx)
+}
+
+func (f *accountFilter) Put(ns string, key, value []byte) error {
+	if ns == AccountKVNamespace {
+		f.add(key)
+	}
+	return f.store.Put(ns, key, value)
+}
+
+func (f *accountFilter) Get(ns string, key []byte) ([]byte, error) {
+	if ns != AccountKVNamespace {
+		return f.store.Get(ns, key)
+	}
+	if !f.mayExist(key) {
+		_accountFilterMtc.WithLabelValues("absent").Inc()
+		return nil, errors.Wrapf(db.ErrNotExist, "key = %x doesn't exist", key)
+	}
+	value, err := f.store.Get(ns, key)
+	if errors.Cause(err) == db.ErrNotExist {
+		_accountFilterMtc.WithLabelValues("falsePositive").Inc()
+	} else {
+		_accountFilterMtc.WithLabelValues("present").Inc()
+	}
+	return value, err
+}
+
+func (f *accountFilter) Delete(ns string, key []byte) error {
+	return f.store.Delete(ns, key)
+}
+
+func (f *accountFilter) Filter(ns string, cond db.Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	return f.store.Filter(ns, cond, minKey, maxKey)
+}
+
+// WriteBatch adds the accounts written by the batch into the filter, and then writes the batch
+func (f *accountFilter) WriteBatch(b batch.KVStoreBatch) error {
+	for i := 0; i < b.Size(); i++ {
+		wi, err := b.Entry(i)
+		if err != nil {
+			return err
+		}
+		if wi.WriteType() == batch.Put && wi.Namespace() == AccountKVNamespace {
+			f.add(wi.Key())
+		}
+	}
+	return f.store.WriteBatch(b)
+}
+
+func (f *accountFilter) add(key []byte) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.filter != nil {
+		f.filter.Add(key)
+	}
+}
+
+func (f *accountFilter) mayExist(key []byte) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return f.filter == nil || f.filter.Exist(key)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestAccountFilter(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	testPath, err := testutil.PathOfTempFile("test-account-filter")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+
+	var (
+		alice = identityset.Address(1).Bytes()
+		bob   = identityset.Address(2).Bytes()
+		carol = identityset.Address(3).Bytes()
+	)
+	store := db.NewBoltDB(cfg)
+	require.NoError(store.Start(ctx))
+	require.NoError(store.Put(AccountKVNamespace, alice, []byte("alice")))
+	require.NoError(store.Stop(ctx))
+
+	f := newAccountFilter(db.NewBoltDB(cfg), 1<<16)
+	require.NoError(f.Start(ctx))
+	// the existing accounts are loaded on start
+	value, err := f.Get(AccountKVNamespace, alice)
+	require.NoError(err)
+	require.Equal([]byte("alice"), value)
+	require.False(f.mayExist(bob))
+	_, err = f.Get(AccountKVNamespace, bob)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	b := batch.NewBatch()
+	b.Put(AccountKVNamespace, bob, []byte("bob"), "")
+	require.NoError(f.WriteBatch(b))
+	require.NoError(f.Put(AccountKVNamespace, carol, []byte("carol")))
+	for _, addr := range [][]byte{bob, carol} {
+		require.True(f.mayExist(addr))
+		_, err = f.Get(AccountKVNamespace, addr)
+		require.NoError(err)
+	}
+	// deleted accounts stay in the filter
+	require.NoError(f.Delete(AccountKVNamespace, carol))
+	require.True(f.mayExist(carol))
+	_, err = f.Get(AccountKVNamespace, carol)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+	require.NoError(f.Stop(ctx))
+}
@@ -0,0 +1,496 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	// ArchiveDiffNamespace is the bucket storing the diff layers of the archive journal, which are not flattened yet
+	ArchiveDiffNamespace = ArchiveNamespacePrefix + "Diff"
+	// ArchiveSnapshotNamespace is the bucket storing the flattened snapshot of the archive journal
+	ArchiveSnapshotNamespace = ArchiveNamespacePrefix + "Snapshot"
+)
+
+var (
+	_archiveStartHeightKey    = []byte("startHeight")
+	_archiveSnapshotHeightKey = []byte("snapshotHeight")
+)
+
+type (
+	// archiveJournal is the KVStore of the factory in archive mode, which journals the states before they are
+	// changed by each block, instead of keeping a version of the state trie per height.
+	//
+	// The diff of each block is kept in an in-memory diff layer, and persisted along with the block in
+	// ArchiveDiffNamespace for recovery. Every interval heights, the diff layers are flattened into the snapshot in
+	// ArchiveSnapshotNamespace, keyed by namespace hash + key + height, so that the state at a height is read by a
+	// single seek of the snapshot, a lookup of the diff layers, or the latest state.
+	archiveJournal struct {
+		mutex          sync.RWMutex
+		dao            db.KVStore
+		interval       uint64
+		startHeight    uint64
+		snapshotHeight uint64
+		height         uint64
+		layers         []*diffLayer
+	}
+
+	// diffLayer is the states before they are changed by the block at the height
+	diffLayer struct {
+		height  uint64
+		entries map[string]*diffEntry
+	}
+
+	diffEntry struct {
+		ns    string
+		key   []byte
+		exist bool
+		value []byte
+	}
+
+	// archiveView is the read-only KVStore of the states at a height
+	archiveView struct {
+		journal *archiveJournal
+		height  uint64
+	}
+)
+
+func newArchiveJournal(dao db.KVStore, interval uint64) *archiveJournal {
+	return &archiveJournal{
+		dao:      dao,
+		interval: interval,
+	}
+}
+
+func (j *archiveJournal) Start(ctx context.Context) error {
+	if err := j.dao.Start(ctx); err != nil {
+		return err
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	value, err := j.dao.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	switch errors.Cause(err) {
+	case nil:
+		j.height = byteutil.BytesToUint64(value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		j.height = 0
+	default:
+		return err
+	}
+	// the history before the journal is enabled is not available
+	value, err = j.dao.Get(ArchiveDiffNamespace, _archiveStartHeightKey)
+	switch errors.Cause(err) {
+	case nil:
+		j.startHeight = byteutil.BytesToUint64BigEndian(value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		j.startHeight = j.height
+		if err := j.dao.Put(ArchiveDiffNamespace, _archiveStartHeightKey, byteutil.Uint64ToBytesBigEndian(j.height)); err != nil {
+			return errors.Wrap(err, "failed to init the start height of archive journal")
+		}
+	default:
+		return err
+	}
+	value, err = j.dao.Get(ArchiveDiffNamespace, _archiveSnapshotHeightKey)
+	switch errors.Cause(err) {
+	case nil:
+		j.snapshotHeight = byteutil.BytesToUint64BigEndian(value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		j.snapshotHeight = j.startHeight
+	default:
+		return err
+	}
+	j.layers = nil
+	for h := j.snapshotHeight + 1; h <= j.height; h++ {
+		data, err := j.dao.Get(ArchiveDiffNamespace, byteutil.Uint64ToBytesBigEndian(h))
+		if err != nil {
+			return errors.Wrapf(err, "failed to load the diff layer at height %d", h)
+		}
+		layer, err := deserializeDiffLayer(h, data)
+		if err != nil {
+			return err
+		}
+		j.layers = append(j.layers, layer)
+	}
+	return nil
+}
+
+func (j *archiveJournal) Stop(ctx context.Context) error {
+	return j.dao.Stop(ctx)
+}
+
+func (j *archiveJournal) Put(ns string, key, value []byte) error {
+	return j.dao.Put(ns, key, value)
+}
+
+func (j *archiveJournal) Get(ns string, key []byte) ([]byte, error) {
+	return j.dao.Get(ns, key)
+}
+
+func (j *archiveJournal) Delete(ns string, key []byte) error {
+	return j.dao.Delete(ns, key)
+}
+
+func (j *archiveJournal) Filter(ns string, cond db.Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	return j.dao.Filter(ns, cond, minKey, maxKey)
+}
+
+// WriteBatch writes the batch, and journals the diff of the block if the batch commits a new height
+func (j *archiveJournal) WriteBatch(b batch.KVStoreBatch) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	height, ok := batchHeight(b)
+	if !ok || height <= j.height {
+		return j.dao.WriteBatch(b)
+	}
+	if height != j.height+1 {
+		return errors.Errorf("archive journal at height %d cannot journal height %d", j.height, height)
+	}
+	layer, err := j.newDiffLayer(height, b)
+	if err != nil {
+		return err
+	}
+	var (
+		layers         = append(j.layers, layer)
+		snapshotHeight = j.snapshotHeight
+	)
+	if height-j.snapshotHeight < j.interval {
+		b.Put(ArchiveDiffNamespace, byteutil.Uint64ToBytesBigEndian(height), layer.serialize(), "failed to put diff layer")
+	} else {
+		// flatten the diff layers into the snapshot
+		for _, l := range layers {
+			for _, k := range l.sortedKeys() {
+				b.Put(ArchiveSnapshotNamespace, snapshotKey([]byte(k), l.height), l.entries[k].serializeValue(), "failed to put snapshot")
+			}
+			if l.height != height {
+				b.Delete(ArchiveDiffNamespace, byteutil.Uint64ToBytesBigEndian(l.height), "failed to delete diff layer")
+			}
+		}
+		b.Put(ArchiveDiffNamespace, _archiveSnapshotHeightKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put snapshot height")
+		layers, snapshotHeight = nil, height
+	}
+	if err := j.dao.WriteBatch(b); err != nil {
+		return err
+	}
+	j.height, j.layers, j.snapshotHeight = height, layers, snapshotHeight
+	return nil
+}
+
+// atHeight returns the read-only view of the states at the height
+func (j *archiveJournal) atHeight(height uint64) (db.KVStore, error) {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	if height < j.startHeight {
+		return nil, errors.Wrapf(ErrNoArchiveData, "archive journal starts at height %d", j.startHeight)
+	}
+	return &archiveView{journal: j, height: height}, nil
+}
+
+func (j *archiveJournal) newDiffLayer(height uint64, b batch.KVStoreBatch) (*diffLayer, error) {
+	layer := &diffLayer{
+		height:  height,
+		entries: make(map[string]*diffEntry),
+	}
+	for i := 0; i < b.Size(); i++ {
+		wi, err := b.Entry(i)
+		if err != nil {
+			return nil, err
+		}
+		switch wi.Namespace() {
+		case ArchiveTrieNamespace, ArchiveDiffNamespace, ArchiveSnapshotNamespace, PreimageNamespace:
+			continue
+		}
+		k := string(archiveKey(wi.Namespace(), wi.Key()))
+		if _, ok := layer.entries[k]; ok {
+			continue
+		}
+		entry := &diffEntry{ns: wi.Namespace(), key: wi.Key()}
+		value, err := j.dao.Get(wi.Namespace(), wi.Key())
+		switch errors.Cause(err) {
+		case nil:
+			entry.exist, entry.value = true, value
+		case db.ErrNotExist, db.ErrBucketNotExist:
+		default:
+			return nil, err
+		}
+		layer.entries[k] = entry
+	}
+	return layer, nil
+}
+
+// get returns the value of the key at the height, which is the value before it is changed by the first block after
+// the height, or the latest value if it has not been changed since
+func (j *archiveJournal) get(height uint64, ns string, key []byte) ([]byte, error) {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	if height >= j.height {
+		return j.dao.Get(ns, key)
+	}
+	ak := archiveKey(ns, key)
+	var (
+		entry *diffEntry
+		err   error
+	)
+	if height < j.snapshotHeight {
+		if entry, err = j.snapshotEntry(ak, height); err != nil {
+			return nil, err
+		}
+	}
+	if entry == nil {
+		entry = j.layerEntry(ak, height)
+	}
+	if entry == nil {
+		return j.dao.Get(ns, key)
+	}
+	if !entry.exist {
+		return nil, errors.Wrapf(db.ErrNotExist, "ns %s key %x doesn't exist at height %d", ns, key, height)
+	}
+	return entry.value, nil
+}
+
+func (j *archiveJournal) snapshotEntry(ak []byte, height uint64) (*diffEntry, error) {
+	var entry *diffEntry
+	_, _, err := j.dao.Filter(ArchiveSnapshotNamespace, func(k, v []byte) bool {
+		if entry != nil || len(k) != len(ak)+8 || !bytes.HasPrefix(k, ak) {
+			return false
+		}
+		entry = deserializeDiffValue(v)
+		return false
+	}, snapshotKey(ak, height+1), snapshotKey(ak, j.snapshotHeight))
+	if cause := errors.Cause(err); cause != nil && cause != db.ErrNotExist && cause != db.ErrBucketNotExist {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (j *archiveJournal) layerEntry(ak []byte, height uint64) *diffEntry {
+	for _, l := range j.layers {
+		if l.height <= height {
+			continue
+		}
+		if entry, ok := l.entries[string(ak)]; ok {
+			return entry
+		}
+	}
+	return nil
+}
+
+// filter returns the states in the namespace at the height, which are the latest states in the namespace along with
+// the states changed after the height, it scans the whole history of the namespace in the snapshot
+func (j *archiveJournal) filter(height uint64, ns string, cond db.Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	if height >= j.height {
+		return j.dao.Filter(ns, cond, minKey, maxKey)
+	}
+	states := make(map[string][]byte)
+	keys, values, err := j.dao.Filter(ns, func(k, v []byte) bool { return true }, minKey, maxKey)
+	if cause := errors.Cause(err); cause != nil && cause != db.ErrNotExist && cause != db.ErrBucketNotExist {
+		return nil, nil, err
+	}
+	for i := range keys {
+		states[string(keys[i])] = values[i]
+	}
+	inRange := func(key []byte) bool {
+		return (len(minKey) == 0 || bytes.Compare(key, minKey) >= 0) && (len(maxKey) == 0 || bytes.Compare(key, maxKey) <= 0)
+	}
+	changed := make(map[string]*diffEntry)
+	if height < j.snapshotHeight {
+		prefix := namespaceKey(ns)
+		_, _, err := j.dao.Filter(ArchiveSnapshotNamespace, func(k, v []byte) bool {
+			if len(k) < len(prefix)+8 || !bytes.HasPrefix(k, prefix) {
+				return false
+			}
+			key := k[len(prefix) : len(k)-8]
+			h := byteutil.BytesToUint64BigEndian(k[len(k)-8:])
+			if _, ok := changed[string(key)]; ok || h <= height || h > j.snapshotHeight || !inRange(key) {
+				return false
+			}
+			entry := deserializeDiffValue(v)
+			entry.key = append([]byte{}, key...)
+			changed[string(key)] = entry
+			return false
+		}, prefix, prefixSuccessor(prefix))
+		if cause := errors.Cause(err); cause != nil && cause != db.ErrNotExist && cause != db.ErrBucketNotExist {
+			return nil, nil, err
+		}
+	}
+	for _, l := range j.layers {
+		if l.height <= height {
+			continue
+		}
+		for _, entry := range l.entries {
+			if entry.ns != ns || !inRange(entry.key) {
+				continue
+			}
+			if _, ok := changed[string(entry.key)]; !ok {
+				changed[string(entry.key)] = entry
+			}
+		}
+	}
+	for k, entry := range changed {
+		if entry.exist {
+			states[k] = entry.value
+		} else {
+			delete(states, k)
+		}
+	}
+	if len(states) == 0 && err != nil {
+		// the namespace doesn't exist at the height either
+		return nil, nil, err
+	}
+	sorted := make([]string, 0, len(states))
+	for k := range states {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	var fk, fv [][]byte
+	for _, k := range sorted {
+		if cond([]byte(k), states[k]) {
+			fk = append(fk, []byte(k))
+			fv = append(fv, states[k])
+		}
+	}
+	return fk, fv, nil
+}
+
+func (v *archiveView) Start(context.Context) error { return nil }
+
+func (v *archiveView) Stop(context.Context) error { return nil }
+
+func (v *archiveView) Put(string, []byte, []byte) error {
+	return errors.Wrap(ErrNotSupported, "cannot write historical states")
+}
+
+func (v *archiveView) Get(ns string, key []byte) ([]byte, error) {
+	return v.journal.get(v.height, ns, key)
+}
+
+func (v *archiveView) Delete(string, []byte) error {
+	return errors.Wrap(ErrNotSupported, "cannot write historical states")
+}
+
+func (v *archiveView) WriteBatch(batch.KVStoreBatch) error {
+	return errors.Wrap(ErrNotSupported, "cannot write historical states")
+}
+
+func (v *archiveView) Filter(ns string, cond db.Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	return v.journal.filter(v.height, ns, cond, minKey, maxKey)
+}
+
+// batchHeight returns the height committed by the batch
+func batchHeight(b batch.KVStoreBatch) (uint64, bool) {
+	for i := b.Size() - 1; i >= 0; i-- {
+		wi, err := b.Entry(i)
+		if err != nil {
+			return 0, false
+		}
+		if wi.WriteType() == batch.Put && wi.Namespace() == AccountKVNamespace && string(wi.Key()) == CurrentHeightKey {
+			return byteutil.BytesToUint64(wi.Value()), true
+		}
+	}
+	return 0, false
+}
+
+func archiveKey(ns string, key []byte) []byte {
+	nsHash := hash.Hash160b([]byte(ns))
+	return append(nsHash[:], key...)
+}
+
+func snapshotKey(ak []byte, height uint64) []byte {
+	return append(append([]byte{}, ak...), byteutil.Uint64ToBytesBigEndian(height)...)
+}
+
+// prefixSuccessor returns the smallest key greater than all the keys with the prefix
+func prefixSuccessor(prefix []byte) []byte {
+	next := append([]byte{}, prefix...)
+	for i := len(next) - 1; i >= 0; i-- {
+		if next[i] < 0xff {
+			next[i]++
+			return next[:i+1]
+		}
+	}
+	return nil
+}
+
+func (l *diffLayer) sortedKeys() []string {
+	keys := make([]string, 0, len(l.entries))
+	for k := range l.entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (l *diffLayer) serialize() []byte {
+	var data []byte
+	for _, k := range l.sortedKeys() {
+		e := l.entries[k]
+		data = binary.AppendUvarint(data, uint64(len(e.ns)))
+		data = append(data, e.ns...)
+		data = binary.AppendUvarint(data, uint64(len(e.key)))
+		data = append(data, e.key...)
+		value := e.serializeValue()
+		data = binary.AppendUvarint(data, uint64(len(value)))
+		data = append(data, value...)
+	}
+	return data
+}
+
+func deserializeDiffLayer(height uint64, data []byte) (*diffLayer, error) {
+	layer := &diffLayer{
+		height:  height,
+		entries: make(map[string]*diffEntry),
+	}
+	next := func() ([]byte, error) {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return nil, errors.Wrapf(db.ErrInvalid, "invalid diff layer at height %d", height)
+		}
+		field := data[n : n+int(size)]
+		data = data[n+int(size):]
+		return field, nil
+	}
+	for len(data) > 0 {
+		var fields [3][]byte
+		for i := range fields {
+			field, err := next()
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = field
+		}
+		entry := deserializeDiffValue(fields[2])
+		entry.ns, entry.key = string(fields[0]), fields[1]
+		layer.entries[string(archiveKey(entry.ns, entry.key))] = entry
+	}
+	return layer, nil
+}
+
+func (e *diffEntry) serializeValue() []byte {
+	if !e.exist {
+		return []byte{0}
+	}
+	return append([]byte{1}, e.value...)
+}
+
+func deserializeDiffValue(data []byte) *diffEntry {
+	if len(data) == 0 || data[0] == 0 {
+		return &diffEntry{}
+	}
+	return &diffEntry{exist: true, value: append([]byte{}, data[1:]...)}
+}
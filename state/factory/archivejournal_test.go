@@ -0,0 +1,93 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestArchiveJournal(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	testPath, err := testutil.PathOfTempFile("test-archive-journal")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+
+	const ns = "test"
+	var (
+		k1, k2, k3 = []byte("k1"), []byte("k2"), []byte("k3")
+		j          = newArchiveJournal(db.NewBoltDB(cfg), 2)
+	)
+	require.NoError(j.Start(ctx))
+	commit := func(height uint64, puts map[string]string, deletes ...[]byte) {
+		b := batch.NewBatch()
+		for k, v := range puts {
+			b.Put(ns, []byte(k), []byte(v), "")
+		}
+		for _, k := range deletes {
+			b.Delete(ns, k, "")
+		}
+		b.Put(AccountKVNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(height), "")
+		require.NoError(j.WriteBatch(b))
+	}
+	commit(0, map[string]string{"k1": "v0"})
+	commit(1, map[string]string{"k1": "v1", "k2": "a1"})
+	// height 2 flattens the diff layers into the snapshot
+	commit(2, map[string]string{"k3": "c2"}, k2)
+	commit(3, map[string]string{"k1": "v3"})
+	require.EqualValues(2, j.snapshotHeight)
+	require.Len(j.layers, 1)
+
+	expected := []map[string]string{
+		{"k1": "v0"},
+		{"k1": "v1", "k2": "a1"},
+		{"k1": "v1", "k3": "c2"},
+		{"k1": "v3", "k3": "c2"},
+	}
+	check := func() {
+		for h, states := range expected {
+			view, err := j.atHeight(uint64(h))
+			require.NoError(err)
+			for _, k := range [][]byte{k1, k2, k3} {
+				value, err := view.Get(ns, k)
+				if v, ok := states[string(k)]; ok {
+					require.NoError(err)
+					require.Equal(v, string(value))
+				} else {
+					require.Equal(db.ErrNotExist, errors.Cause(err))
+				}
+			}
+			keys, values, err := view.Filter(ns, func(k, v []byte) bool { return true }, nil, nil)
+			require.NoError(err)
+			require.Len(keys, len(states))
+			for i := range keys {
+				require.Equal(states[string(keys[i])], string(values[i]))
+			}
+			require.Error(view.Put(ns, k1, nil))
+		}
+	}
+	check()
+
+	// the diff layers are reloaded on restart
+	require.NoError(j.Stop(ctx))
+	j = newArchiveJournal(db.NewBoltDB(cfg), 2)
+	require.NoError(j.Start(ctx))
+	require.EqualValues(3, j.height)
+	require.Len(j.layers, 1)
+	check()
+	require.NoError(j.Stop(ctx))
+}
@@ -40,7 +40,7 @@ import (
 
 const (
 	// AccountKVNamespace is the bucket name for account
-	AccountKVNamespace = "Account"
+	AccountKVNamespace = protocol.AccountKVNamespace
 	// ArchiveNamespacePrefix is the prefix of the buckets storing history data
 	ArchiveNamespacePrefix = "Archive"
 	// CurrentHeightKey indicates the key of current factory height in underlying DB
@@ -49,6 +49,8 @@ const (
 	ArchiveTrieNamespace = "AccountTrie"
 	// ArchiveTrieRootKey indicates the key of accountTrie root hash in underlying DB
 	ArchiveTrieRootKey = "archiveTrieRoot"
+	// PreimageNamespace is the bucket mapping the hashed namespaces and keys in the state trie back to their preimages
+	PreimageNamespace = "Preimage"
 )
 
 var (
@@ -90,6 +92,20 @@ type (
 		WorkingSetAtHeight(context.Context, uint64, ...*action.SealedEnvelope) (protocol.StateManager, error)
 	}
 
+	// StateProver generates the merkle proof of a state in the state trie
+	StateProver interface {
+		// StateProof returns the root hash of the state trie and the proof of the state, the proof is generated by
+		// trie.TwoLayerTrie, and it returns ErrNotSupported if the states are not stored in a trie
+		StateProof(...protocol.StateOption) ([]byte, [][]byte, error)
+	}
+
+	// PreimageReader resolves the hashed namespaces and keys in the state trie
+	PreimageReader interface {
+		// Preimage returns the namespace or the key whose hash is the given hash, it returns ErrNotSupported if the
+		// preimage store is not enabled
+		Preimage(hash.Hash160) ([]byte, error)
+	}
+
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
 	factory struct {
 		lifecycle                lifecycle.Lifecycle
@@ -98,8 +114,10 @@ type (
 		registry                 *protocol.Registry
 		currentChainHeight       uint64
 		saveHistory              bool
+		savePreimages            bool
 		twoLayerTrie             trie.TwoLayerTrie // global state trie, this is a read only trie
 		dao                      db.KVStore        // the underlying DB for account/contract storage
+		journal                  *archiveJournal   // the journal of history states, nil if not in archive mode
 		timerFactory             *prometheustimer.TimerFactory
 		workingsets              cache.LRUCache // lru cache for workingsets
 		protocolView             protocol.View
@@ -156,6 +174,7 @@ func NewFactory(cfg Config, dao db.KVStore, opts ...Option) (Factory, error) {
 		currentChainHeight: 0,
 		registry:           protocol.NewRegistry(),
 		saveHistory:        cfg.Chain.EnableArchiveMode,
+		savePreimages:      cfg.Chain.EnablePreimageStore,
 		protocolView:       protocol.View{},
 		workingsets:        cache.NewThreadSafeLruCache(int(cfg.Chain.WorkingSetCacheSize)),
 		dao:                dao,
@@ -167,6 +186,16 @@ func NewFactory(cfg Config, dao db.KVStore, opts ...Option) (Factory, error) {
 			return nil, err
 		}
 	}
+	if sf.saveHistory && cfg.Chain.ArchiveSnapshotInterval > 0 {
+		sf.journal = newArchiveJournal(dao, cfg.Chain.ArchiveSnapshotInterval)
+		sf.dao = sf.journal
+	}
+	if cfg.Chain.TrieNodeCacheSize > 0 {
+		sf.dao = newTrieNodeCache(sf.dao, cfg.Chain.TrieNodeCacheSize)
+	}
+	if cfg.Chain.AccountFilterSize > 0 {
+		sf.dao = newAccountFilter(sf.dao, cfg.Chain.AccountFilterSize)
+	}
 	timerFactory, err := prometheustimer.New(
 		"iotex_statefactory_perf",
 		"Performance of state factory module",
@@ -231,8 +260,13 @@ func (sf *factory) Start(ctx context.Context) error {
 }
 
 func (sf *factory) Stop(ctx context.Context) error {
+	ctx = protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(protocol.WithRegistry(ctx, sf.registry), sf.cfg.Genesis))
 	sf.mutex.Lock()
 	defer sf.mutex.Unlock()
+	// the lock is held, so the views are persisted along with the states they are built from
+	if err := sf.registry.PersistAllViews(ctx, &tipReader{sf}, sf.protocolView); err != nil {
+		log.L().Error("Failed to persist protocol views.", zap.Error(err))
+	}
 	if err := sf.dao.Stop(ctx); err != nil {
 		return err
 	}
@@ -244,6 +278,10 @@ func (sf *factory) Stop(ctx context.Context) error {
 func (sf *factory) Height() (uint64, error) {
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
+	return sf.height()
+}
+
+func (sf *factory) height() (uint64, error) {
 	height, err := sf.dao.Get(AccountKVNamespace, []byte(CurrentHeightKey))
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to get factory's height from underlying DB")
@@ -265,7 +303,7 @@ func (sf *factory) newWorkingSet(ctx context.Context, height uint64) (*workingSe
 	if err != nil {
 		return nil, err
 	}
-	store, err := newFactoryWorkingSetStore(sf.protocolView, flusher)
+	store, err := newFactoryWorkingSetStore(sf.protocolView, flusher, sf.savePreimages)
 	if err != nil {
 		return nil, err
 	}
@@ -278,6 +316,18 @@ func (sf *factory) newWorkingSetAtHeight(ctx context.Context, height uint64) (*w
 	defer span.End()
 
 	g := genesis.MustExtractGenesisContext(ctx)
+	if sf.journal != nil {
+		// the history states are read from the archive journal instead of the state trie
+		view, err := sf.journal.atHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		flusher, err := db.NewKVStoreFlusher(view, batch.NewCachedBatch(), sf.flusherOptions(!g.IsEaster(height))...)
+		if err != nil {
+			return nil, err
+		}
+		return sf.createSfWorkingSet(ctx, height, newStateDBWorkingSetStore(sf.protocolView, flusher, true))
+	}
 	flusher, err := db.NewKVStoreFlusher(
 		sf.dao,
 		batch.NewCachedBatch(),
@@ -314,7 +364,7 @@ func (sf *factory) createSfWorkingSet(ctx context.Context, height uint64, store
 func (sf *factory) flusherOptions(preEaster bool) []db.KVStoreFlusherOption {
 	opts := []db.KVStoreFlusherOption{
 		db.SerializeFilterOption(func(wi *batch.WriteInfo) bool {
-			if wi.Namespace() == ArchiveTrieNamespace {
+			if wi.Namespace() == ArchiveTrieNamespace || wi.Namespace() == PreimageNamespace {
 				return true
 			}
 			if wi.Namespace() != evm.CodeKVNameSpace && wi.Namespace() != staking.CandsMapNS {
@@ -329,7 +379,8 @@ func (sf *factory) flusherOptions(preEaster bool) []db.KVStoreFlusherOption {
 			return wi.Serialize()
 		}),
 	}
-	if sf.saveHistory {
+	// the archive journal keeps the history states, so the state trie is pruned as in non-archive mode
+	if sf.saveHistory && sf.journal == nil {
 		opts = append(opts, db.FlushTranslateOption(func(wi *batch.WriteInfo) *batch.WriteInfo {
 			if wi.WriteType() == batch.Delete && wi.Namespace() == ArchiveTrieNamespace {
 				return nil
@@ -494,6 +545,10 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 func (sf *factory) State(s interface{}, opts ...protocol.StateOption) (uint64, error) {
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
+	return sf.state(s, opts...)
+}
+
+func (sf *factory) state(s interface{}, opts ...protocol.StateOption) (uint64, error) {
 	cfg, err := processOptions(opts...)
 	if err != nil {
 		return 0, err
@@ -516,6 +571,10 @@ func (sf *factory) State(s interface{}, opts ...protocol.StateOption) (uint64, e
 func (sf *factory) States(opts ...protocol.StateOption) (uint64, state.Iterator, error) {
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
+	return sf.states(opts...)
+}
+
+func (sf *factory) states(opts ...protocol.StateOption) (uint64, state.Iterator, error) {
 	cfg, err := processOptions(opts...)
 	if err != nil {
 		return 0, nil, err
@@ -534,11 +593,68 @@ func (sf *factory) States(opts ...protocol.StateOption) (uint64, state.Iterator,
 	return sf.currentChainHeight, iter, nil
 }
 
+// Preimage returns the namespace or the key whose hash is the given hash
+func (sf *factory) Preimage(h hash.Hash160) ([]byte, error) {
+	if !sf.savePreimages {
+		return nil, errors.Wrap(ErrNotSupported, "preimage store is not enabled")
+	}
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	value, err := sf.dao.Get(PreimageNamespace, h[:])
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist || errors.Cause(err) == db.ErrBucketNotExist {
+			return nil, errors.Wrapf(state.ErrStateNotExist, "failed to get preimage of %x", h)
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
 // ReadView reads the view
 func (sf *factory) ReadView(name string) (interface{}, error) {
 	return sf.protocolView.Read(name)
 }
 
+// StateRoot returns the root hash of the state trie
+func (sf *factory) StateRoot() (hash.Hash256, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	return sf.stateRoot()
+}
+
+func (sf *factory) stateRoot() (hash.Hash256, error) {
+	rh, err := sf.rootHash()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.BytesToHash256(rh), nil
+}
+
+// tipReader reads the states at the tip without locking, for the caller holding sf.mutex
+type tipReader struct {
+	sf *factory
+}
+
+func (r *tipReader) Height() (uint64, error) {
+	return r.sf.height()
+}
+
+func (r *tipReader) State(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+	return r.sf.state(s, opts...)
+}
+
+func (r *tipReader) States(opts ...protocol.StateOption) (uint64, state.Iterator, error) {
+	return r.sf.states(opts...)
+}
+
+func (r *tipReader) ReadView(name string) (interface{}, error) {
+	return r.sf.ReadView(name)
+}
+
+func (r *tipReader) StateRoot() (hash.Hash256, error) {
+	return r.sf.stateRoot()
+}
+
 //======================================
 // private trie constructor functions
 //======================================
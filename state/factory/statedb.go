@@ -106,6 +106,9 @@ func NewStateDB(cfg Config, dao db.KVStore, opts ...StateDBOption) (Factory, err
 			return nil, err
 		}
 	}
+	if cfg.Chain.AccountFilterSize > 0 {
+		dao = newAccountFilter(dao, cfg.Chain.AccountFilterSize)
+	}
 	sdb.dao = newDaoRetrofitter(dao)
 	timerFactory, err := prometheustimer.New(
 		"iotex_statefactory_perf",
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+)
+
+var (
+	_trieNodeCacheMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_trie_node_cache",
+			Help: "IoTeX trie node cache hits, misses and evictions",
+		},
+		[]string{"type"},
+	)
+	_trieNodeCacheSizeMtc = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iotex_trie_node_cache_size",
+			Help: "IoTeX trie node cache size in bytes",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_trieNodeCacheMtc)
+	prometheus.MustRegister(_trieNodeCacheSizeMtc)
+}
+
+type (
+	// trieNodeCache is a KVStore caching the trie nodes in ArchiveTrieNamespace, it is shared by the working sets
+	// and the reads of the factory, so that the nodes of the hot accounts, e.g., the bucket pool and the rewarding
+	// fund, are not read from the DB repeatedly. The cache is bounded by the total size of the cached nodes, and the
+	// least recently used nodes are evicted first
+	trieNodeCache struct {
+		mutex   sync.Mutex
+		store   db.KVStore
+		maxSize uint64
+		size    uint64
+		lru     *list.List
+		nodes   map[string]*list.Element
+	}
+
+	trieNodeCacheEntry struct {
+		key   string
+		value []byte
+	}
+)
+
+func newTrieNodeCache(store db.KVStore, maxSize uint64) *trieNodeCache {
+	return &trieNodeCache{
+		store:   store,
+		maxSize: maxSize,
+		lru:     list.New(),
+		nodes:   make(map[string]*list.Element),
+	}
+}
+
+func (c *trieNodeCache) Start(ctx context.Context) error {
+	return c.store.Start(ctx)
+}
+
+func (c *trieNodeCache) Stop(ctx context.Context) error {
+	c.mutex.Lock()
+	c.lru.Init()
+	c.nodes = make(map[string]*list.Element)
+	c.size = 0
+	_trieNodeCacheSizeMtc.Set(0)
+	c.mutex.Unlock()
+	return c.store.Stop(ctx)
+}
+
+func (c *trieNodeCache) Put(ns string, key, value []byte) error {
+	if err := c.store.Put(ns, key, value); err != nil {
+		return err
+	}
+	if ns == ArchiveTrieNamespace {
+		c.add(key, value)
+	}
+	return nil
+}
+
+func (c *trieNodeCache) Get(ns string, key []byte) ([]byte, error) {
+	if ns != ArchiveTrieNamespace {
+		return c.store.Get(ns, key)
+	}
+	if value, ok := c.get(key); ok {
+		_trieNodeCacheMtc.WithLabelValues("hit").Inc()
+		return value, nil
+	}
+	_trieNodeCacheMtc.WithLabelValues("miss").Inc()
+	value, err := c.store.Get(ns, key)
+	if err != nil {
+		return nil, err
+	}
+	c.add(key, value)
+	return value, nil
+}
+
+func (c *trieNodeCache) Delete(ns string, key []byte) error {
+	if err := c.store.Delete(ns, key); err != nil {
+		return err
+	}
+	if ns == ArchiveTrieNamespace {
+		c.remove(key)
+	}
+	return nil
+}
+
+func (c *trieNodeCache) Filter(ns string, cond db.Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	return c.store.Filter(ns, cond, minKey, maxKey)
+}
+
+// WriteBatch writes the batch, and caches the trie nodes written by it, which are likely to be read by the next block
+func (c *trieNodeCache) WriteBatch(b batch.KVStoreBatch) error {
+	// the batch may be cleared by the store once written
+	var writes []*batch.WriteInfo
+	for i := 0; i < b.Size(); i++ {
+		wi, err := b.Entry(i)
+		if err != nil {
+			return err
+		}
+		if wi.Namespace() == ArchiveTrieNamespace {
+			writes = append(writes, wi)
+		}
+	}
+	if err := c.store.WriteBatch(b); err != nil {
+		return err
+	}
+	for _, wi := range writes {
+		switch wi.WriteType() {
+		case batch.Put:
+			c.add(wi.Key(), wi.Value())
+		case batch.Delete:
+			c.remove(wi.Key())
+		}
+	}
+	return nil
+}
+
+func (c *trieNodeCache) get(key []byte) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	elem, ok := c.nodes[string(key)]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*trieNodeCacheEntry).value, true
+}
+
+func (c *trieNodeCache) add(key, value []byte) {
+	entrySize := uint64(len(key) + len(value))
+	if entrySize > c.maxSize {
+		c.remove(key)
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if elem, ok := c.nodes[string(key)]; ok {
+		entry := elem.Value.(*trieNodeCacheEntry)
+		c.size = c.size - uint64(len(entry.value)) + uint64(len(value))
+		entry.value = value
+		c.lru.MoveToFront(elem)
+	} else {
+		c.nodes[string(key)] = c.lru.PushFront(&trieNodeCacheEntry{key: string(key), value: value})
+		c.size += entrySize
+	}
+	for c.size > c.maxSize {
+		c.removeElement(c.lru.Back())
+		_trieNodeCacheMtc.WithLabelValues("evict").Inc()
+	}
+	_trieNodeCacheSizeMtc.Set(float64(c.size))
+}
+
+func (c *trieNodeCache) remove(key []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if elem, ok := c.nodes[string(key)]; ok {
+		c.removeElement(elem)
+		_trieNodeCacheSizeMtc.Set(float64(c.size))
+	}
+}
+
+func (c *trieNodeCache) removeElement(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*trieNodeCacheEntry)
+	delete(c.nodes, entry.key)
+	c.size -= uint64(len(entry.key) + len(entry.value))
+}
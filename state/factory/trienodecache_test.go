@@ -0,0 +1,69 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+)
+
+func TestTrieNodeCache(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	store := db.NewMemKVStore()
+	// room for two nodes of 2-byte key and 3-byte value
+	c := newTrieNodeCache(store, 10)
+	require.NoError(c.Start(ctx))
+
+	require.NoError(c.Put(ArchiveTrieNamespace, []byte("n1"), []byte("v11")))
+	require.NoError(c.Put(AccountKVNamespace, []byte("a1"), []byte("acc")))
+	require.Len(c.nodes, 1)
+	require.EqualValues(5, c.size)
+
+	// the cache is read before the store
+	require.NoError(store.Put(ArchiveTrieNamespace, []byte("n1"), []byte("xxx")))
+	value, err := c.Get(ArchiveTrieNamespace, []byte("n1"))
+	require.NoError(err)
+	require.Equal([]byte("v11"), value)
+	value, err = c.Get(AccountKVNamespace, []byte("a1"))
+	require.NoError(err)
+	require.Equal([]byte("acc"), value)
+	require.Len(c.nodes, 1)
+
+	// a miss caches the node read from the store
+	require.NoError(store.Put(ArchiveTrieNamespace, []byte("n2"), []byte("v22")))
+	value, err = c.Get(ArchiveTrieNamespace, []byte("n2"))
+	require.NoError(err)
+	require.Equal([]byte("v22"), value)
+	require.Len(c.nodes, 2)
+	_, err = c.Get(ArchiveTrieNamespace, []byte("n3"))
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	// the least recently used node is evicted
+	_, err = c.Get(ArchiveTrieNamespace, []byte("n1"))
+	require.NoError(err)
+	b := batch.NewBatch()
+	b.Put(ArchiveTrieNamespace, []byte("n3"), []byte("v33"), "")
+	b.Delete(ArchiveTrieNamespace, []byte("n1"), "")
+	require.NoError(c.WriteBatch(b))
+	require.Len(c.nodes, 1)
+	require.Contains(c.nodes, "n3")
+	require.EqualValues(5, c.size)
+	_, err = c.Get(ArchiveTrieNamespace, []byte("n1"))
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	// a node larger than the cache is not cached
+	require.NoError(c.Put(ArchiveTrieNamespace, []byte("n4"), []byte("large value")))
+	require.Len(c.nodes, 1)
+	require.NoError(c.Stop(ctx))
+	require.Zero(c.size)
+}
@@ -127,7 +127,7 @@ func withActionCtx(ctx context.Context, selp *action.SealedEnvelope) (context.Co
 		return nil, err
 	}
 	actionCtx.GasPrice = selp.GasPrice()
-	intrinsicGas, err := selp.IntrinsicGas()
+	intrinsicGas, err := protocol.IntrinsicGas(ctx, selp.Envelope)
 	if err != nil {
 		return nil, err
 	}
@@ -317,6 +317,21 @@ func (ws *workingSet) State(s interface{}, opts ...protocol.StateOption) (uint64
 	return ws.height, state.Deserialize(s, value)
 }
 
+// StateProof returns the root hash of the state trie and the merkle proof of a state
+func (ws *workingSet) StateProof(opts ...protocol.StateOption) ([]byte, [][]byte, error) {
+	cfg, err := processOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	prover, ok := ws.store.(interface {
+		Proof(string, []byte) ([]byte, [][]byte, error)
+	})
+	if !ok {
+		return nil, nil, errors.Wrap(ErrNotSupported, "states are not stored in a trie")
+	}
+	return prover.Proof(cfg.Namespace, cfg.Key)
+}
+
 func (ws *workingSet) States(opts ...protocol.StateOption) (uint64, state.Iterator, error) {
 	cfg, err := processOptions(opts...)
 	if err != nil {
@@ -601,7 +616,30 @@ func (ws *workingSet) pickAndRunActions(
 		if dl, ok := ctx.Deadline(); ok {
 			deadline = &dl
 		}
-		actionIterator := actioniterator.NewActionIterator(ap.PendingActionMap())
+		var (
+			actionIterator actioniterator.ActionIterator
+			iteratorOpts   []actioniterator.Option
+		)
+		if fCtx.EnableDynamicFeeTx {
+			// pick the actions paying the highest priority fee first
+			iteratorOpts = append(iteratorOpts, actioniterator.WithBaseFee(blkCtx.BaseFee))
+		}
+		if reader, ok := ap.(actpool.PriorityReader); ok {
+			// pick the actions of the locals and the system-critical actions first
+			iteratorOpts = append(iteratorOpts, actioniterator.WithPriority(reader.IsPriority))
+		}
+		if reader, ok := ap.(actpool.AccessSetReader); ok {
+			actionIterator = actioniterator.NewConflictAwareActionIterator(ap.PendingActionMap(), func(selp *action.SealedEnvelope) ([]string, []string, bool) {
+				set, ok := reader.AccessSet(selp)
+				if !ok {
+					return nil, nil, false
+				}
+				return set.Reads, set.Writes, true
+			}, iteratorOpts...)
+		} else {
+			actionIterator = actioniterator.NewActionIterator(ap.PendingActionMap(), iteratorOpts...)
+		}
+		recorder, _ := ap.(actpool.ExecutionTimeRecorder)
 		for {
 			if deadline != nil && time.Now().After(*deadline) {
 				duration := time.Since(blkCtx.BlockTimeStamp)
@@ -656,7 +694,11 @@ func (ws *workingSet) pickAndRunActions(
 				actionIterator.PopAccount()
 				continue
 			}
+			start := time.Now()
 			receipt, err := ws.runAction(actionCtx, nextAction)
+			if recorder != nil {
+				recorder.RecordExecutionTime(nextAction, time.Since(start))
+			}
 			switch errors.Cause(err) {
 			case nil:
 				// do nothing
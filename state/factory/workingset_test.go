@@ -23,6 +23,8 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
 	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/testutil"
@@ -122,6 +124,70 @@ func TestWorkingSet_ReadWriteView(t *testing.T) {
 	}
 }
 
+func TestWorkingSet_StateProof(t *testing.T) {
+	r := require.New(t)
+	ws := newFactoryWorkingSet(t)
+	key1, key2 := []byte("key1"), []byte("key2")
+	_, err := ws.PutState(&testString{"value1"}, protocol.NamespaceOption("ns"), protocol.KeyOption(key1))
+	r.NoError(err)
+	root, proof, err := ws.StateProof(protocol.NamespaceOption("ns"), protocol.KeyOption(key1))
+	r.NoError(err)
+	v, err := mptrie.VerifyTwoLayerProof(root, namespaceKey("ns"), toLegacyKey(key1), proof)
+	r.NoError(err)
+	r.Equal([]byte("value1"), v)
+	root, proof, err = ws.StateProof(protocol.NamespaceOption("ns"), protocol.KeyOption(key2))
+	r.NoError(err)
+	_, err = mptrie.VerifyTwoLayerProof(root, namespaceKey("ns"), toLegacyKey(key2), proof)
+	r.Equal(trie.ErrNotExist, errors.Cause(err))
+
+	// states are not stored in a trie
+	_, _, err = newStateDBWorkingSet(t).StateProof(protocol.NamespaceOption("ns"), protocol.KeyOption(key1))
+	r.Equal(ErrNotSupported, errors.Cause(err))
+}
+
+func TestFactory_Preimage(t *testing.T) {
+	r := require.New(t)
+	ctx := genesis.WithGenesisContext(
+		protocol.WithRegistry(context.Background(), protocol.NewRegistry()),
+		genesis.TestDefault(),
+	)
+	cfg := DefaultConfig
+	cfg.Chain.EnablePreimageStore = true
+	sf, err := NewFactory(cfg, db.NewMemKVStore())
+	r.NoError(err)
+	r.NoError(sf.Start(ctx))
+	defer func() {
+		r.NoError(sf.Stop(ctx))
+	}()
+	ws, err := sf.(workingSetCreator).newWorkingSet(ctx, 1)
+	r.NoError(err)
+	key := []byte("key1")
+	_, err = ws.PutState(&testString{"value1"}, protocol.NamespaceOption("ns"), protocol.KeyOption(key))
+	r.NoError(err)
+	// the preimages are excluded from the digest
+	noPreimage := newFactoryWorkingSet(t)
+	_, err = noPreimage.PutState(&testString{"value1"}, protocol.NamespaceOption("ns"), protocol.KeyOption(key))
+	r.NoError(err)
+	r.Equal(noPreimage.store.Digest(), ws.store.Digest())
+
+	r.NoError(ws.store.Commit())
+	reader := sf.(PreimageReader)
+	preimage, err := reader.Preimage(hash.Hash160b(key))
+	r.NoError(err)
+	r.Equal(key, preimage)
+	preimage, err = reader.Preimage(hash.Hash160b([]byte("ns")))
+	r.NoError(err)
+	r.Equal([]byte("ns"), preimage)
+	_, err = reader.Preimage(hash.Hash160b([]byte("key2")))
+	r.Equal(state.ErrStateNotExist, errors.Cause(err))
+
+	// preimage store is not enabled
+	sf2, err := NewFactory(DefaultConfig, db.NewMemKVStore())
+	r.NoError(err)
+	_, err = sf2.(PreimageReader).Preimage(hash.Hash160b(key))
+	r.Equal(ErrNotSupported, errors.Cause(err))
+}
+
 func TestWorkingSet_Dock(t *testing.T) {
 	var (
 		r   = require.New(t)
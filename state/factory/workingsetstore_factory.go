@@ -25,22 +25,27 @@ type factoryWorkingSetStore struct {
 	*workingSetStoreCommon
 	tlt       trie.TwoLayerTrie
 	trieRoots map[int][]byte
+	// preimages is the hashes whose preimages have been written, nil if the preimages are not saved
+	preimages map[hash.Hash160]struct{}
 }
 
-func newFactoryWorkingSetStore(view protocol.View, flusher db.KVStoreFlusher) (workingSetStore, error) {
+func newFactoryWorkingSetStore(view protocol.View, flusher db.KVStoreFlusher, savePreimages bool) (workingSetStore, error) {
 	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, flusher.KVStoreWithBuffer(), ArchiveTrieRootKey, true)
 	if err != nil {
 		return nil, err
 	}
-
-	return &factoryWorkingSetStore{
+	store := &factoryWorkingSetStore{
 		workingSetStoreCommon: &workingSetStoreCommon{
 			flusher: flusher,
 			view:    view,
 		},
 		tlt:       tlt,
 		trieRoots: make(map[int][]byte),
-	}, nil
+	}
+	if savePreimages {
+		store.preimages = make(map[hash.Hash160]struct{})
+	}
+	return store, nil
 }
 
 func newFactoryWorkingSetStoreAtHeight(view protocol.View, flusher db.KVStoreFlusher, height uint64) (workingSetStore, error) {
@@ -75,6 +80,7 @@ func (store *factoryWorkingSetStore) Get(ns string, key []byte) ([]byte, error)
 func (store *factoryWorkingSetStore) Put(ns string, key []byte, value []byte) error {
 	store.workingSetStoreCommon.Put(ns, key, value)
 	nsHash := hash.Hash160b([]byte(ns))
+	store.putPreimages(ns, nsHash, key)
 
 	return store.tlt.Upsert(nsHash[:], toLegacyKey(key), value)
 }
@@ -82,6 +88,7 @@ func (store *factoryWorkingSetStore) Put(ns string, key []byte, value []byte) er
 func (store *factoryWorkingSetStore) Delete(ns string, key []byte) error {
 	store.workingSetStoreCommon.Delete(ns, key)
 	nsHash := hash.Hash160b([]byte(ns))
+	store.putPreimages(ns, nsHash, key)
 
 	err := store.tlt.Delete(nsHash[:], toLegacyKey(key))
 	if errors.Cause(err) == trie.ErrNotExist {
@@ -90,10 +97,39 @@ func (store *factoryWorkingSetStore) Delete(ns string, key []byte) error {
 	return err
 }
 
+// putPreimages writes the preimages of the hashed namespace and key, which are excluded from the digest
+func (store *factoryWorkingSetStore) putPreimages(ns string, nsHash hash.Hash160, key []byte) {
+	if store.preimages == nil {
+		return
+	}
+	if _, ok := store.preimages[nsHash]; !ok {
+		store.flusher.KVStoreWithBuffer().MustPut(PreimageNamespace, nsHash[:], []byte(ns))
+		store.preimages[nsHash] = struct{}{}
+	}
+	keyHash := hash.Hash160b(key)
+	if _, ok := store.preimages[keyHash]; !ok {
+		store.flusher.KVStoreWithBuffer().MustPut(PreimageNamespace, keyHash[:], key)
+		store.preimages[keyHash] = struct{}{}
+	}
+}
+
 func (store *factoryWorkingSetStore) States(ns string, keys [][]byte) ([][]byte, [][]byte, error) {
 	return readStatesFromTLT(store.tlt, ns, keys)
 }
 
+// Proof returns the root hash of the state trie and the proof of the key in namespace
+func (store *factoryWorkingSetStore) Proof(ns string, key []byte) ([]byte, [][]byte, error) {
+	rootHash, err := store.tlt.RootHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := store.tlt.Proof(namespaceKey(ns), toLegacyKey(key))
+	if err != nil {
+		return nil, nil, err
+	}
+	return rootHash, proof, nil
+}
+
 func (store *factoryWorkingSetStore) Finalize(h uint64) error {
 	rootHash, err := store.tlt.RootHash()
 	if err != nil {
@@ -124,6 +160,10 @@ func (store *factoryWorkingSetStore) RevertSnapshot(snapshot int) error {
 	if err := store.workingSetStoreCommon.RevertSnapshot(snapshot); err != nil {
 		return err
 	}
+	if store.preimages != nil {
+		// the preimages written after the snapshot are reverted as well
+		store.preimages = make(map[hash.Hash160]struct{})
+	}
 	root, ok := store.trieRoots[snapshot]
 	if !ok {
 		// this should not happen, b/c we save the trie root on a successful return of Snapshot(), but check anyway
@@ -3,6 +3,7 @@ package systemcontractindex
 import (
 	"context"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/v2/db"
@@ -10,13 +11,18 @@ import (
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 )
 
+// _journalNSSuffix is the suffix of the namespace of journal
+const _journalNSSuffix = "#journal"
+
 // IndexerCommon is the common struct for all contract indexers
 // It provides the basic functions, including
 //  1. kvstore
 //  2. put/get index height
 //  3. contract address
+//  4. journal of the latest blocks, to roll back the index
 type IndexerCommon struct {
 	kvstore         db.KVStore
+	journal         *db.Journal
 	ns              string
 	key             []byte
 	startHeight     uint64
@@ -28,6 +34,7 @@ type IndexerCommon struct {
 func NewIndexerCommon(kvstore db.KVStore, ns string, key []byte, contractAddress string, startHeight uint64) *IndexerCommon {
 	return &IndexerCommon{
 		kvstore:         kvstore,
+		journal:         db.NewJournal(kvstore, ns+_journalNSSuffix, db.DefaultJournalDepth),
 		ns:              ns,
 		key:             key,
 		startHeight:     startHeight,
@@ -82,9 +89,12 @@ func (s *IndexerCommon) loadHeight() (uint64, error) {
 // StartHeight returns the start height of the indexer
 func (s *IndexerCommon) StartHeight() uint64 { return s.startHeight }
 
-// Commit commits the height to the indexer
-func (s *IndexerCommon) Commit(height uint64, delta batch.KVStoreBatch) error {
+// Commit commits the height to the indexer, and journals the delta of the block
+func (s *IndexerCommon) Commit(height uint64, blkHash hash.Hash256, delta batch.KVStoreBatch) error {
 	delta.Put(s.ns, s.key, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
+	if err := s.journal.Record(delta, height, blkHash); err != nil {
+		return err
+	}
 	if err := s.kvstore.WriteBatch(delta); err != nil {
 		return err
 	}
@@ -92,6 +102,31 @@ func (s *IndexerCommon) Commit(height uint64, delta batch.KVStoreBatch) error {
 	return nil
 }
 
+// Checkpoint returns the hash of the indexed block at the height
+func (s *IndexerCommon) Checkpoint(height uint64) (hash.Hash256, error) {
+	return s.journal.Checkpoint(height)
+}
+
+// Rollback reverts the committed deltas to the height
+func (s *IndexerCommon) Rollback(height uint64) error {
+	// the blocks before the start height are not indexed
+	if s.startHeight > 0 && height < s.startHeight-1 {
+		height = s.startHeight - 1
+	}
+	if height >= s.height {
+		return nil
+	}
+	if err := s.journal.Revert(s.height, height); err != nil {
+		return errors.Wrapf(err, "failed to roll back index from %d to %d", s.height, height)
+	}
+	h, err := s.loadHeight()
+	if err != nil {
+		return err
+	}
+	s.height = h
+	return nil
+}
+
 // ExpectedHeight returns the expected height
 func (s *IndexerCommon) ExpectedHeight() uint64 {
 	if s.height < s.startHeight {
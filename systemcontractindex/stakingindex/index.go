@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
@@ -209,13 +210,32 @@ func (s *Indexer) PutBlock(ctx context.Context, blk *block.Block) error {
 		}
 	}
 	// commit
-	return s.commit(handler, blk.Height())
+	return s.commit(handler, blk.Height(), blk.HashBlock())
 }
 
-func (s *Indexer) commit(handler *eventHandler, height uint64) error {
+// Checkpoint returns the hash of the indexed block at the height
+func (s *Indexer) Checkpoint(height uint64) (hash.Hash256, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.common.Checkpoint(height)
+}
+
+// Rollback reverts the index to the height
+func (s *Indexer) Rollback(_ context.Context, height uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.common.Rollback(height); err != nil {
+		return err
+	}
+	// reload the cache from the reverted db
+	s.cache = newCache(s.ns, s.bucketNS)
+	return s.cache.Load(s.common.KVStore())
+}
+
+func (s *Indexer) commit(handler *eventHandler, height uint64, blkHash hash.Hash256) error {
 	delta, dirty := handler.Finalize()
 	// update db
-	if err := s.common.Commit(height, delta); err != nil {
+	if err := s.common.Commit(height, blkHash, delta); err != nil {
 		return err
 	}
 	// update cache
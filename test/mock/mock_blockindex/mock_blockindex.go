@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	bloom "github.com/iotexproject/go-pkgs/bloom"
+	hash "github.com/iotexproject/go-pkgs/hash"
 	logfilter "github.com/iotexproject/iotex-core/v2/api/logfilter"
 	block "github.com/iotexproject/iotex-core/v2/blockchain/block"
 )
@@ -52,6 +53,21 @@ func (mr *MockBloomFilterIndexerMockRecorder) BlockFilterByHeight(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockFilterByHeight", reflect.TypeOf((*MockBloomFilterIndexer)(nil).BlockFilterByHeight), arg0)
 }
 
+// Checkpoint mocks base method.
+func (m *MockBloomFilterIndexer) Checkpoint(arg0 uint64) (hash.Hash256, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkpoint", arg0)
+	ret0, _ := ret[0].(hash.Hash256)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkpoint indicates an expected call of Checkpoint.
+func (mr *MockBloomFilterIndexerMockRecorder) Checkpoint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkpoint", reflect.TypeOf((*MockBloomFilterIndexer)(nil).Checkpoint), arg0)
+}
+
 // FilterBlocksInRange mocks base method.
 func (m *MockBloomFilterIndexer) FilterBlocksInRange(arg0 *logfilter.LogFilter, arg1, arg2, arg3 uint64) ([]uint64, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +126,20 @@ func (mr *MockBloomFilterIndexerMockRecorder) RangeBloomFilterNumElements() *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RangeBloomFilterNumElements", reflect.TypeOf((*MockBloomFilterIndexer)(nil).RangeBloomFilterNumElements))
 }
 
+// Rollback mocks base method.
+func (m *MockBloomFilterIndexer) Rollback(arg0 context.Context, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockBloomFilterIndexerMockRecorder) Rollback(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockBloomFilterIndexer)(nil).Rollback), arg0, arg1)
+}
+
 // Start mocks base method.
 func (m *MockBloomFilterIndexer) Start(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -37,6 +37,21 @@ func (m *MockIndexer) EXPECT() *MockIndexerMockRecorder {
 	return m.recorder
 }
 
+// Checkpoint mocks base method.
+func (m *MockIndexer) Checkpoint(arg0 uint64) (hash.Hash256, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkpoint", arg0)
+	ret0, _ := ret[0].(hash.Hash256)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkpoint indicates an expected call of Checkpoint.
+func (mr *MockIndexerMockRecorder) Checkpoint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkpoint", reflect.TypeOf((*MockIndexer)(nil).Checkpoint), arg0)
+}
+
 // GetActionCountByAddress mocks base method.
 func (m *MockIndexer) GetActionCountByAddress(arg0 hash.Hash160) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -200,6 +215,20 @@ func (mr *MockIndexerMockRecorder) PutBlocks(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBlocks", reflect.TypeOf((*MockIndexer)(nil).PutBlocks), arg0, arg1)
 }
 
+// Rollback mocks base method.
+func (m *MockIndexer) Rollback(arg0 context.Context, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockIndexerMockRecorder) Rollback(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockIndexer)(nil).Rollback), arg0, arg1)
+}
+
 // Start mocks base method.
 func (m *MockIndexer) Start(arg0 context.Context) error {
 	m.ctrl.T.Helper()
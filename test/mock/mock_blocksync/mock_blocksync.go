@@ -50,6 +50,20 @@ func (mr *MockBlockSyncMockRecorder) BuildReport() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildReport", reflect.TypeOf((*MockBlockSync)(nil).BuildReport))
 }
 
+// DownloadedHeight mocks base method.
+func (m *MockBlockSync) DownloadedHeight() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadedHeight")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// DownloadedHeight indicates an expected call of DownloadedHeight.
+func (mr *MockBlockSyncMockRecorder) DownloadedHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadedHeight", reflect.TypeOf((*MockBlockSync)(nil).DownloadedHeight))
+}
+
 // ProcessBlock mocks base method.
 func (m *MockBlockSync) ProcessBlock(arg0 context.Context, arg1 string, arg2 *block.Block) error {
 	m.ctrl.T.Helper()
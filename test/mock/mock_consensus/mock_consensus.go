@@ -118,6 +118,21 @@ func (mr *MockConsensusMockRecorder) Start(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockConsensus)(nil).Start), arg0)
 }
 
+// State mocks base method.
+func (m *MockConsensus) State() (scheme.ConsensusState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "State")
+	ret0, _ := ret[0].(scheme.ConsensusState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// State indicates an expected call of State.
+func (mr *MockConsensusMockRecorder) State() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockConsensus)(nil).State))
+}
+
 // Stop mocks base method.
 func (m *MockConsensus) Stop(arg0 context.Context) error {
 	m.ctrl.T.Helper()
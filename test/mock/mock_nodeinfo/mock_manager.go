@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	p2p "github.com/iotexproject/iotex-core/v2/p2p"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 	proto "google.golang.org/protobuf/proto"
 )
@@ -65,6 +66,20 @@ func (mr *MocktransmitterMockRecorder) Info() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocktransmitter)(nil).Info))
 }
 
+// PeerScores mocks base method.
+func (m *Mocktransmitter) PeerScores() []p2p.PeerScore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerScores")
+	ret0, _ := ret[0].([]p2p.PeerScore)
+	return ret0
+}
+
+// PeerScores indicates an expected call of PeerScores.
+func (mr *MocktransmitterMockRecorder) PeerScores() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerScores", reflect.TypeOf((*Mocktransmitter)(nil).PeerScores))
+}
+
 // UnicastOutbound mocks base method.
 func (m *Mocktransmitter) UnicastOutbound(arg0 context.Context, arg1 peer.AddrInfo, arg2 proto.Message) error {
 	m.ctrl.T.Helper()
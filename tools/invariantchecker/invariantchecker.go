@@ -0,0 +1,143 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// This is a tool that checks the chain-wide invariants over a height range of the chain and state db, and writes
+// a json report. It exits with code 1 if any invariant is violated, so it can be used in CI as well.
+// The states at history heights are only available if the state db is in archive mode.
+// To use, run "make invariant"
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	glog "log"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blockchain/invariant"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/server/itx"
+)
+
+/**
+ * overwritePath is the path to the config file which overwrite default values
+ * secretPath is the path to the  config file store secret values
+ */
+var (
+	genesisPath    string
+	_overwritePath string
+	_secretPath    string
+	_plugins       strs
+	_startHeight   uint64
+	_endHeight     uint64
+	_outputPath    string
+)
+
+type strs []string
+
+func (ss *strs) String() string {
+	return strings.Join(*ss, ",")
+}
+
+func (ss *strs) Set(str string) error {
+	*ss = append(*ss, str)
+	return nil
+}
+
+func init() {
+	flag.StringVar(&genesisPath, "genesis-path", "", "Genesis path")
+	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
+	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
+	flag.Var(&_plugins, "plugin", "Plugin of the node")
+	flag.Uint64Var(&_startHeight, "start-height", 0, "Start height of the range, the state height if 0")
+	flag.Uint64Var(&_endHeight, "end-height", 0, "End height of the range, the state height if 0")
+	flag.StringVar(&_outputPath, "output", "", "Path of the json report, stdout if empty")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"usage: invariantchecker -config-path=[string]\n -start-height=[int]\n -end-height=[int]\n -output=[string]\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	genesisCfg, err := genesis.New(genesisPath)
+	if err != nil {
+		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
+	}
+
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, _plugins)
+	if err != nil {
+		glog.Fatalln("Failed to new config.", zap.Error(err))
+	}
+
+	cfg.Genesis = genesisCfg
+
+	svr, err := itx.NewServer(cfg)
+	if err != nil {
+		log.L().Fatal("Failed to create server.", zap.Error(err))
+	}
+	cs := svr.ChainService(cfg.Chain.ID)
+	bc := cs.Blockchain()
+	if err := bc.Start(context.Background()); err != nil {
+		log.L().Fatal("Failed to start blockchain.", zap.Error(err))
+	}
+	defer func() {
+		if err := bc.Stop(context.Background()); err != nil {
+			log.L().Fatal("Failed to stop blockchain.", zap.Error(err))
+		}
+	}()
+
+	sf := cs.StateFactory()
+	height, err := sf.Height()
+	if err != nil {
+		log.L().Fatal("Failed to read state height.", zap.Error(err))
+	}
+	if _endHeight == 0 {
+		_endHeight = height
+	}
+	if _startHeight == 0 {
+		_startHeight = _endHeight
+	}
+	checker := invariant.NewChecker(cfg.Genesis, cs.BlockDAO(), func(ctx context.Context, h uint64) (protocol.StateReader, error) {
+		// the state db returns the latest states at any height if archive mode is not enabled
+		if h != height && !cfg.Chain.EnableArchiveMode {
+			return nil, errors.Errorf("archive mode is required to read states at height %d", h)
+		}
+		return sf.WorkingSetAtHeight(genesis.WithGenesisContext(ctx, cfg.Genesis), h)
+	})
+	report, err := checker.Check(context.Background(), _startHeight, _endHeight)
+	if err != nil {
+		log.L().Fatal("Failed to check invariants.", zap.Error(err))
+	}
+	if err := writeReport(report); err != nil {
+		log.L().Fatal("Failed to write report.", zap.Error(err))
+	}
+	if !report.OK() {
+		log.L().Error("Invariants are violated.", zap.Int("failed", report.Failed))
+		os.Exit(1)
+	}
+}
+
+func writeReport(report *invariant.Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _outputPath == "" {
+		_, err = fmt.Println(string(data))
+		return err
+	}
+	return os.WriteFile(_outputPath, data, 0644)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	convertDbCmdShorts = map[string]string{
+		"english": "Sub-Command for converting IoTeX bolt db file to pebble db.",
+		"chinese": "将IoTeX bolt db 文件转换为 pebble db 的子命令",
+	}
+	convertDbCmdLongs = map[string]string{
+		"english": "Sub-Command for converting IoTeX bolt db file to pebble db offline, e.g., the state db (trie.db) or a chain db file in v2 format. The node must be stopped during the conversion.",
+		"chinese": "离线将IoTeX bolt db 文件转换为 pebble db 的子命令，例如状态数据库（trie.db）或 v2 格式的区块链 db 文件。转换期间节点必须停止。",
+	}
+	convertDbCmdUse = map[string]string{
+		"english": "convert",
+		"chinese": "convert",
+	}
+	convertDbFlagSrcUse = map[string]string{
+		"english": "The bolt db file you want to convert.",
+		"chinese": "您要转换的 bolt db 文件。",
+	}
+	convertDbFlagDstUse = map[string]string{
+		"english": "The path of the pebble db you want to convert to, which must not exist.",
+		"chinese": "您要转换到的 pebble db 路径，该路径必须不存在。",
+	}
+	convertDbFlagBatchSizeUse = map[string]string{
+		"english": "The number of records written in a batch.",
+		"chinese": "每批写入的记录数。",
+	}
+)
+
+var (
+	// ConvertDb Used to Sub command.
+	ConvertDb = &cobra.Command{
+		Use:   common.TranslateInLang(convertDbCmdUse),
+		Short: common.TranslateInLang(convertDbCmdShorts),
+		Long:  common.TranslateInLang(convertDbCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return convertDbFile()
+		},
+	}
+)
+
+var (
+	srcFile   = ""
+	dstPath   = ""
+	batchSize = 10000
+)
+
+func init() {
+	ConvertDb.PersistentFlags().StringVarP(&srcFile, "src", "s", "", common.TranslateInLang(convertDbFlagSrcUse))
+	ConvertDb.PersistentFlags().StringVarP(&dstPath, "dst", "d", "", common.TranslateInLang(convertDbFlagDstUse))
+	ConvertDb.PersistentFlags().IntVarP(&batchSize, "batch-size", "b", 10000, common.TranslateInLang(convertDbFlagBatchSizeUse))
+}
+
+func convertDbFile() (err error) {
+	if srcFile == "" {
+		return fmt.Errorf("--src is empty")
+	}
+	if dstPath == "" {
+		return fmt.Errorf("--dst is empty")
+	}
+	if _, err := os.Stat(dstPath); !os.IsNotExist(err) {
+		return fmt.Errorf("the --dst path %s already exists", dstPath)
+	}
+
+	cfg := db.DefaultConfig
+	cfg.DbPath = srcFile
+	cfg.ReadOnly = true
+	src := db.NewBoltDB(cfg)
+	cfg.DbPath = dstPath
+	cfg.ReadOnly = false
+	dst := db.NewPebbleDB(cfg)
+
+	ctx := context.Background()
+	if err := src.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start the bolt db %s: %v", srcFile, err)
+	}
+	defer func() {
+		if e := src.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+	if err := dst.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start the pebble db %s: %v", dstPath, err)
+	}
+	defer func() {
+		if e := dst.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	total, err := db.CopyKVStore(src, dst, batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %v", srcFile, err)
+	}
+	fmt.Printf("Converted %d records from %s to %s.\n", total, srcFile, dstPath)
+	return nil
+}
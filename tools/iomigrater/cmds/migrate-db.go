@@ -11,6 +11,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/tools/iomigrater/common"
 )
 
@@ -41,6 +42,10 @@ var (
 		"english": "The path you want to migrate to",
 		"chinese": "您要迁移到的路径。",
 	}
+	migrateDbFlagDBTypeUse = map[string]string{
+		"english": "The db type of the new file, boltdb or pebbledb",
+		"chinese": "新文件的数据库类型，boltdb 或 pebbledb",
+	}
 	migrateDbFlagBlockHeightUse = map[string]string{
 		"english": "The height you want to migrate to, Cannot be larger than the height of the migration file (the old file)",
 		"chinese": "您要迁移到的高度，不能大于迁移文件（旧文件）的高度。",
@@ -63,12 +68,14 @@ var (
 	oldFile     = ""
 	newFile     = ""
 	blockHeight = uint64(0)
+	newDBType   = db.DBBolt
 )
 
 func init() {
 	MigrateDb.PersistentFlags().StringVarP(&oldFile, "old-file", "o", "", common.TranslateInLang(migrateDbFlagOldFileUse))
 	MigrateDb.PersistentFlags().StringVarP(&newFile, "new-file", "n", "", common.TranslateInLang(migrateDbFlagNewFileUse))
 	MigrateDb.PersistentFlags().Uint64VarP(&blockHeight, "block-height", "b", uint64(0), common.TranslateInLang(migrateDbFlagBlockHeightUse))
+	MigrateDb.PersistentFlags().StringVarP(&newDBType, "db-type", "t", db.DBBolt, common.TranslateInLang(migrateDbFlagDBTypeUse))
 }
 
 func getProgressMod(num uint64) (int, int) {
@@ -101,6 +108,9 @@ func migrateDbFile() (err error) {
 	if blockHeight == 0 {
 		return fmt.Errorf("--block-height is 0")
 	}
+	if newDBType != db.DBBolt && newDBType != db.DBPebble {
+		return fmt.Errorf("unsupported --db-type %s", newDBType)
+	}
 
 	height, err := checkDbFileHeight(oldFile)
 	if err != nil {
@@ -122,6 +132,7 @@ func migrateDbFile() (err error) {
 	}
 
 	cfg.DB.DbPath = newFile
+	cfg.DB.DBType = newDBType
 	newDAO, err := filedao.NewFileDAO(cfg.DB, deser)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create dao from %s", newFile)
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+)
+
+type (
+	prefetchedBlock struct {
+		blk *block.Block
+		err error
+	}
+
+	prefetchJob struct {
+		height uint64
+		out    chan prefetchedBlock
+	}
+
+	// prefetchDAO reads blocks and receipts of a height range ahead with parallel workers, and serves them
+	// in the order of height. Reads out of the order fall back to the underlying dao.
+	prefetchDAO struct {
+		blockdao.BlockDAO
+		next    uint64
+		end     uint64
+		pending chan chan prefetchedBlock
+		cancel  context.CancelFunc
+	}
+)
+
+func newPrefetchDAO(dao blockdao.BlockDAO, start, end uint64, workers int) *prefetchDAO {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &prefetchDAO{
+		BlockDAO: dao,
+		next:     start,
+		end:      end,
+		pending:  make(chan chan prefetchedBlock, workers*_prefetchFactor),
+		cancel:   cancel,
+	}
+	jobs := make(chan prefetchJob)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				job.out <- p.fetch(job.height)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for h := start; h <= end; h++ {
+			// the result channel is queued before the job is dispatched, so results are consumed in order
+			out := make(chan prefetchedBlock, 1)
+			select {
+			case p.pending <- out:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- prefetchJob{height: h, out: out}:
+			case <-ctx.Done():
+				return
+			}
+			if h == end {
+				return
+			}
+		}
+	}()
+	return p
+}
+
+func (p *prefetchDAO) fetch(height uint64) prefetchedBlock {
+	blk, err := p.BlockDAO.GetBlockByHeight(height)
+	if err != nil {
+		return prefetchedBlock{err: err}
+	}
+	if blk.Receipts == nil {
+		if blk.Receipts, err = p.BlockDAO.GetReceipts(height); err != nil {
+			return prefetchedBlock{err: err}
+		}
+	}
+	return prefetchedBlock{blk: blk}
+}
+
+func (p *prefetchDAO) GetBlockByHeight(height uint64) (*block.Block, error) {
+	if height != p.next || height > p.end {
+		return p.BlockDAO.GetBlockByHeight(height)
+	}
+	res := <-<-p.pending
+	p.next++
+	return res.blk, res.err
+}
+
+func (p *prefetchDAO) stop() {
+	p.cancel()
+}
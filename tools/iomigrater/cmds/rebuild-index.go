@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/schollz/progressbar/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/blockindex/contractstaking"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/systemcontractindex/stakingindex"
+	"github.com/iotexproject/iotex-core/v2/tools/iomigrater/common"
+)
+
+// indexers which can be rebuilt from block data
+const (
+	indexerBlock           = "index"
+	indexerBloomfilter     = "bloomfilter"
+	indexerContractStaking = "contractstaking"
+
+	// _prefetchFactor is the number of blocks prefetched per worker
+	_prefetchFactor = 4
+)
+
+// Multi-language support
+var (
+	rebuildIndexCmdShorts = map[string]string{
+		"english": "Sub-Command for rebuilding an indexer of IoTeX blockchain from block data.",
+		"chinese": "从区块数据重建IoTeX区块链索引的子命令",
+	}
+	rebuildIndexCmdLongs = map[string]string{
+		"english": "Sub-Command for rebuilding an indexer (index, bloomfilter or contractstaking) of IoTeX blockchain from the chain db file, other indexers are left untouched.",
+		"chinese": "从区块链 db 文件重建IoTeX区块链的一个索引（index、bloomfilter 或 contractstaking）的子命令，其他索引不受影响",
+	}
+	rebuildIndexCmdUse = map[string]string{
+		"english": "rebuild",
+		"chinese": "rebuild",
+	}
+	rebuildIndexFlagConfigUse = map[string]string{
+		"english": "The config file of the node.",
+		"chinese": "节点的配置文件。",
+	}
+	rebuildIndexFlagGenesisUse = map[string]string{
+		"english": "The genesis file of the node.",
+		"chinese": "节点的创世文件。",
+	}
+	rebuildIndexFlagIndexerUse = map[string]string{
+		"english": "The indexer you want to rebuild, one of index, bloomfilter and contractstaking.",
+		"chinese": "您要重建的索引，index、bloomfilter 或 contractstaking 之一。",
+	}
+	rebuildIndexFlagWorkersUse = map[string]string{
+		"english": "The number of workers reading block data in parallel.",
+		"chinese": "并行读取区块数据的线程数。",
+	}
+)
+
+var (
+	// RebuildIndex Used to Sub command.
+	RebuildIndex = &cobra.Command{
+		Use:   common.TranslateInLang(rebuildIndexCmdUse),
+		Short: common.TranslateInLang(rebuildIndexCmdShorts),
+		Long:  common.TranslateInLang(rebuildIndexCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rebuildIndex()
+		},
+	}
+)
+
+var (
+	rebuildConfigFile  = ""
+	rebuildGenesisFile = ""
+	rebuildIndexer     = ""
+	rebuildWorkers     = 4
+)
+
+func init() {
+	RebuildIndex.PersistentFlags().StringVarP(&rebuildConfigFile, "config-path", "c", "", common.TranslateInLang(rebuildIndexFlagConfigUse))
+	RebuildIndex.PersistentFlags().StringVarP(&rebuildGenesisFile, "genesis-path", "g", "", common.TranslateInLang(rebuildIndexFlagGenesisUse))
+	RebuildIndex.PersistentFlags().StringVarP(&rebuildIndexer, "indexer", "i", "", common.TranslateInLang(rebuildIndexFlagIndexerUse))
+	RebuildIndex.PersistentFlags().IntVarP(&rebuildWorkers, "workers", "w", 4, common.TranslateInLang(rebuildIndexFlagWorkersUse))
+}
+
+func rebuildIndex() (err error) {
+	if rebuildIndexer == "" {
+		return fmt.Errorf("--indexer is empty")
+	}
+	if rebuildWorkers <= 0 {
+		return fmt.Errorf("--workers should be greater than 0")
+	}
+	g, err := genesis.New(rebuildGenesisFile)
+	if err != nil {
+		return fmt.Errorf("failed to new genesis: %v", err)
+	}
+	configPaths := []string{}
+	if rebuildConfigFile != "" {
+		configPaths = append(configPaths, rebuildConfigFile)
+	}
+	cfg, err := config.New(configPaths, []string{})
+	if err != nil {
+		return fmt.Errorf("failed to new config: %v", err)
+	}
+	cfg.Genesis = g
+
+	indexer, indexPath, err := createIndexer(cfg, rebuildIndexer)
+	if err != nil {
+		return err
+	}
+
+	dbConfig := cfg.DB
+	dbConfig.DbPath = cfg.Chain.ChainDBPath
+	store, err := filedao.NewFileDAO(dbConfig, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create dao from %s", cfg.Chain.ChainDBPath)
+	}
+	dao := blockdao.NewBlockDAOWithIndexersAndCache(store, nil, cfg.DB.MaxCacheSize)
+
+	ctx := genesis.WithGenesisContext(protocol.WithBlockchainCtx(context.Background(), protocol.BlockchainCtx{
+		ChainID:      cfg.Chain.ID,
+		EvmNetworkID: cfg.Chain.EVMNetworkID,
+	}), g)
+	if err := dao.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start the chain db file")
+	}
+	defer func() {
+		if e := dao.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	// only the db of the target indexer is removed, other indexers are left untouched
+	if err := os.Remove(indexPath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove %s", indexPath)
+	}
+	if err := indexer.Start(ctx); err != nil {
+		return errors.Wrapf(err, "failed to start indexer %s", rebuildIndexer)
+	}
+	defer func() {
+		if e := indexer.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	tipHeight, err := indexer.Height()
+	if err != nil {
+		return err
+	}
+	startHeight := tipHeight + 1
+	if indexerWS, ok := indexer.(blockdao.BlockIndexerWithStart); ok && indexerWS.StartHeight() > startHeight {
+		startHeight = indexerWS.StartHeight()
+	}
+	targetHeight, err := dao.Height()
+	if err != nil {
+		return err
+	}
+	if startHeight > targetHeight {
+		fmt.Printf("Indexer %s is already at height %d.\n", rebuildIndexer, tipHeight)
+		return nil
+	}
+
+	fmt.Printf("Rebuilding indexer %s from height %d to %d.\n", rebuildIndexer, startHeight, targetHeight)
+	total, step := getProgressMod(targetHeight - startHeight + 1)
+	bar := progressbar.New(total)
+	reporter := func(height uint64) {
+		if (height-startHeight+1)%uint64(step) == 0 {
+			bar.Add(1)
+		}
+	}
+	prefetcher := newPrefetchDAO(dao, startHeight, targetHeight, rebuildWorkers)
+	defer prefetcher.stop()
+	if err := blockdao.NewBlockIndexerChecker(prefetcher).CheckIndexer(ctx, indexer, targetHeight, reporter); err != nil {
+		return errors.Wrapf(err, "failed to rebuild indexer %s", rebuildIndexer)
+	}
+	fmt.Printf("\nIndexer %s is rebuilt to height %d.\n", rebuildIndexer, targetHeight)
+	return nil
+}
+
+// createIndexer creates the indexer in the same way as the node, and returns the path of its db
+func createIndexer(cfg config.Config, name string) (blockdao.BlockIndexer, string, error) {
+	dbConfig := cfg.DB
+	switch name {
+	case indexerBlock:
+		dbConfig.DbPath = cfg.Chain.IndexDBPath
+		indexer, err := blockindex.NewIndexer(db.NewBoltDB(dbConfig), cfg.Genesis.Hash())
+		return indexer, dbConfig.DbPath, err
+	case indexerBloomfilter:
+		dbConfig.DbPath = cfg.Chain.BloomfilterIndexDBPath
+		indexer, err := blockindex.NewBloomfilterIndexer(db.NewBoltDB(dbConfig), cfg.Indexer)
+		return indexer, dbConfig.DbPath, err
+	case indexerContractStaking:
+		// contract staking indexers of v1 and v2 share the same db, so they are rebuilt together
+		dbConfig.DbPath = cfg.Chain.ContractStakingIndexDBPath
+		kvstore := db.NewBoltDB(dbConfig)
+		var indexers []blockdao.BlockIndexer
+		if len(cfg.Genesis.SystemStakingContractAddress) > 0 {
+			voteCalcConsts := cfg.Genesis.VoteWeightCalConsts
+			indexer, err := contractstaking.NewContractStakingIndexer(
+				kvstore,
+				contractstaking.Config{
+					ContractAddress:      cfg.Genesis.SystemStakingContractAddress,
+					ContractDeployHeight: cfg.Genesis.SystemStakingContractHeight,
+					CalculateVoteWeight: func(v *staking.VoteBucket) *big.Int {
+						return staking.CalculateVoteWeight(voteCalcConsts, v, false)
+					},
+					BlockInterval: cfg.DardanellesUpgrade.BlockInterval,
+				})
+			if err != nil {
+				return nil, "", err
+			}
+			indexers = append(indexers, indexer)
+		}
+		if len(cfg.Genesis.SystemStakingContractV2Address) > 0 {
+			indexers = append(indexers, stakingindex.NewIndexer(
+				kvstore,
+				cfg.Genesis.SystemStakingContractV2Address,
+				cfg.Genesis.SystemStakingContractV2Height, cfg.DardanellesUpgrade.BlockInterval,
+			))
+		}
+		if len(indexers) == 0 {
+			return nil, "", errors.New("no staking contract is set in genesis")
+		}
+		return blockindex.NewSyncIndexers(indexers...), dbConfig.DbPath, nil
+	case "candidate", "stakingcandidates":
+		return nil, "", errors.Errorf("indexer %s is built from the protocol states, and cannot be rebuilt from block data", name)
+	default:
+		return nil, "", errors.Errorf("unknown indexer %s", name)
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestRebuildIndex(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	chainPath := filepath.Join(dir, "chain.db")
+	indexPath := filepath.Join(dir, "index.db")
+	configPath := filepath.Join(dir, "config.yaml")
+	require.NoError(os.WriteFile(configPath, []byte(fmt.Sprintf(
+		"chain:\n  chainDBPath: %s\n  indexDBPath: %s\n  bloomfilterIndexDBPath: %s\n",
+		chainPath, indexPath, filepath.Join(dir, "bloomfilter.index.db"),
+	)), 0644))
+
+	// write a small chain db
+	cfg := config.Default
+	cfg.DB.DbPath = chainPath
+	store, err := filedao.NewFileDAO(cfg.DB, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	require.NoError(err)
+	ctx := context.Background()
+	require.NoError(store.Start(ctx))
+	var (
+		builder = block.NewTestingBuilder()
+		prev    = hash.ZeroHash256
+		hashes  []hash.Hash256
+	)
+	for i := uint64(1); i <= 5; i++ {
+		blk, err := builder.
+			SetHeight(i).
+			SetPrevBlockHash(prev).
+			SetTimeStamp(testutil.TimestampNow().UTC()).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		require.NoError(store.PutBlock(ctx, &blk))
+		prev = blk.HashBlock()
+		hashes = append(hashes, prev)
+	}
+	require.NoError(store.Stop(ctx))
+
+	rebuildConfigFile, rebuildGenesisFile, rebuildWorkers = configPath, "", 2
+	defer func() {
+		rebuildConfigFile, rebuildIndexer, rebuildWorkers = "", "", 4
+	}()
+
+	rebuildIndexer = ""
+	require.ErrorContains(rebuildIndex(), "--indexer is empty")
+	rebuildIndexer = "candidate"
+	require.ErrorContains(rebuildIndex(), "cannot be rebuilt from block data")
+	rebuildIndexer = "unknown"
+	require.ErrorContains(rebuildIndex(), "unknown indexer")
+
+	// a stale index is replaced by the rebuilt one
+	require.NoError(os.WriteFile(indexPath, []byte("stale"), 0644))
+	rebuildIndexer = indexerBlock
+	require.NoError(rebuildIndex())
+
+	cfg.DB.DbPath = indexPath
+	indexer, err := blockindex.NewIndexer(db.NewBoltDB(cfg.DB), hash.ZeroHash256)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	defer indexer.Stop(ctx)
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.EqualValues(5, height)
+	for i, h := range hashes {
+		blkHash, err := indexer.GetBlockHash(uint64(i + 1))
+		require.NoError(err)
+		require.Equal(h, blkHash)
+	}
+
+	rebuildIndexer = indexerBloomfilter
+	require.NoError(rebuildIndex())
+}
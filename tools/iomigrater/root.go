@@ -46,7 +46,9 @@ func Execute() {
 
 func init() {
 	RootCmd.AddCommand(cmd.CheckHeight)
+	RootCmd.AddCommand(cmd.ConvertDb)
 	RootCmd.AddCommand(cmd.MigrateDb)
+	RootCmd.AddCommand(cmd.RebuildIndex)
 
 	RootCmd.HelpFunc()
 }
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package webhook
+
+import "time"
+
+type (
+	// Config is the config of the web-hook notifier
+	Config struct {
+		// Endpoints are the web-hooks the events are posted to, empty disables the notifier
+		Endpoints []EndpointConfig `yaml:"endpoints"`
+		// Delegates are the delegates whose missed blocks are notified, empty notifies all delegates
+		Delegates []string `yaml:"delegates"`
+		// LargeStakeAmount is the min amount in rau of a staking action to be notified
+		LargeStakeAmount string `yaml:"largeStakeAmount"`
+		// BehindBlocks is the number of blocks the node lags behind the network to be notified
+		BehindBlocks uint64 `yaml:"behindBlocks"`
+		// StallTimeout is the duration without a new block to notify the consensus stall
+		StallTimeout time.Duration `yaml:"stallTimeout"`
+		// CheckInterval is the interval of checking whether the node falls behind or the consensus stalls
+		CheckInterval time.Duration `yaml:"checkInterval"`
+		// QueueSize is the max number of events queued for an endpoint, the events beyond are dropped
+		QueueSize int `yaml:"queueSize"`
+		// RequestTimeout is the timeout of a post
+		RequestTimeout time.Duration `yaml:"requestTimeout"`
+		// MaxRetries is the max number of retries of a failed post
+		MaxRetries int `yaml:"maxRetries"`
+		// RetryInterval is the interval before the first retry, which doubles on each retry up to MaxRetryInterval
+		RetryInterval    time.Duration `yaml:"retryInterval"`
+		MaxRetryInterval time.Duration `yaml:"maxRetryInterval"`
+	}
+
+	// EndpointConfig is the config of a web-hook
+	EndpointConfig struct {
+		// URL is the URL the events are posted to
+		URL string `yaml:"url"`
+		// Secret is the key of the HMAC-SHA256 signature of the payload, empty skips the signing
+		Secret string `yaml:"secret"`
+		// Events are the events posted to the endpoint, empty posts all events
+		Events []string `yaml:"events"`
+	}
+)
+
+// DefaultConfig is the default config of the web-hook notifier
+var DefaultConfig = Config{
+	Endpoints:        []EndpointConfig{},
+	Delegates:        []string{},
+	LargeStakeAmount: "1000000000000000000000000",
+	BehindBlocks:     60,
+	StallTimeout:     time.Minute,
+	CheckInterval:    10 * time.Second,
+	QueueSize:        1000,
+	RequestTimeout:   10 * time.Second,
+	MaxRetries:       5,
+	RetryInterval:    time.Second,
+	MaxRetryInterval: time.Minute,
+}
+
+// Enabled returns true if the web-hook notifier is enabled
+func (cfg Config) Enabled() bool {
+	return len(cfg.Endpoints) > 0
+}
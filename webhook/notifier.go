@@ -0,0 +1,415 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const (
+	// EventNewBlock is posted on each new block
+	EventNewBlock = "newBlock"
+	// EventMissedBlock is posted when a delegate misses its slot to propose a block
+	EventMissedBlock = "missedBlock"
+	// EventNodeBehind is posted when the node falls behind the network
+	EventNodeBehind = "nodeBehind"
+	// EventConsensusStall is posted when there is no new block for a while
+	EventConsensusStall = "consensusStall"
+	// EventLargeStake is posted on a staking action of a large amount
+	EventLargeStake = "largeStake"
+
+	// EventHeader and SignatureHeader are the http headers of the event type and the signature of the payload
+	EventHeader     = "X-IoTeX-Event"
+	SignatureHeader = "X-IoTeX-Signature"
+)
+
+var _webhookMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_webhook_deliveries",
+		Help: "Deliveries of the web-hook events",
+	},
+	[]string{"event", "result"},
+)
+
+func init() {
+	prometheus.MustRegister(_webhookMtc)
+}
+
+type (
+	// SyncStatusFunc returns the height of the node and the height of the network
+	SyncStatusFunc func() (uint64, uint64)
+
+	// MissedProposersFunc returns the delegates missing their slots before the block is proposed
+	MissedProposersFunc func(*block.Block) []string
+
+	// Event is the payload posted to the web-hooks
+	Event struct {
+		Type string    `json:"event"`
+		Time time.Time `json:"time"`
+		Data any       `json:"data"`
+	}
+
+	// NewBlock is the data of EventNewBlock
+	NewBlock struct {
+		Height     uint64    `json:"height"`
+		Hash       string    `json:"hash"`
+		Producer   string    `json:"producer"`
+		Timestamp  time.Time `json:"timestamp"`
+		NumActions int       `json:"numActions"`
+	}
+
+	// MissedBlock is the data of EventMissedBlock, the delegate missed its slot before the producer proposed the
+	// block at the height
+	MissedBlock struct {
+		Height   uint64 `json:"height"`
+		Delegate string `json:"delegate"`
+		Producer string `json:"producer"`
+	}
+
+	// NodeBehind is the data of EventNodeBehind
+	NodeBehind struct {
+		Height       uint64 `json:"height"`
+		TargetHeight uint64 `json:"targetHeight"`
+	}
+
+	// ConsensusStall is the data of EventConsensusStall
+	ConsensusStall struct {
+		Height        uint64    `json:"height"`
+		LastBlockTime time.Time `json:"lastBlockTime"`
+		Stalled       string    `json:"stalled"`
+	}
+
+	// LargeStake is the data of EventLargeStake
+	LargeStake struct {
+		Height     uint64 `json:"height"`
+		ActionHash string `json:"actionHash"`
+		Type       string `json:"type"`
+		Staker     string `json:"staker"`
+		Amount     string `json:"amount"`
+	}
+
+	// Notifier posts the chain events to the web-hooks. The events are queued and posted in background with retries,
+	// so that a slow or unavailable web-hook never blocks the chain
+	Notifier struct {
+		cfg             Config
+		endpoints       []*endpoint
+		delegates       map[string]struct{}
+		largeStake      *big.Int
+		syncStatus      SyncStatusFunc
+		missedProposers MissedProposersFunc
+		client          *http.Client
+
+		mu            sync.Mutex
+		lastHeight    uint64
+		lastBlockTime time.Time
+		behind        bool
+		stalled       bool
+
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	}
+
+	endpoint struct {
+		cfg    EndpointConfig
+		events map[string]struct{}
+		queue  chan *delivery
+	}
+
+	delivery struct {
+		event string
+		body  []byte
+	}
+)
+
+// NewNotifier creates a new web-hook notifier, the node behind and the missed blocks are not notified if the
+// corresponding function is nil
+func NewNotifier(cfg Config, syncStatus SyncStatusFunc, missedProposers MissedProposersFunc) (*Notifier, error) {
+	largeStake, ok := new(big.Int).SetString(cfg.LargeStakeAmount, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid large stake amount %s", cfg.LargeStakeAmount)
+	}
+	n := &Notifier{
+		cfg:             cfg,
+		delegates:       make(map[string]struct{}, len(cfg.Delegates)),
+		largeStake:      largeStake,
+		syncStatus:      syncStatus,
+		missedProposers: missedProposers,
+		client:          &http.Client{Timeout: cfg.RequestTimeout},
+	}
+	for _, d := range cfg.Delegates {
+		n.delegates[d] = struct{}{}
+	}
+	for _, epCfg := range cfg.Endpoints {
+		if epCfg.URL == "" {
+			return nil, errors.New("empty web-hook url")
+		}
+		ep := &endpoint{
+			cfg:    epCfg,
+			events: make(map[string]struct{}, len(epCfg.Events)),
+			queue:  make(chan *delivery, max(cfg.QueueSize, 1)),
+		}
+		for _, e := range epCfg.Events {
+			ep.events[e] = struct{}{}
+		}
+		n.endpoints = append(n.endpoints, ep)
+	}
+	return n, nil
+}
+
+// Start starts the notifier
+func (n *Notifier) Start(context.Context) error {
+	n.mu.Lock()
+	n.lastBlockTime = time.Now()
+	n.mu.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
+	for _, ep := range n.endpoints {
+		n.wg.Add(1)
+		go n.post(ctx, ep)
+	}
+	if n.cfg.CheckInterval > 0 {
+		n.wg.Add(1)
+		go n.watch(ctx)
+	}
+	return nil
+}
+
+// Stop stops the notifier, the queued events are dropped
+func (n *Notifier) Stop(context.Context) error {
+	if n.cancel != nil {
+		n.cancel()
+	}
+	n.wg.Wait()
+	return nil
+}
+
+// ReceiveBlock notifies the new block, the missed blocks before it, and the large staking actions in it
+func (n *Notifier) ReceiveBlock(blk *block.Block) error {
+	n.mu.Lock()
+	n.lastHeight = blk.Height()
+	n.lastBlockTime = time.Now()
+	n.stalled = false
+	n.mu.Unlock()
+
+	var (
+		height   = blk.Height()
+		producer = blk.ProducerAddress()
+		h        = blk.HashBlock()
+	)
+	n.notify(EventNewBlock, &NewBlock{
+		Height:     height,
+		Hash:       hex.EncodeToString(h[:]),
+		Producer:   producer,
+		Timestamp:  blk.Timestamp(),
+		NumActions: len(blk.Actions),
+	})
+	if n.missedProposers != nil {
+		for _, d := range n.missedProposers(blk) {
+			if _, ok := n.delegates[d]; len(n.delegates) > 0 && !ok {
+				continue
+			}
+			n.notify(EventMissedBlock, &MissedBlock{
+				Height:   height,
+				Delegate: d,
+				Producer: producer,
+			})
+		}
+	}
+	for _, selp := range blk.Actions {
+		var (
+			typ    string
+			amount *big.Int
+		)
+		switch act := selp.Action().(type) {
+		case *action.CreateStake:
+			typ, amount = "createStake", act.Amount()
+		case *action.DepositToStake:
+			typ, amount = "depositToStake", act.Amount()
+		default:
+			continue
+		}
+		if amount == nil || amount.Cmp(n.largeStake) < 0 {
+			continue
+		}
+		actHash, err := selp.Hash()
+		if err != nil {
+			return err
+		}
+		n.notify(EventLargeStake, &LargeStake{
+			Height:     height,
+			ActionHash: hex.EncodeToString(actHash[:]),
+			Type:       typ,
+			Staker:     selp.SenderAddress().String(),
+			Amount:     amount.String(),
+		})
+	}
+	return nil
+}
+
+// check notifies when the node falls behind the network or the consensus stalls, once until it recovers
+func (n *Notifier) check(now time.Time) {
+	if n.syncStatus != nil {
+		height, target := n.syncStatus()
+		n.mu.Lock()
+		wasBehind := n.behind
+		n.behind = target > height+n.cfg.BehindBlocks
+		n.mu.Unlock()
+		if n.behind && !wasBehind {
+			n.notify(EventNodeBehind, &NodeBehind{
+				Height:       height,
+				TargetHeight: target,
+			})
+		}
+	}
+	n.mu.Lock()
+	var (
+		height        = n.lastHeight
+		lastBlockTime = n.lastBlockTime
+		stall         = !n.stalled && n.cfg.StallTimeout > 0 && now.Sub(lastBlockTime) > n.cfg.StallTimeout
+	)
+	if stall {
+		n.stalled = true
+	}
+	n.mu.Unlock()
+	if stall {
+		n.notify(EventConsensusStall, &ConsensusStall{
+			Height:        height,
+			LastBlockTime: lastBlockTime,
+			Stalled:       now.Sub(lastBlockTime).Round(time.Second).String(),
+		})
+	}
+}
+
+// notify queues the event to the endpoints subscribing to it
+func (n *Notifier) notify(event string, data any) {
+	body, err := json.Marshal(&Event{
+		Type: event,
+		Time: time.Now(),
+		Data: data,
+	})
+	if err != nil {
+		log.L().Error("Failed to marshal web-hook event.", zap.String("event", event), zap.Error(err))
+		return
+	}
+	for _, ep := range n.endpoints {
+		if _, ok := ep.events[event]; len(ep.events) > 0 && !ok {
+			continue
+		}
+		select {
+		case ep.queue <- &delivery{event: event, body: body}:
+		default:
+			_webhookMtc.WithLabelValues(event, "dropped").Inc()
+			log.L().Warn("Web-hook queue is full, the event is dropped.", zap.String("url", ep.cfg.URL), zap.String("event", event))
+		}
+	}
+}
+
+func (n *Notifier) watch(ctx context.Context) {
+	defer n.wg.Done()
+	ticker := time.NewTicker(n.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n.check(now)
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, ep *endpoint) {
+	defer n.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-ep.queue:
+			if err := n.deliver(ctx, ep, d); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				_webhookMtc.WithLabelValues(d.event, "failed").Inc()
+				log.L().Error("Failed to post web-hook event.", zap.String("url", ep.cfg.URL), zap.String("event", d.event), zap.Error(err))
+				continue
+			}
+			_webhookMtc.WithLabelValues(d.event, "delivered").Inc()
+		}
+	}
+}
+
+// deliver posts the event, and retries with exponential backoff on the network errors, the server errors and the
+// rate limiting
+func (n *Notifier) deliver(ctx context.Context, ep *endpoint, d *delivery) error {
+	wait := n.cfg.RetryInterval
+	for i := 0; ; i++ {
+		retry, err := n.send(ctx, ep, d)
+		if err == nil || !retry || i >= n.cfg.MaxRetries {
+			return err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		if wait *= 2; n.cfg.MaxRetryInterval > 0 && wait > n.cfg.MaxRetryInterval {
+			wait = n.cfg.MaxRetryInterval
+		}
+	}
+}
+
+// send posts the event once, and returns whether it is worth retrying if it fails
+func (n *Notifier) send(ctx context.Context, ep *endpoint, d *delivery) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.cfg.URL, bytes.NewReader(d.body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, d.event)
+	if ep.cfg.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign([]byte(ep.cfg.Secret), d.body))
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("web-hook responded with status %d", resp.StatusCode)
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+// Sign returns the HMAC-SHA256 signature of the payload in the signature header, for the receivers to verify the
+// payload is posted by the node
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
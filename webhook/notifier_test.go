@@ -0,0 +1,211 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+type received struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+func testServer(t *testing.T, failures int) (*httptest.Server, func() []received) {
+	var (
+		mu   sync.Mutex
+		reqs []received
+	)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		reqs = append(reqs, received{
+			event:     r.Header.Get(EventHeader),
+			signature: r.Header.Get(SignatureHeader),
+			body:      body,
+		})
+	}))
+	return svr, func() []received {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]received{}, reqs...)
+	}
+}
+
+func testConfig(endpoints ...EndpointConfig) Config {
+	cfg := DefaultConfig
+	cfg.Endpoints = endpoints
+	cfg.CheckInterval = 0
+	cfg.RetryInterval = 10 * time.Millisecond
+	cfg.MaxRetryInterval = 20 * time.Millisecond
+	return cfg
+}
+
+func TestNotifier(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	svr, reqs := testServer(t, 2)
+	defer svr.Close()
+	blockSvr, blockReqs := testServer(t, 0)
+	defer blockSvr.Close()
+
+	cfg := testConfig(
+		EndpointConfig{URL: svr.URL, Secret: "secret", Events: []string{EventMissedBlock, EventLargeStake}},
+		EndpointConfig{URL: blockSvr.URL, Events: []string{EventNewBlock}},
+	)
+	cfg.Delegates = []string{identityset.Address(1).String(), identityset.Address(2).String()}
+	n, err := NewNotifier(cfg, nil, func(*block.Block) []string {
+		return []string{identityset.Address(1).String(), identityset.Address(3).String()}
+	})
+	require.NoError(err)
+	require.NoError(n.Start(ctx))
+	defer func() {
+		require.NoError(n.Stop(ctx))
+	}()
+
+	small, err := action.SignedCreateStake(1, "cand", "100", 1, false, nil, 100000, big.NewInt(0), identityset.PrivateKey(4))
+	require.NoError(err)
+	large, err := action.SignedCreateStake(2, "cand", cfg.LargeStakeAmount, 1, false, nil, 100000, big.NewInt(0), identityset.PrivateKey(4))
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		AddActions(small, large).
+		SetHeight(10).
+		SetTimeStamp(time.Now()).
+		SignAndBuild(identityset.PrivateKey(2))
+	require.NoError(err)
+	require.NoError(n.ReceiveBlock(&blk))
+
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return len(reqs()) == 2 && len(blockReqs()) == 1, nil
+	}))
+	// the missed block of the delegate not configured is filtered, and the first event is delivered after retries
+	events := reqs()
+	require.Equal(EventMissedBlock, events[0].event)
+	require.Equal(EventLargeStake, events[1].event)
+	for _, e := range events {
+		require.Equal(Sign([]byte("secret"), e.body), e.signature)
+	}
+	var missed struct {
+		Data MissedBlock `json:"data"`
+	}
+	require.NoError(json.Unmarshal(events[0].body, &missed))
+	require.Equal(MissedBlock{
+		Height:   10,
+		Delegate: identityset.Address(1).String(),
+		Producer: identityset.Address(2).String(),
+	}, missed.Data)
+	var stake struct {
+		Data LargeStake `json:"data"`
+	}
+	require.NoError(json.Unmarshal(events[1].body, &stake))
+	require.Equal("createStake", stake.Data.Type)
+	require.Equal(cfg.LargeStakeAmount, stake.Data.Amount)
+	require.Equal(identityset.Address(4).String(), stake.Data.Staker)
+
+	// the endpoint without secret is not signed
+	blockEvent := blockReqs()[0]
+	require.Equal(EventNewBlock, blockEvent.event)
+	require.Empty(blockEvent.signature)
+	var newBlock struct {
+		Data NewBlock `json:"data"`
+	}
+	require.NoError(json.Unmarshal(blockEvent.body, &newBlock))
+	require.EqualValues(10, newBlock.Data.Height)
+	require.Equal(2, newBlock.Data.NumActions)
+}
+
+func TestNotifierCheck(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	svr, reqs := testServer(t, 0)
+	defer svr.Close()
+
+	var height, target uint64 = 100, 100
+	cfg := testConfig(EndpointConfig{URL: svr.URL})
+	n, err := NewNotifier(cfg, func() (uint64, uint64) {
+		return height, target
+	}, nil)
+	require.NoError(err)
+	require.NoError(n.Start(ctx))
+	defer func() {
+		require.NoError(n.Stop(ctx))
+	}()
+
+	now := time.Now()
+	n.check(now)
+	// the node behind and the consensus stall are notified once until recovery
+	target = 100 + cfg.BehindBlocks + 1
+	n.check(now.Add(cfg.StallTimeout + time.Second))
+	n.check(now.Add(cfg.StallTimeout + 2*time.Second))
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return len(reqs()) == 2, nil
+	}))
+	events := reqs()
+	require.Equal(EventNodeBehind, events[0].event)
+	require.Equal(EventConsensusStall, events[1].event)
+
+	target = 100
+	n.check(now.Add(cfg.StallTimeout + 3*time.Second))
+	target = 100 + cfg.BehindBlocks + 1
+	n.check(now.Add(cfg.StallTimeout + 4*time.Second))
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return len(reqs()) == 3, nil
+	}))
+	require.Equal(EventNodeBehind, reqs()[2].event)
+}
+
+func TestNotifierRetry(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	var status atomic.Int32
+	status.Store(http.StatusBadRequest)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer svr.Close()
+
+	n, err := NewNotifier(testConfig(EndpointConfig{URL: svr.URL}), nil, nil)
+	require.NoError(err)
+	ep := n.endpoints[0]
+	d := &delivery{event: EventNewBlock, body: []byte("{}")}
+	retry, err := n.send(ctx, ep, d)
+	require.Error(err)
+	require.False(retry)
+
+	status.Store(http.StatusTooManyRequests)
+	retry, err = n.send(ctx, ep, d)
+	require.Error(err)
+	require.True(retry)
+	// gives up after max retries
+	require.Error(n.deliver(ctx, ep, d))
+
+	_, err = NewNotifier(Config{LargeStakeAmount: "abc"}, nil, nil)
+	require.Error(err)
+}